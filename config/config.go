@@ -10,9 +10,13 @@ import (
 	"embed"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/PivotLLM/Maestro/global"
 )
@@ -48,30 +52,55 @@ type Config struct {
 	chrootDir         string                 // resolved chroot directory (optional)
 	playbooksDir      string                 // resolved playbooks directory
 	projectsDir       string                 // resolved projects directory
+	exportsDir        string                 // resolved project and playbook export archive directory
+	sharedDir         string                 // resolved shared evidence library directory
 	agentsDir         string                 // resolved default agents directory for LLM execution
 	referenceDirs     []ReferenceDirResolved // resolved external reference directories
 	resolvedExtraPath []string               // resolved extra PATH entries for LLM command lookup
 	embeddedFS        embed.FS               // embedded reference files
 	llmAliasMap       map[string]string      // maps alias (or canonical id) → canonical id
 	warnings          []string               // deferred warnings collected before logger is available
+	redactor          *global.Redactor       // secret masking for logs and result files (nil when disabled)
+	clock             *global.Clock          // timezone and formats for project logs and reports
 }
 
 // configData holds the parsed configuration (internal)
 type configData struct {
-	Version               int            `json:"version"`
-	BaseDir               string         `json:"base_dir"`
-	Chroot                string         `json:"chroot,omitempty"`
-	PlaybooksDir          string         `json:"playbooks_dir,omitempty"`
-	ProjectsDir           string         `json:"projects_dir,omitempty"`
-	AgentsDir             string         `json:"agents_dir,omitempty"`
-	ExtraPath             []string       `json:"extra_path,omitempty"`
-	ReferenceDirs         []ReferenceDir `json:"reference_dirs,omitempty"`
-	DefaultLLM            string         `json:"default_llm,omitempty"`
-	LLMs                  []LLM          `json:"llms"`
-	Runner                Runner         `json:"runner,omitempty"`
-	Logging               Logging        `json:"logging"`
-	ValidateLLMsOnStartup bool           `json:"validate_llms_on_startup,omitempty"`
-	MarkNonDestructive    bool           `json:"mark_non_destructive,omitempty"`
+	Version               int                       `json:"version"`
+	BaseDir               string                    `json:"base_dir"`
+	Chroot                string                    `json:"chroot,omitempty"`
+	PlaybooksDir          string                    `json:"playbooks_dir,omitempty"`
+	ProjectsDir           string                    `json:"projects_dir,omitempty"`
+	ExportsDir            string                    `json:"exports_dir,omitempty"`
+	SharedDir             string                    `json:"shared_dir,omitempty"`
+	AgentsDir             string                    `json:"agents_dir,omitempty"`
+	ExtraPath             []string                  `json:"extra_path,omitempty"`
+	ReferenceDirs         []ReferenceDir            `json:"reference_dirs,omitempty"`
+	DefaultLLM            string                    `json:"default_llm,omitempty"`
+	LLMs                  []LLM                     `json:"llms"`
+	Runner                Runner                    `json:"runner,omitempty"`
+	Maintenance           Maintenance               `json:"maintenance,omitempty"`
+	LLMProbe              LLMProbe                  `json:"llm_probe,omitempty"`
+	ReportLanguage        global.ReportLanguage     `json:"report_language,omitempty"`
+	ReportAttribution     global.ReportAttribution  `json:"report_attribution,omitempty"`
+	Redaction             global.Redaction          `json:"redaction,omitempty"`
+	OutputSanitization    global.OutputSanitization `json:"output_sanitization,omitempty"`
+	PIIDetection          global.PIIDetection       `json:"pii_detection,omitempty"`
+	PromptRedaction       global.PromptRedaction    `json:"prompt_redaction,omitempty"`
+	Timestamps            global.Timestamps         `json:"timestamps,omitempty"`
+	Webhooks              []global.Webhook          `json:"webhooks,omitempty"`
+	Embeddings            global.Embeddings         `json:"embeddings,omitempty"`
+	Conversion            global.Conversion         `json:"conversion,omitempty"`
+	ImportPolicy          global.ImportPolicy       `json:"import_policy,omitempty"`
+	ReportOutput          global.ReportOutput       `json:"report_output,omitempty"`
+	ReportPrefix          global.ReportPrefix       `json:"report_prefix,omitempty"`
+	Logging               Logging                   `json:"logging"`
+	ValidateLLMsOnStartup bool                      `json:"validate_llms_on_startup,omitempty"`
+	ValidateLLMsStrict    bool                      `json:"validate_llms_strict,omitempty"` // Refuse to start if the default LLM fails startup validation
+	MarkNonDestructive    bool                      `json:"mark_non_destructive,omitempty"`
+	StrictParams          bool                      `json:"strict_params,omitempty"`      // Reject tool calls with unknown argument names
+	ResultsLayout         string                    `json:"results_layout,omitempty"`     // "flat" (default) or "partitioned"
+	PlaybookSnapshots     bool                      `json:"playbook_snapshots,omitempty"` // Keep previous versions of changed playbook files
 }
 
 // ReferenceDir represents an external directory to mount in the reference library
@@ -107,6 +136,14 @@ const (
 	OutputFormatGeneric = "generic"
 )
 
+// StderrPolicy constants for storing LLM stderr
+const (
+	StderrPolicyDiscard   = "discard"    // Drop stderr entirely
+	StderrPolicyStripANSI = "strip-ansi" // Keep all of stderr without ANSI escapes
+	StderrPolicyTail      = "tail"       // Strip ANSI escapes and keep the last stderr_tail_kb KB (default)
+	StderrPolicyKeepAll   = "keep-all"   // Keep all of stderr
+)
+
 // LLM represents an LLM configuration
 type LLM struct {
 	ID           string   `json:"id"`
@@ -124,6 +161,10 @@ type LLM struct {
 	Args []string `json:"args,omitempty"`
 	// Stdin: if true, prompt is piped to command's stdin instead of using {{PROMPT}} placeholder
 	Stdin bool `json:"stdin,omitempty"`
+	// ResumeArgs replace Args when a call continues an earlier session (e.g. a QA
+	// revision), with {{SESSION_ID}} for the session the provider reported. Only the
+	// new turn is sent, as the provider already holds the conversation.
+	ResumeArgs []string `json:"resume_args,omitempty"`
 
 	// WorkingDir is the working directory for process execution (resolved at load time)
 	WorkingDir string `json:"working_dir,omitempty"`
@@ -137,6 +178,105 @@ type LLM struct {
 
 	// RecoveryConfig configures error recovery for this LLM (rate limits, transient errors)
 	RecoveryConfig *LLMRecoveryConfig `json:"recovery,omitempty"`
+
+	// StderrPolicy controls how much of the process stderr is kept in results and history.
+	// Valid values: "discard", "strip-ansi", "tail", "keep-all" (default: "tail")
+	StderrPolicy string `json:"stderr_policy,omitempty"`
+	// StderrTailKB is the amount of stderr kept by the "tail" policy (default: global.DefaultStderrTailKB)
+	StderrTailKB int `json:"stderr_tail_kb,omitempty"`
+
+	// Pricing estimates the cost of each call from its token counts, for max_cost_usd.
+	// Without pricing, the cost reported by the provider (if any) is used.
+	Pricing *LLMPricing `json:"pricing,omitempty"`
+
+	// Generation sets the sampling parameters of every call: substituted for
+	// {{TEMPERATURE}}, {{TOP_P}} and {{MAX_TOKENS}} in args, and passed as dispatch
+	// options to a host dispatcher.
+	Generation *global.GenerationParams `json:"generation,omitempty"`
+	// RetryGeneration overrides generation parameters when a task is retried (e.g. after
+	// a schema validation failure): the first entry applies to the second attempt, the
+	// second entry to the third, and the last entry to every later attempt.
+	RetryGeneration []global.GenerationParams `json:"retry_generation,omitempty"`
+
+	// StructuredOutput marks an LLM that enforces a JSON schema natively (e.g. OpenAI
+	// json_schema mode or Anthropic tool use). The task set's response schema is then
+	// substituted for {{SCHEMA}} or {{SCHEMA_FILE}} in args instead of being embedded
+	// in the prompt; responses are still validated against it.
+	StructuredOutput bool `json:"structured_output,omitempty"`
+
+	// ContextWindow is the LLM's context limit in tokens (0 = unknown). Prompts are
+	// fitted to it, less the max_tokens reserved for the response: low-priority
+	// sections are shortened, and a task whose required sections do not fit fails.
+	ContextWindow int `json:"context_window,omitempty"`
+
+	// Warmup keeps a local model (e.g. Ollama or llama.cpp) loaded: a priming call
+	// before each run, and a keep-alive substituted for {{KEEP_ALIVE}} in args so
+	// the provider does not unload the model between tasks.
+	Warmup *LLMWarmup `json:"warmup,omitempty"`
+
+	// Env sets environment variables for the command, added to Maestro's own.
+	// ${VAR} references in command, args, resume_args and env values are expanded
+	// from Maestro's environment when the config is loaded.
+	Env map[string]string `json:"env,omitempty"`
+	// KeyFile is a file holding the LLM's API key, and KeyCommand a command that
+	// prints it (e.g. ["pass", "show", "openai/api-key"]); the key is read at load
+	// time and substituted for {{API_KEY}} in args, resume_args and env values,
+	// so it never has to be written in the config file.
+	KeyFile    string   `json:"key_file,omitempty"`
+	KeyCommand []string `json:"key_command,omitempty"`
+}
+
+// PromptTokenLimit returns the tokens available to the prompt of an attempt
+// (0 = unlimited): the context window less the response's max_tokens
+func (l *LLM) PromptTokenLimit(attempt int) int {
+	if l.ContextWindow <= 0 {
+		return 0
+	}
+	limit := l.ContextWindow - l.GenerationFor(attempt).MaxTokens
+	if limit < 1 {
+		limit = 1
+	}
+	return limit
+}
+
+// GenerationFor returns the generation parameters of an attempt (1 for the first
+// call): the configured parameters with the retry overrides for that attempt.
+func (l *LLM) GenerationFor(attempt int) global.GenerationParams {
+	var g global.GenerationParams
+	if l.Generation != nil {
+		g = *l.Generation
+	}
+	if attempt < 2 || len(l.RetryGeneration) == 0 {
+		return g
+	}
+	retry := attempt - 2
+	if retry >= len(l.RetryGeneration) {
+		retry = len(l.RetryGeneration) - 1
+	}
+	return g.Merge(l.RetryGeneration[retry])
+}
+
+// LLMWarmup configures warm standby for a local model
+type LLMWarmup struct {
+	Enabled   bool   `json:"enabled,omitempty"`    // Dispatch a priming call before each run
+	Prompt    string `json:"prompt,omitempty"`     // Priming prompt (default: the recovery test prompt)
+	KeepAlive string `json:"keep_alive,omitempty"` // How long the provider keeps the model loaded (e.g. "30m", "-1" for ever)
+}
+
+// LLMPricing holds per-LLM token prices in USD per million tokens
+type LLMPricing struct {
+	InputPerMTok      float64 `json:"input_per_mtok,omitempty"`
+	OutputPerMTok     float64 `json:"output_per_mtok,omitempty"`
+	CacheReadPerMTok  float64 `json:"cache_read_per_mtok,omitempty"`
+	CacheWritePerMTok float64 `json:"cache_write_per_mtok,omitempty"`
+}
+
+// Cost returns the estimated cost in USD of a call with the given token counts
+func (p *LLMPricing) Cost(inputTokens, outputTokens, cacheReadTokens, cacheWriteTokens int) float64 {
+	return (float64(inputTokens)*p.InputPerMTok +
+		float64(outputTokens)*p.OutputPerMTok +
+		float64(cacheReadTokens)*p.CacheReadPerMTok +
+		float64(cacheWriteTokens)*p.CacheWritePerMTok) / 1e6
 }
 
 // LLMRecoveryConfig configures error recovery for an LLM (rate limits, transient errors)
@@ -153,8 +293,9 @@ type LLMRecoveryConfig struct {
 
 // Logging represents logging configuration
 type Logging struct {
-	File  string `json:"file"`
-	Level string `json:"level"`
+	File   string `json:"file"`
+	Level  string `json:"level"`
+	Format string `json:"format,omitempty"` // "text" (default) or "json"
 }
 
 // Runner represents runner configuration for automated task execution
@@ -166,6 +307,28 @@ type Runner struct {
 	RetryDelaySeconds         int           `json:"retry_delay_seconds,omitempty"`
 	RateLimit                 RateLimit     `json:"rate_limit,omitempty"`
 	DefaultDisclaimerTemplate string        `json:"default_disclaimer_template,omitempty"` // Default disclaimer file for reports
+	DisclaimerPolicy          string        `json:"disclaimer_policy,omitempty"`           // Projects without a disclaimer_template: "required", "optional" or "default"
+	Dashboard                 bool          `json:"dashboard,omitempty"`                   // Write dashboard.json to the project after each run
+	ResumeInterruptedRuns     bool          `json:"resume_interrupted_runs,omitempty"`     // Resume runs interrupted by a crash when Maestro starts
+	MinFreeDiskMB             int           `json:"min_free_disk_mb,omitempty"`            // Free disk space required to start a run (default: 100, -1 = no check)
+	TaskSetLocking            bool          `json:"taskset_locking,omitempty"`             // Lock only the task sets a run covers, so other task sets of the project can run concurrently
+	PriorityTieBreak          string        `json:"priority_tie_break,omitempty"`          // Order of tasks with equal priority: "id" (default) or "attempts"
+}
+
+// Maintenance configures the background job that collects orphaned result files
+// and applies the results retention policy
+type Maintenance struct {
+	IntervalHours int                     `json:"interval_hours,omitempty"` // How often to clean up results (0 = disabled)
+	Action        string                  `json:"action,omitempty"`         // "delete" (default) or "archive"
+	MinAgeHours   int                     `json:"min_age_hours,omitempty"`  // Only collect files older than this (default: 24)
+	Retention     global.ResultsRetention `json:"retention,omitempty"`      // Limits on the result files each project keeps
+}
+
+// LLMProbe configures the background job that sends each enabled LLM its test
+// prompt and records availability history
+type LLMProbe struct {
+	IntervalMinutes        int `json:"interval_minutes,omitempty"`          // How often to probe (0 = disabled)
+	PreflightMaxAgeMinutes int `json:"preflight_max_age_minutes,omitempty"` // A successful probe this recent satisfies the run pre-flight check (default: twice the interval)
 }
 
 // RateLimit represents rate limiting configuration
@@ -427,6 +590,142 @@ func (c *Config) validate() error {
 		return fmt.Errorf("config version %d is newer than supported (expected 1)", c.data.Version)
 	}
 
+	// Check results layout
+	switch c.data.ResultsLayout {
+	case "", global.ResultsLayoutFlat, global.ResultsLayoutPartitioned:
+	default:
+		return fmt.Errorf("invalid results_layout %q (must be %q or %q)", c.data.ResultsLayout, global.ResultsLayoutFlat, global.ResultsLayoutPartitioned)
+	}
+
+	// Check log format
+	switch c.data.Logging.Format {
+	case "", global.LogFormatText, global.LogFormatJSON:
+	default:
+		return fmt.Errorf("invalid logging.format %q (must be %q or %q)", c.data.Logging.Format, global.LogFormatText, global.LogFormatJSON)
+	}
+
+	// Check run cost limit
+	if c.data.Runner.Limits.MaxCostUSD < 0 {
+		return fmt.Errorf("invalid runner.limits.max_cost_usd %v (must not be negative)", c.data.Runner.Limits.MaxCostUSD)
+	}
+
+	// Check priority tie-break policy
+	switch c.data.Runner.PriorityTieBreak {
+	case "", global.PriorityTieBreakID, global.PriorityTieBreakAttempts:
+	default:
+		return fmt.Errorf("invalid runner.priority_tie_break %q (must be %q or %q)", c.data.Runner.PriorityTieBreak, global.PriorityTieBreakID, global.PriorityTieBreakAttempts)
+	}
+
+	// Check disclaimer policy
+	switch c.data.Runner.DisclaimerPolicy {
+	case "", global.DisclaimerPolicyRequired, global.DisclaimerPolicyOptional, global.DisclaimerPolicyDefault:
+	default:
+		return fmt.Errorf("invalid runner.disclaimer_policy %q (must be %q, %q or %q)", c.data.Runner.DisclaimerPolicy, global.DisclaimerPolicyRequired, global.DisclaimerPolicyOptional, global.DisclaimerPolicyDefault)
+	}
+	if c.data.Runner.DisclaimerPolicy == global.DisclaimerPolicyDefault && c.data.Runner.DefaultDisclaimerTemplate == "" {
+		return fmt.Errorf("runner.disclaimer_policy %q requires runner.default_disclaimer_template", global.DisclaimerPolicyDefault)
+	}
+	if t := c.data.Runner.DefaultDisclaimerTemplate; t != "" && !strings.Contains(strings.Trim(t, "/"), "/") {
+		return fmt.Errorf("invalid runner.default_disclaimer_template %q (must be 'playbook-name/path/to/file.md')", t)
+	}
+
+	// Check maintenance action
+	switch c.data.Maintenance.Action {
+	case "", global.CleanupActionDelete, global.CleanupActionArchive:
+	default:
+		return fmt.Errorf("invalid maintenance action %q (must be %q or %q)", c.data.Maintenance.Action, global.CleanupActionDelete, global.CleanupActionArchive)
+	}
+
+	if c.data.LLMProbe.IntervalMinutes < 0 || c.data.LLMProbe.PreflightMaxAgeMinutes < 0 {
+		return fmt.Errorf("invalid llm_probe settings (must not be negative)")
+	}
+
+	// Check results retention
+	retention := c.data.Maintenance.Retention
+	switch retention.Action {
+	case "", global.RetentionActionCompact, global.RetentionActionDelete:
+	default:
+		return fmt.Errorf("invalid maintenance.retention action %q (must be %q or %q)", retention.Action, global.RetentionActionCompact, global.RetentionActionDelete)
+	}
+	if retention.MaxAgeDays < 0 || retention.MaxPerTask < 0 || retention.MaxTotalMB < 0 {
+		return fmt.Errorf("invalid maintenance.retention limits (must not be negative)")
+	}
+
+	// Check PII detection types
+	for _, t := range c.data.PIIDetection.Types {
+		if !slices.Contains(global.PIITypes(), t) {
+			return fmt.Errorf("invalid pii_detection type %q (must be one of: %s)", t, strings.Join(global.PIITypes(), ", "))
+		}
+	}
+
+	if err := global.ValidatePromptRedaction(&c.data.PromptRedaction); err != nil {
+		return fmt.Errorf("invalid prompt_redaction config: %w", err)
+	}
+
+	// Compile redaction patterns
+	redactor, err := global.NewRedactor(c.data.Redaction)
+	if err != nil {
+		return fmt.Errorf("invalid redaction config: %w", err)
+	}
+	c.redactor = redactor
+
+	// Load the timestamp timezone
+	clock, err := global.NewClock(c.data.Timestamps)
+	if err != nil {
+		return fmt.Errorf("invalid timestamps config: %w", err)
+	}
+	c.clock = clock
+
+	// Check webhooks
+	for i, webhook := range c.data.Webhooks {
+		u, err := url.Parse(webhook.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid webhooks[%d].url %q (must be an http or https URL)", i, webhook.URL)
+		}
+		for _, event := range webhook.Events {
+			switch event {
+			case global.WebhookEventRunCompleted, global.WebhookEventTaskEscalated, global.WebhookEventBudgetExceeded:
+			default:
+				return fmt.Errorf("invalid webhooks[%d] event %q (must be %q, %q or %q)", i, event,
+					global.WebhookEventRunCompleted, global.WebhookEventTaskEscalated, global.WebhookEventBudgetExceeded)
+			}
+		}
+	}
+
+	// Check the embeddings endpoint (optional)
+	if e := c.data.Embeddings; e.Endpoint != "" || e.Model != "" {
+		u, err := url.Parse(e.Endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid embeddings.endpoint %q (must be an http or https URL)", e.Endpoint)
+		}
+		if e.Model == "" {
+			return fmt.Errorf("embeddings.model is required when embeddings.endpoint is set")
+		}
+		if e.ChunkSize < 0 || e.ChunkOverlap < 0 || e.BatchSize < 0 || e.MaxFileKB < 0 || e.TimeoutSeconds < 0 {
+			return fmt.Errorf("invalid embeddings config (values must not be negative)")
+		}
+	}
+
+	// Check the document conversion settings (optional)
+	if err := c.data.Conversion.Validate(); err != nil {
+		return err
+	}
+
+	// Check the import policy (optional)
+	if err := c.data.ImportPolicy.Validate(); err != nil {
+		return err
+	}
+
+	// Check the HTML and PDF report settings (optional)
+	if err := c.data.ReportOutput.Validate(); err != nil {
+		return err
+	}
+
+	// Check the report session prefix settings (optional)
+	if err := c.data.ReportPrefix.Validate(); err != nil {
+		return err
+	}
+
 	// Check LLMs - at least one must be defined (but doesn't need to be enabled)
 	if len(c.data.LLMs) == 0 {
 		return fmt.Errorf("llms cannot be empty - please define at least one LLM")
@@ -442,6 +741,15 @@ func (c *Config) validate() error {
 		c.resolvedExtraPath = append(c.resolvedExtraPath, resolved)
 	}
 
+	// Expand environment variables and read API keys before the LLMs are checked
+	for i := range c.data.LLMs {
+		key, err := c.resolveLLMSecrets(&c.data.LLMs[i])
+		if err != nil {
+			return err
+		}
+		c.redactor.AddSecrets(key)
+	}
+
 	llmIDs := make(map[string]bool)
 	for _, llm := range c.data.LLMs {
 		if llm.ID == "" {
@@ -485,6 +793,17 @@ func (c *Config) validate() error {
 			}
 		}
 
+		// Resume args must name the session, and take the prompt like args do
+		if len(llm.ResumeArgs) > 0 {
+			joined := strings.Join(llm.ResumeArgs, " ")
+			if !strings.Contains(joined, global.PlaceholderSessionID) {
+				return fmt.Errorf("LLM resume_args must contain %s placeholder for LLM %s", global.PlaceholderSessionID, llm.ID)
+			}
+			if !llm.Stdin && !strings.Contains(joined, "{{PROMPT}}") {
+				return fmt.Errorf("LLM resume_args must contain {{PROMPT}} placeholder for LLM %s (or set stdin: true)", llm.ID)
+			}
+		}
+
 		// Validate and normalize timeout (0 → DefaultTimeout)
 		normalizedTimeout, timeoutErr := global.ValidateTimeout(llm.Timeout)
 		if timeoutErr != nil {
@@ -507,6 +826,55 @@ func (c *Config) validate() error {
 			}
 		}
 
+		// Check stderr policy (empty defaults to "tail")
+		switch llm.StderrPolicy {
+		case "", StderrPolicyDiscard, StderrPolicyStripANSI, StderrPolicyTail, StderrPolicyKeepAll:
+		default:
+			return fmt.Errorf("invalid stderr_policy %q for LLM %s (must be %q, %q, %q or %q)", llm.StderrPolicy, llm.ID,
+				StderrPolicyDiscard, StderrPolicyStripANSI, StderrPolicyTail, StderrPolicyKeepAll)
+		}
+		if p := llm.Pricing; p != nil && (p.InputPerMTok < 0 || p.OutputPerMTok < 0 || p.CacheReadPerMTok < 0 || p.CacheWritePerMTok < 0) {
+			return fmt.Errorf("invalid pricing for LLM %s (prices must not be negative)", llm.ID)
+		}
+		if llm.Generation != nil {
+			if err := llm.Generation.Validate(); err != nil {
+				return fmt.Errorf("invalid generation for LLM %s: %w", llm.ID, err)
+			}
+		}
+		for i, retry := range llm.RetryGeneration {
+			if err := retry.Validate(); err != nil {
+				return fmt.Errorf("invalid retry_generation entry %d for LLM %s: %w", i+1, llm.ID, err)
+			}
+		}
+		if w := llm.Warmup; w != nil && w.KeepAlive != "" {
+			if _, err := time.ParseDuration(w.KeepAlive); err != nil {
+				if _, err := strconv.Atoi(w.KeepAlive); err != nil {
+					return fmt.Errorf("invalid warmup keep_alive %q for LLM %s (must be a duration such as \"30m\" or a number of seconds)", w.KeepAlive, llm.ID)
+				}
+			}
+		}
+		if llm.ContextWindow < 0 {
+			return fmt.Errorf("invalid context_window %d for LLM %s (must not be negative)", llm.ContextWindow, llm.ID)
+		}
+		if llm.ContextWindow > 0 && llm.Generation != nil && llm.Generation.MaxTokens >= llm.ContextWindow {
+			return fmt.Errorf("generation max_tokens %d must be less than context_window %d for LLM %s", llm.Generation.MaxTokens, llm.ContextWindow, llm.ID)
+		}
+		if llm.StructuredOutput {
+			hasSchemaPlaceholder := false
+			for _, arg := range llm.Args {
+				if strings.Contains(arg, global.PlaceholderSchema) || strings.Contains(arg, global.PlaceholderSchemaFile) {
+					hasSchemaPlaceholder = true
+					break
+				}
+			}
+			if !hasSchemaPlaceholder {
+				return fmt.Errorf("LLM args must contain %s or %s placeholder for LLM %s (structured_output is set)", global.PlaceholderSchema, global.PlaceholderSchemaFile, llm.ID)
+			}
+		}
+		if llm.StderrTailKB < 0 {
+			return fmt.Errorf("invalid stderr_tail_kb %d for LLM %s (must not be negative)", llm.StderrTailKB, llm.ID)
+		}
+
 		// Validate command executable exists (only for enabled LLMs)
 		if llm.Enabled {
 			expandedCmd := expandHomePath(llm.Command)
@@ -625,6 +993,31 @@ func (c *Config) normalizePaths() error {
 		return fmt.Errorf("failed to create projects directory at %s: %w", c.projectsDir, err)
 	}
 
+	// Resolve exports directory (default: next to the projects directory, so it
+	// stays inside a chroot that holds the projects)
+	if c.data.ExportsDir != "" {
+		c.exportsDir = c.resolvePath(c.data.ExportsDir)
+	} else {
+		c.exportsDir = filepath.Join(filepath.Dir(c.projectsDir), global.DefaultExportsDir)
+	}
+
+	// Create exports directory if it doesn't exist
+	if err := os.MkdirAll(c.exportsDir, 0755); err != nil {
+		return fmt.Errorf("failed to create exports directory at %s: %w", c.exportsDir, err)
+	}
+
+	// Resolve shared evidence directory (default: next to the projects directory)
+	if c.data.SharedDir != "" {
+		c.sharedDir = c.resolvePath(c.data.SharedDir)
+	} else {
+		c.sharedDir = filepath.Join(filepath.Dir(c.projectsDir), global.DefaultSharedDir)
+	}
+
+	// Create shared evidence directory if it doesn't exist
+	if err := os.MkdirAll(c.sharedDir, 0755); err != nil {
+		return fmt.Errorf("failed to create shared directory at %s: %w", c.sharedDir, err)
+	}
+
 	// Resolve external reference directories (optional)
 	for _, refDir := range c.data.ReferenceDirs {
 		if refDir.Path == "" {
@@ -660,6 +1053,9 @@ func (c *Config) normalizePaths() error {
 		c.data.Logging.File = c.resolvePath(c.data.Logging.File)
 	}
 
+	// Resolve the report theme (CSS file for HTML reports)
+	c.data.ReportOutput.Theme = c.resolvePath(c.data.ReportOutput.Theme)
+
 	// Resolve agents directory (default working dir for all LLM processes)
 	agentsDirRaw := c.data.AgentsDir
 	if agentsDirRaw == "" {
@@ -770,6 +1166,16 @@ func (c *Config) validateChrootContainment() error {
 		return err
 	}
 
+	// Validate exports_dir is within chroot
+	if err := isWithinChroot(c.exportsDir, "exports_dir"); err != nil {
+		return err
+	}
+
+	// Validate shared_dir is within chroot
+	if err := isWithinChroot(c.sharedDir, "shared_dir"); err != nil {
+		return err
+	}
+
 	// Note: reference_dirs are NOT validated against chroot.
 	// They are read-only directories that cannot be modified via MCP tools,
 	// so they don't pose a security risk even if outside the chroot.
@@ -837,6 +1243,16 @@ func (c *Config) ProjectsDir() string {
 	return c.projectsDir
 }
 
+// ExportsDir returns the resolved exports directory (always absolute)
+func (c *Config) ExportsDir() string {
+	return c.exportsDir
+}
+
+// SharedDir returns the resolved shared evidence library directory (always absolute)
+func (c *Config) SharedDir() string {
+	return c.sharedDir
+}
+
 // LLMs returns all configured LLMs
 func (c *Config) LLMs() []LLM {
 	return c.data.LLMs
@@ -876,9 +1292,185 @@ func (c *Config) LogLevel() string {
 	return c.data.Logging.Level
 }
 
-// ValidateLLMsOnStartup returns whether LLM validation is enabled
+// LogFormat returns the log record format ("text" or "json")
+func (c *Config) LogFormat() string {
+	if c.data == nil || c.data.Logging.Format == "" {
+		return global.LogFormatText
+	}
+	return c.data.Logging.Format
+}
+
+// ResultsLayout returns the result file layout ("flat" or "partitioned")
+func (c *Config) ResultsLayout() string {
+	if c.data == nil || c.data.ResultsLayout == "" {
+		return global.ResultsLayoutFlat
+	}
+	return c.data.ResultsLayout
+}
+
+// Maintenance returns the maintenance configuration with defaults applied
+func (c *Config) Maintenance() Maintenance {
+	var m Maintenance
+	if c.data != nil {
+		m = c.data.Maintenance
+	}
+	if m.Action == "" {
+		m.Action = global.CleanupActionDelete
+	}
+	if m.MinAgeHours <= 0 {
+		m.MinAgeHours = global.DefaultCleanupMinAgeHours
+	}
+	if m.Retention.Action == "" {
+		m.Retention.Action = global.RetentionActionCompact
+	}
+	return m
+}
+
+// LLMProbe returns the LLM availability probe configuration with defaults applied
+func (c *Config) LLMProbe() LLMProbe {
+	var p LLMProbe
+	if c.data != nil {
+		p = c.data.LLMProbe
+	}
+	if p.PreflightMaxAgeMinutes == 0 {
+		p.PreflightMaxAgeMinutes = 2 * p.IntervalMinutes
+	}
+	return p
+}
+
+// ReportLanguage returns the confidence phrase mappings for report templates with defaults applied
+func (c *Config) ReportLanguage() global.ReportLanguage {
+	var l global.ReportLanguage
+	if c.data != nil {
+		l = c.data.ReportLanguage
+	}
+	return l.WithDefaults()
+}
+
+// ReportAttribution returns the AI-disclosure marker settings for reports with defaults applied
+func (c *Config) ReportAttribution() global.ReportAttribution {
+	var a global.ReportAttribution
+	if c.data != nil {
+		a = c.data.ReportAttribution
+	}
+	return a.WithDefaults()
+}
+
+// Redactor returns the secret masking applied to logs and result files, or nil if redaction is disabled
+func (c *Config) Redactor() *global.Redactor {
+	return c.redactor
+}
+
+// OutputSanitization returns the cleanup applied to LLM output before storage and report rendering
+func (c *Config) OutputSanitization() global.OutputSanitization {
+	if c.data == nil {
+		return global.OutputSanitization{}
+	}
+	return c.data.OutputSanitization
+}
+
+// PIIDetection returns the configuration of the PII scan of stored responses
+func (c *Config) PIIDetection() global.PIIDetection {
+	if c.data == nil {
+		return global.PIIDetection{}
+	}
+	return c.data.PIIDetection
+}
+
+// PromptRedaction returns the configuration of the redaction of prompts before
+// they are sent to an LLM
+func (c *Config) PromptRedaction() global.PromptRedaction {
+	if c.data == nil {
+		return global.PromptRedaction{}
+	}
+	return c.data.PromptRedaction
+}
+
+// Clock returns the timezone and formats used for project logs and reports.
+// Nil (server local time, default formats) before the config is validated.
+func (c *Config) Clock() *global.Clock {
+	return c.clock
+}
+
+// Webhooks returns the configured webhooks with defaults applied
+func (c *Config) Webhooks() []global.Webhook {
+	if c.data == nil {
+		return nil
+	}
+	webhooks := make([]global.Webhook, 0, len(c.data.Webhooks))
+	for _, webhook := range c.data.Webhooks {
+		webhooks = append(webhooks, webhook.WithDefaults())
+	}
+	return webhooks
+}
+
+// Embeddings returns the semantic search embeddings config with defaults applied
+func (c *Config) Embeddings() global.Embeddings {
+	if c.data == nil {
+		return global.Embeddings{}
+	}
+	return c.data.Embeddings.WithDefaults()
+}
+
+// Conversion returns the document conversion config with defaults applied
+func (c *Config) Conversion() global.Conversion {
+	if c.data == nil {
+		return global.Conversion{}.WithDefaults()
+	}
+	return c.data.Conversion.WithDefaults()
+}
+
+// ImportPolicy returns the policy for imported files with defaults applied
+func (c *Config) ImportPolicy() global.ImportPolicy {
+	if c.data == nil {
+		return global.ImportPolicy{}.WithDefaults()
+	}
+	return c.data.ImportPolicy.WithDefaults()
+}
+
+// ReportOutput returns the HTML and PDF report settings with defaults applied
+func (c *Config) ReportOutput() global.ReportOutput {
+	if c.data == nil {
+		return global.ReportOutput{}.WithDefaults()
+	}
+	return c.data.ReportOutput.WithDefaults()
+}
+
+// ReportPrefix returns the report session prefix settings with defaults applied
+func (c *Config) ReportPrefix() global.ReportPrefix {
+	if c.data == nil {
+		return global.ReportPrefix{}.WithDefaults()
+	}
+	return c.data.ReportPrefix.WithDefaults()
+}
+
+// EmbeddingsDir returns the directory holding the semantic search indexes (next
+// to the projects directory)
+func (c *Config) EmbeddingsDir() string {
+	return filepath.Join(filepath.Dir(c.projectsDir), global.DefaultEmbeddingsDir)
+}
+
+// ValidateLLMsOnStartup returns whether LLM validation is enabled (strict mode
+// implies it)
 func (c *Config) ValidateLLMsOnStartup() bool {
-	return c.data.ValidateLLMsOnStartup
+	return c.data.ValidateLLMsOnStartup || c.data.ValidateLLMsStrict
+}
+
+// ValidateLLMsStrict returns whether startup is refused when the default LLM
+// fails startup validation
+func (c *Config) ValidateLLMsStrict() bool {
+	return c.data.ValidateLLMsStrict
+}
+
+// StrictParams returns true if tool calls with unknown argument names are rejected
+func (c *Config) StrictParams() bool {
+	return c.data != nil && c.data.StrictParams
+}
+
+// PlaybookSnapshots returns true if the previous content of a playbook file is
+// kept whenever it changes, so that playbook_restore can return to it
+func (c *Config) PlaybookSnapshots() bool {
+	return c.data != nil && c.data.PlaybookSnapshots
 }
 
 // MarkNonDestructive returns true if tools should be marked as non-destructive
@@ -953,6 +1545,19 @@ func (c *Config) Runner() Runner {
 	if r.RetryDelaySeconds <= 0 {
 		r.RetryDelaySeconds = global.DefaultRetryDelaySeconds
 	}
+	if r.MinFreeDiskMB == 0 {
+		r.MinFreeDiskMB = global.DefaultMinFreeDiskMB
+	}
+	if r.PriorityTieBreak == "" {
+		r.PriorityTieBreak = global.PriorityTieBreakID
+	}
+	// A configured default disclaimer applies unless a policy says otherwise
+	if r.DisclaimerPolicy == "" {
+		r.DisclaimerPolicy = global.DisclaimerPolicyRequired
+		if r.DefaultDisclaimerTemplate != "" {
+			r.DisclaimerPolicy = global.DisclaimerPolicyDefault
+		}
+	}
 	if r.RateLimit.MaxRequests <= 0 {
 		r.RateLimit.MaxRequests = global.DefaultRateLimitRequests
 	}
@@ -998,6 +1603,22 @@ func (llm *LLM) GetOutputFormat() string {
 	}
 }
 
+// GetStderrPolicy returns the effective stderr policy for this LLM (defaults to "tail")
+func (llm *LLM) GetStderrPolicy() string {
+	if llm.StderrPolicy == "" {
+		return StderrPolicyTail
+	}
+	return llm.StderrPolicy
+}
+
+// GetStderrTailKB returns the amount of stderr kept by the "tail" policy, in KB
+func (llm *LLM) GetStderrTailKB() int {
+	if llm.StderrTailKB <= 0 {
+		return global.DefaultStderrTailKB
+	}
+	return llm.StderrTailKB
+}
+
 // Helper functions
 
 func fileExists(path string) bool {
@@ -8,6 +8,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/PivotLLM/Maestro/global"
@@ -45,6 +46,366 @@ func TestValidate(t *testing.T) {
 			},
 			wantError: true,
 		},
+		{
+			name: "invalid results layout",
+			config: &configData{
+				Version:       1,
+				BaseDir:       "/tmp/maestro",
+				ResultsLayout: "by-day",
+				LLMs: []LLM{
+					{
+						ID:          "test",
+						Type:        "command",
+						Command:     "/bin/echo",
+						Args:        []string{"{{PROMPT}}"},
+						Description: "Test LLM",
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "invalid log format",
+			config: &configData{
+				Version: 1,
+				BaseDir: "/tmp/maestro",
+				Logging: Logging{Format: "xml"},
+				LLMs: []LLM{
+					{
+						ID:          "test",
+						Type:        "command",
+						Command:     "/bin/echo",
+						Args:        []string{"{{PROMPT}}"},
+						Description: "Test LLM",
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "invalid stderr policy",
+			config: &configData{
+				Version: 1,
+				BaseDir: "/tmp/maestro",
+				LLMs: []LLM{
+					{
+						ID:           "test",
+						Type:         "command",
+						Command:      "/bin/echo",
+						Args:         []string{"{{PROMPT}}"},
+						Description:  "Test LLM",
+						StderrPolicy: "truncate",
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "unknown timezone",
+			config: &configData{
+				Version:    1,
+				BaseDir:    "/tmp/maestro",
+				Timestamps: global.Timestamps{Timezone: "Mars/Olympus_Mons"},
+				LLMs: []LLM{
+					{
+						ID:          "test",
+						Type:        "command",
+						Command:     "/bin/echo",
+						Args:        []string{"{{PROMPT}}"},
+						Description: "Test LLM",
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "webhook with unknown event",
+			config: &configData{
+				Version:  1,
+				BaseDir:  "/tmp/maestro",
+				Webhooks: []global.Webhook{{URL: "https://hooks.example.com/maestro", Events: []string{"run_started"}}},
+				LLMs: []LLM{
+					{
+						ID:          "test",
+						Type:        "command",
+						Command:     "/bin/echo",
+						Args:        []string{"{{PROMPT}}"},
+						Description: "Test LLM",
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "invalid maintenance action",
+			config: &configData{
+				Version:     1,
+				BaseDir:     "/tmp/maestro",
+				Maintenance: Maintenance{Action: "compress"},
+				LLMs: []LLM{
+					{
+						ID:          "test",
+						Type:        "command",
+						Command:     "/bin/echo",
+						Args:        []string{"{{PROMPT}}"},
+						Description: "Test LLM",
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "invalid priority tie-break",
+			config: &configData{
+				Version: 1,
+				BaseDir: "/tmp/maestro",
+				Runner:  Runner{PriorityTieBreak: "random"},
+				LLMs: []LLM{
+					{
+						ID:          "test",
+						Type:        "command",
+						Command:     "/bin/echo",
+						Args:        []string{"{{PROMPT}}"},
+						Description: "Test LLM",
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "invalid disclaimer policy",
+			config: &configData{
+				Version: 1,
+				BaseDir: "/tmp/maestro",
+				Runner:  Runner{DisclaimerPolicy: "sometimes"},
+				LLMs: []LLM{
+					{
+						ID:          "test",
+						Type:        "command",
+						Command:     "/bin/echo",
+						Args:        []string{"{{PROMPT}}"},
+						Description: "Test LLM",
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "default disclaimer policy without a template",
+			config: &configData{
+				Version: 1,
+				BaseDir: "/tmp/maestro",
+				Runner:  Runner{DisclaimerPolicy: "default"},
+				LLMs: []LLM{
+					{
+						ID:          "test",
+						Type:        "command",
+						Command:     "/bin/echo",
+						Args:        []string{"{{PROMPT}}"},
+						Description: "Test LLM",
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "invalid report prefix format",
+			config: &configData{
+				Version:      1,
+				BaseDir:      "/tmp/maestro",
+				ReportPrefix: global.ReportPrefix{Format: "{date}/{title}"},
+				LLMs: []LLM{
+					{
+						ID:          "test",
+						Type:        "command",
+						Command:     "/bin/echo",
+						Args:        []string{"{{PROMPT}}"},
+						Description: "Test LLM",
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "invalid warmup keep alive",
+			config: &configData{
+				Version: 1,
+				BaseDir: "/tmp/maestro",
+				LLMs: []LLM{
+					{
+						ID:          "local",
+						Type:        "command",
+						Command:     "/usr/local/bin/ollama",
+						Args:        []string{"run", "llama3", "--keepalive={{KEEP_ALIVE}}", "{{PROMPT}}"},
+						Description: "Local LLM",
+						Warmup:      &LLMWarmup{Enabled: true, KeepAlive: "half an hour"},
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "unknown pii detection type",
+			config: &configData{
+				Version:      1,
+				BaseDir:      "/tmp/maestro",
+				PIIDetection: global.PIIDetection{Enabled: true, Types: []string{"passport"}},
+				LLMs: []LLM{
+					{
+						ID:          "test",
+						Type:        "command",
+						Command:     "/bin/echo",
+						Args:        []string{"{{PROMPT}}"},
+						Description: "Test LLM",
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "negative llm probe interval",
+			config: &configData{
+				Version:  1,
+				BaseDir:  "/tmp/maestro",
+				LLMProbe: LLMProbe{IntervalMinutes: -5},
+				LLMs: []LLM{
+					{
+						ID:          "test",
+						Type:        "command",
+						Command:     "/bin/echo",
+						Args:        []string{"{{PROMPT}}"},
+						Description: "Test LLM",
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "invalid retention action",
+			config: &configData{
+				Version:     1,
+				BaseDir:     "/tmp/maestro",
+				Maintenance: Maintenance{Retention: global.ResultsRetention{MaxAgeDays: 90, Action: "archive"}},
+				LLMs: []LLM{
+					{
+						ID:          "test",
+						Type:        "command",
+						Command:     "/bin/echo",
+						Args:        []string{"{{PROMPT}}"},
+						Description: "Test LLM",
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "invalid retry generation",
+			config: &configData{
+				Version: 1,
+				BaseDir: "/tmp/maestro",
+				LLMs: []LLM{
+					{
+						ID:              "test",
+						Type:            "command",
+						Command:         "/bin/echo",
+						Args:            []string{"{{PROMPT}}"},
+						Description:     "Test LLM",
+						RetryGeneration: []global.GenerationParams{{MaxTokens: -1}},
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "structured output without schema placeholder",
+			config: &configData{
+				Version: 1,
+				BaseDir: "/tmp/maestro",
+				LLMs: []LLM{
+					{
+						ID:               "test",
+						Type:             "command",
+						Command:          "/bin/echo",
+						Args:             []string{"{{PROMPT}}"},
+						Description:      "Test LLM",
+						StructuredOutput: true,
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "negative context window",
+			config: &configData{
+				Version: 1,
+				BaseDir: "/tmp/maestro",
+				LLMs: []LLM{
+					{
+						ID:            "test",
+						Type:          "command",
+						Command:       "/bin/echo",
+						Args:          []string{"{{PROMPT}}"},
+						Description:   "Test LLM",
+						ContextWindow: -1,
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "resume args without session placeholder",
+			config: &configData{
+				Version: 1,
+				BaseDir: "/tmp/maestro",
+				LLMs: []LLM{
+					{
+						ID:          "test",
+						Type:        "command",
+						Command:     "/bin/echo",
+						Args:        []string{"{{PROMPT}}"},
+						ResumeArgs:  []string{"--resume", "{{PROMPT}}"},
+						Description: "Test LLM",
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "embeddings endpoint without model",
+			config: &configData{
+				Version:    1,
+				BaseDir:    "/tmp/maestro",
+				Embeddings: global.Embeddings{Endpoint: "http://localhost:11434/v1/embeddings"},
+				LLMs: []LLM{
+					{
+						ID:          "test",
+						Type:        "command",
+						Command:     "/bin/echo",
+						Args:        []string{"{{PROMPT}}"},
+						Description: "Test LLM",
+					},
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "conversion concurrency too high",
+			config: &configData{
+				Version:    1,
+				BaseDir:    "/tmp/maestro",
+				Conversion: global.Conversion{Concurrency: global.MaxConversionConcurrency + 1},
+				LLMs: []LLM{
+					{
+						ID:          "test",
+						Type:        "command",
+						Command:     "/bin/echo",
+						Args:        []string{"{{PROMPT}}"},
+						Description: "Test LLM",
+					},
+				},
+			},
+			wantError: true,
+		},
 		{
 			name: "empty LLMs",
 			config: &configData{
@@ -251,6 +612,73 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func TestResolveLLMSecrets(t *testing.T) {
+	t.Setenv("TEST_SECRETS_HOST", "api.example.com")
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "key")
+	if err := os.WriteFile(keyFile, []byte("file-key-0123456789\n"), 0600); err != nil {
+		t.Fatalf("Failed to write key file: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		llm       LLM
+		wantArgs  []string
+		wantEnv   map[string]string
+		wantKey   string
+		wantError bool
+	}{
+		{
+			name:     "env expansion and key file",
+			llm:      LLM{Enabled: true, KeyFile: keyFile, Args: []string{"--host", "${TEST_SECRETS_HOST}", "{{PROMPT}}"}, Env: map[string]string{"API_KEY": "{{API_KEY}}"}},
+			wantArgs: []string{"--host", "api.example.com", "{{PROMPT}}"},
+			wantEnv:  map[string]string{"API_KEY": "file-key-0123456789"},
+			wantKey:  "file-key-0123456789",
+		},
+		{
+			name:     "key command",
+			llm:      LLM{Enabled: true, KeyCommand: []string{"/bin/echo", "command-key-0123456789"}, Args: []string{"--key={{API_KEY}}", "{{PROMPT}}"}},
+			wantArgs: []string{"--key=command-key-0123456789", "{{PROMPT}}"},
+			wantKey:  "command-key-0123456789",
+		},
+		{
+			name:     "disabled LLM left as configured",
+			llm:      LLM{Args: []string{"${TEST_SECRETS_UNSET}", "{{API_KEY}}"}},
+			wantArgs: []string{"${TEST_SECRETS_UNSET}", "{{API_KEY}}"},
+		},
+		{name: "unset variable", llm: LLM{Enabled: true, Args: []string{"${TEST_SECRETS_UNSET}"}}, wantError: true},
+		{name: "placeholder without key", llm: LLM{Enabled: true, Args: []string{"{{API_KEY}}"}}, wantError: true},
+		{name: "key file and command", llm: LLM{Enabled: true, KeyFile: keyFile, KeyCommand: []string{"/bin/echo"}}, wantError: true},
+		{name: "missing key file", llm: LLM{Enabled: true, KeyFile: filepath.Join(dir, "missing")}, wantError: true},
+		{name: "failing key command", llm: LLM{Enabled: true, KeyCommand: []string{"/bin/false"}}, wantError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{data: &configData{BaseDir: dir}}
+			llm := tt.llm
+			llm.ID = "test"
+			key, err := cfg.resolveLLMSecrets(&llm)
+			if (err != nil) != tt.wantError {
+				t.Fatalf("resolveLLMSecrets() error = %v, wantError %v", err, tt.wantError)
+			}
+			if tt.wantError {
+				return
+			}
+			if key != tt.wantKey {
+				t.Errorf("key = %q, want %q", key, tt.wantKey)
+			}
+			if strings.Join(llm.Args, " ") != strings.Join(tt.wantArgs, " ") {
+				t.Errorf("args = %v, want %v", llm.Args, tt.wantArgs)
+			}
+			for name, want := range tt.wantEnv {
+				if llm.Env[name] != want {
+					t.Errorf("env %s = %q, want %q", name, llm.Env[name], want)
+				}
+			}
+		})
+	}
+}
+
 func TestExpandHomePath(t *testing.T) {
 	tests := []struct {
 		name     string
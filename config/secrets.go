@@ -0,0 +1,152 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// envReference matches a ${VAR} reference to an environment variable
+var envReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// expandEnv replaces ${VAR} references in s with the values of the environment
+// variables, failing if one is not set
+func expandEnv(s string) (string, error) {
+	var missing []string
+	expanded := envReference.ReplaceAllStringFunc(s, func(ref string) string {
+		name := envReference.FindStringSubmatch(ref)[1]
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			missing = append(missing, name)
+		}
+		return value
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("environment variable %s is not set", strings.Join(missing, ", "))
+	}
+	return expanded, nil
+}
+
+// resolveLLMSecrets expands the ${VAR} references of an enabled LLM and reads
+// its API key from key_file or key_command, substituting it for {{API_KEY}}.
+// It returns the key ("" if there is none) so that it can be masked in logs.
+// Disabled LLMs are left as configured, so their secrets are not required.
+func (c *Config) resolveLLMSecrets(llm *LLM) (string, error) {
+	if llm.KeyFile != "" && len(llm.KeyCommand) > 0 {
+		return "", fmt.Errorf("LLM %s: set key_file or key_command, not both", llm.ID)
+	}
+	if !llm.Enabled {
+		return "", nil
+	}
+
+	var err error
+	if llm.Command, err = expandEnv(llm.Command); err != nil {
+		return "", fmt.Errorf("LLM %s: command: %w", llm.ID, err)
+	}
+	for _, args := range [][]string{llm.Args, llm.ResumeArgs} {
+		for i := range args {
+			if args[i], err = expandEnv(args[i]); err != nil {
+				return "", fmt.Errorf("LLM %s: args: %w", llm.ID, err)
+			}
+		}
+	}
+	for name, value := range llm.Env {
+		if llm.Env[name], err = expandEnv(value); err != nil {
+			return "", fmt.Errorf("LLM %s: env %s: %w", llm.ID, name, err)
+		}
+	}
+
+	key, err := c.readLLMKey(llm)
+	if err != nil {
+		return "", fmt.Errorf("LLM %s: %w", llm.ID, err)
+	}
+
+	used := false
+	substitute := func(s string) string {
+		if strings.Contains(s, global.PlaceholderAPIKey) {
+			used = true
+			return strings.ReplaceAll(s, global.PlaceholderAPIKey, key)
+		}
+		return s
+	}
+	for _, args := range [][]string{llm.Args, llm.ResumeArgs} {
+		for i := range args {
+			args[i] = substitute(args[i])
+		}
+	}
+	for name, value := range llm.Env {
+		llm.Env[name] = substitute(value)
+	}
+
+	switch {
+	case used && key == "":
+		return "", fmt.Errorf("LLM %s: %s is used but neither key_file nor key_command is set", llm.ID, global.PlaceholderAPIKey)
+	case !used && key != "":
+		c.warnings = append(c.warnings, fmt.Sprintf("LLM %s: the API key is not used (add %s to args or env)", llm.ID, global.PlaceholderAPIKey))
+	}
+	return key, nil
+}
+
+// readLLMKey returns the API key of an LLM, the first line of its key_file or
+// of the output of its key_command (as with pass, which may print other fields
+// after it), or "" if it has neither
+func (c *Config) readLLMKey(llm *LLM) (string, error) {
+	var key string
+	switch {
+	case llm.KeyFile != "":
+		path := c.resolvePath(llm.KeyFile)
+		info, err := os.Stat(path)
+		if err != nil {
+			return "", fmt.Errorf("key_file: %w", err)
+		}
+		if info.Mode().Perm()&0077 != 0 {
+			c.warnings = append(c.warnings, fmt.Sprintf("LLM %s: key_file %s is readable by other users (chmod 600 it)", llm.ID, path))
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("key_file: %w", err)
+		}
+		key = firstLine(string(data))
+		if key == "" {
+			return "", fmt.Errorf("key_file %s is empty", path)
+		}
+
+	case len(llm.KeyCommand) > 0:
+		command, err := lookPath(expandHomePath(llm.KeyCommand[0]), c.resolvedExtraPath)
+		if err != nil {
+			return "", fmt.Errorf("key_command: %w", err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), global.SecretCommandTimeout*time.Second)
+		defer cancel()
+		cmd := exec.CommandContext(ctx, command, llm.KeyCommand[1:]...)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err != nil {
+			return "", fmt.Errorf("key_command %s failed: %w: %s", llm.KeyCommand[0], err, strings.TrimSpace(stderr.String()))
+		}
+		key = firstLine(string(out))
+		if key == "" {
+			return "", fmt.Errorf("key_command %s printed nothing", llm.KeyCommand[0])
+		}
+	}
+	return key, nil
+}
+
+// firstLine returns the first line of s with surrounding whitespace removed
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(line)
+}
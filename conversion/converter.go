@@ -0,0 +1,238 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package conversion
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+	"github.com/tenebris-tech/x2md/convert"
+	"github.com/tenebris-tech/x2md/pdf2md/pdf"
+)
+
+// Converter converts files to Markdown. The steps of the conversion pipeline
+// are converters: the built-in text extraction and the external commands of the
+// configuration, followed by any registered with Service.Register.
+type Converter interface {
+	// Name identifies the converter in the metadata and warnings of a file
+	Name() string
+	// Handles reports whether the converter converts the file at path. Files
+	// no converter handles are not converted.
+	Handles(path string) bool
+	// Convert converts the file at path and writes the Markdown to output. The
+	// metadata it returns may be nil; the service records the characters of the
+	// output and the converter's name.
+	Convert(ctx context.Context, path, output string) (*global.ConversionMetadata, error)
+}
+
+// failedOutputPrefix starts the output the converter writes for a PDF it could
+// not extract any text from
+const failedOutputPrefix = "# Conversion Failed"
+
+// textConverter extracts text with the built-in converter
+type textConverter struct {
+	step global.ConversionStep
+}
+
+// commandConverter runs an external converter
+type commandConverter struct {
+	step global.ConversionStep
+}
+
+// newConverter returns the converter of a pipeline step
+func newConverter(step global.ConversionStep) Converter {
+	if step.Method == global.ConversionMethodCommand {
+		return &commandConverter{step: step}
+	}
+	return &textConverter{step: step}
+}
+
+func (c *textConverter) Name() string { return c.step.Name }
+
+func (c *textConverter) Handles(path string) bool {
+	if !builtinSupports(path) {
+		return false
+	}
+	return !hasFilter(c.step) || matchesFilter(c.step, path)
+}
+
+func (c *textConverter) Convert(_ context.Context, path, output string) (*global.ConversionMetadata, error) {
+	dir := filepath.Dir(output)
+	converter := convert.New(convert.WithOutputDirectory(dir), convert.WithSkipExisting(false))
+	result, err := converter.Convert(path)
+	if err != nil {
+		return nil, err
+	}
+	if result.Failed > 0 {
+		reason := "conversion failed"
+		if len(result.Errors) > 0 {
+			reason = strings.TrimPrefix(result.Errors[0].Error(), path+": ")
+		}
+		return nil, fmt.Errorf("%s", reason)
+	}
+
+	written := filepath.Join(dir, filepath.Base(path)+".md")
+	if written != output {
+		if err := os.Rename(written, output); err != nil {
+			return nil, fmt.Errorf("failed to move output: %w", err)
+		}
+	}
+	data, err := os.ReadFile(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read output: %w", err)
+	}
+	// The converter explains why a PDF has no text in place of the output
+	if strings.HasPrefix(string(data), failedOutputPrefix) {
+		return nil, fmt.Errorf("no text could be extracted (the PDF is encrypted, or scanned without a text layer)")
+	}
+
+	meta := &global.ConversionMetadata{}
+	if strings.ToLower(filepath.Ext(path)) == ".pdf" {
+		countPages(path, meta)
+	}
+	return meta, nil
+}
+
+func (c *commandConverter) Name() string { return c.step.Name }
+
+// Handles reports whether the step's extensions or MIME types match the file.
+// A step without either is a fallback for the formats of the built-in converter.
+func (c *commandConverter) Handles(path string) bool {
+	if !hasFilter(c.step) {
+		return builtinSupports(path)
+	}
+	return matchesFilter(c.step, path)
+}
+
+func (c *commandConverter) Convert(ctx context.Context, path, output string) (*global.ConversionMetadata, error) {
+	toStdout := true
+	args := make([]string, len(c.step.Args))
+	for i, arg := range c.step.Args {
+		toStdout = toStdout && !strings.Contains(arg, global.ConversionOutputPlaceholder)
+		arg = strings.ReplaceAll(arg, global.ConversionInputPlaceholder, path)
+		args[i] = strings.ReplaceAll(arg, global.ConversionOutputPlaceholder, output)
+	}
+
+	timeout := c.step.TimeoutSeconds
+	if timeout == 0 {
+		timeout = global.DefaultConversionTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, c.step.Command, args...)
+	cmd.Dir = filepath.Dir(output)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("timed out after %d seconds", timeout)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%v: %s", err, msg)
+		}
+		return nil, err
+	}
+	if toStdout {
+		if err := os.WriteFile(output, stdout.Bytes(), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write output: %w", err)
+		}
+	}
+	return nil, nil
+}
+
+// builtinSupports reports whether the built-in converter supports a file's extension
+func builtinSupports(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, supported := range convert.DefaultExtensions {
+		if ext == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// hasFilter reports whether a step is limited to some extensions or MIME types
+func hasFilter(step global.ConversionStep) bool {
+	return len(step.Extensions) > 0 || len(step.MimeTypes) > 0
+}
+
+// matchesFilter reports whether a file has one of a step's extensions or MIME
+// types. The MIME type is taken from the extension and, failing a match, from
+// the file's content; a type such as "image/*" matches all its subtypes.
+func matchesFilter(step global.ConversionStep, path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, e := range step.Extensions {
+		if strings.ToLower(e) == ext {
+			return true
+		}
+	}
+	if len(step.MimeTypes) == 0 {
+		return false
+	}
+	if global.MatchesMimeType(step.MimeTypes, mime.TypeByExtension(ext)) {
+		return true
+	}
+	return global.MatchesMimeType(step.MimeTypes, sniffMimeType(path))
+}
+
+// sniffMimeType returns the MIME type of a file's content, or "" if it cannot be read
+func sniffMimeType(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	buf := make([]byte, 512)
+	n, _ := f.Read(buf)
+	if n == 0 {
+		return ""
+	}
+	return http.DetectContentType(buf[:n])
+}
+
+// countPages records the pages of a PDF and how many have no text. Pages whose
+// text cannot be extracted count as empty.
+func countPages(path string, meta *global.ConversionMetadata) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	parser := pdf.NewParser(data)
+	if err := parser.Parse(); err != nil {
+		return
+	}
+	pages, err := parser.GetPageCount()
+	if err != nil || pages == 0 {
+		return
+	}
+	extractor := pdf.NewTextExtractor(parser)
+	for i := 0; i < pages; i++ {
+		items, err := extractor.ExtractPage(i)
+		if err != nil {
+			meta.Warnings = append(meta.Warnings, fmt.Sprintf("page %d could not be extracted: %v", i+1, err))
+		}
+		chars := 0
+		for _, item := range items {
+			chars += countChars(item.Text)
+		}
+		if chars == 0 {
+			meta.EmptyPages++
+		}
+	}
+	meta.Pages = pages
+	meta.EmptyPageRatio = float64(meta.EmptyPages) / float64(pages)
+}
@@ -0,0 +1,117 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package conversion
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// attempt is the output of one pipeline step
+type attempt struct {
+	output string // Markdown file in the temporary directory
+	meta   global.ConversionMetadata
+	issues []string // Quality thresholds the output does not meet
+}
+
+// runPipeline converts path with the converters that handle it, writing their
+// output under tmpDir, and returns the output to keep with its metadata.
+// Converters run until one meets the quality thresholds; otherwise the output
+// with the most text is kept and flagged as low quality.
+func (s *Service) runPipeline(path, tmpDir string) (string, *global.ConversionMetadata, error) {
+	var best *attempt
+	var warnings []string
+	pages := 0
+	for i, c := range s.pipeline() {
+		if !c.Handles(path) {
+			continue
+		}
+		stepDir := filepath.Join(tmpDir, fmt.Sprintf("%d", i))
+		if err := os.Mkdir(stepDir, 0755); err != nil {
+			return "", nil, fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+
+		a, err := runConverter(c, path, filepath.Join(stepDir, filepath.Base(path)+".md"))
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s failed: %v", c.Name(), err))
+			continue
+		}
+		// Converters that do not count pages use the count of an earlier one
+		if a.meta.Pages == 0 {
+			a.meta.Pages = pages
+		}
+		pages = a.meta.Pages
+		a.issues = s.assess(&a.meta)
+
+		if best == nil || a.meta.Characters > best.meta.Characters {
+			best = a
+		}
+		if len(a.issues) == 0 {
+			best = a
+			break
+		}
+		warnings = append(warnings, fmt.Sprintf("%s: %s", c.Name(), strings.Join(a.issues, "; ")))
+	}
+
+	if best == nil {
+		if len(warnings) == 0 {
+			return "", nil, fmt.Errorf("no converter handles %s files", strings.ToLower(filepath.Ext(path)))
+		}
+		return "", nil, fmt.Errorf("%s", strings.Join(warnings, "; "))
+	}
+
+	// Steps that failed or fell short, including the one used if it did
+	meta := best.meta
+	meta.LowQuality = len(best.issues) > 0
+	meta.Warnings = append(meta.Warnings, warnings...)
+	return best.output, &meta, nil
+}
+
+// runConverter converts a file with one converter and measures the output
+func runConverter(c Converter, path, output string) (*attempt, error) {
+	meta, err := c.Convert(context.Background(), path, output)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(output)
+	if err != nil {
+		return nil, fmt.Errorf("no output: %w", err)
+	}
+	a := &attempt{output: output}
+	if meta != nil {
+		a.meta = *meta
+	}
+	a.meta.Method = c.Name()
+	a.meta.Characters = countChars(string(data))
+	return a, nil
+}
+
+// assess returns the quality thresholds the metadata does not meet
+func (s *Service) assess(meta *global.ConversionMetadata) []string {
+	var issues []string
+	if meta.Pages > 0 {
+		if perPage := meta.Characters / meta.Pages; perPage < s.cfg.MinCharsPerPage {
+			issues = append(issues, fmt.Sprintf("%d characters per page (minimum %d)", perPage, s.cfg.MinCharsPerPage))
+		}
+		if meta.EmptyPageRatio > s.cfg.MaxEmptyPageRatio {
+			issues = append(issues, fmt.Sprintf("%d of %d pages without text", meta.EmptyPages, meta.Pages))
+		}
+	} else if meta.Characters < s.cfg.MinCharsPerPage {
+		issues = append(issues, fmt.Sprintf("%d characters (minimum %d)", meta.Characters, s.cfg.MinCharsPerPage))
+	}
+	return issues
+}
+
+// countChars returns the number of characters of text, not counting whitespace
+func countChars(text string) int {
+	return utf8.RuneCountInString(strings.Join(strings.Fields(text), ""))
+}
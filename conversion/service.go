@@ -0,0 +1,370 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+// Package conversion converts documents (PDF, DOCX, XLSX, and the formats of
+// configured or registered converters) to Markdown beside the originals. Files
+// are converted by a pool of workers, and each conversion is tracked as a job
+// whose progress can be read while it runs. Each file goes through the pipeline
+// of converters, falling back to the next when the output falls short of the
+// quality thresholds. Output files are written to a temporary directory and
+// renamed into place once complete, so a conversion that was interrupted can be
+// run again: files that already have their Markdown output are skipped.
+package conversion
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+	"github.com/PivotLLM/Maestro/logging"
+	"github.com/google/uuid"
+)
+
+// tempDirPrefix names the temporary directories output is written to
+const tempDirPrefix = ".convert-"
+
+// Service runs and tracks document conversions
+type Service struct {
+	cfg        global.Conversion
+	logger     *logging.Logger
+	mu         sync.Mutex
+	converters []Converter // Pipeline steps, in order
+	jobs       map[string]*job
+	order      []string // Job IDs, oldest first
+}
+
+// job is a conversion in progress or completed. Its fields are guarded by the
+// service mutex.
+type job struct {
+	state global.ConversionJob
+	done  chan struct{}
+}
+
+// NewService creates a conversion service with the pipeline of the configuration
+func NewService(cfg global.Conversion, logger *logging.Logger) *Service {
+	s := &Service{
+		cfg:    cfg.WithDefaults(),
+		logger: logger,
+		jobs:   make(map[string]*job),
+	}
+	for _, step := range s.cfg.Pipeline {
+		s.converters = append(s.converters, newConverter(step))
+	}
+	return s
+}
+
+// Register adds a converter to the end of the pipeline, so that it converts the
+// files it handles that the configured steps do not, or do poorly. Converter
+// names must be unique.
+func (s *Service) Register(c Converter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, existing := range s.converters {
+		if existing.Name() == c.Name() {
+			return fmt.Errorf("a converter named %s is already registered", c.Name())
+		}
+	}
+	s.converters = append(s.converters, c)
+	s.logger.Infof("Registered converter %s", c.Name())
+	return nil
+}
+
+// pipeline returns the converters of the pipeline
+func (s *Service) pipeline() []Converter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Converter(nil), s.converters...)
+}
+
+// Start begins converting path, a file or (with recursive) a directory within
+// baseDir, in the background and returns the job as it starts. project is the
+// project the files belong to, or "" for the shared evidence library.
+// concurrency is the number of files converted at once; 0 uses the configured
+// concurrency.
+func (s *Service) Start(project, baseDir, path string, recursive bool, concurrency int) (*global.ConversionJob, error) {
+	j, err := s.start(project, baseDir, path, recursive, concurrency)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return j.snapshot(), nil
+}
+
+// Convert converts path like Start and waits for the conversion to complete
+func (s *Service) Convert(project, baseDir, path string, recursive bool, concurrency int) (*global.ConversionJob, error) {
+	j, err := s.start(project, baseDir, path, recursive, concurrency)
+	if err != nil {
+		return nil, err
+	}
+	<-j.done
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return j.snapshot(), nil
+}
+
+// start lists the files of a conversion, registers its job and starts converting
+func (s *Service) start(project, baseDir, path string, recursive bool, concurrency int) (*job, error) {
+	if concurrency <= 0 {
+		concurrency = s.cfg.Concurrency
+	}
+	if concurrency > global.MaxConversionConcurrency {
+		return nil, fmt.Errorf("concurrency must be 1 to %d", global.MaxConversionConcurrency)
+	}
+
+	root := filepath.Join(baseDir, filepath.FromSlash(path))
+	files, err := s.listFiles(root, recursive)
+	if err != nil {
+		return nil, err
+	}
+
+	j := &job{
+		state: global.ConversionJob{
+			ID:          uuid.New().String(),
+			Project:     project,
+			Path:        path,
+			Recursive:   recursive,
+			Status:      global.ConversionStatusRunning,
+			Concurrency: concurrency,
+			Total:       len(files),
+			StartedAt:   time.Now(),
+			Files:       make([]global.ConversionFile, len(files)),
+		},
+		done: make(chan struct{}),
+	}
+	for i, file := range files {
+		j.state.Files[i] = global.ConversionFile{Path: relPath(baseDir, file), Status: global.ConversionFilePending}
+	}
+
+	s.mu.Lock()
+	s.jobs[j.state.ID] = j
+	s.order = append(s.order, j.state.ID)
+	s.pruneLocked()
+	s.mu.Unlock()
+
+	go s.run(j, baseDir, files)
+	return j, nil
+}
+
+// Job returns the current state of a conversion job
+func (s *Service) Job(id string) (*global.ConversionJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j, ok := s.jobs[id]
+	if !ok {
+		return nil, fmt.Errorf("conversion job not found: %s", id)
+	}
+	return j.snapshot(), nil
+}
+
+// Jobs returns the conversion jobs of a project, most recent first, without
+// their per-file details
+func (s *Service) Jobs(project string) []global.ConversionJob {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	jobs := []global.ConversionJob{}
+	for i := len(s.order) - 1; i >= 0; i-- {
+		j := s.jobs[s.order[i]]
+		if j.state.Project != project {
+			continue
+		}
+		summary := j.state
+		summary.Files = nil
+		jobs = append(jobs, summary)
+	}
+	return jobs
+}
+
+// run converts the files of a job with a pool of workers
+func (s *Service) run(j *job, baseDir string, files []string) {
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < j.state.Concurrency && w < len(files); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				s.setFile(j, i, global.ConversionFile{Status: global.ConversionFileConverting})
+				start := time.Now()
+				output, status, reason, meta := s.convertFile(files[i])
+				s.setFile(j, i, global.ConversionFile{
+					Output:     relPath(baseDir, output),
+					Status:     status,
+					Reason:     reason,
+					DurationMs: time.Since(start).Milliseconds(),
+					Metadata:   meta,
+				})
+				switch {
+				case status == global.ConversionFileFailed:
+					s.logger.Warnf("Conversion of %s failed: %s", files[i], reason)
+				case meta != nil && meta.LowQuality:
+					s.logger.Warnf("Conversion of %s is low quality: %s", files[i], strings.Join(meta.Warnings, "; "))
+				}
+			}
+		}()
+	}
+	for i := range files {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	s.mu.Lock()
+	now := time.Now()
+	j.state.Status = global.ConversionStatusCompleted
+	j.state.FinishedAt = &now
+	s.logger.Infof("Conversion of %s/%s completed: %d converted (%d low quality), %d skipped, %d failed", j.state.Project, j.state.Path, j.state.Converted, j.state.LowQuality, j.state.Skipped, j.state.Failed)
+	s.mu.Unlock()
+	close(j.done)
+}
+
+// setFile records the progress of one file of a job
+func (s *Service) setFile(j *job, i int, update global.ConversionFile) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	file := &j.state.Files[i]
+	file.Status = update.Status
+	if update.Status == global.ConversionFileConverting {
+		return
+	}
+	file.Output, file.Reason, file.DurationMs, file.Metadata = update.Output, update.Reason, update.DurationMs, update.Metadata
+	j.state.Processed++
+	switch update.Status {
+	case global.ConversionFileConverted:
+		j.state.Converted++
+		if update.Metadata != nil && update.Metadata.LowQuality {
+			j.state.LowQuality++
+		}
+	case global.ConversionFileSkipped:
+		j.state.Skipped++
+	case global.ConversionFileFailed:
+		j.state.Failed++
+	}
+}
+
+// pruneLocked drops the oldest completed jobs beyond MaxConversionJobs
+func (s *Service) pruneLocked() {
+	excess := len(s.order) - global.MaxConversionJobs
+	kept := s.order[:0]
+	for _, id := range s.order {
+		if excess > 0 && s.jobs[id].state.Status == global.ConversionStatusCompleted {
+			delete(s.jobs, id)
+			excess--
+			continue
+		}
+		kept = append(kept, id)
+	}
+	s.order = kept
+}
+
+// snapshot returns a copy of the job's state
+func (j *job) snapshot() *global.ConversionJob {
+	state := j.state
+	state.Files = append([]global.ConversionFile(nil), j.state.Files...)
+	if j.state.FinishedAt != nil {
+		finished := *j.state.FinishedAt
+		state.FinishedAt = &finished
+	}
+	return &state
+}
+
+// listFiles returns the files at root that a converter handles: root itself,
+// or with recursive the files in the directory tree under it. Other files are
+// ignored. Symbolic links and the temporary directories of interrupted
+// conversions are not followed.
+func (s *Service) listFiles(root string, recursive bool) ([]string, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf("cannot access %s: %w", filepath.Base(root), err)
+	}
+	if !info.IsDir() {
+		if !s.convertible(root) {
+			return nil, nil
+		}
+		return []string{root}, nil
+	}
+	if !recursive {
+		return nil, fmt.Errorf("%s is a directory; use recursive conversion", filepath.Base(root))
+	}
+
+	var files []string
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && strings.HasPrefix(d.Name(), tempDirPrefix) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Type().IsRegular() && s.convertible(path) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+	return files, nil
+}
+
+// convertible reports whether a converter handles a file. Markdown files,
+// which include the output of conversions, are never converted.
+func (s *Service) convertible(path string) bool {
+	if strings.EqualFold(filepath.Ext(path), ".md") {
+		return false
+	}
+	for _, c := range s.pipeline() {
+		if c.Handles(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// convertFile converts one file to <file>.md with the conversion pipeline and
+// returns the output path, the outcome, for skipped and failed files the reason
+// and for converted files the output's metadata. The output is written to a
+// temporary directory beside the file and renamed into place, so an existing
+// output is always complete.
+func (s *Service) convertFile(path string) (string, string, string, *global.ConversionMetadata) {
+	output := path + ".md"
+	if _, err := os.Stat(output); err == nil {
+		return output, global.ConversionFileSkipped, "already converted", nil
+	}
+
+	tmpDir, err := os.MkdirTemp(filepath.Dir(path), tempDirPrefix)
+	if err != nil {
+		return "", global.ConversionFileFailed, fmt.Sprintf("failed to create temporary directory: %v", err), nil
+	}
+	defer os.RemoveAll(tmpDir)
+
+	converted, meta, err := s.runPipeline(path, tmpDir)
+	if err != nil {
+		return "", global.ConversionFileFailed, err.Error(), nil
+	}
+	if err := os.Rename(converted, output); err != nil {
+		return "", global.ConversionFileFailed, fmt.Sprintf("failed to save output: %v", err), nil
+	}
+	return output, global.ConversionFileConverted, "", meta
+}
+
+// relPath returns path relative to baseDir with forward slashes, or "" for ""
+func relPath(baseDir, path string) string {
+	if path == "" {
+		return ""
+	}
+	rel, err := filepath.Rel(baseDir, path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,303 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package conversion
+
+import (
+	"archive/zip"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+	"github.com/PivotLLM/Maestro/logging"
+)
+
+// writeDocx writes a minimal Word document containing text
+func writeDocx(t *testing.T, path, text string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("create %s: %v", path, err)
+	}
+	defer f.Close()
+	z := zip.NewWriter(f)
+	w, err := z.Create("word/document.xml")
+	if err != nil {
+		t.Fatalf("create document.xml: %v", err)
+	}
+	_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body><w:p><w:r><w:t>` + text + `</w:t></w:r></w:p></w:body></w:document>`))
+	if err := z.Close(); err != nil {
+		t.Fatalf("close zip: %v", err)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	dir := t.TempDir()
+	evidence := filepath.Join(dir, "evidence")
+	for _, sub := range []string{"policies", ".convert-leftover"} {
+		if err := os.MkdirAll(filepath.Join(evidence, sub), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i, name := range []string{"a.docx", "b.docx", "c.docx", "policies/d.docx"} {
+		writeDocx(t, filepath.Join(evidence, name), "Evidence "+string(rune('A'+i)))
+	}
+	// Already converted, not a document, broken, and inside an interrupted conversion's temporary directory
+	files := map[string]string{
+		"c.docx.md":                   "existing",
+		"notes.txt":                   "text",
+		"broken.pdf":                  "not a PDF",
+		".convert-leftover/e.docx":    "leftover",
+		".convert-leftover/e.docx.md": "partial",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(evidence, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	logger, err := logging.New(filepath.Join(t.TempDir(), "test.log"))
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	s := NewService(global.Conversion{Concurrency: 2}, logger)
+	job, err := s.Convert("proj", dir, "evidence", true, 0)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if job.Status != global.ConversionStatusCompleted || job.Concurrency != 2 || job.FinishedAt == nil {
+		t.Errorf("job status %s, concurrency %d, finished %v", job.Status, job.Concurrency, job.FinishedAt)
+	}
+	if job.Total != 5 || job.Processed != 5 || job.Converted != 3 || job.Skipped != 1 || job.Failed != 1 {
+		t.Fatalf("job = %+v", job)
+	}
+	outcomes := make(map[string]global.ConversionFile)
+	for _, file := range job.Files {
+		outcomes[file.Path] = file
+	}
+	if f := outcomes["evidence/policies/d.docx"]; f.Status != global.ConversionFileConverted || f.Output != "evidence/policies/d.docx.md" {
+		t.Errorf("d.docx = %+v", f)
+	}
+	if f := outcomes["evidence/c.docx"]; f.Status != global.ConversionFileSkipped || f.Reason == "" {
+		t.Errorf("c.docx = %+v", f)
+	}
+	if f := outcomes["evidence/broken.pdf"]; f.Status != global.ConversionFileFailed || f.Reason == "" || f.Output != "" {
+		t.Errorf("broken.pdf = %+v", f)
+	}
+	if data, _ := os.ReadFile(filepath.Join(evidence, "a.docx.md")); !strings.Contains(string(data), "Evidence A") {
+		t.Errorf("a.docx.md = %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(evidence, "broken.pdf.md")); !os.IsNotExist(err) {
+		t.Error("a failed conversion left an output file")
+	}
+	entries, _ := filepath.Glob(filepath.Join(evidence, tempDirPrefix+"*"))
+	if len(entries) != 1 {
+		t.Errorf("temporary directories left behind: %v", entries)
+	}
+
+	// Running again resumes: everything converted before is skipped
+	job, err = s.Convert("proj", dir, "evidence", true, 0)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if job.Converted != 0 || job.Skipped != 4 || job.Failed != 1 {
+		t.Errorf("second run = %+v", job)
+	}
+
+	jobs := s.Jobs("proj")
+	if len(jobs) != 2 || jobs[0].ID != job.ID || jobs[0].Files != nil {
+		t.Errorf("Jobs() = %+v", jobs)
+	}
+	if len(s.Jobs("other")) != 0 {
+		t.Error("Jobs() returned another project's jobs")
+	}
+
+	if _, err := s.Convert("proj", dir, "evidence", false, 0); err == nil {
+		t.Error("Convert() accepted a directory without recursive")
+	}
+	if job, err := s.Convert("proj", dir, "evidence/notes.txt", false, 0); err != nil || job.Total != 0 {
+		t.Errorf("Convert() of an unsupported file = %+v, %v", job, err)
+	}
+	if _, err := s.Convert("proj", dir, "evidence", true, global.MaxConversionConcurrency+1); err == nil {
+		t.Error("Convert() accepted too much concurrency")
+	}
+}
+
+func TestStartReportsProgress(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.docx", "b.docx"} {
+		writeDocx(t, filepath.Join(dir, name), "Evidence")
+	}
+
+	logger, err := logging.New(filepath.Join(t.TempDir(), "test.log"))
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	s := NewService(global.Conversion{}, logger)
+	started, err := s.Start("proj", dir, ".", true, 1)
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if started.Status != global.ConversionStatusRunning || started.Total != 2 || len(started.Files) != 2 {
+		t.Fatalf("started job = %+v", started)
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		job, err := s.Job(started.ID)
+		if err != nil {
+			t.Fatalf("Job() error = %v", err)
+		}
+		if job.Status == global.ConversionStatusCompleted {
+			if job.Converted != 2 {
+				t.Errorf("completed job = %+v", job)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("conversion did not complete")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if _, err := s.Job("missing"); err == nil {
+		t.Error("Job() found a missing job")
+	}
+}
+
+func TestPipelineFallback(t *testing.T) {
+	dir := t.TempDir()
+	writeDocx(t, filepath.Join(dir, "short.docx"), "Scanned")
+	long := strings.Repeat("Recognized text. ", 5)
+
+	logger, err := logging.New(filepath.Join(t.TempDir(), "test.log"))
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	ocr := global.ConversionStep{
+		Name:    "ocr",
+		Method:  global.ConversionMethodCommand,
+		Command: "/bin/sh",
+		Args:    []string{"-c", `test -f "$1" && printf '%s' "$2" > "$3"`, "sh", global.ConversionInputPlaceholder, long, global.ConversionOutputPlaceholder},
+	}
+	broken := global.ConversionStep{Name: "broken", Method: global.ConversionMethodCommand, Command: "/bin/false", Args: []string{global.ConversionInputPlaceholder}}
+	text := global.ConversionStep{Name: "text", Method: global.ConversionMethodText}
+
+	// Text extraction yields almost nothing, so the next step that works is used
+	s := NewService(global.Conversion{Pipeline: []global.ConversionStep{text, broken, ocr}}, logger)
+	job, err := s.Convert("proj", dir, "short.docx", false, 0)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	meta := job.Files[0].Metadata
+	if job.Converted != 1 || job.LowQuality != 0 || meta == nil || meta.Method != "ocr" || meta.LowQuality || meta.Characters != len(strings.Join(strings.Fields(long), "")) {
+		t.Fatalf("job = %+v, metadata = %+v", job, meta)
+	}
+	if len(meta.Warnings) != 2 || !strings.HasPrefix(meta.Warnings[0], "text: 7 characters") || !strings.HasPrefix(meta.Warnings[1], "broken failed") {
+		t.Errorf("warnings = %q", meta.Warnings)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "short.docx.md")); string(data) != long {
+		t.Errorf("output = %q", data)
+	}
+
+	// Without a better step the output is kept and flagged
+	if err := os.Remove(filepath.Join(dir, "short.docx.md")); err != nil {
+		t.Fatal(err)
+	}
+	s = NewService(global.Conversion{Pipeline: []global.ConversionStep{text, broken}}, logger)
+	job, err = s.Convert("proj", dir, "short.docx", false, 0)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if meta := job.Files[0].Metadata; job.Converted != 1 || job.LowQuality != 1 || meta == nil || meta.Method != "text" || !meta.LowQuality {
+		t.Errorf("job = %+v, metadata = %+v", job, meta)
+	}
+
+	// Files no step handles are not converted
+	if err := os.Remove(filepath.Join(dir, "short.docx.md")); err != nil {
+		t.Fatal(err)
+	}
+	ocr.Extensions = []string{".pdf"}
+	s = NewService(global.Conversion{Pipeline: []global.ConversionStep{ocr}}, logger)
+	job, err = s.Convert("proj", dir, "short.docx", false, 0)
+	if err != nil || job.Total != 0 {
+		t.Errorf("Convert() = %+v, %v", job, err)
+	}
+}
+
+// drawingConverter is a registered converter for a proprietary format
+type drawingConverter struct{}
+
+func (drawingConverter) Name() string { return "drawings" }
+
+func (drawingConverter) Handles(path string) bool { return strings.HasSuffix(path, ".dwg") }
+
+func (drawingConverter) Convert(_ context.Context, path, output string) (*global.ConversionMetadata, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return &global.ConversionMetadata{Pages: 1}, os.WriteFile(output, []byte("# Drawing\n\n"+string(data)), 0644)
+}
+
+func TestPluggableConverters(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"site.dwg":  "\x00AC1032" + strings.Repeat(" layer walls", 10),
+		"costs.csv": strings.Repeat("item,amount\n", 10),
+		"notes.md":  "Markdown is never converted",
+		"other.bin": "\x00\x01\x02 not handled",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	logger, err := logging.New(filepath.Join(t.TempDir(), "test.log"))
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	// A command keyed by MIME type: text/csv is taken from the extension
+	csv := global.ConversionStep{
+		Name:      "csv",
+		Method:    global.ConversionMethodCommand,
+		Command:   "/bin/cat",
+		Args:      []string{global.ConversionInputPlaceholder},
+		MimeTypes: []string{"text/*"},
+	}
+	s := NewService(global.Conversion{Pipeline: []global.ConversionStep{{Name: "text", Method: global.ConversionMethodText}, csv}}, logger)
+	if err := s.Register(drawingConverter{}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := s.Register(drawingConverter{}); err == nil {
+		t.Error("Register() accepted a duplicate name")
+	}
+
+	job, err := s.Convert("proj", dir, ".", true, 0)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if job.Total != 2 || job.Converted != 2 {
+		t.Fatalf("job = %+v", job)
+	}
+	methods := make(map[string]string)
+	for _, file := range job.Files {
+		methods[file.Path] = file.Metadata.Method
+	}
+	if methods["site.dwg"] != "drawings" || methods["costs.csv"] != "csv" {
+		t.Errorf("methods = %v", methods)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "site.dwg.md")); !strings.HasPrefix(string(data), "# Drawing") {
+		t.Errorf("site.dwg.md = %q", data)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "costs.csv.md")); string(data) != files["costs.csv"] {
+		t.Errorf("costs.csv.md = %q", data)
+	}
+}
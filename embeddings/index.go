@@ -0,0 +1,129 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package embeddings
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// index is the stored index of one collection
+type index struct {
+	Model string                  `json:"model"`
+	Files map[string]*indexedFile `json:"files"`
+}
+
+// indexedFile is the indexed state of one file
+type indexedFile struct {
+	SizeBytes  int64     `json:"size_bytes"`
+	ModifiedAt time.Time `json:"modified_at"`
+	Checksum   string    `json:"checksum"`
+	Chunks     []chunk   `json:"chunks"`
+}
+
+// chunk is a part of a file with its embedding
+type chunk struct {
+	Line   int       `json:"line"`
+	Text   string    `json:"text"`
+	Vector []float32 `json:"vector"`
+}
+
+// String returns the collection as used in log messages and index file names
+func (c Collection) String() string {
+	if c.Name == "" {
+		return c.Source
+	}
+	return c.Source + "-" + c.Name
+}
+
+// indexPath returns the index file of a collection
+func (s *Service) indexPath(collection Collection) string {
+	return filepath.Join(s.dir, collection.String()+".json")
+}
+
+// loadIndex reads the index of a collection (empty if it was never synced)
+func (s *Service) loadIndex(collection Collection) (*index, error) {
+	idx := &index{Files: make(map[string]*indexedFile)}
+	data, err := os.ReadFile(s.indexPath(collection))
+	if os.IsNotExist(err) {
+		return idx, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embeddings index: %w", err)
+	}
+	if err := json.Unmarshal(data, idx); err != nil {
+		return nil, fmt.Errorf("invalid embeddings index %s: %w", collection, err)
+	}
+	if idx.Files == nil {
+		idx.Files = make(map[string]*indexedFile)
+	}
+	return idx, nil
+}
+
+// saveIndex writes the index of a collection
+func (s *Service) saveIndex(collection Collection, idx *index) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create embeddings directory: %w", err)
+	}
+	data, err := json.Marshal(idx)
+	if err != nil {
+		return err
+	}
+	return global.AtomicWrite(s.indexPath(collection), data)
+}
+
+// chunkText splits text into chunks of about size characters, sharing overlap
+// characters with the previous chunk. A chunk ends at a line break when one is
+// found in its second half.
+func chunkText(text string, size, overlap int) []chunk {
+	var chunks []chunk
+	start, line := 0, 1
+	for start < len(text) {
+		end := start + size
+		if end >= len(text) {
+			end = len(text)
+		} else if i := strings.LastIndexByte(text[start+size/2:end], '\n'); i >= 0 {
+			end = start + size/2 + i + 1
+		} else {
+			for end > start+1 && !utf8.RuneStart(text[end]) {
+				end--
+			}
+		}
+
+		if strings.TrimSpace(text[start:end]) != "" {
+			chunks = append(chunks, chunk{Line: line, Text: text[start:end]})
+		}
+		if end == len(text) {
+			break
+		}
+
+		next := end - overlap
+		if next <= start {
+			next = end
+		}
+		for next < end && !utf8.RuneStart(text[next]) {
+			next++
+		}
+		line += strings.Count(text[start:next], "\n")
+		start = next
+	}
+	return chunks
+}
+
+// checksum returns the SHA-256 of content
+func checksum(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,329 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+// Package embeddings provides semantic search over project files, playbook files
+// and reference documents. Files are split into chunks that are embedded with an
+// OpenAI-compatible embeddings endpoint; the vectors are kept in one index file
+// per collection and updated incrementally as files change.
+package embeddings
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+	"github.com/PivotLLM/Maestro/logging"
+)
+
+// Service indexes and searches collections of documents
+type Service struct {
+	cfg    global.Embeddings
+	dir    string // Directory holding the index files
+	client *http.Client
+	logger *logging.Logger
+	mu     sync.Mutex // Serializes index updates
+}
+
+// Collection identifies a set of indexed documents: a project, a playbook, or
+// the reference library (Name is empty)
+type Collection struct {
+	Source string `json:"source"` // "project", "playbook" or "reference"
+	Name   string `json:"name,omitempty"`
+}
+
+// Document is a file offered for indexing. Content is only loaded when the file
+// changed since it was last indexed; files without a modification time (such as
+// embedded reference documents) are always loaded and compared by checksum.
+type Document struct {
+	Path       string
+	SizeBytes  int64
+	ModifiedAt time.Time
+	Load       func() (string, error)
+}
+
+// SyncStats summarizes an index update
+type SyncStats struct {
+	Indexed   int `json:"indexed"`   // Files (re)embedded
+	Unchanged int `json:"unchanged"` // Files already up to date
+	Removed   int `json:"removed"`   // Files dropped from the index
+	Skipped   int `json:"skipped"`   // Binary, unreadable or oversized files
+	Chunks    int `json:"chunks"`    // Chunks embedded
+}
+
+// Match is a chunk returned by Search
+type Match struct {
+	Source string  `json:"source"`
+	Name   string  `json:"name,omitempty"` // Project or playbook name
+	Path   string  `json:"path"`
+	Line   int     `json:"line"` // First line of the chunk
+	Score  float64 `json:"score"`
+	Text   string  `json:"text"`
+}
+
+// NewService creates an embeddings service storing its indexes in dir
+func NewService(cfg global.Embeddings, dir string, logger *logging.Logger) *Service {
+	cfg = cfg.WithDefaults()
+	return &Service{
+		cfg:    cfg,
+		dir:    dir,
+		client: &http.Client{Timeout: time.Duration(cfg.TimeoutSeconds) * time.Second},
+		logger: logger,
+	}
+}
+
+// Sync brings the index of a collection up to date with docs: new and changed
+// files are chunked and embedded, and files no longer present are dropped. The
+// whole collection is re-embedded when the configured model changes.
+func (s *Service) Sync(collection Collection, docs []Document) (*SyncStats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idx, err := s.loadIndex(collection)
+	if err != nil {
+		return nil, err
+	}
+	if idx.Model != s.cfg.Model {
+		idx = &index{Model: s.cfg.Model, Files: make(map[string]*indexedFile)}
+	}
+
+	stats := &SyncStats{}
+	present := make(map[string]bool, len(docs))
+	changed := false
+	maxBytes := int64(s.cfg.MaxFileKB) * 1024
+	for _, doc := range docs {
+		present[doc.Path] = true
+		existing := idx.Files[doc.Path]
+		if existing != nil && !doc.ModifiedAt.IsZero() && existing.SizeBytes == doc.SizeBytes && existing.ModifiedAt.Equal(doc.ModifiedAt) {
+			stats.Unchanged++
+			continue
+		}
+		if doc.SizeBytes > maxBytes {
+			stats.Skipped++
+			if existing != nil {
+				delete(idx.Files, doc.Path)
+				changed = true
+			}
+			continue
+		}
+		content, err := doc.Load()
+		if err != nil {
+			stats.Skipped++
+			continue
+		}
+		checksum := checksum(content)
+		if existing != nil && existing.Checksum == checksum {
+			existing.SizeBytes, existing.ModifiedAt = doc.SizeBytes, doc.ModifiedAt
+			stats.Unchanged++
+			changed = true
+			continue
+		}
+
+		file := &indexedFile{SizeBytes: doc.SizeBytes, ModifiedAt: doc.ModifiedAt, Checksum: checksum}
+		chunks := chunkText(content, s.cfg.ChunkSize, s.cfg.ChunkOverlap)
+		texts := make([]string, len(chunks))
+		for i, c := range chunks {
+			texts[i] = c.Text
+		}
+		vectors, err := s.embed(texts)
+		if err != nil {
+			// Keep what was embedded so far
+			if saveErr := s.saveIndex(collection, idx); saveErr != nil {
+				s.logger.Warnf("Failed to save embeddings index for %s: %v", collection, saveErr)
+			}
+			return stats, fmt.Errorf("failed to embed %s: %w", doc.Path, err)
+		}
+		for i := range chunks {
+			chunks[i].Vector = vectors[i]
+		}
+		file.Chunks = chunks
+		idx.Files[doc.Path] = file
+		stats.Indexed++
+		stats.Chunks += len(chunks)
+		changed = true
+	}
+	for path := range idx.Files {
+		if !present[path] {
+			delete(idx.Files, path)
+			stats.Removed++
+			changed = true
+		}
+	}
+
+	if changed {
+		if err := s.saveIndex(collection, idx); err != nil {
+			return stats, err
+		}
+		s.logger.Infof("Embeddings index %s: %d indexed (%d chunks), %d unchanged, %d removed, %d skipped",
+			collection, stats.Indexed, stats.Chunks, stats.Unchanged, stats.Removed, stats.Skipped)
+	}
+	return stats, nil
+}
+
+// Search returns the chunks of the given collections most similar to query,
+// best first. Collections that were never synced are ignored.
+func (s *Service) Search(query string, collections []Collection, limit int) ([]Match, error) {
+	if limit <= 0 {
+		limit = global.DefaultSemanticSearchLimit
+	}
+	vectors, err := s.embed([]string{query})
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+	queryVector := vectors[0]
+
+	matches := []Match{}
+	for _, collection := range collections {
+		s.mu.Lock()
+		idx, err := s.loadIndex(collection)
+		s.mu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+		if idx.Model != s.cfg.Model {
+			continue
+		}
+		for path, file := range idx.Files {
+			for _, c := range file.Chunks {
+				matches = append(matches, Match{
+					Source: collection.Source,
+					Name:   collection.Name,
+					Path:   path,
+					Line:   c.Line,
+					Score:  dot(queryVector, c.Vector),
+					Text:   c.Text,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		if matches[i].Path != matches[j].Path {
+			return matches[i].Path < matches[j].Path
+		}
+		return matches[i].Line < matches[j].Line
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, nil
+}
+
+// embeddingsRequest and embeddingsResponse are the OpenAI embeddings API payloads
+type embeddingsRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type embeddingsResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+// embed returns the normalized embedding of each text, in batches of BatchSize
+func (s *Service) embed(texts []string) ([][]float32, error) {
+	vectors := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += s.cfg.BatchSize {
+		end := start + s.cfg.BatchSize
+		if end > len(texts) {
+			end = len(texts)
+		}
+		batch, err := s.embedBatch(texts[start:end])
+		if err != nil {
+			return nil, err
+		}
+		vectors = append(vectors, batch...)
+	}
+	return vectors, nil
+}
+
+// embedBatch sends one embeddings request
+func (s *Service) embedBatch(texts []string) ([][]float32, error) {
+	body, err := json.Marshal(embeddingsRequest{Model: s.cfg.Model, Input: texts})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, s.cfg.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.cfg.APIKeyEnv != "" {
+		if key := os.Getenv(s.cfg.APIKeyEnv); key != "" {
+			req.Header.Set("Authorization", "Bearer "+key)
+		}
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, bytes.TrimSpace(data))
+	}
+
+	var parsed embeddingsResponse
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("invalid embeddings response: %w", err)
+	}
+	if len(parsed.Data) != len(texts) {
+		return nil, fmt.Errorf("embeddings response has %d vectors for %d inputs", len(parsed.Data), len(texts))
+	}
+	vectors := make([][]float32, len(texts))
+	for i, d := range parsed.Data {
+		index := d.Index
+		if index < 0 || index >= len(texts) || vectors[index] != nil {
+			index = i
+		}
+		vectors[index] = normalize(d.Embedding)
+	}
+	return vectors, nil
+}
+
+// normalize scales a vector to unit length so that similarity is a dot product
+func normalize(v []float32) []float32 {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	if sum == 0 {
+		return v
+	}
+	norm := float32(math.Sqrt(sum))
+	result := make([]float32, len(v))
+	for i, x := range v {
+		result[i] = x / norm
+	}
+	return result
+}
+
+// dot returns the dot product of two vectors (0 if their dimensions differ)
+func dot(a, b []float32) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var sum float64
+	for i := range a {
+		sum += float64(a[i]) * float64(b[i])
+	}
+	return sum
+}
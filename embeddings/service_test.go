@@ -0,0 +1,147 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package embeddings
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+	"github.com/PivotLLM/Maestro/logging"
+)
+
+// topics are the dimensions of the test embeddings: one per word counted
+var topics = []string{"firewall", "password", "backup"}
+
+// newTestServer returns an embeddings endpoint that counts topic words, and the
+// number of texts it was asked to embed
+func newTestServer(t *testing.T) (*httptest.Server, *int) {
+	t.Helper()
+	embedded := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req embeddingsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Model != "test-model" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		var resp embeddingsResponse
+		for i, text := range req.Input {
+			vector := make([]float32, len(topics))
+			for j, topic := range topics {
+				vector[j] = float32(strings.Count(strings.ToLower(text), topic)) + 0.01
+			}
+			resp.Data = append(resp.Data, struct {
+				Index     int       `json:"index"`
+				Embedding []float32 `json:"embedding"`
+			}{Index: i, Embedding: vector})
+		}
+		embedded += len(req.Input)
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	return server, &embedded
+}
+
+func createTestLogger(t *testing.T) *logging.Logger {
+	t.Helper()
+	logger, err := logging.New(filepath.Join(t.TempDir(), "test.log"))
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	t.Cleanup(func() { _ = logger.Close() })
+	return logger
+}
+
+func staticDoc(path, content string, modifiedAt time.Time) Document {
+	return Document{Path: path, SizeBytes: int64(len(content)), ModifiedAt: modifiedAt, Load: func() (string, error) { return content, nil }}
+}
+
+func TestSyncAndSearch(t *testing.T) {
+	server, embedded := newTestServer(t)
+	defer server.Close()
+	svc := NewService(global.Embeddings{Endpoint: server.URL, Model: "test-model", BatchSize: 2}, t.TempDir(), createTestLogger(t))
+
+	collection := Collection{Source: global.EmbeddingsSourceProject, Name: "audit"}
+	modifiedAt := time.Now()
+	docs := []Document{
+		staticDoc("network.md", "The firewall allows all inbound traffic.\nFirewall rules are not reviewed.", modifiedAt),
+		staticDoc("identity.md", "Password rotation is not enforced.", modifiedAt),
+		staticDoc("dr.md", "Backups are tested yearly.", modifiedAt),
+	}
+
+	stats, err := svc.Sync(collection, docs)
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if stats.Indexed != 3 || stats.Chunks != 3 || *embedded != 3 {
+		t.Errorf("first sync: %+v, embedded %d", stats, *embedded)
+	}
+
+	matches, err := svc.Search("firewall configuration", []Collection{collection}, 2)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(matches) != 2 || matches[0].Path != "network.md" || matches[0].Name != "audit" || matches[0].Line != 1 {
+		t.Errorf("matches = %+v", matches)
+	}
+
+	// Unchanged files are not embedded again; removed files are dropped
+	*embedded = 0
+	stats, err = svc.Sync(collection, docs[:2])
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if stats.Unchanged != 2 || stats.Removed != 1 || stats.Indexed != 0 || *embedded != 0 {
+		t.Errorf("second sync: %+v, embedded %d", stats, *embedded)
+	}
+
+	// A changed file is re-embedded
+	docs[1] = staticDoc("identity.md", "Password rotation is enforced every 90 days.", modifiedAt.Add(time.Minute))
+	stats, err = svc.Sync(collection, docs[:2])
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if stats.Indexed != 1 || stats.Unchanged != 1 {
+		t.Errorf("third sync: %+v", stats)
+	}
+	matches, err = svc.Search("password", []Collection{collection, {Source: global.EmbeddingsSourceReference}}, 0)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(matches) != 2 || matches[0].Path != "identity.md" || !strings.Contains(matches[0].Text, "90 days") {
+		t.Errorf("matches after change = %+v", matches)
+	}
+}
+
+func TestChunkText(t *testing.T) {
+	text := strings.Repeat("line of text\n", 30) // 390 bytes
+	chunks := chunkText(text, 100, 20)
+	if len(chunks) < 4 {
+		t.Fatalf("got %d chunks, want at least 4", len(chunks))
+	}
+	if chunks[0].Line != 1 || chunks[1].Line <= 1 {
+		t.Errorf("chunk lines = %d, %d", chunks[0].Line, chunks[1].Line)
+	}
+	for i, c := range chunks {
+		if len(c.Text) > 100 {
+			t.Errorf("chunk %d is %d bytes", i, len(c.Text))
+		}
+		if i < len(chunks)-1 && !strings.HasSuffix(c.Text, "\n") {
+			t.Errorf("chunk %d does not end at a line break: %q", i, c.Text)
+		}
+	}
+	if last := chunks[len(chunks)-1]; !strings.HasSuffix(text, last.Text) {
+		t.Errorf("last chunk %q is not the end of the text", last.Text)
+	}
+
+	if chunks := chunkText("   \n\n", 100, 20); len(chunks) != 0 {
+		t.Errorf("blank text produced %d chunks", len(chunks))
+	}
+}
@@ -0,0 +1,33 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+// ApprovalGate selects the QA results of a task set that a human must sign off
+// with task_approve or task_reject before the task is done
+type ApprovalGate struct {
+	Escalate bool   `json:"escalate,omitempty"` // Hold tasks QA escalated
+	Severity string `json:"severity,omitempty"` // Hold tasks QA rated this severity or worse
+}
+
+// ValidateApprovalGate checks the approval settings of a task set
+func ValidateApprovalGate(gate *ApprovalGate) error {
+	if gate == nil {
+		return nil
+	}
+	return ValidateQASeverity(gate.Severity)
+}
+
+// Requires reports whether a QA result with this verdict and severity must be
+// approved. A nil gate requires nothing.
+func (g *ApprovalGate) Requires(verdict, severity string) bool {
+	if g == nil {
+		return false
+	}
+	if g.Escalate && verdict == QAVerdictEscalate {
+		return true
+	}
+	return g.Severity != "" && QASeverityAtLeast(severity, g.Severity)
+}
@@ -0,0 +1,34 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import "testing"
+
+func TestApprovalGateRequires(t *testing.T) {
+	tests := []struct {
+		gate              *ApprovalGate
+		verdict, severity string
+		want              bool
+	}{
+		{nil, "escalate", "critical", false},
+		{&ApprovalGate{Escalate: true}, "escalate", "", true},
+		{&ApprovalGate{Escalate: true}, "pass", "critical", false},
+		{&ApprovalGate{Severity: "high"}, "pass", "critical", true},
+		{&ApprovalGate{Severity: "high"}, "pass", "High", true},
+		{&ApprovalGate{Severity: "high"}, "pass", "medium", false},
+		{&ApprovalGate{Severity: "high"}, "escalate", "", false},
+		{&ApprovalGate{Escalate: true, Severity: "low"}, "pass", "low", true},
+	}
+	for _, tt := range tests {
+		if got := tt.gate.Requires(tt.verdict, tt.severity); got != tt.want {
+			t.Errorf("%+v.Requires(%q, %q) = %v, want %v", tt.gate, tt.verdict, tt.severity, got, tt.want)
+		}
+	}
+
+	if err := ValidateApprovalGate(&ApprovalGate{Severity: "severe"}); err == nil {
+		t.Error("ValidateApprovalGate accepted an invalid severity")
+	}
+}
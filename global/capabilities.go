@@ -0,0 +1,47 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Capability reports whether an optional subsystem is active in this Maestro
+// instance, and what depends on it
+type Capability struct {
+	Name     string   `json:"name"`
+	Active   bool     `json:"active"`
+	Detail   string   `json:"detail,omitempty"`   // How it is provided, or what to do to activate it
+	Features []string `json:"features,omitempty"` // What needs it
+}
+
+// SubsystemUnavailableError is returned when a feature needs an optional
+// subsystem that is not active, so that callers can report it as such rather
+// than as a failure of the feature itself
+type SubsystemUnavailableError struct {
+	Subsystem string `json:"subsystem"`
+	Feature   string `json:"feature"`          // What was attempted
+	Remedy    string `json:"remedy,omitempty"` // How to make it work
+}
+
+// Error describes the missing subsystem and the remedy
+func (e *SubsystemUnavailableError) Error() string {
+	msg := fmt.Sprintf("%s is not possible: the %s subsystem is not active in this Maestro instance", e.Feature, e.Subsystem)
+	if e.Remedy != "" {
+		msg += "; " + e.Remedy
+	}
+	return msg
+}
+
+// AsSubsystemUnavailable returns the SubsystemUnavailableError in err's chain, if any
+func AsSubsystemUnavailable(err error) (*SubsystemUnavailableError, bool) {
+	var unavailable *SubsystemUnavailableError
+	if errors.As(err, &unavailable) {
+		return unavailable, true
+	}
+	return nil, false
+}
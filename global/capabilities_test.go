@@ -0,0 +1,34 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSubsystemUnavailableError(t *testing.T) {
+	err := fmt.Errorf("failed to apply pipeline: %w", &SubsystemUnavailableError{
+		Subsystem: SubsystemPlaybooks,
+		Feature:   "loading pipeline pb/pipeline.json",
+		Remedy:    "create the runner with a playbooks service",
+	})
+
+	unavailable, ok := AsSubsystemUnavailable(err)
+	if !ok || unavailable.Subsystem != SubsystemPlaybooks {
+		t.Fatalf("AsSubsystemUnavailable(%v) = %v, %v", err, unavailable, ok)
+	}
+	for _, want := range []string{"loading pipeline pb/pipeline.json", "playbooks subsystem is not active", "create the runner with a playbooks service"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not contain %q", err.Error(), want)
+		}
+	}
+
+	if _, ok := AsSubsystemUnavailable(fmt.Errorf("not found")); ok {
+		t.Error("AsSubsystemUnavailable matched an unrelated error")
+	}
+}
@@ -0,0 +1,69 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"fmt"
+	"time"
+)
+
+// Clock provides the current time in the configured timezone and formats times
+// for project logs and reports. A nil Clock leaves times in their own location
+// (server local time for the current time) and uses the default formats.
+type Clock struct {
+	location *time.Location
+	formats  Timestamps
+}
+
+// NewClock builds a Clock from the configuration.
+// Returns an error if the timezone is unknown.
+func NewClock(cfg Timestamps) (*Clock, error) {
+	c := &Clock{location: time.Local, formats: cfg.WithDefaults()}
+	if cfg.Timezone != "" {
+		location, err := time.LoadLocation(cfg.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("unknown timezone %q: %w", cfg.Timezone, err)
+		}
+		c.location = location
+	}
+	return c, nil
+}
+
+// Now returns the current time in the configured timezone
+func (c *Clock) Now() time.Time {
+	return c.In(time.Now())
+}
+
+// In returns t in the configured timezone
+func (c *Clock) In(t time.Time) time.Time {
+	if c == nil {
+		return t
+	}
+	return t.In(c.location)
+}
+
+// Log formats t for project and task log entries
+func (c *Clock) Log(t time.Time) string {
+	return c.In(t).Format(c.timestamps().LogFormat)
+}
+
+// Report formats t as a date and time in reports
+func (c *Clock) Report(t time.Time) string {
+	return c.In(t).Format(c.timestamps().ReportFormat)
+}
+
+// Date formats t as a date in reports
+func (c *Clock) Date(t time.Time) string {
+	return c.In(t).Format(c.timestamps().DateFormat)
+}
+
+// timestamps returns the formats in use
+func (c *Clock) timestamps() Timestamps {
+	if c == nil {
+		return Timestamps{}.WithDefaults()
+	}
+	return c.formats
+}
@@ -0,0 +1,39 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClock(t *testing.T) {
+	c, err := NewClock(Timestamps{Timezone: "America/Toronto", DateFormat: "02 Jan 2006"})
+	if err != nil {
+		t.Fatalf("NewClock: %v", err)
+	}
+
+	ts := time.Date(2026, 1, 15, 14, 30, 0, 0, time.UTC)
+	if got := c.Log(ts); got != "2026-01-15T09:30:00-05:00" {
+		t.Errorf("Log = %q", got)
+	}
+	if got := c.Report(ts); got != "2026-01-15 09:30:00" {
+		t.Errorf("Report = %q", got)
+	}
+	if got := c.Date(ts); got != "15 Jan 2026" {
+		t.Errorf("Date = %q", got)
+	}
+
+	// A nil clock keeps the time's location and uses the default formats
+	var nilClock *Clock
+	if got := nilClock.Report(ts); got != "2026-01-15 14:30:00" {
+		t.Errorf("nil Report = %q", got)
+	}
+
+	if _, err := NewClock(Timestamps{Timezone: "Nowhere/Invalid"}); err == nil {
+		t.Error("expected an error for an unknown timezone")
+	}
+}
@@ -11,10 +11,14 @@ import "fmt"
 const (
 	// Configuration constants
 	ConfigEnvVar          = "MAESTRO_CONFIG"
+	FuzzErrorFilesEnvVar  = "MAESTRO_FUZZ_ERROR_FILES" // Directory of error files whose LLM responses seed the fuzz tests
 	DefaultBaseDir        = "~/.maestro"
 	DefaultConfigFileName = "config.json"
 	DefaultPlaybooksDir   = "playbooks"
 	DefaultProjectsDir    = "projects"
+	DefaultExportsDir     = "exports"
+	DefaultSharedDir      = "shared"
+	DefaultEmbeddingsDir  = "embeddings"
 
 	// Fixed category names
 	CategoryReference = "reference"
@@ -26,6 +30,16 @@ const (
 	ToolReferenceGet    = "reference_get"
 	ToolReferenceSearch = "reference_search"
 
+	// MCP Tool Names - Shared evidence (read-only to projects)
+	ToolSharedList   = "shared_list"
+	ToolSharedGet    = "shared_get"
+	ToolSharedSearch = "shared_search"
+	ToolSharedImport = "shared_import"
+	ToolSharedDelete = "shared_delete"
+
+	// MCP Tool Names - Semantic search (optional, requires an embeddings endpoint)
+	ToolSemanticSearch = "semantic_search"
+
 	// MCP Tool Names - Playbook
 	ToolPlaybookList       = "playbook_list"
 	ToolPlaybookCreate     = "playbook_create"
@@ -39,6 +53,11 @@ const (
 	ToolPlaybookFileRename = "playbook_file_rename"
 	ToolPlaybookFileDelete = "playbook_file_delete"
 	ToolPlaybookSearch     = "playbook_search"
+	ToolPlaybookExport     = "playbook_export"
+	ToolPlaybookImport     = "playbook_import"
+	ToolPlaybookHistory    = "playbook_history"
+	ToolPlaybookRestore    = "playbook_restore"
+	ToolPlaybookLint       = "playbook_lint"
 
 	// MCP Tool Names - Project
 	ToolProjectCreate      = "project_create"
@@ -47,6 +66,19 @@ const (
 	ToolProjectList        = "project_list"
 	ToolProjectRename      = "project_rename"
 	ToolProjectDelete      = "project_delete"
+	ToolProjectDashboard   = "project_dashboard"
+	ToolProjectCleanup     = "project_results_cleanup"
+	ToolProjectPrune       = "project_results_prune"
+	ToolProjectPurge       = "project_retention_purge"
+	ToolProjectDiff        = "project_diff"
+	ToolProjectTrends      = "project_trends"
+	ToolProjectTemplates   = "project_templates"
+	ToolProjectSnapshot    = "project_snapshot"
+	ToolProjectSnapshots   = "project_snapshot_list"
+	ToolProjectSnapshotDel = "project_snapshot_delete"
+	ToolProjectAudit       = "project_audit"
+	ToolProjectExport      = "project_export"
+	ToolProjectImport      = "project_import"
 	ToolProjectFileList    = "project_file_list"
 	ToolProjectFileGet     = "project_file_get"
 	ToolProjectFilePut     = "project_file_put"
@@ -56,6 +88,7 @@ const (
 	ToolProjectFileDelete  = "project_file_delete"
 	ToolProjectFileSearch  = "project_file_search"
 	ToolProjectFileConvert = "project_file_convert"
+	ToolProjectConvStatus  = "project_file_convert_status"
 	ToolProjectFileExtract = "project_file_extract"
 
 	// MCP Tool Names - Project Log
@@ -69,25 +102,40 @@ const (
 	ToolTaskSetUpdate = "taskset_update"
 	ToolTaskSetDelete = "taskset_delete"
 	ToolTaskSetReset  = "taskset_reset"
+	ToolTaskSetCopy   = "taskset_copy"
+	ToolPipelineApply = "pipeline_apply"
 
 	// MCP Tool Names - Tasks
-	ToolTaskCreate    = "task_create"
-	ToolTaskGet       = "task_get"
-	ToolTaskList      = "task_list"
-	ToolTaskUpdate    = "task_update"
-	ToolTaskDelete    = "task_delete"
-	ToolTaskRun       = "task_run"
-	ToolTaskStatus    = "task_status"
-	ToolTaskResults   = "task_results"
-	ToolTaskResultGet = "task_result_get"
-	ToolTaskReport    = "task_report"
-	ToolTaskDispatch  = "task_dispatch"
+	ToolTaskCreate             = "task_create"
+	ToolTaskCreateFromTemplate = "task_create_from_template"
+	ToolTaskCreateBulk         = "task_create_bulk"
+	ToolTaskCreateMapReduce    = "task_create_mapreduce"
+	ToolTaskGet                = "task_get"
+	ToolTaskList               = "task_list"
+	ToolTaskUpdate             = "task_update"
+	ToolTaskDelete             = "task_delete"
+	ToolTaskBulkStatus         = "task_bulk_update_status"
+	ToolTaskUpdateBulk         = "task_update_bulk"
+	ToolTaskEvidence           = "task_evidence_requests"
+	ToolTaskRun                = "task_run"
+	ToolTaskRunResume          = "task_run_resume"
+	ToolTaskStatus             = "task_status"
+	ToolTaskEvents             = "task_events"
+	ToolTaskInflight           = "task_inflight"
+	ToolTaskResults            = "task_results"
+	ToolTaskResultGet          = "task_result_get"
+	ToolTaskAttemptDiff        = "task_attempt_diff"
+	ToolTaskReport             = "task_report"
+	ToolTaskDispatch           = "task_dispatch"
 
 	// MCP Tool Names - Supervisor
 	ToolSupervisorUpdate = "supervisor_update"
+	ToolTaskApprove      = "task_approve"
+	ToolTaskReject       = "task_reject"
 
 	// MCP Tool Names - Report Generation
-	ToolReportCreate = "report_create"
+	ToolReportCreate        = "report_create"
+	ToolDeliverableGenerate = "deliverable_generate"
 
 	// MCP Tool Names - LLM
 	ToolLLMList     = "llm_list"
@@ -120,11 +168,15 @@ const (
 	ToolFileImport = "file_import"
 
 	// MCP Tool Names - Reports (read-only domain with controlled write)
-	ToolReportList   = "report_list"
-	ToolReportRead   = "report_read"
-	ToolReportStart  = "report_start"
-	ToolReportAppend = "report_append"
-	ToolReportEnd    = "report_end"
+	ToolReportList     = "report_list"
+	ToolReportRead     = "report_read"
+	ToolReportSearch   = "report_search"
+	ToolReportStart    = "report_start"
+	ToolReportAppend   = "report_append"
+	ToolReportEnd      = "report_end"
+	ToolReportSessions = "report_sessions"
+	ToolReportFinalize = "report_finalize"
+	ToolReportDebug    = "report_debug"
 
 	// MCP Tool Names - System
 	ToolHealth    = "health"
@@ -144,23 +196,79 @@ const (
 	TaskStatusCancelled  = "cancelled"
 
 	// Work/QA Execution Status Constants
-	ExecutionStatusWaiting    = "waiting"
-	ExecutionStatusProcessing = "processing"
-	ExecutionStatusRetry      = "retry"
-	ExecutionStatusFailed     = "failed"
-	ExecutionStatusError      = "error" // Schema validation or parsing errors (response saved for audit)
-	ExecutionStatusDone       = "done"
+	ExecutionStatusWaiting          = "waiting"
+	ExecutionStatusProcessing       = "processing"
+	ExecutionStatusRetry            = "retry"
+	ExecutionStatusFailed           = "failed"
+	ExecutionStatusError            = "error" // Schema validation or parsing errors (response saved for audit)
+	ExecutionStatusDone             = "done"
+	ExecutionStatusOnHold           = "on_hold"           // Parked by a planner; never picked up by the runner
+	ExecutionStatusAwaitingApproval = "awaiting_approval" // QA result held for human sign-off (task_approve/task_reject)
 
 	// QA Verdict Constants (standardized values for all playbooks)
 	QAVerdictPass     = "pass"     // Work is acceptable, no further action
 	QAVerdictFail     = "fail"     // Work needs revision, send back to worker
 	QAVerdictEscalate = "escalate" // Cannot be resolved by QA, flag for escalation
 
+	// Approval decisions recorded in task history
+	ApprovalDecisionApproved = "approved"
+	ApprovalDecisionRejected = "rejected"
+
+	// Supervisor edit modes (supervisor_update)
+	SupervisorEditReplace = "replace" // The response was replaced wholesale
+	SupervisorEditPatch   = "patch"   // A JSON merge patch was applied to the response
+
+	// QA Severity Constants (optional "severity" field of QA responses, most severe first)
+	QASeverityCritical = "critical"
+	QASeverityHigh     = "high"
+	QASeverityMedium   = "medium"
+	QASeverityLow      = "low"
+
+	// QA Skip Rule Condition Operators
+	ConditionOpEquals    = "equals"
+	ConditionOpNotEquals = "not_equals"
+	ConditionOpIn        = "in"
+	ConditionOpExists    = "exists"
+
 	// Path Constants
 	MaxTaskPathDepth  = 3
 	TaskPathSeparator = "/"
 	ListPathSeparator = "__" // Double underscore to avoid conflict with hyphens in path segment names
 
+	// ErrorCodeUnknownParameters is returned in strict mode for unrecognized tool arguments
+	ErrorCodeUnknownParameters = "unknown_parameters"
+
+	// ErrorCodePromptTooLarge fails a task whose required prompt sections exceed the LLM's context window
+	ErrorCodePromptTooLarge = "prompt_too_large"
+
+	// ErrorCodeLLMNotAllowed fails a task whose LLM is not in its project's allowed_llms
+	ErrorCodeLLMNotAllowed = "llm_not_allowed"
+
+	// ErrorCodeApprovalRejected marks a task whose QA result was rejected by its approver
+	ErrorCodeApprovalRejected = "approval_rejected"
+
+	// ErrorCodeSubsystemUnavailable is returned when a feature needs an optional subsystem that is not active
+	ErrorCodeSubsystemUnavailable = "subsystem_unavailable"
+
+	// Optional subsystems, reported by health under capabilities
+	SubsystemProjects   = "projects"   // Project metadata, files, reports and logs
+	SubsystemPlaybooks  = "playbooks"  // Playbook instructions, templates and pipelines
+	SubsystemReference  = "reference"  // Read-only reference documents
+	SubsystemShared     = "shared"     // Shared evidence library
+	SubsystemLibrary    = "library"    // Context injection (context_keys)
+	SubsystemLLM        = "llm"        // Maestro's own LLM dispatch and the LLM tools
+	SubsystemEmbeddings = "embeddings" // Semantic search
+
+	// Prompt Fitting Constants (context_window of LLMs)
+	PromptCharsPerToken    = 4   // Token estimate for prompts; conservative for English text and JSON
+	MinTruncatedPromptText = 400 // Shorter remains of a truncated section are dropped instead
+
+	// MaxExternalIDLength limits caller-assigned task external IDs
+	MaxExternalIDLength = 128
+
+	// MaxBulkTasks limits the tasks created by one task_create_bulk call
+	MaxBulkTasks = 500
+
 	// Response Format Constants
 	ResponseFormatText = "text"
 	ResponseFormatJSON = "json"
@@ -168,16 +276,324 @@ const (
 	// File Constants
 	ProjectFileName = "project.json"
 	ProjectLogName  = "log.txt"
+	DashboardFile   = "dashboard.json"
+	TrendsFile      = "trends.jsonl"
+	AuditFile       = "audit.jsonl"
+	EventsFile      = "events.jsonl"
 	MetaSuffix      = ".meta.json"
 	ListsDir        = "lists"
 	TasksDir        = "tasks"
 	FilesDir        = "files"
 	LogsDir         = "logs"
 	ReportsDir      = "reports"
+	InternalDir     = "internal"  // Maestro bookkeeping, such as run journals
+	JournalDir      = "journal"   // internal/journal/<run id>.jsonl
+	SnapshotsDir    = "snapshots" // snapshots/<snapshot id>/ (read-only views of files, results, reports and tasks)
+
+	// Snapshot Constants
+	SnapshotManifestFile = "snapshot.json"
+	SnapshotIDFormat     = "20060102-150405" // Snapshot IDs are creation times, with -2, -3... on collision
+
+	// Result file suffixes (results/<uuid>.json, results/<uuid>-error.json)
+	ResultFileSuffix = ".json"
+	ErrorFileSuffix  = "-error.json"
+
+	// Results Layout Constants
+	ResultsLayoutFlat        = "flat"        // results/<uuid>.json (default)
+	ResultsLayoutPartitioned = "partitioned" // results/<taskset path>/<yyyymm>/<uuid>.json
+
+	// Results Cleanup Constants (orphaned error and partial files)
+	OrphanedResultsDir        = "_orphaned" // results/_orphaned/<timestamp>/ (cannot collide with task set paths)
+	PartialFileSuffix         = ".tmp"      // Left behind by an interrupted atomic write
+	CleanupActionDelete       = "delete"
+	CleanupActionArchive      = "archive"
+	CleanupReasonSucceeded    = "task_succeeded"
+	CleanupReasonDeleted      = "task_deleted"
+	CleanupReasonPartial      = "partial_write"
+	DefaultCleanupMinAgeHours = 24
+
+	// LLM availability probe constants
+	LLMProbeHistoryHours = 24 // Probe records kept per LLM for availability statistics
+
+	// Results Retention Constants (pruning old result files)
+	ResultsIndexFile        = "_index.json" // results/_index.json: summaries of pruned files
+	RetentionActionCompact  = "compact"     // Remove full prompts and raw LLM output from result files
+	RetentionActionDelete   = "delete"
+	RetentionReasonMaxAge   = "max_age"
+	RetentionReasonPerTask  = "max_per_task"
+	RetentionReasonMaxTotal = "max_total_size"
+
+	// Project Retention Constants (purging project data after the project is done)
+	RetentionActionAnonymize = "anonymize" // Replace personal identifiers, keep everything else
+	RetentionScopeResults    = "results"   // Result, error and archived files
+	RetentionScopeLogs       = "logs"      // Project and task logs
+	RetentionScopeFiles      = "files"     // Project files
+
+	// Template Registry Constants (schemas and templates referenced by task sets)
+	RegistryKindWorkerSchema   = "worker_response_schema"
+	RegistryKindQASchema       = "qa_response_schema"
+	RegistryKindReportTemplate = "report_template"
+	RegistryKindReportManifest = "report_manifest"
+	RegistryKindReportInclude  = "report_include" // layouts and partials of report templates
+	RegistrySourceProject      = "project"
+	RegistrySourcePlaybook     = "playbook"
+	RegistrySourceInline       = "inline"  // schema stored in the task set itself
+	RegistrySourceMissing      = "missing" // referenced but not found
+
+	// Audit Trail Constants (audit.jsonl outcomes)
+	AuditOutcomeOK    = "ok"
+	AuditOutcomeError = "error"
+	DefaultAuditLimit = 100
+
+	// Task Event Constants (events.jsonl progress events)
+	EventTaskStarted      = "task_started"
+	EventLLMDispatched    = "llm_dispatched"
+	EventValidationFailed = "validation_failed"
+	EventQAStarted        = "qa_started"
+	EventQASkipped        = "qa_skipped"
+	EventTaskFinished     = "task_finished"
+	DefaultEventsLimit    = 100
+
+	// Run Journal Constants (write-ahead record of in-flight tasks)
+	JournalRunStarted   = "run_started"
+	JournalTaskStarted  = "task_started"
+	JournalTaskEnded    = "task_ended"
+	JournalKindRun      = "run"
+	JournalKindDispatch = "dispatch"
+	ResumeActionResumed = "resumed" // Run started again with the same request
+	ResumeActionFailed  = "failed"  // Dispatch task marked failed (dispatches are single-shot)
+	ResumeActionPending = "pending" // Not resumed yet (dry run, or another run is in progress)
+
+	// Report Language Constants (confidence-weighted phrasing)
+	DefaultConfidenceField  = "confidence"
+	DefaultConfidencePhrase = "indicates"
+
+	// Report Attribution Constants (AI-disclosure marker; {model} and {date} are replaced)
+	DefaultAttributionFormat = "*AI-generated content ({model}, {date})*"
+
+	// Redaction Constants (secret masking in logs and result files)
+	DefaultRedactionReplacement = "[REDACTED]"
+	MinRedactedSecretLength     = 8 // Shorter environment values are too likely to occur in normal text
+
+	// PII Detection Constants (identifier types reported in results)
+	PIITypeEmail      = "email"
+	PIITypeSSN        = "ssn"         // US Social Security number
+	PIITypeSIN        = "sin"         // Canadian Social Insurance Number (Luhn checked)
+	PIITypeCreditCard = "credit_card" // Payment card number (Luhn checked)
+	PIITypeIBAN       = "iban"        // International bank account number (mod-97 checked)
+	PIITypePhone      = "phone"
+	MaxPIISamples     = 3 // Masked examples kept per identifier type
+
+	// Prompt Redaction Constants (values replaced with placeholders before dispatch)
+	RedactionTypeSecret  = "secret" // API keys and tokens matched by the built-in redaction patterns
+	HistoryTypeRedaction = "redaction"
+
+	// Generation Parameter Constants (command LLM args; an arg with an unset parameter is omitted)
+	PlaceholderTemperature = "{{TEMPERATURE}}"
+	PlaceholderTopP        = "{{TOP_P}}"
+	PlaceholderMaxTokens   = "{{MAX_TOKENS}}"
+
+	// Structured Output Constants (command LLM args of LLMs with structured_output)
+	PlaceholderSchema     = "{{SCHEMA}}"      // the response schema JSON
+	PlaceholderSchemaFile = "{{SCHEMA_FILE}}" // path of a temporary file holding the response schema
+	SchemaFilePattern     = "maestro-schema-*.json"
+
+	// Conversation Constants (message roles in dispatch requests; resume_args of command LLMs)
+	MessageRoleSystem    = "system"
+	MessageRoleUser      = "user"
+	MessageRoleAssistant = "assistant"
+	PlaceholderSessionID = "{{SESSION_ID}}" // the provider session continued by resume_args
+
+	// Dispatch Option Constants (per-call options; command LLM args, an arg with an unset option is omitted)
+	DispatchPriorityLow     = "low"
+	DispatchPriorityNormal  = "normal"
+	DispatchPriorityHigh    = "high"
+	CacheControlEnabled     = "enabled"  // ask the provider to cache the prompt
+	CacheControlDisabled    = "disabled" // bypass the provider's prompt cache
+	PlaceholderPriority     = "{{PRIORITY}}"
+	PlaceholderCacheControl = "{{CACHE_CONTROL}}"
+	PlaceholderLabel        = "{{LABEL}}"
+
+	// LLM Secret Constants
+	PlaceholderAPIKey    = "{{API_KEY}}" // command LLM args and env of LLMs with key_file or key_command
+	SecretCommandTimeout = 30            // Seconds a key_command may run at startup
+
+	// LLM Warm-up Constants
+	PlaceholderKeepAlive = "{{KEEP_ALIVE}}"                // command LLM args of LLMs with warmup.keep_alive
+	DefaultLLMTestPrompt = "Respond with only the word OK" // test, probe and priming calls
+
+	// Timestamp Constants (Go time layouts)
+	DefaultLogTimestampFormat    = "2006-01-02T15:04:05Z07:00" // RFC 3339
+	DefaultReportTimestampFormat = "2006-01-02 15:04:05"
+	DefaultReportDateFormat      = "2006-01-02"
+	ReportPrefixDateFormat       = "20060102" // {date} of report file prefixes; fixed so names stay sortable and filename-safe
+	ReportPrefixTimeFormat       = "1504"     // {time} of report file prefixes
+
+	// Webhook Constants
+	WebhookEventRunCompleted     = "run_completed"
+	WebhookEventTaskEscalated    = "task_escalated"
+	WebhookEventBudgetExceeded   = "budget_exceeded"
+	WebhookEventHeader           = "X-Maestro-Event"
+	WebhookSignatureHeader       = "X-Maestro-Signature" // "sha256=" + hex HMAC-SHA256 of the body
+	DefaultWebhookTimeoutSeconds = 10
+
+	// Embeddings Constants (semantic_search index)
+	EmbeddingsSourceProject         = "project"
+	EmbeddingsSourcePlaybook        = "playbook"
+	EmbeddingsSourceReference       = "reference"
+	DefaultEmbeddingsChunkSize      = 2000 // Characters per chunk
+	DefaultEmbeddingsChunkOverlap   = 200  // Characters repeated at the start of the next chunk
+	DefaultEmbeddingsBatchSize      = 32   // Chunks per embeddings request
+	DefaultEmbeddingsMaxFileKB      = 1024 // Larger files are not indexed
+	DefaultEmbeddingsTimeoutSeconds = 60
+	DefaultSemanticSearchLimit      = 10
+
+	// Conversion Constants (project_file_convert)
+	DefaultConversionConcurrency   = 4
+	MaxConversionConcurrency       = 16
+	MaxConversionJobs              = 50 // Completed jobs kept for project_file_convert_status
+	ConversionStatusRunning        = "running"
+	ConversionStatusCompleted      = "completed"
+	ConversionFilePending          = "pending"
+	ConversionFileConverting       = "converting"
+	ConversionFileConverted        = "converted"
+	ConversionFileSkipped          = "skipped"
+	ConversionFileFailed           = "failed"
+	ConversionMethodText           = "text"    // Built-in text extraction
+	ConversionMethodCommand        = "command" // External converter
+	ConversionInputPlaceholder     = "{{INPUT}}"
+	ConversionOutputPlaceholder    = "{{OUTPUT}}"
+	DefaultConversionMinChars      = 50  // Characters per page
+	DefaultConversionMaxEmptyRatio = 0.5 // Share of pages without text
+	DefaultConversionTimeout       = 300 // Seconds for a command step
+
+	// Project Diff Constants
+	DefaultDiffKeyField      = "item_id"         // Response field identifying a finding when the task has no external_id
+	DefaultDiffCompareFields = "severity,status" // Response fields compared between baseline and current findings
+	DiffMatchKey             = "key"             // Align findings by external ID, key field or title
+	DiffMatchTitle           = "title"           // Align findings by task title and type
+
+	// Attempt Diff Constants
+	MaxAttemptDiffLines = 500 // Most lines reported by a text attempt diff
+
+	// Worker Tool Constants (agentic worker mode)
+	DefaultWorkerToolCalls   = 10    // Tool calls a worker may make per task when max_calls is not set
+	MaxWorkerToolCallsLimit  = 50    // Most tool calls max_calls allows
+	MaxWorkerToolOutputBytes = 20000 // Longest tool result sent back to the worker
+
+	// Evidence Request Constants
+	MissingEvidenceField = "missing_evidence" // Standard worker/QA response field listing evidence that was not provided
+	EvidenceHoldReason   = "awaiting evidence"
+
+	// Evidence File Constants (evidence_files attached to worker prompts)
+	MaxEvidencePatterns = 20    // Most evidence_files entries per task
+	MaxEvidenceFiles    = 50    // Most files evidence_files attaches to one prompt
+	EvidenceChunkBytes  = 16000 // Evidence files are inlined in chunks of at most this size
+
+	// Map-Reduce Constants (task_create_mapreduce)
+	MinMapReduceChunkBytes = 1000 // Smallest chunk_size accepted
+	MaxMapReduceChunks     = 200  // Most map tasks one call creates
+	MapReduceChunkDir      = "mapreduce"
+
+	// ReportIndexSuffix names the per-run index of generated reports (<prefix>Index.md)
+	ReportIndexSuffix = "Index"
+
+	// Generated Report Block Constants. Report generation wraps each part it writes
+	// in marker comments, so a later run can find, skip or replace it.
+	ReportBlockBegin     = "<!-- maestro:begin %s %s -->" // key, content hash
+	ReportBlockEnd       = "<!-- maestro:end %s -->"      // key
+	ReportBlockOverview  = "overview"                     // summary, trends and aggregate; "overview:<path>" for filtered runs
+	ReportBlockTaskSet   = "taskset:"                     // + task set path
+	ReportBlockTask      = "task:"                        // + task UUID
+	ReportBlockHashBytes = 8                              // SHA-256 bytes kept in the hash
+
+	// Report Output Format Constants (task_report format, report_create formats, manifest formats)
+	ReportFormatMarkdown    = "markdown"
+	ReportFormatJSON        = "json"
+	ReportFormatHTML        = "html"
+	ReportFormatPDF         = "pdf"
+	DefaultReportPDFTimeout = 120 // Seconds for report_output.pdf_command
+
+	// Report Prefix Constants (report_prefix config)
+	DefaultReportPrefixFormat      = "{date}-{time}-{title}-"
+	ReportPrefixCollisionIncrement = "increment" // Add -2, -3, ... to a prefix already used
+	ReportPrefixCollisionRefuse    = "refuse"    // Fail to start the session
+
+	// Report Archive Constants (reports/archive/<prefix>/)
+	ReportArchiveDir           = "archive"
+	ReportArchiveManifestFile  = "MANIFEST.json"
+	ReportArchiveChecksumsFile = "SHA256SUMS"
+
+	// Export Archive Constants (<exports_dir>/<name>-<timestamp>.zip|.tar.gz)
+	ExportFormatZip        = "zip"
+	ExportFormatTarGz      = "tar.gz"
+	ExportManifestFile     = "MAESTRO_EXPORT.json"
+	ExportFormatVersion    = 1
+	ExportKindProject      = "project"
+	ExportKindPlaybook     = "playbook"
+	MaxArchiveExtractBytes = 4 << 30         // Largest total size an imported archive may expand to
+	AnonymizationMapSuffix = ".mapping.json" // <archive name without extension>.mapping.json, beside the archive
+
+	// Playbook Version Constants (<playbook>/.versions/history.json, .versions/<version>/<path>)
+	PlaybookVersionsDir   = ".versions"
+	PlaybookHistoryFile   = "history.json"
+	PlaybookChangePut     = "put"
+	PlaybookChangeAppend  = "append"
+	PlaybookChangeEdit    = "edit"
+	PlaybookChangeRename  = "rename"
+	PlaybookChangeDelete  = "delete"
+	PlaybookChangeRestore = "restore"
+
+	// Health Status Constants (per-subsystem status reported by the health tool)
+	HealthStatusOK       = "ok"
+	HealthStatusDegraded = "degraded"
+	HealthStatusCritical = "critical"
+	HealthCodeOK         = 0 // Monitoring exit-code convention: 0 OK, 1 warning, 2 critical
+	HealthCodeDegraded   = 1
+	HealthCodeCritical   = 2
+
+	// Playbook Lint Constants (structural checks run by playbook_lint)
+	LintCheckStructure        = "structure"         // Expected files and directories are present
+	LintCheckSchemas          = "schemas"           // schemas/*.json parse as JSON Schema
+	LintCheckInstructions     = "instructions_size" // instructions/ files are within the size threshold
+	LintCheckListTemplates    = "list_templates"    // Templates referenced by lists/*.json exist
+	LintCheckLinks            = "links"             // Relative Markdown links resolve
+	LintSeverityError         = "error"
+	LintSeverityWarning       = "warning"
+	LintErrorPenalty          = 15 // Score points lost per error
+	LintWarningPenalty        = 5  // Score points lost per warning
+	DefaultLintInstructionsKB = 64 // Instructions files larger than this are flagged
+
+	// Result Summaries
+	DefaultResultSummaryChars = 200  // Length of a result summary when the task set sets none
+	MaxResultSummaryChars     = 2000 // Upper bound on result_summary.max_chars
+	ResultSummaryPrompt       = "Summarize the following task output in at most %d characters, for an orchestrator deciding what to do next. Reply with the summary only.\n\n"
+
+	// Pipelines
+	DefaultPipelineFile     = "pipeline.json" // Pipeline file read from a playbook when none is named
+	PipelineActionCreated   = "created"
+	PipelineActionUpdated   = "updated"
+	PipelineActionUnchanged = "unchanged"
+	PipelineActionDeleted   = "deleted"
 
 	// List Schema Version
 	ListSchemaVersion = "1.0"
 
+	// List Sampling Constants
+	SampleMethodRandom     = "random"
+	SampleMethodStratified = "stratified" // Proportional random sample within each stratum
+	SampleMethodSystematic = "systematic" // Every k-th item from a random start
+	StratifyByTag          = "tag"        // Stratum is the item's first tag
+	StratifyBySection      = "section"
+	StratifyBySourceDoc    = "source_doc"
+	SampleStratumNone      = "(none)"  // Stratum of items without a value
+	MaxSampleSeed          = 1<<53 - 1 // Largest seed that survives a round trip through a JSON number
+
+	// List Combination Constants (list_create_tasks with several lists)
+	ListCombineConcatenate  = "concatenate"   // One task per item of each list in turn
+	ListCombineCrossProduct = "cross_product" // One task per combination of one item from each list
+	MaxListCombinations     = 10000           // Most tasks a cross product may create
+
 	// Default Values
 	DefaultLimit            = 50
 	DefaultLogLimit         = 100
@@ -185,6 +601,7 @@ const (
 	DefaultTimeout          = 1800       // seconds
 	MinTimeout              = 60         // seconds
 	MaxTimeout              = 7200       // seconds
+	DefaultStderrTailKB     = 16         // KB of LLM stderr kept by the "tail" stderr policy
 
 	// Limits: Infrastructure Retries (network failures, command timeouts - no LLM cost)
 	DefaultMaxRetries = 3  // Default retries for infrastructure failures
@@ -202,9 +619,24 @@ const (
 	DefaultMaxConcurrent     = 5
 	DefaultMaxRounds         = 5 // Max retry rounds per run
 	DefaultRetryDelaySeconds = 60
+	DefaultMinFreeDiskMB     = 100 // Free disk space required to start a run
 	DefaultRateLimitRequests = 10
 	DefaultRateLimitPeriod   = 60
 
+	// Task Priority: tasks with higher priorities run first
+	MaxTaskPriority = 100
+
+	// Task Priority Tie-Break Policies (runner.priority_tie_break)
+	PriorityTieBreakID       = "id"       // Equal priorities keep task set order, then task ID (default)
+	PriorityTieBreakAttempts = "attempts" // Equal priorities run the task with the fewest worker attempts first
+
+	// Disclaimer Policies (runner.disclaimer_policy): what applies to a project
+	// that sets no disclaimer_template
+	DisclaimerPolicyRequired = "required" // Projects must set a disclaimer_template or "none"
+	DisclaimerPolicyOptional = "optional" // No disclaimer
+	DisclaimerPolicyDefault  = "default"  // runner.default_disclaimer_template
+	DisclaimerNone           = "none"     // disclaimer_template of a project without a disclaimer
+
 	// Project Name Constraints
 	DefaultProjectNameMaxLen = 64
 
@@ -215,8 +647,17 @@ const (
 	LogLevelError = "ERROR"
 	LogLevelFatal = "FATAL"
 
+	// Log Formats
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+
 	// API Key Prefix
 	EnvKeyPrefix = "env:"
+
+	// Import Policy Constants
+	DefaultImportMaxFileSizeMB     = 512                                                      // Largest file an import brings in
+	DefaultImportMaxTotalSizeMB    = 4096                                                     // Largest total of one import or extraction
+	DefaultImportBlockedExtensions = ".vmdk,.vdi,.vhd,.vhdx,.qcow2,.ova,.iso,.img,.dmg,.vmem" // Disk, VM and memory images
 )
 
 // ValidateTimeout validates and normalizes a timeout value.
@@ -282,3 +723,12 @@ func ValidateMaxRetries(maxRetries int) (int, error) {
 	}
 	return maxRetries, nil
 }
+
+// ValidateTaskPriority checks that a task priority is between 0 (the default)
+// and MaxTaskPriority
+func ValidateTaskPriority(priority int) error {
+	if priority < 0 || priority > MaxTaskPriority {
+		return fmt.Errorf("priority must be between 0 and %d", MaxTaskPriority)
+	}
+	return nil
+}
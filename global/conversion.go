@@ -0,0 +1,112 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Conversion configures the conversion of documents (PDF, DOCX, XLSX) to Markdown.
+// Each file goes through the steps of the pipeline in order until one produces
+// output that meets the quality thresholds, so a fallback such as OCR can follow
+// text extraction. When none does, the output with the most text is kept and
+// flagged as low quality.
+type Conversion struct {
+	Concurrency       int              `json:"concurrency,omitempty"`          // Files converted at once (default: 4)
+	Pipeline          []ConversionStep `json:"pipeline,omitempty"`             // Conversion steps (default: text extraction only)
+	MinCharsPerPage   int              `json:"min_chars_per_page,omitempty"`   // Less text per page (or per file without pages) is low quality (default: 50)
+	MaxEmptyPageRatio float64          `json:"max_empty_page_ratio,omitempty"` // A larger share of pages without text is low quality (default: 0.5)
+}
+
+// ConversionStep is one converter of a conversion pipeline. The "text" method
+// extracts text with the built-in converter. The "command" method runs an
+// external converter, such as an OCR tool or a converter for a proprietary
+// format, with {{INPUT}} in its args replaced by the file to convert and
+// {{OUTPUT}} by the Markdown file to write; without {{OUTPUT}} the command's
+// standard output is the Markdown. A step converts the files matching its
+// extensions or MIME types; without either, it converts the formats the
+// built-in converter supports.
+type ConversionStep struct {
+	Name           string   `json:"name"`
+	Method         string   `json:"method"` // text or command
+	Command        string   `json:"command,omitempty"`
+	Args           []string `json:"args,omitempty"`
+	Extensions     []string `json:"extensions,omitempty"`      // File extensions the step converts
+	MimeTypes      []string `json:"mime_types,omitempty"`      // MIME types the step converts, e.g. "image/*"
+	TimeoutSeconds int      `json:"timeout_seconds,omitempty"` // Command timeout (default: 300)
+}
+
+// WithDefaults returns a copy of Conversion with defaults applied for zero values
+func (c Conversion) WithDefaults() Conversion {
+	result := c
+	if result.Concurrency <= 0 {
+		result.Concurrency = DefaultConversionConcurrency
+	}
+	if len(result.Pipeline) == 0 {
+		result.Pipeline = []ConversionStep{{Name: ConversionMethodText, Method: ConversionMethodText}}
+	}
+	if result.MinCharsPerPage <= 0 {
+		result.MinCharsPerPage = DefaultConversionMinChars
+	}
+	if result.MaxEmptyPageRatio <= 0 {
+		result.MaxEmptyPageRatio = DefaultConversionMaxEmptyRatio
+	}
+	return result
+}
+
+// Validate checks the conversion settings
+func (c Conversion) Validate() error {
+	if c.Concurrency < 0 || c.Concurrency > MaxConversionConcurrency {
+		return fmt.Errorf("invalid conversion.concurrency %d (must be 1 to %d)", c.Concurrency, MaxConversionConcurrency)
+	}
+	if c.MinCharsPerPage < 0 {
+		return fmt.Errorf("invalid conversion.min_chars_per_page %d (must not be negative)", c.MinCharsPerPage)
+	}
+	if c.MaxEmptyPageRatio < 0 || c.MaxEmptyPageRatio > 1 {
+		return fmt.Errorf("invalid conversion.max_empty_page_ratio %g (must be 0 to 1)", c.MaxEmptyPageRatio)
+	}
+	names := make(map[string]bool, len(c.Pipeline))
+	for i, step := range c.Pipeline {
+		if step.Name == "" {
+			return fmt.Errorf("conversion.pipeline[%d]: name is required", i)
+		}
+		if names[step.Name] {
+			return fmt.Errorf("conversion.pipeline: step name %s is used more than once", step.Name)
+		}
+		names[step.Name] = true
+		switch step.Method {
+		case ConversionMethodText:
+		case ConversionMethodCommand:
+			if step.Command == "" {
+				return fmt.Errorf("conversion step %s: command is required", step.Name)
+			}
+			hasInput := false
+			for _, arg := range step.Args {
+				hasInput = hasInput || strings.Contains(arg, ConversionInputPlaceholder)
+			}
+			if !hasInput {
+				return fmt.Errorf("conversion step %s: args must contain %s", step.Name, ConversionInputPlaceholder)
+			}
+		default:
+			return fmt.Errorf("conversion step %s: invalid method '%s' (must be %s or %s)", step.Name, step.Method, ConversionMethodText, ConversionMethodCommand)
+		}
+		if step.TimeoutSeconds < 0 {
+			return fmt.Errorf("conversion step %s: timeout_seconds must not be negative", step.Name)
+		}
+		for _, ext := range step.Extensions {
+			if !strings.HasPrefix(ext, ".") {
+				return fmt.Errorf("conversion step %s: extension %q must start with '.'", step.Name, ext)
+			}
+		}
+		for _, mimeType := range step.MimeTypes {
+			if major, minor, ok := strings.Cut(mimeType, "/"); !ok || major == "" || minor == "" {
+				return fmt.Errorf("conversion step %s: invalid MIME type %q (e.g. application/pdf or image/*)", step.Name, mimeType)
+			}
+		}
+	}
+	return nil
+}
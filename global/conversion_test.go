@@ -0,0 +1,41 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import "testing"
+
+func TestConversionValidate(t *testing.T) {
+	ocr := ConversionStep{Name: "ocr", Method: ConversionMethodCommand, Command: "ocr", Args: []string{ConversionInputPlaceholder}, Extensions: []string{".pdf"}}
+	tests := []struct {
+		name    string
+		conv    Conversion
+		wantErr bool
+	}{
+		{"empty", Conversion{}, false},
+		{"pipeline", Conversion{Pipeline: []ConversionStep{{Name: "text", Method: ConversionMethodText}, ocr}, MaxEmptyPageRatio: 0.2}, false},
+		{"concurrency", Conversion{Concurrency: MaxConversionConcurrency + 1}, true},
+		{"ratio", Conversion{MaxEmptyPageRatio: 1.5}, true},
+		{"min chars", Conversion{MinCharsPerPage: -1}, true},
+		{"no name", Conversion{Pipeline: []ConversionStep{{Method: ConversionMethodText}}}, true},
+		{"duplicate name", Conversion{Pipeline: []ConversionStep{ocr, ocr}}, true},
+		{"method", Conversion{Pipeline: []ConversionStep{{Name: "x", Method: "magic"}}}, true},
+		{"no command", Conversion{Pipeline: []ConversionStep{{Name: "x", Method: ConversionMethodCommand, Args: []string{ConversionInputPlaceholder}}}}, true},
+		{"no input", Conversion{Pipeline: []ConversionStep{{Name: "x", Method: ConversionMethodCommand, Command: "ocr"}}}, true},
+		{"extension", Conversion{Pipeline: []ConversionStep{{Name: "x", Method: ConversionMethodText, Extensions: []string{"pdf"}}}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.conv.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	defaults := Conversion{}.WithDefaults()
+	if len(defaults.Pipeline) != 1 || defaults.Pipeline[0].Method != ConversionMethodText || defaults.MinCharsPerPage != DefaultConversionMinChars {
+		t.Errorf("WithDefaults() = %+v", defaults)
+	}
+}
@@ -0,0 +1,296 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ArchiveFormat returns the archive format implied by a file name, or an empty
+// string if it is neither a zip nor a gzipped tar file
+func ArchiveFormat(name string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".zip"):
+		return ExportFormatZip
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return ExportFormatTarGz
+	}
+	return ""
+}
+
+// WriteDirArchive writes the regular files under srcDir to a zip or gzipped tar
+// archive (chosen by the archive file name). Once the files are written, manifest
+// is called with their paths, sizes and checksums and its result is stored last
+// under manifestName. Symbolic links and other special files are not included,
+// and skip, if set, excludes files and directories by slash-separated relative
+// path. The archive is written to a temporary file and renamed into place.
+// Returns the files archived, not counting the manifest.
+func WriteDirArchive(srcDir, archivePath, manifestName string, manifest func(files []ExportFile) ([]byte, error), skip func(rel string, isDir bool) bool) ([]ExportFile, error) {
+	format := ArchiveFormat(archivePath)
+	if format == "" {
+		return nil, fmt.Errorf("unsupported archive format: %s (use .zip or .tar.gz)", filepath.Base(archivePath))
+	}
+	if err := os.MkdirAll(filepath.Dir(archivePath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	tmpPath := archivePath + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create archive: %w", err)
+	}
+	fail := func(err error) ([]ExportFile, error) {
+		_ = f.Close()
+		_ = os.Remove(tmpPath)
+		return nil, err
+	}
+
+	var add func(name string, modTime time.Time, size int64, r io.Reader) error
+	var finish func() error
+	if format == ExportFormatZip {
+		zw := zip.NewWriter(f)
+		add = func(name string, modTime time.Time, _ int64, r io.Reader) error {
+			w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modTime})
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(w, r)
+			return err
+		}
+		finish = zw.Close
+	} else {
+		gw := gzip.NewWriter(f)
+		tw := tar.NewWriter(gw)
+		add = func(name string, modTime time.Time, size int64, r io.Reader) error {
+			if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: size, ModTime: modTime, Typeflag: tar.TypeReg}); err != nil {
+				return err
+			}
+			_, err := io.Copy(tw, r)
+			return err
+		}
+		finish = func() error {
+			if err := tw.Close(); err != nil {
+				return err
+			}
+			return gw.Close()
+		}
+	}
+
+	files := []ExportFile{}
+	walkErr := filepath.WalkDir(srcDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == srcDir {
+			return nil
+		}
+		rel, err := filepath.Rel(srcDir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if skip != nil && skip(rel, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() || !d.Type().IsRegular() || rel == manifestName {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		src, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = src.Close() }()
+		hash := sha256.New()
+		if err := add(rel, info.ModTime(), info.Size(), io.TeeReader(src, hash)); err != nil {
+			return fmt.Errorf("failed to archive %s: %w", rel, err)
+		}
+		files = append(files, ExportFile{Path: rel, Size: info.Size(), SHA256: hex.EncodeToString(hash.Sum(nil))})
+		return nil
+	})
+	if walkErr != nil {
+		return fail(walkErr)
+	}
+
+	data, err := manifest(files)
+	if err != nil {
+		return fail(fmt.Errorf("failed to build manifest: %w", err))
+	}
+	if err := add(manifestName, time.Now(), int64(len(data)), strings.NewReader(string(data))); err != nil {
+		return fail(fmt.Errorf("failed to write manifest: %w", err))
+	}
+
+	if err := finish(); err != nil {
+		return fail(fmt.Errorf("failed to write archive: %w", err))
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := os.Rename(tmpPath, archivePath); err != nil {
+		_ = os.Remove(tmpPath)
+		return nil, fmt.Errorf("failed to save archive: %w", err)
+	}
+	return files, nil
+}
+
+// ExtractDirArchive extracts a zip or gzipped tar archive written by
+// WriteDirArchive into destDir and returns its manifest and the files extracted
+// with their sizes and checksums. Only regular files are extracted; entries whose
+// path is absolute or leaves destDir are rejected, as are archives that expand
+// beyond MaxArchiveExtractBytes. It is an error for the manifest to be missing.
+func ExtractDirArchive(archivePath, destDir, manifestName string) ([]byte, []ExportFile, error) {
+	var manifest []byte
+	files := []ExportFile{}
+	var total int64
+
+	extract := func(name string, r io.Reader) error {
+		clean := path.Clean(strings.ReplaceAll(name, "\\", "/"))
+		if clean == manifestName {
+			data, err := io.ReadAll(io.LimitReader(r, 1<<20))
+			manifest = data
+			return err
+		}
+		if path.IsAbs(clean) || clean == "." || clean == ".." || strings.HasPrefix(clean, "../") {
+			return fmt.Errorf("archive entry has an unsafe path: %s", name)
+		}
+		dest := filepath.Join(destDir, filepath.FromSlash(clean))
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return err
+		}
+		out, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+		if err != nil {
+			return err
+		}
+		hash := sha256.New()
+		n, err := io.Copy(io.MultiWriter(out, hash), io.LimitReader(r, MaxArchiveExtractBytes-total+1))
+		_ = out.Close()
+		if err != nil {
+			return fmt.Errorf("failed to extract %s: %w", name, err)
+		}
+		total += n
+		if total > MaxArchiveExtractBytes {
+			return fmt.Errorf("archive expands beyond %d bytes", int64(MaxArchiveExtractBytes))
+		}
+		files = append(files, ExportFile{Path: clean, Size: n, SHA256: hex.EncodeToString(hash.Sum(nil))})
+		return nil
+	}
+
+	var err error
+	switch ArchiveFormat(archivePath) {
+	case ExportFormatZip:
+		err = extractZip(archivePath, extract)
+	case ExportFormatTarGz:
+		err = extractTarGz(archivePath, extract)
+	default:
+		err = fmt.Errorf("unsupported archive format: %s (use .zip or .tar.gz)", filepath.Base(archivePath))
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if manifest == nil {
+		return nil, nil, fmt.Errorf("archive has no %s; it was not exported by %s", manifestName, ProgramName)
+	}
+	return manifest, files, nil
+}
+
+// VerifyExportFiles checks the files extracted from an archive against the file
+// list of its manifest: every listed file must be present with the listed size
+// and checksum, and no unlisted file may be present
+func VerifyExportFiles(listed, extracted []ExportFile) error {
+	got := make(map[string]ExportFile, len(extracted))
+	for _, f := range extracted {
+		got[f.Path] = f
+	}
+	for _, want := range listed {
+		f, ok := got[want.Path]
+		if !ok {
+			return fmt.Errorf("archive is incomplete: %s is missing", want.Path)
+		}
+		if f.Size != want.Size || f.SHA256 != want.SHA256 {
+			return fmt.Errorf("archive is corrupt or was modified: checksum mismatch for %s", want.Path)
+		}
+		delete(got, want.Path)
+	}
+	for path := range got {
+		return fmt.Errorf("archive was modified: %s is not in the manifest", path)
+	}
+	return nil
+}
+
+// extractZip passes each regular file of a zip archive to extract
+func extractZip(archivePath string, extract func(name string, r io.Reader) error) error {
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer func() { _ = zr.Close() }()
+
+	for _, f := range zr.File {
+		if !f.Mode().IsRegular() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", f.Name, err)
+		}
+		err = extract(f.Name, rc)
+		_ = rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// extractTarGz passes each regular file of a gzipped tar archive to extract
+func extractTarGz(archivePath string, extract func(name string, r io.Reader) error) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := extract(hdr.Name, tr); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,109 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDirArchiveRoundTrip(t *testing.T) {
+	tmpDir := t.TempDir()
+	srcDir := filepath.Join(tmpDir, "src")
+	for name, content := range map[string]string{
+		"a.txt":          "alpha",
+		"sub/b.txt":      "beta",
+		"skip/c.txt":     "skipped",
+		"sub/task.lock":  "",
+		"MANIFEST-X.txt": "kept",
+	} {
+		path := filepath.Join(srcDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("MkdirAll failed: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+	manifest := func([]ExportFile) ([]byte, error) { return []byte(`{"ok":true}`), nil }
+	skip := func(rel string, isDir bool) bool {
+		return (isDir && rel == "skip") || filepath.Ext(rel) == ".lock"
+	}
+
+	for _, name := range []string{"out.zip", "out.tar.gz"} {
+		archivePath := filepath.Join(tmpDir, name)
+		files, err := WriteDirArchive(srcDir, archivePath, "manifest.json", manifest, skip)
+		if err != nil {
+			t.Fatalf("%s: WriteDirArchive failed: %v", name, err)
+		}
+		if len(files) != 3 {
+			t.Errorf("%s: archived %d files, want 3", name, len(files))
+		}
+
+		destDir := filepath.Join(tmpDir, "dest-"+name)
+		data, extracted, err := ExtractDirArchive(archivePath, destDir, "manifest.json")
+		if err != nil {
+			t.Fatalf("%s: ExtractDirArchive failed: %v", name, err)
+		}
+		if string(data) != `{"ok":true}` {
+			t.Errorf("%s: manifest %q", name, data)
+		}
+		if err := VerifyExportFiles(files, extracted); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+		if err := VerifyExportFiles(files[1:], extracted); err == nil {
+			t.Errorf("%s: expected error for a file missing from the manifest", name)
+		}
+		files[0].SHA256 = "0000"
+		if err := VerifyExportFiles(files, extracted); err == nil {
+			t.Errorf("%s: expected error for a checksum mismatch", name)
+		}
+		if data, err := os.ReadFile(filepath.Join(destDir, "sub", "b.txt")); err != nil || string(data) != "beta" {
+			t.Errorf("%s: sub/b.txt = %q, %v", name, data, err)
+		}
+		if FileExists(filepath.Join(destDir, "skip", "c.txt")) || FileExists(filepath.Join(destDir, "manifest.json")) {
+			t.Errorf("%s: skipped file or manifest was extracted", name)
+		}
+	}
+
+	// Archives without the manifest were not written by WriteDirArchive
+	if _, _, err := ExtractDirArchive(filepath.Join(tmpDir, "out.zip"), filepath.Join(tmpDir, "dest-other"), "other.json"); err == nil {
+		t.Error("Expected error for a missing manifest")
+	}
+
+	if _, err := WriteDirArchive(srcDir, filepath.Join(tmpDir, "out.rar"), "manifest.json", nil, nil); err == nil {
+		t.Error("Expected error for unsupported archive format")
+	}
+}
+
+func TestExtractDirArchiveRejectsUnsafePaths(t *testing.T) {
+	tmpDir := t.TempDir()
+	archivePath := filepath.Join(tmpDir, "evil.zip")
+
+	f, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	zw := zip.NewWriter(f)
+	for _, name := range []string{"manifest.json", "../escape.txt"} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("zip Create failed: %v", err)
+		}
+		_, _ = w.Write([]byte("{}"))
+	}
+	_ = zw.Close()
+	_ = f.Close()
+
+	if _, _, err := ExtractDirArchive(archivePath, filepath.Join(tmpDir, "dest"), "manifest.json"); err == nil {
+		t.Error("Expected error for an entry outside the destination")
+	}
+	if FileExists(filepath.Join(tmpDir, "escape.txt")) {
+		t.Error("Entry outside the destination was written")
+	}
+}
@@ -0,0 +1,53 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ValidateEvidenceFiles checks the evidence_files of a task: project file paths
+// or glob patterns, relative to the project's files
+func ValidateEvidenceFiles(patterns []string) error {
+	if len(patterns) > MaxEvidencePatterns {
+		return fmt.Errorf("evidence_files has %d entries (max %d)", len(patterns), MaxEvidencePatterns)
+	}
+	for _, pattern := range patterns {
+		if pattern == "" || strings.HasPrefix(pattern, "/") || strings.Contains(pattern, "\\") {
+			return fmt.Errorf("evidence_files: invalid path %q (must be relative to the project's files)", pattern)
+		}
+		for _, segment := range strings.Split(pattern, "/") {
+			if segment == ".." {
+				return fmt.Errorf("evidence_files: invalid path %q (must not contain '..')", pattern)
+			}
+		}
+		if _, err := path.Match(strings.TrimSuffix(pattern, "/**"), ""); err != nil {
+			return fmt.Errorf("evidence_files: invalid pattern %q: %v", pattern, err)
+		}
+	}
+	return nil
+}
+
+// MatchEvidenceFile reports whether a project file path matches an evidence_files
+// entry: the same path, a path.Match pattern ("logs/*.txt"), or a directory
+// followed by "/**" for every file below it
+func MatchEvidenceFile(pattern, filePath string) bool {
+	if dir, ok := strings.CutSuffix(pattern, "/**"); ok {
+		if !strings.ContainsAny(dir, "*?[") {
+			return strings.HasPrefix(filePath, dir+"/")
+		}
+		for d := path.Dir(filePath); d != "."; d = path.Dir(d) {
+			if matched, _ := path.Match(dir, d); matched {
+				return true
+			}
+		}
+		return false
+	}
+	matched, _ := path.Match(pattern, filePath)
+	return matched
+}
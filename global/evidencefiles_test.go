@@ -0,0 +1,54 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateEvidenceFiles(t *testing.T) {
+	if err := ValidateEvidenceFiles([]string{"evidence/firewall.txt", "logs/*.log", "configs/**"}); err != nil {
+		t.Errorf("ValidateEvidenceFiles() error = %v", err)
+	}
+	if err := ValidateEvidenceFiles(nil); err != nil {
+		t.Errorf("ValidateEvidenceFiles(nil) error = %v", err)
+	}
+
+	invalid := map[string][]string{
+		"absolute":    {"/etc/passwd"},
+		"traversal":   {"evidence/../../secrets"},
+		"backslash":   {"evidence\\firewall.txt"},
+		"bad pattern": {"logs/[a-"},
+		"too many":    strings.Split(strings.Repeat("a,", MaxEvidencePatterns)+"a", ","),
+	}
+	for name, patterns := range invalid {
+		if err := ValidateEvidenceFiles(patterns); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestMatchEvidenceFile(t *testing.T) {
+	tests := []struct {
+		pattern, path string
+		want          bool
+	}{
+		{"evidence/firewall.txt", "evidence/firewall.txt", true},
+		{"evidence/firewall.txt", "evidence/firewall.txt.bak", false},
+		{"logs/*.log", "logs/web01.log", true},
+		{"logs/*.log", "logs/archive/web01.log", false},
+		{"configs/**", "configs/web01/nginx.conf", true},
+		{"configs/**", "configs.txt", false},
+		{"hosts/*/**", "hosts/web01/etc/hosts", true},
+		{"hosts/*/**", "other/web01/etc/hosts", false},
+	}
+	for _, tt := range tests {
+		if got := MatchEvidenceFile(tt.pattern, tt.path); got != tt.want {
+			t.Errorf("MatchEvidenceFile(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
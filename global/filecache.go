@@ -0,0 +1,168 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"os"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// FileCache keeps values parsed from files, such as task sets and projects, so
+// repeated reads of an unchanged file skip reading and parsing it. An entry is
+// used only while the file's size and modification time match those it was
+// parsed from, so files changed by other processes are read again. Writers in
+// this process should call Invalidate, as two writes within the file system's
+// timestamp resolution can leave the same size and time.
+//
+// Values are copied in and out, so callers may modify what they get. The zero
+// value is ready to use.
+type FileCache[T any] struct {
+	mu      sync.Mutex
+	entries map[string]fileCacheEntry[T]
+}
+
+type fileCacheEntry[T any] struct {
+	size    int64
+	modTime time.Time
+	value   T
+}
+
+// Get returns a copy of the value cached for a file, if the file described by
+// info has not changed since it was cached
+func (c *FileCache[T]) Get(path string, info os.FileInfo) (T, bool) {
+	c.mu.Lock()
+	entry, ok := c.entries[path]
+	c.mu.Unlock()
+
+	if !ok || entry.size != info.Size() || !entry.modTime.Equal(info.ModTime()) {
+		var zero T
+		return zero, false
+	}
+	return DeepCopy(entry.value), true
+}
+
+// Put caches a copy of the value parsed from a file, as described by the info
+// taken before the file was read
+func (c *FileCache[T]) Put(path string, info os.FileInfo, value T) {
+	entry := fileCacheEntry[T]{size: info.Size(), modTime: info.ModTime(), value: DeepCopy(value)}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[string]fileCacheEntry[T])
+	}
+	c.entries[path] = entry
+}
+
+// Invalidate drops the value cached for a file
+func (c *FileCache[T]) Invalidate(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, path)
+}
+
+// DeepCopy returns a copy of a value that shares no pointers, slices or maps
+// with it. Unexported struct fields, such as those of time.Time, are copied
+// as they are.
+func DeepCopy[T any](value T) T {
+	src := reflect.ValueOf(&value).Elem()
+	dst := reflect.New(src.Type()).Elem()
+	copyValue(dst, src)
+	return dst.Interface().(T)
+}
+
+// copyValue deep-copies src into dst, which is settable and of the same type
+func copyValue(dst, src reflect.Value) {
+	if !hasReferences(src.Type()) {
+		dst.Set(src)
+		return
+	}
+	switch src.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			return
+		}
+		ptr := reflect.New(src.Type().Elem())
+		copyValue(ptr.Elem(), src.Elem())
+		dst.Set(ptr)
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		slice := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		if hasReferences(src.Type().Elem()) {
+			for i := 0; i < src.Len(); i++ {
+				copyValue(slice.Index(i), src.Index(i))
+			}
+		} else {
+			reflect.Copy(slice, src)
+		}
+		dst.Set(slice)
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		m := reflect.MakeMapWithSize(src.Type(), src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			value := reflect.New(src.Type().Elem()).Elem()
+			copyValue(value, iter.Value())
+			m.SetMapIndex(iter.Key(), value)
+		}
+		dst.Set(m)
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+		value := reflect.New(src.Elem().Type()).Elem()
+		copyValue(value, src.Elem())
+		dst.Set(value)
+	case reflect.Struct:
+		dst.Set(src)
+		for i := 0; i < src.NumField(); i++ {
+			if field := dst.Field(i); field.CanSet() && hasReferences(field.Type()) {
+				copyValue(field, src.Field(i))
+			}
+		}
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			copyValue(dst.Index(i), src.Index(i))
+		}
+	default:
+		dst.Set(src)
+	}
+}
+
+// referenceTypes caches hasReferences by type
+var referenceTypes sync.Map
+
+// hasReferences reports whether a copy of a value of a type would share memory
+// with it through exported pointers, slices, maps or interfaces. Such values are
+// copied field by field; others are copied by assignment.
+func hasReferences(t reflect.Type) bool {
+	if cached, ok := referenceTypes.Load(t); ok {
+		return cached.(bool)
+	}
+	// Assume recursive types have references while they are being checked
+	referenceTypes.Store(t, true)
+
+	result := false
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+		result = true
+	case reflect.Array:
+		result = hasReferences(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField() && !result; i++ {
+			field := t.Field(i)
+			result = field.IsExported() && hasReferences(field.Type)
+		}
+	}
+	referenceTypes.Store(t, result)
+	return result
+}
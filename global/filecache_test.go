@@ -0,0 +1,82 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "taskset.json")
+	if err := os.WriteFile(path, []byte(`{"path": "controls"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cache FileCache[*TaskSet]
+	if _, ok := cache.Get(path, info); ok {
+		t.Fatal("empty cache returned a value")
+	}
+
+	cache.Put(path, info, &TaskSet{Path: "controls", Tasks: []Task{{Title: "Access"}}})
+	got, ok := cache.Get(path, info)
+	if !ok || got.Path != "controls" || got.Tasks[0].Title != "Access" {
+		t.Fatalf("Get() = %+v, %v", got, ok)
+	}
+
+	// Callers own what they get
+	got.Tasks[0].Title = "Changed"
+	if again, _ := cache.Get(path, info); again.Tasks[0].Title != "Access" {
+		t.Errorf("cached value modified through a returned copy: %q", again.Tasks[0].Title)
+	}
+
+	// A changed file is not served from the cache
+	later := info.ModTime().Add(time.Second)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	changed, _ := os.Stat(path)
+	if _, ok := cache.Get(path, changed); ok {
+		t.Error("cache served a value for a changed file")
+	}
+
+	cache.Invalidate(path)
+	if _, ok := cache.Get(path, info); ok {
+		t.Error("cache served an invalidated value")
+	}
+}
+
+func TestDeepCopy(t *testing.T) {
+	now := time.Now()
+	original := &TaskSet{
+		Path:      "controls",
+		CreatedAt: now,
+		Tasks:     []Task{{Title: "Access", DependsOn: []string{"a"}, Env: map[string]string{"k": "v"}}},
+	}
+
+	copied := DeepCopy(original)
+	if copied == original || !copied.CreatedAt.Equal(now) || copied.Tasks[0].Env["k"] != "v" {
+		t.Fatalf("DeepCopy() = %+v", copied)
+	}
+	copied.Tasks[0].DependsOn[0] = "b"
+	copied.Tasks[0].Env["k"] = "changed"
+	if original.Tasks[0].DependsOn[0] != "a" || original.Tasks[0].Env["k"] != "v" {
+		t.Error("copy shares slices or maps with the original")
+	}
+
+	values := map[string]interface{}{"list": []interface{}{map[string]interface{}{"n": 1.0}}}
+	inner := DeepCopy(values)["list"].([]interface{})[0].(map[string]interface{})
+	inner["n"] = 2.0
+	if values["list"].([]interface{})[0].(map[string]interface{})["n"] != 1.0 {
+		t.Error("copy shares values held in interfaces with the original")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"syscall"
 	"unicode/utf8"
 )
 
@@ -79,3 +80,26 @@ func DirExists(path string) bool {
 func EnsureDir(path string) error {
 	return os.MkdirAll(path, 0755)
 }
+
+// CheckWritableDir creates the directory if needed and writes and removes a probe file in it.
+func CheckWritableDir(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(dir, ".maestro-write-check-*")
+	if err != nil {
+		return err
+	}
+	name := f.Name()
+	_ = f.Close()
+	return os.Remove(name)
+}
+
+// FreeDiskMB returns the space available to unprivileged users on the file system holding path, in MB.
+func FreeDiskMB(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return stat.Bavail * uint64(stat.Bsize) / (1024 * 1024), nil
+}
@@ -0,0 +1,67 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GenerationParams are the sampling parameters sent with an LLM call. Unset
+// fields are left to the provider's defaults.
+type GenerationParams struct {
+	Temperature *float64 `json:"temperature,omitempty"`
+	TopP        *float64 `json:"top_p,omitempty"`
+	MaxTokens   int      `json:"max_tokens,omitempty"`
+}
+
+// IsEmpty reports whether no parameter is set
+func (g GenerationParams) IsEmpty() bool {
+	return g.Temperature == nil && g.TopP == nil && g.MaxTokens == 0
+}
+
+// Merge returns a copy of g with the parameters set in override replacing its own
+func (g GenerationParams) Merge(override GenerationParams) GenerationParams {
+	if override.Temperature != nil {
+		g.Temperature = override.Temperature
+	}
+	if override.TopP != nil {
+		g.TopP = override.TopP
+	}
+	if override.MaxTokens != 0 {
+		g.MaxTokens = override.MaxTokens
+	}
+	return g
+}
+
+// Validate checks that the parameters are within the ranges providers accept
+func (g GenerationParams) Validate() error {
+	if g.Temperature != nil && (*g.Temperature < 0 || *g.Temperature > 2) {
+		return fmt.Errorf("temperature %v must be between 0 and 2", *g.Temperature)
+	}
+	if g.TopP != nil && (*g.TopP <= 0 || *g.TopP > 1) {
+		return fmt.Errorf("top_p %v must be greater than 0 and at most 1", *g.TopP)
+	}
+	if g.MaxTokens < 0 {
+		return fmt.Errorf("max_tokens %d must not be negative", g.MaxTokens)
+	}
+	return nil
+}
+
+// String formats the parameters that are set, for logs
+func (g GenerationParams) String() string {
+	var parts []string
+	if g.Temperature != nil {
+		parts = append(parts, fmt.Sprintf("temperature=%v", *g.Temperature))
+	}
+	if g.TopP != nil {
+		parts = append(parts, fmt.Sprintf("top_p=%v", *g.TopP))
+	}
+	if g.MaxTokens != 0 {
+		parts = append(parts, fmt.Sprintf("max_tokens=%d", g.MaxTokens))
+	}
+	return strings.Join(parts, " ")
+}
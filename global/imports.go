@@ -0,0 +1,170 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ImportPolicy limits the files file_import, shared_import and
+// project_file_extract bring in, so a careless import of a disk image or a
+// whole home directory cannot fill the disk. Files that break the policy are
+// left out of directory imports and archive extractions, each with the reason,
+// and make a single-file import fail. An import whose files would exceed the
+// total size fails before anything is written.
+type ImportPolicy struct {
+	MaxFileSizeMB     int      `json:"max_file_size_mb,omitempty"`   // Largest file imported (default: 512, -1 = no limit)
+	MaxTotalSizeMB    int      `json:"max_total_size_mb,omitempty"`  // Largest total of one import or extraction (default: 4096, -1 = no limit)
+	AllowedExtensions []string `json:"allowed_extensions,omitempty"` // Only files with these extensions are imported (default: any)
+	BlockedExtensions []string `json:"blocked_extensions,omitempty"` // Files with these extensions are never imported (default: disk and VM images; [] for none)
+	AllowedMimeTypes  []string `json:"allowed_mime_types,omitempty"` // Only files of these MIME types are imported, e.g. "text/*" (default: any)
+	BlockedMimeTypes  []string `json:"blocked_mime_types,omitempty"` // Files of these MIME types are never imported
+}
+
+// ImportRejection is a file an import or extraction left out under the import policy
+type ImportRejection struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+// WithDefaults returns a copy of ImportPolicy with defaults applied for zero values
+func (p ImportPolicy) WithDefaults() ImportPolicy {
+	result := p
+	if result.MaxFileSizeMB == 0 {
+		result.MaxFileSizeMB = DefaultImportMaxFileSizeMB
+	}
+	if result.MaxTotalSizeMB == 0 {
+		result.MaxTotalSizeMB = DefaultImportMaxTotalSizeMB
+	}
+	if result.BlockedExtensions == nil {
+		result.BlockedExtensions = strings.Split(DefaultImportBlockedExtensions, ",")
+	}
+	return result
+}
+
+// Validate checks the import policy settings
+func (p ImportPolicy) Validate() error {
+	if p.MaxFileSizeMB < -1 {
+		return fmt.Errorf("invalid import_policy.max_file_size_mb %d (must be positive, or -1 for no limit)", p.MaxFileSizeMB)
+	}
+	if p.MaxTotalSizeMB < -1 {
+		return fmt.Errorf("invalid import_policy.max_total_size_mb %d (must be positive, or -1 for no limit)", p.MaxTotalSizeMB)
+	}
+	for _, ext := range append(append([]string{}, p.AllowedExtensions...), p.BlockedExtensions...) {
+		if !strings.HasPrefix(ext, ".") || len(ext) < 2 {
+			return fmt.Errorf("import_policy: extension %q must start with '.'", ext)
+		}
+	}
+	for _, t := range append(append([]string{}, p.AllowedMimeTypes...), p.BlockedMimeTypes...) {
+		if !strings.Contains(t, "/") {
+			return fmt.Errorf("import_policy: invalid MIME type %q (expected e.g. application/pdf or image/*)", t)
+		}
+	}
+	return nil
+}
+
+// CheckFile returns why the policy rejects a file, or nil if it may be
+// imported. head returns the start of the file's content, to detect its MIME
+// type; it is only called when the policy has MIME types and may return nil.
+func (p ImportPolicy) CheckFile(name string, size int64, head func() []byte) error {
+	if p.MaxFileSizeMB > 0 && size > int64(p.MaxFileSizeMB)*1024*1024 {
+		return fmt.Errorf("%s is %s, larger than the import limit of %d MB per file", name, FormatSize(size), p.MaxFileSizeMB)
+	}
+
+	ext := strings.ToLower(filepath.Ext(name))
+	if hasExtension(p.BlockedExtensions, ext) {
+		return fmt.Errorf("%s: %s files are blocked by the import policy", name, ext)
+	}
+	if len(p.AllowedExtensions) > 0 && !hasExtension(p.AllowedExtensions, ext) {
+		return fmt.Errorf("%s: the import policy only allows %s files", name, strings.Join(p.AllowedExtensions, ", "))
+	}
+
+	if len(p.AllowedMimeTypes) == 0 && len(p.BlockedMimeTypes) == 0 {
+		return nil
+	}
+	// The type of the extension and the type of the content both count
+	types := []string{mime.TypeByExtension(ext)}
+	if data := head(); len(data) > 0 {
+		types = append(types, http.DetectContentType(data))
+	}
+	for _, t := range types {
+		if MatchesMimeType(p.BlockedMimeTypes, t) {
+			return fmt.Errorf("%s: %s content is blocked by the import policy", name, strings.Split(t, ";")[0])
+		}
+	}
+	if len(p.AllowedMimeTypes) > 0 && !MatchesMimeType(p.AllowedMimeTypes, types[0]) && (len(types) < 2 || !MatchesMimeType(p.AllowedMimeTypes, types[1])) {
+		return fmt.Errorf("%s: the import policy only allows %s content", name, strings.Join(p.AllowedMimeTypes, ", "))
+	}
+	return nil
+}
+
+// CheckTotal returns an error if the files of one import are larger in total
+// than the policy allows
+func (p ImportPolicy) CheckTotal(files int, total int64) error {
+	if p.MaxTotalSizeMB > 0 && total > int64(p.MaxTotalSizeMB)*1024*1024 {
+		return fmt.Errorf("the %d files to import total %s, more than the import limit of %d MB per call; import a smaller directory or raise import_policy.max_total_size_mb", files, FormatSize(total), p.MaxTotalSizeMB)
+	}
+	return nil
+}
+
+// FileHead returns a function that reads the start of a file, for CheckFile
+func FileHead(path string) func() []byte {
+	return func() []byte {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil
+		}
+		defer f.Close()
+		buf := make([]byte, 512)
+		n, _ := f.Read(buf)
+		return buf[:n]
+	}
+}
+
+// MatchesMimeType reports whether a MIME type, with any parameters, is one of
+// types. A type such as "image/*" matches all its subtypes.
+func MatchesMimeType(types []string, mimeType string) bool {
+	mimeType, _, _ = strings.Cut(mimeType, ";")
+	mimeType = strings.ToLower(strings.TrimSpace(mimeType))
+	if mimeType == "" {
+		return false
+	}
+	for _, t := range types {
+		t = strings.ToLower(t)
+		if t == mimeType || (strings.HasSuffix(t, "/*") && strings.HasPrefix(mimeType, strings.TrimSuffix(t, "*"))) {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatSize formats a size in bytes as KB, MB or GB
+func FormatSize(size int64) string {
+	switch {
+	case size >= 1024*1024*1024:
+		return fmt.Sprintf("%.1f GB", float64(size)/(1024*1024*1024))
+	case size >= 1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1024*1024))
+	case size >= 1024:
+		return fmt.Sprintf("%.1f KB", float64(size)/1024)
+	}
+	return fmt.Sprintf("%d bytes", size)
+}
+
+// hasExtension reports whether ext (lowercase) is one of exts
+func hasExtension(exts []string, ext string) bool {
+	for _, e := range exts {
+		if strings.ToLower(e) == ext {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestImportPolicy(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		policy  ImportPolicy
+		wantErr bool
+	}{
+		{"empty", ImportPolicy{}, false},
+		{"no limits", ImportPolicy{MaxFileSizeMB: -1, MaxTotalSizeMB: -1, BlockedExtensions: []string{}}, false},
+		{"file size", ImportPolicy{MaxFileSizeMB: -2}, true},
+		{"total size", ImportPolicy{MaxTotalSizeMB: -5}, true},
+		{"extension", ImportPolicy{AllowedExtensions: []string{"pdf"}}, true},
+		{"mime type", ImportPolicy{BlockedMimeTypes: []string{"video"}}, true},
+	} {
+		if err := tt.policy.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+
+	text := func() []byte { return []byte("plain evidence text") }
+	defaults := ImportPolicy{}.WithDefaults()
+	if err := defaults.CheckFile("server.VMDK", 1024, text); err == nil || !strings.Contains(err.Error(), ".vmdk files are blocked") {
+		t.Errorf("VM image not blocked by default: %v", err)
+	}
+	if err := defaults.CheckFile("big.pdf", int64(DefaultImportMaxFileSizeMB+1)*1024*1024, text); err == nil || !strings.Contains(err.Error(), "larger than the import limit") {
+		t.Errorf("large file not rejected: %v", err)
+	}
+	if err := defaults.CheckFile("policy.pdf", 1024, text); err != nil {
+		t.Errorf("CheckFile() = %v", err)
+	}
+	if err := (ImportPolicy{BlockedExtensions: []string{}}).WithDefaults().CheckFile("disk.iso", 1024, text); err != nil {
+		t.Errorf("empty blocked_extensions still blocks: %v", err)
+	}
+	if err := defaults.CheckTotal(3, int64(DefaultImportMaxTotalSizeMB+1)*1024*1024); err == nil {
+		t.Error("expected error for an import over the total limit")
+	}
+
+	allowed := ImportPolicy{AllowedExtensions: []string{".pdf", ".txt"}, BlockedMimeTypes: []string{"application/x-executable", "image/*"}}
+	if err := allowed.CheckFile("notes", 10, text); err == nil {
+		t.Error("expected error for a file without an allowed extension")
+	}
+	if err := allowed.CheckFile("notes.txt", 10, text); err != nil {
+		t.Errorf("CheckFile() = %v", err)
+	}
+	png := func() []byte { return []byte("\x89PNG\r\n\x1a\n0000") }
+	if err := allowed.CheckFile("scan.pdf", 10, png); err == nil || !strings.Contains(err.Error(), "image/png content is blocked") {
+		t.Errorf("image content named .pdf not rejected: %v", err)
+	}
+	textOnly := ImportPolicy{AllowedMimeTypes: []string{"text/*"}}
+	if err := textOnly.CheckFile("notes.txt", 10, text); err != nil {
+		t.Errorf("CheckFile() = %v", err)
+	}
+	if err := textOnly.CheckFile("scan.bin", 10, png); err == nil {
+		t.Error("expected error for content of a type not allowed")
+	}
+}
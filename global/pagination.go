@@ -0,0 +1,70 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// cursorVersion prefixes encoded cursors so the format can change later
+const cursorVersion = "v1:"
+
+// EncodeCursor returns an opaque pagination cursor for the sort key of the last
+// item on a page.
+func EncodeCursor(key string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursorVersion + key))
+}
+
+// DecodeCursor returns the sort key recorded in a cursor from EncodeCursor
+func DecodeCursor(cursor string) (string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || !strings.HasPrefix(string(data), cursorVersion) {
+		return "", fmt.Errorf("invalid cursor")
+	}
+	return strings.TrimPrefix(string(data), cursorVersion), nil
+}
+
+// IntCursorKey formats an integer sort key so that it orders correctly as a string
+func IntCursorKey(n int) string {
+	return fmt.Sprintf("%012d", n)
+}
+
+// Paginate returns one page of items, which must be in ascending key order.
+// With a cursor, the page starts after the item the cursor was issued for, so
+// items appended between calls never shift the page (offset is ignored).
+// Without one, offset is used. limit <= 0 returns all remaining items.
+// Returns the page, its starting index, and the cursor for the next page
+// ("" when there are no more items).
+func Paginate[T any](items []T, key func(T) string, cursor string, offset, limit int) ([]T, int, string, error) {
+	start := offset
+	if cursor != "" {
+		after, err := DecodeCursor(cursor)
+		if err != nil {
+			return nil, 0, "", err
+		}
+		start = sort.Search(len(items), func(i int) bool { return key(items[i]) > after })
+	}
+	if start < 0 {
+		start = 0
+	}
+	if start >= len(items) {
+		return []T{}, len(items), "", nil
+	}
+
+	end := len(items)
+	if limit > 0 && start+limit < end {
+		end = start + limit
+	}
+
+	next := ""
+	if end < len(items) {
+		next = EncodeCursor(key(items[end-1]))
+	}
+	return items[start:end], start, next, nil
+}
@@ -0,0 +1,42 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"testing"
+)
+
+func TestPaginateCursorStableWhileAppending(t *testing.T) {
+	key := func(n int) string { return IntCursorKey(n) }
+	items := []int{1, 2, 3, 4, 5}
+
+	page, start, next, err := Paginate(items, key, "", 0, 2)
+	if err != nil || start != 0 || len(page) != 2 || page[1] != 2 || next == "" {
+		t.Fatalf("first page = %v start=%d next=%q err=%v", page, start, next, err)
+	}
+
+	// Items appended (or inserted before the cursor) between calls do not shift the page
+	items = append([]int{0}, append(items, 6, 7)...)
+	page, start, next, err = Paginate(items, key, next, 0, 2)
+	if err != nil || len(page) != 2 || page[0] != 3 || page[1] != 4 || start != 3 {
+		t.Fatalf("second page = %v start=%d err=%v", page, start, err)
+	}
+
+	page, _, next, err = Paginate(items, key, next, 0, 10)
+	if err != nil || len(page) != 3 || page[0] != 5 || next != "" {
+		t.Fatalf("last page = %v next=%q err=%v", page, next, err)
+	}
+
+	// Offset paging still works and also returns a cursor
+	page, start, next, err = Paginate(items, key, "", 6, 1)
+	if err != nil || start != 6 || len(page) != 1 || page[0] != 6 || next == "" {
+		t.Fatalf("offset page = %v start=%d next=%q err=%v", page, start, next, err)
+	}
+
+	if _, _, _, err := Paginate(items, key, "not-a-cursor", 0, 1); err == nil {
+		t.Error("expected invalid cursor error")
+	}
+}
@@ -0,0 +1,233 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// piiDetector finds one type of personal identifier. Candidates matched by the
+// pattern are only counted when valid reports them as genuine (e.g. a passing
+// checksum), which keeps order numbers and similar digit runs out of the findings.
+type piiDetector struct {
+	piiType string
+	pattern *regexp.Regexp
+	valid   func(match string) bool
+}
+
+// piiDetectors are applied in order; a match overlapping an earlier detector's
+// match is not counted again (a card number is not also a phone number)
+var piiDetectors = []piiDetector{
+	{PIITypeEmail, regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`), nil},
+	{PIITypeCreditCard, regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`), validCardNumber},
+	{PIITypeIBAN, regexp.MustCompile(`\b[A-Z]{2}\d{2}(?: ?[A-Z0-9]{4}){2,7}(?: ?[A-Z0-9]{1,3})?\b`), validIBAN},
+	{PIITypeSSN, regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`), validSSN},
+	{PIITypeSIN, regexp.MustCompile(`\b\d{3}[ -]\d{3}[ -]\d{3}\b`), validSIN},
+	{PIITypePhone, regexp.MustCompile(`(?:\+\d{1,3}[ .-]?)?(?:\(\d{3}\)|\b\d{3})[ .-]\d{3}[ .-]\d{4}\b`), nil},
+}
+
+// PIITypes returns the identifier types DetectPII can report
+func PIITypes() []string {
+	types := make([]string, len(piiDetectors))
+	for i, d := range piiDetectors {
+		types[i] = d.piiType
+	}
+	return types
+}
+
+// piiMatch is one personal identifier found in text
+type piiMatch struct {
+	piiType string
+	start   int
+	end     int
+}
+
+// findPII returns the personal identifiers of the given types (all when empty)
+// in text, grouped by type in detector order
+func findPII(text string, types []string) []piiMatch {
+	wanted := make(map[string]bool, len(types))
+	for _, t := range types {
+		wanted[t] = true
+	}
+
+	var matches []piiMatch
+	overlaps := func(span []int) bool {
+		for _, m := range matches {
+			if span[0] < m.end && m.start < span[1] {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, d := range piiDetectors {
+		if len(wanted) > 0 && !wanted[d.piiType] {
+			continue
+		}
+		for _, span := range d.pattern.FindAllStringIndex(text, -1) {
+			if overlaps(span) || (d.valid != nil && !d.valid(text[span[0]:span[1]])) {
+				continue
+			}
+			matches = append(matches, piiMatch{piiType: d.piiType, start: span[0], end: span[1]})
+		}
+	}
+	return matches
+}
+
+// DetectPII scans text for personal identifiers of the given types (all when
+// empty). Returns nil when nothing is found.
+func DetectPII(text string, types []string) *PIIReport {
+	report := &PIIReport{}
+	for _, m := range findPII(text, types) {
+		if n := len(report.Findings); n == 0 || report.Findings[n-1].Type != m.piiType {
+			report.Findings = append(report.Findings, PIIFinding{Type: m.piiType})
+		}
+		finding := &report.Findings[len(report.Findings)-1]
+		finding.Count++
+		if len(finding.Samples) < MaxPIISamples {
+			finding.Samples = append(finding.Samples, maskPII(m.piiType, text[m.start:m.end]))
+		}
+		report.Total++
+	}
+	if report.Total == 0 {
+		return nil
+	}
+	return report
+}
+
+// AnonymizePII replaces every personal identifier DetectPII would report with
+// its type in brackets (e.g. "[email]"), returning the text and the number of
+// identifiers replaced
+func AnonymizePII(text string) (string, int) {
+	matches := findPII(text, nil)
+	if len(matches) == 0 {
+		return text, 0
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].start < matches[j].start })
+
+	var sb strings.Builder
+	last := 0
+	for _, m := range matches {
+		sb.WriteString(text[last:m.start])
+		sb.WriteString("[" + m.piiType + "]")
+		last = m.end
+	}
+	sb.WriteString(text[last:])
+	return sb.String(), len(matches)
+}
+
+// Types returns the identifier types found
+func (r *PIIReport) Types() []string {
+	if r == nil {
+		return nil
+	}
+	types := make([]string, len(r.Findings))
+	for i, f := range r.Findings {
+		types[i] = f.Type
+	}
+	return types
+}
+
+// maskPII hides an identifier for display: the first character and domain of an
+// email address, the last four characters of anything else
+func maskPII(piiType, match string) string {
+	if piiType == PIITypeEmail {
+		local, domain, _ := strings.Cut(match, "@")
+		return local[:1] + "***@" + domain
+	}
+	isAlnum := func(c byte) bool { return c >= '0' && c <= '9' || c >= 'A' && c <= 'Z' }
+	hidden := -4 // Characters left to mask, once the last four are excluded
+	for i := 0; i < len(match); i++ {
+		if isAlnum(match[i]) {
+			hidden++
+		}
+	}
+	var masked strings.Builder
+	for i := 0; i < len(match); i++ {
+		c := match[i]
+		if isAlnum(c) && hidden > 0 {
+			c = '*'
+			hidden--
+		}
+		masked.WriteByte(c)
+	}
+	return masked.String()
+}
+
+// digitsOf returns the digits of s
+func digitsOf(s string) string {
+	var sb strings.Builder
+	for _, c := range s {
+		if c >= '0' && c <= '9' {
+			sb.WriteRune(c)
+		}
+	}
+	return sb.String()
+}
+
+// luhnValid reports whether a digit string passes the Luhn checksum
+func luhnValid(digits string) bool {
+	sum := 0
+	for i := 0; i < len(digits); i++ {
+		d := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// validCardNumber checks the issuer prefix and Luhn checksum of a card number
+func validCardNumber(match string) bool {
+	digits := digitsOf(match)
+	if len(digits) < 13 || len(digits) > 19 || !strings.ContainsAny(digits[:1], "23456") {
+		return false
+	}
+	return luhnValid(digits)
+}
+
+// validSSN rejects numbers the US Social Security Administration never issues
+func validSSN(match string) bool {
+	area, group, serial := match[0:3], match[4:6], match[7:11]
+	return area != "000" && area != "666" && area[0] != '9' && group != "00" && serial != "0000"
+}
+
+// validSIN checks the Luhn checksum of a Canadian SIN; 0 and 8 are not issued
+func validSIN(match string) bool {
+	digits := digitsOf(match)
+	return digits[0] != '0' && digits[0] != '8' && luhnValid(digits)
+}
+
+// validIBAN checks the mod-97 checksum of an IBAN
+func validIBAN(match string) bool {
+	iban := strings.ReplaceAll(match, " ", "")
+	if len(iban) < 15 || len(iban) > 34 {
+		return false
+	}
+	rearranged := iban[4:] + iban[:4]
+	remainder := 0
+	for _, c := range rearranged {
+		var value int
+		switch {
+		case c >= '0' && c <= '9':
+			value = int(c - '0')
+			remainder = (remainder*10 + value) % 97
+			continue
+		case c >= 'A' && c <= 'Z':
+			value = int(c-'A') + 10
+		default:
+			return false
+		}
+		remainder = (remainder*100 + value) % 97
+	}
+	return remainder == 1
+}
@@ -0,0 +1,61 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetectPII(t *testing.T) {
+	text := `Interviewed jane.doe@example.com (555-123-4567) about access reviews.
+Test card 4111 1111 1111 1111 was found in logs; order 4111 1111 1111 1112 was not a card.
+Payroll extract lists SSN 123-45-6789 and SIN 130 692 544; 000-12-3456 is not a valid SSN.
+Refunds go to GB82 WEST 1234 5698 7654 32.`
+
+	report := DetectPII(text, nil)
+	if report == nil {
+		t.Fatal("DetectPII found nothing")
+	}
+	counts := map[string]int{}
+	samples := map[string][]string{}
+	for _, f := range report.Findings {
+		counts[f.Type] = f.Count
+		samples[f.Type] = f.Samples
+	}
+	want := map[string]int{PIITypeEmail: 1, PIITypeCreditCard: 1, PIITypeIBAN: 1, PIITypeSSN: 1, PIITypeSIN: 1, PIITypePhone: 1}
+	if !reflect.DeepEqual(counts, want) || report.Total != 6 {
+		t.Errorf("DetectPII counts = %v (total %d), want %v", counts, report.Total, want)
+	}
+	if got := samples[PIITypeSSN]; len(got) != 1 || got[0] != "***-**-6789" {
+		t.Errorf("SSN samples = %v", got)
+	}
+	if got := samples[PIITypeEmail]; len(got) != 1 || got[0] != "j***@example.com" {
+		t.Errorf("email samples = %v", got)
+	}
+	if got := samples[PIITypeCreditCard]; len(got) != 1 || got[0] != "**** **** **** 1111" {
+		t.Errorf("card samples = %v", got)
+	}
+
+	// Only the requested types are reported
+	report = DetectPII(text, []string{PIITypeSSN})
+	if got := report.Types(); !reflect.DeepEqual(got, []string{PIITypeSSN}) {
+		t.Errorf("types = %v, want only ssn", got)
+	}
+
+	if DetectPII("The firewall denies inbound traffic on port 23.", nil) != nil {
+		t.Error("expected no findings in text without identifiers")
+	}
+}
+
+func TestAnonymizePII(t *testing.T) {
+	text := "Contact jane.doe@example.com or 555-123-4567; SSN 123-45-6789, order 4111 1111 1111 1112."
+	got, n := AnonymizePII(text)
+	want := "Contact [email] or [phone]; SSN [ssn], order 4111 1111 1111 1112."
+	if got != want || n != 3 {
+		t.Errorf("AnonymizePII = %q (%d), want %q (3)", got, n, want)
+	}
+}
@@ -0,0 +1,132 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidatePipeline checks that a pipeline is well formed: task set paths are
+// unique and titled, task set dependencies name task sets of the pipeline and
+// form no cycle, tasks are titled and have a prompt field, task titles and
+// external IDs are unique, and QA skip rules and result summaries are valid
+func ValidatePipeline(p *Pipeline) error {
+	if p == nil || len(p.TaskSets) == 0 {
+		return fmt.Errorf("pipeline declares no task_sets")
+	}
+
+	paths := make(map[string]*PipelineTaskSet, len(p.TaskSets))
+	for i := range p.TaskSets {
+		ts := &p.TaskSets[i]
+		if ts.Path == "" {
+			return fmt.Errorf("task set %d: path is required", i+1)
+		}
+		if paths[ts.Path] != nil {
+			return fmt.Errorf("task set %s: duplicate path", ts.Path)
+		}
+		paths[ts.Path] = ts
+		if ts.Title == "" {
+			return fmt.Errorf("task set %s: title is required", ts.Path)
+		}
+	}
+
+	externalIDs := make(map[string]string)
+	for i := range p.TaskSets {
+		ts := &p.TaskSets[i]
+		for _, dep := range ts.DependsOn {
+			if paths[dep] == nil {
+				return fmt.Errorf("task set %s: depends_on names %s, which is not a task set of the pipeline", ts.Path, dep)
+			}
+		}
+		if ts.QA != nil {
+			if err := ValidateQASkipRules(ts.QA.SkipRules); err != nil {
+				return fmt.Errorf("task set %s: %w", ts.Path, err)
+			}
+		}
+		if err := ValidateResultSummary(ts.ResultSummary); err != nil {
+			return fmt.Errorf("task set %s: %w", ts.Path, err)
+		}
+
+		titles := make(map[string]bool, len(ts.Tasks))
+		for j, task := range ts.Tasks {
+			if task.Title == "" {
+				return fmt.Errorf("task set %s: task %d: title is required", ts.Path, j+1)
+			}
+			if task.ExternalID == "" {
+				// Tasks without an external ID are matched by title
+				if titles[task.Title] {
+					return fmt.Errorf("task set %s: task %q: duplicate title (set external_id to tell them apart)", ts.Path, task.Title)
+				}
+				titles[task.Title] = true
+			} else {
+				if other, ok := externalIDs[task.ExternalID]; ok {
+					return fmt.Errorf("task set %s: task %q: external_id %s is also used in %s", ts.Path, task.Title, task.ExternalID, other)
+				}
+				externalIDs[task.ExternalID] = ts.Path
+			}
+			if task.Prompt == "" && task.InstructionsFile == "" && task.InstructionsText == "" {
+				return fmt.Errorf("task set %s: task %q: at least one prompt field is required: instructions_file, instructions_text, or prompt", ts.Path, task.Title)
+			}
+			if err := ValidateTaskEnv(task.Env); err != nil {
+				return fmt.Errorf("task set %s: task %q: %w", ts.Path, task.Title, err)
+			}
+			if err := task.Limits.Validate(); err != nil {
+				return fmt.Errorf("task set %s: task %q: %w", ts.Path, task.Title, err)
+			}
+			if err := ValidateTaskPriority(task.Priority); err != nil {
+				return fmt.Errorf("task set %s: task %q: %w", ts.Path, task.Title, err)
+			}
+		}
+	}
+
+	return findTaskSetCycle(p.TaskSets, paths)
+}
+
+// findTaskSetCycle returns an error describing the first cycle in the
+// depends_on of the pipeline task sets, or nil if there is none
+func findTaskSetCycle(taskSets []PipelineTaskSet, paths map[string]*PipelineTaskSet) error {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int)
+	var stack []string
+
+	var visit func(path string) error
+	visit = func(path string) error {
+		switch state[path] {
+		case visited:
+			return nil
+		case visiting:
+			start := 0
+			for i, s := range stack {
+				if s == path {
+					start = i
+					break
+				}
+			}
+			return fmt.Errorf("task set dependency cycle: %s", strings.Join(append(stack[start:], path), " -> "))
+		}
+		state[path] = visiting
+		stack = append(stack, path)
+		for _, dep := range paths[path].DependsOn {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[path] = visited
+		return nil
+	}
+
+	for _, ts := range taskSets {
+		if err := visit(ts.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
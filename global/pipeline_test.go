@@ -0,0 +1,56 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidatePipeline(t *testing.T) {
+	valid := func() *Pipeline {
+		return &Pipeline{TaskSets: []PipelineTaskSet{
+			{Path: "intake", Title: "Intake", Tasks: []PipelineTask{{Title: "Scope", ExternalID: "scope", Prompt: "Describe the scope"}}},
+			{Path: "assessment", Title: "Assessment", DependsOn: []string{"intake"}, Tasks: []PipelineTask{
+				{Title: "Access control", Prompt: "Assess access control", DependsOn: []string{"scope"}},
+				{Title: "Logging", Prompt: "Assess logging"},
+			}},
+		}}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(p *Pipeline)
+		want   string
+	}{
+		{"valid", func(p *Pipeline) {}, ""},
+		{"no task sets", func(p *Pipeline) { p.TaskSets = nil }, "no task_sets"},
+		{"duplicate path", func(p *Pipeline) { p.TaskSets[1].Path = "intake" }, "duplicate path"},
+		{"missing title", func(p *Pipeline) { p.TaskSets[0].Title = "" }, "title is required"},
+		{"unknown dependency", func(p *Pipeline) { p.TaskSets[1].DependsOn = []string{"fieldwork"} }, "not a task set of the pipeline"},
+		{"dependency cycle", func(p *Pipeline) { p.TaskSets[0].DependsOn = []string{"assessment"} }, "cycle: intake -> assessment -> intake"},
+		{"duplicate task title", func(p *Pipeline) { p.TaskSets[1].Tasks[1].Title = "Access control" }, "duplicate title"},
+		{"duplicate external id", func(p *Pipeline) { p.TaskSets[1].Tasks[1].ExternalID = "scope" }, "also used in intake"},
+		{"no prompt", func(p *Pipeline) { p.TaskSets[0].Tasks[0].Prompt = "" }, "prompt field is required"},
+		{"invalid skip rule", func(p *Pipeline) { p.TaskSets[0].QA = &PipelineQA{SkipRules: []QASkipRule{{Name: "empty"}}} }, "at least one condition"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := valid()
+			tt.modify(p)
+			err := ValidatePipeline(p)
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("ValidatePipeline() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ValidatePipeline() = %v, want error containing %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,361 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// secretPatterns match the API keys and tokens of the "secret" redaction type
+var secretPatterns = func() []*regexp.Regexp {
+	patterns := make([]*regexp.Regexp, len(builtinRedactionPatterns))
+	for i, p := range builtinRedactionPatterns {
+		patterns[i] = regexp.MustCompile(p)
+	}
+	return patterns
+}()
+
+// placeholderPattern matches the placeholders a PromptRedactor writes
+var placeholderPattern = regexp.MustCompile(`\[[A-Z0-9_]+_\d+\]`)
+
+// PromptRedactionTypes returns the types a prompt redaction can replace
+func PromptRedactionTypes() []string {
+	return append(PIITypes(), RedactionTypeSecret)
+}
+
+// MergePromptRedaction combines the configured prompt redaction with a
+// project's own: redaction is on if either enables it, the project's types
+// replace the configured ones, and both sets of rules apply
+func MergePromptRedaction(configured PromptRedaction, project *PromptRedaction) PromptRedaction {
+	if project == nil {
+		return configured
+	}
+	merged := PromptRedaction{
+		Enabled: configured.Enabled || project.Enabled,
+		Types:   configured.Types,
+		Rules:   append(append([]PromptRedactionRule{}, configured.Rules...), project.Rules...),
+	}
+	if len(project.Types) > 0 {
+		merged.Types = project.Types
+	}
+	return merged
+}
+
+// promptRedactionRule is a compiled PromptRedactionRule
+type promptRedactionRule struct {
+	kind     string
+	patterns []*regexp.Regexp
+}
+
+// PromptRedactor finds the values a prompt redaction replaces. A nil
+// PromptRedactor replaces nothing.
+type PromptRedactor struct {
+	piiTypes []string // PII types to replace; nil with none, empty with all
+	secrets  bool
+	rules    []promptRedactionRule
+}
+
+// NewPromptRedactor compiles a prompt redaction. Returns nil if it is not
+// enabled, or an error if it is invalid.
+func NewPromptRedactor(policy PromptRedaction) (*PromptRedactor, error) {
+	rules, err := compilePromptRedaction(policy)
+	if err != nil || !policy.Enabled {
+		return nil, err
+	}
+
+	p := &PromptRedactor{rules: rules, secrets: len(policy.Types) == 0}
+	if len(policy.Types) == 0 {
+		p.piiTypes = []string{}
+	}
+	for _, t := range policy.Types {
+		if t == RedactionTypeSecret {
+			p.secrets = true
+		} else {
+			p.piiTypes = append(p.piiTypes, t)
+		}
+	}
+	return p, nil
+}
+
+// ValidatePromptRedaction checks the types and rules of a prompt redaction
+func ValidatePromptRedaction(policy *PromptRedaction) error {
+	if policy == nil {
+		return nil
+	}
+	_, err := compilePromptRedaction(*policy)
+	return err
+}
+
+// compilePromptRedaction validates a prompt redaction and compiles its rules.
+// Dictionary terms become one case-insensitive whole-word pattern per rule.
+func compilePromptRedaction(policy PromptRedaction) ([]promptRedactionRule, error) {
+	for _, t := range policy.Types {
+		if !slices.Contains(PromptRedactionTypes(), t) {
+			return nil, fmt.Errorf("invalid prompt redaction type %q (must be one of: %s)", t, strings.Join(PromptRedactionTypes(), ", "))
+		}
+	}
+
+	rules := make([]promptRedactionRule, 0, len(policy.Rules))
+	for i, rule := range policy.Rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("prompt redaction rule %d: name is required", i+1)
+		}
+		kind := placeholderKind(rule.Name)
+		if kind == "" {
+			return nil, fmt.Errorf("prompt redaction rule %s: name must contain letters or digits", rule.Name)
+		}
+		compiled := promptRedactionRule{kind: kind}
+		if rule.Pattern != "" {
+			re, err := regexp.Compile(rule.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("prompt redaction rule %s: invalid pattern: %w", rule.Name, err)
+			}
+			compiled.patterns = append(compiled.patterns, re)
+		}
+		var terms []string
+		for _, term := range rule.Terms {
+			if term = strings.TrimSpace(term); term != "" {
+				terms = append(terms, regexp.QuoteMeta(term))
+			}
+		}
+		if len(terms) > 0 {
+			// Longest first, so a term is not cut short by a shorter one it starts with
+			sort.Slice(terms, func(i, j int) bool { return len(terms[i]) > len(terms[j]) })
+			compiled.patterns = append(compiled.patterns, regexp.MustCompile(`(?i)\b(?:`+strings.Join(terms, "|")+`)\b`))
+		}
+		if len(compiled.patterns) == 0 {
+			return nil, fmt.Errorf("prompt redaction rule %s: pattern or terms is required", rule.Name)
+		}
+		rules = append(rules, compiled)
+	}
+	return rules, nil
+}
+
+// placeholderKind turns a type or rule name into the label of its
+// placeholders: upper case, with runs of other characters as underscores
+func placeholderKind(name string) string {
+	var sb strings.Builder
+	underscore := false
+	for _, c := range strings.ToUpper(name) {
+		if c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' {
+			if underscore && sb.Len() > 0 {
+				sb.WriteByte('_')
+			}
+			sb.WriteRune(c)
+			underscore = false
+		} else {
+			underscore = true
+		}
+	}
+	return sb.String()
+}
+
+// redactionMatch is one value a prompt redaction replaces
+type redactionMatch struct {
+	kind  string
+	start int
+	end   int
+}
+
+// find returns the values to replace in text, ordered by position. Custom rules
+// come first, then personal identifiers, then secrets; a match overlapping an
+// earlier one is dropped.
+func (p *PromptRedactor) find(text string) []redactionMatch {
+	var matches []redactionMatch
+	add := func(kind string, start, end int) {
+		for _, m := range matches {
+			if start < m.end && m.start < end {
+				return
+			}
+		}
+		matches = append(matches, redactionMatch{kind: kind, start: start, end: end})
+	}
+
+	// Placeholders already in the text (such as a response quoted in a QA
+	// prompt) are kept as they are
+	for _, span := range placeholderPattern.FindAllStringIndex(text, -1) {
+		add("", span[0], span[1])
+	}
+	for _, rule := range p.rules {
+		for _, re := range rule.patterns {
+			for _, span := range re.FindAllStringIndex(text, -1) {
+				if span[0] < span[1] {
+					add(rule.kind, span[0], span[1])
+				}
+			}
+		}
+	}
+	if p.piiTypes != nil {
+		for _, m := range findPII(text, p.piiTypes) {
+			add(placeholderKind(m.piiType), m.start, m.end)
+		}
+	}
+	if p.secrets {
+		for _, re := range secretPatterns {
+			for _, span := range re.FindAllStringIndex(text, -1) {
+				add(placeholderKind(RedactionTypeSecret), span[0], span[1])
+			}
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool { return matches[i].start < matches[j].start })
+	return matches
+}
+
+// Redact returns text with each value to replace swapped for its placeholder
+// in m, and how many distinct values of each kind it replaced
+func (p *PromptRedactor) Redact(text string, m *RedactionMap) (string, []RedactionCount) {
+	if p == nil || text == "" {
+		return text, nil
+	}
+	matches := p.find(text)
+	if len(matches) == 0 {
+		return text, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var sb strings.Builder
+	var counts []RedactionCount
+	seen := make(map[string]bool)
+	last := 0
+	for _, match := range matches {
+		if match.kind == "" {
+			continue
+		}
+		value := text[match.start:match.end]
+		placeholder := m.placeholderLocked(match.kind, value)
+		sb.WriteString(text[last:match.start])
+		sb.WriteString(placeholder)
+		last = match.end
+
+		if seen[placeholder] {
+			continue
+		}
+		seen[placeholder] = true
+		i := slices.IndexFunc(counts, func(c RedactionCount) bool { return c.Kind == match.kind })
+		if i < 0 {
+			counts = append(counts, RedactionCount{Kind: match.kind})
+			i = len(counts) - 1
+		}
+		counts[i].Count++
+	}
+	if counts == nil {
+		return text, nil
+	}
+	sb.WriteString(text[last:])
+	return sb.String(), counts
+}
+
+// RedactionMap holds the placeholders of the values replaced in the prompts of
+// one task, so that a value keeps its placeholder across the worker, QA and
+// revision prompts. It is safe for concurrent use.
+type RedactionMap struct {
+	mu           sync.Mutex
+	values       map[string]string // Placeholder → value
+	placeholders map[string]string // Value → placeholder
+	next         map[string]int    // Kind → number of the next placeholder
+}
+
+// NewRedactionMap returns an empty RedactionMap
+func NewRedactionMap() *RedactionMap {
+	return &RedactionMap{
+		values:       make(map[string]string),
+		placeholders: make(map[string]string),
+		next:         make(map[string]int),
+	}
+}
+
+// placeholderLocked returns the placeholder of a value, assigning the next one
+// of its kind to a new value. The caller holds m.mu.
+func (m *RedactionMap) placeholderLocked(kind, value string) string {
+	if placeholder, ok := m.placeholders[value]; ok {
+		return placeholder
+	}
+	m.next[kind]++
+	placeholder := fmt.Sprintf("[%s_%d]", kind, m.next[kind])
+	m.placeholders[value] = placeholder
+	m.values[placeholder] = value
+	return placeholder
+}
+
+// NewRedactionMapFrom returns a RedactionMap holding the given placeholders and
+// their values, such as those of an anonymized export
+func NewRedactionMapFrom(values map[string]string) *RedactionMap {
+	m := NewRedactionMap()
+	for placeholder, value := range values {
+		m.values[placeholder] = value
+		m.placeholders[value] = placeholder
+	}
+	return m
+}
+
+// Values returns a copy of the placeholders of m and their values
+func (m *RedactionMap) Values() map[string]string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	values := make(map[string]string, len(m.values))
+	for placeholder, value := range m.values {
+		values[placeholder] = value
+	}
+	return values
+}
+
+// Counts returns how many distinct values of each kind m holds, by kind
+func (m *RedactionMap) Counts() []RedactionCount {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	kinds := make([]string, 0, len(m.next))
+	for kind := range m.next {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	var counts []RedactionCount
+	for _, kind := range kinds {
+		counts = append(counts, RedactionCount{Kind: kind, Count: m.next[kind]})
+	}
+	return counts
+}
+
+// Restore returns text with the placeholders of m replaced by their values.
+// Values restored into valid JSON are escaped so that it stays valid.
+func (m *RedactionMap) Restore(text string) string {
+	if m == nil || text == "" || !strings.Contains(text, "[") {
+		return text
+	}
+	escape := json.Valid([]byte(text))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return placeholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		value, ok := m.values[placeholder]
+		if !ok {
+			return placeholder
+		}
+		if escape {
+			if encoded, err := json.Marshal(value); err == nil {
+				return string(encoded[1 : len(encoded)-1])
+			}
+		}
+		return value
+	})
+}
+
+// FormatRedactionCounts describes redaction counts for logs, e.g. "2 EMAIL, 1 CLIENT"
+func FormatRedactionCounts(counts []RedactionCount) string {
+	parts := make([]string, len(counts))
+	for i, c := range counts {
+		parts[i] = fmt.Sprintf("%d %s", c.Count, c.Kind)
+	}
+	return strings.Join(parts, ", ")
+}
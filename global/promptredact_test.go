@@ -0,0 +1,114 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPromptRedactor(t *testing.T) {
+	redactor, err := NewPromptRedactor(PromptRedaction{
+		Enabled: true,
+		Rules: []PromptRedactionRule{
+			{Name: "client", Terms: []string{"Acme Corp", "Acme"}},
+			{Name: "account id", Pattern: `ACC-\d{6}`},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewPromptRedactor() error = %v", err)
+	}
+
+	prompt := `Review ACME CORP's access for jane.doe@example.com (account ACC-123456).
+Acme Corp rotated key sk-ant-REDACTED; contact jane.doe@example.com.`
+	placeholders := NewRedactionMap()
+	redacted, counts := redactor.Redact(prompt, placeholders)
+
+	want := `Review [CLIENT_1]'s access for [EMAIL_1] (account [ACCOUNT_ID_1]).
+[CLIENT_2] rotated key [SECRET_1]; contact [EMAIL_1].`
+	if redacted != want {
+		t.Errorf("Redact() =\n%s\nwant\n%s", redacted, want)
+	}
+	wantCounts := []RedactionCount{{"CLIENT", 2}, {"EMAIL", 1}, {"ACCOUNT_ID", 1}, {"SECRET", 1}}
+	if !reflect.DeepEqual(counts, wantCounts) {
+		t.Errorf("Redact() counts = %v, want %v", counts, wantCounts)
+	}
+
+	// A value keeps its placeholder in later prompts, and placeholders already
+	// in the text are left alone
+	again, counts := redactor.Redact("Follow up with jane.doe@example.com about [CLIENT_1]", placeholders)
+	if again != "Follow up with [EMAIL_1] about [CLIENT_1]" || len(counts) != 1 {
+		t.Errorf("Redact() = %q, counts %v", again, counts)
+	}
+
+	if got := placeholders.Restore(redacted); got != prompt {
+		t.Errorf("Restore() =\n%s\nwant\n%s", got, prompt)
+	}
+	if got := placeholders.Restore("[EMAIL_2] and [UNKNOWN]"); got != "[EMAIL_2] and [UNKNOWN]" {
+		t.Errorf("Restore() replaced unknown placeholders: %q", got)
+	}
+
+	// The placeholders can be saved and loaded again, as for an anonymized export
+	wantTotals := []RedactionCount{{"ACCOUNT_ID", 1}, {"CLIENT", 2}, {"EMAIL", 1}, {"SECRET", 1}}
+	if got := placeholders.Counts(); !reflect.DeepEqual(got, wantTotals) {
+		t.Errorf("Counts() = %v, want %v", got, wantTotals)
+	}
+	if got := NewRedactionMapFrom(placeholders.Values()).Restore(redacted); got != prompt {
+		t.Errorf("Restore() from saved values =\n%s\nwant\n%s", got, prompt)
+	}
+
+	// Values restored into JSON are escaped
+	quoted := NewRedactionMap()
+	rule, _ := NewPromptRedactor(PromptRedaction{Enabled: true, Types: []string{RedactionTypeSecret}, Rules: []PromptRedactionRule{{Name: "quote", Terms: []string{`say "hi`}}}})
+	if redacted, _ := rule.Redact(`They say "hi there`, quoted); redacted != "They [QUOTE_1] there" {
+		t.Fatalf("Redact() = %q", redacted)
+	}
+	restored := quoted.Restore(`{"finding": "They [QUOTE_1] there"}`)
+	var decoded map[string]string
+	if err := json.Unmarshal([]byte(restored), &decoded); err != nil || decoded["finding"] != `They say "hi there` {
+		t.Errorf("Restore() into JSON = %s (%v)", restored, err)
+	}
+
+	// Only the selected types are replaced
+	emailOnly, _ := NewPromptRedactor(PromptRedaction{Enabled: true, Types: []string{PIITypeEmail}})
+	if got, _ := emailOnly.Redact("jane.doe@example.com sk-ant-REDACTED", NewRedactionMap()); got != "[EMAIL_1] sk-ant-REDACTED" {
+		t.Errorf("Redact() with email only = %q", got)
+	}
+
+	if disabled, err := NewPromptRedactor(PromptRedaction{}); disabled != nil || err != nil {
+		t.Errorf("NewPromptRedactor() of a disabled redaction = %v, %v", disabled, err)
+	}
+}
+
+func TestValidatePromptRedaction(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy PromptRedaction
+		want   string
+	}{
+		{"valid", PromptRedaction{Types: []string{PIITypeEmail, RedactionTypeSecret}, Rules: []PromptRedactionRule{{Name: "client", Terms: []string{"Acme"}}}}, ""},
+		{"unknown type", PromptRedaction{Types: []string{"address"}}, "invalid prompt redaction type"},
+		{"no name", PromptRedaction{Rules: []PromptRedactionRule{{Pattern: "x"}}}, "name is required"},
+		{"nothing to match", PromptRedaction{Rules: []PromptRedactionRule{{Name: "client"}}}, "pattern or terms is required"},
+		{"invalid pattern", PromptRedaction{Rules: []PromptRedactionRule{{Name: "client", Pattern: "("}}}, "invalid pattern"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePromptRedaction(&tt.policy)
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("ValidatePromptRedaction() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ValidatePromptRedaction() = %v, want error containing %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Default locations of the standardized fields of a QA response
+const (
+	qaVerdictField  = "verdict"
+	qaSeverityField = "severity"
+)
+
+// ValidateQAFieldMap checks the QA field map of a task set: paths must not have
+// empty segments and verdict values must map to pass, fail or escalate
+func ValidateQAFieldMap(m *QAFieldMap) error {
+	if m == nil {
+		return nil
+	}
+	for _, field := range [][2]string{{"verdict", m.Verdict}, {"feedback", m.Feedback}, {"severity", m.Severity}} {
+		if field[1] == "" {
+			continue
+		}
+		for _, key := range strings.Split(qaFieldPath(field[1]), ".") {
+			if key == "" {
+				return fmt.Errorf("qa_fields.%s: invalid path %q", field[0], field[1])
+			}
+		}
+	}
+	seen := make(map[string]bool, len(m.Verdicts))
+	for value, verdict := range m.Verdicts {
+		key := strings.ToLower(strings.TrimSpace(value))
+		if key == "" {
+			return fmt.Errorf("qa_fields.verdicts: empty value")
+		}
+		if seen[key] {
+			return fmt.Errorf("qa_fields.verdicts: %q is mapped twice", value)
+		}
+		seen[key] = true
+		switch verdict {
+		case QAVerdictPass, QAVerdictFail, QAVerdictEscalate:
+		default:
+			return fmt.Errorf("qa_fields.verdicts: %q maps to %q (must be %s, %s or %s)", value, verdict, QAVerdictPass, QAVerdictFail, QAVerdictEscalate)
+		}
+	}
+	return nil
+}
+
+// qaFieldPath returns a field path without its optional "$." prefix
+func qaFieldPath(path string) string {
+	return strings.TrimPrefix(path, "$.")
+}
+
+// VerdictPath returns the path of the verdict in QA responses
+func (m *QAFieldMap) VerdictPath() string {
+	if m == nil || m.Verdict == "" {
+		return qaVerdictField
+	}
+	return qaFieldPath(m.Verdict)
+}
+
+// SeverityPath returns the path of the severity in QA responses
+func (m *QAFieldMap) SeverityPath() string {
+	if m == nil || m.Severity == "" {
+		return qaSeverityField
+	}
+	return qaFieldPath(m.Severity)
+}
+
+// CustomVerdict reports whether the map moves the verdict or renames its values,
+// so QA responses need not have the standard 'verdict' field
+func (m *QAFieldMap) CustomVerdict() bool {
+	return m != nil && (m.VerdictPath() != qaVerdictField || len(m.Verdicts) > 0)
+}
+
+// MapVerdict returns the standardized verdict for a value of the QA response's
+// verdict field: its mapping, matched case-insensitively, or the value lowercased
+func (m *QAFieldMap) MapVerdict(value string) string {
+	if m != nil {
+		for from, to := range m.Verdicts {
+			if strings.EqualFold(strings.TrimSpace(from), strings.TrimSpace(value)) {
+				return to
+			}
+		}
+	}
+	return strings.ToLower(value)
+}
+
+// LookupQAField returns the value at a dot-separated path of a QA response
+func LookupQAField(doc map[string]any, path string) (any, bool) {
+	return lookupField(doc, qaFieldPath(path))
+}
+
+// QAFieldText returns the value at a path of a QA response as text: strings as
+// themselves, anything else in its JSON form, "" when the path is missing
+func QAFieldText(doc map[string]any, path string) string {
+	value, ok := LookupQAField(doc, path)
+	if !ok || value == nil {
+		return ""
+	}
+	return conditionString(value)
+}
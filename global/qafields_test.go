@@ -0,0 +1,41 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import "testing"
+
+func TestValidateQAFieldMap(t *testing.T) {
+	valid := &QAFieldMap{Verdict: "$.assessment.outcome", Feedback: "notes", Verdicts: map[string]string{"approved": "pass", "rework": "escalate"}}
+	if err := ValidateQAFieldMap(valid); err != nil {
+		t.Errorf("ValidateQAFieldMap() error = %v", err)
+	}
+	if err := ValidateQAFieldMap(nil); err != nil {
+		t.Errorf("ValidateQAFieldMap(nil) error = %v", err)
+	}
+
+	invalid := map[string]*QAFieldMap{
+		"empty segment":  {Verdict: "assessment..outcome"},
+		"trailing dot":   {Feedback: "notes."},
+		"empty value":    {Verdicts: map[string]string{" ": "pass"}},
+		"mapped twice":   {Verdicts: map[string]string{"OK": "pass", "ok": "pass"}},
+		"unknown target": {Verdicts: map[string]string{"approved": "done"}},
+	}
+	for name, m := range invalid {
+		if err := ValidateQAFieldMap(m); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	if got := valid.MapVerdict("Approved"); got != QAVerdictPass {
+		t.Errorf("MapVerdict(Approved) = %q, want %q", got, QAVerdictPass)
+	}
+	if got := valid.MapVerdict("FAIL"); got != "fail" {
+		t.Errorf("MapVerdict(FAIL) = %q, want fail", got)
+	}
+	if (&QAFieldMap{Feedback: "notes"}).CustomVerdict() || !valid.CustomVerdict() {
+		t.Error("CustomVerdict() reports the wrong maps")
+	}
+}
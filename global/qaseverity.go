@@ -0,0 +1,50 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"fmt"
+	"strings"
+)
+
+// QASeverities lists the QA severity levels, most severe first
+var QASeverities = []string{QASeverityCritical, QASeverityHigh, QASeverityMedium, QASeverityLow}
+
+// qaSeverityRank returns the rank of a severity (0 = most severe), or -1 if the
+// value is not a QA severity
+func qaSeverityRank(severity string) int {
+	for i, s := range QASeverities {
+		if s == severity {
+			return i
+		}
+	}
+	return -1
+}
+
+// NormalizeQASeverity returns a QA severity in canonical form (trimmed and
+// lowercased), or "" when the value is not one of QASeverities
+func NormalizeQASeverity(severity string) string {
+	severity = strings.ToLower(strings.TrimSpace(severity))
+	if qaSeverityRank(severity) < 0 {
+		return ""
+	}
+	return severity
+}
+
+// ValidateQASeverity checks a configured severity threshold; empty means none
+func ValidateQASeverity(severity string) error {
+	if severity == "" || NormalizeQASeverity(severity) != "" {
+		return nil
+	}
+	return fmt.Errorf("invalid QA severity %q (must be one of: %s)", severity, strings.Join(QASeverities, ", "))
+}
+
+// QASeverityAtLeast reports whether severity is as severe as minimum or more.
+// Values that are not QA severities never meet a threshold.
+func QASeverityAtLeast(severity, minimum string) bool {
+	rank, threshold := qaSeverityRank(NormalizeQASeverity(severity)), qaSeverityRank(NormalizeQASeverity(minimum))
+	return rank >= 0 && threshold >= 0 && rank <= threshold
+}
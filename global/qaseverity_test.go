@@ -0,0 +1,43 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import "testing"
+
+func TestQASeverityAtLeast(t *testing.T) {
+	tests := []struct {
+		severity, minimum string
+		want              bool
+	}{
+		{"critical", "critical", true},
+		{"Critical ", "critical", true},
+		{"high", "critical", false},
+		{"critical", "medium", true},
+		{"medium", "medium", true},
+		{"low", "medium", false},
+		{"", "low", false},
+		{"info", "low", false},
+		{"critical", "", false},
+	}
+	for _, tt := range tests {
+		if got := QASeverityAtLeast(tt.severity, tt.minimum); got != tt.want {
+			t.Errorf("QASeverityAtLeast(%q, %q) = %v, want %v", tt.severity, tt.minimum, got, tt.want)
+		}
+	}
+}
+
+func TestValidateQASeverity(t *testing.T) {
+	for _, valid := range []string{"", "critical", "High", "low"} {
+		if err := ValidateQASeverity(valid); err != nil {
+			t.Errorf("ValidateQASeverity(%q) = %v", valid, err)
+		}
+	}
+	for _, invalid := range []string{"severe", "info", "none"} {
+		if err := ValidateQASeverity(invalid); err == nil {
+			t.Errorf("ValidateQASeverity(%q) = nil, want error", invalid)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ValidateQASkipRules checks that QA skip rules are well formed: each rule has a
+// unique name and at least one condition, and each condition names a field and
+// a known operator with the values it needs
+func ValidateQASkipRules(rules []QASkipRule) error {
+	names := make(map[string]bool, len(rules))
+	for i, rule := range rules {
+		if rule.Name == "" {
+			return fmt.Errorf("qa skip rule %d: name is required", i+1)
+		}
+		if names[rule.Name] {
+			return fmt.Errorf("qa skip rule %s: duplicate name", rule.Name)
+		}
+		names[rule.Name] = true
+		if len(rule.Conditions) == 0 {
+			return fmt.Errorf("qa skip rule %s: at least one condition is required", rule.Name)
+		}
+		for _, c := range rule.Conditions {
+			if c.Field == "" {
+				return fmt.Errorf("qa skip rule %s: condition field is required", rule.Name)
+			}
+			switch c.Op {
+			case ConditionOpEquals, ConditionOpNotEquals, ConditionOpExists:
+			case ConditionOpIn:
+				if len(c.Values) == 0 {
+					return fmt.Errorf("qa skip rule %s: condition on %s needs values for %s", rule.Name, c.Field, c.Op)
+				}
+			default:
+				return fmt.Errorf("qa skip rule %s: invalid op %q (must be %s, %s, %s or %s)", rule.Name, c.Op,
+					ConditionOpEquals, ConditionOpNotEquals, ConditionOpIn, ConditionOpExists)
+			}
+		}
+	}
+	return nil
+}
+
+// MatchQASkipRule returns the first rule whose conditions all hold for a JSON
+// response, or nil if none does. A response that is not a JSON object matches
+// no rule.
+func MatchQASkipRule(rules []QASkipRule, response string) *QASkipRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	var doc map[string]any
+	if err := json.Unmarshal([]byte(response), &doc); err != nil {
+		return nil
+	}
+	for i := range rules {
+		matched := true
+		for _, c := range rules[i].Conditions {
+			if !c.holds(doc) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+// holds reports whether the condition is satisfied by the response document.
+// Apart from not_equals, a condition on a missing field does not hold.
+func (c FieldCondition) holds(doc map[string]any) bool {
+	value, ok := lookupField(doc, c.Field)
+	switch c.Op {
+	case ConditionOpExists:
+		return ok
+	case ConditionOpEquals:
+		return ok && conditionString(value) == c.Value
+	case ConditionOpNotEquals:
+		return !ok || conditionString(value) != c.Value
+	case ConditionOpIn:
+		if !ok {
+			return false
+		}
+		s := conditionString(value)
+		for _, v := range c.Values {
+			if s == v {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lookupField follows a dot-separated path through nested JSON objects
+func lookupField(doc map[string]any, path string) (any, bool) {
+	var current any = doc
+	for _, key := range strings.Split(path, ".") {
+		obj, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if current, ok = obj[key]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// conditionString renders a JSON value for comparison: strings as themselves,
+// anything else in its JSON form
+func conditionString(value any) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
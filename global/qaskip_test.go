@@ -0,0 +1,68 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import "testing"
+
+func TestMatchQASkipRule(t *testing.T) {
+	rules := []QASkipRule{
+		{Name: "not-applicable", Conditions: []FieldCondition{
+			{Field: "result", Op: ConditionOpEquals, Value: "not_applicable"},
+			{Field: "justification", Op: ConditionOpExists},
+		}},
+		{Name: "low-risk-pass", Conditions: []FieldCondition{
+			{Field: "assessment.status", Op: ConditionOpIn, Values: []string{"compliant", "pass"}},
+			{Field: "assessment.findings", Op: ConditionOpEquals, Value: "0"},
+			{Field: "escalate", Op: ConditionOpNotEquals, Value: "true"},
+		}},
+	}
+
+	tests := []struct {
+		name     string
+		response string
+		want     string
+	}{
+		{"equals and exists", `{"result":"not_applicable","justification":"No cloud assets"}`, "not-applicable"},
+		{"missing field", `{"result":"not_applicable"}`, ""},
+		{"nested in, number and missing not_equals", `{"assessment":{"status":"compliant","findings":0}}`, "low-risk-pass"},
+		{"boolean not_equals", `{"assessment":{"status":"pass","findings":0},"escalate":true}`, ""},
+		{"value not in list", `{"assessment":{"status":"partial","findings":0}}`, ""},
+		{"not an object", `["not_applicable"]`, ""},
+		{"not json", `result: not_applicable`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ""
+			if rule := MatchQASkipRule(rules, tt.response); rule != nil {
+				got = rule.Name
+			}
+			if got != tt.want {
+				t.Errorf("MatchQASkipRule() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateQASkipRules(t *testing.T) {
+	valid := []QASkipRule{{Name: "na", Conditions: []FieldCondition{{Field: "result", Op: ConditionOpEquals, Value: "not_applicable"}}}}
+	if err := ValidateQASkipRules(valid); err != nil {
+		t.Errorf("ValidateQASkipRules() error = %v", err)
+	}
+
+	invalid := map[string][]QASkipRule{
+		"no name":         {{Conditions: valid[0].Conditions}},
+		"duplicate name":  {valid[0], valid[0]},
+		"no conditions":   {{Name: "empty"}},
+		"no field":        {{Name: "x", Conditions: []FieldCondition{{Op: ConditionOpExists}}}},
+		"unknown op":      {{Name: "x", Conditions: []FieldCondition{{Field: "result", Op: "matches"}}}},
+		"in needs values": {{Name: "x", Conditions: []FieldCondition{{Field: "result", Op: ConditionOpIn}}}},
+	}
+	for name, rules := range invalid {
+		if err := ValidateQASkipRules(rules); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// builtinRedactionPatterns match common API key and token formats
+var builtinRedactionPatterns = []string{
+	`(?i)\bbearer\s+[A-Za-z0-9._~+/=-]{16,}`,          // Authorization: Bearer <token>
+	`\bsk-(?:ant-|proj-)?[A-Za-z0-9_-]{20,}`,          // Anthropic and OpenAI API keys
+	`\bAKIA[0-9A-Z]{16}\b`,                            // AWS access key IDs
+	`\bAIza[0-9A-Za-z_-]{35}\b`,                       // Google API keys
+	`\bgh[pousr]_[A-Za-z0-9]{36,}\b`,                  // GitHub tokens
+	`\bxox[abprs]-[A-Za-z0-9-]{10,}`,                  // Slack tokens
+	`(?i)\b(?:api[_-]?key|secret|password)=[^\s&"']+`, // key=value pairs in URLs and command lines
+}
+
+// credentialEnvSuffixes identify environment variables holding credentials, such as
+// the API keys used by the LLM CLIs Maestro runs
+var credentialEnvSuffixes = []string{"_API_KEY", "_APIKEY", "_TOKEN", "_SECRET", "_PASSWORD", "_ACCESS_KEY"}
+
+// Redactor masks secrets in text before it is written to logs or result files.
+// A nil Redactor leaves text unchanged.
+type Redactor struct {
+	patterns    []*regexp.Regexp
+	secrets     []string // Literal values, longest first so overlapping values are fully masked
+	replacement string
+}
+
+// NewRedactor builds a Redactor from the configuration and the current environment.
+// Returns nil if redaction is disabled, or an error if a pattern does not compile.
+func NewRedactor(cfg Redaction) (*Redactor, error) {
+	if cfg.Disabled {
+		return nil, nil
+	}
+	cfg = cfg.WithDefaults()
+
+	r := &Redactor{replacement: cfg.Replacement}
+	for _, p := range append(append([]string{}, builtinRedactionPatterns...), cfg.Patterns...) {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", p, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+
+	named := make(map[string]bool, len(cfg.EnvVars))
+	for _, name := range cfg.EnvVars {
+		named[name] = true
+	}
+	seen := make(map[string]bool)
+	for _, kv := range os.Environ() {
+		name, value, ok := strings.Cut(kv, "=")
+		if !ok || len(value) < MinRedactedSecretLength || seen[value] {
+			continue
+		}
+		if named[name] || isCredentialEnvVar(name) {
+			r.secrets = append(r.secrets, value)
+			seen[value] = true
+		}
+	}
+	sort.Slice(r.secrets, func(i, j int) bool { return len(r.secrets[i]) > len(r.secrets[j]) })
+
+	return r, nil
+}
+
+// isCredentialEnvVar reports whether an environment variable name looks like a credential
+func isCredentialEnvVar(name string) bool {
+	upper := strings.ToUpper(name)
+	for _, suffix := range credentialEnvSuffixes {
+		if strings.HasSuffix(upper, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddSecrets adds literal values to mask, such as API keys read from files or
+// secret managers that are not in the environment
+func (r *Redactor) AddSecrets(values ...string) {
+	if r == nil {
+		return
+	}
+	for _, value := range values {
+		if len(value) < MinRedactedSecretLength || slices.Contains(r.secrets, value) {
+			continue
+		}
+		r.secrets = append(r.secrets, value)
+	}
+	sort.Slice(r.secrets, func(i, j int) bool { return len(r.secrets[i]) > len(r.secrets[j]) })
+}
+
+// Redact returns s with every secret replaced
+func (r *Redactor) Redact(s string) string {
+	if r == nil || s == "" {
+		return s
+	}
+	for _, secret := range r.secrets {
+		s = strings.ReplaceAll(s, secret, r.replacement)
+	}
+	for _, re := range r.patterns {
+		s = re.ReplaceAllLiteralString(s, r.replacement)
+	}
+	return s
+}
+
+// RedactJSON returns JSON-encoded data with secrets replaced inside its string
+// values. Each string literal is decoded before matching, so escaped characters are
+// seen as written, and re-encoded only if it changed, so the layout is kept.
+func (r *Redactor) RedactJSON(data []byte) []byte {
+	if r == nil || len(data) == 0 {
+		return data
+	}
+
+	var out []byte
+	last := 0
+	for i := 0; i < len(data); i++ {
+		if data[i] != '"' {
+			continue
+		}
+		end := i + 1
+		for end < len(data) && data[end] != '"' {
+			if data[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(data) {
+			break
+		}
+
+		var value string
+		if err := json.Unmarshal(data[i:end+1], &value); err == nil {
+			if redacted := r.Redact(value); redacted != value {
+				if encoded, err := json.Marshal(redacted); err == nil {
+					out = append(out, data[last:i]...)
+					out = append(out, encoded...)
+					last = end + 1
+				}
+			}
+		}
+		i = end
+	}
+	if out == nil {
+		return data
+	}
+	return append(out, data[last:]...)
+}
@@ -0,0 +1,63 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ReportOutput configures the HTML and PDF renderings of reports. HTML reports
+// are styled with the theme, a CSS file (default: a built-in theme). PDF reports
+// are laid out by the built-in writer unless PDFCommand names an HTML-to-PDF
+// converter, which is run with {{INPUT}} in its args replaced by the HTML file
+// and {{OUTPUT}} by the PDF file to write; without {{OUTPUT}} the command's
+// standard output is the PDF.
+type ReportOutput struct {
+	Theme             string   `json:"theme,omitempty"`               // CSS file for HTML reports; relative paths are under base_dir
+	PDFCommand        string   `json:"pdf_command,omitempty"`         // HTML-to-PDF converter (default: built-in writer)
+	PDFArgs           []string `json:"pdf_args,omitempty"`            // Arguments of the converter
+	PDFTimeoutSeconds int      `json:"pdf_timeout_seconds,omitempty"` // Converter timeout (default: 120)
+}
+
+// WithDefaults returns a copy of ReportOutput with defaults applied for zero values
+func (o ReportOutput) WithDefaults() ReportOutput {
+	result := o
+	if result.PDFTimeoutSeconds <= 0 {
+		result.PDFTimeoutSeconds = DefaultReportPDFTimeout
+	}
+	return result
+}
+
+// Validate checks the report output settings
+func (o ReportOutput) Validate() error {
+	if o.PDFTimeoutSeconds < 0 {
+		return fmt.Errorf("invalid report_output.pdf_timeout_seconds %d (must not be negative)", o.PDFTimeoutSeconds)
+	}
+	if o.PDFCommand == "" {
+		if len(o.PDFArgs) > 0 {
+			return fmt.Errorf("report_output.pdf_args requires pdf_command")
+		}
+		return nil
+	}
+	for _, arg := range o.PDFArgs {
+		if strings.Contains(arg, ConversionInputPlaceholder) {
+			return nil
+		}
+	}
+	return fmt.Errorf("report_output.pdf_args must contain %s", ConversionInputPlaceholder)
+}
+
+// ValidateReportFormats checks the extra formats of generated reports, which are
+// rendered from the Markdown report alongside it
+func ValidateReportFormats(formats []string) error {
+	for _, format := range formats {
+		if format != ReportFormatHTML && format != ReportFormatPDF {
+			return fmt.Errorf("invalid report format '%s' (must be %s or %s)", format, ReportFormatHTML, ReportFormatPDF)
+		}
+	}
+	return nil
+}
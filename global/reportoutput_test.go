@@ -0,0 +1,38 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import "testing"
+
+func TestReportOutput(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		output  ReportOutput
+		wantErr bool
+	}{
+		{"empty", ReportOutput{}, false},
+		{"theme", ReportOutput{Theme: "themes/client.css"}, false},
+		{"command", ReportOutput{PDFCommand: "weasyprint", PDFArgs: []string{"{{INPUT}}", "{{OUTPUT}}"}}, false},
+		{"command without input", ReportOutput{PDFCommand: "weasyprint", PDFArgs: []string{"-"}}, true},
+		{"args without command", ReportOutput{PDFArgs: []string{"{{INPUT}}"}}, true},
+		{"timeout", ReportOutput{PDFTimeoutSeconds: -1}, true},
+	} {
+		if err := tt.output.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+
+	if got := (ReportOutput{}).WithDefaults().PDFTimeoutSeconds; got != DefaultReportPDFTimeout {
+		t.Errorf("PDFTimeoutSeconds = %d, want %d", got, DefaultReportPDFTimeout)
+	}
+
+	if err := ValidateReportFormats([]string{ReportFormatHTML, ReportFormatPDF}); err != nil {
+		t.Errorf("ValidateReportFormats() = %v", err)
+	}
+	if err := ValidateReportFormats([]string{"docx"}); err == nil {
+		t.Error("expected error for an unsupported report format")
+	}
+}
@@ -0,0 +1,98 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ReportPrefix configures the prefixes of report sessions. Format is expanded
+// with the tokens {project}, {title}, {date}, {time} and {seq} (the project's
+// report session number); a prefix always ends with "-". OnCollision decides
+// what happens when the prefix was already used by another session of the
+// project: "increment" (default) adds -2, -3, ... and "refuse" fails.
+type ReportPrefix struct {
+	Format      string `json:"format,omitempty"`       // Prefix format (default: "{date}-{time}-{title}-")
+	OnCollision string `json:"on_collision,omitempty"` // "increment" (default) or "refuse"
+}
+
+// reportPrefixToken matches a {token} of a prefix format
+var reportPrefixToken = regexp.MustCompile(`\{[^{}]*\}`)
+
+// reportPrefixTokens are the tokens a prefix format may use
+var reportPrefixTokens = []string{"{project}", "{title}", "{date}", "{time}", "{seq}"}
+
+// WithDefaults returns a copy of ReportPrefix with defaults applied for zero values
+func (p ReportPrefix) WithDefaults() ReportPrefix {
+	result := p
+	if result.Format == "" {
+		result.Format = DefaultReportPrefixFormat
+	}
+	if result.OnCollision == "" {
+		result.OnCollision = ReportPrefixCollisionIncrement
+	}
+	return result
+}
+
+// Validate checks the report prefix settings. Text outside the tokens must be
+// letters, digits, "-" or "_" so prefixes stay filename-safe.
+func (p ReportPrefix) Validate() error {
+	switch p.OnCollision {
+	case "", ReportPrefixCollisionIncrement, ReportPrefixCollisionRefuse:
+	default:
+		return fmt.Errorf("invalid report_prefix.on_collision %q (must be %q or %q)", p.OnCollision, ReportPrefixCollisionIncrement, ReportPrefixCollisionRefuse)
+	}
+	if p.Format != "" && !reportPrefixToken.MatchString(p.Format) {
+		return fmt.Errorf("invalid report_prefix.format %q: must contain at least one of %s", p.Format, strings.Join(reportPrefixTokens, ", "))
+	}
+	for _, token := range reportPrefixToken.FindAllString(p.Format, -1) {
+		if !isReportPrefixToken(token) {
+			return fmt.Errorf("invalid report_prefix.format %q: unknown token %s (must be one of %s)", p.Format, token, strings.Join(reportPrefixTokens, ", "))
+		}
+	}
+	for _, r := range reportPrefixToken.ReplaceAllString(p.Format, "") {
+		if !isPrefixRune(r) {
+			return fmt.Errorf("invalid report_prefix.format %q: %q is not allowed in file names (use letters, digits, '-' or '_')", p.Format, r)
+		}
+	}
+	return nil
+}
+
+// Expand returns the prefix for a report session. title must already be
+// filename-safe.
+func (p ReportPrefix) Expand(project, title string, now time.Time, seq int) string {
+	format := p.WithDefaults().Format
+	prefix := strings.NewReplacer(
+		"{project}", project,
+		"{title}", title,
+		"{date}", now.Format(ReportPrefixDateFormat),
+		"{time}", now.Format(ReportPrefixTimeFormat),
+		"{seq}", fmt.Sprintf("%03d", seq),
+	).Replace(format)
+	prefix = strings.TrimLeft(prefix, "-_")
+	if !strings.HasSuffix(prefix, "-") {
+		prefix += "-"
+	}
+	return prefix
+}
+
+// isReportPrefixToken reports whether token is one of reportPrefixTokens
+func isReportPrefixToken(token string) bool {
+	for _, t := range reportPrefixTokens {
+		if t == token {
+			return true
+		}
+	}
+	return false
+}
+
+// isPrefixRune reports whether r may appear in a report prefix
+func isPrefixRune(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_'
+}
@@ -0,0 +1,48 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReportPrefix(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		prefix  ReportPrefix
+		wantErr bool
+	}{
+		{"empty", ReportPrefix{}, false},
+		{"tokens", ReportPrefix{Format: "{project}_{seq}-{title}", OnCollision: ReportPrefixCollisionRefuse}, false},
+		{"unknown token", ReportPrefix{Format: "{client}-{date}"}, true},
+		{"unsafe text", ReportPrefix{Format: "{date}/{title}"}, true},
+		{"no token", ReportPrefix{Format: "report-"}, true},
+		{"collision", ReportPrefix{OnCollision: "overwrite"}, true},
+	} {
+		if err := tt.prefix.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+
+	now := time.Date(2026, 3, 9, 14, 5, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		format, want string
+	}{
+		{"", "20260309-1405-ISO-Audit-"},
+		{"{project}-{seq}", "acme-007-"},
+		{"{date}_{title}", "20260309_ISO-Audit-"},
+		{"-{title}-", "ISO-Audit-"},
+	} {
+		if got := (ReportPrefix{Format: tt.format}).Expand("acme", "ISO-Audit", now, 7); got != tt.want {
+			t.Errorf("Expand(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+
+	if got := (ReportPrefix{}).WithDefaults().OnCollision; got != ReportPrefixCollisionIncrement {
+		t.Errorf("OnCollision = %q, want %q", got, ReportPrefixCollisionIncrement)
+	}
+}
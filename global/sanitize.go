@@ -0,0 +1,76 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ansiEscape matches ANSI escape sequences: CSI sequences (colors, cursor movement,
+// line erasing), OSC sequences (window titles, hyperlinks) and two-byte escapes
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)|\x1b[@-Z\\-_]`)
+
+// StripANSI removes ANSI escape sequences, such as the colors and progress bars
+// written by vendor CLIs to their terminal output
+func StripANSI(s string) string {
+	return ansiEscape.ReplaceAllString(s, "")
+}
+
+// CollapseCarriageReturns reduces each line that a terminal would redraw with
+// carriage returns (progress bars, spinners) to the text last drawn on it
+func CollapseCarriageReturns(s string) string {
+	if !strings.Contains(s, "\r") {
+		return s
+	}
+	lines := strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		if !strings.Contains(line, "\r") {
+			continue
+		}
+		segments := strings.Split(line, "\r")
+		last := ""
+		for _, segment := range segments {
+			if segment != "" {
+				last = segment
+			}
+		}
+		lines[i] = last
+	}
+	return strings.Join(lines, "\n")
+}
+
+// StripControlChars removes non-printable control characters, keeping newlines,
+// tabs and carriage returns (which CollapseCarriageReturns handles)
+func StripControlChars(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' || r == '\r' {
+			return r
+		}
+		if r < 0x20 || (r >= 0x7f && r <= 0x9f) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// Apply sanitizes LLM output as configured. ANSI escapes are removed first so
+// that control-character stripping cannot leave their printable remains behind.
+func (o OutputSanitization) Apply(s string) string {
+	if o.Disabled || s == "" {
+		return s
+	}
+	if !o.KeepANSI {
+		s = StripANSI(s)
+	}
+	if !o.KeepCarriageReturns {
+		s = CollapseCarriageReturns(s)
+	}
+	if !o.KeepControlChars {
+		s = StripControlChars(s)
+	}
+	return s
+}
@@ -0,0 +1,46 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import "testing"
+
+func TestOutputSanitization(t *testing.T) {
+	input := "\x1b[1;32mDone\x1b[0m\nDownloading 10%\rDownloading 50%\rDownloading 100%\r\nbell\x07 here\ttab\x1b]0;title\x07"
+
+	tests := []struct {
+		name string
+		cfg  OutputSanitization
+		want string
+	}{
+		{"default", OutputSanitization{}, "Done\nDownloading 100%\nbell here\ttab"},
+		{"disabled", OutputSanitization{Disabled: true}, input},
+		{"keep ansi", OutputSanitization{KeepANSI: true, KeepControlChars: true}, "\x1b[1;32mDone\x1b[0m\nDownloading 100%\nbell\x07 here\ttab\x1b]0;title\x07"},
+		{"keep carriage returns", OutputSanitization{KeepCarriageReturns: true}, "Done\nDownloading 10%\rDownloading 50%\rDownloading 100%\r\nbell here\ttab"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.Apply(input); got != tt.want {
+				t.Errorf("Apply() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCollapseCarriageReturns(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"plain\ntext", "plain\ntext"},
+		{"windows\r\nlines\r\n", "windows\nlines\n"},
+		{"spinner |\rspinner /\rspinner -\r", "spinner -"},
+	}
+	for _, tt := range tests {
+		if got := CollapseCarriageReturns(tt.input); got != tt.want {
+			t.Errorf("CollapseCarriageReturns(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
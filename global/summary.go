@@ -0,0 +1,52 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ValidateResultSummary checks the summary settings of a task set
+func ValidateResultSummary(rs *ResultSummary) error {
+	if rs == nil {
+		return nil
+	}
+	if rs.MaxChars < 0 || rs.MaxChars > MaxResultSummaryChars {
+		return fmt.Errorf("result_summary max_chars must be between 0 and %d, got %d", MaxResultSummaryChars, rs.MaxChars)
+	}
+	return nil
+}
+
+// SummaryText returns the text a result summary is made from: the value of
+// field in a JSON response, or the whole response when field is empty or not
+// found, with runs of whitespace collapsed
+func SummaryText(response, field string) string {
+	text := response
+	if field != "" {
+		var doc map[string]any
+		if err := json.Unmarshal([]byte(response), &doc); err == nil {
+			if value, ok := lookupField(doc, field); ok {
+				text = conditionString(value)
+			}
+		}
+	}
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// TruncateSummary shortens text to at most maxChars characters (the default
+// length when 0), ending it with "…" when cut
+func TruncateSummary(text string, maxChars int) string {
+	if maxChars <= 0 {
+		maxChars = DefaultResultSummaryChars
+	}
+	runes := []rune(text)
+	if len(runes) <= maxChars {
+		return text
+	}
+	return strings.TrimSpace(string(runes[:maxChars-1])) + "…"
+}
@@ -0,0 +1,38 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import "testing"
+
+func TestResultSummary(t *testing.T) {
+	response := `{"assessment": {"status": "compliant", "notes": "MFA is enforced\n  for all   admins."}, "score": 4}`
+
+	tests := []struct {
+		name     string
+		field    string
+		maxChars int
+		want     string
+	}{
+		{"nested field", "assessment.notes", 0, "MFA is enforced for all admins."},
+		{"truncated", "assessment.notes", 10, "MFA is en…"},
+		{"non-string field", "score", 0, "4"},
+		{"missing field falls back to the response", "verdict", 12, `{"assessmen…`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TruncateSummary(SummaryText(response, tt.field), tt.maxChars); got != tt.want {
+				t.Errorf("summary = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := SummaryText("plain\ttext\n\nresponse", "notes"); got != "plain text response" {
+		t.Errorf("non-JSON response summary = %q", got)
+	}
+	if err := ValidateResultSummary(&ResultSummary{MaxChars: MaxResultSummaryChars + 1}); err == nil {
+		t.Error("expected error for max_chars over the limit")
+	}
+}
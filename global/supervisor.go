@@ -0,0 +1,64 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"slices"
+	"sort"
+)
+
+// Fields returns the JSON fields the diff adds, removes or changes, sorted
+func (d ResponseDiff) Fields() []string {
+	var fields []string
+	for _, changes := range [][]DiffFieldChange{d.Added, d.Removed, d.Changed} {
+		for _, c := range changes {
+			fields = append(fields, c.Field)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// SupervisorEditedFields returns the JSON fields changed by any supervisor edit
+// of the result, sorted
+func (r *TaskResult) SupervisorEditedFields() []string {
+	var fields []string
+	for _, edit := range r.SupervisorEdits {
+		for _, f := range edit.Fields {
+			if !slices.Contains(fields, f) {
+				fields = append(fields, f)
+			}
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// MergePatch applies a JSON merge patch (RFC 7386) to a decoded JSON value:
+// object members of the patch replace those of the target, null members remove
+// them and nested objects are merged. Any other patch replaces the target.
+func MergePatch(target, patch any) any {
+	patchMap, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	targetMap, ok := target.(map[string]any)
+	if !ok {
+		targetMap = make(map[string]any)
+	}
+	merged := make(map[string]any, len(targetMap))
+	for k, v := range targetMap {
+		merged[k] = v
+	}
+	for k, v := range patchMap {
+		if v == nil {
+			delete(merged, k)
+			continue
+		}
+		merged[k] = MergePatch(merged[k], v)
+	}
+	return merged
+}
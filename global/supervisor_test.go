@@ -0,0 +1,47 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMergePatch(t *testing.T) {
+	for _, tt := range []struct {
+		name, target, patch, want string
+	}{
+		{"replace member", `{"rating": "full", "notes": "ok"}`, `{"rating": "partial"}`, `{"rating": "partial", "notes": "ok"}`},
+		{"remove member", `{"rating": "full", "notes": "ok"}`, `{"notes": null}`, `{"rating": "full"}`},
+		{"merge nested", `{"a": {"b": 1, "c": 2}}`, `{"a": {"c": 3, "d": 4}}`, `{"a": {"b": 1, "c": 3, "d": 4}}`},
+		{"replace array", `{"items": [1, 2]}`, `{"items": [3]}`, `{"items": [3]}`},
+		{"add object", `{"a": 1}`, `{"b": {"c": null, "d": 1}}`, `{"a": 1, "b": {"d": 1}}`},
+	} {
+		var target, patch, want any
+		json.Unmarshal([]byte(tt.target), &target)
+		json.Unmarshal([]byte(tt.patch), &patch)
+		json.Unmarshal([]byte(tt.want), &want)
+		if got := MergePatch(target, patch); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: MergePatch() = %v, want %v", tt.name, got, want)
+		}
+	}
+}
+
+func TestSupervisorEditedFields(t *testing.T) {
+	diff := ResponseDiff{
+		Added:   []DiffFieldChange{{Field: "notes"}},
+		Changed: []DiffFieldChange{{Field: "rating"}, {Field: "evidence[0]"}},
+	}
+	if got := diff.Fields(); !reflect.DeepEqual(got, []string{"evidence[0]", "notes", "rating"}) {
+		t.Errorf("Fields() = %v", got)
+	}
+
+	result := &TaskResult{SupervisorEdits: []SupervisorEdit{{Fields: []string{"rating"}}, {Fields: []string{"notes", "rating"}}}}
+	if got := result.SupervisorEditedFields(); !reflect.DeepEqual(got, []string{"notes", "rating"}) {
+		t.Errorf("SupervisorEditedFields() = %v", got)
+	}
+}
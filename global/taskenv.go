@@ -0,0 +1,48 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// taskEnvName matches a valid task environment variable name
+var taskEnvName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// taskEnvReference matches a ${NAME} reference in a prompt
+var taskEnvReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// ValidateTaskEnv checks the names of a task's environment variables
+func ValidateTaskEnv(env map[string]string) error {
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !taskEnvName.MatchString(name) {
+			return fmt.Errorf("invalid env name %q (must be letters, digits and underscores, not starting with a digit)", name)
+		}
+	}
+	return nil
+}
+
+// ExpandTaskEnv replaces the ${NAME} references in text with the task's
+// environment variables. References to names the task does not define are
+// left as they are, so that shell snippets in instructions survive.
+func ExpandTaskEnv(text string, env map[string]string) string {
+	if len(env) == 0 {
+		return text
+	}
+	return taskEnvReference.ReplaceAllStringFunc(text, func(ref string) string {
+		if value, ok := env[ref[2:len(ref)-1]]; ok {
+			return value
+		}
+		return ref
+	})
+}
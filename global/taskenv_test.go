@@ -0,0 +1,42 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import "testing"
+
+func TestExpandTaskEnv(t *testing.T) {
+	env := map[string]string{"HOST": "web01.example.com", "CONTROL_ID": "AC-2"}
+
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Review ${CONTROL_ID} on ${HOST}.", "Review AC-2 on web01.example.com."},
+		{"${HOST}${HOST}", "web01.example.com" + "web01.example.com"},
+		{"Run `echo ${PATH}` and $HOST", "Run `echo ${PATH}` and $HOST"}, // Undefined and unbraced references are kept
+		{"No references", "No references"},
+	}
+	for _, tt := range tests {
+		if got := ExpandTaskEnv(tt.text, env); got != tt.want {
+			t.Errorf("ExpandTaskEnv(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+
+	if got := ExpandTaskEnv("${HOST}", nil); got != "${HOST}" {
+		t.Errorf("ExpandTaskEnv without env = %q", got)
+	}
+}
+
+func TestValidateTaskEnv(t *testing.T) {
+	if err := ValidateTaskEnv(map[string]string{"HOST": "a", "_id2": "b"}); err != nil {
+		t.Errorf("valid names rejected: %v", err)
+	}
+	for _, name := range []string{"2HOST", "HOST-NAME", "", "A B"} {
+		if err := ValidateTaskEnv(map[string]string{name: "x"}); err == nil {
+			t.Errorf("invalid name %q accepted", name)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TaskTemplate is a reusable task definition stored as a JSON file in a
+// playbook. When a task is created from it, the ${NAME} references to its
+// variables are replaced in every text field; other references are kept, so
+// that the task's env can still fill them in when it runs.
+type TaskTemplate struct {
+	Description            string                 `json:"description,omitempty"`
+	Variables              []TaskTemplateVariable `json:"variables,omitempty"`
+	Path                   string                 `json:"path,omitempty"` // Default task set path
+	Title                  string                 `json:"title"`
+	Type                   string                 `json:"type,omitempty"`
+	InstructionsFile       string                 `json:"instructions_file,omitempty"`
+	InstructionsFileSource string                 `json:"instructions_file_source,omitempty"`
+	InstructionsText       string                 `json:"instructions_text,omitempty"`
+	Prompt                 string                 `json:"prompt,omitempty"`
+	LLMModelID             string                 `json:"llm_model_id,omitempty"`
+	QA                     *TaskTemplateQA        `json:"qa,omitempty"`
+	Templates              *DefaultTemplates      `json:"templates,omitempty"` // Schemas and report templates of a task set created for the task
+	Env                    map[string]string      `json:"env,omitempty"`
+	Limits                 *TaskLimits            `json:"limits,omitempty"`
+	Priority               int                    `json:"priority,omitempty"`
+}
+
+// TaskTemplateVariable is a value supplied when a task is created from a template
+type TaskTemplateVariable struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Default     string `json:"default,omitempty"`
+	Required    bool   `json:"required,omitempty"` // A value must be supplied; the default is ignored
+}
+
+// TaskTemplateQA is the QA configuration of a task template
+type TaskTemplateQA struct {
+	Enabled                bool   `json:"enabled"`
+	InstructionsFile       string `json:"instructions_file,omitempty"`
+	InstructionsFileSource string `json:"instructions_file_source,omitempty"`
+	InstructionsText       string `json:"instructions_text,omitempty"`
+	Prompt                 string `json:"prompt,omitempty"`
+	LLMModelID             string `json:"llm_model_id,omitempty"`
+}
+
+// ValidateTaskTemplate checks a task template before it is used
+func ValidateTaskTemplate(t *TaskTemplate) error {
+	if t.Title == "" {
+		return fmt.Errorf("title is required")
+	}
+	if t.Prompt == "" && t.InstructionsFile == "" && t.InstructionsText == "" {
+		return fmt.Errorf("at least one prompt field is required: instructions_file, instructions_text, or prompt")
+	}
+	seen := make(map[string]bool, len(t.Variables))
+	for _, v := range t.Variables {
+		if !taskEnvName.MatchString(v.Name) {
+			return fmt.Errorf("invalid variable name %q (must be letters, digits and underscores, not starting with a digit)", v.Name)
+		}
+		if seen[v.Name] {
+			return fmt.Errorf("variable %s is declared more than once", v.Name)
+		}
+		seen[v.Name] = true
+	}
+	if err := ValidateTaskEnv(t.Env); err != nil {
+		return err
+	}
+	if err := t.Limits.Validate(); err != nil {
+		return err
+	}
+	return ValidateTaskPriority(t.Priority)
+}
+
+// Instantiate returns a copy of the template with its variables replaced by
+// values, falling back to their defaults. Values for undeclared variables and
+// missing values for required ones are errors.
+func (t *TaskTemplate) Instantiate(values map[string]string) (*TaskTemplate, error) {
+	declared := make(map[string]bool, len(t.Variables))
+	for _, v := range t.Variables {
+		declared[v.Name] = true
+	}
+	var unknown []string
+	for name := range values {
+		if !declared[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown template variable(s): %s", strings.Join(unknown, ", "))
+	}
+
+	vars := make(map[string]string, len(t.Variables))
+	var missing []string
+	for _, v := range t.Variables {
+		value, ok := values[v.Name]
+		switch {
+		case ok:
+			vars[v.Name] = value
+		case v.Required:
+			missing = append(missing, v.Name)
+		default:
+			vars[v.Name] = v.Default
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing value(s) for required template variable(s): %s", strings.Join(missing, ", "))
+	}
+
+	expand := func(text string) string { return ExpandTaskEnv(text, vars) }
+	out := *t
+	out.Path = expand(t.Path)
+	out.Title = expand(t.Title)
+	out.Type = expand(t.Type)
+	out.InstructionsFile = expand(t.InstructionsFile)
+	out.InstructionsText = expand(t.InstructionsText)
+	out.Prompt = expand(t.Prompt)
+	out.LLMModelID = expand(t.LLMModelID)
+	if t.QA != nil {
+		qa := *t.QA
+		qa.InstructionsFile = expand(qa.InstructionsFile)
+		qa.InstructionsText = expand(qa.InstructionsText)
+		qa.Prompt = expand(qa.Prompt)
+		qa.LLMModelID = expand(qa.LLMModelID)
+		out.QA = &qa
+	}
+	if t.Env != nil {
+		out.Env = make(map[string]string, len(t.Env))
+		for name, value := range t.Env {
+			out.Env[name] = expand(value)
+		}
+	}
+	return &out, nil
+}
@@ -0,0 +1,60 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import "testing"
+
+func TestTaskTemplateInstantiate(t *testing.T) {
+	tmpl := &TaskTemplate{
+		Variables: []TaskTemplateVariable{
+			{Name: "SYSTEM", Required: true},
+			{Name: "FRAMEWORK", Default: "ISO 27001"},
+		},
+		Path:   "review/${SYSTEM}",
+		Title:  "Review ${SYSTEM} against ${FRAMEWORK}",
+		Prompt: "Assess ${SYSTEM}. Hosts: ${HOSTS}",
+		QA:     &TaskTemplateQA{Enabled: true, Prompt: "Check the ${SYSTEM} review"},
+		Env:    map[string]string{"HOSTS": "${SYSTEM}-01"},
+	}
+	if err := ValidateTaskTemplate(tmpl); err != nil {
+		t.Fatalf("ValidateTaskTemplate() error = %v", err)
+	}
+
+	task, err := tmpl.Instantiate(map[string]string{"SYSTEM": "payroll"})
+	if err != nil {
+		t.Fatalf("Instantiate() error = %v", err)
+	}
+	if task.Path != "review/payroll" || task.Title != "Review payroll against ISO 27001" {
+		t.Errorf("Instantiate() path %q, title %q", task.Path, task.Title)
+	}
+	// References to names that are not variables are left for the task's env
+	if task.Prompt != "Assess payroll. Hosts: ${HOSTS}" || task.QA.Prompt != "Check the payroll review" || task.Env["HOSTS"] != "payroll-01" {
+		t.Errorf("Instantiate() prompt %q, QA prompt %q, env %v", task.Prompt, task.QA.Prompt, task.Env)
+	}
+	if tmpl.Title != "Review ${SYSTEM} against ${FRAMEWORK}" || tmpl.QA.Prompt != "Check the ${SYSTEM} review" {
+		t.Error("Instantiate() changed the template")
+	}
+
+	if _, err := tmpl.Instantiate(nil); err == nil {
+		t.Error("Instantiate() accepted a missing required variable")
+	}
+	if _, err := tmpl.Instantiate(map[string]string{"SYSTEM": "payroll", "OWNER": "jane"}); err == nil {
+		t.Error("Instantiate() accepted an unknown variable")
+	}
+
+	invalid := []*TaskTemplate{
+		{Prompt: "No title"},
+		{Title: "No prompt"},
+		{Title: "Bad variable", Prompt: "x", Variables: []TaskTemplateVariable{{Name: "1X"}}},
+		{Title: "Duplicate", Prompt: "x", Variables: []TaskTemplateVariable{{Name: "X"}, {Name: "X"}}},
+		{Title: "Priority", Prompt: "x", Priority: MaxTaskPriority + 1},
+	}
+	for _, tmpl := range invalid {
+		if err := ValidateTaskTemplate(tmpl); err == nil {
+			t.Errorf("ValidateTaskTemplate(%q) accepted an invalid template", tmpl.Title)
+		}
+	}
+}
@@ -26,6 +26,155 @@ type Project struct {
 	DisclaimerTemplate string                `json:"disclaimer_template,omitempty"` // Path to disclaimer MD file (e.g., "playbook/templates/disclaimer.md")
 	ReportManifest     []ReportManifestEntry `json:"report_manifest,omitempty"`     // Ordered list of tasksets contributing to report
 	ReportSequence     int                   `json:"report_sequence,omitempty"`     // Counter for manifest ordering
+	OutputLanguage     string                `json:"output_language,omitempty"`     // Required response language (ISO 639-1 code, e.g. "fr")
+	FinalizedReports   []FinalizedReport     `json:"finalized_reports,omitempty"`   // Frozen deliverables archived by report_finalize
+	ReportSessions     []ReportSession       `json:"report_sessions,omitempty"`     // Report sessions started, oldest first
+	DoneAt             *time.Time            `json:"done_at,omitempty"`             // When the status last became done
+	Retention          *ProjectRetention     `json:"retention,omitempty"`           // Purge policy applied after the project is done
+	PurgedAt           *time.Time            `json:"purged_at,omitempty"`           // When the retention policy was applied
+	AllowedLLMs        []string              `json:"allowed_llms,omitempty"`        // LLM IDs the project may use (any when empty)
+	PromptRedaction    *PromptRedaction      `json:"prompt_redaction,omitempty"`    // Redaction of prompts, added to the configured one
+}
+
+// ProjectRetention purges a project's data a number of days after its status
+// becomes done, to honour client data retention commitments
+type ProjectRetention struct {
+	Days    int      `json:"days"`              // Days after done_at before the purge
+	Action  string   `json:"action,omitempty"`  // "delete" (default) or "anonymize"
+	Include []string `json:"include,omitempty"` // "results", "logs" and/or "files" (default: all)
+}
+
+// ProjectPurgeSummary reports the outcome of applying a project retention policy
+type ProjectPurgeSummary struct {
+	Project     string             `json:"project"`
+	Action      string             `json:"action"`
+	Include     []string           `json:"include"`
+	DryRun      bool               `json:"dry_run,omitempty"`
+	DoneAt      *time.Time         `json:"done_at,omitempty"`
+	DueAt       *time.Time         `json:"due_at,omitempty"` // When the purge is due (unset while the project is not done)
+	Due         bool               `json:"due"`
+	Deleted     int                `json:"deleted"`
+	Anonymized  int                `json:"anonymized"`
+	Identifiers int                `json:"identifiers,omitempty"` // Personal identifiers replaced
+	BytesFreed  int64              `json:"bytes_freed"`
+	Files       []ProjectPurgeFile `json:"files,omitempty"`
+}
+
+// ProjectPurgeFile describes one file deleted or anonymized by a retention purge
+type ProjectPurgeFile struct {
+	Path        string `json:"path"`   // Relative to the project directory
+	Scope       string `json:"scope"`  // "results", "logs" or "files"
+	Action      string `json:"action"` // "delete" or "anonymize"
+	Size        int64  `json:"size"`
+	Identifiers int    `json:"identifiers,omitempty"` // Personal identifiers replaced
+	Reason      string `json:"reason,omitempty"`      // Why an anonymize purge deleted the file
+}
+
+// FinalizedReport records a report session frozen into an immutable archive
+type FinalizedReport struct {
+	Prefix      string    `json:"prefix"`       // Report session prefix that was finalized
+	ArchivePath string    `json:"archive_path"` // Archive directory relative to the project (e.g., "reports/archive/20251219-1234-ISO-Audit")
+	Checksum    string    `json:"checksum"`     // SHA-256 of the archive's SHA256SUMS file
+	FileCount   int       `json:"file_count"`   // Number of archived files
+	FinalizedAt time.Time `json:"finalized_at"`
+}
+
+// ReportSession records a report session of a project, so its prefix is not
+// reused by a later session
+type ReportSession struct {
+	Prefix    string     `json:"prefix"`
+	Title     string     `json:"title,omitempty"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"` // Unset while the session is active
+	Finalized bool       `json:"finalized,omitempty"`
+}
+
+// ProjectSnapshot records a read-only view of a project at a point in time.
+// Snapshot files are hard links to the project's files where possible, so a
+// snapshot costs little space until the project's files are replaced.
+type ProjectSnapshot struct {
+	ID        string    `json:"id"`              // Snapshot ID, passed as "snapshot" to read tools
+	Project   string    `json:"project"`         // Project the snapshot belongs to
+	Label     string    `json:"label,omitempty"` // Optional description (e.g., "Delivered to client")
+	CreatedAt time.Time `json:"created_at"`
+	Files     int       `json:"files"`     // Project files captured
+	Results   int       `json:"results"`   // Result files captured
+	Reports   int       `json:"reports"`   // Reports captured
+	TaskSets  int       `json:"task_sets"` // Task set files captured
+	Linked    int       `json:"linked"`    // Files shared with the project by hard link
+	Copied    int       `json:"copied"`    // Files copied (hard links unavailable)
+	SizeBytes int64     `json:"size_bytes"`
+}
+
+// ExportManifest is stored in an export archive to identify its contents
+type ExportManifest struct {
+	Kind           string       `json:"kind"`                  // What was exported ("project" or "playbook")
+	FormatVersion  int          `json:"format_version"`        // Archive layout version
+	Name           string       `json:"name"`                  // Name of the exported project or playbook
+	Version        string       `json:"version,omitempty"`     // Playbooks: version of the bundle
+	Description    string       `json:"description,omitempty"` // Playbooks: what the bundle is for
+	MaestroVersion string       `json:"maestro_version"`       // Version of the exporting instance
+	ExportedAt     time.Time    `json:"exported_at"`
+	Files          []ExportFile `json:"files"` // Every file in the archive except the manifest
+}
+
+// ExportFile describes a file in an export archive
+type ExportFile struct {
+	Path   string `json:"path"` // Path relative to the exported directory
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// ExportResult describes an archive written to the exports directory
+type ExportResult struct {
+	Name       string           `json:"name"`    // Exported project or playbook
+	Archive    string           `json:"archive"` // Archive file name in the exports directory
+	Path       string           `json:"path"`    // Absolute path of the archive
+	Format     string           `json:"format"`  // "zip" or "tar.gz"
+	Files      int              `json:"files"`
+	SizeBytes  int64            `json:"size_bytes"`
+	Anonymized []RedactionCount `json:"anonymized,omitempty"` // Distinct values replaced by placeholders, by kind
+	Mapping    string           `json:"mapping,omitempty"`    // File in the exports directory holding the placeholders' values
+	Skipped    []string         `json:"skipped,omitempty"`    // Binary files left out of an anonymized export
+}
+
+// AnonymizationMapping holds the values behind the placeholders of an
+// anonymized export. It is written beside the archive, never into it.
+type AnonymizationMapping struct {
+	Project      string            `json:"project"`
+	Archive      string            `json:"archive"`
+	CreatedAt    time.Time         `json:"created_at"`
+	Placeholders map[string]string `json:"placeholders"` // Placeholder → original value
+}
+
+// ImportResult describes an archive imported from the exports directory
+type ImportResult struct {
+	Name           string    `json:"name"`          // Name the archive was imported as
+	Archive        string    `json:"archive"`       // Archive file name in the exports directory
+	ExportedName   string    `json:"exported_name"` // Name the archive was exported from
+	Version        string    `json:"version,omitempty"`
+	Description    string    `json:"description,omitempty"`
+	MaestroVersion string    `json:"maestro_version,omitempty"`
+	ExportedAt     time.Time `json:"exported_at"`
+	Files          int       `json:"files"`
+	Restored       int       `json:"restored,omitempty"` // Files whose placeholders were replaced by their original values
+}
+
+// ReportArchiveFile describes a single file in a report archive manifest
+type ReportArchiveFile struct {
+	Path   string `json:"path"` // Path relative to the archive root (e.g., "results/<uuid>.json")
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// ReportArchiveManifest is written to MANIFEST.json at the root of a report archive
+type ReportArchiveManifest struct {
+	Project     string              `json:"project"`
+	Prefix      string              `json:"prefix"`
+	ReportTitle string              `json:"report_title,omitempty"`
+	FinalizedAt time.Time           `json:"finalized_at"`
+	Checksum    string              `json:"checksum"` // SHA-256 of SHA256SUMS
+	Files       []ReportArchiveFile `json:"files"`
 }
 
 // ReportManifestEntry represents a taskset's contribution to the report
@@ -46,18 +195,366 @@ type DefaultTemplates struct {
 // When a template path ends in .json, it's parsed as []ReportTemplateConfig.
 // When it ends in .md, it's treated as a single template with suffix "Report".
 type ReportTemplateConfig struct {
-	Suffix string `json:"suffix"` // Report suffix (e.g., "Report", "Internal", "Summary")
-	File   string `json:"file"`   // Template file path relative to manifest location
+	Suffix         string `json:"suffix"`                    // Report suffix (e.g., "Report", "Internal", "Summary")
+	File           string `json:"file"`                      // Template file path relative to manifest location
+	Title          string `json:"title,omitempty"`           // Report heading, shown after the report session title
+	Description    string `json:"description,omitempty"`     // Shown under the heading and in the report index
+	Audience       string `json:"audience,omitempty"`        // Intended readers (e.g., "Client", "Internal team")
+	Order          int    `json:"order,omitempty"`           // Generation and index order (lower = earlier)
+	IncludeSummary bool   `json:"include_summary,omitempty"` // Prepend summary statistics to each generated section
+	IncludeTrends  bool   `json:"include_trends,omitempty"`  // Prepend the per-run metric trends (trends.jsonl)
+	Aggregate      string `json:"aggregate,omitempty"`       // Template rendered once over all task results, before the per-task detail
+
+	// Renderings written beside the Markdown report for delivery
+	Formats []string `json:"formats,omitempty"` // Also render the report as "html" and/or "pdf"
+	Theme   string   `json:"theme,omitempty"`   // CSS theme of the HTML rendering, relative to the manifest location
+
+	// Variant settings tailor one report to its audience from the same results
+	Variant         string   `json:"variant,omitempty"`          // Name exposed to templates as _variant (default: the suffix)
+	IncludeFields   []string `json:"include_fields,omitempty"`   // Only these top-level result fields reach the template
+	ExcludeFields   []string `json:"exclude_fields,omitempty"`   // Result fields removed; dotted paths reach nested fields
+	IncludeSections []string `json:"include_sections,omitempty"` // Only task sets under these paths are included
+	ExcludeSections []string `json:"exclude_sections,omitempty"` // Task sets under these paths are left out
+}
+
+// ReportIndexEntry describes one report file in the per-run report index
+type ReportIndexEntry struct {
+	File        string `json:"file"`
+	Suffix      string `json:"suffix"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Audience    string `json:"audience,omitempty"`
+	// PIIReview lists the report's sections whose results contain possible PII
+	// (pii_detection) and need manual redaction review before delivery
+	PIIReview []string `json:"pii_review,omitempty"`
+}
+
+// ReportBlock is one generated part of a report, such as a task's section,
+// keyed so that a later run can find it again
+type ReportBlock struct {
+	Key     string
+	Content string
+}
+
+// ReportMergeResult counts how a generated report's blocks were written
+type ReportMergeResult struct {
+	Added     int `json:"added"`
+	Replaced  int `json:"replaced"`
+	Unchanged int `json:"unchanged"`
+}
+
+// ReportSearchMatch is a report section matching a report_search query
+type ReportSearchMatch struct {
+	Project string `json:"project"`
+	Report  string `json:"report"`            // Report filename
+	Title   string `json:"title,omitempty"`   // The report's level 1 heading
+	Heading string `json:"heading,omitempty"` // Heading of the matching section ("" before the first heading)
+	Line    int    `json:"line"`              // Line of the section heading, from 1
+	Snippet string `json:"snippet"`
+	Score   int    `json:"score"` // Query term occurrences, heading matches counting triple
+}
+
+// ReportSearchResult is the response of report_search
+type ReportSearchResult struct {
+	Query   string              `json:"query"`
+	Project string              `json:"project,omitempty"` // Empty when all projects were searched
+	Matches []ReportSearchMatch `json:"matches"`
+	Total   int                 `json:"total"`
+	Count   int                 `json:"count"`
+}
+
+// ReportLanguage maps a structured confidence field in results to the phrasing
+// report templates use for a finding, so wording tracks how well it was verified
+// (e.g. "confirms" for a verified finding, "suggests" for a medium-confidence one)
+type ReportLanguage struct {
+	ConfidenceField string            `json:"confidence_field,omitempty"` // Result field holding the confidence (default: "confidence")
+	Phrases         map[string]string `json:"phrases,omitempty"`          // Confidence value (case-insensitive) -> phrase
+	DefaultPhrase   string            `json:"default_phrase,omitempty"`   // Phrase for missing or unmapped values (default: "indicates")
+}
+
+// WithDefaults returns a copy of ReportLanguage with defaults applied for zero values
+func (l ReportLanguage) WithDefaults() ReportLanguage {
+	result := l
+	if result.ConfidenceField == "" {
+		result.ConfidenceField = DefaultConfidenceField
+	}
+	if len(result.Phrases) == 0 {
+		result.Phrases = map[string]string{
+			"confirmed":  "confirms",
+			"verified":   "confirms",
+			"high":       "indicates",
+			"medium":     "suggests",
+			"low":        "may indicate",
+			"unverified": "may indicate",
+		}
+	}
+	if result.DefaultPhrase == "" {
+		result.DefaultPhrase = DefaultConfidencePhrase
+	}
+	return result
+}
+
+// ReportAttribution configures the inline marker added to every report section
+// rendered from LLM output, to meet AI-disclosure policies at the finding level
+type ReportAttribution struct {
+	Enabled bool   `json:"enabled,omitempty"`
+	Format  string `json:"format,omitempty"` // Marker text; {model} and {date} are replaced (default: "*AI-generated content ({model}, {date})*")
+}
+
+// WithDefaults returns a copy of ReportAttribution with defaults applied for zero values
+func (a ReportAttribution) WithDefaults() ReportAttribution {
+	result := a
+	if result.Format == "" {
+		result.Format = DefaultAttributionFormat
+	}
+	return result
+}
+
+// Redaction configures the masking of secrets in everything Maestro writes to logs,
+// project logs and result files (including history). Built-in patterns and the
+// values of credential environment variables are always masked unless disabled.
+type Redaction struct {
+	Disabled    bool     `json:"disabled,omitempty"`
+	Patterns    []string `json:"patterns,omitempty"`    // Additional regular expressions to mask
+	EnvVars     []string `json:"env_vars,omitempty"`    // Additional environment variables whose values are masked
+	Replacement string   `json:"replacement,omitempty"` // Text substituted for each match (default: "[REDACTED]")
+}
+
+// WithDefaults returns a copy of Redaction with defaults applied for zero values
+func (r Redaction) WithDefaults() Redaction {
+	result := r
+	if result.Replacement == "" {
+		result.Replacement = DefaultRedactionReplacement
+	}
+	return result
+}
+
+// OutputSanitization configures the cleanup of terminal noise in LLM stdout and
+// stderr before they are stored and before results are rendered into reports.
+// Every pass is on unless disabled.
+type OutputSanitization struct {
+	Disabled            bool `json:"disabled,omitempty"`
+	KeepANSI            bool `json:"keep_ansi,omitempty"`             // Keep ANSI escape sequences
+	KeepCarriageReturns bool `json:"keep_carriage_returns,omitempty"` // Keep lines redrawn with carriage returns as written
+	KeepControlChars    bool `json:"keep_control_chars,omitempty"`    // Keep non-printable control characters
+}
+
+// PIIDetection configures the scan of each stored worker and QA response for
+// personal identifiers. Findings are attached to the result and flag report
+// sections for manual redaction review; the response itself is not changed.
+type PIIDetection struct {
+	Enabled bool     `json:"enabled,omitempty"`
+	Types   []string `json:"types,omitempty"` // Identifier types to look for (default: all)
+}
+
+// PIIFinding counts the matches of one identifier type in a response
+type PIIFinding struct {
+	Type    string   `json:"type"`
+	Count   int      `json:"count"`
+	Samples []string `json:"samples,omitempty"` // Masked examples, e.g. "***-**-6789"
+}
+
+// PIIReport summarizes the personal identifiers found in a response
+type PIIReport struct {
+	Total    int          `json:"total"`
+	Findings []PIIFinding `json:"findings"`
+}
+
+// PromptRedaction replaces personal identifiers, secrets and custom terms in
+// prompts with placeholders such as [EMAIL_1] before they are sent to an LLM,
+// and puts the values back where the response repeats the placeholders
+type PromptRedaction struct {
+	Enabled bool                  `json:"enabled,omitempty"`
+	Types   []string              `json:"types,omitempty"` // PII types and/or "secret" to replace (default: all)
+	Rules   []PromptRedactionRule `json:"rules,omitempty"` // Custom patterns and dictionaries
+}
+
+// PromptRedactionRule replaces the matches of a regular expression, the terms
+// of a dictionary (whole words, any case), or both
+type PromptRedactionRule struct {
+	Name    string   `json:"name"` // Placeholder label: "client" gives [CLIENT_1]
+	Pattern string   `json:"pattern,omitempty"`
+	Terms   []string `json:"terms,omitempty"`
+}
+
+// RedactionCount is how many distinct values of one kind a prompt redaction replaced
+type RedactionCount struct {
+	Kind  string `json:"kind"`
+	Count int    `json:"count"`
+}
+
+// Timestamps configures the timezone and formats of the times Maestro writes to
+// project logs, report prefixes and rendered reports. Formats are Go time layouts.
+type Timestamps struct {
+	Timezone     string `json:"timezone,omitempty"`      // IANA name such as "UTC" or "America/Toronto" (default: server local time)
+	LogFormat    string `json:"log_format,omitempty"`    // Project and task log entries (default: RFC 3339)
+	ReportFormat string `json:"report_format,omitempty"` // Date and time in reports (default: "2006-01-02 15:04:05")
+	DateFormat   string `json:"date_format,omitempty"`   // Dates in reports and attribution markers (default: "2006-01-02")
+}
+
+// WithDefaults returns a copy of Timestamps with defaults applied for zero values
+func (t Timestamps) WithDefaults() Timestamps {
+	result := t
+	if result.LogFormat == "" {
+		result.LogFormat = DefaultLogTimestampFormat
+	}
+	if result.ReportFormat == "" {
+		result.ReportFormat = DefaultReportTimestampFormat
+	}
+	if result.DateFormat == "" {
+		result.DateFormat = DefaultReportDateFormat
+	}
+	return result
+}
+
+// Webhook configures an HTTP endpoint notified of run events. With a secret, each
+// request carries an HMAC-SHA256 signature of its body.
+type Webhook struct {
+	URL            string   `json:"url"`
+	Secret         string   `json:"secret,omitempty"`          // HMAC key for the X-Maestro-Signature header
+	Events         []string `json:"events,omitempty"`          // Events to send (default: all)
+	TimeoutSeconds int      `json:"timeout_seconds,omitempty"` // Request timeout (default: 10)
+}
+
+// WithDefaults returns a copy of Webhook with defaults applied for zero values
+func (w Webhook) WithDefaults() Webhook {
+	result := w
+	if len(result.Events) == 0 {
+		result.Events = []string{WebhookEventRunCompleted, WebhookEventTaskEscalated, WebhookEventBudgetExceeded}
+	}
+	if result.TimeoutSeconds <= 0 {
+		result.TimeoutSeconds = DefaultWebhookTimeoutSeconds
+	}
+	return result
+}
+
+// Wants reports whether the webhook subscribes to an event
+func (w Webhook) Wants(event string) bool {
+	for _, e := range w.WithDefaults().Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Embeddings configures the optional semantic search index. Chunks of project
+// files, playbook files and reference documents are embedded with an
+// OpenAI-compatible embeddings endpoint (e.g. OpenAI, Ollama, vLLM).
+type Embeddings struct {
+	Endpoint       string `json:"endpoint,omitempty"`        // URL of the embeddings API (e.g. http://localhost:11434/v1/embeddings)
+	Model          string `json:"model,omitempty"`           // Embedding model name
+	APIKeyEnv      string `json:"api_key_env,omitempty"`     // Environment variable holding the API key (sent as a bearer token)
+	ChunkSize      int    `json:"chunk_size,omitempty"`      // Characters per chunk (default: 2000)
+	ChunkOverlap   int    `json:"chunk_overlap,omitempty"`   // Characters shared by consecutive chunks (default: 200)
+	BatchSize      int    `json:"batch_size,omitempty"`      // Chunks per request (default: 32)
+	MaxFileKB      int    `json:"max_file_kb,omitempty"`     // Larger files are skipped (default: 1024)
+	TimeoutSeconds int    `json:"timeout_seconds,omitempty"` // Request timeout (default: 60)
+}
+
+// Enabled reports whether an embeddings endpoint is configured
+func (e Embeddings) Enabled() bool {
+	return e.Endpoint != "" && e.Model != ""
+}
+
+// WithDefaults returns a copy of Embeddings with defaults applied for zero values
+func (e Embeddings) WithDefaults() Embeddings {
+	result := e
+	if result.ChunkSize <= 0 {
+		result.ChunkSize = DefaultEmbeddingsChunkSize
+	}
+	if result.ChunkOverlap <= 0 {
+		result.ChunkOverlap = DefaultEmbeddingsChunkOverlap
+	}
+	if result.ChunkOverlap >= result.ChunkSize {
+		result.ChunkOverlap = result.ChunkSize / 10
+	}
+	if result.BatchSize <= 0 {
+		result.BatchSize = DefaultEmbeddingsBatchSize
+	}
+	if result.MaxFileKB <= 0 {
+		result.MaxFileKB = DefaultEmbeddingsMaxFileKB
+	}
+	if result.TimeoutSeconds <= 0 {
+		result.TimeoutSeconds = DefaultEmbeddingsTimeoutSeconds
+	}
+	return result
+}
+
+// ConversionJob reports the progress of a document conversion. Paths are
+// relative to the files directory the conversion runs in.
+type ConversionJob struct {
+	ID          string           `json:"id"`
+	Project     string           `json:"project,omitempty"` // Empty for the shared evidence library
+	Path        string           `json:"path"`
+	Recursive   bool             `json:"recursive"`
+	Status      string           `json:"status"` // running or completed
+	Concurrency int              `json:"concurrency"`
+	Total       int              `json:"total"`     // Files to convert
+	Processed   int              `json:"processed"` // Files converted, skipped or failed so far
+	Converted   int              `json:"converted"`
+	Skipped     int              `json:"skipped"`
+	Failed      int              `json:"failed"`
+	LowQuality  int              `json:"low_quality,omitempty"` // Converted files flagged as low quality
+	StartedAt   time.Time        `json:"started_at"`
+	FinishedAt  *time.Time       `json:"finished_at,omitempty"`
+	Files       []ConversionFile `json:"files,omitempty"`
+}
+
+// ConversionFile is the outcome of converting one file
+type ConversionFile struct {
+	Path       string              `json:"path"`
+	Output     string              `json:"output,omitempty"`
+	Status     string              `json:"status"`           // pending, converting, converted, skipped or failed
+	Reason     string              `json:"reason,omitempty"` // Why the file was skipped or failed
+	DurationMs int64               `json:"duration_ms,omitempty"`
+	Metadata   *ConversionMetadata `json:"metadata,omitempty"` // Converted files only
+}
+
+// ConversionMetadata describes the output of a converted file. Pages and empty
+// pages are known for PDFs converted by text extraction. Warnings include the
+// pipeline steps that failed or fell short before the one used.
+type ConversionMetadata struct {
+	Method         string   `json:"method"` // Name of the pipeline step that produced the output
+	Pages          int      `json:"pages,omitempty"`
+	EmptyPages     int      `json:"empty_pages,omitempty"`
+	EmptyPageRatio float64  `json:"empty_page_ratio,omitempty"`
+	Characters     int      `json:"characters"`
+	LowQuality     bool     `json:"low_quality,omitempty"` // The output does not meet the quality thresholds
+	Warnings       []string `json:"warnings,omitempty"`
+}
+
+// WebhookPayload is the JSON body posted to webhooks
+type WebhookPayload struct {
+	Event     string       `json:"event"` // "run_completed", "task_escalated" or "budget_exceeded"
+	Project   string       `json:"project"`
+	Path      string       `json:"path,omitempty"`
+	Timestamp time.Time    `json:"timestamp"`
+	Message   string       `json:"message,omitempty"`
+	Run       *RunResult   `json:"run,omitempty"`     // Run summary (run_completed, budget_exceeded)
+	Task      *WebhookTask `json:"task,omitempty"`    // Escalated task (task_escalated)
+	Reports   []string     `json:"reports,omitempty"` // Generated reports, relative to the project directory
+}
+
+// WebhookTask identifies the task a webhook event is about
+type WebhookTask struct {
+	ID         int    `json:"id"`
+	UUID       string `json:"uuid"`
+	ExternalID string `json:"external_id,omitempty"`
+	Title      string `json:"title"`
+	Path       string `json:"path"`
 }
 
 // Limits controls execution limits for tasks
 // MaxRetries: Infrastructure retries (network failures, command timeouts) - no LLM cost
 // MaxWorker: Maximum worker LLM invocations per task (billable)
 // MaxQA: Maximum QA iterations per task (billable)
+// MaxCostUSD: Maximum estimated spend per run, across all tasks
 type Limits struct {
-	MaxRetries int `json:"max_retries,omitempty"`
-	MaxWorker  int `json:"max_worker,omitempty"`
-	MaxQA      int `json:"max_qa,omitempty"`
+	MaxRetries int     `json:"max_retries,omitempty"`
+	MaxWorker  int     `json:"max_worker,omitempty"`
+	MaxQA      int     `json:"max_qa,omitempty"`
+	MaxCostUSD float64 `json:"max_cost_usd,omitempty"` // Halts a run once its estimated spend reaches this (0 = no limit)
 }
 
 // WithDefaults returns a copy of Limits with defaults applied for zero values
@@ -75,36 +572,115 @@ func (l Limits) WithDefaults() Limits {
 	return result
 }
 
+// TaskLimits overrides the limits of a task's task set for that task alone.
+// Zero fields inherit; Timeout (seconds) replaces the LLM's timeout.
+type TaskLimits struct {
+	MaxRetries int `json:"max_retries,omitempty"`
+	MaxWorker  int `json:"max_worker,omitempty"`
+	MaxQA      int `json:"max_qa,omitempty"`
+	Timeout    int `json:"timeout,omitempty"`
+}
+
+// IsEmpty reports whether no override is set
+func (l *TaskLimits) IsEmpty() bool {
+	return l == nil || *l == TaskLimits{}
+}
+
+// Validate checks each override that is set against the bounds of its setting
+func (l *TaskLimits) Validate() error {
+	if l == nil {
+		return nil
+	}
+	checks := []struct {
+		value    int
+		validate func(int) (int, error)
+	}{
+		{l.MaxRetries, ValidateMaxRetries},
+		{l.MaxWorker, ValidateMaxWorker},
+		{l.MaxQA, ValidateMaxQA},
+		{l.Timeout, ValidateTimeout},
+	}
+	for _, c := range checks {
+		if c.value == 0 {
+			continue
+		}
+		if _, err := c.validate(c.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ForTask returns a copy of Limits with the task's own limits applied
+func (l Limits) ForTask(task *Task) Limits {
+	if task == nil || task.Limits == nil {
+		return l
+	}
+	result := l
+	if task.Limits.MaxRetries > 0 {
+		result.MaxRetries = task.Limits.MaxRetries
+	}
+	if task.Limits.MaxWorker > 0 {
+		result.MaxWorker = task.Limits.MaxWorker
+	}
+	if task.Limits.MaxQA > 0 {
+		result.MaxQA = task.Limits.MaxQA
+	}
+	return result
+}
+
 // TaskSet represents a collection of tasks at a specific path
 type TaskSet struct {
-	Path                   string    `json:"path"`
-	Title                  string    `json:"title"`
-	Description            string    `json:"description,omitempty"`
-	WorkerResponseTemplate string    `json:"worker_response_template,omitempty"`
-	WorkerReportTemplate   string    `json:"worker_report_template,omitempty"`
-	QAResponseTemplate     string    `json:"qa_response_template,omitempty"`
-	QAReportTemplate       string    `json:"qa_report_template,omitempty"`
-	Parallel               bool      `json:"parallel"`
-	Limits                 Limits    `json:"limits,omitempty"` // Execution limits for tasks in this set
-	SkipValidation         bool      `json:"skip_validation,omitempty"`
-	CallbackURL            string     `json:"callback_url,omitempty"`
-	CallbackedAt           *time.Time `json:"callbacked_at,omitempty"`
-	CreatedAt              time.Time  `json:"created_at"`
-	UpdatedAt              time.Time  `json:"updated_at"`
-	Tasks                  []Task    `json:"tasks"`
+	Path                   string         `json:"path"`
+	Title                  string         `json:"title"`
+	Description            string         `json:"description,omitempty"`
+	WorkerResponseTemplate string         `json:"worker_response_template,omitempty"`
+	WorkerReportTemplate   string         `json:"worker_report_template,omitempty"`
+	QAResponseTemplate     string         `json:"qa_response_template,omitempty"`
+	QAReportTemplate       string         `json:"qa_report_template,omitempty"`
+	RevisionPromptTemplate string         `json:"revision_prompt_template,omitempty"` // Go template replacing the QA feedback of revision prompts
+	Parallel               bool           `json:"parallel"`
+	Limits                 Limits         `json:"limits,omitempty"` // Execution limits for tasks in this set
+	SkipValidation         bool           `json:"skip_validation,omitempty"`
+	CallbackURL            string         `json:"callback_url,omitempty"`
+	CallbackedAt           *time.Time     `json:"callbacked_at,omitempty"`
+	OutputLanguage         string         `json:"output_language,omitempty"`  // Overrides the project output language
+	QADefaults             *QADefaults    `json:"qa_defaults,omitempty"`      // QA instructions inherited by tasks with QA enabled
+	QASkipRules            []QASkipRule   `json:"qa_skip_rules,omitempty"`    // Skip QA for validated responses that match a rule
+	QAFields               *QAFieldMap    `json:"qa_fields,omitempty"`        // Where a nonstandard QA schema keeps the verdict, feedback and severity
+	QAHaltSeverity         string         `json:"qa_halt_severity,omitempty"` // Halt the run when QA reports this severity or worse
+	Approval               *ApprovalGate  `json:"approval,omitempty"`         // QA results that need human sign-off before the task is done
+	WorkerTools            *WorkerTools   `json:"worker_tools,omitempty"`     // Read-only project tools the worker may call during a task
+	ResultSummary          *ResultSummary `json:"result_summary,omitempty"`   // How the summary stored in each result is made
+	DependsOn              []string       `json:"depends_on,omitempty"`       // Task sets whose tasks must all be done before these run
+	Sampling               []ListSampling `json:"sampling,omitempty"`         // Samples the tasks were created from, oldest first
+	CreatedAt              time.Time      `json:"created_at"`
+	UpdatedAt              time.Time      `json:"updated_at"`
+	Tasks                  []Task         `json:"tasks"`
 }
 
 // Task represents a unit of work within a task set
 // Note: Results and history are stored in results/<uuid>.json files, not in tasks.json
 type Task struct {
-	ID        int           `json:"id"`
-	UUID      string        `json:"uuid"`
-	Title     string        `json:"title"`
-	Type      string        `json:"type,omitempty"`
-	CreatedAt time.Time     `json:"created_at"`
-	UpdatedAt time.Time     `json:"updated_at"`
-	Work      WorkExecution `json:"work"`
-	QA        QAExecution   `json:"qa"`
+	ID         int               `json:"id"`
+	UUID       string            `json:"uuid"`
+	ExternalID string            `json:"external_id,omitempty"` // Caller-assigned ID, unique per project
+	Title      string            `json:"title"`
+	Type       string            `json:"type,omitempty"`
+	DependsOn  []string          `json:"depends_on,omitempty"` // UUIDs or external IDs of tasks that must be done first
+	Env        map[string]string `json:"env,omitempty"`        // Substituted for ${NAME} in instructions and prompts; exported to command LLMs
+	Limits     *TaskLimits       `json:"limits,omitempty"`     // Overrides the task set's limits for this task
+	Priority   int               `json:"priority,omitempty"`   // Higher priorities run first (default 0)
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+	Work       WorkExecution     `json:"work"`
+	QA         QAExecution       `json:"qa"`
+}
+
+// ConversationMessage is one turn of a conversation sent to an LLM
+type ConversationMessage struct {
+	Role    string `json:"role"` // "system", "user" or "assistant"
+	Content string `json:"content"`
 }
 
 // Message represents a single message in the task execution history
@@ -124,6 +700,7 @@ type Message struct {
 	ExitCode     *int   `json:"exit_code,omitempty"` // Command exit code (nil if not applicable, omitempty so prompts don't show it)
 	Stdout       string `json:"stdout"`              // Raw stdout from LLM
 	Stderr       string `json:"stderr"`              // Raw stderr from LLM
+	Text         string `json:"text,omitempty"`      // Parser-extracted response, when it differs from stdout
 	ResponseSize int    `json:"response_size"`       // Size of stdout
 
 	// Provider envelope summary (populated for response messages)
@@ -132,6 +709,7 @@ type Message struct {
 	IsError       bool   `json:"is_error,omitempty"`       // LLM reported an error in its output envelope
 	Success       bool   `json:"success,omitempty"`        // True iff exit==0 AND no provider-reported error
 	NumTurns      int    `json:"num_turns,omitempty"`      // Number of provider turns
+	SessionID     string `json:"session_id,omitempty"`     // Provider session the response belongs to (for resume_args)
 
 	// Resource accounting (mirrors ClawEh DispatchStatus where applicable)
 	InputTokens         int     `json:"input_tokens,omitempty"`
@@ -143,9 +721,23 @@ type Message struct {
 	BytesSent           int64   `json:"bytes_sent,omitempty"`
 	BytesReceived       int64   `json:"bytes_received,omitempty"`
 
+	// Generation parameters the call was made with (response messages; nil when none were set)
+	Generation *GenerationParams `json:"generation,omitempty"`
+	// StructuredOutput is set when the response schema was passed to the LLM natively
+	StructuredOutput bool `json:"structured_output,omitempty"`
+
 	// Infrastructure error - present when command couldn't execute
 	Error string `json:"error,omitempty"` // Infrastructure error message
 
+	// Worker tool call (role "tool"); Content holds the result sent back
+	Tool     string         `json:"tool,omitempty"`
+	ToolArgs map[string]any `json:"tool_args,omitempty"`
+
+	// Human approval decision (role "approver")
+	Decision string `json:"decision,omitempty"` // "approved" or "rejected"
+	Approver string `json:"approver,omitempty"` // Who made the decision
+	Note     string `json:"note,omitempty"`     // Approver's note
+
 	// Legacy fields (for backwards compatibility with existing result files)
 	Type    string `json:"type,omitempty"`    // "prompt", "response", "error", "validation" (deprecated)
 	Content string `json:"content,omitempty"` // The actual message content (deprecated - use Prompt/Stdout)
@@ -158,6 +750,8 @@ type WorkExecution struct {
 	InstructionsFileSource string     `json:"instructions_file_source,omitempty"`
 	InstructionsText       string     `json:"instructions_text,omitempty"`
 	Prompt                 string     `json:"prompt,omitempty"`
+	EvidenceFiles          []string   `json:"evidence_files,omitempty"`     // Project files or glob patterns inlined in the prompt as evidence
+	DependencyResults      bool       `json:"dependency_results,omitempty"` // Inline the worker responses of depends_on tasks in the prompt
 	LLMModelID             string     `json:"llm_model_id,omitempty"`
 	Status                 string     `json:"status"`
 	Error                  string     `json:"error,omitempty"`
@@ -165,6 +759,155 @@ type WorkExecution struct {
 	Invocations            int        `json:"invocations"`               // Number of worker LLM invocations (any exit code)
 	InfraRetries           int        `json:"infra_retries,omitempty"`   // Infrastructure failures (couldn't execute)
 	LastAttemptAt          *time.Time `json:"last_attempt_at,omitempty"` // For retry delay calculation
+	HoldReason             string     `json:"hold_reason,omitempty"`     // Why the task is on_hold (cleared when it leaves on_hold)
+}
+
+// QADefaults holds the QA instructions a task set provides to its tasks with QA
+// enabled. Each field applies to the tasks that leave it empty.
+type QADefaults struct {
+	InstructionsFile       string `json:"instructions_file,omitempty"`
+	InstructionsFileSource string `json:"instructions_file_source,omitempty"`
+	InstructionsText       string `json:"instructions_text,omitempty"`
+	Prompt                 string `json:"prompt,omitempty"`
+}
+
+// IsEmpty reports whether no default is set
+func (d *QADefaults) IsEmpty() bool {
+	return d == nil || *d == QADefaults{}
+}
+
+// Apply returns a copy of qa with the defaults filled in for the instructions
+// and prompt the task does not set. The file source is inherited only with the
+// file, so a task's own instructions file keeps its own source.
+func (d *QADefaults) Apply(qa QAExecution) QAExecution {
+	if d == nil {
+		return qa
+	}
+	if qa.InstructionsFile == "" {
+		qa.InstructionsFile = d.InstructionsFile
+		qa.InstructionsFileSource = d.InstructionsFileSource
+	}
+	if qa.InstructionsText == "" {
+		qa.InstructionsText = d.InstructionsText
+	}
+	if qa.Prompt == "" {
+		qa.Prompt = d.Prompt
+	}
+	return qa
+}
+
+// QASkipRule skips the QA LLM call for a task whose schema-validated worker
+// response satisfies every condition of the rule
+type QASkipRule struct {
+	Name       string           `json:"name"`
+	Conditions []FieldCondition `json:"conditions"`
+}
+
+// QAFieldMap tells the runner where a nonstandard QA response schema keeps the
+// standardized fields. Each field is a dot-separated path into the response (a
+// leading "$." is accepted); Verdicts maps the schema's own verdict values to
+// pass, fail or escalate.
+type QAFieldMap struct {
+	Verdict  string            `json:"verdict,omitempty"`  // Default: "verdict"
+	Feedback string            `json:"feedback,omitempty"` // Text given to the worker on revision, with the full response
+	Severity string            `json:"severity,omitempty"` // Default: "severity"
+	Verdicts map[string]string `json:"verdicts,omitempty"` // e.g. {"approved": "pass", "rework": "fail"} (case-insensitive)
+}
+
+// ResultSummary configures the short summary stored in each result of a task
+// set. The summary is the first MaxChars characters of Field (a dot-separated
+// path into a JSON response) or of the whole response; with LLMID, that text is
+// summarized by the LLM instead, falling back to truncation if the call fails.
+type ResultSummary struct {
+	Field    string `json:"field,omitempty"`
+	MaxChars int    `json:"max_chars,omitempty"` // 0 = DefaultResultSummaryChars
+	LLMID    string `json:"llm_id,omitempty"`
+}
+
+// Pipeline declares the task sets of an engagement in a playbook file, so that
+// a complex setup can be reviewed as code and applied to a project with
+// pipeline_apply. Applying it again brings the project back in line with it.
+type Pipeline struct {
+	Name        string            `json:"name,omitempty"`
+	Description string            `json:"description,omitempty"`
+	TaskSets    []PipelineTaskSet `json:"task_sets"`
+}
+
+// PipelineTaskSet declares a task set of a pipeline and the tasks it starts with.
+// LLMModelID and the QA policy apply to the declared tasks that do not set their own.
+type PipelineTaskSet struct {
+	Path           string            `json:"path"`
+	Title          string            `json:"title"`
+	Description    string            `json:"description,omitempty"`
+	DependsOn      []string          `json:"depends_on,omitempty"` // Task set paths whose tasks must all be done first
+	Templates      *DefaultTemplates `json:"templates,omitempty"`  // Response schemas and report templates (or multi-report manifests)
+	Parallel       bool              `json:"parallel,omitempty"`
+	Limits         *Limits           `json:"limits,omitempty"`
+	SkipValidation bool              `json:"skip_validation,omitempty"`
+	OutputLanguage string            `json:"output_language,omitempty"`
+	LLMModelID     string            `json:"llm_model_id,omitempty"` // Worker LLM of the declared tasks
+	QA             *PipelineQA       `json:"qa,omitempty"`
+	ResultSummary  *ResultSummary    `json:"result_summary,omitempty"`
+	Tasks          []PipelineTask    `json:"tasks,omitempty"`
+}
+
+// PipelineQA is the QA policy of a pipeline task set
+type PipelineQA struct {
+	Enabled    bool         `json:"enabled"`                // QA for the declared tasks that do not set qa
+	LLMModelID string       `json:"llm_model_id,omitempty"` // QA LLM of the declared tasks
+	Defaults   *QADefaults  `json:"defaults,omitempty"`     // Task set QA defaults
+	SkipRules  []QASkipRule `json:"skip_rules,omitempty"`
+}
+
+// PipelineTask declares a task of a pipeline task set. Tasks are matched to the
+// tasks of an existing task set by external ID, or by title when they have none.
+type PipelineTask struct {
+	Title                  string            `json:"title"`
+	Type                   string            `json:"type,omitempty"`
+	ExternalID             string            `json:"external_id,omitempty"`
+	DependsOn              []string          `json:"depends_on,omitempty"` // External IDs or UUIDs of tasks that must be done first
+	Env                    map[string]string `json:"env,omitempty"`
+	Limits                 *TaskLimits       `json:"limits,omitempty"`
+	Priority               int               `json:"priority,omitempty"`
+	InstructionsFile       string            `json:"instructions_file,omitempty"`
+	InstructionsFileSource string            `json:"instructions_file_source,omitempty"`
+	InstructionsText       string            `json:"instructions_text,omitempty"`
+	Prompt                 string            `json:"prompt,omitempty"`
+	LLMModelID             string            `json:"llm_model_id,omitempty"`
+	QA                     *bool             `json:"qa,omitempty"` // Overrides qa.enabled of the task set
+}
+
+// PipelineAction is what applying a pipeline does to one task set or task
+type PipelineAction struct {
+	Kind    string            `json:"kind"`              // "taskset" or "task"
+	Target  string            `json:"target"`            // Task set path, or "<path>/<title>" for a task
+	Action  string            `json:"action"`            // created, updated, unchanged or deleted
+	Changes []DiffFieldChange `json:"changes,omitempty"` // Settings that change, for updates; non-string values as JSON
+}
+
+// PipelineApplyResult reports the outcome of pipeline_apply, or with DryRun set
+// what it would do
+type PipelineApplyResult struct {
+	Project   string           `json:"project"`
+	Playbook  string           `json:"playbook"`
+	File      string           `json:"file"`
+	Name      string           `json:"name,omitempty"`
+	DryRun    bool             `json:"dry_run,omitempty"`
+	Created   int              `json:"created"`
+	Updated   int              `json:"updated"`
+	Unchanged int              `json:"unchanged"`
+	Deleted   int              `json:"deleted"`
+	Actions   []PipelineAction `json:"actions"`
+}
+
+// FieldCondition tests a field of a JSON response. Field is a dot-separated path
+// into the response (e.g. "result" or "assessment.status"); values are compared
+// as strings, with numbers, booleans and null in their JSON form.
+type FieldCondition struct {
+	Field  string   `json:"field"`
+	Op     string   `json:"op"`               // equals, not_equals, in, exists
+	Value  string   `json:"value,omitempty"`  // For equals and not_equals
+	Values []string `json:"values,omitempty"` // For in
 }
 
 // QAExecution tracks the QA phase of task execution
@@ -179,8 +922,11 @@ type QAExecution struct {
 	Status                 string `json:"status,omitempty"`
 	Error                  string `json:"error,omitempty"`         // Error message if status is "error"
 	Verdict                string `json:"verdict,omitempty"`       // QA verdict: "pass", "fail", "escalate"
+	Severity               string `json:"severity,omitempty"`      // Optional QA severity: "critical", "high", "medium", "low"
 	Invocations            int    `json:"invocations,omitempty"`   // Number of QA LLM invocations (any exit code)
 	InfraRetries           int    `json:"infra_retries,omitempty"` // Infrastructure failures (couldn't execute)
+	Skipped                bool   `json:"skipped,omitempty"`       // QA was not run because a task set skip rule matched
+	SkipRule               string `json:"skip_rule,omitempty"`     // Name of the skip rule that matched
 }
 
 // ListRef references an item within a list file
@@ -194,10 +940,11 @@ type ListRef struct {
 // Stored in results/<uuid>.json
 type TaskResult struct {
 	// Identity
-	TaskID    int    `json:"task_id"`
-	TaskUUID  string `json:"task_uuid"`
-	TaskTitle string `json:"task_title"`
-	TaskType  string `json:"task_type,omitempty"`
+	TaskID         int    `json:"task_id"`
+	TaskUUID       string `json:"task_uuid"`
+	TaskExternalID string `json:"task_external_id,omitempty"`
+	TaskTitle      string `json:"task_title"`
+	TaskType       string `json:"task_type,omitempty"`
 
 	// Timing
 	CreatedAt   time.Time `json:"created_at"`
@@ -215,6 +962,24 @@ type TaskResult struct {
 	// Supervisor override - when true, supervisor has provided the response
 	// and this task should not be sent to a worker again (except on reset)
 	SupervisorOverride bool `json:"supervisor_override"`
+
+	// Supervisor edits of the worker response, oldest first
+	SupervisorEdits []SupervisorEdit `json:"supervisor_edits,omitempty"`
+
+	// Set when retention removed the full prompts and raw LLM output to save space
+	CompactedAt *time.Time `json:"compacted_at,omitempty"`
+
+	// Short summary of the worker response (see ResultSummary)
+	Summary string `json:"summary,omitempty"`
+}
+
+// SupervisorEdit records one supervisor_update of a worker response: whether it
+// replaced or patched the response, and how the response changed
+type SupervisorEdit struct {
+	EditedAt time.Time    `json:"edited_at"`
+	Mode     string       `json:"mode"`             // SupervisorEditReplace or SupervisorEditPatch
+	Fields   []string     `json:"fields,omitempty"` // JSON fields the edit added, removed or changed
+	Diff     ResponseDiff `json:"diff"`
 }
 
 // WorkerResult contains the complete audit trail for worker execution
@@ -224,6 +989,7 @@ type WorkerResult struct {
 	InstructionsFileSource string `json:"instructions_file_source,omitempty"`
 	InstructionsText       string `json:"instructions_text,omitempty"`
 	TaskPrompt             string `json:"task_prompt,omitempty"`
+	PlaybookVersion        int    `json:"playbook_version,omitempty"` // Version of the playbook the instructions file came from
 
 	// What was actually sent/received
 	FullPrompt        string `json:"full_prompt"` // Complete constructed prompt sent to LLM
@@ -235,6 +1001,13 @@ type WorkerResult struct {
 	ErrorCode         string `json:"error_code,omitempty"`         // Machine-readable failure code (e.g. "no_llm_enabled")
 	NormalTermination bool   `json:"normal_termination,omitempty"` // true when LLM completed normally
 	StopReason        string `json:"stop_reason,omitempty"`        // non-empty only on abnormal termination
+
+	// Conversation holds the earlier turns that FullPrompt continued, for a
+	// revision sent as a conversation rather than a complete prompt
+	Conversation []ConversationMessage `json:"conversation,omitempty"`
+
+	// PII summarizes the personal identifiers found in Response (pii_detection)
+	PII *PIIReport `json:"pii,omitempty"`
 }
 
 // QAResult contains the complete audit trail for QA execution
@@ -244,15 +1017,22 @@ type QAResult struct {
 	InstructionsFileSource string `json:"instructions_file_source,omitempty"`
 	InstructionsText       string `json:"instructions_text,omitempty"`
 	TaskPrompt             string `json:"task_prompt,omitempty"`
+	PlaybookVersion        int    `json:"playbook_version,omitempty"` // Version of the playbook the instructions file came from
 
 	// What was actually sent/received
-	FullPrompt  string `json:"full_prompt"` // Complete QA prompt sent to LLM
-	Response    string `json:"response"`    // Full QA LLM response
-	Verdict     string `json:"verdict"`     // pass/fail/escalate
+	FullPrompt  string `json:"full_prompt"`        // Complete QA prompt sent to LLM
+	Response    string `json:"response"`           // Full QA LLM response
+	Verdict     string `json:"verdict"`            // pass/fail/escalate
+	Severity    string `json:"severity,omitempty"` // critical/high/medium/low, when QA reported one
 	LLMModelID  string `json:"llm_model_id"`
 	Invocations int    `json:"invocations"`
 	Status      string `json:"status"`
 	Error       string `json:"error,omitempty"`
+	Skipped     bool   `json:"skipped,omitempty"`   // QA was not run because a skip rule matched
+	SkipRule    string `json:"skip_rule,omitempty"` // Name of the skip rule that matched
+
+	// PII summarizes the personal identifiers found in Response (pii_detection)
+	PII *PIIReport `json:"pii,omitempty"`
 }
 
 // RunRequest represents a request to run tasks via the runner
@@ -263,16 +1043,564 @@ type RunRequest struct {
 	Parallel *bool  `json:"parallel"`       // Override taskset parallel setting (nil = use taskset setting)
 }
 
+// RunRecovery describes a task set run in recovery mode, waiting for an LLM
+// that failed or was rate limited to become available again
+type RunRecovery struct {
+	Project       string     `json:"project"`
+	Path          string     `json:"path"`
+	LLMID         string     `json:"llm_id"`
+	Since         time.Time  `json:"since"`                   // When recovery was entered or last extended by another failure or probe
+	NextProbeAt   *time.Time `json:"next_probe_at,omitempty"` // When the LLM is next probed (in the past while a probe runs)
+	ScheduleIndex int        `json:"schedule_index"`          // Position in the LLM's recovery test_schedule_seconds (failed probes so far)
+	AbortAt       time.Time  `json:"abort_at"`                // When the run gives up unless the LLM recovers or fails again first
+}
+
+// InflightTask describes an LLM call the runner is waiting on
+type InflightTask struct {
+	TaskUUID    string    `json:"task_uuid"`
+	TaskID      int       `json:"task_id"`
+	Project     string    `json:"project"`
+	Path        string    `json:"path"`
+	Phase       string    `json:"phase"` // "worker", "qa", "revision" or "summary"
+	LLMID       string    `json:"llm_id"`
+	StartedAt   time.Time `json:"started_at"`
+	ElapsedMs   int64     `json:"elapsed_ms"`
+	PromptBytes int       `json:"prompt_bytes"`
+	PID         int       `json:"pid,omitempty"` // Process ID of a command LLM, once started
+}
+
 // RunResult represents the result of a runner execution
 type RunResult struct {
-	Project        string `json:"project"`
-	Path           string `json:"path,omitempty"`
-	TasksFound     int    `json:"tasks_found"`
-	TasksExecuted  int    `json:"tasks_executed"`
-	TasksSucceeded int    `json:"tasks_succeeded"`
-	TasksFailed    int    `json:"tasks_failed"`
-	TasksSkipped   int    `json:"tasks_skipped"` // Max attempts reached or retry delay not elapsed
-	Message        string `json:"message,omitempty"`
+	Project        string  `json:"project"`
+	Path           string  `json:"path,omitempty"`
+	RunID          string  `json:"run_id,omitempty"` // Correlates the run's log records and journal
+	TasksFound     int     `json:"tasks_found"`
+	TasksExecuted  int     `json:"tasks_executed"`
+	TasksSucceeded int     `json:"tasks_succeeded"`
+	TasksFailed    int     `json:"tasks_failed"`
+	TasksSkipped   int     `json:"tasks_skipped"` // Max attempts reached or retry delay not elapsed
+	Message        string  `json:"message,omitempty"`
+	SpentUSD       float64 `json:"spent_usd,omitempty"`        // Estimated LLM spend so far
+	MaxCostUSD     float64 `json:"max_cost_usd,omitempty"`     // Run cost limit, if any
+	CostExceeded   bool    `json:"cost_exceeded,omitempty"`    // Run halted because the cost limit was reached
+	QASeverityHalt bool    `json:"qa_severity_halt,omitempty"` // Run halted because QA reported a severity at the task set's qa_halt_severity
+}
+
+// RunJournalEntry is one line of a run journal (internal/journal/<run id>.jsonl).
+// The journal is written ahead of each step and removed when the run finishes,
+// so a journal left behind marks a run that was interrupted.
+type RunJournalEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Entry     string    `json:"entry"`          // "run_started", "task_started" or "task_ended"
+	Kind      string    `json:"kind,omitempty"` // "run" or "dispatch" (run_started only)
+	Path      string    `json:"path,omitempty"`
+	Type      string    `json:"type,omitempty"`     // Task type filter of the run
+	Parallel  *bool     `json:"parallel,omitempty"` // Parallel override of the run
+	TaskUUID  string    `json:"task_uuid,omitempty"`
+	TaskID    int       `json:"task_id,omitempty"`
+	PID       int       `json:"pid,omitempty"`  // Process running the run (run_started only)
+	Host      string    `json:"host,omitempty"` // Host of that process (run_started only)
+}
+
+// InterruptedTask is a task that was in flight when a run was interrupted
+type InterruptedTask struct {
+	TaskUUID string `json:"task_uuid"`
+	TaskID   int    `json:"task_id"`
+	Path     string `json:"path"`
+}
+
+// InterruptedRun is a run whose journal was left behind, e.g. by a crash
+type InterruptedRun struct {
+	RunID     string            `json:"run_id"`
+	Kind      string            `json:"kind"`
+	Path      string            `json:"path,omitempty"`
+	Type      string            `json:"type,omitempty"`
+	Parallel  *bool             `json:"parallel,omitempty"`
+	StartedAt time.Time         `json:"started_at"`
+	InFlight  []InterruptedTask `json:"in_flight"`
+	Action    string            `json:"action,omitempty"` // "resumed", "failed" or "pending"
+}
+
+// StaleTask is a task found in processing at startup with no live run to
+// finish it, and returned to waiting
+type StaleTask struct {
+	Project  string `json:"project"`
+	Path     string `json:"path"`
+	TaskUUID string `json:"task_uuid"`
+	TaskID   int    `json:"task_id"`
+	Phase    string `json:"phase"` // "work" or "qa"
+}
+
+// StartupRecovery is what the startup reconciliation of runs found and recovered
+type StartupRecovery struct {
+	At              time.Time   `json:"at"`
+	StaleTasks      []StaleTask `json:"stale_tasks"`               // Tasks reset from processing to waiting
+	InterruptedRuns int         `json:"interrupted_runs"`          // Journals left behind by dead runs
+	Resumed         []string    `json:"resumed,omitempty"`         // Projects whose interrupted runs were resumed
+	AwaitingResume  []string    `json:"awaiting_resume,omitempty"` // Projects with interrupted runs left for task_run_resume
+	LiveElsewhere   []string    `json:"live_elsewhere,omitempty"`  // Projects skipped because another process is running them
+}
+
+// RunResumeResult is returned by task_run_resume
+type RunResumeResult struct {
+	Project string           `json:"project"`
+	DryRun  bool             `json:"dry_run,omitempty"`
+	Runs    []InterruptedRun `json:"runs"`
+	Resumed *RunResult       `json:"resumed,omitempty"` // The run started again, if any
+	Message string           `json:"message"`
+}
+
+// DeliverableResult is the response from deliverable_generate
+type DeliverableResult struct {
+	Project    string   `json:"project"`
+	Template   string   `json:"template"`
+	Output     string   `json:"output"`
+	Findings   int      `json:"findings"`
+	SizeBytes  int      `json:"size_bytes"`
+	Unresolved []string `json:"unresolved,omitempty"` // Placeholders left unfilled
+}
+
+// ProjectDashboard is the machine-readable project summary written to dashboard.json
+type ProjectDashboard struct {
+	Project           string             `json:"project"`
+	GeneratedAt       time.Time          `json:"generated_at"`
+	TotalTasks        int                `json:"total_tasks"`
+	ByStatus          map[string]int     `json:"by_status"`
+	ByQAVerdict       map[string]int     `json:"by_qa_verdict,omitempty"`
+	BySeverity        map[string]int     `json:"by_severity,omitempty"`          // "severity" field of worker results
+	ByQAIssueSeverity map[string]int     `json:"by_qa_issue_severity,omitempty"` // "severity" of issues raised by QA
+	Usage             DashboardUsage     `json:"usage"`
+	TaskSets          []DashboardTaskSet `json:"task_sets"`
+	LastRun           *DashboardRun      `json:"last_run,omitempty"`
+}
+
+// DashboardUsage totals LLM usage recorded in task result histories
+type DashboardUsage struct {
+	LLMCalls            int     `json:"llm_calls"`
+	InputTokens         int     `json:"input_tokens"`
+	OutputTokens        int     `json:"output_tokens"`
+	CacheReadTokens     int     `json:"cache_read_tokens"`
+	CacheCreationTokens int     `json:"cache_creation_tokens"`
+	CostUSD             float64 `json:"cost_usd"`
+}
+
+// DashboardTaskSet holds per-taskset status counts
+type DashboardTaskSet struct {
+	Path       string         `json:"path"`
+	Title      string         `json:"title"`
+	TotalTasks int            `json:"total_tasks"`
+	ByStatus   map[string]int `json:"by_status"`
+}
+
+// DashboardRun describes the most recent runner execution
+type DashboardRun struct {
+	Path           string    `json:"path,omitempty"`
+	StartedAt      time.Time `json:"started_at"`
+	CompletedAt    time.Time `json:"completed_at"`
+	TasksFound     int       `json:"tasks_found"`
+	TasksExecuted  int       `json:"tasks_executed"`
+	TasksSucceeded int       `json:"tasks_succeeded"`
+	TasksFailed    int       `json:"tasks_failed"`
+	TasksSkipped   int       `json:"tasks_skipped"`
+	LLMCalls       int64     `json:"llm_calls"`
+	LLMCallBudget  int64     `json:"llm_call_budget"`
+	BudgetExceeded bool      `json:"budget_exceeded,omitempty"`
+	// Templates maps each referenced schema and template to its checksum when the run started
+	Templates map[string]string `json:"templates,omitempty"`
+}
+
+// TrendPoint records the key project metrics at the end of one run (one line of trends.jsonl)
+type TrendPoint struct {
+	RunAt          time.Time      `json:"run_at"`
+	Path           string         `json:"path,omitempty"`
+	TasksExecuted  int            `json:"tasks_executed"`
+	TasksSucceeded int            `json:"tasks_succeeded"`
+	TasksFailed    int            `json:"tasks_failed"`
+	TotalTasks     int            `json:"total_tasks"`
+	ByStatus       map[string]int `json:"by_status"`
+	BySeverity     map[string]int `json:"by_severity,omitempty"` // "severity" field of worker results
+	QAReviewed     int            `json:"qa_reviewed"`
+	QAPassRate     float64        `json:"qa_pass_rate"` // Fraction of QA-reviewed tasks with verdict "pass"
+	CostUSD        float64        `json:"cost_usd"`     // Total cost recorded in task results
+	RunCostUSD     float64        `json:"run_cost_usd"` // Cost added since the previous point
+	// Templates maps each referenced schema and template to its checksum when the run started
+	Templates map[string]string `json:"templates,omitempty"`
+}
+
+// ProjectTrends is the per-run metric history returned by project_trends
+type ProjectTrends struct {
+	Project string       `json:"project"`
+	Runs    int          `json:"runs"` // Points recorded, before any limit
+	Points  []TrendPoint `json:"points"`
+}
+
+// TemplateRegistryEntry is a schema or template referenced by a project's task sets
+type TemplateRegistryEntry struct {
+	Path             string     `json:"path"`               // As referenced; "inline:<task set>:<kind>" for inline schemas
+	Kind             string     `json:"kind"`               // See RegistryKind constants
+	Source           string     `json:"source"`             // "project", "playbook", "inline" or "missing"
+	Resolved         string     `json:"resolved,omitempty"` // Where the content was loaded from
+	TaskSets         []string   `json:"task_sets"`          // Task sets that reference it
+	Checksum         string     `json:"checksum,omitempty"` // SHA-256 of the content
+	SizeBytes        int64      `json:"size_bytes"`
+	ModifiedAt       *time.Time `json:"modified_at,omitempty"`
+	PlaybookVersion  int        `json:"playbook_version,omitempty"`
+	Changed          bool       `json:"changed,omitempty"`           // Checksum differs from the last run
+	PreviousChecksum string     `json:"previous_checksum,omitempty"` // Checksum at the last run, when changed
+}
+
+// TemplateRegistry catalogs the schemas and templates a project's task sets
+// reference, compared with the checksums recorded by the last run
+type TemplateRegistry struct {
+	Project     string                  `json:"project"`
+	GeneratedAt time.Time               `json:"generated_at"`
+	LastRunAt   *time.Time              `json:"last_run_at,omitempty"`
+	Changed     int                     `json:"changed"` // Entries changed (or added) since the last run
+	Missing     int                     `json:"missing"`
+	Entries     []TemplateRegistryEntry `json:"entries"`
+}
+
+// AuditEntry records one tool invocation that touched a project (one line of audit.jsonl)
+type AuditEntry struct {
+	Timestamp  time.Time `json:"timestamp"`
+	Tool       string    `json:"tool"`
+	ArgsHash   string    `json:"args_hash"` // SHA-256 of the JSON-encoded arguments
+	AgentID    string    `json:"agent_id,omitempty"`
+	Session    string    `json:"session,omitempty"`
+	Channel    string    `json:"channel,omitempty"`
+	Outcome    string    `json:"outcome"` // "ok" or "error"
+	Error      string    `json:"error,omitempty"`
+	DurationMs int64     `json:"duration_ms"`
+}
+
+// ProjectAudit is the audit trail returned by project_audit
+type ProjectAudit struct {
+	Project string       `json:"project"`
+	Matched int          `json:"matched"` // Entries matching the filters, before any limit
+	Entries []AuditEntry `json:"entries"`
+}
+
+// TaskEvent records one task state transition during a run (one line of events.jsonl)
+type TaskEvent struct {
+	Timestamp  time.Time `json:"timestamp"`
+	Event      string    `json:"event"`
+	Path       string    `json:"path"`
+	TaskID     int       `json:"task_id"`
+	TaskUUID   string    `json:"task_uuid"`
+	Phase      string    `json:"phase,omitempty"` // "worker" or "qa"
+	LLMModelID string    `json:"llm_model_id,omitempty"`
+	Detail     string    `json:"detail,omitempty"` // Final status, validation summary, etc.
+}
+
+// TaskEvents is the event stream returned by task_events
+type TaskEvents struct {
+	Project string      `json:"project"`
+	Matched int         `json:"matched"` // Events matching the filters, before any limit
+	Events  []TaskEvent `json:"events"`
+}
+
+// ResultsCleanupSummary reports the outcome of collecting orphaned result files
+type ResultsCleanupSummary struct {
+	Project     string               `json:"project"`
+	Action      string               `json:"action"` // "delete" or "archive"
+	DryRun      bool                 `json:"dry_run,omitempty"`
+	MinAgeHours int                  `json:"min_age_hours"`
+	Scanned     int                  `json:"scanned"`
+	Collected   int                  `json:"collected"`
+	Bytes       int64                `json:"bytes"`
+	ByReason    map[string]int       `json:"by_reason,omitempty"`
+	ArchiveDir  string               `json:"archive_dir,omitempty"` // Relative to the results directory
+	Files       []ResultsCleanupFile `json:"files,omitempty"`
+}
+
+// ResultsCleanupFile describes one orphaned file found by a cleanup
+type ResultsCleanupFile struct {
+	Path   string `json:"path"` // Relative to the results directory
+	Reason string `json:"reason"`
+	Size   int64  `json:"size"`
+}
+
+// ResultsRetention limits the result files a project keeps. Files beyond a limit
+// are pruned oldest first: compacted (full prompts and raw LLM output removed) or deleted.
+// A zero limit is not applied.
+type ResultsRetention struct {
+	MaxAgeDays int    `json:"max_age_days,omitempty"` // Prune files last modified more than this many days ago
+	MaxPerTask int    `json:"max_per_task,omitempty"` // Keep only the newest files of each task (result, error and archived files)
+	MaxTotalMB int    `json:"max_total_mb,omitempty"` // Prune the oldest files while the results directory is larger
+	Action     string `json:"action,omitempty"`       // "compact" (default) or "delete"
+}
+
+// Enabled returns true if any retention limit is set
+func (r ResultsRetention) Enabled() bool {
+	return r.MaxAgeDays > 0 || r.MaxPerTask > 0 || r.MaxTotalMB > 0
+}
+
+// ResultsIndex is the summary of every result file pruned from a project,
+// kept in results/_index.json so the outcome of each task survives pruning
+type ResultsIndex struct {
+	Project string              `json:"project"`
+	Entries []ResultsIndexEntry `json:"entries"`
+}
+
+// ResultsIndexEntry summarizes one pruned file
+type ResultsIndexEntry struct {
+	Path        string     `json:"path"` // Relative to the results directory
+	TaskUUID    string     `json:"task_uuid"`
+	TaskID      int        `json:"task_id,omitempty"`
+	TaskTitle   string     `json:"task_title,omitempty"`
+	TaskSet     string     `json:"task_set,omitempty"`
+	Status      string     `json:"status,omitempty"`  // Worker status recorded in the result
+	Verdict     string     `json:"verdict,omitempty"` // QA verdict recorded in the result
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	Size        int64      `json:"size"`   // Size before pruning
+	Action      string     `json:"action"` // "compact" or "delete"
+	Reason      string     `json:"reason"` // "max_age", "max_per_task" or "max_total_size"
+	PrunedAt    time.Time  `json:"pruned_at"`
+}
+
+// ResultsPruneSummary reports the outcome of applying a retention policy
+type ResultsPruneSummary struct {
+	Project    string              `json:"project"`
+	Action     string              `json:"action"`
+	DryRun     bool                `json:"dry_run,omitempty"`
+	Scanned    int                 `json:"scanned"`
+	Pruned     int                 `json:"pruned"`
+	BytesFreed int64               `json:"bytes_freed"`
+	TotalBytes int64               `json:"total_bytes"` // Size of the results directory afterwards
+	ByReason   map[string]int      `json:"by_reason,omitempty"`
+	Files      []ResultsIndexEntry `json:"files,omitempty"`
+}
+
+// BulkStatusSummary reports the outcome of a bulk task status transition
+type BulkStatusSummary struct {
+	Project  string             `json:"project"`
+	ToStatus string             `json:"to_status"`
+	DryRun   bool               `json:"dry_run,omitempty"`
+	Matched  int                `json:"matched"`
+	Updated  []BulkStatusChange `json:"updated"`
+	Skipped  []BulkStatusChange `json:"skipped,omitempty"`
+}
+
+// BulkStatusChange describes one task considered by a bulk status transition.
+// Reason is set only for skipped tasks.
+type BulkStatusChange struct {
+	UUID       string `json:"uuid"`
+	ExternalID string `json:"external_id,omitempty"`
+	Path       string `json:"path"`
+	ID         int    `json:"id"`
+	FromStatus string `json:"from_status"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+// BulkUpdateSummary reports the outcome of a bulk task update. Fields lists
+// the task fields the update sets.
+type BulkUpdateSummary struct {
+	Project string             `json:"project"`
+	Fields  []string           `json:"fields"`
+	DryRun  bool               `json:"dry_run,omitempty"`
+	Matched int                `json:"matched"`
+	Updated []BulkStatusChange `json:"updated"`
+	Skipped []BulkStatusChange `json:"skipped,omitempty"`
+}
+
+// TaskDefinition is one task of a bulk creation. Its fields are the
+// task_create parameters.
+type TaskDefinition struct {
+	Title                    string            `json:"title"`
+	Type                     string            `json:"type,omitempty"`
+	ExternalID               string            `json:"external_id,omitempty"`
+	DependsOn                []string          `json:"depends_on,omitempty"` // Existing tasks, or external IDs of other tasks in the batch
+	Env                      map[string]string `json:"env,omitempty"`
+	Limits                   *TaskLimits       `json:"limits,omitempty"`
+	Priority                 int               `json:"priority,omitempty"`
+	InstructionsFile         string            `json:"instructions_file,omitempty"`
+	InstructionsFileSource   string            `json:"instructions_file_source,omitempty"`
+	InstructionsText         string            `json:"instructions_text,omitempty"`
+	Prompt                   string            `json:"prompt,omitempty"`
+	EvidenceFiles            []string          `json:"evidence_files,omitempty"`
+	LLMModelID               string            `json:"llm_model_id,omitempty"`
+	QAEnabled                bool              `json:"qa_enabled,omitempty"`
+	QAInstructionsFile       string            `json:"qa_instructions_file,omitempty"`
+	QAInstructionsFileSource string            `json:"qa_instructions_file_source,omitempty"`
+	QAInstructionsText       string            `json:"qa_instructions_text,omitempty"`
+	QAPrompt                 string            `json:"qa_prompt,omitempty"`
+	QALLMModelID             string            `json:"qa_llm_model_id,omitempty"`
+}
+
+// TaskSetCopyResult reports a task set copied by taskset_copy. Warnings list
+// what may need attention in the copy, such as instruction files the
+// destination project does not have.
+type TaskSetCopyResult struct {
+	SourceProject string       `json:"source_project"`
+	SourcePath    string       `json:"source_path"`
+	Project       string       `json:"project"`
+	Path          string       `json:"path"`
+	Title         string       `json:"title"`
+	Tasks         []CopiedTask `json:"tasks"`
+	Warnings      []string     `json:"warnings,omitempty"`
+}
+
+// CopiedTask is a task of a copied task set with the task it was copied from
+type CopiedTask struct {
+	ID         int    `json:"id"`
+	UUID       string `json:"uuid"`
+	ExternalID string `json:"external_id,omitempty"`
+	SourceUUID string `json:"source_uuid"`
+}
+
+// BulkCreateResult reports the outcome of a bulk task creation. Tasks are
+// created all or none: when Errors is not empty, nothing was created.
+type BulkCreateResult struct {
+	Project string            `json:"project"`
+	Path    string            `json:"path"`
+	DryRun  bool              `json:"dry_run,omitempty"`
+	Created int               `json:"created"`
+	Tasks   []BulkCreatedTask `json:"tasks"`
+	Errors  []BulkTaskError   `json:"errors,omitempty"`
+}
+
+// BulkCreatedTask identifies a task created by a bulk creation. Index is the
+// position of its definition, from 0.
+type BulkCreatedTask struct {
+	Index      int    `json:"index"`
+	ID         int    `json:"id"`
+	UUID       string `json:"uuid"`
+	ExternalID string `json:"external_id,omitempty"`
+	Title      string `json:"title"`
+}
+
+// BulkTaskError is why a task definition of a bulk creation was rejected
+type BulkTaskError struct {
+	Index int    `json:"index"`
+	Title string `json:"title,omitempty"`
+	Error string `json:"error"`
+}
+
+// MapReduceDefinition describes the tasks task_create_mapreduce creates over a
+// large project file: a map task per chunk and a reduce task over their responses
+type MapReduceDefinition struct {
+	File         string `json:"file"`
+	ChunkSize    int    `json:"chunk_size,omitempty"` // Bytes per chunk, EvidenceChunkBytes when 0
+	Title        string `json:"title"`
+	MapPrompt    string `json:"map_prompt"`
+	ReducePrompt string `json:"reduce_prompt"`
+	LLMModelID   string `json:"llm_model_id,omitempty"`
+}
+
+// MapReduceResult reports the chunk files and tasks created by task_create_mapreduce
+type MapReduceResult struct {
+	Project    string            `json:"project"`
+	Path       string            `json:"path"`
+	File       string            `json:"file"`
+	ChunkSize  int               `json:"chunk_size"`
+	Chunks     []string          `json:"chunks"` // Project files holding the chunks, one per map task
+	MapTasks   []BulkCreatedTask `json:"map_tasks"`
+	ReduceTask BulkCreatedTask   `json:"reduce_task"`
+}
+
+// EvidenceRequestList is the consolidated list of evidence that worker and QA
+// responses reported as missing (the standard "missing_evidence" field)
+type EvidenceRequestList struct {
+	Project      string            `json:"project"`
+	Path         string            `json:"path,omitempty"` // Task set path prefix that was scanned
+	GeneratedAt  time.Time         `json:"generated_at"`
+	TasksScanned int               `json:"tasks_scanned"`
+	Total        int               `json:"total"`
+	Requests     []EvidenceRequest `json:"requests"`
+	File         string            `json:"file,omitempty"`           // Project file the list was written to
+	FollowUpPath string            `json:"follow_up_path,omitempty"` // Task set holding the follow-up tasks
+	TasksCreated int               `json:"tasks_created,omitempty"`
+}
+
+// EvidenceRequest is one distinct piece of missing evidence and the tasks that asked for it
+type EvidenceRequest struct {
+	Description string           `json:"description"`
+	Sources     []EvidenceSource `json:"sources"`
+}
+
+// EvidenceSource identifies a task response that reported missing evidence
+type EvidenceSource struct {
+	TaskUUID       string `json:"task_uuid"`
+	TaskExternalID string `json:"task_external_id,omitempty"`
+	TaskTitle      string `json:"task_title"`
+	Path           string `json:"path"`
+	From           string `json:"from"` // "worker" or "qa"
+}
+
+// ProjectDiff is the findings-level delta between a project and a baseline
+// (another project or task set, or a finalized report archive)
+type ProjectDiff struct {
+	Project       string            `json:"project"`
+	Path          string            `json:"path,omitempty"`          // Task sets compared, if not all
+	Baseline      string            `json:"baseline"`                // "project:<name>" or "archive:<project>/<prefix>"
+	BaselinePath  string            `json:"baseline_path,omitempty"` // Baseline task sets, if not all
+	GeneratedAt   time.Time         `json:"generated_at"`
+	MatchBy       string            `json:"match_by"` // "key" or "title"
+	KeyField      string            `json:"key_field"`
+	CompareFields []string          `json:"compare_fields"`
+	Summary       ProjectDiffCounts `json:"summary"`
+	New           []DiffFinding     `json:"new"`
+	Resolved      []DiffFinding     `json:"resolved"`
+	Changed       []DiffFinding     `json:"changed"`
+	File          string            `json:"file,omitempty"` // Project file the delta report was written to
+}
+
+// ProjectDiffCounts summarizes a project diff
+type ProjectDiffCounts struct {
+	Baseline      int `json:"baseline"` // Findings in the baseline
+	Current       int `json:"current"`  // Findings in the project
+	New           int `json:"new"`
+	Resolved      int `json:"resolved"`
+	Changed       int `json:"changed"`
+	Unchanged     int `json:"unchanged"`
+	DuplicateKeys int `json:"duplicate_keys,omitempty"` // Findings ignored because an earlier one had the same key
+}
+
+// DiffFinding is one finding in a project diff
+type DiffFinding struct {
+	Key      string            `json:"key"`
+	Title    string            `json:"title"`
+	TaskUUID string            `json:"task_uuid"`
+	Fields   map[string]string `json:"fields,omitempty"`  // Compared field values (current, or baseline for resolved findings)
+	Changes  []DiffFieldChange `json:"changes,omitempty"` // Set for changed findings
+}
+
+// DiffFieldChange is a compared field whose value differs from the baseline
+type DiffFieldChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// ResponseDiff is the difference between two responses. JSON responses are
+// compared field by field, anything else line by line.
+type ResponseDiff struct {
+	Format    string            `json:"format"` // "json" or "text"
+	Identical bool              `json:"identical"`
+	Added     []DiffFieldChange `json:"added,omitempty"`     // JSON fields only in the later response
+	Removed   []DiffFieldChange `json:"removed,omitempty"`   // JSON fields only in the earlier response
+	Changed   []DiffFieldChange `json:"changed,omitempty"`   // JSON fields whose value differs
+	TextDiff  []string          `json:"text_diff,omitempty"` // Removed ("- ") and added ("+ ") lines, in order
+}
+
+// AttemptDiff compares the responses of two attempts of a task
+type AttemptDiff struct {
+	Project   string      `json:"project"`
+	TaskUUID  string      `json:"task_uuid"`
+	TaskID    int         `json:"task_id"`
+	TaskTitle string      `json:"task_title"`
+	Role      string      `json:"role"` // "worker" or "qa"
+	From      AttemptInfo `json:"from"`
+	To        AttemptInfo `json:"to"`
+	ResponseDiff
+}
+
+// AttemptInfo identifies one attempt in an attempt diff
+type AttemptInfo struct {
+	Invocation   int       `json:"invocation"`
+	LLMModelID   string    `json:"llm_model_id,omitempty"`
+	Timestamp    time.Time `json:"timestamp"`
+	ResponseSize int       `json:"response_size"`
 }
 
 // ResultsRequest represents a request to get task results
@@ -282,8 +1610,9 @@ type ResultsRequest struct {
 	TaskID        *int   `json:"task_id,omitempty"` // If provided, return single task result
 	Offset        int    `json:"offset,omitempty"`
 	Limit         int    `json:"limit,omitempty"`
+	Cursor        string `json:"cursor,omitempty"`         // Opaque cursor from a previous response (overrides offset)
 	Status        string `json:"status,omitempty"`         // Filter by status
-	Summary       bool   `json:"summary,omitempty"`        // If true, return only task_id, title, work_status
+	Summary       bool   `json:"summary,omitempty"`        // If true, return only task_id, title, work_status and summary
 	WorkerPattern string `json:"worker_pattern,omitempty"` // Regex pattern to match against worker response
 	QAPattern     string `json:"qa_pattern,omitempty"`     // Regex pattern to match against QA response
 }
@@ -295,16 +1624,19 @@ type ResultsResponse struct {
 	TotalCount    int                 `json:"total_count"`
 	ReturnedCount int                 `json:"returned_count"`
 	Offset        int                 `json:"offset"`
-	Results       []TaskResult        `json:"results"`             // Full results (when summary=false)
-	Summaries     []TaskResultSummary `json:"summaries,omitempty"` // Summary results (when summary=true)
+	NextCursor    string              `json:"next_cursor,omitempty"` // Pass as cursor to get the next page
+	Results       []TaskResult        `json:"results"`               // Full results (when summary=false)
+	Summaries     []TaskResultSummary `json:"summaries,omitempty"`   // Summary results (when summary=true)
 }
 
 // TaskResultSummary represents a minimal task result with only Maestro core fields
 type TaskResultSummary struct {
 	TaskID     int    `json:"task_id"`
 	TaskUUID   string `json:"task_uuid"`
+	ExternalID string `json:"external_id,omitempty"`
 	TaskTitle  string `json:"task_title"`
 	WorkStatus string `json:"work_status"`
+	Summary    string `json:"summary,omitempty"`
 }
 
 // SingleResultResponse represents the response for a single task result
@@ -321,11 +1653,12 @@ type SingleResultResponse struct {
 // Contains only the essential finding information without history or prompts
 type TaskResultGetResponse struct {
 	// Task identity
-	TaskID    int    `json:"task_id"`
-	TaskUUID  string `json:"task_uuid"`
-	TaskTitle string `json:"task_title"`
-	TaskType  string `json:"task_type,omitempty"`
-	TaskPath  string `json:"task_path"`
+	TaskID     int    `json:"task_id"`
+	TaskUUID   string `json:"task_uuid"`
+	ExternalID string `json:"external_id,omitempty"`
+	TaskTitle  string `json:"task_title"`
+	TaskType   string `json:"task_type,omitempty"`
+	TaskPath   string `json:"task_path"`
 
 	// Template info for supervisor updates
 	WorkerResponseTemplate string `json:"worker_response_template,omitempty"`
@@ -345,7 +1678,8 @@ type TaskResultGetResponse struct {
 	QAError    string `json:"qa_error,omitempty"`
 
 	// Supervisor info
-	SupervisorOverride bool `json:"supervisor_override"`
+	SupervisorOverride bool             `json:"supervisor_override"`
+	SupervisorEdits    []SupervisorEdit `json:"supervisor_edits,omitempty"`
 
 	// Timing
 	CompletedAt time.Time `json:"completed_at,omitempty"`
@@ -359,9 +1693,41 @@ type List struct {
 	Templates   *DefaultTemplates `json:"templates,omitempty"` // List-level templates for task creation
 	CreatedAt   time.Time         `json:"created_at"`
 	UpdatedAt   time.Time         `json:"updated_at"`
+	Sampling    *ListSampling     `json:"sampling,omitempty"` // How the items were sampled, for sampled copies
 	Items       []ListItem        `json:"items"`
 }
 
+// SampleSpec requests a sample of list items
+type SampleSpec struct {
+	Size       int    // Number of items to select (0 = all)
+	Seed       int64  // Random seed (0 = generate one)
+	Method     string // "random", "stratified" or "systematic" (empty = random, or stratified with StratifyBy)
+	StratifyBy string // Stratified samples: "tag", "section" or "source_doc"
+}
+
+// ListSampling records how a sample of list items was selected, so a sampled
+// audit can be reproduced: the same seed over the same list selects the same items
+type ListSampling struct {
+	List       string          `json:"list"`   // Source list name
+	Method     string          `json:"method"` // "random", "stratified" or "systematic"
+	Seed       int64           `json:"seed"`
+	Size       int             `json:"size"`                  // Items selected
+	Population int             `json:"population"`            // Items in the source list
+	StratifyBy string          `json:"stratify_by,omitempty"` // Stratified samples: the item field defining strata
+	Strata     []SampleStratum `json:"strata,omitempty"`      // Stratified samples: allocation per stratum
+	Interval   float64         `json:"interval,omitempty"`    // Systematic samples: items between selections
+	Start      *int            `json:"start,omitempty"`       // Systematic samples: index of the first selected item (the random start)
+	ItemIDs    []string        `json:"item_ids"`              // Selected items, in selection order
+	SampledAt  time.Time       `json:"sampled_at"`
+}
+
+// SampleStratum records the allocation of a stratified sample to one stratum
+type SampleStratum struct {
+	Value      string `json:"value"`      // Tag, section or source document ("(none)" for items without one)
+	Population int    `json:"population"` // Items in the stratum
+	Size       int    `json:"size"`       // Items selected from the stratum
+}
+
 // ListItem represents a single item in a list
 type ListItem struct {
 	ID        string   `json:"id"`
@@ -414,12 +1780,16 @@ type ListItemSearchResponse struct {
 	TotalCount    int        `json:"total_count"`
 	ReturnedCount int        `json:"returned_count"`
 	Offset        int        `json:"offset"`
+	NextCursor    string     `json:"next_cursor,omitempty"` // Pass as cursor to get the next page
 }
 
 // ListCreateTasksResponse represents the response for list_create_tasks
 type ListCreateTasksResponse struct {
-	TasksCreated int    `json:"tasks_created"`
-	ListName     string `json:"list_name"`
-	ItemCount    int    `json:"item_count"`
-	TaskIDs      []int  `json:"task_ids"`
+	TasksCreated int           `json:"tasks_created"`
+	ListName     string        `json:"list_name"`
+	ItemCount    int           `json:"item_count"`
+	TaskIDs      []int         `json:"task_ids"`
+	Lists        []string      `json:"lists,omitempty"`    // Lists combined, when more than one
+	Combine      string        `json:"combine,omitempty"`  // How the lists were combined
+	Sampling     *ListSampling `json:"sampling,omitempty"` // Set when the items were sampled
 }
@@ -0,0 +1,54 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// WorkerToolNames are the read-only project tools a worker may call
+var WorkerToolNames = []string{ToolProjectFileList, ToolProjectFileGet, ToolProjectFileSearch, ToolListGet}
+
+// WorkerTools lets the worker LLM of a task set call read-only project tools
+// during a task instead of receiving all evidence in the prompt
+type WorkerTools struct {
+	MaxCalls int      `json:"max_calls,omitempty"` // Tool calls allowed per task (default DefaultWorkerToolCalls)
+	Tools    []string `json:"tools,omitempty"`     // Tools offered (default: all WorkerToolNames)
+}
+
+// ValidateWorkerTools checks the worker tool settings of a task set
+func ValidateWorkerTools(wt *WorkerTools) error {
+	if wt == nil {
+		return nil
+	}
+	if wt.MaxCalls < 0 || wt.MaxCalls > MaxWorkerToolCallsLimit {
+		return fmt.Errorf("worker_tools.max_calls must be between 1 and %d", MaxWorkerToolCallsLimit)
+	}
+	for _, tool := range wt.Tools {
+		if !slices.Contains(WorkerToolNames, tool) {
+			return fmt.Errorf("worker_tools: %q is not a worker tool (must be one of %s)", tool, strings.Join(WorkerToolNames, ", "))
+		}
+	}
+	return nil
+}
+
+// Limit returns the tool calls allowed per task
+func (wt *WorkerTools) Limit() int {
+	if wt.MaxCalls == 0 {
+		return DefaultWorkerToolCalls
+	}
+	return wt.MaxCalls
+}
+
+// Allowed returns the tools offered to the worker
+func (wt *WorkerTools) Allowed() []string {
+	if len(wt.Tools) == 0 {
+		return WorkerToolNames
+	}
+	return wt.Tools
+}
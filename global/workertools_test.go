@@ -0,0 +1,40 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package global
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestValidateWorkerTools(t *testing.T) {
+	if err := ValidateWorkerTools(nil); err != nil {
+		t.Errorf("ValidateWorkerTools(nil) error = %v", err)
+	}
+	if err := ValidateWorkerTools(&WorkerTools{MaxCalls: 5, Tools: []string{ToolProjectFileGet}}); err != nil {
+		t.Errorf("ValidateWorkerTools() error = %v", err)
+	}
+
+	invalid := map[string]*WorkerTools{
+		"negative":     {MaxCalls: -1},
+		"over limit":   {MaxCalls: MaxWorkerToolCallsLimit + 1},
+		"unknown tool": {Tools: []string{ToolProjectFilePut}},
+	}
+	for name, wt := range invalid {
+		if err := ValidateWorkerTools(wt); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	defaults := &WorkerTools{}
+	if defaults.Limit() != DefaultWorkerToolCalls || !slices.Equal(defaults.Allowed(), WorkerToolNames) {
+		t.Errorf("defaults: Limit() = %d, Allowed() = %v", defaults.Limit(), defaults.Allowed())
+	}
+	custom := &WorkerTools{MaxCalls: 3, Tools: []string{ToolListGet}}
+	if custom.Limit() != 3 || !slices.Equal(custom.Allowed(), []string{ToolListGet}) {
+		t.Errorf("custom: Limit() = %d, Allowed() = %v", custom.Limit(), custom.Allowed())
+	}
+}
@@ -0,0 +1,141 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package lists
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// sourceList is a list loaded for task creation, with the name it was requested by
+type sourceList struct {
+	name string
+	list *global.List
+}
+
+// taskUnit is what one created task covers: a single list item, or one item
+// from each list of a cross product
+type taskUnit struct {
+	key   string // Identifies the unit in sampling records
+	items []unitItem
+}
+
+// unitItem is a list item together with the list it came from
+type unitItem struct {
+	list string
+	item global.ListItem
+}
+
+// combineLists builds the task units for the loaded lists. A single list gives
+// one unit per item. Several lists are either concatenated, one unit per item of
+// each list in turn, or crossed, one unit per combination of one item from each
+// list in list order.
+func combineLists(sources []sourceList, combine string) ([]taskUnit, error) {
+	if len(sources) == 1 {
+		units := make([]taskUnit, 0, len(sources[0].list.Items))
+		for _, item := range sources[0].list.Items {
+			units = append(units, taskUnit{key: item.ID, items: []unitItem{{list: sources[0].name, item: item}}})
+		}
+		return units, nil
+	}
+
+	switch combine {
+	case "", global.ListCombineConcatenate:
+		var units []taskUnit
+		for _, src := range sources {
+			for _, item := range src.list.Items {
+				units = append(units, taskUnit{key: src.name + "/" + item.ID, items: []unitItem{{list: src.name, item: item}}})
+			}
+		}
+		return units, nil
+
+	case global.ListCombineCrossProduct:
+		total := 1
+		for _, src := range sources {
+			total *= len(src.list.Items)
+			if total > global.MaxListCombinations {
+				return nil, fmt.Errorf("cross product has more than %d combinations; split the lists or create tasks in several calls", global.MaxListCombinations)
+			}
+		}
+		units := []taskUnit{{}}
+		for _, src := range sources {
+			next := make([]taskUnit, 0, len(units)*len(src.list.Items))
+			for _, unit := range units {
+				for _, item := range src.list.Items {
+					items := append(append([]unitItem{}, unit.items...), unitItem{list: src.name, item: item})
+					key := item.ID
+					if unit.key != "" {
+						key = unit.key + "/" + item.ID
+					}
+					next = append(next, taskUnit{key: key, items: items})
+				}
+			}
+			units = next
+		}
+		return units, nil
+
+	default:
+		return nil, fmt.Errorf("invalid combine mode: %s (must be %s or %s)", combine, global.ListCombineConcatenate, global.ListCombineCrossProduct)
+	}
+}
+
+// samplingItem describes a unit for sampling. A combination is stratified by
+// its item from the first list.
+func (u taskUnit) samplingItem() global.ListItem {
+	first := u.items[0].item
+	return global.ListItem{
+		ID:        u.key,
+		Title:     first.Title,
+		SourceDoc: first.SourceDoc,
+		Section:   first.Section,
+		Tags:      first.Tags,
+	}
+}
+
+// title fills a title template for the unit. {{title}} and {{id}} are those of
+// a single item, or joined with " / " for a combination, and {{<list>.title}}
+// and {{<list>.id}} address the item from a named list.
+func (u taskUnit) title(template string) string {
+	titles := make([]string, 0, len(u.items))
+	ids := make([]string, 0, len(u.items))
+	for _, ui := range u.items {
+		template = strings.ReplaceAll(template, "{{"+ui.list+".title}}", ui.item.Title)
+		template = strings.ReplaceAll(template, "{{"+ui.list+".id}}", ui.item.ID)
+		titles = append(titles, ui.item.Title)
+		ids = append(ids, ui.item.ID)
+	}
+	template = strings.ReplaceAll(template, "{{title}}", strings.Join(titles, " / "))
+	return strings.ReplaceAll(template, "{{id}}", strings.Join(ids, " / "))
+}
+
+// context builds the item context appended to the task prompt. A combination
+// gets one block per item, headed with the list it came from.
+func (u taskUnit) context() string {
+	var sb strings.Builder
+	for _, ui := range u.items {
+		if len(u.items) == 1 {
+			sb.WriteString("\n=== LIST ITEM ===\n")
+		} else {
+			sb.WriteString(fmt.Sprintf("\n=== LIST ITEM (%s) ===\n", ui.list))
+		}
+		item := ui.item
+		sb.WriteString(fmt.Sprintf("ID: %s\n", item.ID))
+		sb.WriteString(fmt.Sprintf("Title: %s\n", item.Title))
+		sb.WriteString(fmt.Sprintf("Content: %s\n", item.Content))
+		if item.SourceDoc != "" {
+			sb.WriteString(fmt.Sprintf("Source: %s\n", item.SourceDoc))
+		}
+		if item.Section != "" {
+			sb.WriteString(fmt.Sprintf("Section: %s\n", item.Section))
+		}
+		if len(item.Tags) > 0 {
+			sb.WriteString(fmt.Sprintf("Tags: %s\n", strings.Join(item.Tags, ", ")))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,219 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package lists
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// validateSample checks a sample request before any list is loaded
+func validateSample(spec *global.SampleSpec) error {
+	if spec == nil {
+		return nil
+	}
+	if spec.Size < 0 {
+		return fmt.Errorf("sample must not be negative")
+	}
+	if spec.Seed < 0 || spec.Seed > global.MaxSampleSeed {
+		return fmt.Errorf("seed must be between 1 and %d", int64(global.MaxSampleSeed))
+	}
+	switch sampleMethod(spec) {
+	case global.SampleMethodStratified:
+		switch spec.StratifyBy {
+		case global.StratifyByTag, global.StratifyBySection, global.StratifyBySourceDoc:
+		case "":
+			return fmt.Errorf("stratified sampling requires stratify_by (%s, %s or %s)",
+				global.StratifyByTag, global.StratifyBySection, global.StratifyBySourceDoc)
+		default:
+			return fmt.Errorf("invalid stratify_by: %s (must be %s, %s or %s)", spec.StratifyBy,
+				global.StratifyByTag, global.StratifyBySection, global.StratifyBySourceDoc)
+		}
+	case global.SampleMethodRandom, global.SampleMethodSystematic:
+		if spec.StratifyBy != "" {
+			return fmt.Errorf("stratify_by only applies to %s sampling", global.SampleMethodStratified)
+		}
+	default:
+		return fmt.Errorf("invalid sample method: %s (must be %s, %s or %s)", spec.Method,
+			global.SampleMethodRandom, global.SampleMethodStratified, global.SampleMethodSystematic)
+	}
+	return nil
+}
+
+// sampleMethod returns the method a sample request uses: random unless given,
+// or stratified when only stratify_by is given
+func sampleMethod(spec *global.SampleSpec) string {
+	if spec.Method != "" {
+		return spec.Method
+	}
+	if spec.StratifyBy != "" {
+		return global.SampleMethodStratified
+	}
+	return global.SampleMethodRandom
+}
+
+// sampleItems selects the items a sample request asks for. Without a sample, or
+// when the sample covers the whole list, every item is returned and no sampling
+// is recorded. Otherwise the selection is seeded, and the returned record holds
+// the seed (generated when none was given) and the methodology so the same
+// sample can be drawn again.
+func (s *Service) sampleItems(listName string, items []global.ListItem, spec *global.SampleSpec) ([]global.ListItem, *global.ListSampling) {
+	if spec == nil || spec.Size <= 0 || spec.Size >= len(items) {
+		return items, nil
+	}
+
+	seed := spec.Seed
+	if seed == 0 {
+		seed = rand.Int63n(global.MaxSampleSeed) + 1
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	sampling := &global.ListSampling{
+		List:       listName,
+		Method:     sampleMethod(spec),
+		Seed:       seed,
+		Population: len(items),
+		SampledAt:  time.Now(),
+	}
+
+	var selected []global.ListItem
+	switch sampling.Method {
+	case global.SampleMethodStratified:
+		sampling.StratifyBy = spec.StratifyBy
+		selected, sampling.Strata = stratifiedSample(items, spec.Size, spec.StratifyBy, rng)
+	case global.SampleMethodSystematic:
+		var start int
+		selected, start, sampling.Interval = systematicSample(items, spec.Size, rng)
+		sampling.Start = &start
+	default:
+		selected = randomSample(items, spec.Size, rng)
+	}
+
+	sampling.Size = len(selected)
+	sampling.ItemIDs = make([]string, 0, len(selected))
+	for _, item := range selected {
+		sampling.ItemIDs = append(sampling.ItemIDs, item.ID)
+	}
+
+	s.logger.Infof("Sampling %d of %d items from list '%s' (method=%s, seed=%d)", sampling.Size, sampling.Population, listName, sampling.Method, seed)
+	return selected, sampling
+}
+
+// randomSample returns a random sample of n items from the given slice.
+// Uses Fisher-Yates shuffle on a copy to avoid modifying the original.
+func randomSample(items []global.ListItem, n int, rng *rand.Rand) []global.ListItem {
+	if n >= len(items) {
+		return items
+	}
+
+	// Create a copy to shuffle
+	shuffled := make([]global.ListItem, len(items))
+	copy(shuffled, items)
+
+	// Fisher-Yates shuffle
+	for i := len(shuffled) - 1; i > 0; i-- {
+		j := rng.Intn(i + 1)
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	}
+
+	return shuffled[:n]
+}
+
+// stratifiedSample divides the items into strata by a field and draws a random
+// sample from each, allocating n across the strata in proportion to their size
+// (largest remainder, ties to the earlier stratum). Strata are listed in order
+// of first appearance and selected items keep list order within the sample.
+func stratifiedSample(items []global.ListItem, n int, stratifyBy string, rng *rand.Rand) ([]global.ListItem, []global.SampleStratum) {
+	var order []string
+	members := make(map[string][]int)
+	for i, item := range items {
+		value := stratumValue(item, stratifyBy)
+		if _, ok := members[value]; !ok {
+			order = append(order, value)
+		}
+		members[value] = append(members[value], i)
+	}
+
+	// Proportional allocation: whole shares first, then the remaining items to
+	// the strata with the largest fractional shares
+	strata := make([]global.SampleStratum, len(order))
+	remainders := make([]int, len(order))
+	allocated := 0
+	for i, value := range order {
+		share := len(members[value]) * n
+		strata[i] = global.SampleStratum{Value: value, Population: len(members[value]), Size: share / len(items)}
+		remainders[i] = share % len(items)
+		allocated += strata[i].Size
+	}
+	for ; allocated < n; allocated++ {
+		best := -1
+		for i := range strata {
+			if strata[i].Size < strata[i].Population && (best < 0 || remainders[i] > remainders[best]) {
+				best = i
+			}
+		}
+		strata[best].Size++
+		remainders[best] = -1
+	}
+
+	chosen := make([]bool, len(items))
+	for _, stratum := range strata {
+		indexes := members[stratum.Value]
+		rng.Shuffle(len(indexes), func(i, j int) { indexes[i], indexes[j] = indexes[j], indexes[i] })
+		for _, index := range indexes[:stratum.Size] {
+			chosen[index] = true
+		}
+	}
+
+	selected := make([]global.ListItem, 0, n)
+	for i, item := range items {
+		if chosen[i] {
+			selected = append(selected, item)
+		}
+	}
+	return selected, strata
+}
+
+// stratumValue returns the stratum an item belongs to. Items with several tags
+// are placed by their first tag.
+func stratumValue(item global.ListItem, stratifyBy string) string {
+	var value string
+	switch stratifyBy {
+	case global.StratifyByTag:
+		if len(item.Tags) > 0 {
+			value = item.Tags[0]
+		}
+	case global.StratifyBySection:
+		value = item.Section
+	case global.StratifyBySourceDoc:
+		value = item.SourceDoc
+	}
+	if value == "" {
+		return global.SampleStratumNone
+	}
+	return value
+}
+
+// systematicSample selects every k-th item in list order, where k is the list
+// size divided by n, starting from a random position within the first interval.
+// Returns the selection, the index of the first selected item and the interval.
+func systematicSample(items []global.ListItem, n int, rng *rand.Rand) ([]global.ListItem, int, float64) {
+	interval := float64(len(items)) / float64(n)
+	offset := rng.Float64() * interval
+
+	selected := make([]global.ListItem, 0, n)
+	for i := 0; i < n; i++ {
+		index := int(offset + float64(i)*interval)
+		if index >= len(items) { // Guard against floating point rounding on the last step
+			index = len(items) - 1
+		}
+		selected = append(selected, items[index])
+	}
+	return selected, int(offset), interval
+}
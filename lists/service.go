@@ -12,7 +12,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/fs"
-	"math/rand"
 	"os"
 	"path/filepath"
 	"strings"
@@ -591,37 +590,41 @@ func (s *Service) Rename(source, project, playbook, listName, newListName string
 // Copy copies a list from one location to another.
 // This supports copying between projects, playbooks, and reference (read-only source).
 // The from/to list names should not include .json extension.
-// If sample > 0, randomly selects that many items from the source list.
+// If sample is set, copies a seeded random sample of the source items instead, records
+// the sampling in the copied list and returns it.
 func (s *Service) Copy(
 	fromSource, fromProject, fromPlaybook, fromListName string,
 	toSource, toProject, toPlaybook, toListName string,
-	sample int,
-) error {
+	sample *global.SampleSpec,
+) (*global.ListSampling, error) {
 	// Validate destination is writable
 	if !isWritable(toSource) {
-		return fmt.Errorf("cannot copy list to read-only destination: %s", toSource)
+		return nil, fmt.Errorf("cannot copy list to read-only destination: %s", toSource)
+	}
+	if err := validateSample(sample); err != nil {
+		return nil, err
 	}
 
 	// Normalize list names
 	fromFilename, err := normalizeListName(fromListName)
 	if err != nil {
-		return fmt.Errorf("invalid source list name: %w", err)
+		return nil, fmt.Errorf("invalid source list name: %w", err)
 	}
 	toFilename, err := normalizeListName(toListName)
 	if err != nil {
-		return fmt.Errorf("invalid destination list name: %w", err)
+		return nil, fmt.Errorf("invalid destination list name: %w", err)
 	}
 
 	// Load source list
 	sourceList, _, err := s.loadList(fromSource, fromProject, fromPlaybook, fromListName)
 	if err != nil {
-		return fmt.Errorf("failed to load source list: %w", err)
+		return nil, fmt.Errorf("failed to load source list: %w", err)
 	}
 
 	// Resolve destination directory
 	destListDir, err := s.resolveListDir(toSource, toProject, toPlaybook)
 	if err != nil {
-		return fmt.Errorf("failed to resolve destination: %w", err)
+		return nil, fmt.Errorf("failed to resolve destination: %w", err)
 	}
 
 	destPath := filepath.Join(destListDir, toFilename)
@@ -632,14 +635,16 @@ func (s *Service) Copy(
 
 	// Check if destination already exists
 	if global.FileExists(destPath) {
-		return fmt.Errorf("destination list already exists: %s", toListName)
+		return nil, fmt.Errorf("destination list already exists: %s", toListName)
 	}
 
 	// Determine which items to copy (all or sampled)
-	itemsToCopy := sourceList.Items
-	if sample > 0 && sample < len(sourceList.Items) {
-		itemsToCopy = s.randomSample(sourceList.Items, sample)
-		s.logger.Infof("Sampling %d of %d items from list '%s'", sample, len(sourceList.Items), fromListName)
+	itemsToCopy, sampling := s.sampleItems(fromListName, sourceList.Items, sample)
+
+	// A copy of a sampled list keeps its sampling record
+	recorded := sampling
+	if recorded == nil {
+		recorded = sourceList.Sampling
 	}
 
 	// Create a copy of the list with updated timestamps
@@ -651,17 +656,18 @@ func (s *Service) Copy(
 		Templates:   sourceList.Templates,
 		CreatedAt:   now,
 		UpdatedAt:   now,
+		Sampling:    recorded,
 		Items:       make([]global.ListItem, len(itemsToCopy)),
 	}
 	copy(copiedList.Items, itemsToCopy)
 
 	// Save to destination
 	if err := s.saveList(destPath, copiedList); err != nil {
-		return fmt.Errorf("failed to save copied list: %w", err)
+		return nil, fmt.Errorf("failed to save copied list: %w", err)
 	}
 
 	s.logger.Infof("Copied list from %s/%s to %s/%s", fromSource, fromFilename, toSource, toFilename)
-	return nil
+	return sampling, nil
 }
 
 // AddItem adds a new item to a list.
@@ -883,7 +889,9 @@ func (s *Service) GetItem(source, project, playbook, listName, itemID string) (*
 // SearchItems searches for items in a list.
 // The listName parameter should be the list name without .json extension.
 // The completeFilter parameter is only used for project lists: "true", "false", or "" (no filter).
-func (s *Service) SearchItems(source, project, playbook, listName, query, sourceDoc, section string, tags []string, completeFilter string, offset, limit int) (*global.ListItemSearchResponse, error) {
+// A non-empty cursor (from a previous response's next_cursor) takes precedence over offset and
+// pages by list position, so items appended between calls are neither skipped nor repeated.
+func (s *Service) SearchItems(source, project, playbook, listName, query, sourceDoc, section string, tags []string, completeFilter string, offset, limit int, cursor string) (*global.ListItemSearchResponse, error) {
 	if limit <= 0 {
 		limit = global.DefaultLimit
 	}
@@ -893,11 +901,15 @@ func (s *Service) SearchItems(source, project, playbook, listName, query, source
 		return nil, err
 	}
 
-	// Filter items
-	var matches []global.ListItem
+	// Filter items, keeping each match's position in the list for cursor paging
+	type indexedItem struct {
+		index int
+		item  global.ListItem
+	}
+	var matches []indexedItem
 	queryLower := strings.ToLower(query)
 
-	for _, item := range list.Items {
+	for index, item := range list.Items {
 		// Query filter (case-insensitive substring on id OR content)
 		if query != "" {
 			idMatch := strings.Contains(strings.ToLower(item.ID), queryLower)
@@ -948,81 +960,132 @@ func (s *Service) SearchItems(source, project, playbook, listName, query, source
 			}
 		}
 
-		matches = append(matches, item)
+		matches = append(matches, indexedItem{index: index, item: item})
 	}
 
 	// Apply pagination
 	total := len(matches)
-	if offset >= total {
-		return &global.ListItemSearchResponse{
-			Items:         []global.ListItem{},
-			TotalCount:    total,
-			ReturnedCount: 0,
-			Offset:        offset,
-		}, nil
+	page, start, nextCursor, err := global.Paginate(matches, func(m indexedItem) string {
+		return global.IntCursorKey(m.index)
+	}, cursor, offset, limit)
+	if err != nil {
+		return nil, err
 	}
 
-	end := offset + limit
-	if end > total {
-		end = total
+	result := make([]global.ListItem, len(page))
+	for i, m := range page {
+		result[i] = m.item
 	}
 
-	result := matches[offset:end]
-
 	s.logger.Debugf("Search in list %s: found %d matches (returned %d)", listName, total, len(result))
 	return &global.ListItemSearchResponse{
 		Items:         result,
 		TotalCount:    total,
 		ReturnedCount: len(result),
-		Offset:        offset,
+		Offset:        start,
+		NextCursor:    nextCursor,
 	}, nil
 }
 
 // TaskCreator interface for creating tasks and managing tasksets (to avoid circular dependency)
 type TaskCreator interface {
-	CreateTask(project, path, title, taskType string, work *global.WorkExecution, qa *global.QAExecution) (*global.Task, error)
+	CreateTask(project, path, title, taskType, externalID string, work *global.WorkExecution, qa *global.QAExecution) (*global.Task, error)
+	UpdateTask(project, taskUUID string, updates map[string]interface{}) (*global.Task, error)
 	GetTaskSet(project, path string) (*global.TaskSet, error)
-	CreateTaskSet(project, path, title, description string, templates *global.DefaultTemplates, parallel bool, limits global.Limits, skipValidation bool, callbackURL string) (*global.TaskSet, error)
+	AddTaskSetSampling(project, path string, sampling global.ListSampling) error
+	CreateTaskSet(project, path, title, description string, templates *global.DefaultTemplates, parallel bool, limits global.Limits, skipValidation bool, callbackURL, outputLanguage string, qaDefaults *global.QADefaults) (*global.TaskSet, error)
 }
 
 // CreateTasks creates tasks from list items.
-// The listName parameter should be the list name without .json extension.
-// The priority parameter is reserved for future use.
-// The qaTemplate parameter, if non-nil, enables QA for all created tasks.
-// The sample parameter, if > 0, randomly selects that many items from the list.
+// The listNames parameter holds the list names without .json extension. With
+// several lists, combine selects how they are combined: "concatenate" (default)
+// creates one task per item of each list, "cross_product" one task per
+// combination of one item from each list, with every item's context in the prompt.
+// The priority parameter sets the scheduling priority of every created task.
+// The qaTemplate parameter, if non-nil, enables QA for all created tasks. Its
+// instructions and prompt become the QA defaults of a task set created here (or
+// match those of an existing one) rather than being copied into every task.
+// The sample parameter, if set, selects a seeded sample of the items (or
+// combinations); the sampling is recorded in the task set and returned.
 // The parallel parameter enables parallel task execution in the created taskset.
 func (s *Service) CreateTasks(
 	taskCreator TaskCreator,
-	listSource, project, playbook, listName string,
+	listSource, project, playbook string, listNames []string, combine string,
 	targetProject, path string,
 	titleTemplate, taskType string, priority int,
 	llmModelID, instructionsFile, instructionsFileSource, instructionsText, basePrompt string,
 	qaTemplate *global.QAExecution,
-	sample int,
+	sample *global.SampleSpec,
 	parallel bool,
 ) (*global.ListCreateTasksResponse, error) {
-	// Load the list
-	list, _, err := s.loadList(listSource, project, playbook, listName)
+	if len(listNames) == 0 {
+		return nil, fmt.Errorf("at least one list is required")
+	}
+	if err := validateSample(sample); err != nil {
+		return nil, err
+	}
+	if err := global.ValidateTaskPriority(priority); err != nil {
+		return nil, err
+	}
+
+	// Load the lists; the first one provides the task set title and templates
+	var sources []sourceList
+	for _, name := range listNames {
+		l, _, err := s.loadList(listSource, project, playbook, name)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, sourceList{name: strings.TrimSuffix(name, ".json"), list: l})
+	}
+	list := sources[0].list
+	listName := strings.Join(listNames, ",")
+
+	units, err := combineLists(sources, combine)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(list.Items) == 0 {
-		return &global.ListCreateTasksResponse{
-			TasksCreated: 0,
-			ListName:     list.Name,
-			ItemCount:    0,
-			TaskIDs:      []int{},
-		}, nil
+	response := &global.ListCreateTasksResponse{
+		ListName: list.Name,
+		TaskIDs:  []int{},
+	}
+	if len(sources) > 1 {
+		response.Lists = listNames
+		response.Combine = combine
+		if combine == "" {
+			response.Combine = global.ListCombineConcatenate
+		}
+	}
+
+	if len(units) == 0 {
+		return response, nil
+	}
+	response.ItemCount = len(units)
+
+	// Shared QA instructions are kept once, as task set QA defaults, when the
+	// task set is new or already has the same defaults
+	var qaDefaults *global.QADefaults
+	if qaTemplate != nil && qaTemplate.Enabled {
+		qaDefaults = &global.QADefaults{
+			InstructionsFile:       qaTemplate.InstructionsFile,
+			InstructionsFileSource: qaTemplate.InstructionsFileSource,
+			InstructionsText:       qaTemplate.InstructionsText,
+			Prompt:                 qaTemplate.Prompt,
+		}
 	}
+	stampQA := !qaDefaults.IsEmpty()
 
 	// Ensure taskset exists, creating it with list templates if needed
-	_, err = taskCreator.GetTaskSet(targetProject, path)
-	if err != nil {
+	existing, err := taskCreator.GetTaskSet(targetProject, path)
+	if err == nil {
+		if existing.QADefaults != nil && qaDefaults != nil && *existing.QADefaults == *qaDefaults {
+			stampQA = false
+		}
+	} else {
 		// Taskset doesn't exist, create it with list templates
 		tasksetTitle := list.Name
 		if tasksetTitle == "" {
-			tasksetTitle = listName
+			tasksetTitle = listNames[0]
 		}
 		_, err = taskCreator.CreateTaskSet(
 			targetProject,
@@ -1034,18 +1097,33 @@ func (s *Service) CreateTasks(
 			global.Limits{}, // use defaults
 			false,           // skipValidation
 			"",              // callbackURL
+			"",              // outputLanguage - inherit from project
+			qaDefaults,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create task set: %w", err)
 		}
 		s.logger.Infof("Created task set '%s' with list templates", path)
+		stampQA = false
 	}
 
-	// If sample is specified, randomly select that many items
-	items := list.Items
-	if sample > 0 && sample < len(list.Items) {
-		items = s.randomSample(list.Items, sample)
-		s.logger.Infof("Sampling %d of %d items from list '%s'", sample, len(list.Items), listName)
+	// If sample is specified, select that many items (or combinations) and record how
+	if sample != nil && sample.Size > 0 && sample.Size < len(units) {
+		byKey := make(map[string]taskUnit, len(units))
+		candidates := make([]global.ListItem, 0, len(units))
+		for _, unit := range units {
+			byKey[unit.key] = unit
+			candidates = append(candidates, unit.samplingItem())
+		}
+		selected, sampling := s.sampleItems(listName, candidates, sample)
+		units = units[:0]
+		for _, item := range selected {
+			units = append(units, byKey[item.ID])
+		}
+		if err := taskCreator.AddTaskSetSampling(targetProject, path, *sampling); err != nil {
+			return nil, fmt.Errorf("failed to record sampling: %w", err)
+		}
+		response.Sampling = sampling
 	}
 
 	// Default title template
@@ -1053,34 +1131,13 @@ func (s *Service) CreateTasks(
 		titleTemplate = "{{title}}"
 	}
 
-	// Note: priority is reserved for future use when task prioritization is implemented
-	_ = priority
-
 	var taskIDs []int
-	for _, item := range items {
+	for _, unit := range units {
 		// Build task title from template (supports {{title}} and {{id}} placeholders)
-		title := titleTemplate
-		title = strings.ReplaceAll(title, "{{title}}", item.Title)
-		title = strings.ReplaceAll(title, "{{id}}", item.ID)
-
-		// Build item context to append to prompt
-		var itemContext strings.Builder
-		itemContext.WriteString("\n=== LIST ITEM ===\n")
-		itemContext.WriteString(fmt.Sprintf("ID: %s\n", item.ID))
-		itemContext.WriteString(fmt.Sprintf("Title: %s\n", item.Title))
-		itemContext.WriteString(fmt.Sprintf("Content: %s\n", item.Content))
-		if item.SourceDoc != "" {
-			itemContext.WriteString(fmt.Sprintf("Source: %s\n", item.SourceDoc))
-		}
-		if item.Section != "" {
-			itemContext.WriteString(fmt.Sprintf("Section: %s\n", item.Section))
-		}
-		if len(item.Tags) > 0 {
-			itemContext.WriteString(fmt.Sprintf("Tags: %s\n", strings.Join(item.Tags, ", ")))
-		}
+		title := unit.title(titleTemplate)
 
 		// Combine base prompt with item context
-		fullPrompt := basePrompt + itemContext.String()
+		fullPrompt := basePrompt + unit.context()
 
 		// Create work execution object
 		work := &global.WorkExecution{
@@ -1095,14 +1152,17 @@ func (s *Service) CreateTasks(
 		// Create QA execution object from template or auto-enable from list templates
 		var qa *global.QAExecution
 		if qaTemplate != nil && qaTemplate.Enabled {
-			// Explicit QA config provided
+			// Explicit QA config provided; instructions come from the task set
+			// defaults unless they differ from them
 			qa = &global.QAExecution{
-				Enabled:                true,
-				InstructionsFile:       qaTemplate.InstructionsFile,
-				InstructionsFileSource: qaTemplate.InstructionsFileSource,
-				InstructionsText:       qaTemplate.InstructionsText,
-				Prompt:                 qaTemplate.Prompt,
-				LLMModelID:             qaTemplate.LLMModelID,
+				Enabled:    true,
+				LLMModelID: qaTemplate.LLMModelID,
+			}
+			if stampQA {
+				qa.InstructionsFile = qaTemplate.InstructionsFile
+				qa.InstructionsFileSource = qaTemplate.InstructionsFileSource
+				qa.InstructionsText = qaTemplate.InstructionsText
+				qa.Prompt = qaTemplate.Prompt
 			}
 		} else if list.Templates != nil && (list.Templates.QAResponseTemplate != "" || list.Templates.QAReportTemplate != "") {
 			// List has QA templates - auto-enable QA
@@ -1122,41 +1182,24 @@ func (s *Service) CreateTasks(
 			path,
 			title,
 			taskType,
+			"",
 			work,
 			qa,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create task for item '%s': %w", item.ID, err)
+			return nil, fmt.Errorf("failed to create task for item '%s': %w", unit.key, err)
+		}
+		if priority != 0 {
+			if _, err := taskCreator.UpdateTask(targetProject, task.UUID, map[string]interface{}{"priority": priority}); err != nil {
+				return nil, fmt.Errorf("failed to set priority of task for item '%s': %w", unit.key, err)
+			}
 		}
 
 		taskIDs = append(taskIDs, task.ID)
 	}
 
 	s.logger.Infof("Created %d tasks from list '%s'", len(taskIDs), listName)
-	return &global.ListCreateTasksResponse{
-		TasksCreated: len(taskIDs),
-		ListName:     list.Name,
-		ItemCount:    len(list.Items),
-		TaskIDs:      taskIDs,
-	}, nil
-}
-
-// randomSample returns a random sample of n items from the given slice.
-// Uses Fisher-Yates shuffle on a copy to avoid modifying the original.
-func (s *Service) randomSample(items []global.ListItem, n int) []global.ListItem {
-	if n >= len(items) {
-		return items
-	}
-
-	// Create a copy to shuffle
-	shuffled := make([]global.ListItem, len(items))
-	copy(shuffled, items)
-
-	// Fisher-Yates shuffle
-	for i := len(shuffled) - 1; i > 0; i-- {
-		j := rand.Intn(i + 1)
-		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
-	}
-
-	return shuffled[:n]
+	response.TasksCreated = len(taskIDs)
+	response.TaskIDs = taskIDs
+	return response, nil
 }
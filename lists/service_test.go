@@ -7,8 +7,10 @@ package lists
 
 import (
 	"embed"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/PivotLLM/Maestro/global"
@@ -484,7 +486,7 @@ func TestItemSearch(t *testing.T) {
 	}
 
 	// Search by query (content)
-	result, err := service.SearchItems(SourceProject, "test-project", "", "items.json", "password", "", "", nil, "", 0, 50)
+	result, err := service.SearchItems(SourceProject, "test-project", "", "items.json", "password", "", "", nil, "", 0, 50, "")
 	if err != nil {
 		t.Fatalf("Failed to search: %v", err)
 	}
@@ -493,7 +495,7 @@ func TestItemSearch(t *testing.T) {
 	}
 
 	// Search by query (ID)
-	result, err = service.SearchItems(SourceProject, "test-project", "", "items.json", "req-001", "", "", nil, "", 0, 50)
+	result, err = service.SearchItems(SourceProject, "test-project", "", "items.json", "req-001", "", "", nil, "", 0, 50, "")
 	if err != nil {
 		t.Fatalf("Failed to search: %v", err)
 	}
@@ -502,7 +504,7 @@ func TestItemSearch(t *testing.T) {
 	}
 
 	// Search by source_doc
-	result, err = service.SearchItems(SourceProject, "test-project", "", "items.json", "", "doc1.md", "", nil, "", 0, 50)
+	result, err = service.SearchItems(SourceProject, "test-project", "", "items.json", "", "doc1.md", "", nil, "", 0, 50, "")
 	if err != nil {
 		t.Fatalf("Failed to search: %v", err)
 	}
@@ -511,7 +513,7 @@ func TestItemSearch(t *testing.T) {
 	}
 
 	// Search by tags (AND logic)
-	result, err = service.SearchItems(SourceProject, "test-project", "", "items.json", "", "", "", []string{"security", "auth"}, "", 0, 50)
+	result, err = service.SearchItems(SourceProject, "test-project", "", "items.json", "", "", "", []string{"security", "auth"}, "", 0, 50, "")
 	if err != nil {
 		t.Fatalf("Failed to search: %v", err)
 	}
@@ -638,3 +640,319 @@ func TestListNameValidation(t *testing.T) {
 		}
 	}
 }
+
+func TestListCopySampleSeed(t *testing.T) {
+	service, tempDir := setupTestService(t)
+	defer os.RemoveAll(tempDir)
+
+	createTestProject(t, tempDir, "test-project")
+
+	var items []global.ListItem
+	for i := 0; i < 20; i++ {
+		items = append(items, global.ListItem{ID: fmt.Sprintf("item-%d", i), Title: "Item", Content: "content"})
+	}
+	if err := service.Create(SourceProject, "test-project", "", "source", "Source", "", items); err != nil {
+		t.Fatalf("Failed to create list: %v", err)
+	}
+
+	sample := &global.SampleSpec{Size: 5, Seed: 12345}
+	first, err := service.Copy(SourceProject, "test-project", "", "source", SourceProject, "test-project", "", "first", sample)
+	if err != nil {
+		t.Fatalf("Failed to copy list: %v", err)
+	}
+	second, err := service.Copy(SourceProject, "test-project", "", "source", SourceProject, "test-project", "", "second", sample)
+	if err != nil {
+		t.Fatalf("Failed to copy list: %v", err)
+	}
+
+	if first == nil || first.Seed != 12345 || first.Size != 5 || first.Population != 20 {
+		t.Fatalf("Unexpected sampling record: %+v", first)
+	}
+	if len(first.ItemIDs) != 5 || strings.Join(first.ItemIDs, ",") != strings.Join(second.ItemIDs, ",") {
+		t.Errorf("Same seed gave different samples: %v and %v", first.ItemIDs, second.ItemIDs)
+	}
+
+	copied, err := service.Get(SourceProject, "test-project", "", "first")
+	if err != nil {
+		t.Fatalf("Failed to get copied list: %v", err)
+	}
+	if copied.Sampling == nil || copied.Sampling.Seed != 12345 {
+		t.Errorf("Copied list does not record the sampling: %+v", copied.Sampling)
+	}
+
+	// Without a seed, one is generated and recorded
+	generated, err := service.Copy(SourceProject, "test-project", "", "source", SourceProject, "test-project", "", "third", &global.SampleSpec{Size: 5})
+	if err != nil {
+		t.Fatalf("Failed to copy list: %v", err)
+	}
+	if generated.Seed <= 0 {
+		t.Errorf("Expected a generated seed, got %d", generated.Seed)
+	}
+
+	if _, err := service.Copy(SourceProject, "test-project", "", "source", SourceProject, "test-project", "", "bad", &global.SampleSpec{Size: 5, Seed: -1}); err == nil {
+		t.Error("Expected error for negative seed")
+	}
+}
+
+func TestListCopySampleMethods(t *testing.T) {
+	service, tempDir := setupTestService(t)
+	defer os.RemoveAll(tempDir)
+
+	createTestProject(t, tempDir, "test-project")
+
+	// 12 items in section A, 6 in section B and 2 without a section
+	var items []global.ListItem
+	for i := 0; i < 20; i++ {
+		section := "A"
+		if i >= 12 {
+			section = "B"
+		}
+		if i >= 18 {
+			section = ""
+		}
+		items = append(items, global.ListItem{ID: fmt.Sprintf("item-%d", i), Title: "Item", Content: "content", Section: section})
+	}
+	if err := service.Create(SourceProject, "test-project", "", "source", "Source", "", items); err != nil {
+		t.Fatalf("Failed to create list: %v", err)
+	}
+
+	stratified, err := service.Copy(SourceProject, "test-project", "", "source", SourceProject, "test-project", "", "stratified",
+		&global.SampleSpec{Size: 10, Seed: 7, StratifyBy: global.StratifyBySection})
+	if err != nil {
+		t.Fatalf("Failed to copy list: %v", err)
+	}
+	if stratified.Method != global.SampleMethodStratified || stratified.StratifyBy != global.StratifyBySection {
+		t.Errorf("Unexpected methodology: %+v", stratified)
+	}
+	want := map[string]int{"A": 6, "B": 3, global.SampleStratumNone: 1}
+	if len(stratified.Strata) != len(want) {
+		t.Fatalf("Expected %d strata, got %+v", len(want), stratified.Strata)
+	}
+	for _, stratum := range stratified.Strata {
+		if stratum.Size != want[stratum.Value] {
+			t.Errorf("Stratum %s: expected %d items, got %d", stratum.Value, want[stratum.Value], stratum.Size)
+		}
+	}
+	if len(stratified.ItemIDs) != 10 {
+		t.Errorf("Expected 10 sampled items, got %d", len(stratified.ItemIDs))
+	}
+
+	systematic, err := service.Copy(SourceProject, "test-project", "", "source", SourceProject, "test-project", "", "systematic",
+		&global.SampleSpec{Size: 5, Seed: 7, Method: global.SampleMethodSystematic})
+	if err != nil {
+		t.Fatalf("Failed to copy list: %v", err)
+	}
+	if systematic.Interval != 4 || systematic.Start == nil || *systematic.Start >= 4 {
+		t.Fatalf("Unexpected systematic sampling: %+v", systematic)
+	}
+	for i, id := range systematic.ItemIDs {
+		if expected := fmt.Sprintf("item-%d", *systematic.Start+4*i); id != expected {
+			t.Errorf("Systematic item %d: expected %s, got %s", i, expected, id)
+		}
+	}
+
+	invalid := []*global.SampleSpec{
+		{Size: 5, Method: global.SampleMethodStratified},
+		{Size: 5, StratifyBy: "owner"},
+		{Size: 5, Method: global.SampleMethodSystematic, StratifyBy: global.StratifyByTag},
+		{Size: 5, Method: "cluster"},
+	}
+	for _, spec := range invalid {
+		if _, err := service.Copy(SourceProject, "test-project", "", "source", SourceProject, "test-project", "", "invalid", spec); err == nil {
+			t.Errorf("Expected error for sample %+v", spec)
+		}
+	}
+}
+
+// fakeTaskCreator records the tasks CreateTasks creates
+type fakeTaskCreator struct {
+	taskSet *global.TaskSet
+	tasks   []global.Task
+}
+
+func (f *fakeTaskCreator) CreateTask(project, path, title, taskType, externalID string, work *global.WorkExecution, qa *global.QAExecution) (*global.Task, error) {
+	task := global.Task{ID: len(f.tasks) + 1, UUID: fmt.Sprintf("task-%d", len(f.tasks)+1), Title: title, Type: taskType, Work: *work}
+	if qa != nil {
+		task.QA = *qa
+	}
+	f.tasks = append(f.tasks, task)
+	return &task, nil
+}
+
+func (f *fakeTaskCreator) UpdateTask(project, taskUUID string, updates map[string]interface{}) (*global.Task, error) {
+	for i := range f.tasks {
+		if f.tasks[i].UUID == taskUUID {
+			if priority, ok := updates["priority"].(int); ok {
+				f.tasks[i].Priority = priority
+			}
+			return &f.tasks[i], nil
+		}
+	}
+	return nil, fmt.Errorf("task not found: %s", taskUUID)
+}
+
+func (f *fakeTaskCreator) GetTaskSet(project, path string) (*global.TaskSet, error) {
+	if f.taskSet == nil {
+		return nil, fmt.Errorf("task set not found: %s", path)
+	}
+	return f.taskSet, nil
+}
+
+func (f *fakeTaskCreator) AddTaskSetSampling(project, path string, sampling global.ListSampling) error {
+	f.taskSet.Sampling = append(f.taskSet.Sampling, sampling)
+	return nil
+}
+
+func (f *fakeTaskCreator) CreateTaskSet(project, path, title, description string, templates *global.DefaultTemplates, parallel bool, limits global.Limits, skipValidation bool, callbackURL, outputLanguage string, qaDefaults *global.QADefaults) (*global.TaskSet, error) {
+	f.taskSet = &global.TaskSet{Path: path, Title: title, QADefaults: qaDefaults}
+	return f.taskSet, nil
+}
+
+func TestCreateTasksCombinedLists(t *testing.T) {
+	service, tempDir := setupTestService(t)
+	defer os.RemoveAll(tempDir)
+
+	createTestProject(t, tempDir, "test-project")
+
+	controls := []global.ListItem{
+		{ID: "AC-1", Title: "Access policy", Content: "Policy exists"},
+		{ID: "AC-2", Title: "Account management", Content: "Accounts reviewed"},
+	}
+	systems := []global.ListItem{
+		{ID: "SYS-1", Title: "Payroll", Content: "Payroll system"},
+		{ID: "SYS-2", Title: "CRM", Content: "Customer system"},
+		{ID: "SYS-3", Title: "Intranet", Content: "Internal portal"},
+	}
+	if err := service.Create(SourceProject, "test-project", "", "controls", "Controls", "", controls); err != nil {
+		t.Fatalf("Failed to create list: %v", err)
+	}
+	if err := service.Create(SourceProject, "test-project", "", "systems", "Systems", "", systems); err != nil {
+		t.Fatalf("Failed to create list: %v", err)
+	}
+
+	creator := &fakeTaskCreator{}
+	resp, err := service.CreateTasks(creator, SourceProject, "test-project", "", []string{"controls", "systems"}, global.ListCombineCrossProduct,
+		"test-project", "testing", "Test {{controls.id}} on {{systems.title}}", "test", 0,
+		"test-llm", "", "", "", "Test the control.", nil, nil, false)
+	if err != nil {
+		t.Fatalf("Failed to create tasks: %v", err)
+	}
+	if resp.TasksCreated != 6 || resp.ItemCount != 6 || resp.Combine != global.ListCombineCrossProduct {
+		t.Fatalf("Unexpected response: %+v", resp)
+	}
+	if creator.tasks[4].Title != "Test AC-2 on CRM" {
+		t.Errorf("Unexpected title: %s", creator.tasks[4].Title)
+	}
+	prompt := creator.tasks[4].Work.Prompt
+	if !strings.Contains(prompt, "=== LIST ITEM (controls) ===\nID: AC-2") || !strings.Contains(prompt, "=== LIST ITEM (systems) ===\nID: SYS-2") {
+		t.Errorf("Prompt is missing the context of both items:\n%s", prompt)
+	}
+
+	// Concatenation creates one task per item of each list
+	creator = &fakeTaskCreator{}
+	resp, err = service.CreateTasks(creator, SourceProject, "test-project", "", []string{"controls", "systems"}, "",
+		"test-project", "inventory", "", "test", 0, "test-llm", "", "", "", "Review.", nil, nil, false)
+	if err != nil {
+		t.Fatalf("Failed to create tasks: %v", err)
+	}
+	if resp.TasksCreated != 5 || resp.Combine != global.ListCombineConcatenate {
+		t.Errorf("Unexpected response: %+v", resp)
+	}
+
+	// Samples of a cross product select combinations
+	creator = &fakeTaskCreator{}
+	resp, err = service.CreateTasks(creator, SourceProject, "test-project", "", []string{"controls", "systems"}, global.ListCombineCrossProduct,
+		"test-project", "sampled", "", "test", 0, "test-llm", "", "", "", "Test.", nil, &global.SampleSpec{Size: 2, Seed: 3}, false)
+	if err != nil {
+		t.Fatalf("Failed to create tasks: %v", err)
+	}
+	if resp.TasksCreated != 2 || resp.Sampling == nil || resp.Sampling.Population != 6 || len(creator.taskSet.Sampling) != 1 {
+		t.Errorf("Unexpected sampled response: %+v", resp)
+	}
+	for _, id := range resp.Sampling.ItemIDs {
+		if !strings.Contains(id, "/SYS-") {
+			t.Errorf("Expected a combination key, got %s", id)
+		}
+	}
+
+	if _, err := service.CreateTasks(&fakeTaskCreator{}, SourceProject, "test-project", "", []string{"controls", "systems"}, "zip",
+		"test-project", "bad", "", "test", 0, "test-llm", "", "", "", "", nil, nil, false); err == nil {
+		t.Error("Expected error for unknown combine mode")
+	}
+}
+
+func TestCreateTasksQADefaults(t *testing.T) {
+	service, tempDir := setupTestService(t)
+	defer os.RemoveAll(tempDir)
+
+	createTestProject(t, tempDir, "test-project")
+
+	items := []global.ListItem{
+		{ID: "AC-1", Title: "Access policy", Content: "Policy exists"},
+		{ID: "AC-2", Title: "Account management", Content: "Accounts reviewed"},
+	}
+	if err := service.Create(SourceProject, "test-project", "", "controls", "Controls", "", items); err != nil {
+		t.Fatalf("Failed to create list: %v", err)
+	}
+	qa := &global.QAExecution{Enabled: true, InstructionsFile: "qa.md", InstructionsText: "Check the evidence.", LLMModelID: "qa-llm"}
+
+	// A new task set receives the QA instructions once, as its defaults
+	creator := &fakeTaskCreator{}
+	if _, err := service.CreateTasks(creator, SourceProject, "test-project", "", []string{"controls"}, "",
+		"test-project", "testing", "", "test", 0, "test-llm", "", "", "", "Test.", qa, nil, false); err != nil {
+		t.Fatalf("Failed to create tasks: %v", err)
+	}
+	want := global.QADefaults{InstructionsFile: "qa.md", InstructionsText: "Check the evidence."}
+	if creator.taskSet.QADefaults == nil || *creator.taskSet.QADefaults != want {
+		t.Errorf("QADefaults = %+v, want %+v", creator.taskSet.QADefaults, want)
+	}
+	for _, task := range creator.tasks {
+		if !task.QA.Enabled || task.QA.LLMModelID != "qa-llm" || task.QA.InstructionsFile != "" || task.QA.InstructionsText != "" {
+			t.Errorf("Task %d QA = %+v, want QA enabled without copied instructions", task.ID, task.QA)
+		}
+	}
+
+	// An existing task set with other defaults gets the instructions on each task
+	creator.taskSet.QADefaults = &global.QADefaults{Prompt: "Other review."}
+	creator.tasks = nil
+	if _, err := service.CreateTasks(creator, SourceProject, "test-project", "", []string{"controls"}, "",
+		"test-project", "testing", "", "test", 0, "test-llm", "", "", "", "Test.", qa, nil, false); err != nil {
+		t.Fatalf("Failed to create tasks: %v", err)
+	}
+	for _, task := range creator.tasks {
+		if task.QA.InstructionsFile != "qa.md" || task.QA.InstructionsText != "Check the evidence." {
+			t.Errorf("Task %d QA = %+v, want copied instructions", task.ID, task.QA)
+		}
+	}
+}
+
+func TestCreateTasksPriority(t *testing.T) {
+	service, tempDir := setupTestService(t)
+	defer os.RemoveAll(tempDir)
+
+	createTestProject(t, tempDir, "test-project")
+
+	items := []global.ListItem{
+		{ID: "AC-1", Title: "Access policy", Content: "Policy exists"},
+		{ID: "AC-2", Title: "Account management", Content: "Accounts reviewed"},
+	}
+	if err := service.Create(SourceProject, "test-project", "", "controls", "Controls", "", items); err != nil {
+		t.Fatalf("Failed to create list: %v", err)
+	}
+
+	creator := &fakeTaskCreator{}
+	if _, err := service.CreateTasks(creator, SourceProject, "test-project", "", []string{"controls"}, "",
+		"test-project", "testing", "", "test", 7, "test-llm", "", "", "", "Test.", nil, nil, false); err != nil {
+		t.Fatalf("Failed to create tasks: %v", err)
+	}
+	for _, task := range creator.tasks {
+		if task.Priority != 7 {
+			t.Errorf("Task %d priority = %d, want 7", task.ID, task.Priority)
+		}
+	}
+
+	if _, err := service.CreateTasks(&fakeTaskCreator{}, SourceProject, "test-project", "", []string{"controls"}, "",
+		"test-project", "testing", "", "test", global.MaxTaskPriority+1, "test-llm", "", "", "", "Test.", nil, nil, false); err == nil {
+		t.Error("Expected error for a priority above the maximum")
+	}
+}
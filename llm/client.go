@@ -10,8 +10,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -27,6 +30,9 @@ type Service struct {
 	logger    *logging.Logger
 	library   *library.Service
 	llmConfig map[string]*config.LLM
+	probeMu   sync.Mutex
+	probes    map[string][]ProbeRecord // Availability probe history by canonical LLM ID
+	startup   *StartupValidation       // Outcome of validate_llms_on_startup (nil until run)
 }
 
 // DispatchRequest represents a request to dispatch work to an LLM
@@ -35,13 +41,40 @@ type DispatchRequest struct {
 	Prompt      string           `json:"prompt"`
 	ContextKeys []string         `json:"context_keys,omitempty"`
 	Options     *DispatchOptions `json:"options,omitempty"`
+	// Messages are the earlier turns of a conversation, oldest first; Prompt is
+	// the new user turn. A host dispatcher sends them as native messages; a
+	// command LLM receives them as a transcript ahead of the prompt.
+	Messages []global.ConversationMessage `json:"messages,omitempty"`
+	// SessionID continues a provider session reported by an earlier call. A
+	// command LLM with resume_args is then sent only the prompt.
+	SessionID string `json:"session_id,omitempty"`
+	// OnStart, if set, is called with the process ID once a command LLM has
+	// started. Host dispatchers may ignore it.
+	OnStart func(pid int) `json:"-"`
+	// Env holds the task's environment variables, exported to a command LLM
+	// alongside its own (which take precedence)
+	Env map[string]string `json:"env,omitempty"`
+	// Timeout, if set, replaces the LLM's timeout (seconds) for this call
+	Timeout int `json:"timeout,omitempty"`
 }
 
-// DispatchOptions represents options for LLM dispatch
+// DispatchOptions represents options for LLM dispatch. Without options, the
+// LLM's configured generation parameters are used.
 type DispatchOptions struct {
-	MaxTokens     int     `json:"max_tokens,omitempty"`
-	Temperature   float64 `json:"temperature,omitempty"`
-	ModelOverride string  `json:"model_override,omitempty"`
+	global.GenerationParams
+	ModelOverride string `json:"model_override,omitempty"`
+	// ResponseSchema is the JSON schema the response must conform to, set only for
+	// LLMs with structured_output so that the provider enforces it natively
+	ResponseSchema string `json:"response_schema,omitempty"`
+
+	// Per-call controls, recorded in the DispatchResult. Command LLMs receive the
+	// priority, cache control and label through the {{PRIORITY}},
+	// {{CACHE_CONTROL}} and {{LABEL}} placeholders in their args.
+	MaxOutputBytes int64  `json:"max_output_bytes,omitempty"` // Stdout kept from the call (0 = all)
+	WorkingDir     string `json:"working_dir,omitempty"`      // Overrides the LLM's working_dir
+	Priority       string `json:"priority,omitempty"`         // low, normal or high
+	CacheControl   string `json:"cache_control,omitempty"`    // enabled or disabled (empty = provider default)
+	Label          string `json:"label,omitempty"`            // Caller's tag for the call
 }
 
 // DispatchResult represents the result of an LLM dispatch
@@ -51,7 +84,7 @@ type DispatchResult struct {
 	// Process
 	ExitCode int    `json:"exit_code"`      // Command exit code (0 = success, non-zero = LLM error)
 	Stdout   string `json:"stdout"`         // Raw stdout (ALWAYS captured)
-	Stderr   string `json:"stderr"`         // Raw stderr (ALWAYS captured)
+	Stderr   string `json:"stderr"`         // Stderr as kept by the LLM's stderr_policy
 	Text     string `json:"text,omitempty"` // Parser-extracted response text
 
 	// Response envelope
@@ -61,6 +94,7 @@ type DispatchResult struct {
 	ResponseParsed    bool   `json:"response_parsed,omitempty"`    // true when response was successfully extracted from structured envelope
 	NormalTermination bool   `json:"normal_termination,omitempty"` // true when LLM completed normally
 	StopReason        string `json:"stop_reason,omitempty"`        // provider-reported stop reason (populated on success and abnormal termination)
+	SessionID         string `json:"session_id,omitempty"`         // provider session, for continuing the conversation
 
 	// Resource accounting (mirrors ClawEh DispatchStatus where applicable)
 	InputTokens         int     `json:"input_tokens,omitempty"`
@@ -73,6 +107,15 @@ type DispatchResult struct {
 	BytesReceived       int64   `json:"bytes_received,omitempty"` // Raw stdout byte count (alias of ResponseSize for clarity)
 	ProviderModel       string  `json:"provider_model,omitempty"` // Provider-returned model name (distinct from Maestro's config ID)
 	Success             bool    `json:"success"`                  // True iff ExitCode == 0 AND no provider-reported error
+
+	// Per-call controls as applied
+	Label           string `json:"label,omitempty"`
+	Priority        string `json:"priority,omitempty"`
+	CacheControl    string `json:"cache_control,omitempty"`
+	WorkingDir      string `json:"working_dir,omitempty"`
+	OutputTruncated bool   `json:"output_truncated,omitempty"` // Stdout exceeded max_output_bytes and was cut
+
+	rawStderr string // Stderr before the stderr policy, for rate-limit detection
 }
 
 // ProviderReportedError reports whether the provider surfaced an error in its
@@ -122,6 +165,7 @@ func NewService(cfg *config.Config, logger *logging.Logger, libraryService *libr
 		logger:    logger,
 		library:   libraryService,
 		llmConfig: llmConfig,
+		probes:    make(map[string][]ProbeRecord),
 	}
 }
 
@@ -129,9 +173,12 @@ func NewService(cfg *config.Config, logger *logging.Logger, libraryService *libr
 //
 //goland:noinspection GoNameStartsWithPackageName
 type LLMInfo struct {
-	ID          string `json:"id"`
-	Description string `json:"description"`
-	Enabled     bool   `json:"enabled"`
+	ID               string `json:"id"`
+	Description      string `json:"description"`
+	Enabled          bool   `json:"enabled"`
+	StructuredOutput bool   `json:"structured_output,omitempty"`
+	// Availability summarizes recent probes (nil until the LLM is probed)
+	Availability *Availability `json:"availability,omitempty"`
 }
 
 // LLMExecInfo represents execution details for an LLM (for logging)
@@ -157,9 +204,11 @@ func (s *Service) ListLLMs() *LLMListResult {
 
 	for _, llm := range s.config.LLMs() {
 		llms = append(llms, LLMInfo{
-			ID:          llm.ID,
-			Description: llm.Description,
-			Enabled:     llm.Enabled,
+			ID:               llm.ID,
+			Description:      llm.Description,
+			Enabled:          llm.Enabled,
+			StructuredOutput: llm.StructuredOutput,
+			Availability:     s.Availability(llm.ID),
 		})
 	}
 
@@ -215,6 +264,18 @@ func (s *Service) validateRequest(req *DispatchRequest) (*config.LLM, error) {
 		return nil, fmt.Errorf("prompt is required")
 	}
 
+	for i, msg := range req.Messages {
+		switch msg.Role {
+		case global.MessageRoleSystem, global.MessageRoleUser, global.MessageRoleAssistant:
+		default:
+			return nil, fmt.Errorf("message %d has invalid role %q (must be system, user, or assistant)", i+1, msg.Role)
+		}
+	}
+
+	if err := s.validateOptions(req.Options); err != nil {
+		return nil, err
+	}
+
 	// Resolve alias to canonical id before lookup
 	canonical := s.config.ResolveID(req.LLMID)
 	llm, exists := s.llmConfig[canonical]
@@ -229,6 +290,40 @@ func (s *Service) validateRequest(req *DispatchRequest) (*config.LLM, error) {
 	return llm, nil
 }
 
+// validateOptions validates the per-call controls of a dispatch request
+func (s *Service) validateOptions(opts *DispatchOptions) error {
+	if opts == nil {
+		return nil
+	}
+	if opts.MaxOutputBytes < 0 {
+		return fmt.Errorf("max_output_bytes cannot be negative")
+	}
+	switch opts.Priority {
+	case "", global.DispatchPriorityLow, global.DispatchPriorityNormal, global.DispatchPriorityHigh:
+	default:
+		return fmt.Errorf("invalid priority %q (must be low, normal, or high)", opts.Priority)
+	}
+	switch opts.CacheControl {
+	case "", global.CacheControlEnabled, global.CacheControlDisabled:
+	default:
+		return fmt.Errorf("invalid cache_control %q (must be enabled or disabled)", opts.CacheControl)
+	}
+	if opts.WorkingDir != "" {
+		if !filepath.IsAbs(opts.WorkingDir) {
+			return fmt.Errorf("working_dir must be an absolute path: %s", opts.WorkingDir)
+		}
+		if s.config.ChrootEnabled() {
+			if _, err := global.ValidatePathWithinDir(s.config.Chroot(), opts.WorkingDir); err != nil {
+				return fmt.Errorf("working_dir is outside the chroot: %s", opts.WorkingDir)
+			}
+		}
+		if !global.DirExists(opts.WorkingDir) {
+			return fmt.Errorf("working_dir does not exist: %s", opts.WorkingDir)
+		}
+	}
+	return nil
+}
+
 // loadContextContent loads content from context keys
 // Note: Context injection via library is deprecated. Use project files instead.
 func (s *Service) loadContextContent(contextKeys []string) (string, error) {
@@ -238,7 +333,11 @@ func (s *Service) loadContextContent(contextKeys []string) (string, error) {
 
 	// Library is no longer used - context injection is deprecated
 	if s.library == nil {
-		return "", fmt.Errorf("context injection is not available - use project files instead")
+		return "", &global.SubsystemUnavailableError{
+			Subsystem: global.SubsystemLibrary,
+			Feature:   "context injection (context_keys)",
+			Remedy:    "use project files instead",
+		}
 	}
 
 	var contextParts []string
@@ -276,10 +375,14 @@ func (s *Service) Dispatch(req *DispatchRequest) (*DispatchResult, error) {
 	}
 
 	// Timeout comes from the LLM config (set at load time; always >= MinTimeout)
+	// unless the request sets its own
 	timeout := llm.Timeout
 	if timeout == 0 {
 		timeout = global.DefaultTimeout
 	}
+	if req.Timeout > 0 {
+		timeout = req.Timeout
+	}
 
 	s.logger.Debugf("Dispatching to LLM %s (timeout: %ds): %s", req.LLMID, timeout, req.Prompt)
 
@@ -300,7 +403,13 @@ func (s *Service) Dispatch(req *DispatchRequest) (*DispatchResult, error) {
 	return result, nil
 }
 
-// TestLLM sends a simple test prompt to verify LLM availability
+// ContextInjection reports whether context_keys can be injected into prompts
+func (s *Service) ContextInjection() bool {
+	return s.library != nil
+}
+
+// TestLLM sends a simple test prompt to verify LLM availability, recording the
+// outcome in the LLM's probe history
 // Returns (true, nil) if LLM responds successfully
 // Returns (false, nil) if LLM is rate-limited or unavailable (exit code != 0)
 // Returns (false, error) if infrastructure error prevents test
@@ -316,26 +425,37 @@ func (s *Service) TestLLM(llmID string) (bool, error) {
 	}
 
 	// Use configured test prompt or default
-	testPrompt := "Respond with only the word OK"
+	testPrompt := global.DefaultLLMTestPrompt
 	if llm.RecoveryConfig != nil && llm.RecoveryConfig.TestPrompt != "" {
 		testPrompt = llm.RecoveryConfig.TestPrompt
 	}
 
+	start := time.Now()
 	result, err := s.Dispatch(&DispatchRequest{
 		LLMID:  llmID,
 		Prompt: testPrompt,
 	})
+	record := ProbeRecord{At: start, DurationMs: time.Since(start).Milliseconds()}
 
 	if err != nil {
+		record.Error = err.Error()
+		s.recordProbe(canonical, record)
 		return false, err // Infrastructure failure
 	}
 
 	// Check for rate limit patterns
 	if s.IsRateLimited(result, llm) {
+		record.Error = "rate limited"
+		s.recordProbe(canonical, record)
 		return false, nil // Rate limited
 	}
 
-	return result.ExitCode == 0, nil
+	record.OK = result.ExitCode == 0
+	if !record.OK {
+		record.Error = fmt.Sprintf("exit code %d", result.ExitCode)
+	}
+	s.recordProbe(canonical, record)
+	return record.OK, nil
 }
 
 // IsRateLimited checks if a dispatch result indicates rate limiting
@@ -348,7 +468,11 @@ func (s *Service) IsRateLimited(result *DispatchResult, llm *config.LLM) bool {
 		return false
 	}
 
-	combined := strings.ToLower(result.Stdout + result.Stderr)
+	stderr := result.Stderr
+	if result.rawStderr != "" {
+		stderr = result.rawStderr
+	}
+	combined := strings.ToLower(result.Stdout + stderr)
 	for _, pattern := range llm.RecoveryConfig.RateLimitPatterns {
 		if strings.Contains(combined, strings.ToLower(pattern)) {
 			return true
@@ -357,29 +481,63 @@ func (s *Service) IsRateLimited(result *DispatchResult, llm *config.LLM) bool {
 	return false
 }
 
+// outputSanitization returns the configured cleanup of LLM output
+func (s *Service) outputSanitization() global.OutputSanitization {
+	if s.config == nil {
+		return global.OutputSanitization{}
+	}
+	return s.config.OutputSanitization()
+}
+
 // callCommandLLM executes a command-line LLM
 func (s *Service) callCommandLLM(llm *config.LLM, req *DispatchRequest, contextContent string, timeout int) (*DispatchResult, error) {
-	// Build the full prompt with context
+	// Continuing a session sends only the new turn; the provider holds the rest
+	argTemplate := llm.Args
+	resume := req.SessionID != "" && len(llm.ResumeArgs) > 0
+	if resume {
+		argTemplate = make([]string, len(llm.ResumeArgs))
+		for i, arg := range llm.ResumeArgs {
+			argTemplate[i] = strings.ReplaceAll(arg, global.PlaceholderSessionID, req.SessionID)
+		}
+	}
+
+	// Build the full prompt with context and any earlier turns
 	var fullPrompt strings.Builder
-	if contextContent != "" {
-		fullPrompt.WriteString(contextContent)
+	if resume {
+		fullPrompt.WriteString(req.Prompt)
+	} else {
+		if contextContent != "" {
+			fullPrompt.WriteString(contextContent)
+		}
+		fullPrompt.WriteString(conversationTranscript(req.Messages))
+		fullPrompt.WriteString("=== TASK ===\n")
+		fullPrompt.WriteString(req.Prompt)
 	}
-	fullPrompt.WriteString("=== TASK ===\n")
-	fullPrompt.WriteString(req.Prompt)
 
 	promptText := fullPrompt.String()
 
-	// Build args - substitute {{PROMPT}} unless using stdin
-	var args []string
-	if llm.Stdin {
-		// Use args as-is when using stdin
-		args = llm.Args
+	// Build args - substitute generation parameters, the response schema, and
+	// {{PROMPT}} unless using stdin
+	generation := llm.GenerationFor(1)
+	var schema string
+	opts := req.Options
+	if opts != nil {
+		generation = opts.GenerationParams
+		schema = opts.ResponseSchema
 	} else {
-		// Substitute {{PROMPT}} placeholder in args
-		args = make([]string, len(llm.Args))
-		for i, arg := range llm.Args {
-			args[i] = strings.ReplaceAll(arg, "{{PROMPT}}", promptText)
+		opts = &DispatchOptions{}
+	}
+	schemaArgList, cleanup, schemaErr := schemaArgs(keepAliveArgs(optionArgs(generationArgs(argTemplate, generation), opts), llm.Warmup), schema)
+	if schemaErr != nil {
+		return nil, fmt.Errorf("infrastructure failure: %w", schemaErr)
+	}
+	defer cleanup()
+	args := make([]string, 0, len(llm.Args))
+	for _, arg := range schemaArgList {
+		if !llm.Stdin {
+			arg = strings.ReplaceAll(arg, "{{PROMPT}}", promptText)
 		}
+		args = append(args, arg)
 	}
 
 	// Compute bytes handed to the child process (prompt + args), used for
@@ -421,6 +579,22 @@ func (s *Service) callCommandLLM(llm *config.LLM, req *DispatchRequest, contextC
 	if llm.WorkingDir != "" {
 		cmd.Dir = llm.WorkingDir
 	}
+	if opts.WorkingDir != "" {
+		cmd.Dir = opts.WorkingDir
+	}
+
+	// Add the task's environment variables, then the LLM's own (e.g. its API
+	// key), to Maestro's. The last value of a name wins, so a task cannot
+	// override the LLM's settings.
+	if len(req.Env) > 0 || len(llm.Env) > 0 {
+		cmd.Env = os.Environ()
+		for name, value := range req.Env {
+			cmd.Env = append(cmd.Env, name+"="+value)
+		}
+		for name, value := range llm.Env {
+			cmd.Env = append(cmd.Env, name+"="+value)
+		}
+	}
 
 	// WaitDelay is a safety net: if our process-group kill fails (e.g., a grandchild
 	// escaped the group via its own setsid) and a pipe-holding process is still running,
@@ -429,9 +603,10 @@ func (s *Service) callCommandLLM(llm *config.LLM, req *DispatchRequest, contextC
 	// already sent SIGKILL, so any remaining process is truly stuck.
 	cmd.WaitDelay = 30 * time.Second
 
-	// Capture stdout and stderr
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
+	// Capture stdout (up to max_output_bytes) and stderr
+	stdout := &limitedBuffer{max: opts.MaxOutputBytes}
+	var stderr bytes.Buffer
+	cmd.Stdout = stdout
 	cmd.Stderr = &stderr
 
 	// Pipe prompt to stdin if configured
@@ -447,6 +622,9 @@ func (s *Service) callCommandLLM(llm *config.LLM, req *DispatchRequest, contextC
 	if startErr := cmd.Start(); startErr != nil {
 		return nil, fmt.Errorf("infrastructure failure: %w", startErr)
 	}
+	if req.OnStart != nil {
+		req.OnStart(cmd.Process.Pid)
+	}
 
 	// processExited is closed by the main goroutine after cmd.Wait() returns,
 	// signalling the watchdog goroutine to exit cleanly.
@@ -495,11 +673,15 @@ func (s *Service) callCommandLLM(llm *config.LLM, req *DispatchRequest, contextC
 
 	// Capture raw stdout/stderr byte counts BEFORE any trimming/parsing; this is
 	// the wire-level byte count that BytesReceived must reflect.
-	rawStdoutLen := stdout.Len()
+	rawStdoutLen := stdout.received
+	if stdout.truncated {
+		s.logger.Warnf("LLM %s output truncated to max_output_bytes (%d of %d bytes kept)", llm.ID, opts.MaxOutputBytes, rawStdoutLen)
+	}
 
-	// Get output (always capture stdout and stderr)
-	output := strings.TrimSpace(stdout.String())
-	stderrOutput := strings.TrimSpace(stderr.String())
+	// Get output (always capture stdout and stderr), without terminal noise
+	sanitization := s.outputSanitization()
+	output := strings.TrimSpace(sanitization.Apply(stdout.String()))
+	stderrOutput := strings.TrimSpace(sanitization.Apply(stderr.String()))
 	responseSize := len(output)
 
 	// Check for infrastructure failures (command couldn't execute at all)
@@ -545,14 +727,15 @@ func (s *Service) callCommandLLM(llm *config.LLM, req *DispatchRequest, contextC
 	result := &DispatchResult{
 		ExitCode:            exitCode,
 		Stdout:              output,
-		Stderr:              stderrOutput,
-		Text:                parsed.Text,
+		Stderr:              applyStderrPolicy(stderrOutput, llm),
+		Text:                sanitization.Apply(parsed.Text),
 		IsError:             parsed.IsError,
 		NumTurns:            parsed.NumTurns,
 		ResponseSize:        responseSize,
 		ResponseParsed:      parsed.ResponseParsed,
 		NormalTermination:   normalTermination,
 		StopReason:          parsed.StopReason,
+		SessionID:           parsed.SessionID,
 		InputTokens:         parsed.InputTokens,
 		OutputTokens:        parsed.OutputTokens,
 		CacheReadTokens:     parsed.CacheReadTokens,
@@ -562,6 +745,12 @@ func (s *Service) callCommandLLM(llm *config.LLM, req *DispatchRequest, contextC
 		BytesSent:           bytesSent,
 		BytesReceived:       int64(rawStdoutLen),
 		ProviderModel:       parsed.ProviderModel,
+		Label:               opts.Label,
+		Priority:            opts.Priority,
+		CacheControl:        opts.CacheControl,
+		WorkingDir:          cmd.Dir,
+		OutputTruncated:     stdout.truncated,
+		rawStderr:           stderrOutput,
 	}
 	result.Success = exitCode == 0 && !result.ProviderReportedError()
 
@@ -571,3 +760,49 @@ func (s *Service) callCommandLLM(llm *config.LLM, req *DispatchRequest, contextC
 
 	return result, nil
 }
+
+// conversationTranscript renders earlier turns of a conversation for a command
+// LLM, which takes a single prompt
+func conversationTranscript(messages []global.ConversationMessage) string {
+	if len(messages) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("=== CONVERSATION SO FAR ===\n\n")
+	for _, msg := range messages {
+		sb.WriteString(fmt.Sprintf("--- %s ---\n", strings.ToUpper(msg.Role)))
+		sb.WriteString(msg.Content)
+		sb.WriteString("\n\n")
+	}
+	return sb.String()
+}
+
+// limitedBuffer keeps at most max bytes of what is written to it (all of it when
+// max is 0). The rest is counted and discarded rather than refused, so the
+// process is never blocked on a full pipe.
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	max       int64
+	received  int64
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	b.received += int64(len(p))
+	if b.max > 0 {
+		room := b.max - int64(b.buf.Len())
+		if int64(len(p)) > room {
+			b.truncated = true
+			if room > 0 {
+				b.buf.Write(p[:room])
+			}
+			return len(p), nil
+		}
+	}
+	return b.buf.Write(p)
+}
+
+// String returns the bytes kept
+func (b *limitedBuffer) String() string {
+	return b.buf.String()
+}
@@ -0,0 +1,126 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package llm
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/PivotLLM/Maestro/config"
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// generationArgs substitutes generation parameters in command args. An arg that
+// refers to a parameter that is not set is omitted, so flags should be written as
+// a single arg (e.g. "--temperature={{TEMPERATURE}}").
+func generationArgs(args []string, g global.GenerationParams) []string {
+	values := map[string]string{}
+	if g.Temperature != nil {
+		values[global.PlaceholderTemperature] = strconv.FormatFloat(*g.Temperature, 'f', -1, 64)
+	}
+	if g.TopP != nil {
+		values[global.PlaceholderTopP] = strconv.FormatFloat(*g.TopP, 'f', -1, 64)
+	}
+	if g.MaxTokens != 0 {
+		values[global.PlaceholderMaxTokens] = strconv.Itoa(g.MaxTokens)
+	}
+
+	return substituteArgs(args, values, global.PlaceholderTemperature, global.PlaceholderTopP, global.PlaceholderMaxTokens)
+}
+
+// optionArgs substitutes the per-call priority, cache control and label of a
+// dispatch in command args, omitting args that refer to an option that is not set
+func optionArgs(args []string, opts *DispatchOptions) []string {
+	values := map[string]string{}
+	if opts.Priority != "" {
+		values[global.PlaceholderPriority] = opts.Priority
+	}
+	if opts.CacheControl != "" {
+		values[global.PlaceholderCacheControl] = opts.CacheControl
+	}
+	if opts.Label != "" {
+		values[global.PlaceholderLabel] = opts.Label
+	}
+	return substituteArgs(args, values, global.PlaceholderPriority, global.PlaceholderCacheControl, global.PlaceholderLabel)
+}
+
+// keepAliveArgs substitutes an LLM's warm standby keep-alive in command args,
+// omitting args that refer to it when no keep-alive is configured
+func keepAliveArgs(args []string, warmup *config.LLMWarmup) []string {
+	values := map[string]string{}
+	if warmup != nil && warmup.KeepAlive != "" {
+		values[global.PlaceholderKeepAlive] = warmup.KeepAlive
+	}
+	return substituteArgs(args, values, global.PlaceholderKeepAlive)
+}
+
+// substituteArgs replaces placeholders in args with their values. An arg that
+// refers to a placeholder without a value is omitted.
+func substituteArgs(args []string, values map[string]string, placeholders ...string) []string {
+	result := make([]string, 0, len(args))
+	for _, arg := range args {
+		keep := true
+		for _, placeholder := range placeholders {
+			if !strings.Contains(arg, placeholder) {
+				continue
+			}
+			value, ok := values[placeholder]
+			if !ok {
+				keep = false
+				break
+			}
+			arg = strings.ReplaceAll(arg, placeholder, value)
+		}
+		if keep {
+			result = append(result, arg)
+		}
+	}
+	return result
+}
+
+// schemaArgs substitutes the response schema in command args: {{SCHEMA}} with the
+// schema JSON and {{SCHEMA_FILE}} with the path of a temporary file holding it.
+// Without a schema, args that refer to it are omitted. The returned cleanup
+// removes the temporary file and must be called once the command has finished.
+func schemaArgs(args []string, schema string) ([]string, func(), error) {
+	cleanup := func() {}
+	var schemaFile string
+
+	result := make([]string, 0, len(args))
+	for _, arg := range args {
+		hasSchema := strings.Contains(arg, global.PlaceholderSchema)
+		hasSchemaFile := strings.Contains(arg, global.PlaceholderSchemaFile)
+		if !hasSchema && !hasSchemaFile {
+			result = append(result, arg)
+			continue
+		}
+		if schema == "" {
+			continue
+		}
+		if hasSchemaFile && schemaFile == "" {
+			f, err := os.CreateTemp("", global.SchemaFilePattern)
+			if err != nil {
+				return nil, cleanup, fmt.Errorf("failed to create schema file: %w", err)
+			}
+			schemaFile = f.Name()
+			cleanup = func() { _ = os.Remove(schemaFile) }
+			_, err = f.WriteString(schema)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				cleanup()
+				return nil, func() {}, fmt.Errorf("failed to write schema file: %w", err)
+			}
+		}
+		arg = strings.ReplaceAll(arg, global.PlaceholderSchemaFile, schemaFile)
+		arg = strings.ReplaceAll(arg, global.PlaceholderSchema, schema)
+		result = append(result, arg)
+	}
+	return result, cleanup, nil
+}
@@ -0,0 +1,125 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package llm
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PivotLLM/Maestro/config"
+	"github.com/PivotLLM/Maestro/global"
+)
+
+func TestGenerationArgs(t *testing.T) {
+	args := []string{"-p", "{{PROMPT}}", "--temperature={{TEMPERATURE}}", "--top-p={{TOP_P}}", "--max-tokens={{MAX_TOKENS}}"}
+	temperature := 0.7
+
+	got := generationArgs(args, global.GenerationParams{Temperature: &temperature, MaxTokens: 4096})
+	want := []string{"-p", "{{PROMPT}}", "--temperature=0.7", "--max-tokens=4096"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("generationArgs() = %v, want %v", got, want)
+	}
+
+	got = generationArgs(args, global.GenerationParams{})
+	want = []string{"-p", "{{PROMPT}}"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("generationArgs() without parameters = %v, want %v", got, want)
+	}
+}
+
+func TestSchemaArgs(t *testing.T) {
+	schema := `{"type":"object"}`
+	args := []string{"-p", "{{PROMPT}}", "--json-schema={{SCHEMA}}", "--schema-file={{SCHEMA_FILE}}"}
+
+	got, cleanup, err := schemaArgs(args, schema)
+	if err != nil {
+		t.Fatalf("schemaArgs() error: %v", err)
+	}
+	if len(got) != 4 || got[2] != "--json-schema="+schema || !strings.HasPrefix(got[3], "--schema-file=") {
+		t.Fatalf("schemaArgs() = %v, want the schema substituted", got)
+	}
+	schemaFile := strings.TrimPrefix(got[3], "--schema-file=")
+	data, err := os.ReadFile(schemaFile)
+	if err != nil || string(data) != schema {
+		t.Errorf("schema file %s = %q (%v), want %q", schemaFile, data, err, schema)
+	}
+	cleanup()
+	if _, err := os.Stat(schemaFile); !os.IsNotExist(err) {
+		t.Errorf("schema file %s not removed by cleanup", schemaFile)
+	}
+
+	got, cleanup, err = schemaArgs(args, "")
+	if err != nil {
+		t.Fatalf("schemaArgs() without schema error: %v", err)
+	}
+	cleanup()
+	want := []string{"-p", "{{PROMPT}}"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("schemaArgs() without schema = %v, want %v", got, want)
+	}
+}
+
+func TestOptionArgs(t *testing.T) {
+	args := []string{"-p", "{{PROMPT}}", "--priority={{PRIORITY}}", "--cache={{CACHE_CONTROL}}", "--tag={{LABEL}}"}
+
+	got := optionArgs(args, &DispatchOptions{Priority: "low", Label: "nightly"})
+	want := []string{"-p", "{{PROMPT}}", "--priority=low", "--tag=nightly"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("optionArgs() = %v, want %v", got, want)
+	}
+}
+
+func TestLimitedBuffer(t *testing.T) {
+	b := &limitedBuffer{max: 5}
+	for _, chunk := range []string{"abc", "defg", "hi"} {
+		if n, err := b.Write([]byte(chunk)); n != len(chunk) || err != nil {
+			t.Fatalf("Write(%q) = %d, %v", chunk, n, err)
+		}
+	}
+	if b.String() != "abcde" || b.received != 9 || !b.truncated {
+		t.Errorf("limitedBuffer = %q (received %d, truncated %v)", b.String(), b.received, b.truncated)
+	}
+
+	unlimited := &limitedBuffer{}
+	_, _ = unlimited.Write([]byte("abcdefghi"))
+	if unlimited.String() != "abcdefghi" || unlimited.truncated {
+		t.Errorf("unlimited buffer = %q (truncated %v)", unlimited.String(), unlimited.truncated)
+	}
+}
+
+func TestGenerationFor(t *testing.T) {
+	temperature, lower, lowest := 0.7, 0.3, 0.0
+	llm := &config.LLM{
+		Generation: &global.GenerationParams{Temperature: &temperature, MaxTokens: 2048},
+		RetryGeneration: []global.GenerationParams{
+			{Temperature: &lower},
+			{Temperature: &lowest, MaxTokens: 4096},
+		},
+	}
+
+	tests := []struct {
+		attempt     int
+		temperature float64
+		maxTokens   int
+	}{
+		{1, 0.7, 2048},
+		{2, 0.3, 2048},
+		{3, 0.0, 4096},
+		{5, 0.0, 4096}, // the last entry applies to later attempts
+	}
+	for _, tt := range tests {
+		g := llm.GenerationFor(tt.attempt)
+		if g.Temperature == nil || *g.Temperature != tt.temperature || g.MaxTokens != tt.maxTokens {
+			t.Errorf("attempt %d: got %s, want temperature=%v max_tokens=%d", tt.attempt, g, tt.temperature, tt.maxTokens)
+		}
+	}
+
+	if g := (&config.LLM{}).GenerationFor(3); !g.IsEmpty() {
+		t.Errorf("GenerationFor() without config = %s, want empty", g)
+	}
+}
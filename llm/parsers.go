@@ -30,6 +30,7 @@ type ParsedOutput struct {
 	IsError           bool
 	NormalTermination bool
 	StopReason        string
+	SessionID         string // Provider session, for continuing the conversation
 
 	// Resource accounting
 	NumTurns            int
@@ -79,6 +80,7 @@ type claudeResultLine struct {
 	StopReason   string           `json:"stop_reason"`
 	TotalCostUSD float64          `json:"total_cost_usd"`
 	Usage        claudeUsageBlock `json:"usage"`
+	SessionID    string           `json:"session_id"`
 	// Model is the envelope's top-level model field. Claude CLI sets this to
 	// the *last* model used in the turn, which is often a helper tier even when
 	// the bulk of work ran on a higher-tier model. Used only as a fallback when
@@ -123,11 +125,12 @@ type geminiCliTokens struct {
 
 // codexEvent represents a single JSONL event from `codex exec --json`.
 type codexEvent struct {
-	Type    string          `json:"type"`
-	Message string          `json:"message,omitempty"`
-	Item    *codexItem      `json:"item,omitempty"`
-	Error   *codexEventErr  `json:"error,omitempty"`
-	Usage   *codexUsageInfo `json:"usage,omitempty"`
+	Type     string          `json:"type"`
+	ThreadID string          `json:"thread_id,omitempty"`
+	Message  string          `json:"message,omitempty"`
+	Item     *codexItem      `json:"item,omitempty"`
+	Error    *codexEventErr  `json:"error,omitempty"`
+	Usage    *codexUsageInfo `json:"usage,omitempty"`
 }
 
 type codexItem struct {
@@ -212,6 +215,7 @@ func parseClaudeOutput(stdout string) ParsedOutput {
 			IsError:             r.IsError,
 			NormalTermination:   normalTermination,
 			StopReason:          stopReason,
+			SessionID:           r.SessionID,
 			NumTurns:            r.NumTurns,
 			InputTokens:         r.Usage.InputTokens,
 			OutputTokens:        r.Usage.OutputTokens,
@@ -344,6 +348,7 @@ func parseGeminiOutput(stdout string) ParsedOutput {
 		IsError:           !normalTermination,
 		NormalTermination: normalTermination,
 		StopReason:        stopReason,
+		SessionID:         resp.SessionID,
 		InputTokens:       chosenModelStats.Tokens.Input,
 		OutputTokens:      chosenModelStats.Tokens.Candidates,
 		CacheReadTokens:   chosenModelStats.Tokens.Cached,
@@ -373,6 +378,7 @@ func parseCodexOutput(stdout string) ParsedOutput {
 		stopReason  string
 		usage       codexUsageInfo
 		usageSeen   bool
+		threadID    string
 	)
 
 	scanner := bufio.NewScanner(strings.NewReader(stdout))
@@ -391,6 +397,8 @@ func parseCodexOutput(stdout string) ParsedOutput {
 		foundEvents = true
 
 		switch event.Type {
+		case "thread.started":
+			threadID = event.ThreadID
 		case "item.completed":
 			if event.Item != nil && event.Item.Type == "agent_message" && event.Item.Text != "" {
 				parts = append(parts, event.Item.Text)
@@ -436,6 +444,7 @@ func parseCodexOutput(stdout string) ParsedOutput {
 		IsError:           isError,
 		NormalTermination: !isError,
 		StopReason:        stopReason,
+		SessionID:         threadID,
 	}
 	if usageSeen {
 		out.InputTokens = usage.InputTokens
@@ -27,6 +27,9 @@ func TestParseClaudeOutput_SuccessPopulatesResourceFields(t *testing.T) {
 	if got.Text != "Hi!" {
 		t.Errorf("Text = %q, want %q", got.Text, "Hi!")
 	}
+	if got.SessionID != "s" {
+		t.Errorf("SessionID = %q, want %q", got.SessionID, "s")
+	}
 	if got.NumTurns != 1 {
 		t.Errorf("NumTurns = %d, want 1", got.NumTurns)
 	}
@@ -172,6 +175,9 @@ func TestParseCodexOutput_SuccessPopulatesTokens(t *testing.T) {
 	if got.CacheReadTokens != 7552 {
 		t.Errorf("CacheReadTokens = %d, want 7552", got.CacheReadTokens)
 	}
+	if got.SessionID != "t" {
+		t.Errorf("SessionID = %q, want %q", got.SessionID, "t")
+	}
 }
 
 func TestParseCodexOutput_TurnFailedFlagsError(t *testing.T) {
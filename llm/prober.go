@@ -0,0 +1,172 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package llm
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// ProbeRecord is the outcome of one availability probe of an LLM
+type ProbeRecord struct {
+	At         time.Time `json:"at"`
+	OK         bool      `json:"ok"`
+	Error      string    `json:"error,omitempty"`
+	DurationMs int64     `json:"duration_ms"`
+}
+
+// Availability summarizes an LLM's probes over the last LLMProbeHistoryHours
+type Availability struct {
+	Summary         string    `json:"summary"` // e.g. "last OK 4m ago, 98% 24h availability"
+	LastProbe       time.Time `json:"last_probe"`
+	LastProbeOK     bool      `json:"last_probe_ok"`
+	LastOK          time.Time `json:"last_ok,omitempty"`
+	LastError       string    `json:"last_error,omitempty"`
+	Probes          int       `json:"probes"`
+	AvailabilityPct float64   `json:"availability_pct"`
+}
+
+// ProbeHistory is implemented by dispatchers that keep availability probe
+// history, letting the runner skip pre-flight test calls for LLMs that were
+// recently probed successfully
+type ProbeHistory interface {
+	RecentlyAvailable(llmID string, maxAge time.Duration) (time.Time, bool)
+}
+
+// recordProbe adds a probe outcome to an LLM's history, dropping records older
+// than the history window
+func (s *Service) recordProbe(llmID string, record ProbeRecord) {
+	s.probeMu.Lock()
+	defer s.probeMu.Unlock()
+
+	if s.probes == nil {
+		s.probes = make(map[string][]ProbeRecord)
+	}
+	cutoff := record.At.Add(-global.LLMProbeHistoryHours * time.Hour)
+	history := s.probes[llmID]
+	start := 0
+	for start < len(history) && history[start].At.Before(cutoff) {
+		start++
+	}
+	s.probes[llmID] = append(history[start:], record)
+}
+
+// Availability returns an LLM's probe statistics, or nil if it has not been probed
+func (s *Service) Availability(llmID string) *Availability {
+	s.probeMu.Lock()
+	defer s.probeMu.Unlock()
+
+	history := s.probes[s.config.ResolveID(llmID)]
+	if len(history) == 0 {
+		return nil
+	}
+
+	cutoff := time.Now().Add(-global.LLMProbeHistoryHours * time.Hour)
+	a := &Availability{}
+	ok := 0
+	for _, record := range history {
+		if record.At.Before(cutoff) {
+			continue
+		}
+		a.Probes++
+		if record.OK {
+			ok++
+			a.LastOK = record.At
+		}
+	}
+	last := history[len(history)-1]
+	a.LastProbe, a.LastProbeOK, a.LastError = last.At, last.OK, last.Error
+	if a.Probes > 0 {
+		a.AvailabilityPct = float64(ok*1000/a.Probes) / 10
+	}
+
+	lastOK := "never OK"
+	if !a.LastOK.IsZero() {
+		lastOK = fmt.Sprintf("last OK %s ago", formatAge(time.Since(a.LastOK)))
+	}
+	a.Summary = fmt.Sprintf("%s, %g%% %dh availability", lastOK, a.AvailabilityPct, global.LLMProbeHistoryHours)
+	if !last.OK {
+		a.Summary += fmt.Sprintf(", last probe failed %s ago", formatAge(time.Since(last.At)))
+	}
+	return a
+}
+
+// RecentlyAvailable reports whether the LLM's most recent probe succeeded
+// within maxAge, returning when it ran
+func (s *Service) RecentlyAvailable(llmID string, maxAge time.Duration) (time.Time, bool) {
+	if maxAge <= 0 {
+		return time.Time{}, false
+	}
+	s.probeMu.Lock()
+	defer s.probeMu.Unlock()
+
+	history := s.probes[s.config.ResolveID(llmID)]
+	if len(history) == 0 {
+		return time.Time{}, false
+	}
+	last := history[len(history)-1]
+	return last.At, last.OK && time.Since(last.At) <= maxAge
+}
+
+// ProbeAll sends every enabled LLM its test prompt, recording the outcomes
+func (s *Service) ProbeAll() {
+	for _, llm := range s.config.EnabledLLMs() {
+		available, err := s.TestLLM(llm.ID)
+		switch {
+		case err != nil:
+			s.logger.Warnf("LLM probe: %s failed: %v", llm.ID, err)
+		case !available:
+			s.logger.Warnf("LLM probe: %s is not available", llm.ID)
+		default:
+			s.logger.Debugf("LLM probe: %s OK", llm.ID)
+		}
+	}
+}
+
+// StartProber starts the background job that probes every enabled LLM every
+// llm_probe.interval_minutes. It returns a function that stops the job; when the
+// interval is 0 no job is started and stop is a no-op.
+func (s *Service) StartProber() (stop func()) {
+	p := s.config.LLMProbe()
+	if p.IntervalMinutes <= 0 {
+		return func() {}
+	}
+
+	interval := time.Duration(p.IntervalMinutes) * time.Minute
+	s.logger.Infof("LLM probing enabled: every %dm, pre-flight max age %dm", p.IntervalMinutes, p.PreflightMaxAgeMinutes)
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				s.ProbeAll()
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
+
+// formatAge formats a duration as a short age such as "45s", "4m" or "3h"
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+}
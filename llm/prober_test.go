@@ -0,0 +1,58 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package llm
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PivotLLM/Maestro/config"
+)
+
+func TestProbeHistory(t *testing.T) {
+	s := &Service{config: config.New()}
+	if s.Availability("claude") != nil {
+		t.Error("expected no availability before any probe")
+	}
+
+	now := time.Now()
+	s.recordProbe("claude", ProbeRecord{At: now.Add(-30 * time.Hour), OK: false, Error: "exit code 1"}) // Outside the window
+	for i := 49; i > 0; i-- {
+		s.recordProbe("claude", ProbeRecord{At: now.Add(-time.Duration(i) * 10 * time.Minute), OK: i != 20})
+	}
+	s.recordProbe("claude", ProbeRecord{At: now.Add(-4 * time.Minute), OK: true})
+
+	a := s.Availability("claude")
+	if a == nil {
+		t.Fatal("expected availability after probes")
+	}
+	if a.Probes != 50 || a.AvailabilityPct != 98 || !a.LastProbeOK {
+		t.Errorf("availability = %+v", a)
+	}
+	if a.Summary != "last OK 4m ago, 98% 24h availability" {
+		t.Errorf("summary = %q", a.Summary)
+	}
+
+	if _, ok := s.RecentlyAvailable("claude", 10*time.Minute); !ok {
+		t.Error("expected a probe 4m ago to satisfy a 10m max age")
+	}
+	if _, ok := s.RecentlyAvailable("claude", 2*time.Minute); ok {
+		t.Error("expected a probe 4m ago not to satisfy a 2m max age")
+	}
+	if _, ok := s.RecentlyAvailable("claude", 0); ok {
+		t.Error("expected a zero max age to always require a test call")
+	}
+
+	// A failed latest probe is not recent availability, whatever came before
+	s.recordProbe("claude", ProbeRecord{At: now, Error: "rate limited"})
+	if _, ok := s.RecentlyAvailable("claude", time.Hour); ok {
+		t.Error("expected a failed latest probe to require a test call")
+	}
+	if a := s.Availability("claude"); a.LastProbeOK || !strings.Contains(a.Summary, "last probe failed") {
+		t.Errorf("availability after failure = %+v", a)
+	}
+}
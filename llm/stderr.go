@@ -0,0 +1,46 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package llm
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/PivotLLM/Maestro/config"
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// applyStderrPolicy returns the part of an LLM process's stderr that is kept in
+// dispatch results, and from there in history and result files
+func applyStderrPolicy(stderr string, llm *config.LLM) string {
+	switch llm.GetStderrPolicy() {
+	case config.StderrPolicyDiscard:
+		return ""
+	case config.StderrPolicyKeepAll:
+		return stderr
+	case config.StderrPolicyStripANSI:
+		return strings.TrimSpace(global.StripANSI(stderr))
+	default:
+		return tailText(strings.TrimSpace(global.StripANSI(stderr)), llm.GetStderrTailKB()*1024)
+	}
+}
+
+// tailText returns the last maxBytes of s, starting at a line boundary when there
+// is one, with a note of how much was omitted
+func tailText(s string, maxBytes int) string {
+	if len(s) <= maxBytes {
+		return s
+	}
+	start := len(s) - maxBytes
+	for start < len(s) && !utf8.RuneStart(s[start]) {
+		start++
+	}
+	if i := strings.IndexByte(s[start:], '\n'); i >= 0 && i < len(s)-start-1 {
+		start += i + 1
+	}
+	return fmt.Sprintf("[... %d bytes of stderr omitted ...]\n%s", start, s[start:])
+}
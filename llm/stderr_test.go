@@ -0,0 +1,51 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package llm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PivotLLM/Maestro/config"
+)
+
+func TestApplyStderrPolicy(t *testing.T) {
+	stderr := "\x1b[32mLoading\x1b[0m model\nready"
+
+	tests := []struct {
+		policy string
+		want   string
+	}{
+		{config.StderrPolicyDiscard, ""},
+		{config.StderrPolicyKeepAll, stderr},
+		{config.StderrPolicyStripANSI, "Loading model\nready"},
+		{"", "Loading model\nready"}, // default "tail" strips ANSI and fits within the limit
+	}
+	for _, tt := range tests {
+		got := applyStderrPolicy(stderr, &config.LLM{StderrPolicy: tt.policy})
+		if got != tt.want {
+			t.Errorf("policy %q: got %q, want %q", tt.policy, got, tt.want)
+		}
+	}
+
+	// The tail policy keeps the last lines within the limit and notes what was dropped
+	long := strings.Repeat("progress line\n", 200) + "Error: quota exceeded"
+	got := applyStderrPolicy(long, &config.LLM{StderrPolicy: config.StderrPolicyTail, StderrTailKB: 1})
+	if !strings.HasPrefix(got, "[... ") || !strings.HasSuffix(got, "Error: quota exceeded") {
+		t.Errorf("unexpected tail: %q", got)
+	}
+	if body := got[strings.Index(got, "\n")+1:]; len(body) > 1024 || !strings.HasPrefix(body, "progress line") {
+		t.Errorf("tail not cut at a line boundary within 1 KB: %d bytes, %q", len(body), body[:20])
+	}
+}
+
+func TestIsRateLimitedUsesRawStderr(t *testing.T) {
+	llm := &config.LLM{RecoveryConfig: &config.LLMRecoveryConfig{RateLimitPatterns: []string{"rate limit"}}}
+	result := &DispatchResult{ExitCode: 1, Stderr: "", rawStderr: "Error: rate limit reached"}
+	if !(&Service{}).IsRateLimited(result, llm) {
+		t.Error("rate limit in discarded stderr was not detected")
+	}
+}
@@ -0,0 +1,94 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package llm
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// StartupValidation is the outcome of sending every enabled LLM its test
+// prompt when Maestro starts
+type StartupValidation struct {
+	At      time.Time          `json:"at"`
+	Results []ValidationResult `json:"results"`
+}
+
+// ValidationResult is the outcome of validating one LLM
+type ValidationResult struct {
+	LLMID     string `json:"llm_id"`
+	OK        bool   `json:"ok"`
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+	Default   bool   `json:"default,omitempty"` // The configured default_llm
+}
+
+// ValidateLLMs sends every enabled LLM its test prompt concurrently, logging
+// each LLM's status and latency. The outcome is kept for the health tool and,
+// like any test call, recorded in the availability probe history.
+func (s *Service) ValidateLLMs() *StartupValidation {
+	enabled := s.config.EnabledLLMs()
+	defaultLLM := s.config.ResolveID(s.config.DefaultLLM())
+	validation := &StartupValidation{At: time.Now(), Results: make([]ValidationResult, len(enabled))}
+
+	var wg sync.WaitGroup
+	for i, l := range enabled {
+		wg.Add(1)
+		go func(i int, llmID string) {
+			defer wg.Done()
+			start := time.Now()
+			ok, err := s.TestLLM(llmID)
+			result := ValidationResult{LLMID: llmID, OK: ok, LatencyMs: time.Since(start).Milliseconds(), Default: llmID == defaultLLM}
+			switch {
+			case err != nil:
+				result.Error = err.Error()
+			case !ok:
+				result.Error = "test prompt failed or was rate limited"
+			}
+			validation.Results[i] = result
+		}(i, l.ID)
+	}
+	wg.Wait()
+
+	for _, r := range validation.Results {
+		if r.OK {
+			s.logger.Infof("LLM validation: %s OK (%dms)", r.LLMID, r.LatencyMs)
+		} else {
+			s.logger.Warnf("LLM validation: %s failed after %dms: %s", r.LLMID, r.LatencyMs, r.Error)
+		}
+	}
+
+	s.probeMu.Lock()
+	s.startup = validation
+	s.probeMu.Unlock()
+	return validation
+}
+
+// StartupValidation returns the outcome of startup validation, or nil if it has
+// not run
+func (s *Service) StartupValidation() *StartupValidation {
+	s.probeMu.Lock()
+	defer s.probeMu.Unlock()
+	return s.startup
+}
+
+// DefaultLLMError returns an error if the configured default LLM is not enabled
+// or did not pass validation, and nil when no default LLM is configured
+func (v *StartupValidation) DefaultLLMError(defaultLLM string) error {
+	if defaultLLM == "" {
+		return nil
+	}
+	for _, r := range v.Results {
+		if r.Default {
+			if r.OK {
+				return nil
+			}
+			return fmt.Errorf("default LLM %s is unreachable: %s", r.LLMID, r.Error)
+		}
+	}
+	return fmt.Errorf("default LLM %s is not enabled", defaultLLM)
+}
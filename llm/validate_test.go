@@ -0,0 +1,32 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package llm
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDefaultLLMError(t *testing.T) {
+	v := &StartupValidation{Results: []ValidationResult{
+		{LLMID: "claude", OK: true, Default: true},
+		{LLMID: "codex", Error: "exit code 1"},
+	}}
+	if err := v.DefaultLLMError("claude"); err != nil {
+		t.Errorf("expected a validated default LLM to pass, got %v", err)
+	}
+	if err := v.DefaultLLMError(""); err != nil {
+		t.Errorf("expected no error without a default LLM, got %v", err)
+	}
+
+	v.Results[0] = ValidationResult{LLMID: "claude", Error: "rate limited", Default: true}
+	if err := v.DefaultLLMError("claude"); err == nil || !strings.Contains(err.Error(), "unreachable") {
+		t.Errorf("expected unreachable default LLM error, got %v", err)
+	}
+	if err := (&StartupValidation{}).DefaultLLMError("gemini"); err == nil || !strings.Contains(err.Error(), "not enabled") {
+		t.Errorf("expected disabled default LLM error, got %v", err)
+	}
+}
@@ -6,6 +6,7 @@
 package logging
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
@@ -15,11 +16,42 @@ import (
 	"github.com/PivotLLM/Maestro/global"
 )
 
-// Logger provides structured logging with the required format
+// Logger provides structured logging with the required format. Loggers
+// returned by With share the output, level and format of the logger they were
+// derived from.
 type Logger struct {
-	logger  *log.Logger
-	level   string
-	logFile *os.File
+	*output
+	fields Fields
+}
+
+// output is the destination and settings shared by a logger and those derived from it
+type output struct {
+	logger   *log.Logger
+	level    string
+	format   string // global.LogFormatText or global.LogFormatJSON
+	logFile  *os.File
+	redactor *global.Redactor
+}
+
+// Fields are the correlation fields attached to the records of a logger. In
+// JSON format each non-empty field is a key of the record; in text format only
+// the run ID is appended, as the messages already name the task and LLM.
+type Fields struct {
+	Project  string `json:"project,omitempty"`
+	Path     string `json:"path,omitempty"`
+	RunID    string `json:"run_id,omitempty"`
+	TaskUUID string `json:"task_uuid,omitempty"`
+	TaskID   int    `json:"task_id,omitempty"`
+	LLMID    string `json:"llm_id,omitempty"`
+}
+
+// record is one log record in JSON format
+type record struct {
+	Time    string `json:"time"`
+	Level   string `json:"level"`
+	PID     int    `json:"pid"`
+	Message string `json:"msg"`
+	Fields
 }
 
 // New creates a new logger instance that writes to the specified file
@@ -45,11 +77,37 @@ func New(logPath string) (*Logger, error) {
 	}
 
 	logger := log.New(logFile, "", 0) // No default prefix/flags since we format ourselves
-	return &Logger{
+	return &Logger{output: &output{
 		logger:  logger,
 		level:   global.LogLevelInfo,
+		format:  global.LogFormatText,
 		logFile: logFile,
-	}, nil
+	}}, nil
+}
+
+// With returns a logger that adds fields to every record, on top of the fields
+// of this logger. Empty fields are left as they are.
+func (l *Logger) With(fields Fields) *Logger {
+	merged := l.fields
+	if fields.Project != "" {
+		merged.Project = fields.Project
+	}
+	if fields.Path != "" {
+		merged.Path = fields.Path
+	}
+	if fields.RunID != "" {
+		merged.RunID = fields.RunID
+	}
+	if fields.TaskUUID != "" {
+		merged.TaskUUID = fields.TaskUUID
+	}
+	if fields.TaskID != 0 {
+		merged.TaskID = fields.TaskID
+	}
+	if fields.LLMID != "" {
+		merged.LLMID = fields.LLMID
+	}
+	return &Logger{output: l.output, fields: merged}
 }
 
 // Sync flushes any buffered log data to disk
@@ -75,6 +133,17 @@ func (l *Logger) SetLevel(level string) {
 	l.level = level
 }
 
+// SetFormat sets the record format: global.LogFormatJSON for one JSON object
+// per line, anything else for the human-readable text format
+func (l *Logger) SetFormat(format string) {
+	l.format = format
+}
+
+// SetRedactor sets the secret masking applied to every message (nil disables it)
+func (l *Logger) SetRedactor(redactor *global.Redactor) {
+	l.redactor = redactor
+}
+
 // shouldLog determines if a message should be logged based on the current level
 func (l *Logger) shouldLog(level string) bool {
 	levels := map[string]int{
@@ -100,15 +169,25 @@ func (l *Logger) shouldLog(level string) bool {
 
 // formatMessage formats a log message with the required format
 func (l *Logger) formatMessage(level, message string) string {
-	timestamp := time.Now().Format("2006-01-02 15:04:05")
+	now := time.Now()
 	pid := os.Getpid()
-	return fmt.Sprintf("%s [%s] [%d] %s", timestamp, level, pid, message)
+	if l.format == global.LogFormatJSON {
+		data, err := json.Marshal(record{Time: now.Format(time.RFC3339Nano), Level: level, PID: pid, Message: message, Fields: l.fields})
+		if err == nil {
+			return string(data)
+		}
+	}
+	formatted := fmt.Sprintf("%s [%s] [%d] %s", now.Format("2006-01-02 15:04:05"), level, pid, message)
+	if l.fields.RunID != "" {
+		formatted += " run_id=" + l.fields.RunID
+	}
+	return formatted
 }
 
 // log performs the actual logging
 func (l *Logger) log(level, message string) {
 	if l.shouldLog(level) {
-		formatted := l.formatMessage(level, message)
+		formatted := l.formatMessage(level, l.redactor.Redact(message))
 		l.logger.Println(formatted)
 	}
 }
@@ -71,8 +71,10 @@ func main() {
 		_ = logger.Close()
 	}(logger)
 
-	// Set log level from config
+	// Set log level, format and secret redaction from config
 	logger.SetLevel(cfg.LogLevel())
+	logger.SetFormat(cfg.LogFormat())
+	logger.SetRedactor(cfg.Redactor())
 
 	// Announce startup
 	logger.Infof("%s v%s starting", global.ProgramName, global.Version)
@@ -112,12 +114,6 @@ func main() {
 		logger.Warn("No LLMs are enabled - llm_dispatch will not work until you enable at least one LLM in the configuration")
 	}
 
-	// Optional LLM validation on startup
-	if cfg.ValidateLLMsOnStartup() {
-		logger.Info("LLM validation on startup is enabled, but not yet implemented")
-		// TODO: Implement LLM validation if needed
-	}
-
 	// Create and start server
 	srv, err := server.New(cfg, logger)
 	if err != nil {
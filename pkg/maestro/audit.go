@@ -0,0 +1,95 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package maestro
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+	"github.com/PivotLLM/toolspec"
+)
+
+// auditRecorder appends an audit entry to a project's audit trail
+type auditRecorder func(project string, entry *global.AuditEntry) error
+
+// withAudit wraps every tool handler so each call that names a project is recorded
+// in that project's audit.jsonl once the handler returns. Arguments are stored as a
+// hash only, so the trail shows who called what without copying content into it.
+func withAudit(defs []toolspec.ToolDefinition, record auditRecorder, onError func(project string, err error)) []toolspec.ToolDefinition {
+	for i := range defs {
+		tool := defs[i].Name
+		handler := defs[i].Handler
+		defs[i].Handler = func(call *toolspec.ToolCall) (*toolspec.Result, error) {
+			start := time.Now()
+			res, err := handler(call)
+			if call == nil {
+				return res, err
+			}
+
+			project := auditProject(tool, call.Args, res, err)
+			if project == "" {
+				return res, err
+			}
+
+			entry := &global.AuditEntry{
+				Timestamp:  start.UTC(),
+				Tool:       tool,
+				ArgsHash:   hashArgs(call.Args),
+				AgentID:    call.AgentID,
+				Session:    call.Session,
+				Channel:    call.Channel,
+				Outcome:    global.AuditOutcomeOK,
+				DurationMs: time.Since(start).Milliseconds(),
+			}
+			switch {
+			case err != nil:
+				entry.Outcome = global.AuditOutcomeError
+				entry.Error = err.Error()
+			case res != nil && res.IsError:
+				entry.Outcome = global.AuditOutcomeError
+				entry.Error = res.ForLLM
+			}
+
+			if auditErr := record(project, entry); auditErr != nil && onError != nil {
+				onError(project, auditErr)
+			}
+			return res, err
+		}
+	}
+	return defs
+}
+
+// auditProject returns the project a tool call touched, or "" if it touched none.
+// Task, list and file tools name it in "project"; project tools in "name". A
+// successful rename is recorded under the new name, which is where the trail now lives.
+func auditProject(tool string, args map[string]any, res *toolspec.Result, err error) string {
+	if project := parseString(args, "project", ""); project != "" {
+		return project
+	}
+	if !strings.HasPrefix(tool, "project_") {
+		return ""
+	}
+	if tool == global.ToolProjectRename && err == nil && (res == nil || !res.IsError) {
+		if newName := parseString(args, "new_name", ""); newName != "" {
+			return newName
+		}
+	}
+	return parseString(args, "name", "")
+}
+
+// hashArgs returns the hex SHA-256 of the JSON-encoded arguments (keys are sorted by encoding/json)
+func hashArgs(args map[string]any) string {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,88 @@
+// Maestro
+// License: MIT
+
+package maestro
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/PivotLLM/Maestro/global"
+	"github.com/PivotLLM/toolspec"
+)
+
+func TestWithAudit(t *testing.T) {
+	recorded := make(map[string][]global.AuditEntry)
+	record := func(project string, entry *global.AuditEntry) error {
+		recorded[project] = append(recorded[project], *entry)
+		return nil
+	}
+
+	defs := withAudit([]toolspec.ToolDefinition{
+		{
+			Name: global.ToolTaskCreate,
+			Handler: func(call *toolspec.ToolCall) (*toolspec.Result, error) {
+				return &toolspec.Result{ForLLM: "ok"}, nil
+			},
+		},
+		{
+			Name: global.ToolProjectRename,
+			Handler: func(call *toolspec.ToolCall) (*toolspec.Result, error) {
+				return &toolspec.Result{ForLLM: "renamed"}, nil
+			},
+		},
+		{
+			Name: global.ToolProjectGet,
+			Handler: func(call *toolspec.ToolCall) (*toolspec.Result, error) {
+				return nil, errors.New("name parameter is required")
+			},
+		},
+		{
+			Name: global.ToolPlaybookList,
+			Handler: func(call *toolspec.ToolCall) (*toolspec.Result, error) {
+				return &toolspec.Result{ForLLM: "[]"}, nil
+			},
+		},
+	}, record, nil)
+
+	// Task tools are recorded under their "project" argument, with the caller
+	args := map[string]any{"project": "p1", "title": "t"}
+	if _, err := defs[0].Handler(&toolspec.ToolCall{Args: args, AgentID: "agent", Session: "s1"}); err != nil {
+		t.Fatalf("handler: %v", err)
+	}
+	entries := recorded["p1"]
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry for p1, got %d", len(entries))
+	}
+	entry := entries[0]
+	if entry.Tool != global.ToolTaskCreate || entry.Outcome != global.AuditOutcomeOK || entry.AgentID != "agent" || entry.Session != "s1" {
+		t.Errorf("unexpected entry: %+v", entry)
+	}
+	if entry.ArgsHash == "" || entry.ArgsHash != hashArgs(map[string]any{"title": "t", "project": "p1"}) {
+		t.Errorf("args hash %q does not match the arguments", entry.ArgsHash)
+	}
+
+	// A successful rename is recorded under the new name
+	if _, err := defs[1].Handler(&toolspec.ToolCall{Args: map[string]any{"name": "p1", "new_name": "p2"}}); err != nil {
+		t.Fatalf("handler: %v", err)
+	}
+	if len(recorded["p2"]) != 1 {
+		t.Errorf("rename not recorded under new name: %v", recorded)
+	}
+
+	// Handler errors are recorded as outcome "error" and still returned
+	if _, err := defs[2].Handler(&toolspec.ToolCall{Args: map[string]any{"name": "p2"}}); err == nil {
+		t.Fatal("expected the handler error to be returned")
+	}
+	if got := recorded["p2"]; len(got) != 2 || got[1].Outcome != global.AuditOutcomeError || got[1].Error == "" {
+		t.Errorf("error outcome not recorded: %+v", got)
+	}
+
+	// Calls that name no project are not recorded
+	if _, err := defs[3].Handler(&toolspec.ToolCall{Args: map[string]any{"name": "playbook"}}); err != nil {
+		t.Fatalf("handler: %v", err)
+	}
+	if len(recorded) != 2 {
+		t.Errorf("unexpected projects recorded: %v", recorded)
+	}
+}
@@ -0,0 +1,89 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package maestro
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/PivotLLM/Maestro/global"
+
+	"github.com/PivotLLM/toolspec"
+)
+
+// subsystemError is the structured error returned when a tool needs an
+// optional subsystem that is not active
+type subsystemError struct {
+	Error     string `json:"error"`
+	ErrorCode string `json:"error_code"`
+	Subsystem string `json:"subsystem"`
+	Feature   string `json:"feature"`
+	Remedy    string `json:"remedy,omitempty"`
+}
+
+// capabilities reports which optional subsystems are active: the runner's,
+// then the ones the tools provide themselves
+func (p *Provider) capabilities() []global.Capability {
+	var caps []global.Capability
+	if p.runner != nil {
+		caps = p.runner.Capabilities()
+	}
+
+	llmCap := global.Capability{Name: global.SubsystemLLM, Active: !p.hostDispatched && p.llm != nil,
+		Features: []string{global.ToolLLMList, global.ToolLLMDispatch, global.ToolLLMTest}}
+	switch {
+	case p.hostDispatched:
+		llmCap.Detail = "the host dispatches all LLM work and selects the model; the LLM tools are not exposed"
+	case p.llm == nil:
+		llmCap.Detail = "no LLM service is configured"
+	}
+
+	libraryCap := global.Capability{Name: global.SubsystemLibrary, Active: p.llm != nil && p.llm.ContextInjection(),
+		Features: []string{"context_keys"}}
+	if !libraryCap.Active {
+		libraryCap.Detail = "context injection is not supported; use project files instead"
+	}
+
+	embeddingsCap := global.Capability{Name: global.SubsystemEmbeddings, Active: p.embeddings != nil,
+		Features: []string{global.ToolSemanticSearch}}
+	if !embeddingsCap.Active {
+		embeddingsCap.Detail = "set embeddings in config.json to enable semantic search"
+	}
+
+	return append(caps, llmCap, libraryCap, embeddingsCap)
+}
+
+// requireSubsystem returns a SubsystemUnavailableError if the named subsystem
+// is not active, and nil if it is
+func (p *Provider) requireSubsystem(name, feature string) error {
+	for _, c := range p.capabilities() {
+		if c.Name == name && !c.Active {
+			return &global.SubsystemUnavailableError{Subsystem: name, Feature: feature, Remedy: c.Detail}
+		}
+	}
+	return nil
+}
+
+// errorResult returns err as a tool error. A missing subsystem is reported as
+// a structured error, so that callers can tell it apart from a failure of the
+// feature itself.
+func errorResult(err error) *toolspec.Result {
+	unavailable, ok := global.AsSubsystemUnavailable(err)
+	if !ok {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}
+	}
+	b, jsonErr := json.Marshal(subsystemError{
+		Error:     err.Error(),
+		ErrorCode: global.ErrorCodeSubsystemUnavailable,
+		Subsystem: unavailable.Subsystem,
+		Feature:   unavailable.Feature,
+		Remedy:    unavailable.Remedy,
+	})
+	if jsonErr != nil {
+		return &toolspec.Result{ForLLM: err.Error(), IsError: true}
+	}
+	return &toolspec.Result{ForLLM: string(b), IsError: true}
+}
@@ -8,11 +8,17 @@ package maestro
 import (
 	"github.com/PivotLLM/toolspec"
 
+	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/PivotLLM/Maestro/global"
 	"github.com/PivotLLM/Maestro/llm"
+	"github.com/PivotLLM/Maestro/runner"
+	templatespkg "github.com/PivotLLM/Maestro/templates"
 )
 
 // Project tool handlers
@@ -24,8 +30,10 @@ func (p *Provider) handleProjectCreate(call *toolspec.ToolCall) (*toolspec.Resul
 	projectContext := parseString(call.Args, "context", "")
 	status := parseString(call.Args, "status", "")
 	disclaimerTemplate := parseString(call.Args, "disclaimer_template", "")
+	outputLanguage := parseString(call.Args, "output_language", "")
+	allowedLLMsStr := parseString(call.Args, "allowed_llms", "")
 
-	p.logToolCall(global.ToolProjectCreate, map[string]string{"name": name})
+	p.logToolCall(global.ToolProjectCreate, map[string]string{"name": name, "allowed_llms": allowedLLMsStr})
 
 	if name == "" {
 		return nil, fmt.Errorf("%s", "name parameter is required")
@@ -33,14 +41,27 @@ func (p *Provider) handleProjectCreate(call *toolspec.ToolCall) (*toolspec.Resul
 	if title == "" {
 		return nil, fmt.Errorf("%s", "title parameter is required")
 	}
-	if disclaimerTemplate == "" {
-		return &toolspec.Result{ForLLM: fmt.Sprint("disclaimer_template parameter is required: provide a playbook path (e.g., 'playbook-name/templates/disclaimer.md') or 'none'"), IsError: true}, nil
+
+	outputLanguage, err := templatespkg.NormalizeLanguage(outputLanguage)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+	var allowedLLMs []string
+	if allowedLLMsStr != "" {
+		if allowedLLMs, err = p.parseAllowedLLMs(allowedLLMsStr); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
 	}
 
-	proj, err := p.projects.Create(name, title, description, projectContext, status, disclaimerTemplate)
+	proj, err := p.projects.Create(name, title, description, projectContext, status, disclaimerTemplate, outputLanguage)
 	if err != nil {
 		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
 	}
+	if len(allowedLLMs) > 0 {
+		if proj, err = p.projects.SetAllowedLLMs(name, allowedLLMs); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+	}
 
 	return createJSONResult(proj)
 }
@@ -62,6 +83,405 @@ func (p *Provider) handleProjectGet(call *toolspec.ToolCall) (*toolspec.Result,
 	return createJSONResult(proj)
 }
 
+func (p *Provider) handleProjectDashboard(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	name := parseString(call.Args, "name", "")
+
+	p.logToolCall(global.ToolProjectDashboard, map[string]string{"name": name})
+
+	if name == "" {
+		return nil, fmt.Errorf("%s", "name parameter is required")
+	}
+
+	if !p.projects.ProjectExists(name) {
+		return &toolspec.Result{ForLLM: fmt.Sprintf("project not found: %s", name), IsError: true}, nil
+	}
+
+	dashboard, err := p.runner.BuildDashboard(name)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	return createJSONResult(dashboard)
+}
+
+func (p *Provider) handleProjectResultsCleanup(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	maintenance := p.config.Maintenance()
+	name := parseString(call.Args, "name", "")
+	action := parseString(call.Args, "action", maintenance.Action)
+	minAgeHours := int(parseFloat64(call.Args, "min_age_hours", float64(maintenance.MinAgeHours)))
+	dryRun := parseBool(call.Args, "dry_run", false)
+
+	p.logToolCall(global.ToolProjectCleanup, map[string]string{
+		"name":          name,
+		"action":        action,
+		"min_age_hours": fmt.Sprintf("%d", minAgeHours),
+		"dry_run":       fmt.Sprintf("%t", dryRun),
+	})
+
+	if name == "" {
+		return nil, fmt.Errorf("%s", "name parameter is required")
+	}
+	if minAgeHours < 0 {
+		return nil, fmt.Errorf("%s", "min_age_hours cannot be negative")
+	}
+
+	summary, err := p.tasks.CleanupResults(name, action, time.Duration(minAgeHours)*time.Hour, dryRun)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	return createJSONResult(summary)
+}
+
+func (p *Provider) handleProjectResultsPrune(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	retention := p.config.Maintenance().Retention
+	name := parseString(call.Args, "name", "")
+	policy := global.ResultsRetention{
+		MaxAgeDays: int(parseFloat64(call.Args, "max_age_days", float64(retention.MaxAgeDays))),
+		MaxPerTask: int(parseFloat64(call.Args, "max_per_task", float64(retention.MaxPerTask))),
+		MaxTotalMB: int(parseFloat64(call.Args, "max_total_mb", float64(retention.MaxTotalMB))),
+		Action:     parseString(call.Args, "action", retention.Action),
+	}
+	dryRun := parseBool(call.Args, "dry_run", false)
+
+	p.logToolCall(global.ToolProjectPrune, map[string]string{
+		"name":         name,
+		"action":       policy.Action,
+		"max_age_days": fmt.Sprintf("%d", policy.MaxAgeDays),
+		"max_per_task": fmt.Sprintf("%d", policy.MaxPerTask),
+		"max_total_mb": fmt.Sprintf("%d", policy.MaxTotalMB),
+		"dry_run":      fmt.Sprintf("%t", dryRun),
+	})
+
+	if name == "" {
+		return nil, fmt.Errorf("%s", "name parameter is required")
+	}
+	if !dryRun && p.runner.IsProjectRunning(name) {
+		return &toolspec.Result{ForLLM: fmt.Sprintf("a run is in progress for project %s; wait for it to finish or use dry_run", name), IsError: true}, nil
+	}
+
+	summary, err := p.tasks.PruneResults(name, policy, dryRun)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	return createJSONResult(summary)
+}
+
+func (p *Provider) handleProjectRetentionPurge(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	name := parseString(call.Args, "name", "")
+	dryRun := parseBool(call.Args, "dry_run", true)
+
+	p.logToolCall(global.ToolProjectPurge, map[string]string{
+		"name":    name,
+		"dry_run": fmt.Sprintf("%t", dryRun),
+	})
+
+	if name == "" {
+		return nil, fmt.Errorf("%s", "name parameter is required")
+	}
+	if !dryRun && p.runner.IsProjectRunning(name) {
+		return &toolspec.Result{ForLLM: fmt.Sprintf("a run is in progress for project %s; wait for it to finish or use dry_run", name), IsError: true}, nil
+	}
+
+	summary, err := p.projects.PurgeProject(name, dryRun)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	return createJSONResult(summary)
+}
+
+func (p *Provider) handleProjectDiff(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	name := parseString(call.Args, "name", "")
+	path := parseString(call.Args, "path", "")
+	baselineProject := parseString(call.Args, "baseline_project", "")
+	baselinePath := parseString(call.Args, "baseline_path", "")
+	baselineArchive := parseString(call.Args, "baseline_archive", "")
+	keyField := parseString(call.Args, "key_field", "")
+	matchBy := parseString(call.Args, "match_by", "")
+	compareFieldsStr := parseString(call.Args, "compare_fields", "")
+	outputFile := parseString(call.Args, "output_file", "")
+
+	p.logToolCall(global.ToolProjectDiff, map[string]string{
+		"name":             name,
+		"path":             path,
+		"baseline_project": baselineProject,
+		"baseline_path":    baselinePath,
+		"baseline_archive": baselineArchive,
+		"match_by":         matchBy,
+		"output_file":      outputFile,
+	})
+
+	if name == "" {
+		return nil, fmt.Errorf("%s", "name parameter is required")
+	}
+	if baselineProject == "" && baselineArchive == "" && baselinePath == "" {
+		return nil, fmt.Errorf("%s", "baseline_project, baseline_path or baseline_archive is required")
+	}
+
+	var compareFields []string
+	for _, field := range strings.Split(compareFieldsStr, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			compareFields = append(compareFields, field)
+		}
+	}
+
+	diff, err := p.runner.DiffProjects(name, runner.DiffOptions{
+		Path:            path,
+		BaselineProject: baselineProject,
+		BaselinePath:    baselinePath,
+		BaselineArchive: baselineArchive,
+		KeyField:        keyField,
+		CompareFields:   compareFields,
+		MatchBy:         matchBy,
+	})
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	if outputFile != "" {
+		if _, err := p.projects.PutFile(name, outputFile, runner.FormatProjectDiff(diff, p.config.Clock()), "Delta report against "+diff.Baseline); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprintf("failed to write %s: %v", outputFile, err), IsError: true}, nil
+		}
+		diff.File = outputFile
+	}
+
+	return createJSONResult(diff)
+}
+
+func (p *Provider) handleProjectTrends(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	name := parseString(call.Args, "name", "")
+	limit := int(parseFloat64(call.Args, "limit", 0))
+
+	p.logToolCall(global.ToolProjectTrends, map[string]string{"name": name, "limit": fmt.Sprintf("%d", limit)})
+
+	if name == "" {
+		return nil, fmt.Errorf("%s", "name parameter is required")
+	}
+
+	if !p.projects.ProjectExists(name) {
+		return &toolspec.Result{ForLLM: fmt.Sprintf("project not found: %s", name), IsError: true}, nil
+	}
+
+	points, err := p.projects.GetTrends(name)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	trends := &global.ProjectTrends{Project: name, Runs: len(points), Points: points}
+	if limit > 0 && len(points) > limit {
+		trends.Points = points[len(points)-limit:]
+	}
+
+	return createJSONResult(trends)
+}
+
+func (p *Provider) handleProjectTemplates(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	name := parseString(call.Args, "name", "")
+	changedOnly := parseBool(call.Args, "changed_only", false)
+
+	p.logToolCall(global.ToolProjectTemplates, map[string]string{"name": name, "changed_only": fmt.Sprintf("%v", changedOnly)})
+
+	if name == "" {
+		return nil, fmt.Errorf("%s", "name parameter is required")
+	}
+
+	if !p.projects.ProjectExists(name) {
+		return &toolspec.Result{ForLLM: fmt.Sprintf("project not found: %s", name), IsError: true}, nil
+	}
+
+	registry, err := p.runner.TemplateRegistry(name)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	if changedOnly {
+		entries := []global.TemplateRegistryEntry{}
+		for _, entry := range registry.Entries {
+			if entry.Changed || entry.Source == global.RegistrySourceMissing {
+				entries = append(entries, entry)
+			}
+		}
+		registry.Entries = entries
+	}
+
+	return createJSONResult(registry)
+}
+
+func (p *Provider) handleProjectSnapshot(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	name := parseString(call.Args, "name", "")
+	label := parseString(call.Args, "label", "")
+
+	p.logToolCall(global.ToolProjectSnapshot, map[string]string{"name": name, "label": label})
+
+	if name == "" {
+		return nil, fmt.Errorf("%s", "name parameter is required")
+	}
+
+	snapshot, err := p.projects.CreateSnapshot(name, label)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	return createJSONResult(snapshot)
+}
+
+func (p *Provider) handleProjectSnapshotList(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	name := parseString(call.Args, "name", "")
+
+	p.logToolCall(global.ToolProjectSnapshots, map[string]string{"name": name})
+
+	if name == "" {
+		return nil, fmt.Errorf("%s", "name parameter is required")
+	}
+
+	snapshots, err := p.projects.ListSnapshots(name)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	result := map[string]interface{}{
+		"project":   name,
+		"snapshots": snapshots,
+		"count":     len(snapshots),
+	}
+
+	return createJSONResult(result)
+}
+
+func (p *Provider) handleProjectSnapshotDelete(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	name := parseString(call.Args, "name", "")
+	snapshot := parseString(call.Args, "snapshot", "")
+
+	p.logToolCall(global.ToolProjectSnapshotDel, map[string]string{"name": name, "snapshot": snapshot})
+
+	if name == "" {
+		return nil, fmt.Errorf("%s", "name parameter is required")
+	}
+	if snapshot == "" {
+		return nil, fmt.Errorf("%s", "snapshot parameter is required")
+	}
+
+	if err := p.projects.DeleteSnapshot(name, snapshot); err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	return createJSONResult(map[string]interface{}{
+		"project":  name,
+		"snapshot": snapshot,
+		"deleted":  true,
+	})
+}
+
+func (p *Provider) handleProjectExport(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	name := parseString(call.Args, "name", "")
+	format := parseString(call.Args, "format", "")
+	anonymize := parseBool(call.Args, "anonymize", false)
+
+	p.logToolCall(global.ToolProjectExport, map[string]string{"name": name, "format": format, "anonymize": fmt.Sprint(anonymize)})
+
+	if name == "" {
+		return nil, fmt.Errorf("%s", "name parameter is required")
+	}
+
+	if p.runner.IsProjectRunning(name) {
+		return &toolspec.Result{ForLLM: fmt.Sprintf("a run is in progress for project %s; export it when the run finishes", name), IsError: true}, nil
+	}
+
+	var redactor *global.PromptRedactor
+	if anonymize {
+		var err error
+		if redactor, err = p.runner.AnonymizationRedactor(name); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+	}
+
+	result, err := p.projects.Export(name, format, redactor)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	return createJSONResult(result)
+}
+
+func (p *Provider) handleProjectImport(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	archive := parseString(call.Args, "archive", "")
+	name := parseString(call.Args, "name", "")
+	mapping := parseString(call.Args, "mapping", "")
+
+	p.logToolCall(global.ToolProjectImport, map[string]string{"archive": archive, "name": name, "mapping": mapping})
+
+	if archive == "" {
+		return nil, fmt.Errorf("%s", "archive parameter is required")
+	}
+
+	result, err := p.projects.Import(archive, name, mapping)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	return createJSONResult(result)
+}
+
+func (p *Provider) handleProjectAudit(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	name := parseString(call.Args, "name", "")
+	tool := parseString(call.Args, "tool", "")
+	outcome := parseString(call.Args, "outcome", "")
+	session := parseString(call.Args, "session", "")
+	sinceStr := parseString(call.Args, "since", "")
+	limit := int(parseFloat64(call.Args, "limit", float64(global.DefaultAuditLimit)))
+
+	p.logToolCall(global.ToolProjectAudit, map[string]string{"name": name, "tool": tool, "outcome": outcome, "since": sinceStr})
+
+	if name == "" {
+		return nil, fmt.Errorf("%s", "name parameter is required")
+	}
+	if outcome != "" && outcome != global.AuditOutcomeOK && outcome != global.AuditOutcomeError {
+		return &toolspec.Result{ForLLM: fmt.Sprintf("invalid outcome %q: must be '%s' or '%s'", outcome, global.AuditOutcomeOK, global.AuditOutcomeError), IsError: true}, nil
+	}
+	var since time.Time
+	if sinceStr != "" {
+		var err error
+		if since, err = time.Parse(time.RFC3339, sinceStr); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprintf("invalid since %q: must be an RFC 3339 timestamp", sinceStr), IsError: true}, nil
+		}
+	}
+
+	if !p.projects.ProjectExists(name) {
+		return &toolspec.Result{ForLLM: fmt.Sprintf("project not found: %s", name), IsError: true}, nil
+	}
+
+	entries, err := p.projects.GetAudit(name)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	matched := make([]global.AuditEntry, 0, len(entries))
+	for _, entry := range entries {
+		if tool != "" && entry.Tool != tool {
+			continue
+		}
+		if outcome != "" && entry.Outcome != outcome {
+			continue
+		}
+		if session != "" && entry.Session != session {
+			continue
+		}
+		if !since.IsZero() && entry.Timestamp.Before(since) {
+			continue
+		}
+		matched = append(matched, entry)
+	}
+
+	audit := &global.ProjectAudit{Project: name, Matched: len(matched), Entries: matched}
+	if limit > 0 && len(matched) > limit {
+		audit.Entries = matched[len(matched)-limit:]
+	}
+
+	return createJSONResult(audit)
+}
+
 func (p *Provider) handleProjectUpdate(call *toolspec.ToolCall) (*toolspec.Result, error) {
 	name := parseString(call.Args, "name", "")
 	titleStr := parseString(call.Args, "title", "")
@@ -69,15 +489,21 @@ func (p *Provider) handleProjectUpdate(call *toolspec.ToolCall) (*toolspec.Resul
 	contextStr := parseString(call.Args, "context", "")
 	statusStr := parseString(call.Args, "status", "")
 	disclaimerTemplateStr := parseString(call.Args, "disclaimer_template", "")
+	outputLanguageStr := parseString(call.Args, "output_language", "")
+	retentionDays := int(parseFloat64(call.Args, "retention_days", -1))
+	retentionAction := parseString(call.Args, "retention_action", "")
+	retentionInclude := parseString(call.Args, "retention_include", "")
+	allowedLLMsStr := parseString(call.Args, "allowed_llms", "")
+	promptRedactionStr := parseString(call.Args, "prompt_redaction", "")
 
-	p.logToolCall(global.ToolProjectUpdate, map[string]string{"name": name, "status": statusStr})
+	p.logToolCall(global.ToolProjectUpdate, map[string]string{"name": name, "status": statusStr, "allowed_llms": allowedLLMsStr})
 
 	if name == "" {
 		return nil, fmt.Errorf("%s", "name parameter is required")
 	}
 
 	// Convert empty strings to nil pointers for optional fields
-	var title, description, projectContext, status, disclaimerTemplate *string
+	var title, description, projectContext, status, disclaimerTemplate, outputLanguage *string
 	if titleStr != "" {
 		title = &titleStr
 	}
@@ -93,15 +519,117 @@ func (p *Provider) handleProjectUpdate(call *toolspec.ToolCall) (*toolspec.Resul
 	if disclaimerTemplateStr != "" {
 		disclaimerTemplate = &disclaimerTemplateStr
 	}
+	if outputLanguageStr != "" {
+		normalized, err := parseOutputLanguage(outputLanguageStr)
+		if err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+		outputLanguage = &normalized
+	}
+
+	if retentionDays < 0 && (retentionAction != "" || retentionInclude != "") {
+		return nil, fmt.Errorf("%s", "retention_days is required with retention_action or retention_include")
+	}
+	var allowedLLMs []string
+	if allowedLLMsStr != "" {
+		var err error
+		if allowedLLMs, err = p.parseAllowedLLMs(allowedLLMsStr); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+	}
+	promptRedaction, err := parsePromptRedaction(promptRedactionStr)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
 
-	proj, err := p.projects.Update(name, title, description, projectContext, status, disclaimerTemplate)
+	proj, err := p.projects.Update(name, title, description, projectContext, status, disclaimerTemplate, outputLanguage)
 	if err != nil {
 		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
 	}
 
+	// retention_days 0 removes the retention policy
+	if retentionDays >= 0 {
+		var retention *global.ProjectRetention
+		if retentionDays > 0 {
+			retention = &global.ProjectRetention{Days: retentionDays, Action: retentionAction}
+			for _, scope := range strings.Split(retentionInclude, ",") {
+				if scope = strings.TrimSpace(scope); scope != "" {
+					retention.Include = append(retention.Include, scope)
+				}
+			}
+		}
+		if proj, err = p.projects.SetRetention(name, retention); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+	}
+
+	if allowedLLMsStr != "" {
+		if proj, err = p.projects.SetAllowedLLMs(name, allowedLLMs); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+	}
+
+	if promptRedactionStr != "" {
+		if proj, err = p.projects.SetPromptRedaction(name, promptRedaction); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+	}
+
 	return createJSONResult(proj)
 }
 
+// parsePromptRedaction parses a prompt_redaction update value, a JSON object;
+// "none" removes the project's prompt redaction
+func parsePromptRedaction(value string) (*global.PromptRedaction, error) {
+	if value == "" || value == "none" {
+		return nil, nil
+	}
+	var redaction global.PromptRedaction
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&redaction); err != nil {
+		return nil, fmt.Errorf("prompt_redaction must be a JSON object: %v", err)
+	}
+	if err := global.ValidatePromptRedaction(&redaction); err != nil {
+		return nil, err
+	}
+	return &redaction, nil
+}
+
+// parseOutputLanguage normalizes an output_language update value.
+// "none" clears the setting; anything else must be a supported language.
+func parseOutputLanguage(value string) (string, error) {
+	if value == "none" {
+		return "", nil
+	}
+	return templatespkg.NormalizeLanguage(value)
+}
+
+// parseAllowedLLMs parses a comma-separated allowed_llms value into canonical
+// LLM IDs. "none" lifts the restriction.
+func (p *Provider) parseAllowedLLMs(value string) ([]string, error) {
+	if value == "none" {
+		return nil, nil
+	}
+	var allowed []string
+	for _, id := range strings.Split(value, ",") {
+		if id = strings.TrimSpace(id); id == "" {
+			continue
+		}
+		llmConfig := p.config.GetLLM(id)
+		if llmConfig == nil {
+			return nil, fmt.Errorf("allowed_llms: unknown LLM %s (see %s)", id, global.ToolLLMList)
+		}
+		if !slices.Contains(allowed, llmConfig.ID) {
+			allowed = append(allowed, llmConfig.ID)
+		}
+	}
+	if len(allowed) == 0 {
+		return nil, fmt.Errorf("allowed_llms lists no LLMs; use 'none' to allow any")
+	}
+	return allowed, nil
+}
+
 func (p *Provider) handleProjectList(call *toolspec.ToolCall) (*toolspec.Result, error) {
 	status := parseString(call.Args, "status", "")
 	limit := int(parseFloat64(call.Args, "limit", 0))
@@ -201,8 +729,17 @@ func (p *Provider) handleLLMList(call *toolspec.ToolCall) (*toolspec.Result, err
 func (p *Provider) handleLLMDispatch(call *toolspec.ToolCall) (*toolspec.Result, error) {
 	llmID := parseString(call.Args, "llm_id", "")
 	prompt := parseString(call.Args, "prompt", "")
+	project := parseString(call.Args, "project", "")
 
-	p.logToolCall(global.ToolLLMDispatch, map[string]string{"llm_id": llmID})
+	opts := &llm.DispatchOptions{
+		MaxOutputBytes: int64(parseFloat64(call.Args, "max_output_bytes", 0)),
+		WorkingDir:     parseString(call.Args, "working_dir", ""),
+		Priority:       parseString(call.Args, "priority", ""),
+		CacheControl:   parseString(call.Args, "cache_control", ""),
+		Label:          parseString(call.Args, "label", ""),
+	}
+
+	p.logToolCall(global.ToolLLMDispatch, map[string]string{"llm_id": llmID, "label": opts.Label, "project": project})
 
 	if llmID == "" {
 		return nil, fmt.Errorf("%s", "llm_id parameter is required")
@@ -210,6 +747,14 @@ func (p *Provider) handleLLMDispatch(call *toolspec.ToolCall) (*toolspec.Result,
 	if prompt == "" {
 		return nil, fmt.Errorf("%s", "prompt parameter is required")
 	}
+	if project != "" {
+		if !p.projects.ProjectExists(project) {
+			return &toolspec.Result{ForLLM: fmt.Sprintf("project not found: %s", project), IsError: true}, nil
+		}
+		if err := p.runner.CheckLLMAllowed(project, llmID); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+	}
 
 	// Parse context_keys from raw arguments if available
 	var contextKeys []string
@@ -219,10 +764,17 @@ func (p *Provider) handleLLMDispatch(call *toolspec.ToolCall) (*toolspec.Result,
 		Prompt:      prompt,
 		ContextKeys: contextKeys,
 	}
+	if *opts != (llm.DispatchOptions{}) {
+		// Options replace the LLM's defaults, so carry its generation parameters
+		if llmConfig := p.llm.GetLLM(llmID); llmConfig != nil {
+			opts.GenerationParams = llmConfig.GenerationFor(1)
+		}
+		req.Options = opts
+	}
 
 	result, err := p.llm.Dispatch(req)
 	if err != nil {
-		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		return errorResult(err), nil
 	}
 
 	return createJSONResult(result)
@@ -274,15 +826,44 @@ func (p *Provider) handleHealth(call *toolspec.ToolCall) (*toolspec.Result, erro
 		}
 	}
 
+	// Subsystem checks, each with a machine-readable status code
+	subsystems := map[string]healthCheck{
+		"disk":          p.checkDisk(),
+		"playbooks_dir": p.checkPlaybooksDir(),
+	}
+	if !p.hostDispatched && p.llm != nil && p.config.HasEnabledLLM() {
+		subsystems["llms"] = p.checkLLMs()
+	}
+	if p.runner != nil {
+		subsystems["runs"] = p.checkRuns()
+		subsystems["tasks"] = p.checkTasks()
+	}
+	statusCode := global.HealthCodeOK
+	for _, name := range []string{"disk", "playbooks_dir", "llms", "runs", "tasks"} {
+		if check, ok := subsystems[name]; ok && check.Code == global.HealthCodeCritical {
+			issues = append(issues, check.Message)
+		}
+	}
+	for _, check := range subsystems {
+		statusCode = max(statusCode, check.Code)
+	}
+
 	// Build result
 	healthy := len(issues) == 0
-	status := "healthy"
 	if !healthy {
+		statusCode = global.HealthCodeCritical
+	}
+	status := "healthy"
+	switch statusCode {
+	case global.HealthCodeDegraded:
+		status = "degraded"
+	case global.HealthCodeCritical:
 		status = "unhealthy"
 	}
 
 	result := map[string]interface{}{
 		"status":       status,
+		"status_code":  statusCode,
 		"healthy":      healthy,
 		"program_name": global.ProgramName,
 		"version":      global.Version,
@@ -296,8 +877,16 @@ func (p *Provider) handleHealth(call *toolspec.ToolCall) (*toolspec.Result, erro
 		result["config_path"] = p.config.ConfigPath()
 		result["first_run"] = p.config.IsFirstRun()
 		result["enabled_llms"] = len(p.config.EnabledLLMs())
+		if availability := p.llmAvailability(); len(availability) > 0 {
+			result["llm_availability"] = availability
+		}
+		if p.llm != nil && p.llm.StartupValidation() != nil {
+			result["llm_startup_validation"] = p.llm.StartupValidation()
+		}
 	}
 
+	result["subsystems"] = subsystems
+	result["capabilities"] = p.capabilities()
 	if len(issues) > 0 {
 		result["issues"] = issues
 	}
@@ -305,6 +894,17 @@ func (p *Provider) handleHealth(call *toolspec.ToolCall) (*toolspec.Result, erro
 	return createJSONResult(result)
 }
 
+// llmAvailability returns the probe summary of each enabled LLM that has been probed
+func (p *Provider) llmAvailability() map[string]string {
+	availability := make(map[string]string)
+	for _, l := range p.config.EnabledLLMs() {
+		if a := p.llm.Availability(l.ID); a != nil {
+			availability[l.ID] = a.Summary
+		}
+	}
+	return availability
+}
+
 // Helper to check if directory exists
 func dirExists(path string) bool {
 	info, err := os.Stat(path)
@@ -9,10 +9,8 @@ import (
 	"github.com/PivotLLM/toolspec"
 
 	"fmt"
-	"path/filepath"
 
 	"github.com/PivotLLM/Maestro/global"
-	"github.com/tenebris-tech/x2md/convert"
 )
 
 // handleFileCopy handles copying files within and between domains
@@ -46,13 +44,13 @@ func (p *Provider) handleFileCopy(call *toolspec.ToolCall) (*toolspec.Result, er
 	}
 
 	// Validate source
-	if fromSource != "reference" && fromSource != "playbook" && fromSource != "project" {
-		return nil, fmt.Errorf("%s", "from_source must be 'reference', 'playbook', or 'project'")
+	if fromSource != "reference" && fromSource != "shared" && fromSource != "playbook" && fromSource != "project" {
+		return nil, fmt.Errorf("%s", "from_source must be 'reference', 'shared', 'playbook', or 'project'")
 	}
 
-	// Validate destination (reference is read-only)
+	// Validate destination (reference and shared are read-only)
 	if toSource != "playbook" && toSource != "project" {
-		return &toolspec.Result{ForLLM: fmt.Sprint("to_source must be 'playbook' or 'project' (reference is read-only)"), IsError: true}, nil
+		return &toolspec.Result{ForLLM: fmt.Sprint("to_source must be 'playbook' or 'project' (reference and shared are read-only)"), IsError: true}, nil
 	}
 
 	// Read source file (entire file, no byte range)
@@ -67,6 +65,13 @@ func (p *Provider) handleFileCopy(call *toolspec.ToolCall) (*toolspec.Result, er
 		}
 		content = item.Content
 
+	case "shared":
+		item, err := p.shared.Get(fromPath, 0, 0)
+		if err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(fmt.Sprintf("failed to read source file: %v", err)), IsError: true}, nil
+		}
+		content = item.Content
+
 	case "playbook":
 		if fromPlaybook == "" {
 			return nil, fmt.Errorf("%s", "from_playbook parameter is required when from_source is 'playbook'")
@@ -147,10 +152,13 @@ type ImportAndConvertResult struct {
 	FilesImported int    `json:"files_imported"`
 	LinksImported int    `json:"links_imported"`
 	ImportedTo    string `json:"imported_to"`
+	// Files left out under the import policy
+	Rejected []global.ImportRejection `json:"rejected,omitempty"`
 	// Conversion results (only present if convert=true)
-	Converted      *int `json:"converted,omitempty"`
-	ConvertSkipped *int `json:"convert_skipped,omitempty"`
-	ConvertFailed  *int `json:"convert_failed,omitempty"`
+	Converted         *int     `json:"converted,omitempty"`
+	ConvertSkipped    *int     `json:"convert_skipped,omitempty"`
+	ConvertFailed     *int     `json:"convert_failed,omitempty"`
+	ConvertLowQuality []string `json:"convert_low_quality,omitempty"` // Converted files with little text
 }
 
 // handleFileDelete deletes a file from a project or playbook domain.
@@ -230,20 +238,14 @@ func (p *Provider) handleFileImport(call *toolspec.ToolCall) (*toolspec.Result,
 		FilesImported: importResult.FilesImported,
 		LinksImported: importResult.LinksImported,
 		ImportedTo:    importResult.ImportedTo,
+		Rejected:      importResult.Rejected,
 	}
 
 	// Run conversion if requested
 	if doConvert && importResult.FilesImported > 0 {
 		filesDir := p.projects.GetFilesDir(project)
 		if filesDir != "" {
-			importedPath := filepath.Join(filesDir, importResult.ImportedTo)
-
-			converter := convert.New(
-				convert.WithRecursion(true), // Always recursive for imports
-				convert.WithSkipExisting(true),
-			)
-
-			convertResult, convertErr := converter.Convert(importedPath)
+			convertResult, convertErr := p.conversion.Convert(project, filesDir, importResult.ImportedTo, true, 0)
 			if convertErr != nil {
 				// Log but don't fail - import succeeded
 				p.logger.Warnf("Conversion after import failed: %v", convertErr)
@@ -254,6 +256,7 @@ func (p *Provider) handleFileImport(call *toolspec.ToolCall) (*toolspec.Result,
 				result.Converted = &converted
 				result.ConvertSkipped = &skipped
 				result.ConvertFailed = &failed
+				result.ConvertLowQuality = lowQualityFiles(convertResult)
 			}
 		}
 	}
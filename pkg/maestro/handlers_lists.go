@@ -10,6 +10,7 @@ import (
 
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/PivotLLM/Maestro/global"
 )
@@ -183,6 +184,9 @@ func (p *Provider) handleListCopy(call *toolspec.ToolCall) (*toolspec.Result, er
 
 	// Sampling
 	sample := int(parseFloat64(call.Args, "sample", 0))
+	seed := int64(parseFloat64(call.Args, "seed", 0))
+	sampleMethod := parseString(call.Args, "sample_method", "")
+	stratifyBy := parseString(call.Args, "stratify_by", "")
 
 	// Build cleaner source/destination strings for logging
 	fromStr := fromList
@@ -216,11 +220,12 @@ func (p *Provider) handleListCopy(call *toolspec.ToolCall) (*toolspec.Result, er
 		return nil, fmt.Errorf("%s", "to_list parameter is required")
 	}
 
-	if err := p.lists.Copy(
+	sampling, err := p.lists.Copy(
 		fromSource, fromProject, fromPlaybook, fromList,
 		toSource, toProject, toPlaybook, toList,
-		sample,
-	); err != nil {
+		&global.SampleSpec{Size: sample, Seed: seed, Method: sampleMethod, StratifyBy: stratifyBy},
+	)
+	if err != nil {
 		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
 	}
 
@@ -229,8 +234,10 @@ func (p *Provider) handleListCopy(call *toolspec.ToolCall) (*toolspec.Result, er
 		"to_list":   toList,
 		"copied":    true,
 	}
-	if sample > 0 {
-		result["sample"] = sample
+	if sampling != nil {
+		result["sample"] = sampling.Size
+		result["seed"] = sampling.Seed
+		result["sampling"] = sampling
 	}
 
 	return createJSONResult(result)
@@ -466,6 +473,7 @@ func (p *Provider) handleListItemSearch(call *toolspec.ToolCall) (*toolspec.Resu
 	completeFilter := parseString(call.Args, "complete", "")
 	offset := int(parseFloat64(call.Args, "offset", 0))
 	limit := int(parseFloat64(call.Args, "limit", 0))
+	cursor := parseString(call.Args, "cursor", "")
 
 	p.logToolCall(global.ToolListItemSearch, map[string]string{"source": source, "list": listName, "query": query, "complete": completeFilter})
 
@@ -482,7 +490,7 @@ func (p *Provider) handleListItemSearch(call *toolspec.ToolCall) (*toolspec.Resu
 		}
 	}
 
-	result, err := p.lists.SearchItems(source, project, playbook, listName, query, sourceDoc, section, tags, completeFilter, offset, limit)
+	result, err := p.lists.SearchItems(source, project, playbook, listName, query, sourceDoc, section, tags, completeFilter, offset, limit, cursor)
 	if err != nil {
 		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
 	}
@@ -498,6 +506,8 @@ func (p *Provider) handleListCreateTasks(call *toolspec.ToolCall) (*toolspec.Res
 	listProject := parseString(call.Args, "list_project", "")
 	listPlaybook := parseString(call.Args, "list_playbook", "")
 	listName := parseString(call.Args, "list", "")
+	extraLists := parseString(call.Args, "lists", "")
+	combine := parseString(call.Args, "combine", "")
 
 	// Target project and path parameters
 	targetProject := parseString(call.Args, "project", "")
@@ -525,13 +535,31 @@ func (p *Provider) handleListCreateTasks(call *toolspec.ToolCall) (*toolspec.Res
 
 	// Sampling and parallel execution
 	sample := int(parseFloat64(call.Args, "sample", 0))
+	seed := int64(parseFloat64(call.Args, "seed", 0))
+	sampleMethod := parseString(call.Args, "sample_method", "")
+	stratifyBy := parseString(call.Args, "stratify_by", "")
 	parallel := parseBool(call.Args, "parallel", false)
 
 	// Log with sample info if specified
 	logParams := map[string]string{"list": listName, "project": targetProject, "type": taskType}
+	if extraLists != "" {
+		logParams["lists"] = extraLists
+	}
+	if combine != "" {
+		logParams["combine"] = combine
+	}
 	if sample > 0 {
 		logParams["sample"] = fmt.Sprintf("%d", sample)
 	}
+	if seed != 0 {
+		logParams["seed"] = fmt.Sprintf("%d", seed)
+	}
+	if sampleMethod != "" {
+		logParams["sample_method"] = sampleMethod
+	}
+	if stratifyBy != "" {
+		logParams["stratify_by"] = stratifyBy
+	}
 	p.logToolCall(global.ToolListCreateTasks, logParams)
 
 	if listName == "" {
@@ -547,12 +575,19 @@ func (p *Provider) handleListCreateTasks(call *toolspec.ToolCall) (*toolspec.Res
 	// Validate instructions files exist before creating tasks
 	if instructionsFile != "" {
 		if err := p.validateInstructionsFile(targetProject, instructionsFile, instructionsFileSource); err != nil {
-			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+			return errorResult(err), nil
 		}
 	}
 	if qaEnabled && qaInstructionsFile != "" {
 		if err := p.validateInstructionsFile(targetProject, qaInstructionsFile, qaInstructionsFileSource); err != nil {
-			return &toolspec.Result{ForLLM: fmt.Sprint(fmt.Sprintf("QA %s", err.Error())), IsError: true}, nil
+			return errorResult(fmt.Errorf("QA %w", err)), nil
+		}
+	}
+
+	listNames := []string{listName}
+	for _, name := range strings.Split(extraLists, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			listNames = append(listNames, name)
 		}
 	}
 
@@ -571,12 +606,12 @@ func (p *Provider) handleListCreateTasks(call *toolspec.ToolCall) (*toolspec.Res
 
 	result, err := p.lists.CreateTasks(
 		p.tasks,
-		listSource, listProject, listPlaybook, listName,
+		listSource, listProject, listPlaybook, listNames, combine,
 		targetProject, path,
 		titleTemplate, taskType, priority,
 		llmModelID, instructionsFile, instructionsFileSource, instructionsText, prompt,
 		qa,
-		sample,
+		&global.SampleSpec{Size: sample, Seed: seed, Method: sampleMethod, StratifyBy: stratifyBy},
 		parallel,
 	)
 	if err != nil {
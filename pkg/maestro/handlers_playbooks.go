@@ -98,6 +98,113 @@ func (p *Provider) handlePlaybookDelete(call *toolspec.ToolCall) (*toolspec.Resu
 	return createJSONResult(result)
 }
 
+func (p *Provider) handlePlaybookExport(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	name := parseString(call.Args, "name", "")
+	version := parseString(call.Args, "version", "")
+	description := parseString(call.Args, "description", "")
+	format := parseString(call.Args, "format", "")
+
+	p.logToolCall(global.ToolPlaybookExport, map[string]string{"name": name, "version": version, "format": format})
+
+	if name == "" {
+		return nil, fmt.Errorf("%s", "name parameter is required")
+	}
+
+	result, err := p.playbooks.Export(name, version, description, format)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	return createJSONResult(result)
+}
+
+func (p *Provider) handlePlaybookImport(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	archive := parseString(call.Args, "archive", "")
+	name := parseString(call.Args, "name", "")
+
+	p.logToolCall(global.ToolPlaybookImport, map[string]string{"archive": archive, "name": name})
+
+	if archive == "" {
+		return nil, fmt.Errorf("%s", "archive parameter is required")
+	}
+
+	result, err := p.playbooks.Import(archive, name)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	return createJSONResult(result)
+}
+
+func (p *Provider) handlePlaybookHistory(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	playbook := parseString(call.Args, "playbook", "")
+	path := parseString(call.Args, "path", "")
+	limit := int(parseFloat64(call.Args, "limit", 0))
+
+	p.logToolCall(global.ToolPlaybookHistory, map[string]string{"playbook": playbook, "path": path})
+
+	if playbook == "" {
+		return nil, fmt.Errorf("%s", "playbook parameter is required")
+	}
+
+	history, err := p.playbooks.History(playbook, path, limit)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	return createJSONResult(history)
+}
+
+func (p *Provider) handlePlaybookRestore(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	playbook := parseString(call.Args, "playbook", "")
+	path := parseString(call.Args, "path", "")
+	version := int(parseFloat64(call.Args, "version", -1))
+
+	p.logToolCall(global.ToolPlaybookRestore, map[string]string{"playbook": playbook, "path": path, "version": fmt.Sprint(version)})
+
+	if playbook == "" {
+		return nil, fmt.Errorf("%s", "playbook parameter is required")
+	}
+	if path == "" {
+		return nil, fmt.Errorf("%s", "path parameter is required")
+	}
+	if version < 0 {
+		return nil, fmt.Errorf("%s", "version parameter is required")
+	}
+
+	newVersion, err := p.playbooks.Restore(playbook, path, version)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	result := map[string]interface{}{
+		"playbook":      playbook,
+		"path":          path,
+		"restored_from": version,
+		"version":       newVersion,
+	}
+
+	return createJSONResult(result)
+}
+
+func (p *Provider) handlePlaybookLint(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	playbook := parseString(call.Args, "playbook", "")
+	maxInstructionsKB := int(parseFloat64(call.Args, "max_instructions_kb", 0))
+
+	p.logToolCall(global.ToolPlaybookLint, map[string]string{"playbook": playbook})
+
+	if playbook == "" {
+		return nil, fmt.Errorf("%s", "playbook parameter is required")
+	}
+
+	report, err := p.playbooks.Lint(playbook, maxInstructionsKB)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	return createJSONResult(report)
+}
+
 // Playbook file handlers
 
 func (p *Provider) handlePlaybookFileList(call *toolspec.ToolCall) (*toolspec.Result, error) {
@@ -174,6 +281,7 @@ func (p *Provider) handlePlaybookFilePut(call *toolspec.ToolCall) (*toolspec.Res
 		"playbook": playbook,
 		"path":     path,
 		"created":  created,
+		"version":  p.playbooks.Version(playbook),
 	}
 
 	return createJSONResult(result)
@@ -206,6 +314,7 @@ func (p *Provider) handlePlaybookFileAppend(call *toolspec.ToolCall) (*toolspec.
 		"playbook": playbook,
 		"path":     path,
 		"success":  true,
+		"version":  p.playbooks.Version(playbook),
 	}
 
 	return createJSONResult(result)
@@ -240,6 +349,7 @@ func (p *Provider) handlePlaybookFileEdit(call *toolspec.ToolCall) (*toolspec.Re
 		"playbook": playbook,
 		"path":     path,
 		"success":  true,
+		"version":  p.playbooks.Version(playbook),
 	}
 
 	return createJSONResult(result)
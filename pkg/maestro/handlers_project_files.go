@@ -13,11 +13,11 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
-	"github.com/tenebris-tech/x2md/convert"
-
 	"github.com/PivotLLM/Maestro/global"
+	"github.com/PivotLLM/Maestro/projects"
 )
 
 // Project file handlers
@@ -25,14 +25,31 @@ import (
 func (p *Provider) handleProjectFileList(call *toolspec.ToolCall) (*toolspec.Result, error) {
 	project := parseString(call.Args, "project", "")
 	prefix := parseString(call.Args, "prefix", "")
+	limit := int(parseFloat64(call.Args, "limit", 0))
+	cursor := parseString(call.Args, "cursor", "")
+	snapshot := parseString(call.Args, "snapshot", "")
 
-	p.logToolCall(global.ToolProjectFileList, map[string]string{"project": project})
+	p.logToolCall(global.ToolProjectFileList, map[string]string{"project": project, "snapshot": snapshot})
 
 	if project == "" {
 		return nil, fmt.Errorf("%s", "project parameter is required")
 	}
 
-	items, err := p.projects.ListFiles(project, prefix)
+	var items []projects.FileItem
+	var err error
+	if snapshot != "" {
+		items, err = p.projects.ListSnapshotFiles(project, snapshot, prefix)
+	} else {
+		items, err = p.projects.ListFiles(project, prefix)
+	}
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	// Page by path so files added between calls are neither skipped nor repeated
+	total := len(items)
+	sort.Slice(items, func(i, j int) bool { return items[i].Path < items[j].Path })
+	items, _, nextCursor, err := global.Paginate(items, func(f projects.FileItem) string { return f.Path }, cursor, 0, limit)
 	if err != nil {
 		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
 	}
@@ -41,6 +58,13 @@ func (p *Provider) handleProjectFileList(call *toolspec.ToolCall) (*toolspec.Res
 		"project": project,
 		"files":   items,
 		"count":   len(items),
+		"total":   total,
+	}
+	if snapshot != "" {
+		result["snapshot"] = snapshot
+	}
+	if nextCursor != "" {
+		result["next_cursor"] = nextCursor
 	}
 
 	return createJSONResult(result)
@@ -51,8 +75,9 @@ func (p *Provider) handleProjectFileGet(call *toolspec.ToolCall) (*toolspec.Resu
 	path := parseString(call.Args, "path", "")
 	byteOffset := int64(parseFloat64(call.Args, "byte_offset", 0))
 	maxBytes := int64(parseFloat64(call.Args, "max_bytes", 0))
+	snapshot := parseString(call.Args, "snapshot", "")
 
-	p.logToolCall(global.ToolProjectFileGet, map[string]string{"project": project, "path": path})
+	p.logToolCall(global.ToolProjectFileGet, map[string]string{"project": project, "path": path, "snapshot": snapshot})
 
 	if project == "" {
 		return nil, fmt.Errorf("%s", "project parameter is required")
@@ -61,7 +86,13 @@ func (p *Provider) handleProjectFileGet(call *toolspec.ToolCall) (*toolspec.Resu
 		return nil, fmt.Errorf("%s", "path parameter is required")
 	}
 
-	item, err := p.projects.GetFile(project, path, byteOffset, maxBytes)
+	var item *projects.FileItem
+	var err error
+	if snapshot != "" {
+		item, err = p.projects.GetSnapshotFile(project, snapshot, path, byteOffset, maxBytes)
+	} else {
+		item, err = p.projects.GetFile(project, path, byteOffset, maxBytes)
+	}
 	if err != nil {
 		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
 	}
@@ -258,8 +289,10 @@ func (p *Provider) handleProjectFileConvert(call *toolspec.ToolCall) (*toolspec.
 	project := parseString(call.Args, "project", "")
 	path := parseString(call.Args, "path", "")
 	recursive := parseBool(call.Args, "recursive", false)
+	concurrency := int(parseFloat64(call.Args, "concurrency", 0))
+	background := parseBool(call.Args, "background", false)
 
-	p.logToolCall(global.ToolProjectFileConvert, map[string]string{"project": project, "path": path})
+	p.logToolCall(global.ToolProjectFileConvert, map[string]string{"project": project, "path": path, "background": fmt.Sprintf("%t", background)})
 
 	if project == "" {
 		return nil, fmt.Errorf("%s", "project parameter is required")
@@ -310,14 +343,25 @@ func (p *Provider) handleProjectFileConvert(call *toolspec.ToolCall) (*toolspec.
 		}
 	}
 
-	// Create converter with options
-	converter := convert.New(
-		convert.WithRecursion(recursive),
-		convert.WithSkipExisting(true),
-	)
-
-	// Run conversion
-	result, err := converter.Convert(fullPath)
+	// Convert in the background, or wait and report every file
+	if background {
+		job, err := p.conversion.Start(project, filesDir, path, recursive, concurrency)
+		if err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(fmt.Sprintf("conversion failed: %v", err)), IsError: true}, nil
+		}
+		return createJSONResult(map[string]interface{}{
+			"project":     project,
+			"path":        path,
+			"recursive":   recursive,
+			"job_id":      job.ID,
+			"status":      job.Status,
+			"total":       job.Total,
+			"concurrency": job.Concurrency,
+			"message":     fmt.Sprintf("Converting %d file(s) in the background; poll %s with job_id for progress", job.Total, global.ToolProjectConvStatus),
+		})
+	}
+
+	job, err := p.conversion.Convert(project, filesDir, path, recursive, concurrency)
 	if err != nil {
 		return &toolspec.Result{ForLLM: fmt.Sprint(fmt.Sprintf("conversion failed: %v", err)), IsError: true}, nil
 	}
@@ -327,15 +371,22 @@ func (p *Provider) handleProjectFileConvert(call *toolspec.ToolCall) (*toolspec.
 		"project":   project,
 		"path":      path,
 		"recursive": recursive,
-		"converted": result.Converted,
-		"skipped":   result.Skipped,
-		"failed":    result.Failed,
-	}
-
-	if result.Converted > 0 {
-		response["message"] = fmt.Sprintf("Converted %d file(s)", result.Converted)
-	} else if result.Skipped > 0 {
-		response["message"] = fmt.Sprintf("No files converted (%d skipped)", result.Skipped)
+		"job_id":    job.ID,
+		"converted": job.Converted,
+		"skipped":   job.Skipped,
+		"failed":    job.Failed,
+		"files":     job.Files,
+	}
+	if job.LowQuality > 0 {
+		response["low_quality"] = job.LowQuality
+	}
+
+	if job.Converted > 0 && job.LowQuality > 0 {
+		response["message"] = fmt.Sprintf("Converted %d file(s); %d have little text, see the warnings in their metadata", job.Converted, job.LowQuality)
+	} else if job.Converted > 0 {
+		response["message"] = fmt.Sprintf("Converted %d file(s)", job.Converted)
+	} else if job.Skipped > 0 {
+		response["message"] = fmt.Sprintf("No files converted (%d skipped)", job.Skipped)
 	} else {
 		response["message"] = "No files to convert"
 	}
@@ -343,6 +394,44 @@ func (p *Provider) handleProjectFileConvert(call *toolspec.ToolCall) (*toolspec.
 	return createJSONResult(response)
 }
 
+// lowQualityFiles returns the paths of the files a conversion flagged as low quality
+func lowQualityFiles(job *global.ConversionJob) []string {
+	var files []string
+	for _, file := range job.Files {
+		if file.Metadata != nil && file.Metadata.LowQuality {
+			files = append(files, file.Path)
+		}
+	}
+	return files
+}
+
+// handleProjectFileConvertStatus reports the progress of a project's file conversions
+func (p *Provider) handleProjectFileConvertStatus(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	project := parseString(call.Args, "project", "")
+	jobID := parseString(call.Args, "job_id", "")
+
+	p.logToolCall(global.ToolProjectConvStatus, map[string]string{"project": project, "job_id": jobID})
+
+	if project == "" {
+		return nil, fmt.Errorf("%s", "project parameter is required")
+	}
+
+	if jobID == "" {
+		jobs := p.conversion.Jobs(project)
+		return createJSONResult(map[string]interface{}{
+			"project": project,
+			"jobs":    jobs,
+			"count":   len(jobs),
+		})
+	}
+
+	job, err := p.conversion.Job(jobID)
+	if err != nil || job.Project != project {
+		return &toolspec.Result{ForLLM: fmt.Sprintf("conversion job not found: %s", jobID), IsError: true}, nil
+	}
+	return createJSONResult(job)
+}
+
 // handleProjectFileExtract extracts a zip archive within a project's files directory
 func (p *Provider) handleProjectFileExtract(call *toolspec.ToolCall) (*toolspec.Result, error) {
 	project := parseString(call.Args, "project", "")
@@ -402,7 +491,7 @@ func (p *Provider) handleProjectFileExtract(call *toolspec.ToolCall) (*toolspec.
 	extractDir := filepath.Join(filepath.Dir(zipPath), extractDirName)
 
 	// Extract the zip
-	extracted, skipped, err := extractZipFile(zipPath, extractDir, overwrite, p.logger)
+	extracted, skipped, rejected, err := extractZipFile(zipPath, extractDir, overwrite, p.config.ImportPolicy(), p.logger)
 	if err != nil {
 		return &toolspec.Result{ForLLM: fmt.Sprint(fmt.Sprintf("extraction failed: %v", err)), IsError: true}, nil
 	}
@@ -419,21 +508,22 @@ func (p *Provider) handleProjectFileExtract(call *toolspec.ToolCall) (*toolspec.
 		"files_skipped":   skipped,
 		"links_removed":   linksRemoved,
 	}
+	if len(rejected) > 0 {
+		response["rejected"] = rejected
+	}
 
 	// Run conversion if requested
 	if doConvert && extracted > 0 {
-		converter := convert.New(
-			convert.WithRecursion(true),
-			convert.WithSkipExisting(true),
-		)
-
-		convertResult, convertErr := converter.Convert(extractDir)
+		convertResult, convertErr := p.conversion.Convert(project, filesDir, filepath.ToSlash(strings.TrimPrefix(extractDir, filesDir+"/")), true, 0)
 		if convertErr != nil {
 			p.logger.Warnf("Conversion after extraction failed: %v", convertErr)
 		} else {
 			response["converted"] = convertResult.Converted
 			response["convert_skipped"] = convertResult.Skipped
 			response["convert_failed"] = convertResult.Failed
+			if lowQuality := lowQualityFiles(convertResult); len(lowQuality) > 0 {
+				response["convert_low_quality"] = lowQuality
+			}
 		}
 	}
 
@@ -441,12 +531,14 @@ func (p *Provider) handleProjectFileExtract(call *toolspec.ToolCall) (*toolspec.
 }
 
 // extractZipFile extracts a zip archive to the specified directory.
-// Returns counts of extracted and skipped files.
-func extractZipFile(zipPath, destDir string, overwrite bool, logger interface{ Warnf(string, ...interface{}) }) (int, int, error) {
+// Returns counts of extracted and skipped files, and the files the import
+// policy rejected, which are left out. An archive whose files are larger in
+// total than the policy allows is not extracted.
+func extractZipFile(zipPath, destDir string, overwrite bool, policy global.ImportPolicy, logger interface{ Warnf(string, ...interface{}) }) (int, int, []global.ImportRejection, error) {
 	// Open the zip file
 	r, err := zip.OpenReader(zipPath)
 	if err != nil {
-		return 0, 0, fmt.Errorf("failed to open zip file: %w", err)
+		return 0, 0, nil, fmt.Errorf("failed to open zip file: %w", err)
 	}
 	defer r.Close()
 
@@ -456,10 +548,35 @@ func extractZipFile(zipPath, destDir string, overwrite bool, logger interface{ W
 	// Get absolute destination for security checks
 	absDestDir, err := filepath.Abs(destDir)
 	if err != nil {
-		return 0, 0, fmt.Errorf("failed to resolve destination directory: %w", err)
+		return 0, 0, nil, fmt.Errorf("failed to resolve destination directory: %w", err)
+	}
+
+	// Check the files against the import policy before extracting anything
+	var rejections []global.ImportRejection
+	rejected := make(map[*zip.File]bool)
+	files := 0
+	var total int64
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		if err := policy.CheckFile(f.Name, int64(f.UncompressedSize64), zipEntryHead(f)); err != nil {
+			rejected[f] = true
+			rejections = append(rejections, global.ImportRejection{Path: f.Name, Reason: err.Error()})
+			continue
+		}
+		files++
+		total += int64(f.UncompressedSize64)
+	}
+	if err := policy.CheckTotal(files, total); err != nil {
+		return 0, 0, rejections, err
 	}
 
 	for _, f := range r.File {
+		if rejected[f] {
+			continue
+		}
+
 		// Clean and validate the path
 		cleanName := filepath.Clean(f.Name)
 
@@ -487,7 +604,7 @@ func extractZipFile(zipPath, destDir string, overwrite bool, logger interface{ W
 		// Handle directories
 		if f.FileInfo().IsDir() {
 			if err := os.MkdirAll(destPath, 0755); err != nil {
-				return extracted, skipped, fmt.Errorf("failed to create directory %s: %w", cleanName, err)
+				return extracted, skipped, rejections, fmt.Errorf("failed to create directory %s: %w", cleanName, err)
 			}
 			continue
 		}
@@ -502,18 +619,33 @@ func extractZipFile(zipPath, destDir string, overwrite bool, logger interface{ W
 
 		// Ensure parent directory exists
 		if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
-			return extracted, skipped, fmt.Errorf("failed to create parent directory for %s: %w", cleanName, err)
+			return extracted, skipped, rejections, fmt.Errorf("failed to create parent directory for %s: %w", cleanName, err)
 		}
 
 		// Extract the file
 		if err := extractZipEntry(f, destPath); err != nil {
-			return extracted, skipped, fmt.Errorf("failed to extract %s: %w", cleanName, err)
+			return extracted, skipped, rejections, fmt.Errorf("failed to extract %s: %w", cleanName, err)
 		}
 
 		extracted++
 	}
 
-	return extracted, skipped, nil
+	return extracted, skipped, rejections, nil
+}
+
+// zipEntryHead returns a function that reads the start of a zip entry, for
+// ImportPolicy.CheckFile
+func zipEntryHead(f *zip.File) func() []byte {
+	return func() []byte {
+		rc, err := f.Open()
+		if err != nil {
+			return nil
+		}
+		defer rc.Close()
+		buf := make([]byte, 512)
+		n, _ := io.ReadFull(rc, buf)
+		return buf[:n]
+	}
 }
 
 // extractZipEntry extracts a single file from a zip archive
@@ -524,6 +656,10 @@ func extractZipEntry(f *zip.File, destPath string) error {
 	}
 	defer rc.Close()
 
+	// Replace rather than truncate an existing file: it may be hard-linked into a snapshot
+	if err := os.Remove(destPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
 	outFile, err := os.OpenFile(destPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, f.Mode())
 	if err != nil {
 		return err
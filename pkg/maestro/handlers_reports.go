@@ -6,25 +6,34 @@
 package maestro
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/PivotLLM/toolspec"
 
 	"github.com/PivotLLM/Maestro/global"
+	"github.com/PivotLLM/Maestro/projects"
 )
 
 // Report handlers - Read-only domain with controlled write access
 
 func (p *Provider) handleReportList(call *toolspec.ToolCall) (*toolspec.Result, error) {
 	project := parseString(call.Args, "project", "")
+	snapshot := parseString(call.Args, "snapshot", "")
 
-	p.logToolCall(global.ToolReportList, map[string]string{"project": project})
+	p.logToolCall(global.ToolReportList, map[string]string{"project": project, "snapshot": snapshot})
 
 	if project == "" {
 		return nil, fmt.Errorf("%s", "project parameter is required")
 	}
 
-	items, err := p.projects.ListReports(project)
+	var items []projects.ReportItem
+	var err error
+	if snapshot != "" {
+		items, err = p.projects.ListSnapshotReports(project, snapshot)
+	} else {
+		items, err = p.projects.ListReports(project)
+	}
 	if err != nil {
 		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
 	}
@@ -34,6 +43,9 @@ func (p *Provider) handleReportList(call *toolspec.ToolCall) (*toolspec.Result,
 		"reports": items,
 		"count":   len(items),
 	}
+	if snapshot != "" {
+		result["snapshot"] = snapshot
+	}
 
 	return createJSONResult(result)
 }
@@ -43,8 +55,9 @@ func (p *Provider) handleReportRead(call *toolspec.ToolCall) (*toolspec.Result,
 	report := parseString(call.Args, "report", "")
 	byteOffset := int64(parseFloat64(call.Args, "byte_offset", 0))
 	maxBytes := int64(parseFloat64(call.Args, "max_bytes", 0))
+	snapshot := parseString(call.Args, "snapshot", "")
 
-	p.logToolCall(global.ToolReportRead, map[string]string{"project": project, "report": report})
+	p.logToolCall(global.ToolReportRead, map[string]string{"project": project, "report": report, "snapshot": snapshot})
 
 	if project == "" {
 		return nil, fmt.Errorf("%s", "project parameter is required")
@@ -53,7 +66,13 @@ func (p *Provider) handleReportRead(call *toolspec.ToolCall) (*toolspec.Result,
 		return nil, fmt.Errorf("%s", "report parameter is required")
 	}
 
-	item, err := p.projects.ReadReport(project, report, byteOffset, maxBytes)
+	var item *projects.ReportItem
+	var err error
+	if snapshot != "" {
+		item, err = p.projects.ReadSnapshotReport(project, snapshot, report, byteOffset, maxBytes)
+	} else {
+		item, err = p.projects.ReadReport(project, report, byteOffset, maxBytes)
+	}
 	if err != nil {
 		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
 	}
@@ -61,6 +80,26 @@ func (p *Provider) handleReportRead(call *toolspec.ToolCall) (*toolspec.Result,
 	return createJSONResult(item)
 }
 
+func (p *Provider) handleReportSearch(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	query := parseString(call.Args, "query", "")
+	project := parseString(call.Args, "project", "")
+	limit := int(parseFloat64(call.Args, "limit", 0))
+	offset := int(parseFloat64(call.Args, "offset", 0))
+
+	p.logToolCall(global.ToolReportSearch, map[string]string{"project": project, "query": query})
+
+	if query == "" {
+		return nil, fmt.Errorf("%s", "query parameter is required")
+	}
+
+	result, err := p.projects.SearchReports(project, query, limit, offset)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	return createJSONResult(result)
+}
+
 func (p *Provider) handleReportStart(call *toolspec.ToolCall) (*toolspec.Result, error) {
 	project := parseString(call.Args, "project", "")
 	title := parseString(call.Args, "title", "")
@@ -104,7 +143,7 @@ func (p *Provider) handleReportAppend(call *toolspec.ToolCall) (*toolspec.Result
 		return nil, fmt.Errorf("%s", "content parameter is required")
 	}
 
-	err := p.projects.AppendReport(project, content, report)
+	err := p.projects.AppendReport(project, content, report, nil)
 	if err != nil {
 		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
 	}
@@ -174,3 +213,119 @@ func (p *Provider) handleReportEnd(call *toolspec.ToolCall) (*toolspec.Result, e
 
 	return createJSONResult(result)
 }
+
+func (p *Provider) handleReportSessions(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	project := parseString(call.Args, "project", "")
+
+	p.logToolCall(global.ToolReportSessions, map[string]string{"project": project})
+
+	if project == "" {
+		return nil, fmt.Errorf("%s", "project parameter is required")
+	}
+
+	sessions, err := p.projects.ReportSessions(project)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	result := map[string]interface{}{
+		"project":  project,
+		"sessions": sessions,
+		"count":    len(sessions),
+	}
+
+	return createJSONResult(result)
+}
+
+func (p *Provider) handleReportFinalize(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	project := parseString(call.Args, "project", "")
+
+	p.logToolCall(global.ToolReportFinalize, map[string]string{"project": project})
+
+	if project == "" {
+		return nil, fmt.Errorf("%s", "project parameter is required")
+	}
+
+	// Collect the templates used by the project's task sets
+	templates, err := p.runner.ReportTemplateContents(project)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	finalized, err := p.projects.FinalizeReport(project, templates)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	result := map[string]interface{}{
+		"project":      project,
+		"prefix":       finalized.Prefix,
+		"archive_path": finalized.ArchivePath,
+		"checksum":     finalized.Checksum,
+		"file_count":   finalized.FileCount,
+		"finalized_at": finalized.FinalizedAt,
+		"message":      "Report finalized and archived. Report session ended; new work will start a new report.",
+		"success":      true,
+	}
+
+	return createJSONResult(result)
+}
+
+func (p *Provider) handleReportDebug(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	project := parseString(call.Args, "project", "")
+	uuid := parseString(call.Args, "uuid", "")
+	template := parseString(call.Args, "template", "")
+	suffix := parseString(call.Args, "suffix", "")
+	phase := parseString(call.Args, "phase", "worker")
+
+	p.logToolCall(global.ToolReportDebug, map[string]string{"project": project, "uuid": uuid, "template": template})
+
+	if project == "" {
+		return nil, fmt.Errorf("%s", "project parameter is required")
+	}
+	if uuid == "" {
+		return nil, fmt.Errorf("%s", "uuid parameter is required")
+	}
+	if phase != "worker" && phase != "qa" {
+		return &toolspec.Result{ForLLM: fmt.Sprint("phase must be 'worker' or 'qa'"), IsError: true}, nil
+	}
+
+	debug, err := p.runner.DebugReport(project, uuid, template, suffix, phase == "qa")
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	return createJSONResult(debug)
+}
+
+func (p *Provider) handleDeliverableGenerate(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	project := parseString(call.Args, "project", "")
+	template := parseString(call.Args, "template", "")
+	source := parseString(call.Args, "source", "project")
+	output := parseString(call.Args, "output", "")
+	path := parseString(call.Args, "path", "")
+	data := parseString(call.Args, "data", "")
+
+	p.logToolCall(global.ToolDeliverableGenerate, map[string]string{"project": project, "template": template, "source": source, "output": output, "path": path})
+
+	if project == "" {
+		return nil, fmt.Errorf("%s", "project parameter is required")
+	}
+	if template == "" {
+		return nil, fmt.Errorf("%s", "template parameter is required")
+	}
+
+	var extra map[string]interface{}
+	if data != "" {
+		if err := json.Unmarshal([]byte(data), &extra); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprintf("data must be a JSON object: %v", err), IsError: true}, nil
+		}
+	}
+
+	result, err := p.runner.GenerateDeliverable(project, template, source, output, path, extra)
+	if err != nil {
+		return errorResult(err), nil
+	}
+
+	return createJSONResult(result)
+}
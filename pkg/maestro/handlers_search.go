@@ -0,0 +1,162 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package maestro
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/PivotLLM/toolspec"
+
+	"github.com/PivotLLM/Maestro/embeddings"
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// Semantic search tool handler (optional, requires an embeddings endpoint)
+
+func (p *Provider) handleSemanticSearch(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	query := parseString(call.Args, "query", "")
+	project := parseString(call.Args, "project", "")
+	playbook := parseString(call.Args, "playbook", "")
+	includeReference := parseBool(call.Args, "reference", false)
+	limit := int(parseFloat64(call.Args, "limit", global.DefaultSemanticSearchLimit))
+
+	p.logToolCall(global.ToolSemanticSearch, map[string]string{"query": query, "project": project, "playbook": playbook, "reference": fmt.Sprintf("%v", includeReference)})
+
+	if query == "" {
+		return nil, fmt.Errorf("%s", "query parameter is required")
+	}
+	if project == "" && playbook == "" && !includeReference {
+		return nil, fmt.Errorf("%s", "at least one of project, playbook or reference is required")
+	}
+	if p.embeddings == nil {
+		return &toolspec.Result{ForLLM: "semantic search is not configured (set embeddings.endpoint and embeddings.model)", IsError: true}, nil
+	}
+
+	// Bring each selected index up to date before searching
+	var collections []embeddings.Collection
+	indexed := make(map[string]*embeddings.SyncStats)
+	update := func(collection embeddings.Collection, docs []embeddings.Document, err error) error {
+		if err != nil {
+			return err
+		}
+		stats, err := p.embeddings.Sync(collection, docs)
+		if err != nil {
+			return err
+		}
+		collections = append(collections, collection)
+		indexed[collection.String()] = stats
+		return nil
+	}
+	if project != "" {
+		docs, err := p.projectDocuments(project)
+		if err := update(embeddings.Collection{Source: global.EmbeddingsSourceProject, Name: project}, docs, err); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+	}
+	if playbook != "" {
+		docs, err := p.playbookDocuments(playbook)
+		if err := update(embeddings.Collection{Source: global.EmbeddingsSourcePlaybook, Name: playbook}, docs, err); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+	}
+	if includeReference {
+		docs, err := p.referenceDocuments()
+		if err := update(embeddings.Collection{Source: global.EmbeddingsSourceReference}, docs, err); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+	}
+
+	matches, err := p.embeddings.Search(query, collections, limit)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	result := map[string]interface{}{
+		"query":   query,
+		"matches": matches,
+		"count":   len(matches),
+		"indexed": indexed,
+	}
+
+	return createJSONResult(result)
+}
+
+// projectDocuments returns the files of a project for indexing
+func (p *Provider) projectDocuments(project string) ([]embeddings.Document, error) {
+	items, err := p.projects.ListFiles(project, "")
+	if err != nil {
+		return nil, err
+	}
+	docs := make([]embeddings.Document, 0, len(items))
+	for _, item := range items {
+		path := item.Path
+		modifiedAt, _ := time.Parse(time.RFC3339, item.ModifiedAt)
+		docs = append(docs, embeddings.Document{
+			Path:       path,
+			SizeBytes:  item.SizeBytes,
+			ModifiedAt: modifiedAt,
+			Load: func() (string, error) {
+				file, err := p.projects.GetFile(project, path, 0, 0)
+				if err != nil {
+					return "", err
+				}
+				return file.Content, nil
+			},
+		})
+	}
+	return docs, nil
+}
+
+// playbookDocuments returns the files of a playbook for indexing
+func (p *Provider) playbookDocuments(playbook string) ([]embeddings.Document, error) {
+	items, err := p.playbooks.ListFiles(playbook, "")
+	if err != nil {
+		return nil, err
+	}
+	docs := make([]embeddings.Document, 0, len(items))
+	for _, item := range items {
+		path := item.Path
+		docs = append(docs, embeddings.Document{
+			Path:       path,
+			SizeBytes:  item.SizeBytes,
+			ModifiedAt: item.ModifiedAt,
+			Load: func() (string, error) {
+				file, err := p.playbooks.GetFile(playbook, path, 0, 0)
+				if err != nil {
+					return "", err
+				}
+				return file.Content, nil
+			},
+		})
+	}
+	return docs, nil
+}
+
+// referenceDocuments returns the reference library for indexing
+func (p *Provider) referenceDocuments() ([]embeddings.Document, error) {
+	items, err := p.reference.List("")
+	if err != nil {
+		return nil, err
+	}
+	docs := make([]embeddings.Document, 0, len(items))
+	for _, item := range items {
+		path := item.Path
+		docs = append(docs, embeddings.Document{
+			Path:       path,
+			SizeBytes:  item.SizeBytes,
+			ModifiedAt: item.ModifiedAt,
+			Load: func() (string, error) {
+				ref, err := p.reference.Get(path, 0, 0)
+				if err != nil {
+					return "", err
+				}
+				return ref.Content, nil
+			},
+		})
+	}
+	return docs, nil
+}
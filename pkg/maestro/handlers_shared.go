@@ -0,0 +1,155 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package maestro
+
+import (
+	"fmt"
+
+	"github.com/PivotLLM/toolspec"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// Shared evidence library tool handlers (read-only to projects)
+
+func (p *Provider) handleSharedList(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	prefix := parseString(call.Args, "prefix", "")
+
+	p.logToolCall(global.ToolSharedList, map[string]string{"prefix": prefix})
+
+	items, err := p.shared.List(prefix)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	result := map[string]interface{}{
+		"items": items,
+		"count": len(items),
+	}
+
+	return createJSONResult(result)
+}
+
+func (p *Provider) handleSharedGet(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	path := parseString(call.Args, "path", "")
+	byteOffset := int64(parseFloat64(call.Args, "byte_offset", 0))
+	maxBytes := int64(parseFloat64(call.Args, "max_bytes", 0))
+
+	p.logToolCall(global.ToolSharedGet, map[string]string{"path": path})
+
+	if path == "" {
+		return nil, fmt.Errorf("%s", "path parameter is required")
+	}
+
+	item, err := p.shared.Get(path, byteOffset, maxBytes)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	return createJSONResult(item)
+}
+
+func (p *Provider) handleSharedSearch(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	query := parseString(call.Args, "query", "")
+	limit := int(parseFloat64(call.Args, "limit", 0))
+	offset := int(parseFloat64(call.Args, "offset", 0))
+
+	p.logToolCall(global.ToolSharedSearch, map[string]string{"query": query})
+
+	if query == "" {
+		return nil, fmt.Errorf("%s", "query parameter is required")
+	}
+
+	items, total, err := p.shared.Search(query, limit, offset)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	result := map[string]interface{}{
+		"items": items,
+		"total": total,
+		"count": len(items),
+	}
+
+	return createJSONResult(result)
+}
+
+// handleSharedImport imports external files into the shared evidence library,
+// optionally converting them to Markdown so every project can read them
+func (p *Provider) handleSharedImport(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	source := parseString(call.Args, "source", "")
+	dest := parseString(call.Args, "path", "")
+	recursive := parseBool(call.Args, "recursive", false)
+	doConvert := parseBool(call.Args, "convert", false)
+
+	p.logToolCall(global.ToolSharedImport, map[string]string{
+		"source":    source,
+		"path":      dest,
+		"recursive": fmt.Sprintf("%t", recursive),
+		"convert":   fmt.Sprintf("%t", doConvert),
+	})
+
+	if source == "" {
+		return nil, fmt.Errorf("%s", "source parameter is required")
+	}
+
+	importResult, err := p.shared.Import(source, dest, recursive)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	result := map[string]interface{}{
+		"source":         importResult.Source,
+		"recursive":      importResult.Recursive,
+		"files_imported": importResult.FilesImported,
+		"imported_to":    importResult.ImportedTo,
+	}
+	if importResult.LinksSkipped > 0 {
+		result["links_skipped"] = importResult.LinksSkipped
+	}
+	if len(importResult.Rejected) > 0 {
+		result["rejected"] = importResult.Rejected
+	}
+
+	// Run conversion if requested
+	if doConvert && importResult.FilesImported > 0 {
+		convertResult, convertErr := p.conversion.Convert("", p.shared.Dir(), importResult.ImportedTo, true, 0)
+		if convertErr != nil {
+			// Log but don't fail - import succeeded
+			p.logger.Warnf("Conversion after shared import failed: %v", convertErr)
+		} else {
+			result["converted"] = convertResult.Converted
+			result["convert_skipped"] = convertResult.Skipped
+			result["convert_failed"] = convertResult.Failed
+			if lowQuality := lowQualityFiles(convertResult); len(lowQuality) > 0 {
+				result["convert_low_quality"] = lowQuality
+			}
+		}
+	}
+
+	return createJSONResult(result)
+}
+
+func (p *Provider) handleSharedDelete(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	path := parseString(call.Args, "path", "")
+
+	p.logToolCall(global.ToolSharedDelete, map[string]string{"path": path})
+
+	if path == "" {
+		return nil, fmt.Errorf("%s", "path parameter is required")
+	}
+
+	if err := p.shared.Delete(path); err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	result := map[string]interface{}{
+		"path":    path,
+		"deleted": true,
+	}
+
+	return createJSONResult(result)
+}
@@ -16,15 +16,19 @@ import (
 	"time"
 
 	"github.com/PivotLLM/Maestro/global"
+	"github.com/PivotLLM/Maestro/runner"
+	templatespkg "github.com/PivotLLM/Maestro/templates"
 )
 
 // handleSupervisorUpdate handles the supervisor_update MCP tool.
-// Allows a supervisor to replace the worker response with their own content.
-// The response must pass template validation. History is append-only.
+// Allows a supervisor to replace the worker response with their own content, or
+// to patch it. The response must pass template validation. History is append-only,
+// and the result file records how each edit changed the response.
 func (p *Provider) handleSupervisorUpdate(call *toolspec.ToolCall) (*toolspec.Result, error) {
 	project := parseString(call.Args, "project", "")
 	uuid := parseString(call.Args, "uuid", "")
 	response := parseString(call.Args, "response", "")
+	patch := parseString(call.Args, "patch", "")
 
 	p.logToolCall(global.ToolSupervisorUpdate, map[string]string{"project": project, "uuid": uuid})
 
@@ -34,8 +38,11 @@ func (p *Provider) handleSupervisorUpdate(call *toolspec.ToolCall) (*toolspec.Re
 	if uuid == "" {
 		return nil, fmt.Errorf("%s", "uuid parameter is required")
 	}
-	if response == "" {
-		return nil, fmt.Errorf("%s", "response parameter is required")
+	if response == "" && patch == "" {
+		return nil, fmt.Errorf("%s", "response or patch parameter is required")
+	}
+	if response != "" && patch != "" {
+		return &toolspec.Result{ForLLM: fmt.Sprint("provide either response or patch, not both"), IsError: true}, nil
 	}
 
 	// Get task to find the taskset for template validation
@@ -50,35 +57,8 @@ func (p *Provider) handleSupervisorUpdate(call *toolspec.ToolCall) (*toolspec.Re
 		return &toolspec.Result{ForLLM: fmt.Sprint(fmt.Sprintf("failed to get taskset: %v", err)), IsError: true}, nil
 	}
 
-	// Validate response against worker_response_template
-	if taskset.WorkerResponseTemplate != "" {
-		// Load template
-		templateContent, err := p.loadTemplate(project, taskset.WorkerResponseTemplate)
-		if err != nil {
-			return &toolspec.Result{ForLLM: fmt.Sprint(fmt.Sprintf("failed to load response template: %v", err)), IsError: true}, nil
-		}
-
-		// Parse template as JSON schema
-		var schema map[string]interface{}
-		if err := json.Unmarshal([]byte(templateContent), &schema); err != nil {
-			return &toolspec.Result{ForLLM: fmt.Sprint(fmt.Sprintf("failed to parse response template: %v", err)), IsError: true}, nil
-		}
-
-		// Parse response as JSON
-		var responseData map[string]interface{}
-		if err := json.Unmarshal([]byte(response), &responseData); err != nil {
-			return &toolspec.Result{ForLLM: fmt.Sprint(fmt.Sprintf("response must be valid JSON matching template. Template:\n%s\n\nYour response is not valid JSON: %v", templateContent, err)), IsError: true}, nil
-		}
-
-		// Basic validation: check required fields exist
-		if err := validateResponseAgainstSchema(responseData, schema); err != nil {
-			return &toolspec.Result{ForLLM: fmt.Sprint(fmt.Sprintf("response does not match template. Template:\n%s\n\nValidation error: %v", templateContent, err)), IsError: true}, nil
-		}
-	}
-
 	// Load existing result
-	resultsDir := p.tasks.GetResultsDir(project)
-	resultPath := filepath.Join(resultsDir, uuid+".json")
+	resultPath := p.tasks.ResultFile(project, taskPath, task, global.ResultFileSuffix)
 
 	var taskResult global.TaskResult
 	resultData, err := os.ReadFile(resultPath)
@@ -110,6 +90,51 @@ func (p *Provider) handleSupervisorUpdate(call *toolspec.ToolCall) (*toolspec.Re
 		}
 	}
 
+	// A patch is applied to the current worker response
+	previous := taskResult.Worker.Response
+	mode := global.SupervisorEditReplace
+	if patch != "" {
+		mode = global.SupervisorEditPatch
+		if response, err = applyResponsePatch(previous, patch); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+	}
+
+	// Validate response against worker_response_template
+	if taskset.WorkerResponseTemplate != "" {
+		// Load template
+		templateContent, err := p.loadTemplate(project, taskset.WorkerResponseTemplate)
+		if err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(fmt.Sprintf("failed to load response template: %v", err)), IsError: true}, nil
+		}
+
+		// Parse template as JSON schema
+		var schema map[string]interface{}
+		if err := json.Unmarshal([]byte(templateContent), &schema); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(fmt.Sprintf("failed to parse response template: %v", err)), IsError: true}, nil
+		}
+
+		// Parse response as JSON
+		var responseData map[string]interface{}
+		if err := json.Unmarshal([]byte(response), &responseData); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(fmt.Sprintf("response must be valid JSON matching template. Template:\n%s\n\nYour response is not valid JSON: %v", templateContent, err)), IsError: true}, nil
+		}
+
+		// Basic validation: check required fields exist
+		if err := validateResponseAgainstSchema(responseData, schema); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(fmt.Sprintf("response does not match template. Template:\n%s\n\nValidation error: %v", templateContent, err)), IsError: true}, nil
+		}
+	}
+
+	// Record how the edit changed the response
+	diff := runner.DiffResponses(previous, response)
+	taskResult.SupervisorEdits = append(taskResult.SupervisorEdits, global.SupervisorEdit{
+		EditedAt: time.Now(),
+		Mode:     mode,
+		Fields:   diff.Fields(),
+		Diff:     diff,
+	})
+
 	// Add supervisor message to history
 	supervisorMessage := global.Message{
 		Timestamp: time.Now(),
@@ -127,6 +152,7 @@ func (p *Provider) handleSupervisorUpdate(call *toolspec.ToolCall) (*toolspec.Re
 	if taskResult.QA != nil {
 		taskResult.QA.Response = ""
 		taskResult.QA.Verdict = ""
+		taskResult.QA.Severity = ""
 		taskResult.QA.Error = ""
 		taskResult.QA.FullPrompt = ""
 		taskResult.QA.Status = "superseded"
@@ -142,7 +168,10 @@ func (p *Provider) handleSupervisorUpdate(call *toolspec.ToolCall) (*toolspec.Re
 		return &toolspec.Result{ForLLM: fmt.Sprint(fmt.Sprintf("failed to marshal result: %v", err)), IsError: true}, nil
 	}
 
-	if err := os.WriteFile(resultPath, newResultData, 0644); err != nil {
+	if err := os.MkdirAll(filepath.Dir(resultPath), 0755); err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(fmt.Sprintf("failed to create results directory: %v", err)), IsError: true}, nil
+	}
+	if err := global.AtomicWrite(resultPath, p.config.Redactor().RedactJSON(newResultData)); err != nil {
 		return &toolspec.Result{ForLLM: fmt.Sprint(fmt.Sprintf("failed to save result: %v", err)), IsError: true}, nil
 	}
 
@@ -152,8 +181,9 @@ func (p *Provider) handleSupervisorUpdate(call *toolspec.ToolCall) (*toolspec.Re
 			"status": global.ExecutionStatusDone,
 		},
 		"qa": map[string]interface{}{
-			"verdict": "N/A",
-			"status":  "superseded",
+			"verdict":  "N/A",
+			"severity": "",
+			"status":   "superseded",
 		},
 	}
 	if _, err := p.tasks.UpdateTask(project, uuid, updates); err != nil {
@@ -162,9 +192,11 @@ func (p *Provider) handleSupervisorUpdate(call *toolspec.ToolCall) (*toolspec.Re
 
 	result := map[string]interface{}{
 		"project":             project,
-		"uuid":                uuid,
+		"uuid":                task.UUID,
 		"task_id":             task.ID,
 		"supervisor_override": true,
+		"mode":                mode,
+		"fields":              diff.Fields(),
 		"status":              "done",
 		"message":             "Supervisor response applied successfully",
 	}
@@ -172,6 +204,112 @@ func (p *Provider) handleSupervisorUpdate(call *toolspec.ToolCall) (*toolspec.Re
 	return createJSONResult(result)
 }
 
+// applyResponsePatch applies a supervisor's JSON merge patch to a worker
+// response, which must be a JSON object, and returns the patched response
+func applyResponsePatch(response, patch string) (string, error) {
+	if response == "" {
+		return "", fmt.Errorf("the task has no worker response to patch; use response to provide one")
+	}
+	var current map[string]interface{}
+	if err := json.Unmarshal([]byte(templatespkg.ExtractJSON(response)), &current); err != nil {
+		return "", fmt.Errorf("patch requires a JSON object worker response; use response to replace it")
+	}
+	var changes map[string]interface{}
+	if err := json.Unmarshal([]byte(patch), &changes); err != nil {
+		return "", fmt.Errorf("patch must be a JSON object: %v", err)
+	}
+	patched, err := json.MarshalIndent(global.MergePatch(current, changes), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode patched response: %v", err)
+	}
+	return string(patched), nil
+}
+
+// handleTaskApprove handles the task_approve MCP tool
+func (p *Provider) handleTaskApprove(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	return p.decideApproval(call, global.ToolTaskApprove, true)
+}
+
+// handleTaskReject handles the task_reject MCP tool
+func (p *Provider) handleTaskReject(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	return p.decideApproval(call, global.ToolTaskReject, false)
+}
+
+// decideApproval records a human decision on a task awaiting approval. An
+// approved task becomes done and its result is reported; a rejected one fails.
+// The decision and the approver's note are appended to the task history.
+func (p *Provider) decideApproval(call *toolspec.ToolCall, tool string, approve bool) (*toolspec.Result, error) {
+	project := parseString(call.Args, "project", "")
+	uuid := parseString(call.Args, "uuid", "")
+	note := parseString(call.Args, "note", "")
+	approver := parseString(call.Args, "approver", "")
+
+	p.logToolCall(tool, map[string]string{"project": project, "uuid": uuid, "approver": approver})
+
+	if project == "" {
+		return nil, fmt.Errorf("%s", "project parameter is required")
+	}
+	if uuid == "" {
+		return nil, fmt.Errorf("%s", "uuid parameter is required")
+	}
+	if !approve && note == "" {
+		return nil, fmt.Errorf("%s", "note parameter is required when rejecting a task")
+	}
+
+	task, taskPath, err := p.tasks.DecideApproval(project, uuid, approve, note)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	decision := global.ApprovalDecisionApproved
+	if !approve {
+		decision = global.ApprovalDecisionRejected
+	}
+
+	// Append the decision to the history of the result file
+	resultPath := p.tasks.ResultFile(project, taskPath, task, global.ResultFileSuffix)
+	resultData, err := os.ReadFile(resultPath)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprintf("task %s but its result file could not be read: %v", decision, err), IsError: true}, nil
+	}
+	var taskResult global.TaskResult
+	if err := json.Unmarshal(resultData, &taskResult); err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprintf("task %s but its result file could not be parsed: %v", decision, err), IsError: true}, nil
+	}
+
+	taskResult.History = append(taskResult.History, global.Message{
+		Timestamp: time.Now(),
+		Role:      "approver",
+		Decision:  decision,
+		Approver:  approver,
+		Note:      note,
+	})
+	taskResult.Worker.Status = task.Work.Status
+	taskResult.Worker.Error = task.Work.Error
+	taskResult.Worker.ErrorCode = task.Work.ErrorCode
+
+	newResultData, err := json.MarshalIndent(taskResult, "", "  ")
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprintf("failed to marshal result: %v", err), IsError: true}, nil
+	}
+	if err := global.AtomicWrite(resultPath, p.config.Redactor().RedactJSON(newResultData)); err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprintf("task %s but the decision was not saved to its history: %v", decision, err), IsError: true}, nil
+	}
+
+	result := map[string]interface{}{
+		"project":  project,
+		"uuid":     task.UUID,
+		"task_id":  task.ID,
+		"decision": decision,
+		"status":   task.Work.Status,
+	}
+	if approver != "" {
+		result["approver"] = approver
+	}
+
+	return createJSONResult(result)
+}
+
 // loadTemplate loads a template file from playbook or project files
 func (p *Provider) loadTemplate(project, templatePath string) (string, error) {
 	// Try playbook first (format: playbook-name/path/to/file)
@@ -234,13 +372,25 @@ func validateResponseAgainstSchema(response, schema map[string]interface{}) erro
 func (p *Provider) handleReportCreate(call *toolspec.ToolCall) (*toolspec.Result, error) {
 	project := parseString(call.Args, "project", "")
 	path := parseString(call.Args, "path", "")
+	formatsStr := parseString(call.Args, "formats", "")
+	regenerate := parseBool(call.Args, "regenerate", false)
 
-	p.logToolCall(global.ToolReportCreate, map[string]string{"project": project, "path": path})
+	p.logToolCall(global.ToolReportCreate, map[string]string{"project": project, "path": path, "formats": formatsStr, "regenerate": fmt.Sprintf("%t", regenerate)})
 
 	if project == "" {
 		return nil, fmt.Errorf("%s", "project parameter is required")
 	}
 
+	var formats []string
+	for _, format := range strings.Split(formatsStr, ",") {
+		if format = strings.ToLower(strings.TrimSpace(format)); format != "" {
+			formats = append(formats, format)
+		}
+	}
+	if err := global.ValidateReportFormats(formats); err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
 	// Get project to retrieve its title for the report session
 	proj, err := p.projects.Get(project)
 	if err != nil {
@@ -254,9 +404,9 @@ func (p *Provider) handleReportCreate(call *toolspec.ToolCall) (*toolspec.Result
 	}
 
 	// Use runner's GenerateReport function
-	reports, err := p.runner.GenerateReport(project, path)
+	reports, err := p.runner.GenerateReport(project, path, formats, regenerate)
 	if err != nil {
-		return &toolspec.Result{ForLLM: fmt.Sprint(fmt.Sprintf("failed to generate report: %v", err)), IsError: true}, nil
+		return errorResult(fmt.Errorf("failed to generate report: %w", err)), nil
 	}
 
 	result := map[string]interface{}{
@@ -13,9 +13,11 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/PivotLLM/Maestro/global"
 	"github.com/PivotLLM/Maestro/reporting"
+	"github.com/PivotLLM/Maestro/runner"
 )
 
 // handleTaskRun handles the task_run MCP tool
@@ -72,6 +74,101 @@ func (p *Provider) handleTaskStatus(call *toolspec.ToolCall) (*toolspec.Result,
 	return createJSONResult(result)
 }
 
+// handleTaskRunResume handles the task_run_resume MCP tool
+func (p *Provider) handleTaskRunResume(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	project := parseString(call.Args, "project", "")
+	dryRun := parseBool(call.Args, "dry_run", false)
+
+	p.logToolCall(global.ToolTaskRunResume, map[string]string{"project": project, "dry_run": fmt.Sprintf("%t", dryRun)})
+
+	if project == "" {
+		return nil, fmt.Errorf("%s", "project is required")
+	}
+
+	result, err := p.runner.ResumeInterruptedRuns(project, dryRun)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	return createJSONResult(result)
+}
+
+// handleTaskEvents handles the task_events MCP tool
+func (p *Provider) handleTaskEvents(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	project := parseString(call.Args, "project", "")
+	path := parseString(call.Args, "path", "")
+	taskID := int(parseFloat64(call.Args, "task_id", -1))
+	event := parseString(call.Args, "event", "")
+	sinceStr := parseString(call.Args, "since", "")
+	limit := int(parseFloat64(call.Args, "limit", float64(global.DefaultEventsLimit)))
+
+	p.logToolCall(global.ToolTaskEvents, map[string]string{"project": project, "path": path, "event": event, "since": sinceStr})
+
+	if project == "" {
+		return nil, fmt.Errorf("%s", "project is required")
+	}
+	var since time.Time
+	if sinceStr != "" {
+		var err error
+		if since, err = time.Parse(time.RFC3339, sinceStr); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprintf("invalid since %q: must be an RFC 3339 timestamp", sinceStr), IsError: true}, nil
+		}
+	}
+
+	if !p.projects.ProjectExists(project) {
+		return &toolspec.Result{ForLLM: fmt.Sprintf("project not found: %s", project), IsError: true}, nil
+	}
+
+	events, err := p.projects.GetEvents(project)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	matched := make([]global.TaskEvent, 0, len(events))
+	for _, e := range events {
+		if path != "" && !strings.HasPrefix(e.Path, path) {
+			continue
+		}
+		if taskID >= 0 && e.TaskID != taskID {
+			continue
+		}
+		if event != "" && e.Event != event {
+			continue
+		}
+		// Strictly after, so the last timestamp seen can be passed back to poll for new events
+		if !since.IsZero() && !e.Timestamp.After(since) {
+			continue
+		}
+		matched = append(matched, e)
+	}
+
+	result := &global.TaskEvents{Project: project, Matched: len(matched), Events: matched}
+	if limit > 0 && len(matched) > limit {
+		result.Events = matched[len(matched)-limit:]
+	}
+
+	return createJSONResult(result)
+}
+
+// handleTaskInflight handles the task_inflight MCP tool
+func (p *Provider) handleTaskInflight(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	project := parseString(call.Args, "project", "")
+
+	p.logToolCall(global.ToolTaskInflight, map[string]string{"project": project})
+
+	inflight := make([]global.InflightTask, 0)
+	for _, t := range p.runner.InflightTasks() {
+		if project == "" || t.Project == project {
+			inflight = append(inflight, t)
+		}
+	}
+
+	return createJSONResult(map[string]interface{}{
+		"count": len(inflight),
+		"tasks": inflight,
+	})
+}
+
 // handleTaskResults handles the task_results MCP tool
 func (p *Provider) handleTaskResults(call *toolspec.ToolCall) (*toolspec.Result, error) {
 	project := parseString(call.Args, "project", "")
@@ -80,6 +177,7 @@ func (p *Provider) handleTaskResults(call *toolspec.ToolCall) (*toolspec.Result,
 	taskID := int(parseFloat64(call.Args, "task_id", -1))
 	offset := int(parseFloat64(call.Args, "offset", 0))
 	limit := int(parseFloat64(call.Args, "limit", float64(global.DefaultLimit)))
+	cursor := parseString(call.Args, "cursor", "")
 	summary := parseBool(call.Args, "summary", false)
 	workerPattern := parseString(call.Args, "worker_pattern", "")
 	qaPattern := parseString(call.Args, "qa_pattern", "")
@@ -96,6 +194,7 @@ func (p *Provider) handleTaskResults(call *toolspec.ToolCall) (*toolspec.Result,
 		Status:        status,
 		Offset:        offset,
 		Limit:         limit,
+		Cursor:        cursor,
 		Summary:       summary,
 		WorkerPattern: workerPattern,
 		QAPattern:     qaPattern,
@@ -119,8 +218,9 @@ func (p *Provider) handleTaskResults(call *toolspec.ToolCall) (*toolspec.Result,
 func (p *Provider) handleTaskResultGet(call *toolspec.ToolCall) (*toolspec.Result, error) {
 	project := parseString(call.Args, "project", "")
 	uuid := parseString(call.Args, "uuid", "")
+	snapshot := parseString(call.Args, "snapshot", "")
 
-	p.logToolCall(global.ToolTaskResultGet, map[string]string{"project": project, "uuid": uuid})
+	p.logToolCall(global.ToolTaskResultGet, map[string]string{"project": project, "uuid": uuid, "snapshot": snapshot})
 
 	if project == "" {
 		return nil, fmt.Errorf("%s", "project is required")
@@ -150,17 +250,31 @@ func (p *Provider) handleTaskResultGet(call *toolspec.ToolCall) (*toolspec.Resul
 		// If loading fails, we just leave schemaContent empty - not critical
 	}
 
-	// Load result file
-	resultsDir := p.tasks.GetResultsDir(project)
-	resultPath := filepath.Join(resultsDir, uuid+".json")
+	// Load result file, from the same place in the snapshot if one is given
+	resultPath := p.tasks.ResultFile(project, taskPath, task, global.ResultFileSuffix)
+	if snapshot != "" {
+		snapshotResults, err := p.projects.SnapshotResultsDir(project, snapshot)
+		if err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+		rel, err := filepath.Rel(p.tasks.GetResultsDir(project), resultPath)
+		if err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(fmt.Sprintf("failed to locate result file: %v", err)), IsError: true}, nil
+		}
+		resultPath = filepath.Join(snapshotResults, rel)
+	}
 
 	data, err := os.ReadFile(resultPath)
 	if err != nil {
+		if os.IsNotExist(err) && snapshot != "" {
+			return &toolspec.Result{ForLLM: fmt.Sprintf("task %s has no result in snapshot %s", uuid, snapshot), IsError: true}, nil
+		}
 		if os.IsNotExist(err) {
 			// Task exists but no result yet - return basic info with empty responses
 			response := global.TaskResultGetResponse{
 				TaskID:                 task.ID,
 				TaskUUID:               task.UUID,
+				ExternalID:             task.ExternalID,
 				TaskTitle:              task.Title,
 				TaskType:               task.Type,
 				TaskPath:               taskPath,
@@ -185,6 +299,7 @@ func (p *Provider) handleTaskResultGet(call *toolspec.ToolCall) (*toolspec.Resul
 	response := global.TaskResultGetResponse{
 		TaskID:                 taskResult.TaskID,
 		TaskUUID:               taskResult.TaskUUID,
+		ExternalID:             task.ExternalID,
 		TaskTitle:              taskResult.TaskTitle,
 		TaskType:               taskResult.TaskType,
 		TaskPath:               taskPath,
@@ -196,6 +311,7 @@ func (p *Provider) handleTaskResultGet(call *toolspec.ToolCall) (*toolspec.Resul
 		WorkerErrorCode:        taskResult.Worker.ErrorCode,
 		QAEnabled:              task.QA.Enabled,
 		SupervisorOverride:     taskResult.SupervisorOverride,
+		SupervisorEdits:        taskResult.SupervisorEdits,
 		CompletedAt:            taskResult.CompletedAt,
 	}
 
@@ -210,6 +326,36 @@ func (p *Provider) handleTaskResultGet(call *toolspec.ToolCall) (*toolspec.Resul
 	return createJSONResult(response)
 }
 
+// handleTaskAttemptDiff handles the task_attempt_diff MCP tool
+func (p *Provider) handleTaskAttemptDiff(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	project := parseString(call.Args, "project", "")
+	uuid := parseString(call.Args, "uuid", "")
+	role := parseString(call.Args, "role", "worker")
+	from := int(parseFloat64(call.Args, "from", 0))
+	to := int(parseFloat64(call.Args, "to", 0))
+
+	p.logToolCall(global.ToolTaskAttemptDiff, map[string]string{
+		"project": project,
+		"uuid":    uuid,
+		"role":    role,
+		"from":    fmt.Sprint(from),
+		"to":      fmt.Sprint(to),
+	})
+
+	if project == "" {
+		return nil, fmt.Errorf("%s", "project is required")
+	}
+	if uuid == "" {
+		return nil, fmt.Errorf("%s", "uuid is required")
+	}
+
+	diff, err := p.runner.DiffAttempts(project, uuid, role, from, to)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+	return createJSONResult(diff)
+}
+
 // handleTaskReport handles the task_report MCP tool
 func (p *Provider) handleTaskReport(call *toolspec.ToolCall) (*toolspec.Result, error) {
 	project := parseString(call.Args, "project", "")
@@ -217,6 +363,7 @@ func (p *Provider) handleTaskReport(call *toolspec.ToolCall) (*toolspec.Result,
 	status := parseString(call.Args, "status", "")
 	taskType := parseString(call.Args, "type", "")
 	qaVerdict := parseString(call.Args, "qa_verdict", "")
+	qaSeverity := parseString(call.Args, "qa_severity", "")
 	format := parseString(call.Args, "format", "markdown")
 	outputPath := parseString(call.Args, "output", "")
 
@@ -225,14 +372,21 @@ func (p *Provider) handleTaskReport(call *toolspec.ToolCall) (*toolspec.Result,
 	if project == "" {
 		return nil, fmt.Errorf("%s", "project is required")
 	}
+	if format == global.ReportFormatPDF && outputPath == "" {
+		return nil, fmt.Errorf("%s", "output is required for pdf format")
+	}
+	if err := global.ValidateQASeverity(qaSeverity); err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
 
 	// Build filter
 	var filter *reporting.ReportFilter
-	if path != "" || status != "" || qaVerdict != "" || taskType != "" {
+	if path != "" || status != "" || qaVerdict != "" || qaSeverity != "" || taskType != "" {
 		filter = &reporting.ReportFilter{
 			PathPrefix:   path,
 			StatusFilter: status,
 			QAVerdict:    qaVerdict,
+			QASeverity:   qaSeverity,
 		}
 
 		// Handle type filter
@@ -284,6 +438,14 @@ func (p *Provider) handleTaskReport(call *toolspec.ToolCall) (*toolspec.Result,
 		reporting.WithPlaybookLoader(playbookLoader),
 		reporting.WithReferenceLoader(referenceLoader),
 		reporting.WithProjectLoader(projectLoader),
+		reporting.WithReportLanguage(p.config.ReportLanguage()),
+		reporting.WithAttribution(p.config.ReportAttribution()),
+		reporting.WithSanitization(p.config.OutputSanitization()),
+		reporting.WithClock(p.config.Clock()),
+		reporting.WithOutput(p.config.ReportOutput()),
+		reporting.WithResultLocator(func(project, path string, task *global.Task) string {
+			return p.tasks.ResultFile(project, path, task, global.ResultFileSuffix)
+		}),
 	)
 	report := reporter.BuildReport(project, taskSetList.TaskSets, filter, resultsDir)
 
@@ -294,6 +456,13 @@ func (p *Provider) handleTaskReport(call *toolspec.ToolCall) (*toolspec.Result,
 		content, err = reporter.GenerateJSON(report)
 	case "markdown", "md":
 		content, err = reporter.GenerateHierarchicalMarkdown(report)
+	case global.ReportFormatHTML, global.ReportFormatPDF:
+		content, err = reporter.GenerateHierarchicalMarkdown(report)
+		if err == nil {
+			var rendered []byte
+			rendered, err = reporter.Render(content, format, "")
+			content = string(rendered)
+		}
 	default:
 		content, err = reporter.GenerateHierarchicalMarkdown(report)
 	}
@@ -309,5 +478,60 @@ func (p *Provider) handleTaskReport(call *toolspec.ToolCall) (*toolspec.Result,
 		}
 	}
 
+	// A PDF is binary, so only where it was saved is returned
+	if format == global.ReportFormatPDF {
+		return createJSONResult(map[string]interface{}{
+			"project":    project,
+			"format":     format,
+			"output":     outputPath,
+			"size_bytes": len(content),
+		})
+	}
+
 	return &toolspec.Result{ForLLM: content}, nil
 }
+
+// handleTaskEvidenceRequests handles the task_evidence_requests MCP tool
+func (p *Provider) handleTaskEvidenceRequests(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	project := parseString(call.Args, "project", "")
+	path := parseString(call.Args, "path", "")
+	outputFile := parseString(call.Args, "output_file", "")
+	followUpPath := parseString(call.Args, "followup_path", "")
+	followUpPrompt := parseString(call.Args, "followup_prompt", "")
+	followUpLLM := parseString(call.Args, "followup_llm_model_id", "")
+
+	p.logToolCall(global.ToolTaskEvidence, map[string]string{
+		"project":       project,
+		"path":          path,
+		"output_file":   outputFile,
+		"followup_path": followUpPath,
+	})
+
+	if project == "" {
+		return nil, fmt.Errorf("%s", "project is required")
+	}
+
+	list, err := p.runner.CollectEvidenceRequests(project, path)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	if outputFile != "" {
+		content := runner.FormatEvidenceRequests(list, p.config.Clock())
+		if _, err := p.projects.PutFile(project, outputFile, content, "Consolidated evidence requests"); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprintf("failed to write %s: %v", outputFile, err), IsError: true}, nil
+		}
+		list.File = outputFile
+	}
+
+	if followUpPath != "" {
+		created, err := p.runner.CreateEvidenceFollowUps(project, followUpPath, followUpPrompt, followUpLLM, list)
+		if err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+		list.FollowUpPath = followUpPath
+		list.TasksCreated = created
+	}
+
+	return createJSONResult(list)
+}
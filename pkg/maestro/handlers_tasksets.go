@@ -8,11 +8,14 @@ package maestro
 import (
 	"github.com/PivotLLM/toolspec"
 
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/PivotLLM/Maestro/global"
 	"github.com/PivotLLM/Maestro/runner"
+	"github.com/PivotLLM/Maestro/tasks"
 	templatespkg "github.com/PivotLLM/Maestro/templates"
 )
 
@@ -25,6 +28,7 @@ func (p *Provider) handleTaskSetCreate(call *toolspec.ToolCall) (*toolspec.Resul
 	parallel := parseBool(call.Args, "parallel", false)
 	maxWorker := int(parseFloat64(call.Args, "max_worker", 0))
 	maxQA := int(parseFloat64(call.Args, "max_qa", 0))
+	maxCostUSD := parseFloat64(call.Args, "max_cost_usd", 0)
 	workerResponseTemplate := parseString(call.Args, "worker_response_template", "")
 	workerReportTemplate := parseString(call.Args, "worker_report_template", "")
 	qaResponseTemplate := parseString(call.Args, "qa_response_template", "")
@@ -58,6 +62,10 @@ func (p *Provider) handleTaskSetCreate(call *toolspec.ToolCall) (*toolspec.Resul
 		}
 		limits.MaxQA = validated
 	}
+	if maxCostUSD < 0 {
+		return &toolspec.Result{ForLLM: fmt.Sprint("max_cost_usd must not be negative"), IsError: true}, nil
+	}
+	limits.MaxCostUSD = maxCostUSD
 
 	// Build templates if any are provided
 	var templates *global.DefaultTemplates
@@ -70,11 +78,16 @@ func (p *Provider) handleTaskSetCreate(call *toolspec.ToolCall) (*toolspec.Resul
 		}
 	}
 
-	// Validate QA response schema if provided
+	qaFields, err := parseQAFieldMap(parseString(call.Args, "qa_fields", ""))
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	// Validate QA response schema if provided, against the QA field map if any
 	if qaResponseTemplate != "" {
 		schemaContent := p.loadSchemaContent(qaResponseTemplate)
 		if schemaContent != "" {
-			if err := templatespkg.ValidateQASchema(schemaContent); err != nil {
+			if err := templatespkg.ValidateQASchemaWith(schemaContent, qaFields); err != nil {
 				return &toolspec.Result{ForLLM: fmt.Sprint("invalid qa_response_template: " + err.Error()), IsError: true}, nil
 			}
 		}
@@ -83,11 +96,104 @@ func (p *Provider) handleTaskSetCreate(call *toolspec.ToolCall) (*toolspec.Resul
 	skipValidation := parseBool(call.Args, "skip_validation", false)
 	callbackURL := parseString(call.Args, "callback_url", "")
 
-	taskSet, err := p.tasks.CreateTaskSet(project, path, title, description, templates, parallel, limits, skipValidation, callbackURL)
+	outputLanguage, err := templatespkg.NormalizeLanguage(parseString(call.Args, "output_language", ""))
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	qaDefaults := parseQADefaults(call.Args, nil)
+	if qaDefaults != nil {
+		if err := p.validateInstructionsFile(project, qaDefaults.InstructionsFile, qaDefaults.InstructionsFileSource); err != nil {
+			return errorResult(fmt.Errorf("QA %w", err)), nil
+		}
+	}
+
+	qaSkipRules, err := parseQASkipRules(parseString(call.Args, "qa_skip_rules", ""))
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	resultSummary, err := parseResultSummary(parseString(call.Args, "result_summary", ""))
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	qaHaltSeverity := parseString(call.Args, "qa_halt_severity", "")
+	if err := global.ValidateQASeverity(qaHaltSeverity); err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	approval, err := parseApprovalGate(parseString(call.Args, "approval", ""))
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	revisionPromptTemplate := parseString(call.Args, "revision_prompt_template", "")
+	if err := p.validateRevisionPromptTemplate(project, revisionPromptTemplate); err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	workerTools, err := parseWorkerTools(parseString(call.Args, "worker_tools", ""))
 	if err != nil {
 		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
 	}
 
+	taskSet, err := p.tasks.CreateTaskSet(project, path, title, description, templates, parallel, limits, skipValidation, callbackURL, outputLanguage, qaDefaults)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	if len(qaSkipRules) > 0 {
+		if err := p.tasks.SetTaskSetQASkipRules(project, path, qaSkipRules); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprintf("task set created but qa_skip_rules were not set: %v", err), IsError: true}, nil
+		}
+		taskSet.QASkipRules = qaSkipRules
+	}
+
+	if resultSummary != nil {
+		if err := p.tasks.SetTaskSetResultSummary(project, path, resultSummary); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprintf("task set created but result_summary was not set: %v", err), IsError: true}, nil
+		}
+		taskSet.ResultSummary = resultSummary
+	}
+
+	if qaHaltSeverity != "" {
+		if err := p.tasks.SetTaskSetQAHaltSeverity(project, path, qaHaltSeverity); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprintf("task set created but qa_halt_severity was not set: %v", err), IsError: true}, nil
+		}
+		taskSet.QAHaltSeverity = global.NormalizeQASeverity(qaHaltSeverity)
+	}
+
+	if approval != nil {
+		if err := p.tasks.SetTaskSetApproval(project, path, approval); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprintf("task set created but approval was not set: %v", err), IsError: true}, nil
+		}
+		if taskSet, err = p.tasks.GetTaskSet(project, path); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+	}
+
+	if qaFields != nil {
+		if err := p.tasks.SetTaskSetQAFields(project, path, qaFields); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprintf("task set created but qa_fields were not set: %v", err), IsError: true}, nil
+		}
+		taskSet.QAFields = qaFields
+	}
+
+	if revisionPromptTemplate != "" {
+		if err := p.tasks.SetTaskSetRevisionPromptTemplate(project, path, revisionPromptTemplate); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprintf("task set created but revision_prompt_template was not set: %v", err), IsError: true}, nil
+		}
+		taskSet.RevisionPromptTemplate = revisionPromptTemplate
+	}
+
+	if workerTools != nil {
+		if err := p.tasks.SetTaskSetWorkerTools(project, path, workerTools); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprintf("task set created but worker_tools were not set: %v", err), IsError: true}, nil
+		}
+		taskSet.WorkerTools = workerTools
+	}
+
 	return createJSONResult(taskSet)
 }
 
@@ -141,6 +247,7 @@ func (p *Provider) handleTaskSetUpdate(call *toolspec.ToolCall) (*toolspec.Resul
 	parallelStr := parseString(call.Args, "parallel", "")
 	maxWorkerVal := int(parseFloat64(call.Args, "max_worker", -1))
 	maxQAVal := int(parseFloat64(call.Args, "max_qa", -1))
+	maxCostUSD := parseFloat64(call.Args, "max_cost_usd", -1)
 	workerResponseTemplate := parseString(call.Args, "worker_response_template", "")
 	workerReportTemplate := parseString(call.Args, "worker_report_template", "")
 	qaResponseTemplate := parseString(call.Args, "qa_response_template", "")
@@ -169,9 +276,13 @@ func (p *Provider) handleTaskSetUpdate(call *toolspec.ToolCall) (*toolspec.Resul
 		parallel = &parallelVal
 	}
 
-	// Handle limits updates
-	if maxWorkerVal >= 0 || maxQAVal >= 0 {
-		limits = &global.Limits{}
+	// Handle limits updates; limits that are not given keep their current values
+	if maxWorkerVal >= 0 || maxQAVal >= 0 || maxCostUSD >= 0 {
+		current, err := p.tasks.GetTaskSet(project, path)
+		if err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+		limits = &current.Limits
 		if maxWorkerVal >= 0 {
 			validated, err := global.ValidateMaxWorker(maxWorkerVal)
 			if err != nil {
@@ -186,6 +297,9 @@ func (p *Provider) handleTaskSetUpdate(call *toolspec.ToolCall) (*toolspec.Resul
 			}
 			limits.MaxQA = validated
 		}
+		if maxCostUSD >= 0 {
+			limits.MaxCostUSD = maxCostUSD
+		}
 	}
 
 	// Build templates if any are provided
@@ -199,11 +313,26 @@ func (p *Provider) handleTaskSetUpdate(call *toolspec.ToolCall) (*toolspec.Resul
 		}
 	}
 
-	// Validate QA response schema if being updated
-	if qaResponseTemplate != "" {
-		schemaContent := p.loadSchemaContent(qaResponseTemplate)
-		if schemaContent != "" {
-			if err := templatespkg.ValidateQASchema(schemaContent); err != nil {
+	qaFieldsStr := parseString(call.Args, "qa_fields", "")
+	qaFields, err := parseQAFieldMap(qaFieldsStr)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	// Validate QA response schema if it or the QA field map is being updated,
+	// taking whichever is not from the current task set
+	if qaResponseTemplate != "" || qaFieldsStr != "" {
+		schemaTemplate, fields := qaResponseTemplate, qaFields
+		if current, err := p.tasks.GetTaskSet(project, path); err == nil {
+			if schemaTemplate == "" {
+				schemaTemplate = current.QAResponseTemplate
+			}
+			if qaFieldsStr == "" {
+				fields = current.QAFields
+			}
+		}
+		if schemaContent := p.loadSchemaContent(schemaTemplate); schemaContent != "" {
+			if err := templatespkg.ValidateQASchemaWith(schemaContent, fields); err != nil {
 				return &toolspec.Result{ForLLM: fmt.Sprint("invalid qa_response_template: " + err.Error()), IsError: true}, nil
 			}
 		}
@@ -224,7 +353,105 @@ func (p *Provider) handleTaskSetUpdate(call *toolspec.ToolCall) (*toolspec.Resul
 		callbackURL = &callbackURLStr
 	}
 
-	taskSet, err := p.tasks.UpdateTaskSet(project, path, title, description, templates, parallel, limits, skipValidation, callbackURL)
+	// Handle output_language update ("none" clears the taskset override)
+	var outputLanguage *string
+	outputLanguageStr := parseString(call.Args, "output_language", "")
+	if outputLanguageStr != "" {
+		normalized, err := parseOutputLanguage(outputLanguageStr)
+		if err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+		outputLanguage = &normalized
+	}
+
+	// Handle QA default updates; defaults that are not given keep their current values
+	var qaDefaults *global.QADefaults
+	if parseQADefaults(call.Args, nil) != nil {
+		current, err := p.tasks.GetTaskSet(project, path)
+		if err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+		qaDefaults = parseQADefaults(call.Args, current.QADefaults)
+		if err := p.validateInstructionsFile(project, qaDefaults.InstructionsFile, qaDefaults.InstructionsFileSource); err != nil {
+			return errorResult(fmt.Errorf("QA %w", err)), nil
+		}
+	}
+
+	// Handle qa_skip_rules update ("none" removes the rules)
+	if qaSkipRulesStr := parseString(call.Args, "qa_skip_rules", ""); qaSkipRulesStr != "" {
+		qaSkipRules, err := parseQASkipRules(qaSkipRulesStr)
+		if err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+		if err := p.tasks.SetTaskSetQASkipRules(project, path, qaSkipRules); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+	}
+
+	// Handle result_summary update ("none" restores the default)
+	if resultSummaryStr := parseString(call.Args, "result_summary", ""); resultSummaryStr != "" {
+		resultSummary, err := parseResultSummary(resultSummaryStr)
+		if err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+		if err := p.tasks.SetTaskSetResultSummary(project, path, resultSummary); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+	}
+
+	// Handle qa_halt_severity update ("none" removes the policy)
+	if qaHaltSeverity := parseString(call.Args, "qa_halt_severity", ""); qaHaltSeverity != "" {
+		if qaHaltSeverity == "none" {
+			qaHaltSeverity = ""
+		}
+		if err := p.tasks.SetTaskSetQAHaltSeverity(project, path, qaHaltSeverity); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+	}
+
+	// Handle approval update ("none" removes the gate)
+	if approvalStr := parseString(call.Args, "approval", ""); approvalStr != "" {
+		approval, err := parseApprovalGate(approvalStr)
+		if err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+		if err := p.tasks.SetTaskSetApproval(project, path, approval); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+	}
+
+	// Handle qa_fields update ("none" removes the map)
+	if qaFieldsStr != "" {
+		if err := p.tasks.SetTaskSetQAFields(project, path, qaFields); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+	}
+
+	// Handle revision_prompt_template update ("none" restores the standard QA feedback)
+	if revisionPromptTemplate := parseString(call.Args, "revision_prompt_template", ""); revisionPromptTemplate != "" {
+		if revisionPromptTemplate == "none" {
+			revisionPromptTemplate = ""
+		}
+		if err := p.validateRevisionPromptTemplate(project, revisionPromptTemplate); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+		if err := p.tasks.SetTaskSetRevisionPromptTemplate(project, path, revisionPromptTemplate); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+	}
+
+	// Handle worker_tools update ("none" turns worker tool calls off)
+	if workerToolsStr := parseString(call.Args, "worker_tools", ""); workerToolsStr != "" {
+		workerTools, err := parseWorkerTools(workerToolsStr)
+		if err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+		if err := p.tasks.SetTaskSetWorkerTools(project, path, workerTools); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+	}
+
+	taskSet, err := p.tasks.UpdateTaskSet(project, path, title, description, templates, parallel, limits, skipValidation, callbackURL, outputLanguage, qaDefaults)
 	if err != nil {
 		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
 	}
@@ -320,16 +547,100 @@ func (p *Provider) handleTaskSetReset(call *toolspec.ToolCall) (*toolspec.Result
 	return createJSONResult(result)
 }
 
+// handleTaskSetCopy handles the taskset_copy MCP tool
+func (p *Provider) handleTaskSetCopy(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	project := parseString(call.Args, "project", "")
+	path := parseString(call.Args, "path", "")
+	targetProject := parseString(call.Args, "target_project", project)
+	targetPath := parseString(call.Args, "target_path", path)
+	title := parseString(call.Args, "title", "")
+	suffix := parseString(call.Args, "external_id_suffix", "")
+
+	p.logToolCall(global.ToolTaskSetCopy, map[string]string{"project": project, "path": path, "target_project": targetProject, "target_path": targetPath})
+
+	if project == "" {
+		return nil, fmt.Errorf("%s", "project is required")
+	}
+	if path == "" {
+		return nil, fmt.Errorf("%s", "path is required")
+	}
+
+	result, err := p.tasks.CopyTaskSet(project, path, targetProject, targetPath, title, suffix)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	// Project instruction files may not exist in another project
+	if targetProject != project {
+		taskSet, err := p.tasks.GetTaskSet(targetProject, targetPath)
+		if err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+		missing := make(map[string]bool)
+		check := func(file, source string) {
+			if err := p.validateInstructionsFile(targetProject, file, source); err != nil && !missing[file] {
+				missing[file] = true
+				result.Warnings = append(result.Warnings, err.Error())
+			}
+		}
+		if taskSet.QADefaults != nil {
+			check(taskSet.QADefaults.InstructionsFile, taskSet.QADefaults.InstructionsFileSource)
+		}
+		for _, task := range taskSet.Tasks {
+			check(task.Work.InstructionsFile, task.Work.InstructionsFileSource)
+			check(task.QA.InstructionsFile, task.QA.InstructionsFileSource)
+		}
+	}
+
+	return createJSONResult(result)
+}
+
+// handlePipelineApply handles the pipeline_apply MCP tool
+func (p *Provider) handlePipelineApply(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	project := parseString(call.Args, "project", "")
+	playbook := parseString(call.Args, "playbook", "")
+	file := parseString(call.Args, "file", "")
+	dryRun := parseBool(call.Args, "dry_run", false)
+	prune := parseBool(call.Args, "prune", false)
+
+	p.logToolCall(global.ToolPipelineApply, map[string]string{"project": project, "playbook": playbook, "file": file, "dry_run": fmt.Sprintf("%t", dryRun), "prune": fmt.Sprintf("%t", prune)})
+
+	if project == "" {
+		return nil, fmt.Errorf("%s", "project is required")
+	}
+	if playbook == "" {
+		return nil, fmt.Errorf("%s", "playbook is required")
+	}
+
+	result, err := p.runner.ApplyPipeline(project, playbook, file, dryRun, prune)
+	if err != nil {
+		return errorResult(err), nil
+	}
+
+	return createJSONResult(result)
+}
+
 // handleTaskCreate handles the task_create MCP tool
 func (p *Provider) handleTaskCreate(call *toolspec.ToolCall) (*toolspec.Result, error) {
 	project := parseString(call.Args, "project", "")
 	path := parseString(call.Args, "path", "")
 	title := parseString(call.Args, "title", "")
 	taskType := parseString(call.Args, "type", "")
+	externalID := parseString(call.Args, "external_id", "")
+	dependsOn := parseDependsOn(parseString(call.Args, "depends_on", ""))
+	envStr := parseString(call.Args, "env", "")
+	limits := &global.TaskLimits{
+		MaxWorker:  int(parseFloat64(call.Args, "max_worker", 0)),
+		MaxQA:      int(parseFloat64(call.Args, "max_qa", 0)),
+		MaxRetries: int(parseFloat64(call.Args, "max_retries", 0)),
+		Timeout:    int(parseFloat64(call.Args, "timeout", 0)),
+	}
+	priority := int(parseFloat64(call.Args, "priority", 0))
 	instructionsFile := parseString(call.Args, "instructions_file", "")
 	instructionsFileSource := parseString(call.Args, "instructions_file_source", "")
 	instructionsText := parseString(call.Args, "instructions_text", "")
 	prompt := parseString(call.Args, "prompt", "")
+	evidenceFiles := parseEvidenceFiles(parseString(call.Args, "evidence_files", ""))
 	llmModelID := parseString(call.Args, "llm_model_id", "")
 	qaEnabled := parseBool(call.Args, "qa_enabled", false)
 	qaInstructionsFile := parseString(call.Args, "qa_instructions_file", "")
@@ -350,15 +661,35 @@ func (p *Provider) handleTaskCreate(call *toolspec.ToolCall) (*toolspec.Result,
 		return nil, fmt.Errorf("%s", "title is required")
 	}
 
+	// Dependencies must name existing tasks; a new task cannot close a cycle
+	if len(dependsOn) > 0 {
+		if err := p.tasks.ValidateDependencies(project, "", dependsOn); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+	}
+	env, err := parseTaskEnv(envStr)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+	if err := limits.Validate(); err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+	if err := global.ValidateTaskPriority(priority); err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+	if err := global.ValidateEvidenceFiles(evidenceFiles); err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
 	// Validate instructions files exist before creating task
 	if instructionsFile != "" {
 		if err := p.validateInstructionsFile(project, instructionsFile, instructionsFileSource); err != nil {
-			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+			return errorResult(err), nil
 		}
 	}
 	if qaEnabled && qaInstructionsFile != "" {
 		if err := p.validateInstructionsFile(project, qaInstructionsFile, qaInstructionsFileSource); err != nil {
-			return &toolspec.Result{ForLLM: fmt.Sprint(fmt.Sprintf("QA %s", err.Error())), IsError: true}, nil
+			return errorResult(fmt.Errorf("QA %w", err)), nil
 		}
 	}
 
@@ -367,6 +698,7 @@ func (p *Provider) handleTaskCreate(call *toolspec.ToolCall) (*toolspec.Result,
 		InstructionsFileSource: instructionsFileSource,
 		InstructionsText:       instructionsText,
 		Prompt:                 prompt,
+		EvidenceFiles:          evidenceFiles,
 		LLMModelID:             llmModelID,
 		Status:                 global.ExecutionStatusWaiting,
 	}
@@ -383,14 +715,226 @@ func (p *Provider) handleTaskCreate(call *toolspec.ToolCall) (*toolspec.Result,
 		}
 	}
 
-	task, err := p.tasks.CreateTask(project, path, title, taskType, work, qa)
+	task, err := p.tasks.CreateTask(project, path, title, taskType, externalID, work, qa)
 	if err != nil {
 		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
 	}
 
+	if len(dependsOn) > 0 {
+		if task, err = p.tasks.UpdateTask(project, task.UUID, map[string]interface{}{"depends_on": dependsOn}); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprintf("task created but depends_on was not set: %v", err), IsError: true}, nil
+		}
+	}
+	if len(env) > 0 {
+		if task, err = p.tasks.UpdateTask(project, task.UUID, map[string]interface{}{"env": env}); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprintf("task created but env was not set: %v", err), IsError: true}, nil
+		}
+	}
+	if !limits.IsEmpty() {
+		if task, err = p.tasks.UpdateTask(project, task.UUID, map[string]interface{}{"limits": limits}); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprintf("task created but its limits were not set: %v", err), IsError: true}, nil
+		}
+	}
+	if priority != 0 {
+		if task, err = p.tasks.UpdateTask(project, task.UUID, map[string]interface{}{"priority": priority}); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprintf("task created but its priority was not set: %v", err), IsError: true}, nil
+		}
+	}
+
 	return createJSONResult(task)
 }
 
+// handleTaskCreateFromTemplate handles the task_create_from_template MCP tool
+func (p *Provider) handleTaskCreateFromTemplate(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	project := parseString(call.Args, "project", "")
+	playbook := parseString(call.Args, "playbook", "")
+	template := parseString(call.Args, "template", "")
+	path := parseString(call.Args, "path", "")
+	title := parseString(call.Args, "title", "")
+	externalID := parseString(call.Args, "external_id", "")
+	variablesStr := parseString(call.Args, "variables", "")
+	dependsOn := parseDependsOn(parseString(call.Args, "depends_on", ""))
+
+	p.logToolCall(global.ToolTaskCreateFromTemplate, map[string]string{"project": project, "playbook": playbook, "template": template, "path": path})
+
+	if project == "" {
+		return nil, fmt.Errorf("%s", "project is required")
+	}
+	if playbook == "" {
+		return nil, fmt.Errorf("%s", "playbook is required")
+	}
+	if template == "" {
+		return nil, fmt.Errorf("%s", "template is required")
+	}
+
+	variables := map[string]string{}
+	if variablesStr != "" {
+		if err := json.Unmarshal([]byte(variablesStr), &variables); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprintf("variables must be a JSON object of string values: %v", err), IsError: true}, nil
+		}
+	}
+	if len(dependsOn) > 0 {
+		if err := p.tasks.ValidateDependencies(project, "", dependsOn); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+	}
+
+	tmpl, err := p.runner.LoadTaskTemplate(playbook, template)
+	if err != nil {
+		return errorResult(err), nil
+	}
+	tmpl, err = tmpl.Instantiate(variables)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	// Validate instructions files exist before creating the task
+	if err := p.validateInstructionsFile(project, tmpl.InstructionsFile, tmpl.InstructionsFileSource); err != nil {
+		return errorResult(err), nil
+	}
+	if tmpl.QA != nil && tmpl.QA.Enabled {
+		if err := p.validateInstructionsFile(project, tmpl.QA.InstructionsFile, tmpl.QA.InstructionsFileSource); err != nil {
+			return errorResult(fmt.Errorf("QA %w", err)), nil
+		}
+	}
+
+	task, err := p.runner.CreateTaskFromTemplate(project, tmpl, path, title, externalID)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+	if len(dependsOn) > 0 {
+		if task, err = p.tasks.UpdateTask(project, task.UUID, map[string]interface{}{"depends_on": dependsOn}); err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprintf("task created but depends_on was not set: %v", err), IsError: true}, nil
+		}
+	}
+
+	return createJSONResult(task)
+}
+
+// handleTaskCreateBulk handles the task_create_bulk MCP tool
+func (p *Provider) handleTaskCreateBulk(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	project := parseString(call.Args, "project", "")
+	path := parseString(call.Args, "path", "")
+	tasksStr := parseString(call.Args, "tasks", "")
+	file := parseString(call.Args, "file", "")
+	dryRun := parseBool(call.Args, "dry_run", false)
+
+	p.logToolCall(global.ToolTaskCreateBulk, map[string]string{"project": project, "path": path, "file": file, "dry_run": fmt.Sprintf("%t", dryRun)})
+
+	if project == "" {
+		return nil, fmt.Errorf("%s", "project is required")
+	}
+	if path == "" {
+		return nil, fmt.Errorf("%s", "path is required")
+	}
+	if (tasksStr == "") == (file == "") {
+		return nil, fmt.Errorf("%s", "exactly one of tasks or file is required")
+	}
+
+	source := "tasks"
+	if file != "" {
+		item, err := p.projects.GetFile(project, file, 0, 0)
+		if err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+		tasksStr, source = item.Content, file
+	}
+	defs, err := parseTaskDefinitions(tasksStr)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprintf("%s: %v", source, err), IsError: true}, nil
+	}
+
+	// Instruction files are checked here; the rest by the task service, which
+	// creates nothing unless every definition is valid
+	var fileErrors []global.BulkTaskError
+	for i, def := range defs {
+		err := p.validateInstructionsFile(project, def.InstructionsFile, def.InstructionsFileSource)
+		if err == nil && def.QAEnabled {
+			if err = p.validateInstructionsFile(project, def.QAInstructionsFile, def.QAInstructionsFileSource); err != nil {
+				err = fmt.Errorf("QA %w", err)
+			}
+		}
+		if err != nil {
+			fileErrors = append(fileErrors, global.BulkTaskError{Index: i, Title: def.Title, Error: err.Error()})
+		}
+	}
+
+	result, err := p.tasks.CreateTasks(project, path, defs, dryRun || len(fileErrors) > 0)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+	if len(fileErrors) > 0 {
+		result.DryRun = dryRun
+		result.Tasks = []global.BulkCreatedTask{}
+		result.Errors = mergeBulkTaskErrors(fileErrors, result.Errors)
+	}
+	return createJSONResult(result)
+}
+
+// handleTaskCreateMapReduce handles the task_create_mapreduce MCP tool
+func (p *Provider) handleTaskCreateMapReduce(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	project := parseString(call.Args, "project", "")
+	path := parseString(call.Args, "path", "")
+	def := &global.MapReduceDefinition{
+		File:         parseString(call.Args, "file", ""),
+		ChunkSize:    int(parseFloat64(call.Args, "chunk_size", 0)),
+		Title:        parseString(call.Args, "title", ""),
+		MapPrompt:    parseString(call.Args, "map_prompt", ""),
+		ReducePrompt: parseString(call.Args, "reduce_prompt", ""),
+		LLMModelID:   parseString(call.Args, "llm_model_id", ""),
+	}
+
+	p.logToolCall(global.ToolTaskCreateMapReduce, map[string]string{"project": project, "path": path, "file": def.File, "chunk_size": fmt.Sprintf("%d", def.ChunkSize)})
+
+	if project == "" {
+		return nil, fmt.Errorf("%s", "project is required")
+	}
+	if path == "" {
+		return nil, fmt.Errorf("%s", "path is required")
+	}
+	if def.File == "" {
+		return nil, fmt.Errorf("%s", "file is required")
+	}
+	if def.Title == "" {
+		return nil, fmt.Errorf("%s", "title is required")
+	}
+	if def.MapPrompt == "" || def.ReducePrompt == "" {
+		return nil, fmt.Errorf("%s", "map_prompt and reduce_prompt are required")
+	}
+
+	result, err := p.runner.CreateMapReduce(project, path, def)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+	return createJSONResult(result)
+}
+
+// parseTaskDefinitions parses the task definitions of task_create_bulk, a JSON array
+func parseTaskDefinitions(value string) ([]global.TaskDefinition, error) {
+	var defs []global.TaskDefinition
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&defs); err != nil {
+		return nil, fmt.Errorf("must be a JSON array of task definitions: %v", err)
+	}
+	return defs, nil
+}
+
+// mergeBulkTaskErrors combines two lists of bulk creation errors sorted by
+// index, keeping the first error of each definition
+func mergeBulkTaskErrors(a, b []global.BulkTaskError) []global.BulkTaskError {
+	seen := make(map[int]bool, len(a)+len(b))
+	var merged []global.BulkTaskError
+	for _, e := range append(append([]global.BulkTaskError{}, a...), b...) {
+		if !seen[e.Index] {
+			seen[e.Index] = true
+			merged = append(merged, e)
+		}
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Index < merged[j].Index })
+	return merged
+}
+
 // handleTaskGet handles the task_get MCP tool
 func (p *Provider) handleTaskGet(call *toolspec.ToolCall) (*toolspec.Result, error) {
 	project := parseString(call.Args, "project", "")
@@ -451,6 +995,7 @@ func (p *Provider) handleTaskList(call *toolspec.ToolCall) (*toolspec.Result, er
 	taskType := parseString(call.Args, "type", "")
 	offset := int(parseFloat64(call.Args, "offset", 0))
 	limit := int(parseFloat64(call.Args, "limit", float64(global.DefaultLimit)))
+	cursor := parseString(call.Args, "cursor", "")
 
 	p.logToolCall(global.ToolTaskList, map[string]string{"project": project, "path": path})
 
@@ -458,7 +1003,7 @@ func (p *Provider) handleTaskList(call *toolspec.ToolCall) (*toolspec.Result, er
 		return nil, fmt.Errorf("%s", "project is required")
 	}
 
-	result, err := p.tasks.ListTasks(project, path, status, taskType, limit, offset)
+	result, err := p.tasks.ListTasks(project, path, status, taskType, limit, offset, cursor)
 	if err != nil {
 		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
 	}
@@ -472,21 +1017,19 @@ func (p *Provider) handleTaskUpdate(call *toolspec.ToolCall) (*toolspec.Result,
 	taskUUID := parseString(call.Args, "uuid", "")
 	title := parseString(call.Args, "title", "")
 	taskType := parseString(call.Args, "type", "")
-	workStatus := parseString(call.Args, "work_status", "")
+	dependsOnStr := parseString(call.Args, "depends_on", "")
+	envStr := parseString(call.Args, "env", "")
+	maxWorker := int(parseFloat64(call.Args, "max_worker", -1))
+	maxQA := int(parseFloat64(call.Args, "max_qa", -1))
+	maxRetries := int(parseFloat64(call.Args, "max_retries", -1))
+	timeout := int(parseFloat64(call.Args, "timeout", -1))
+	priority := int(parseFloat64(call.Args, "priority", -1))
+	evidenceFilesStr := parseString(call.Args, "evidence_files", "")
 
-	// Work execution fields
 	instructionsFile := parseString(call.Args, "instructions_file", "")
 	instructionsFileSource := parseString(call.Args, "instructions_file_source", "")
-	instructionsText := parseString(call.Args, "instructions_text", "")
-	prompt := parseString(call.Args, "prompt", "")
-	llmModelID := parseString(call.Args, "llm_model_id", "")
-
-	// QA execution fields
 	qaInstructionsFile := parseString(call.Args, "qa_instructions_file", "")
 	qaInstructionsFileSource := parseString(call.Args, "qa_instructions_file_source", "")
-	qaInstructionsText := parseString(call.Args, "qa_instructions_text", "")
-	qaPrompt := parseString(call.Args, "qa_prompt", "")
-	qaLLMModelID := parseString(call.Args, "qa_llm_model_id", "")
 
 	p.logToolCall(global.ToolTaskUpdate, map[string]string{"project": project, "uuid": taskUUID})
 
@@ -500,12 +1043,12 @@ func (p *Provider) handleTaskUpdate(call *toolspec.ToolCall) (*toolspec.Result,
 	// Validate instructions files if being updated
 	if instructionsFile != "" {
 		if err := p.validateInstructionsFile(project, instructionsFile, instructionsFileSource); err != nil {
-			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+			return errorResult(err), nil
 		}
 	}
 	if qaInstructionsFile != "" {
 		if err := p.validateInstructionsFile(project, qaInstructionsFile, qaInstructionsFileSource); err != nil {
-			return &toolspec.Result{ForLLM: fmt.Sprint(fmt.Sprintf("QA %s", err.Error())), IsError: true}, nil
+			return errorResult(fmt.Errorf("QA %w", err)), nil
 		}
 	}
 
@@ -516,50 +1059,61 @@ func (p *Provider) handleTaskUpdate(call *toolspec.ToolCall) (*toolspec.Result,
 	if taskType != "" {
 		updates["type"] = taskType
 	}
-	if workStatus != "" {
-		updates["work_status"] = workStatus
-	}
-
-	// Work execution updates
-	workUpdates := make(map[string]interface{})
-	if instructionsFile != "" {
-		workUpdates["instructions_file"] = instructionsFile
-	}
-	if instructionsFileSource != "" {
-		workUpdates["instructions_file_source"] = instructionsFileSource
-	}
-	if instructionsText != "" {
-		workUpdates["instructions_text"] = instructionsText
-	}
-	if prompt != "" {
-		workUpdates["prompt"] = prompt
-	}
-	if llmModelID != "" {
-		workUpdates["llm_model_id"] = llmModelID
+	if dependsOnStr == "none" {
+		updates["depends_on"] = []string{}
+	} else if dependsOnStr != "" {
+		updates["depends_on"] = parseDependsOn(dependsOnStr)
 	}
-	if len(workUpdates) > 0 {
-		updates["work"] = workUpdates
+	if envStr != "" {
+		env, err := parseTaskEnv(envStr)
+		if err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+		updates["env"] = env
 	}
 
-	// QA execution updates
-	qaUpdates := make(map[string]interface{})
-	if qaInstructionsFile != "" {
-		qaUpdates["instructions_file"] = qaInstructionsFile
-	}
-	if qaInstructionsFileSource != "" {
-		qaUpdates["instructions_file_source"] = qaInstructionsFileSource
-	}
-	if qaInstructionsText != "" {
-		qaUpdates["instructions_text"] = qaInstructionsText
-	}
-	if qaPrompt != "" {
-		qaUpdates["prompt"] = qaPrompt
+	// Limits that are not given keep their current values; 0 clears one
+	if maxWorker >= 0 || maxQA >= 0 || maxRetries >= 0 || timeout >= 0 {
+		current, _, err := p.tasks.GetTask(project, taskUUID)
+		if err != nil {
+			return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+		}
+		limits := &global.TaskLimits{}
+		if current.Limits != nil {
+			*limits = *current.Limits
+		}
+		for _, l := range []struct {
+			value  int
+			target *int
+		}{
+			{maxWorker, &limits.MaxWorker},
+			{maxQA, &limits.MaxQA},
+			{maxRetries, &limits.MaxRetries},
+			{timeout, &limits.Timeout},
+		} {
+			if l.value >= 0 {
+				*l.target = l.value
+			}
+		}
+		updates["limits"] = limits
 	}
-	if qaLLMModelID != "" {
-		qaUpdates["llm_model_id"] = qaLLMModelID
+	if priority >= 0 {
+		updates["priority"] = priority
 	}
-	if len(qaUpdates) > 0 {
-		updates["qa"] = qaUpdates
+
+	addExecutionUpdates(call.Args, updates)
+
+	// Evidence files replace the current ones; "none" clears them
+	if evidenceFilesStr != "" {
+		work, _ := updates["work"].(map[string]interface{})
+		if work == nil {
+			work = make(map[string]interface{})
+			updates["work"] = work
+		}
+		work["evidence_files"] = []string{}
+		if evidenceFilesStr != "none" {
+			work["evidence_files"] = parseEvidenceFiles(evidenceFilesStr)
+		}
 	}
 
 	task, err := p.tasks.UpdateTask(project, taskUUID, updates)
@@ -597,6 +1151,289 @@ func (p *Provider) handleTaskDelete(call *toolspec.ToolCall) (*toolspec.Result,
 	return createJSONResult(result)
 }
 
+// handleTaskBulkUpdateStatus handles the task_bulk_update_status MCP tool
+func (p *Provider) handleTaskBulkUpdateStatus(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	project := parseString(call.Args, "project", "")
+	path := parseString(call.Args, "path", "")
+	taskType := parseString(call.Args, "type", "")
+	fromStatus := parseString(call.Args, "from_status", "")
+	toStatus := parseString(call.Args, "to_status", "")
+	holdReason := parseString(call.Args, "hold_reason", "")
+	dryRun := parseBool(call.Args, "dry_run", false)
+
+	p.logToolCall(global.ToolTaskBulkStatus, map[string]string{
+		"project":     project,
+		"path":        path,
+		"type":        taskType,
+		"from_status": fromStatus,
+		"to_status":   toStatus,
+		"dry_run":     fmt.Sprintf("%t", dryRun),
+	})
+
+	if project == "" {
+		return nil, fmt.Errorf("%s", "project is required")
+	}
+	if toStatus == "" {
+		return nil, fmt.Errorf("%s", "to_status is required")
+	}
+	if path == "" && taskType == "" && fromStatus == "" {
+		return nil, fmt.Errorf("%s", "at least one filter is required: path, type, or from_status")
+	}
+
+	if !dryRun && p.runner.IsProjectRunning(project) {
+		return &toolspec.Result{ForLLM: fmt.Sprintf("a run is in progress for project %s; wait for it to finish or use dry_run", project), IsError: true}, nil
+	}
+
+	summary, err := p.tasks.BulkUpdateStatus(project, path, taskType, fromStatus, toStatus, holdReason, dryRun)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	return createJSONResult(summary)
+}
+
+// addExecutionUpdates adds the work and QA fields given in args (work_status,
+// hold_reason, the instruction and prompt fields and the LLM models) to the
+// updates of a task
+func addExecutionUpdates(args map[string]any, updates map[string]interface{}) {
+	params := []string{"instructions_file", "instructions_file_source", "instructions_text", "prompt", "llm_model_id"}
+	for _, section := range []struct {
+		key, prefix string
+	}{
+		{"work", ""},
+		{"qa", "qa_"},
+	} {
+		sectionUpdates := make(map[string]interface{})
+		if section.key == "work" {
+			if status := parseString(args, "work_status", ""); status != "" {
+				sectionUpdates["status"] = status
+			}
+			if holdReason := parseString(args, "hold_reason", ""); holdReason != "" {
+				sectionUpdates["hold_reason"] = holdReason
+			}
+		}
+		for _, param := range params {
+			if value := parseString(args, section.prefix+param, ""); value != "" {
+				sectionUpdates[param] = value
+			}
+		}
+		if len(sectionUpdates) > 0 {
+			updates[section.key] = sectionUpdates
+		}
+	}
+}
+
+// handleTaskUpdateBulk handles the task_update_bulk MCP tool
+func (p *Provider) handleTaskUpdateBulk(call *toolspec.ToolCall) (*toolspec.Result, error) {
+	project := parseString(call.Args, "project", "")
+	filter := tasks.BulkTaskFilter{
+		Path:      parseString(call.Args, "path", ""),
+		Type:      parseString(call.Args, "type", ""),
+		Status:    parseString(call.Args, "status", ""),
+		QAVerdict: parseString(call.Args, "qa_verdict", ""),
+	}
+	setType := parseString(call.Args, "set_type", "")
+	instructionsFile := parseString(call.Args, "instructions_file", "")
+	instructionsFileSource := parseString(call.Args, "instructions_file_source", "")
+	qaInstructionsFile := parseString(call.Args, "qa_instructions_file", "")
+	qaInstructionsFileSource := parseString(call.Args, "qa_instructions_file_source", "")
+	priority := int(parseFloat64(call.Args, "priority", -1))
+	limits := &global.TaskLimits{
+		MaxWorker:  int(parseFloat64(call.Args, "max_worker", -1)),
+		MaxQA:      int(parseFloat64(call.Args, "max_qa", -1)),
+		MaxRetries: int(parseFloat64(call.Args, "max_retries", -1)),
+		Timeout:    int(parseFloat64(call.Args, "timeout", -1)),
+	}
+	dryRun := parseBool(call.Args, "dry_run", false)
+
+	p.logToolCall(global.ToolTaskUpdateBulk, map[string]string{
+		"project":    project,
+		"path":       filter.Path,
+		"type":       filter.Type,
+		"status":     filter.Status,
+		"qa_verdict": filter.QAVerdict,
+		"dry_run":    fmt.Sprintf("%t", dryRun),
+	})
+
+	if project == "" {
+		return nil, fmt.Errorf("%s", "project is required")
+	}
+	if filter == (tasks.BulkTaskFilter{}) {
+		return nil, fmt.Errorf("%s", "at least one filter is required: path, type, status, or qa_verdict")
+	}
+
+	if instructionsFile != "" {
+		if err := p.validateInstructionsFile(project, instructionsFile, instructionsFileSource); err != nil {
+			return errorResult(err), nil
+		}
+	}
+	if qaInstructionsFile != "" {
+		if err := p.validateInstructionsFile(project, qaInstructionsFile, qaInstructionsFileSource); err != nil {
+			return errorResult(fmt.Errorf("QA %w", err)), nil
+		}
+	}
+
+	updates := make(map[string]interface{})
+	if setType != "" {
+		updates["type"] = setType
+	}
+	if priority >= 0 {
+		updates["priority"] = priority
+	}
+	if limits.MaxWorker < 0 && limits.MaxQA < 0 && limits.MaxRetries < 0 && limits.Timeout < 0 {
+		limits = nil
+	}
+	addExecutionUpdates(call.Args, updates)
+
+	if !dryRun && p.runner.IsProjectRunning(project) {
+		return &toolspec.Result{ForLLM: fmt.Sprintf("a run is in progress for project %s; wait for it to finish or use dry_run", project), IsError: true}, nil
+	}
+
+	summary, err := p.tasks.UpdateTasks(project, filter, updates, limits, dryRun)
+	if err != nil {
+		return &toolspec.Result{ForLLM: fmt.Sprint(err.Error()), IsError: true}, nil
+	}
+
+	return createJSONResult(summary)
+}
+
+// parseQADefaults applies the qa_instructions_file, qa_instructions_file_source,
+// qa_instructions_text and qa_prompt parameters to a copy of current (which may
+// be nil). The value 'none' clears a default. Returns nil if none is given.
+func parseQADefaults(args map[string]any, current *global.QADefaults) *global.QADefaults {
+	file := parseString(args, "qa_instructions_file", "")
+	source := parseString(args, "qa_instructions_file_source", "")
+	text := parseString(args, "qa_instructions_text", "")
+	prompt := parseString(args, "qa_prompt", "")
+	if file == "" && source == "" && text == "" && prompt == "" {
+		return nil
+	}
+
+	var defaults global.QADefaults
+	if current != nil {
+		defaults = *current
+	}
+	set := func(field *string, value string) {
+		if value == "none" {
+			*field = ""
+		} else if value != "" {
+			*field = value
+		}
+	}
+	set(&defaults.InstructionsFile, file)
+	set(&defaults.InstructionsFileSource, source)
+	set(&defaults.InstructionsText, text)
+	set(&defaults.Prompt, prompt)
+
+	// The source belongs to the file
+	if defaults.InstructionsFile == "" {
+		defaults.InstructionsFileSource = ""
+	}
+	return &defaults
+}
+
+// parseQASkipRules parses the qa_skip_rules parameter: a JSON array of rules, or
+// 'none' (or an empty value) for no rules
+func parseQASkipRules(value string) ([]global.QASkipRule, error) {
+	if value == "" || value == "none" {
+		return nil, nil
+	}
+	var rules []global.QASkipRule
+	if err := json.Unmarshal([]byte(value), &rules); err != nil {
+		return nil, fmt.Errorf("qa_skip_rules must be a JSON array of rules: %v", err)
+	}
+	if err := global.ValidateQASkipRules(rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// parseResultSummary parses the result_summary parameter: a JSON object, or
+// 'none' (or an empty value) for the default summary
+func parseResultSummary(value string) (*global.ResultSummary, error) {
+	if value == "" || value == "none" {
+		return nil, nil
+	}
+	var summary global.ResultSummary
+	if err := json.Unmarshal([]byte(value), &summary); err != nil {
+		return nil, fmt.Errorf("result_summary must be a JSON object: %v", err)
+	}
+	if err := global.ValidateResultSummary(&summary); err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// parseApprovalGate parses the approval parameter: a JSON object of approval
+// settings, or "none" (or empty) for no gate
+func parseApprovalGate(value string) (*global.ApprovalGate, error) {
+	if value == "" || value == "none" {
+		return nil, nil
+	}
+	var gate global.ApprovalGate
+	if err := json.Unmarshal([]byte(value), &gate); err != nil {
+		return nil, fmt.Errorf("approval must be a JSON object: %v", err)
+	}
+	if err := global.ValidateApprovalGate(&gate); err != nil {
+		return nil, fmt.Errorf("approval %w", err)
+	}
+	return &gate, nil
+}
+
+// parseQAFieldMap parses the qa_fields parameter: a JSON object locating the
+// verdict, feedback and severity of QA responses, or "none" (or empty) for the
+// standard fields
+func parseQAFieldMap(value string) (*global.QAFieldMap, error) {
+	if value == "" || value == "none" {
+		return nil, nil
+	}
+	var fields global.QAFieldMap
+	if err := json.Unmarshal([]byte(value), &fields); err != nil {
+		return nil, fmt.Errorf("qa_fields must be a JSON object: %v", err)
+	}
+	if err := global.ValidateQAFieldMap(&fields); err != nil {
+		return nil, err
+	}
+	return &fields, nil
+}
+
+// parseWorkerTools parses the worker_tools parameter: a JSON object of worker
+// tool settings, or "none" (or empty) for workers without tools
+func parseWorkerTools(value string) (*global.WorkerTools, error) {
+	if value == "" || value == "none" {
+		return nil, nil
+	}
+	var tools global.WorkerTools
+	if err := json.Unmarshal([]byte(value), &tools); err != nil {
+		return nil, fmt.Errorf("worker_tools must be a JSON object: %v", err)
+	}
+	if err := global.ValidateWorkerTools(&tools); err != nil {
+		return nil, err
+	}
+	return &tools, nil
+}
+
+// validateRevisionPromptTemplate checks that a revision prompt template can be
+// loaded, from a playbook or the project's files, and parses as a Go template.
+// An empty path needs no validation.
+func (p *Provider) validateRevisionPromptTemplate(project, templatePath string) error {
+	if templatePath == "" {
+		return nil
+	}
+	content := p.loadSchemaContent(templatePath)
+	if content == "" {
+		projectContent, err := p.tasks.GetProjectFile(project, templatePath)
+		if err != nil || projectContent == "" {
+			return fmt.Errorf("revision_prompt_template not found: %s", templatePath)
+		}
+		content = projectContent
+	}
+	if err := templatespkg.ValidateTemplate(content); err != nil {
+		return fmt.Errorf("invalid revision_prompt_template: %w", err)
+	}
+	return nil
+}
+
 // validateInstructionsFile checks if an instructions file exists at the given source.
 // Returns an error if the file does not exist or cannot be accessed.
 // If instructionsFile is empty, returns nil (no validation needed).
@@ -622,8 +1459,9 @@ func (p *Provider) validateInstructionsFile(project, instructionsFile, instructi
 		return nil
 
 	case "playbook":
-		if p.playbooks == nil {
-			return fmt.Errorf("playbooks service not available")
+		// The runner loads the file when the task runs
+		if err := p.requireSubsystem(global.SubsystemPlaybooks, "instructions_file_source 'playbook'"); err != nil {
+			return err
 		}
 		// instructions_file should be "playbook-name/path/to/file.md"
 		parts := strings.SplitN(instructionsFile, "/", 2)
@@ -640,8 +1478,8 @@ func (p *Provider) validateInstructionsFile(project, instructionsFile, instructi
 		return nil
 
 	case "reference":
-		if p.reference == nil {
-			return fmt.Errorf("reference service not available")
+		if err := p.requireSubsystem(global.SubsystemReference, "instructions_file_source 'reference'"); err != nil {
+			return err
 		}
 		_, err := p.reference.Get(instructionsFile, 0, 0)
 		if err != nil {
@@ -649,8 +1487,15 @@ func (p *Provider) validateInstructionsFile(project, instructionsFile, instructi
 		}
 		return nil
 
+	case "shared":
+		_, err := p.shared.Get(instructionsFile, 0, 0)
+		if err != nil {
+			return fmt.Errorf("instructions file not found in shared library: %s", instructionsFile)
+		}
+		return nil
+
 	default:
-		return fmt.Errorf("invalid instructions_file_source: %s (must be project, playbook, or reference)", source)
+		return fmt.Errorf("invalid instructions_file_source: %s (must be project, playbook, reference, or shared)", source)
 	}
 }
 
@@ -729,3 +1574,42 @@ func (p *Provider) handleTaskDispatch(call *toolspec.ToolCall) (*toolspec.Result
 
 	return createJSONResult(result)
 }
+
+// parseTaskEnv parses an env parameter: a JSON object of string values.
+// "none" (or an empty value) yields an empty env, which clears it on update.
+func parseTaskEnv(value string) (map[string]string, error) {
+	env := map[string]string{}
+	if value == "" || value == "none" {
+		return env, nil
+	}
+	if err := json.Unmarshal([]byte(value), &env); err != nil {
+		return nil, fmt.Errorf("env must be a JSON object of string values: %v", err)
+	}
+	if err := global.ValidateTaskEnv(env); err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+// parseDependsOn splits a comma-separated depends_on parameter into task references
+func parseDependsOn(value string) []string {
+	var refs []string
+	for _, ref := range strings.Split(value, ",") {
+		if ref = strings.TrimSpace(ref); ref != "" {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// parseEvidenceFiles splits a comma-separated evidence_files parameter into
+// project file paths and glob patterns
+func parseEvidenceFiles(value string) []string {
+	var patterns []string
+	for _, pattern := range strings.Split(value, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
@@ -0,0 +1,143 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package maestro
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// healthCheck is the status of one subsystem reported by the health tool.
+// Code follows the monitoring exit-code convention (0 ok, 1 degraded, 2 critical).
+type healthCheck struct {
+	Status  string         `json:"status"`
+	Code    int            `json:"code"`
+	Message string         `json:"message,omitempty"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// newHealthCheck returns a check with the status matching code
+func newHealthCheck(code int, message string, details map[string]any) healthCheck {
+	status := global.HealthStatusOK
+	switch code {
+	case global.HealthCodeDegraded:
+		status = global.HealthStatusDegraded
+	case global.HealthCodeCritical:
+		status = global.HealthStatusCritical
+	}
+	return healthCheck{Status: status, Code: code, Message: message, Details: details}
+}
+
+// checkDisk reports the free space of the file system holding the projects
+// directory; below runner.min_free_disk_mb, runs are refused
+func (p *Provider) checkDisk() healthCheck {
+	dir := p.config.ProjectsDir()
+	minFree := p.config.Runner().MinFreeDiskMB
+	freeMB, err := global.FreeDiskMB(dir)
+	if err != nil {
+		return newHealthCheck(global.HealthCodeCritical, fmt.Sprintf("cannot determine free disk space for %s: %v", dir, err), nil)
+	}
+	details := map[string]any{"path": dir, "free_mb": freeMB, "min_free_mb": minFree}
+	switch {
+	case minFree >= 0 && freeMB < uint64(minFree):
+		return newHealthCheck(global.HealthCodeCritical, fmt.Sprintf("only %d MB free for the projects directory, below the %d MB runs require", freeMB, minFree), details)
+	case minFree > 0 && freeMB < 2*uint64(minFree):
+		return newHealthCheck(global.HealthCodeDegraded, fmt.Sprintf("only %d MB free for the projects directory, close to the %d MB runs require", freeMB, minFree), details)
+	}
+	return newHealthCheck(global.HealthCodeOK, "", details)
+}
+
+// checkPlaybooksDir reports whether playbook files can be written
+func (p *Provider) checkPlaybooksDir() healthCheck {
+	dir := p.config.PlaybooksDir()
+	details := map[string]any{"path": dir}
+	if err := global.CheckWritableDir(dir); err != nil {
+		return newHealthCheck(global.HealthCodeDegraded, fmt.Sprintf("playbooks directory %s is not writable: %v", dir, err), details)
+	}
+	return newHealthCheck(global.HealthCodeOK, "", details)
+}
+
+// checkLLMs reports the last availability probe of each enabled LLM: degraded
+// when some failed, critical when none is enabled or all failed
+func (p *Provider) checkLLMs() healthCheck {
+	enabled := p.config.EnabledLLMs()
+	if len(enabled) == 0 {
+		return newHealthCheck(global.HealthCodeCritical, "no LLMs are enabled", nil)
+	}
+
+	probes := make(map[string]any)
+	var failed []string
+	for _, l := range enabled {
+		a := p.llm.Availability(l.ID)
+		if a == nil {
+			probes[l.ID] = map[string]any{"probed": false}
+			continue
+		}
+		probes[l.ID] = map[string]any{"probed": true, "last_probe": a.LastProbe, "ok": a.LastProbeOK, "error": a.LastError}
+		if !a.LastProbeOK {
+			failed = append(failed, l.ID)
+		}
+	}
+
+	details := map[string]any{"enabled": len(enabled), "last_probe": probes}
+	switch {
+	case len(failed) == len(enabled):
+		return newHealthCheck(global.HealthCodeCritical, "every enabled LLM failed its last probe", details)
+	case len(failed) > 0:
+		return newHealthCheck(global.HealthCodeDegraded, fmt.Sprintf("last probe failed for %s", strings.Join(failed, ", ")), details)
+	}
+	return newHealthCheck(global.HealthCodeOK, "", details)
+}
+
+// checkRuns reports runs in progress and what was recovered at startup from
+// runs that died. It is degraded while a run is in recovery mode waiting for an
+// LLM, or while interrupted runs are waiting for task_run_resume.
+func (p *Provider) checkRuns() healthCheck {
+	running := p.runner.RunningProjects()
+	recoveries := p.runner.Recoveries()
+	details := map[string]any{"in_progress": len(running), "projects": running, "recovery": recoveries}
+	startup := p.runner.StartupRecovery()
+	if startup != nil {
+		details["startup_recovery"] = startup
+	}
+	if len(recoveries) > 0 {
+		llms := make([]string, len(recoveries))
+		for i, rec := range recoveries {
+			llms[i] = fmt.Sprintf("%s/%s (%s", rec.Project, rec.Path, rec.LLMID)
+			if rec.NextProbeAt != nil {
+				llms[i] += ", next probe " + rec.NextProbeAt.UTC().Format(time.RFC3339)
+			}
+			llms[i] += ", aborts " + rec.AbortAt.UTC().Format(time.RFC3339) + ")"
+		}
+		return newHealthCheck(global.HealthCodeDegraded, fmt.Sprintf("%d run(s) in recovery mode: %s", len(recoveries), strings.Join(llms, ", ")), details)
+	}
+	if startup != nil {
+		var awaiting []string
+		for _, project := range startup.AwaitingResume {
+			if runs, err := p.runner.InterruptedRuns(project); err == nil && len(runs) > 0 {
+				awaiting = append(awaiting, project)
+			}
+		}
+		if len(awaiting) > 0 {
+			return newHealthCheck(global.HealthCodeDegraded, fmt.Sprintf("interrupted runs found at startup are waiting for %s in: %s", global.ToolTaskRunResume, strings.Join(awaiting, ", ")), details)
+		}
+	}
+	return newHealthCheck(global.HealthCodeOK, "", details)
+}
+
+// checkTasks reports the tasks waiting, due for a retry or being processed
+// across all projects
+func (p *Provider) checkTasks() healthCheck {
+	pending, err := p.runner.PendingTasks()
+	details := map[string]any{"pending": pending}
+	if err != nil {
+		return newHealthCheck(global.HealthCodeDegraded, fmt.Sprintf("failed to count tasks: %v", err), details)
+	}
+	return newHealthCheck(global.HealthCodeOK, "", details)
+}
@@ -5,9 +5,11 @@ package maestro
 
 import (
 	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/PivotLLM/Maestro/config"
+	"github.com/PivotLLM/Maestro/global"
 )
 
 // newHealthTestProvider builds a minimal Provider over a prepared base dir,
@@ -73,3 +75,78 @@ func TestHandleHealth_Standalone(t *testing.T) {
 		t.Errorf("expected unhealthy with no LLMs, got healthy=%v", out["healthy"])
 	}
 }
+
+// TestHandleHealth_Subsystems: every report carries per-subsystem checks and
+// a top-level status code monitoring agents can alert on.
+func TestHandleHealth_Subsystems(t *testing.T) {
+	out := healthResult(t, newHealthTestProvider(t, true))
+
+	subsystems, ok := out["subsystems"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected subsystems object, got %v", out["subsystems"])
+	}
+	for _, name := range []string{"disk", "playbooks_dir"} {
+		check, ok := subsystems[name].(map[string]any)
+		if !ok {
+			t.Errorf("missing %s check in %v", name, subsystems)
+			continue
+		}
+		if _, ok := check["code"].(float64); !ok {
+			t.Errorf("%s check has no numeric code: %v", name, check)
+		}
+		if check["status"] == nil {
+			t.Errorf("%s check has no status: %v", name, check)
+		}
+	}
+	if _, ok := out["status_code"].(float64); !ok {
+		t.Errorf("expected numeric status_code, got %v", out["status_code"])
+	}
+
+	// Standalone with no LLMs enabled is unhealthy, i.e. critical
+	out = healthResult(t, newHealthTestProvider(t, false))
+	if out["status"] != "unhealthy" || out["status_code"] != float64(2) {
+		t.Errorf("expected unhealthy/2, got %v/%v", out["status"], out["status_code"])
+	}
+}
+
+// TestHandleHealth_Capabilities: health reports which optional subsystems are
+// active, with what to do about an inactive one.
+func TestHandleHealth_Capabilities(t *testing.T) {
+	out := healthResult(t, newHealthTestProvider(t, true))
+
+	caps, ok := out["capabilities"].([]any)
+	if !ok {
+		t.Fatalf("capabilities missing or not a list: %v", out["capabilities"])
+	}
+	byName := make(map[string]map[string]any)
+	for _, c := range caps {
+		entry := c.(map[string]any)
+		byName[entry["name"].(string)] = entry
+	}
+	for _, name := range []string{global.SubsystemLLM, global.SubsystemEmbeddings} {
+		entry, ok := byName[name]
+		if !ok {
+			t.Fatalf("capability %s not reported: %v", name, caps)
+		}
+		if entry["active"] != false || entry["detail"] == "" {
+			t.Errorf("capability %s = %v, want inactive with a detail", name, entry)
+		}
+	}
+}
+
+// TestErrorResult: a missing subsystem is reported as a structured error,
+// anything else as plain text.
+func TestErrorResult(t *testing.T) {
+	res := errorResult(fmt.Errorf("QA %w", &global.SubsystemUnavailableError{Subsystem: global.SubsystemPlaybooks, Feature: "loading pipeline pb/p.json", Remedy: "add a playbooks service"}))
+	var out subsystemError
+	if err := json.Unmarshal([]byte(res.ForLLM), &out); err != nil {
+		t.Fatalf("unmarshal %q: %v", res.ForLLM, err)
+	}
+	if !res.IsError || out.ErrorCode != global.ErrorCodeSubsystemUnavailable || out.Subsystem != global.SubsystemPlaybooks || out.Remedy == "" {
+		t.Errorf("structured error = %+v", out)
+	}
+
+	if res := errorResult(fmt.Errorf("not found")); res.ForLLM != "not found" || !res.IsError {
+		t.Errorf("plain error = %+v", res)
+	}
+}
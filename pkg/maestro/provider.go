@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/PivotLLM/Maestro/config"
+	"github.com/PivotLLM/Maestro/conversion"
+	"github.com/PivotLLM/Maestro/embeddings"
 	"github.com/PivotLLM/Maestro/global"
 	"github.com/PivotLLM/Maestro/lists"
 	"github.com/PivotLLM/Maestro/llm"
@@ -15,6 +17,7 @@ import (
 	"github.com/PivotLLM/Maestro/projects"
 	"github.com/PivotLLM/Maestro/reference"
 	"github.com/PivotLLM/Maestro/runner"
+	"github.com/PivotLLM/Maestro/shared"
 	"github.com/PivotLLM/Maestro/tasks"
 
 	"github.com/PivotLLM/toolspec"
@@ -29,6 +32,12 @@ type HostDeps struct {
 	// it into a DispatchResult. With a host Dispatcher present, Maestro does not
 	// choose the model and the LLM-management tools are not exposed.
 	Dispatcher llm.Dispatcher
+	// LLM, when set, is the LLM service the host's runner dispatches through, so
+	// that llm_list and health report the availability probes it records
+	LLM *llm.Service
+	// Converters are added to the end of the conversion pipeline, after the
+	// steps of the configuration, to convert formats of the host's own
+	Converters []conversion.Converter
 }
 
 // Provider implements toolspec.ToolProvider for Maestro.
@@ -36,12 +45,15 @@ type Provider struct {
 	config             *config.Config
 	logger             *logging.Logger
 	reference          *reference.Service
+	shared             *shared.Service
 	playbooks          *playbooks.Service
 	projects           *projects.Service
 	tasks              *tasks.Service
 	lists              *lists.Service
 	llm                *llm.Service
 	runner             *runner.Runner
+	embeddings         *embeddings.Service // nil unless an embeddings endpoint is configured
+	conversion         *conversion.Service
 	markNonDestructive bool
 	hostDispatched     bool
 	deps               toolspec.Deps
@@ -60,6 +72,8 @@ func (p *Provider) RegisterTools(deps toolspec.Deps) []toolspec.ToolDefinition {
 	// Initialize logger and runner from Host if provided
 	var rInst *runner.Runner
 	var hostDispatcher llm.Dispatcher
+	var llmService *llm.Service
+	var hostConverters []conversion.Converter
 	if hd, ok := deps.Host.(HostDeps); ok {
 		if hd.Logger != nil {
 			p.logger = hd.Logger
@@ -70,6 +84,8 @@ func (p *Provider) RegisterTools(deps toolspec.Deps) []toolspec.ToolDefinition {
 			rInst = hd.Runner
 		}
 		hostDispatcher = hd.Dispatcher
+		llmService = hd.LLM
+		hostConverters = hd.Converters
 	} else if l, ok := deps.Host.(*logging.Logger); ok && l != nil {
 		// Fallback for previous implementation
 		p.logger = l
@@ -91,7 +107,8 @@ func (p *Provider) RegisterTools(deps toolspec.Deps) []toolspec.ToolDefinition {
 		reference.WithExternalDirs(externalDirs),
 		reference.WithLogger(p.logger),
 	)
-	p.playbooks = playbooks.NewService(cfg.PlaybooksDir(), p.logger)
+	p.shared = shared.NewService(cfg.SharedDir(), cfg.ImportPolicy(), p.logger)
+	p.playbooks = playbooks.NewService(cfg.PlaybooksDir(), cfg.ExportsDir(), cfg.PlaybookSnapshots(), p.logger)
 	p.projects = projects.NewService(cfg, p.logger)
 	p.tasks = tasks.NewService(cfg, p.projects, p.logger)
 	p.lists = lists.NewService(
@@ -100,7 +117,19 @@ func (p *Provider) RegisterTools(deps toolspec.Deps) []toolspec.ToolDefinition {
 		lists.WithEmbeddedFS(cfg.EmbeddedFS()),
 		lists.WithLogger(p.logger),
 	)
-	p.llm = llm.NewService(cfg, p.logger, nil)
+	p.llm = llmService
+	if p.llm == nil {
+		p.llm = llm.NewService(cfg, p.logger, nil)
+	}
+	p.conversion = conversion.NewService(cfg.Conversion(), p.logger)
+	for _, c := range hostConverters {
+		if err := p.conversion.Register(c); err != nil {
+			p.logger.Warnf("Converter not registered: %v", err)
+		}
+	}
+	if cfg.Embeddings().Enabled() {
+		p.embeddings = embeddings.NewService(cfg.Embeddings(), cfg.EmbeddingsDir(), p.logger)
+	}
 
 	// The runner dispatches through the host's Dispatcher when one is injected
 	// (the host owns model selection); otherwise it uses Maestro's own llm.Service.
@@ -129,6 +158,17 @@ func (p *Provider) RegisterTools(deps toolspec.Deps) []toolspec.ToolDefinition {
 		// HTTP callback_url parameter is meaningless here — hide it.
 		defs = withoutParam(defs, "callback_url")
 	}
+	if p.embeddings == nil {
+		// Semantic search needs an embeddings endpoint
+		defs = withoutTools(defs, global.ToolSemanticSearch)
+	}
+	if cfg.StrictParams() {
+		defs = withStrictParams(defs)
+	}
+	// Outermost, so calls rejected by strict mode are recorded too
+	defs = withAudit(defs, p.projects.AppendAudit, func(project string, err error) {
+		p.logger.Debugf("Audit entry not recorded for project %s: %v", project, err)
+	})
 	return defs
 }
 
@@ -0,0 +1,125 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package maestro
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/PivotLLM/Maestro/global"
+	"github.com/PivotLLM/toolspec"
+)
+
+// maxSuggestionDistance is the largest edit distance for a "did you mean" suggestion
+const maxSuggestionDistance = 3
+
+// unknownParamsError is the structured error returned in strict mode
+type unknownParamsError struct {
+	Error       string            `json:"error"`
+	ErrorCode   string            `json:"error_code"`
+	Tool        string            `json:"tool"`
+	Unknown     []string          `json:"unknown"`
+	Suggestions map[string]string `json:"suggestions,omitempty"` // unknown name -> closest valid name
+	Valid       []string          `json:"valid"`
+}
+
+// withStrictParams wraps every tool handler so calls containing argument names the
+// tool does not declare are rejected instead of silently ignored. This catches
+// client-side typos (e.g. "insructions_file") that otherwise produce empty prompts.
+func withStrictParams(defs []toolspec.ToolDefinition) []toolspec.ToolDefinition {
+	for i := range defs {
+		def := defs[i]
+		valid := make([]string, 0, len(def.Parameters))
+		for _, prm := range def.Parameters {
+			valid = append(valid, prm.Name)
+		}
+		sort.Strings(valid)
+		handler := def.Handler
+		defs[i].Handler = func(call *toolspec.ToolCall) (*toolspec.Result, error) {
+			if call != nil {
+				if res := checkUnknownParams(def.Name, call.Args, valid); res != nil {
+					return res, nil
+				}
+			}
+			return handler(call)
+		}
+	}
+	return defs
+}
+
+// checkUnknownParams returns an error result listing unknown argument names, or nil
+func checkUnknownParams(tool string, args map[string]any, valid []string) *toolspec.Result {
+	known := make(map[string]bool, len(valid))
+	for _, name := range valid {
+		known[name] = true
+	}
+
+	var unknown []string
+	for name := range args {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+
+	suggestions := make(map[string]string)
+	for _, name := range unknown {
+		if match := closestParam(name, valid); match != "" {
+			suggestions[name] = match
+		}
+	}
+
+	msg := fmt.Sprintf("unknown parameter(s) for %s: %s", tool, strings.Join(unknown, ", "))
+	b, err := json.Marshal(unknownParamsError{
+		Error:       msg,
+		ErrorCode:   global.ErrorCodeUnknownParameters,
+		Tool:        tool,
+		Unknown:     unknown,
+		Suggestions: suggestions,
+		Valid:       valid,
+	})
+	if err != nil {
+		return &toolspec.Result{ForLLM: msg, IsError: true}
+	}
+	return &toolspec.Result{ForLLM: string(b), IsError: true}
+}
+
+// closestParam returns the valid name nearest to name, or "" if none is close enough
+func closestParam(name string, valid []string) string {
+	best, bestDist := "", maxSuggestionDistance+1
+	for _, candidate := range valid {
+		if d := editDistance(strings.ToLower(name), candidate); d < bestDist {
+			best, bestDist = candidate, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,56 @@
+// Maestro
+// License: MIT
+
+package maestro
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/PivotLLM/Maestro/global"
+	"github.com/PivotLLM/toolspec"
+)
+
+func TestWithStrictParams(t *testing.T) {
+	called := false
+	defs := withStrictParams([]toolspec.ToolDefinition{{
+		Name: global.ToolTaskCreate,
+		Parameters: []toolspec.Parameter{
+			{Name: "project", Type: "string"},
+			{Name: "instructions_file", Type: "string"},
+		},
+		Handler: func(call *toolspec.ToolCall) (*toolspec.Result, error) {
+			called = true
+			return &toolspec.Result{ForLLM: "ok"}, nil
+		},
+	}})
+	handler := defs[0].Handler
+
+	// Known arguments pass through to the handler
+	res, err := handler(&toolspec.ToolCall{Args: map[string]any{"project": "p", "instructions_file": "x.md"}})
+	if err != nil || res.IsError || !called {
+		t.Fatalf("valid call rejected: %+v, %v", res, err)
+	}
+
+	// A typo is rejected with a structured error and a suggestion
+	called = false
+	res, err = handler(&toolspec.ToolCall{Args: map[string]any{"project": "p", "insructions_file": "x.md"}})
+	if err != nil || !res.IsError || called {
+		t.Fatalf("unknown parameter not rejected: %+v, %v (called=%v)", res, err, called)
+	}
+	var out unknownParamsError
+	if err := json.Unmarshal([]byte(res.ForLLM), &out); err != nil {
+		t.Fatalf("error is not structured JSON: %q", res.ForLLM)
+	}
+	if out.ErrorCode != global.ErrorCodeUnknownParameters || len(out.Unknown) != 1 || out.Unknown[0] != "insructions_file" {
+		t.Errorf("unexpected error: %+v", out)
+	}
+	if out.Suggestions["insructions_file"] != "instructions_file" {
+		t.Errorf("suggestions = %v", out.Suggestions)
+	}
+
+	// Unrelated names get no suggestion
+	if got := closestParam("callback", []string{"project", "instructions_file"}); got != "" {
+		t.Errorf("closestParam = %q, want none", got)
+	}
+}
@@ -45,6 +45,71 @@ func (p *Provider) getToolDefinitions() []toolspec.ToolDefinition {
 			Handler: p.handleReferenceSearch,
 			Hints:   &toolspec.ToolHints{ReadOnly: toolspec.Allow(true)},
 		},
+		{
+			Name:        global.ToolSharedList,
+			Description: "List files in the shared evidence library: documents reused across projects, such as standards texts and prior-year reports. Projects read them in place (instructions_file_source 'shared' or file_copy from_source 'shared') instead of importing them again.",
+			Parameters: []toolspec.Parameter{
+				{Name: "prefix", Type: "string", Description: "Optional path prefix filter", Required: false},
+			},
+			Handler: p.handleSharedList,
+			Hints:   &toolspec.ToolHints{ReadOnly: toolspec.Allow(true)},
+		},
+		{
+			Name:        global.ToolSharedGet,
+			Description: "Read a file from the shared evidence library.",
+			Parameters: []toolspec.Parameter{
+				{Name: "path", Type: "string", Description: "Path of the file in the shared library", Required: false},
+				{Name: "byte_offset", Type: "number", Description: "Byte position to start reading from, for chunked reading of large files (default: 0)", Required: false},
+				{Name: "max_bytes", Type: "number", Description: "Maximum bytes to return in this chunk, for chunked reading of large files (default: 0 = entire file)", Required: false},
+			},
+			Handler: p.handleSharedGet,
+			Hints:   &toolspec.ToolHints{ReadOnly: toolspec.Allow(true)},
+		},
+		{
+			Name:        global.ToolSharedSearch,
+			Description: "Search the shared evidence library by filename or content.",
+			Parameters: []toolspec.Parameter{
+				{Name: "query", Type: "string", Description: "Search query string", Required: false},
+				{Name: "limit", Type: "number", Description: "Maximum number of results", Required: false},
+				{Name: "offset", Type: "number", Description: "Number of results to skip", Required: false},
+			},
+			Handler: p.handleSharedSearch,
+			Hints:   &toolspec.ToolHints{ReadOnly: toolspec.Allow(true)},
+		},
+		{
+			Name:        global.ToolSharedImport,
+			Description: "Import external files into the shared evidence library once so every project can read them. This bypasses the normal chroot restrictions to allow importing from anywhere on the filesystem. Symlinks are skipped. Existing files at the destination are replaced. The configured import policy applies: files over the size limit or of a blocked type (disk and VM images by default) are left out and listed in 'rejected', and an import over the total size limit fails.",
+			Parameters: []toolspec.Parameter{
+				{Name: "source", Type: "string", Description: "Source file or directory path (absolute path on the filesystem)", Required: false},
+				{Name: "path", Type: "string", Description: "Destination path in the shared library (default: the source's name)", Required: false},
+				{Name: "recursive", Type: "boolean", Description: "If true, recursively import directories. Required when source is a directory.", Required: false},
+				{Name: "convert", Type: "boolean", Description: "If true, automatically convert imported files (PDF, DOCX, XLSX and the formats of configured converters) to Markdown after import.", Required: false},
+			},
+			Handler: p.handleSharedImport,
+			Hints:   nil,
+		},
+		{
+			Name:        global.ToolSharedDelete,
+			Description: "Delete a file or directory from the shared evidence library. Task sets that reference it with instructions_file_source 'shared' will fail until it is imported again.",
+			Parameters: []toolspec.Parameter{
+				{Name: "path", Type: "string", Description: "Path of the file or directory to delete", Required: false},
+			},
+			Handler: p.handleSharedDelete,
+			Hints:   &toolspec.ToolHints{Destructive: toolspec.Allow(!p.markNonDestructive)},
+		},
+		{
+			Name:        global.ToolSemanticSearch,
+			Description: "Search project files, playbook files and reference documents by meaning rather than exact words, returning the most relevant chunks with their path and first line. Use it to find evidence in large corpora; follow up with project_file_get or playbook_file_get for full context. Select at least one source. Indexes are updated incrementally before each search, so the first search of a large project takes longer. Only available when an embeddings endpoint is configured.",
+			Parameters: []toolspec.Parameter{
+				{Name: "query", Type: "string", Description: "What to look for, in natural language", Required: false},
+				{Name: "project", Type: "string", Description: "Search this project's files (optional)", Required: false},
+				{Name: "playbook", Type: "string", Description: "Search this playbook's files (optional)", Required: false},
+				{Name: "reference", Type: "boolean", Description: "Search the reference documentation (default: false)", Required: false},
+				{Name: "limit", Type: "number", Description: "Maximum number of chunks to return (default: 10)", Required: false},
+			},
+			Handler: p.handleSemanticSearch,
+			Hints:   &toolspec.ToolHints{ReadOnly: toolspec.Allow(true)},
+		},
 		{
 			Name:        global.ToolPlaybookList,
 			Description: "List all playbooks. Playbooks are user-created collections of reusable knowledge and procedures.",
@@ -80,6 +145,60 @@ func (p *Provider) getToolDefinitions() []toolspec.ToolDefinition {
 			Handler: p.handlePlaybookDelete,
 			Hints:   &toolspec.ToolHints{Destructive: toolspec.Allow(!p.markNonDestructive)},
 		},
+		{
+			Name:        global.ToolPlaybookExport,
+			Description: "Export a playbook as a portable bundle (zip or tar.gz) in the exports directory, with a manifest recording its name, version, description and the checksum of every file. Share the bundle with colleagues or publish it; they install it with playbook_import.",
+			Parameters: []toolspec.Parameter{
+				{Name: "name", Type: "string", Description: "Playbook name", Required: false},
+				{Name: "version", Type: "string", Description: "Bundle version (e.g., '1.2.0'); used in the archive name. Default: export timestamp", Required: false},
+				{Name: "description", Type: "string", Description: "What the playbook is for, recorded in the manifest", Required: false},
+				{Name: "format", Type: "string", Description: "Archive format: 'zip' (default) or 'tar.gz'", Required: false},
+			},
+			Handler: p.handlePlaybookExport,
+			Hints:   nil,
+		},
+		{
+			Name:        global.ToolPlaybookImport,
+			Description: "Install a playbook from a bundle written by playbook_export. The bundle must be in the exports directory; every file is verified against the manifest checksums. Existing playbooks are never overwritten.",
+			Parameters: []toolspec.Parameter{
+				{Name: "archive", Type: "string", Description: "Bundle file name in the exports directory (e.g., 'iso27001-1.2.0.zip')", Required: false},
+				{Name: "name", Type: "string", Description: "Playbook name to import as (default: the exported playbook's name)", Required: false},
+			},
+			Handler: p.handlePlaybookImport,
+			Hints:   nil,
+		},
+		{
+			Name:        global.ToolPlaybookHistory,
+			Description: "Show a playbook's version history. Every file put, append, edit, rename, delete or restore bumps the playbook version; task results record the version of the procedure they used.",
+			Parameters: []toolspec.Parameter{
+				{Name: "playbook", Type: "string", Description: "Playbook name", Required: false},
+				{Name: "path", Type: "string", Description: "Only show changes to this file", Required: false},
+				{Name: "limit", Type: "number", Description: "Show only the most recent changes (default: all)", Required: false},
+			},
+			Handler: p.handlePlaybookHistory,
+			Hints:   &toolspec.ToolHints{ReadOnly: toolspec.Allow(true)},
+		},
+		{
+			Name:        global.ToolPlaybookRestore,
+			Description: "Restore a playbook file to its content at an earlier playbook version (see playbook_history). Requires playbook_snapshots to have been enabled when the file changed. The restore is recorded as a new version.",
+			Parameters: []toolspec.Parameter{
+				{Name: "playbook", Type: "string", Description: "Playbook name", Required: false},
+				{Name: "path", Type: "string", Description: "File path within the playbook", Required: false},
+				{Name: "version", Type: "number", Description: "Playbook version to restore the file to", Required: false},
+			},
+			Handler: p.handlePlaybookRestore,
+			Hints:   nil,
+		},
+		{
+			Name:        global.ToolPlaybookLint,
+			Description: "Check a playbook for the structural pieces Maestro expects before distributing it: procedure.md and the schemas/, templates/ and instructions/ directories, schemas that parse as JSON Schema, instructions files within a size threshold, templates referenced by lists/*.json that exist, and relative Markdown links that resolve. Returns a score out of 100 (each error costs 15 points, each warning 5), pass/fail (no errors), and the issues found.",
+			Parameters: []toolspec.Parameter{
+				{Name: "playbook", Type: "string", Description: "Playbook name", Required: false},
+				{Name: "max_instructions_kb", Type: "number", Description: "Largest instructions file allowed, in KB (default: 64)", Required: false},
+			},
+			Handler: p.handlePlaybookLint,
+			Hints:   &toolspec.ToolHints{ReadOnly: toolspec.Allow(true)},
+		},
 		{
 			Name:        global.ToolPlaybookFileList,
 			Description: "List files in a playbook.",
@@ -181,7 +300,9 @@ func (p *Provider) getToolDefinitions() []toolspec.ToolDefinition {
 				{Name: "description", Type: "string", Description: "Project description", Required: false},
 				{Name: "context", Type: "string", Description: "Global context included in all task prompts (e.g., audit period, customer info)", Required: false},
 				{Name: "status", Type: "string", Description: "Initial status (pending, in_progress, done, cancelled)", Required: false},
-				{Name: "disclaimer_template", Type: "string", Description: "Path to disclaimer file for reports (e.g., 'playbook-name/templates/disclaimer.md') or 'none'. This text appears at the top of generated reports. Use it to disclose AI assistance.", Required: false},
+				{Name: "disclaimer_template", Type: "string", Description: "Path to disclaimer file for reports (e.g., 'playbook-name/templates/disclaimer.md'), 'none', or 'default' to follow the configured disclaimer policy. This text appears at the top of generated reports. Use it to disclose AI assistance. Required unless the configuration provides a default or makes disclaimers optional.", Required: false},
+				{Name: "output_language", Type: "string", Description: "Required response language as an ISO 639-1 code or English name (e.g., 'fr', 'German'). Enforced in prompts and by post-hoc detection; responses predominantly in another language fail validation.", Required: false},
+				{Name: "allowed_llms", Type: "string", Description: "Comma-separated IDs or aliases of the only LLMs the project may use (e.g. an on-prem model for sensitive client data). Runs and llm_dispatch calls for the project are refused for any other LLM. Default: any LLM.", Required: false},
 			},
 			Handler: p.handleProjectCreate,
 			Hints:   nil,
@@ -195,6 +316,152 @@ func (p *Provider) getToolDefinitions() []toolspec.ToolDefinition {
 			Handler: p.handleProjectGet,
 			Hints:   &toolspec.ToolHints{ReadOnly: toolspec.Allow(true)},
 		},
+		{
+			Name:        global.ToolProjectDashboard,
+			Description: "Get a machine-readable project summary: task status counts, QA verdicts, severity rollups, LLM usage and cost totals, and the last run. Same content as the project's dashboard.json, which the runner writes after each run when the runner 'dashboard' option is enabled.",
+			Parameters: []toolspec.Parameter{
+				{Name: "name", Type: "string", Description: "Project name", Required: false},
+			},
+			Handler: p.handleProjectDashboard,
+			Hints:   &toolspec.ToolHints{ReadOnly: toolspec.Allow(true)},
+		},
+		{
+			Name:        global.ToolProjectCleanup,
+			Description: "Collect orphaned files from a project's results directory: error files (<uuid>-error.json) whose task has since succeeded or been deleted, and partial files (*.tmp) left by interrupted writes. Files are deleted or moved to results/_orphaned/<timestamp>/. Returns a summary of what was collected. The same cleanup runs in the background when maintenance.interval_hours is configured.",
+			Parameters: []toolspec.Parameter{
+				{Name: "name", Type: "string", Description: "Project name", Required: false},
+				{Name: "action", Type: "string", Description: "'delete' or 'archive' (default: maintenance.action from config, else 'delete')", Required: false},
+				{Name: "min_age_hours", Type: "number", Description: "Only collect files last modified at least this many hours ago (default: maintenance.min_age_hours from config, else 24)", Required: false},
+				{Name: "dry_run", Type: "boolean", Description: "List what would be collected without changing anything (default: false)", Required: false},
+			},
+			Handler: p.handleProjectResultsCleanup,
+			Hints:   &toolspec.ToolHints{Destructive: toolspec.Allow(!p.markNonDestructive)},
+		},
+		{
+			Name:        global.ToolProjectPrune,
+			Description: "Prune old files from a project's results directory under a retention policy: files older than max_age_days, beyond the newest max_per_task files of a task, then the oldest files while the directory exceeds max_total_mb. 'compact' keeps result responses, verdicts and usage but removes full prompts and raw LLM output (error and archived files are deleted); 'delete' removes the files. Every pruned file is summarized in results/_index.json. Limits default to maintenance.retention in the config; the same policy runs in the background with maintenance.interval_hours.",
+			Parameters: []toolspec.Parameter{
+				{Name: "name", Type: "string", Description: "Project name", Required: false},
+				{Name: "max_age_days", Type: "number", Description: "Prune files last modified more than this many days ago (0 = no limit)", Required: false},
+				{Name: "max_per_task", Type: "number", Description: "Keep only the newest files of each task (0 = no limit)", Required: false},
+				{Name: "max_total_mb", Type: "number", Description: "Prune the oldest files while the results directory is larger than this (0 = no limit)", Required: false},
+				{Name: "action", Type: "string", Description: "'compact' (default) or 'delete'", Required: false},
+				{Name: "dry_run", Type: "boolean", Description: "List what would be pruned without changing anything (default: false)", Required: false},
+			},
+			Handler: p.handleProjectResultsPrune,
+			Hints:   &toolspec.ToolHints{Destructive: toolspec.Allow(!p.markNonDestructive)},
+		},
+		{
+			Name:        global.ToolProjectPurge,
+			Description: "Preview or apply a project's retention policy (set with project_update retention_days). Once the policy's days have passed since the project status became done, its results, logs and/or files are deleted, or with action 'anonymize' rewritten with emails, phone numbers, SSNs, SINs, card numbers and IBANs replaced by their type; binary files are deleted. The same purge runs in the background with maintenance.interval_hours. Defaults to a dry run, which lists the affected files and when the purge is due.",
+			Parameters: []toolspec.Parameter{
+				{Name: "name", Type: "string", Description: "Project name", Required: false},
+				{Name: "dry_run", Type: "boolean", Description: "List what would be purged without changing anything (default: true). Set false to purge now; refused before the purge is due.", Required: false},
+			},
+			Handler: p.handleProjectRetentionPurge,
+			Hints:   &toolspec.ToolHints{Destructive: toolspec.Allow(!p.markNonDestructive)},
+		},
+		{
+			Name:        global.ToolProjectDiff,
+			Description: "Compare a project's findings with a baseline: another project (e.g. last quarter's audit), another task set, or one of the project's finalized report archives. Each completed task result is a finding keyed by task external_id, else the key_field in the worker response, else the task title; with match_by 'title', findings are aligned by task title and type instead. Returns new, resolved and changed findings, optionally written as a markdown delta report.",
+			Parameters: []toolspec.Parameter{
+				{Name: "name", Type: "string", Description: "Project name (current findings)", Required: false},
+				{Name: "path", Type: "string", Description: "Compare only the findings of this task set and the task sets under it (default: all)", Required: false},
+				{Name: "baseline_project", Type: "string", Description: "Project to compare against (default: this project when baseline_path is given)", Required: false},
+				{Name: "baseline_path", Type: "string", Description: "Compare against only the findings of this task set and those under it in the baseline project", Required: false},
+				{Name: "baseline_archive", Type: "string", Description: "Report prefix of a finalized archive to compare against; the archive of baseline_project if given, else of this project", Required: false},
+				{Name: "match_by", Type: "string", Description: "How findings are aligned: 'key' (external_id, key_field, then title; default) or 'title' (task title and type)", Required: false},
+				{Name: "key_field", Type: "string", Description: "Worker response field identifying a finding when the task has no external_id (default: 'item_id')", Required: false},
+				{Name: "compare_fields", Type: "string", Description: "Comma-separated worker response fields compared for changes (default: 'severity,status')", Required: false},
+				{Name: "output_file", Type: "string", Description: "Project file to write the delta report to as markdown (optional)", Required: false},
+			},
+			Handler: p.handleProjectDiff,
+			Hints:   nil,
+		},
+		{
+			Name:        global.ToolProjectTrends,
+			Description: "Get the metrics recorded at the end of each run (oldest first): task status counts, findings by severity, QA pass rate, and cost. Use it to follow progress across remediation re-tests. Report manifest entries with include_trends add the same series to generated reports.",
+			Parameters: []toolspec.Parameter{
+				{Name: "name", Type: "string", Description: "Project name", Required: false},
+				{Name: "limit", Type: "number", Description: "Return only the most recent N runs (default: all)", Required: false},
+			},
+			Handler: p.handleProjectTrends,
+			Hints:   &toolspec.ToolHints{ReadOnly: toolspec.Allow(true)},
+		},
+		{
+			Name:        global.ToolProjectTemplates,
+			Description: "List the schemas and templates referenced by a project's task sets (response schemas, report templates and manifests, layouts and partials) with where each was resolved from, its SHA-256 checksum, size and last modified time. Every run records these checksums; entries that differ from the last run are marked 'changed', so templates edited mid-engagement can be reviewed.",
+			Parameters: []toolspec.Parameter{
+				{Name: "name", Type: "string", Description: "Project name", Required: false},
+				{Name: "changed_only", Type: "boolean", Description: "Only return entries changed since the last run or not found (default: false)", Required: false},
+			},
+			Handler: p.handleProjectTemplates,
+			Hints:   &toolspec.ToolHints{ReadOnly: toolspec.Allow(true)},
+		},
+		{
+			Name:        global.ToolProjectSnapshot,
+			Description: "Create a read-only snapshot of a project's files, results, reports and task sets, e.g. when a report is delivered. QA reviewers can then read the state as of that moment by passing the snapshot ID as 'snapshot' to project_file_list, project_file_get, report_list, report_read and task_result_get while work continues. Files are hard-linked where possible, so snapshots are cheap.",
+			Parameters: []toolspec.Parameter{
+				{Name: "name", Type: "string", Description: "Project name", Required: false},
+				{Name: "label", Type: "string", Description: "Optional description (e.g., 'Delivered to client')", Required: false},
+			},
+			Handler: p.handleProjectSnapshot,
+		},
+		{
+			Name:        global.ToolProjectSnapshots,
+			Description: "List a project's snapshots, oldest first, with their IDs, labels, creation times and file counts.",
+			Parameters: []toolspec.Parameter{
+				{Name: "name", Type: "string", Description: "Project name", Required: false},
+			},
+			Handler: p.handleProjectSnapshotList,
+			Hints:   &toolspec.ToolHints{ReadOnly: toolspec.Allow(true)},
+		},
+		{
+			Name:        global.ToolProjectSnapshotDel,
+			Description: "Delete a project snapshot. The project's own files are not affected.",
+			Parameters: []toolspec.Parameter{
+				{Name: "name", Type: "string", Description: "Project name", Required: false},
+				{Name: "snapshot", Type: "string", Description: "Snapshot ID", Required: false},
+			},
+			Handler: p.handleProjectSnapshotDelete,
+			Hints:   &toolspec.ToolHints{Destructive: toolspec.Allow(!p.markNonDestructive)},
+		},
+		{
+			Name:        global.ToolProjectAudit,
+			Description: "Query the project's append-only audit trail: every tool call that named the project, with tool, arguments hash, caller (agent, session, channel), timestamp, outcome and duration. Newest entries last. Use it for forensic review of who changed what; the human-readable project log is separate.",
+			Parameters: []toolspec.Parameter{
+				{Name: "name", Type: "string", Description: "Project name", Required: false},
+				{Name: "tool", Type: "string", Description: "Only entries for this tool (optional)", Required: false},
+				{Name: "outcome", Type: "string", Description: "Only entries with this outcome: 'ok' or 'error' (optional)", Required: false},
+				{Name: "session", Type: "string", Description: "Only entries from this session (optional)", Required: false},
+				{Name: "since", Type: "string", Description: "Only entries at or after this RFC 3339 timestamp (optional)", Required: false},
+				{Name: "limit", Type: "number", Description: "Return only the most recent N matching entries (default: 100)", Required: false},
+			},
+			Handler: p.handleProjectAudit,
+			Hints:   &toolspec.ToolHints{ReadOnly: toolspec.Allow(true)},
+		},
+		{
+			Name:        global.ToolProjectExport,
+			Description: "Export an entire project (metadata, files, lists, task sets, results, reports and logs) as a single zip or tar.gz archive in the exports directory, for backup, migration or sharing. Copy the archive to another instance's exports directory and call project_import there.",
+			Parameters: []toolspec.Parameter{
+				{Name: "name", Type: "string", Description: "Project name", Required: false},
+				{Name: "format", Type: "string", Description: "Archive format: 'zip' (default) or 'tar.gz'", Required: false},
+				{Name: "anonymize", Type: "boolean", Description: "Replace the values covered by the prompt redaction types and rules (client names, hostnames, people, personal identifiers) with placeholders in every text file, leave binary files out, and write the placeholders' values to a separate mapping file beside the archive (default: false)", Required: false},
+			},
+			Handler: p.handleProjectExport,
+			Hints:   nil,
+		},
+		{
+			Name:        global.ToolProjectImport,
+			Description: "Import a project from an archive written by project_export, on this or another Maestro instance. The archive must be in the exports directory. Existing projects are never overwritten.",
+			Parameters: []toolspec.Parameter{
+				{Name: "archive", Type: "string", Description: "Archive file name in the exports directory (e.g., 'my-audit-20260115-103000.zip')", Required: false},
+				{Name: "name", Type: "string", Description: "Project name to import as (default: the exported project's name)", Required: false},
+				{Name: "mapping", Type: "string", Description: "Mapping file of an anonymized export, in the exports directory, to restore the original values (optional)", Required: false},
+			},
+			Handler: p.handleProjectImport,
+			Hints:   nil,
+		},
 		{
 			Name:        global.ToolProjectUpdate,
 			Description: "Update project metadata.",
@@ -204,7 +471,13 @@ func (p *Provider) getToolDefinitions() []toolspec.ToolDefinition {
 				{Name: "description", Type: "string", Description: "New description (optional)", Required: false},
 				{Name: "context", Type: "string", Description: "Global context included in all task prompts (optional)", Required: false},
 				{Name: "status", Type: "string", Description: "New status (optional)", Required: false},
-				{Name: "disclaimer_template", Type: "string", Description: "Path to disclaimer MD file for reports (optional)", Required: false},
+				{Name: "disclaimer_template", Type: "string", Description: "Path to disclaimer MD file for reports, 'none', or 'default' to follow the configured disclaimer policy (optional)", Required: false},
+				{Name: "output_language", Type: "string", Description: "Required response language (e.g., 'fr'), or 'none' to clear (optional)", Required: false},
+				{Name: "retention_days", Type: "number", Description: "Purge the project's data this many days after its status becomes done, or 0 to remove the retention policy (optional)", Required: false},
+				{Name: "retention_action", Type: "string", Description: "Retention purge action: 'delete' (default) or 'anonymize' (replace personal identifiers) (optional, with retention_days)", Required: false},
+				{Name: "retention_include", Type: "string", Description: "Comma-separated parts to purge: 'results', 'logs', 'files' (default: all) (optional, with retention_days)", Required: false},
+				{Name: "allowed_llms", Type: "string", Description: "Comma-separated IDs or aliases of the only LLMs the project may use, or 'none' to allow any (optional)", Required: false},
+				{Name: "prompt_redaction", Type: "string", Description: "Prompt redaction of the project as a JSON object, added to the configured one: {\"enabled\": true, \"types\": [\"email\", \"secret\"], \"rules\": [{\"name\": \"client\", \"terms\": [\"Acme Corp\"]}, {\"name\": \"account\", \"pattern\": \"ACC-\\\\d{6}\"}]}. Matches are replaced with placeholders such as [CLIENT_1] before prompts are sent to an LLM and restored in responses. 'none' removes it (optional)", Required: false},
 			},
 			Handler: p.handleProjectUpdate,
 			Hints:   nil,
@@ -245,6 +518,9 @@ func (p *Provider) getToolDefinitions() []toolspec.ToolDefinition {
 			Parameters: []toolspec.Parameter{
 				{Name: "project", Type: "string", Description: "Project name", Required: false},
 				{Name: "prefix", Type: "string", Description: "Optional path prefix filter", Required: false},
+				{Name: "limit", Type: "number", Description: "Maximum number of files to return (default: all)", Required: false},
+				{Name: "cursor", Type: "string", Description: "Opaque cursor from a previous response's next_cursor; returns the following page. Stable while items are being added.", Required: false},
+				{Name: "snapshot", Type: "string", Description: "Snapshot ID to read from instead of the live project (see project_snapshot_list)", Required: false},
 			},
 			Handler: p.handleProjectFileList,
 			Hints:   &toolspec.ToolHints{ReadOnly: toolspec.Allow(true)},
@@ -257,6 +533,7 @@ func (p *Provider) getToolDefinitions() []toolspec.ToolDefinition {
 				{Name: "path", Type: "string", Description: "File path within the project", Required: false},
 				{Name: "byte_offset", Type: "number", Description: "Byte position to start reading from, for chunked reading of large files (default: 0)", Required: false},
 				{Name: "max_bytes", Type: "number", Description: "Maximum bytes to return in this chunk, for chunked reading of large files (default: 0 = entire file)", Required: false},
+				{Name: "snapshot", Type: "string", Description: "Snapshot ID to read from instead of the live project (see project_snapshot_list)", Required: false},
 			},
 			Handler: p.handleProjectFileGet,
 			Hints:   &toolspec.ToolHints{ReadOnly: toolspec.Allow(true)},
@@ -333,23 +610,35 @@ func (p *Provider) getToolDefinitions() []toolspec.ToolDefinition {
 		},
 		{
 			Name:        global.ToolProjectFileConvert,
-			Description: "Convert files in a project to Markdown. Supports PDF, DOCX, and XLSX files.",
+			Description: "Convert files in a project to Markdown (<file>.md beside each). Supports PDF, DOCX, and XLSX files, plus the formats of the configured conversion pipeline (conversion.pipeline). Files are converted in parallel; files already converted are skipped, so an interrupted conversion can be run again. Reports the outcome of each file.",
 			Parameters: []toolspec.Parameter{
 				{Name: "project", Type: "string", Description: "Project name", Required: false},
 				{Name: "path", Type: "string", Description: "Path within project files directory. Must be a file if recursive=false, or a directory if recursive=true.", Required: false},
 				{Name: "recursive", Type: "boolean", Description: "If true, recursively convert all files in directory. If false, convert single file. Default: false.", Required: false},
+				{Name: "concurrency", Type: "number", Description: "Files converted at once, 1 to 16 (default: conversion.concurrency, 4)", Required: false},
+				{Name: "background", Type: "boolean", Description: "If true, return a job_id at once and convert in the background; poll project_file_convert_status for progress. Default: false (wait for the conversion).", Required: false},
 			},
 			Handler: p.handleProjectFileConvert,
 			Hints:   nil,
 		},
+		{
+			Name:        global.ToolProjectConvStatus,
+			Description: "Get the progress of a project's file conversion: files converted, skipped and failed so far, and the status of each file. Without job_id, lists the project's recent conversions.",
+			Parameters: []toolspec.Parameter{
+				{Name: "project", Type: "string", Description: "Project name", Required: false},
+				{Name: "job_id", Type: "string", Description: "Job ID returned by project_file_convert", Required: false},
+			},
+			Handler: p.handleProjectFileConvertStatus,
+			Hints:   &toolspec.ToolHints{ReadOnly: toolspec.Allow(true)},
+		},
 		{
 			Name:        global.ToolProjectFileExtract,
-			Description: "Extract a zip archive within a project's files directory. Extracts to a directory with the same name as the archive (without .zip extension) in the same location.",
+			Description: "Extract a zip archive within a project's files directory. Extracts to a directory with the same name as the archive (without .zip extension) in the same location. The configured import policy applies: files over the size limit or of a blocked type are left out and listed in 'rejected', and an archive over the total size limit is not extracted.",
 			Parameters: []toolspec.Parameter{
 				{Name: "project", Type: "string", Description: "Project name", Required: false},
 				{Name: "path", Type: "string", Description: "Path to the .zip file within the project files directory", Required: false},
 				{Name: "overwrite", Type: "boolean", Description: "If true, overwrite existing files during extraction. Default: false (skip existing files).", Required: false},
-				{Name: "convert", Type: "boolean", Description: "If true, convert extracted files (PDF, DOCX, XLSX) to Markdown after extraction. Default: false.", Required: false},
+				{Name: "convert", Type: "boolean", Description: "If true, convert extracted files (PDF, DOCX, XLSX and the formats of configured converters) to Markdown after extraction. Default: false.", Required: false},
 			},
 			Handler: p.handleProjectFileExtract,
 			Hints:   nil,
@@ -377,17 +666,23 @@ func (p *Provider) getToolDefinitions() []toolspec.ToolDefinition {
 		},
 		{
 			Name:        global.ToolLLMList,
-			Description: "List all configured LLMs with their IDs, names, and descriptions.",
+			Description: "List all configured LLMs with their IDs, names, and descriptions. LLMs that have been probed include their availability over the last 24 hours (last OK, availability percentage).",
 			Parameters:  []toolspec.Parameter{},
 			Handler:     p.handleLLMList,
 			Hints:       &toolspec.ToolHints{ReadOnly: toolspec.Allow(true)},
 		},
 		{
 			Name:        global.ToolLLMDispatch,
-			Description: "Send a prompt to a configured LLM.",
+			Description: "Send a prompt to a configured LLM. Optional per-call controls are passed to the LLM and recorded in the result.",
 			Parameters: []toolspec.Parameter{
 				{Name: "llm_id", Type: "string", Description: "ID of the LLM to use (see llm_list)", Required: false},
 				{Name: "prompt", Type: "string", Description: "The prompt to send to the LLM", Required: false},
+				{Name: "max_output_bytes", Type: "number", Description: "Keep at most this many bytes of the LLM's output; the result notes output_truncated when it was cut (default: all)", Required: false},
+				{Name: "working_dir", Type: "string", Description: "Absolute path of an existing directory to run the LLM in instead of its configured working directory", Required: false},
+				{Name: "priority", Type: "string", Description: "Call priority passed to the LLM: low, normal, or high", Required: false},
+				{Name: "cache_control", Type: "string", Description: "Prompt caching passed to the LLM: enabled or disabled (default: provider default)", Required: false},
+				{Name: "label", Type: "string", Description: "Tag for the call, passed to the LLM and recorded in the result and logs", Required: false},
+				{Name: "project", Type: "string", Description: "Project the call is made for; refused if the LLM is not in the project's allowed_llms", Required: false},
 			},
 			Handler: p.handleLLMDispatch,
 			Hints:   nil,
@@ -403,21 +698,21 @@ func (p *Provider) getToolDefinitions() []toolspec.ToolDefinition {
 		},
 		{
 			Name:        global.ToolHealth,
-			Description: "Check Maestro health status. Returns whether the system is healthy and any issues that need to be resolved (e.g. a missing base directory). When the host owns LLM dispatch, no LLM configuration is reported.",
+			Description: "Check Maestro health status. Returns whether the system is healthy and any issues that need to be resolved (e.g. a missing base directory or an LLM that failed its last availability probe), with a summary of each probed LLM's recent availability. When the host owns LLM dispatch, no LLM configuration is reported.",
 			Parameters:  []toolspec.Parameter{},
 			Handler:     p.handleHealth,
 			Hints:       &toolspec.ToolHints{ReadOnly: toolspec.Allow(true)},
 		},
 		{
 			Name:        global.ToolFileCopy,
-			Description: "Copy a file within or between domains (reference, playbooks, projects). More efficient than using get+put as it doesn't load file content into the conversation. Use this instead of get+put when copying files.",
+			Description: "Copy a file within or between domains (reference, shared library, playbooks, projects). More efficient than using get+put as it doesn't load file content into the conversation. Use this instead of get+put when copying files.",
 			Parameters: []toolspec.Parameter{
 				{Name: "from_path", Type: "string", Description: "Source file path", Required: false},
 				{Name: "to_path", Type: "string", Description: "Destination file path", Required: false},
-				{Name: "from_source", Type: "string", Description: "Source domain: 'project' (default), 'playbook', or 'reference'", Required: false},
+				{Name: "from_source", Type: "string", Description: "Source domain: 'project' (default), 'playbook', 'reference', or 'shared'", Required: false},
 				{Name: "from_project", Type: "string", Description: "Source project name (required when from_source is 'project')", Required: false},
 				{Name: "from_playbook", Type: "string", Description: "Source playbook name (required when from_source is 'playbook')", Required: false},
-				{Name: "to_source", Type: "string", Description: "Destination domain: 'project' (default) or 'playbook' (reference is read-only)", Required: false},
+				{Name: "to_source", Type: "string", Description: "Destination domain: 'project' (default) or 'playbook' (reference and shared are read-only)", Required: false},
 				{Name: "to_project", Type: "string", Description: "Destination project name (required when to_source is 'project')", Required: false},
 				{Name: "to_playbook", Type: "string", Description: "Destination playbook name (required when to_source is 'playbook')", Required: false},
 				{Name: "summary", Type: "string", Description: "Optional summary description for the destination file metadata", Required: false},
@@ -439,12 +734,12 @@ func (p *Provider) getToolDefinitions() []toolspec.ToolDefinition {
 		},
 		{
 			Name:        global.ToolFileImport,
-			Description: "Import external files into a project's files/imported/ directory. This bypasses the normal chroot restrictions to allow importing files from anywhere on the filesystem. Imported files can then be accessed via project_file_* tools.",
+			Description: "Import external files into a project's files/imported/ directory. This bypasses the normal chroot restrictions to allow importing files from anywhere on the filesystem. Imported files can then be accessed via project_file_* tools. The configured import policy applies: files over the size limit or of a blocked type (disk and VM images by default) are left out and listed in 'rejected', and an import over the total size limit fails.",
 			Parameters: []toolspec.Parameter{
 				{Name: "source", Type: "string", Description: "Source file or directory path (absolute path on the filesystem)", Required: false},
 				{Name: "project", Type: "string", Description: "Target project name to import files into", Required: false},
 				{Name: "recursive", Type: "boolean", Description: "If true, recursively import directories. Required when source is a directory.", Required: false},
-				{Name: "convert", Type: "boolean", Description: "If true, automatically convert imported files (PDF, DOCX, XLSX) to Markdown after import.", Required: false},
+				{Name: "convert", Type: "boolean", Description: "If true, automatically convert imported files (PDF, DOCX, XLSX and the formats of configured converters) to Markdown after import.", Required: false},
 			},
 			Handler: p.handleFileImport,
 			Hints:   nil,
@@ -454,6 +749,7 @@ func (p *Provider) getToolDefinitions() []toolspec.ToolDefinition {
 			Description: "List all reports in a project's reports directory.",
 			Parameters: []toolspec.Parameter{
 				{Name: "project", Type: "string", Description: "Project name", Required: false},
+				{Name: "snapshot", Type: "string", Description: "Snapshot ID to read from instead of the live project (see project_snapshot_list)", Required: false},
 			},
 			Handler: p.handleReportList,
 			Hints:   &toolspec.ToolHints{ReadOnly: toolspec.Allow(true)},
@@ -466,13 +762,26 @@ func (p *Provider) getToolDefinitions() []toolspec.ToolDefinition {
 				{Name: "report", Type: "string", Description: "Report filename (e.g., '20251219-1234-Audit-Report.md')", Required: false},
 				{Name: "byte_offset", Type: "number", Description: "Byte position to start reading from (default: 0)", Required: false},
 				{Name: "max_bytes", Type: "number", Description: "Maximum bytes to return (default: 0 = entire file)", Required: false},
+				{Name: "snapshot", Type: "string", Description: "Snapshot ID to read from instead of the live project (see project_snapshot_list)", Required: false},
 			},
 			Handler: p.handleReportRead,
 			Hints:   &toolspec.ToolHints{ReadOnly: toolspec.Allow(true)},
 		},
+		{
+			Name:        global.ToolReportSearch,
+			Description: "Search the content of Markdown reports, in one project or across all projects, to locate prior findings. Reports are split into sections by heading; a section matches when it contains every word of the query (case-insensitive). Returns the report, section heading, line and a snippet of each matching section, best matches first.",
+			Parameters: []toolspec.Parameter{
+				{Name: "query", Type: "string", Description: "Words to search for", Required: false},
+				{Name: "project", Type: "string", Description: "Project name (optional, searches all if omitted)", Required: false},
+				{Name: "limit", Type: "number", Description: "Maximum number of results", Required: false},
+				{Name: "offset", Type: "number", Description: "Number of results to skip", Required: false},
+			},
+			Handler: p.handleReportSearch,
+			Hints:   &toolspec.ToolHints{ReadOnly: toolspec.Allow(true)},
+		},
 		{
 			Name:        global.ToolReportStart,
-			Description: "Start a report session for a project. Sets a prefix (e.g., '20251219-1234-Audit-') that all subsequent report_append calls will use.",
+			Description: "Start a report session for a project. Sets a prefix (e.g., '20251219-1234-Audit-', formatted by the report_prefix config) that all subsequent report_append calls will use. A prefix already used by another session of the project gets -2, -3, ... added, or is refused when report_prefix.on_collision is 'refuse'.",
 			Parameters: []toolspec.Parameter{
 				{Name: "project", Type: "string", Description: "Project name", Required: false},
 				{Name: "title", Type: "string", Description: "Report title (used to generate prefix)", Required: false},
@@ -501,6 +810,51 @@ func (p *Provider) getToolDefinitions() []toolspec.ToolDefinition {
 			Handler: p.handleReportEnd,
 			Hints:   nil,
 		},
+		{
+			Name:        global.ToolReportSessions,
+			Description: "List the report sessions of a project, oldest first: each session's prefix, title, start and end times, whether it is active or was finalized, and the report files carrying its prefix.",
+			Parameters: []toolspec.Parameter{
+				{Name: "project", Type: "string", Description: "Project name", Required: false},
+			},
+			Handler: p.handleReportSessions,
+			Hints:   &toolspec.ToolHints{ReadOnly: toolspec.Allow(true)},
+		},
+		{
+			Name:        global.ToolReportFinalize,
+			Description: "Freeze the active report session as the engagement deliverable. Snapshots the session's reports, all result files, and the templates used into an immutable archive under reports/archive/<prefix>/ with a SHA256SUMS file and MANIFEST.json. Ends the session; new work continues into a new report.",
+			Parameters: []toolspec.Parameter{
+				{Name: "project", Type: "string", Description: "Project name", Required: false},
+			},
+			Handler: p.handleReportFinalize,
+			Hints:   nil,
+		},
+		{
+			Name:        global.ToolReportDebug,
+			Description: "Debug report template rendering for a single task. Returns the parsed JSON fields of the result, the merged template context (including _task_title, _qa_result and other metadata), the top-level fields the template references but the context lacks, any template error, and the rendered output.",
+			Parameters: []toolspec.Parameter{
+				{Name: "project", Type: "string", Description: "Project name", Required: false},
+				{Name: "uuid", Type: "string", Description: "Task UUID or external_id", Required: false},
+				{Name: "template", Type: "string", Description: "Template path to render with (default: the task set's worker_report_template, or qa_report_template for phase 'qa')", Required: false},
+				{Name: "suffix", Type: "string", Description: "Report suffix to select when the template is a multi-report JSON manifest (default: 'Report')", Required: false},
+				{Name: "phase", Type: "string", Description: "Result to render: 'worker' (default) or 'qa'", Required: false},
+			},
+			Handler: p.handleReportDebug,
+			Hints:   &toolspec.ToolHints{ReadOnly: toolspec.Allow(true)},
+		},
+		{
+			Name:        global.ToolDeliverableGenerate,
+			Description: "Fill a client-supplied DOCX template with the project's findings and save the formatted deliverable as a project file. The template can use {{name}} placeholders (project, project_title, date, generated_at, summary.total_tasks and other summary fields, or any key from data), content controls tagged with a value name, and table rows containing {{findings.<field>}} that repeat once per completed task (fields come from the worker's JSON response plus _task_id, _task_title, _qa_verdict, _confidence, _path and the other template metadata). Returns the output path and any placeholders left unfilled.",
+			Parameters: []toolspec.Parameter{
+				{Name: "project", Type: "string", Description: "Project name", Required: false},
+				{Name: "template", Type: "string", Description: "Path to the .docx template (project file path, or 'playbook-name/path' when source is 'playbook')", Required: false},
+				{Name: "source", Type: "string", Description: "Where the template lives: 'project' (default) or 'playbook'", Required: false},
+				{Name: "output", Type: "string", Description: "Project file path for the filled .docx (default: deliverables/<template file name>)", Required: false},
+				{Name: "path", Type: "string", Description: "Only include findings from task sets under this path prefix", Required: false},
+				{Name: "data", Type: "string", Description: "JSON object of extra template values, such as client name or engagement dates; overrides the built-in values", Required: false},
+			},
+			Handler: p.handleDeliverableGenerate,
+			Hints:   nil,
+		},
 		{
 			Name:        global.ToolListList,
 			Description: "List all lists in the specified source (project, playbook, or reference).",
@@ -593,6 +947,9 @@ func (p *Provider) getToolDefinitions() []toolspec.ToolDefinition {
 				{Name: "to_project", Type: "string", Description: "Destination project name (when to_source is 'project')", Required: false},
 				{Name: "to_playbook", Type: "string", Description: "Destination playbook name (when to_source is 'playbook')", Required: false},
 				{Name: "sample", Type: "number", Description: "Randomly sample N items from the source list instead of copying all. Useful for test audits.", Required: false},
+				{Name: "seed", Type: "number", Description: "Random seed for sample (positive integer). The same seed and source list give the same sample. If omitted, a seed is generated. The seed used is returned and recorded in the copied list's sampling metadata.", Required: false},
+				{Name: "sample_method", Type: "string", Description: "How sample items are selected: 'random' (uniform, default), 'stratified' (proportional random sample within each stratum, requires stratify_by) or 'systematic' (every k-th item from a random start). The methodology is recorded with the sampling metadata.", Required: false},
+				{Name: "stratify_by", Type: "string", Description: "Item field defining strata for stratified sampling: 'tag' (first tag), 'section' or 'source_doc'. Implies sample_method 'stratified'.", Required: false},
 			},
 			Handler: p.handleListCopy,
 			Hints:   nil,
@@ -686,26 +1043,29 @@ func (p *Provider) getToolDefinitions() []toolspec.ToolDefinition {
 				{Name: "complete", Type: "string", Description: "Filter by complete status (projects only): 'true', 'false', or '' (no filter)", Required: false},
 				{Name: "offset", Type: "number", Description: "Number of results to skip", Required: false},
 				{Name: "limit", Type: "number", Description: "Maximum number of results", Required: false},
+				{Name: "cursor", Type: "string", Description: "Opaque cursor from a previous response's next_cursor; returns the following page and overrides offset. Stable while items are being added.", Required: false},
 			},
 			Handler: p.handleListItemSearch,
 			Hints:   &toolspec.ToolHints{ReadOnly: toolspec.Allow(true)},
 		},
 		{
 			Name:        global.ToolListCreateTasks,
-			Description: "Create tasks from list items. Creates one task per item with item context appended to the prompt.",
+			Description: "Create tasks from list items. Creates one task per item with item context appended to the prompt. Several lists can be concatenated or crossed (e.g. each control tested on each system).",
 			Parameters: []toolspec.Parameter{
 				{Name: "list", Type: "string", Description: "List name", Required: false},
+				{Name: "lists", Type: "string", Description: "Comma-separated additional lists (same source) to combine with list", Required: false},
+				{Name: "combine", Type: "string", Description: "How list and lists are combined: 'concatenate' (default, one task per item of each list) or 'cross_product' (one task per combination of one item from each list, with every item's context in the prompt)", Required: false},
 				{Name: "project", Type: "string", Description: "Target project for created tasks", Required: false},
 				{Name: "type", Type: "string", Description: "Task type for all created tasks", Required: false},
 				{Name: "list_source", Type: "string", Description: "Source domain for the list: 'project' (default), 'playbook', or 'reference'", Required: false},
 				{Name: "list_project", Type: "string", Description: "Project containing the list (when list_source is 'project')", Required: false},
 				{Name: "list_playbook", Type: "string", Description: "Playbook containing the list (when list_source is 'playbook')", Required: false},
 				{Name: "path", Type: "string", Description: "Task set path for created tasks (e.g., 'analysis', 'analysis/code')", Required: false},
-				{Name: "title_template", Type: "string", Description: "Task title template. Use {{title}} for item title, {{id}} for item ID. Default: '{{title}}'", Required: false},
-				{Name: "priority", Type: "number", Description: "Task priority for all created tasks", Required: false},
+				{Name: "title_template", Type: "string", Description: "Task title template. Use {{title}} for item title, {{id}} for item ID (joined with ' / ' for cross products), or {{<list>.title}} and {{<list>.id}} for the item from a named list. Default: '{{title}}'", Required: false},
+				{Name: "priority", Type: "number", Description: "Scheduling priority of all created tasks, from 0 (default) to 100; higher priorities run first", Required: false},
 				{Name: "llm_model_id", Type: "string", Description: "LLM model ID for runner execution", Required: false},
 				{Name: "instructions_file", Type: "string", Description: "Path to instructions file. For 'playbook' source, path MUST start with playbook name: 'playbook-name/path/file.md'. For 'project' source (uses target project) or 'reference' source, use relative path: 'path/file.md'.", Required: false},
-				{Name: "instructions_file_source", Type: "string", Description: "Source type for instructions_file: 'project' (default - uses project's files directory), 'playbook' (uses playbook files), or 'reference' (uses embedded reference docs).", Required: false},
+				{Name: "instructions_file_source", Type: "string", Description: "Source type for instructions_file: 'project' (default - uses project's files directory), 'playbook' (uses playbook files), 'reference' (uses embedded reference docs), or 'shared' (uses the shared evidence library).", Required: false},
 				{Name: "instructions_text", Type: "string", Description: "Inline instructions text", Required: false},
 				{Name: "prompt", Type: "string", Description: "Base prompt (item context will be appended)", Required: false},
 				{Name: "qa_enabled", Type: "boolean", Description: "Enable QA phase for this task", Required: false},
@@ -715,6 +1075,9 @@ func (p *Provider) getToolDefinitions() []toolspec.ToolDefinition {
 				{Name: "qa_prompt", Type: "string", Description: "QA direct prompt text", Required: false},
 				{Name: "qa_llm_model_id", Type: "string", Description: "QA LLM model ID", Required: false},
 				{Name: "sample", Type: "number", Description: "Randomly sample N items from the list instead of using all items. Useful for test audits.", Required: false},
+				{Name: "seed", Type: "number", Description: "Random seed for sample (positive integer). The same seed and list give the same sample. If omitted, a seed is generated. The seed used is returned and recorded in the task set's sampling metadata.", Required: false},
+				{Name: "sample_method", Type: "string", Description: "How sample items are selected: 'random' (uniform, default), 'stratified' (proportional random sample within each stratum, requires stratify_by) or 'systematic' (every k-th item from a random start). The methodology is recorded with the sampling metadata.", Required: false},
+				{Name: "stratify_by", Type: "string", Description: "Item field defining strata for stratified sampling: 'tag' (first tag), 'section' or 'source_doc'. Implies sample_method 'stratified'.", Required: false},
 				{Name: "parallel", Type: "boolean", Description: "Enable parallel task execution. Set to true if tasks are independent and can run concurrently for efficiency. Default: false (sequential).", Required: false},
 			},
 			Handler: p.handleListCreateTasks,
@@ -735,6 +1098,19 @@ func (p *Provider) getToolDefinitions() []toolspec.ToolDefinition {
 				{Name: "qa_report_template", Type: "string", Description: "Path to markdown template for QA reports", Required: false},
 				{Name: "skip_validation", Type: "boolean", Description: "Skip schema validation and report generation for this task set (default: false)", Required: false},
 				{Name: "callback_url", Type: "string", Description: "URL to POST completion notification when tasks finish", Required: false},
+				{Name: "output_language", Type: "string", Description: "Required response language for this task set (e.g., 'fr'). Overrides the project output_language.", Required: false},
+				{Name: "qa_instructions_file", Type: "string", Description: "Default QA instructions file for tasks with QA enabled that do not set their own", Required: false},
+				{Name: "qa_instructions_file_source", Type: "string", Description: "Source for qa_instructions_file: 'project', 'playbook', 'reference', or 'shared'", Required: false},
+				{Name: "qa_instructions_text", Type: "string", Description: "Default QA inline instructions text for tasks with QA enabled that do not set their own", Required: false},
+				{Name: "qa_prompt", Type: "string", Description: "Default QA prompt for tasks with QA enabled that do not set their own", Required: false},
+				{Name: "qa_skip_rules", Type: "string", Description: "JSON array of rules that skip the QA call when the schema-validated worker response matches, e.g. [{\"name\":\"na\",\"conditions\":[{\"field\":\"result\",\"op\":\"equals\",\"value\":\"not_applicable\"}]}]. Ops: equals, not_equals, in (with values), exists. Requires worker_response_template.", Required: false},
+				{Name: "result_summary", Type: "string", Description: "JSON object setting the short summary stored in each result and returned by task_results summary mode: {\"field\": \"assessment.notes\", \"max_chars\": 200, \"llm_id\": \"cheap-llm\"}. field takes the text from a JSON response field (default: whole response); llm_id has an LLM summarize it instead of truncating (optional)", Required: false},
+				{Name: "max_cost_usd", Type: "number", Description: "Halt a run of this task set once its estimated LLM spend reaches this many USD (default: runner.limits.max_cost_usd from config; 0 = no limit)", Required: false},
+				{Name: "qa_halt_severity", Type: "string", Description: "Halt a run of this task set when QA reports this severity or worse in its optional 'severity' field: critical, high, medium or low (default: never halt)", Required: false},
+				{Name: "approval", Type: "string", Description: "JSON object selecting QA results that a human must sign off with task_approve or task_reject before the task is done: {\"escalate\": true, \"severity\": \"high\"}. escalate holds escalated tasks; severity holds tasks QA rated this severity or worse (optional)", Required: false},
+				{Name: "qa_fields", Type: "string", Description: "JSON object telling the runner where QA responses of a schema without the standard fields put them: {\"verdict\": \"$.assessment.outcome\", \"feedback\": \"$.assessment.notes\", \"severity\": \"$.risk\", \"verdicts\": {\"approved\": \"pass\", \"rejected\": \"fail\", \"rework\": \"escalate\"}}. Paths are dot-separated (optional '$.' prefix); verdicts maps the schema's values to pass, fail or escalate (optional)", Required: false},
+				{Name: "revision_prompt_template", Type: "string", Description: "Go template (playbook path or project file) replacing the standard QA feedback when a task is revised. Fields: .OriginalPrompt, .PriorResponse, .QAVerdict, .QASeverity, .QAFeedback, .QAResponse, .QAFindings (QA response as JSON), .Continued, .TaskID, .TaskTitle, .Project (optional)", Required: false},
+				{Name: "worker_tools", Type: "string", Description: "JSON object letting workers call read-only project tools during a task before giving their final answer: {\"max_calls\": 10, \"tools\": [\"project_file_get\", \"project_file_search\"]}. max_calls caps tool calls per task (default 10, max 50); tools defaults to project_file_list, project_file_get, project_file_search and list_get. Every call is logged in task history (optional)", Required: false},
 			},
 			Handler: p.handleTaskSetCreate,
 			Hints:   nil,
@@ -774,6 +1150,19 @@ func (p *Provider) getToolDefinitions() []toolspec.ToolDefinition {
 				{Name: "qa_report_template", Type: "string", Description: "Path to markdown template for QA reports", Required: false},
 				{Name: "skip_validation", Type: "string", Description: "Set skip_validation: 'true' or 'false' (optional)", Required: false},
 				{Name: "callback_url", Type: "string", Description: "URL to POST completion notification when tasks finish (optional)", Required: false},
+				{Name: "output_language", Type: "string", Description: "Required response language for this task set, or 'none' to fall back to the project setting (optional)", Required: false},
+				{Name: "qa_instructions_file", Type: "string", Description: "Default QA instructions file for tasks with QA enabled, or 'none' to remove it (optional)", Required: false},
+				{Name: "qa_instructions_file_source", Type: "string", Description: "Source for qa_instructions_file: 'project', 'playbook', 'reference', or 'shared' (optional)", Required: false},
+				{Name: "qa_instructions_text", Type: "string", Description: "Default QA inline instructions text, or 'none' to remove it (optional)", Required: false},
+				{Name: "qa_prompt", Type: "string", Description: "Default QA prompt, or 'none' to remove it (optional)", Required: false},
+				{Name: "qa_skip_rules", Type: "string", Description: "JSON array of QA skip rules replacing the current ones (see taskset_create), or 'none' to remove them (optional)", Required: false},
+				{Name: "result_summary", Type: "string", Description: "JSON object of result summary settings replacing the current ones (see taskset_create), or 'none' for the default (optional)", Required: false},
+				{Name: "max_cost_usd", Type: "number", Description: "Run cost limit in USD, or 0 to fall back to the config setting (optional)", Required: false},
+				{Name: "qa_halt_severity", Type: "string", Description: "QA severity that halts a run (see taskset_create), or 'none' to remove the policy (optional)", Required: false},
+				{Name: "approval", Type: "string", Description: "JSON object of approval settings replacing the current ones (see taskset_create), or 'none' to remove the gate (optional)", Required: false},
+				{Name: "qa_fields", Type: "string", Description: "JSON object of QA field mappings replacing the current ones (see taskset_create), or 'none' for the standard fields (optional)", Required: false},
+				{Name: "revision_prompt_template", Type: "string", Description: "Revision prompt template (see taskset_create), or 'none' for the standard QA feedback (optional)", Required: false},
+				{Name: "worker_tools", Type: "string", Description: "JSON object of worker tool settings replacing the current ones (see taskset_create), or 'none' to turn worker tool calls off (optional)", Required: false},
 			},
 			Handler: p.handleTaskSetUpdate,
 			Hints:   nil,
@@ -789,7 +1178,7 @@ func (p *Provider) getToolDefinitions() []toolspec.ToolDefinition {
 				{Name: "prompt", Type: "string", Description: "Inline prompt text", Required: false},
 				{Name: "instructions_text", Type: "string", Description: "Multi-line instructions text", Required: false},
 				{Name: "instructions_file", Type: "string", Description: "Instructions file path", Required: false},
-				{Name: "instructions_file_source", Type: "string", Description: "Source for instructions file: project, playbook, or reference", Required: false},
+				{Name: "instructions_file_source", Type: "string", Description: "Source for instructions file: project, playbook, reference, or shared", Required: false},
 				{Name: "callback_url", Type: "string", Description: "URL to POST completion notification", Required: false},
 			},
 			Handler: p.handleTaskDispatch,
@@ -821,6 +1210,49 @@ func (p *Provider) getToolDefinitions() []toolspec.ToolDefinition {
 			Handler: p.handleTaskSetReset,
 			Hints:   nil,
 		},
+		{
+			Name:        global.ToolTaskSetCopy,
+			Description: "Copy a task set, with its settings, templates and tasks, to a new path in the same project or another project, e.g. to re-run last quarter's audit structure against new evidence. Tasks keep their IDs and instructions but get new UUIDs, and their status is reset to waiting; results are not copied. Dependencies between the set's tasks are remapped to the copies.",
+			Parameters: []toolspec.Parameter{
+				{Name: "project", Type: "string", Description: "Project of the task set to copy", Required: false},
+				{Name: "path", Type: "string", Description: "Path of the task set to copy", Required: false},
+				{Name: "target_project", Type: "string", Description: "Project to copy into (default: project)", Required: false},
+				{Name: "target_path", Type: "string", Description: "Path of the copy (default: path; required when copying within the project)", Required: false},
+				{Name: "title", Type: "string", Description: "Title of the copy (default: the source title)", Required: false},
+				{Name: "external_id_suffix", Type: "string", Description: "Appended to the tasks' external IDs, which must be unique per project (e.g. '-q3')", Required: false},
+			},
+			Handler: p.handleTaskSetCopy,
+			Hints:   nil,
+		},
+		{
+			Name:        global.ToolPipelineApply,
+			Description: "Apply a pipeline file from a playbook to a project: creates the task sets and tasks it declares and updates those whose settings differ (templates, limits, LLM routing, QA policy, dependencies). Idempotent: task status and results are kept, and tasks the pipeline does not declare are left alone unless prune is set. Use dry_run to preview what would be created, updated or deleted, with the before and after value of each changed setting.",
+			Parameters: []toolspec.Parameter{
+				{Name: "project", Type: "string", Description: "Project name (must exist)", Required: false},
+				{Name: "playbook", Type: "string", Description: "Playbook containing the pipeline file", Required: false},
+				{Name: "file", Type: "string", Description: "Pipeline file path within the playbook (default: pipeline.json)", Required: false},
+				{Name: "dry_run", Type: "boolean", Description: "Report what applying the pipeline would do without changing anything (default: false)", Required: false},
+				{Name: "prune", Type: "boolean", Description: "Delete tasks of the pipeline's task sets that the pipeline does not declare (default: false)", Required: false},
+			},
+			Handler: p.handlePipelineApply,
+			Hints:   nil,
+		},
+		{
+			Name:        global.ToolTaskCreateFromTemplate,
+			Description: "Create a task from a task template: a reusable JSON task definition in a playbook (instructions, prompts, QA configuration, schemas, env, limits and priority) whose ${NAME} variables are replaced with the values given. The task set is created with the template's schemas if it does not exist.",
+			Parameters: []toolspec.Parameter{
+				{Name: "project", Type: "string", Description: "Project name", Required: false},
+				{Name: "playbook", Type: "string", Description: "Playbook containing the template", Required: false},
+				{Name: "template", Type: "string", Description: "Template file path within the playbook (e.g. 'task-templates/access-review.json')", Required: false},
+				{Name: "variables", Type: "string", Description: "JSON object of template variable values, e.g. {\"SYSTEM\": \"Payroll\"}; variables not given take their defaults", Required: false},
+				{Name: "path", Type: "string", Description: "Task set path (default: the template's path)", Required: false},
+				{Name: "title", Type: "string", Description: "Task title (default: the template's title)", Required: false},
+				{Name: "external_id", Type: "string", Description: "Your own identifier for the task, unique per project", Required: false},
+				{Name: "depends_on", Type: "string", Description: "Comma-separated UUIDs or external IDs of tasks that must be done first", Required: false},
+			},
+			Handler: p.handleTaskCreateFromTemplate,
+			Hints:   nil,
+		},
 		{
 			Name:        global.ToolTaskCreate,
 			Description: "Create a new task within a task set. At least one prompt field is required.",
@@ -829,10 +1261,19 @@ func (p *Provider) getToolDefinitions() []toolspec.ToolDefinition {
 				{Name: "path", Type: "string", Description: "Task set path", Required: false},
 				{Name: "title", Type: "string", Description: "Task title", Required: false},
 				{Name: "type", Type: "string", Description: "Task type for filtering/grouping", Required: false},
+				{Name: "external_id", Type: "string", Description: "Your own identifier for the task (e.g. spreadsheet row or ticket key), unique per project. Accepted anywhere a task UUID is.", Required: false},
+				{Name: "depends_on", Type: "string", Description: "Comma-separated UUIDs or external_ids of tasks (in any task set of the project) that must be done before this task runs", Required: false},
+				{Name: "env", Type: "string", Description: "JSON object of string parameters, e.g. {\"HOST\": \"web01\"}. Each is substituted for ${NAME} in the instructions and prompts, and exported to command LLMs", Required: false},
+				{Name: "max_worker", Type: "number", Description: "Maximum worker invocations for this task, overriding the task set's limit", Required: false},
+				{Name: "max_qa", Type: "number", Description: "Maximum QA iterations for this task, overriding the task set's limit", Required: false},
+				{Name: "max_retries", Type: "number", Description: "Maximum infrastructure retries for this task, overriding the task set's limit", Required: false},
+				{Name: "timeout", Type: "number", Description: "Timeout in seconds of each LLM call for this task, overriding the LLM's timeout", Required: false},
+				{Name: "priority", Type: "number", Description: "Scheduling priority from 0 (default) to 100; higher priorities run first", Required: false},
 				{Name: "instructions_file", Type: "string", Description: "Path to instructions file", Required: false},
-				{Name: "instructions_file_source", Type: "string", Description: "Source for instructions_file: 'project', 'playbook', or 'reference'", Required: false},
+				{Name: "instructions_file_source", Type: "string", Description: "Source for instructions_file: 'project', 'playbook', 'reference', or 'shared'", Required: false},
 				{Name: "instructions_text", Type: "string", Description: "Inline instructions text", Required: false},
 				{Name: "prompt", Type: "string", Description: "Direct prompt text", Required: false},
+				{Name: "evidence_files", Type: "string", Description: "Comma-separated project file paths or glob patterns (e.g. 'evidence/firewall.txt,logs/*.log,configs/**') whose contents are inlined in the worker prompt under an EVIDENCE section, in chunks that are shortened to fit the context window", Required: false},
 				{Name: "llm_model_id", Type: "string", Description: "LLM model ID for execution", Required: false},
 				{Name: "qa_enabled", Type: "boolean", Description: "Enable QA phase for this task", Required: false},
 				{Name: "qa_instructions_file", Type: "string", Description: "QA instructions file path", Required: false},
@@ -845,12 +1286,41 @@ func (p *Provider) getToolDefinitions() []toolspec.ToolDefinition {
 			Handler: p.handleTaskCreate,
 			Hints:   nil,
 		},
+		{
+			Name:        global.ToolTaskCreateBulk,
+			Description: "Create many tasks in a task set in one call, all or none. Each definition takes the task_create parameters. If any definition is invalid, nothing is created and the error of each rejected definition is returned by index.",
+			Parameters: []toolspec.Parameter{
+				{Name: "project", Type: "string", Description: "Project name", Required: false},
+				{Name: "path", Type: "string", Description: "Task set path", Required: false},
+				{Name: "tasks", Type: "string", Description: "JSON array of task definitions, e.g. [{\"title\": \"Control 1\", \"external_id\": \"C-1\", \"prompt\": \"...\", \"qa_enabled\": true}]. depends_on may name other definitions by external_id", Required: false},
+				{Name: "file", Type: "string", Description: "Project file containing the JSON array, instead of tasks", Required: false},
+				{Name: "dry_run", Type: "boolean", Description: "Only check the definitions (default: false)", Required: false},
+			},
+			Handler: p.handleTaskCreateBulk,
+			Hints:   nil,
+		},
+		{
+			Name:        global.ToolTaskCreateMapReduce,
+			Description: "Split a large project file into chunks and create a map task per chunk and a reduce task over their responses. Each chunk is saved under mapreduce/<path>/ and attached to its map task as evidence; the reduce task depends on all map tasks and receives their responses in its prompt. The task set is created if it does not exist.",
+			Parameters: []toolspec.Parameter{
+				{Name: "project", Type: "string", Description: "Project name", Required: false},
+				{Name: "path", Type: "string", Description: "Task set path", Required: false},
+				{Name: "file", Type: "string", Description: "Project file to split", Required: false},
+				{Name: "chunk_size", Type: "number", Description: "Bytes per chunk, split at line breaks where possible (default: 16000, minimum 1000, at most 200 chunks)", Required: false},
+				{Name: "title", Type: "string", Description: "Title of the tasks, suffixed with (map i of n) and (reduce)", Required: false},
+				{Name: "map_prompt", Type: "string", Description: "Prompt of each map task, run over one chunk", Required: false},
+				{Name: "reduce_prompt", Type: "string", Description: "Prompt of the reduce task, run over the map task responses", Required: false},
+				{Name: "llm_model_id", Type: "string", Description: "LLM for the map and reduce tasks (optional)", Required: false},
+			},
+			Handler: p.handleTaskCreateMapReduce,
+			Hints:   nil,
+		},
 		{
 			Name:        global.ToolTaskGet,
 			Description: "Get a task by UUID or by path and ID.",
 			Parameters: []toolspec.Parameter{
 				{Name: "project", Type: "string", Description: "Project name", Required: false},
-				{Name: "uuid", Type: "string", Description: "Task UUID (preferred)", Required: false},
+				{Name: "uuid", Type: "string", Description: "Task UUID or external_id (preferred)", Required: false},
 				{Name: "path", Type: "string", Description: "Task set path (required with id)", Required: false},
 				{Name: "id", Type: "number", Description: "Task ID within task set (required with path)", Required: false},
 			},
@@ -867,6 +1337,7 @@ func (p *Provider) getToolDefinitions() []toolspec.ToolDefinition {
 				{Name: "type", Type: "string", Description: "Filter by task type", Required: false},
 				{Name: "offset", Type: "number", Description: "Number of tasks to skip", Required: false},
 				{Name: "limit", Type: "number", Description: "Maximum number of tasks to return", Required: false},
+				{Name: "cursor", Type: "string", Description: "Opaque cursor from a previous response's next_cursor; returns the following page and overrides offset. Stable while items are being added.", Required: false},
 			},
 			Handler: p.handleTaskList,
 			Hints:   &toolspec.ToolHints{ReadOnly: toolspec.Allow(true)},
@@ -876,17 +1347,26 @@ func (p *Provider) getToolDefinitions() []toolspec.ToolDefinition {
 			Description: "Update a task's metadata, instructions, or prompts.",
 			Parameters: []toolspec.Parameter{
 				{Name: "project", Type: "string", Description: "Project name", Required: false},
-				{Name: "uuid", Type: "string", Description: "Task UUID", Required: false},
+				{Name: "uuid", Type: "string", Description: "Task UUID or external_id", Required: false},
 				{Name: "title", Type: "string", Description: "New title (optional)", Required: false},
 				{Name: "type", Type: "string", Description: "New type (optional)", Required: false},
-				{Name: "work_status", Type: "string", Description: "New work status (optional)", Required: false},
+				{Name: "work_status", Type: "string", Description: "New work status (optional). 'on_hold' parks the task so the runner skips it; set 'waiting' to release it", Required: false},
+				{Name: "hold_reason", Type: "string", Description: "Why the task is on hold, e.g. 'awaiting client evidence' (only with status on_hold; cleared when the task leaves on_hold)", Required: false},
+				{Name: "depends_on", Type: "string", Description: "Comma-separated UUIDs or external_ids of tasks that must be done first, replacing the current list; 'none' clears it. Rejected if it would create a cycle", Required: false},
+				{Name: "env", Type: "string", Description: "JSON object of string parameters substituted for ${NAME} in the instructions and prompts and exported to command LLMs, replacing the current ones; 'none' clears them", Required: false},
+				{Name: "max_worker", Type: "number", Description: "Maximum worker invocations for this task, overriding the task set's limit; 0 removes the override", Required: false},
+				{Name: "max_qa", Type: "number", Description: "Maximum QA iterations for this task, overriding the task set's limit; 0 removes the override", Required: false},
+				{Name: "max_retries", Type: "number", Description: "Maximum infrastructure retries for this task, overriding the task set's limit; 0 removes the override", Required: false},
+				{Name: "timeout", Type: "number", Description: "Timeout in seconds of each LLM call for this task, overriding the LLM's timeout; 0 removes the override", Required: false},
+				{Name: "priority", Type: "number", Description: "Scheduling priority from 0 (default) to 100; higher priorities run first", Required: false},
 				{Name: "instructions_file", Type: "string", Description: "Path to instructions file (validated before update)", Required: false},
-				{Name: "instructions_file_source", Type: "string", Description: "Source for instructions_file: 'project', 'playbook', or 'reference'", Required: false},
+				{Name: "instructions_file_source", Type: "string", Description: "Source for instructions_file: 'project', 'playbook', 'reference', or 'shared'", Required: false},
 				{Name: "instructions_text", Type: "string", Description: "Inline instructions text", Required: false},
 				{Name: "prompt", Type: "string", Description: "Direct prompt text", Required: false},
+				{Name: "evidence_files", Type: "string", Description: "Comma-separated project file paths or glob patterns inlined in the worker prompt as evidence, replacing the current ones; 'none' clears them", Required: false},
 				{Name: "llm_model_id", Type: "string", Description: "LLM model ID for task execution", Required: false},
 				{Name: "qa_instructions_file", Type: "string", Description: "Path to QA instructions file (validated before update)", Required: false},
-				{Name: "qa_instructions_file_source", Type: "string", Description: "Source for QA instructions_file: 'project', 'playbook', or 'reference'", Required: false},
+				{Name: "qa_instructions_file_source", Type: "string", Description: "Source for QA instructions_file: 'project', 'playbook', 'reference', or 'shared'", Required: false},
 				{Name: "qa_instructions_text", Type: "string", Description: "QA inline instructions text", Required: false},
 				{Name: "qa_prompt", Type: "string", Description: "QA direct prompt text", Required: false},
 				{Name: "qa_llm_model_id", Type: "string", Description: "QA LLM model ID", Required: false},
@@ -899,11 +1379,72 @@ func (p *Provider) getToolDefinitions() []toolspec.ToolDefinition {
 			Description: "Delete a task by UUID.",
 			Parameters: []toolspec.Parameter{
 				{Name: "project", Type: "string", Description: "Project name", Required: false},
-				{Name: "uuid", Type: "string", Description: "Task UUID", Required: false},
+				{Name: "uuid", Type: "string", Description: "Task UUID or external_id", Required: false},
 			},
 			Handler: p.handleTaskDelete,
 			Hints:   &toolspec.ToolHints{Destructive: toolspec.Allow(!p.markNonDestructive)},
 		},
+		{
+			Name:        global.ToolTaskBulkStatus,
+			Description: "Set the work status of every task matching a filter (path prefix, type, current status). Unsafe transitions are skipped and reported: tasks being processed, tasks already in the target status, and tasks set to 'done' without a result file. Refused while a run is in progress for the project.",
+			Parameters: []toolspec.Parameter{
+				{Name: "project", Type: "string", Description: "Project name", Required: false},
+				{Name: "path", Type: "string", Description: "Task set path prefix to filter", Required: false},
+				{Name: "type", Type: "string", Description: "Filter by task type", Required: false},
+				{Name: "from_status", Type: "string", Description: "Filter by current work status; 'processing' recovers tasks left behind by an interrupted run", Required: false},
+				{Name: "to_status", Type: "string", Description: "New work status: 'waiting', 'retry', 'failed', 'error', 'done', or 'on_hold'", Required: false},
+				{Name: "hold_reason", Type: "string", Description: "Why the tasks are on hold (only with to_status 'on_hold')", Required: false},
+				{Name: "dry_run", Type: "boolean", Description: "List the tasks that would change without updating them (default: false)", Required: false},
+			},
+			Handler: p.handleTaskBulkUpdateStatus,
+			Hints:   nil,
+		},
+		{
+			Name:        global.ToolTaskUpdateBulk,
+			Description: "Apply the same field updates to every task matching a filter, e.g. switch the waiting tasks under 'analysis/*' to another llm_model_id, or set the tasks QA escalated (qa_verdict 'escalate') to 'failed'. Only the fields given are changed; limits not given keep each task's value. Tasks being processed, tasks set to 'done' without a result file and tasks already up to date are skipped and reported. Refused while a run is in progress for the project.",
+			Parameters: []toolspec.Parameter{
+				{Name: "project", Type: "string", Description: "Project name", Required: false},
+				{Name: "path", Type: "string", Description: "Filter: task set path prefix, or a pattern with '*' (e.g. 'analysis/*')", Required: false},
+				{Name: "type", Type: "string", Description: "Filter by task type", Required: false},
+				{Name: "status", Type: "string", Description: "Filter by current work status", Required: false},
+				{Name: "qa_verdict", Type: "string", Description: "Filter by QA verdict: 'pass', 'fail' or 'escalate'", Required: false},
+				{Name: "set_type", Type: "string", Description: "New task type", Required: false},
+				{Name: "work_status", Type: "string", Description: "New work status: 'waiting', 'retry', 'failed', 'error', 'done', or 'on_hold'", Required: false},
+				{Name: "hold_reason", Type: "string", Description: "Why the tasks are on hold (only for tasks that are or become 'on_hold')", Required: false},
+				{Name: "priority", Type: "number", Description: "New priority", Required: false},
+				{Name: "max_worker", Type: "number", Description: "New maximum worker attempts (0 removes the override)", Required: false},
+				{Name: "max_qa", Type: "number", Description: "New maximum QA attempts (0 removes the override)", Required: false},
+				{Name: "max_retries", Type: "number", Description: "New maximum infrastructure retries (0 removes the override)", Required: false},
+				{Name: "timeout", Type: "number", Description: "New LLM call timeout in seconds (0 removes the override)", Required: false},
+				{Name: "instructions_file", Type: "string", Description: "New worker instructions file", Required: false},
+				{Name: "instructions_file_source", Type: "string", Description: "Source for instructions_file: 'project', 'playbook', 'reference', or 'shared'", Required: false},
+				{Name: "instructions_text", Type: "string", Description: "New worker instructions text", Required: false},
+				{Name: "prompt", Type: "string", Description: "New worker prompt", Required: false},
+				{Name: "llm_model_id", Type: "string", Description: "New worker LLM model ID", Required: false},
+				{Name: "qa_instructions_file", Type: "string", Description: "New QA instructions file", Required: false},
+				{Name: "qa_instructions_file_source", Type: "string", Description: "Source for qa_instructions_file: 'project', 'playbook', 'reference', or 'shared'", Required: false},
+				{Name: "qa_instructions_text", Type: "string", Description: "New QA instructions text", Required: false},
+				{Name: "qa_prompt", Type: "string", Description: "New QA prompt", Required: false},
+				{Name: "qa_llm_model_id", Type: "string", Description: "New QA LLM model ID", Required: false},
+				{Name: "dry_run", Type: "boolean", Description: "List the tasks that would change without updating them (default: false)", Required: false},
+			},
+			Handler: p.handleTaskUpdateBulk,
+			Hints:   nil,
+		},
+		{
+			Name:        global.ToolTaskEvidence,
+			Description: "Consolidate the evidence that task results report as missing (the standard 'missing_evidence' field in worker and QA responses) into one de-duplicated request list, optionally written to a project file and turned into on-hold follow-up tasks.",
+			Parameters: []toolspec.Parameter{
+				{Name: "project", Type: "string", Description: "Project name", Required: false},
+				{Name: "path", Type: "string", Description: "Task set path prefix to scan (optional, default: all)", Required: false},
+				{Name: "output_file", Type: "string", Description: "Project file to write the request list to as markdown, e.g. 'evidence-requests.md' (optional)", Required: false},
+				{Name: "followup_path", Type: "string", Description: "Existing task set to add one on-hold follow-up task per request to (optional). Requests that already have a task there are skipped", Required: false},
+				{Name: "followup_prompt", Type: "string", Description: "Instructions for follow-up tasks; the request and the tasks that raised it are appended (optional)", Required: false},
+				{Name: "followup_llm_model_id", Type: "string", Description: "LLM model ID for follow-up tasks (optional)", Required: false},
+			},
+			Handler: p.handleTaskEvidenceRequests,
+			Hints:   nil,
+		},
 		{
 			Name:        global.ToolTaskRun,
 			Description: "Run eligible tasks for a project. Tasks in 'waiting' or 'retry' status are executed. Returns immediately with count of tasks queued.",
@@ -919,9 +1460,19 @@ func (p *Provider) getToolDefinitions() []toolspec.ToolDefinition {
 			// via ToolCall.Notify when every task finishes.
 			Async: true,
 		},
+		{
+			Name:        global.ToolTaskRunResume,
+			Description: "Recover runs interrupted by a crash or restart, found from the run journal. Tasks left in flight are returned to waiting, interrupted dispatches are marked failed, and the most recent interrupted run is started again with the same path, type and parallel settings. Use dry_run to list interrupted runs and their in-flight tasks without changing anything.",
+			Parameters: []toolspec.Parameter{
+				{Name: "project", Type: "string", Description: "Project name", Required: false},
+				{Name: "dry_run", Type: "boolean", Description: "Only report interrupted runs (default: false)", Required: false},
+			},
+			Handler: p.handleTaskRunResume,
+			Hints:   nil,
+		},
 		{
 			Name:        global.ToolTaskStatus,
-			Description: "Get current status of tasks in a project, including counts by status and whether a run is in progress.",
+			Description: "Get current status of tasks in a project, including counts by status, pending tasks blocked by dependencies that are not done (with blocked_by), whether a run is in progress, and runs in recovery mode waiting for an LLM (with the next probe and abort deadline).",
 			Parameters: []toolspec.Parameter{
 				{Name: "project", Type: "string", Description: "Project name", Required: false},
 				{Name: "path", Type: "string", Description: "Task set path prefix to filter (optional)", Required: false},
@@ -930,6 +1481,29 @@ func (p *Provider) getToolDefinitions() []toolspec.ToolDefinition {
 			Handler: p.handleTaskStatus,
 			Hints:   &toolspec.ToolHints{ReadOnly: toolspec.Allow(true)},
 		},
+		{
+			Name:        global.ToolTaskEvents,
+			Description: "Get progress events recorded as tasks move through a run: task_started, llm_dispatched, validation_failed, qa_started, qa_skipped and task_finished (with the final status). Oldest first. Poll with since set to the last event's timestamp to see what is happening mid-run.",
+			Parameters: []toolspec.Parameter{
+				{Name: "project", Type: "string", Description: "Project name", Required: false},
+				{Name: "path", Type: "string", Description: "Task set path prefix to filter (optional)", Required: false},
+				{Name: "task_id", Type: "number", Description: "Only events for this task ID (optional)", Required: false},
+				{Name: "event", Type: "string", Description: "Only events of this type (optional)", Required: false},
+				{Name: "since", Type: "string", Description: "Only events after this RFC 3339 timestamp (optional)", Required: false},
+				{Name: "limit", Type: "number", Description: "Return only the most recent N matching events (default: 100)", Required: false},
+			},
+			Handler: p.handleTaskEvents,
+			Hints:   &toolspec.ToolHints{ReadOnly: toolspec.Allow(true)},
+		},
+		{
+			Name:        global.ToolTaskInflight,
+			Description: "List the LLM calls tasks are waiting on right now, across all runs, oldest first: task UUID and ID, project, path, phase (worker, qa or revision), LLM, elapsed time, prompt size and, for command LLMs, the process ID.",
+			Parameters: []toolspec.Parameter{
+				{Name: "project", Type: "string", Description: "Only calls for this project (optional)", Required: false},
+			},
+			Handler: p.handleTaskInflight,
+			Hints:   &toolspec.ToolHints{ReadOnly: toolspec.Allow(true)},
+		},
 		{
 			Name:        global.ToolTaskResults,
 			Description: "Get task execution results. Returns completed task results with their outputs.",
@@ -940,7 +1514,8 @@ func (p *Provider) getToolDefinitions() []toolspec.ToolDefinition {
 				{Name: "status", Type: "string", Description: "Filter by status: done, failed (optional)", Required: false},
 				{Name: "offset", Type: "number", Description: "Number of results to skip (default: 0)", Required: false},
 				{Name: "limit", Type: "number", Description: "Maximum number of results (default: 50)", Required: false},
-				{Name: "summary", Type: "boolean", Description: "If true, returns only task_id, task_uuid, task_title, work_status (default: false)", Required: false},
+				{Name: "cursor", Type: "string", Description: "Opaque cursor from a previous response's next_cursor; returns the following page and overrides offset. Stable while items are being added.", Required: false},
+				{Name: "summary", Type: "boolean", Description: "If true, returns only task_id, task_uuid, task_title, work_status and a short summary of the response (default: false)", Required: false},
 				{Name: "worker_pattern", Type: "string", Description: "Regex pattern to match against worker response (optional)", Required: false},
 				{Name: "qa_pattern", Type: "string", Description: "Regex pattern to match against QA response (optional). If both patterns provided, uses OR logic.", Required: false},
 			},
@@ -952,11 +1527,25 @@ func (p *Provider) getToolDefinitions() []toolspec.ToolDefinition {
 			Description: "Get a single task result by UUID. Returns worker/QA responses without history or prompts. Includes worker_response_template for supervisor updates.",
 			Parameters: []toolspec.Parameter{
 				{Name: "project", Type: "string", Description: "Project name", Required: false},
-				{Name: "uuid", Type: "string", Description: "Task UUID", Required: false},
+				{Name: "uuid", Type: "string", Description: "Task UUID or external_id", Required: false},
+				{Name: "snapshot", Type: "string", Description: "Snapshot ID to read from instead of the live project (see project_snapshot_list)", Required: false},
 			},
 			Handler: p.handleTaskResultGet,
 			Hints:   &toolspec.ToolHints{ReadOnly: toolspec.Allow(true)},
 		},
+		{
+			Name:        global.ToolTaskAttemptDiff,
+			Description: "Compare the responses of two attempts of a task. JSON responses are compared field by field (added, removed and changed fields by path, e.g. findings[2].severity); other responses line by line. Defaults to the last two attempts. Helps explain why QA-driven revisions oscillate or regress.",
+			Parameters: []toolspec.Parameter{
+				{Name: "project", Type: "string", Description: "Project name", Required: false},
+				{Name: "uuid", Type: "string", Description: "Task UUID or external_id", Required: false},
+				{Name: "role", Type: "string", Description: "Attempts to compare: worker (default) or qa", Required: false},
+				{Name: "from", Type: "number", Description: "Invocation number of the earlier attempt (default: the attempt before 'to')", Required: false},
+				{Name: "to", Type: "number", Description: "Invocation number of the later attempt (default: the latest)", Required: false},
+			},
+			Handler: p.handleTaskAttemptDiff,
+			Hints:   &toolspec.ToolHints{ReadOnly: toolspec.Allow(true)},
+		},
 		{
 			Name:        global.ToolTaskReport,
 			Description: "Generate a report from task results. Supports filtering and multiple output formats.",
@@ -966,8 +1555,8 @@ func (p *Provider) getToolDefinitions() []toolspec.ToolDefinition {
 				{Name: "status", Type: "string", Description: "Filter by work status (optional)", Required: false},
 				{Name: "type", Type: "string", Description: "Filter by task type (optional)", Required: false},
 				{Name: "qa_passed", Type: "boolean", Description: "Filter by QA passed status (optional)", Required: false},
-				{Name: "qa_severity", Type: "string", Description: "Filter by QA severity (optional)", Required: false},
-				{Name: "format", Type: "string", Description: "Output format: markdown (default) or json", Required: false},
+				{Name: "qa_severity", Type: "string", Description: "Only include tasks QA rated at least this severe: critical, high, medium or low (optional)", Required: false},
+				{Name: "format", Type: "string", Description: "Output format: markdown (default), json, html (styled with the report_output theme) or pdf (requires output)", Required: false},
 				{Name: "output", Type: "string", Description: "File path to save report (optional)", Required: false},
 			},
 			Handler: p.handleTaskReport,
@@ -975,21 +1564,48 @@ func (p *Provider) getToolDefinitions() []toolspec.ToolDefinition {
 		},
 		{
 			Name:        global.ToolSupervisorUpdate,
-			Description: "Allows a supervisor to replace the worker response with their own content. The response must pass template validation. History is append-only.",
+			Description: "Allows a supervisor to replace the worker response with their own content, or to patch some of its fields. The result must pass template validation. History is append-only; the result file records the fields each edit changed, and reports mark the task as edited by a supervisor.",
 			Parameters: []toolspec.Parameter{
 				{Name: "project", Type: "string", Description: "Project name", Required: true},
-				{Name: "uuid", Type: "string", Description: "Task UUID", Required: true},
-				{Name: "response", Type: "string", Description: "Supervisor's replacement response (must match worker_response_template if defined)", Required: true},
+				{Name: "uuid", Type: "string", Description: "Task UUID or external_id", Required: true},
+				{Name: "response", Type: "string", Description: "Supervisor's replacement response (must match worker_response_template if defined). Provide this or patch.", Required: false},
+				{Name: "patch", Type: "string", Description: "JSON merge patch applied to the current JSON worker response instead of replacing it, e.g. {\"rating\": \"partial\", \"notes\": null}: members replace those of the response, null removes them and nested objects are merged. Provide this or response.", Required: false},
 			},
 			Handler: p.handleSupervisorUpdate,
 			Hints:   nil,
 		},
+		{
+			Name:        global.ToolTaskApprove,
+			Description: "Approve a task awaiting approval (held by its task set's approval gate). The task becomes done, so dependent tasks can run and reports include its result. The decision is appended to the task history.",
+			Parameters: []toolspec.Parameter{
+				{Name: "project", Type: "string", Description: "Project name", Required: true},
+				{Name: "uuid", Type: "string", Description: "Task UUID or external_id", Required: true},
+				{Name: "approver", Type: "string", Description: "Who approved the result (optional)", Required: false},
+				{Name: "note", Type: "string", Description: "Approver's note, recorded in the task history (optional)", Required: false},
+			},
+			Handler: p.handleTaskApprove,
+			Hints:   nil,
+		},
+		{
+			Name:        global.ToolTaskReject,
+			Description: "Reject a task awaiting approval (held by its task set's approval gate). The task fails with error_code 'approval_rejected' and its result is left out of reports; reset it to run it again. The decision is appended to the task history.",
+			Parameters: []toolspec.Parameter{
+				{Name: "project", Type: "string", Description: "Project name", Required: true},
+				{Name: "uuid", Type: "string", Description: "Task UUID or external_id", Required: true},
+				{Name: "note", Type: "string", Description: "Why the result was rejected, recorded in the task history", Required: true},
+				{Name: "approver", Type: "string", Description: "Who rejected the result (optional)", Required: false},
+			},
+			Handler: p.handleTaskReject,
+			Hints:   nil,
+		},
 		{
 			Name:        global.ToolReportCreate,
-			Description: "Generate reports from task results. Uses the same report generation logic as the runner. Supports optional path filtering.",
+			Description: "Generate reports from task results. Uses the same report generation logic as the runner. Supports optional path filtering. Each Markdown report can also be rendered as HTML and PDF beside it, for every report via formats or per report via the template manifest's formats.",
 			Parameters: []toolspec.Parameter{
 				{Name: "project", Type: "string", Description: "Project name", Required: true},
 				{Name: "path", Type: "string", Description: "Task set path prefix to filter (optional)", Required: false},
+				{Name: "formats", Type: "string", Description: "Comma-separated renderings written beside each Markdown report: 'html', 'pdf' (default: those of the template manifest)", Required: false},
+				{Name: "regenerate", Type: "boolean", Description: "Rebuild each report from scratch instead of updating it in place, dropping content appended with report_append (default: false)", Required: false},
 			},
 			Handler: p.handleReportCreate,
 			Hints:   nil,
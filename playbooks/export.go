@@ -0,0 +1,178 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package playbooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+	"github.com/google/uuid"
+)
+
+// versionPattern validates bundle versions used in archive names (e.g. 1.2.0, 2026-q1)
+var versionPattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+// Export writes a playbook to a bundle in the exports directory: a zip or tar.gz
+// archive of all its files with a manifest recording the name, version,
+// description and the checksum of every file. The archive is named
+// <playbook>-<version>.<format>, or <playbook>-<timestamp>.<format> without a
+// version; an existing bundle is never overwritten.
+func (s *Service) Export(name, version, description, format string) (*global.ExportResult, error) {
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+	playbookPath := s.playbookDir(name)
+	if !global.DirExists(playbookPath) {
+		return nil, fmt.Errorf("playbook '%s' not found", name)
+	}
+	if version != "" && !versionPattern.MatchString(version) {
+		return nil, fmt.Errorf("invalid version: %s (use letters, digits, dots, hyphens and underscores)", version)
+	}
+	if format == "" {
+		format = global.ExportFormatZip
+	}
+	if format != global.ExportFormatZip && format != global.ExportFormatTarGz {
+		return nil, fmt.Errorf("invalid format: %s (must be %s or %s)", format, global.ExportFormatZip, global.ExportFormatTarGz)
+	}
+
+	label := version
+	if label == "" {
+		label = time.Now().Format("20060102-150405")
+	}
+	archive := fmt.Sprintf("%s-%s.%s", name, label, format)
+	archivePath := filepath.Join(s.exportsDir, archive)
+	if global.FileExists(archivePath) {
+		return nil, fmt.Errorf("bundle already exists in exports directory: %s (export under a new version)", archive)
+	}
+
+	manifest := func(files []global.ExportFile) ([]byte, error) {
+		return json.MarshalIndent(global.ExportManifest{
+			Kind:           global.ExportKindPlaybook,
+			FormatVersion:  global.ExportFormatVersion,
+			Name:           name,
+			Version:        version,
+			Description:    description,
+			MaestroVersion: global.Version,
+			ExportedAt:     time.Now(),
+			Files:          files,
+		}, "", "  ")
+	}
+
+	mutex := s.getPathMutex(playbookPath)
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	// The version history stays with this instance; an imported playbook starts a new one
+	files, err := global.WriteDirArchive(playbookPath, archivePath, global.ExportManifestFile, manifest,
+		func(rel string, isDir bool) bool {
+			return isDir && rel == global.PlaybookVersionsDir
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+
+	s.logger.Infof("Exported playbook %s to %s (%d files)", name, archivePath, len(files))
+	return &global.ExportResult{
+		Name:      name,
+		Archive:   archive,
+		Path:      archivePath,
+		Format:    format,
+		Files:     len(files),
+		SizeBytes: info.Size(),
+	}, nil
+}
+
+// Import creates a playbook from a bundle in the exports directory written by
+// Export. Every file is checked against the manifest checksums before the
+// playbook is created. The playbook keeps its exported name unless name is
+// given; an existing playbook is never overwritten.
+func (s *Service) Import(archive, name string) (*global.ImportResult, error) {
+	if archive == "" || archive != filepath.Base(archive) || strings.HasPrefix(archive, ".") || strings.ContainsAny(archive, `/\`) {
+		return nil, fmt.Errorf("invalid archive name: %s (use a file name in the exports directory)", archive)
+	}
+	archivePath := filepath.Join(s.exportsDir, archive)
+	if !global.FileExists(archivePath) {
+		return nil, fmt.Errorf("archive not found in exports directory: %s", archive)
+	}
+	if name != "" {
+		if err := validateName(name); err != nil {
+			return nil, err
+		}
+	}
+
+	// Extract beside the playbooks so the final move is a rename. List skips
+	// hidden directories, so the partial import is never visible.
+	if err := os.MkdirAll(s.baseDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create playbooks directory: %w", err)
+	}
+	tmpDir := filepath.Join(s.baseDir, ".import-"+uuid.New().String())
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	data, extracted, err := global.ExtractDirArchive(archivePath, tmpDir, global.ExportManifestFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest global.ExportManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", global.ExportManifestFile, err)
+	}
+	if manifest.Kind != global.ExportKindPlaybook {
+		return nil, fmt.Errorf("archive contains a %s, not a playbook", manifest.Kind)
+	}
+	if manifest.FormatVersion > global.ExportFormatVersion {
+		return nil, fmt.Errorf("archive format version %d is newer than this instance supports (%d); upgrade %s",
+			manifest.FormatVersion, global.ExportFormatVersion, global.ProgramName)
+	}
+	if err := global.VerifyExportFiles(manifest.Files, extracted); err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = manifest.Name
+		if err := validateName(name); err != nil {
+			return nil, fmt.Errorf("archive has an invalid playbook name: %w", err)
+		}
+	}
+
+	playbookPath := s.playbookDir(name)
+	mutex := s.getPathMutex(playbookPath)
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if global.DirExists(playbookPath) {
+		return nil, fmt.Errorf("playbook '%s' already exists (import it under another name)", name)
+	}
+	// An empty playbook exports no files, so the extraction directory may not exist
+	if err := os.MkdirAll(tmpDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to import playbook: %w", err)
+	}
+	if err := os.Rename(tmpDir, playbookPath); err != nil {
+		return nil, fmt.Errorf("failed to import playbook: %w", err)
+	}
+
+	s.logger.Infof("Imported playbook %s from %s (%d files)", name, archivePath, len(extracted))
+	return &global.ImportResult{
+		Name:           name,
+		Archive:        archive,
+		ExportedName:   manifest.Name,
+		Version:        manifest.Version,
+		Description:    manifest.Description,
+		MaestroVersion: manifest.MaestroVersion,
+		ExportedAt:     manifest.ExportedAt,
+		Files:          len(extracted),
+	}, nil
+}
@@ -34,8 +34,11 @@ func (s *Service) ListFiles(playbookName, prefix string) ([]FileItem, error) {
 			return nil // Skip files we can't read
 		}
 
-		// Skip directories
+		// Skip directories, including the version history
 		if info.IsDir() {
+			if path == filepath.Join(playbookPath, global.PlaybookVersionsDir) {
+				return filepath.SkipDir
+			}
 			return nil
 		}
 
@@ -169,6 +172,28 @@ func (s *Service) GetFile(playbookName, path string, offset, maxBytes int64) (*F
 	return item, nil
 }
 
+// ReadBinaryFile reads a whole playbook file as raw bytes, without the UTF-8
+// check GetFile applies. Used for binary templates such as DOCX files.
+func (s *Service) ReadBinaryFile(playbookName, path string) ([]byte, error) {
+	absPath, err := s.validateFilePath(playbookName, path)
+	if err != nil {
+		return nil, err
+	}
+
+	mutex := s.getPathMutex(absPath)
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	content, err := os.ReadFile(absPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("file not found: %s", path)
+		}
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return content, nil
+}
+
 // PutFile creates or overwrites a file in a playbook.
 func (s *Service) PutFile(playbookName, path, content, summary string) (bool, error) {
 	absPath, err := s.validateFilePath(playbookName, path)
@@ -189,8 +214,11 @@ func (s *Service) PutFile(playbookName, path, content, summary string) (bool, er
 	_, err = os.Stat(absPath)
 	exists := err == nil
 
-	// Write content atomically
-	if err := global.AtomicWrite(absPath, []byte(content)); err != nil {
+	// Write content atomically, recording a new playbook version
+	change := Change{Action: global.PlaybookChangePut, Path: path, Created: !exists}
+	if _, err := s.recordChange(playbookName, change, absPath, func() error {
+		return global.AtomicWrite(absPath, []byte(content))
+	}); err != nil {
 		return false, err
 	}
 
@@ -245,8 +273,11 @@ func (s *Service) AppendFile(playbookName, path, content, summary string) error
 	// Append content
 	newContent := existingContent + content
 
-	// Write content atomically
-	if err := global.AtomicWrite(absPath, []byte(newContent)); err != nil {
+	// Write content atomically, recording a new playbook version
+	change := Change{Action: global.PlaybookChangeAppend, Path: path, Created: !exists}
+	if _, err := s.recordChange(playbookName, change, absPath, func() error {
+		return global.AtomicWrite(absPath, []byte(newContent))
+	}); err != nil {
 		return err
 	}
 
@@ -318,9 +349,15 @@ func (s *Service) EditFile(playbookName, path, oldString, newString string, repl
 		newContent = strings.Replace(content, oldString, newString, 1)
 	}
 
-	// Write updated content atomically
-	if err := global.AtomicWrite(absPath, []byte(newContent)); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
+	// Write updated content atomically, recording a new playbook version
+	change := Change{Action: global.PlaybookChangeEdit, Path: path}
+	if _, err := s.recordChange(playbookName, change, absPath, func() error {
+		if err := global.AtomicWrite(absPath, []byte(newContent)); err != nil {
+			return fmt.Errorf("failed to write file: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return err
 	}
 
 	// Update metadata (preserve existing summary)
@@ -378,9 +415,15 @@ func (s *Service) RenameFile(playbookName, fromPath, toPath string) error {
 		return fmt.Errorf("failed to create destination directory: %w", err)
 	}
 
-	// Rename file
-	if err := os.Rename(absFromPath, absToPath); err != nil {
-		return fmt.Errorf("failed to rename file: %w", err)
+	// Rename file, recording a new playbook version
+	change := Change{Action: global.PlaybookChangeRename, Path: fromPath, NewPath: toPath}
+	if _, err := s.recordChange(playbookName, change, absFromPath, func() error {
+		if err := os.Rename(absFromPath, absToPath); err != nil {
+			return fmt.Errorf("failed to rename file: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return err
 	}
 
 	// Rename metadata file if exists
@@ -410,9 +453,15 @@ func (s *Service) DeleteFile(playbookName, path string) error {
 		return fmt.Errorf("file not found: %s", path)
 	}
 
-	// Delete file
-	if err := os.Remove(absPath); err != nil {
-		return fmt.Errorf("failed to delete file: %w", err)
+	// Delete file, recording a new playbook version
+	change := Change{Action: global.PlaybookChangeDelete, Path: path}
+	if _, err := s.recordChange(playbookName, change, absPath, func() error {
+		if err := os.Remove(absPath); err != nil {
+			return fmt.Errorf("failed to delete file: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return err
 	}
 
 	// Delete metadata file if exists
@@ -464,6 +513,9 @@ func (s *Service) Search(playbookName, query string, limit, offset int) ([]FileI
 				return nil
 			}
 
+			if info.IsDir() && path == filepath.Join(playbookPath, global.PlaybookVersionsDir) {
+				return filepath.SkipDir
+			}
 			if info.IsDir() || strings.HasSuffix(path, global.MetaSuffix) {
 				return nil
 			}
@@ -0,0 +1,248 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package playbooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// LintIssue is one problem found by Lint
+type LintIssue struct {
+	Check    string `json:"check"`    // e.g. "schemas" or "links"
+	Severity string `json:"severity"` // "error" or "warning"
+	Path     string `json:"path,omitempty"`
+	Message  string `json:"message"`
+}
+
+// LintReport is the outcome of checking a playbook's structure. Score starts
+// at 100 and loses points for each error and warning; a playbook passes when
+// it has no errors.
+type LintReport struct {
+	Playbook string         `json:"playbook"`
+	Score    int            `json:"score"`
+	Passed   bool           `json:"passed"`
+	Files    int            `json:"files"`
+	Errors   int            `json:"errors"`
+	Warnings int            `json:"warnings"`
+	Checks   map[string]int `json:"checks"` // Issues found by each check (0 = clean)
+	Issues   []LintIssue    `json:"issues,omitempty"`
+}
+
+// lintReporter records an issue found by a lint check
+type lintReporter func(check, severity, filePath, format string, args ...interface{})
+
+// markdownLink matches the target of an inline Markdown link or image
+var markdownLink = regexp.MustCompile(`\]\(([^)\s]+)(?:\s+"[^"]*")?\)`)
+
+// lintDirs are the directories a playbook is expected to have
+var lintDirs = []string{"schemas", "templates", "instructions"}
+
+// Lint checks a playbook for the structural pieces Maestro expects: the
+// procedure document and standard directories, schemas that parse as JSON
+// Schema, instructions files no larger than maxInstructionsKB (the default
+// threshold when 0), templates referenced by lists that exist, and relative
+// Markdown links that resolve within the playbook.
+func (s *Service) Lint(name string, maxInstructionsKB int) (*LintReport, error) {
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+	if !s.Exists(name) {
+		return nil, fmt.Errorf("playbook '%s' not found", name)
+	}
+	if maxInstructionsKB <= 0 {
+		maxInstructionsKB = global.DefaultLintInstructionsKB
+	}
+
+	files, err := s.ListFiles(name, "")
+	if err != nil {
+		return nil, err
+	}
+
+	report := &LintReport{
+		Playbook: name,
+		Files:    len(files),
+		Checks: map[string]int{
+			global.LintCheckStructure:     0,
+			global.LintCheckSchemas:       0,
+			global.LintCheckInstructions:  0,
+			global.LintCheckListTemplates: 0,
+			global.LintCheckLinks:         0,
+		},
+	}
+	var add lintReporter = func(check, severity, filePath, format string, args ...interface{}) {
+		report.Issues = append(report.Issues, LintIssue{Check: check, Severity: severity, Path: filePath, Message: fmt.Sprintf(format, args...)})
+		report.Checks[check]++
+		if severity == global.LintSeverityError {
+			report.Errors++
+		} else {
+			report.Warnings++
+		}
+	}
+
+	exists := make(map[string]bool, len(files))
+	for _, f := range files {
+		exists[f.Path] = true
+	}
+	hasDir := func(dir string) bool {
+		for p := range exists {
+			if strings.HasPrefix(p, dir+"/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	if !exists["procedure.md"] {
+		add(global.LintCheckStructure, global.LintSeverityWarning, "procedure.md", "missing procedure.md describing how to use the playbook")
+	}
+	for _, dir := range lintDirs {
+		if !hasDir(dir) {
+			add(global.LintCheckStructure, global.LintSeverityWarning, dir+"/", "no files in %s/", dir)
+		}
+	}
+
+	for _, f := range files {
+		absPath := filepath.Join(s.playbookDir(name), filepath.FromSlash(f.Path))
+		switch {
+		case strings.HasPrefix(f.Path, "schemas/") && strings.HasSuffix(f.Path, ".json"):
+			data, err := os.ReadFile(absPath)
+			if err != nil {
+				add(global.LintCheckSchemas, global.LintSeverityError, f.Path, "cannot read schema: %v", err)
+			} else if err := checkSchema(string(data)); err != nil {
+				add(global.LintCheckSchemas, global.LintSeverityError, f.Path, "%v", err)
+			}
+
+		case strings.HasPrefix(f.Path, "instructions/"):
+			if f.SizeBytes == 0 {
+				add(global.LintCheckInstructions, global.LintSeverityWarning, f.Path, "instructions file is empty")
+			} else if kb := (f.SizeBytes + 1023) / 1024; kb > int64(maxInstructionsKB) {
+				add(global.LintCheckInstructions, global.LintSeverityError, f.Path, "instructions file is %d KB, over the %d KB threshold; split it or move reference material to files the worker reads", kb, maxInstructionsKB)
+			}
+
+		case strings.HasPrefix(f.Path, "lists/") && strings.HasSuffix(f.Path, ".json"):
+			s.lintListTemplates(name, f.Path, absPath, exists, add)
+		}
+
+		if strings.HasSuffix(f.Path, ".md") {
+			lintLinks(f.Path, absPath, exists, add)
+		}
+	}
+
+	report.Score = 100 - report.Errors*global.LintErrorPenalty - report.Warnings*global.LintWarningPenalty
+	if report.Score < 0 {
+		report.Score = 0
+	}
+	report.Passed = report.Errors == 0
+
+	s.logger.Debugf("Linted playbook '%s': score %d, %d error(s), %d warning(s)", name, report.Score, report.Errors, report.Warnings)
+	return report, nil
+}
+
+// checkSchema reports whether content is valid JSON that compiles as a JSON Schema
+func checkSchema(content string) error {
+	if !json.Valid([]byte(content)) {
+		return fmt.Errorf("schema is not valid JSON")
+	}
+	if _, err := gojsonschema.NewSchema(gojsonschema.NewStringLoader(content)); err != nil {
+		return fmt.Errorf("invalid JSON Schema: %v", err)
+	}
+	return nil
+}
+
+// lintListTemplates checks that the templates a list carries into task sets exist.
+// References are "playbook/path" or inline JSON schemas; other paths resolve
+// against the files of the project the tasks are created in and are noted only.
+func (s *Service) lintListTemplates(name, listPath, absPath string, exists map[string]bool, add lintReporter) {
+	data, err := os.ReadFile(absPath)
+	if err != nil {
+		add(global.LintCheckListTemplates, global.LintSeverityError, listPath, "cannot read list: %v", err)
+		return
+	}
+	var list global.List
+	if err := json.Unmarshal(data, &list); err != nil {
+		add(global.LintCheckListTemplates, global.LintSeverityError, listPath, "list is not valid JSON: %v", err)
+		return
+	}
+	if list.Templates == nil {
+		return
+	}
+
+	refs := []struct{ field, ref string }{
+		{"worker_response_template", list.Templates.WorkerResponseTemplate},
+		{"worker_report_template", list.Templates.WorkerReportTemplate},
+		{"qa_response_template", list.Templates.QAResponseTemplate},
+		{"qa_report_template", list.Templates.QAReportTemplate},
+	}
+	for _, r := range refs {
+		ref := strings.TrimSpace(r.ref)
+		switch {
+		case ref == "":
+		case strings.HasPrefix(ref, "{"):
+			if err := checkSchema(ref); err != nil {
+				add(global.LintCheckListTemplates, global.LintSeverityError, listPath, "%s: inline %v", r.field, err)
+			}
+		case !strings.Contains(ref, "/"):
+			add(global.LintCheckListTemplates, global.LintSeverityWarning, listPath, "%s %q resolves against project files and cannot be checked; use playbook-name/path", r.field, ref)
+		default:
+			playbook, filePath, _ := strings.Cut(ref, "/")
+			if playbook == name {
+				if !exists[filePath] {
+					add(global.LintCheckListTemplates, global.LintSeverityError, listPath, "%s %q does not exist", r.field, ref)
+				}
+			} else if absRef, err := s.validateFilePath(playbook, filePath); err != nil || !global.FileExists(absRef) {
+				add(global.LintCheckListTemplates, global.LintSeverityError, listPath, "%s %q does not exist", r.field, ref)
+			} else {
+				add(global.LintCheckListTemplates, global.LintSeverityWarning, listPath, "%s %q refers to playbook %s, which must be distributed alongside", r.field, ref, playbook)
+			}
+		}
+	}
+}
+
+// lintLinks checks that the relative links of a Markdown file resolve to files
+// of the playbook
+func lintLinks(mdPath, absPath string, exists map[string]bool, add lintReporter) {
+	data, err := os.ReadFile(absPath)
+	if err != nil {
+		return
+	}
+	for _, m := range markdownLink.FindAllStringSubmatch(string(data), -1) {
+		target := m[1]
+		if target == "" || strings.Contains(target, "{{") || strings.HasPrefix(target, "#") || strings.HasPrefix(target, "/") || strings.Contains(target, "://") || strings.HasPrefix(target, "mailto:") {
+			continue
+		}
+		target, _, _ = strings.Cut(target, "#")
+		target, _, _ = strings.Cut(target, "?")
+
+		resolved := path.Clean(path.Join(path.Dir(mdPath), target))
+		if resolved == ".." || strings.HasPrefix(resolved, "../") {
+			add(global.LintCheckLinks, global.LintSeverityError, mdPath, "link %q points outside the playbook", m[1])
+			continue
+		}
+		if exists[resolved] {
+			continue
+		}
+		isDir := false
+		for p := range exists {
+			if strings.HasPrefix(p, resolved+"/") {
+				isDir = true
+				break
+			}
+		}
+		if !isDir {
+			add(global.LintCheckLinks, global.LintSeverityError, mdPath, "broken link %q", m[1])
+		}
+	}
+}
@@ -0,0 +1,72 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package playbooks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+func TestLint(t *testing.T) {
+	svc := createTestService(t)
+	if err := svc.Create("iso"); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	put := func(path, content string) {
+		t.Helper()
+		if _, err := svc.PutFile("iso", path, content, ""); err != nil {
+			t.Fatalf("PutFile %s failed: %v", path, err)
+		}
+	}
+
+	put("procedure.md", "See [worker instructions](instructions/worker.md) and [the template](templates/worker_response.md#fields).")
+	put("schemas/worker_response.json", `{"type": "object", "properties": {"status": {"type": "string"}}}`)
+	put("templates/worker_response.md", "Status: {{.status}} ([evidence]({{.url}}))")
+	put("instructions/worker.md", "Assess the control.")
+	put("lists/controls.json", `{"version": "1.0", "name": "controls", "items": [],
+		"templates": {"worker_response_template": "iso/schemas/worker_response.json", "worker_report_template": "iso/templates/worker_response.md"}}`)
+
+	report, err := svc.Lint("iso", 0)
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+	if !report.Passed || report.Score != 100 || len(report.Issues) != 0 {
+		t.Errorf("clean playbook report = %+v", report)
+	}
+
+	// Break each check
+	put("schemas/qa_response.json", `{"type": "object",`)
+	put("instructions/qa.md", strings.Repeat("x", 3*1024))
+	put("lists/controls.json", `{"version": "1.0", "name": "controls", "items": [],
+		"templates": {"worker_response_template": "iso/schemas/missing.json", "qa_report_template": "qa.md"}}`)
+	put("templates/qa_response.md", "Back to [procedure](../procedure.md), out to [config](../../config.json), and [gone](gone.md).")
+
+	report, err = svc.Lint("iso", 2)
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+	want := map[string]int{
+		global.LintCheckStructure:     0,
+		global.LintCheckSchemas:       1,
+		global.LintCheckInstructions:  1,
+		global.LintCheckListTemplates: 2, // missing file (error), project-relative path (warning)
+		global.LintCheckLinks:         2, // outside the playbook, broken
+	}
+	for check, n := range want {
+		if report.Checks[check] != n {
+			t.Errorf("%s issues = %d, want %d (%+v)", check, report.Checks[check], n, report.Issues)
+		}
+	}
+	if report.Passed || report.Errors != 5 || report.Warnings != 1 || report.Score != 100-5*global.LintErrorPenalty-global.LintWarningPenalty {
+		t.Errorf("report = errors %d, warnings %d, score %d, passed %t", report.Errors, report.Warnings, report.Score, report.Passed)
+	}
+
+	if _, err := svc.Lint("missing", 0); err == nil {
+		t.Error("expected error linting a missing playbook")
+	}
+}
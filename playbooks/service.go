@@ -12,6 +12,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 	"sync"
 	"time"
 
@@ -24,9 +25,11 @@ var namePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
 
 // Service provides playbook operations.
 type Service struct {
-	baseDir   string
-	logger    *logging.Logger
-	pathMutex sync.Map // per-path locking
+	baseDir    string
+	exportsDir string // where playbook bundles are exported to and imported from
+	snapshots  bool   // keep each file's previous content when it changes
+	logger     *logging.Logger
+	pathMutex  sync.Map // per-path locking
 }
 
 // Playbook represents a playbook directory.
@@ -48,12 +51,15 @@ type FileItem struct {
 	TotalBytes int64 `json:"total_bytes,omitempty"`
 }
 
-// NewService creates a new playbooks service.
-func NewService(baseDir string, logger *logging.Logger) *Service {
+// NewService creates a new playbooks service. With snapshots, the previous
+// content of a file is kept whenever it changes so it can be restored.
+func NewService(baseDir, exportsDir string, snapshots bool, logger *logging.Logger) *Service {
 	return &Service{
-		baseDir:   baseDir,
-		logger:    logger,
-		pathMutex: sync.Map{},
+		baseDir:    baseDir,
+		exportsDir: exportsDir,
+		snapshots:  snapshots,
+		logger:     logger,
+		pathMutex:  sync.Map{},
 	}
 }
 
@@ -96,6 +102,12 @@ func (s *Service) validateFilePath(playbookName, path string) (string, error) {
 		return "", err
 	}
 
+	// The version history is managed by Maestro
+	versionsDir := filepath.Join(playbookPath, global.PlaybookVersionsDir)
+	if absPath == versionsDir || strings.HasPrefix(absPath, versionsDir+string(filepath.Separator)) {
+		return "", fmt.Errorf("path is reserved for playbook versions: %s", path)
+	}
+
 	return absPath, nil
 }
 
@@ -6,10 +6,14 @@
 package playbooks
 
 import (
+	"archive/zip"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/PivotLLM/Maestro/global"
 	"github.com/PivotLLM/Maestro/logging"
 )
 
@@ -45,7 +49,7 @@ func createTestService(t *testing.T) *Service {
 	})
 
 	logger := createTestLogger(t)
-	return NewService(tmpDir, logger)
+	return NewService(filepath.Join(tmpDir, "playbooks"), filepath.Join(tmpDir, "exports"), true, logger)
 }
 
 func TestValidateName(t *testing.T) {
@@ -537,3 +541,172 @@ func TestListSkipsHiddenAndMeta(t *testing.T) {
 		t.Errorf("Expected visible.txt, got %s", items[0].Path)
 	}
 }
+
+func TestExportImport(t *testing.T) {
+	svc := createTestService(t)
+
+	if err := svc.Create("audit-kit"); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	_, _ = svc.PutFile("audit-kit", "instructions/analyze.md", "Analyze the control.", "Analysis instructions")
+	_, _ = svc.PutFile("audit-kit", "templates/report.md", "# Report", "")
+
+	exported, err := svc.Export("audit-kit", "1.2.0", "Control audit playbook", "")
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if exported.Archive != "audit-kit-1.2.0.zip" || exported.Files < 2 {
+		t.Errorf("Export() = %+v", exported)
+	}
+	if _, err := svc.Export("audit-kit", "1.2.0", "", ""); err == nil {
+		t.Error("Export() should refuse to overwrite an existing bundle")
+	}
+	if _, err := svc.Export("audit-kit", "../1.3", "", ""); err == nil {
+		t.Error("Export() should reject an invalid version")
+	}
+
+	if _, err := svc.Import(exported.Archive, ""); err == nil {
+		t.Error("Import() should refuse to overwrite an existing playbook")
+	}
+	imported, err := svc.Import(exported.Archive, "audit-kit-copy")
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if imported.Version != "1.2.0" || imported.Description != "Control audit playbook" || imported.ExportedName != "audit-kit" {
+		t.Errorf("Import() = %+v", imported)
+	}
+	item, err := svc.GetFile("audit-kit-copy", "instructions/analyze.md", 0, 0)
+	if err != nil || item.Content != "Analyze the control." || item.Summary != "Analysis instructions" {
+		t.Errorf("GetFile() after import = %+v, %v", item, err)
+	}
+
+	// A bundle whose files no longer match the manifest is rejected
+	tampered := filepath.Join(svc.exportsDir, "tampered.zip")
+	if err := rewriteZipEntry(filepath.Join(svc.exportsDir, exported.Archive), tampered, "templates/report.md", "# Altered"); err != nil {
+		t.Fatalf("Failed to tamper with bundle: %v", err)
+	}
+	if _, err := svc.Import("tampered.zip", "tampered"); err == nil {
+		t.Error("Import() should reject a bundle with a checksum mismatch")
+	}
+	if svc.Exists("tampered") {
+		t.Error("Rejected bundle should not create a playbook")
+	}
+}
+
+// rewriteZipEntry copies a zip archive, replacing the content of one entry
+func rewriteZipEntry(src, dst, name, content string) error {
+	zr, err := zip.OpenReader(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = zr.Close() }()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = out.Close() }()
+
+	zw := zip.NewWriter(out)
+	for _, f := range zr.File {
+		w, err := zw.Create(f.Name)
+		if err != nil {
+			return err
+		}
+		if f.Name == name {
+			_, err = w.Write([]byte(content))
+		} else {
+			var rc io.ReadCloser
+			if rc, err = f.Open(); err == nil {
+				_, err = io.Copy(w, rc)
+				_ = rc.Close()
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func TestVersionsAndRestore(t *testing.T) {
+	svc := createTestService(t)
+
+	if err := svc.Create("audit-kit"); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if v := svc.Version("audit-kit"); v != 0 {
+		t.Errorf("Version() = %d before any change, want 0", v)
+	}
+
+	_, _ = svc.PutFile("audit-kit", "analyze.md", "Version one.", "")      // v1
+	_ = svc.EditFile("audit-kit", "analyze.md", "one", "two", false)       // v2
+	_, _ = svc.PutFile("audit-kit", "notes.md", "Notes.", "")              // v3
+	_ = svc.DeleteFile("audit-kit", "notes.md")                            // v4
+	_ = svc.RenameFile("audit-kit", "analyze.md", "procedures/analyze.md") // v5
+	if v := svc.Version("audit-kit"); v != 5 {
+		t.Fatalf("Version() = %d, want 5", v)
+	}
+
+	history, err := svc.History("audit-kit", "analyze.md", 0)
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if len(history.Changes) != 3 || history.Changes[0].Action != global.PlaybookChangePut || !history.Changes[0].Created {
+		t.Errorf("History(analyze.md) = %+v", history.Changes)
+	}
+	if history, _ := svc.History("audit-kit", "", 2); len(history.Changes) != 2 || history.Changes[1].Version != 5 {
+		t.Errorf("History(limit 2) = %+v", history.Changes)
+	}
+
+	// The history is not a playbook file
+	items, _ := svc.ListFiles("audit-kit", "")
+	for _, item := range items {
+		if strings.HasPrefix(item.Path, global.PlaybookVersionsDir) {
+			t.Errorf("ListFiles() returned %s", item.Path)
+		}
+	}
+	if _, err := svc.GetFile("audit-kit", global.PlaybookVersionsDir+"/"+global.PlaybookHistoryFile, 0, 0); err == nil {
+		t.Error("GetFile() should reject the versions directory")
+	}
+
+	if _, err := svc.Restore("audit-kit", "procedures/analyze.md", 4); err == nil {
+		t.Error("Restore() should fail for a file created by a rename")
+	}
+
+	// Restore a file as it was at version 1
+	_, _ = svc.PutFile("audit-kit", "analyze.md", "Version three.", "") // v6
+	newVersion, err := svc.Restore("audit-kit", "analyze.md", 1)
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if newVersion != 7 {
+		t.Errorf("Restore() version = %d, want 7", newVersion)
+	}
+	if item, _ := svc.GetFile("audit-kit", "analyze.md", 0, 0); item == nil || item.Content != "Version one." {
+		t.Errorf("restored content = %+v", item)
+	}
+
+	// Restore a deleted file
+	if _, err := svc.Restore("audit-kit", "notes.md", 3); err != nil {
+		t.Fatalf("Restore() deleted file error = %v", err)
+	}
+	if item, _ := svc.GetFile("audit-kit", "notes.md", 0, 0); item == nil || item.Content != "Notes." {
+		t.Errorf("restored deleted file = %+v", item)
+	}
+
+	if _, err := svc.Restore("audit-kit", "notes.md", 2); err == nil {
+		t.Error("Restore() should fail for a file that did not exist at the version")
+	}
+	if _, err := svc.Restore("audit-kit", "analyze.md", 99); err == nil {
+		t.Error("Restore() should reject a version that is not earlier")
+	}
+
+	// Without snapshots the history is kept but files cannot be restored
+	svc.snapshots = false
+	_ = svc.EditFile("audit-kit", "notes.md", "Notes", "More notes", false)
+	before := svc.Version("audit-kit") - 1
+	if _, err := svc.Restore("audit-kit", "notes.md", before); err == nil {
+		t.Error("Restore() should fail without a snapshot")
+	}
+}
@@ -0,0 +1,229 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package playbooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// History is the change history of a playbook. Every change to a file bumps the
+// playbook version.
+type History struct {
+	Playbook string   `json:"playbook"`
+	Version  int      `json:"version"` // Current version; 0 before the first recorded change
+	Changes  []Change `json:"changes"` // Oldest first
+}
+
+// Change records one change to a playbook file.
+type Change struct {
+	Version   int       `json:"version"`
+	Action    string    `json:"action"`             // put, append, edit, rename, delete, restore
+	Path      string    `json:"path"`               // File changed (the source of a rename)
+	NewPath   string    `json:"new_path,omitempty"` // Destination of a rename
+	Created   bool      `json:"created,omitempty"`  // The file did not exist before the change
+	Snapshot  bool      `json:"snapshot,omitempty"` // The file as it was before the change is kept
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// versionsDir returns the directory holding a playbook's history and snapshots.
+func (s *Service) versionsDir(name string) string {
+	return filepath.Join(s.playbookDir(name), global.PlaybookVersionsDir)
+}
+
+// snapshotPath returns where the file at path is kept as it was before a change.
+func (s *Service) snapshotPath(name string, version int, path string) string {
+	return filepath.Join(s.versionsDir(name), fmt.Sprint(version), filepath.FromSlash(path))
+}
+
+// loadHistory reads a playbook's history; a playbook without one is at version 0.
+func (s *Service) loadHistory(name string) (*History, error) {
+	data, err := os.ReadFile(filepath.Join(s.versionsDir(name), global.PlaybookHistoryFile))
+	if os.IsNotExist(err) {
+		return &History{Playbook: name, Changes: []Change{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read playbook history: %w", err)
+	}
+	var history History
+	if err := json.Unmarshal(data, &history); err != nil {
+		return nil, fmt.Errorf("invalid playbook history: %w", err)
+	}
+	history.Playbook = name
+	return &history, nil
+}
+
+// saveHistory writes a playbook's history.
+func (s *Service) saveHistory(name string, history *History) error {
+	data, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.versionsDir(name), 0755); err != nil {
+		return err
+	}
+	return global.AtomicWrite(filepath.Join(s.versionsDir(name), global.PlaybookHistoryFile), data)
+}
+
+// recordChange applies a change to a playbook file and records it as a new
+// playbook version. It is called with the file's lock held. When snapshots are
+// enabled and the file exists, its content is kept first so that the previous
+// version can be restored. Returns the new version.
+func (s *Service) recordChange(name string, change Change, absPath string, apply func() error) (int, error) {
+	mutex := s.getPathMutex(s.versionsDir(name))
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	history, err := s.loadHistory(name)
+	if err != nil {
+		return 0, err
+	}
+	change.Version = history.Version + 1
+	change.ChangedAt = time.Now()
+
+	if s.snapshots && global.FileExists(absPath) {
+		data, err := os.ReadFile(absPath)
+		if err != nil {
+			return 0, fmt.Errorf("failed to snapshot %s: %w", change.Path, err)
+		}
+		snapshot := s.snapshotPath(name, change.Version, change.Path)
+		if err := os.MkdirAll(filepath.Dir(snapshot), 0755); err != nil {
+			return 0, fmt.Errorf("failed to snapshot %s: %w", change.Path, err)
+		}
+		if err := global.AtomicWrite(snapshot, data); err != nil {
+			return 0, fmt.Errorf("failed to snapshot %s: %w", change.Path, err)
+		}
+		change.Snapshot = true
+	}
+
+	if err := apply(); err != nil {
+		if change.Snapshot {
+			_ = os.RemoveAll(filepath.Join(s.versionsDir(name), fmt.Sprint(change.Version)))
+		}
+		return 0, err
+	}
+
+	history.Version = change.Version
+	history.Changes = append(history.Changes, change)
+	if err := s.saveHistory(name, history); err != nil {
+		s.logger.Warnf("Failed to record version %d of playbook '%s': %v", change.Version, name, err)
+	}
+	return change.Version, nil
+}
+
+// Version returns the current version of a playbook (0 if it has no recorded
+// changes or does not exist).
+func (s *Service) Version(name string) int {
+	if err := validateName(name); err != nil {
+		return 0
+	}
+	history, err := s.loadHistory(name)
+	if err != nil {
+		return 0
+	}
+	return history.Version
+}
+
+// History returns the change history of a playbook, optionally only the changes
+// to one file, keeping the most recent limit changes (0 for all).
+func (s *Service) History(name, path string, limit int) (*History, error) {
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+	if !s.Exists(name) {
+		return nil, fmt.Errorf("playbook '%s' not found", name)
+	}
+	history, err := s.loadHistory(name)
+	if err != nil {
+		return nil, err
+	}
+	if path != "" {
+		changes := []Change{}
+		for _, c := range history.Changes {
+			if c.Path == path || c.NewPath == path {
+				changes = append(changes, c)
+			}
+		}
+		history.Changes = changes
+	}
+	if limit > 0 && len(history.Changes) > limit {
+		history.Changes = history.Changes[len(history.Changes)-limit:]
+	}
+	return history, nil
+}
+
+// Restore returns a file to its content at an earlier playbook version, using
+// the snapshot taken by the first later change to the file. The restore is
+// itself recorded as a new version. Returns the new version.
+func (s *Service) Restore(name, path string, version int) (int, error) {
+	absPath, err := s.validateFilePath(name, path)
+	if err != nil {
+		return 0, err
+	}
+	if !s.Exists(name) {
+		return 0, fmt.Errorf("playbook '%s' not found", name)
+	}
+
+	mutex := s.getPathMutex(absPath)
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	history, err := s.loadHistory(name)
+	if err != nil {
+		return 0, err
+	}
+	if version < 0 || version >= history.Version {
+		return 0, fmt.Errorf("version must be between 0 and %d (the current version is %d)", history.Version-1, history.Version)
+	}
+
+	// The first change to the file after the version holds its content at that version
+	var next *Change
+	for i := range history.Changes {
+		c := &history.Changes[i]
+		if c.Version > version && (c.Path == path || c.NewPath == path) {
+			next = c
+			break
+		}
+	}
+	switch {
+	case next == nil:
+		return 0, fmt.Errorf("%s has not changed since version %d", path, version)
+	case next.NewPath == path || (next.Path == path && next.Created):
+		return 0, fmt.Errorf("%s did not exist at version %d", path, version)
+	case !next.Snapshot:
+		return 0, fmt.Errorf("no snapshot of %s at version %d (enable playbook_snapshots to keep file versions)", path, version)
+	}
+
+	content, err := os.ReadFile(s.snapshotPath(name, next.Version, path))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read snapshot of %s: %w", path, err)
+	}
+
+	exists := global.FileExists(absPath)
+	newVersion, err := s.recordChange(name, Change{Action: global.PlaybookChangeRestore, Path: path, Created: !exists}, absPath, func() error {
+		return global.AtomicWrite(absPath, content)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	existingMeta, _ := global.LoadFileMetadata(absPath)
+	summary := ""
+	if existingMeta != nil {
+		summary = existingMeta.Summary
+	}
+	if err := global.SaveFileMetadata(absPath, global.UpdateFileMetadata(existingMeta, summary)); err != nil {
+		s.logger.Warnf("Failed to save metadata for %s/%s: %v", name, path, err)
+	}
+
+	s.logger.Infof("Restored %s in playbook '%s' to version %d (now version %d)", path, name, version, newVersion)
+	return newVersion, nil
+}
@@ -0,0 +1,290 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package projects
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// getArchiveDir returns the archive directory for a report prefix
+func (s *Service) getArchiveDir(project, prefix string) string {
+	return filepath.Join(s.getReportsDir(project), global.ReportArchiveDir, strings.TrimSuffix(prefix, "-"))
+}
+
+// FinalizeReport freezes the active report session into an immutable, checksummed
+// archive under reports/archive/<prefix>/. The archive contains the session's
+// reports, every result file, and the supplied templates (keyed by template path).
+// Archived files are made read-only, the archive is recorded on the project, and
+// the report session is ended so new work starts a fresh deliverable.
+func (s *Service) FinalizeReport(project string, templates map[string]string) (*global.FinalizedReport, error) {
+	if err := validateProjectName(project); err != nil {
+		return nil, err
+	}
+
+	if !s.ProjectExists(project) {
+		return nil, fmt.Errorf("project not found: %s", project)
+	}
+
+	proj, err := s.Get(project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	if proj.ReportPrefix == "" {
+		return nil, fmt.Errorf("no active report session")
+	}
+
+	archiveDir := s.getArchiveDir(project, proj.ReportPrefix)
+	if _, err := os.Stat(archiveDir); err == nil {
+		return nil, fmt.Errorf("report already finalized: %s", proj.ReportPrefix)
+	}
+
+	// Build the archive in a temporary directory and rename it into place
+	tmpDir := archiveDir + ".tmp"
+	_ = os.RemoveAll(tmpDir)
+	if err := os.MkdirAll(tmpDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create archive directory: %w", err)
+	}
+
+	var files []global.ReportArchiveFile
+	archive := func(relPath string, data []byte) error {
+		dest := filepath.Join(tmpDir, relPath)
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(dest, data, 0644); err != nil {
+			return err
+		}
+		sum := sha256.Sum256(data)
+		files = append(files, global.ReportArchiveFile{
+			Path:   filepath.ToSlash(relPath),
+			Size:   int64(len(data)),
+			SHA256: hex.EncodeToString(sum[:]),
+		})
+		return nil
+	}
+
+	cleanup := func(err error) (*global.FinalizedReport, error) {
+		_ = os.RemoveAll(tmpDir)
+		return nil, err
+	}
+
+	// 1. Reports belonging to this session
+	reports, err := s.ListReports(project)
+	if err != nil {
+		return cleanup(err)
+	}
+	reportCount := 0
+	for _, item := range reports {
+		if !strings.HasPrefix(item.Name, proj.ReportPrefix) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.getReportsDir(project), item.Name))
+		if err != nil {
+			return cleanup(fmt.Errorf("failed to read report %s: %w", item.Name, err))
+		}
+		if err := archive(filepath.Join(global.ReportsDir, item.Name), data); err != nil {
+			return cleanup(fmt.Errorf("failed to archive report %s: %w", item.Name, err))
+		}
+		reportCount++
+	}
+	if reportCount == 0 {
+		return cleanup(fmt.Errorf("no reports found for session %s", proj.ReportPrefix))
+	}
+
+	// 2. All result files (results and error details), in either results layout
+	resultsDir := s.getResultsDir(project)
+	walkErr := filepath.WalkDir(resultsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() && d.Name() == global.OrphanedResultsDir {
+			return filepath.SkipDir
+		}
+		if !d.Type().IsRegular() || !strings.HasSuffix(d.Name(), ".json") {
+			return nil
+		}
+		rel, err := filepath.Rel(resultsDir, path)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read result %s: %w", rel, err)
+		}
+		if err := archive(filepath.Join("results", rel), data); err != nil {
+			return fmt.Errorf("failed to archive result %s: %w", rel, err)
+		}
+		return nil
+	})
+	if walkErr != nil {
+		return cleanup(walkErr)
+	}
+
+	// 3. Templates used to produce the reports
+	for templatePath, content := range templates {
+		clean := filepath.Clean(filepath.FromSlash(templatePath))
+		if filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+			s.logger.Warnf("Project %s: Skipping template with unsafe path in archive: %s", project, templatePath)
+			continue
+		}
+		if err := archive(filepath.Join("templates", clean), []byte(content)); err != nil {
+			return cleanup(fmt.Errorf("failed to archive template %s: %w", templatePath, err))
+		}
+	}
+
+	// Write SHA256SUMS (sha256sum -c compatible) and derive the archive checksum from it
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	var sums strings.Builder
+	for _, f := range files {
+		sums.WriteString(fmt.Sprintf("%s  %s\n", f.SHA256, f.Path))
+	}
+	sumsData := []byte(sums.String())
+	if err := os.WriteFile(filepath.Join(tmpDir, global.ReportArchiveChecksumsFile), sumsData, 0644); err != nil {
+		return cleanup(fmt.Errorf("failed to write checksums: %w", err))
+	}
+	archiveSum := sha256.Sum256(sumsData)
+	checksum := hex.EncodeToString(archiveSum[:])
+
+	now := time.Now()
+	manifest := global.ReportArchiveManifest{
+		Project:     project,
+		Prefix:      proj.ReportPrefix,
+		ReportTitle: proj.ReportTitle,
+		FinalizedAt: now,
+		Checksum:    checksum,
+		Files:       files,
+	}
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return cleanup(fmt.Errorf("failed to marshal archive manifest: %w", err))
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, global.ReportArchiveManifestFile), manifestData, 0644); err != nil {
+		return cleanup(fmt.Errorf("failed to write archive manifest: %w", err))
+	}
+
+	if err := os.Rename(tmpDir, archiveDir); err != nil {
+		return cleanup(fmt.Errorf("failed to finalize archive: %w", err))
+	}
+
+	// Make the archived files immutable
+	if err := makeReadOnly(archiveDir); err != nil {
+		s.logger.Warnf("Project %s: Failed to make archive read-only: %v", project, err)
+	}
+
+	relArchive, _ := filepath.Rel(s.getProjectDir(project), archiveDir)
+	finalized := global.FinalizedReport{
+		Prefix:      proj.ReportPrefix,
+		ArchivePath: filepath.ToSlash(relArchive),
+		Checksum:    checksum,
+		FileCount:   len(files),
+		FinalizedAt: now,
+	}
+
+	// Record the archive and end the session
+	proj.FinalizedReports = append(proj.FinalizedReports, finalized)
+	endReportSession(proj, now, true)
+	proj.ReportPrefix = ""
+	proj.ReportStartedAt = nil
+	proj.ReportTitle = ""
+	proj.ReportIntro = ""
+	proj.ReportDate = ""
+	proj.UpdatedAt = now
+
+	if err := s.saveProject(project, proj); err != nil {
+		return nil, fmt.Errorf("failed to save project: %w", err)
+	}
+
+	if err := s.appendLogEntry(project, fmt.Sprintf("Report finalized: %s (%d files, sha256 %s)", finalized.ArchivePath, finalized.FileCount, checksum)); err != nil {
+		s.logger.Warnf("Failed to log report finalization: %v", err)
+	}
+
+	s.logger.Infof("Project %s: Report finalized to %s (%d files)", project, finalized.ArchivePath, finalized.FileCount)
+	return &finalized, nil
+}
+
+// VerifyReportArchive recomputes the checksums of a finalized archive and returns
+// the paths of any files that are missing or no longer match.
+func (s *Service) VerifyReportArchive(project, prefix string) ([]string, error) {
+	if err := validateProjectName(project); err != nil {
+		return nil, err
+	}
+
+	archiveDir := s.getArchiveDir(project, prefix)
+	data, err := os.ReadFile(filepath.Join(archiveDir, global.ReportArchiveManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("archive not found for prefix %s: %w", prefix, err)
+	}
+
+	var manifest global.ReportArchiveManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse archive manifest: %w", err)
+	}
+
+	var mismatched []string
+	for _, f := range manifest.Files {
+		content, err := os.ReadFile(filepath.Join(archiveDir, filepath.FromSlash(f.Path)))
+		if err != nil {
+			mismatched = append(mismatched, f.Path)
+			continue
+		}
+		sum := sha256.Sum256(content)
+		if hex.EncodeToString(sum[:]) != f.SHA256 {
+			mismatched = append(mismatched, f.Path)
+		}
+	}
+
+	sumsData, err := os.ReadFile(filepath.Join(archiveDir, global.ReportArchiveChecksumsFile))
+	if err != nil {
+		mismatched = append(mismatched, global.ReportArchiveChecksumsFile)
+	} else {
+		sum := sha256.Sum256(sumsData)
+		if hex.EncodeToString(sum[:]) != manifest.Checksum {
+			mismatched = append(mismatched, global.ReportArchiveChecksumsFile)
+		}
+	}
+
+	return mismatched, nil
+}
+
+// ArchiveResultsDir returns the results directory inside the finalized report
+// archive for a prefix, so earlier results can be read after the project moved on
+func (s *Service) ArchiveResultsDir(project, prefix string) (string, error) {
+	if err := validateProjectName(project); err != nil {
+		return "", err
+	}
+
+	archiveDir := s.getArchiveDir(project, prefix)
+	if _, err := os.Stat(filepath.Join(archiveDir, global.ReportArchiveManifestFile)); err != nil {
+		return "", fmt.Errorf("archive not found for prefix %s", prefix)
+	}
+	return filepath.Join(archiveDir, "results"), nil
+}
+
+// makeReadOnly removes write permission from every file under root.
+// Directories stay writable so project_delete can still remove the archive.
+func makeReadOnly(root string) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		return os.Chmod(path, 0444)
+	})
+}
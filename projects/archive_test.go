@@ -0,0 +1,227 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package projects
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+func TestFinalizeReport(t *testing.T) {
+	svc, _ := createTestServiceWithConfig(t)
+
+	if _, err := svc.Create("archive-test", "Archive Test", "", "", "", "none", ""); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	// No session yet
+	if _, err := svc.FinalizeReport("archive-test", nil); err == nil {
+		t.Error("Expected error finalizing without an active session")
+	}
+
+	prefix, err := svc.StartReport("archive-test", "ISO Audit", "")
+	if err != nil {
+		t.Fatalf("StartReport failed: %v", err)
+	}
+	if err := svc.AppendReport("archive-test", "## Findings\n\nNone.\n", "", nil); err != nil {
+		t.Fatalf("AppendReport failed: %v", err)
+	}
+
+	resultPath := filepath.Join(svc.GetResultsDir("archive-test"), "abc.json")
+	if err := os.WriteFile(resultPath, []byte(`{"task_uuid":"abc"}`), 0644); err != nil {
+		t.Fatalf("Failed to write result: %v", err)
+	}
+
+	templates := map[string]string{
+		"playbook/templates/report.md": "{{.finding}}",
+		"../escape.md":                 "ignored",
+	}
+
+	finalized, err := svc.FinalizeReport("archive-test", templates)
+	if err != nil {
+		t.Fatalf("FinalizeReport failed: %v", err)
+	}
+
+	if finalized.Prefix != prefix {
+		t.Errorf("Prefix = %q, want %q", finalized.Prefix, prefix)
+	}
+	// Report + result + one safe template
+	if finalized.FileCount != 3 {
+		t.Errorf("FileCount = %d, want 3", finalized.FileCount)
+	}
+	if len(finalized.Checksum) != 64 {
+		t.Errorf("Checksum = %q, want sha256 hex", finalized.Checksum)
+	}
+
+	archiveDir := svc.getArchiveDir("archive-test", prefix)
+	for _, rel := range []string{
+		global.ReportArchiveManifestFile,
+		global.ReportArchiveChecksumsFile,
+		filepath.Join(global.ReportsDir, prefix+"Report.md"),
+		filepath.Join("results", "abc.json"),
+		filepath.Join("templates", "playbook", "templates", "report.md"),
+	} {
+		if !global.FileExists(filepath.Join(archiveDir, rel)) {
+			t.Errorf("Archive missing %s", rel)
+		}
+	}
+
+	// Session ended and archive recorded
+	proj, err := svc.Get("archive-test")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if proj.ReportPrefix != "" {
+		t.Errorf("ReportPrefix = %q, want empty after finalize", proj.ReportPrefix)
+	}
+	if len(proj.FinalizedReports) != 1 || proj.FinalizedReports[0].Checksum != finalized.Checksum {
+		t.Errorf("FinalizedReports not recorded: %+v", proj.FinalizedReports)
+	}
+
+	// New work does not affect the frozen archive
+	if err := os.WriteFile(resultPath, []byte(`{"task_uuid":"abc","changed":true}`), 0644); err != nil {
+		t.Fatalf("Failed to rewrite result: %v", err)
+	}
+	mismatched, err := svc.VerifyReportArchive("archive-test", prefix)
+	if err != nil {
+		t.Fatalf("VerifyReportArchive failed: %v", err)
+	}
+	if len(mismatched) != 0 {
+		t.Errorf("Unexpected mismatches: %v", mismatched)
+	}
+
+	// Project can still be deleted with a read-only archive present
+	if err := svc.Delete("archive-test"); err != nil {
+		t.Errorf("Delete failed: %v", err)
+	}
+}
+
+func TestProjectExportImport(t *testing.T) {
+	for _, format := range []string{global.ExportFormatZip, global.ExportFormatTarGz} {
+		t.Run(format, func(t *testing.T) {
+			svc, _ := createTestServiceWithConfig(t)
+
+			if _, err := svc.Create("export-test", "Export Test", "", "", "", "none", ""); err != nil {
+				t.Fatalf("Create failed: %v", err)
+			}
+			if _, err := svc.PutFile("export-test", "notes/scope.md", "In scope", ""); err != nil {
+				t.Fatalf("PutFile failed: %v", err)
+			}
+			if err := svc.AppendJournal("export-test", "run-1", &global.RunJournalEntry{Entry: global.JournalRunStarted}); err != nil {
+				t.Fatalf("AppendJournal failed: %v", err)
+			}
+
+			exported, err := svc.Export("export-test", format, nil)
+			if err != nil {
+				t.Fatalf("Export failed: %v", err)
+			}
+			if exported.Format != format || exported.Files == 0 || exported.SizeBytes == 0 {
+				t.Errorf("Unexpected export result: %+v", exported)
+			}
+
+			// The original name is taken, so the archive is imported under a new one
+			if _, err := svc.Import(exported.Archive, "", ""); err == nil {
+				t.Error("Expected error importing over an existing project")
+			}
+			imported, err := svc.Import(exported.Archive, "export-copy", "")
+			if err != nil {
+				t.Fatalf("Import failed: %v", err)
+			}
+			if imported.ExportedName != "export-test" || imported.Files != exported.Files {
+				t.Errorf("Unexpected import result: %+v", imported)
+			}
+
+			proj, err := svc.Get("export-copy")
+			if err != nil {
+				t.Fatalf("Get failed: %v", err)
+			}
+			if proj.Name != "export-copy" || proj.Title != "Export Test" {
+				t.Errorf("Imported project = %s (%s), want export-copy (Export Test)", proj.Name, proj.Title)
+			}
+			content, err := svc.GetFile("export-copy", "notes/scope.md", 0, 0)
+			if err != nil || content.Content != "In scope" {
+				t.Errorf("Imported file = %+v, %v", content, err)
+			}
+			journals, err := svc.GetJournals("export-copy")
+			if err != nil || len(journals) != 0 {
+				t.Errorf("Run journals should not be exported, got %d (%v)", len(journals), err)
+			}
+
+			if _, err := svc.Import("../"+exported.Archive, "escape", ""); err == nil {
+				t.Error("Expected error for an archive path outside the exports directory")
+			}
+		})
+	}
+}
+
+func TestProjectExportAnonymized(t *testing.T) {
+	svc, _ := createTestServiceWithConfig(t)
+
+	if _, err := svc.Create("acme-audit", "Acme Corp audit", "Audit for Acme Corp", "", "", "none", ""); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := svc.PutFile("acme-audit", "notes/scope.md", "Acme Corp servers; contact jane@acme.example", ""); err != nil {
+		t.Fatalf("PutFile failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(svc.getFilesDir("acme-audit"), "logo.png"), []byte{0x89, 'P', 'N', 'G', 0, 0}, 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	redactor, err := global.NewPromptRedactor(global.PromptRedaction{
+		Enabled: true,
+		Types:   []string{global.PIITypeEmail},
+		Rules:   []global.PromptRedactionRule{{Name: "client", Terms: []string{"Acme Corp"}}},
+	})
+	if err != nil {
+		t.Fatalf("NewPromptRedactor failed: %v", err)
+	}
+	exported, err := svc.Export("acme-audit", global.ExportFormatZip, redactor)
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if exported.Mapping == "" || len(exported.Anonymized) != 2 {
+		t.Errorf("Unexpected export result: %+v", exported)
+	}
+	if len(exported.Skipped) != 1 || exported.Skipped[0] != "files/logo.png" {
+		t.Errorf("Skipped = %v, want [files/logo.png]", exported.Skipped)
+	}
+
+	// Imported without the mapping, the project holds only placeholders
+	if _, err := svc.Import(exported.Archive, "demo", ""); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	content, err := svc.GetFile("demo", "notes/scope.md", 0, 0)
+	if err != nil || content.Content != "[CLIENT_1] servers; contact [EMAIL_1]" {
+		t.Errorf("Anonymized file = %+v, %v", content, err)
+	}
+	proj, err := svc.Get("demo")
+	if err != nil || proj.Title != "[CLIENT_1] audit" {
+		t.Errorf("Anonymized title = %q, %v", proj.Title, err)
+	}
+
+	// The mapping restores the original values
+	restored, err := svc.Import(exported.Archive, "restored", exported.Mapping)
+	if err != nil {
+		t.Fatalf("Import with mapping failed: %v", err)
+	}
+	if restored.Restored == 0 {
+		t.Errorf("Unexpected import result: %+v", restored)
+	}
+	content, err = svc.GetFile("restored", "notes/scope.md", 0, 0)
+	if err != nil || content.Content != "Acme Corp servers; contact jane@acme.example" {
+		t.Errorf("Restored file = %+v, %v", content, err)
+	}
+	if proj, err := svc.Get("restored"); err != nil || proj.Title != "Acme Corp audit" {
+		t.Errorf("Restored title = %q, %v", proj.Title, err)
+	}
+
+	if _, err := svc.Import(exported.Archive, "other", "missing.mapping.json"); err == nil {
+		t.Error("Expected error for a missing mapping")
+	}
+}
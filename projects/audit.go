@@ -0,0 +1,90 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package projects
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// getAuditPath returns the path to the project's audit.jsonl
+func (s *Service) getAuditPath(project string) string {
+	return filepath.Join(s.getProjectDir(project), global.AuditFile)
+}
+
+// AppendAudit appends one tool invocation to audit.jsonl.
+// The file is only ever appended to; nothing in Maestro rewrites or truncates it.
+func (s *Service) AppendAudit(project string, entry *global.AuditEntry) error {
+	if err := validateProjectName(project); err != nil {
+		return err
+	}
+
+	if !s.ProjectExists(project) {
+		return fmt.Errorf("project not found: %s", project)
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+
+	s.auditMu.Lock()
+	defer s.auditMu.Unlock()
+
+	f, err := os.OpenFile(s.getAuditPath(project), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open audit file: %w", err)
+	}
+	defer func(f *os.File) {
+		_ = f.Close()
+	}(f)
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+
+	return nil
+}
+
+// GetAudit reads the audit trail, oldest first.
+// Returns an empty list if nothing has been recorded yet. Unparseable lines are skipped.
+func (s *Service) GetAudit(project string) ([]global.AuditEntry, error) {
+	if err := validateProjectName(project); err != nil {
+		return nil, err
+	}
+
+	entries := []global.AuditEntry{}
+	f, err := os.Open(s.getAuditPath(project))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return entries, nil
+		}
+		return nil, fmt.Errorf("failed to read audit trail: %w", err)
+	}
+	defer func(f *os.File) {
+		_ = f.Close()
+	}(f)
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry global.AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit trail: %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,66 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package projects
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// getDashboardPath returns the path to the project's dashboard.json
+func (s *Service) getDashboardPath(project string) string {
+	return filepath.Join(s.getProjectDir(project), global.DashboardFile)
+}
+
+// SaveDashboard writes the project dashboard to dashboard.json
+func (s *Service) SaveDashboard(project string, dashboard *global.ProjectDashboard) error {
+	if err := validateProjectName(project); err != nil {
+		return err
+	}
+
+	if !s.ProjectExists(project) {
+		return fmt.Errorf("project not found: %s", project)
+	}
+
+	data, err := json.MarshalIndent(dashboard, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal dashboard: %w", err)
+	}
+
+	if err := global.AtomicWrite(s.getDashboardPath(project), data); err != nil {
+		return fmt.Errorf("failed to write dashboard: %w", err)
+	}
+
+	s.logger.Debugf("Project %s: Wrote %s", project, global.DashboardFile)
+	return nil
+}
+
+// GetDashboard reads the saved project dashboard.
+// Returns nil without error if no dashboard has been written yet.
+func (s *Service) GetDashboard(project string) (*global.ProjectDashboard, error) {
+	if err := validateProjectName(project); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(s.getDashboardPath(project))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read dashboard: %w", err)
+	}
+
+	var dashboard global.ProjectDashboard
+	if err := json.Unmarshal(data, &dashboard); err != nil {
+		return nil, fmt.Errorf("failed to parse dashboard: %w", err)
+	}
+
+	return &dashboard, nil
+}
@@ -0,0 +1,90 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package projects
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// getEventsPath returns the path to the project's events.jsonl
+func (s *Service) getEventsPath(project string) string {
+	return filepath.Join(s.getProjectDir(project), global.EventsFile)
+}
+
+// AppendEvent appends one task progress event to events.jsonl.
+// The file is only ever appended to; nothing in Maestro rewrites or truncates it.
+func (s *Service) AppendEvent(project string, event *global.TaskEvent) error {
+	if err := validateProjectName(project); err != nil {
+		return err
+	}
+
+	if !s.ProjectExists(project) {
+		return fmt.Errorf("project not found: %s", project)
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	s.eventsMu.Lock()
+	defer s.eventsMu.Unlock()
+
+	f, err := os.OpenFile(s.getEventsPath(project), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open events file: %w", err)
+	}
+	defer func(f *os.File) {
+		_ = f.Close()
+	}(f)
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write event: %w", err)
+	}
+
+	return nil
+}
+
+// GetEvents reads the progress events, oldest first.
+// Returns an empty list if nothing has been recorded yet. Unparseable lines are skipped.
+func (s *Service) GetEvents(project string) ([]global.TaskEvent, error) {
+	if err := validateProjectName(project); err != nil {
+		return nil, err
+	}
+
+	events := []global.TaskEvent{}
+	f, err := os.Open(s.getEventsPath(project))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return events, nil
+		}
+		return nil, fmt.Errorf("failed to read events: %w", err)
+	}
+	defer func(f *os.File) {
+		_ = f.Close()
+	}(f)
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var event global.TaskEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			continue
+		}
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read events: %w", err)
+	}
+
+	return events, nil
+}
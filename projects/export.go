@@ -0,0 +1,353 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package projects
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+	"github.com/google/uuid"
+)
+
+// exportArchivePath returns the path of an archive in the exports directory.
+// Archive names are plain file names; paths are rejected.
+func (s *Service) exportArchivePath(archive string) (string, error) {
+	if archive == "" || archive != filepath.Base(archive) || strings.HasPrefix(archive, ".") || strings.ContainsAny(archive, `/\`) {
+		return "", fmt.Errorf("invalid archive name: %s (use a file name in the exports directory)", archive)
+	}
+	return filepath.Join(s.config.ExportsDir(), archive), nil
+}
+
+// Export writes the whole project (metadata, files, lists, task sets, results,
+// reports and logs) to a zip or tar.gz archive in the exports directory.
+// Run journals and task set lock files are left out: they describe work in
+// progress on this instance only. Snapshots are left out too; they duplicate
+// the project's own files.
+// With anonymize set, the values it covers are replaced with placeholders in
+// every text file, binary files are left out, and the placeholders' values are
+// written to a mapping file beside the archive so that Import can restore them.
+func (s *Service) Export(project, format string, anonymize *global.PromptRedactor) (*global.ExportResult, error) {
+	if err := validateProjectName(project); err != nil {
+		return nil, err
+	}
+	if !s.ProjectExists(project) {
+		return nil, fmt.Errorf("project not found: %s", project)
+	}
+	if format == "" {
+		format = global.ExportFormatZip
+	}
+	if format != global.ExportFormatZip && format != global.ExportFormatTarGz {
+		return nil, fmt.Errorf("invalid format: %s (must be %s or %s)", format, global.ExportFormatZip, global.ExportFormatTarGz)
+	}
+
+	manifest := func(files []global.ExportFile) ([]byte, error) {
+		return json.MarshalIndent(global.ExportManifest{
+			Kind:           global.ExportKindProject,
+			FormatVersion:  global.ExportFormatVersion,
+			Name:           project,
+			MaestroVersion: global.Version,
+			ExportedAt:     time.Now(),
+			Files:          files,
+		}, "", "  ")
+	}
+
+	archive := fmt.Sprintf("%s-%s.%s", project, time.Now().Format("20060102-150405"), format)
+	archivePath := filepath.Join(s.config.ExportsDir(), archive)
+
+	mutex := s.getProjectMutex(project)
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	skip := func(rel string, isDir bool) bool {
+		return (isDir && (rel == global.InternalDir || rel == global.SnapshotsDir)) || strings.HasSuffix(rel, ".lock")
+	}
+
+	// An anonymized export archives an anonymized copy of the project. The
+	// leading dot keeps the copy out of project listings.
+	srcDir := s.getProjectDir(project)
+	var placeholders *global.RedactionMap
+	var skipped []string
+	if anonymize != nil {
+		tmpDir := filepath.Join(s.config.ProjectsDir(), ".export-"+uuid.New().String())
+		defer func() { _ = os.RemoveAll(tmpDir) }()
+		placeholders = global.NewRedactionMap()
+		var err error
+		if skipped, err = anonymizeDir(srcDir, tmpDir, anonymize, placeholders, skip); err != nil {
+			return nil, fmt.Errorf("failed to anonymize project: %w", err)
+		}
+		srcDir = tmpDir
+	}
+
+	exported, err := global.WriteDirArchive(srcDir, archivePath, global.ExportManifestFile, manifest, skip)
+	if err != nil {
+		return nil, err
+	}
+
+	var mapping string
+	if placeholders != nil {
+		mapping = strings.TrimSuffix(archive, "."+format) + global.AnonymizationMapSuffix
+		data, err := json.MarshalIndent(global.AnonymizationMapping{
+			Project:      project,
+			Archive:      archive,
+			CreatedAt:    time.Now(),
+			Placeholders: placeholders.Values(),
+		}, "", "  ")
+		if err == nil {
+			err = os.WriteFile(filepath.Join(s.config.ExportsDir(), mapping), data, 0600)
+		}
+		if err != nil {
+			_ = os.Remove(archivePath)
+			return nil, fmt.Errorf("failed to write anonymization mapping: %w", err)
+		}
+	}
+
+	info, err := os.Stat(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+
+	files := len(exported)
+	kind := "Project exported"
+	if placeholders != nil {
+		kind = "Anonymized project exported"
+	}
+	s.logger.Infof("%s to %s (%d files)", kind, archivePath, files)
+	if err := s.appendLogEntry(project, fmt.Sprintf("%s to %s (%d files)", kind, archive, files)); err != nil {
+		s.logger.Warnf("Failed to log project export: %v", err)
+	}
+
+	result := &global.ExportResult{
+		Name:      project,
+		Archive:   archive,
+		Path:      archivePath,
+		Format:    format,
+		Files:     files,
+		SizeBytes: info.Size(),
+		Mapping:   mapping,
+		Skipped:   skipped,
+	}
+	if placeholders != nil {
+		result.Anonymized = placeholders.Counts()
+	}
+	return result, nil
+}
+
+// anonymizeDir copies the files under srcDir that skip does not exclude to
+// destDir, replacing the values redactor covers with placeholders recorded in
+// placeholders. Binary files cannot be anonymized and are left out; their
+// relative paths are returned.
+func anonymizeDir(srcDir, destDir string, redactor *global.PromptRedactor, placeholders *global.RedactionMap, skip func(rel string, isDir bool) bool) ([]string, error) {
+	var skipped []string
+	err := filepath.WalkDir(srcDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(srcDir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel != "." && skip(rel, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		dest := filepath.Join(destDir, filepath.FromSlash(rel))
+		if d.IsDir() {
+			return os.MkdirAll(dest, 0755)
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		anonymized, _, ok := replaceContent(p, data, func(text string) (string, int) {
+			text, counts := redactor.Redact(text, placeholders)
+			return text, len(counts)
+		})
+		if !ok {
+			skipped = append(skipped, rel)
+			return nil
+		}
+		if err := os.WriteFile(dest, anonymized, 0644); err != nil {
+			return err
+		}
+		if info, err := d.Info(); err == nil {
+			_ = os.Chtimes(dest, info.ModTime(), info.ModTime())
+		}
+		return nil
+	})
+	return skipped, err
+}
+
+// Import creates a project from an archive in the exports directory written by
+// Export, on this or another Maestro instance. The project keeps its exported
+// name unless name is given; either way no existing project is overwritten.
+// With mapping, the mapping file of an anonymized export, the placeholders in
+// the imported files are replaced by their original values.
+func (s *Service) Import(archive, name, mapping string) (*global.ImportResult, error) {
+	archivePath, err := s.exportArchivePath(archive)
+	if err != nil {
+		return nil, err
+	}
+	if !global.FileExists(archivePath) {
+		return nil, fmt.Errorf("archive not found in exports directory: %s", archive)
+	}
+	var placeholders *global.RedactionMap
+	if mapping != "" {
+		if placeholders, err = s.loadAnonymizationMapping(mapping, archive); err != nil {
+			return nil, err
+		}
+	}
+	if name != "" {
+		if err := validateProjectName(name); err != nil {
+			return nil, err
+		}
+	}
+
+	// Extract beside the projects so the final move is a rename. The leading dot
+	// keeps the directory out of project listings.
+	tmpDir := filepath.Join(s.config.ProjectsDir(), ".import-"+uuid.New().String())
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	data, extracted, err := global.ExtractDirArchive(archivePath, tmpDir, global.ExportManifestFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest global.ExportManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", global.ExportManifestFile, err)
+	}
+	if manifest.Kind != global.ExportKindProject {
+		return nil, fmt.Errorf("archive contains a %s, not a project", manifest.Kind)
+	}
+	if manifest.FormatVersion > global.ExportFormatVersion {
+		return nil, fmt.Errorf("archive format version %d is newer than this instance supports (%d); upgrade %s",
+			manifest.FormatVersion, global.ExportFormatVersion, global.ProgramName)
+	}
+	if err := global.VerifyExportFiles(manifest.Files, extracted); err != nil {
+		return nil, err
+	}
+	if !global.FileExists(filepath.Join(tmpDir, global.ProjectFileName)) {
+		return nil, fmt.Errorf("archive has no %s", global.ProjectFileName)
+	}
+	restored := 0
+	if placeholders != nil {
+		if restored, err = restoreAnonymized(tmpDir, extracted, placeholders); err != nil {
+			return nil, fmt.Errorf("failed to restore anonymized values: %w", err)
+		}
+	}
+	if name == "" {
+		name = manifest.Name
+		if err := validateProjectName(name); err != nil {
+			return nil, fmt.Errorf("archive has an invalid project name: %w", err)
+		}
+	}
+
+	mutex := s.getProjectMutex(name)
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	projectDir := s.getProjectDir(name)
+	if _, err := os.Stat(projectDir); err == nil {
+		return nil, fmt.Errorf("project already exists: %s (import it under another name)", name)
+	}
+	if err := os.Rename(tmpDir, projectDir); err != nil {
+		return nil, fmt.Errorf("failed to import project: %w", err)
+	}
+
+	proj, err := s.loadProject(name)
+	if err != nil {
+		return nil, fmt.Errorf("imported project cannot be loaded: %w", err)
+	}
+	if proj.Name != name {
+		proj.Name = name
+		proj.UpdatedAt = time.Now()
+		if err := s.saveProject(name, proj); err != nil {
+			s.logger.Warnf("Failed to update project.json after import: %v", err)
+		}
+	}
+
+	files := len(extracted)
+	s.logger.Infof("Imported project %s from %s (%d files)", name, archivePath, files)
+	entry := fmt.Sprintf("Project imported from %s (exported as %s by %s %s)", archive, manifest.Name, global.ProgramName, manifest.MaestroVersion)
+	if placeholders != nil {
+		entry += fmt.Sprintf("; original values restored from %s in %d files", mapping, restored)
+	}
+	if err := s.appendLogEntry(name, entry); err != nil {
+		s.logger.Warnf("Failed to log project import: %v", err)
+	}
+
+	return &global.ImportResult{
+		Name:           name,
+		Archive:        archive,
+		ExportedName:   manifest.Name,
+		MaestroVersion: manifest.MaestroVersion,
+		ExportedAt:     manifest.ExportedAt,
+		Files:          files,
+		Restored:       restored,
+	}, nil
+}
+
+// loadAnonymizationMapping reads the mapping file of an anonymized export of
+// archive from the exports directory
+func (s *Service) loadAnonymizationMapping(mapping, archive string) (*global.RedactionMap, error) {
+	mappingPath, err := s.exportArchivePath(mapping)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(mappingPath)
+	if err != nil {
+		return nil, fmt.Errorf("mapping not found in exports directory: %s", mapping)
+	}
+	var m global.AnonymizationMapping
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("invalid mapping %s: %w", mapping, err)
+	}
+	if m.Archive != archive {
+		return nil, fmt.Errorf("mapping %s belongs to archive %s, not %s", mapping, m.Archive, archive)
+	}
+	return global.NewRedactionMapFrom(m.Placeholders), nil
+}
+
+// restoreAnonymized replaces the placeholders in the extracted text files under
+// dir by their values. Returns the number of files changed.
+func restoreAnonymized(dir string, files []global.ExportFile, placeholders *global.RedactionMap) (int, error) {
+	restored := 0
+	for _, file := range files {
+		path := filepath.Join(dir, filepath.FromSlash(file.Path))
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return restored, err
+		}
+		out, changed, ok := replaceContent(path, data, func(text string) (string, int) {
+			restoredText := placeholders.Restore(text)
+			if restoredText == text {
+				return text, 0
+			}
+			return restoredText, 1
+		})
+		if !ok || changed == 0 {
+			continue
+		}
+		if err := os.WriteFile(path, out, 0644); err != nil {
+			return restored, err
+		}
+		restored++
+	}
+	return restored, nil
+}
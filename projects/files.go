@@ -60,8 +60,20 @@ func (s *Service) ListFiles(project, prefix string) ([]FileItem, error) {
 		return nil, fmt.Errorf("project not found: %s", project)
 	}
 
-	filesDir := s.getFilesDir(project)
+	return s.listFiles(project, s.getFilesDir(project), prefix)
+}
+
+// ListSnapshotFiles lists the files of a project snapshot, optionally filtered by prefix.
+func (s *Service) ListSnapshotFiles(project, snapshot, prefix string) ([]FileItem, error) {
+	dir, err := s.snapshotDir(project, snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return s.listFiles(project, filepath.Join(dir, global.FilesDir), prefix)
+}
 
+// listFiles lists the files under filesDir, optionally filtered by prefix.
+func (s *Service) listFiles(project, filesDir, prefix string) ([]FileItem, error) {
 	// Check if files directory exists
 	if !global.DirExists(filesDir) {
 		return []FileItem{}, nil
@@ -141,6 +153,24 @@ func (s *Service) GetFile(project, path string, offset, maxBytes int64) (*FileIt
 	mutex.Lock()
 	defer mutex.Unlock()
 
+	return s.readFile(project, path, absPath, offset, maxBytes)
+}
+
+// GetSnapshotFile retrieves a file from a project snapshot with optional byte range.
+func (s *Service) GetSnapshotFile(project, snapshot, path string, offset, maxBytes int64) (*FileItem, error) {
+	dir, err := s.snapshotDir(project, snapshot)
+	if err != nil {
+		return nil, err
+	}
+	absPath, err := global.ValidatePathWithinDir(filepath.Join(dir, global.FilesDir), path)
+	if err != nil {
+		return nil, err
+	}
+	return s.readFile(project, path, absPath, offset, maxBytes)
+}
+
+// readFile reads a project file at absPath with optional byte range.
+func (s *Service) readFile(project, path, absPath string, offset, maxBytes int64) (*FileItem, error) {
 	// Check file exists
 	info, err := os.Stat(absPath)
 	if err != nil {
@@ -214,6 +244,33 @@ func (s *Service) GetFile(project, path string, offset, maxBytes int64) (*FileIt
 	return item, nil
 }
 
+// ReadBinaryFile reads a whole project file as raw bytes, without the UTF-8
+// check GetFile applies. Used for binary templates such as DOCX files.
+func (s *Service) ReadBinaryFile(project, path string) ([]byte, error) {
+	absPath, err := s.validateFilePath(project, path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify project exists
+	if !s.ProjectExists(project) {
+		return nil, fmt.Errorf("project not found: %s", project)
+	}
+
+	mutex := s.getProjectMutex(project)
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	content, err := os.ReadFile(absPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("file not found: %s", path)
+		}
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return content, nil
+}
+
 // PutFile creates or overwrites a file in a project.
 func (s *Service) PutFile(project, path, content, summary string) (bool, error) {
 	absPath, err := s.validateFilePath(project, path)
@@ -494,12 +551,17 @@ type ImportResult struct {
 	LinksImported int    `json:"links_imported"`
 	LinksRemoved  int    `json:"links_removed,omitempty"` // Symlinks removed for escaping base directory
 	ImportedTo    string `json:"imported_to"`
+
+	Rejected []global.ImportRejection `json:"rejected,omitempty"` // Files left out under the import policy
 }
 
 // ImportFiles imports external files into a project's files/imported/ directory.
 // This bypasses the chroot to allow importing from anywhere on the filesystem.
 // The source can be a file or directory. If recursive is true, directories are
 // imported recursively preserving their structure. Symlinks are preserved as symlinks.
+// Files the import policy rejects are left out of directories and listed in the
+// result; a rejected single file, or files larger in total than the policy
+// allows, fail the import before anything is written.
 func (s *Service) ImportFiles(project, source string, recursive bool) (*ImportResult, error) {
 	if err := validateProjectName(project); err != nil {
 		return nil, err
@@ -519,6 +581,41 @@ func (s *Service) ImportFiles(project, source string, recursive bool) (*ImportRe
 		return nil, fmt.Errorf("failed to access source: %w", err)
 	}
 
+	// Check the files against the import policy before writing anything
+	policy := s.config.ImportPolicy()
+	rejected := make(map[string]bool)
+	var rejections []global.ImportRejection
+	if sourceInfo.Mode()&os.ModeSymlink == 0 {
+		if !sourceInfo.IsDir() {
+			if err := policy.CheckFile(filepath.Base(source), sourceInfo.Size(), global.FileHead(source)); err != nil {
+				return nil, err
+			}
+		} else if recursive {
+			files := 0
+			var total int64
+			_ = walkNoFollow(source, func(path string, info os.FileInfo, err error) error {
+				if err != nil || !info.Mode().IsRegular() {
+					return nil
+				}
+				relPath, err := filepath.Rel(source, path)
+				if err != nil {
+					return nil
+				}
+				if err := policy.CheckFile(filepath.ToSlash(relPath), info.Size(), global.FileHead(path)); err != nil {
+					rejected[path] = true
+					rejections = append(rejections, global.ImportRejection{Path: filepath.ToSlash(relPath), Reason: err.Error()})
+					return nil
+				}
+				files++
+				total += info.Size()
+				return nil
+			})
+			if err := policy.CheckTotal(files, total); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// Create base imported directory
 	baseImportedDir := filepath.Join(s.getFilesDir(project), "imported")
 	if err := global.EnsureDir(baseImportedDir); err != nil {
@@ -548,6 +645,7 @@ func (s *Service) ImportFiles(project, source string, recursive bool) (*ImportRe
 		Source:     source,
 		Recursive:  recursive,
 		ImportedTo: importedTo,
+		Rejected:   rejections,
 	}
 
 	mutex := s.getProjectMutex(project)
@@ -611,8 +709,8 @@ func (s *Service) ImportFiles(project, source string, recursive bool) (*ImportRe
 				return nil
 			}
 
-			// Skip directories (they'll be created as needed)
-			if info.IsDir() {
+			// Skip directories (they'll be created as needed) and rejected files
+			if info.IsDir() || rejected[path] {
 				return nil
 			}
 
@@ -650,6 +748,9 @@ func (s *Service) ImportFiles(project, source string, recursive bool) (*ImportRe
 	importedFullPath := filepath.Join(s.getFilesDir(project), "imported")
 	result.LinksRemoved = sanitizeSymlinks(importedFullPath, s.logger)
 
+	if len(result.Rejected) > 0 {
+		s.logger.Warnf("Import into project '%s' left out %d file(s) under the import policy", project, len(result.Rejected))
+	}
 	if result.LinksRemoved > 0 {
 		result.LinksImported -= result.LinksRemoved
 		s.logger.Infof("Imported %d files and %d symlinks into project '%s' at '%s' (%d unsafe symlinks removed)",
@@ -809,6 +910,10 @@ func copyFile(src, dst string) error {
 		return err
 	}
 
+	// Replace rather than truncate an existing file: it may be hard-linked into a snapshot
+	if err := os.Remove(dst); err != nil && !os.IsNotExist(err) {
+		return err
+	}
 	dstFile, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, srcInfo.Mode())
 	if err != nil {
 		return err
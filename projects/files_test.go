@@ -8,6 +8,7 @@ package projects
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/PivotLLM/Maestro/config"
@@ -36,6 +37,12 @@ func createTestLogger(t *testing.T) *logging.Logger {
 }
 
 func createTestServiceWithConfig(t *testing.T) (*Service, string) {
+	return createTestServiceWithConfigExtra(t, "")
+}
+
+// createTestServiceWithConfigExtra is createTestServiceWithConfig with extra
+// top-level config JSON (ending with a comma)
+func createTestServiceWithConfigExtra(t *testing.T, extra string) (*Service, string) {
 	tmpDir, err := os.MkdirTemp("", "projects-test-*")
 	if err != nil {
 		t.Fatalf("Failed to create temp dir: %v", err)
@@ -49,7 +56,7 @@ func createTestServiceWithConfig(t *testing.T) (*Service, string) {
 	configPath := filepath.Join(tmpDir, "config.json")
 	configContent := `{
 		"version": 1,
-		"base_dir": "` + tmpDir + `",
+		"base_dir": "` + tmpDir + `",` + extra + `
 		"llms": [
 			{
 				"id": "test-llm",
@@ -81,7 +88,7 @@ func TestProjectFileOperations(t *testing.T) {
 	svc, _ := createTestServiceWithConfig(t)
 
 	// Create a project first
-	proj, err := svc.Create("file-test", "Test Project", "For testing files", "", "", "none")
+	proj, err := svc.Create("file-test", "Test Project", "For testing files", "", "", "none", "")
 	if err != nil {
 		t.Fatalf("Create() error = %v", err)
 	}
@@ -269,7 +276,7 @@ func TestProjectFileSearch(t *testing.T) {
 	svc, _ := createTestServiceWithConfig(t)
 
 	// Create project with files
-	_, err := svc.Create("search-test", "Search Test", "", "", "", "none")
+	_, err := svc.Create("search-test", "Search Test", "", "", "", "none", "")
 	if err != nil {
 		t.Fatalf("Create() error = %v", err)
 	}
@@ -338,7 +345,7 @@ func TestProjectRename(t *testing.T) {
 	svc, _ := createTestServiceWithConfig(t)
 
 	// Create a project
-	_, err := svc.Create("original", "Original Project", "", "", "", "none")
+	_, err := svc.Create("original", "Original Project", "", "", "", "none", "")
 	if err != nil {
 		t.Fatalf("Create() error = %v", err)
 	}
@@ -383,7 +390,7 @@ func TestProjectRename(t *testing.T) {
 	})
 
 	t.Run("rename to existing name", func(t *testing.T) {
-		_, _ = svc.Create("another", "Another", "", "", "", "none")
+		_, _ = svc.Create("another", "Another", "", "", "", "none", "")
 		err := svc.Rename("another", "renamed")
 		if err == nil {
 			t.Error("Rename() expected error when destination exists")
@@ -393,3 +400,76 @@ func TestProjectRename(t *testing.T) {
 
 // NOTE: Subproject file operation tests have been removed during the refactoring.
 // Subprojects are no longer supported - use path-based task sets instead.
+
+func TestCheckRunStorage(t *testing.T) {
+	svc, _ := createTestServiceWithConfig(t)
+
+	if _, err := svc.Create("storage-test", "Storage Test", "", "", "", "none", ""); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if problems := svc.CheckRunStorage("storage-test", -1); len(problems) != 0 {
+		t.Errorf("unexpected problems: %v", problems)
+	}
+	if _, err := os.Stat(svc.getReportsDir("storage-test")); err != nil {
+		t.Errorf("reports directory not created: %v", err)
+	}
+
+	// No file system has this much space free
+	problems := svc.CheckRunStorage("storage-test", 1<<40)
+	if len(problems) != 1 || !strings.Contains(problems[0], "min_free_disk_mb") {
+		t.Errorf("expected a disk space problem, got %v", problems)
+	}
+
+	if os.Geteuid() != 0 {
+		resultsDir := svc.GetResultsDir("storage-test")
+		if err := os.Chmod(resultsDir, 0555); err != nil {
+			t.Fatalf("Chmod failed: %v", err)
+		}
+		defer os.Chmod(resultsDir, 0755)
+		if problems := svc.CheckRunStorage("storage-test", -1); len(problems) != 1 || !strings.Contains(problems[0], resultsDir) {
+			t.Errorf("expected an unwritable results directory, got %v", problems)
+		}
+	}
+}
+
+func TestImportFilesPolicy(t *testing.T) {
+	svc, tmpDir := createTestServiceWithConfig(t)
+	if _, err := svc.Create("import-test", "Import Test", "import policy", "", "", "none", ""); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	src := filepath.Join(tmpDir, "evidence")
+	if err := os.MkdirAll(filepath.Join(src, "vm"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{"policy.txt": "Access policy", "vm/server.vmdk": "disk image", "vm/install.iso": "image"} {
+		if err := os.WriteFile(filepath.Join(src, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A blocked single file fails the import
+	if _, err := svc.ImportFiles("import-test", filepath.Join(src, "vm", "install.iso"), false); err == nil {
+		t.Error("expected error importing a blocked file")
+	}
+
+	// Blocked files are left out of a directory import
+	result, err := svc.ImportFiles("import-test", src, true)
+	if err != nil {
+		t.Fatalf("ImportFiles() error = %v", err)
+	}
+	if result.FilesImported != 1 || len(result.Rejected) != 2 {
+		t.Fatalf("ImportFiles() = %+v", result)
+	}
+	if result.Rejected[0].Path != "vm/install.iso" || !strings.Contains(result.Rejected[0].Reason, ".iso files are blocked") {
+		t.Errorf("unexpected rejection: %+v", result.Rejected[0])
+	}
+	filesDir := svc.GetFilesDir("import-test")
+	if _, err := os.Stat(filepath.Join(filesDir, "imported", "evidence", "vm", "server.vmdk")); !os.IsNotExist(err) {
+		t.Errorf("blocked file was imported (stat error %v)", err)
+	}
+	if _, err := os.Stat(filepath.Join(filesDir, "imported", "evidence", "policy.txt")); err != nil {
+		t.Errorf("allowed file not imported: %v", err)
+	}
+}
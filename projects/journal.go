@@ -0,0 +1,144 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package projects
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// getJournalDir returns the directory holding the project's run journals
+func (s *Service) getJournalDir(project string) string {
+	return filepath.Join(s.getProjectDir(project), global.InternalDir, global.JournalDir)
+}
+
+// getJournalPath returns the path to a run's journal
+func (s *Service) getJournalPath(project, runID string) string {
+	return filepath.Join(s.getJournalDir(project), runID+".jsonl")
+}
+
+// AppendJournal appends an entry to a run's journal, creating it on the first entry.
+// Each entry is synced to disk before returning so that it survives a crash.
+func (s *Service) AppendJournal(project, runID string, entry *global.RunJournalEntry) error {
+	if err := validateProjectName(project); err != nil {
+		return err
+	}
+
+	if !s.ProjectExists(project) {
+		return fmt.Errorf("project not found: %s", project)
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal journal entry: %w", err)
+	}
+
+	s.journalMu.Lock()
+	defer s.journalMu.Unlock()
+
+	if err := os.MkdirAll(s.getJournalDir(project), 0755); err != nil {
+		return fmt.Errorf("failed to create journal directory: %w", err)
+	}
+
+	f, err := os.OpenFile(s.getJournalPath(project, runID), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open journal: %w", err)
+	}
+	defer func(f *os.File) {
+		_ = f.Close()
+	}(f)
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write journal entry: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		return fmt.Errorf("failed to sync journal: %w", err)
+	}
+
+	return nil
+}
+
+// GetJournals reads every run journal in the project, keyed by run ID.
+// Returns an empty map if there are none. Unparseable lines are skipped, so a
+// journal cut off mid-line by a crash is still read.
+func (s *Service) GetJournals(project string) (map[string][]global.RunJournalEntry, error) {
+	if err := validateProjectName(project); err != nil {
+		return nil, err
+	}
+
+	journals := make(map[string][]global.RunJournalEntry)
+	files, err := os.ReadDir(s.getJournalDir(project))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return journals, nil
+		}
+		return nil, fmt.Errorf("failed to read journal directory: %w", err)
+	}
+
+	s.journalMu.Lock()
+	defer s.journalMu.Unlock()
+
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".jsonl") {
+			continue
+		}
+		entries, err := readJournal(filepath.Join(s.getJournalDir(project), file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		journals[strings.TrimSuffix(file.Name(), ".jsonl")] = entries
+	}
+
+	return journals, nil
+}
+
+// readJournal reads the entries of one journal file
+func readJournal(path string) ([]global.RunJournalEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+	defer func(f *os.File) {
+		_ = f.Close()
+	}(f)
+
+	var entries []global.RunJournalEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry global.RunJournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	return entries, nil
+}
+
+// RemoveJournal deletes a run's journal once the run has finished or been recovered
+func (s *Service) RemoveJournal(project, runID string) error {
+	if err := validateProjectName(project); err != nil {
+		return err
+	}
+
+	s.journalMu.Lock()
+	defer s.journalMu.Unlock()
+
+	if err := os.Remove(s.getJournalPath(project, runID)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove journal: %w", err)
+	}
+	return nil
+}
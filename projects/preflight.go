@@ -0,0 +1,46 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package projects
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// CheckRunStorage verifies that a run can write everything it produces: the
+// project, results and reports directories must be writable, the project log
+// must accept appends, and the file system must have at least minFreeMB free
+// (a negative value skips the disk space check). Returns one actionable
+// message per problem; an empty result means the run can proceed.
+func (s *Service) CheckRunStorage(project string, minFreeMB int) []string {
+	var problems []string
+
+	projectDir := s.getProjectDir(project)
+	for _, dir := range []string{projectDir, s.getResultsDir(project), s.getReportsDir(project)} {
+		if err := global.CheckWritableDir(dir); err != nil {
+			problems = append(problems, fmt.Sprintf("directory %s is not writable: %v (check its permissions and owner)", dir, err))
+		}
+	}
+
+	logPath := s.getProjectLogPath(project)
+	if f, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644); err != nil {
+		problems = append(problems, fmt.Sprintf("project log %s cannot be written: %v (check its permissions and owner)", logPath, err))
+	} else {
+		_ = f.Close()
+	}
+
+	if minFreeMB >= 0 {
+		if freeMB, err := global.FreeDiskMB(projectDir); err != nil {
+			problems = append(problems, fmt.Sprintf("cannot determine free disk space for %s: %v", projectDir, err))
+		} else if freeMB < uint64(minFreeMB) {
+			problems = append(problems, fmt.Sprintf("only %d MB free on the file system holding %s, below the %d MB minimum (free up space or lower runner.min_free_disk_mb)", freeMB, projectDir, minFreeMB))
+		}
+	}
+
+	return problems
+}
@@ -27,7 +27,14 @@ import (
 type Service struct {
 	config       *config.Config
 	logger       *logging.Logger
-	projectMutex sync.Map // map[string]*sync.Mutex for per-project locking
+	projectMutex sync.Map   // map[string]*sync.Mutex for per-project locking
+	auditMu      sync.Mutex // Serializes audit.jsonl appends so concurrent calls never interleave lines
+	eventsMu     sync.Mutex // Serializes events.jsonl appends from parallel tasks
+	journalMu    sync.Mutex // Serializes run journal writes, reads and removal
+	reportIndex  sync.Map   // map[string]*indexedReport of report sections, keyed by file path
+
+	// Parsed project files, reused while unchanged
+	cache global.FileCache[*global.Project]
 }
 
 // ProjectInfo is returned by List operations
@@ -129,9 +136,15 @@ func validateProjectStatus(status string) error {
 	return nil
 }
 
-// loadProject loads a project file
+// loadProject loads a project file, or takes it from the cache while the file is unchanged
 func (s *Service) loadProject(project string) (*global.Project, error) {
 	projectPath := s.getProjectFilePath(project)
+	info, statErr := os.Stat(projectPath)
+	if statErr == nil {
+		if proj, ok := s.cache.Get(projectPath, info); ok {
+			return proj, nil
+		}
+	}
 
 	data, err := os.ReadFile(projectPath)
 	if err != nil {
@@ -146,6 +159,9 @@ func (s *Service) loadProject(project string) (*global.Project, error) {
 		return nil, fmt.Errorf("failed to parse project file: %w", err)
 	}
 
+	if statErr == nil {
+		s.cache.Put(projectPath, info, &proj)
+	}
 	return &proj, nil
 }
 
@@ -153,6 +169,7 @@ func (s *Service) loadProject(project string) (*global.Project, error) {
 func (s *Service) saveProject(project string, proj *global.Project) error {
 	projectDir := s.getProjectDir(project)
 	projectPath := s.getProjectFilePath(project)
+	defer s.cache.Invalidate(projectPath)
 
 	// Ensure directory exists
 	if err := os.MkdirAll(projectDir, 0755); err != nil {
@@ -198,7 +215,7 @@ func (s *Service) saveProject(project string, proj *global.Project) error {
 }
 
 // Create creates a new project
-func (s *Service) Create(project, title, description, projectContext, status, disclaimerTemplate string) (*global.Project, error) {
+func (s *Service) Create(project, title, description, projectContext, status, disclaimerTemplate, outputLanguage string) (*global.Project, error) {
 	if err := validateProjectName(project); err != nil {
 		return nil, err
 	}
@@ -212,14 +229,13 @@ func (s *Service) Create(project, title, description, projectContext, status, di
 		return nil, err
 	}
 
-	// Validate disclaimer_template is provided and valid
-	if disclaimerTemplate == "" {
-		return nil, fmt.Errorf("disclaimer_template is required: provide a playbook path (e.g., 'playbook-name/templates/disclaimer.md') or 'none'")
+	// Validate disclaimer_template; whether it may be omitted depends on the policy
+	disclaimerTemplate, err := s.normalizeDisclaimer(disclaimerTemplate)
+	if err != nil {
+		return nil, err
 	}
-	if disclaimerTemplate != "none" {
-		if err := s.validateDisclaimerPath(disclaimerTemplate); err != nil {
-			return nil, err
-		}
+	if disclaimerTemplate == "" && s.config.Runner().DisclaimerPolicy == global.DisclaimerPolicyRequired {
+		return nil, fmt.Errorf("disclaimer_template is required: provide a playbook path (e.g., 'playbook-name/templates/disclaimer.md') or 'none'")
 	}
 
 	mutex := s.getProjectMutex(project)
@@ -241,9 +257,13 @@ func (s *Service) Create(project, title, description, projectContext, status, di
 		Context:            projectContext,
 		Status:             status,
 		DisclaimerTemplate: disclaimerTemplate,
+		OutputLanguage:     outputLanguage,
 		CreatedAt:          now,
 		UpdatedAt:          now,
 	}
+	if status == global.ProjectStatusDone {
+		proj.DoneAt = &now
+	}
 
 	if err := s.saveProject(project, proj); err != nil {
 		return nil, err
@@ -280,6 +300,48 @@ func (s *Service) validateDisclaimerPath(disclaimerPath string) error {
 	return nil
 }
 
+// normalizeDisclaimer validates a disclaimer_template value: a playbook path,
+// "none", or "default" (or empty) to follow runner.disclaimer_policy, which is
+// stored as an empty value
+func (s *Service) normalizeDisclaimer(disclaimerTemplate string) (string, error) {
+	switch disclaimerTemplate {
+	case "", global.DisclaimerPolicyDefault:
+		return "", nil
+	case global.DisclaimerNone:
+		return disclaimerTemplate, nil
+	}
+	if err := s.validateDisclaimerPath(disclaimerTemplate); err != nil {
+		return "", err
+	}
+	return disclaimerTemplate, nil
+}
+
+// Disclaimer returns the disclaimer template a project's reports use, or ""
+// for none: the project's own disclaimer_template, otherwise whatever
+// runner.disclaimer_policy prescribes. Returns an error when the policy
+// requires a disclaimer_template the project lacks, or the template is missing.
+func (s *Service) Disclaimer(proj *global.Project) (string, error) {
+	disclaimerTemplate := proj.DisclaimerTemplate
+	if disclaimerTemplate == "" {
+		runner := s.config.Runner()
+		switch runner.DisclaimerPolicy {
+		case global.DisclaimerPolicyOptional:
+			return "", nil
+		case global.DisclaimerPolicyDefault:
+			disclaimerTemplate = runner.DefaultDisclaimerTemplate
+		default:
+			return "", fmt.Errorf("disclaimer_template is not configured for project %s: update project with disclaimer_template set to a playbook path or 'none'", proj.Name)
+		}
+	}
+	if disclaimerTemplate == global.DisclaimerNone {
+		return "", nil
+	}
+	if err := s.validateDisclaimerPath(disclaimerTemplate); err != nil {
+		return "", err
+	}
+	return disclaimerTemplate, nil
+}
+
 // Get retrieves a project
 func (s *Service) Get(project string) (*global.Project, error) {
 	if err := validateProjectName(project); err != nil {
@@ -299,7 +361,7 @@ func (s *Service) Get(project string) (*global.Project, error) {
 }
 
 // Update updates project metadata
-func (s *Service) Update(project string, title, description, projectContext, status, disclaimerTemplate *string) (*global.Project, error) {
+func (s *Service) Update(project string, title, description, projectContext, status, disclaimerTemplate, outputLanguage *string) (*global.Project, error) {
 	if err := validateProjectName(project); err != nil {
 		return nil, err
 	}
@@ -327,10 +389,26 @@ func (s *Service) Update(project string, title, description, projectContext, sta
 		if err := validateProjectStatus(*status); err != nil {
 			return nil, err
 		}
+		if *status != proj.Status {
+			// The retention clock starts when the project is done; reopening it
+			// stops the clock and allows a later purge again
+			proj.DoneAt, proj.PurgedAt = nil, nil
+			if *status == global.ProjectStatusDone {
+				now := time.Now()
+				proj.DoneAt = &now
+			}
+		}
 		proj.Status = *status
 	}
 	if disclaimerTemplate != nil {
-		proj.DisclaimerTemplate = *disclaimerTemplate
+		normalized, err := s.normalizeDisclaimer(*disclaimerTemplate)
+		if err != nil {
+			return nil, err
+		}
+		proj.DisclaimerTemplate = normalized
+	}
+	if outputLanguage != nil {
+		proj.OutputLanguage = *outputLanguage
 	}
 
 	proj.UpdatedAt = time.Now()
@@ -343,6 +421,63 @@ func (s *Service) Update(project string, title, description, projectContext, sta
 	return proj, nil
 }
 
+// SetAllowedLLMs restricts the LLMs the project may use to the given IDs, or
+// lifts the restriction when allowed is empty. The IDs are not checked against
+// the configuration here; callers pass canonical IDs.
+func (s *Service) SetAllowedLLMs(project string, allowed []string) (*global.Project, error) {
+	if err := validateProjectName(project); err != nil {
+		return nil, err
+	}
+
+	mutex := s.getProjectMutex(project)
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	proj, err := s.loadProject(project)
+	if err != nil {
+		return nil, err
+	}
+	proj.AllowedLLMs = allowed
+	if len(allowed) == 0 {
+		proj.AllowedLLMs = nil
+	}
+	proj.UpdatedAt = time.Now()
+	if err := s.saveProject(project, proj); err != nil {
+		return nil, err
+	}
+
+	s.logger.Debugf("Updated allowed LLMs of project: %s", project)
+	return proj, nil
+}
+
+// SetPromptRedaction sets the project's prompt redaction, which adds to the
+// configured one, or removes it when redaction is nil
+func (s *Service) SetPromptRedaction(project string, redaction *global.PromptRedaction) (*global.Project, error) {
+	if err := validateProjectName(project); err != nil {
+		return nil, err
+	}
+	if err := global.ValidatePromptRedaction(redaction); err != nil {
+		return nil, err
+	}
+
+	mutex := s.getProjectMutex(project)
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	proj, err := s.loadProject(project)
+	if err != nil {
+		return nil, err
+	}
+	proj.PromptRedaction = redaction
+	proj.UpdatedAt = time.Now()
+	if err := s.saveProject(project, proj); err != nil {
+		return nil, err
+	}
+
+	s.logger.Debugf("Updated prompt redaction of project: %s", project)
+	return proj, nil
+}
+
 // List lists all projects with optional status filter
 func (s *Service) List(status string, limit, offset int) (*ProjectListResult, error) {
 	if limit <= 0 {
@@ -521,8 +656,8 @@ func (s *Service) appendLogEntry(project, message string) error {
 	}(f)
 
 	// Write log entry
-	timestamp := time.Now().Format(time.RFC3339)
-	entry := fmt.Sprintf("%s %s\n", timestamp, message)
+	timestamp := s.clock().Log(time.Now())
+	entry := fmt.Sprintf("%s %s\n", timestamp, s.redact(message))
 	if _, err := f.WriteString(entry); err != nil {
 		return fmt.Errorf("failed to write log entry: %w", err)
 	}
@@ -530,6 +665,22 @@ func (s *Service) appendLogEntry(project, message string) error {
 	return nil
 }
 
+// redact masks configured secrets in text written to project and task logs
+func (s *Service) redact(message string) string {
+	if s.config == nil {
+		return message
+	}
+	return s.config.Redactor().Redact(message)
+}
+
+// clock returns the timezone and formats for project logs and reports
+func (s *Service) clock() *global.Clock {
+	if s.config == nil {
+		return nil
+	}
+	return s.config.Clock()
+}
+
 // AppendLog appends a log entry to the project or task log
 func (s *Service) AppendLog(project, taskID, message string) error {
 	if err := validateProjectName(project); err != nil {
@@ -564,8 +715,8 @@ func (s *Service) AppendLog(project, taskID, message string) error {
 			_ = f.Close()
 		}(f)
 
-		timestamp := time.Now().Format(time.RFC3339)
-		entry := fmt.Sprintf("%s %s\n", timestamp, message)
+		timestamp := s.clock().Log(time.Now())
+		entry := fmt.Sprintf("%s %s\n", timestamp, s.redact(message))
 		if _, err := f.WriteString(entry); err != nil {
 			return fmt.Errorf("failed to write task log entry: %w", err)
 		}
@@ -0,0 +1,147 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package projects
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// Marker lines around generated report blocks
+var (
+	reportBlockBegin = regexp.MustCompile("^" + fmt.Sprintf(regexp.QuoteMeta(global.ReportBlockBegin), `(\S+)`, `(\S+)`) + "$")
+	reportBlockEnd   = regexp.MustCompile("^" + fmt.Sprintf(regexp.QuoteMeta(global.ReportBlockEnd), `(\S+)`) + "$")
+)
+
+// reportPart is a generated block of a report, or the text between blocks
+type reportPart struct {
+	key  string // "" for text
+	hash string
+	raw  string
+}
+
+// isReportBlockMarker reports whether a line is a generated block's marker
+func isReportBlockMarker(line string) bool {
+	line = strings.TrimSpace(line)
+	return reportBlockBegin.MatchString(line) || reportBlockEnd.MatchString(line)
+}
+
+// reportBlockHash returns the hash of a block's content, which tells whether
+// the block changed since it was written
+func reportBlockHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:global.ReportBlockHashBytes])
+}
+
+// formatReportBlock returns a block between its markers, followed by a blank line
+func formatReportBlock(key, content string) string {
+	content = strings.Trim(content, "\n")
+	return fmt.Sprintf(global.ReportBlockBegin, key, reportBlockHash(content)) + "\n" +
+		content + "\n" +
+		fmt.Sprintf(global.ReportBlockEnd, key) + "\n\n"
+}
+
+// parseReportParts splits a report into its generated blocks and the text around
+// them. A block takes the blank lines after its end marker; a begin marker
+// without a matching end marker is left as text.
+func parseReportParts(content string) []reportPart {
+	lines := strings.SplitAfter(content, "\n")
+	var parts []reportPart
+	var text strings.Builder
+	flushText := func() {
+		if text.Len() > 0 {
+			parts = append(parts, reportPart{raw: text.String()})
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		m := reportBlockBegin.FindStringSubmatch(strings.TrimSpace(lines[i]))
+		if m == nil {
+			text.WriteString(lines[i])
+			continue
+		}
+		end := -1
+		for j := i + 1; j < len(lines); j++ {
+			if e := reportBlockEnd.FindStringSubmatch(strings.TrimSpace(lines[j])); e != nil && e[1] == m[1] {
+				end = j
+				break
+			}
+		}
+		if end < 0 {
+			text.WriteString(lines[i])
+			continue
+		}
+		for end+1 < len(lines) && strings.TrimSpace(lines[end+1]) == "" && lines[end+1] != "" {
+			end++
+		}
+		flushText()
+		parts = append(parts, reportPart{key: m[1], hash: m[2], raw: strings.Join(lines[i:end+1], "")})
+		i = end
+	}
+	flushText()
+	return parts
+}
+
+// mergeReportBlocks merges generated blocks into a report. A block whose key is
+// in the report replaces it if its content changed; a new block goes after the
+// block before it in blocks, or at the end of the report.
+func mergeReportBlocks(content string, blocks []global.ReportBlock) (string, *global.ReportMergeResult) {
+	parts := parseReportParts(content)
+	index := make(map[string]int)
+	for i, part := range parts {
+		if part.key != "" {
+			index[part.key] = i
+		}
+	}
+
+	// New blocks to insert after each existing part; -1 is the end of the report
+	inserts := make(map[int][]string)
+	anchor := -1
+	result := &global.ReportMergeResult{}
+	for _, block := range blocks {
+		formatted := formatReportBlock(block.Key, block.Content)
+		i, exists := index[block.Key]
+		if !exists {
+			inserts[anchor] = append(inserts[anchor], formatted)
+			result.Added++
+			continue
+		}
+		if parts[i].hash == reportBlockHash(strings.Trim(block.Content, "\n")) {
+			result.Unchanged++
+		} else {
+			parts[i].raw = formatted
+			result.Replaced++
+		}
+		anchor = i
+	}
+
+	var out strings.Builder
+	write := func(raw []string) {
+		for _, r := range raw {
+			// Keep new blocks off the end of text that did not end its paragraph
+			if s := out.String(); s != "" && !strings.HasSuffix(s, "\n\n") {
+				if strings.HasSuffix(s, "\n") {
+					out.WriteString("\n")
+				} else {
+					out.WriteString("\n\n")
+				}
+			}
+			out.WriteString(r)
+		}
+	}
+	for i, part := range parts {
+		out.WriteString(part.raw)
+		write(inserts[i])
+	}
+	write(inserts[-1])
+	return out.String(), result
+}
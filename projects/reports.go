@@ -45,13 +45,19 @@ func validateReportName(name string) error {
 	if strings.Contains(name, "..") {
 		return fmt.Errorf("report name cannot contain '..'")
 	}
-	// Must end with .md
-	if !strings.HasSuffix(name, ".md") {
-		return fmt.Errorf("report name must end with .md")
+	// Must be a Markdown report or one of its HTML and PDF renderings
+	if !isReportFile(name) {
+		return fmt.Errorf("report name must end with .md, .html or .pdf")
 	}
 	return nil
 }
 
+// isReportFile reports whether a file name is a Markdown report or a rendering of one
+func isReportFile(name string) bool {
+	return strings.HasSuffix(name, ".md") || strings.HasSuffix(name, "."+global.ReportFormatHTML) ||
+		strings.HasSuffix(name, "."+global.ReportFormatPDF)
+}
+
 // ListReports lists all reports in a project.
 func (s *Service) ListReports(project string) ([]ReportItem, error) {
 	if err := validateProjectName(project); err != nil {
@@ -62,8 +68,20 @@ func (s *Service) ListReports(project string) ([]ReportItem, error) {
 		return nil, fmt.Errorf("project not found: %s", project)
 	}
 
-	reportsDir := s.getReportsDir(project)
+	return s.listReports(project, s.getReportsDir(project))
+}
+
+// ListSnapshotReports lists the reports of a project snapshot.
+func (s *Service) ListSnapshotReports(project, snapshot string) ([]ReportItem, error) {
+	dir, err := s.snapshotDir(project, snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return s.listReports(project, filepath.Join(dir, global.ReportsDir))
+}
 
+// listReports lists the reports in reportsDir.
+func (s *Service) listReports(project, reportsDir string) ([]ReportItem, error) {
 	// Check if reports directory exists
 	if !global.DirExists(reportsDir) {
 		return []ReportItem{}, nil
@@ -82,8 +100,8 @@ func (s *Service) ListReports(project string) ([]ReportItem, error) {
 		}
 
 		name := entry.Name()
-		if !strings.HasSuffix(name, ".md") {
-			continue // Only markdown reports
+		if !isReportFile(name) {
+			continue // Only reports and their renderings
 		}
 
 		info, err := entry.Info()
@@ -117,7 +135,31 @@ func (s *Service) ReadReport(project, name string, offset, maxBytes int64) (*Rep
 		return nil, fmt.Errorf("project not found: %s", project)
 	}
 
-	reportsDir := s.getReportsDir(project)
+	mutex := s.getProjectMutex(project)
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	return s.readReport(project, s.getReportsDir(project), name, offset, maxBytes)
+}
+
+// ReadSnapshotReport retrieves a report from a project snapshot with optional byte range.
+func (s *Service) ReadSnapshotReport(project, snapshot, name string, offset, maxBytes int64) (*ReportItem, error) {
+	if err := validateReportName(name); err != nil {
+		return nil, err
+	}
+	dir, err := s.snapshotDir(project, snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return s.readReport(project, filepath.Join(dir, global.ReportsDir), name, offset, maxBytes)
+}
+
+// readReport reads a report in reportsDir with optional byte range.
+func (s *Service) readReport(project, reportsDir, name string, offset, maxBytes int64) (*ReportItem, error) {
+	if strings.HasSuffix(name, "."+global.ReportFormatPDF) {
+		return nil, fmt.Errorf("%s is a PDF and cannot be read as text; read its Markdown report instead", name)
+	}
+
 	absPath := filepath.Join(reportsDir, name)
 
 	// Verify path is within reports directory (defense in depth)
@@ -125,10 +167,6 @@ func (s *Service) ReadReport(project, name string, offset, maxBytes int64) (*Rep
 		return nil, fmt.Errorf("invalid report path")
 	}
 
-	mutex := s.getProjectMutex(project)
-	mutex.Lock()
-	defer mutex.Unlock()
-
 	// Check file exists
 	info, err := os.Stat(absPath)
 	if err != nil {
@@ -190,7 +228,8 @@ func (s *Service) ReadReport(project, name string, offset, maxBytes int64) (*Rep
 
 // StartReport initializes a report session with a prefix.
 // Stores the title, intro, and date in project config - actual file writing happens on first append.
-// Returns the generated prefix.
+// The prefix is made from the configured report_prefix format; a prefix already
+// used by another session is incremented or refused. Returns the generated prefix.
 func (s *Service) StartReport(project, title, intro string) (string, error) {
 	if err := validateProjectName(project); err != nil {
 		return "", err
@@ -200,22 +239,35 @@ func (s *Service) StartReport(project, title, intro string) (string, error) {
 		return "", fmt.Errorf("project not found: %s", project)
 	}
 
-	// Generate prefix: YYYYMMDD-HHMM-<sanitized-title>-
-	now := time.Now()
-	sanitizedTitle := sanitizeTitleForPrefix(title)
-	prefix := fmt.Sprintf("%s-%s-", now.Format("20060102-1504"), sanitizedTitle)
-
-	// Update project with report prefix, title, intro, and date
 	proj, err := s.Get(project)
 	if err != nil {
 		return "", fmt.Errorf("failed to get project: %w", err)
 	}
 
+	// Generate prefix, by default YYYYMMDD-HHMM-<sanitized-title>-
+	now := s.clock().Now()
+	settings := s.config.ReportPrefix()
+	prefix := settings.Expand(project, sanitizeTitleForPrefix(title), now, len(proj.ReportSessions)+1)
+	if s.reportPrefixInUse(project, proj, prefix) {
+		if settings.OnCollision == global.ReportPrefixCollisionRefuse {
+			return "", fmt.Errorf("report prefix %s is already used by another report session of project %s", prefix, project)
+		}
+		base := strings.TrimSuffix(prefix, "-")
+		for n := 2; s.reportPrefixInUse(project, proj, prefix); n++ {
+			prefix = fmt.Sprintf("%s-%d-", base, n)
+		}
+	}
+
+	// A session still active is replaced by the new one
+	endReportSession(proj, now, false)
+
+	// Update project with report prefix, title, intro, and date
+	proj.ReportSessions = append(proj.ReportSessions, global.ReportSession{Prefix: prefix, Title: title, StartedAt: now})
 	proj.ReportPrefix = prefix
 	proj.ReportStartedAt = &now
 	proj.ReportTitle = title
 	proj.ReportIntro = intro
-	proj.ReportDate = now.Format("2006-01-02") // Capture date at session start
+	proj.ReportDate = s.clock().Date(now) // Capture date at session start
 	proj.UpdatedAt = now
 
 	if err := s.saveProject(project, proj); err != nil {
@@ -236,36 +288,112 @@ func (s *Service) StartReport(project, title, intro string) (string, error) {
 // If reportName is empty, appends to main report (<prefix>Report.md).
 // If no report session is active, auto-initializes with project name.
 // If the file doesn't exist, adds the L1 header (title) and optional intro first.
-func (s *Service) AppendReport(project, content, reportName string) error {
-	if err := validateProjectName(project); err != nil {
+// meta (optional) supplies the manifest title, audience and description for the header.
+func (s *Service) AppendReport(project, content, reportName string, meta *global.ReportTemplateConfig) error {
+	if content == "" {
+		return fmt.Errorf("content cannot be empty")
+	}
+
+	proj, filename, err := s.openReport(project, reportName)
+	if err != nil {
 		return err
 	}
+	absPath := filepath.Join(s.getReportsDir(project), filename)
 
-	if !s.ProjectExists(project) {
-		return fmt.Errorf("project not found: %s", project)
+	mutex := s.getProjectMutex(project)
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	// Read existing content if file exists; a new file starts with the header
+	var existingContent string
+	if data, err := os.ReadFile(absPath); err == nil {
+		existingContent = string(data)
+	} else {
+		existingContent = s.reportHeader(proj, meta)
 	}
 
-	if content == "" {
-		return fmt.Errorf("content cannot be empty")
+	// Append content
+	newContent := existingContent + content
+
+	// Write atomically
+	if err := global.AtomicWrite(absPath, []byte(newContent)); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	s.logger.Infof("Project %s: Wrote report %s", project, filename)
+	return nil
+}
+
+// WriteGeneratedReport writes generated blocks to a report, like AppendReport, but
+// idempotently: blocks already in the report with the same content are left as they
+// are, blocks whose content changed are replaced in place, and new blocks are
+// inserted after the block that precedes them in blocks, or appended. Content
+// appended by other means stays where it is. With regenerate, the report is
+// rebuilt from its header and the blocks alone.
+func (s *Service) WriteGeneratedReport(project, reportName string, blocks []global.ReportBlock, meta *global.ReportTemplateConfig, regenerate bool) (*global.ReportMergeResult, error) {
+	if len(blocks) == 0 {
+		return nil, fmt.Errorf("content cannot be empty")
+	}
+
+	proj, filename, err := s.openReport(project, reportName)
+	if err != nil {
+		return nil, err
+	}
+	absPath := filepath.Join(s.getReportsDir(project), filename)
+
+	mutex := s.getProjectMutex(project)
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	var existingContent string
+	if data, err := os.ReadFile(absPath); err == nil && !regenerate {
+		existingContent = string(data)
+	} else {
+		existingContent = s.reportHeader(proj, meta)
+	}
+
+	newContent, result := mergeReportBlocks(existingContent, blocks)
+	if result.Added == 0 && result.Replaced == 0 && !regenerate {
+		s.logger.Debugf("Project %s: Report %s is up to date", project, filename)
+		return result, nil
+	}
+
+	if err := global.AtomicWrite(absPath, []byte(newContent)); err != nil {
+		return nil, fmt.Errorf("failed to write report: %w", err)
+	}
+
+	s.logger.Infof("Project %s: Wrote report %s (%d added, %d replaced, %d unchanged)",
+		project, filename, result.Added, result.Replaced, result.Unchanged)
+	return result, nil
+}
+
+// openReport returns the project, with a report session auto-initialized if none
+// is active, and the filename of a report in the session. The reports directory is
+// created if needed.
+func (s *Service) openReport(project, reportName string) (*global.Project, string, error) {
+	if err := validateProjectName(project); err != nil {
+		return nil, "", err
+	}
+
+	if !s.ProjectExists(project) {
+		return nil, "", fmt.Errorf("project not found: %s", project)
 	}
 
 	// Get project to check/set report prefix
 	proj, err := s.Get(project)
 	if err != nil {
-		return fmt.Errorf("failed to get project: %w", err)
+		return nil, "", fmt.Errorf("failed to get project: %w", err)
 	}
 
 	// Auto-initialize report session if not started
 	if proj.ReportPrefix == "" {
-		prefix, err := s.StartReport(project, proj.Title, "")
-		if err != nil {
-			return fmt.Errorf("failed to auto-initialize report session: %w", err)
+		if _, err := s.StartReport(project, proj.Title, ""); err != nil {
+			return nil, "", fmt.Errorf("failed to auto-initialize report session: %w", err)
 		}
-		proj.ReportPrefix = prefix
 		// Re-fetch project to get updated title/intro
 		proj, err = s.Get(project)
 		if err != nil {
-			return fmt.Errorf("failed to get project after init: %w", err)
+			return nil, "", fmt.Errorf("failed to get project after init: %w", err)
 		}
 	}
 
@@ -281,72 +409,158 @@ func (s *Service) AppendReport(project, content, reportName string) error {
 
 	// Validate the resulting filename
 	if err := validateReportName(filename); err != nil {
+		return nil, "", err
+	}
+
+	if err := global.EnsureDir(s.getReportsDir(project)); err != nil {
+		return nil, "", fmt.Errorf("failed to create reports directory: %w", err)
+	}
+
+	return proj, filename, nil
+}
+
+// reportHeader returns the start of a new report: the L1 header with date,
+// manifest metadata, optional intro, and disclaimer
+func (s *Service) reportHeader(proj *global.Project, meta *global.ReportTemplateConfig) string {
+	// Use ReportTitle if set, otherwise fall back to project Title
+	title := proj.ReportTitle
+	if title == "" {
+		title = proj.Title
+	}
+
+	// Build header: title, issued date, then optional intro
+	if meta != nil && meta.Title != "" {
+		title = fmt.Sprintf("%s — %s", title, meta.Title)
+	}
+	header := fmt.Sprintf("# %s\n\n", title)
+
+	// Add issued date (use captured date or current date if not set)
+	reportDate := proj.ReportDate
+	if reportDate == "" {
+		reportDate = s.clock().Date(time.Now())
+	}
+	header += fmt.Sprintf("**Issued:** %s\n\n", reportDate)
+
+	// Add manifest metadata if present
+	if meta != nil && meta.Audience != "" {
+		header += fmt.Sprintf("**Audience:** %s\n\n", meta.Audience)
+	}
+	if meta != nil && meta.Description != "" {
+		header += meta.Description + "\n\n"
+	}
+
+	// Add intro if present
+	if proj.ReportIntro != "" {
+		header += proj.ReportIntro + "\n\n"
+	}
+
+	// Add disclaimer if configured, for the project or by the disclaimer policy
+	disclaimerPath, err := s.Disclaimer(proj)
+	if err != nil {
+		s.logger.Warnf("Report for project %s has no disclaimer: %v", proj.Name, err)
+	}
+	disclaimer := s.loadDisclaimer(disclaimerPath)
+	if disclaimer != "" {
+		// Strip trailing newlines from disclaimer, then add one
+		disclaimer = strings.TrimRight(disclaimer, "\n\r")
+		header += disclaimer + "\n\n"
+	}
+
+	return header
+}
+
+// WriteReportFile writes a rendering of a report, such as its HTML or PDF version,
+// to the reports directory, replacing any previous version.
+func (s *Service) WriteReportFile(project, name string, data []byte) error {
+	if err := validateProjectName(project); err != nil {
 		return err
 	}
 
+	if err := validateReportName(name); err != nil {
+		return err
+	}
+
+	if !s.ProjectExists(project) {
+		return fmt.Errorf("project not found: %s", project)
+	}
+
 	reportsDir := s.getReportsDir(project)
 	if err := global.EnsureDir(reportsDir); err != nil {
 		return fmt.Errorf("failed to create reports directory: %w", err)
 	}
 
-	absPath := filepath.Join(reportsDir, filename)
-
 	mutex := s.getProjectMutex(project)
 	mutex.Lock()
 	defer mutex.Unlock()
 
-	// Read existing content if file exists
-	var existingContent string
-	fileExists := false
-	if data, err := os.ReadFile(absPath); err == nil {
-		existingContent = string(data)
-		fileExists = true
+	if err := global.AtomicWrite(filepath.Join(reportsDir, name), data); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
 	}
 
-	// If file doesn't exist, add the L1 header with date, optional intro, and disclaimer
-	if !fileExists {
-		// Use ReportTitle if set, otherwise fall back to project Title
-		title := proj.ReportTitle
-		if title == "" {
-			title = proj.Title
-		}
+	s.logger.Infof("Project %s: Wrote report %s", project, name)
+	return nil
+}
 
-		// Build header: title, issued date, then optional intro
-		header := fmt.Sprintf("# %s\n\n", title)
+// WriteReportIndex writes the per-run index of generated reports to <prefix>Index.md,
+// replacing any previous index for the session. Returns the index filename.
+func (s *Service) WriteReportIndex(project string, entries []global.ReportIndexEntry) (string, error) {
+	if err := validateProjectName(project); err != nil {
+		return "", err
+	}
 
-		// Add issued date (use captured date or current date if not set)
-		reportDate := proj.ReportDate
-		if reportDate == "" {
-			reportDate = time.Now().Format("2006-01-02")
-		}
-		header += fmt.Sprintf("**Issued:** %s\n\n", reportDate)
+	proj, err := s.Get(project)
+	if err != nil {
+		return "", fmt.Errorf("failed to get project: %w", err)
+	}
 
-		// Add intro if present
-		if proj.ReportIntro != "" {
-			header += proj.ReportIntro + "\n\n"
-		}
+	if proj.ReportPrefix == "" {
+		return "", fmt.Errorf("no active report session")
+	}
 
-		// Add disclaimer if configured
-		disclaimer := s.loadDisclaimer(proj.DisclaimerTemplate)
-		if disclaimer != "" {
-			// Strip trailing newlines from disclaimer, then add one
-			disclaimer = strings.TrimRight(disclaimer, "\n\r")
-			header += disclaimer + "\n\n"
-		}
+	filename := proj.ReportPrefix + global.ReportIndexSuffix + ".md"
+	if err := validateReportName(filename); err != nil {
+		return "", err
+	}
 
-		existingContent = header
+	title := proj.ReportTitle
+	if title == "" {
+		title = proj.Title
 	}
 
-	// Append content
-	newContent := existingContent + content
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# %s — Report Index\n\n", title))
+	sb.WriteString(fmt.Sprintf("**Generated:** %s\n\n", s.clock().Report(time.Now())))
+	sb.WriteString("| Report | Title | Audience | Description |\n")
+	sb.WriteString("|--------|-------|----------|-------------|\n")
+	for _, entry := range entries {
+		sb.WriteString(fmt.Sprintf("| [%s](%s) | %s | %s | %s |\n",
+			entry.File, entry.File, indexCell(entry.Title), indexCell(entry.Audience), indexCell(entry.Description)))
+	}
 
-	// Write atomically
-	if err := global.AtomicWrite(absPath, []byte(newContent)); err != nil {
-		return fmt.Errorf("failed to write report: %w", err)
+	reportsDir := s.getReportsDir(project)
+	if err := global.EnsureDir(reportsDir); err != nil {
+		return "", fmt.Errorf("failed to create reports directory: %w", err)
 	}
 
-	s.logger.Infof("Project %s: Wrote report %s", project, filename)
-	return nil
+	mutex := s.getProjectMutex(project)
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if err := global.AtomicWrite(filepath.Join(reportsDir, filename), []byte(sb.String())); err != nil {
+		return "", fmt.Errorf("failed to write report index: %w", err)
+	}
+
+	s.logger.Infof("Project %s: Wrote report index %s", project, filename)
+	return filename, nil
+}
+
+// indexCell formats a value for a markdown table cell
+func indexCell(value string) string {
+	value = strings.TrimSpace(strings.ReplaceAll(value, "\n", " "))
+	if value == "" {
+		return "-"
+	}
+	return strings.ReplaceAll(value, "|", "\\|")
 }
 
 // EndReport ends the report session and clears the prefix.
@@ -368,12 +582,14 @@ func (s *Service) EndReport(project string) error {
 		return fmt.Errorf("no active report session")
 	}
 
+	now := time.Now()
+	endReportSession(proj, now, false)
 	proj.ReportPrefix = ""
 	proj.ReportStartedAt = nil
 	proj.ReportTitle = ""
 	proj.ReportIntro = ""
 	proj.ReportDate = ""
-	proj.UpdatedAt = time.Now()
+	proj.UpdatedAt = now
 
 	if err := s.saveProject(project, proj); err != nil {
 		return fmt.Errorf("failed to save project: %w", err)
@@ -401,6 +617,90 @@ func (s *Service) GetReportPrefix(project string) (string, error) {
 	return proj.ReportPrefix, nil
 }
 
+// ReportSessions returns the report sessions of a project, oldest first. Each
+// lists the report files that carry its prefix.
+func (s *Service) ReportSessions(project string) ([]ReportSessionInfo, error) {
+	if err := validateProjectName(project); err != nil {
+		return nil, err
+	}
+
+	if !s.ProjectExists(project) {
+		return nil, fmt.Errorf("project not found: %s", project)
+	}
+
+	proj, err := s.Get(project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	reports, err := s.ListReports(project)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]ReportSessionInfo, 0, len(proj.ReportSessions))
+	for _, session := range proj.ReportSessions {
+		info := ReportSessionInfo{
+			ReportSession: session,
+			Active:        session.EndedAt == nil && session.Prefix == proj.ReportPrefix,
+			Reports:       []string{},
+		}
+		for _, report := range reports {
+			if strings.HasPrefix(report.Name, session.Prefix) {
+				info.Reports = append(info.Reports, report.Name)
+			}
+		}
+		sessions = append(sessions, info)
+	}
+	return sessions, nil
+}
+
+// ReportSessionInfo describes a report session listed by ReportSessions
+type ReportSessionInfo struct {
+	global.ReportSession
+	Active  bool     `json:"active"`
+	Reports []string `json:"reports"` // Report files in the reports directory with the session's prefix
+}
+
+// reportPrefixInUse reports whether a prefix was used by a report session of the
+// project, or by report files written before sessions were recorded
+func (s *Service) reportPrefixInUse(project string, proj *global.Project, prefix string) bool {
+	if proj.ReportPrefix == prefix {
+		return true
+	}
+	for _, session := range proj.ReportSessions {
+		if session.Prefix == prefix {
+			return true
+		}
+	}
+	for _, finalized := range proj.FinalizedReports {
+		if finalized.Prefix == prefix {
+			return true
+		}
+	}
+	entries, err := os.ReadDir(s.getReportsDir(project))
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// endReportSession records the end of the project's active report session, if any
+func endReportSession(proj *global.Project, now time.Time, finalized bool) {
+	for i := len(proj.ReportSessions) - 1; i >= 0; i-- {
+		session := &proj.ReportSessions[i]
+		if session.Prefix == proj.ReportPrefix && session.EndedAt == nil {
+			session.EndedAt = &now
+			session.Finalized = finalized
+			return
+		}
+	}
+}
+
 // sanitizeTitleForPrefix converts a title to a safe prefix component.
 func sanitizeTitleForPrefix(title string) string {
 	if title == "" {
@@ -439,7 +739,7 @@ func sanitizeTitleForPrefix(title string) string {
 	return result
 }
 
-// loadDisclaimer loads disclaimer content from the configured path.
+// loadDisclaimer loads disclaimer content from the path resolved by Disclaimer.
 // Path format: "playbook-name/path/to/file.md" or "none" for no disclaimer.
 // Returns empty string if "none" or if file not found.
 func (s *Service) loadDisclaimer(disclaimerPath string) string {
@@ -0,0 +1,321 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package projects
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+func TestAppendReportManifestMetadata(t *testing.T) {
+	svc, _ := createTestServiceWithConfig(t)
+
+	if _, err := svc.Create("meta-test", "Meta Test", "", "", "", "none", ""); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	prefix, err := svc.StartReport("meta-test", "ISO Audit", "")
+	if err != nil {
+		t.Fatalf("StartReport failed: %v", err)
+	}
+
+	meta := &global.ReportTemplateConfig{
+		Suffix:      "Internal",
+		Title:       "Internal Findings",
+		Audience:    "Audit team",
+		Description: "Full details for internal review.",
+	}
+	if err := svc.AppendReport("meta-test", "## Findings\n", "Internal", meta); err != nil {
+		t.Fatalf("AppendReport failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(svc.getReportsDir("meta-test"), prefix+"Internal.md"))
+	if err != nil {
+		t.Fatalf("Failed to read report: %v", err)
+	}
+	content := string(data)
+	for _, want := range []string{"# ISO Audit — Internal Findings\n", "**Audience:** Audit team\n", "Full details for internal review.\n"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Report header missing %q:\n%s", want, content)
+		}
+	}
+
+	indexFile, err := svc.WriteReportIndex("meta-test", []global.ReportIndexEntry{
+		{File: prefix + "Report.md", Suffix: "Report"},
+		{File: prefix + "Internal.md", Suffix: "Internal", Title: "Internal Findings", Audience: "Audit team", Description: "Full | details"},
+	})
+	if err != nil {
+		t.Fatalf("WriteReportIndex failed: %v", err)
+	}
+	if indexFile != prefix+global.ReportIndexSuffix+".md" {
+		t.Errorf("indexFile = %q", indexFile)
+	}
+
+	data, err = os.ReadFile(filepath.Join(svc.getReportsDir("meta-test"), indexFile))
+	if err != nil {
+		t.Fatalf("Failed to read index: %v", err)
+	}
+	index := string(data)
+	if !strings.Contains(index, "| Internal Findings | Audit team | Full \\| details |") {
+		t.Errorf("Index missing described entry:\n%s", index)
+	}
+	if !strings.Contains(index, "("+prefix+"Report.md) | - | - | - |") {
+		t.Errorf("Index missing plain entry:\n%s", index)
+	}
+}
+
+func TestDisclaimerPolicy(t *testing.T) {
+	writeDisclaimer := func(t *testing.T, baseDir string) {
+		dir := filepath.Join(baseDir, "playbooks", "legal", "templates")
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "disclaimer.md"), []byte("Prepared with AI assistance.\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Required (the default without a configured template): projects must choose
+	svc, _ := createTestServiceWithConfig(t)
+	if _, err := svc.Create("required", "Required", "", "", "", "", ""); err == nil {
+		t.Error("Create without disclaimer_template succeeded under the required policy")
+	}
+
+	// Optional: no disclaimer unless the project sets one
+	svc, _ = createTestServiceWithConfigExtra(t, `"runner": {"disclaimer_policy": "optional"},`)
+	proj, err := svc.Create("optional", "Optional", "", "", "", "", "")
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if path, err := svc.Disclaimer(proj); err != nil || path != "" {
+		t.Errorf("Disclaimer() = %q, %v; want none", path, err)
+	}
+
+	// A configured default applies to projects without their own, but not to "none"
+	svc, baseDir := createTestServiceWithConfigExtra(t, `"runner": {"default_disclaimer_template": "legal/templates/disclaimer.md"},`)
+	writeDisclaimer(t, baseDir)
+	proj, err = svc.Create("default", "Default", "", "", "", "default", "")
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if path, err := svc.Disclaimer(proj); err != nil || path != "legal/templates/disclaimer.md" {
+		t.Errorf("Disclaimer() = %q, %v; want the configured default", path, err)
+	}
+	if !strings.Contains(svc.reportHeader(proj, nil), "Prepared with AI assistance.") {
+		t.Error("report header missing the default disclaimer")
+	}
+	none := "none"
+	if proj, err = svc.Update("default", nil, nil, nil, nil, &none, nil); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if path, err := svc.Disclaimer(proj); err != nil || path != "" {
+		t.Errorf("Disclaimer() after opting out = %q, %v; want none", path, err)
+	}
+	missing := "legal/templates/missing.md"
+	if _, err := svc.Update("default", nil, nil, nil, nil, &missing, nil); err == nil {
+		t.Error("Update accepted a missing disclaimer template")
+	}
+}
+
+func TestReportPrefixCollision(t *testing.T) {
+	// Sessions with the same title get distinct prefixes
+	svc, _ := createTestServiceWithConfigExtra(t, `"report_prefix": {"format": "{project}-{title}"},`)
+	if _, err := svc.Create("prefix-test", "Prefix Test", "", "", "", "none", ""); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	first, err := svc.StartReport("prefix-test", "ISO Audit", "")
+	if err != nil {
+		t.Fatalf("StartReport failed: %v", err)
+	}
+	if err := svc.AppendReport("prefix-test", "## First\n", "", nil); err != nil {
+		t.Fatalf("AppendReport failed: %v", err)
+	}
+	if err := svc.EndReport("prefix-test"); err != nil {
+		t.Fatalf("EndReport failed: %v", err)
+	}
+	second, err := svc.StartReport("prefix-test", "ISO Audit", "")
+	if err != nil {
+		t.Fatalf("StartReport failed: %v", err)
+	}
+	if second != strings.TrimSuffix(first, "-")+"-2-" {
+		t.Errorf("second prefix = %q, want %q with -2", second, first)
+	}
+
+	sessions, err := svc.ReportSessions("prefix-test")
+	if err != nil {
+		t.Fatalf("ReportSessions failed: %v", err)
+	}
+	if len(sessions) != 2 || sessions[0].Prefix != first || sessions[0].Active || sessions[0].EndedAt == nil || !sessions[1].Active {
+		t.Fatalf("sessions = %+v", sessions)
+	}
+	if len(sessions[0].Reports) != 1 || sessions[0].Reports[0] != first+"Report.md" || len(sessions[1].Reports) != 0 {
+		t.Errorf("session reports = %v, %v", sessions[0].Reports, sessions[1].Reports)
+	}
+
+	// A custom format with a refuse policy fails instead
+	svc, _ = createTestServiceWithConfigExtra(t, `"report_prefix": {"format": "{project}-{title}", "on_collision": "refuse"},`)
+	if _, err := svc.Create("refuse-test", "Refuse Test", "", "", "", "none", ""); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	prefix, err := svc.StartReport("refuse-test", "Audit", "")
+	if err != nil || prefix != "refuse-test-Audit-" {
+		t.Fatalf("StartReport = %q, %v", prefix, err)
+	}
+	if _, err := svc.StartReport("refuse-test", "Audit", ""); err == nil {
+		t.Error("StartReport reused a prefix under the refuse policy")
+	}
+}
+
+func TestWriteReportFile(t *testing.T) {
+	svc, _ := createTestServiceWithConfig(t)
+
+	if _, err := svc.Create("render-test", "Render Test", "", "", "", "none", ""); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if err := svc.WriteReportFile("render-test", "Audit-Report.html", []byte("<h1>Audit</h1>")); err != nil {
+		t.Fatalf("WriteReportFile failed: %v", err)
+	}
+	if err := svc.WriteReportFile("render-test", "Audit-Report.pdf", []byte("%PDF-1.4\n\xe2\xe3")); err != nil {
+		t.Fatalf("WriteReportFile failed: %v", err)
+	}
+	for _, name := range []string{"Audit-Report.docx", "../Audit-Report.html"} {
+		if err := svc.WriteReportFile("render-test", name, []byte("x")); err == nil {
+			t.Errorf("expected error writing %s", name)
+		}
+	}
+
+	items, err := svc.ListReports("render-test")
+	if err != nil || len(items) != 2 {
+		t.Fatalf("ListReports() = %v, %v; want both renderings", items, err)
+	}
+	item, err := svc.ReadReport("render-test", "Audit-Report.html", 0, 0)
+	if err != nil || item.Content != "<h1>Audit</h1>" {
+		t.Errorf("ReadReport(html) = %v, %v", item, err)
+	}
+	if _, err := svc.ReadReport("render-test", "Audit-Report.pdf", 0, 0); err == nil || !strings.Contains(err.Error(), "cannot be read as text") {
+		t.Errorf("ReadReport(pdf) error = %v", err)
+	}
+}
+
+func TestSearchReports(t *testing.T) {
+	svc, _ := createTestServiceWithConfig(t)
+
+	for _, name := range []string{"search-a", "search-b"} {
+		if _, err := svc.Create(name, name, "", "", "", "none", ""); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+	if err := svc.WriteReportFile("search-a", "20250101-0900-Audit-Report.md", []byte("# Audit\n\nIntro text.\n\n## Access Control\n\nMFA is not enforced for administrator accounts.\n\n```\n# not a heading\n```\n\n## Logging\n\nAudit logs are kept for 30 days.\n")); err != nil {
+		t.Fatalf("WriteReportFile failed: %v", err)
+	}
+	if err := svc.WriteReportFile("search-b", "20250301-0900-Review-Report.md", []byte("# Review\n\n## Findings\n\nAdministrator MFA was enabled after the audit.\n")); err != nil {
+		t.Fatalf("WriteReportFile failed: %v", err)
+	}
+
+	result, err := svc.SearchReports("", "mfa administrator", 0, 0)
+	if err != nil {
+		t.Fatalf("SearchReports failed: %v", err)
+	}
+	if result.Total != 2 || result.Count != 2 {
+		t.Fatalf("SearchReports() total = %d, count = %d; want 2", result.Total, result.Count)
+	}
+	first := result.Matches[0]
+	if first.Project != "search-b" || first.Heading != "Findings" || first.Title != "Review" || first.Line != 3 {
+		t.Errorf("first match = %+v; want the newer report's Findings section", first)
+	}
+	if second := result.Matches[1]; second.Heading != "Access Control" || second.Line != 5 || !strings.Contains(second.Snippet, "MFA is not enforced") {
+		t.Errorf("second match = %+v", second)
+	}
+
+	// Headings count for more than text, and code blocks stay in their section
+	result, err = svc.SearchReports("search-a", "logging", 0, 0)
+	if err != nil || result.Total != 1 || result.Matches[0].Score != 3 {
+		t.Errorf("SearchReports(logging) = %+v, %v", result, err)
+	}
+	result, err = svc.SearchReports("search-a", "not a heading", 0, 0)
+	if err != nil || result.Total != 1 || result.Matches[0].Heading != "Access Control" {
+		t.Errorf("SearchReports(code) = %+v, %v", result, err)
+	}
+
+	// A changed report is indexed again
+	if err := svc.WriteReportFile("search-a", "20250101-0900-Audit-Report.md", []byte("# Audit\n\n## Retention\n\nAudit logs are kept for one year.\n")); err != nil {
+		t.Fatalf("WriteReportFile failed: %v", err)
+	}
+	result, err = svc.SearchReports("search-a", "one year", 0, 0)
+	if err != nil || result.Total != 1 || result.Matches[0].Heading != "Retention" {
+		t.Errorf("SearchReports(reindexed) = %+v, %v", result, err)
+	}
+
+	if _, err := svc.SearchReports("search-a", "  ", 0, 0); err == nil {
+		t.Error("expected error for an empty query")
+	}
+	if _, err := svc.SearchReports("missing", "mfa", 0, 0); err == nil {
+		t.Error("expected error for a missing project")
+	}
+}
+
+func TestMergeReportBlocks(t *testing.T) {
+	blocks := []global.ReportBlock{
+		{Key: "taskset:controls", Content: "## Controls\n"},
+		{Key: "task:a", Content: "### A\n\n---\n"},
+		{Key: "task:b", Content: "### B\n\n---\n"},
+	}
+	first, result := mergeReportBlocks("# Report\n\n", blocks)
+	if *result != (global.ReportMergeResult{Added: 3}) {
+		t.Errorf("first merge = %+v", *result)
+	}
+
+	// Unchanged blocks, and text added by hand, are kept as they are
+	edited := first + "Note without newline"
+	again, result := mergeReportBlocks(edited, blocks)
+	if again != edited || *result != (global.ReportMergeResult{Unchanged: 3}) {
+		t.Errorf("second merge = %+v:\n%s", *result, again)
+	}
+
+	// Changed blocks are replaced, new ones follow the block before them
+	changed := []global.ReportBlock{
+		{Key: "overview", Content: "Summary\n"},
+		blocks[0],
+		{Key: "task:a", Content: "### A (revised)\n\n---\n"},
+		{Key: "task:c", Content: "### C\n\n---\n"},
+		blocks[2],
+	}
+	merged, result := mergeReportBlocks(edited, changed)
+	if *result != (global.ReportMergeResult{Added: 2, Replaced: 1, Unchanged: 2}) {
+		t.Errorf("third merge = %+v", *result)
+	}
+	var order []int
+	for _, text := range []string{"### A (revised)", "### C", "### B", "Note without newline", "Summary"} {
+		order = append(order, strings.Index(merged, text))
+	}
+	for i := 1; i < len(order); i++ {
+		if order[i-1] < 0 || order[i] < order[i-1] {
+			t.Fatalf("blocks out of order %v:\n%s", order, merged)
+		}
+	}
+	if strings.Contains(merged, "### A\n") || !strings.Contains(merged, "Note without newline\n\n<!-- maestro:begin overview ") {
+		t.Errorf("unexpected merge:\n%s", merged)
+	}
+
+	// Report search sees the content, not the markers
+	_, sections := splitSections(merged)
+	for _, section := range sections {
+		if strings.Contains(section.text, "maestro:") {
+			t.Errorf("marker in searchable text: %q", section.text)
+		}
+	}
+
+	// An unterminated block is left as text
+	parts := parseReportParts("<!-- maestro:begin task:x 00 -->\nopen\n")
+	if len(parts) != 1 || parts[0].key != "" {
+		t.Errorf("parseReportParts(unterminated) = %+v", parts)
+	}
+}
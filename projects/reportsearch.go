@@ -0,0 +1,256 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package projects
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// Report search splits each Markdown report into its sections, one per heading,
+// and keeps them in an index keyed by file. A report is indexed again when its
+// size or modification time changes, so reports appended to or regenerated
+// since the last search are found without rebuilding the whole index.
+
+// reportSnippetLength is the approximate length of a search snippet
+const reportSnippetLength = 200
+
+var reportHeading = regexp.MustCompile(`^(#{1,6})\s+(.*?)(?:\s+#+)?\s*$`)
+
+// indexedReport is the index of one report file
+type indexedReport struct {
+	size     int64
+	modTime  time.Time
+	title    string
+	sections []reportSection
+}
+
+// reportSection is the text under one heading of a report
+type reportSection struct {
+	heading string
+	line    int
+	text    string
+	lower   string // Heading and text in lower case, for matching
+}
+
+// SearchReports searches the sections of a project's Markdown reports, or of
+// every project's when project is empty. A section matches when it contains
+// every word of the query, in its heading or text, regardless of case. Matches
+// are ranked by how often the words occur, then newest report first.
+func (s *Service) SearchReports(project, query string, limit, offset int) (*global.ReportSearchResult, error) {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("search query cannot be empty")
+	}
+
+	if limit <= 0 {
+		limit = global.DefaultLimit
+	}
+
+	var targets []string
+	if project != "" {
+		if err := validateProjectName(project); err != nil {
+			return nil, err
+		}
+		if !s.ProjectExists(project) {
+			return nil, fmt.Errorf("project not found: %s", project)
+		}
+		targets = append(targets, project)
+	} else {
+		result, err := s.List("", 0, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, proj := range result.Projects {
+			targets = append(targets, proj.Name)
+		}
+	}
+
+	matches := []global.ReportSearchMatch{}
+	for _, target := range targets {
+		reportsDir := s.getReportsDir(target)
+		entries, err := os.ReadDir(reportsDir)
+		if err != nil {
+			continue // No reports yet
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+				continue
+			}
+			report, err := s.indexReport(filepath.Join(reportsDir, entry.Name()))
+			if err != nil {
+				s.logger.Warnf("Failed to index report %s/%s: %v", target, entry.Name(), err)
+				continue
+			}
+			for _, section := range report.sections {
+				score := sectionScore(section, terms)
+				if score == 0 {
+					continue
+				}
+				matches = append(matches, global.ReportSearchMatch{
+					Project: target,
+					Report:  entry.Name(),
+					Title:   report.title,
+					Heading: section.heading,
+					Line:    section.line,
+					Snippet: snippet(section.text, terms),
+					Score:   score,
+				})
+			}
+		}
+	}
+
+	// Report names start with their session's timestamp, so later names are newer
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Report != b.Report {
+			return a.Report > b.Report
+		}
+		if a.Project != b.Project {
+			return a.Project < b.Project
+		}
+		return a.Line < b.Line
+	})
+
+	result := &global.ReportSearchResult{Query: query, Project: project, Total: len(matches), Matches: []global.ReportSearchMatch{}}
+	if offset < len(matches) {
+		end := offset + limit
+		if end > len(matches) {
+			end = len(matches)
+		}
+		result.Matches = matches[offset:end]
+	}
+	result.Count = len(result.Matches)
+
+	s.logger.Debugf("Report search '%s' found %d matching sections, returning %d", query, result.Total, result.Count)
+	return result, nil
+}
+
+// indexReport returns the index of a report file, indexing it if it is new or
+// has changed since it was last indexed
+func (s *Service) indexReport(path string) (*indexedReport, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if cached, ok := s.reportIndex.Load(path); ok {
+		report := cached.(*indexedReport)
+		if report.size == info.Size() && report.modTime.Equal(info.ModTime()) {
+			return report, nil
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	report := &indexedReport{size: info.Size(), modTime: info.ModTime()}
+	report.title, report.sections = splitSections(string(data))
+	s.reportIndex.Store(path, report)
+	return report, nil
+}
+
+// splitSections splits a Markdown report into the sections under its headings
+// and returns them with the report's title. Headings inside code blocks are
+// section text.
+func splitSections(content string) (string, []reportSection) {
+	var title string
+	var sections []reportSection
+	current := reportSection{line: 1}
+	var text []string
+	inCode := false
+
+	flush := func() {
+		current.text = strings.TrimSpace(strings.Join(text, "\n"))
+		if current.heading != "" || current.text != "" {
+			current.lower = strings.ToLower(current.heading + "\n" + current.text)
+			sections = append(sections, current)
+		}
+		text = nil
+	}
+
+	for i, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inCode = !inCode
+		}
+		if isReportBlockMarker(line) && !inCode {
+			continue
+		}
+		if m := reportHeading.FindStringSubmatch(line); m != nil && !inCode {
+			flush()
+			current = reportSection{heading: m[2], line: i + 1}
+			if title == "" && len(m[1]) == 1 {
+				title = m[2]
+			}
+			continue
+		}
+		text = append(text, line)
+	}
+	flush()
+	return title, sections
+}
+
+// sectionScore returns how often the terms occur in a section, counting heading
+// occurrences three times, or 0 unless every term occurs
+func sectionScore(section reportSection, terms []string) int {
+	heading := strings.ToLower(section.heading)
+	score := 0
+	for _, term := range terms {
+		n := strings.Count(section.lower, term)
+		if n == 0 {
+			return 0
+		}
+		score += n + 2*strings.Count(heading, term)
+	}
+	return score
+}
+
+// snippet returns the text around the first occurrence of a term, on one line
+// and cut at word boundaries, or the start of the text if no term occurs in it
+func snippet(text string, terms []string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	lower := strings.ToLower(text)
+	first := -1
+	for _, term := range terms {
+		if i := strings.Index(lower, term); i >= 0 && (first < 0 || i < first) {
+			first = i
+		}
+	}
+
+	start := 0
+	if first > reportSnippetLength/3 {
+		start = first - reportSnippetLength/3
+		if space := strings.IndexByte(text[start:first], ' '); space >= 0 {
+			start += space + 1
+		}
+	}
+	end := start + reportSnippetLength
+	if end >= len(text) {
+		end = len(text)
+	} else if space := strings.LastIndexByte(text[start:end], ' '); space > 0 {
+		end = start + space
+	}
+
+	result := text[start:end]
+	if start > 0 {
+		result = "…" + result
+	}
+	if end < len(text) {
+		result += "…"
+	}
+	return result
+}
@@ -0,0 +1,308 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package projects
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// retentionScopes are the parts of a project a retention policy can purge
+var retentionScopes = []string{global.RetentionScopeResults, global.RetentionScopeLogs, global.RetentionScopeFiles}
+
+// normalizeRetention validates a retention policy and applies its defaults
+func normalizeRetention(retention *global.ProjectRetention) error {
+	if retention.Days <= 0 {
+		return fmt.Errorf("retention days must be greater than 0")
+	}
+	switch retention.Action {
+	case "":
+		retention.Action = global.RetentionActionDelete
+	case global.RetentionActionDelete, global.RetentionActionAnonymize:
+	default:
+		return fmt.Errorf("invalid retention action '%s': must be '%s' or '%s'", retention.Action, global.RetentionActionDelete, global.RetentionActionAnonymize)
+	}
+	if len(retention.Include) == 0 {
+		retention.Include = slices.Clone(retentionScopes)
+	}
+	for _, scope := range retention.Include {
+		if !slices.Contains(retentionScopes, scope) {
+			return fmt.Errorf("invalid retention scope '%s': must be one of %s", scope, strings.Join(retentionScopes, ", "))
+		}
+	}
+	return nil
+}
+
+// SetRetention sets the project's retention policy, or removes it when
+// retention is nil
+func (s *Service) SetRetention(project string, retention *global.ProjectRetention) (*global.Project, error) {
+	if err := validateProjectName(project); err != nil {
+		return nil, err
+	}
+	if retention != nil {
+		if err := normalizeRetention(retention); err != nil {
+			return nil, err
+		}
+	}
+
+	mutex := s.getProjectMutex(project)
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	proj, err := s.loadProject(project)
+	if err != nil {
+		return nil, err
+	}
+	proj.Retention = retention
+	proj.UpdatedAt = time.Now()
+	if err := s.saveProject(project, proj); err != nil {
+		return nil, err
+	}
+
+	s.logger.Debugf("Updated retention policy of project: %s", project)
+	return proj, nil
+}
+
+// PurgeProject applies the project's retention policy: the results, logs and
+// files it includes are deleted, or with action "anonymize" rewritten with the
+// personal identifiers PII detection recognizes replaced by their type. Files
+// that cannot be anonymized (binary files) are deleted. The purge is only
+// applied once the policy's days have passed since the project became done;
+// with dryRun the summary lists what the purge would change at any time.
+func (s *Service) PurgeProject(project string, dryRun bool) (*global.ProjectPurgeSummary, error) {
+	if err := validateProjectName(project); err != nil {
+		return nil, err
+	}
+
+	mutex := s.getProjectMutex(project)
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	proj, err := s.loadProject(project)
+	if err != nil {
+		return nil, err
+	}
+	if proj.Retention == nil {
+		return nil, fmt.Errorf("project %s has no retention policy: set retention_days with project_update", project)
+	}
+	policy := *proj.Retention
+	if err := normalizeRetention(&policy); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	summary := &global.ProjectPurgeSummary{
+		Project: project,
+		Action:  policy.Action,
+		Include: policy.Include,
+		DryRun:  dryRun,
+		DoneAt:  proj.DoneAt,
+	}
+	if proj.Status == global.ProjectStatusDone && proj.DoneAt != nil {
+		dueAt := proj.DoneAt.AddDate(0, 0, policy.Days)
+		summary.DueAt = &dueAt
+		summary.Due = !now.Before(dueAt)
+	}
+	if !dryRun && !summary.Due {
+		if summary.DueAt == nil {
+			return summary, fmt.Errorf("retention purge of %s is not due: the project is not done", project)
+		}
+		return summary, fmt.Errorf("retention purge of %s is not due until %s", project, s.clock().Log(*summary.DueAt))
+	}
+
+	projectDir := s.getProjectDir(project)
+	var purgeErr error
+	for _, scope := range policy.Include {
+		for _, path := range s.retentionFiles(project, scope) {
+			if purgeErr = purgeFile(projectDir, path, scope, policy.Action, dryRun, summary); purgeErr != nil {
+				break
+			}
+		}
+		if purgeErr != nil {
+			break
+		}
+	}
+	if dryRun {
+		return summary, purgeErr
+	}
+
+	proj.PurgedAt = &now
+	if err := s.saveProject(project, proj); err != nil && purgeErr == nil {
+		purgeErr = err
+	}
+	msg := fmt.Sprintf("Retention purge (%s of %s): deleted %d and anonymized %d file(s), replaced %d identifier(s)",
+		policy.Action, strings.Join(policy.Include, ", "), summary.Deleted, summary.Anonymized, summary.Identifiers)
+	if err := s.appendLogEntry(project, msg); err != nil {
+		s.logger.Warnf("Failed to log retention purge for %s: %v", project, err)
+	}
+	s.logger.Infof("Project %s: %s", project, msg)
+	if purgeErr != nil {
+		return summary, fmt.Errorf("retention purge failed: %w", purgeErr)
+	}
+	return summary, nil
+}
+
+// ApplyRetention purges the project if its retention policy is due and it has
+// not been purged since it became done. Returns nil when nothing was due.
+func (s *Service) ApplyRetention(project string) (*global.ProjectPurgeSummary, error) {
+	proj, err := s.Get(project)
+	if err != nil {
+		return nil, err
+	}
+	if proj.Retention == nil || proj.Status != global.ProjectStatusDone || proj.DoneAt == nil || proj.PurgedAt != nil ||
+		time.Now().Before(proj.DoneAt.AddDate(0, 0, proj.Retention.Days)) {
+		return nil, nil
+	}
+	return s.PurgeProject(project, false)
+}
+
+// retentionFiles lists the files of a project in a retention scope. Task logs
+// are kept with the results but belong to the logs scope.
+func (s *Service) retentionFiles(project, scope string) []string {
+	isTaskLog := func(name string) bool {
+		return strings.HasPrefix(name, "task-") && strings.HasSuffix(name, ".log")
+	}
+
+	var root string
+	var include func(path string) bool
+	switch scope {
+	case global.RetentionScopeResults:
+		root = s.getResultsDir(project)
+		include = func(path string) bool { return !isTaskLog(filepath.Base(path)) }
+	case global.RetentionScopeLogs:
+		root = s.getResultsDir(project)
+		include = func(path string) bool { return filepath.Dir(path) == root && isTaskLog(filepath.Base(path)) }
+	case global.RetentionScopeFiles:
+		root = s.getFilesDir(project)
+		include = func(string) bool { return true }
+	}
+
+	var paths []string
+	if scope == global.RetentionScopeLogs && global.FileExists(s.getProjectLogPath(project)) {
+		paths = append(paths, s.getProjectLogPath(project))
+	}
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // A missing directory has nothing to purge
+		}
+		if d.Type().IsRegular() && include(path) {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	return paths
+}
+
+// purgeFile deletes or anonymizes one file, adding it to the summary.
+// Anonymized files are only listed when identifiers were replaced.
+func purgeFile(projectDir, path, scope, action string, dryRun bool, summary *global.ProjectPurgeSummary) error {
+	rel, err := filepath.Rel(projectDir, path)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", rel, err)
+	}
+	file := global.ProjectPurgeFile{Path: filepath.ToSlash(rel), Scope: scope, Action: global.RetentionActionDelete, Size: info.Size()}
+
+	if action == global.RetentionActionAnonymize {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", rel, err)
+		}
+		anonymized, replaced, ok := anonymizeContent(path, data)
+		if ok {
+			if replaced == 0 {
+				return nil
+			}
+			file.Action = global.RetentionActionAnonymize
+			file.Identifiers = replaced
+			if !dryRun {
+				if err := global.AtomicWrite(path, anonymized); err != nil {
+					return fmt.Errorf("failed to anonymize %s: %w", rel, err)
+				}
+			}
+			summary.Anonymized++
+			summary.Identifiers += replaced
+			summary.Files = append(summary.Files, file)
+			return nil
+		}
+		file.Reason = "binary file cannot be anonymized"
+	}
+
+	if !dryRun {
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", rel, err)
+		}
+	}
+	summary.Deleted++
+	summary.BytesFreed += file.Size
+	summary.Files = append(summary.Files, file)
+	return nil
+}
+
+// anonymizeContent replaces personal identifiers in a text file. ok is false
+// for content that is not text.
+func anonymizeContent(path string, data []byte) ([]byte, int, bool) {
+	return replaceContent(path, data, global.AnonymizePII)
+}
+
+// replaceContent applies replace, which returns its result and the number of
+// values it replaced, to a text file. JSON files are rewritten value by value so
+// that the result is still valid JSON. ok is false for content that is not text.
+func replaceContent(path string, data []byte, replace func(string) (string, int)) ([]byte, int, bool) {
+	if !utf8.Valid(data) || bytes.IndexByte(data, 0) >= 0 {
+		return nil, 0, false
+	}
+	if strings.HasSuffix(path, ".json") {
+		var value any
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber() // Keep numbers exactly as written
+		if err := decoder.Decode(&value); err == nil {
+			replaced := 0
+			value = replaceJSON(value, replace, &replaced)
+			if replaced == 0 {
+				return data, 0, true
+			}
+			out, err := json.MarshalIndent(value, "", "  ")
+			if err == nil {
+				return out, replaced, true
+			}
+		}
+	}
+	text, replaced := replace(string(data))
+	return []byte(text), replaced, true
+}
+
+// replaceJSON applies replace to every string of a decoded JSON value
+func replaceJSON(value any, replace func(string) (string, int), replaced *int) any {
+	switch v := value.(type) {
+	case string:
+		text, n := replace(v)
+		*replaced += n
+		return text
+	case []any:
+		for i := range v {
+			v[i] = replaceJSON(v[i], replace, replaced)
+		}
+	case map[string]any:
+		for key, item := range v {
+			v[key] = replaceJSON(item, replace, replaced)
+		}
+	}
+	return value
+}
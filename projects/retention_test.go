@@ -0,0 +1,102 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package projects
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+func TestProjectRetention(t *testing.T) {
+	svc, _ := createTestServiceWithConfig(t)
+
+	if _, err := svc.Create("retention-test", "Retention Test", "", "", "", "none", ""); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := svc.PutFile("retention-test", "interviews/notes.md", "Spoke with jane.doe@example.com (555-123-4567).", ""); err != nil {
+		t.Fatalf("PutFile failed: %v", err)
+	}
+	resultsDir := svc.GetResultsDir("retention-test")
+	if err := os.MkdirAll(resultsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	resultPath := filepath.Join(resultsDir, "abc.json")
+	if err := os.WriteFile(resultPath, []byte(`{"task_id": 1, "worker": {"response": "Payroll lists SSN 123-45-6789"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(svc.GetFilesDir("retention-test"), "scan.bin"), []byte{0x89, 'P', 'N', 'G', 0}, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := svc.PurgeProject("retention-test", true); err == nil {
+		t.Error("expected error purging a project without a retention policy")
+	}
+	if _, err := svc.SetRetention("retention-test", &global.ProjectRetention{Days: 30, Action: "shred"}); err == nil {
+		t.Error("expected error for an invalid retention action")
+	}
+	if _, err := svc.SetRetention("retention-test", &global.ProjectRetention{Days: 30, Action: global.RetentionActionAnonymize}); err != nil {
+		t.Fatalf("SetRetention failed: %v", err)
+	}
+
+	// Not done yet: a dry run previews, a purge is refused and maintenance skips it
+	preview, err := svc.PurgeProject("retention-test", true)
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if preview.Due || preview.Anonymized != 2 || preview.Deleted != 1 || preview.Identifiers != 3 {
+		t.Errorf("preview = %+v", preview)
+	}
+	if _, err := svc.PurgeProject("retention-test", false); err == nil || !strings.Contains(err.Error(), "not done") {
+		t.Errorf("expected purge of an open project to be refused, got %v", err)
+	}
+
+	done := global.ProjectStatusDone
+	proj, err := svc.Update("retention-test", nil, nil, nil, &done, nil, nil)
+	if err != nil || proj.DoneAt == nil {
+		t.Fatalf("Update to done = %+v, %v", proj, err)
+	}
+	if summary, err := svc.ApplyRetention("retention-test"); summary != nil || err != nil {
+		t.Errorf("ApplyRetention before due = %+v, %v", summary, err)
+	}
+
+	// Backdate completion past the retention period
+	mutex := svc.getProjectMutex("retention-test")
+	mutex.Lock()
+	proj, _ = svc.loadProject("retention-test")
+	doneAt := time.Now().AddDate(0, 0, -31)
+	proj.DoneAt = &doneAt
+	_ = svc.saveProject("retention-test", proj)
+	mutex.Unlock()
+
+	summary, err := svc.ApplyRetention("retention-test")
+	if err != nil || summary == nil {
+		t.Fatalf("ApplyRetention = %+v, %v", summary, err)
+	}
+	if !summary.Due || summary.Anonymized != 2 || summary.Deleted != 1 {
+		t.Errorf("summary = %+v", summary)
+	}
+	data, _ := os.ReadFile(resultPath)
+	if strings.Contains(string(data), "6789") || !strings.Contains(string(data), "[ssn]") {
+		t.Errorf("result not anonymized: %s", data)
+	}
+	item, err := svc.GetFile("retention-test", "interviews/notes.md", 0, 0)
+	if err != nil || item.Content != "Spoke with [email] ([phone])." {
+		t.Errorf("file after purge = %+v, %v", item, err)
+	}
+	if global.FileExists(filepath.Join(svc.GetFilesDir("retention-test"), "scan.bin")) {
+		t.Error("expected the binary file to be deleted")
+	}
+
+	// Purged once: later maintenance passes leave the project alone
+	if summary, err := svc.ApplyRetention("retention-test"); summary != nil || err != nil {
+		t.Errorf("ApplyRetention after purge = %+v, %v", summary, err)
+	}
+}
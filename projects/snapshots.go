@@ -0,0 +1,211 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package projects
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// snapshotIDRegex validates snapshot IDs (creation time, optional collision suffix)
+var snapshotIDRegex = regexp.MustCompile(`^[0-9]{8}-[0-9]{6}(-[0-9]+)?$`)
+
+// getSnapshotsDir returns the directory holding a project's snapshots
+func (s *Service) getSnapshotsDir(project string) string {
+	return filepath.Join(s.getProjectDir(project), global.SnapshotsDir)
+}
+
+// snapshotDir returns the directory of an existing snapshot
+func (s *Service) snapshotDir(project, snapshot string) (string, error) {
+	if err := validateProjectName(project); err != nil {
+		return "", err
+	}
+	if !snapshotIDRegex.MatchString(snapshot) {
+		return "", fmt.Errorf("invalid snapshot ID: %s", snapshot)
+	}
+	dir := filepath.Join(s.getSnapshotsDir(project), snapshot)
+	if _, err := os.Stat(filepath.Join(dir, global.SnapshotManifestFile)); err != nil {
+		return "", fmt.Errorf("snapshot not found: %s", snapshot)
+	}
+	return dir, nil
+}
+
+// CreateSnapshot captures the project's files, results, reports and task sets
+// as a read-only view that read tools can address by snapshot ID while work
+// continues. Files are hard-linked where the filesystem allows and copied
+// otherwise; Maestro replaces project files rather than rewriting them in place,
+// so linked files keep the content they had when the snapshot was taken.
+func (s *Service) CreateSnapshot(project, label string) (*global.ProjectSnapshot, error) {
+	if err := validateProjectName(project); err != nil {
+		return nil, err
+	}
+	if !s.ProjectExists(project) {
+		return nil, fmt.Errorf("project not found: %s", project)
+	}
+
+	mutex := s.getProjectMutex(project)
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	now := time.Now()
+	id := now.Format(global.SnapshotIDFormat)
+	for n := 2; global.DirExists(filepath.Join(s.getSnapshotsDir(project), id)); n++ {
+		id = fmt.Sprintf("%s-%d", now.Format(global.SnapshotIDFormat), n)
+	}
+	snapshot := &global.ProjectSnapshot{ID: id, Project: project, Label: label, CreatedAt: now}
+
+	// Build the snapshot in a temporary directory and rename it into place
+	dir := filepath.Join(s.getSnapshotsDir(project), id)
+	tmpDir := dir + global.PartialFileSuffix
+	_ = os.RemoveAll(tmpDir)
+	cleanup := func(err error) (*global.ProjectSnapshot, error) {
+		_ = os.RemoveAll(tmpDir)
+		return nil, err
+	}
+
+	projectDir := s.getProjectDir(project)
+	for _, area := range []struct {
+		name    string
+		count   *int
+		skipDir string // Subdirectory left out of the snapshot
+	}{
+		{global.FilesDir, &snapshot.Files, ""},
+		{"results", &snapshot.Results, global.OrphanedResultsDir},
+		{global.ReportsDir, &snapshot.Reports, global.ReportArchiveDir}, // Finalized archives are already immutable
+		{global.TasksDir, &snapshot.TaskSets, ""},
+	} {
+		src := filepath.Join(projectDir, area.name)
+		err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if d.IsDir() && path != src && d.Name() == area.skipDir {
+				return filepath.SkipDir
+			}
+			name := d.Name()
+			if !d.Type().IsRegular() || strings.HasSuffix(name, global.PartialFileSuffix) || strings.HasSuffix(name, ".lock") {
+				return nil
+			}
+			rel, err := filepath.Rel(src, path)
+			if err != nil {
+				return err
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			dest := filepath.Join(tmpDir, area.name, rel)
+			if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+				return err
+			}
+			if err := os.Link(path, dest); err == nil {
+				snapshot.Linked++
+			} else if err := copyFile(path, dest); err == nil {
+				snapshot.Copied++
+			} else {
+				return fmt.Errorf("failed to capture %s/%s: %w", area.name, filepath.ToSlash(rel), err)
+			}
+			snapshot.SizeBytes += info.Size()
+			if !strings.HasSuffix(name, global.MetaSuffix) { // Metadata is captured with its file
+				*area.count++
+			}
+			return nil
+		})
+		if err != nil {
+			return cleanup(err)
+		}
+	}
+
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return cleanup(fmt.Errorf("failed to marshal snapshot manifest: %w", err))
+	}
+	if err := global.AtomicWrite(filepath.Join(tmpDir, global.SnapshotManifestFile), data); err != nil {
+		return cleanup(fmt.Errorf("failed to write snapshot manifest: %w", err))
+	}
+	if err := os.Rename(tmpDir, dir); err != nil {
+		return cleanup(fmt.Errorf("failed to create snapshot: %w", err))
+	}
+
+	if err := s.appendLogEntry(project, fmt.Sprintf("Snapshot %s created (%d files, %d results, %d reports)", id, snapshot.Files, snapshot.Results, snapshot.Reports)); err != nil {
+		s.logger.Warnf("Failed to log snapshot creation: %v", err)
+	}
+	s.logger.Infof("Project %s: Snapshot %s created (%d linked, %d copied)", project, id, snapshot.Linked, snapshot.Copied)
+	return snapshot, nil
+}
+
+// ListSnapshots returns a project's snapshots, oldest first
+func (s *Service) ListSnapshots(project string) ([]global.ProjectSnapshot, error) {
+	if err := validateProjectName(project); err != nil {
+		return nil, err
+	}
+	if !s.ProjectExists(project) {
+		return nil, fmt.Errorf("project not found: %s", project)
+	}
+
+	snapshots := []global.ProjectSnapshot{}
+	entries, err := os.ReadDir(s.getSnapshotsDir(project))
+	if os.IsNotExist(err) {
+		return snapshots, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshots directory: %w", err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || !snapshotIDRegex.MatchString(entry.Name()) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.getSnapshotsDir(project), entry.Name(), global.SnapshotManifestFile))
+		if err != nil {
+			continue
+		}
+		var snapshot global.ProjectSnapshot
+		if err := json.Unmarshal(data, &snapshot); err != nil {
+			s.logger.Warnf("Project %s: Invalid snapshot manifest %s: %v", project, entry.Name(), err)
+			continue
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool { return snapshots[i].CreatedAt.Before(snapshots[j].CreatedAt) })
+	return snapshots, nil
+}
+
+// DeleteSnapshot removes a snapshot. The project's own files are unaffected.
+func (s *Service) DeleteSnapshot(project, snapshot string) error {
+	dir, err := s.snapshotDir(project, snapshot)
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to delete snapshot: %w", err)
+	}
+	if err := s.appendLogEntry(project, fmt.Sprintf("Snapshot %s deleted", snapshot)); err != nil {
+		s.logger.Warnf("Failed to log snapshot deletion: %v", err)
+	}
+	s.logger.Infof("Project %s: Snapshot %s deleted", project, snapshot)
+	return nil
+}
+
+// SnapshotResultsDir returns the results directory of a snapshot
+func (s *Service) SnapshotResultsDir(project, snapshot string) (string, error) {
+	dir, err := s.snapshotDir(project, snapshot)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "results"), nil
+}
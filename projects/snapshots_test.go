@@ -0,0 +1,93 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package projects
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProjectSnapshot(t *testing.T) {
+	svc, _ := createTestServiceWithConfig(t)
+
+	if _, err := svc.Create("snapshot-test", "Snapshot Test", "", "", "", "none", ""); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := svc.PutFile("snapshot-test", "evidence/policy.md", "Version 1", ""); err != nil {
+		t.Fatalf("PutFile failed: %v", err)
+	}
+	if _, err := svc.StartReport("snapshot-test", "ISO Audit", ""); err != nil {
+		t.Fatalf("StartReport failed: %v", err)
+	}
+	if err := svc.AppendReport("snapshot-test", "## Findings\n\nNone.\n", "", nil); err != nil {
+		t.Fatalf("AppendReport failed: %v", err)
+	}
+	resultPath := filepath.Join(svc.GetResultsDir("snapshot-test"), "abc.json")
+	if err := os.WriteFile(resultPath, []byte(`{"task_uuid":"abc"}`), 0644); err != nil {
+		t.Fatalf("Failed to write result: %v", err)
+	}
+
+	snapshot, err := svc.CreateSnapshot("snapshot-test", "Delivered")
+	if err != nil {
+		t.Fatalf("CreateSnapshot failed: %v", err)
+	}
+	if snapshot.Files != 1 || snapshot.Results != 1 || snapshot.Reports != 1 || snapshot.Linked+snapshot.Copied == 0 {
+		t.Errorf("snapshot = %+v", snapshot)
+	}
+
+	// Work continues: the snapshot keeps the delivered state
+	if _, err := svc.PutFile("snapshot-test", "evidence/policy.md", "Version 2", ""); err != nil {
+		t.Fatalf("PutFile failed: %v", err)
+	}
+	if _, err := svc.PutFile("snapshot-test", "evidence/new.md", "Added later", ""); err != nil {
+		t.Fatalf("PutFile failed: %v", err)
+	}
+	item, err := svc.GetSnapshotFile("snapshot-test", snapshot.ID, "evidence/policy.md", 0, 0)
+	if err != nil {
+		t.Fatalf("GetSnapshotFile failed: %v", err)
+	}
+	if item.Content != "Version 1" {
+		t.Errorf("snapshot content = %q, want Version 1", item.Content)
+	}
+	files, err := svc.ListSnapshotFiles("snapshot-test", snapshot.ID, "")
+	if err != nil || len(files) != 1 {
+		t.Errorf("ListSnapshotFiles = %+v, %v", files, err)
+	}
+	reports, err := svc.ListSnapshotReports("snapshot-test", snapshot.ID)
+	if err != nil || len(reports) != 1 {
+		t.Fatalf("ListSnapshotReports = %+v, %v", reports, err)
+	}
+	if _, err := svc.ReadSnapshotReport("snapshot-test", snapshot.ID, reports[0].Name, 0, 0); err != nil {
+		t.Errorf("ReadSnapshotReport failed: %v", err)
+	}
+	if _, err := svc.GetSnapshotFile("snapshot-test", snapshot.ID, "../project.json", 0, 0); err == nil {
+		t.Error("Expected error reading outside the snapshot")
+	}
+	if _, err := svc.ListSnapshotFiles("snapshot-test", "../files", ""); err == nil {
+		t.Error("Expected error for an invalid snapshot ID")
+	}
+
+	// A second snapshot in the same second gets its own ID
+	second, err := svc.CreateSnapshot("snapshot-test", "")
+	if err != nil {
+		t.Fatalf("CreateSnapshot failed: %v", err)
+	}
+	snapshots, err := svc.ListSnapshots("snapshot-test")
+	if err != nil || len(snapshots) != 2 || snapshots[0].ID == snapshots[1].ID || snapshots[0].Label != "Delivered" {
+		t.Errorf("ListSnapshots = %+v, %v", snapshots, err)
+	}
+
+	if err := svc.DeleteSnapshot("snapshot-test", second.ID); err != nil {
+		t.Fatalf("DeleteSnapshot failed: %v", err)
+	}
+	if item, err := svc.GetFile("snapshot-test", "evidence/policy.md", 0, 0); err != nil || item.Content != "Version 2" {
+		t.Errorf("live file after deleting a snapshot = %+v, %v", item, err)
+	}
+	if snapshots, _ := svc.ListSnapshots("snapshot-test"); len(snapshots) != 1 {
+		t.Errorf("snapshots after delete = %d, want 1", len(snapshots))
+	}
+}
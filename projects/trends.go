@@ -0,0 +1,87 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package projects
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// getTrendsPath returns the path to the project's trends.jsonl
+func (s *Service) getTrendsPath(project string) string {
+	return filepath.Join(s.getProjectDir(project), global.TrendsFile)
+}
+
+// AppendTrend appends one run's metrics to trends.jsonl
+func (s *Service) AppendTrend(project string, point *global.TrendPoint) error {
+	if err := validateProjectName(project); err != nil {
+		return err
+	}
+
+	if !s.ProjectExists(project) {
+		return fmt.Errorf("project not found: %s", project)
+	}
+
+	data, err := json.Marshal(point)
+	if err != nil {
+		return fmt.Errorf("failed to marshal trend point: %w", err)
+	}
+
+	f, err := os.OpenFile(s.getTrendsPath(project), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open trends file: %w", err)
+	}
+	defer func(f *os.File) {
+		_ = f.Close()
+	}(f)
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write trend point: %w", err)
+	}
+
+	s.logger.Debugf("Project %s: Appended run metrics to %s", project, global.TrendsFile)
+	return nil
+}
+
+// GetTrends reads the recorded run metrics, oldest first.
+// Returns an empty list if no run has been recorded yet. Unparseable lines are skipped.
+func (s *Service) GetTrends(project string) ([]global.TrendPoint, error) {
+	if err := validateProjectName(project); err != nil {
+		return nil, err
+	}
+
+	points := []global.TrendPoint{}
+	f, err := os.Open(s.getTrendsPath(project))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return points, nil
+		}
+		return nil, fmt.Errorf("failed to read trends: %w", err)
+	}
+	defer func(f *os.File) {
+		_ = f.Close()
+	}(f)
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var point global.TrendPoint
+		if err := json.Unmarshal(scanner.Bytes(), &point); err != nil {
+			continue
+		}
+		points = append(points, point)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read trends: %w", err)
+	}
+
+	return points, nil
+}
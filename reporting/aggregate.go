@@ -0,0 +1,117 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package reporting
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// RenderAggregate renders a report's aggregate template once over all of its
+// task results, for an executive summary ahead of the per-task detail. The
+// template sees:
+//
+//	project, generated_at, variant
+//	summary    counts of the report's tasks (total_tasks, by_verdict, ...)
+//	tasksets   path, title, description and task_count of each task set
+//	tasks      every task, as per-task templates see it, plus _path and
+//	           _taskset_title; results that are not JSON objects are in _result
+//	failed     tasks whose work failed or whose QA verdict is fail
+//	escalated  tasks whose QA verdict is escalate
+//
+// Returns "" when there is no template or it cannot be rendered.
+func (r *Reporter) RenderAggregate(report *ProjectReport, templatePath, variant string) string {
+	if templatePath == "" {
+		return ""
+	}
+
+	summary := ReportSummary{ByVerdict: make(map[string]int), ByType: make(map[string]int)}
+	tasksets := []interface{}{}
+	tasks := []interface{}{}
+	failed := []interface{}{}
+	escalated := []interface{}{}
+	for _, ts := range report.TaskSets {
+		tasksets = append(tasksets, map[string]interface{}{
+			"path":        ts.Path,
+			"title":       ts.Title,
+			"description": ts.Description,
+			"task_count":  len(ts.Tasks),
+		})
+		for _, task := range ts.Tasks {
+			summary.add(task)
+			data := r.aggregateTask(ts, task)
+			tasks = append(tasks, data)
+			switch {
+			case task.WorkStatus == global.ExecutionStatusFailed || task.QAVerdict == global.QAVerdictFail:
+				failed = append(failed, data)
+			case task.QAVerdict == global.QAVerdictEscalate:
+				escalated = append(escalated, data)
+			}
+		}
+	}
+
+	// Templates address the summary by its JSON field names, like results
+	var summaryData map[string]interface{}
+	encoded, _ := json.Marshal(summary)
+	_ = json.Unmarshal(encoded, &summaryData)
+
+	data := map[string]interface{}{
+		"project":      report.Project,
+		"generated_at": report.GeneratedAt,
+		"variant":      variant,
+		"summary":      summaryData,
+		"tasksets":     tasksets,
+		"tasks":        tasks,
+		"failed":       failed,
+		"escalated":    escalated,
+	}
+
+	// Same path convention as task templates: playbook first, then project
+	sources := []string{"project"}
+	if r.playbookLoader != nil && strings.Contains(templatePath, "/") {
+		sources = []string{"playbook", "project"}
+	}
+	var lastErr error
+	for _, source := range sources {
+		tmpl, err := r.loadTemplate(templatePath, source)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			if r.logger != nil {
+				r.logger.Warnf("Failed to execute aggregate template %s: %v", templatePath, err)
+			}
+			return ""
+		}
+		return buf.String()
+	}
+
+	if r.logger != nil {
+		r.logger.Warnf("Failed to load aggregate template %s: %v", templatePath, lastErr)
+	}
+	return ""
+}
+
+// aggregateTask returns the template data of one task for an aggregate template
+func (r *Reporter) aggregateTask(ts TaskSetReport, task TaskReport) map[string]interface{} {
+	data, err := r.workTemplateData(task)
+	if err != nil || data == nil {
+		data = make(map[string]interface{})
+		addTaskMetadata(data, task)
+		r.addConfidencePhrase(data)
+		if task.WorkResult != "" {
+			data["_result"] = task.WorkResult
+		}
+	}
+	data["_path"] = ts.Path
+	data["_taskset_title"] = ts.Title
+	return data
+}
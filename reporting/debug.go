@@ -0,0 +1,176 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package reporting
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// RenderDebug exposes the intermediate data used to render a task with a template,
+// so template authors can see why a field renders blank.
+type RenderDebug struct {
+	TaskID         int                    `json:"task_id"`
+	TaskUUID       string                 `json:"task_uuid"`
+	Phase          string                 `json:"phase"` // "worker" or "qa"
+	TemplatePath   string                 `json:"template_path"`
+	TemplateSource string                 `json:"template_source,omitempty"` // "playbook" or "project"
+	TemplateError  string                 `json:"template_error,omitempty"`  // Load, parse or execution error
+	RawResult      string                 `json:"raw_result"`
+	ParsedFields   map[string]interface{} `json:"parsed_fields,omitempty"` // Result JSON as parsed, before metadata is merged
+	ParseError     string                 `json:"parse_error,omitempty"`   // Set when the result is not a JSON object
+	Context        map[string]interface{} `json:"context,omitempty"`       // Merged template context (fields + _task_* + _qa_*)
+	TemplateFields []string               `json:"template_fields,omitempty"`
+	MissingFields  []string               `json:"missing_fields,omitempty"` // Top-level fields referenced by the template but absent from the context
+	Rendered       string                 `json:"rendered"`
+	UsedRawResult  bool                   `json:"used_raw_result"` // True if the report would fall back to the raw result
+}
+
+// DebugRender renders a task with the given template and returns every intermediate
+// step. The rendering follows the same rules as RenderWithTemplate and
+// RenderQAWithTemplate, including the fallback to the raw result.
+func (r *Reporter) DebugRender(task TaskReport, templatePath string, qa bool) *RenderDebug {
+	debug := &RenderDebug{
+		TaskID:       task.ID,
+		TaskUUID:     task.UUID,
+		Phase:        "worker",
+		TemplatePath: templatePath,
+		RawResult:    task.WorkResult,
+	}
+	build := r.workTemplateData
+	if qa {
+		debug.Phase = "qa"
+		debug.RawResult = task.QAResult
+		build = r.qaTemplateData
+	}
+
+	// Parsed fields as they appear in the result, before metadata is merged
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(debug.RawResult), &parsed); err != nil {
+		debug.ParseError = err.Error()
+	} else {
+		debug.ParsedFields = parsed
+	}
+
+	if context, err := build(task); err == nil {
+		debug.Context = context
+	}
+
+	debug.Rendered = debug.RawResult
+	debug.UsedRawResult = true
+
+	if templatePath == "" {
+		debug.TemplateError = "no template configured; raw result is used"
+		return debug
+	}
+
+	// Same resolution order as RenderWithTemplate: playbook first for playbook-style paths, then project
+	sources := []string{"project"}
+	if r.playbookLoader != nil && strings.Contains(templatePath, "/") {
+		sources = []string{"playbook", "project"}
+	}
+	var tmpl *template.Template
+	var loadErrors []string
+	for _, source := range sources {
+		t, err := r.loadTemplate(templatePath, source)
+		if err == nil {
+			tmpl = t
+			debug.TemplateSource = source
+			break
+		}
+		loadErrors = append(loadErrors, source+": "+err.Error())
+	}
+	if tmpl == nil {
+		debug.TemplateError = strings.Join(loadErrors, "; ")
+		return debug
+	}
+
+	debug.TemplateFields = templateFields(tmpl)
+	if debug.Context != nil {
+		for _, field := range debug.TemplateFields {
+			if _, ok := debug.Context[field]; !ok {
+				debug.MissingFields = append(debug.MissingFields, field)
+			}
+		}
+	}
+
+	if debug.Context == nil {
+		debug.TemplateError = "result is not a JSON object; raw result is used"
+		return debug
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, debug.Context); err != nil {
+		debug.TemplateError = err.Error()
+		return debug
+	}
+
+	debug.Rendered = buf.String()
+	debug.UsedRawResult = false
+	return debug
+}
+
+// templateFields returns the sorted top-level field names referenced by a template
+// (e.g. {{.finding}} or {{if .evidence}}). Fields inside range/with bodies are
+// relative to a different dot and are not included.
+func templateFields(tmpl *template.Template) []string {
+	seen := make(map[string]bool)
+	for _, t := range tmpl.Templates() {
+		if t.Tree != nil && t.Tree.Root != nil {
+			collectFields(t.Tree.Root, seen)
+		}
+	}
+	fields := make([]string, 0, len(seen))
+	for field := range seen {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// collectFields walks a template parse tree and records the first identifier of each field node
+func collectFields(node parse.Node, seen map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectFields(child, seen)
+		}
+	case *parse.ActionNode:
+		collectFields(n.Pipe, seen)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			collectFields(cmd, seen)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			collectFields(arg, seen)
+		}
+	case *parse.FieldNode:
+		if len(n.Ident) > 0 {
+			seen[n.Ident[0]] = true
+		}
+	case *parse.IfNode:
+		collectFields(n.Pipe, seen)
+		collectFields(n.List, seen)
+		collectFields(n.ElseList, seen)
+	case *parse.RangeNode:
+		collectFields(n.Pipe, seen)
+		collectFields(n.ElseList, seen)
+	case *parse.WithNode:
+		collectFields(n.Pipe, seen)
+		collectFields(n.ElseList, seen)
+	}
+}
@@ -0,0 +1,294 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package reporting
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+var (
+	docxParagraph   = regexp.MustCompile(`(?s)<w:p[ >].*?</w:p>`)
+	docxText        = regexp.MustCompile(`(?s)<w:t(?: [^>]*)?>(.*?)</w:t>`)
+	docxRow         = regexp.MustCompile(`(?s)<w:tr[ >].*?</w:tr>`)
+	docxSDT         = regexp.MustCompile(`(?s)<w:sdt>.*?</w:sdt>`)
+	docxSDTTag      = regexp.MustCompile(`<w:tag w:val="([^"]*)"\s*/>`)
+	docxSDTContent  = regexp.MustCompile(`(?s)<w:sdtContent>.*</w:sdtContent>`)
+	docxPlaceholder = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*\}\}`)
+)
+
+// docxPart reports whether a DOCX part holds text to fill: the document body,
+// headers and footers
+func docxPart(name string) bool {
+	if name == "word/document.xml" {
+		return true
+	}
+	return strings.HasPrefix(name, "word/") && strings.HasSuffix(name, ".xml") &&
+		(strings.HasPrefix(name, "word/header") || strings.HasPrefix(name, "word/footer"))
+}
+
+// FillDOCX fills a DOCX template with data and returns the filled document along
+// with the placeholders that had no value, which are left in place.
+//
+// The template can use {{name}} placeholders (dotted names reach into nested
+// objects), content controls whose tag is a data key, and table rows that repeat
+// once per item of a list: a row containing {{findings.title}} is written once
+// for each element of data["findings"]. A placeholder that Word split across
+// runs is merged back, taking the formatting of its paragraph's first run.
+func FillDOCX(template []byte, data map[string]interface{}) ([]byte, []string, error) {
+	reader, err := zip.NewReader(bytes.NewReader(template), int64(len(template)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("template is not a valid DOCX file: %w", err)
+	}
+
+	var out bytes.Buffer
+	writer := zip.NewWriter(&out)
+	unresolved := make(map[string]bool)
+	found := false
+	for _, f := range reader.File {
+		if !docxPart(f.Name) {
+			if err := writer.Copy(f); err != nil {
+				return nil, nil, fmt.Errorf("failed to copy %s: %w", f.Name, err)
+			}
+			continue
+		}
+		found = found || f.Name == "word/document.xml"
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read %s: %w", f.Name, err)
+		}
+		content, err := io.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read %s: %w", f.Name, err)
+		}
+
+		filled := fillDOCXPart(string(content), data, unresolved)
+
+		w, err := writer.CreateHeader(&zip.FileHeader{Name: f.Name, Method: zip.Deflate, Modified: f.Modified})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to write %s: %w", f.Name, err)
+		}
+		if _, err := w.Write([]byte(filled)); err != nil {
+			return nil, nil, fmt.Errorf("failed to write %s: %w", f.Name, err)
+		}
+	}
+	if !found {
+		return nil, nil, fmt.Errorf("template is not a valid DOCX file: word/document.xml not found")
+	}
+	if err := writer.Close(); err != nil {
+		return nil, nil, fmt.Errorf("failed to write DOCX: %w", err)
+	}
+
+	missing := make([]string, 0, len(unresolved))
+	for name := range unresolved {
+		missing = append(missing, name)
+	}
+	sort.Strings(missing)
+	return out.Bytes(), missing, nil
+}
+
+// fillDOCXPart fills one XML part, recording placeholders without a value
+func fillDOCXPart(xml string, data map[string]interface{}, unresolved map[string]bool) string {
+	xml = docxParagraph.ReplaceAllStringFunc(xml, mergeSplitPlaceholders)
+	xml = docxRow.ReplaceAllStringFunc(xml, func(row string) string { return expandRow(row, data) })
+	xml = docxSDT.ReplaceAllStringFunc(xml, func(sdt string) string { return fillContentControl(sdt, data) })
+	xml = replacePlaceholders(xml, data, unresolved)
+	// Keep leading and trailing spaces of filled values
+	return strings.ReplaceAll(xml, "<w:t>", `<w:t xml:space="preserve">`)
+}
+
+// mergeSplitPlaceholders moves the text of a paragraph into its first text run
+// when a placeholder is split across runs, as Word does after spell checks or edits
+func mergeSplitPlaceholders(paragraph string) string {
+	matches := docxText.FindAllStringSubmatchIndex(paragraph, -1)
+	if len(matches) < 2 {
+		return paragraph
+	}
+
+	var joined strings.Builder
+	whole := 0
+	for _, m := range matches {
+		text := paragraph[m[2]:m[3]]
+		joined.WriteString(text)
+		whole += len(docxPlaceholder.FindAllStringIndex(text, -1))
+	}
+	if len(docxPlaceholder.FindAllStringIndex(joined.String(), -1)) == whole {
+		return paragraph
+	}
+
+	var sb strings.Builder
+	last := 0
+	for i, m := range matches {
+		sb.WriteString(paragraph[last:m[0]])
+		if i == 0 {
+			sb.WriteString(`<w:t xml:space="preserve">` + joined.String() + `</w:t>`)
+		} else {
+			sb.WriteString(`<w:t></w:t>`)
+		}
+		last = m[1]
+	}
+	sb.WriteString(paragraph[last:])
+	return sb.String()
+}
+
+// expandRow repeats a table row once per item of the list its placeholders
+// refer to, or removes it when the list is empty
+func expandRow(row string, data map[string]interface{}) string {
+	var list []interface{}
+	prefix := ""
+	for _, m := range docxPlaceholder.FindAllStringSubmatch(row, -1) {
+		name, _, ok := strings.Cut(m[1], ".")
+		if !ok {
+			continue
+		}
+		if items, isList := data[name].([]interface{}); isList {
+			list, prefix = items, name+"."
+			break
+		}
+	}
+	if prefix == "" {
+		return row
+	}
+
+	var sb strings.Builder
+	for _, item := range list {
+		fields, _ := item.(map[string]interface{})
+		sb.WriteString(docxPlaceholder.ReplaceAllStringFunc(row, func(placeholder string) string {
+			name := docxPlaceholder.FindStringSubmatch(placeholder)[1]
+			if !strings.HasPrefix(name, prefix) {
+				return placeholder
+			}
+			value, ok := lookupValue(fields, strings.TrimPrefix(name, prefix))
+			if !ok {
+				return ""
+			}
+			return docxValue(value)
+		}))
+	}
+	return sb.String()
+}
+
+// fillContentControl sets the text of a content control whose tag names a data
+// key, replacing the control's placeholder text
+func fillContentControl(sdt string, data map[string]interface{}) string {
+	tag := docxSDTTag.FindStringSubmatch(sdt)
+	if tag == nil {
+		return sdt
+	}
+	value, ok := lookupValue(data, html.UnescapeString(tag[1]))
+	if !ok {
+		return sdt
+	}
+
+	sdt = strings.Replace(sdt, "<w:showingPlcHdr/>", "", 1)
+	return docxSDTContent.ReplaceAllStringFunc(sdt, func(content string) string {
+		first := true
+		return docxText.ReplaceAllStringFunc(content, func(string) string {
+			if !first {
+				return "<w:t></w:t>"
+			}
+			first = false
+			return `<w:t xml:space="preserve">` + docxValue(value) + "</w:t>"
+		})
+	})
+}
+
+// replacePlaceholders fills the remaining {{name}} placeholders
+func replacePlaceholders(xml string, data map[string]interface{}, unresolved map[string]bool) string {
+	return docxPlaceholder.ReplaceAllStringFunc(xml, func(placeholder string) string {
+		name := docxPlaceholder.FindStringSubmatch(placeholder)[1]
+		value, ok := lookupValue(data, name)
+		if !ok {
+			unresolved[name] = true
+			return placeholder
+		}
+		return docxValue(value)
+	})
+}
+
+// lookupValue resolves a dotted name in nested objects
+func lookupValue(data map[string]interface{}, name string) (interface{}, bool) {
+	var current interface{} = data
+	for _, key := range strings.Split(name, ".") {
+		fields, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = fields[key]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// docxValue renders a value as escaped run text, turning newlines into line breaks
+func docxValue(value interface{}) string {
+	var text string
+	switch v := value.(type) {
+	case nil:
+		text = ""
+	case string:
+		text = v
+	case float64:
+		text = fmt.Sprint(v)
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				parts = append(parts, s)
+			} else {
+				data, _ := json.Marshal(item)
+				parts = append(parts, string(data))
+			}
+		}
+		text = strings.Join(parts, "\n")
+	case map[string]interface{}:
+		data, _ := json.Marshal(v)
+		text = string(data)
+	default:
+		text = fmt.Sprint(v)
+	}
+
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = html.EscapeString(line)
+	}
+	return strings.Join(lines, `</w:t><w:br/><w:t xml:space="preserve">`)
+}
+
+// Findings returns the structured result of each completed task in a report, in
+// report order, for filling deliverable templates. Each finding holds the parsed
+// worker response plus the task metadata fields templates see (_task_id,
+// _task_title, _qa_verdict and so on) and _path, the task set path. Tasks whose
+// result is not a JSON object are skipped.
+func (r *Reporter) Findings(report *ProjectReport) []interface{} {
+	findings := []interface{}{}
+	for _, ts := range report.TaskSets {
+		for _, task := range ts.Tasks {
+			if task.WorkStatus != global.ExecutionStatusDone || task.WorkResult == "" {
+				continue
+			}
+			data, err := r.workTemplateData(task)
+			if err != nil {
+				continue
+			}
+			data["_path"] = ts.Path
+			findings = append(findings, data)
+		}
+	}
+	return findings
+}
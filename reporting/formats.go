@@ -0,0 +1,132 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package reporting
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// Render renders a Markdown report in a format: markdown, html or pdf. theme is
+// the CSS of HTML output; when empty, the configured theme file is used, or the
+// built-in theme. PDF output is converted from the HTML by the configured
+// converter, or laid out by the built-in writer.
+func (r *Reporter) Render(markdown, format, theme string) ([]byte, error) {
+	switch format {
+	case global.ReportFormatMarkdown, "md":
+		return []byte(markdown), nil
+	case global.ReportFormatHTML:
+		css, err := r.theme(theme)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(RenderHTML(markdown, css)), nil
+	case global.ReportFormatPDF:
+		if r.output.PDFCommand == "" {
+			return RenderPDF(markdown), nil
+		}
+		css, err := r.theme(theme)
+		if err != nil {
+			return nil, err
+		}
+		return r.convertPDF(RenderHTML(markdown, css))
+	default:
+		return nil, fmt.Errorf("unsupported report format '%s' (must be %s, %s or %s)", format, global.ReportFormatMarkdown, global.ReportFormatHTML, global.ReportFormatPDF)
+	}
+}
+
+// LoadTheme loads a CSS theme named in a report manifest. Like manifests, themes
+// are playbook files ("playbook-name/path") or project files.
+func (r *Reporter) LoadTheme(themePath string) (string, error) {
+	if r.playbookLoader != nil && strings.Contains(themePath, "/") {
+		if content, err := r.playbookLoader.GetContent(themePath); err == nil {
+			return content, nil
+		}
+	}
+	if r.projectLoader == nil {
+		return "", fmt.Errorf("theme not found: %s", themePath)
+	}
+	content, err := r.projectLoader.GetContent(themePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to load theme %s: %w", themePath, err)
+	}
+	return content, nil
+}
+
+// theme returns the CSS of HTML output: the given theme, else the configured
+// theme file, else "" for the built-in theme
+func (r *Reporter) theme(theme string) (string, error) {
+	if theme != "" || r.output.Theme == "" {
+		return theme, nil
+	}
+	data, err := os.ReadFile(r.output.Theme)
+	if err != nil {
+		return "", fmt.Errorf("failed to read report theme: %w", err)
+	}
+	return string(data), nil
+}
+
+// convertPDF converts an HTML report to PDF with the configured converter
+func (r *Reporter) convertPDF(html string) ([]byte, error) {
+	tmpDir, err := os.MkdirTemp("", "maestro-report-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	input := filepath.Join(tmpDir, "report.html")
+	output := filepath.Join(tmpDir, "report.pdf")
+	if err := os.WriteFile(input, []byte(html), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write HTML for PDF conversion: %w", err)
+	}
+
+	toStdout := true
+	args := make([]string, len(r.output.PDFArgs))
+	for i, arg := range r.output.PDFArgs {
+		toStdout = toStdout && !strings.Contains(arg, global.ConversionOutputPlaceholder)
+		arg = strings.ReplaceAll(arg, global.ConversionInputPlaceholder, input)
+		args[i] = strings.ReplaceAll(arg, global.ConversionOutputPlaceholder, output)
+	}
+
+	timeout := r.output.PDFTimeoutSeconds
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, r.output.PDFCommand, args...)
+	cmd.Dir = tmpDir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("PDF conversion timed out after %d seconds", timeout)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("PDF conversion failed: %v: %s", err, msg)
+		}
+		return nil, fmt.Errorf("PDF conversion failed: %w", err)
+	}
+
+	if toStdout {
+		if stdout.Len() == 0 {
+			return nil, fmt.Errorf("PDF conversion produced no output")
+		}
+		return stdout.Bytes(), nil
+	}
+	data, err := os.ReadFile(output)
+	if err != nil {
+		return nil, fmt.Errorf("PDF conversion produced no output: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,480 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package reporting
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// The template function library. Functions that read fields of list items take
+// a field path ("severity", "evidence.path") resolved against JSON objects and
+// report structs alike; items missing the field are skipped or render empty, as
+// results of different tasks rarely share every field.
+
+// libraryFuncs returns the date, Markdown, conditional, aggregation and escaping
+// functions available to report templates
+func (r *Reporter) libraryFuncs() template.FuncMap {
+	return template.FuncMap{
+		// Dates, in the configured timezone
+		"now":         func() time.Time { return r.clock.Now() },
+		"formatDate":  r.formatDate,
+		"daysBetween": daysBetween,
+
+		// Markdown builders
+		"table":   table,
+		"row":     row,
+		"bullets": bullets,
+
+		// Conditional content
+		"default":  defaultValue,
+		"coalesce": coalesce,
+		"empty":    isEmpty,
+		"section":  section,
+
+		// Lists and numbers
+		"get":     lookup,
+		"pluck":   pluck,
+		"flatten": flatten,
+		"where":   where,
+		"count":   count,
+		"countBy": countBy,
+		"sum":     sum,
+		"avg":     avg,
+		"min":     minimum,
+		"max":     maximum,
+		"add":     func(a, b interface{}) float64 { return number(a) + number(b) },
+		"sub":     func(a, b interface{}) float64 { return number(a) - number(b) },
+		"mul":     func(a, b interface{}) float64 { return number(a) * number(b) },
+		"div":     divide,
+		"percent": percent,
+		"round":   round,
+
+		// Escaping
+		"escapeHTML":     func(v interface{}) string { return html.EscapeString(text(v)) },
+		"escapeMarkdown": escapeMarkdown,
+	}
+}
+
+// formatDate formats a time with a Go layout ("02 Jan 2006") in the configured
+// timezone. Values that are not times are rendered unchanged, as with date.
+func (r *Reporter) formatDate(layout string, v interface{}) string {
+	return formatTime(v, func(t time.Time) string { return r.clock.In(t).Format(layout) })
+}
+
+// daysBetween returns the whole days from one time to another, negative when to
+// is earlier. Times are time values or RFC 3339 strings.
+func daysBetween(from, to interface{}) (int, error) {
+	start, err := toTime(from)
+	if err != nil {
+		return 0, err
+	}
+	end, err := toTime(to)
+	if err != nil {
+		return 0, err
+	}
+	return int(end.Sub(start).Hours() / 24), nil
+}
+
+// toTime converts a template value to a time
+func toTime(v interface{}) (time.Time, error) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, nil
+	case *time.Time:
+		if t != nil {
+			return *t, nil
+		}
+	case string:
+		if parsed, err := time.Parse(time.RFC3339, t); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("not a time: %v", v)
+}
+
+// table renders list items as a Markdown table, one row per item. Each column is
+// a field path, optionally followed by ":Header"; without one the header is the
+// field name. Renders nothing for an empty list, so it can be passed to section.
+func table(items interface{}, columns ...string) (string, error) {
+	list, err := toList(items)
+	if err != nil || len(list) == 0 {
+		return "", err
+	}
+	if len(columns) == 0 {
+		return "", fmt.Errorf("table requires at least one column")
+	}
+
+	fields := make([]string, len(columns))
+	headers := make([]interface{}, len(columns))
+	separator := make([]string, len(columns))
+	for i, column := range columns {
+		field, header, found := strings.Cut(column, ":")
+		if !found {
+			header = field[strings.LastIndex(field, ".")+1:]
+		}
+		fields[i], headers[i], separator[i] = field, header, "---"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(row(headers...))
+	sb.WriteString("| " + strings.Join(separator, " | ") + " |\n")
+	for _, item := range list {
+		cells := make([]interface{}, len(fields))
+		for i, field := range fields {
+			cells[i] = lookup(item, field)
+		}
+		sb.WriteString(row(cells...))
+	}
+	return sb.String(), nil
+}
+
+// row renders values as one Markdown table row, escaping pipes and joining lines
+func row(cells ...interface{}) string {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		value := strings.Join(strings.Fields(text(cell)), " ")
+		escaped[i] = strings.ReplaceAll(value, "|", "\\|")
+	}
+	return "| " + strings.Join(escaped, " | ") + " |\n"
+}
+
+// bullets renders list items, or a field of each, as a Markdown bullet list
+func bullets(items interface{}, field ...string) (string, error) {
+	list, err := toList(items)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	for _, item := range list {
+		if len(field) > 0 {
+			item = lookup(item, field[0])
+		}
+		if value := strings.Join(strings.Fields(text(item)), " "); value != "" {
+			sb.WriteString("- " + value + "\n")
+		}
+	}
+	return sb.String(), nil
+}
+
+// section returns a heading followed by content, or nothing when the content is
+// blank, so optional sections disappear instead of leaving an empty heading
+func section(heading string, content interface{}) string {
+	body := strings.TrimSpace(text(content))
+	if body == "" {
+		return ""
+	}
+	return heading + "\n\n" + body + "\n"
+}
+
+// defaultValue returns the value, or the default when the value is empty
+func defaultValue(def, v interface{}) interface{} {
+	if isEmpty(v) {
+		return def
+	}
+	return v
+}
+
+// coalesce returns the first value that is not empty
+func coalesce(values ...interface{}) interface{} {
+	for _, v := range values {
+		if !isEmpty(v) {
+			return v
+		}
+	}
+	return nil
+}
+
+// isEmpty reports whether a value is missing, zero, blank or has no elements
+func isEmpty(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return strings.TrimSpace(rv.String()) == ""
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return rv.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return rv.IsNil()
+	default:
+		return rv.IsZero()
+	}
+}
+
+// lookup returns the value at a dotted field path of a JSON object or struct,
+// or nil if any part of the path is missing
+func lookup(item interface{}, path string) interface{} {
+	if path == "" {
+		return item
+	}
+	current := reflect.ValueOf(item)
+	for _, name := range strings.Split(path, ".") {
+		for current.Kind() == reflect.Pointer || current.Kind() == reflect.Interface {
+			if current.IsNil() {
+				return nil
+			}
+			current = current.Elem()
+		}
+		switch current.Kind() {
+		case reflect.Map:
+			if current.Type().Key().Kind() != reflect.String {
+				return nil
+			}
+			current = current.MapIndex(reflect.ValueOf(name).Convert(current.Type().Key()))
+		case reflect.Struct:
+			current = current.FieldByName(name)
+			if current.IsValid() && !current.CanInterface() {
+				return nil
+			}
+		default:
+			return nil
+		}
+		if !current.IsValid() {
+			return nil
+		}
+	}
+	return current.Interface()
+}
+
+// pluck returns a field of each list item that has it
+func pluck(items interface{}, field string) ([]interface{}, error) {
+	list, err := toList(items)
+	if err != nil {
+		return nil, err
+	}
+	values := []interface{}{}
+	for _, item := range list {
+		if value := lookup(item, field); value != nil {
+			values = append(values, value)
+		}
+	}
+	return values, nil
+}
+
+// flatten joins lists of lists into one list, such as the findings plucked
+// from every task; items that are not lists are kept as they are
+func flatten(items interface{}) ([]interface{}, error) {
+	list, err := toList(items)
+	if err != nil {
+		return nil, err
+	}
+	flat := []interface{}{}
+	for _, item := range list {
+		if inner, err := toList(item); err == nil {
+			flat = append(flat, inner...)
+		} else {
+			flat = append(flat, item)
+		}
+	}
+	return flat, nil
+}
+
+// where returns the list items whose field equals one of the values, ignoring case
+func where(items interface{}, field string, values ...interface{}) ([]interface{}, error) {
+	list, err := toList(items)
+	if err != nil {
+		return nil, err
+	}
+	matches := []interface{}{}
+	for _, item := range list {
+		actual := text(lookup(item, field))
+		for _, want := range values {
+			if strings.EqualFold(actual, text(want)) {
+				matches = append(matches, item)
+				break
+			}
+		}
+	}
+	return matches, nil
+}
+
+// count returns the number of elements of a list, map or string, 0 for nil
+func count(v interface{}) int {
+	if v == nil {
+		return 0
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.String:
+		return rv.Len()
+	default:
+		return 1
+	}
+}
+
+// countBy counts list items by the value of a field. Templates range over the
+// result in value order; items without the field are counted under "".
+func countBy(items interface{}, field string) (map[string]int, error) {
+	list, err := toList(items)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int)
+	for _, item := range list {
+		counts[text(lookup(item, field))]++
+	}
+	return counts, nil
+}
+
+// numbers returns the numeric values of list items, or of a field of each;
+// values that are not numbers are skipped
+func numbers(items interface{}, field []string) ([]float64, error) {
+	list, err := toList(items)
+	if err != nil {
+		return nil, err
+	}
+	var values []float64
+	for _, item := range list {
+		if len(field) > 0 {
+			item = lookup(item, field[0])
+		}
+		if value, ok := toNumber(item); ok {
+			values = append(values, value)
+		}
+	}
+	return values, nil
+}
+
+// sum returns the total of list items, or of a field of each
+func sum(items interface{}, field ...string) (float64, error) {
+	values, err := numbers(items, field)
+	total := 0.0
+	for _, value := range values {
+		total += value
+	}
+	return total, err
+}
+
+// avg returns the mean of list items, or of a field of each, 0 when there are none
+func avg(items interface{}, field ...string) (float64, error) {
+	values, err := numbers(items, field)
+	if err != nil || len(values) == 0 {
+		return 0, err
+	}
+	total, _ := sum(values)
+	return total / float64(len(values)), nil
+}
+
+// minimum returns the smallest of list items, or of a field of each, 0 when there are none
+func minimum(items interface{}, field ...string) (float64, error) {
+	values, err := numbers(items, field)
+	if err != nil || len(values) == 0 {
+		return 0, err
+	}
+	sort.Float64s(values)
+	return values[0], nil
+}
+
+// maximum returns the largest of list items, or of a field of each, 0 when there are none
+func maximum(items interface{}, field ...string) (float64, error) {
+	values, err := numbers(items, field)
+	if err != nil || len(values) == 0 {
+		return 0, err
+	}
+	sort.Float64s(values)
+	return values[len(values)-1], nil
+}
+
+// divide divides two numbers
+func divide(a, b interface{}) (float64, error) {
+	divisor := number(b)
+	if divisor == 0 {
+		return 0, fmt.Errorf("division by zero")
+	}
+	return number(a) / divisor, nil
+}
+
+// percent returns part as a percentage of total to one decimal place, 0 when total is 0
+func percent(part, total interface{}) float64 {
+	whole := number(total)
+	if whole == 0 {
+		return 0
+	}
+	return round(number(part)*100/whole, 1)
+}
+
+// round rounds a number to a number of decimal places
+func round(v interface{}, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(number(v)*scale) / scale
+}
+
+// number converts a template value to a number, 0 if it is not one
+func number(v interface{}) float64 {
+	value, _ := toNumber(v)
+	return value
+}
+
+// toNumber converts a template value to a number. JSON numbers arrive as
+// float64; integers come from report structs and numeric strings from results.
+func toNumber(v interface{}) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.String:
+		value, err := strconv.ParseFloat(strings.TrimSpace(rv.String()), 64)
+		return value, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// toList converts a template value to a list. Nil is an empty list; anything
+// other than a slice or array is an error.
+func toList(v interface{}) ([]interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("expected a list, got %T", v)
+	}
+	list := make([]interface{}, rv.Len())
+	for i := range list {
+		list[i] = rv.Index(i).Interface()
+	}
+	return list, nil
+}
+
+// text renders a template value as text: nil is empty and lists are joined with commas
+func text(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	if list, err := toList(v); err == nil {
+		parts := make([]string, len(list))
+		for i, item := range list {
+			parts[i] = text(item)
+		}
+		return strings.Join(parts, ", ")
+	}
+	return fmt.Sprint(v)
+}
+
+// markdownEscaper escapes the characters Markdown would treat as formatting
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`,
+	"<", `\<`, ">", `\>`, "#", `\#`, "|", `\|`,
+)
+
+// escapeMarkdown escapes a value so it renders as literal text in Markdown
+func escapeMarkdown(v interface{}) string {
+	return markdownEscaper.Replace(text(v))
+}
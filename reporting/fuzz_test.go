@@ -0,0 +1,57 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package reporting
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// FuzzRenderWithTemplate feeds arbitrary worker and QA results through the
+// report templates: whatever the LLM returned, rendering must not panic and
+// results that are not JSON objects are reported raw
+func FuzzRenderWithTemplate(f *testing.F) {
+	for _, seed := range [][2]string{
+		{`{"finding": "ok", "confidence": "high"}`, `{"verdict": "pass"}`},
+		{`{"finding": null, "confidence": 7}`, `{"verdict": ["pass"]}`},
+		{`{"confidence": {"level": "high"}, "evidence": [1, "two", null]}`, `not json`},
+		{`["finding"]`, `{}`},
+		{"```json\n{\"finding\": \"x\"}\n```", ``},
+		{`null`, `null`},
+	} {
+		f.Add(seed[0], seed[1])
+	}
+
+	loader := ContentLoaderFunc(func(path string) (string, error) {
+		switch path {
+		case "work.md":
+			return "{{.finding}} {{.confidence}}{{with ._qa_result}} {{.verdict}}{{end}}{{range .evidence}} {{.}}{{end}}", nil
+		case "qa.md":
+			return "{{.verdict}} {{.feedback}}", nil
+		}
+		return "", os.ErrNotExist
+	})
+	r := New(nil, WithProjectLoader(loader))
+	variant := global.ReportTemplateConfig{ExcludeFields: []string{"evidence", "confidence.level"}}
+
+	f.Fuzz(func(t *testing.T, workResult, qaResult string) {
+		task := TaskReport{ID: 1, WorkResult: workResult, QAResult: qaResult}
+
+		var object map[string]interface{}
+		isObject := json.Unmarshal([]byte(workResult), &object) == nil && object != nil
+		if out := r.RenderWithTemplate(task, "work.md"); !isObject && out != workResult {
+			t.Fatalf("RenderWithTemplate(%q) = %q, want the raw result", workResult, out)
+		}
+		r.RenderQAWithTemplate(task, "qa.md")
+
+		if narrowed := ApplyVariant(task, variant); !isObject && narrowed.WorkResult != workResult {
+			t.Fatalf("ApplyVariant(%q) = %q, want the result unchanged", workResult, narrowed.WorkResult)
+		}
+	})
+}
@@ -0,0 +1,185 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package reporting
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// defaultTheme is the stylesheet of HTML reports when no theme is configured
+const defaultTheme = `body {
+  font-family: "Helvetica Neue", Helvetica, Arial, sans-serif;
+  font-size: 11pt;
+  line-height: 1.5;
+  color: #1f2933;
+  max-width: 60em;
+  margin: 2em auto;
+  padding: 0 1.5em;
+}
+h1, h2, h3, h4, h5, h6 { color: #102a43; line-height: 1.25; margin: 1.6em 0 0.6em; }
+h1 { font-size: 1.9em; border-bottom: 2px solid #334e68; padding-bottom: 0.3em; }
+h2 { font-size: 1.45em; border-bottom: 1px solid #d9e2ec; padding-bottom: 0.2em; }
+h3 { font-size: 1.2em; }
+a { color: #2f6fb0; }
+code, pre { font-family: Menlo, Consolas, "Liberation Mono", monospace; font-size: 0.9em; }
+code { background: #f0f4f8; padding: 0.1em 0.3em; border-radius: 3px; }
+pre { background: #f0f4f8; padding: 0.8em 1em; overflow-x: auto; border-radius: 4px; }
+pre code { background: none; padding: 0; }
+blockquote { margin: 1em 0; padding: 0.2em 1em; border-left: 4px solid #bcccdc; color: #486581; }
+table { border-collapse: collapse; margin: 1em 0; width: 100%; }
+th, td { border: 1px solid #bcccdc; padding: 0.4em 0.6em; text-align: left; vertical-align: top; }
+th { background: #f0f4f8; }
+hr { border: none; border-top: 1px solid #d9e2ec; margin: 2em 0; }
+@media print {
+  body { max-width: none; margin: 0; }
+  h1, h2, h3 { page-break-after: avoid; }
+  pre, blockquote, tr { page-break-inside: avoid; }
+}
+`
+
+// RenderHTML renders a Markdown report as a standalone HTML document styled with
+// css, or with the built-in theme when css is empty. The document title is the
+// report's first level 1 heading.
+func RenderHTML(markdown, css string) string {
+	if css == "" {
+		css = defaultTheme
+	}
+	blocks := parseMarkdown(markdown)
+
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	if title := documentTitle(blocks); title != "" {
+		sb.WriteString("<title>" + html.EscapeString(title) + "</title>\n")
+	}
+	// A closing style tag in the theme would end the stylesheet early
+	sb.WriteString("<style>\n" + strings.ReplaceAll(css, "</style", "<\\/style") + "</style>\n")
+	sb.WriteString("</head>\n<body>\n")
+	writeHTMLBlocks(&sb, blocks)
+	sb.WriteString("</body>\n</html>\n")
+	return sb.String()
+}
+
+// writeHTMLBlocks writes blocks as HTML
+func writeHTMLBlocks(sb *strings.Builder, blocks []mdBlock) {
+	for _, b := range blocks {
+		switch b.kind {
+		case blockHeading:
+			fmt.Fprintf(sb, "<h%d>%s</h%d>\n", b.level, inlineHTML(b.text), b.level)
+
+		case blockParagraph:
+			sb.WriteString("<p>" + inlineHTML(b.text) + "</p>\n")
+
+		case blockRule:
+			sb.WriteString("<hr>\n")
+
+		case blockCode:
+			if b.lang != "" {
+				fmt.Fprintf(sb, "<pre><code class=\"language-%s\">", html.EscapeString(b.lang))
+			} else {
+				sb.WriteString("<pre><code>")
+			}
+			sb.WriteString(html.EscapeString(strings.Join(b.lines, "\n")))
+			sb.WriteString("</code></pre>\n")
+
+		case blockQuote:
+			sb.WriteString("<blockquote>\n")
+			writeHTMLBlocks(sb, b.children)
+			sb.WriteString("</blockquote>\n")
+
+		case blockList:
+			tag := "ul"
+			if b.ordered {
+				tag = "ol"
+				if b.start > 1 {
+					fmt.Fprintf(sb, "<ol start=\"%d\">\n", b.start)
+				} else {
+					sb.WriteString("<ol>\n")
+				}
+			} else {
+				sb.WriteString("<ul>\n")
+			}
+			for _, item := range b.items {
+				sb.WriteString("<li>")
+				// A single paragraph is written without its <p>, as tight lists are
+				if len(item) == 1 && item[0].kind == blockParagraph {
+					sb.WriteString(inlineHTML(item[0].text))
+				} else {
+					sb.WriteString("\n")
+					writeHTMLBlocks(sb, item)
+				}
+				sb.WriteString("</li>\n")
+			}
+			sb.WriteString("</" + tag + ">\n")
+
+		case blockTable:
+			sb.WriteString("<table>\n")
+			for r, row := range b.rows {
+				cellTag := "td"
+				if r == 0 {
+					cellTag = "th"
+					sb.WriteString("<thead>\n")
+				} else if r == 1 {
+					sb.WriteString("<tbody>\n")
+				}
+				sb.WriteString("<tr>")
+				for c := range b.rows[0] {
+					cell := ""
+					if c < len(row) {
+						cell = row[c]
+					}
+					if c < len(b.align) && b.align[c] != "" {
+						fmt.Fprintf(sb, "<%s style=\"text-align: %s\">%s</%s>", cellTag, b.align[c], inlineHTML(cell), cellTag)
+					} else {
+						fmt.Fprintf(sb, "<%s>%s</%s>", cellTag, inlineHTML(cell), cellTag)
+					}
+				}
+				sb.WriteString("</tr>\n")
+				if r == 0 {
+					sb.WriteString("</thead>\n")
+				}
+			}
+			if len(b.rows) > 1 {
+				sb.WriteString("</tbody>\n")
+			}
+			sb.WriteString("</table>\n")
+		}
+	}
+}
+
+// inlineHTML renders inline Markdown as HTML
+func inlineHTML(text string) string {
+	var sb strings.Builder
+	for _, span := range parseInline(text) {
+		s := html.EscapeString(span.text)
+		if span.code {
+			s = "<code>" + s + "</code>"
+		}
+		if span.italic {
+			s = "<em>" + s + "</em>"
+		}
+		if span.bold {
+			s = "<strong>" + s + "</strong>"
+		}
+		if span.href != "" && safeHref(span.href) {
+			s = "<a href=\"" + html.EscapeString(span.href) + "\">" + s + "</a>"
+		}
+		sb.WriteString(s)
+	}
+	return sb.String()
+}
+
+// safeHref reports whether a link target is safe to keep in HTML: web and mail
+// links, anchors and relative paths, but not script or data URLs
+func safeHref(href string) bool {
+	lower := strings.ToLower(href)
+	scheme, _, found := strings.Cut(lower, ":")
+	if !found || strings.ContainsAny(scheme, "/?#") {
+		return true
+	}
+	return scheme == "http" || scheme == "https" || scheme == "mailto"
+}
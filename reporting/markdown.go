@@ -0,0 +1,437 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package reporting
+
+import (
+	"regexp"
+	"strings"
+)
+
+// The Markdown parser covers what report templates produce: ATX headings,
+// paragraphs, bulleted and numbered lists (nested by indentation), fenced code,
+// block quotes, pipe tables and horizontal rules, with bold, italic, inline code
+// and links inside text. Both the HTML and PDF renderers work from its blocks.
+
+// blockKind identifies the kind of a Markdown block
+type blockKind int
+
+const (
+	blockParagraph blockKind = iota
+	blockHeading
+	blockList
+	blockCode
+	blockQuote
+	blockTable
+	blockRule
+)
+
+// mdBlock is one block of a Markdown document
+type mdBlock struct {
+	kind     blockKind
+	level    int         // Heading level
+	text     string      // Paragraph and heading text (inline Markdown)
+	lang     string      // Code block language
+	lines    []string    // Code block lines
+	ordered  bool        // Numbered list
+	start    int         // First number of a numbered list
+	items    [][]mdBlock // List items
+	children []mdBlock   // Block quote content
+	rows     [][]string  // Table rows, the first being the header
+	align    []string    // Table column alignment: "", "left", "center" or "right"
+}
+
+// mdSpan is a run of text with one style
+type mdSpan struct {
+	text   string
+	bold   bool
+	italic bool
+	code   bool
+	href   string
+}
+
+var (
+	mdHeading   = regexp.MustCompile(`^(#{1,6})\s+(.*?)(?:\s+#+)?\s*$`)
+	mdRule      = regexp.MustCompile(`^\s{0,3}([-*_])(\s*([-*_]))*\s*$`)
+	mdBullet    = regexp.MustCompile(`^(\s*)([-*+])\s+(.*)$`)
+	mdNumbered  = regexp.MustCompile(`^(\s*)(\d{1,9})[.)]\s+(.*)$`)
+	mdFence     = regexp.MustCompile("^\\s*(```+|~~~+)\\s*([^`\\s]*)")
+	mdTableSep  = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	mdQuoteLine = regexp.MustCompile(`^\s{0,3}>\s?(.*)$`)
+	mdComment   = regexp.MustCompile(`^\s{0,3}<!--.*-->\s*$`) // e.g. generated block markers
+)
+
+// parseMarkdown splits a Markdown document into blocks
+func parseMarkdown(markdown string) []mdBlock {
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+	return parseBlocks(lines)
+}
+
+// parseBlocks parses lines into blocks
+func parseBlocks(lines []string) []mdBlock {
+	var blocks []mdBlock
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "" || mdComment.MatchString(line):
+			i++
+
+		case mdFence.MatchString(line):
+			m := mdFence.FindStringSubmatch(line)
+			fence := m[1]
+			block := mdBlock{kind: blockCode, lang: m[2]}
+			i++
+			for i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence) {
+				block.lines = append(block.lines, lines[i])
+				i++
+			}
+			i++ // Closing fence
+			blocks = append(blocks, block)
+
+		case mdHeading.MatchString(line):
+			m := mdHeading.FindStringSubmatch(line)
+			blocks = append(blocks, mdBlock{kind: blockHeading, level: len(m[1]), text: m[2]})
+			i++
+
+		case isRule(line):
+			blocks = append(blocks, mdBlock{kind: blockRule})
+			i++
+
+		case mdQuoteLine.MatchString(line):
+			var quoted []string
+			for i < len(lines) && mdQuoteLine.MatchString(lines[i]) {
+				quoted = append(quoted, mdQuoteLine.FindStringSubmatch(lines[i])[1])
+				i++
+			}
+			blocks = append(blocks, mdBlock{kind: blockQuote, children: parseBlocks(quoted)})
+
+		case strings.HasPrefix(trimmed, "|") && i+1 < len(lines) && mdTableSep.MatchString(lines[i+1]) && strings.Contains(lines[i+1], "-"):
+			block := mdBlock{kind: blockTable, rows: [][]string{splitRow(line)}}
+			for _, cell := range splitRow(lines[i+1]) {
+				block.align = append(block.align, cellAlign(cell))
+			}
+			i += 2
+			for i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "|") {
+				block.rows = append(block.rows, splitRow(lines[i]))
+				i++
+			}
+			blocks = append(blocks, block)
+
+		case mdBullet.MatchString(line) || mdNumbered.MatchString(line):
+			var block mdBlock
+			block, i = parseList(lines, i)
+			blocks = append(blocks, block)
+
+		default:
+			var text []string
+			for i < len(lines) && strings.TrimSpace(lines[i]) != "" && (len(text) == 0 || !startsBlock(lines, i)) {
+				text = append(text, strings.TrimSpace(lines[i]))
+				i++
+			}
+			blocks = append(blocks, mdBlock{kind: blockParagraph, text: strings.Join(text, "\n")})
+		}
+	}
+	return blocks
+}
+
+// startsBlock reports whether line i starts a block other than a paragraph
+func startsBlock(lines []string, i int) bool {
+	line := lines[i]
+	return mdFence.MatchString(line) || mdComment.MatchString(line) || mdHeading.MatchString(line) || isRule(line) ||
+		mdQuoteLine.MatchString(line) || mdBullet.MatchString(line) || mdNumbered.MatchString(line) ||
+		(strings.HasPrefix(strings.TrimSpace(line), "|") && i+1 < len(lines) && mdTableSep.MatchString(lines[i+1]))
+}
+
+// isRule reports whether a line is a horizontal rule: three or more of the same
+// of -, * or _, optionally spaced
+func isRule(line string) bool {
+	if !mdRule.MatchString(line) {
+		return false
+	}
+	stripped := strings.Join(strings.Fields(line), "")
+	return len(stripped) >= 3 && strings.Count(stripped, stripped[:1]) == len(stripped)
+}
+
+// parseList parses the list starting at line i and returns it with the index of
+// the line after it. Lines indented past an item's marker, including nested
+// lists, belong to the item.
+func parseList(lines []string, i int) (mdBlock, int) {
+	block := mdBlock{kind: blockList}
+	indent, ordered, start, _ := listMarker(lines[i])
+	block.ordered = ordered
+	block.start = start
+
+	for i < len(lines) {
+		itemIndent, itemOrdered, _, text := listMarker(lines[i])
+		if itemIndent < 0 || itemIndent != indent || itemOrdered != ordered {
+			break
+		}
+		item := []string{text}
+		i++
+		for i < len(lines) {
+			line := lines[i]
+			if strings.TrimSpace(line) == "" {
+				// A blank line ends the item unless indented content follows
+				if i+1 < len(lines) && leadingSpaces(lines[i+1]) > indent && strings.TrimSpace(lines[i+1]) != "" {
+					item = append(item, "")
+					i++
+					continue
+				}
+				break
+			}
+			if leadingSpaces(line) <= indent {
+				if itemIndent, _, _, _ := listMarker(line); itemIndent >= 0 || startsBlock(lines, i) {
+					break
+				}
+			}
+			item = append(item, dedent(line, indent+2))
+			i++
+		}
+		block.items = append(block.items, parseBlocks(item))
+		// A blank line between items does not end the list
+		if i+1 < len(lines) && strings.TrimSpace(lines[i]) == "" {
+			if nextIndent, nextOrdered, _, _ := listMarker(lines[i+1]); nextIndent == indent && nextOrdered == ordered {
+				i++
+			}
+		}
+	}
+	return block, i
+}
+
+// listMarker returns the indentation, kind, number and text of a list item line,
+// or an indentation of -1 if the line is not a list item
+func listMarker(line string) (int, bool, int, string) {
+	if m := mdBullet.FindStringSubmatch(line); m != nil && !isRule(line) {
+		return len(m[1]), false, 0, m[3]
+	}
+	if m := mdNumbered.FindStringSubmatch(line); m != nil {
+		n := 0
+		for _, c := range m[2] {
+			n = n*10 + int(c-'0')
+		}
+		return len(m[1]), true, n, m[3]
+	}
+	return -1, false, 0, ""
+}
+
+// leadingSpaces returns the indentation of a line, counting a tab as four spaces
+func leadingSpaces(line string) int {
+	n := 0
+	for _, c := range line {
+		switch c {
+		case ' ':
+			n++
+		case '\t':
+			n += 4
+		default:
+			return n
+		}
+	}
+	return n
+}
+
+// dedent removes up to n spaces of indentation from a line
+func dedent(line string, n int) string {
+	for n > 0 && len(line) > 0 && (line[0] == ' ' || line[0] == '\t') {
+		if line[0] == '\t' {
+			n -= 4
+		} else {
+			n--
+		}
+		line = line[1:]
+	}
+	return line
+}
+
+// splitRow splits a table row into its cells
+func splitRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, "\\|") {
+		line = line[:len(line)-1]
+	}
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// cellAlign returns the alignment a table separator cell sets
+func cellAlign(cell string) string {
+	left, right := strings.HasPrefix(cell, ":"), strings.HasSuffix(cell, ":")
+	switch {
+	case left && right:
+		return "center"
+	case right:
+		return "right"
+	case left:
+		return "left"
+	}
+	return ""
+}
+
+// parseInline splits inline Markdown into styled spans. Markers without a
+// closing marker are kept as text.
+func parseInline(text string) []mdSpan {
+	return inlineSpans(text, mdSpan{})
+}
+
+// inlineSpans parses text with the style of its enclosing markup
+func inlineSpans(text string, style mdSpan) []mdSpan {
+	var spans []mdSpan
+	var plain strings.Builder
+	flush := func() {
+		if plain.Len() > 0 {
+			span := style
+			span.text = plain.String()
+			spans = append(spans, span)
+			plain.Reset()
+		}
+	}
+
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == '\\' && i+1 < len(text) && strings.ContainsRune("\\`*_[]()#+-.!|>", rune(text[i+1])):
+			plain.WriteByte(text[i+1])
+			i += 2
+			continue
+
+		case c == '`':
+			if end := strings.IndexByte(text[i+1:], '`'); end >= 0 {
+				flush()
+				span := style
+				span.text = text[i+1 : i+1+end]
+				span.code = true
+				spans = append(spans, span)
+				i += end + 2
+				continue
+			}
+
+		case strings.HasPrefix(text[i:], "**") || strings.HasPrefix(text[i:], "__"):
+			marker := text[i : i+2]
+			if end := strings.Index(text[i+2:], marker); end > 0 {
+				flush()
+				inner := style
+				inner.bold = true
+				spans = append(spans, inlineSpans(text[i+2:i+2+end], inner)...)
+				i += end + 4
+				continue
+			}
+
+		case (c == '*' || c == '_' && (i == 0 || !isWordByte(text[i-1]))) && i+1 < len(text) && text[i+1] != ' ':
+			if end := closingEmphasis(text[i+1:], c); end > 0 {
+				flush()
+				inner := style
+				inner.italic = true
+				spans = append(spans, inlineSpans(text[i+1:i+1+end], inner)...)
+				i += end + 2
+				continue
+			}
+
+		case c == '[':
+			if label, href, n, ok := parseLink(text[i:]); ok {
+				flush()
+				inner := style
+				inner.href = href
+				spans = append(spans, inlineSpans(label, inner)...)
+				i += n
+				continue
+			}
+		}
+		plain.WriteByte(c)
+		i++
+	}
+	flush()
+	return spans
+}
+
+// closingEmphasis returns the index in text of the marker closing an emphasis,
+// or -1. An underscore only closes at the end of a word, so snake_case names
+// are left alone.
+func closingEmphasis(text string, marker byte) int {
+	for i := 0; i < len(text); i++ {
+		if text[i] != marker {
+			continue
+		}
+		if marker == '*' && i+1 < len(text) && text[i+1] == '*' {
+			i++
+			continue
+		}
+		if marker == '_' && i+1 < len(text) && isWordByte(text[i+1]) {
+			continue
+		}
+		if i > 0 && text[i-1] != ' ' {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseLink parses a [label](href) link at the start of text and returns its
+// label, target and length
+func parseLink(text string) (string, string, int, bool) {
+	depth := 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				if i+1 >= len(text) || text[i+1] != '(' {
+					return "", "", 0, false
+				}
+				end := strings.IndexByte(text[i+2:], ')')
+				if end < 0 {
+					return "", "", 0, false
+				}
+				href := strings.TrimSpace(text[i+2 : i+2+end])
+				if href == "" || strings.ContainsAny(href, " \t\n") {
+					return "", "", 0, false
+				}
+				return text[1:i], href, i + 3 + end, true
+			}
+		}
+	}
+	return "", "", 0, false
+}
+
+// isWordByte reports whether a byte is part of a word
+func isWordByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+// documentTitle returns the text of the first level 1 heading, or ""
+func documentTitle(blocks []mdBlock) string {
+	for _, b := range blocks {
+		if b.kind == blockHeading && b.level == 1 {
+			return plainText(parseInline(b.text))
+		}
+	}
+	return ""
+}
+
+// plainText returns the text of spans without their styles
+func plainText(spans []mdSpan) string {
+	var sb strings.Builder
+	for _, s := range spans {
+		sb.WriteString(s.text)
+	}
+	return sb.String()
+}
@@ -0,0 +1,216 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package reporting
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+const (
+	// maxLayoutDepth limits how many layouts a template may chain through extends
+	maxLayoutDepth = 5
+	// maxPartialDepth limits how deeply partials may include other partials
+	maxPartialDepth = 10
+	// partialsDir is the conventional directory for shared partials
+	partialsDir = "partials"
+)
+
+// extendsPattern matches a layout directive at the start of a template:
+//
+//	{{/* extends "layouts/base.md" */}}
+var extendsPattern = regexp.MustCompile(`^\s*\{\{-?\s*/\*\s*extends\s+"([^"]+)"\s*\*/\s*-?\}\}`)
+
+// parseTemplate parses template content together with its layout chain and partials.
+//
+// A template that starts with an extends directive is rendered through the named
+// layout: the layout is parsed first and the template's {{define "name"}} blocks
+// replace the layout's {{block "name" .}} defaults. Any {{template "name" .}}
+// reference that is not defined in the set is loaded as a partial from the same
+// source (see includeCandidates for the search order).
+// The returned paths are the layouts and partials that were loaded.
+func (r *Reporter) parseTemplate(templatePath, source, content string) (*template.Template, []string, error) {
+	// Collect the layout chain: the template itself, then each layout it extends
+	chain := []string{content}
+	var includes []string
+	current := templatePath
+	for layout := layoutName(content); layout != ""; layout = layoutName(chain[len(chain)-1]) {
+		if len(chain) > maxLayoutDepth {
+			return nil, nil, fmt.Errorf("layout chain exceeds %d levels", maxLayoutDepth)
+		}
+		layoutPath, layoutContent, err := r.resolveInclude(current, layout, source)
+		if err != nil {
+			return nil, nil, fmt.Errorf("layout %q: %w", layout, err)
+		}
+		chain = append(chain, layoutContent)
+		includes = append(includes, layoutPath)
+		current = layoutPath
+	}
+
+	// Parse the outermost layout first. An extending template's body contains only
+	// the directive and definitions, so text/template keeps the layout's body and
+	// the later definitions override the layout's blocks.
+	tmpl := template.New(templatePath).Funcs(r.templateFuncs())
+	for i := len(chain) - 1; i >= 0; i-- {
+		if _, err := tmpl.Parse(chain[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Resolve partials until every referenced template is defined
+	for depth := 0; ; depth++ {
+		missing := undefinedTemplates(tmpl)
+		if len(missing) == 0 {
+			break
+		}
+		if depth >= maxPartialDepth {
+			return nil, nil, fmt.Errorf("partials nested more than %d levels: %s", maxPartialDepth, strings.Join(missing, ", "))
+		}
+		for _, name := range missing {
+			partialPath, partial, err := r.resolveInclude(templatePath, name, source)
+			if err != nil {
+				return nil, nil, fmt.Errorf("partial %q: %w", name, err)
+			}
+			if _, err := tmpl.New(name).Parse(partial); err != nil {
+				return nil, nil, fmt.Errorf("partial %q: %w", name, err)
+			}
+			includes = append(includes, partialPath)
+		}
+	}
+
+	return tmpl, includes, nil
+}
+
+// TemplateIncludes returns the paths of the layouts and partials used by a
+// template, resolved the same way as RenderWithTemplate.
+func (r *Reporter) TemplateIncludes(templatePath string) []string {
+	sources := []string{"project"}
+	if r.playbookLoader != nil && strings.Contains(templatePath, "/") {
+		sources = []string{"playbook", "project"}
+	}
+	for _, source := range sources {
+		if _, err := r.loadTemplate(templatePath, source); err == nil {
+			return r.templateIncludes[source+":"+templatePath]
+		}
+	}
+	return nil
+}
+
+// layoutName returns the layout named by a template's extends directive, if any
+func layoutName(content string) string {
+	if m := extendsPattern.FindStringSubmatch(content); m != nil {
+		return strings.TrimSpace(m[1])
+	}
+	return ""
+}
+
+// resolveInclude loads a layout or partial referenced from fromPath and returns
+// the path it was found at together with its content.
+func (r *Reporter) resolveInclude(fromPath, name, source string) (string, string, error) {
+	candidates := includeCandidates(fromPath, name, source)
+	if len(candidates) == 0 {
+		return "", "", fmt.Errorf("invalid name")
+	}
+	for _, candidate := range candidates {
+		if content, err := r.loadContent(candidate, source); err == nil {
+			return candidate, content, nil
+		}
+	}
+	return "", "", fmt.Errorf("not found (searched: %s)", strings.Join(candidates, ", "))
+}
+
+// includeCandidates returns the paths searched for a layout or partial, in order:
+//  1. <template dir>/partials/<name>
+//  2. <template dir>/<name>
+//  3. <playbook>/templates/partials/<name> and <playbook>/partials/<name> (playbook source)
+//  4. <name> as a full path (e.g. "other-playbook/templates/partials/header.md")
+//
+// Names without an extension get ".md" appended.
+func includeCandidates(fromPath, name, source string) []string {
+	file := path.Clean(strings.TrimSpace(name))
+	if file == "." || path.IsAbs(file) || file == ".." || strings.HasPrefix(file, "../") {
+		return nil
+	}
+	if path.Ext(file) == "" {
+		file += ".md"
+	}
+
+	dir := path.Dir(fromPath)
+	candidates := []string{
+		path.Join(dir, partialsDir, file),
+		path.Join(dir, file),
+	}
+	if source == "playbook" {
+		if playbook, _, found := strings.Cut(fromPath, "/"); found {
+			candidates = append(candidates,
+				path.Join(playbook, "templates", partialsDir, file),
+				path.Join(playbook, partialsDir, file),
+			)
+		}
+	}
+	if strings.Contains(file, "/") {
+		candidates = append(candidates, file)
+	}
+
+	// Remove duplicates while keeping the search order
+	seen := make(map[string]bool)
+	unique := candidates[:0]
+	for _, c := range candidates {
+		if !seen[c] {
+			seen[c] = true
+			unique = append(unique, c)
+		}
+	}
+	return unique
+}
+
+// undefinedTemplates returns the sorted names referenced with {{template}} that
+// are not yet defined in the template set
+func undefinedTemplates(tmpl *template.Template) []string {
+	refs := make(map[string]bool)
+	for _, t := range tmpl.Templates() {
+		if t.Tree != nil && t.Tree.Root != nil {
+			collectTemplateRefs(t.Tree.Root, refs)
+		}
+	}
+	var missing []string
+	for name := range refs {
+		if tmpl.Lookup(name) == nil {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// collectTemplateRefs walks a parse tree and records the names of {{template}} calls
+func collectTemplateRefs(node parse.Node, refs map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectTemplateRefs(child, refs)
+		}
+	case *parse.TemplateNode:
+		refs[n.Name] = true
+	case *parse.IfNode:
+		collectTemplateRefs(n.List, refs)
+		collectTemplateRefs(n.ElseList, refs)
+	case *parse.RangeNode:
+		collectTemplateRefs(n.List, refs)
+		collectTemplateRefs(n.ElseList, refs)
+	case *parse.WithNode:
+		collectTemplateRefs(n.List, refs)
+		collectTemplateRefs(n.ElseList, refs)
+	}
+}
@@ -0,0 +1,587 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package reporting
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The built-in PDF writer lays a Markdown report out on A4 pages with the
+// standard PDF fonts, which every viewer provides, so no fonts are embedded.
+// Text outside the fonts' WinAnsi character set is written as '?'. For full
+// typography, configure an HTML-to-PDF command (report_output.pdf_command).
+
+// Page geometry and type sizes, in points
+const (
+	pdfPageWidth   = 595.28
+	pdfPageHeight  = 841.89
+	pdfMargin      = 56.0
+	pdfBodySize    = 10.5
+	pdfBodyLeading = 14.5
+	pdfCodeSize    = 8.5
+	pdfCodeLeading = 11.0
+	pdfTableSize   = 9.0
+	pdfCellPadding = 4.0
+	pdfListIndent  = 16.0
+	pdfQuoteIndent = 12.0
+)
+
+// pdfFont is one of the standard fonts of the writer
+type pdfFont int
+
+const (
+	fontRegular pdfFont = iota
+	fontBold
+	fontItalic
+	fontMono
+)
+
+// pdfFontNames are the base fonts, in pdfFont order
+var pdfFontNames = []string{"Helvetica", "Helvetica-Bold", "Helvetica-Oblique", "Courier"}
+
+// pdfHeadingSizes are the type sizes of heading levels 1 to 6
+var pdfHeadingSizes = []float64{20, 16, 13.5, 12, 11, 10.5}
+
+// Glyph widths of the printable ASCII characters (32 to 126), in thousandths
+// of the type size, from the fonts' metrics
+var (
+	helveticaWidths = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	helveticaBoldWidths = [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
+
+// winAnsi maps the characters of the WinAnsi encoding outside Latin-1 to their codes
+var winAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87,
+	'ˆ': 0x88, '‰': 0x89, 'Š': 0x8A, '‹': 0x8B, 'Œ': 0x8C, 'Ž': 0x8E, '‘': 0x91,
+	'’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98,
+	'™': 0x99, 'š': 0x9A, '›': 0x9B, 'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+// encodeWinAnsi converts text to the WinAnsi encoding of the standard fonts
+func encodeWinAnsi(text string) string {
+	var sb strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\t':
+			sb.WriteString("    ")
+		case r >= 0x20 && r < 0x7F, r >= 0xA0 && r <= 0xFF:
+			sb.WriteByte(byte(r))
+		case winAnsi[r] != 0:
+			sb.WriteByte(winAnsi[r])
+		case r < 0x20:
+			// Control characters are dropped
+		default:
+			sb.WriteByte('?')
+		}
+	}
+	return sb.String()
+}
+
+// textWidth returns the width of WinAnsi text set in a font at a size
+func textWidth(font pdfFont, size float64, text string) float64 {
+	total := 0
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case font == fontMono:
+			total += 600
+		case c < 32 || c > 126:
+			total += 556
+		case font == fontBold:
+			total += helveticaBoldWidths[c-32]
+		default:
+			total += helveticaWidths[c-32]
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// pdfEscape escapes WinAnsi text for a PDF string
+func pdfEscape(text string) string {
+	r := strings.NewReplacer(`\`, `\\`, "(", `\(`, ")", `\)`)
+	return r.Replace(text)
+}
+
+// pdfToken is a word of text in one font. A token not preceded by a space is
+// kept on the same line as the one before it.
+type pdfToken struct {
+	font  pdfFont
+	text  string // WinAnsi
+	space bool   // Preceded by a space
+}
+
+// pdfDoc lays out a document page by page
+type pdfDoc struct {
+	pages []*bytes.Buffer
+	page  *bytes.Buffer
+	y     float64 // Top of the next line
+	gray  float64 // Text color: 0 is black
+}
+
+// RenderPDF lays out a Markdown report as a PDF document with the built-in writer
+func RenderPDF(markdown string) []byte {
+	blocks := parseMarkdown(markdown)
+	d := &pdfDoc{}
+	d.newPage()
+	d.blocks(blocks, pdfMargin, pdfPageWidth-2*pdfMargin)
+	return d.bytes(documentTitle(blocks))
+}
+
+// newPage starts a page
+func (d *pdfDoc) newPage() {
+	d.page = &bytes.Buffer{}
+	d.pages = append(d.pages, d.page)
+	d.y = pdfPageHeight - pdfMargin
+}
+
+// atTop reports whether nothing has been written on the page yet
+func (d *pdfDoc) atTop() bool {
+	return d.y >= pdfPageHeight-pdfMargin
+}
+
+// need starts a new page unless height fits on the current one
+func (d *pdfDoc) need(height float64) {
+	if d.y-height < pdfMargin && !d.atTop() {
+		d.newPage()
+	}
+}
+
+// gap leaves vertical space, except at the top of a page
+func (d *pdfDoc) gap(height float64) {
+	if !d.atTop() {
+		d.y -= height
+	}
+}
+
+// blocks writes blocks in a column starting at x
+func (d *pdfDoc) blocks(blocks []mdBlock, x, width float64) {
+	for _, b := range blocks {
+		switch b.kind {
+		case blockHeading:
+			size := pdfHeadingSizes[b.level-1]
+			d.gap(size * 0.7)
+			// Keep the heading with the first lines that follow it
+			d.need(size*1.3 + 2*pdfBodyLeading)
+			d.text(tokenize(parseInline(b.text), fontBold), x, width, size, size*1.3)
+			if b.level <= 2 {
+				d.rule(x, width, 0.6)
+			}
+			d.y -= size * 0.3
+
+		case blockParagraph:
+			d.text(tokenize(parseInline(b.text), fontRegular), x, width, pdfBodySize, pdfBodyLeading)
+			d.y -= pdfBodyLeading * 0.45
+
+		case blockRule:
+			d.need(12)
+			d.y -= 6
+			d.rule(x, width, 0.8)
+			d.y -= 6
+
+		case blockCode:
+			d.code(b.lines, x, width)
+
+		case blockQuote:
+			d.quote(b.children, x, width)
+
+		case blockList:
+			d.list(b, x, width)
+
+		case blockTable:
+			d.table(b, x, width)
+		}
+	}
+}
+
+// text writes tokens wrapped to width, one line at a time
+func (d *pdfDoc) text(tokens []pdfToken, x, width, size, leading float64) {
+	for _, line := range wrap(tokens, width, size) {
+		d.need(leading)
+		d.line(line, x, size)
+		d.y -= leading
+	}
+}
+
+// line writes one line of tokens with its baseline a type size below the top
+func (d *pdfDoc) line(tokens []pdfToken, x, size float64) {
+	if len(tokens) == 0 {
+		return
+	}
+	if d.gray > 0 {
+		fmt.Fprintf(d.page, "%s g\n", num(d.gray))
+	}
+	fmt.Fprintf(d.page, "BT %s %s Td", num(x), num(d.y-size))
+	for i, t := range tokens {
+		text := t.text
+		if i > 0 && t.space {
+			text = " " + text
+		}
+		fmt.Fprintf(d.page, " /F%d %s Tf (%s) Tj", int(t.font)+1, num(size), pdfEscape(text))
+	}
+	d.page.WriteString(" ET\n")
+	if d.gray > 0 {
+		d.page.WriteString("0 g\n")
+	}
+}
+
+// rule draws a horizontal line at the current position
+func (d *pdfDoc) rule(x, width, lineWidth float64) {
+	d.y -= 2
+	fmt.Fprintf(d.page, "%s w 0.75 G %s %s m %s %s l S 0 G\n", num(lineWidth), num(x), num(d.y), num(x+width), num(d.y))
+	d.y -= 2
+}
+
+// code writes a code block on a shaded background, wrapping long lines
+func (d *pdfDoc) code(lines []string, x, width float64) {
+	perLine := int((width - 2*pdfCellPadding) / (0.6 * pdfCodeSize))
+	if perLine < 1 {
+		perLine = 1
+	}
+	var wrapped []string
+	for _, line := range lines {
+		line = encodeWinAnsi(line)
+		for len(line) > perLine {
+			wrapped = append(wrapped, line[:perLine])
+			line = line[perLine:]
+		}
+		wrapped = append(wrapped, line)
+	}
+
+	d.need(pdfCodeLeading + 2*pdfCellPadding)
+	d.shade(x, width, pdfCellPadding)
+	d.y -= pdfCellPadding
+	for _, line := range wrapped {
+		if d.y-pdfCodeLeading < pdfMargin {
+			d.newPage()
+		}
+		d.shade(x, width, pdfCodeLeading)
+		d.line([]pdfToken{{font: fontMono, text: line}}, x+pdfCellPadding, pdfCodeSize+1)
+		d.y -= pdfCodeLeading
+	}
+	d.shade(x, width, pdfCellPadding)
+	d.y -= pdfCellPadding + pdfBodyLeading*0.6
+}
+
+// shade fills a band of the code background below the current position
+func (d *pdfDoc) shade(x, width, height float64) {
+	fmt.Fprintf(d.page, "0.94 g %s %s %s %s re f 0 g\n", num(x), num(d.y-height), num(width), num(height))
+}
+
+// quote writes a block quote indented in gray with a bar beside it
+func (d *pdfDoc) quote(children []mdBlock, x, width float64) {
+	startPage, startY := len(d.pages)-1, d.y
+	gray := d.gray
+	d.gray = 0.35
+	d.blocks(children, x+pdfQuoteIndent, width-pdfQuoteIndent)
+	d.gray = gray
+
+	// The bar runs down each page the quote is on
+	for p := startPage; p < len(d.pages); p++ {
+		top, bottom := pdfPageHeight-pdfMargin, pdfMargin
+		if p == startPage {
+			top = startY
+		}
+		if p == len(d.pages)-1 {
+			bottom = d.y + pdfBodyLeading*0.45
+		}
+		fmt.Fprintf(d.pages[p], "2 w 0.75 G %s %s m %s %s l S 0 G\n", num(x+3), num(top), num(x+3), num(bottom))
+	}
+}
+
+// list writes a list, each item's marker beside its first line
+func (d *pdfDoc) list(b mdBlock, x, width float64) {
+	for i, item := range b.items {
+		marker := encodeWinAnsi("•")
+		if b.ordered {
+			marker = strconv.Itoa(b.start+i) + "."
+		}
+		d.need(pdfBodyLeading)
+		d.line([]pdfToken{{font: fontRegular, text: marker}}, x+2, pdfBodySize)
+		d.blocks(item, x+pdfListIndent, width-pdfListIndent)
+	}
+	d.y -= pdfBodyLeading * 0.2
+}
+
+// table writes a table with bordered cells, repeating the header row on each page
+func (d *pdfDoc) table(b mdBlock, x, width float64) {
+	columns := len(b.rows[0])
+	leading := pdfTableSize * 1.3
+
+	// Columns get a share of the width in proportion to their widest cell
+	natural := make([]float64, columns)
+	total := 0.0
+	for _, row := range b.rows {
+		for c := 0; c < columns && c < len(row); c++ {
+			w := textWidth(fontRegular, pdfTableSize, encodeWinAnsi(plainText(parseInline(row[c])))) + 2*pdfCellPadding
+			if w > natural[c] {
+				natural[c] = w
+			}
+		}
+	}
+	for c := range natural {
+		if natural[c] < 30 {
+			natural[c] = 30
+		}
+		total += natural[c]
+	}
+	widths := make([]float64, columns)
+	for c := range natural {
+		widths[c] = width * natural[c] / total
+	}
+
+	for r := range b.rows {
+		cells, height := d.tableCells(b, r, widths, leading)
+		if d.y-height < pdfMargin && !d.atTop() {
+			d.newPage()
+			if r > 0 {
+				header, headerHeight := d.tableCells(b, 0, widths, leading)
+				d.drawRow(header, true, x, widths, headerHeight, leading)
+			}
+		}
+		d.drawRow(cells, r == 0, x, widths, height, leading)
+	}
+	d.y -= pdfBodyLeading * 0.6
+}
+
+// tableCells wraps the cells of table row r to their columns and returns them
+// with the height of the row
+func (d *pdfDoc) tableCells(b mdBlock, r int, widths []float64, leading float64) ([][][]pdfToken, float64) {
+	font := fontRegular
+	if r == 0 {
+		font = fontBold
+	}
+	cells := make([][][]pdfToken, len(widths))
+	height := 0.0
+	for c := range widths {
+		text := ""
+		if c < len(b.rows[r]) {
+			text = b.rows[r][c]
+		}
+		cells[c] = wrap(tokenize(parseInline(text), font), widths[c]-2*pdfCellPadding, pdfTableSize)
+		if h := float64(len(cells[c]))*leading + 2*pdfCellPadding; h > height {
+			height = h
+		}
+	}
+	return cells, height
+}
+
+// drawRow draws the cells of a table row at the current position
+func (d *pdfDoc) drawRow(cells [][][]pdfToken, header bool, x float64, widths []float64, height, leading float64) {
+	top := d.y
+	cx := x
+	for c, lines := range cells {
+		if header {
+			fmt.Fprintf(d.page, "0.94 g %s %s %s %s re f 0 g\n", num(cx), num(top-height), num(widths[c]), num(height))
+		}
+		fmt.Fprintf(d.page, "0.5 w 0.7 G %s %s %s %s re S 0 G\n", num(cx), num(top-height), num(widths[c]), num(height))
+		d.y = top - pdfCellPadding
+		for _, line := range lines {
+			d.line(line, cx+pdfCellPadding, pdfTableSize)
+			d.y -= leading
+		}
+		cx += widths[c]
+	}
+	d.y = top - height
+}
+
+// tokenize splits styled spans into words in the font of their style. A link
+// is followed by its target when the two differ.
+func tokenize(spans []mdSpan, base pdfFont) []pdfToken {
+	var tokens []pdfToken
+	space := false
+	for i, span := range spans {
+		font := base
+		switch {
+		case span.code:
+			font = fontMono
+		case span.bold:
+			font = fontBold
+		case span.italic && base != fontBold:
+			font = fontItalic
+		}
+		var word strings.Builder
+		emit := func() {
+			if word.Len() > 0 {
+				tokens = append(tokens, pdfToken{font: font, text: word.String(), space: space})
+				word.Reset()
+				space = false
+			}
+		}
+		text := encodeWinAnsi(span.text)
+		for k := 0; k < len(text); k++ {
+			if text[k] == ' ' || text[k] == '\n' {
+				emit()
+				space = len(tokens) > 0
+				continue
+			}
+			word.WriteByte(text[k])
+		}
+		emit()
+
+		// Write the target after the last span of a link
+		if span.href != "" && (i+1 == len(spans) || spans[i+1].href != span.href) {
+			label := ""
+			for j := i; j >= 0 && spans[j].href == span.href; j-- {
+				label = spans[j].text + label
+			}
+			if strings.TrimSpace(label) != span.href {
+				tokens = append(tokens, pdfToken{font: base, text: "(" + encodeWinAnsi(span.href) + ")", space: true})
+			}
+		}
+	}
+	return tokens
+}
+
+// wrap breaks tokens into lines no wider than width. Tokens not separated by a
+// space stay together, and a word wider than a line is split.
+func wrap(tokens []pdfToken, width, size float64) [][]pdfToken {
+	var lines [][]pdfToken
+	var line []pdfToken
+	lineWidth := 0.0
+	spaceWidth := textWidth(fontRegular, size, " ")
+
+	for i := 0; i < len(tokens); {
+		// A group is a token and those joined to it without a space
+		j := i + 1
+		for j < len(tokens) && !tokens[j].space {
+			j++
+		}
+		group := tokens[i:j]
+		groupWidth := 0.0
+		for _, t := range group {
+			groupWidth += textWidth(t.font, size, t.text)
+		}
+
+		gap := 0.0
+		if len(line) > 0 {
+			gap = spaceWidth
+		}
+		switch {
+		case lineWidth+gap+groupWidth <= width:
+			line = append(line, group...)
+			lineWidth += gap + groupWidth
+		case len(line) > 0:
+			lines = append(lines, line)
+			line, lineWidth = nil, 0
+			continue
+		default:
+			// Wider than a line: split it
+			for _, t := range group {
+				for t.text != "" {
+					n := fitChars(t, width-lineWidth, size)
+					if n == 0 {
+						if len(line) > 0 {
+							lines = append(lines, line)
+							line, lineWidth = nil, 0
+							continue
+						}
+						n = 1
+					}
+					part := pdfToken{font: t.font, text: t.text[:n], space: t.space && len(line) > 0}
+					line = append(line, part)
+					lineWidth += textWidth(t.font, size, part.text)
+					t.text, t.space = t.text[n:], false
+					if t.text != "" {
+						lines = append(lines, line)
+						line, lineWidth = nil, 0
+					}
+				}
+			}
+		}
+		i = j
+	}
+	if len(line) > 0 {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// fitChars returns how many leading characters of a token fit in width
+func fitChars(t pdfToken, width, size float64) int {
+	n := 0
+	for n < len(t.text) && textWidth(t.font, size, t.text[:n+1]) <= width {
+		n++
+	}
+	return n
+}
+
+// bytes assembles the pages into a PDF file, numbering the pages in the footer
+func (d *pdfDoc) bytes(title string) []byte {
+	const fontObjects = 3 // Objects 3 to 6 are the fonts
+	infoObject := fontObjects + len(pdfFontNames)
+	firstPage := infoObject + 1
+
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i+1)
+	}
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	var fonts []string
+	for i, name := range pdfFontNames {
+		object(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", name))
+		fonts = append(fonts, fmt.Sprintf("/F%d %d 0 R", i+1, fontObjects+i))
+	}
+	object(fmt.Sprintf("<< /Title (%s) /Producer (Maestro) >>", pdfEscape(encodeWinAnsi(title))))
+
+	for i, page := range d.pages {
+		footer := encodeWinAnsi(fmt.Sprintf("Page %d of %d", i+1, len(d.pages)))
+		fmt.Fprintf(page, "0.4 g BT %s %s Td /F1 8 Tf (%s) Tj ET 0 g\n",
+			num(pdfPageWidth-pdfMargin-textWidth(fontRegular, 8, footer)), num(pdfMargin/2), footer)
+
+		var stream bytes.Buffer
+		zw := zlib.NewWriter(&stream)
+		_, _ = zw.Write(page.Bytes())
+		_ = zw.Close()
+		object(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", stream.Len(), stream.String()))
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << %s >> >> /Contents %d 0 R >>",
+			num(pdfPageWidth), num(pdfPageHeight), strings.Join(fonts, " "), len(offsets)))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, infoObject, xref)
+	return out.Bytes()
+}
+
+// num formats a number for a content stream
+func num(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 2, 64)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "" || s == "-" {
+		return "0"
+	}
+	return s
+}
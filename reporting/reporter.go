@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"text/template"
@@ -36,13 +37,24 @@ func (f ContentLoaderFunc) GetContent(path string) (string, error) {
 
 // Reporter generates reports from task results
 type Reporter struct {
-	logger          *logging.Logger
-	projectLoader   ContentLoader
-	playbookLoader  ContentLoader // accepts paths in format "playbook-name/path/to/file"
-	referenceLoader ContentLoader
-	templateCache   map[string]*template.Template
+	logger           *logging.Logger
+	projectLoader    ContentLoader
+	playbookLoader   ContentLoader // accepts paths in format "playbook-name/path/to/file"
+	referenceLoader  ContentLoader
+	templateCache    map[string]*template.Template
+	templateIncludes map[string][]string // Layouts and partials loaded per cached template
+	resultLocator    ResultLocator
+	language         global.ReportLanguage     // Confidence phrase mappings (keys lowercased)
+	attribution      global.ReportAttribution  // AI-disclosure marker added to rendered LLM output
+	sanitization     global.OutputSanitization // Terminal noise removed from results before rendering
+	clock            *global.Clock             // Timezone and formats for dates and times in reports
+	output           global.ReportOutput       // Theme and PDF converter of HTML and PDF renderings
 }
 
+// ResultLocator returns the result file path for a task in a task set.
+// Without a locator, results are read from <resultsDir>/<uuid>.json.
+type ResultLocator func(project, path string, task *global.Task) string
+
 // Option configures a Reporter
 type Option func(*Reporter)
 
@@ -61,6 +73,53 @@ func WithPlaybookLoader(loader ContentLoader) Option {
 	}
 }
 
+// WithResultLocator sets how BuildReport finds result files
+func WithResultLocator(locator ResultLocator) Option {
+	return func(r *Reporter) {
+		r.resultLocator = locator
+	}
+}
+
+// WithReportLanguage sets the confidence phrase mappings exposed to templates
+// as _confidence, _phrase and the phrase function
+func WithReportLanguage(language global.ReportLanguage) Option {
+	return func(r *Reporter) {
+		r.language = normalizeLanguage(language)
+	}
+}
+
+// WithAttribution sets the inline AI-disclosure marker that, when enabled, is
+// appended to every worker and QA result rendered into a report
+func WithAttribution(attribution global.ReportAttribution) Option {
+	return func(r *Reporter) {
+		r.attribution = attribution.WithDefaults()
+	}
+}
+
+// WithSanitization sets the cleanup applied to worker and QA results loaded for
+// a report, so terminal noise in results stored by older versions or a host
+// dispatcher does not reach the rendered output
+func WithSanitization(sanitization global.OutputSanitization) Option {
+	return func(r *Reporter) {
+		r.sanitization = sanitization
+	}
+}
+
+// WithClock sets the timezone and formats used for the dates and times written
+// into reports, including the timestamp and date template functions
+func WithClock(clock *global.Clock) Option {
+	return func(r *Reporter) {
+		r.clock = clock
+	}
+}
+
+// WithOutput sets the theme and PDF converter used to render reports as HTML and PDF
+func WithOutput(output global.ReportOutput) Option {
+	return func(r *Reporter) {
+		r.output = output.WithDefaults()
+	}
+}
+
 // WithReferenceLoader sets the reference content loader
 func WithReferenceLoader(loader ContentLoader) Option {
 	return func(r *Reporter) {
@@ -71,8 +130,10 @@ func WithReferenceLoader(loader ContentLoader) Option {
 // New creates a new Reporter
 func New(logger *logging.Logger, opts ...Option) *Reporter {
 	r := &Reporter{
-		logger:        logger,
-		templateCache: make(map[string]*template.Template),
+		logger:           logger,
+		templateCache:    make(map[string]*template.Template),
+		templateIncludes: make(map[string][]string),
+		language:         normalizeLanguage(global.ReportLanguage{}),
 	}
 
 	for _, opt := range opts {
@@ -82,56 +143,61 @@ func New(logger *logging.Logger, opts ...Option) *Reporter {
 	return r
 }
 
-// loadTemplate loads and parses a template from the specified source
+// loadTemplate loads and parses a template from the specified source.
+// Layouts named by an extends directive and partials referenced with
+// {{template "name" .}} are resolved from the same source.
 func (r *Reporter) loadTemplate(templatePath, source string) (*template.Template, error) {
 	cacheKey := source + ":" + templatePath
 	if tmpl, ok := r.templateCache[cacheKey]; ok {
 		return tmpl, nil
 	}
 
-	var content string
-	var err error
+	content, err := r.loadContent(templatePath, source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load template: %w", err)
+	}
+
+	tmpl, includes, err := r.parseTemplate(templatePath, source, content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
 
+	r.templateCache[cacheKey] = tmpl
+	r.templateIncludes[cacheKey] = includes
+	return tmpl, nil
+}
+
+// loadContent loads raw content from the specified source
+func (r *Reporter) loadContent(path, source string) (string, error) {
 	switch source {
 	case "project":
 		if r.projectLoader == nil {
-			return nil, fmt.Errorf("project loader not configured")
+			return "", fmt.Errorf("project loader not configured")
 		}
-		content, err = r.projectLoader.GetContent(templatePath)
+		return r.projectLoader.GetContent(path)
 	case "playbook":
 		// Path format: "playbook-name/path/to/template.md"
 		if r.playbookLoader == nil {
-			return nil, fmt.Errorf("playbook loader not configured")
+			return "", fmt.Errorf("playbook loader not configured")
 		}
-		content, err = r.playbookLoader.GetContent(templatePath)
+		return r.playbookLoader.GetContent(path)
 	case "reference":
 		if r.referenceLoader == nil {
-			return nil, fmt.Errorf("reference loader not configured")
+			return "", fmt.Errorf("reference loader not configured")
 		}
-		content, err = r.referenceLoader.GetContent(templatePath)
+		return r.referenceLoader.GetContent(path)
 	default:
-		return nil, fmt.Errorf("unknown template source: %s", source)
+		return "", fmt.Errorf("unknown template source: %s", source)
 	}
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to load template: %w", err)
-	}
-
-	tmpl, err := template.New(templatePath).Funcs(templateFuncs()).Parse(content)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse template: %w", err)
-	}
-
-	r.templateCache[cacheKey] = tmpl
-	return tmpl, nil
 }
 
 // templateFuncs returns custom template functions
-func templateFuncs() template.FuncMap {
-	return template.FuncMap{
-		"upper": strings.ToUpper,
-		"lower": strings.ToLower,
-		"title": strings.Title,
+func (r *Reporter) templateFuncs() template.FuncMap {
+	funcs := template.FuncMap{
+		"phrase": r.phrase,
+		"upper":  strings.ToUpper,
+		"lower":  strings.ToLower,
+		"title":  strings.Title,
 		"json": func(v interface{}) string {
 			data, err := json.MarshalIndent(v, "", "  ")
 			if err != nil {
@@ -139,6 +205,35 @@ func templateFuncs() template.FuncMap {
 			}
 			return string(data)
 		},
+		"timestamp": func(v interface{}) string { return formatTime(v, r.clock.Report) },
+		"date":      func(v interface{}) string { return formatTime(v, r.clock.Date) },
+	}
+	for name, fn := range r.libraryFuncs() {
+		funcs[name] = fn
+	}
+	return funcs
+}
+
+// formatTime formats a time for the timestamp and date template functions. Result
+// fields hold times as RFC 3339 strings; other values are rendered unchanged.
+func formatTime(v interface{}, format func(time.Time) string) string {
+	switch t := v.(type) {
+	case time.Time:
+		return format(t)
+	case *time.Time:
+		if t == nil {
+			return ""
+		}
+		return format(*t)
+	case string:
+		if parsed, err := time.Parse(time.RFC3339, t); err == nil {
+			return format(parsed)
+		}
+		return t
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
 	}
 }
 
@@ -209,13 +304,22 @@ func (r *Reporter) loadTemplateManifest(manifestPath string) []global.ReportTemp
 		return nil
 	}
 
-	// Resolve relative paths - files are relative to the manifest location
+	// Resolve relative paths - files and themes are relative to the manifest location
 	manifestDir := filepath.Dir(manifestPath)
 	for i := range configs {
 		if !strings.HasPrefix(configs[i].File, "/") && !strings.Contains(configs[i].File, "/") {
 			// Relative path - prepend manifest directory
 			configs[i].File = filepath.Join(manifestDir, configs[i].File)
 		}
+		if configs[i].Aggregate != "" && !strings.Contains(configs[i].Aggregate, "/") {
+			configs[i].Aggregate = filepath.Join(manifestDir, configs[i].Aggregate)
+		}
+		if configs[i].Theme != "" && !strings.Contains(configs[i].Theme, "/") {
+			configs[i].Theme = filepath.Join(manifestDir, configs[i].Theme)
+		}
+		if err := global.ValidateReportFormats(configs[i].Formats); err != nil && r.logger != nil {
+			r.logger.Warnf("Template manifest %s, report %s: %v", manifestPath, configs[i].Suffix, err)
+		}
 	}
 
 	return configs
@@ -226,7 +330,13 @@ func (r *Reporter) loadTemplateManifest(manifestPath string) []global.ReportTemp
 // - Paths in format "playbook-name/path/file.md" are loaded from playbooks
 // - Other paths are loaded from the project
 // If playbook loading fails, it falls back to project loading.
+// When attribution is enabled, the marker is appended to any non-empty output.
 func (r *Reporter) RenderWithTemplate(task TaskReport, templatePath string) string {
+	return r.attribute(r.renderWork(task, templatePath), task.LLMModelID, task.CompletedAt)
+}
+
+// renderWork renders a task result without the attribution marker
+func (r *Reporter) renderWork(task TaskReport, templatePath string) string {
 	if templatePath == "" {
 		return task.WorkResult
 	}
@@ -245,8 +355,13 @@ func (r *Reporter) RenderWithTemplate(task TaskReport, templatePath string) stri
 }
 
 // RenderQAWithTemplate renders a QA result using the configured QA template
-// It follows the same path resolution as RenderWithTemplate.
+// It follows the same path resolution and attribution as RenderWithTemplate.
 func (r *Reporter) RenderQAWithTemplate(task TaskReport, templatePath string) string {
+	return r.attribute(r.renderQA(task, templatePath), task.QALLMModelID, task.CompletedAt)
+}
+
+// renderQA renders a QA result without the attribution marker
+func (r *Reporter) renderQA(task TaskReport, templatePath string) string {
 	if templatePath == "" || task.QAResult == "" {
 		return task.QAResult
 	}
@@ -270,8 +385,8 @@ func (r *Reporter) renderQAResult(task TaskReport, templatePath, templateSource
 	}
 
 	// Try to parse the QA result as JSON for template data
-	var data map[string]interface{}
-	if err := json.Unmarshal([]byte(task.QAResult), &data); err != nil {
+	data, err := r.qaTemplateData(task)
+	if err != nil {
 		// Not valid JSON, return raw result
 		if r.logger != nil {
 			r.logger.Debugf("Task %d: QA result is not JSON, using raw output", task.ID)
@@ -279,13 +394,6 @@ func (r *Reporter) renderQAResult(task TaskReport, templatePath, templateSource
 		return task.QAResult
 	}
 
-	// Add task metadata to the data for templates that need it
-	data["_task_id"] = task.ID
-	data["_task_title"] = task.Title
-	data["_task_type"] = task.Type
-	data["_task_status"] = task.WorkStatus
-	data["_qa_verdict"] = task.QAVerdict
-
 	// Load and execute template
 	tmpl, err := r.loadTemplate(templatePath, templateSource)
 	if err != nil {
@@ -306,29 +414,100 @@ func (r *Reporter) renderQAResult(task TaskReport, templatePath, templateSource
 	return buf.String()
 }
 
-// renderTaskResult renders a task result using its template or returns raw result
-func (r *Reporter) renderTaskResult(task TaskReport, templatePath, templateSource string) string {
-	// If no template specified, return raw result
-	if templatePath == "" {
-		return task.WorkResult
+// attribute appends the AI-disclosure marker to rendered LLM output when
+// attribution is enabled. Empty output is left empty so it can still be skipped.
+func (r *Reporter) attribute(content, model string, completedAt *time.Time) string {
+	if !r.attribution.Enabled || strings.TrimSpace(content) == "" {
+		return content
 	}
-
-	// Try to parse the work result as JSON for template data
-	var data map[string]interface{}
-	if err := json.Unmarshal([]byte(task.WorkResult), &data); err != nil {
-		// Not valid JSON, return raw result
-		if r.logger != nil {
-			r.logger.Debugf("Task %d: Work result is not JSON, using raw output", task.ID)
-		}
-		return task.WorkResult
+	if model == "" {
+		model = "unknown model"
 	}
+	date := time.Now()
+	if completedAt != nil && !completedAt.IsZero() {
+		date = *completedAt
+	}
+	marker := strings.NewReplacer("{model}", model, "{date}", r.clock.Date(date)).Replace(r.attribution.Format)
+	return strings.TrimRight(content, "\n") + "\n\n" + marker + "\n"
+}
 
-	// Add task metadata to the data for templates that need it
+// addTaskMetadata adds the task metadata fields available to every template
+func addTaskMetadata(data map[string]interface{}, task TaskReport) {
 	data["_task_id"] = task.ID
 	data["_task_title"] = task.Title
 	data["_task_type"] = task.Type
 	data["_task_status"] = task.WorkStatus
 	data["_qa_verdict"] = task.QAVerdict
+	data["_qa_severity"] = task.QASeverity
+	data["_variant"] = task.Variant
+	data["_pii_review"] = len(task.PIITypes) > 0
+	data["_pii_types"] = task.PIITypes
+	data["_supervisor_edited"] = task.SupervisorEdited
+	data["_supervisor_fields"] = task.SupervisorFields
+}
+
+// supervisorEditNote describes a supervisor's edit of a task for a report: the
+// fields changed, or "yes" when the result is not JSON
+func supervisorEditNote(task TaskReport) string {
+	if len(task.SupervisorFields) == 0 {
+		return "yes"
+	}
+	return strings.Join(task.SupervisorFields, ", ")
+}
+
+// addConfidencePhrase adds the result's confidence (_confidence) and the phrase
+// it maps to (_phrase), so templates can word findings by how well they were verified
+func (r *Reporter) addConfidencePhrase(data map[string]interface{}) {
+	confidence := ""
+	if value, ok := data[r.language.ConfidenceField]; ok && value != nil {
+		confidence = normalizeConfidence(value)
+	}
+	data["_confidence"] = confidence
+	data["_phrase"] = r.phrase(confidence)
+}
+
+// phrase returns the phrase configured for a confidence value, or the default
+// phrase when the value is missing or unmapped. Exposed to templates as
+// {{phrase .confidence}} for findings nested inside a result.
+func (r *Reporter) phrase(confidence interface{}) string {
+	if confidence == nil {
+		return r.language.DefaultPhrase
+	}
+	if phrase, ok := r.language.Phrases[normalizeConfidence(confidence)]; ok {
+		return phrase
+	}
+	return r.language.DefaultPhrase
+}
+
+// normalizeConfidence returns a confidence value as a lowercased, trimmed string
+func normalizeConfidence(value interface{}) string {
+	return strings.ToLower(strings.TrimSpace(fmt.Sprint(value)))
+}
+
+// normalizeLanguage applies defaults to report language settings and lowercases
+// the phrase keys so lookups are case-insensitive
+func normalizeLanguage(language global.ReportLanguage) global.ReportLanguage {
+	language = language.WithDefaults()
+	phrases := make(map[string]string, len(language.Phrases))
+	for value, phrase := range language.Phrases {
+		phrases[normalizeConfidence(value)] = phrase
+	}
+	language.Phrases = phrases
+	return language
+}
+
+// workTemplateData builds the template context for a worker result: the parsed
+// JSON response plus task metadata, the confidence phrase and the parsed QA
+// result (as _qa_result).
+func (r *Reporter) workTemplateData(task TaskReport) (map[string]interface{}, error) {
+	data, err := parseResultObject(task.WorkResult)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add task metadata to the data for templates that need it
+	addTaskMetadata(data, task)
+	r.addConfidencePhrase(data)
 
 	// Add QA result as parsed JSON for template access
 	if task.QAResult != "" {
@@ -338,6 +517,52 @@ func (r *Reporter) renderTaskResult(task TaskReport, templatePath, templateSourc
 		}
 	}
 
+	return data, nil
+}
+
+// parseResultObject parses an LLM result as a JSON object. A bare null decodes
+// without error but leaves no object to add task metadata to, so it is rejected.
+func parseResultObject(result string) (map[string]interface{}, error) {
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(result), &data); err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, fmt.Errorf("result is null, not a JSON object")
+	}
+	return data, nil
+}
+
+// qaTemplateData builds the template context for a QA result
+func (r *Reporter) qaTemplateData(task TaskReport) (map[string]interface{}, error) {
+	data, err := parseResultObject(task.QAResult)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add task metadata to the data for templates that need it
+	addTaskMetadata(data, task)
+	r.addConfidencePhrase(data)
+	return data, nil
+}
+
+// renderTaskResult renders a task result using its template or returns raw result
+func (r *Reporter) renderTaskResult(task TaskReport, templatePath, templateSource string) string {
+	// If no template specified, return raw result
+	if templatePath == "" {
+		return task.WorkResult
+	}
+
+	// Try to parse the work result as JSON for template data
+	data, err := r.workTemplateData(task)
+	if err != nil {
+		// Not valid JSON, return raw result
+		if r.logger != nil {
+			r.logger.Debugf("Task %d: Work result is not JSON, using raw output", task.ID)
+		}
+		return task.WorkResult
+	}
+
 	// Load and execute template
 	tmpl, err := r.loadTemplate(templatePath, templateSource)
 	if err != nil {
@@ -369,15 +594,64 @@ type ProjectReport struct {
 
 // ReportSummary contains aggregate statistics
 type ReportSummary struct {
-	TotalTasks       int            `json:"total_tasks"`
-	CompletedTasks   int            `json:"completed_tasks"`
-	FailedTasks      int            `json:"failed_tasks"`
-	PendingTasks     int            `json:"pending_tasks"`
-	QAPassedTasks    int            `json:"qa_passed_tasks"`
-	QAFailedTasks    int            `json:"qa_failed_tasks"`
-	QAEscalatedTasks int            `json:"qa_escalated_tasks"`
-	ByVerdict        map[string]int `json:"by_verdict,omitempty"`
-	ByType           map[string]int `json:"by_type,omitempty"`
+	TotalTasks            int            `json:"total_tasks"`
+	CompletedTasks        int            `json:"completed_tasks"`
+	FailedTasks           int            `json:"failed_tasks"`
+	PendingTasks          int            `json:"pending_tasks"`
+	OnHoldTasks           int            `json:"on_hold_tasks"`
+	AwaitingApprovalTasks int            `json:"awaiting_approval_tasks,omitempty"` // QA results held for human sign-off
+	QAPassedTasks         int            `json:"qa_passed_tasks"`
+	QAFailedTasks         int            `json:"qa_failed_tasks"`
+	QAEscalatedTasks      int            `json:"qa_escalated_tasks"`
+	ByVerdict             map[string]int `json:"by_verdict,omitempty"`
+	ByQASeverity          map[string]int `json:"by_qa_severity,omitempty"` // QA severity of tasks QA rated
+	ByType                map[string]int `json:"by_type,omitempty"`
+	PIIReviewTasks        int            `json:"pii_review_tasks,omitempty"`        // Tasks whose results contain possible PII
+	SupervisorEditedTasks int            `json:"supervisor_edited_tasks,omitempty"` // Tasks whose work result a supervisor changed
+}
+
+// add counts a task in the summary
+func (s *ReportSummary) add(task TaskReport) {
+	s.TotalTasks++
+	if len(task.PIITypes) > 0 {
+		s.PIIReviewTasks++
+	}
+	if task.SupervisorEdited {
+		s.SupervisorEditedTasks++
+	}
+	s.ByType[task.Type]++
+
+	switch task.WorkStatus {
+	case global.ExecutionStatusDone:
+		s.CompletedTasks++
+	case global.ExecutionStatusFailed:
+		s.FailedTasks++
+	case global.ExecutionStatusOnHold:
+		s.OnHoldTasks++
+	case global.ExecutionStatusAwaitingApproval:
+		s.AwaitingApprovalTasks++
+	default:
+		s.PendingTasks++
+	}
+
+	// QA verdicts are only set on tasks with QA enabled
+	if task.QAVerdict != "" {
+		s.ByVerdict[task.QAVerdict]++
+		switch task.QAVerdict {
+		case global.QAVerdictPass:
+			s.QAPassedTasks++
+		case global.QAVerdictFail:
+			s.QAFailedTasks++
+		case global.QAVerdictEscalate:
+			s.QAEscalatedTasks++
+		}
+	}
+	if task.QASeverity != "" {
+		if s.ByQASeverity == nil {
+			s.ByQASeverity = make(map[string]int)
+		}
+		s.ByQASeverity[task.QASeverity]++
+	}
 }
 
 // TaskSetReport represents a task set in the report
@@ -392,18 +666,28 @@ type TaskSetReport struct {
 
 // TaskReport represents a task in the report
 type TaskReport struct {
-	ID          int        `json:"id"`
-	UUID        string     `json:"uuid"`
-	Title       string     `json:"title"`
-	Type        string     `json:"type"`
-	WorkStatus  string     `json:"work_status"`
-	WorkResult  string     `json:"work_result,omitempty"`
-	QAEnabled   bool       `json:"qa_enabled"`
-	QAVerdict   string     `json:"qa_verdict,omitempty"` // "pass", "fail", "escalate"
-	QAFeedback  string     `json:"qa_feedback,omitempty"`
-	QAIssues    []string   `json:"qa_issues,omitempty"`
-	QAResult    string     `json:"qa_result,omitempty"`
-	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ID           int        `json:"id"`
+	UUID         string     `json:"uuid"`
+	ExternalID   string     `json:"external_id,omitempty"`
+	Title        string     `json:"title"`
+	Type         string     `json:"type"`
+	WorkStatus   string     `json:"work_status"`
+	HoldReason   string     `json:"hold_reason,omitempty"`
+	WorkResult   string     `json:"work_result,omitempty"`
+	LLMModelID   string     `json:"llm_model_id,omitempty"` // LLM that produced the work result
+	QAEnabled    bool       `json:"qa_enabled"`
+	QAVerdict    string     `json:"qa_verdict,omitempty"`  // "pass", "fail", "escalate"
+	QASeverity   string     `json:"qa_severity,omitempty"` // "critical", "high", "medium", "low"
+	QAFeedback   string     `json:"qa_feedback,omitempty"`
+	QAIssues     []string   `json:"qa_issues,omitempty"`
+	QAResult     string     `json:"qa_result,omitempty"`
+	QALLMModelID string     `json:"qa_llm_model_id,omitempty"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+	Variant      string     `json:"variant,omitempty"`   // Report variant being rendered, exposed to templates as _variant
+	PIITypes     []string   `json:"pii_types,omitempty"` // Identifier types found in the results; the section needs redaction review
+
+	SupervisorEdited bool     `json:"supervisor_edited,omitempty"` // A supervisor replaced or patched the work result
+	SupervisorFields []string `json:"supervisor_fields,omitempty"` // JSON fields of the work result a supervisor changed
 }
 
 // ReportFilter specifies filters for report generation
@@ -411,6 +695,7 @@ type ReportFilter struct {
 	PathPrefix   string   // Filter by task set path prefix
 	StatusFilter string   // Filter by work status (done, failed, etc.)
 	QAVerdict    string   // Filter by QA verdict (pass, fail, escalate)
+	QASeverity   string   // Filter by minimum QA severity (critical, high, medium, low)
 	Types        []string // Filter by task types
 }
 
@@ -419,7 +704,7 @@ type ReportFilter struct {
 func (r *Reporter) BuildReport(project string, taskSets []*global.TaskSet, filter *ReportFilter, resultsDir string) *ProjectReport {
 	report := &ProjectReport{
 		Project:     project,
-		GeneratedAt: time.Now(),
+		GeneratedAt: r.clock.Now(),
 		Summary: ReportSummary{
 			ByVerdict: make(map[string]int),
 			ByType:    make(map[string]int),
@@ -457,6 +742,13 @@ func (r *Reporter) BuildReport(project string, taskSets []*global.TaskSet, filte
 				}
 			}
 
+			// Apply QA severity filter: only tasks QA rated at least this severe
+			if filter != nil && filter.QASeverity != "" {
+				if !global.QASeverityAtLeast(task.QA.Severity, filter.QASeverity) {
+					continue
+				}
+			}
+
 			// Apply type filter
 			if filter != nil && len(filter.Types) > 0 {
 				found := false
@@ -474,21 +766,40 @@ func (r *Reporter) BuildReport(project string, taskSets []*global.TaskSet, filte
 			taskReport := TaskReport{
 				ID:         task.ID,
 				UUID:       task.UUID,
+				ExternalID: task.ExternalID,
 				Title:      task.Title,
 				Type:       task.Type,
 				WorkStatus: task.Work.Status,
+				HoldReason: task.Work.HoldReason,
 				QAEnabled:  task.QA.Enabled,
 			}
 
 			// Load results from results file if available
 			if resultsDir != "" && (task.Work.Status == global.ExecutionStatusDone || task.Work.Status == global.ExecutionStatusFailed) {
-				resultPath := filepath.Join(resultsDir, task.UUID+".json")
+				resultPath := filepath.Join(resultsDir, task.UUID+global.ResultFileSuffix)
+				if r.resultLocator != nil {
+					resultPath = r.resultLocator(project, ts.Path, &task)
+				}
 				if data, err := os.ReadFile(resultPath); err == nil {
 					var result global.TaskResult
 					if err := json.Unmarshal(data, &result); err == nil {
-						taskReport.WorkResult = result.Worker.Response
+						taskReport.WorkResult = r.sanitization.Apply(result.Worker.Response)
+						taskReport.LLMModelID = result.Worker.LLMModelID
+						taskReport.PIITypes = result.Worker.PII.Types()
+						taskReport.SupervisorEdited = result.SupervisorOverride
+						taskReport.SupervisorFields = result.SupervisorEditedFields()
 						if result.QA != nil {
-							taskReport.QAResult = result.QA.Response
+							taskReport.QAResult = r.sanitization.Apply(result.QA.Response)
+							taskReport.QALLMModelID = result.QA.LLMModelID
+							for _, t := range result.QA.PII.Types() {
+								if !slices.Contains(taskReport.PIITypes, t) {
+									taskReport.PIITypes = append(taskReport.PIITypes, t)
+								}
+							}
+						}
+						if !result.CompletedAt.IsZero() {
+							completedAt := result.CompletedAt
+							taskReport.CompletedAt = &completedAt
 						}
 					}
 				}
@@ -496,6 +807,7 @@ func (r *Reporter) BuildReport(project string, taskSets []*global.TaskSet, filte
 
 			if task.QA.Enabled {
 				taskReport.QAVerdict = task.QA.Verdict
+				taskReport.QASeverity = task.QA.Severity
 				// Extract feedback/notes/comments and issues from QA result if loaded
 				if taskReport.QAResult != "" {
 					var qaResult struct {
@@ -519,32 +831,7 @@ func (r *Reporter) BuildReport(project string, taskSets []*global.TaskSet, filte
 			}
 
 			taskSetReport.Tasks = append(taskSetReport.Tasks, taskReport)
-
-			// Update summary
-			report.Summary.TotalTasks++
-			report.Summary.ByType[task.Type]++
-
-			switch task.Work.Status {
-			case global.ExecutionStatusDone:
-				report.Summary.CompletedTasks++
-			case global.ExecutionStatusFailed:
-				report.Summary.FailedTasks++
-			default:
-				report.Summary.PendingTasks++
-			}
-
-			// Update QA verdict counts
-			if task.QA.Enabled && task.QA.Verdict != "" {
-				report.Summary.ByVerdict[task.QA.Verdict]++
-				switch task.QA.Verdict {
-				case global.QAVerdictPass:
-					report.Summary.QAPassedTasks++
-				case global.QAVerdictFail:
-					report.Summary.QAFailedTasks++
-				case global.QAVerdictEscalate:
-					report.Summary.QAEscalatedTasks++
-				}
-			}
+			report.Summary.add(taskReport)
 		}
 
 		if len(taskSetReport.Tasks) > 0 {
@@ -555,11 +842,129 @@ func (r *Reporter) BuildReport(project string, taskSets []*global.TaskSet, filte
 	return report
 }
 
+// GenerateSummaryMarkdown renders summary statistics as a markdown section,
+// used for report manifest entries with include_summary set
+func GenerateSummaryMarkdown(summary ReportSummary) string {
+	var sb strings.Builder
+	sb.WriteString("## Summary\n\n")
+	sb.WriteString("| Metric | Count |\n")
+	sb.WriteString("|--------|-------|\n")
+	sb.WriteString(fmt.Sprintf("| Total Tasks | %d |\n", summary.TotalTasks))
+	sb.WriteString(fmt.Sprintf("| Completed | %d |\n", summary.CompletedTasks))
+	sb.WriteString(fmt.Sprintf("| Failed | %d |\n", summary.FailedTasks))
+	sb.WriteString(fmt.Sprintf("| Pending | %d |\n", summary.PendingTasks))
+	if summary.OnHoldTasks > 0 {
+		sb.WriteString(fmt.Sprintf("| On Hold | %d |\n", summary.OnHoldTasks))
+	}
+	if summary.AwaitingApprovalTasks > 0 {
+		sb.WriteString(fmt.Sprintf("| Awaiting Approval | %d |\n", summary.AwaitingApprovalTasks))
+	}
+	if summary.QAPassedTasks > 0 {
+		sb.WriteString(fmt.Sprintf("| QA Passed | %d |\n", summary.QAPassedTasks))
+	}
+	if summary.QAFailedTasks > 0 {
+		sb.WriteString(fmt.Sprintf("| QA Failed | %d |\n", summary.QAFailedTasks))
+	}
+	if summary.QAEscalatedTasks > 0 {
+		sb.WriteString(fmt.Sprintf("| QA Escalated | %d |\n", summary.QAEscalatedTasks))
+	}
+	if summary.PIIReviewTasks > 0 {
+		sb.WriteString(fmt.Sprintf("| PII Review Required | %d |\n", summary.PIIReviewTasks))
+	}
+	if summary.SupervisorEditedTasks > 0 {
+		sb.WriteString(fmt.Sprintf("| Edited by Supervisor | %d |\n", summary.SupervisorEditedTasks))
+	}
+
+	if len(summary.ByVerdict) > 0 {
+		verdicts := make([]string, 0, len(summary.ByVerdict))
+		for verdict := range summary.ByVerdict {
+			verdicts = append(verdicts, verdict)
+		}
+		sort.Strings(verdicts)
+		sb.WriteString("\n| Verdict | Count |\n")
+		sb.WriteString("|---------|-------|\n")
+		for _, verdict := range verdicts {
+			sb.WriteString(fmt.Sprintf("| %s | %d |\n", verdict, summary.ByVerdict[verdict]))
+		}
+	}
+
+	if len(summary.ByQASeverity) > 0 {
+		sb.WriteString("\n| QA Severity | Count |\n")
+		sb.WriteString("|-------------|-------|\n")
+		for _, severity := range global.QASeverities {
+			if count := summary.ByQASeverity[severity]; count > 0 {
+				sb.WriteString(fmt.Sprintf("| %s | %d |\n", severity, count))
+			}
+		}
+	}
+
+	sb.WriteString("\n---\n\n")
+	return sb.String()
+}
+
+// reportTrendRuns limits the trends section to the most recent runs
+const reportTrendRuns = 10
+
+// GenerateTrendsMarkdown renders per-run metrics (oldest first) as a markdown
+// section, used for report manifest entries with include_trends set. Run times
+// are shown in the clock's timezone.
+func GenerateTrendsMarkdown(points []global.TrendPoint, clock *global.Clock) string {
+	var sb strings.Builder
+	sb.WriteString("## Trends\n\n")
+	if len(points) == 0 {
+		sb.WriteString("No runs have been recorded yet.\n\n---\n\n")
+		return sb.String()
+	}
+	if len(points) > reportTrendRuns {
+		points = points[len(points)-reportTrendRuns:]
+	}
+
+	severitySet := make(map[string]bool)
+	for _, point := range points {
+		for severity := range point.BySeverity {
+			severitySet[severity] = true
+		}
+	}
+	severities := make([]string, 0, len(severitySet))
+	for severity := range severitySet {
+		severities = append(severities, severity)
+	}
+	sort.Strings(severities)
+
+	sb.WriteString("| Run | Tasks | Done | Failed | QA Pass Rate |")
+	for _, severity := range severities {
+		sb.WriteString(fmt.Sprintf(" %s |", severity))
+	}
+	sb.WriteString(" Run Cost |\n")
+	sb.WriteString("|-----|-------|------|--------|--------------|")
+	for range severities {
+		sb.WriteString("------|")
+	}
+	sb.WriteString("----------|\n")
+
+	for _, point := range points {
+		passRate := "-"
+		if point.QAReviewed > 0 {
+			passRate = fmt.Sprintf("%.0f%%", point.QAPassRate*100)
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %d | %d | %d | %s |",
+			clock.In(point.RunAt).Format("2006-01-02 15:04"), point.TotalTasks,
+			point.ByStatus[global.ExecutionStatusDone], point.ByStatus[global.ExecutionStatusFailed], passRate))
+		for _, severity := range severities {
+			sb.WriteString(fmt.Sprintf(" %d |", point.BySeverity[severity]))
+		}
+		sb.WriteString(fmt.Sprintf(" $%.2f |\n", point.RunCostUSD))
+	}
+
+	sb.WriteString("\n---\n\n")
+	return sb.String()
+}
+
 // GenerateMarkdown generates a markdown report
 func (r *Reporter) GenerateMarkdown(report *ProjectReport) (string, error) {
 	tmpl := `# Project Report: {{.Project}}
 
-**Generated**: {{.GeneratedAt.Format "2006-01-02 15:04:05"}}
+**Generated**: {{timestamp .GeneratedAt}}
 
 ## Summary
 
@@ -568,10 +973,14 @@ func (r *Reporter) GenerateMarkdown(report *ProjectReport) (string, error) {
 | Total Tasks | {{.Summary.TotalTasks}} |
 | Completed | {{.Summary.CompletedTasks}} |
 | Failed | {{.Summary.FailedTasks}} |
-| Pending | {{.Summary.PendingTasks}} |
+| Pending | {{.Summary.PendingTasks}} |{{if gt .Summary.OnHoldTasks 0}}
+| On Hold | {{.Summary.OnHoldTasks}} |{{end}}{{if gt .Summary.AwaitingApprovalTasks 0}}
+| Awaiting Approval | {{.Summary.AwaitingApprovalTasks}} |{{end}}
 {{if gt .Summary.QAPassedTasks 0}}| QA Passed | {{.Summary.QAPassedTasks}} |{{end}}
 {{if gt .Summary.QAFailedTasks 0}}| QA Failed | {{.Summary.QAFailedTasks}} |{{end}}
 {{if gt .Summary.QAEscalatedTasks 0}}| QA Escalated | {{.Summary.QAEscalatedTasks}} |{{end}}
+{{if gt .Summary.PIIReviewTasks 0}}| PII Review Required | {{.Summary.PIIReviewTasks}} |{{end}}
+{{if gt .Summary.SupervisorEditedTasks 0}}| Edited by Supervisor | {{.Summary.SupervisorEditedTasks}} |{{end}}
 
 {{if .Summary.ByVerdict}}
 ### By Verdict
@@ -581,6 +990,14 @@ func (r *Reporter) GenerateMarkdown(report *ProjectReport) (string, error) {
 {{range $k, $v := .Summary.ByVerdict}}| {{$k}} | {{$v}} |
 {{end}}{{end}}
 
+{{if .Summary.ByQASeverity}}
+### By QA Severity
+
+| Severity | Count |
+|----------|-------|
+{{range $k, $v := .Summary.ByQASeverity}}| {{$k}} | {{$v}} |
+{{end}}{{end}}
+
 {{if .Summary.ByType}}
 ### By Type
 
@@ -604,7 +1021,8 @@ func (r *Reporter) GenerateMarkdown(report *ProjectReport) (string, error) {
 
 - **Type**: {{.Type}}
 - **Status**: {{.WorkStatus}}
-{{if .QAEnabled}}- **QA**: {{.QAVerdict}}{{end}}
+{{if .QAEnabled}}- **QA**: {{.QAVerdict}}{{if .QASeverity}} ({{.QASeverity}}){{end}}{{end}}
+{{if .SupervisorEdited}}- **Edited by Supervisor**: {{if .SupervisorFields}}{{range $i, $f := .SupervisorFields}}{{if $i}}, {{end}}{{$f}}{{end}}{{else}}yes{{end}}{{end}}
 
 {{if .WorkResult}}
 #### Result
@@ -628,7 +1046,7 @@ func (r *Reporter) GenerateMarkdown(report *ProjectReport) (string, error) {
 {{end}}
 `
 
-	t, err := template.New("report").Parse(tmpl)
+	t, err := template.New("report").Funcs(r.templateFuncs()).Parse(tmpl)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse template: %w", err)
 	}
@@ -665,7 +1083,7 @@ func (r *Reporter) GenerateHierarchicalMarkdown(report *ProjectReport) (string,
 	var sb strings.Builder
 
 	sb.WriteString(fmt.Sprintf("# Project Report: %s\n\n", report.Project))
-	sb.WriteString(fmt.Sprintf("**Generated**: %s\n\n", report.GeneratedAt.Format("2006-01-02 15:04:05")))
+	sb.WriteString(fmt.Sprintf("**Generated**: %s\n\n", r.clock.Report(report.GeneratedAt)))
 
 	// Summary
 	sb.WriteString("## Summary\n\n")
@@ -673,11 +1091,25 @@ func (r *Reporter) GenerateHierarchicalMarkdown(report *ProjectReport) (string,
 	sb.WriteString(fmt.Sprintf("- **Completed**: %d\n", report.Summary.CompletedTasks))
 	sb.WriteString(fmt.Sprintf("- **Failed**: %d\n", report.Summary.FailedTasks))
 	sb.WriteString(fmt.Sprintf("- **Pending**: %d\n", report.Summary.PendingTasks))
+	if report.Summary.OnHoldTasks > 0 {
+		sb.WriteString(fmt.Sprintf("- **On Hold**: %d\n", report.Summary.OnHoldTasks))
+	}
+	if report.Summary.AwaitingApprovalTasks > 0 {
+		sb.WriteString(fmt.Sprintf("- **Awaiting Approval**: %d\n", report.Summary.AwaitingApprovalTasks))
+	}
+	if report.Summary.SupervisorEditedTasks > 0 {
+		sb.WriteString(fmt.Sprintf("- **Edited by Supervisor**: %d\n", report.Summary.SupervisorEditedTasks))
+	}
 
 	if report.Summary.QAPassedTasks > 0 || report.Summary.QAFailedTasks > 0 {
 		sb.WriteString(fmt.Sprintf("- **QA Passed**: %d\n", report.Summary.QAPassedTasks))
 		sb.WriteString(fmt.Sprintf("- **QA Failed**: %d\n", report.Summary.QAFailedTasks))
 	}
+	for _, severity := range global.QASeverities {
+		if count := report.Summary.ByQASeverity[severity]; count > 0 {
+			sb.WriteString(fmt.Sprintf("- **QA %s**: %d\n", strings.Title(severity), count))
+		}
+	}
 
 	sb.WriteString("\n---\n\n")
 
@@ -723,9 +1155,15 @@ func (r *Reporter) GenerateHierarchicalMarkdown(report *ProjectReport) (string,
 					default:
 						sb.WriteString(fmt.Sprintf("**QA**: %s\n", task.QAVerdict))
 					}
+					if task.QASeverity != "" {
+						sb.WriteString(fmt.Sprintf("**QA Severity**: %s\n", task.QASeverity))
+					}
 				} else {
 					sb.WriteString("**QA**: None\n")
 				}
+				if task.SupervisorEdited {
+					sb.WriteString(fmt.Sprintf("**Edited by Supervisor**: %s\n", supervisorEditNote(task)))
+				}
 
 				if task.WorkResult != "" {
 					sb.WriteString("\n")
@@ -743,9 +1181,9 @@ func (r *Reporter) GenerateHierarchicalMarkdown(report *ProjectReport) (string,
 						renderedQA := r.RenderQAWithTemplate(task, ts.QAReportTemplate)
 						sb.WriteString(renderedQA)
 					} else if task.QAFeedback != "" {
-						sb.WriteString(task.QAFeedback)
+						sb.WriteString(r.attribute(task.QAFeedback, task.QALLMModelID, task.CompletedAt))
 					} else {
-						sb.WriteString(task.QAResult)
+						sb.WriteString(r.attribute(task.QAResult, task.QALLMModelID, task.CompletedAt))
 					}
 					sb.WriteString("\n")
 
@@ -785,6 +1223,13 @@ func (r *Reporter) SaveReport(report *ProjectReport, outputPath, format string)
 		content, err = r.GenerateHierarchicalMarkdown(report)
 	case "json":
 		content, err = r.GenerateJSON(report)
+	case global.ReportFormatHTML, global.ReportFormatPDF:
+		content, err = r.GenerateHierarchicalMarkdown(report)
+		if err == nil {
+			var rendered []byte
+			rendered, err = r.Render(content, format, "")
+			content = string(rendered)
+		}
 	default:
 		content, err = r.GenerateHierarchicalMarkdown(report)
 	}
@@ -799,7 +1244,7 @@ func (r *Reporter) SaveReport(report *ProjectReport, outputPath, format string)
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
-	if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
+	if err := global.AtomicWrite(outputPath, []byte(content)); err != nil {
 		return fmt.Errorf("failed to write report: %w", err)
 	}
 
@@ -814,8 +1259,9 @@ func (r *Reporter) SaveReport(report *ProjectReport, outputPath, format string)
 func GenerateFilename(prefix string, format string) string {
 	timestamp := time.Now().Format("2006-01-02-150405")
 	ext := "md"
-	if format == "json" {
-		ext = "json"
+	switch format {
+	case "json", global.ReportFormatHTML, global.ReportFormatPDF:
+		ext = format
 	}
 
 	if prefix == "" {
@@ -6,7 +6,11 @@
 package reporting
 
 import (
+	"archive/zip"
+	"bytes"
 	"encoding/json"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -14,6 +18,7 @@ import (
 	"time"
 
 	"github.com/PivotLLM/Maestro/global"
+	"github.com/tenebris-tech/x2md/pdf2md/pdf"
 )
 
 func TestNew(t *testing.T) {
@@ -231,6 +236,38 @@ func TestBuildReportQAFilter(t *testing.T) {
 	}
 }
 
+func TestBuildReportQASeverity(t *testing.T) {
+	r := New(nil)
+
+	task := func(id int, severity string) global.Task {
+		return global.Task{
+			ID:   id,
+			Work: global.WorkExecution{Status: global.ExecutionStatusDone},
+			QA:   global.QAExecution{Enabled: true, Verdict: global.QAVerdictFail, Severity: severity, Status: global.ExecutionStatusDone},
+		}
+	}
+	taskSets := []*global.TaskSet{{
+		Path:  "test",
+		Title: "Test",
+		Tasks: []global.Task{task(1, global.QASeverityCritical), task(2, global.QASeverityHigh), task(3, global.QASeverityLow), task(4, "")},
+	}}
+
+	// The summary counts each severity
+	report := r.BuildReport("test", taskSets, nil, "")
+	if got := report.Summary.ByQASeverity; got[global.QASeverityCritical] != 1 || got[global.QASeverityHigh] != 1 || got[global.QASeverityLow] != 1 || len(got) != 3 {
+		t.Errorf("ByQASeverity = %v", got)
+	}
+	if md := GenerateSummaryMarkdown(report.Summary); !strings.Contains(md, "| critical | 1 |") {
+		t.Errorf("summary markdown missing severity counts:\n%s", md)
+	}
+
+	// The filter keeps tasks at least as severe as the minimum
+	report = r.BuildReport("test", taskSets, &ReportFilter{QASeverity: global.QASeverityHigh}, "")
+	if report.Summary.TotalTasks != 2 {
+		t.Errorf("QA severity filter: expected 2 tasks, got %d", report.Summary.TotalTasks)
+	}
+}
+
 func TestGenerateMarkdown(t *testing.T) {
 	r := New(nil)
 
@@ -728,6 +765,93 @@ func TestLoadTemplateNoLoaderConfigured(t *testing.T) {
 	}
 }
 
+func TestTemplatePartials(t *testing.T) {
+	files := map[string]string{
+		"playbook/templates/worker.md":                  "{{template \"finding-header\" .}}\n{{.finding}}",
+		"playbook/templates/partials/finding-header.md": "### {{._task_title}} {{template \"badge\" .}}",
+		"playbook/partials/badge.md":                    "[{{.status}}]",
+		"playbook/templates/missing.md":                 "{{template \"nowhere\" .}}",
+		"playbook/templates/nested/worker.md":           "{{template \"shared/templates/partials/footer.md\" .}}",
+		"shared/templates/partials/footer.md":           "Footer {{.finding}}",
+	}
+	loader := ContentLoaderFunc(func(path string) (string, error) {
+		if content, ok := files[path]; ok {
+			return content, nil
+		}
+		return "", os.ErrNotExist
+	})
+
+	r := New(nil, WithPlaybookLoader(loader))
+	task := TaskReport{
+		ID:         1,
+		Title:      "Access Control",
+		WorkResult: `{"finding": "Weak passwords", "status": "fail"}`,
+	}
+
+	result := r.RenderWithTemplate(task, "playbook/templates/worker.md")
+	if result != "### Access Control [fail]\nWeak passwords" {
+		t.Errorf("unexpected render with partials: %q", result)
+	}
+
+	includes := r.TemplateIncludes("playbook/templates/worker.md")
+	if len(includes) != 2 || includes[0] != "playbook/templates/partials/finding-header.md" || includes[1] != "playbook/partials/badge.md" {
+		t.Errorf("TemplateIncludes = %v", includes)
+	}
+
+	// Partials can be referenced by full path, e.g. from another playbook
+	result = r.RenderWithTemplate(task, "playbook/templates/nested/worker.md")
+	if result != "Footer Weak passwords" {
+		t.Errorf("unexpected render with full-path partial: %q", result)
+	}
+
+	// An unresolvable partial falls back to the raw result
+	result = r.RenderWithTemplate(task, "playbook/templates/missing.md")
+	if result != task.WorkResult {
+		t.Errorf("expected raw result for missing partial, got: %q", result)
+	}
+}
+
+func TestTemplateLayout(t *testing.T) {
+	files := map[string]string{
+		"layouts/base.md": "{{/* extends \"root\" */}}{{define \"body\"}}{{block \"title\" .}}Untitled{{end}}\n{{block \"content\" .}}No content{{end}}{{end}}",
+		"layouts/root.md": "<{{template \"body\" .}}>",
+		"worker.md":       "{{/* extends \"layouts/base\" */}}\n{{define \"content\"}}Finding: {{.finding}}{{end}}\n",
+		"titled.md":       "{{/* extends \"layouts/base.md\" */}}\n{{define \"title\"}}# {{._task_title}}{{end}}\n{{define \"content\"}}{{.finding}}{{end}}\n",
+		"loop-a.md":       "{{/* extends \"loop-b\" */}}",
+		"loop-b.md":       "{{/* extends \"loop-a\" */}}",
+	}
+	loader := ContentLoaderFunc(func(path string) (string, error) {
+		if content, ok := files[path]; ok {
+			return content, nil
+		}
+		return "", os.ErrNotExist
+	})
+
+	r := New(nil, WithProjectLoader(loader))
+	task := TaskReport{
+		ID:         1,
+		Title:      "Access Control",
+		WorkResult: `{"finding": "Weak passwords"}`,
+	}
+
+	// Layout default title, overridden content, two levels of layouts
+	result := r.RenderWithTemplate(task, "worker.md")
+	if result != "<Untitled\nFinding: Weak passwords>" {
+		t.Errorf("unexpected layout render: %q", result)
+	}
+
+	result = r.RenderWithTemplate(task, "titled.md")
+	if result != "<# Access Control\nWeak passwords>" {
+		t.Errorf("unexpected layout render with title: %q", result)
+	}
+
+	// Circular layouts are rejected and fall back to the raw result
+	result = r.RenderWithTemplate(task, "loop-a.md")
+	if result != task.WorkResult {
+		t.Errorf("expected raw result for circular layout, got: %q", result)
+	}
+}
+
 // ============================================================================
 // Notes/Feedback/Comments Extraction Tests
 // ============================================================================
@@ -781,6 +905,91 @@ func TestBuildReportQAFeedbackExtraction(t *testing.T) {
 	}
 }
 
+func TestBuildReportPIIReview(t *testing.T) {
+	r := New(nil)
+	resultsDir := t.TempDir()
+
+	resultData := global.TaskResult{
+		Worker: global.WorkerResult{
+			Response: "Contact jane.doe@example.com",
+			PII:      &global.PIIReport{Total: 1, Findings: []global.PIIFinding{{Type: global.PIITypeEmail, Count: 1}}},
+		},
+		QA: &global.QAResult{
+			Response: `{"qa_verdict": "Pass", "feedback": "Mentions SSN 123-45-6789"}`,
+			PII:      &global.PIIReport{Total: 2, Findings: []global.PIIFinding{{Type: global.PIITypeSSN, Count: 1}, {Type: global.PIITypeEmail, Count: 1}}},
+		},
+	}
+	resultBytes, _ := json.Marshal(resultData)
+	os.WriteFile(filepath.Join(resultsDir, "uuid-pii.json"), resultBytes, 0644)
+
+	taskSets := []*global.TaskSet{
+		{
+			Path:  "test",
+			Title: "Test",
+			Tasks: []global.Task{
+				{ID: 1, UUID: "uuid-pii", Title: "Interviews", Work: global.WorkExecution{Status: global.ExecutionStatusDone}, QA: global.QAExecution{Enabled: true}},
+				{ID: 2, UUID: "uuid-none", Title: "Firewall", Work: global.WorkExecution{Status: global.ExecutionStatusDone}},
+			},
+		},
+	}
+
+	report := r.BuildReport("test", taskSets, nil, resultsDir)
+	task := report.TaskSets[0].Tasks[0]
+	if len(task.PIITypes) != 2 || task.PIITypes[0] != global.PIITypeEmail || task.PIITypes[1] != global.PIITypeSSN {
+		t.Errorf("PIITypes = %v, want [email ssn]", task.PIITypes)
+	}
+	if report.Summary.PIIReviewTasks != 1 {
+		t.Errorf("PIIReviewTasks = %d, want 1", report.Summary.PIIReviewTasks)
+	}
+	if !strings.Contains(GenerateSummaryMarkdown(report.Summary), "| PII Review Required | 1 |") {
+		t.Error("summary markdown does not count the task needing PII review")
+	}
+}
+
+func TestBuildReportSupervisorEdits(t *testing.T) {
+	r := New(nil)
+	resultsDir := t.TempDir()
+
+	resultData := global.TaskResult{
+		Worker:             global.WorkerResult{Response: `{"rating": "partial", "notes": "Reviewed"}`},
+		SupervisorOverride: true,
+		SupervisorEdits: []global.SupervisorEdit{
+			{Mode: global.SupervisorEditPatch, Fields: []string{"rating"}},
+			{Mode: global.SupervisorEditPatch, Fields: []string{"notes", "rating"}},
+		},
+	}
+	resultBytes, _ := json.Marshal(resultData)
+	os.WriteFile(filepath.Join(resultsDir, "uuid-edited.json"), resultBytes, 0644)
+
+	taskSets := []*global.TaskSet{
+		{
+			Path:  "test",
+			Title: "Test",
+			Tasks: []global.Task{
+				{ID: 1, UUID: "uuid-edited", Title: "Access reviews", Work: global.WorkExecution{Status: global.ExecutionStatusDone}},
+				{ID: 2, UUID: "uuid-none", Title: "Firewall", Work: global.WorkExecution{Status: global.ExecutionStatusDone}},
+			},
+		},
+	}
+
+	report := r.BuildReport("test", taskSets, nil, resultsDir)
+	task := report.TaskSets[0].Tasks[0]
+	if !task.SupervisorEdited || strings.Join(task.SupervisorFields, ",") != "notes,rating" {
+		t.Errorf("SupervisorEdited = %v, SupervisorFields = %v, want true [notes rating]", task.SupervisorEdited, task.SupervisorFields)
+	}
+	if report.Summary.SupervisorEditedTasks != 1 {
+		t.Errorf("SupervisorEditedTasks = %d, want 1", report.Summary.SupervisorEditedTasks)
+	}
+
+	md, err := r.GenerateMarkdown(report)
+	if err != nil {
+		t.Fatalf("GenerateMarkdown() error = %v", err)
+	}
+	if !strings.Contains(md, "- **Edited by Supervisor**: notes, rating") {
+		t.Errorf("markdown does not flag the edited fields:\n%s", md)
+	}
+}
+
 func TestBuildReportQANotesExtraction(t *testing.T) {
 	r := New(nil)
 	tmpDir := t.TempDir()
@@ -1100,6 +1309,37 @@ func TestTemplateFunctions(t *testing.T) {
 	}
 }
 
+func TestConfidencePhrases(t *testing.T) {
+	mockLoader := ContentLoaderFunc(func(path string) (string, error) {
+		return `Testing {{._phrase}} the control.{{range .findings}} Evidence {{phrase .confidence}} {{.text}}.{{end}}`, nil
+	})
+
+	task := TaskReport{
+		ID:         1,
+		WorkResult: `{"confidence": "Confirmed", "findings": [{"confidence": "medium", "text": "gaps"}, {"text": "drift"}]}`,
+	}
+
+	r := New(nil, WithProjectLoader(mockLoader))
+	result := r.RenderWithTemplate(task, "template.md")
+	want := "Testing confirms the control. Evidence suggests gaps. Evidence indicates drift."
+	if result != want {
+		t.Errorf("default phrases: got %q, want %q", result, want)
+	}
+
+	// Configured mappings replace the defaults and read the configured field
+	task.WorkResult = `{"assurance": "TESTED", "findings": [{"confidence": "medium", "text": "gaps"}]}`
+	r = New(nil, WithProjectLoader(mockLoader), WithReportLanguage(global.ReportLanguage{
+		ConfidenceField: "assurance",
+		Phrases:         map[string]string{"Tested": "demonstrated", "medium": "points to"},
+		DefaultPhrase:   "appears to show",
+	}))
+	result = r.RenderWithTemplate(task, "template.md")
+	want = "Testing demonstrated the control. Evidence points to gaps."
+	if result != want {
+		t.Errorf("configured phrases: got %q, want %q", result, want)
+	}
+}
+
 // ============================================================================
 // Report Verdict Summary Tests
 // ============================================================================
@@ -1149,3 +1389,469 @@ func TestBuildReportVerdictSummary(t *testing.T) {
 		t.Errorf("expected ByVerdict[escalate]=1, got %d", report.Summary.ByVerdict[global.QAVerdictEscalate])
 	}
 }
+
+// ============================================================================
+// Render Debug Tests
+// ============================================================================
+
+func TestDebugRender(t *testing.T) {
+	mockLoader := ContentLoaderFunc(func(path string) (string, error) {
+		return "### {{._task_title}}\n{{.finding}} {{.evidence}} {{if .severity}}{{.severity}}{{end}}", nil
+	})
+
+	r := New(nil, WithProjectLoader(mockLoader))
+
+	task := TaskReport{
+		ID:         3,
+		UUID:       "uuid-3",
+		Title:      "Access Control",
+		WorkStatus: global.ExecutionStatusDone,
+		WorkResult: `{"finding": "Weak passwords", "severity": "high"}`,
+		QAResult:   `{"verdict": "pass"}`,
+	}
+
+	debug := r.DebugRender(task, "template.md", false)
+
+	if debug.UsedRawResult {
+		t.Errorf("expected template rendering, got raw result (error: %s)", debug.TemplateError)
+	}
+	if debug.TemplateSource != "project" {
+		t.Errorf("TemplateSource = %q, want project", debug.TemplateSource)
+	}
+	if _, ok := debug.ParsedFields["_task_title"]; ok {
+		t.Error("ParsedFields should not include merged metadata")
+	}
+	if debug.Context["_task_title"] != "Access Control" {
+		t.Errorf("Context _task_title = %v", debug.Context["_task_title"])
+	}
+	if _, ok := debug.Context["_qa_result"]; !ok {
+		t.Error("Context missing _qa_result")
+	}
+	if len(debug.MissingFields) != 1 || debug.MissingFields[0] != "evidence" {
+		t.Errorf("MissingFields = %v, want [evidence]", debug.MissingFields)
+	}
+	if !strings.Contains(debug.Rendered, "### Access Control") || !strings.Contains(debug.Rendered, "Weak passwords") {
+		t.Errorf("unexpected rendered output: %s", debug.Rendered)
+	}
+}
+
+func TestDebugRenderNonJSONResult(t *testing.T) {
+	mockLoader := ContentLoaderFunc(func(path string) (string, error) {
+		return "{{.finding}}", nil
+	})
+
+	r := New(nil, WithProjectLoader(mockLoader))
+
+	task := TaskReport{ID: 1, WorkResult: "plain text"}
+	debug := r.DebugRender(task, "template.md", false)
+
+	if !debug.UsedRawResult || debug.Rendered != "plain text" {
+		t.Errorf("expected raw result fallback, got %+v", debug)
+	}
+	if debug.ParseError == "" || debug.TemplateError == "" {
+		t.Errorf("expected parse and template errors, got parse=%q template=%q", debug.ParseError, debug.TemplateError)
+	}
+}
+
+func TestGenerateTrendsMarkdown(t *testing.T) {
+	if out := GenerateTrendsMarkdown(nil, nil); !strings.Contains(out, "No runs have been recorded") {
+		t.Errorf("expected empty trends note, got: %s", out)
+	}
+
+	points := []global.TrendPoint{
+		{TotalTasks: 4, ByStatus: map[string]int{"done": 2}, BySeverity: map[string]int{"high": 2}, RunCostUSD: 1.5},
+		{TotalTasks: 4, ByStatus: map[string]int{"done": 4}, BySeverity: map[string]int{"low": 1}, QAReviewed: 4, QAPassRate: 0.75},
+	}
+	out := GenerateTrendsMarkdown(points, nil)
+	for _, want := range []string{"## Trends", "| high | low |", "| 4 | 2 | 0 | - | 2 | 0 | $1.50 |", "| 4 | 4 | 0 | 75% | 0 | 1 | $0.00 |"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("trends markdown missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestAttribution(t *testing.T) {
+	completedAt := time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC)
+	task := TaskReport{ID: 1, WorkResult: "Finding text\n", LLMModelID: "claude", QAResult: "Looks right", QALLMModelID: "gemini", CompletedAt: &completedAt}
+
+	plain := New(nil)
+	if out := plain.RenderWithTemplate(task, ""); out != task.WorkResult {
+		t.Errorf("attribution disabled, got %q", out)
+	}
+
+	r := New(nil, WithAttribution(global.ReportAttribution{Enabled: true}))
+	if out := r.RenderWithTemplate(task, ""); out != "Finding text\n\n*AI-generated content (claude, 2026-03-14)*\n" {
+		t.Errorf("unexpected attributed result: %q", out)
+	}
+
+	r = New(nil, WithAttribution(global.ReportAttribution{Enabled: true, Format: "[{model} @ {date}]"}))
+	if out := r.RenderQAWithTemplate(task, ""); !strings.HasSuffix(out, "[gemini @ 2026-03-14]\n") {
+		t.Errorf("unexpected attributed QA result: %q", out)
+	}
+	if out := r.RenderWithTemplate(TaskReport{ID: 2}, ""); out != "" {
+		t.Errorf("empty result should stay empty, got %q", out)
+	}
+}
+
+func TestTimestampFuncs(t *testing.T) {
+	clock, err := global.NewClock(global.Timestamps{Timezone: "Asia/Tokyo", DateFormat: "2006/01/02"})
+	if err != nil {
+		t.Fatalf("NewClock: %v", err)
+	}
+	completedAt := time.Date(2026, 3, 14, 20, 0, 0, 0, time.UTC)
+	task := TaskReport{ID: 1, LLMModelID: "claude", CompletedAt: &completedAt}
+
+	r := New(nil, WithClock(clock), WithAttribution(global.ReportAttribution{Enabled: true, Format: "({date})"}))
+	funcs := r.templateFuncs()
+	if got := funcs["date"].(func(interface{}) string)("2026-03-14T20:00:00Z"); got != "2026/03/15" {
+		t.Errorf("date = %q", got)
+	}
+	if got := funcs["timestamp"].(func(interface{}) string)(completedAt); got != "2026-03-15 05:00:00" {
+		t.Errorf("timestamp = %q", got)
+	}
+	if got := funcs["date"].(func(interface{}) string)("not a time"); got != "not a time" {
+		t.Errorf("non-time value changed: %q", got)
+	}
+	task.WorkResult = "Finding"
+	if out := r.RenderWithTemplate(task, ""); !strings.HasSuffix(out, "(2026/03/15)\n") {
+		t.Errorf("attribution date not in configured timezone: %q", out)
+	}
+}
+
+func TestTemplateLibraryFuncs(t *testing.T) {
+	templates := map[string]string{
+		"table":     `{{table .findings "id:ID" "severity" "detail.text:Detail"}}`,
+		"empty":     `[{{table .missing "id"}}]`,
+		"section":   `{{section "### Critical" (bullets (where .findings "severity" "CRITICAL") "id")}}{{section "### None" (bullets .missing)}}`,
+		"aggregate": `{{sum .findings "score"}} {{avg .findings "score"}} {{min .findings "score"}} {{max .findings "score"}} {{count .findings}} {{percent 1 3}} {{round 2.345 2}}`,
+		"countBy":   `{{range $k, $v := countBy .findings "severity"}}{{$k}}={{$v}} {{end}}`,
+		"default":   `{{default "n/a" .missing}} {{default "n/a" .owner}} {{coalesce .missing "" .owner}}`,
+		"escape":    `{{escapeHTML .owner}} {{escapeMarkdown "a_b*c"}}`,
+		"dates":     `{{formatDate "02 Jan 2006" .tested_at}} {{daysBetween .tested_at "2026-03-24T10:00:00Z"}}`,
+	}
+	loader := ContentLoaderFunc(func(path string) (string, error) {
+		if tmpl, ok := templates[path]; ok {
+			return tmpl, nil
+		}
+		return "", os.ErrNotExist
+	})
+
+	clock, err := global.NewClock(global.Timestamps{Timezone: "Asia/Tokyo"})
+	if err != nil {
+		t.Fatalf("NewClock: %v", err)
+	}
+	r := New(nil, WithProjectLoader(loader), WithClock(clock))
+	task := TaskReport{ID: 1, WorkResult: `{
+		"owner": "<Ops & IT>",
+		"tested_at": "2026-03-14T20:00:00Z",
+		"findings": [
+			{"id": "F1", "severity": "critical", "score": 9, "detail": {"text": "MFA | disabled\nfor admins"}},
+			{"id": "F2", "severity": "low", "score": "2"},
+			{"id": "F3", "severity": "critical", "score": 4}
+		]
+	}`}
+
+	want := map[string]string{
+		"table":     "| ID | severity | Detail |\n| --- | --- | --- |\n| F1 | critical | MFA \\| disabled for admins |\n| F2 | low |  |\n| F3 | critical |  |\n",
+		"empty":     "[]",
+		"section":   "### Critical\n\n- F1\n- F3\n",
+		"aggregate": "15 5 2 9 3 33.3 2.35",
+		"countBy":   "critical=2 low=1 ",
+		"default":   "n/a <Ops & IT> <Ops & IT>",
+		"escape":    "&lt;Ops &amp; IT&gt; a\\_b\\*c",
+		"dates":     "15 Mar 2026 9",
+	}
+	for name, expected := range want {
+		if got := r.RenderWithTemplate(task, name); got != expected {
+			t.Errorf("%s = %q, want %q", name, got, expected)
+		}
+	}
+
+	funcs := r.templateFuncs()
+	if _, err := funcs["div"].(func(a, b interface{}) (float64, error))(1, 0); err == nil {
+		t.Error("expected error dividing by zero")
+	}
+	if _, err := funcs["sum"].(func(interface{}, ...string) (float64, error))("not a list"); err == nil {
+		t.Error("expected error summing a non-list")
+	}
+	if got := funcs["get"].(func(interface{}, string) interface{})(task, "Title"); got != "" {
+		t.Errorf("get(struct) = %v", got)
+	}
+}
+
+func TestRenderAggregate(t *testing.T) {
+	loader := ContentLoaderFunc(func(path string) (string, error) {
+		switch path {
+		case "summary.md":
+			return `{{.project}}/{{.variant}}: {{.summary.total_tasks}} tasks, {{.summary.qa_failed_tasks}} failed QA
+{{range $s, $n := countBy (flatten (pluck .tasks "findings")) "severity"}}{{$s}}={{$n}} {{end}}
+{{range .failed}}{{._taskset_title}}/{{._task_title}} {{end}}| {{range .escalated}}{{._task_title}}{{end}} | {{(index .tasks 2)._result}}`, nil
+		case "broken.md":
+			return `{{sum .project}}`, nil
+		}
+		return "", os.ErrNotExist
+	})
+	r := New(nil, WithProjectLoader(loader))
+
+	report := &ProjectReport{Project: "audit", TaskSets: []TaskSetReport{
+		{Path: "controls", Title: "Controls", Tasks: []TaskReport{
+			{ID: 1, Title: "Access", WorkStatus: "done", QAVerdict: "fail", WorkResult: `{"findings": [{"severity": "high"}, {"severity": "low"}]}`},
+			{ID: 2, Title: "Backups", WorkStatus: "done", QAVerdict: "escalate", WorkResult: `{"findings": [{"severity": "high"}]}`},
+		}},
+		{Path: "network", Title: "Network", Tasks: []TaskReport{
+			{ID: 3, Title: "Firewall", WorkStatus: "failed", WorkResult: "timed out"},
+		}},
+	}}
+
+	want := "audit/Client: 3 tasks, 1 failed QA\nhigh=2 low=1 \nControls/Access Network/Firewall | Backups | timed out"
+	if got := r.RenderAggregate(report, "summary.md", "Client"); got != want {
+		t.Errorf("RenderAggregate() = %q, want %q", got, want)
+	}
+	if got := r.RenderAggregate(report, "broken.md", ""); got != "" {
+		t.Errorf("failing template rendered %q", got)
+	}
+	if got := r.RenderAggregate(report, "missing.md", ""); got != "" {
+		t.Errorf("missing template rendered %q", got)
+	}
+}
+
+func TestFillDOCX(t *testing.T) {
+	document := `<w:document><w:body>` +
+		`<w:p><w:r><w:t>Client: {{client}}</w:t></w:r></w:p>` +
+		`<w:p><w:r><w:t>Total: {{sum</w:t></w:r><w:r><w:t>mary.total}}</w:t></w:r></w:p>` +
+		`<w:p><w:r><w:t>{{missing}}</w:t></w:r></w:p>` +
+		`<w:sdt><w:sdtPr><w:tag w:val="project_title"/><w:showingPlcHdr/></w:sdtPr><w:sdtContent><w:r><w:t>Click here</w:t></w:r></w:sdtContent></w:sdt>` +
+		`<w:tbl><w:tr><w:tc><w:p><w:r><w:t>Title</w:t></w:r></w:p></w:tc></w:tr>` +
+		`<w:tr><w:tc><w:p><w:r><w:t>{{findings._task_title}}: {{findings.detail}}</w:t></w:r></w:p></w:tc></w:tr></w:tbl>` +
+		`</w:body></w:document>`
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range map[string]string{"[Content_Types].xml": "<Types/>", "word/document.xml": document} {
+		w, _ := zw.Create(name)
+		_, _ = w.Write([]byte(content))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("zip: %v", err)
+	}
+
+	data := map[string]interface{}{
+		"client":        "A & B",
+		"project_title": "Audit",
+		"summary":       map[string]interface{}{"total": float64(2)},
+		"findings": []interface{}{
+			map[string]interface{}{"_task_title": "One", "detail": "first\nline"},
+			map[string]interface{}{"_task_title": "Two", "detail": "second"},
+		},
+	}
+	filled, unresolved, err := FillDOCX(buf.Bytes(), data)
+	if err != nil {
+		t.Fatalf("FillDOCX: %v", err)
+	}
+	if len(unresolved) != 1 || unresolved[0] != "missing" {
+		t.Errorf("unresolved = %v", unresolved)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(filled), int64(len(filled)))
+	if err != nil {
+		t.Fatalf("filled document is not a zip: %v", err)
+	}
+	var out string
+	for _, f := range zr.File {
+		if f.Name == "word/document.xml" {
+			rc, _ := f.Open()
+			content, _ := io.ReadAll(rc)
+			_ = rc.Close()
+			out = string(content)
+		}
+	}
+
+	for _, want := range []string{
+		"Client: A &amp; B",
+		"Total: 2",
+		"{{missing}}",
+		`<w:t xml:space="preserve">Audit</w:t>`,
+		`One: first</w:t><w:br/><w:t xml:space="preserve">line`,
+		"Two: second",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("filled document missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "showingPlcHdr") || strings.Contains(out, "Click here") || strings.Contains(out, "{{findings") {
+		t.Errorf("template markers left in document:\n%s", out)
+	}
+
+	if _, _, err := FillDOCX([]byte("not a zip"), data); err == nil {
+		t.Error("expected error for invalid template")
+	}
+}
+
+func TestApplyVariant(t *testing.T) {
+	task := TaskReport{
+		ID:         1,
+		WorkStatus: "done",
+		WorkResult: `{"summary":"Weak TLS","severity":"high","evidence":[{"file":"a.conf","excerpt":"ssl_protocols TLSv1"}],"raw":"x"}`,
+	}
+
+	executive := global.ReportTemplateConfig{Suffix: "Executive", Variant: "executive", ExcludeFields: []string{"evidence.excerpt", "raw"}}
+	out := ApplyVariant(task, executive)
+	if out.Variant != "executive" {
+		t.Errorf("variant = %q", out.Variant)
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(out.WorkResult), &data); err != nil {
+		t.Fatalf("filtered result is not JSON: %v", err)
+	}
+	if _, ok := data["raw"]; ok {
+		t.Error("excluded field raw still present")
+	}
+	evidence := data["evidence"].([]interface{})[0].(map[string]interface{})
+	if _, ok := evidence["excerpt"]; ok || evidence["file"] != "a.conf" {
+		t.Errorf("nested exclusion not applied: %v", evidence)
+	}
+	if task.WorkResult == out.WorkResult {
+		t.Error("original task was modified or not filtered")
+	}
+
+	owner := ApplyVariant(task, global.ReportTemplateConfig{Suffix: "Owners", IncludeFields: []string{"summary", "severity"}})
+	data = nil
+	_ = json.Unmarshal([]byte(owner.WorkResult), &data)
+	if len(data) != 2 || owner.Variant != "Owners" {
+		t.Errorf("include_fields not applied: %v (variant %q)", data, owner.Variant)
+	}
+
+	raw := ApplyVariant(TaskReport{WorkResult: "plain text"}, executive)
+	if raw.WorkResult != "plain text" {
+		t.Errorf("non-JSON result changed: %q", raw.WorkResult)
+	}
+
+	cfg := global.ReportTemplateConfig{IncludeSections: []string{"analysis"}, ExcludeSections: []string{"analysis/raw"}}
+	for path, want := range map[string]bool{"analysis": true, "analysis/tls": true, "analysis/raw": false, "analysis2": false, "intake": false} {
+		if got := IncludesSection(cfg, path); got != want {
+			t.Errorf("IncludesSection(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestRenderHTML(t *testing.T) {
+	markdown := "# Audit — Report\n\n**Issued:** 2026-01-02\n\n## Access Control\n\n" +
+		"Finding with `code`, *emphasis* and a [link](https://example.com). snake_case_name stays.\n\n" +
+		"- first\n- second\n  - nested\n\n1. one\n2. two\n\n" +
+		"| Item | Severity |\n|------|:--------:|\n| A \\| B | <high> |\n\n" +
+		"> Quoted note\n\n```json\n{\"a\": \"<b>\"}\n```\n\n---\n\n[bad](javascript:alert(1))\n" +
+		"<!-- maestro:begin task:u1 0123456789abcdef -->\nMarked finding\n<!-- maestro:end task:u1 -->\n"
+
+	out := RenderHTML(markdown, "")
+	for _, want := range []string{
+		"<title>Audit — Report</title>",
+		"<h1>Audit — Report</h1>",
+		"<p><strong>Issued:</strong> 2026-01-02</p>",
+		"<code>code</code>",
+		"<em>emphasis</em>",
+		`<a href="https://example.com">link</a>`,
+		"snake_case_name stays",
+		"<ul>\n<li>first</li>\n<li>\n<p>second</p>\n<ul>\n<li>nested</li>\n</ul>\n</li>\n</ul>",
+		"<ol>\n<li>one</li>\n<li>two</li>\n</ol>",
+		"<th>Item</th>",
+		`<td style="text-align: center">&lt;high&gt;</td>`,
+		"<td>A | B</td>",
+		"<blockquote>\n<p>Quoted note</p>\n</blockquote>",
+		`<pre><code class="language-json">{&#34;a&#34;: &#34;&lt;b&gt;&#34;}</code></pre>`,
+		"<hr>",
+		"border-collapse", // Built-in theme
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("HTML missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "javascript:") {
+		t.Error("script link kept in HTML")
+	}
+	if !strings.Contains(out, "<p>Marked finding</p>") || strings.Contains(out, "maestro:") {
+		t.Errorf("comment lines not dropped:\n%s", out)
+	}
+
+	themed := RenderHTML("# Title\n", "body { color: red; }</style><script>")
+	if !strings.Contains(themed, "body { color: red; }") || strings.Contains(themed, "</style><script>") {
+		t.Errorf("theme not applied safely:\n%s", themed)
+	}
+}
+
+func TestRenderPDF(t *testing.T) {
+	var sb strings.Builder
+	sb.WriteString("# Audit Report\n\n## Findings\n\n")
+	for i := 0; i < 80; i++ {
+		sb.WriteString(fmt.Sprintf("Finding %d: the **control** is missing for `host-%d` — see [docs](https://example.com).\n\n", i, i))
+	}
+	sb.WriteString("| Item | Severity |\n|---|---|\n| Unpatched server | High |\n\n```\nlong code line " + strings.Repeat("x", 200) + "\n```\n")
+
+	data := RenderPDF(sb.String())
+	if !bytes.HasPrefix(data, []byte("%PDF-1.4")) || !bytes.HasSuffix(data, []byte("%%EOF\n")) {
+		t.Fatal("output is not a PDF file")
+	}
+
+	parser := pdf.NewParser(data)
+	if err := parser.Parse(); err != nil {
+		t.Fatalf("Parse() = %v", err)
+	}
+	pages, err := parser.GetPageCount()
+	if err != nil || pages < 2 {
+		t.Fatalf("GetPageCount() = %d, %v; want several pages", pages, err)
+	}
+	var text strings.Builder
+	extractor := pdf.NewTextExtractor(parser)
+	for i := 0; i < pages; i++ {
+		items, err := extractor.ExtractPage(i)
+		if err != nil {
+			t.Fatalf("ExtractPage(%d) = %v", i, err)
+		}
+		for _, item := range items {
+			text.WriteString(item.Text + " ")
+		}
+	}
+	// The extractor returns each run of text separately
+	extracted := strings.Join(strings.Fields(text.String()), " ")
+	for _, want := range []string{"Audit Report", "Finding 79", "host-0", "(https://example.com)", "Unpatched server", fmt.Sprintf("Page %d of %d", pages, pages)} {
+		if !strings.Contains(extracted, want) {
+			t.Errorf("PDF text missing %q", want)
+		}
+	}
+}
+
+func TestRenderFormats(t *testing.T) {
+	dir := t.TempDir()
+	themeFile := filepath.Join(dir, "theme.css")
+	if err := os.WriteFile(themeFile, []byte("h1 { color: teal; }"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	r := New(nil, WithOutput(global.ReportOutput{Theme: themeFile}))
+
+	out, err := r.Render("# Title\n", global.ReportFormatHTML, "")
+	if err != nil || !strings.Contains(string(out), "color: teal") {
+		t.Errorf("configured theme not used: %v\n%s", err, out)
+	}
+	out, err = r.Render("# Title\n", global.ReportFormatHTML, "h1 { color: navy; }")
+	if err != nil || !strings.Contains(string(out), "color: navy") || strings.Contains(string(out), "teal") {
+		t.Errorf("manifest theme not preferred: %v\n%s", err, out)
+	}
+	if _, err := r.Render("# Title\n", "docx", ""); err == nil {
+		t.Error("expected error for an unsupported format")
+	}
+
+	// A converter that writes its input as the PDF shows the HTML was passed on
+	converter := New(nil, WithOutput(global.ReportOutput{PDFCommand: "cp", PDFArgs: []string{"{{INPUT}}", "{{OUTPUT}}"}}))
+	out, err = converter.Render("# Converted\n", global.ReportFormatPDF, "")
+	if err != nil || !strings.Contains(string(out), "<h1>Converted</h1>") {
+		t.Errorf("PDF converter output = %q, %v", out, err)
+	}
+	stdout := New(nil, WithOutput(global.ReportOutput{PDFCommand: "cat", PDFArgs: []string{"{{INPUT}}"}}))
+	out, err = stdout.Render("# Piped\n", global.ReportFormatPDF, "")
+	if err != nil || !strings.Contains(string(out), "<h1>Piped</h1>") {
+		t.Errorf("PDF converter stdout = %q, %v", out, err)
+	}
+	failing := New(nil, WithOutput(global.ReportOutput{PDFCommand: "false", PDFArgs: []string{"{{INPUT}}"}}))
+	if _, err := failing.Render("# Title\n", global.ReportFormatPDF, ""); err == nil {
+		t.Error("expected error from a failing converter")
+	}
+}
@@ -0,0 +1,95 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package reporting
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// VariantName returns the name a report variant exposes to templates as _variant
+func VariantName(cfg global.ReportTemplateConfig) string {
+	if cfg.Variant != "" {
+		return cfg.Variant
+	}
+	return cfg.Suffix
+}
+
+// IncludesSection reports whether a report variant covers the task set at path.
+// Section paths match the task set itself and everything under it.
+func IncludesSection(cfg global.ReportTemplateConfig, path string) bool {
+	if len(cfg.IncludeSections) > 0 && !matchesSection(cfg.IncludeSections, path) {
+		return false
+	}
+	return !matchesSection(cfg.ExcludeSections, path)
+}
+
+// matchesSection reports whether path is one of the sections or under one
+func matchesSection(sections []string, path string) bool {
+	for _, section := range sections {
+		section = strings.Trim(section, "/")
+		if path == section || strings.HasPrefix(path, section+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyVariant returns the task as a report variant sees it: the variant name is
+// set for templates and the result is narrowed to the variant's fields. Results
+// that are not JSON objects are left unchanged.
+func ApplyVariant(task TaskReport, cfg global.ReportTemplateConfig) TaskReport {
+	task.Variant = VariantName(cfg)
+	if task.WorkResult == "" || (len(cfg.IncludeFields) == 0 && len(cfg.ExcludeFields) == 0) {
+		return task
+	}
+
+	data, err := parseResultObject(task.WorkResult)
+	if err != nil {
+		return task
+	}
+
+	if len(cfg.IncludeFields) > 0 {
+		kept := make(map[string]interface{}, len(cfg.IncludeFields))
+		for _, field := range cfg.IncludeFields {
+			if value, ok := data[field]; ok {
+				kept[field] = value
+			}
+		}
+		data = kept
+	}
+	for _, field := range cfg.ExcludeFields {
+		removeField(data, strings.Split(field, "."))
+	}
+
+	filtered, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return task
+	}
+	task.WorkResult = string(filtered)
+	return task
+}
+
+// removeField deletes a dotted field path, descending into objects and into
+// every element of arrays of objects
+func removeField(value interface{}, path []string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(path) == 1 {
+			delete(v, path[0])
+			return
+		}
+		if child, ok := v[path[0]]; ok {
+			removeField(child, path[1:])
+		}
+	case []interface{}:
+		for _, item := range v {
+			removeField(item, path)
+		}
+	}
+}
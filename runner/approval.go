@@ -0,0 +1,36 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"fmt"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// holdForApproval parks a task whose QA result its task set's approval gate
+// selects. Until a human decides with task_approve or task_reject the task is
+// not done, so dependent tasks wait and reports leave its result out.
+func (r *Runner) holdForApproval(project, path string, task *global.Task) {
+	taskSet, err := r.tasks.GetTaskSet(project, path)
+	if err != nil || !taskSet.Approval.Requires(task.QA.Verdict, task.QA.Severity) {
+		return
+	}
+
+	updates := map[string]interface{}{
+		"work": map[string]interface{}{
+			"status": global.ExecutionStatusAwaitingApproval,
+		},
+	}
+	if _, err := r.tasks.UpdateTask(project, task.UUID, updates); err != nil {
+		r.logger.Errorf("Task %d: Failed to hold task for approval: %v", task.ID, err)
+		return
+	}
+	task.Work.Status = global.ExecutionStatusAwaitingApproval
+
+	r.logger.Infof("Task %d: QA result (verdict: %s, severity: %s) awaits approval in project %s", task.ID, task.QA.Verdict, task.QA.Severity, project)
+	r.logToProject(project, fmt.Sprintf("Task %d: Awaiting approval (use task_approve or task_reject)", task.ID))
+}
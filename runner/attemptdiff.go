@@ -0,0 +1,261 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/PivotLLM/Maestro/global"
+	"github.com/PivotLLM/Maestro/templates"
+)
+
+// DiffAttempts compares the responses of two attempts (invocations) of a task
+// for a role ("worker" or "qa"). With to 0 the latest attempt is used, and with
+// from 0 the one before to. Responses that are both JSON are compared field by
+// field; otherwise they are compared line by line.
+func (r *Runner) DiffAttempts(project, taskUUID, role string, from, to int) (*global.AttemptDiff, error) {
+	if role == "" {
+		role = "worker"
+	}
+	if role != "worker" && role != "qa" {
+		return nil, fmt.Errorf("invalid role %q (must be worker or qa)", role)
+	}
+
+	task, path, err := r.tasks.GetTask(project, taskUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A running task's history is in memory until its result is saved
+	history := r.getTaskHistory(task.UUID)
+	if history == nil {
+		data, err := os.ReadFile(r.tasks.ResultFile(project, path, task, global.ResultFileSuffix))
+		if err != nil {
+			return nil, fmt.Errorf("task %d has no result to compare", task.ID)
+		}
+		var result global.TaskResult
+		if err := json.Unmarshal(data, &result); err != nil {
+			return nil, fmt.Errorf("failed to parse result of task %d: %w", task.ID, err)
+		}
+		if result.CompactedAt != nil {
+			return nil, fmt.Errorf("the result of task %d was compacted and no longer holds its responses", task.ID)
+		}
+		history = result.History
+	}
+
+	// The last response of each invocation is its attempt
+	attempts := make(map[int]global.Message)
+	var invocations []int
+	for _, msg := range history {
+		if msg.Role != role || msg.ExitCode == nil {
+			continue
+		}
+		if _, ok := attempts[msg.Invocation]; !ok {
+			invocations = append(invocations, msg.Invocation)
+		}
+		attempts[msg.Invocation] = msg
+	}
+	sort.Ints(invocations)
+	if len(invocations) < 2 {
+		return nil, fmt.Errorf("task %d has %d %s attempt(s); at least 2 are needed to compare", task.ID, len(invocations), role)
+	}
+
+	if to == 0 {
+		to = invocations[len(invocations)-1]
+	}
+	if from == 0 {
+		for _, inv := range invocations {
+			if inv < to {
+				from = inv
+			}
+		}
+	}
+	before, ok := attempts[from]
+	if !ok {
+		return nil, fmt.Errorf("task %d has no %s attempt %d (attempts: %s)", task.ID, role, from, formatInvocations(invocations))
+	}
+	after, ok := attempts[to]
+	if !ok {
+		return nil, fmt.Errorf("task %d has no %s attempt %d (attempts: %s)", task.ID, role, to, formatInvocations(invocations))
+	}
+
+	return &global.AttemptDiff{
+		Project:      project,
+		TaskUUID:     task.UUID,
+		TaskID:       task.ID,
+		TaskTitle:    task.Title,
+		Role:         role,
+		From:         attemptInfo(before),
+		To:           attemptInfo(after),
+		ResponseDiff: DiffResponses(attemptResponse(before), attemptResponse(after)),
+	}, nil
+}
+
+// DiffResponses compares two responses: field by field when both are JSON,
+// otherwise line by line
+func DiffResponses(before, after string) global.ResponseDiff {
+	var diff global.ResponseDiff
+	var beforeJSON, afterJSON any
+	if json.Unmarshal([]byte(templates.ExtractJSON(before)), &beforeJSON) == nil &&
+		json.Unmarshal([]byte(templates.ExtractJSON(after)), &afterJSON) == nil {
+		diff.Format = "json"
+		diffJSON(&diff, "", beforeJSON, afterJSON)
+		diff.Identical = len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Changed) == 0
+	} else {
+		diff.Format = "text"
+		diff.TextDiff = diffLines(before, after)
+		diff.Identical = before == after
+	}
+	return diff
+}
+
+// attemptInfo identifies the attempt of a response message
+func attemptInfo(msg global.Message) global.AttemptInfo {
+	return global.AttemptInfo{
+		Invocation:   msg.Invocation,
+		LLMModelID:   msg.LLMModelID,
+		Timestamp:    msg.Timestamp,
+		ResponseSize: msg.ResponseSize,
+	}
+}
+
+// attemptResponse returns the response of a message: the parser-extracted text
+// if it was kept, else the raw stdout
+func attemptResponse(msg global.Message) string {
+	if msg.Text != "" {
+		return msg.Text
+	}
+	if msg.Stdout != "" {
+		return msg.Stdout
+	}
+	return msg.Content
+}
+
+// formatInvocations lists invocation numbers for an error message
+func formatInvocations(invocations []int) string {
+	parts := make([]string, len(invocations))
+	for i, inv := range invocations {
+		parts[i] = fmt.Sprint(inv)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// diffJSON records the differences between two decoded JSON values under path.
+// Objects are compared by key and arrays by index.
+func diffJSON(diff *global.ResponseDiff, path string, before, after any) {
+	beforeMap, beforeIsMap := before.(map[string]any)
+	afterMap, afterIsMap := after.(map[string]any)
+	if beforeIsMap && afterIsMap {
+		keys := make(map[string]bool)
+		for k := range beforeMap {
+			keys[k] = true
+		}
+		for k := range afterMap {
+			keys[k] = true
+		}
+		sorted := make([]string, 0, len(keys))
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+		for _, k := range sorted {
+			field := k
+			if path != "" {
+				field = path + "." + k
+			}
+			b, inBefore := beforeMap[k]
+			a, inAfter := afterMap[k]
+			switch {
+			case !inBefore:
+				diff.Added = append(diff.Added, global.DiffFieldChange{Field: field, After: pipelineValue(a)})
+			case !inAfter:
+				diff.Removed = append(diff.Removed, global.DiffFieldChange{Field: field, Before: pipelineValue(b)})
+			default:
+				diffJSON(diff, field, b, a)
+			}
+		}
+		return
+	}
+
+	beforeList, beforeIsList := before.([]any)
+	afterList, afterIsList := after.([]any)
+	if beforeIsList && afterIsList {
+		for i := 0; i < max(len(beforeList), len(afterList)); i++ {
+			field := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(beforeList):
+				diff.Added = append(diff.Added, global.DiffFieldChange{Field: field, After: pipelineValue(afterList[i])})
+			case i >= len(afterList):
+				diff.Removed = append(diff.Removed, global.DiffFieldChange{Field: field, Before: pipelineValue(beforeList[i])})
+			default:
+				diffJSON(diff, field, beforeList[i], afterList[i])
+			}
+		}
+		return
+	}
+
+	b, a := pipelineValue(before), pipelineValue(after)
+	if b != a || fmt.Sprintf("%T", before) != fmt.Sprintf("%T", after) {
+		if path == "" {
+			path = "$"
+		}
+		diff.Changed = append(diff.Changed, global.DiffFieldChange{Field: path, Before: b, After: a})
+	}
+}
+
+// diffLines returns the lines removed ("- ") from before and added ("+ ") in
+// after, in order, from a longest-common-subsequence line diff. Lines shared by
+// both are left out, and the result is capped at MaxAttemptDiffLines.
+func diffLines(before, after string) []string {
+	if before == after {
+		return nil
+	}
+	a, b := strings.Split(before, "\n"), strings.Split(after, "\n")
+
+	// Common leading and trailing lines need no comparison
+	for len(a) > 0 && len(b) > 0 && a[0] == b[0] {
+		a, b = a[1:], b[1:]
+	}
+	for len(a) > 0 && len(b) > 0 && a[len(a)-1] == b[len(b)-1] {
+		a, b = a[:len(a)-1], b[:len(b)-1]
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var lines []string
+	i, j := 0, 0
+	for (i < len(a) || j < len(b)) && len(lines) < global.MaxAttemptDiffLines {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, "- "+a[i])
+			i++
+		default:
+			lines = append(lines, "+ "+b[j])
+			j++
+		}
+	}
+	return lines
+}
@@ -0,0 +1,232 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// Benchmarks of the hot paths of chatty orchestrating clients, run against a
+// synthetic large project:
+//
+//	go test ./runner -run '^$' -bench . -benchmem
+//
+// TestLargeProjectPerformance runs the same paths once with time budgets, so a
+// regression that makes them scale badly fails the normal test run.
+
+// Size of the synthetic large project
+const (
+	largeTaskSets    = 20
+	largeTasksPerSet = 500 // 10,000 tasks
+	largeFiles       = 1000
+)
+
+// largeProjectBudgets are the most each hot path may take on the large project.
+// They are far above the usual times, to stay clear of slow machines while
+// catching work that grows with the square of the project size.
+var largeProjectBudgets = map[string]time.Duration{
+	"ListTaskSets":     time.Second,
+	"ListTasks":        time.Second,
+	"GetTaskStatus":    time.Second,
+	"GetResults":       3 * time.Second,
+	"GenerateReport":   3 * time.Second,
+	"SearchFiles":      2 * time.Second,
+	"SearchReports":    time.Second,
+	"BulkUpdateStatus": 2 * time.Second,
+}
+
+// generateLargeProject creates a project of taskSets task sets of tasksPerSet
+// completed tasks, each with a JSON result, and files project files of a few
+// paragraphs. Results and files mention "encryption" in one item of ten, for
+// searches to find.
+func generateLargeProject(tb testing.TB, runner *testRunner, project string, taskSets, tasksPerSet, files int) {
+	tb.Helper()
+
+	if _, err := runner.projects.Create(project, "Large Project", "synthetic benchmark project", "", "", "none", ""); err != nil {
+		tb.Fatalf("Failed to create project: %v", err)
+	}
+
+	for s := 0; s < taskSets; s++ {
+		path := fmt.Sprintf("area-%02d/controls", s)
+		if _, err := runner.tasks.CreateTaskSet(project, path, fmt.Sprintf("Area %d", s), "", nil, true, global.Limits{}, false, "", "", nil); err != nil {
+			tb.Fatalf("Failed to create task set: %v", err)
+		}
+		for start := 0; start < tasksPerSet; start += global.MaxBulkTasks {
+			var defs []global.TaskDefinition
+			for i := start; i < tasksPerSet && i < start+global.MaxBulkTasks; i++ {
+				defs = append(defs, global.TaskDefinition{
+					Title:      fmt.Sprintf("Control %d.%d", s, i),
+					Type:       []string{"access", "logging", "backup", "network"}[i%4],
+					ExternalID: fmt.Sprintf("C-%d-%d", s, i),
+					Prompt:     "Assess the control against the evidence",
+				})
+			}
+			if result, err := runner.tasks.CreateTasks(project, path, defs, false); err != nil || len(result.Errors) > 0 {
+				tb.Fatalf("Failed to create tasks: %v %v", err, result)
+			}
+		}
+
+		taskSet, err := runner.tasks.GetTaskSet(project, path)
+		if err != nil {
+			tb.Fatalf("Failed to get task set: %v", err)
+		}
+		for i := range taskSet.Tasks {
+			task := &taskSet.Tasks[i]
+			topic := "access review"
+			if i%10 == 0 {
+				topic = "encryption at rest"
+			}
+			response, _ := json.Marshal(map[string]interface{}{
+				"summary":  fmt.Sprintf("%s: the %s control is partly effective", task.Title, topic),
+				"severity": []string{"low", "medium", "high"}[i%3],
+				"score":    i % 10,
+				"findings": []map[string]string{{"severity": "medium", "detail": "Evidence is incomplete for " + topic}},
+			})
+			data, _ := json.Marshal(global.TaskResult{
+				TaskUUID:  task.UUID,
+				TaskTitle: task.Title,
+				Worker:    global.WorkerResult{Response: string(response), Status: global.ExecutionStatusDone},
+			})
+			file := runner.tasks.ResultFile(project, path, task, global.ResultFileSuffix)
+			if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+				tb.Fatalf("Failed to create results dir: %v", err)
+			}
+			if err := os.WriteFile(file, data, 0644); err != nil {
+				tb.Fatalf("Failed to write result: %v", err)
+			}
+		}
+		if _, err := runner.tasks.BulkUpdateStatus(project, path, "", "", global.ExecutionStatusDone, "", false); err != nil {
+			tb.Fatalf("Failed to complete tasks: %v", err)
+		}
+	}
+
+	for f := 0; f < files; f++ {
+		topic := "user access reviews are performed quarterly"
+		if f%10 == 0 {
+			topic = "disk encryption is enabled on all servers"
+		}
+		content := fmt.Sprintf("# Evidence %d\n\nThe policy states that %s.\n\nOwner: IT operations. Reviewed annually.\n", f, topic)
+		if _, err := runner.projects.PutFile(project, fmt.Sprintf("evidence/dir-%02d/doc-%04d.md", f%20, f), content, ""); err != nil {
+			tb.Fatalf("Failed to write file: %v", err)
+		}
+	}
+}
+
+// largeProjectOps returns the hot paths, each run once per call
+func largeProjectOps(tb testing.TB, runner *testRunner, project string) map[string]func() {
+	check := func(name string, err error) {
+		if err != nil {
+			tb.Fatalf("%s failed: %v", name, err)
+		}
+	}
+	return map[string]func(){
+		"ListTaskSets": func() {
+			_, err := runner.tasks.ListTaskSets(project, "")
+			check("ListTaskSets", err)
+		},
+		"ListTasks": func() {
+			_, err := runner.tasks.ListTasks(project, "area-10/controls", global.ExecutionStatusDone, "", 100, 200, "")
+			check("ListTasks", err)
+		},
+		"GetTaskStatus": func() {
+			_, err := runner.GetTaskStatus(project, "", "")
+			check("GetTaskStatus", err)
+		},
+		"GetResults": func() {
+			_, err := runner.GetResults(&global.ResultsRequest{Project: project, Limit: 100, Offset: 5000, Summary: true})
+			check("GetResults", err)
+		},
+		"GenerateReport": func() {
+			_, err := runner.GenerateReport(project, "area-00", nil, false)
+			check("GenerateReport", err)
+			check("EndReport", runner.projects.EndReport(project))
+		},
+		"SearchFiles": func() {
+			_, _, err := runner.projects.SearchFiles(project, "encryption", 50, 0)
+			check("SearchFiles", err)
+		},
+		"SearchReports": func() {
+			_, err := runner.projects.SearchReports(project, "encryption at rest", 50, 0)
+			check("SearchReports", err)
+		},
+		"BulkUpdateStatus": func() {
+			_, err := runner.tasks.BulkUpdateStatus(project, "", "", global.ExecutionStatusDone, global.ExecutionStatusWaiting, "", true)
+			check("BulkUpdateStatus", err)
+		},
+	}
+}
+
+// benchmarkLargeProject runs one hot path against a freshly generated large project
+func benchmarkLargeProject(b *testing.B, name string) {
+	runner, tmpDir := setupTestRunner(b)
+	defer os.RemoveAll(tmpDir)
+	generateLargeProject(b, runner, "large", largeTaskSets, largeTasksPerSet, largeFiles)
+	ops := largeProjectOps(b, runner, "large")
+	if name == "SearchReports" {
+		ops["GenerateReport"]()
+	}
+	op := ops[name]
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		op()
+	}
+}
+
+func BenchmarkListTaskSets(b *testing.B)     { benchmarkLargeProject(b, "ListTaskSets") }
+func BenchmarkListTasks(b *testing.B)        { benchmarkLargeProject(b, "ListTasks") }
+func BenchmarkGetTaskStatus(b *testing.B)    { benchmarkLargeProject(b, "GetTaskStatus") }
+func BenchmarkGetResults(b *testing.B)       { benchmarkLargeProject(b, "GetResults") }
+func BenchmarkGenerateReport(b *testing.B)   { benchmarkLargeProject(b, "GenerateReport") }
+func BenchmarkSearchFiles(b *testing.B)      { benchmarkLargeProject(b, "SearchFiles") }
+func BenchmarkSearchReports(b *testing.B)    { benchmarkLargeProject(b, "SearchReports") }
+func BenchmarkBulkUpdateStatus(b *testing.B) { benchmarkLargeProject(b, "BulkUpdateStatus") }
+
+// TestLargeProjectPerformance runs each hot path on the large project and fails
+// when one exceeds its budget. Skipped with -short, as generating the project
+// takes a few seconds.
+func TestLargeProjectPerformance(t *testing.T) {
+	if testing.Short() {
+		t.Skip("large project performance test skipped in short mode")
+	}
+
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+	generateLargeProject(t, runner, "large", largeTaskSets, largeTasksPerSet, largeFiles)
+
+	ops := largeProjectOps(t, runner, "large")
+	for _, name := range []string{"ListTaskSets", "ListTasks", "GetTaskStatus", "GetResults", "GenerateReport", "SearchFiles", "SearchReports", "BulkUpdateStatus"} {
+		start := time.Now()
+		ops[name]()
+		elapsed := time.Since(start)
+		t.Logf("%s: %v", name, elapsed)
+		if budget := largeProjectBudgets[name]; elapsed > budget {
+			t.Errorf("%s took %v on %d tasks and %d files (budget %v)", name, elapsed, largeTaskSets*largeTasksPerSet, largeFiles, budget)
+		}
+	}
+
+	// The searches find what the generator planted
+	status, err := runner.GetTaskStatus("large", "", "")
+	if err != nil || status.TotalTasks != largeTaskSets*largeTasksPerSet || status.Done != status.TotalTasks {
+		t.Errorf("GetTaskStatus() = %+v, %v", status, err)
+	}
+	items, total, err := runner.projects.SearchFiles("large", "encryption", 50, 0)
+	if err != nil || total != largeFiles/10 || len(items) != 50 {
+		t.Errorf("SearchFiles() = %d of %d, %v", len(items), total, err)
+	}
+	reports, err := runner.projects.SearchReports("large", "encryption at rest", 0, 0)
+	if err != nil || reports.Total == 0 {
+		t.Errorf("SearchReports() = %+v, %v", reports, err)
+	}
+}
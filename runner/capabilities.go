@@ -0,0 +1,45 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// subsystemRemedies says how to make each optional runner subsystem available
+var subsystemRemedies = map[string]string{
+	global.SubsystemProjects:  "the runner was created without a projects service; create it with one, or use project files instead",
+	global.SubsystemPlaybooks: "the runner was created without a playbooks service; create it with one, or copy the file into the project and use source 'project'",
+	global.SubsystemReference: "the runner was created without a reference service; create it with one, or copy the file into the project and use source 'project'",
+}
+
+// Capabilities reports which of the runner's optional subsystems are active
+func (r *Runner) Capabilities() []global.Capability {
+	capability := func(name string, active bool, features ...string) global.Capability {
+		c := global.Capability{Name: name, Active: active, Features: features}
+		if !active {
+			c.Detail = subsystemRemedies[name]
+		}
+		return c
+	}
+	return []global.Capability{
+		capability(global.SubsystemProjects, r.projects != nil, "reports", "deliverables", "project logs", "allowed_llms", "prompt redaction"),
+		capability(global.SubsystemPlaybooks, r.playbooks != nil, "playbook instructions_file", "playbook templates and schemas", "pipeline_apply"),
+		capability(global.SubsystemReference, r.reference != nil, "reference instructions_file", "reference report templates"),
+		capability(global.SubsystemShared, r.shared != nil, "shared instructions_file"),
+	}
+}
+
+// RequireSubsystem returns a SubsystemUnavailableError if the named subsystem
+// is not active, and nil if it is
+func (r *Runner) RequireSubsystem(name, feature string) error {
+	for _, c := range r.Capabilities() {
+		if c.Name == name && !c.Active {
+			return &global.SubsystemUnavailableError{Subsystem: name, Feature: feature, Remedy: c.Detail}
+		}
+	}
+	return nil
+}
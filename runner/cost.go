@@ -0,0 +1,44 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"fmt"
+
+	"github.com/PivotLLM/Maestro/global"
+	"github.com/PivotLLM/Maestro/llm"
+)
+
+// runCostLimit returns the max_cost_usd for a run: the first task set's limit,
+// falling back to the runner limits in the config
+func (r *Runner) runCostLimit(taskSets []*global.TaskSet) float64 {
+	if len(taskSets) > 0 && taskSets[0].Limits.MaxCostUSD > 0 {
+		return taskSets[0].Limits.MaxCostUSD
+	}
+	return r.config.Runner().Limits.MaxCostUSD
+}
+
+// estimateCost returns the estimated cost of an LLM call: from the LLM's configured
+// pricing when it has one, otherwise the cost reported by the provider
+func (r *Runner) estimateCost(llmID string, result *llm.DispatchResult) float64 {
+	if result == nil {
+		return 0
+	}
+	if cfg := r.config.GetLLM(llmID); cfg != nil && cfg.Pricing != nil {
+		return cfg.Pricing.Cost(result.InputTokens, result.OutputTokens, result.CacheReadTokens, result.CacheCreationTokens)
+	}
+	return result.CostUSD
+}
+
+// recordSpend adds the estimated cost of an LLM call to the run budget and logs
+// when it reaches the run's cost limit, which halts the run
+func (r *Runner) recordSpend(project string, taskID int, budget *runBudget, llmID string, result *llm.DispatchResult) {
+	if !budget.addCost(r.estimateCost(llmID, result)) {
+		return
+	}
+	r.logger.Warnf("Task %d: Cost limit reached for project %s: spent $%.4f of $%.4f, halting run", taskID, project, budget.spent(), budget.maxCostUSD)
+	r.logToProject(project, fmt.Sprintf("Task %d: Cost limit reached: spent $%.4f of $%.4f. No further LLM calls will be made in this run.", taskID, budget.spent(), budget.maxCostUSD))
+}
@@ -0,0 +1,144 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// BuildDashboard computes the project dashboard from the current task state and
+// result files. Last run information is carried over from the saved dashboard.
+func (r *Runner) BuildDashboard(project string) (*global.ProjectDashboard, error) {
+	taskSetList, err := r.tasks.ListTaskSets(project, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list task sets: %w", err)
+	}
+
+	dashboard := &global.ProjectDashboard{
+		Project:           project,
+		GeneratedAt:       r.config.Clock().Now(),
+		ByStatus:          make(map[string]int),
+		ByQAVerdict:       make(map[string]int),
+		BySeverity:        make(map[string]int),
+		ByQAIssueSeverity: make(map[string]int),
+		TaskSets:          []global.DashboardTaskSet{},
+	}
+
+	for _, ts := range taskSetList.TaskSets {
+		tsSummary := global.DashboardTaskSet{
+			Path:     ts.Path,
+			Title:    ts.Title,
+			ByStatus: make(map[string]int),
+		}
+
+		for _, task := range ts.Tasks {
+			tsSummary.TotalTasks++
+			tsSummary.ByStatus[task.Work.Status]++
+			dashboard.TotalTasks++
+			dashboard.ByStatus[task.Work.Status]++
+			if task.QA.Enabled && task.QA.Verdict != "" {
+				dashboard.ByQAVerdict[task.QA.Verdict]++
+			}
+
+			data, err := os.ReadFile(r.tasks.ResultFile(project, ts.Path, &task, global.ResultFileSuffix))
+			if err != nil {
+				continue
+			}
+			var result global.TaskResult
+			if err := json.Unmarshal(data, &result); err != nil {
+				continue
+			}
+
+			addUsage(&dashboard.Usage, result.History)
+			if severity := resultSeverity(result.Worker.Response); severity != "" {
+				dashboard.BySeverity[severity]++
+			}
+			if result.QA != nil {
+				for _, severity := range qaIssueSeverities(result.QA.Response) {
+					dashboard.ByQAIssueSeverity[severity]++
+				}
+			}
+		}
+
+		dashboard.TaskSets = append(dashboard.TaskSets, tsSummary)
+	}
+
+	if r.projects != nil {
+		if saved, err := r.projects.GetDashboard(project); err == nil && saved != nil {
+			dashboard.LastRun = saved.LastRun
+		}
+	}
+
+	return dashboard, nil
+}
+
+// writeDashboard builds the dashboard with the given last run and saves it to dashboard.json
+func (r *Runner) writeDashboard(project string, lastRun *global.DashboardRun) {
+	if r.projects == nil {
+		return
+	}
+
+	dashboard, err := r.BuildDashboard(project)
+	if err != nil {
+		r.logger.Warnf("Failed to build dashboard for project %s: %v", project, err)
+		return
+	}
+	if lastRun != nil {
+		dashboard.LastRun = lastRun
+	}
+
+	if err := r.projects.SaveDashboard(project, dashboard); err != nil {
+		r.logger.Warnf("Failed to save dashboard for project %s: %v", project, err)
+	}
+}
+
+// addUsage adds the LLM calls, tokens and cost recorded in a task history.
+// Only response messages (those with an exit code) represent LLM invocations.
+func addUsage(usage *global.DashboardUsage, history []global.Message) {
+	for _, msg := range history {
+		if msg.ExitCode == nil {
+			continue
+		}
+		usage.LLMCalls++
+		usage.InputTokens += msg.InputTokens
+		usage.OutputTokens += msg.OutputTokens
+		usage.CacheReadTokens += msg.CacheReadTokens
+		usage.CacheCreationTokens += msg.CacheCreationTokens
+		usage.CostUSD += msg.CostUSD
+	}
+}
+
+// resultSeverity returns the lowercased top-level "severity" field of a JSON result
+func resultSeverity(response string) string {
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(response), &fields); err != nil {
+		return ""
+	}
+	severity, _ := fields["severity"].(string)
+	return strings.ToLower(strings.TrimSpace(severity))
+}
+
+// qaIssueSeverities returns the lowercased severity of each issue in a QA response
+func qaIssueSeverities(response string) []string {
+	var qa struct {
+		Issues []map[string]interface{} `json:"issues"`
+	}
+	if err := json.Unmarshal([]byte(response), &qa); err != nil {
+		return nil
+	}
+	var severities []string
+	for _, issue := range qa.Issues {
+		if severity, ok := issue["severity"].(string); ok && strings.TrimSpace(severity) != "" {
+			severities = append(severities, strings.ToLower(strings.TrimSpace(severity)))
+		}
+	}
+	return severities
+}
@@ -0,0 +1,127 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/PivotLLM/Maestro/global"
+	"github.com/PivotLLM/Maestro/reporting"
+)
+
+// GenerateDeliverable fills a DOCX template with the project's findings and saves
+// the result as a project file. The template is a project file, or a playbook file
+// ("playbook-name/path") when source is "playbook". pathFilter limits the findings
+// to task sets under a path, and extra adds or overrides template values.
+func (r *Runner) GenerateDeliverable(project, templatePath, source, outputPath, pathFilter string, extra map[string]interface{}) (*global.DeliverableResult, error) {
+	if err := r.RequireSubsystem(global.SubsystemProjects, "deliverable generation"); err != nil {
+		return nil, err
+	}
+
+	template, err := r.loadBinaryTemplate(project, templatePath, source)
+	if err != nil {
+		return nil, err
+	}
+
+	if outputPath == "" {
+		outputPath = "deliverables/" + path.Base(templatePath)
+	}
+	if !strings.HasSuffix(strings.ToLower(outputPath), ".docx") {
+		return nil, fmt.Errorf("output must be a .docx file: %s", outputPath)
+	}
+
+	data, findings, err := r.deliverableData(project, pathFilter)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range extra {
+		data[key] = value
+	}
+
+	filled, unresolved, err := reporting.FillDOCX(template, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := r.projects.PutFile(project, outputPath, string(filled), "Deliverable generated from "+templatePath); err != nil {
+		return nil, fmt.Errorf("failed to save deliverable: %w", err)
+	}
+
+	r.logToProject(project, fmt.Sprintf("Deliverable generated: %s from template %s (%d findings)", outputPath, templatePath, findings))
+	if len(unresolved) > 0 {
+		r.logger.Warnf("Deliverable %s for project %s has unresolved placeholders: %s", outputPath, project, strings.Join(unresolved, ", "))
+	}
+
+	return &global.DeliverableResult{
+		Project:    project,
+		Template:   templatePath,
+		Output:     outputPath,
+		Findings:   findings,
+		SizeBytes:  len(filled),
+		Unresolved: unresolved,
+	}, nil
+}
+
+// loadBinaryTemplate reads a DOCX template from the project or a playbook
+func (r *Runner) loadBinaryTemplate(project, templatePath, source string) ([]byte, error) {
+	switch source {
+	case "", "project":
+		return r.projects.ReadBinaryFile(project, templatePath)
+	case "playbook":
+		if err := r.RequireSubsystem(global.SubsystemPlaybooks, "loading deliverable template "+templatePath); err != nil {
+			return nil, err
+		}
+		parts := strings.SplitN(templatePath, "/", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid playbook template format (expected 'playbook-name/path'): %s", templatePath)
+		}
+		return r.playbooks.ReadBinaryFile(parts[0], parts[1])
+	default:
+		return nil, fmt.Errorf("unknown template source: %s", source)
+	}
+}
+
+// deliverableData builds the values available to a deliverable template and
+// returns them with the number of findings
+func (r *Runner) deliverableData(project, pathFilter string) (map[string]interface{}, int, error) {
+	taskSetList, err := r.tasks.ListTaskSets(project, pathFilter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list task sets: %w", err)
+	}
+
+	filter := &reporting.ReportFilter{PathPrefix: pathFilter}
+	report := r.reporter.BuildReport(project, taskSetList.TaskSets, filter, r.tasks.GetResultsDir(project))
+	findings := r.reporter.Findings(report)
+
+	// Round-trip the summary through JSON so templates address it by its JSON names
+	var summary map[string]interface{}
+	raw, err := json.Marshal(report.Summary)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to build summary: %w", err)
+	}
+	if err := json.Unmarshal(raw, &summary); err != nil {
+		return nil, 0, fmt.Errorf("failed to build summary: %w", err)
+	}
+
+	title := project
+	if info, err := r.projects.Get(project); err == nil && info.Title != "" {
+		title = info.Title
+	}
+
+	clock := r.config.Clock()
+	data := map[string]interface{}{
+		"project":       project,
+		"project_title": title,
+		"date":          clock.Date(report.GeneratedAt),
+		"generated_at":  clock.Report(report.GeneratedAt),
+		"summary":       summary,
+		"findings":      findings,
+	}
+	return data, len(findings), nil
+}
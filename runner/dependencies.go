@@ -0,0 +1,91 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// readyTasks returns the tasks that are still waiting or due for retry and whose
+// dependencies are all done, keeping their order. Tasks in skip are left out.
+func (r *Runner) readyTasks(project string, tasks []*global.Task, skip map[string]bool) []*global.Task {
+	graph, err := r.tasks.DependencyGraph(project)
+	if err != nil {
+		r.logger.Warnf("Failed to build dependency graph for project %s: %v", project, err)
+		return nil
+	}
+
+	var ready []*global.Task
+	for _, task := range tasks {
+		if skip[task.UUID] {
+			continue
+		}
+		current := graph.Task(task.UUID)
+		if current == nil {
+			continue
+		}
+		if current.Work.Status != global.ExecutionStatusWaiting && current.Work.Status != global.ExecutionStatusRetry {
+			continue
+		}
+		if len(graph.Unmet(current)) > 0 {
+			continue
+		}
+		ready = append(ready, task)
+	}
+	return ready
+}
+
+// unmetDependencies returns the dependencies of a task in the task set at path
+// that are not done yet
+func (r *Runner) unmetDependencies(project, path string, task *global.Task) []string {
+	if len(task.DependsOn) == 0 {
+		if ts, err := r.tasks.GetTaskSet(project, path); err == nil && len(ts.DependsOn) == 0 {
+			return nil
+		}
+	}
+	graph, err := r.tasks.DependencyGraph(project)
+	if err != nil {
+		r.logger.Warnf("Failed to build dependency graph for project %s: %v", project, err)
+		return task.DependsOn
+	}
+	return graph.Unmet(task)
+}
+
+// logRemainingTasks reports the tasks still waiting at the end of a run: those
+// that could run but ran out of rounds, and those blocked by dependencies that
+// are not done. Neither is marked failed, so a future run can pick them up.
+func (r *Runner) logRemainingTasks(project, path string, maxRounds int) {
+	remaining := r.getTasksNeedingRetry(project, path)
+	ready := r.readyTasks(project, remaining, nil)
+
+	if len(ready) > 0 {
+		r.logger.Warnf("Max rounds (%d) reached with %d task(s) still waiting", maxRounds, len(ready))
+		r.logToProject(project, fmt.Sprintf("Max rounds (%d) reached with %d task(s) still waiting. Tasks remain in waiting status for future runs.", maxRounds, len(ready)))
+	}
+	if blocked := len(remaining) - len(ready); blocked > 0 {
+		r.logger.Infof("%d task(s) blocked by dependencies that are not done", blocked)
+		r.logToProject(project, fmt.Sprintf("%d task(s) blocked by dependencies that are not done. They will run once their dependencies are done.", blocked))
+	}
+}
+
+// sortByPriority orders tasks by descending priority, keeping their order (task
+// set order, then task ID) among equal priorities. With the "attempts" tie-break
+// policy, the tasks with the fewest worker attempts come first among equal
+// priorities, so retries do not hold back tasks that have not run yet.
+func sortByPriority(tasks []*global.Task, tieBreak string) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if tasks[i].Priority != tasks[j].Priority {
+			return tasks[i].Priority > tasks[j].Priority
+		}
+		if tieBreak == global.PriorityTieBreakAttempts {
+			return tasks[i].Work.Invocations < tasks[j].Work.Invocations
+		}
+		return false
+	})
+}
@@ -0,0 +1,331 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// DiffOptions selects the findings DiffProjects compares
+type DiffOptions struct {
+	Path            string   // Task set path (and the task sets under it) of the project's findings; "" for all
+	BaselineProject string   // Project to compare against; the project itself if only BaselinePath is set
+	BaselinePath    string   // Task set path of the baseline findings in a baseline project; "" for all
+	BaselineArchive string   // Report prefix of a finalized archive to compare against
+	KeyField        string   // Worker response field identifying a finding (default DefaultDiffKeyField)
+	CompareFields   []string // Worker response fields compared (default DefaultDiffCompareFields)
+	MatchBy         string   // DiffMatchKey (default) or DiffMatchTitle
+}
+
+// DiffProjects compares the findings of a project against a baseline: another
+// project (BaselineProject), another task set (BaselinePath, in the project or
+// the baseline project) or a finalized report archive (BaselineArchive, the
+// report prefix; the archive belongs to BaselineProject if set, else to project).
+// Each completed task result is one finding. With DiffMatchKey, a finding is
+// identified by the task's external ID, else by KeyField in the worker response,
+// else by the task title; with DiffMatchTitle, by the task title and type, so
+// re-runs whose tasks were recreated with other IDs still align. Findings only in
+// the project are new, findings only in the baseline are resolved, and findings in
+// both whose CompareFields differ are changed.
+func (r *Runner) DiffProjects(project string, opts DiffOptions) (*global.ProjectDiff, error) {
+	baselineProject, baselineArchive, keyField, compareFields := opts.BaselineProject, opts.BaselineArchive, opts.KeyField, opts.CompareFields
+	if baselineProject == "" && baselineArchive == "" && opts.BaselinePath == "" {
+		return nil, fmt.Errorf("a baseline project, task set or archive is required")
+	}
+	if baselineArchive != "" && opts.BaselinePath != "" {
+		return nil, fmt.Errorf("a baseline path cannot be used with a baseline archive")
+	}
+	if baselineProject == "" && baselineArchive == "" {
+		baselineProject = project
+		if opts.BaselinePath == opts.Path {
+			return nil, fmt.Errorf("the baseline task set is the task set compared")
+		}
+	}
+	matchBy := opts.MatchBy
+	if matchBy == "" {
+		matchBy = global.DiffMatchKey
+	}
+	if matchBy != global.DiffMatchKey && matchBy != global.DiffMatchTitle {
+		return nil, fmt.Errorf("invalid match_by %q (must be %s or %s)", matchBy, global.DiffMatchKey, global.DiffMatchTitle)
+	}
+	if keyField == "" {
+		keyField = global.DefaultDiffKeyField
+	}
+	if len(compareFields) == 0 {
+		compareFields = strings.Split(global.DefaultDiffCompareFields, ",")
+	}
+
+	current, err := r.projectResults(project, opts.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	var baseline []global.TaskResult
+	var baselineName string
+	if baselineArchive != "" {
+		archiveProject := baselineProject
+		if archiveProject == "" {
+			archiveProject = project
+		}
+		dir, err := r.projects.ArchiveResultsDir(archiveProject, baselineArchive)
+		if err != nil {
+			return nil, err
+		}
+		if baseline, err = archiveResults(dir); err != nil {
+			return nil, fmt.Errorf("failed to read archive results: %w", err)
+		}
+		baselineName = fmt.Sprintf("archive:%s/%s", archiveProject, baselineArchive)
+	} else {
+		if baseline, err = r.projectResults(baselineProject, opts.BaselinePath); err != nil {
+			return nil, err
+		}
+		baselineName = "project:" + baselineProject
+	}
+
+	diff := &global.ProjectDiff{
+		Project:       project,
+		Path:          opts.Path,
+		Baseline:      baselineName,
+		BaselinePath:  opts.BaselinePath,
+		GeneratedAt:   r.config.Clock().Now(),
+		MatchBy:       matchBy,
+		KeyField:      keyField,
+		CompareFields: compareFields,
+		New:           []global.DiffFinding{},
+		Resolved:      []global.DiffFinding{},
+		Changed:       []global.DiffFinding{},
+	}
+
+	before, dupBefore := diffFindings(baseline, matchBy, keyField, compareFields)
+	after, dupAfter := diffFindings(current, matchBy, keyField, compareFields)
+	diff.Summary.Baseline = len(before)
+	diff.Summary.Current = len(after)
+	diff.Summary.DuplicateKeys = dupBefore + dupAfter
+
+	for key, finding := range after {
+		old, ok := before[key]
+		if !ok {
+			diff.New = append(diff.New, finding)
+			continue
+		}
+		for _, field := range compareFields {
+			if old.Fields[field] != finding.Fields[field] {
+				finding.Changes = append(finding.Changes, global.DiffFieldChange{
+					Field:  field,
+					Before: old.Fields[field],
+					After:  finding.Fields[field],
+				})
+			}
+		}
+		if len(finding.Changes) > 0 {
+			diff.Changed = append(diff.Changed, finding)
+		} else {
+			diff.Summary.Unchanged++
+		}
+	}
+	for key, finding := range before {
+		if _, ok := after[key]; !ok {
+			diff.Resolved = append(diff.Resolved, finding)
+		}
+	}
+
+	for _, findings := range [][]global.DiffFinding{diff.New, diff.Resolved, diff.Changed} {
+		sort.Slice(findings, func(i, j int) bool { return findings[i].Key < findings[j].Key })
+	}
+	diff.Summary.New = len(diff.New)
+	diff.Summary.Resolved = len(diff.Resolved)
+	diff.Summary.Changed = len(diff.Changed)
+
+	return diff, nil
+}
+
+// FormatProjectDiff renders a project diff as a markdown delta report, with times
+// formatted by the clock
+func FormatProjectDiff(diff *global.ProjectDiff, clock *global.Clock) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# Delta Report: %s\n\n", diff.Project))
+	if diff.Path != "" {
+		sb.WriteString(fmt.Sprintf("**Task Sets**: %s\n", diff.Path))
+	}
+	sb.WriteString(fmt.Sprintf("**Baseline**: %s\n", diff.Baseline))
+	if diff.BaselinePath != "" {
+		sb.WriteString(fmt.Sprintf("**Baseline Task Sets**: %s\n", diff.BaselinePath))
+	}
+	sb.WriteString(fmt.Sprintf("**Generated**: %s\n\n", clock.Report(diff.GeneratedAt)))
+
+	sb.WriteString("## Summary\n\n")
+	sb.WriteString("| Metric | Count |\n")
+	sb.WriteString("|--------|-------|\n")
+	sb.WriteString(fmt.Sprintf("| Baseline Findings | %d |\n", diff.Summary.Baseline))
+	sb.WriteString(fmt.Sprintf("| Current Findings | %d |\n", diff.Summary.Current))
+	sb.WriteString(fmt.Sprintf("| New | %d |\n", diff.Summary.New))
+	sb.WriteString(fmt.Sprintf("| Resolved | %d |\n", diff.Summary.Resolved))
+	sb.WriteString(fmt.Sprintf("| Changed | %d |\n", diff.Summary.Changed))
+	sb.WriteString(fmt.Sprintf("| Unchanged | %d |\n", diff.Summary.Unchanged))
+
+	writeFindings := func(heading string, findings []global.DiffFinding) {
+		sb.WriteString(fmt.Sprintf("\n## %s\n\n", heading))
+		if len(findings) == 0 {
+			sb.WriteString("None.\n")
+			return
+		}
+		for _, finding := range findings {
+			if finding.Key == finding.Title {
+				sb.WriteString(fmt.Sprintf("- **%s**", finding.Key))
+			} else {
+				sb.WriteString(fmt.Sprintf("- **%s**: %s", finding.Key, finding.Title))
+			}
+			if len(finding.Changes) > 0 {
+				changes := make([]string, 0, len(finding.Changes))
+				for _, change := range finding.Changes {
+					changes = append(changes, fmt.Sprintf("%s: %s → %s", change.Field, displayValue(change.Before), displayValue(change.After)))
+				}
+				sb.WriteString(" (" + strings.Join(changes, "; ") + ")")
+			}
+			sb.WriteString("\n")
+		}
+	}
+	writeFindings("New Findings", diff.New)
+	writeFindings("Resolved Findings", diff.Resolved)
+	writeFindings("Changed Findings", diff.Changed)
+
+	return sb.String()
+}
+
+// projectResults returns the parsed result files of every task in a project, or
+// with path of the task sets at and under path
+func (r *Runner) projectResults(project, path string) ([]global.TaskResult, error) {
+	taskSetList, err := r.tasks.ListTaskSets(project, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []global.TaskResult
+	for _, ts := range taskSetList.TaskSets {
+		if path != "" && ts.Path != path && !strings.HasPrefix(ts.Path, path+global.TaskPathSeparator) {
+			continue
+		}
+		for _, task := range ts.Tasks {
+			data, err := os.ReadFile(r.tasks.ResultFile(project, ts.Path, &task, global.ResultFileSuffix))
+			if err != nil {
+				continue
+			}
+			var result global.TaskResult
+			if err := json.Unmarshal(data, &result); err != nil {
+				continue
+			}
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+// archiveResults returns the parsed result files under an archive's results directory
+func archiveResults(dir string) ([]global.TaskResult, error) {
+	var results []global.TaskResult
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), global.ResultFileSuffix) || strings.HasSuffix(d.Name(), global.ErrorFileSuffix) {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var result global.TaskResult
+		if err := json.Unmarshal(data, &result); err == nil {
+			results = append(results, result)
+		}
+		return nil
+	})
+	return results, err
+}
+
+// diffFindings keys the completed results by finding key and extracts the compared
+// fields. Returns the findings and the number of results skipped as duplicate keys.
+func diffFindings(results []global.TaskResult, matchBy, keyField string, compareFields []string) (map[string]global.DiffFinding, int) {
+	findings := make(map[string]global.DiffFinding)
+	duplicates := 0
+	for _, result := range results {
+		if result.Worker.Status == global.ExecutionStatusFailed {
+			continue
+		}
+		var fields map[string]interface{}
+		_ = json.Unmarshal([]byte(result.Worker.Response), &fields)
+
+		key := result.TaskExternalID
+		if matchBy == global.DiffMatchTitle {
+			key = titleKey(result)
+		}
+		if key == "" {
+			key = fieldString(fields, keyField)
+		}
+		if key == "" {
+			key = result.TaskTitle
+		}
+		if _, exists := findings[key]; exists {
+			duplicates++
+			continue
+		}
+
+		finding := global.DiffFinding{
+			Key:      key,
+			Title:    result.TaskTitle,
+			TaskUUID: result.TaskUUID,
+			Fields:   make(map[string]string, len(compareFields)),
+		}
+		for _, field := range compareFields {
+			finding.Fields[field] = fieldString(fields, field)
+		}
+		findings[key] = finding
+	}
+	return findings, duplicates
+}
+
+// titleKey identifies a finding by its task's title and, if set, type
+func titleKey(result global.TaskResult) string {
+	if result.TaskType == "" {
+		return result.TaskTitle
+	}
+	return fmt.Sprintf("%s (%s)", result.TaskTitle, result.TaskType)
+}
+
+// fieldString returns a top-level response field as a trimmed string ("" if absent)
+func fieldString(fields map[string]interface{}, name string) string {
+	value, ok := fields[name]
+	if !ok || value == nil {
+		return ""
+	}
+	if s, ok := value.(string); ok {
+		return strings.TrimSpace(s)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// displayValue shows an empty field value as "(none)" in delta reports
+func displayValue(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
@@ -94,7 +94,7 @@ func setupTestRunnerWithLLMConfig(t *testing.T, llmsJSON, defaultLLM string) (*t
 		reference.WithExternalDirs(externalDirs),
 		reference.WithLogger(logger),
 	)
-	playbooksService := playbooks.NewService(cfg.PlaybooksDir(), logger)
+	playbooksService := playbooks.NewService(cfg.PlaybooksDir(), cfg.ExportsDir(), cfg.PlaybookSnapshots(), logger)
 	projectsService := projects.NewService(cfg, logger)
 	tasksService := tasks.NewService(cfg, projectsService, logger)
 	llmService := llm.NewService(cfg, logger, nil)
@@ -166,7 +166,7 @@ func TestDispatch_NoLLMsEnabled(t *testing.T) {
 	rec := newCallbackRecorder()
 
 	projectName := "test-project"
-	if _, err := runner.projects.Create(projectName, "Test Project", "no-llm dispatch test", "", "", "none"); err != nil {
+	if _, err := runner.projects.Create(projectName, "Test Project", "no-llm dispatch test", "", "", "none", ""); err != nil {
 		t.Fatalf("Failed to create project: %v", err)
 	}
 
@@ -272,7 +272,7 @@ func TestDispatch_BuildPromptFailure(t *testing.T) {
 	rec := newCallbackRecorder()
 
 	projectName := "test-project"
-	if _, err := runner.projects.Create(projectName, "Test Project", "buildPrompt failure test", "", "", "none"); err != nil {
+	if _, err := runner.projects.Create(projectName, "Test Project", "buildPrompt failure test", "", "", "none", ""); err != nil {
 		t.Fatalf("Failed to create project: %v", err)
 	}
 
@@ -347,7 +347,7 @@ func TestDispatch_SuccessCallback(t *testing.T) {
 	rec := newCallbackRecorder()
 
 	projectName := "test-project"
-	if _, err := runner.projects.Create(projectName, "Test Project", "dispatch success test", "", "", "none"); err != nil {
+	if _, err := runner.projects.Create(projectName, "Test Project", "dispatch success test", "", "", "none", ""); err != nil {
 		t.Fatalf("Failed to create project: %v", err)
 	}
 
@@ -412,7 +412,7 @@ func TestDispatch_GetTaskFailureAfterCreate(t *testing.T) {
 	rec := newCallbackRecorder()
 
 	projectName := "test-project"
-	if _, err := runner.projects.Create(projectName, "Test Project", "GetTask failure test", "", "", "none"); err != nil {
+	if _, err := runner.projects.Create(projectName, "Test Project", "GetTask failure test", "", "", "none", ""); err != nil {
 		t.Fatalf("Failed to create project: %v", err)
 	}
 
@@ -421,14 +421,14 @@ func TestDispatch_GetTaskFailureAfterCreate(t *testing.T) {
 	// failed. Only the early GetTask call is mocked.
 	path := "dispatch/get-task-fails"
 	title := "get-task-fails dispatch"
-	if _, err := runner.tasks.CreateTaskSet(projectName, path, title, "", nil, false, global.Limits{}, true, ""); err != nil {
+	if _, err := runner.tasks.CreateTaskSet(projectName, path, title, "", nil, false, global.Limits{}, true, "", "", nil); err != nil {
 		t.Fatalf("Failed to create taskset: %v", err)
 	}
 	work := &global.WorkExecution{
 		Prompt: "irrelevant",
 		Status: global.ExecutionStatusWaiting,
 	}
-	task, err := runner.tasks.CreateTask(projectName, path, title, "", work, nil)
+	task, err := runner.tasks.CreateTask(projectName, path, title, "", "", work, nil)
 	if err != nil {
 		t.Fatalf("Failed to create task: %v", err)
 	}
@@ -155,7 +155,7 @@ func runEnvelopeGateCase(t *testing.T, c envelopeRunnerCase) {
 	defer os.RemoveAll(tmpDir)
 
 	projectName := "envelope-test"
-	if _, err := tr.projects.Create(projectName, "Envelope Test", "envelope gate", "", "", "none"); err != nil {
+	if _, err := tr.projects.Create(projectName, "Envelope Test", "envelope gate", "", "", "none", ""); err != nil {
 		t.Fatalf("create project: %v", err)
 	}
 
@@ -163,7 +163,7 @@ func runEnvelopeGateCase(t *testing.T, c envelopeRunnerCase) {
 	// existing TestRunReturnsImmediately path proves this. We still create a
 	// minimal taskset.
 	templates := createTestTemplates(t, tmpDir)
-	if _, err := tr.tasks.CreateTaskSet(projectName, "main", "Main", "envelope gate", templates, false, global.Limits{MaxWorker: 3, MaxRetries: 3, MaxQA: 1}, false, ""); err != nil {
+	if _, err := tr.tasks.CreateTaskSet(projectName, "main", "Main", "envelope gate", templates, false, global.Limits{MaxWorker: 3, MaxRetries: 3, MaxQA: 1}, false, "", "", nil); err != nil {
 		t.Fatalf("create taskset: %v", err)
 	}
 
@@ -171,7 +171,7 @@ func runEnvelopeGateCase(t *testing.T, c envelopeRunnerCase) {
 		Prompt:     "irrelevant",
 		LLMModelID: "envelope-llm",
 	}
-	task, err := tr.tasks.CreateTask(projectName, "main", "envelope-task", "test", work, nil)
+	task, err := tr.tasks.CreateTask(projectName, "main", "envelope-task", "test", "", work, nil)
 	if err != nil {
 		t.Fatalf("create task: %v", err)
 	}
@@ -275,7 +275,7 @@ func TestRecordHistoryResponse_PopulatesResourceFields(t *testing.T) {
 		Success:             true,
 	}
 
-	tr.Runner.recordHistoryResponse("test-uuid", "worker", result, "test-llm", 1)
+	tr.Runner.recordHistoryResponse("test-uuid", "worker", nil, result, "test-llm", 1)
 
 	historyAny, _ := tr.Runner.taskHistory.Load("test-uuid")
 	if historyAny == nil {
@@ -0,0 +1,28 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// emitEvent appends a task progress event to events.jsonl (best effort), so
+// clients can follow a run mid-flight with task_events
+func (r *Runner) emitEvent(project, path string, task *global.Task, event *global.TaskEvent) {
+	if r.projects == nil || task == nil {
+		return
+	}
+
+	event.Timestamp = time.Now()
+	event.Path = path
+	event.TaskID = task.ID
+	event.TaskUUID = task.UUID
+	if err := r.projects.AppendEvent(project, event); err != nil {
+		r.logger.Warnf("Task %d: Failed to record %s event: %v", task.ID, event.Event, err)
+	}
+}
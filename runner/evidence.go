@@ -0,0 +1,222 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// maxEvidenceTitleLength limits the title of generated follow-up tasks
+const maxEvidenceTitleLength = 120
+
+// CollectEvidenceRequests scans the worker and QA responses of every task under
+// pathPrefix for the standard "missing_evidence" field and consolidates the
+// entries into one list. Identical requests (ignoring case and whitespace) from
+// different tasks are merged, keeping every task that asked for them.
+func (r *Runner) CollectEvidenceRequests(project, pathPrefix string) (*global.EvidenceRequestList, error) {
+	taskSetList, err := r.tasks.ListTaskSets(project, pathPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list task sets: %w", err)
+	}
+
+	list := &global.EvidenceRequestList{
+		Project:     project,
+		Path:        pathPrefix,
+		GeneratedAt: r.config.Clock().Now(),
+		Requests:    []global.EvidenceRequest{},
+	}
+	index := make(map[string]int) // normalized description -> position in list.Requests
+
+	add := func(description string, source global.EvidenceSource) {
+		key := strings.ToLower(strings.Join(strings.Fields(description), " "))
+		if key == "" {
+			return
+		}
+		if i, ok := index[key]; ok {
+			list.Requests[i].Sources = append(list.Requests[i].Sources, source)
+			return
+		}
+		index[key] = len(list.Requests)
+		list.Requests = append(list.Requests, global.EvidenceRequest{
+			Description: strings.TrimSpace(description),
+			Sources:     []global.EvidenceSource{source},
+		})
+	}
+
+	for _, ts := range taskSetList.TaskSets {
+		for _, task := range ts.Tasks {
+			data, err := os.ReadFile(r.tasks.ResultFile(project, ts.Path, &task, global.ResultFileSuffix))
+			if err != nil {
+				continue
+			}
+			var result global.TaskResult
+			if err := json.Unmarshal(data, &result); err != nil {
+				continue
+			}
+			list.TasksScanned++
+
+			source := global.EvidenceSource{
+				TaskUUID:       task.UUID,
+				TaskExternalID: task.ExternalID,
+				TaskTitle:      task.Title,
+				Path:           ts.Path,
+			}
+			source.From = "worker"
+			for _, description := range missingEvidence(result.Worker.Response) {
+				add(description, source)
+			}
+			if result.QA != nil {
+				source.From = "qa"
+				for _, description := range missingEvidence(result.QA.Response) {
+					add(description, source)
+				}
+			}
+		}
+	}
+
+	list.Total = len(list.Requests)
+	return list, nil
+}
+
+// CreateEvidenceFollowUps adds one task per evidence request to the existing task
+// set at path, which supplies the templates the follow-up tasks answer with.
+// Tasks are created on hold (awaiting evidence) so they only run once released.
+// Requests that already have a task with the same title in the task set are
+// skipped, so the list can be regenerated safely. Returns the number of tasks created.
+func (r *Runner) CreateEvidenceFollowUps(project, path, prompt, llmModelID string, list *global.EvidenceRequestList) (int, error) {
+	taskSet, err := r.tasks.GetTaskSet(project, path)
+	if err != nil {
+		return 0, fmt.Errorf("follow-up task set %s: %w (create it with taskset_create first)", path, err)
+	}
+	existing := make(map[string]bool)
+	for _, task := range taskSet.Tasks {
+		existing[task.Title] = true
+	}
+
+	created := 0
+	for _, request := range list.Requests {
+		title := evidenceTaskTitle(request.Description)
+		if existing[title] {
+			continue
+		}
+
+		work := &global.WorkExecution{
+			Prompt:     evidenceTaskPrompt(prompt, request),
+			LLMModelID: llmModelID,
+		}
+		task, err := r.tasks.CreateTask(project, path, title, "evidence", "", work, nil)
+		if err != nil {
+			return created, fmt.Errorf("failed to create follow-up task: %w", err)
+		}
+		if _, err := r.tasks.UpdateTask(project, task.UUID, map[string]interface{}{
+			"work": map[string]interface{}{
+				"status":      global.ExecutionStatusOnHold,
+				"hold_reason": global.EvidenceHoldReason,
+			},
+		}); err != nil {
+			return created, fmt.Errorf("failed to hold follow-up task: %w", err)
+		}
+		existing[title] = true
+		created++
+	}
+
+	if created > 0 {
+		r.logToProject(project, fmt.Sprintf("Created %d evidence follow-up task(s) in %s", created, path))
+	}
+	return created, nil
+}
+
+// FormatEvidenceRequests renders an evidence request list as markdown suitable
+// for sending to the client, with times formatted by the clock
+func FormatEvidenceRequests(list *global.EvidenceRequestList, clock *global.Clock) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# Evidence Requests: %s\n\n", list.Project))
+	sb.WriteString(fmt.Sprintf("**Generated**: %s\n\n", clock.Report(list.GeneratedAt)))
+	if len(list.Requests) == 0 {
+		sb.WriteString("No missing evidence was reported.\n")
+		return sb.String()
+	}
+
+	sb.WriteString("Please provide the following:\n\n")
+	for i, request := range list.Requests {
+		sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, request.Description))
+		refs := make([]string, 0, len(request.Sources))
+		seen := make(map[string]bool)
+		for _, source := range request.Sources {
+			ref := source.TaskTitle
+			if source.TaskExternalID != "" {
+				ref = fmt.Sprintf("%s (%s)", source.TaskTitle, source.TaskExternalID)
+			}
+			if !seen[ref] {
+				seen[ref] = true
+				refs = append(refs, ref)
+			}
+		}
+		sb.WriteString(fmt.Sprintf("   - Related to: %s\n", strings.Join(refs, "; ")))
+	}
+	return sb.String()
+}
+
+// missingEvidence returns the entries of the top-level "missing_evidence" field
+// of a JSON response. The field may be a string, an array of strings, or an
+// array of objects with a "description" field.
+func missingEvidence(response string) []string {
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(response), &fields); err != nil {
+		return nil
+	}
+
+	switch value := fields[global.MissingEvidenceField].(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		var entries []string
+		for _, item := range value {
+			switch entry := item.(type) {
+			case string:
+				entries = append(entries, entry)
+			case map[string]interface{}:
+				if description, ok := entry["description"].(string); ok {
+					entries = append(entries, description)
+				}
+			}
+		}
+		return entries
+	}
+	return nil
+}
+
+// evidenceTaskTitle returns the follow-up task title for an evidence request
+func evidenceTaskTitle(description string) string {
+	title := []rune("Evidence: " + strings.Join(strings.Fields(description), " "))
+	if len(title) > maxEvidenceTitleLength {
+		return strings.TrimSpace(string(title[:maxEvidenceTitleLength-3])) + "..."
+	}
+	return string(title)
+}
+
+// evidenceTaskPrompt builds the prompt of a follow-up task from the caller's
+// instructions and the request it covers
+func evidenceTaskPrompt(prompt string, request global.EvidenceRequest) string {
+	if prompt == "" {
+		prompt = "Review the evidence provided for the request below and state whether it resolves the related findings."
+	}
+
+	var sb strings.Builder
+	sb.WriteString(prompt)
+	sb.WriteString("\n\nEvidence requested: ")
+	sb.WriteString(request.Description)
+	sb.WriteString("\n\nRequested by:\n")
+	for _, source := range request.Sources {
+		sb.WriteString(fmt.Sprintf("- %s (task %s in %s, %s)\n", source.TaskTitle, source.TaskUUID, source.Path, source.From))
+	}
+	return sb.String()
+}
@@ -0,0 +1,125 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// writeEvidence adds the files named by the task's evidence_files to a prompt
+// under an EVIDENCE section. Each chunk of a file is a prompt section of its
+// own, so when the prompt exceeds the LLM's context window the last chunks are
+// shortened or dropped first, after the project context.
+func (r *Runner) writeEvidence(project string, task *global.Task, prompt *promptAssembler) {
+	if len(task.Work.EvidenceFiles) == 0 {
+		return
+	}
+	paths, err := r.evidenceFiles(project, task)
+	if err != nil {
+		r.logEvidence(project, task, fmt.Sprintf("evidence files not attached: %v", err))
+		return
+	}
+	if len(paths) == 0 {
+		return
+	}
+
+	sb := prompt.section("evidence", promptEvidence)
+	sb.WriteString("=== EVIDENCE ===\n\n")
+	sb.WriteString(fmt.Sprintf("The contents of %d project file(s) are provided below as evidence for this task.\n\n", len(paths)))
+	for _, path := range paths {
+		item, err := r.projects.GetFile(project, path, 0, 0)
+		if err != nil {
+			r.logEvidence(project, task, fmt.Sprintf("evidence file %s not attached: %v", path, err))
+			continue
+		}
+		if !utf8.ValidString(item.Content) {
+			sb = prompt.section("evidence "+path, promptEvidence)
+			sb.WriteString(fmt.Sprintf("--- %s ---\n[binary file of %d bytes omitted]\n\n", path, item.SizeBytes))
+			continue
+		}
+		chunks := chunkEvidence(item.Content, global.EvidenceChunkBytes)
+		for i, chunk := range chunks {
+			if len(chunks) == 1 {
+				sb = prompt.section("evidence "+path, promptEvidence)
+				sb.WriteString(fmt.Sprintf("--- %s ---\n", path))
+			} else {
+				sb = prompt.section(fmt.Sprintf("evidence %s (part %d of %d)", path, i+1, len(chunks)), promptEvidence)
+				sb.WriteString(fmt.Sprintf("--- %s (part %d of %d) ---\n", path, i+1, len(chunks)))
+			}
+			sb.WriteString(chunk)
+			if !strings.HasSuffix(chunk, "\n") {
+				sb.WriteString("\n")
+			}
+			sb.WriteString("\n")
+		}
+	}
+}
+
+// evidenceFiles returns the project files matched by the task's evidence_files,
+// in the order of its entries and then by path, at most MaxEvidenceFiles of them.
+// ${NAME} references in the entries are replaced with the task's env.
+func (r *Runner) evidenceFiles(project string, task *global.Task) ([]string, error) {
+	files, err := r.projects.ListFiles(project, "")
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	seen := make(map[string]bool)
+	for _, entry := range task.Work.EvidenceFiles {
+		pattern := global.ExpandTaskEnv(entry, task.Env)
+		matched := false
+		for _, file := range files {
+			if !global.MatchEvidenceFile(pattern, file.Path) {
+				continue
+			}
+			matched = true
+			if !seen[file.Path] {
+				seen[file.Path] = true
+				paths = append(paths, file.Path)
+			}
+		}
+		if !matched {
+			r.logEvidence(project, task, fmt.Sprintf("evidence_files entry %q matches no project file", pattern))
+		}
+	}
+	if len(paths) > global.MaxEvidenceFiles {
+		r.logEvidence(project, task, fmt.Sprintf("evidence_files matches %d files; only the first %d are attached", len(paths), global.MaxEvidenceFiles))
+		paths = paths[:global.MaxEvidenceFiles]
+	}
+	return paths, nil
+}
+
+// logEvidence notes a problem with a task's evidence files in the logs
+func (r *Runner) logEvidence(project string, task *global.Task, message string) {
+	msg := fmt.Sprintf("Task %d: %s", task.ID, message)
+	r.logger.Warnf("%s", msg)
+	r.logToProject(project, msg)
+}
+
+// chunkEvidence splits the content of an evidence file into chunks of at most
+// size bytes, breaking after a newline where there is one
+func chunkEvidence(content string, size int) []string {
+	var chunks []string
+	for len(content) > size {
+		cut := strings.LastIndexByte(content[:size], '\n') + 1
+		if cut == 0 {
+			cut = size
+			for cut > 0 && !utf8.RuneStart(content[cut]) {
+				cut--
+			}
+		}
+		chunks = append(chunks, content[:cut])
+		content = content[cut:]
+	}
+	if content != "" || len(chunks) == 0 {
+		chunks = append(chunks, content)
+	}
+	return chunks
+}
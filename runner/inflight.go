@@ -0,0 +1,80 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+	"github.com/PivotLLM/Maestro/llm"
+)
+
+// inflightCall is an LLM call of a task that has not returned yet. The PID is
+// set from the dispatcher's goroutine once a command LLM starts.
+type inflightCall struct {
+	task global.InflightTask
+	pid  atomic.Int64
+}
+
+// dispatchTracked dispatches a task's LLM call, recording it for InflightTasks
+// until it returns. Calls to an LLM the project is not allowed to use are refused.
+// The prompt is redacted before dispatch, and the redacted values restored in
+// the response. The task's env is exported to command LLMs, and its timeout
+// replaces the LLM's.
+func (r *Runner) dispatchTracked(project, path string, task *global.Task, phase string, req *llm.DispatchRequest) (*llm.DispatchResult, error) {
+	if err := r.CheckLLMAllowed(project, req.LLMID); err != nil {
+		return nil, err
+	}
+	placeholders, err := r.redactPrompt(project, task, phase, req)
+	if err != nil {
+		return nil, fmt.Errorf("prompt redaction: %w", err)
+	}
+	call := &inflightCall{task: global.InflightTask{
+		TaskUUID:    task.UUID,
+		TaskID:      task.ID,
+		Project:     project,
+		Path:        path,
+		Phase:       phase,
+		LLMID:       req.LLMID,
+		StartedAt:   time.Now(),
+		PromptBytes: len(req.Prompt),
+	}}
+	req.OnStart = func(pid int) { call.pid.Store(int64(pid)) }
+	if req.Env == nil {
+		req.Env = task.Env
+	}
+	if req.Timeout == 0 && task.Limits != nil {
+		req.Timeout = task.Limits.Timeout
+	}
+
+	r.inflight.Store(call, struct{}{})
+	defer r.inflight.Delete(call)
+	result, err := r.llm.Dispatch(req)
+	if placeholders != nil && result != nil {
+		result.Text = placeholders.Restore(result.Text)
+		result.Stdout = placeholders.Restore(result.Stdout)
+	}
+	return result, err
+}
+
+// InflightTasks returns the LLM calls in progress across all runs, oldest first
+func (r *Runner) InflightTasks() []global.InflightTask {
+	now := time.Now()
+	calls := make([]global.InflightTask, 0)
+	r.inflight.Range(func(key, _ any) bool {
+		call := key.(*inflightCall)
+		t := call.task
+		t.ElapsedMs = now.Sub(t.StartedAt).Milliseconds()
+		t.PID = int(call.pid.Load())
+		calls = append(calls, t)
+		return true
+	})
+	sort.Slice(calls, func(i, j int) bool { return calls[i].StartedAt.Before(calls[j].StartedAt) })
+	return calls
+}
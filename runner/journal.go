@@ -0,0 +1,324 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// runJournal writes the write-ahead journal of one run or dispatch. A nil
+// runJournal records nothing.
+type runJournal struct {
+	r       *Runner
+	project string
+	runID   string
+}
+
+// startJournal records the start of a run and returns its journal. Failures
+// are logged and leave the run without a journal.
+func (r *Runner) startJournal(project, runID, kind string, req *global.RunRequest) *runJournal {
+	if r.projects == nil {
+		return nil
+	}
+	j := &runJournal{r: r, project: project, runID: runID}
+	entry := &global.RunJournalEntry{Entry: global.JournalRunStarted, Kind: kind, Path: req.Path, Type: req.Type, Parallel: req.Parallel, PID: os.Getpid(), Host: hostname()}
+	if !j.record(entry) {
+		return nil
+	}
+	r.activeJournals.Store(project+"/"+j.runID, true)
+	return j
+}
+
+// record appends an entry to the journal (best effort)
+func (j *runJournal) record(entry *global.RunJournalEntry) bool {
+	entry.Timestamp = time.Now()
+	if err := j.r.projects.AppendJournal(j.project, j.runID, entry); err != nil {
+		j.r.logger.Warnf("Failed to write run journal for project %s: %v", j.project, err)
+		return false
+	}
+	return true
+}
+
+// taskStarted records that a task is about to run
+func (j *runJournal) taskStarted(path string, task *global.Task) {
+	if j == nil {
+		return
+	}
+	j.record(&global.RunJournalEntry{Entry: global.JournalTaskStarted, Path: path, TaskUUID: task.UUID, TaskID: task.ID})
+}
+
+// taskEnded records that a task is no longer running, whatever its outcome
+func (j *runJournal) taskEnded(path string, task *global.Task) {
+	if j == nil {
+		return
+	}
+	j.record(&global.RunJournalEntry{Entry: global.JournalTaskEnded, Path: path, TaskUUID: task.UUID, TaskID: task.ID})
+}
+
+// finish removes the journal of a run that ended normally
+func (j *runJournal) finish() {
+	if j == nil {
+		return
+	}
+	if err := j.r.projects.RemoveJournal(j.project, j.runID); err != nil {
+		j.r.logger.Warnf("Failed to remove run journal for project %s: %v", j.project, err)
+	}
+	j.r.activeJournals.Delete(j.project + "/" + j.runID)
+}
+
+// InterruptedRuns returns the runs of a project whose journals were left behind,
+// oldest first, with the tasks that were in flight when they stopped. Journals of
+// runs still in progress, in this process or another live one, are not included.
+func (r *Runner) InterruptedRuns(project string) ([]global.InterruptedRun, error) {
+	journals, err := r.projects.GetJournals(project)
+	if err != nil {
+		return nil, err
+	}
+
+	runs := []global.InterruptedRun{}
+	for runID, entries := range journals {
+		if r.isActiveJournal(project, runID) || len(entries) == 0 || entries[0].Entry != global.JournalRunStarted || liveElsewhere(entries[0]) {
+			continue
+		}
+		start := entries[0]
+		run := global.InterruptedRun{
+			RunID:     runID,
+			Kind:      start.Kind,
+			Path:      start.Path,
+			Type:      start.Type,
+			Parallel:  start.Parallel,
+			StartedAt: start.Timestamp,
+			InFlight:  []global.InterruptedTask{},
+		}
+
+		inFlight := make(map[string]global.InterruptedTask)
+		var order []string
+		for _, entry := range entries[1:] {
+			switch entry.Entry {
+			case global.JournalTaskStarted:
+				if _, ok := inFlight[entry.TaskUUID]; !ok {
+					order = append(order, entry.TaskUUID)
+				}
+				inFlight[entry.TaskUUID] = global.InterruptedTask{TaskUUID: entry.TaskUUID, TaskID: entry.TaskID, Path: entry.Path}
+			case global.JournalTaskEnded:
+				delete(inFlight, entry.TaskUUID)
+			}
+		}
+		for _, taskUUID := range order {
+			if task, ok := inFlight[taskUUID]; ok {
+				run.InFlight = append(run.InFlight, task)
+			}
+		}
+		runs = append(runs, run)
+	}
+
+	sort.Slice(runs, func(i, j int) bool { return runs[i].StartedAt.Before(runs[j].StartedAt) })
+	return runs, nil
+}
+
+// isActiveJournal reports whether a journal belongs to a run of this process
+// that is still in progress
+func (r *Runner) isActiveJournal(project, runID string) bool {
+	_, active := r.activeJournals.Load(project + "/" + runID)
+	return active
+}
+
+// ResumeInterruptedRuns recovers the interrupted runs of a project. Tasks that
+// were in flight are returned to waiting (a QA review left in processing goes
+// back to waiting too), dispatched tasks are marked failed because dispatches
+// are single-shot, and the most recent interrupted run is started again with
+// the same request. With dryRun, the runs are only reported.
+func (r *Runner) ResumeInterruptedRuns(project string, dryRun bool) (*global.RunResumeResult, error) {
+	if !r.tasks.ProjectExists(project) {
+		return nil, fmt.Errorf("project not found: %s", project)
+	}
+
+	runs, err := r.InterruptedRuns(project)
+	if err != nil {
+		return nil, err
+	}
+	result := &global.RunResumeResult{Project: project, DryRun: dryRun, Runs: runs}
+	if len(runs) == 0 {
+		result.Message = "no interrupted runs found"
+		return result, nil
+	}
+	if dryRun {
+		for i := range result.Runs {
+			result.Runs[i].Action = global.ResumeActionPending
+		}
+		result.Message = fmt.Sprintf("%d interrupted run(s) found", len(runs))
+		return result, nil
+	}
+	if r.IsProjectRunning(project) {
+		return nil, fmt.Errorf("a run is already in progress for project %s", project)
+	}
+
+	// The most recent regular run is started again; it picks up every waiting
+	// task, including those of older interrupted runs with the same scope
+	resume := -1
+	for i, run := range result.Runs {
+		r.releaseInterruptedTasks(project, run)
+		if run.Kind == global.JournalKindDispatch {
+			result.Runs[i].Action = global.ResumeActionFailed
+			if err := r.projects.RemoveJournal(project, run.RunID); err != nil {
+				r.logger.Warnf("Failed to remove run journal for project %s: %v", project, err)
+			}
+			continue
+		}
+		resume = i
+	}
+
+	var messages []string
+	if resume >= 0 {
+		run := result.Runs[resume]
+		req := &global.RunRequest{Project: project, Path: run.Path, Type: run.Type, Parallel: run.Parallel}
+		runResult, err := r.Run(context.Background(), req, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resume run: %w", err)
+		}
+		result.Resumed = runResult
+		messages = append(messages, fmt.Sprintf("resumed run for path %q: %s", run.Path, runResult.Message))
+	}
+	for i, run := range result.Runs {
+		if run.Kind == global.JournalKindDispatch {
+			continue
+		}
+		if resume >= 0 && (i == resume || samePathScope(run.Path, result.Runs[resume].Path)) {
+			result.Runs[i].Action = global.ResumeActionResumed
+			if err := r.projects.RemoveJournal(project, run.RunID); err != nil {
+				r.logger.Warnf("Failed to remove run journal for project %s: %v", project, err)
+			}
+		} else {
+			result.Runs[i].Action = global.ResumeActionPending
+		}
+	}
+
+	failed := 0
+	pending := 0
+	for _, run := range result.Runs {
+		switch run.Action {
+		case global.ResumeActionFailed:
+			failed++
+		case global.ResumeActionPending:
+			pending++
+		}
+	}
+	if failed > 0 {
+		messages = append(messages, fmt.Sprintf("%d interrupted dispatch(es) marked failed", failed))
+	}
+	if pending > 0 {
+		messages = append(messages, fmt.Sprintf("%d run(s) outside the resumed scope remain pending; call %s again when the resumed run finishes", pending, global.ToolTaskRunResume))
+	}
+	result.Message = strings.Join(messages, "; ")
+	r.logToProject(project, fmt.Sprintf("Interrupted runs recovered: %s", result.Message))
+
+	return result, nil
+}
+
+// samePathScope reports whether a run over path is covered by a run over scope
+func samePathScope(path, scope string) bool {
+	return scope == "" || path == scope || strings.HasPrefix(path, scope+"/")
+}
+
+// releaseInterruptedTasks cleans up the tasks a run left in flight. Work status
+// stays waiting while a task runs, so only a QA status left in processing needs
+// resetting; dispatched tasks are failed.
+func (r *Runner) releaseInterruptedTasks(project string, run global.InterruptedRun) {
+	for _, inFlight := range run.InFlight {
+		task, _, err := r.tasks.GetTask(project, inFlight.TaskUUID)
+		if err != nil {
+			r.logger.Warnf("Interrupted run: task %s in project %s: %v", inFlight.TaskUUID, project, err)
+			continue
+		}
+		if run.Kind == global.JournalKindDispatch {
+			if task.Work.Status != global.ExecutionStatusDone && task.Work.Status != global.ExecutionStatusFailed {
+				r.failTaskPreExecution(project, inFlight.Path, task, "interrupted", "dispatch was interrupted by a restart", nil)
+			}
+			continue
+		}
+		if task.QA.Status == global.ExecutionStatusProcessing {
+			updates := map[string]interface{}{
+				"qa": map[string]interface{}{"status": global.ExecutionStatusWaiting},
+			}
+			if _, err := r.tasks.UpdateTask(project, task.UUID, updates); err != nil {
+				r.logger.Warnf("Task %d: Failed to reset interrupted QA status: %v", task.ID, err)
+			}
+		}
+		r.logToProject(project, fmt.Sprintf("Task %d: Interrupted by a restart while in flight, returned to %s", task.ID, task.Work.Status))
+	}
+}
+
+// RecoverInterruptedRuns reconciles runs left behind by a crash or restart when
+// Maestro starts. In every project not being run by another live process, tasks
+// stuck in processing are returned to waiting, then interrupted runs are
+// resumed with runner.resume_interrupted_runs set, or otherwise reported in the
+// server and project logs so they are not left silently waiting. What was found
+// is kept for the health tool (see StartupRecovery).
+func (r *Runner) RecoverInterruptedRuns() {
+	resume := r.config.Runner().ResumeInterruptedRuns
+	recovery := &global.StartupRecovery{At: time.Now(), StaleTasks: []global.StaleTask{}}
+	defer func() {
+		r.startupMu.Lock()
+		r.startupRecovery = recovery
+		r.startupMu.Unlock()
+	}()
+
+	for offset := 0; ; offset += global.DefaultLimit {
+		list, err := r.projects.List("", global.DefaultLimit, offset)
+		if err != nil {
+			r.logger.Warnf("Run recovery: failed to list projects: %v", err)
+			return
+		}
+		for _, info := range list.Projects {
+			if r.projectRunningElsewhere(info.Name) {
+				r.logger.Infof("Run recovery: project %s has a run in another live process, skipping", info.Name)
+				recovery.LiveElsewhere = append(recovery.LiveElsewhere, info.Name)
+				continue
+			}
+			recovery.StaleTasks = append(recovery.StaleTasks, r.resetStaleTasks(info.Name)...)
+
+			runs, err := r.InterruptedRuns(info.Name)
+			if err != nil {
+				r.logger.Warnf("Run recovery: project %s: %v", info.Name, err)
+				continue
+			}
+			if len(runs) == 0 {
+				continue
+			}
+			recovery.InterruptedRuns += len(runs)
+			if !resume {
+				inFlight := 0
+				for _, run := range runs {
+					inFlight += len(run.InFlight)
+				}
+				r.logger.Warnf("Run recovery: project %s has %d interrupted run(s) with %d task(s) in flight; call %s to resume",
+					info.Name, len(runs), inFlight, global.ToolTaskRunResume)
+				r.logToProject(info.Name, fmt.Sprintf("Found %d interrupted run(s) with %d task(s) in flight. Call %s to clean up and resume.",
+					len(runs), inFlight, global.ToolTaskRunResume))
+				recovery.AwaitingResume = append(recovery.AwaitingResume, info.Name)
+				continue
+			}
+			result, err := r.ResumeInterruptedRuns(info.Name, false)
+			if err != nil {
+				r.logger.Warnf("Run recovery: project %s: %v", info.Name, err)
+				recovery.AwaitingResume = append(recovery.AwaitingResume, info.Name)
+				continue
+			}
+			r.logger.Infof("Run recovery: project %s: %s", info.Name, result.Message)
+			recovery.Resumed = append(recovery.Resumed, info.Name)
+		}
+		if offset+len(list.Projects) >= list.Total || len(list.Projects) == 0 {
+			return
+		}
+	}
+}
@@ -0,0 +1,35 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CheckLLMAllowed returns an error if the project's allowed_llms does not
+// include the LLM. Projects without allowed_llms may use any LLM, and in
+// host-dispatch mode the host selects the LLM, so there is nothing to enforce.
+// A project that does not exist has no policy; callers check that it exists.
+func (r *Runner) CheckLLMAllowed(project, llmID string) error {
+	if r.hostDispatched || r.projects == nil || !r.projects.ProjectExists(project) {
+		return nil
+	}
+	proj, err := r.projects.Get(project)
+	if err != nil {
+		return err
+	}
+	if len(proj.AllowedLLMs) == 0 {
+		return nil
+	}
+	llmID = r.config.ResolveID(llmID)
+	for _, allowed := range proj.AllowedLLMs {
+		if r.config.ResolveID(allowed) == llmID {
+			return nil
+		}
+	}
+	return fmt.Errorf("LLM %s is not allowed for project %s (allowed_llms: %s)", llmID, project, strings.Join(proj.AllowedLLMs, ", "))
+}
@@ -0,0 +1,107 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// runLocks tracks the runs in progress. By default a run locks its whole
+// project; with runner.taskset_locking it locks only the task sets it runs, so
+// runs of other task sets in the project can proceed alongside it.
+type runLocks struct {
+	mu       sync.Mutex
+	projects map[string]*projectLock
+}
+
+// projectLock is the lock state of one project with runs in progress
+type projectLock struct {
+	whole    bool            // A run holds the whole project
+	taskSets map[string]bool // Task sets held by per-task-set runs
+}
+
+// acquire locks the task sets at paths, or the whole project when perTaskSet
+// is false. It returns an error describing the conflict if a run in progress
+// already holds the project or one of the task sets.
+func (l *runLocks) acquire(project string, paths []string, perTaskSet bool) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.projects == nil {
+		l.projects = make(map[string]*projectLock)
+	}
+	lock := l.projects[project]
+	if lock == nil {
+		lock = &projectLock{taskSets: make(map[string]bool)}
+		l.projects[project] = lock
+	}
+
+	if lock.whole || (!perTaskSet && len(lock.taskSets) > 0) {
+		return fmt.Errorf("a run is already in progress for project: %s", project)
+	}
+	if !perTaskSet {
+		lock.whole = true
+		return nil
+	}
+
+	var busy []string
+	for _, path := range paths {
+		if lock.taskSets[path] {
+			busy = append(busy, path)
+		}
+	}
+	if len(busy) > 0 {
+		return fmt.Errorf("a run is already in progress for task set(s) %s in project: %s", strings.Join(busy, ", "), project)
+	}
+	for _, path := range paths {
+		lock.taskSets[path] = true
+	}
+	return nil
+}
+
+// release unlocks what acquire locked for the same arguments
+func (l *runLocks) release(project string, paths []string, perTaskSet bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	lock := l.projects[project]
+	if lock == nil {
+		return
+	}
+	if perTaskSet {
+		for _, path := range paths {
+			delete(lock.taskSets, path)
+		}
+	} else {
+		lock.whole = false
+	}
+	if !lock.whole && len(lock.taskSets) == 0 {
+		delete(l.projects, project)
+	}
+}
+
+// running reports whether any run holds the project or one of its task sets
+func (l *runLocks) running(project string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, ok := l.projects[project]
+	return ok
+}
+
+// list returns the projects with runs in progress
+func (l *runLocks) list() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	projects := make([]string, 0, len(l.projects))
+	for project := range l.projects {
+		projects = append(projects, project)
+	}
+	sort.Strings(projects)
+	return projects
+}
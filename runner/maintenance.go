@@ -0,0 +1,92 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"sync"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// StartMaintenance starts the background job that collects orphaned result files,
+// applies the results retention policy and purges projects whose retention
+// policy is due every maintenance.interval_hours. It returns a function that stops the job; when the
+// interval is 0 no job is started and stop is a no-op.
+func (r *Runner) StartMaintenance() (stop func()) {
+	m := r.config.Maintenance()
+	if m.IntervalHours <= 0 {
+		return func() {}
+	}
+
+	interval := time.Duration(m.IntervalHours) * time.Hour
+	r.logger.Infof("Results maintenance enabled: every %dh, action=%s, min_age=%dh", m.IntervalHours, m.Action, m.MinAgeHours)
+	if m.Retention.Enabled() {
+		r.logger.Infof("Results retention enabled: action=%s, max_age=%dd, max_per_task=%d, max_total=%dMB",
+			m.Retention.Action, m.Retention.MaxAgeDays, m.Retention.MaxPerTask, m.Retention.MaxTotalMB)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				r.RunMaintenance()
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
+
+// RunMaintenance cleans up orphaned result files in every project using the
+// configured maintenance policy, prunes results beyond the retention limits if
+// any are set, and applies project retention policies that are due. Projects with a run in progress are skipped and picked
+// up on the next pass. Returns the cleanup summaries of projects where files
+// were collected.
+func (r *Runner) RunMaintenance() []*global.ResultsCleanupSummary {
+	m := r.config.Maintenance()
+	minAge := time.Duration(m.MinAgeHours) * time.Hour
+
+	var summaries []*global.ResultsCleanupSummary
+	for offset := 0; ; offset += global.DefaultLimit {
+		list, err := r.projects.List("", global.DefaultLimit, offset)
+		if err != nil {
+			r.logger.Warnf("Results maintenance: failed to list projects: %v", err)
+			return summaries
+		}
+		for _, info := range list.Projects {
+			if r.IsProjectRunning(info.Name) {
+				r.logger.Debugf("Results maintenance: skipping %s (run in progress)", info.Name)
+				continue
+			}
+			summary, err := r.tasks.CleanupResults(info.Name, m.Action, minAge, false)
+			if err != nil {
+				r.logger.Warnf("Results maintenance: project %s: %v", info.Name, err)
+				continue
+			}
+			if summary.Collected > 0 {
+				summaries = append(summaries, summary)
+			}
+			if m.Retention.Enabled() {
+				if _, err := r.tasks.PruneResults(info.Name, m.Retention, false); err != nil {
+					r.logger.Warnf("Results retention: project %s: %v", info.Name, err)
+				}
+			}
+			if _, err := r.projects.ApplyRetention(info.Name); err != nil {
+				r.logger.Warnf("Project retention: project %s: %v", info.Name, err)
+			}
+		}
+		if offset+len(list.Projects) >= list.Total || len(list.Projects) == 0 {
+			return summaries
+		}
+	}
+}
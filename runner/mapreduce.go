@@ -0,0 +1,171 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// CreateMapReduce splits a large project file into chunks and creates a map
+// task per chunk and a reduce task over their responses in the task set at
+// path, which is created if it does not exist. Each chunk is saved as a project
+// file under MapReduceChunkDir and attached to its map task as evidence; the
+// reduce task depends on every map task and receives their responses in its
+// prompt. Nothing is created unless all map tasks can be.
+func (r *Runner) CreateMapReduce(project, path string, def *global.MapReduceDefinition) (*global.MapReduceResult, error) {
+	if def.File == "" {
+		return nil, fmt.Errorf("file is required")
+	}
+	if def.Title == "" {
+		return nil, fmt.Errorf("title is required")
+	}
+	if def.MapPrompt == "" || def.ReducePrompt == "" {
+		return nil, fmt.Errorf("map_prompt and reduce_prompt are required")
+	}
+	chunkSize := def.ChunkSize
+	if chunkSize == 0 {
+		chunkSize = global.EvidenceChunkBytes
+	}
+	if chunkSize < global.MinMapReduceChunkBytes {
+		return nil, fmt.Errorf("chunk_size must be at least %d bytes", global.MinMapReduceChunkBytes)
+	}
+	if !r.tasks.ProjectExists(project) {
+		return nil, fmt.Errorf("project not found: %s", project)
+	}
+
+	item, err := r.projects.GetFile(project, def.File, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(item.Content) == "" {
+		return nil, fmt.Errorf("file is empty: %s", def.File)
+	}
+	chunks := chunkEvidence(item.Content, chunkSize)
+	if len(chunks) > global.MaxMapReduceChunks {
+		return nil, fmt.Errorf("%s splits into %d chunks of %d bytes (maximum %d): use a larger chunk_size", def.File, len(chunks), chunkSize, global.MaxMapReduceChunks)
+	}
+
+	if _, err := r.tasks.GetTaskSet(project, path); err != nil {
+		if _, err := r.tasks.CreateTaskSet(project, path, path, "", nil, false, global.Limits{}, false, "", "", nil); err != nil {
+			return nil, fmt.Errorf("failed to create task set: %w", err)
+		}
+		r.logger.Infof("Created task set '%s' for map-reduce", path)
+	}
+
+	// Chunks of each call go to a directory of their own, so map tasks created
+	// earlier keep their evidence
+	dir := global.MapReduceChunkDir + "/" + path + "/" + time.Now().Format("20060102-150405")
+	if existing, err := r.projects.ListFiles(project, dir+"/"); err == nil && len(existing) > 0 {
+		return nil, fmt.Errorf("chunk directory %s already exists: try again in a moment", dir)
+	}
+
+	result := &global.MapReduceResult{
+		Project:   project,
+		Path:      path,
+		File:      def.File,
+		ChunkSize: chunkSize,
+	}
+	defs := make([]global.TaskDefinition, len(chunks))
+	for i := range chunks {
+		chunkPath := fmt.Sprintf("%s/part-%03d.txt", dir, i+1)
+		result.Chunks = append(result.Chunks, chunkPath)
+		defs[i] = global.TaskDefinition{
+			Title:         fmt.Sprintf("%s (map %d of %d)", def.Title, i+1, len(chunks)),
+			Prompt:        fmt.Sprintf("%s\n\nThis task covers part %d of %d of %s, provided below as evidence.", def.MapPrompt, i+1, len(chunks), def.File),
+			EvidenceFiles: []string{chunkPath},
+			LLMModelID:    def.LLMModelID,
+		}
+	}
+
+	// Check the map tasks before writing any chunk
+	check, err := r.tasks.CreateTasks(project, path, defs, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(check.Errors) > 0 {
+		return nil, fmt.Errorf("map task %d: %s", check.Errors[0].Index+1, check.Errors[0].Error)
+	}
+	for i, chunk := range chunks {
+		summary := fmt.Sprintf("Part %d of %d of %s", i+1, len(chunks), def.File)
+		if _, err := r.projects.PutFile(project, result.Chunks[i], chunk, summary); err != nil {
+			return nil, fmt.Errorf("failed to save chunk %d: %w", i+1, err)
+		}
+	}
+	created, err := r.tasks.CreateTasks(project, path, defs, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(created.Errors) > 0 {
+		return nil, fmt.Errorf("map task %d: %s", created.Errors[0].Index+1, created.Errors[0].Error)
+	}
+	result.MapTasks = created.Tasks
+
+	work := &global.WorkExecution{
+		Prompt:            fmt.Sprintf("%s\n\nThe responses of the %d map tasks over %s are provided below, in order of the parts they cover.", def.ReducePrompt, len(chunks), def.File),
+		LLMModelID:        def.LLMModelID,
+		DependencyResults: true,
+		Status:            global.ExecutionStatusWaiting,
+	}
+	reduce, err := r.tasks.CreateTask(project, path, def.Title+" (reduce)", "", "", work, nil)
+	if err != nil {
+		return nil, fmt.Errorf("map tasks created but the reduce task was not: %w", err)
+	}
+	dependsOn := make([]string, len(created.Tasks))
+	for i, task := range created.Tasks {
+		dependsOn[i] = task.UUID
+	}
+	if reduce, err = r.tasks.UpdateTask(project, reduce.UUID, map[string]interface{}{"depends_on": dependsOn}); err != nil {
+		return nil, fmt.Errorf("reduce task created but depends_on was not set: %w", err)
+	}
+	result.ReduceTask = global.BulkCreatedTask{Index: len(chunks), ID: reduce.ID, UUID: reduce.UUID, Title: reduce.Title}
+
+	r.logger.Infof("Created map-reduce over %s in %s/%s: %d map task(s) and task %d", def.File, project, path, len(chunks), reduce.ID)
+	r.logToProject(project, fmt.Sprintf("Created map-reduce over %s in %s: %d map task(s) of up to %d bytes and reduce task %d", def.File, path, len(chunks), chunkSize, reduce.ID))
+	return result, nil
+}
+
+// writeDependencyResults adds the worker responses of the tasks a task depends
+// on to its prompt, in depends_on order, when the task asks for them with
+// dependency_results. It is how a map-reduce's reduce task sees the map outputs.
+func (r *Runner) writeDependencyResults(project string, task *global.Task, sb *strings.Builder) {
+	if !task.Work.DependencyResults || len(task.DependsOn) == 0 {
+		return
+	}
+	sb.WriteString("=== DEPENDENCY RESULTS ===\n\n")
+	sb.WriteString(fmt.Sprintf("The responses of the %d task(s) this task depends on are provided below.\n\n", len(task.DependsOn)))
+	for _, ref := range task.DependsOn {
+		dep, depPath, err := r.tasks.GetTask(project, ref)
+		if err != nil {
+			r.logEvidence(project, task, fmt.Sprintf("dependency %s result not attached: %v", ref, err))
+			sb.WriteString(fmt.Sprintf("--- %s ---\n[not available]\n\n", ref))
+			continue
+		}
+		var response string
+		if data, err := os.ReadFile(r.tasks.ResultFile(project, depPath, dep, global.ResultFileSuffix)); err == nil {
+			var taskResult global.TaskResult
+			if json.Unmarshal(data, &taskResult) == nil {
+				response = taskResult.Worker.Response
+			}
+		}
+		sb.WriteString(fmt.Sprintf("--- Task %d: %s ---\n", dep.ID, dep.Title))
+		if response == "" {
+			r.logEvidence(project, task, fmt.Sprintf("dependency task %d has no recorded response", dep.ID))
+			sb.WriteString("[no response recorded]\n\n")
+			continue
+		}
+		sb.WriteString(response)
+		if !strings.HasSuffix(response, "\n") {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+}
@@ -0,0 +1,39 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// detectPII scans a worker or QA response for personal identifiers when
+// pii_detection is enabled, noting any findings in the project log. Returns nil
+// when detection is disabled or nothing was found.
+func (r *Runner) detectPII(project string, task *global.Task, phase, response string) *global.PIIReport {
+	if r.config == nil {
+		return nil
+	}
+	detection := r.config.PIIDetection()
+	if !detection.Enabled {
+		return nil
+	}
+	report := global.DetectPII(response, detection.Types)
+	if report == nil {
+		return nil
+	}
+
+	counts := make([]string, len(report.Findings))
+	for i, f := range report.Findings {
+		counts[i] = fmt.Sprintf("%d %s", f.Count, f.Type)
+	}
+	msg := fmt.Sprintf("Task %d: %s response contains possible PII (%s); manual redaction review required", task.ID, phase, strings.Join(counts, ", "))
+	r.logger.Warnf("%s", msg)
+	r.logToProject(project, msg)
+	return report
+}
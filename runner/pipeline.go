@@ -0,0 +1,483 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/PivotLLM/Maestro/global"
+	"github.com/PivotLLM/Maestro/templates"
+)
+
+// LoadPipeline reads and validates a pipeline file from a playbook. Unknown
+// fields are rejected so that a misspelt setting is not silently ignored.
+func (r *Runner) LoadPipeline(playbook, file string) (*global.Pipeline, error) {
+	if err := r.RequireSubsystem(global.SubsystemPlaybooks, "loading pipeline "+playbook+"/"+file); err != nil {
+		return nil, err
+	}
+	item, err := r.playbooks.GetFile(playbook, file, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pipeline %s/%s: %w", playbook, file, err)
+	}
+	decoder := json.NewDecoder(strings.NewReader(item.Content))
+	decoder.DisallowUnknownFields()
+	var pipeline global.Pipeline
+	if err := decoder.Decode(&pipeline); err != nil {
+		return nil, fmt.Errorf("invalid pipeline %s/%s: %w", playbook, file, err)
+	}
+	if err := global.ValidatePipeline(&pipeline); err != nil {
+		return nil, fmt.Errorf("invalid pipeline %s/%s: %w", playbook, file, err)
+	}
+	for i := range pipeline.TaskSets {
+		ts := &pipeline.TaskSets[i]
+		if ts.OutputLanguage, err = templates.NormalizeLanguage(ts.OutputLanguage); err != nil {
+			return nil, fmt.Errorf("invalid pipeline %s/%s: task set %s: %w", playbook, file, ts.Path, err)
+		}
+	}
+	return &pipeline, nil
+}
+
+// ApplyPipeline brings the task sets and tasks of a project in line with a
+// pipeline file from a playbook. Task sets and tasks that are missing are
+// created and those whose settings differ are updated; task status and results
+// are left alone, so applying the same pipeline again changes nothing. Tasks of
+// the pipeline's task sets that it does not declare (such as tasks added by
+// list_create_tasks) are kept unless prune is set, in which case they are
+// deleted. With dryRun nothing is changed and the result shows what applying
+// the pipeline would do.
+func (r *Runner) ApplyPipeline(project, playbook, file string, dryRun, prune bool) (*global.PipelineApplyResult, error) {
+	if file == "" {
+		file = global.DefaultPipelineFile
+	}
+	if !r.tasks.ProjectExists(project) {
+		return nil, fmt.Errorf("project not found: %s", project)
+	}
+	if !dryRun && r.IsProjectRunning(project) {
+		return nil, fmt.Errorf("a run is in progress for project %s; apply the pipeline once it finishes", project)
+	}
+	pipeline, err := r.LoadPipeline(playbook, file)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &global.PipelineApplyResult{
+		Project:  project,
+		Playbook: playbook,
+		File:     file,
+		Name:     pipeline.Name,
+		DryRun:   dryRun,
+		Actions:  []global.PipelineAction{},
+	}
+	record := func(kind, target, action string, changes []global.DiffFieldChange) {
+		result.Actions = append(result.Actions, global.PipelineAction{Kind: kind, Target: target, Action: action, Changes: changes})
+		switch action {
+		case global.PipelineActionCreated:
+			result.Created++
+		case global.PipelineActionUpdated:
+			result.Updated++
+		case global.PipelineActionDeleted:
+			result.Deleted++
+		default:
+			result.Unchanged++
+		}
+	}
+
+	// Task sets first, so that task set and task dependencies can refer to any of them
+	taskSetActions := make([]string, len(pipeline.TaskSets))
+	taskSetChanges := make([][]global.DiffFieldChange, len(pipeline.TaskSets))
+	for i := range pipeline.TaskSets {
+		if taskSetActions[i], taskSetChanges[i], err = r.applyPipelineTaskSet(project, &pipeline.TaskSets[i], dryRun); err != nil {
+			return result, fmt.Errorf("task set %s: %w", pipeline.TaskSets[i].Path, err)
+		}
+	}
+
+	// Tasks are matched or created before dependencies are set, so that they
+	// may refer to tasks declared later in the pipeline. In a dry run, tasks
+	// that would be created have no UUID.
+	taskUUIDs := make([][]string, len(pipeline.TaskSets))
+	taskActions := make([][]string, len(pipeline.TaskSets))
+	taskChanges := make([][][]global.DiffFieldChange, len(pipeline.TaskSets))
+	var pruned []*global.Task
+	prunedPaths := make(map[string]string)
+	for i := range pipeline.TaskSets {
+		ts := &pipeline.TaskSets[i]
+		existing := &global.TaskSet{Path: ts.Path}
+		if !dryRun || taskSetActions[i] != global.PipelineActionCreated {
+			if existing, err = r.tasks.GetTaskSet(project, ts.Path); err != nil {
+				return result, fmt.Errorf("task set %s: %w", ts.Path, err)
+			}
+		}
+		declared := make(map[string]bool, len(ts.Tasks))
+		for _, task := range ts.Tasks {
+			id, action, changes, err := r.applyPipelineTask(project, ts, existing, task, dryRun)
+			if err != nil {
+				return result, fmt.Errorf("task set %s: task %q: %w", ts.Path, task.Title, err)
+			}
+			declared[id] = true
+			taskUUIDs[i] = append(taskUUIDs[i], id)
+			taskActions[i] = append(taskActions[i], action)
+			taskChanges[i] = append(taskChanges[i], changes)
+		}
+		if prune {
+			for j := range existing.Tasks {
+				if t := &existing.Tasks[j]; !declared[t.UUID] {
+					pruned = append(pruned, t)
+					prunedPaths[t.UUID] = ts.Path
+				}
+			}
+		}
+	}
+
+	for i := range pipeline.TaskSets {
+		ts := &pipeline.TaskSets[i]
+		if taskSetActions[i] != global.PipelineActionCreated || !dryRun {
+			existing, err := r.tasks.GetTaskSet(project, ts.Path)
+			if err != nil {
+				return result, fmt.Errorf("task set %s: %w", ts.Path, err)
+			}
+			if !slices.Equal(existing.DependsOn, ts.DependsOn) {
+				if !dryRun {
+					if err := r.tasks.SetTaskSetDependsOn(project, ts.Path, ts.DependsOn); err != nil {
+						return result, fmt.Errorf("task set %s: %w", ts.Path, err)
+					}
+				}
+				if taskSetActions[i] != global.PipelineActionCreated {
+					taskSetChanges[i] = append(taskSetChanges[i], pipelineChange("depends_on", existing.DependsOn, ts.DependsOn))
+					taskSetActions[i] = global.PipelineActionUpdated
+				}
+			}
+		}
+
+		for j, task := range ts.Tasks {
+			if taskUUIDs[i][j] == "" {
+				continue
+			}
+			current, _, err := r.tasks.GetTask(project, taskUUIDs[i][j])
+			if err != nil {
+				return result, fmt.Errorf("task set %s: task %q: %w", ts.Path, task.Title, err)
+			}
+			if slices.Equal(current.DependsOn, task.DependsOn) {
+				continue
+			}
+			dependsOn := task.DependsOn
+			if dependsOn == nil {
+				dependsOn = []string{}
+			}
+			if !dryRun {
+				if _, err := r.tasks.UpdateTask(project, current.UUID, map[string]interface{}{"depends_on": dependsOn}); err != nil {
+					return result, fmt.Errorf("task set %s: task %q: %w", ts.Path, task.Title, err)
+				}
+			}
+			if taskActions[i][j] != global.PipelineActionCreated {
+				taskChanges[i][j] = append(taskChanges[i][j], pipelineChange("depends_on", current.DependsOn, task.DependsOn))
+				taskActions[i][j] = global.PipelineActionUpdated
+			}
+		}
+	}
+
+	if !dryRun {
+		for _, t := range pruned {
+			if err := r.tasks.DeleteTask(project, t.UUID); err != nil {
+				return result, fmt.Errorf("task set %s: task %q: %w", prunedPaths[t.UUID], t.Title, err)
+			}
+		}
+	}
+
+	for i := range pipeline.TaskSets {
+		ts := &pipeline.TaskSets[i]
+		record("taskset", ts.Path, taskSetActions[i], taskSetChanges[i])
+		for j, task := range ts.Tasks {
+			record("task", ts.Path+"/"+task.Title, taskActions[i][j], taskChanges[i][j])
+		}
+	}
+	for _, t := range pruned {
+		record("task", prunedPaths[t.UUID]+"/"+t.Title, global.PipelineActionDeleted, nil)
+	}
+
+	if !dryRun {
+		r.logToProject(project, fmt.Sprintf("Applied pipeline %s/%s: %d created, %d updated, %d unchanged, %d deleted", playbook, file, result.Created, result.Updated, result.Unchanged, result.Deleted))
+	}
+	return result, nil
+}
+
+// pipelineChange describes a setting that applying a pipeline changes, with
+// values other than strings shown as JSON
+func pipelineChange(field string, before, after any) global.DiffFieldChange {
+	return global.DiffFieldChange{Field: field, Before: pipelineValue(before), After: pipelineValue(after)}
+}
+
+// pipelineValue formats a setting for a pipeline change
+func pipelineValue(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// applyPipelineTaskSet creates the task set a pipeline declares, or updates the
+// settings of an existing one that differ; with dryRun it only reports what it
+// would do. Dependencies are set separately.
+func (r *Runner) applyPipelineTaskSet(project string, ts *global.PipelineTaskSet, dryRun bool) (string, []global.DiffFieldChange, error) {
+	wantTemplates := ts.Templates
+	if wantTemplates == nil {
+		wantTemplates = &global.DefaultTemplates{}
+	}
+	limits := global.Limits{}
+	if ts.Limits != nil {
+		limits = *ts.Limits
+	}
+	limits = limits.WithDefaults()
+	var qaDefaults *global.QADefaults
+	var skipRules []global.QASkipRule
+	if ts.QA != nil {
+		qaDefaults = ts.QA.Defaults
+		skipRules = ts.QA.SkipRules
+	}
+	if qaDefaults.IsEmpty() {
+		qaDefaults = nil
+	}
+
+	existing, err := r.tasks.GetTaskSet(project, ts.Path)
+	if err != nil {
+		if dryRun {
+			return global.PipelineActionCreated, nil, nil
+		}
+		if _, err := r.tasks.CreateTaskSet(project, ts.Path, ts.Title, ts.Description, wantTemplates, ts.Parallel, limits, ts.SkipValidation, "", ts.OutputLanguage, qaDefaults); err != nil {
+			return "", nil, err
+		}
+		if len(skipRules) > 0 {
+			if err := r.tasks.SetTaskSetQASkipRules(project, ts.Path, skipRules); err != nil {
+				return "", nil, err
+			}
+		}
+		if ts.ResultSummary != nil {
+			if err := r.tasks.SetTaskSetResultSummary(project, ts.Path, ts.ResultSummary); err != nil {
+				return "", nil, err
+			}
+		}
+		return global.PipelineActionCreated, nil, nil
+	}
+
+	var changes []global.DiffFieldChange
+	var title, description, outputLanguage *string
+	var templatesUpdate *global.DefaultTemplates
+	var parallel, skipValidation *bool
+	var limitsUpdate *global.Limits
+	var qaDefaultsUpdate *global.QADefaults
+	if existing.Title != ts.Title {
+		title = &ts.Title
+		changes = append(changes, pipelineChange("title", existing.Title, ts.Title))
+	}
+	if existing.Description != ts.Description {
+		description = &ts.Description
+		changes = append(changes, pipelineChange("description", existing.Description, ts.Description))
+	}
+	current := global.DefaultTemplates{
+		WorkerResponseTemplate: existing.WorkerResponseTemplate,
+		WorkerReportTemplate:   existing.WorkerReportTemplate,
+		QAResponseTemplate:     existing.QAResponseTemplate,
+		QAReportTemplate:       existing.QAReportTemplate,
+	}
+	if current != *wantTemplates {
+		templatesUpdate = wantTemplates
+		changes = append(changes, pipelineChange("templates", current, wantTemplates))
+	}
+	if existing.Parallel != ts.Parallel {
+		parallel = &ts.Parallel
+		changes = append(changes, pipelineChange("parallel", existing.Parallel, ts.Parallel))
+	}
+	if existing.Limits != limits {
+		limitsUpdate = &limits
+		changes = append(changes, pipelineChange("limits", existing.Limits, limits))
+	}
+	if existing.SkipValidation != ts.SkipValidation {
+		skipValidation = &ts.SkipValidation
+		changes = append(changes, pipelineChange("skip_validation", existing.SkipValidation, ts.SkipValidation))
+	}
+	if existing.OutputLanguage != ts.OutputLanguage {
+		outputLanguage = &ts.OutputLanguage
+		changes = append(changes, pipelineChange("output_language", existing.OutputLanguage, ts.OutputLanguage))
+	}
+	if !reflect.DeepEqual(existing.QADefaults, qaDefaults) {
+		qaDefaultsUpdate = &global.QADefaults{}
+		if qaDefaults != nil {
+			qaDefaultsUpdate = qaDefaults
+		}
+		changes = append(changes, pipelineChange("qa_defaults", existing.QADefaults, qaDefaults))
+	}
+	if len(changes) > 0 && !dryRun {
+		if _, err := r.tasks.UpdateTaskSet(project, ts.Path, title, description, templatesUpdate, parallel, limitsUpdate, skipValidation, nil, outputLanguage, qaDefaultsUpdate); err != nil {
+			return "", nil, err
+		}
+	}
+
+	if (len(existing.QASkipRules) > 0 || len(skipRules) > 0) && !reflect.DeepEqual(existing.QASkipRules, skipRules) {
+		if !dryRun {
+			if err := r.tasks.SetTaskSetQASkipRules(project, ts.Path, skipRules); err != nil {
+				return "", nil, err
+			}
+		}
+		changes = append(changes, pipelineChange("qa_skip_rules", existing.QASkipRules, skipRules))
+	}
+	if !reflect.DeepEqual(existing.ResultSummary, ts.ResultSummary) {
+		if !dryRun {
+			if err := r.tasks.SetTaskSetResultSummary(project, ts.Path, ts.ResultSummary); err != nil {
+				return "", nil, err
+			}
+		}
+		changes = append(changes, pipelineChange("result_summary", existing.ResultSummary, ts.ResultSummary))
+	}
+
+	if len(changes) == 0 {
+		return global.PipelineActionUnchanged, nil, nil
+	}
+	return global.PipelineActionUpdated, changes, nil
+}
+
+// applyPipelineTask creates a task a pipeline declares, or updates the
+// definition of the matching task of the existing task set: the one with the
+// same external ID or, for tasks without one, the same title. It returns the
+// task's UUID, which is empty for a task a dry run would create. Dependencies
+// are set separately.
+func (r *Runner) applyPipelineTask(project string, ts *global.PipelineTaskSet, existing *global.TaskSet, task global.PipelineTask, dryRun bool) (string, string, []global.DiffFieldChange, error) {
+	llmModelID := task.LLMModelID
+	if llmModelID == "" {
+		llmModelID = ts.LLMModelID
+	}
+	qaEnabled := ts.QA != nil && ts.QA.Enabled
+	if task.QA != nil {
+		qaEnabled = *task.QA
+	}
+	qaLLMModelID := ""
+	if qaEnabled && ts.QA != nil {
+		qaLLMModelID = ts.QA.LLMModelID
+	}
+
+	var current *global.Task
+	for i := range existing.Tasks {
+		t := &existing.Tasks[i]
+		if (task.ExternalID != "" && t.ExternalID == task.ExternalID) || (task.ExternalID == "" && t.ExternalID == "" && t.Title == task.Title) {
+			current = t
+			break
+		}
+	}
+
+	if current == nil {
+		if dryRun {
+			return "", global.PipelineActionCreated, nil, nil
+		}
+		work := &global.WorkExecution{
+			InstructionsFile:       task.InstructionsFile,
+			InstructionsFileSource: task.InstructionsFileSource,
+			InstructionsText:       task.InstructionsText,
+			Prompt:                 task.Prompt,
+			LLMModelID:             llmModelID,
+		}
+		var qa *global.QAExecution
+		if qaEnabled {
+			qa = &global.QAExecution{Enabled: true, LLMModelID: qaLLMModelID}
+		}
+		created, err := r.tasks.CreateTask(project, ts.Path, task.Title, task.Type, task.ExternalID, work, qa)
+		if err != nil {
+			return "", "", nil, err
+		}
+		settings := map[string]interface{}{}
+		if len(task.Env) > 0 {
+			settings["env"] = task.Env
+		}
+		if !task.Limits.IsEmpty() {
+			settings["limits"] = task.Limits
+		}
+		if task.Priority != 0 {
+			settings["priority"] = task.Priority
+		}
+		if len(settings) > 0 {
+			if _, err := r.tasks.UpdateTask(project, created.UUID, settings); err != nil {
+				return "", "", nil, err
+			}
+		}
+		return created.UUID, global.PipelineActionCreated, nil, nil
+	}
+
+	var changes []global.DiffFieldChange
+	updates := map[string]interface{}{}
+	work := map[string]interface{}{}
+	qa := map[string]interface{}{}
+	if current.Title != task.Title {
+		updates["title"] = task.Title
+		changes = append(changes, pipelineChange("title", current.Title, task.Title))
+	}
+	if current.Type != task.Type {
+		updates["type"] = task.Type
+		changes = append(changes, pipelineChange("type", current.Type, task.Type))
+	}
+	if (len(current.Env) > 0 || len(task.Env) > 0) && !reflect.DeepEqual(current.Env, task.Env) {
+		env := task.Env
+		if env == nil {
+			env = map[string]string{}
+		}
+		updates["env"] = env
+		changes = append(changes, pipelineChange("env", current.Env, task.Env))
+	}
+	if (!current.Limits.IsEmpty() || !task.Limits.IsEmpty()) && !reflect.DeepEqual(current.Limits, task.Limits) {
+		limits := task.Limits
+		if limits == nil {
+			limits = &global.TaskLimits{}
+		}
+		updates["limits"] = limits
+		changes = append(changes, pipelineChange("limits", current.Limits, task.Limits))
+	}
+	if current.Priority != task.Priority {
+		updates["priority"] = task.Priority
+		changes = append(changes, pipelineChange("priority", current.Priority, task.Priority))
+	}
+	for _, field := range []struct {
+		label, key string
+		have, want string
+		target     map[string]interface{}
+	}{
+		{"instructions_file", "instructions_file", current.Work.InstructionsFile, task.InstructionsFile, work},
+		{"instructions_file_source", "instructions_file_source", current.Work.InstructionsFileSource, task.InstructionsFileSource, work},
+		{"instructions_text", "instructions_text", current.Work.InstructionsText, task.InstructionsText, work},
+		{"prompt", "prompt", current.Work.Prompt, task.Prompt, work},
+		{"llm_model_id", "llm_model_id", current.Work.LLMModelID, llmModelID, work},
+		{"qa.llm_model_id", "llm_model_id", current.QA.LLMModelID, qaLLMModelID, qa},
+	} {
+		if field.have != field.want {
+			field.target[field.key] = field.want
+			changes = append(changes, pipelineChange(field.label, field.have, field.want))
+		}
+	}
+	if current.QA.Enabled != qaEnabled {
+		qa["enabled"] = qaEnabled
+		changes = append(changes, pipelineChange("qa.enabled", current.QA.Enabled, qaEnabled))
+	}
+	if len(changes) == 0 {
+		return current.UUID, global.PipelineActionUnchanged, nil, nil
+	}
+	if dryRun {
+		return current.UUID, global.PipelineActionUpdated, changes, nil
+	}
+
+	if len(work) > 0 {
+		updates["work"] = work
+	}
+	if len(qa) > 0 {
+		updates["qa"] = qa
+	}
+	if _, err := r.tasks.UpdateTask(project, current.UUID, updates); err != nil {
+		return "", "", nil, err
+	}
+	return current.UUID, global.PipelineActionUpdated, changes, nil
+}
@@ -0,0 +1,59 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"fmt"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// preRunChecks verifies, before a run is queued, that its output can be written,
+// the instruction files of its tasks can be read and their LLMs are allowed for
+// the project. Problems found here would
+// otherwise only surface as warnings or task failures mid-run, after LLM calls
+// have been paid for. taskSetPaths maps each task UUID to its task set path.
+func (r *Runner) preRunChecks(project string, eligibleTasks []*global.Task, taskSetPaths map[string]string) []string {
+	var problems []string
+	if r.projects != nil {
+		problems = append(problems, r.projects.CheckRunStorage(project, r.config.Runner().MinFreeDiskMB)...)
+	}
+
+	// Each instruction file is checked once, however many tasks share it
+	checked := make(map[string]bool)
+	check := func(task *global.Task, file, source string) {
+		if file == "" || checked[source+":"+file] {
+			return
+		}
+		checked[source+":"+file] = true
+		probe := *task
+		probe.Work.InstructionsFile = file
+		probe.Work.InstructionsFileSource = source
+		if _, err := r.loadInstructionsFile(project, &probe); err != nil {
+			problems = append(problems, fmt.Sprintf("task %d: %v", task.ID, err))
+		}
+	}
+	checkLLM := func(task *global.Task, requested string) {
+		if llmID, ok := r.dispatchLLMID(requested); ok {
+			if err := r.CheckLLMAllowed(project, llmID); err != nil {
+				problems = append(problems, fmt.Sprintf("task %d: %v", task.ID, err))
+			}
+		}
+	}
+	for _, task := range eligibleTasks {
+		check(task, task.Work.InstructionsFile, task.Work.InstructionsFileSource)
+		checkLLM(task, task.Work.LLMModelID)
+		if task.QA.Enabled {
+			qa := r.effectiveQA(project, taskSetPaths[task.UUID], task)
+			check(task, qa.InstructionsFile, qa.InstructionsFileSource)
+			if qa.LLMModelID != task.Work.LLMModelID {
+				checkLLM(task, qa.LLMModelID)
+			}
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,279 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/PivotLLM/Maestro/global"
+	"github.com/PivotLLM/Maestro/templates"
+)
+
+// Prompt section priorities, lowest first. Sections are shortened in this order
+// when a prompt exceeds the LLM's context window; required sections never are.
+const (
+	promptOptional = iota // User-defined project context
+	promptEvidence        // Evidence files attached to the task
+	promptRetry           // Feedback on previous attempts
+	promptRequired        // Instructions, task prompt, schema and work under review
+)
+
+// promptSection is a named part of a prompt
+type promptSection struct {
+	name     string
+	priority int
+	text     strings.Builder
+}
+
+// promptAssembler builds a prompt from sections and fits it to a token limit
+type promptAssembler struct {
+	sections []*promptSection
+}
+
+// section starts a new section of the prompt and returns its builder
+func (a *promptAssembler) section(name string, priority int) *strings.Builder {
+	s := &promptSection{name: name, priority: priority}
+	a.sections = append(a.sections, s)
+	return &s.text
+}
+
+// promptTooLargeError reports a prompt whose required sections exceed the LLM's context window
+type promptTooLargeError struct {
+	llmID          string
+	tokens         int
+	limit          int
+	largest        string
+	largestTokens  int
+	contextWindow  int
+	reservedTokens int
+}
+
+func (e *promptTooLargeError) Error() string {
+	return fmt.Sprintf("prompt of about %d tokens does not fit the %d-token context window of LLM %s (%d tokens available after reserving %d for the response); the largest section is %s (about %d tokens)",
+		e.tokens, e.contextWindow, e.llmID, e.limit, e.reservedTokens, e.largest, e.largestTokens)
+}
+
+// estimateTokens approximates the number of tokens in text
+func estimateTokens(text string) int {
+	return (len(text) + global.PromptCharsPerToken - 1) / global.PromptCharsPerToken
+}
+
+// build joins the sections into the prompt. With a limit (in tokens), sections
+// are shortened lowest priority first, and the last such section first, until
+// the prompt fits; the adjustments made are returned for logging. A prompt that
+// still does not fit returns a *promptTooLargeError.
+func (a *promptAssembler) build(llmID string, limit int) (string, []string, error) {
+	texts := make([]string, len(a.sections))
+	total := 0
+	for i, s := range a.sections {
+		texts[i] = s.text.String()
+		total += len(texts[i])
+	}
+	join := func() string { return strings.Join(texts, "") }
+	if limit <= 0 || estimateTokens(join()) <= limit {
+		return join(), nil, nil
+	}
+
+	var adjustments []string
+	for priority := promptOptional; priority < promptRequired; priority++ {
+		for i := len(a.sections) - 1; i >= 0; i-- {
+			if a.sections[i].priority != priority || texts[i] == "" {
+				continue
+			}
+			excess := len(join()) - limit*global.PromptCharsPerToken
+			if excess <= 0 {
+				return join(), adjustments, nil
+			}
+			original := len(texts[i])
+			keep := original - excess - 100 // Room for the truncation marker
+			if keep < global.MinTruncatedPromptText {
+				texts[i] = ""
+				adjustments = append(adjustments, fmt.Sprintf("dropped %s (%d characters)", a.sections[i].name, original))
+				continue
+			}
+			for keep > 0 && !utf8.RuneStart(texts[i][keep]) {
+				keep--
+			}
+			texts[i] = fmt.Sprintf("%s\n[... truncated %d characters to fit the LLM context window ...]\n\n", texts[i][:keep], original-keep)
+			adjustments = append(adjustments, fmt.Sprintf("truncated %s by %d characters", a.sections[i].name, original-keep))
+		}
+	}
+
+	prompt := join()
+	if tokens := estimateTokens(prompt); tokens > limit {
+		tooLarge := &promptTooLargeError{llmID: llmID, tokens: tokens, limit: limit}
+		for i, s := range a.sections {
+			if t := estimateTokens(texts[i]); t > tooLarge.largestTokens {
+				tooLarge.largest, tooLarge.largestTokens = s.name, t
+			}
+		}
+		return "", adjustments, tooLarge
+	}
+	return prompt, adjustments, nil
+}
+
+// assemblePrompt fits a prompt to the context window of the LLM for the given
+// attempt, noting any shortened sections in the project log
+func (r *Runner) assemblePrompt(project string, task *global.Task, a *promptAssembler, llmID string, attempt int) (string, error) {
+	limit := 0
+	llmConfig := r.llm.GetLLM(llmID)
+	if llmConfig != nil {
+		limit = llmConfig.PromptTokenLimit(attempt)
+	}
+	prompt, adjustments, err := a.build(llmID, limit)
+	if len(adjustments) > 0 {
+		msg := fmt.Sprintf("Task %d: Prompt shortened to fit the context window of %s: %s", task.ID, llmID, strings.Join(adjustments, "; "))
+		r.logger.Warnf("%s", msg)
+		r.logToProject(project, msg)
+	}
+	if tooLarge, ok := err.(*promptTooLargeError); ok {
+		tooLarge.contextWindow = llmConfig.ContextWindow
+		tooLarge.reservedTokens = llmConfig.GenerationFor(attempt).MaxTokens
+	}
+	return prompt, err
+}
+
+// writeQAFeedback writes the QA review of the previous attempt for a revision.
+// feedback is the part of the QA response the task set's field map names as
+// its feedback, if any; it is written ahead of the full response.
+func writeQAFeedback(sb *strings.Builder, task *global.Task, qaResponse, feedback string) {
+	sb.WriteString("=== QA FEEDBACK ===\n\n")
+	sb.WriteString(fmt.Sprintf("The previous attempt was reviewed by QA and received verdict: %s\n\n", task.QA.Verdict))
+	if feedback != "" {
+		sb.WriteString("QA feedback:\n")
+		sb.WriteString(feedback)
+		sb.WriteString("\n\n")
+	}
+	sb.WriteString("Full QA response:\n")
+	sb.WriteString(qaResponse)
+}
+
+// revisionFeedbackPrompt builds the prompt of a revision that continues the
+// conversation of the reviewed attempt: the LLM already has the instructions
+// and its response, so only the QA feedback is sent
+func revisionFeedbackPrompt(task *global.Task, qaResponse, feedback string) *promptAssembler {
+	prompt := &promptAssembler{}
+	sb := prompt.section("QA feedback", promptRequired)
+	writeQAFeedback(sb, task, qaResponse, feedback)
+	sb.WriteString("\n\n=== REVISION ===\n\n")
+	sb.WriteString("Revise your previous response to address the QA feedback above. Respond with the complete revised response, not only the changes, following the instructions and response format given earlier.\n")
+	return prompt
+}
+
+// taskInstructions returns the instructions of a task as a worker prompt
+// presents them: its instructions file, inline instructions and task prompt
+func (r *Runner) taskInstructions(project string, task *global.Task) (string, error) {
+	var sb strings.Builder
+	if task.Work.InstructionsFile != "" {
+		content, err := r.loadInstructionsFile(project, task)
+		if err != nil {
+			return "", fmt.Errorf("failed to load instructions file: %w", err)
+		}
+		sb.WriteString(content)
+		sb.WriteString("\n\n")
+	}
+	if task.Work.InstructionsText != "" {
+		sb.WriteString(global.ExpandTaskEnv(task.Work.InstructionsText, task.Env))
+		sb.WriteString("\n\n")
+	}
+	if task.Work.Prompt != "" {
+		sb.WriteString("=== TASK PROMPT ===\n\n")
+		sb.WriteString(global.ExpandTaskEnv(task.Work.Prompt, task.Env))
+		sb.WriteString("\n\n")
+	}
+	return sb.String(), nil
+}
+
+// revisionPromptData is the data available to a task set's revision prompt
+// template, which replaces the standard QA feedback of a revision prompt
+type revisionPromptData struct {
+	Project        string
+	TaskID         int
+	TaskTitle      string
+	Continued      bool           // The LLM holds the reviewed attempt's conversation; the instructions are not resent
+	OriginalPrompt string         // The task's instructions and prompt
+	PriorResponse  string         // The worker response QA reviewed
+	QAVerdict      string         // pass, fail or escalate
+	QASeverity     string         // QA's severity rating, if any
+	QAFeedback     string         // The feedback field named by the task set's qa_fields, if any
+	QAResponse     string         // The full QA response
+	QAFindings     map[string]any // The QA response as JSON, nil when it is not JSON
+}
+
+// renderRevisionPrompt renders the task set's revision prompt template for a
+// revision of task. ok is false when the task set has none, or it cannot be
+// loaded or rendered, and the standard QA feedback is used instead.
+func (r *Runner) renderRevisionPrompt(project, path string, task *global.Task, previous *global.TaskResult, qaResponse, feedback string, continued bool) (string, bool) {
+	taskSet, err := r.tasks.GetTaskSet(project, path)
+	if err != nil || taskSet.RevisionPromptTemplate == "" {
+		return "", false
+	}
+	content := r.loadSchemaContent(project, taskSet.RevisionPromptTemplate)
+	if content == "" {
+		r.logToProject(project, fmt.Sprintf("Task %d: Revision prompt template %s could not be loaded; using the standard QA feedback", task.ID, taskSet.RevisionPromptTemplate))
+		return "", false
+	}
+
+	data := revisionPromptData{
+		Project:    project,
+		TaskID:     task.ID,
+		TaskTitle:  task.Title,
+		Continued:  continued,
+		QAVerdict:  task.QA.Verdict,
+		QASeverity: task.QA.Severity,
+		QAFeedback: feedback,
+		QAResponse: qaResponse,
+	}
+	if data.OriginalPrompt, err = r.taskInstructions(project, task); err != nil {
+		r.logToProject(project, fmt.Sprintf("Task %d: Revision prompt template not used: %v", task.ID, err))
+		return "", false
+	}
+	if previous != nil {
+		data.PriorResponse = previous.Worker.Response
+	}
+	var findings map[string]any
+	if json.Unmarshal([]byte(templates.ExtractJSON(qaResponse)), &findings) == nil {
+		data.QAFindings = findings
+	}
+
+	text, err := r.validator.PopulateTemplate(content, data)
+	if err != nil {
+		r.logToProject(project, fmt.Sprintf("Task %d: Revision prompt template %s failed (%v); using the standard QA feedback", task.ID, taskSet.RevisionPromptTemplate, err))
+		return "", false
+	}
+	return text, true
+}
+
+// revisionConversation returns the conversation a revision continues: the turns
+// of the reviewed attempt, including any it continued itself. It is only used
+// when the LLM can hold the conversation, so that the revision costs just the
+// feedback: a command LLM with resume_args whose provider reported a session
+// (returned as the session ID), or a host dispatcher that takes native messages.
+// Otherwise ok is false and the revision is sent as a complete prompt.
+func (r *Runner) revisionConversation(llmID string, previous *global.TaskResult) ([]global.ConversationMessage, string, bool) {
+	if previous == nil || previous.Worker.LLMModelID != llmID || previous.Worker.FullPrompt == "" || previous.Worker.Response == "" {
+		return nil, "", false
+	}
+	conversation := append(append([]global.ConversationMessage{}, previous.Worker.Conversation...),
+		global.ConversationMessage{Role: global.MessageRoleUser, Content: previous.Worker.FullPrompt},
+		global.ConversationMessage{Role: global.MessageRoleAssistant, Content: previous.Worker.Response},
+	)
+
+	if llmConfig := r.llm.GetLLM(llmID); llmConfig != nil && len(llmConfig.ResumeArgs) > 0 {
+		for i := len(previous.History) - 1; i >= 0; i-- {
+			if msg := previous.History[i]; msg.Role == "worker" && msg.SessionID != "" {
+				return conversation, msg.SessionID, true
+			}
+		}
+	}
+	if r.hostDispatched {
+		return conversation, "", true
+	}
+	return nil, "", false
+}
@@ -0,0 +1,80 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"fmt"
+
+	"github.com/PivotLLM/Maestro/global"
+	"github.com/PivotLLM/Maestro/llm"
+)
+
+// promptRedaction returns the prompt redaction of a project: the configured one
+// combined with the project's own
+func (r *Runner) promptRedaction(project string) (global.PromptRedaction, error) {
+	if r.config == nil {
+		return global.PromptRedaction{}, nil
+	}
+	policy := r.config.PromptRedaction()
+	if r.projects != nil && r.projects.ProjectExists(project) {
+		proj, err := r.projects.Get(project)
+		if err != nil {
+			return policy, err
+		}
+		policy = global.MergePromptRedaction(policy, proj.PromptRedaction)
+	}
+	return policy, nil
+}
+
+// promptRedactor returns the compiled prompt redaction of a project. Nil when
+// neither the configuration nor the project enables it.
+func (r *Runner) promptRedactor(project string) (*global.PromptRedactor, error) {
+	policy, err := r.promptRedaction(project)
+	if err != nil {
+		return nil, err
+	}
+	return global.NewPromptRedactor(policy)
+}
+
+// AnonymizationRedactor returns the redaction an anonymized export of a project
+// applies: the types and rules of its prompt redaction, whether or not prompt
+// redaction is enabled
+func (r *Runner) AnonymizationRedactor(project string) (*global.PromptRedactor, error) {
+	policy, err := r.promptRedaction(project)
+	if err != nil {
+		return nil, err
+	}
+	policy.Enabled = true
+	return global.NewPromptRedactor(policy)
+}
+
+// redactPrompt replaces the values the project's prompt redaction covers in the
+// prompt of a task's LLM call with placeholders, noting what it replaced in the
+// task history and project log. It returns the task's placeholders, to restore
+// the values in the response, or nil when redaction is off.
+func (r *Runner) redactPrompt(project string, task *global.Task, phase string, req *llm.DispatchRequest) (*global.RedactionMap, error) {
+	redactor, err := r.promptRedactor(project)
+	if err != nil || redactor == nil {
+		return nil, err
+	}
+
+	existing, _ := r.redactionMaps.LoadOrStore(task.UUID, global.NewRedactionMap())
+	placeholders := existing.(*global.RedactionMap)
+	prompt, counts := redactor.Redact(req.Prompt, placeholders)
+	if len(counts) == 0 {
+		return placeholders, nil
+	}
+	req.Prompt = prompt
+
+	invocation := task.Work.Invocations
+	if phase == "qa" {
+		invocation = task.QA.Invocations
+	}
+	msg := fmt.Sprintf("Redacted from the %s prompt before dispatch: %s", phase, global.FormatRedactionCounts(counts))
+	r.recordHistory(project, task.UUID, "system", global.HistoryTypeRedaction, msg, req.LLMID, invocation)
+	r.logToProject(project, fmt.Sprintf("Task %d: %s", task.ID, msg))
+	return placeholders, nil
+}
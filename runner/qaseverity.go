@@ -0,0 +1,29 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"fmt"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// checkQAHaltSeverity halts the run when the task's QA severity meets its task
+// set's qa_halt_severity, so a critical finding stops further LLM calls
+func (r *Runner) checkQAHaltSeverity(project, path string, task *global.Task, budget *runBudget) {
+	if task.QA.Severity == "" {
+		return
+	}
+	taskSet, err := r.tasks.GetTaskSet(project, path)
+	if err != nil || !global.QASeverityAtLeast(task.QA.Severity, taskSet.QAHaltSeverity) {
+		return
+	}
+	if !budget.haltForSeverity() {
+		return
+	}
+	r.logger.Warnf("Task %d: QA reported %s severity in project %s (task set %s halts at %s), halting run", task.ID, task.QA.Severity, project, path, taskSet.QAHaltSeverity)
+	r.logToProject(project, fmt.Sprintf("Task %d: QA reported %s severity (qa_halt_severity: %s). No further LLM calls will be made in this run.", task.ID, task.QA.Severity, taskSet.QAHaltSeverity))
+}
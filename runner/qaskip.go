@@ -0,0 +1,80 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// skipQA completes a task without a QA call when its task set has QA skip rules
+// and the worker response, validated against the task set's worker schema,
+// matches one of them. The task and its result file record the rule that
+// matched. Returns true if QA was skipped.
+func (r *Runner) skipQA(project, path string, task *global.Task) bool {
+	taskSet, err := r.tasks.GetTaskSet(project, path)
+	if err != nil || len(taskSet.QASkipRules) == 0 {
+		return false
+	}
+	// Rules only apply to responses that were validated against a schema
+	if taskSet.WorkerResponseTemplate == "" || taskSet.SkipValidation {
+		return false
+	}
+
+	resultPath := r.tasks.ResultFile(project, path, task, global.ResultFileSuffix)
+	data, err := os.ReadFile(resultPath)
+	if err != nil {
+		return false
+	}
+	var taskResult global.TaskResult
+	if err := json.Unmarshal(data, &taskResult); err != nil {
+		return false
+	}
+	rule := global.MatchQASkipRule(taskSet.QASkipRules, taskResult.Worker.Response)
+	if rule == nil {
+		return false
+	}
+
+	updates := map[string]interface{}{
+		"work": map[string]interface{}{
+			"status": global.ExecutionStatusDone,
+		},
+		"qa": map[string]interface{}{
+			"status":    global.ExecutionStatusDone,
+			"skipped":   true,
+			"skip_rule": rule.Name,
+		},
+	}
+	updatedTask, err := r.tasks.UpdateTask(project, task.UUID, updates)
+	if err != nil {
+		r.logger.Errorf("Task %d: Failed to save QA skip, running QA: %v", task.ID, err)
+		return false
+	}
+	task.Work.Status = updatedTask.Work.Status
+	task.QA = updatedTask.QA
+
+	r.logger.Infof("Task %d: QA skipped (rule %s matched)", task.ID, rule.Name)
+	r.logToProject(project, fmt.Sprintf("Task %d: QA skipped, skip rule %s matched", task.ID, rule.Name))
+	r.emitEvent(project, path, task, &global.TaskEvent{Event: global.EventQASkipped, Phase: "qa", Detail: rule.Name})
+
+	taskResult.QA = &global.QAResult{
+		Status:   global.ExecutionStatusDone,
+		Skipped:  true,
+		SkipRule: rule.Name,
+	}
+	updatedData, err := json.MarshalIndent(taskResult, "", "  ")
+	if err != nil {
+		r.logger.Warnf("Task %d: Failed to marshal QA skip result: %v", task.ID, err)
+		return true
+	}
+	if err := r.writeResultFile(resultPath, updatedData); err != nil {
+		r.logger.Warnf("Task %d: Failed to save QA skip to result file: %v", task.ID, err)
+	}
+	return true
+}
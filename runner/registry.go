@@ -0,0 +1,208 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// templateRef is a schema or template referenced by a task set
+type templateRef struct {
+	path    string
+	kind    string
+	taskSet string
+}
+
+// inline reports whether the reference is a schema stored in the task set itself
+func (t templateRef) inline() bool {
+	return strings.HasPrefix(strings.TrimSpace(t.path), "{")
+}
+
+// referencedTemplates returns every schema and template referenced by the
+// project's task sets. Report manifests are expanded so the per-suffix templates
+// they reference are included, and report templates bring their layouts and
+// partials.
+func (r *Runner) referencedTemplates(project string) ([]templateRef, error) {
+	taskSetList, err := r.tasks.ListTaskSets(project, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list task sets: %w", err)
+	}
+
+	var refs []templateRef
+	for _, ts := range taskSetList.TaskSets {
+		add := func(path, kind string) {
+			if path != "" {
+				refs = append(refs, templateRef{path: path, kind: kind, taskSet: ts.Path})
+			}
+		}
+		addReport := func(path string) {
+			add(path, global.RegistryKindReportTemplate)
+			for _, include := range r.reporter.TemplateIncludes(path) {
+				add(include, global.RegistryKindReportInclude)
+			}
+		}
+
+		add(ts.WorkerResponseTemplate, global.RegistryKindWorkerSchema)
+		add(ts.QAResponseTemplate, global.RegistryKindQASchema)
+		for _, reportTemplate := range []string{ts.WorkerReportTemplate, ts.QAReportTemplate} {
+			if strings.HasSuffix(reportTemplate, ".json") {
+				add(reportTemplate, global.RegistryKindReportManifest)
+				for _, cfg := range r.reporter.LoadTemplateConfigs(reportTemplate) {
+					addReport(cfg.File)
+				}
+			} else if reportTemplate != "" {
+				addReport(reportTemplate)
+			}
+		}
+	}
+	return refs, nil
+}
+
+// TemplateRegistry catalogs the schemas and templates referenced by a project's
+// task sets: where each was loaded from, its checksum and when it was last
+// modified. Entries whose checksum differs from the one recorded by the last run
+// are marked as changed, so templates edited mid-engagement can be reviewed.
+func (r *Runner) TemplateRegistry(project string) (*global.TemplateRegistry, error) {
+	refs, err := r.referencedTemplates(project)
+	if err != nil {
+		return nil, err
+	}
+
+	registry := &global.TemplateRegistry{
+		Project:     project,
+		GeneratedAt: time.Now(),
+		Entries:     []global.TemplateRegistryEntry{},
+	}
+	index := make(map[string]int)
+	for _, ref := range refs {
+		key := ref.path
+		if ref.inline() {
+			key = fmt.Sprintf("%s:%s:%s", global.RegistrySourceInline, ref.taskSet, ref.kind)
+		}
+		if i, ok := index[key]; ok {
+			entry := &registry.Entries[i]
+			if entry.TaskSets[len(entry.TaskSets)-1] != ref.taskSet {
+				entry.TaskSets = append(entry.TaskSets, ref.taskSet)
+			}
+			continue
+		}
+		entry := r.resolveTemplate(project, ref)
+		entry.Path = key
+		if entry.Source == global.RegistrySourceMissing {
+			registry.Missing++
+		}
+		index[key] = len(registry.Entries)
+		registry.Entries = append(registry.Entries, entry)
+	}
+	sort.SliceStable(registry.Entries, func(i, j int) bool { return registry.Entries[i].Path < registry.Entries[j].Path })
+
+	// Compare with the checksums recorded by the last run that recorded any
+	if r.projects != nil {
+		points, err := r.projects.GetTrends(project)
+		if err != nil {
+			r.logger.Warnf("Failed to read trends for project %s: %v", project, err)
+		}
+		for i := len(points) - 1; i >= 0; i-- {
+			if points[i].Templates == nil {
+				continue
+			}
+			runAt := points[i].RunAt
+			registry.LastRunAt = &runAt
+			for j := range registry.Entries {
+				entry := &registry.Entries[j]
+				if previous := points[i].Templates[entry.Path]; previous != entry.Checksum {
+					entry.Changed = true
+					entry.PreviousChecksum = previous
+					registry.Changed++
+				}
+			}
+			break
+		}
+	}
+
+	return registry, nil
+}
+
+// resolveTemplate loads a referenced schema or template the same way
+// loadSchemaContent does and describes it for the registry
+func (r *Runner) resolveTemplate(project string, ref templateRef) global.TemplateRegistryEntry {
+	entry := global.TemplateRegistryEntry{Path: ref.path, Kind: ref.kind, TaskSets: []string{ref.taskSet}}
+	var content string
+	found := false
+
+	switch {
+	case ref.inline():
+		entry.Source = global.RegistrySourceInline
+		entry.Resolved = "task set " + ref.taskSet
+		content, found = ref.path, true
+
+	case strings.Contains(ref.path, "/") && r.playbooks != nil:
+		name, path, _ := strings.Cut(ref.path, "/")
+		if item, err := r.playbooks.GetFile(name, path, 0, 0); err == nil {
+			entry.Source = global.RegistrySourcePlaybook
+			entry.Resolved = "playbook " + ref.path
+			entry.PlaybookVersion = r.playbooks.Version(name)
+			modifiedAt := item.ModifiedAt
+			entry.ModifiedAt = &modifiedAt
+			content, found = item.Content, true
+		}
+	}
+
+	if !found && r.projects != nil {
+		if item, err := r.projects.GetFile(project, ref.path, 0, 0); err == nil {
+			entry.Source = global.RegistrySourceProject
+			entry.Resolved = "files/" + ref.path
+			if modifiedAt, err := time.Parse(time.RFC3339, item.ModifiedAt); err == nil {
+				entry.ModifiedAt = &modifiedAt
+			}
+			content, found = item.Content, true
+		}
+	}
+
+	if !found {
+		entry.Source = global.RegistrySourceMissing
+		return entry
+	}
+	sum := sha256.Sum256([]byte(content))
+	entry.Checksum = hex.EncodeToString(sum[:])
+	entry.SizeBytes = int64(len(content))
+	return entry
+}
+
+// runTemplates returns the checksums of the project's schemas and templates for
+// the run record, noting in the project log those changed since the last run
+func (r *Runner) runTemplates(project string) map[string]string {
+	registry, err := r.TemplateRegistry(project)
+	if err != nil {
+		r.logger.Warnf("Failed to build template registry for project %s: %v", project, err)
+		return nil
+	}
+
+	checksums := make(map[string]string, len(registry.Entries))
+	var changed, missing []string
+	for _, entry := range registry.Entries {
+		checksums[entry.Path] = entry.Checksum
+		if entry.Source == global.RegistrySourceMissing {
+			missing = append(missing, entry.Path)
+		} else if entry.Changed {
+			changed = append(changed, entry.Path)
+		}
+	}
+	if len(changed) > 0 {
+		r.logToProject(project, fmt.Sprintf("Templates changed since the last run: %s", strings.Join(changed, ", ")))
+	}
+	if len(missing) > 0 {
+		r.logToProject(project, fmt.Sprintf("Templates not found: %s", strings.Join(missing, ", ")))
+	}
+	return checksums
+}
@@ -12,6 +12,8 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -20,12 +22,14 @@ import (
 	"github.com/PivotLLM/Maestro/config"
 	"github.com/PivotLLM/Maestro/global"
 	"github.com/PivotLLM/Maestro/library"
+	"github.com/PivotLLM/Maestro/lists"
 	"github.com/PivotLLM/Maestro/llm"
 	"github.com/PivotLLM/Maestro/logging"
 	"github.com/PivotLLM/Maestro/playbooks"
 	"github.com/PivotLLM/Maestro/projects"
 	"github.com/PivotLLM/Maestro/reference"
 	"github.com/PivotLLM/Maestro/reporting"
+	"github.com/PivotLLM/Maestro/shared"
 	"github.com/PivotLLM/Maestro/tasks"
 	"github.com/PivotLLM/Maestro/templates"
 	"github.com/google/uuid"
@@ -38,6 +42,8 @@ type Runner struct {
 	library     *library.Service
 	playbooks   *playbooks.Service
 	reference   *reference.Service
+	shared      *shared.Service
+	lists       *lists.Service
 	llm         llm.Dispatcher
 	tasks       *tasks.Service
 	projects    *projects.Service
@@ -49,9 +55,17 @@ type Runner struct {
 	// resolve, validate, or require any model of its own — it just hands the
 	// prompt to the host and lets it pick the model.
 	hostDispatched  bool
-	runningProjects sync.Map       // map[string]bool - tracks which projects have runs in progress
+	runLocks        runLocks       // projects and task sets with runs in progress
 	taskHistory     sync.Map       // map[string][]global.Message - accumulates history by task UUID
+	redactionMaps   sync.Map       // map[task UUID]*global.RedactionMap - prompt redaction placeholders of tasks being executed
 	activeRuns      sync.WaitGroup // tracks active run goroutines for graceful shutdown
+	activeJournals  sync.Map       // map["<project>/<run id>"]bool - journals of runs in progress
+	webhookSends    sync.WaitGroup // tracks webhook deliveries in flight
+	recoveries      sync.Map       // map["<project>/<path>"]*recoveryState - recovery state of task set runs in progress
+	inflight        sync.Map       // map[*inflightCall]struct{} - LLM calls in progress, for task_inflight
+	taskRuns        sync.Map       // map[task UUID]run ID - run executing each task, for log correlation
+	startupMu       sync.Mutex
+	startupRecovery *global.StartupRecovery // What RecoverInterruptedRuns found (nil until it runs)
 }
 
 // recoveryState tracks the state of recovery mode during a run.
@@ -62,6 +76,7 @@ type recoveryState struct {
 	scheduleIndex int         // current index in test_schedule_seconds
 	llmID         string      // which LLM triggered recovery
 	llmConfig     *config.LLM // LLM config for rate limit patterns
+	nextProbeAt   time.Time   // when the current wait ends and the LLM is probed
 	mu            sync.Mutex  // protects state updates
 }
 
@@ -104,6 +119,7 @@ func (rs *recoveryState) exitRecovery() {
 	rs.scheduleIndex = 0
 	rs.llmID = ""
 	rs.llmConfig = nil
+	rs.nextProbeAt = time.Time{}
 }
 
 // advanceSchedule moves to the next interval in the test schedule
@@ -133,6 +149,23 @@ func (rs *recoveryState) getWaitDuration() time.Duration {
 	return time.Duration(schedule[idx]) * time.Second
 }
 
+// scheduleProbe records that the LLM will be probed after waiting d
+func (rs *recoveryState) scheduleProbe(d time.Duration) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.nextProbeAt = time.Now().Add(d)
+}
+
+// abortAfter returns how long recovery may last before the run is aborted.
+// The caller must hold rs.mu.
+func (rs *recoveryState) abortAfter() time.Duration {
+	if rs.llmConfig == nil || rs.llmConfig.RecoveryConfig == nil || rs.llmConfig.RecoveryConfig.AbortAfterSeconds == 0 {
+		// Default: 12 hours
+		return 12 * time.Hour
+	}
+	return time.Duration(rs.llmConfig.RecoveryConfig.AbortAfterSeconds) * time.Second
+}
+
 // shouldAbort returns true if we've exceeded the abort timeout
 func (rs *recoveryState) shouldAbort() bool {
 	rs.mu.Lock()
@@ -142,12 +175,7 @@ func (rs *recoveryState) shouldAbort() bool {
 		return false
 	}
 
-	if rs.llmConfig == nil || rs.llmConfig.RecoveryConfig == nil || rs.llmConfig.RecoveryConfig.AbortAfterSeconds == 0 {
-		// Default: 12 hours
-		return time.Since(rs.enteredAt) > 12*time.Hour
-	}
-
-	return time.Since(rs.enteredAt) > time.Duration(rs.llmConfig.RecoveryConfig.AbortAfterSeconds)*time.Second
+	return time.Since(rs.enteredAt) > rs.abortAfter()
 }
 
 // isInRecovery returns whether we're in recovery mode
@@ -164,16 +192,59 @@ func (rs *recoveryState) getLLMID() string {
 	return rs.llmID
 }
 
+// describe returns the recovery state of a task set run for the status tools
+func (rs *recoveryState) describe(project, path string) global.RunRecovery {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rec := global.RunRecovery{
+		Project:       project,
+		Path:          path,
+		LLMID:         rs.llmID,
+		Since:         rs.enteredAt,
+		ScheduleIndex: rs.scheduleIndex,
+		AbortAt:       rs.enteredAt.Add(rs.abortAfter()),
+	}
+	if !rs.nextProbeAt.IsZero() {
+		next := rs.nextProbeAt
+		rec.NextProbeAt = &next
+	}
+	return rec
+}
+
 // runBudget tracks LLM call budget for a run to prevent runaway costs
 type runBudget struct {
 	maxCalls  int64
 	usedCalls int64 // accessed atomically
 	exceeded  bool  // set when budget exceeded, prevents further calls
 	bufferPct float64
+
+	// Cost limit (max_cost_usd); 0 means no limit
+	maxCostUSD   float64
+	costMu       sync.Mutex
+	spentUSD     float64
+	costExceeded bool
+
+	// Set when QA reported a severity at a task set's qa_halt_severity
+	severityHalt bool
+
+	// Write-ahead journal of the run's in-flight tasks; nil records nothing
+	journal *runJournal
+
+	// Correlation ID of the run in log records (also the journal's run ID)
+	runID string
+}
+
+// id returns the run ID of the budget's run, or "" without a budget
+func (b *runBudget) id() string {
+	if b == nil {
+		return ""
+	}
+	return b.runID
 }
 
 // newRunBudget calculates an LLM call budget based on tasks and limits
-// Formula per task: maxWorker + maxQA (QA calls include revision cycle)
+// Formula per task: maxWorker + maxQA (QA calls include revision cycle), with
+// the task's own limits overriding the run's
 // Then add a buffer percentage (default 10%)
 func (r *Runner) newRunBudget(tasks []*global.Task, limits global.Limits, bufferPct float64) *runBudget {
 	// Apply defaults if limits are zero
@@ -181,12 +252,14 @@ func (r *Runner) newRunBudget(tasks []*global.Task, limits global.Limits, buffer
 
 	var totalCalls int64
 	for _, task := range tasks {
+		taskLimits := limits.ForTask(task)
+
 		// Work phase: up to MaxWorker calls
-		taskCalls := int64(limits.MaxWorker)
+		taskCalls := int64(taskLimits.MaxWorker)
 
 		// QA phase: if enabled, add QA calls
 		if task.QA.Enabled {
-			taskCalls += int64(limits.MaxQA)
+			taskCalls += int64(taskLimits.MaxQA)
 		}
 
 		totalCalls += taskCalls
@@ -229,6 +302,49 @@ func (b *runBudget) used() int64 {
 	return atomic.LoadInt64(&b.usedCalls)
 }
 
+// addCost adds the estimated cost of a call to the run's spend. Returns true for
+// the call that reaches the cost limit; from then on no further calls are allowed.
+func (b *runBudget) addCost(usd float64) bool {
+	if b == nil || usd <= 0 {
+		return false
+	}
+	b.costMu.Lock()
+	defer b.costMu.Unlock()
+	b.spentUSD += usd
+	if b.maxCostUSD > 0 && b.spentUSD >= b.maxCostUSD && !b.costExceeded {
+		b.costExceeded = true
+		b.exceeded = true
+		return true
+	}
+	return false
+}
+
+// haltForSeverity stops further calls because QA reported a severity at the
+// task set's threshold. Returns true for the first such report.
+func (b *runBudget) haltForSeverity() bool {
+	if b == nil {
+		return false
+	}
+	b.costMu.Lock()
+	defer b.costMu.Unlock()
+	if b.severityHalt {
+		return false
+	}
+	b.severityHalt = true
+	b.exceeded = true
+	return true
+}
+
+// spent returns the estimated spend so far
+func (b *runBudget) spent() float64 {
+	if b == nil {
+		return 0
+	}
+	b.costMu.Lock()
+	defer b.costMu.Unlock()
+	return b.spentUSD
+}
+
 // ValidationErrorDetails contains detailed information about a schema validation failure
 type ValidationErrorDetails struct {
 	TaskID           int              `json:"task_id"`
@@ -248,23 +364,38 @@ type ValidationErrorDetails struct {
 	History          []global.Message `json:"history,omitempty"`      // Task execution history for debugging
 }
 
+// writeResultFile writes a result or error file with configured secrets masked,
+// including any that appear in the captured history, stdout or stderr
+func (r *Runner) writeResultFile(path string, data []byte) error {
+	var redactor *global.Redactor
+	if r.config != nil {
+		redactor = r.config.Redactor()
+	}
+	return global.AtomicWrite(path, redactor.RedactJSON(data))
+}
+
 // writeErrorFile writes detailed error information to a file in the results directory
-// Returns the filename (not full path) for logging
-func (r *Runner) writeErrorFile(project string, details *ValidationErrorDetails) (string, error) {
-	resultsDir := r.tasks.GetResultsDir(project)
-	if err := os.MkdirAll(resultsDir, 0755); err != nil {
+// Returns the filename (relative to the results directory) for logging
+func (r *Runner) writeErrorFile(project, path string, task *global.Task, details *ValidationErrorDetails) (string, error) {
+	r.emitEvent(project, path, task, &global.TaskEvent{
+		Event:      global.EventValidationFailed,
+		Phase:      details.Phase,
+		LLMModelID: details.LLMModelID,
+		Detail:     details.Summary,
+	})
+
+	filePath := r.tasks.ResultFile(project, path, task, global.ErrorFileSuffix)
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
 		return "", fmt.Errorf("failed to create results directory: %w", err)
 	}
-
-	filename := details.TaskUUID + "-error.json"
-	filePath := filepath.Join(resultsDir, filename)
+	filename := r.resultFileName(project, filePath)
 
 	data, err := json.MarshalIndent(details, "", "  ")
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal error details: %w", err)
 	}
 
-	if err := os.WriteFile(filePath, data, 0644); err != nil {
+	if err := r.writeResultFile(filePath, data); err != nil {
 		return "", fmt.Errorf("failed to write error file: %w", err)
 	}
 
@@ -314,6 +445,9 @@ func New(cfg *config.Config, logger *logging.Logger, lib *library.Service, playb
 		if len(parts) < 2 {
 			return "", fmt.Errorf("invalid playbook path: %s (expected playbook-name/path)", path)
 		}
+		if playbooksSvc == nil {
+			return "", &global.SubsystemUnavailableError{Subsystem: global.SubsystemPlaybooks, Feature: "loading report template " + path, Remedy: subsystemRemedies[global.SubsystemPlaybooks]}
+		}
 		item, err := playbooksSvc.GetFile(parts[0], parts[1], 0, 0)
 		if err != nil {
 			return "", err
@@ -323,6 +457,9 @@ func New(cfg *config.Config, logger *logging.Logger, lib *library.Service, playb
 
 	// Reference loader
 	referenceLoader := reporting.ContentLoaderFunc(func(path string) (string, error) {
+		if refSvc == nil {
+			return "", &global.SubsystemUnavailableError{Subsystem: global.SubsystemReference, Feature: "loading report template " + path, Remedy: subsystemRemedies[global.SubsystemReference]}
+		}
 		item, err := refSvc.Get(path, 0, 0)
 		if err != nil {
 			return "", err
@@ -330,16 +467,41 @@ func New(cfg *config.Config, logger *logging.Logger, lib *library.Service, playb
 		return item.Content, nil
 	})
 
+	reporterOpts := []reporting.Option{
+		reporting.WithPlaybookLoader(playbookLoader),
+		reporting.WithReferenceLoader(referenceLoader),
+		reporting.WithReportLanguage(cfg.ReportLanguage()),
+		reporting.WithAttribution(cfg.ReportAttribution()),
+		reporting.WithSanitization(cfg.OutputSanitization()),
+		reporting.WithClock(cfg.Clock()),
+		reporting.WithOutput(cfg.ReportOutput()),
+	}
+	if tasksSvc != nil {
+		reporterOpts = append(reporterOpts, reporting.WithResultLocator(func(project, path string, task *global.Task) string {
+			return tasksSvc.ResultFile(project, path, task, global.ResultFileSuffix)
+		}))
+	}
+
+	// Lists service for worker list_get tool calls
+	listsSvc := lists.NewService(
+		lists.WithProjectsDir(cfg.ProjectsDir()),
+		lists.WithPlaybooksDir(cfg.PlaybooksDir()),
+		lists.WithEmbeddedFS(cfg.EmbeddedFS()),
+		lists.WithLogger(logger),
+	)
+
 	return &Runner{
 		config:      cfg,
 		logger:      logger,
 		library:     lib,
 		playbooks:   playbooksSvc,
 		reference:   refSvc,
+		shared:      shared.NewService(cfg.SharedDir(), cfg.ImportPolicy(), logger),
+		lists:       listsSvc,
 		llm:         llmSvc,
 		tasks:       tasksSvc,
 		projects:    projectsSvc,
-		reporter:    reporting.New(logger, reporting.WithPlaybookLoader(playbookLoader), reporting.WithReferenceLoader(referenceLoader)),
+		reporter:    reporting.New(logger, reporterOpts...),
 		validator:   templates.New(logger),
 		rateLimiter: NewRateLimiter(runnerConfig.RateLimit.MaxRequests, runnerConfig.RateLimit.PeriodSeconds),
 	}
@@ -383,8 +545,10 @@ func (r *Runner) logToProject(project, message string) {
 }
 
 // logTaskFinished logs a final "Finished" message when a task reaches a terminal state.
-// This is only called for terminal states (done, failed, escalate), not for tasks that will be retried.
-func (r *Runner) logTaskFinished(project string, task *global.Task) {
+// This is only called for terminal states (done, failed, escalate, awaiting_approval), not for tasks that will be retried.
+func (r *Runner) logTaskFinished(project, path string, task *global.Task) {
+	log := r.taskLogger(project, path, task)
+
 	// Determine the final status string
 	var finalStatus string
 
@@ -396,9 +560,13 @@ func (r *Runner) logTaskFinished(project string, task *global.Task) {
 
 	if task.Work.Status == global.ExecutionStatusFailed {
 		finalStatus = "failed"
+	} else if task.Work.Status == global.ExecutionStatusAwaitingApproval {
+		finalStatus = global.ExecutionStatusAwaitingApproval
 	} else if task.Work.Status == global.ExecutionStatusDone {
 		// Check QA verdict if QA was enabled
-		if task.QA.Enabled {
+		if task.QA.Enabled && task.QA.Skipped {
+			finalStatus = "done (QA skipped)"
+		} else if task.QA.Enabled {
 			switch task.QA.Verdict {
 			case global.QAVerdictEscalate:
 				finalStatus = "escalate"
@@ -418,8 +586,19 @@ func (r *Runner) logTaskFinished(project string, task *global.Task) {
 		return
 	}
 
-	r.logger.Infof("Task %d: Finished with status %s", task.ID, finalStatus)
+	log.Infof("Task %d: Finished with status %s", task.ID, finalStatus)
 	r.logToProject(project, fmt.Sprintf("Task %d: Finished with status %s", task.ID, finalStatus))
+	r.emitEvent(project, path, task, &global.TaskEvent{Event: global.EventTaskFinished, Detail: finalStatus})
+
+	if task.QA.Verdict == global.QAVerdictEscalate && (finalStatus == "escalate" || finalStatus == global.ExecutionStatusAwaitingApproval) {
+		r.notifyWebhooks(&global.WebhookPayload{
+			Event:   global.WebhookEventTaskEscalated,
+			Project: project,
+			Path:    path,
+			Message: fmt.Sprintf("Task %d escalated by QA for human review", task.ID),
+			Task:    &global.WebhookTask{ID: task.ID, UUID: task.UUID, ExternalID: task.ExternalID, Title: task.Title, Path: path},
+		})
+	}
 }
 
 // recordHistoryPrompt records a prompt message to task history
@@ -440,11 +619,61 @@ func (r *Runner) recordHistoryPrompt(taskUUID, role, prompt, llmID string, invoc
 	r.taskHistory.Store(taskUUID, history)
 }
 
+// dispatchOptions returns the generation parameters an LLM is configured with
+// for an attempt (1 for the first call) and the response schema it enforces
+// natively (see nativeSchema), or nil if neither is set. Retries that change the
+// parameters are noted in the project log.
+func (r *Runner) dispatchOptions(project string, task *global.Task, llmID string, attempt int, schema string) *llm.DispatchOptions {
+	llmConfig := r.llm.GetLLM(llmID)
+	if llmConfig == nil {
+		return nil
+	}
+	generation := llmConfig.GenerationFor(attempt)
+	if generation.IsEmpty() && schema == "" {
+		return nil
+	}
+	if attempt > 1 && len(llmConfig.RetryGeneration) > 0 {
+		r.logToProject(project, fmt.Sprintf("Task %d: Attempt %d with %s", task.ID, attempt, generation))
+	}
+	return &llm.DispatchOptions{GenerationParams: generation, ResponseSchema: schema}
+}
+
+// nativeSchema returns the task set's worker or QA response schema (phase
+// "worker" or "qa") when the LLM enforces schemas natively (structured_output),
+// or "" if it does not or no schema is set. Such an LLM receives the schema as a
+// dispatch option and the prompt only notes it; responses are still validated.
+func (r *Runner) nativeSchema(project, path, llmID, phase string) string {
+	llmConfig := r.llm.GetLLM(llmID)
+	if llmConfig == nil || !llmConfig.StructuredOutput {
+		return ""
+	}
+	taskSet, err := r.tasks.GetTaskSet(project, path)
+	if err != nil {
+		return ""
+	}
+	schemaPath := taskSet.WorkerResponseTemplate
+	if phase == "qa" {
+		schemaPath = taskSet.QAResponseTemplate
+	}
+	if schemaPath == "" {
+		return ""
+	}
+	return r.loadSchemaContent(project, schemaPath)
+}
+
+// writeNativeSchemaNote tells the LLM that its response format is enforced by
+// the provider, in place of the schema embedded in the prompt
+func writeNativeSchemaNote(sb *strings.Builder) {
+	sb.WriteString("=== REQUIRED RESPONSE FORMAT ===\n\n")
+	sb.WriteString("Respond with a JSON object only. The response schema is enforced by the LLM provider.\n\n")
+}
+
 // recordHistoryResponse records a response message to task history.
 // Persists envelope-summary and resource-accounting fields from the
 // DispatchResult so callers downstream (and audit consumers reading
-// results/<uuid>.json) can see them.
-func (r *Runner) recordHistoryResponse(taskUUID, role string, result *llm.DispatchResult, llmID string, invocation int) {
+// results/<uuid>.json) can see them, along with the generation parameters
+// the call was made with.
+func (r *Runner) recordHistoryResponse(taskUUID, role string, opts *llm.DispatchOptions, result *llm.DispatchResult, llmID string, invocation int) {
 	exitCode := 0
 	var msg global.Message
 	msg.Timestamp = time.Now()
@@ -452,11 +681,19 @@ func (r *Runner) recordHistoryResponse(taskUUID, role string, result *llm.Dispat
 	msg.Invocation = invocation
 	msg.LLMModelID = llmID
 	msg.Type = "response" // Legacy field for compatibility
+	if opts != nil && !opts.IsEmpty() {
+		generation := opts.GenerationParams
+		msg.Generation = &generation
+	}
+	msg.StructuredOutput = opts != nil && opts.ResponseSchema != ""
 
 	if result != nil {
 		exitCode = result.ExitCode
 		msg.Stdout = result.Stdout
 		msg.Stderr = result.Stderr
+		if result.Text != result.Stdout {
+			msg.Text = result.Text
+		}
 		msg.Content = result.Stdout // Legacy field for compatibility
 		msg.ResponseSize = result.ResponseSize
 
@@ -466,6 +703,7 @@ func (r *Runner) recordHistoryResponse(taskUUID, role string, result *llm.Dispat
 		msg.IsError = result.IsError
 		msg.Success = result.Success
 		msg.NumTurns = result.NumTurns
+		msg.SessionID = result.SessionID
 
 		// Resource accounting
 		msg.InputTokens = result.InputTokens
@@ -529,24 +767,36 @@ func (r *Runner) recordHistory(project, taskUUID, role, msgType, content, llmID
 // log record so a verbose provider error doesn't blow out the log line.
 const llmFinishErrorMaxLen = 500
 
+// taskLogger returns a logger whose records carry the project, task set and
+// task, and the run ID of the run executing the task, if any
+func (r *Runner) taskLogger(project, path string, task *global.Task) *logging.Logger {
+	fields := logging.Fields{Project: project, Path: path, TaskUUID: task.UUID, TaskID: task.ID}
+	if runID, ok := r.taskRuns.Load(task.UUID); ok {
+		fields.RunID = runID.(string)
+	}
+	return r.logger.With(fields)
+}
+
 // logLLMDispatch emits a structured "LLM dispatch" INFO record before
-// invoking an LLM. Mirrors the ClawEh dispatch logging shape.
-func (r *Runner) logLLMDispatch(taskID int, project, path, llmID string, promptBytes int) {
-	r.logger.Infof("LLM dispatch task_id=%d project=%q path=%q llm_id=%q prompt_bytes=%d",
+// invoking an LLM, through the task's logger. Mirrors the ClawEh dispatch
+// logging shape.
+func (r *Runner) logLLMDispatch(log *logging.Logger, taskID int, project, path, llmID string, promptBytes int) {
+	log.With(logging.Fields{LLMID: llmID}).Infof("LLM dispatch task_id=%d project=%q path=%q llm_id=%q prompt_bytes=%d",
 		taskID, project, path, llmID, promptBytes)
 }
 
 // logLLMFinish emits a structured "LLM finish" INFO record after an LLM
 // invocation returns, regardless of success or error. Mirrors the ClawEh
 // finish logging shape. errorMsg is truncated to llmFinishErrorMaxLen.
-func (r *Runner) logLLMFinish(taskID int, llmID string, result *llm.DispatchResult, errorMsg string) {
+func (r *Runner) logLLMFinish(log *logging.Logger, taskID int, llmID string, result *llm.DispatchResult, errorMsg string) {
+	log = log.With(logging.Fields{LLMID: llmID})
 	if result == nil {
 		// Shouldn't happen — but log what we have so we don't lose the event.
-		r.logger.Infof("LLM finish task_id=%d llm_id=%q error_msg=%q",
+		log.Infof("LLM finish task_id=%d llm_id=%q error_msg=%q",
 			taskID, llmID, truncateForLog(errorMsg, llmFinishErrorMaxLen))
 		return
 	}
-	r.logger.Infof("LLM finish task_id=%d llm_id=%q provider_model=%q success=%t exit_code=%d is_error=%t stop_reason=%q num_turns=%d input_tokens=%d output_tokens=%d cache_read_tokens=%d cache_creation_tokens=%d cost_usd=%.6f duration_ms=%d bytes_sent=%d bytes_received=%d error_msg=%q",
+	log.Infof("LLM finish task_id=%d llm_id=%q provider_model=%q success=%t exit_code=%d is_error=%t stop_reason=%q num_turns=%d input_tokens=%d output_tokens=%d cache_read_tokens=%d cache_creation_tokens=%d cost_usd=%.6f duration_ms=%d bytes_sent=%d bytes_received=%d error_msg=%q",
 		taskID,
 		llmID,
 		result.ProviderModel,
@@ -595,6 +845,18 @@ func dispatchErrorMessage(result *llm.DispatchResult) string {
 		result.StopReason, result.IsError)
 }
 
+// recentlyProbed reports whether the LLM passed an availability probe within
+// llm_probe.preflight_max_age_minutes, so the pre-flight check can skip its
+// test call. Dispatchers without probe history always need the test call.
+func (r *Runner) recentlyProbed(llmID string) (time.Time, bool) {
+	history, ok := r.llm.(llm.ProbeHistory)
+	if !ok {
+		return time.Time{}, false
+	}
+	maxAge := time.Duration(r.config.LLMProbe().PreflightMaxAgeMinutes) * time.Minute
+	return history.RecentlyAvailable(llmID, maxAge)
+}
+
 // collectUniqueLLMs collects unique LLM IDs from tasks (worker + QA)
 func (r *Runner) collectUniqueLLMs(tasks []*global.Task) []string {
 	seen := make(map[string]bool)
@@ -657,20 +919,28 @@ func (r *Runner) clearTaskHistory(taskUUID string) {
 
 // TaskStatusResult represents the status of tasks in a project
 type TaskStatusResult struct {
-	Project       string           `json:"project"`
-	TotalTasks    int              `json:"total_tasks"`
-	Pending       int              `json:"pending"`
-	InProgress    int              `json:"in_progress"`
-	Done          int              `json:"done"`
-	Failed        int              `json:"failed"`
-	RunInProgress bool             `json:"run_in_progress"`
-	Tasks         []TaskStatusInfo `json:"tasks"`
+	Project          string           `json:"project"`
+	TotalTasks       int              `json:"total_tasks"`
+	Pending          int              `json:"pending"`
+	InProgress       int              `json:"in_progress"`
+	Done             int              `json:"done"`
+	Failed           int              `json:"failed"`
+	OnHold           int              `json:"on_hold"`
+	AwaitingApproval int              `json:"awaiting_approval"` // QA results held for task_approve/task_reject
+	Blocked          int              `json:"blocked"`           // Pending tasks whose dependencies are not done
+	RunInProgress    bool             `json:"run_in_progress"`
+	Tasks            []TaskStatusInfo `json:"tasks"`
+
+	// Runs of the task sets in recovery mode, waiting for a failed or rate-limited LLM
+	Recovery []global.RunRecovery `json:"recovery,omitempty"`
 }
 
 // TaskStatusInfo represents basic task information for status checking
 type TaskStatusInfo struct {
-	ID     int    `json:"id"`
-	Status string `json:"status"`
+	ID        int      `json:"id"`
+	Status    string   `json:"status"`
+	Priority  int      `json:"priority,omitempty"`
+	BlockedBy []string `json:"blocked_by,omitempty"` // Unmet dependencies of a pending task
 }
 
 // GetTaskStatus returns the current status of tasks in a project
@@ -685,6 +955,11 @@ func (r *Runner) GetTaskStatus(project, path, taskType string) (*TaskStatusResul
 		return nil, fmt.Errorf("failed to list task sets: %w", err)
 	}
 
+	graph, err := r.tasks.DependencyGraph(project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build dependency graph: %w", err)
+	}
+
 	// Aggregate task counts across all task sets
 	result := &TaskStatusResult{
 		Project: project,
@@ -700,29 +975,43 @@ func (r *Runner) GetTaskStatus(project, path, taskType string) (*TaskStatusResul
 
 			result.TotalTasks++
 
+			info := TaskStatusInfo{
+				ID:       task.ID,
+				Status:   task.Work.Status,
+				Priority: task.Priority,
+			}
+
 			// Count by status
 			switch task.Work.Status {
 			case global.ExecutionStatusWaiting, global.ExecutionStatusRetry:
 				result.Pending++
+				if info.BlockedBy = graph.Unmet(&task); len(info.BlockedBy) > 0 {
+					result.Blocked++
+				}
 			case global.ExecutionStatusProcessing:
 				result.InProgress++
 			case global.ExecutionStatusDone:
 				result.Done++
 			case global.ExecutionStatusFailed:
 				result.Failed++
+			case global.ExecutionStatusOnHold:
+				result.OnHold++
+			case global.ExecutionStatusAwaitingApproval:
+				result.AwaitingApproval++
 			}
 
 			// Add task info
-			result.Tasks = append(result.Tasks, TaskStatusInfo{
-				ID:     task.ID,
-				Status: task.Work.Status,
-			})
+			result.Tasks = append(result.Tasks, info)
 		}
 	}
 
-	// Check if a run is in progress
-	_, runInProgress := r.runningProjects.Load(project)
-	result.RunInProgress = runInProgress
+	// Check if a run is in progress, and whether it is waiting out an LLM failure
+	result.RunInProgress = r.runLocks.running(project)
+	for _, rec := range r.Recoveries() {
+		if rec.Project == project && strings.HasPrefix(rec.Path, path) {
+			result.Recovery = append(result.Recovery, rec)
+		}
+	}
 
 	return result, nil
 }
@@ -756,40 +1045,50 @@ func (r *Runner) Run(ctx context.Context, req *global.RunRequest, notify Complet
 		if err != nil {
 			return nil, fmt.Errorf("failed to get project: %w", err)
 		}
-		if proj.DisclaimerTemplate == "" {
-			return nil, fmt.Errorf("disclaimer_template is not configured for project %s: update project with disclaimer_template set to a playbook path or 'none'", req.Project)
-		}
-		// If "none", the report generation will use empty string (current behavior)
-		// If a path, validate the file exists before starting
-		if proj.DisclaimerTemplate != "none" {
-			parts := strings.SplitN(proj.DisclaimerTemplate, "/", 2)
-			if len(parts) < 2 {
-				return nil, fmt.Errorf("invalid disclaimer_template format: must be 'playbook-name/path/to/file.md', got: %s", proj.DisclaimerTemplate)
-			}
-			fullPath := filepath.Join(r.config.PlaybooksDir(), parts[0], parts[1])
-			if _, err := os.Stat(fullPath); os.IsNotExist(err) {
-				return nil, fmt.Errorf("disclaimer template not found: %s", proj.DisclaimerTemplate)
-			}
+		// The project's disclaimer_template, or the disclaimer policy, must resolve
+		// to "none" or an existing file before the run starts
+		if _, err := r.projects.Disclaimer(proj); err != nil {
+			return nil, err
 		}
 	}
 
-	// Check if a run is already in progress
-	_, alreadyRunning := r.runningProjects.LoadOrStore(req.Project, true)
-	if alreadyRunning {
+	// Check if a run is already in progress for the project, or with
+	// taskset_locking for one of the task sets of this run
+	perTaskSet := r.config.Runner().TaskSetLocking
+	lockedPaths := make([]string, len(taskSetListForCheck.TaskSets))
+	for i, ts := range taskSetListForCheck.TaskSets {
+		lockedPaths[i] = ts.Path
+	}
+	if err := r.runLocks.acquire(req.Project, lockedPaths, perTaskSet); err != nil {
 		return &global.RunResult{
 			Project:    req.Project,
 			Path:       req.Path,
 			TasksFound: 0,
-			Message:    fmt.Sprintf("a run is already in progress for project: %s", req.Project),
+			Message:    err.Error(),
 		}, nil
 	}
+	unlock := func() { r.runLocks.release(req.Project, lockedPaths, perTaskSet) }
 
 	// List task sets at path (empty means all)
 	taskSetList, err := r.tasks.ListTaskSets(req.Project, req.Path)
 	if err != nil {
-		r.runningProjects.Delete(req.Project)
+		unlock()
 		return nil, fmt.Errorf("failed to list task sets: %w", err)
 	}
+	if perTaskSet {
+		// Leave out task sets created since the lock was taken
+		locked := make(map[string]bool, len(lockedPaths))
+		for _, path := range lockedPaths {
+			locked[path] = true
+		}
+		kept := taskSetList.TaskSets[:0]
+		for _, ts := range taskSetList.TaskSets {
+			if locked[ts.Path] {
+				kept = append(kept, ts)
+			}
+		}
+		taskSetList.TaskSets = kept
+	}
 
 	// Validate templates for task sets where SkipValidation=false
 	var templateErrors []string
@@ -803,7 +1102,7 @@ func (r *Runner) Run(ctx context.Context, req *global.RunRequest, notify Complet
 		}
 	}
 	if len(templateErrors) > 0 {
-		r.runningProjects.Delete(req.Project)
+		unlock()
 		return nil, fmt.Errorf("template validation failed:\n  - %s", strings.Join(templateErrors, "\n  - "))
 	}
 
@@ -830,6 +1129,23 @@ func (r *Runner) Run(ctx context.Context, req *global.RunRequest, notify Complet
 		}
 	}
 
+	// Refuse dependency cycles, which would block their tasks forever, and order
+	// the tasks by priority so that each one still comes after the tasks it
+	// depends on
+	if len(eligibleTasks) > 0 {
+		sortByPriority(eligibleTasks, r.config.Runner().PriorityTieBreak)
+		graph, err := r.tasks.DependencyGraph(req.Project)
+		if err != nil {
+			unlock()
+			return nil, fmt.Errorf("failed to build dependency graph: %w", err)
+		}
+		if err := graph.FindCycle(eligibleTasks); err != nil {
+			unlock()
+			return nil, err
+		}
+		eligibleTasks = graph.Order(eligibleTasks)
+	}
+
 	// Create result
 	result := &global.RunResult{
 		Project:    req.Project,
@@ -839,12 +1155,21 @@ func (r *Runner) Run(ctx context.Context, req *global.RunRequest, notify Complet
 
 	// If no tasks found, release lock and return
 	if len(eligibleTasks) == 0 {
-		r.runningProjects.Delete(req.Project)
+		unlock()
 		result.Message = "no eligible tasks found"
 		return result, nil
 	}
 
-	// Prepare execution parameters
+	// Fail fast on unwritable output, low disk space and unreadable instruction files
+	if problems := r.preRunChecks(req.Project, eligibleTasks, taskSetPaths); len(problems) > 0 {
+		unlock()
+		return nil, fmt.Errorf("pre-run checks failed:\n  - %s", strings.Join(problems, "\n  - "))
+	}
+
+	// Prepare execution parameters; the run ID correlates the log records and
+	// journal of the run
+	runID := uuid.New().String()
+	result.RunID = runID
 	// Use context.Background() so the goroutine is not cancelled when the MCP request context ends
 	// (e.g., when the stdio connection closes after returning the response)
 	execParams := &runExecutionParams{
@@ -854,6 +1179,8 @@ func (r *Runner) Run(ctx context.Context, req *global.RunRequest, notify Complet
 		eligibleTasks: eligibleTasks,
 		result:        result,
 		notify:        notify,
+		runID:         runID,
+		journal:       r.startJournal(req.Project, runID, global.JournalKindRun, req),
 	}
 
 	// Async execution - return immediately
@@ -861,7 +1188,8 @@ func (r *Runner) Run(ctx context.Context, req *global.RunRequest, notify Complet
 	r.activeRuns.Add(1)
 	go func() {
 		defer r.activeRuns.Done()
-		defer r.runningProjects.Delete(req.Project)
+		defer unlock()
+		defer execParams.journal.finish()
 		r.executeRun(execParams)
 	}()
 
@@ -876,10 +1204,16 @@ type runExecutionParams struct {
 	eligibleTasks []*global.Task
 	result        *global.RunResult
 	notify        CompletionSink // host completion sink; nil ⇒ no callback
+	runID         string         // correlation ID of the run
+	journal       *runJournal    // write-ahead run journal; nil ⇒ not journaled
 }
 
 // executeRun performs the actual task execution (shared between sync and async modes)
 func (r *Runner) executeRun(params *runExecutionParams) {
+	log := r.logger.With(logging.Fields{Project: params.req.Project, RunID: params.runID})
+	startedAt := time.Now()
+	templates := r.runTemplates(params.req.Project)
+
 	// Get limits from first task set or use config defaults
 	var limits global.Limits
 	if len(params.taskSetList.TaskSets) > 0 {
@@ -893,33 +1227,47 @@ func (r *Runner) executeRun(params *runExecutionParams) {
 
 	// Calculate LLM call budget to prevent runaway costs
 	budget := r.newRunBudget(params.eligibleTasks, limits, 0.10)
-	r.logger.Infof("Starting run for project %s: %d eligible tasks, LLM budget: %d calls (limits: worker=%d, qa=%d)",
-		params.req.Project, len(params.eligibleTasks), budget.maxCalls, limits.MaxWorker, limits.MaxQA)
-	r.logToProject(params.req.Project, fmt.Sprintf("Run started: %d eligible tasks, LLM call budget: %d (limits: worker=%d, qa=%d)",
-		len(params.eligibleTasks), budget.maxCalls, limits.MaxWorker, limits.MaxQA))
+	budget.maxCostUSD = r.runCostLimit(params.taskSetList.TaskSets)
+	budget.journal = params.journal
+	budget.runID = params.runID
+	costNote := ""
+	if budget.maxCostUSD > 0 {
+		costNote = fmt.Sprintf(", cost limit: $%.2f", budget.maxCostUSD)
+	}
+	log.Infof("Starting run for project %s: %d eligible tasks, LLM budget: %d calls%s (limits: worker=%d, qa=%d)",
+		params.req.Project, len(params.eligibleTasks), budget.maxCalls, costNote, limits.MaxWorker, limits.MaxQA)
+	r.logToProject(params.req.Project, fmt.Sprintf("Run started: %d eligible tasks, LLM call budget: %d%s (limits: worker=%d, qa=%d)",
+		len(params.eligibleTasks), budget.maxCalls, costNote, limits.MaxWorker, limits.MaxQA))
 
 	// Pre-flight LLM check: test all LLMs that will be used
 	llmsToTest := r.collectUniqueLLMs(params.eligibleTasks)
 	if len(llmsToTest) > 0 {
-		r.logger.Infof("Pre-flight check: testing %d LLM(s) (%s)", len(llmsToTest), strings.Join(llmsToTest, ", "))
+		log.Infof("Pre-flight check: testing %d LLM(s) (%s)", len(llmsToTest), strings.Join(llmsToTest, ", "))
 		r.logToProject(params.req.Project, fmt.Sprintf("Pre-flight check: testing %d LLM(s)", len(llmsToTest)))
 
+		primed := make(map[string]bool) // LLMs loaded by their test call
 		for _, llmID := range llmsToTest {
+			if probedAt, ok := r.recentlyProbed(llmID); ok {
+				log.Infof("Pre-flight check: %s OK (probed %s ago)", llmID, time.Since(probedAt).Round(time.Second))
+				continue
+			}
 			available, err := r.llm.TestLLM(llmID)
 			if err != nil {
-				r.logger.Errorf("Pre-flight check failed for %s: %v", llmID, err)
+				log.Errorf("Pre-flight check failed for %s: %v", llmID, err)
 				r.logToProject(params.req.Project, fmt.Sprintf("Pre-flight check failed for %s: %v", llmID, err))
 				return
 			}
 			if !available {
-				r.logger.Errorf("Pre-flight check: LLM %s is not available", llmID)
+				log.Errorf("Pre-flight check: LLM %s is not available", llmID)
 				r.logToProject(params.req.Project, fmt.Sprintf("Pre-flight check: LLM %s is not available (possibly rate limited)", llmID))
 				return
 			}
-			r.logger.Infof("Pre-flight check: %s OK", llmID)
+			log.Infof("Pre-flight check: %s OK", llmID)
+			primed[llmID] = true
 		}
-		r.logger.Infof("Pre-flight check: all LLMs available, starting %d tasks", len(params.eligibleTasks))
+		log.Infof("Pre-flight check: all LLMs available, starting %d tasks", len(params.eligibleTasks))
 		r.logToProject(params.req.Project, fmt.Sprintf("Pre-flight check passed, starting %d tasks", len(params.eligibleTasks)))
+		r.warmUpLLMs(params.req.Project, llmsToTest, primed)
 	}
 
 	// Determine parallel mode: req.Parallel overrides taskset.Parallel
@@ -941,17 +1289,47 @@ func (r *Runner) executeRun(params *runExecutionParams) {
 	}
 
 	// Log budget usage
-	r.logger.Infof("Run completed for project %s: executed=%d, succeeded=%d, failed=%d, skipped=%d, LLM calls: %d/%d",
+	params.result.SpentUSD = budget.spent()
+	params.result.MaxCostUSD = budget.maxCostUSD
+	params.result.CostExceeded = budget.costExceeded
+	params.result.QASeverityHalt = budget.severityHalt
+	spend := fmt.Sprintf("$%.4f", params.result.SpentUSD)
+	if budget.maxCostUSD > 0 {
+		spend += fmt.Sprintf("/$%.4f", budget.maxCostUSD)
+	}
+	log.Infof("Run completed for project %s: executed=%d, succeeded=%d, failed=%d, skipped=%d, LLM calls: %d/%d, spend: %s",
 		params.req.Project, params.result.TasksExecuted, params.result.TasksSucceeded, params.result.TasksFailed, params.result.TasksSkipped,
-		budget.used(), budget.maxCalls)
-	completionMsg := fmt.Sprintf("Run completed: executed=%d, succeeded=%d, failed=%d, skipped=%d, LLM calls: %d/%d",
+		budget.used(), budget.maxCalls, spend)
+	completionMsg := fmt.Sprintf("Run completed: executed=%d, succeeded=%d, failed=%d, skipped=%d, LLM calls: %d/%d, spend: %s",
 		params.result.TasksExecuted, params.result.TasksSucceeded, params.result.TasksFailed, params.result.TasksSkipped,
-		budget.used(), budget.maxCalls)
-	if budget.exceeded {
+		budget.used(), budget.maxCalls, spend)
+	if budget.costExceeded {
+		completionMsg += " [COST LIMIT REACHED - some tasks skipped]"
+	} else if budget.severityHalt {
+		completionMsg += " [QA SEVERITY THRESHOLD REACHED - some tasks skipped]"
+	} else if budget.exceeded {
 		completionMsg += " [BUDGET EXCEEDED - some tasks skipped]"
 	}
 	r.logToProject(params.req.Project, completionMsg)
 
+	lastRun := &global.DashboardRun{
+		Path:           params.req.Path,
+		StartedAt:      startedAt,
+		CompletedAt:    time.Now(),
+		TasksFound:     params.result.TasksFound,
+		TasksExecuted:  params.result.TasksExecuted,
+		TasksSucceeded: params.result.TasksSucceeded,
+		TasksFailed:    params.result.TasksFailed,
+		TasksSkipped:   params.result.TasksSkipped,
+		LLMCalls:       budget.used(),
+		LLMCallBudget:  budget.maxCalls,
+		BudgetExceeded: budget.exceeded,
+		Templates:      templates,
+	}
+
+	// Record this run's metrics before reporting so trend sections include it
+	r.recordTrend(params.req.Project, lastRun)
+
 	// Determine if any taskset requires report generation (has SkipValidation=false)
 	needsReport := false
 	for _, ts := range params.taskSetList.TaskSets {
@@ -962,10 +1340,18 @@ func (r *Runner) executeRun(params *runExecutionParams) {
 	}
 
 	// Auto-generate report only for tasksets with SkipValidation=false
+	var reports []string
 	if needsReport {
-		if _, err := r.generateAndSaveReport(params.req.Project, params.req.Path); err != nil {
-			r.logger.Errorf("Failed to generate report for project %s: %v", params.req.Project, err)
+		generated, err := r.generateAndSaveReport(params.req.Project, params.req.Path, nil, false)
+		if err != nil {
+			log.Errorf("Failed to generate report for project %s: %v", params.req.Project, err)
 		}
+		reports = generated
+	}
+
+	// Write the machine-readable dashboard if enabled
+	if r.config.Runner().Dashboard {
+		r.writeDashboard(params.req.Project, lastRun)
 	}
 
 	// Build the set of taskset paths that had at least one eligible task in this run.
@@ -994,21 +1380,81 @@ func (r *Runner) executeRun(params *runExecutionParams) {
 			r.sendCallback(params.req.Project, ts, params.notify)
 		}
 	}
+
+	r.notifyRunWebhooks(params.req.Project, params.req.Path, params.result, budget, reports)
 }
 
-// Wait blocks until all active runs complete. Used for graceful shutdown.
+// Wait blocks until all active runs complete and their webhooks are delivered.
+// Used for graceful shutdown.
 func (r *Runner) Wait() {
 	r.activeRuns.Wait()
+	r.webhookSends.Wait()
 }
 
 // IsRunning returns true if any runs are currently in progress.
 func (r *Runner) IsRunning() bool {
-	running := false
-	r.runningProjects.Range(func(_, _ interface{}) bool {
-		running = true
-		return false // stop iteration
+	return len(r.runLocks.list()) > 0
+}
+
+// IsProjectRunning returns true if a run is in progress for the project,
+// including runs that lock only some of its task sets.
+func (r *Runner) IsProjectRunning(project string) bool {
+	return r.runLocks.running(project)
+}
+
+// RunningProjects returns the projects with a run in progress
+func (r *Runner) RunningProjects() []string {
+	return r.runLocks.list()
+}
+
+// Recoveries returns the task set runs in progress that are in recovery mode,
+// waiting for an LLM to become available again
+func (r *Runner) Recoveries() []global.RunRecovery {
+	var recoveries []global.RunRecovery
+	r.recoveries.Range(func(key, value any) bool {
+		rs := value.(*recoveryState)
+		if rs.isInRecovery() {
+			project, path, _ := strings.Cut(key.(string), "/")
+			recoveries = append(recoveries, rs.describe(project, path))
+		}
+		return true
 	})
-	return running
+	sort.Slice(recoveries, func(i, j int) bool {
+		return recoveries[i].Project+recoveries[i].Path < recoveries[j].Project+recoveries[j].Path
+	})
+	return recoveries
+}
+
+// PendingTasks counts the tasks of every project that are waiting, due for a
+// retry or being processed
+func (r *Runner) PendingTasks() (map[string]int, error) {
+	pending := map[string]int{
+		global.ExecutionStatusWaiting:    0,
+		global.ExecutionStatusRetry:      0,
+		global.ExecutionStatusProcessing: 0,
+	}
+	for offset := 0; ; offset += global.DefaultLimit {
+		list, err := r.projects.List("", global.DefaultLimit, offset)
+		if err != nil {
+			return pending, err
+		}
+		for _, info := range list.Projects {
+			taskSetList, err := r.tasks.ListTaskSets(info.Name, "")
+			if err != nil {
+				continue
+			}
+			for _, ts := range taskSetList.TaskSets {
+				for _, task := range ts.Tasks {
+					if _, ok := pending[task.Work.Status]; ok {
+						pending[task.Work.Status]++
+					}
+				}
+			}
+		}
+		if offset+len(list.Projects) >= list.Total || len(list.Projects) == 0 {
+			return pending, nil
+		}
+	}
 }
 
 // getTasksNeedingRetry returns tasks that are in waiting or retry status and need re-processing.
@@ -1030,23 +1476,35 @@ func (r *Runner) getTasksNeedingRetry(project, path string) []*global.Task {
 		}
 	}
 
-	return tasksNeedingRetry
+	if len(tasksNeedingRetry) == 0 {
+		return nil
+	}
+	sortByPriority(tasksNeedingRetry, r.config.Runner().PriorityTieBreak)
+	graph, err := r.tasks.DependencyGraph(project)
+	if err != nil {
+		r.logger.Warnf("Failed to build dependency graph for retry check: %v", err)
+		return tasksNeedingRetry
+	}
+	return graph.Order(tasksNeedingRetry)
 }
 
 // runSequential executes tasks one at a time.
 // In sequential mode, tasks are assumed to be dependent on previous tasks completing.
 // If a task is not done (failed, waiting, etc.), the pass ends and we move to the next round.
 func (r *Runner) runSequential(ctx context.Context, project, path string, tasks []*global.Task, result *global.RunResult, budget *runBudget, limits global.Limits) {
+	log := r.logger.With(logging.Fields{Project: project, Path: path, RunID: budget.id()})
 	maxRounds := r.config.Runner().MaxRounds
 	runnerConfig := r.config.Runner()
 	roundDelay := time.Duration(runnerConfig.RoundDelaySeconds) * time.Second
 	recovery := newRecoveryState()
+	r.recoveries.Store(project+"/"+path, recovery)
+	defer r.recoveries.Delete(project + "/" + path)
 
 	// Process tasks in rounds until no more need processing
 	for round := 1; round <= maxRounds; round++ {
 		// Apply round delay before second and subsequent rounds
 		if round > 1 && roundDelay > 0 {
-			r.logger.Infof("Round delay: waiting %v before starting round %d", roundDelay, round)
+			log.Infof("Round delay: waiting %v before starting round %d", roundDelay, round)
 			r.logToProject(project, fmt.Sprintf("Round delay: waiting %v before round %d", roundDelay, round))
 			select {
 			case <-ctx.Done():
@@ -1063,10 +1521,10 @@ func (r *Runner) runSequential(ctx context.Context, project, path string, tasks
 		} else {
 			// Subsequent rounds re-fetch tasks in waiting status
 			tasksToProcess = r.getTasksNeedingRetry(project, path)
-			if len(tasksToProcess) == 0 {
-				break // No more tasks need processing
+			if len(r.readyTasks(project, tasksToProcess, nil)) == 0 {
+				break // No more tasks need processing, or all are blocked by dependencies
 			}
-			r.logger.Infof("Round %d/%d: %d task(s) need processing", round, maxRounds, len(tasksToProcess))
+			log.Infof("Round %d/%d: %d task(s) need processing", round, maxRounds, len(tasksToProcess))
 			r.logToProject(project, fmt.Sprintf("Round %d/%d: %d task(s) need processing", round, maxRounds, len(tasksToProcess)))
 		}
 
@@ -1081,7 +1539,7 @@ func (r *Runner) runSequential(ctx context.Context, project, path string, tasks
 
 			// Check if we should abort due to recovery timeout
 			if recovery.shouldAbort() {
-				r.logger.Warnf("Recovery timeout reached, aborting run. Uncompleted tasks remain in waiting status.")
+				log.Warnf("Recovery timeout reached, aborting run. Uncompleted tasks remain in waiting status.")
 				r.logToProject(project, "Recovery timeout reached, aborting run. Uncompleted tasks remain in waiting status.")
 				return
 			}
@@ -1096,7 +1554,7 @@ func (r *Runner) runSequential(ctx context.Context, project, path string, tasks
 
 			// Check if budget exceeded before starting task
 			if budget != nil && budget.exceeded {
-				r.logger.Warnf("Task %d: Skipping - LLM budget exceeded", task.ID)
+				log.Warnf("Task %d: Skipping - LLM budget exceeded", task.ID)
 				r.logToProject(project, fmt.Sprintf("Task %d: Skipped - LLM budget exceeded", task.ID))
 				result.TasksSkipped++
 				passComplete = false
@@ -1106,19 +1564,26 @@ func (r *Runner) runSequential(ctx context.Context, project, path string, tasks
 			// Need to find the task set path for this task
 			taskInfo, taskSetPath, err := r.tasks.GetTask(project, task.UUID)
 			if err != nil {
-				r.logger.Errorf("Task %d: Failed to get task set path: %v", task.ID, err)
+				log.Errorf("Task %d: Failed to get task set path: %v", task.ID, err)
 				result.TasksSkipped++
 				passComplete = false
 				break // End this pass - can't proceed without task info
 			}
 
+			// Tasks are ordered by dependency, so a task still blocked here waits on
+			// tasks that cannot finish in this pass; leave it waiting and continue
+			if unmet := r.unmetDependencies(project, taskSetPath, taskInfo); len(unmet) > 0 {
+				log.Infof("Task %d: Blocked by dependencies that are not done: %s", task.ID, strings.Join(unmet, ", "))
+				continue
+			}
+
 			// Execute the task
 			r.executeTaskWithRecovery(ctx, project, taskSetPath, taskInfo, result, budget, limits, recovery)
 
 			// Refresh task status after execution
 			updatedTask, _, err := r.tasks.GetTask(project, task.UUID)
 			if err != nil {
-				r.logger.Errorf("Task %d: Failed to refresh task status: %v", task.ID, err)
+				log.Errorf("Task %d: Failed to refresh task status: %v", task.ID, err)
 				passComplete = false
 				break
 			}
@@ -1130,41 +1595,38 @@ func (r *Runner) runSequential(ctx context.Context, project, path string, tasks
 			}
 		}
 
-		// If we completed the pass with all tasks done, we're finished
+		// If we completed the pass with all runnable tasks done, we're finished
 		if passComplete {
 			remaining := r.getTasksNeedingRetry(project, path)
-			if len(remaining) == 0 {
-				break // All done!
+			if len(r.readyTasks(project, remaining, nil)) == 0 {
+				break // All done, or only tasks blocked by dependencies remain
 			}
 		}
 	}
 
-	// Check if max rounds reached with tasks still waiting
-	// Note: We don't mark tasks as failed here - they remain in waiting status
-	// so a future run can pick them up
-	remainingTasks := r.getTasksNeedingRetry(project, path)
-	if len(remainingTasks) > 0 {
-		r.logger.Warnf("Max rounds (%d) reached with %d task(s) still waiting", maxRounds, len(remainingTasks))
-		r.logToProject(project, fmt.Sprintf("Max rounds (%d) reached with %d task(s) still waiting. Tasks remain in waiting status for future runs.", maxRounds, len(remainingTasks)))
-	}
+	r.logRemainingTasks(project, path, maxRounds)
 }
 
 // runParallel executes tasks concurrently with a worker pool.
-// In parallel mode, tasks are independent and can run concurrently.
+// In parallel mode, tasks are independent and can run concurrently, except that
+// a task with depends_on starts only after its dependencies are done.
 // If a task fails, other tasks continue. Recovery mode is checked between rounds.
 func (r *Runner) runParallel(ctx context.Context, project, path string, tasks []*global.Task, result *global.RunResult, maxConcurrent int, budget *runBudget, limits global.Limits) {
+	log := r.logger.With(logging.Fields{Project: project, Path: path, RunID: budget.id()})
 	var mu sync.Mutex
 	sem := make(chan struct{}, maxConcurrent)
 	maxRounds := r.config.Runner().MaxRounds
 	runnerConfig := r.config.Runner()
 	roundDelay := time.Duration(runnerConfig.RoundDelaySeconds) * time.Second
 	recovery := newRecoveryState()
+	r.recoveries.Store(project+"/"+path, recovery)
+	defer r.recoveries.Delete(project + "/" + path)
 
 	// Process tasks in rounds until no more need processing
 	for round := 1; round <= maxRounds; round++ {
 		// Apply round delay before second and subsequent rounds
 		if round > 1 && roundDelay > 0 {
-			r.logger.Infof("Round delay: waiting %v before starting round %d", roundDelay, round)
+			log.Infof("Round delay: waiting %v before starting round %d", roundDelay, round)
 			r.logToProject(project, fmt.Sprintf("Round delay: waiting %v before round %d", roundDelay, round))
 			select {
 			case <-ctx.Done():
@@ -1175,7 +1637,7 @@ func (r *Runner) runParallel(ctx context.Context, project, path string, tasks []
 
 		// Check if we should abort due to recovery timeout
 		if recovery.shouldAbort() {
-			r.logger.Warnf("Recovery timeout reached, aborting run. Uncompleted tasks remain in waiting status.")
+			log.Warnf("Recovery timeout reached, aborting run. Uncompleted tasks remain in waiting status.")
 			r.logToProject(project, "Recovery timeout reached, aborting run. Uncompleted tasks remain in waiting status.")
 			return
 		}
@@ -1196,98 +1658,103 @@ func (r *Runner) runParallel(ctx context.Context, project, path string, tasks []
 		} else {
 			// Subsequent rounds re-fetch tasks in waiting status
 			tasksToProcess = r.getTasksNeedingRetry(project, path)
-			if len(tasksToProcess) == 0 {
-				break // No more tasks need processing
+			if len(r.readyTasks(project, tasksToProcess, nil)) == 0 {
+				break // No more tasks need processing, or all are blocked by dependencies
 			}
-			r.logger.Infof("Round %d/%d: %d task(s) need processing", round, maxRounds, len(tasksToProcess))
+			log.Infof("Round %d/%d: %d task(s) need processing", round, maxRounds, len(tasksToProcess))
 			r.logToProject(project, fmt.Sprintf("Round %d/%d: %d task(s) need processing", round, maxRounds, len(tasksToProcess)))
 		}
 
-		var wg sync.WaitGroup
-
-		for _, task := range tasksToProcess {
-			select {
-			case <-ctx.Done():
-				wg.Wait() // Wait for in-flight tasks before returning
-				return
-			default:
+		// Tasks start in waves: each wave runs the tasks whose dependencies are done,
+		// so a dependent task starts once the wave completing its dependencies ends
+		attempted := make(map[string]bool, len(tasksToProcess))
+		for {
+			wave := r.readyTasks(project, tasksToProcess, attempted)
+			if len(wave) == 0 {
+				break
 			}
 
-			// Check if budget exceeded before starting task
-			if budget != nil && budget.exceeded {
-				r.logger.Warnf("Task %d: Skipping - LLM budget exceeded", task.ID)
-				r.logToProject(project, fmt.Sprintf("Task %d: Skipped - LLM budget exceeded", task.ID))
-				mu.Lock()
-				result.TasksSkipped++
-				mu.Unlock()
-				continue
-			}
+			var wg sync.WaitGroup
 
-			wg.Add(1)
-			sem <- struct{}{}
+			for _, task := range wave {
+				attempted[task.UUID] = true
 
-			go func(t *global.Task) {
-				defer wg.Done()
-				defer func() { <-sem }()
+				select {
+				case <-ctx.Done():
+					wg.Wait() // Wait for in-flight tasks before returning
+					return
+				default:
+				}
 
-				// Need to find the task set path for this task
-				taskInfo, taskSetPath, err := r.tasks.GetTask(project, t.UUID)
-				if err != nil {
-					r.logger.Errorf("Task %d: Failed to get task set path: %v", t.ID, err)
+				// Check if budget exceeded before starting task
+				if budget != nil && budget.exceeded {
+					log.Warnf("Task %d: Skipping - LLM budget exceeded", task.ID)
+					r.logToProject(project, fmt.Sprintf("Task %d: Skipped - LLM budget exceeded", task.ID))
 					mu.Lock()
 					result.TasksSkipped++
 					mu.Unlock()
-					return
+					continue
 				}
 
-				localResult := &global.RunResult{}
-				r.executeTask(ctx, project, taskSetPath, taskInfo, localResult, budget, limits)
-
-				// Merge results
-				mu.Lock()
-				result.TasksExecuted += localResult.TasksExecuted
-				result.TasksSucceeded += localResult.TasksSucceeded
-				result.TasksFailed += localResult.TasksFailed
-				result.TasksSkipped += localResult.TasksSkipped
-				mu.Unlock()
-
-				// Check if task failed and we should enter recovery mode
-				// This is checked after task completion to allow other workers to finish
-				updatedTask, _, getErr := r.tasks.GetTask(project, t.UUID)
-				if getErr == nil && updatedTask.Work.Status != global.ExecutionStatusDone {
-					llmID := t.Work.LLMModelID
-					if llmID == "" {
-						llmID = r.config.DefaultLLM()
+				wg.Add(1)
+				sem <- struct{}{}
+
+				go func(t *global.Task) {
+					defer wg.Done()
+					defer func() { <-sem }()
+
+					// Need to find the task set path for this task
+					taskInfo, taskSetPath, err := r.tasks.GetTask(project, t.UUID)
+					if err != nil {
+						log.Errorf("Task %d: Failed to get task set path: %v", t.ID, err)
+						mu.Lock()
+						result.TasksSkipped++
+						mu.Unlock()
+						return
+					}
+
+					localResult := &global.RunResult{}
+					r.executeTask(ctx, project, taskSetPath, taskInfo, localResult, budget, limits)
+
+					// Merge results
+					mu.Lock()
+					result.TasksExecuted += localResult.TasksExecuted
+					result.TasksSucceeded += localResult.TasksSucceeded
+					result.TasksFailed += localResult.TasksFailed
+					result.TasksSkipped += localResult.TasksSkipped
+					mu.Unlock()
+
+					// Check if task failed and we should enter recovery mode
+					// This is checked after task completion to allow other workers to finish
+					updatedTask, _, getErr := r.tasks.GetTask(project, t.UUID)
+					if getErr == nil && updatedTask.Work.Status != global.ExecutionStatusDone {
+						llmID := t.Work.LLMModelID
 						if llmID == "" {
-							enabledLLMs := r.config.EnabledLLMs()
-							if len(enabledLLMs) > 0 {
-								llmID = enabledLLMs[0].ID
+							llmID = r.config.DefaultLLM()
+							if llmID == "" {
+								enabledLLMs := r.config.EnabledLLMs()
+								if len(enabledLLMs) > 0 {
+									llmID = enabledLLMs[0].ID
+								}
 							}
 						}
+						// Resolve alias to canonical id so recovery state always stores canonical form
+						llmID = r.config.ResolveID(llmID)
+						llmConfig := r.llm.GetLLM(llmID)
+						if llmConfig != nil && llmConfig.RecoveryConfig != nil {
+							log.Infof("Task %d: Failed - entering recovery mode for LLM %s", t.ID, llmID)
+							r.logToProject(project, fmt.Sprintf("Task %d: Failed - entering recovery mode for LLM %s", t.ID, llmID))
+							recovery.enterRecovery(llmID, llmConfig)
+						}
 					}
-					// Resolve alias to canonical id so recovery state always stores canonical form
-					llmID = r.config.ResolveID(llmID)
-					llmConfig := r.llm.GetLLM(llmID)
-					if llmConfig != nil && llmConfig.RecoveryConfig != nil {
-						r.logger.Infof("Task %d: Failed - entering recovery mode for LLM %s", t.ID, llmID)
-						r.logToProject(project, fmt.Sprintf("Task %d: Failed - entering recovery mode for LLM %s", t.ID, llmID))
-						recovery.enterRecovery(llmID, llmConfig)
-					}
-				}
-			}(task)
-		}
+				}(task)
+			}
 
-		wg.Wait()
+			wg.Wait()
+		}
 	}
 
-	// Check if max rounds reached with tasks still waiting
-	// Note: We don't mark tasks as failed here - they remain in waiting status
-	// so a future run can pick them up
-	remainingTasks := r.getTasksNeedingRetry(project, path)
-	if len(remainingTasks) > 0 {
-		r.logger.Warnf("Max rounds (%d) reached with %d task(s) still waiting", maxRounds, len(remainingTasks))
-		r.logToProject(project, fmt.Sprintf("Max rounds (%d) reached with %d task(s) still waiting. Tasks remain in waiting status for future runs.", maxRounds, len(remainingTasks)))
-	}
+	r.logRemainingTasks(project, path, maxRounds)
 }
 
 // handleRecovery waits for recovery mode to complete by probing the LLM.
@@ -1303,6 +1770,7 @@ func (r *Runner) handleRecovery(ctx context.Context, project string, recovery *r
 
 		// Wait for the scheduled duration
 		waitDuration := recovery.getWaitDuration()
+		recovery.scheduleProbe(waitDuration)
 		llmID := recovery.getLLMID()
 		r.logger.Infof("Project %s: Recovery mode - waiting %v before probing LLM %s", project, waitDuration, llmID)
 		r.logToProject(project, fmt.Sprintf("Recovery mode: waiting %v before probing LLM %s", waitDuration, llmID))
@@ -1363,6 +1831,8 @@ func (r *Runner) handleRecovery(ctx context.Context, project string, recovery *r
 // executeTaskWithRecovery executes a task and enters recovery mode if it fails.
 // This wrapper is used in sequential mode where we need to pause on failures.
 func (r *Runner) executeTaskWithRecovery(ctx context.Context, project, path string, task *global.Task, result *global.RunResult, budget *runBudget, limits global.Limits, recovery *recoveryState) {
+	log := r.taskLogger(project, path, task)
+
 	// Check for cancellation
 	select {
 	case <-ctx.Done():
@@ -1376,7 +1846,7 @@ func (r *Runner) executeTaskWithRecovery(ctx context.Context, project, path stri
 	// Check if the task failed - if so, we may need to enter recovery mode
 	updatedTask, _, err := r.tasks.GetTask(project, task.UUID)
 	if err != nil {
-		r.logger.Warnf("Task %d: Failed to get task status after execution: %v", task.ID, err)
+		log.Warnf("Task %d: Failed to get task status after execution: %v", task.ID, err)
 		return
 	}
 
@@ -1397,7 +1867,7 @@ func (r *Runner) executeTaskWithRecovery(ctx context.Context, project, path stri
 
 		llmConfig := r.llm.GetLLM(llmID)
 		if llmConfig != nil && llmConfig.RecoveryConfig != nil {
-			r.logger.Infof("Task %d: Failed - entering recovery mode for LLM %s", task.ID, llmID)
+			log.Infof("Task %d: Failed - entering recovery mode for LLM %s", task.ID, llmID)
 			r.logToProject(project, fmt.Sprintf("Task %d: Failed - entering recovery mode for LLM %s", task.ID, llmID))
 			recovery.enterRecovery(llmID, llmConfig)
 		}
@@ -1406,30 +1876,51 @@ func (r *Runner) executeTaskWithRecovery(ctx context.Context, project, path stri
 
 // executeTask executes a single task
 func (r *Runner) executeTask(_ context.Context, project, path string, task *global.Task, result *global.RunResult, budget *runBudget, limits global.Limits) {
+	// The task's own limits override its task set's
+	limits = limits.ForTask(task)
+
+	// Records of the task carry the run ID until it returns
+	if runID := budget.id(); runID != "" {
+		r.taskRuns.Store(task.UUID, runID)
+		defer r.taskRuns.Delete(task.UUID)
+	}
+	log := r.taskLogger(project, path, task)
+
+	// Log final "Finished" status for terminal states only, on every exit path.
+	// Re-fetch task to get final status after all updates. Deferred before the
+	// panic recovery so it runs after it.
+	budget.journal.taskStarted(path, task)
+	defer func() {
+		if finalTask, _, err := r.tasks.GetTask(project, task.UUID); err == nil {
+			r.logTaskFinished(project, path, finalTask)
+		}
+		budget.journal.taskEnded(path, task)
+		r.redactionMaps.Delete(task.UUID)
+	}()
+
 	// Panic recovery to prevent crashes
 	defer func() {
 		if rec := recover(); rec != nil {
 			errMsg := fmt.Sprintf("PANIC in task execution: %v", rec)
-			r.logger.Errorf("Task %d: %s", task.ID, errMsg)
+			log.Errorf("Task %d: %s", task.ID, errMsg)
 			r.logToProject(project, fmt.Sprintf("Task %d crashed: %v", task.ID, rec))
 			r.finishTask(project, path, task, "", errMsg, "", "", result, limits, false, "")
 		}
 	}()
 
 	// Wait for rate limiter
-	r.logger.Infof("Task %d: Waiting for rate limiter", task.ID)
+	log.Infof("Task %d: Waiting for rate limiter", task.ID)
 	r.rateLimiter.Wait()
-	r.logger.Infof("Task %d: Rate limiter passed", task.ID)
+	log.Infof("Task %d: Rate limiter passed", task.ID)
 
 	// Check if work has already completed successfully (has results file with worker response)
 	// This prevents re-calling the worker LLM when only QA needs to be retried
-	resultsDir := r.tasks.GetResultsDir(project)
-	resultPath := filepath.Join(resultsDir, task.UUID+".json")
+	resultPath := r.tasks.ResultFile(project, path, task, global.ResultFileSuffix)
 	if data, err := os.ReadFile(resultPath); err == nil {
 		var existingResult global.TaskResult
 		if err := json.Unmarshal(data, &existingResult); err == nil && existingResult.Worker.Status == global.ExecutionStatusDone {
 			// Work already completed - skip to QA workflow if needed
-			r.logger.Infof("Task %d: Work already completed (found existing result), checking QA status", task.ID)
+			log.Infof("Task %d: Work already completed (found existing result), checking QA status", task.ID)
 			r.logToProject(project, fmt.Sprintf("Task %d: Work already completed, checking QA", task.ID))
 
 			// Update local task with the response for QA
@@ -1437,7 +1928,7 @@ func (r *Runner) executeTask(_ context.Context, project, path string, task *glob
 
 			// Check if QA needs to be run
 			if task.QA.Enabled && task.QA.Status != global.ExecutionStatusDone {
-				r.logger.Infof("Task %d: QA enabled and not complete, starting QA workflow", task.ID)
+				log.Infof("Task %d: QA enabled and not complete, starting QA workflow", task.ID)
 				r.executeQAWorkflow(project, path, task, result, budget, limits)
 			}
 			return
@@ -1448,8 +1939,14 @@ func (r *Runner) executeTask(_ context.Context, project, path string, task *glob
 	llmID, ok := r.dispatchLLMID(task.Work.LLMModelID)
 	if !ok {
 		r.logToProject(project, fmt.Sprintf("Task %d: Failed - no LLMs are enabled", task.ID))
-		r.logger.Errorf("Task %d: Failed - no LLMs are enabled", task.ID)
-		r.failTaskPreExecution(project, task, "no_llm_enabled", "no LLMs are enabled", result)
+		log.Errorf("Task %d: Failed - no LLMs are enabled", task.ID)
+		r.failTaskPreExecution(project, path, task, "no_llm_enabled", "no LLMs are enabled", result)
+		return
+	}
+	if err := r.CheckLLMAllowed(project, llmID); err != nil {
+		r.logToProject(project, fmt.Sprintf("Task %d: Failed - %v", task.ID, err))
+		log.Errorf("Task %d: Failed - %v", task.ID, err)
+		r.failTaskPreExecution(project, path, task, global.ErrorCodeLLMNotAllowed, err.Error(), result)
 		return
 	}
 	// Store resolved canonical LLM ID for result file
@@ -1457,7 +1954,7 @@ func (r *Runner) executeTask(_ context.Context, project, path string, task *glob
 
 	// Update task metadata but keep status as 'waiting' until fully complete
 	// This ensures restarts will pick up interrupted tasks
-	r.logger.Infof("Task %d: Updating task metadata (status stays waiting until complete)", task.ID)
+	log.Infof("Task %d: Updating task metadata (status stays waiting until complete)", task.ID)
 	now := time.Now()
 	task.Work.Invocations++
 	task.Work.LastAttemptAt = &now
@@ -1471,31 +1968,37 @@ func (r *Runner) executeTask(_ context.Context, project, path string, task *glob
 		},
 	}
 	if _, err := r.tasks.UpdateTask(project, task.UUID, updates); err != nil {
-		r.logger.Warnf("Task %d: Failed to save task metadata: %v", task.ID, err)
+		log.Warnf("Task %d: Failed to save task metadata: %v", task.ID, err)
 	}
 
 	result.TasksExecuted++
-	r.logger.Infof("Task %d: Beginning execution", task.ID)
+	log.Infof("Task %d: Beginning execution", task.ID)
+	r.emitEvent(project, path, task, &global.TaskEvent{Event: global.EventTaskStarted, Phase: "worker", LLMModelID: llmID})
 
 	// Build prompt from instructions_file, instructions, prompt
-	r.logger.Infof("Task %d: Building prompt", task.ID)
+	log.Infof("Task %d: Building prompt", task.ID)
 	fullPrompt, err := r.buildPrompt(project, path, task)
 	if err != nil {
-		r.logger.Errorf("Task %d: Failed to build prompt: %v", task.ID, err)
+		log.Errorf("Task %d: Failed to build prompt: %v", task.ID, err)
 		r.logToProject(project, fmt.Sprintf("Task %d: Failed to build prompt: %v", task.ID, err))
 		r.recordHistory(project, task.UUID, "system", "error", fmt.Sprintf("Failed to build prompt: %v", err), "", task.Work.Invocations)
+		// A prompt too large for the LLM will not fit on a retry either
+		if _, tooLarge := err.(*promptTooLargeError); tooLarge {
+			r.failTaskPreExecution(project, path, task, global.ErrorCodePromptTooLarge, err.Error(), result)
+			return
+		}
 		r.finishTask(project, path, task, "", err.Error(), "", "", result, limits, false, "")
 		return
 	}
 	promptSize := len(fullPrompt)
-	r.logger.Infof("Task %d: Prompt built (%d bytes)", task.ID, promptSize)
+	log.Infof("Task %d: Prompt built (%d bytes)", task.ID, promptSize)
 
 	// Record worker prompt in history
 	r.recordHistory(project, task.UUID, "worker", "prompt", fullPrompt, llmID, task.Work.Invocations)
 
 	// Check budget before LLM call
 	if !budget.checkAndIncrement() {
-		r.logger.Warnf("Task %d: LLM budget exceeded, skipping", task.ID)
+		log.Warnf("Task %d: LLM budget exceeded, skipping", task.ID)
 		r.logToProject(project, fmt.Sprintf("Task %d: LLM budget exceeded, skipping", task.ID))
 		r.finishTask(project, path, task, "", "LLM budget exceeded", fullPrompt, "", result, limits, false, "")
 		return
@@ -1510,37 +2013,39 @@ func (r *Runner) executeTask(_ context.Context, project, path string, task *glob
 		mode = execInfo.Mode
 		promptInput = execInfo.PromptInput
 	}
-	r.logger.Infof("Task %d: Calling LLM: %s, mode: %s, prompt: %s, size: %d bytes", task.ID, displayName, mode, promptInput, promptSize)
+	log.Infof("Task %d: Calling LLM: %s, mode: %s, prompt: %s, size: %d bytes", task.ID, displayName, mode, promptInput, promptSize)
 	r.logToProject(project, fmt.Sprintf("Task %d: Calling LLM: %s, mode: %s, prompt: %s, size: %d bytes", task.ID, displayName, mode, promptInput, promptSize))
 
 	dispatchReq := &llm.DispatchRequest{
-		LLMID:  llmID,
-		Prompt: fullPrompt,
+		LLMID:   llmID,
+		Prompt:  fullPrompt,
+		Options: r.dispatchOptions(project, task, llmID, task.Work.Invocations, r.nativeSchema(project, path, llmID, "worker")),
 	}
 
-	r.logger.Infof("Task %d: Dispatching to LLM service", task.ID)
-	r.logLLMDispatch(task.ID, project, path, llmID, len(fullPrompt))
+	log.Infof("Task %d: Dispatching to LLM service", task.ID)
+	r.logLLMDispatch(log, task.ID, project, path, llmID, len(fullPrompt))
+	r.emitEvent(project, path, task, &global.TaskEvent{Event: global.EventLLMDispatched, Phase: "worker", LLMModelID: llmID})
 	llmStartTime := time.Now()
-	dispatchResult, err := r.llm.Dispatch(dispatchReq)
+	dispatchResult, err := r.dispatchWorker(project, path, task, budget, "worker", dispatchReq)
 
 	// Handle infrastructure errors (command couldn't execute at all)
 	if err != nil {
-		r.logger.Errorf("Task %d: Infrastructure error: %v", task.ID, err)
+		log.Errorf("Task %d: Infrastructure error: %v", task.ID, err)
 		r.logToProject(project, fmt.Sprintf("Task %d: Infrastructure error: %v", task.ID, err))
 		r.recordHistoryError(task.UUID, "worker", err.Error(), llmID, task.Work.Invocations)
 		// Emit a finish record for the infra failure so log scrapes always
 		// see a paired dispatch/finish event.
-		r.logLLMFinish(task.ID, llmID, nil, err.Error())
+		r.logLLMFinish(log, task.ID, llmID, nil, err.Error())
 
 		// Increment infrastructure retry counter
 		task.Work.InfraRetries++
 		if task.Work.InfraRetries >= limits.MaxRetries {
-			r.logger.Errorf("Task %d: Max infrastructure retries (%d) exceeded", task.ID, limits.MaxRetries)
+			log.Errorf("Task %d: Max infrastructure retries (%d) exceeded", task.ID, limits.MaxRetries)
 			r.logToProject(project, fmt.Sprintf("Task %d: Max infrastructure retries exceeded", task.ID))
 			r.finishTaskWithInfraError(project, path, task, err.Error(), fullPrompt, result, limits)
 		} else {
 			// Schedule retry
-			r.logger.Infof("Task %d: Will retry (%d/%d infrastructure retries)", task.ID, task.Work.InfraRetries, limits.MaxRetries)
+			log.Infof("Task %d: Will retry (%d/%d infrastructure retries)", task.ID, task.Work.InfraRetries, limits.MaxRetries)
 			r.logToProject(project, fmt.Sprintf("Task %d: Will retry (%d/%d infrastructure retries)", task.ID, task.Work.InfraRetries, limits.MaxRetries))
 			updates := map[string]interface{}{
 				"work": map[string]interface{}{
@@ -1550,7 +2055,7 @@ func (r *Runner) executeTask(_ context.Context, project, path string, task *glob
 				},
 			}
 			if _, updateErr := r.tasks.UpdateTask(project, task.UUID, updates); updateErr != nil {
-				r.logger.Errorf("Task %d: Failed to save retry status: %v", task.ID, updateErr)
+				log.Errorf("Task %d: Failed to save retry status: %v", task.ID, updateErr)
 			}
 			result.TasksFailed++
 		}
@@ -1568,26 +2073,27 @@ func (r *Runner) executeTask(_ context.Context, project, path string, task *glob
 	if dispatchFailed {
 		finishErrMsg = dispatchErrorMessage(dispatchResult)
 	}
-	r.logLLMFinish(task.ID, llmID, dispatchResult, finishErrMsg)
+	r.logLLMFinish(log, task.ID, llmID, dispatchResult, finishErrMsg)
+	r.recordSpend(project, task.ID, budget, llmID, dispatchResult)
 	r.logToProject(project, fmt.Sprintf("Task %d: LLM finished exit_code=%d success=%t bytes_received=%d duration_ms=%d (wall=%.1fs)",
 		task.ID, dispatchResult.ExitCode, dispatchResult.Success, dispatchResult.BytesReceived, dispatchResult.DurationMs, llmElapsed))
 
 	// Record response in history with full DispatchResult
-	r.recordHistoryResponse(task.UUID, "worker", dispatchResult, llmID, task.Work.Invocations)
+	r.recordHistoryResponse(task.UUID, "worker", dispatchReq.Options, dispatchResult, llmID, task.Work.Invocations)
 
 	// Check for dispatch failure: non-zero exit code OR provider-reported error envelope.
 	if dispatchFailed {
 		errorMsg := finishErrMsg
-		r.logger.Warnf("Task %d: %s", task.ID, errorMsg)
+		log.Warnf("Task %d: %s", task.ID, errorMsg)
 		r.logToProject(project, fmt.Sprintf("Task %d: %s", task.ID, errorMsg))
 
 		// Check if we're under the invocation limit
 		if task.Work.Invocations >= limits.MaxWorker {
-			r.logger.Errorf("Task %d: Max worker invocations (%d) exceeded", task.ID, limits.MaxWorker)
+			log.Errorf("Task %d: Max worker invocations (%d) exceeded", task.ID, limits.MaxWorker)
 			r.finishTask(project, path, task, "", errorMsg, fullPrompt, dispatchResult.Stderr, result, limits, false, dispatchResult.StopReason)
 		} else {
 			// Schedule retry
-			r.logger.Infof("Task %d: Will retry (%d/%d worker invocations)", task.ID, task.Work.Invocations, limits.MaxWorker)
+			log.Infof("Task %d: Will retry (%d/%d worker invocations)", task.ID, task.Work.Invocations, limits.MaxWorker)
 			r.logToProject(project, fmt.Sprintf("Task %d: Will retry (%d/%d worker invocations)", task.ID, task.Work.Invocations, limits.MaxWorker))
 			updates := map[string]interface{}{
 				"work": map[string]interface{}{
@@ -1596,7 +2102,7 @@ func (r *Runner) executeTask(_ context.Context, project, path string, task *glob
 				},
 			}
 			if _, updateErr := r.tasks.UpdateTask(project, task.UUID, updates); updateErr != nil {
-				r.logger.Errorf("Task %d: Failed to save retry status: %v", task.ID, updateErr)
+				log.Errorf("Task %d: Failed to save retry status: %v", task.ID, updateErr)
 			}
 			result.TasksFailed++
 		}
@@ -1608,21 +2114,27 @@ func (r *Runner) executeTask(_ context.Context, project, path string, task *glob
 	if response == "" && !dispatchResult.ResponseParsed {
 		response = dispatchResult.Stdout
 	}
-	r.logger.Infof("Task %d: Saving result", task.ID)
+	log.Infof("Task %d: Saving result", task.ID)
 	r.finishTask(project, path, task, response, "", fullPrompt, dispatchResult.Stderr, result, limits, dispatchResult.NormalTermination, dispatchResult.StopReason)
 
 	// Check if QA is enabled after successful work completion
 	if task.QA.Enabled && task.Work.Status == global.ExecutionStatusDone {
-		r.logger.Infof("Task %d: QA enabled, starting QA workflow", task.ID)
+		log.Infof("Task %d: QA enabled, starting QA workflow", task.ID)
 		r.executeQAWorkflow(project, path, task, result, budget, limits)
 	}
+}
 
-	// Log final "Finished" status for terminal states only
-	// Re-fetch task to get final status after all updates
-	finalTask, _, err := r.tasks.GetTask(project, task.UUID)
-	if err == nil {
-		r.logTaskFinished(project, finalTask)
+// playbookVersion returns the current version of the playbook an instructions
+// file comes from, or 0 if it does not come from a playbook
+func (r *Runner) playbookVersion(file, source string) int {
+	if source != "playbook" || r.playbooks == nil {
+		return 0
 	}
+	name, _, ok := strings.Cut(file, "/")
+	if !ok {
+		return 0
+	}
+	return r.playbooks.Version(name)
 }
 
 // loadInstructionsFile loads instructions from the appropriate source
@@ -1643,8 +2155,8 @@ func (r *Runner) loadInstructionsFile(project string, task *global.Task) (string
 		}
 
 	case "playbook":
-		if r.playbooks == nil {
-			return "", fmt.Errorf("playbooks service not available")
+		if err := r.RequireSubsystem(global.SubsystemPlaybooks, "loading instructions file "+task.Work.InstructionsFile); err != nil {
+			return "", err
 		}
 		// instructions_file should be "playbook-name/path/to/file.md"
 		// Parse playbook name and path
@@ -1662,8 +2174,8 @@ func (r *Runner) loadInstructionsFile(project string, task *global.Task) (string
 		content = item.Content
 
 	case "reference":
-		if r.reference == nil {
-			return "", fmt.Errorf("reference service not available")
+		if err := r.RequireSubsystem(global.SubsystemReference, "loading instructions file "+task.Work.InstructionsFile); err != nil {
+			return "", err
 		}
 		item, err := r.reference.Get(task.Work.InstructionsFile, 0, 0)
 		if err != nil {
@@ -1671,15 +2183,22 @@ func (r *Runner) loadInstructionsFile(project string, task *global.Task) (string
 		}
 		content = item.Content
 
+	case "shared":
+		item, err := r.shared.Get(task.Work.InstructionsFile, 0, 0)
+		if err != nil {
+			return "", fmt.Errorf("failed to load instructions file %s from shared library: %w", task.Work.InstructionsFile, err)
+		}
+		content = item.Content
+
 	default:
-		return "", fmt.Errorf("invalid instructions_file_source: %s (must be project, playbook, or reference)", source)
+		return "", fmt.Errorf("invalid instructions_file_source: %s (must be project, playbook, reference, or shared)", source)
 	}
 
 	// Replace <project> placeholders with actual project name (cross-project isolation)
 	content = strings.ReplaceAll(content, "<project>", project)
 	content = strings.ReplaceAll(content, "\"<project>\"", fmt.Sprintf("\"%s\"", project))
 
-	return content, nil
+	return global.ExpandTaskEnv(content, task.Env), nil
 }
 
 // loadSchemaContent loads schema content from a path.
@@ -1707,6 +2226,8 @@ func (r *Runner) loadSchemaContent(project, schemaPath string) string {
 				return item.Content
 			}
 			r.logger.Warnf("Failed to load schema from playbook %s/%s", playbookName, path)
+		} else if r.playbooks == nil {
+			r.logger.Warnf("Schema %s is not loaded from a playbook: %v", schemaPath, r.RequireSubsystem(global.SubsystemPlaybooks, "loading schema "+schemaPath))
 		}
 	}
 
@@ -1834,6 +2355,9 @@ func (r *Runner) validateReportTemplate(project, templatePath, templateName stri
 				errors = append(errors, fmt.Sprintf("%s manifest: entry with suffix '%s' has empty file path", templateName, config.Suffix))
 				continue
 			}
+			if config.Suffix == global.ReportIndexSuffix {
+				errors = append(errors, fmt.Sprintf("%s manifest: suffix '%s' is reserved for the report index", templateName, config.Suffix))
+			}
 
 			// Resolve relative path from manifest location
 			var resolvedPath string
@@ -1854,18 +2378,21 @@ func (r *Runner) validateReportTemplate(project, templatePath, templateName stri
 
 // buildPrompt builds the full prompt from project context, instructions_file, instructions_text, and prompt
 func (r *Runner) buildPrompt(project, path string, task *global.Task) (string, error) {
-	var sb strings.Builder
+	var prompt promptAssembler
 
 	// 0. Always inject project name (mandatory for cross-project isolation)
+	sb := prompt.section("project name", promptRequired)
 	sb.WriteString("=== PROJECT CONTEXT ===\n\n")
 	sb.WriteString(fmt.Sprintf("Project: %s\n", project))
 	sb.WriteString("IMPORTANT: Use this project name for ALL file operations (project_file_list, project_file_get, project_file_search).\n\n")
 
-	// Append optional user-defined context if available
+	// Append optional user-defined context if available (shortened first to fit the context window)
 	if proj, err := r.projects.Get(project); err == nil && proj.Context != "" {
+		sb = prompt.section("project context", promptOptional)
 		sb.WriteString(proj.Context)
 		sb.WriteString("\n\n")
 	}
+	sb = prompt.section("instructions", promptRequired)
 
 	// 1. Load instructions from file if specified
 	if task.Work.InstructionsFile != "" {
@@ -1879,21 +2406,30 @@ func (r *Runner) buildPrompt(project, path string, task *global.Task) (string, e
 
 	// 2. Append inline instructions text if specified
 	if task.Work.InstructionsText != "" {
-		sb.WriteString(task.Work.InstructionsText)
+		sb.WriteString(global.ExpandTaskEnv(task.Work.InstructionsText, task.Env))
 		sb.WriteString("\n\n")
 	}
 
 	// 3. Append task-specific prompt with separator
 	if task.Work.Prompt != "" {
 		sb.WriteString("=== TASK PROMPT ===\n\n")
-		sb.WriteString(task.Work.Prompt)
+		sb.WriteString(global.ExpandTaskEnv(task.Work.Prompt, task.Env))
 		sb.WriteString("\n\n")
 	}
 
+	// 3.4. Inline the responses of the tasks it depends on, if requested
+	r.writeDependencyResults(project, task, sb)
+
+	// 3.5. Inline the task's evidence files (shortened to fit the context window)
+	r.writeEvidence(project, task, &prompt)
+	sb = prompt.section("response format", promptRequired)
+
 	// 4. Include expected response schema with clear instructions if configured
 	if taskSet, err := r.tasks.GetTaskSet(project, path); err == nil && taskSet.WorkerResponseTemplate != "" {
 		schema := r.loadSchemaContent(project, taskSet.WorkerResponseTemplate)
-		if schema != "" {
+		if schema != "" && r.nativeSchema(project, path, task.Work.LLMModelID, "worker") != "" {
+			writeNativeSchemaNote(sb)
+		} else if schema != "" {
 			sb.WriteString("=== REQUIRED RESPONSE FORMAT ===\n\n")
 			sb.WriteString("IMPORTANT: You MUST respond with a valid JSON object that matches the schema below.\n")
 			sb.WriteString("Your response will be validated against this schema. If validation fails, you will be asked to retry.\n\n")
@@ -1903,7 +2439,15 @@ func (r *Runner) buildPrompt(project, path string, task *global.Task) (string, e
 		}
 	}
 
+	// 4.5. Require the configured output language
+	outputLanguage := r.outputLanguage(project, path)
+	writeLanguageInstructions(sb, outputLanguage)
+
+	// 4.6. Offer the task set's worker tools
+	writeWorkerToolsInstructions(sb, r.workerTools(project, path))
+
 	// 5. If there was a previous schema error, include it for retry
+	sb = prompt.section("previous attempt errors", promptRetry)
 	if task.Work.Error != "" && task.Work.Invocations > 0 && strings.Contains(task.Work.Error, "schema") {
 		sb.WriteString("=== PREVIOUS ATTEMPT FAILED - PLEASE FIX ===\n\n")
 		sb.WriteString("Your previous response did not match the required schema. Please review the errors below and provide a corrected response.\n\n")
@@ -1912,11 +2456,58 @@ func (r *Runner) buildPrompt(project, path string, task *global.Task) (string, e
 		sb.WriteString("\n\n")
 	}
 
-	return sb.String(), nil
+	// 5.1. If the previous response was in the wrong language, say so explicitly
+	if task.Work.Error != "" && task.Work.Invocations > 0 && strings.HasPrefix(task.Work.Error, languageMismatchPrefix) {
+		sb.WriteString("=== PREVIOUS ATTEMPT FAILED - PLEASE FIX ===\n\n")
+		sb.WriteString(fmt.Sprintf("Your previous response was rejected because it was not written in %s.\n", templates.LanguageName(outputLanguage)))
+		sb.WriteString(task.Work.Error)
+		sb.WriteString("\n\n")
+	}
+
+	return r.assemblePrompt(project, task, &prompt, task.Work.LLMModelID, task.Work.Invocations)
+}
+
+// effectiveQA returns the task's QA configuration with the QA defaults of its
+// task set filled in for the instructions and prompt the task does not set
+func (r *Runner) effectiveQA(project, path string, task *global.Task) global.QAExecution {
+	if taskSet, err := r.tasks.GetTaskSet(project, path); err == nil {
+		return taskSet.QADefaults.Apply(task.QA)
+	}
+	return task.QA
+}
+
+// languageMismatchPrefix starts the task error recorded when a response fails language detection
+const languageMismatchPrefix = "response language mismatch"
+
+// outputLanguage returns the required response language for a taskset.
+// The taskset setting overrides the project setting; empty means unrestricted.
+func (r *Runner) outputLanguage(project, path string) string {
+	if taskSet, err := r.tasks.GetTaskSet(project, path); err == nil && taskSet.OutputLanguage != "" {
+		return taskSet.OutputLanguage
+	}
+	if r.projects != nil {
+		if proj, err := r.projects.Get(project); err == nil {
+			return proj.OutputLanguage
+		}
+	}
+	return ""
+}
+
+// writeLanguageInstructions appends the response language requirement to a prompt
+func writeLanguageInstructions(sb *strings.Builder, language string) {
+	if language == "" {
+		return
+	}
+	name := templates.LanguageName(language)
+	sb.WriteString("=== RESPONSE LANGUAGE ===\n\n")
+	sb.WriteString(fmt.Sprintf("IMPORTANT: Your entire response MUST be written in %s (%s), regardless of the language of the source material or these instructions.\n", name, language))
+	sb.WriteString("Keep JSON field names exactly as specified by the schema; only the content must be in the required language.\n")
+	sb.WriteString("Responses predominantly written in another language will be rejected.\n\n")
 }
 
 // finishTaskWithInfraError marks a task as failed due to infrastructure errors
 func (r *Runner) finishTaskWithInfraError(project, path string, task *global.Task, errorMsg, fullPrompt string, result *global.RunResult, limits global.Limits) {
+	log := r.taskLogger(project, path, task)
 	finalError := fmt.Sprintf("max infrastructure retries exceeded: %s", errorMsg)
 	updates := map[string]interface{}{
 		"work": map[string]interface{}{
@@ -1926,11 +2517,11 @@ func (r *Runner) finishTaskWithInfraError(project, path string, task *global.Tas
 		},
 	}
 	if _, err := r.tasks.UpdateTask(project, task.UUID, updates); err != nil {
-		r.logger.Errorf("Task %d: Failed to save failed status: %v", task.ID, err)
+		log.Errorf("Task %d: Failed to save failed status: %v", task.ID, err)
 	}
 
 	// Write result file with history for debugging
-	r.writeFailedTaskResult(project, task, fullPrompt, "", finalError, "infra_max_retries_exceeded")
+	r.writeFailedTaskResult(project, path, task, fullPrompt, "", finalError, "infra_max_retries_exceeded")
 
 	result.TasksFailed++
 }
@@ -1939,6 +2530,7 @@ func (r *Runner) finishTaskWithInfraError(project, path string, task *global.Tas
 // llmStderr is optional stderr output from LLM command (pass empty string if not applicable)
 // normalTermination and stopReason describe how the LLM terminated (only meaningful on success path)
 func (r *Runner) finishTask(project, path string, task *global.Task, response, errorMsg, fullPrompt, llmStderr string, result *global.RunResult, limits global.Limits, normalTermination bool, stopReason string) {
+	log := r.taskLogger(project, path, task)
 	now := time.Now()
 
 	updates := make(map[string]interface{})
@@ -1950,11 +2542,11 @@ func (r *Runner) finishTask(project, path string, task *global.Task, response, e
 		if isFinalFailure {
 			workUpdates["status"] = global.ExecutionStatusFailed
 			r.logToProject(project, fmt.Sprintf("Task %d: Failed (max worker invocations reached): %s", task.ID, errorMsg))
-			r.logger.Errorf("Task %d: Failed (max worker invocations reached): %s", task.ID, errorMsg)
+			log.Errorf("Task %d: Failed (max worker invocations reached): %s", task.ID, errorMsg)
 		} else {
 			workUpdates["status"] = global.ExecutionStatusWaiting // Allow retry
 			r.logToProject(project, fmt.Sprintf("Task %d: Failed, will retry (%d/%d): %s", task.ID, task.Work.Invocations, limits.MaxWorker, errorMsg))
-			r.logger.Warnf("Task %d: Failed, will retry (%d/%d): %s", task.ID, task.Work.Invocations, limits.MaxWorker, errorMsg)
+			log.Warnf("Task %d: Failed, will retry (%d/%d): %s", task.ID, task.Work.Invocations, limits.MaxWorker, errorMsg)
 		}
 		workUpdates["error"] = errorMsg
 		updates["work"] = workUpdates
@@ -1962,12 +2554,12 @@ func (r *Runner) finishTask(project, path string, task *global.Task, response, e
 
 		// Save task updates
 		if _, err := r.tasks.UpdateTask(project, task.UUID, updates); err != nil {
-			r.logger.Errorf("Task %d: Failed to save task status: %v", task.ID, err)
+			log.Errorf("Task %d: Failed to save task status: %v", task.ID, err)
 		}
 
 		// Write result file with history for debugging (only on final failure)
 		if isFinalFailure {
-			r.writeFailedTaskResult(project, task, fullPrompt, response, errorMsg, "max_invocations_exceeded")
+			r.writeFailedTaskResult(project, path, task, fullPrompt, response, errorMsg, "max_invocations_exceeded")
 		}
 	} else {
 		// Validate response against task set schema if configured (skip if SkipValidation=true).
@@ -2014,14 +2606,14 @@ func (r *Runner) finishTask(project, path string, task *global.Task, response, e
 						LLMModelID:       task.Work.LLMModelID,
 						History:          r.getTaskHistory(task.UUID),
 					}
-					errorFilename, writeErr := r.writeErrorFile(project, errorDetails)
+					errorFilename, writeErr := r.writeErrorFile(project, path, task, errorDetails)
 					if writeErr != nil {
-						r.logger.Warnf("Task %d: Failed to write error file: %v", task.ID, writeErr)
+						log.Warnf("Task %d: Failed to write error file: %v", task.ID, writeErr)
 						errorFilename = "(failed to write)"
 					}
 
 					// Log brief message with file reference
-					r.logger.Warnf("Task %d: Worker schema validation failed (%d errors). Details: results/%s", task.ID, len(errorMessages), errorFilename)
+					log.Warnf("Task %d: Worker schema validation failed (%d errors). Details: results/%s", task.ID, len(errorMessages), errorFilename)
 					r.logToProject(project, fmt.Sprintf("Task %d: Worker schema validation failed (%d errors). Details: results/%s", task.ID, len(errorMessages), errorFilename))
 
 					// Record in history (without the full schema)
@@ -2031,27 +2623,68 @@ func (r *Runner) finishTask(project, path string, task *global.Task, response, e
 					if canRetry {
 						workUpdates["status"] = global.ExecutionStatusWaiting // Allow retry
 						r.logToProject(project, fmt.Sprintf("Task %d: Schema validation failed, will retry (%d/%d)", task.ID, task.Work.Invocations, limits.MaxWorker))
-						r.logger.Warnf("Task %d: Schema validation failed, will retry (%d/%d)", task.ID, task.Work.Invocations, limits.MaxWorker)
+						log.Warnf("Task %d: Schema validation failed, will retry (%d/%d)", task.ID, task.Work.Invocations, limits.MaxWorker)
 					} else {
 						workUpdates["status"] = global.ExecutionStatusFailed
 						r.logToProject(project, fmt.Sprintf("Task %d: Schema validation failed, max retries reached", task.ID))
-						r.logger.Errorf("Task %d: Schema validation failed, max retries reached (%d/%d)", task.ID, task.Work.Invocations, limits.MaxWorker)
+						log.Errorf("Task %d: Schema validation failed, max retries reached (%d/%d)", task.ID, task.Work.Invocations, limits.MaxWorker)
 					}
 					workUpdates["error"] = historyMsg
 					updates["work"] = workUpdates
 					result.TasksFailed++
 
 					if _, err := r.tasks.UpdateTask(project, task.UUID, updates); err != nil {
-						r.logger.Errorf("Task %d: Failed to save task status: %v", task.ID, err)
+						log.Errorf("Task %d: Failed to save task status: %v", task.ID, err)
 					}
 
 					// Write result file with history for final failures
 					if !canRetry {
-						r.writeFailedTaskResult(project, task, fullPrompt, response, historyMsg, errorType)
+						r.writeFailedTaskResult(project, path, task, fullPrompt, response, historyMsg, errorType)
 					}
 					return
 				}
-				r.logger.Infof("Task %d: Response validated against schema", task.ID)
+				log.Infof("Task %d: Response validated against schema", task.ID)
+			}
+		}
+
+		// Enforce the configured output language (post-hoc detection)
+		if expected := r.outputLanguage(project, path); expected != "" {
+			check := templates.CheckLanguage(response, expected)
+			if !check.Match {
+				detected := "an unrecognized language"
+				if check.Detected != "" {
+					detected = fmt.Sprintf("%s (%s)", templates.LanguageName(check.Detected), check.Detected)
+				}
+				languageErr := fmt.Sprintf("%s: expected %s (%s), detected %s", languageMismatchPrefix,
+					templates.LanguageName(expected), expected, detected)
+				canRetry := task.Work.Invocations < limits.MaxWorker
+
+				r.recordHistory(project, task.UUID, "system", "validation", languageErr, task.Work.LLMModelID, task.Work.Invocations)
+				if canRetry {
+					workUpdates["status"] = global.ExecutionStatusWaiting // Allow retry
+					r.logToProject(project, fmt.Sprintf("Task %d: Language check failed, will retry (%d/%d): %s", task.ID, task.Work.Invocations, limits.MaxWorker, languageErr))
+					log.Warnf("Task %d: Language check failed, will retry (%d/%d): %s", task.ID, task.Work.Invocations, limits.MaxWorker, languageErr)
+				} else {
+					workUpdates["status"] = global.ExecutionStatusFailed
+					workUpdates["error_code"] = "language_mismatch"
+					r.logToProject(project, fmt.Sprintf("Task %d: Language check failed, max retries reached: %s", task.ID, languageErr))
+					log.Errorf("Task %d: Language check failed, max retries reached (%d/%d): %s", task.ID, task.Work.Invocations, limits.MaxWorker, languageErr)
+				}
+				workUpdates["error"] = languageErr
+				updates["work"] = workUpdates
+				result.TasksFailed++
+
+				if _, err := r.tasks.UpdateTask(project, task.UUID, updates); err != nil {
+					log.Errorf("Task %d: Failed to save task status: %v", task.ID, err)
+				}
+
+				if !canRetry {
+					r.writeFailedTaskResult(project, path, task, fullPrompt, response, languageErr, "language_mismatch")
+				}
+				return
+			}
+			if check.Conclusive {
+				log.Infof("Task %d: Response language verified (%s)", task.ID, expected)
 			}
 		}
 
@@ -2068,21 +2701,23 @@ func (r *Runner) finishTask(project, path string, task *global.Task, response, e
 
 		responseSize := len(response)
 		r.logToProject(project, fmt.Sprintf("Task %d: Worker completed successfully (response: %d bytes)", task.ID, responseSize))
-		r.logger.Infof("Task %d: Worker completed successfully (response: %d bytes)", task.ID, responseSize)
+		log.Infof("Task %d: Worker completed successfully (response: %d bytes)", task.ID, responseSize)
 
 		// Save result to file with complete audit trail
 		taskResult := global.TaskResult{
-			TaskID:      task.ID,
-			TaskUUID:    task.UUID,
-			TaskTitle:   task.Title,
-			TaskType:    task.Type,
-			CreatedAt:   task.CreatedAt,
-			CompletedAt: now,
+			TaskID:         task.ID,
+			TaskUUID:       task.UUID,
+			TaskExternalID: task.ExternalID,
+			TaskTitle:      task.Title,
+			TaskType:       task.Type,
+			CreatedAt:      task.CreatedAt,
+			CompletedAt:    now,
 			Worker: global.WorkerResult{
 				InstructionsFile:       task.Work.InstructionsFile,
 				InstructionsFileSource: task.Work.InstructionsFileSource,
 				InstructionsText:       task.Work.InstructionsText,
 				TaskPrompt:             task.Work.Prompt,
+				PlaybookVersion:        r.playbookVersion(task.Work.InstructionsFile, task.Work.InstructionsFileSource),
 				FullPrompt:             fullPrompt,
 				Response:               response,
 				LLMModelID:             task.Work.LLMModelID,
@@ -2090,27 +2725,28 @@ func (r *Runner) finishTask(project, path string, task *global.Task, response, e
 				Status:                 global.ExecutionStatusDone,
 				NormalTermination:      normalTermination,
 				StopReason:             stopReason,
+				PII:                    r.detectPII(project, task, "Worker", response),
 			},
 			History: r.getTaskHistory(task.UUID),
+			Summary: r.resultSummary(project, path, task, response),
 		}
 
 		// Save individual result file
-		resultsDir := r.tasks.GetResultsDir(project)
-		if err := os.MkdirAll(resultsDir, 0755); err != nil {
-			r.logger.Warnf("Task %d: Failed to create results directory: %v", task.ID, err)
+		resultPath := r.tasks.ResultFile(project, path, task, global.ResultFileSuffix)
+		if err := os.MkdirAll(filepath.Dir(resultPath), 0755); err != nil {
+			log.Warnf("Task %d: Failed to create results directory: %v", task.ID, err)
 		} else {
-			resultFilename := task.UUID + ".json"
-			resultPath := filepath.Join(resultsDir, resultFilename)
+			resultFilename := r.resultFileName(project, resultPath)
 			resultData, err := json.MarshalIndent(taskResult, "", "  ")
 			if err == nil {
-				if writeErr := os.WriteFile(resultPath, resultData, 0644); writeErr != nil {
-					r.logger.Warnf("Task %d: Failed to save result file: %v", task.ID, writeErr)
+				if writeErr := r.writeResultFile(resultPath, resultData); writeErr != nil {
+					log.Warnf("Task %d: Failed to save result file: %v", task.ID, writeErr)
 				} else {
-					r.logger.Infof("Task %d: Results written to %s (%d bytes)", task.ID, resultFilename, len(resultData))
+					log.Infof("Task %d: Results written to %s (%d bytes)", task.ID, resultFilename, len(resultData))
 					r.logToProject(project, fmt.Sprintf("Task %d: Results written to %s", task.ID, resultFilename))
 				}
 			} else {
-				r.logger.Warnf("Task %d: Failed to marshal result: %v", task.ID, err)
+				log.Warnf("Task %d: Failed to marshal result: %v", task.ID, err)
 			}
 		}
 
@@ -2120,7 +2756,7 @@ func (r *Runner) finishTask(project, path string, task *global.Task, response, e
 
 		// Save task updates
 		if _, err := r.tasks.UpdateTask(project, task.UUID, updates); err != nil {
-			r.logger.Errorf("Task %d: Failed to save task status: %v", task.ID, err)
+			log.Errorf("Task %d: Failed to save task status: %v", task.ID, err)
 		}
 	}
 }
@@ -2128,7 +2764,8 @@ func (r *Runner) finishTask(project, path string, task *global.Task, response, e
 // failTaskPreExecution marks a task as terminally failed before any LLM execution
 // can be attempted (e.g. no enabled LLMs). It updates the task status, writes a
 // failure result file, and increments the run's TasksFailed counter.
-func (r *Runner) failTaskPreExecution(project string, task *global.Task, errorCode, errorMsg string, result *global.RunResult) {
+func (r *Runner) failTaskPreExecution(project, path string, task *global.Task, errorCode, errorMsg string, result *global.RunResult) {
+	log := r.taskLogger(project, path, task)
 	updates := map[string]interface{}{
 		"work": map[string]interface{}{
 			"status":     global.ExecutionStatusFailed,
@@ -2137,7 +2774,7 @@ func (r *Runner) failTaskPreExecution(project string, task *global.Task, errorCo
 		},
 	}
 	if _, err := r.tasks.UpdateTask(project, task.UUID, updates); err != nil {
-		r.logger.Errorf("Task %d: Failed to save pre-execution failed status: %v", task.ID, err)
+		log.Errorf("Task %d: Failed to save pre-execution failed status: %v", task.ID, err)
 	}
 
 	// Mirror the persisted state on the in-memory copy for any subsequent reads.
@@ -2145,30 +2782,41 @@ func (r *Runner) failTaskPreExecution(project string, task *global.Task, errorCo
 	task.Work.Error = errorMsg
 	task.Work.ErrorCode = errorCode
 
-	r.writeFailedTaskResult(project, task, "", "", errorMsg, errorCode)
+	r.writeFailedTaskResult(project, path, task, "", "", errorMsg, errorCode)
 
 	if result != nil {
 		result.TasksFailed++
 	}
 }
 
+// resultFileName returns a result file path relative to the results directory, for logging
+func (r *Runner) resultFileName(project, resultPath string) string {
+	if rel, err := filepath.Rel(r.tasks.GetResultsDir(project), resultPath); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.Base(resultPath)
+}
+
 // writeFailedTaskResult writes a result file for a failed task, preserving history for debugging.
 // errorCode is an optional machine-readable failure code (empty when not classified).
-func (r *Runner) writeFailedTaskResult(project string, task *global.Task, fullPrompt, response, errorMsg, errorCode string) {
+func (r *Runner) writeFailedTaskResult(project, path string, task *global.Task, fullPrompt, response, errorMsg, errorCode string) {
+	log := r.taskLogger(project, path, task)
 	now := time.Now()
 
 	taskResult := global.TaskResult{
-		TaskID:      task.ID,
-		TaskUUID:    task.UUID,
-		TaskTitle:   task.Title,
-		TaskType:    task.Type,
-		CreatedAt:   task.CreatedAt,
-		CompletedAt: now,
+		TaskID:         task.ID,
+		TaskUUID:       task.UUID,
+		TaskExternalID: task.ExternalID,
+		TaskTitle:      task.Title,
+		TaskType:       task.Type,
+		CreatedAt:      task.CreatedAt,
+		CompletedAt:    now,
 		Worker: global.WorkerResult{
 			InstructionsFile:       task.Work.InstructionsFile,
 			InstructionsFileSource: task.Work.InstructionsFileSource,
 			InstructionsText:       task.Work.InstructionsText,
 			TaskPrompt:             task.Work.Prompt,
+			PlaybookVersion:        r.playbookVersion(task.Work.InstructionsFile, task.Work.InstructionsFileSource),
 			FullPrompt:             fullPrompt,
 			Response:               response,
 			LLMModelID:             task.Work.LLMModelID,
@@ -2180,28 +2828,39 @@ func (r *Runner) writeFailedTaskResult(project string, task *global.Task, fullPr
 		History: r.getTaskHistory(task.UUID),
 	}
 
-	resultsDir := r.tasks.GetResultsDir(project)
-	if err := os.MkdirAll(resultsDir, 0755); err != nil {
-		r.logger.Warnf("Task %d: Failed to create results directory: %v", task.ID, err)
+	resultPath := r.tasks.ResultFile(project, path, task, global.ResultFileSuffix)
+	if err := os.MkdirAll(filepath.Dir(resultPath), 0755); err != nil {
+		log.Warnf("Task %d: Failed to create results directory: %v", task.ID, err)
 		return
 	}
 
-	resultFilename := task.UUID + ".json"
-	resultPath := filepath.Join(resultsDir, resultFilename)
+	resultFilename := r.resultFileName(project, resultPath)
 	resultData, err := json.MarshalIndent(taskResult, "", "  ")
 	if err != nil {
-		r.logger.Warnf("Task %d: Failed to marshal failed result: %v", task.ID, err)
+		log.Warnf("Task %d: Failed to marshal failed result: %v", task.ID, err)
 		return
 	}
 
-	if writeErr := os.WriteFile(resultPath, resultData, 0644); writeErr != nil {
-		r.logger.Warnf("Task %d: Failed to save failed result file: %v", task.ID, writeErr)
+	if writeErr := r.writeResultFile(resultPath, resultData); writeErr != nil {
+		log.Warnf("Task %d: Failed to save failed result file: %v", task.ID, writeErr)
 	} else {
-		r.logger.Infof("Task %d: Failed task results written to %s (%d bytes)", task.ID, resultFilename, len(resultData))
+		log.Infof("Task %d: Failed task results written to %s (%d bytes)", task.ID, resultFilename, len(resultData))
 		r.logToProject(project, fmt.Sprintf("Task %d: Failed task results written to %s", task.ID, resultFilename))
 	}
 }
 
+// pagedResult is a task result with the path of its task set, for cursor paging
+type pagedResult struct {
+	path   string
+	result global.TaskResult
+}
+
+// cursorKey orders results by task set path, then task ID. "#" sorts before every
+// character allowed in a path segment, so a task set sorts before its children.
+func (pr pagedResult) cursorKey() string {
+	return pr.path + "#" + global.IntCursorKey(pr.result.TaskID)
+}
+
 // GetResults retrieves task results
 func (r *Runner) GetResults(req *global.ResultsRequest) (*global.ResultsResponse, error) {
 	if !r.tasks.ProjectExists(req.Project) {
@@ -2248,7 +2907,7 @@ func (r *Runner) GetResults(req *global.ResultsRequest) (*global.ResultsResponse
 
 					// Load result file if task is done
 					if task.Work.Status == global.ExecutionStatusDone {
-						resultPath := filepath.Join(r.tasks.GetResultsDir(req.Project), task.UUID+".json")
+						resultPath := r.tasks.ResultFile(req.Project, taskSet.Path, &task, global.ResultFileSuffix)
 						data, err := os.ReadFile(resultPath)
 						if err != nil {
 							return nil, fmt.Errorf("failed to read result file: %w", err)
@@ -2280,8 +2939,10 @@ func (r *Runner) GetResults(req *global.ResultsRequest) (*global.ResultsResponse
 								Summaries: []global.TaskResultSummary{{
 									TaskID:     taskResult.TaskID,
 									TaskUUID:   taskResult.TaskUUID,
+									ExternalID: taskResult.TaskExternalID,
 									TaskTitle:  taskResult.TaskTitle,
 									WorkStatus: taskResult.Worker.Status,
+									Summary:    taskResult.Summary,
 								}},
 							}, nil
 						}
@@ -2317,8 +2978,7 @@ func (r *Runner) GetResults(req *global.ResultsRequest) (*global.ResultsResponse
 	}
 
 	// Collect all completed tasks
-	allResults := make([]global.TaskResult, 0)
-	resultsDir := r.tasks.GetResultsDir(req.Project)
+	collected := make([]pagedResult, 0)
 
 	for _, taskSet := range taskSetList.TaskSets {
 		for _, task := range taskSet.Tasks {
@@ -2329,7 +2989,7 @@ func (r *Runner) GetResults(req *global.ResultsRequest) (*global.ResultsResponse
 
 			// Only include completed tasks
 			if task.Work.Status == global.ExecutionStatusDone {
-				resultPath := filepath.Join(resultsDir, task.UUID+".json")
+				resultPath := r.tasks.ResultFile(req.Project, taskSet.Path, &task, global.ResultFileSuffix)
 				data, err := os.ReadFile(resultPath)
 				if err != nil {
 					r.logger.Warnf("Failed to read result file for task %s: %v", task.UUID, err)
@@ -2347,28 +3007,27 @@ func (r *Runner) GetResults(req *global.ResultsRequest) (*global.ResultsResponse
 					continue
 				}
 
-				allResults = append(allResults, taskResult)
+				collected = append(collected, pagedResult{path: taskSet.Path, result: taskResult})
 			}
 		}
 	}
 
-	// Apply pagination
-	total := len(allResults)
-	offset := req.Offset
+	// Apply pagination. Results are ordered by task set path, then task ID, which
+	// is also the cursor key order.
+	total := len(collected)
 	limit := req.Limit
 
 	if limit <= 0 {
 		limit = global.DefaultLimit
 	}
 
-	if offset >= total {
-		allResults = []global.TaskResult{}
-	} else {
-		end := offset + limit
-		if end > total {
-			end = total
-		}
-		allResults = allResults[offset:end]
+	page, offset, nextCursor, err := global.Paginate(collected, pagedResult.cursorKey, req.Cursor, req.Offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	allResults := make([]global.TaskResult, len(page))
+	for i, pr := range page {
+		allResults[i] = pr.result
 	}
 
 	// Return summary or full results
@@ -2378,8 +3037,10 @@ func (r *Runner) GetResults(req *global.ResultsRequest) (*global.ResultsResponse
 			summaries[i] = global.TaskResultSummary{
 				TaskID:     result.TaskID,
 				TaskUUID:   result.TaskUUID,
+				ExternalID: result.TaskExternalID,
 				TaskTitle:  result.TaskTitle,
 				WorkStatus: result.Worker.Status,
+				Summary:    result.Summary,
 			}
 		}
 		return &global.ResultsResponse{
@@ -2388,6 +3049,7 @@ func (r *Runner) GetResults(req *global.ResultsRequest) (*global.ResultsResponse
 			TotalCount:    total,
 			ReturnedCount: len(summaries),
 			Offset:        offset,
+			NextCursor:    nextCursor,
 			Summaries:     summaries,
 		}, nil
 	}
@@ -2398,6 +3060,7 @@ func (r *Runner) GetResults(req *global.ResultsRequest) (*global.ResultsResponse
 		TotalCount:    total,
 		ReturnedCount: len(allResults),
 		Offset:        offset,
+		NextCursor:    nextCursor,
 		Results:       allResults,
 	}, nil
 }
@@ -2447,24 +3110,32 @@ func ValidateTaskInstructions(instructionsFile, instructionsFileSource string) e
 		}
 		return nil
 
-	case "reference":
-		// Reference files can be any path
+	case "reference", "shared":
+		// Reference and shared library files can be any path
 		return nil
 
 	default:
-		return fmt.Errorf("invalid instructions_file_source: %s (must be project, playbook, or reference)", source)
+		return fmt.Errorf("invalid instructions_file_source: %s (must be project, playbook, reference, or shared)", source)
 	}
 }
 
 // executeQAWorkflow executes the QA workflow after successful work completion
 func (r *Runner) executeQAWorkflow(project, path string, task *global.Task, result *global.RunResult, budget *runBudget, limits global.Limits) {
-	r.logger.Infof("Task %d: Starting QA workflow (invocations: %d, max: %d)", task.ID, task.QA.Invocations, limits.MaxQA)
+	log := r.taskLogger(project, path, task)
+
+	// A validated response that matches a skip rule needs no QA review
+	if r.skipQA(project, path, task) {
+		return
+	}
+
+	log.Infof("Task %d: Starting QA workflow (invocations: %d, max: %d)", task.ID, task.QA.Invocations, limits.MaxQA)
 	r.logToProject(project, fmt.Sprintf("Task %d: Starting QA workflow", task.ID))
+	r.emitEvent(project, path, task, &global.TaskEvent{Event: global.EventQAStarted, Phase: "qa"})
 
 	for task.QA.Invocations < limits.MaxQA {
 		// Check budget before QA call
 		if budget != nil && budget.exceeded {
-			r.logger.Warnf("Task %d: LLM budget exceeded, stopping QA workflow", task.ID)
+			log.Warnf("Task %d: LLM budget exceeded, stopping QA workflow", task.ID)
 			r.logToProject(project, fmt.Sprintf("Task %d: LLM budget exceeded, QA stopped", task.ID))
 			return
 		}
@@ -2475,7 +3146,7 @@ func (r *Runner) executeQAWorkflow(project, path string, task *global.Task, resu
 			// Check if it's a schema validation error that can be retried
 			if sve, ok := IsSchemaValidationError(err); ok {
 				if sve.CanRetry {
-					r.logger.Infof("Task %d: QA schema validation failed, will retry (%d/%d). Error file: results/%s",
+					log.Infof("Task %d: QA schema validation failed, will retry (%d/%d). Error file: results/%s",
 						task.ID, task.QA.Invocations, limits.MaxQA, sve.ErrorFilename)
 					r.logToProject(project, fmt.Sprintf("Task %d: QA schema validation failed, will retry (%d/%d)",
 						task.ID, task.QA.Invocations, limits.MaxQA))
@@ -2483,7 +3154,7 @@ func (r *Runner) executeQAWorkflow(project, path string, task *global.Task, resu
 					continue
 				}
 				// Max retries reached - status already set to failed in executeQA
-				r.logger.Errorf("Task %d: QA schema validation failed, max retries reached (%d/%d)",
+				log.Errorf("Task %d: QA schema validation failed, max retries reached (%d/%d)",
 					task.ID, task.QA.Invocations, limits.MaxQA)
 				r.logToProject(project, fmt.Sprintf("Task %d: QA schema validation failed, max retries reached",
 					task.ID))
@@ -2491,7 +3162,7 @@ func (r *Runner) executeQAWorkflow(project, path string, task *global.Task, resu
 			}
 
 			// Other errors - mark as failed
-			r.logger.Errorf("Task %d: QA execution failed: %v", task.ID, err)
+			log.Errorf("Task %d: QA execution failed: %v", task.ID, err)
 			r.logToProject(project, fmt.Sprintf("Task %d: QA execution failed: %v", task.ID, err))
 
 			// Mark both QA and Work as failed to prevent infinite retry rounds
@@ -2504,7 +3175,7 @@ func (r *Runner) executeQAWorkflow(project, path string, task *global.Task, resu
 				},
 			}
 			if _, updateErr := r.tasks.UpdateTask(project, task.UUID, qaUpdates); updateErr != nil {
-				r.logger.Errorf("Task %d: Failed to save QA failure status: %v", task.ID, updateErr)
+				log.Errorf("Task %d: Failed to save QA failure status: %v", task.ID, updateErr)
 			}
 			return
 		}
@@ -2512,20 +3183,23 @@ func (r *Runner) executeQAWorkflow(project, path string, task *global.Task, resu
 		// Handle QA verdict
 		switch task.QA.Verdict {
 		case global.QAVerdictPass:
-			r.logger.Infof("Task %d: QA passed", task.ID)
+			log.Infof("Task %d: QA passed", task.ID)
 			r.logToProject(project, fmt.Sprintf("Task %d: QA passed", task.ID))
+			r.holdForApproval(project, path, task)
 			return
 
 		case global.QAVerdictEscalate:
-			r.logger.Warnf("Task %d: QA escalated - cannot be resolved by QA", task.ID)
+			log.Warnf("Task %d: QA escalated - cannot be resolved by QA", task.ID)
 			r.logToProject(project, fmt.Sprintf("Task %d: QA escalated", task.ID))
-			// Status is already set to "done" with verdict "escalate" - no further action needed
+			// Status is already set to "done" with verdict "escalate" unless the
+			// task set's approval gate holds it for a human decision
+			r.holdForApproval(project, path, task)
 			return
 
 		case global.QAVerdictFail:
 			// Check if we can retry
 			if task.QA.Invocations >= limits.MaxQA {
-				r.logger.Warnf("Task %d: QA failed and max QA invocations reached (%d/%d)", task.ID, task.QA.Invocations, limits.MaxQA)
+				log.Warnf("Task %d: QA failed and max QA invocations reached (%d/%d)", task.ID, task.QA.Invocations, limits.MaxQA)
 				r.logToProject(project, fmt.Sprintf("Task %d: QA failed, max invocations reached", task.ID))
 
 				// Mark both QA and Work as failed to prevent infinite retry rounds
@@ -2538,25 +3212,25 @@ func (r *Runner) executeQAWorkflow(project, path string, task *global.Task, resu
 					},
 				}
 				if _, updateErr := r.tasks.UpdateTask(project, task.UUID, qaUpdates); updateErr != nil {
-					r.logger.Errorf("Task %d: Failed to save QA failure status: %v", task.ID, updateErr)
+					log.Errorf("Task %d: Failed to save QA failure status: %v", task.ID, updateErr)
 				}
 				return
 			}
 
 			// Check budget before revision
 			if budget != nil && budget.exceeded {
-				r.logger.Warnf("Task %d: LLM budget exceeded, stopping QA workflow", task.ID)
+				log.Warnf("Task %d: LLM budget exceeded, stopping QA workflow", task.ID)
 				r.logToProject(project, fmt.Sprintf("Task %d: LLM budget exceeded, revision stopped", task.ID))
 				return
 			}
 
 			// Revise work with QA feedback
-			r.logger.Infof("Task %d: QA verdict 'fail', revising work (%d/%d)", task.ID, task.QA.Invocations, limits.MaxQA)
+			log.Infof("Task %d: QA verdict 'fail', revising work (%d/%d)", task.ID, task.QA.Invocations, limits.MaxQA)
 			r.logToProject(project, fmt.Sprintf("Task %d: QA failed, revising work (%d/%d)", task.ID, task.QA.Invocations, limits.MaxQA))
 
 			err = r.reviseWork(project, path, task, budget, limits)
 			if err != nil {
-				r.logger.Errorf("Task %d: Work revision failed: %v", task.ID, err)
+				log.Errorf("Task %d: Work revision failed: %v", task.ID, err)
 				r.logToProject(project, fmt.Sprintf("Task %d: Work revision failed: %v", task.ID, err))
 
 				// Mark both QA and Work as failed to prevent infinite retry rounds
@@ -2570,7 +3244,7 @@ func (r *Runner) executeQAWorkflow(project, path string, task *global.Task, resu
 					},
 				}
 				if _, updateErr := r.tasks.UpdateTask(project, task.UUID, qaUpdates); updateErr != nil {
-					r.logger.Errorf("Task %d: Failed to save QA failure status: %v", task.ID, updateErr)
+					log.Errorf("Task %d: Failed to save QA failure status: %v", task.ID, updateErr)
 				}
 				return
 			}
@@ -2580,11 +3254,21 @@ func (r *Runner) executeQAWorkflow(project, path string, task *global.Task, resu
 
 // executeQA executes the QA step for a task
 func (r *Runner) executeQA(project, path string, task *global.Task, budget *runBudget, limits global.Limits) error {
-	r.logger.Infof("Task %d: Executing QA", task.ID)
+	log := r.taskLogger(project, path, task)
+	log.Infof("Task %d: Executing QA", task.ID)
 
 	// Increment QA invocation count
 	task.QA.Invocations++
 
+	// Determine QA LLM (host-dispatch: the host selects it). The prompt depends
+	// on whether it enforces the response schema natively.
+	qaLLMID, ok := r.dispatchLLMID(task.QA.LLMModelID)
+	if !ok {
+		return fmt.Errorf("no LLMs are enabled")
+	}
+	// Store resolved canonical LLM ID back to task so results always record canonical form
+	task.QA.LLMModelID = qaLLMID
+
 	// Build QA prompt
 	qaPrompt, err := r.buildQAPrompt(project, path, task)
 	if err != nil {
@@ -2592,15 +3276,7 @@ func (r *Runner) executeQA(project, path string, task *global.Task, budget *runB
 	}
 
 	qaPromptSize := len(qaPrompt)
-	r.logger.Infof("Task %d: QA prompt built (%d bytes)", task.ID, qaPromptSize)
-
-	// Determine QA LLM (host-dispatch: the host selects it).
-	qaLLMID, ok := r.dispatchLLMID(task.QA.LLMModelID)
-	if !ok {
-		return fmt.Errorf("no LLMs are enabled")
-	}
-	// Store resolved canonical LLM ID back to task so results always record canonical form
-	task.QA.LLMModelID = qaLLMID
+	log.Infof("Task %d: QA prompt built (%d bytes)", task.ID, qaPromptSize)
 
 	// Get exec info for detailed logging
 	qaExecInfo := r.llm.GetExecInfo(qaLLMID)
@@ -2611,7 +3287,7 @@ func (r *Runner) executeQA(project, path string, task *global.Task, budget *runB
 		qaMode = qaExecInfo.Mode
 		qaPromptInput = qaExecInfo.PromptInput
 	}
-	r.logger.Infof("Task %d: Calling QA LLM: %s, mode: %s, prompt: %s, size: %d bytes", task.ID, qaDisplayName, qaMode, qaPromptInput, qaPromptSize)
+	log.Infof("Task %d: Calling QA LLM: %s, mode: %s, prompt: %s, size: %d bytes", task.ID, qaDisplayName, qaMode, qaPromptInput, qaPromptSize)
 	r.logToProject(project, fmt.Sprintf("Task %d: Calling QA LLM: %s, mode: %s, prompt: %s, size: %d bytes", task.ID, qaDisplayName, qaMode, qaPromptInput, qaPromptSize))
 
 	// Record QA prompt in history
@@ -2625,7 +3301,7 @@ func (r *Runner) executeQA(project, path string, task *global.Task, budget *runB
 		},
 	}
 	if _, err := r.tasks.UpdateTask(project, task.UUID, qaUpdates); err != nil {
-		r.logger.Warnf("Task %d: Failed to save QA processing status: %v", task.ID, err)
+		log.Warnf("Task %d: Failed to save QA processing status: %v", task.ID, err)
 	}
 
 	// Check budget before LLM call
@@ -2635,16 +3311,18 @@ func (r *Runner) executeQA(project, path string, task *global.Task, budget *runB
 
 	// Call LLM
 	dispatchReq := &llm.DispatchRequest{
-		LLMID:  qaLLMID,
-		Prompt: qaPrompt,
+		LLMID:   qaLLMID,
+		Prompt:  qaPrompt,
+		Options: r.dispatchOptions(project, task, qaLLMID, task.QA.Invocations, r.nativeSchema(project, path, qaLLMID, "qa")),
 	}
 
-	r.logLLMDispatch(task.ID, project, path, qaLLMID, len(qaPrompt))
+	r.logLLMDispatch(log, task.ID, project, path, qaLLMID, len(qaPrompt))
+	r.emitEvent(project, path, task, &global.TaskEvent{Event: global.EventLLMDispatched, Phase: "qa", LLMModelID: qaLLMID})
 	qaLLMStartTime := time.Now()
-	dispatchResult, err := r.llm.Dispatch(dispatchReq)
+	dispatchResult, err := r.dispatchTracked(project, path, task, "qa", dispatchReq)
 	if err != nil {
 		r.recordHistory(project, task.UUID, "system", "error", fmt.Sprintf("QA LLM call failed: %v", err), qaLLMID, task.QA.Invocations)
-		r.logLLMFinish(task.ID, qaLLMID, nil, err.Error())
+		r.logLLMFinish(log, task.ID, qaLLMID, nil, err.Error())
 		return fmt.Errorf("QA LLM call failed: %w", err)
 	}
 
@@ -2655,11 +3333,12 @@ func (r *Runner) executeQA(project, path string, task *global.Task, budget *runB
 	}
 
 	qaLLMElapsed := time.Since(qaLLMStartTime).Seconds()
-	r.logLLMFinish(task.ID, qaLLMID, dispatchResult, "")
+	r.logLLMFinish(log, task.ID, qaLLMID, dispatchResult, "")
+	r.recordSpend(project, task.ID, budget, qaLLMID, dispatchResult)
 	r.logToProject(project, fmt.Sprintf("Task %d: QA LLM exited with code %d and returned %d bytes in %.1fs", task.ID, dispatchResult.ExitCode, len(qaResponse), qaLLMElapsed))
 
 	// Record QA response in history with full DispatchResult (raw response before JSON extraction)
-	r.recordHistoryResponse(task.UUID, "qa", dispatchResult, qaLLMID, task.QA.Invocations)
+	r.recordHistoryResponse(task.UUID, "qa", dispatchReq.Options, dispatchResult, qaLLMID, task.QA.Invocations)
 
 	// Validate QA response against task set schema if configured.
 	// ExtractJSON is only applied when a schema is configured (avoids corrupting plain-text responses).
@@ -2705,14 +3384,14 @@ func (r *Runner) executeQA(project, path string, task *global.Task, budget *runB
 					LLMModelID:       qaLLMID,
 					History:          r.getTaskHistory(task.UUID),
 				}
-				errorFilename, writeErr := r.writeErrorFile(project, errorDetails)
+				errorFilename, writeErr := r.writeErrorFile(project, path, task, errorDetails)
 				if writeErr != nil {
-					r.logger.Warnf("Task %d: Failed to write error file: %v", task.ID, writeErr)
+					log.Warnf("Task %d: Failed to write error file: %v", task.ID, writeErr)
 					errorFilename = "(failed to write)"
 				}
 
 				// Log brief message with file reference
-				r.logger.Warnf("Task %d: QA schema validation failed (%d errors). Details: results/%s", task.ID, len(errorMessages), errorFilename)
+				log.Warnf("Task %d: QA schema validation failed (%d errors). Details: results/%s", task.ID, len(errorMessages), errorFilename)
 				r.logToProject(project, fmt.Sprintf("Task %d: QA schema validation failed (%d errors). Details: results/%s", task.ID, len(errorMessages), errorFilename))
 
 				// Record in history (without the full schema)
@@ -2740,7 +3419,7 @@ func (r *Runner) executeQA(project, path string, task *global.Task, budget *runB
 				}
 
 				if _, updateErr := r.tasks.UpdateTask(project, task.UUID, qaUpdates); updateErr != nil {
-					r.logger.Warnf("Task %d: Failed to save QA error state: %v", task.ID, updateErr)
+					log.Warnf("Task %d: Failed to save QA error state: %v", task.ID, updateErr)
 				}
 
 				return &SchemaValidationError{
@@ -2750,17 +3429,25 @@ func (r *Runner) executeQA(project, path string, task *global.Task, budget *runB
 					CanRetry:         canRetry,
 				}
 			}
-			r.logger.Infof("Task %d: QA response validated against schema", task.ID)
+			log.Infof("Task %d: QA response validated against schema", task.ID)
 		}
 	}
 
-	// Parse QA response to extract verdict
-	qaResult, err := r.validator.ParseQAResponse([]byte(qaResponse))
+	// Parse QA response to extract verdict, following the task set's field map if any
+	var qaFields *global.QAFieldMap
+	if taskSet, err := r.tasks.GetTaskSet(project, path); err == nil {
+		qaFields = taskSet.QAFields
+	}
+	qaResult, err := r.validator.ParseQAResponseWith([]byte(qaResponse), qaFields)
 	if err != nil {
 		return fmt.Errorf("failed to parse QA response: %w", err)
 	}
 
-	r.logger.Infof("Task %d: QA response parsed (verdict: %s)", task.ID, qaResult.Verdict)
+	if qaResult.Severity != "" {
+		log.Infof("Task %d: QA response parsed (verdict: %s, severity: %s)", task.ID, qaResult.Verdict, qaResult.Severity)
+	} else {
+		log.Infof("Task %d: QA response parsed (verdict: %s)", task.ID, qaResult.Verdict)
+	}
 
 	// Store resolved canonical LLM ID for result file (mirrors worker/revision pattern)
 	task.QA.LLMModelID = qaLLMID
@@ -2775,6 +3462,7 @@ func (r *Runner) executeQA(project, path string, task *global.Task, budget *runB
 			"status":       global.ExecutionStatusDone,
 			"result":       qaResponse,
 			"verdict":      qaResult.Verdict,
+			"severity":     qaResult.Severity,
 			"invocations":  task.QA.Invocations,
 			"llm_model_id": qaLLMID,
 		},
@@ -2787,32 +3475,36 @@ func (r *Runner) executeQA(project, path string, task *global.Task, budget *runB
 
 	// Update local task reference
 	task.QA = updatedTask.QA
+	r.checkQAHaltSeverity(project, path, task, budget)
 
 	// Update result file with QA data
-	resultsDir := r.tasks.GetResultsDir(project)
-	resultFilename := task.UUID + ".json"
-	resultPath := filepath.Join(resultsDir, resultFilename)
+	resultPath := r.tasks.ResultFile(project, path, task, global.ResultFileSuffix)
+	resultFilename := r.resultFileName(project, resultPath)
 
 	// Load existing result
 	resultData, err := os.ReadFile(resultPath)
 	if err != nil {
-		r.logger.Warnf("Task %d: Failed to read result file for QA update: %v", task.ID, err)
+		log.Warnf("Task %d: Failed to read result file for QA update: %v", task.ID, err)
 	} else {
 		var taskResult global.TaskResult
 		if err := json.Unmarshal(resultData, &taskResult); err != nil {
-			r.logger.Warnf("Task %d: Failed to parse result file for QA update: %v", task.ID, err)
+			log.Warnf("Task %d: Failed to parse result file for QA update: %v", task.ID, err)
 		} else {
-			// Add QA result
+			// Add QA result, recording the instructions actually used
+			qa := r.effectiveQA(project, path, task)
 			taskResult.QA = &global.QAResult{
-				InstructionsFile:       task.QA.InstructionsFile,
-				InstructionsFileSource: task.QA.InstructionsFileSource,
-				InstructionsText:       task.QA.InstructionsText,
+				InstructionsFile:       qa.InstructionsFile,
+				InstructionsFileSource: qa.InstructionsFileSource,
+				InstructionsText:       qa.InstructionsText,
+				PlaybookVersion:        r.playbookVersion(qa.InstructionsFile, qa.InstructionsFileSource),
 				FullPrompt:             qaPrompt,
 				Response:               qaResponse,
 				Verdict:                qaResult.Verdict,
+				Severity:               qaResult.Severity,
 				LLMModelID:             qaLLMID,
 				Invocations:            task.QA.Invocations,
 				Status:                 global.ExecutionStatusDone,
+				PII:                    r.detectPII(project, task, "QA", qaResponse),
 			}
 
 			// Update history with latest messages
@@ -2821,14 +3513,14 @@ func (r *Runner) executeQA(project, path string, task *global.Task, budget *runB
 			// Save updated result
 			updatedData, err := json.MarshalIndent(taskResult, "", "  ")
 			if err == nil {
-				if writeErr := os.WriteFile(resultPath, updatedData, 0644); writeErr != nil {
-					r.logger.Warnf("Task %d: Failed to save QA result to file: %v", task.ID, writeErr)
+				if writeErr := r.writeResultFile(resultPath, updatedData); writeErr != nil {
+					log.Warnf("Task %d: Failed to save QA result to file: %v", task.ID, writeErr)
 				} else {
-					r.logger.Infof("Task %d: QA results written to %s (%d bytes)", task.ID, resultFilename, len(updatedData))
+					log.Infof("Task %d: QA results written to %s (%d bytes)", task.ID, resultFilename, len(updatedData))
 					r.logToProject(project, fmt.Sprintf("Task %d: QA results written to %s", task.ID, resultFilename))
 				}
 			} else {
-				r.logger.Warnf("Task %d: Failed to marshal QA result: %v", task.ID, err)
+				log.Warnf("Task %d: Failed to marshal QA result: %v", task.ID, err)
 			}
 		}
 	}
@@ -2838,27 +3530,33 @@ func (r *Runner) executeQA(project, path string, task *global.Task, budget *runB
 
 // buildQAPrompt builds the QA prompt from project context, instructions and work result
 func (r *Runner) buildQAPrompt(project, path string, task *global.Task) (string, error) {
-	var sb strings.Builder
+	var prompt promptAssembler
 
 	// 0. Always inject project name (mandatory for cross-project isolation)
+	sb := prompt.section("project name", promptRequired)
 	sb.WriteString("=== PROJECT CONTEXT ===\n\n")
 	sb.WriteString(fmt.Sprintf("Project: %s\n", project))
 	sb.WriteString("IMPORTANT: Use this project name for ALL file operations (project_file_list, project_file_get, project_file_search).\n\n")
 
-	// Append optional user-defined context if available
+	// Append optional user-defined context if available (shortened first to fit the context window)
 	if proj, err := r.projects.Get(project); err == nil && proj.Context != "" {
+		sb = prompt.section("project context", promptOptional)
 		sb.WriteString(proj.Context)
 		sb.WriteString("\n\n")
 	}
+	sb = prompt.section("instructions", promptRequired)
+
+	// QA instructions the task does not set come from its task set
+	qa := r.effectiveQA(project, path, task)
 
 	// 1. Load instructions from file if specified
-	if task.QA.InstructionsFile != "" {
+	if qa.InstructionsFile != "" {
 		// Temporarily use QA's instructions for loading
 		originalFile := task.Work.InstructionsFile
 		originalSource := task.Work.InstructionsFileSource
 
-		task.Work.InstructionsFile = task.QA.InstructionsFile
-		task.Work.InstructionsFileSource = task.QA.InstructionsFileSource
+		task.Work.InstructionsFile = qa.InstructionsFile
+		task.Work.InstructionsFileSource = qa.InstructionsFileSource
 
 		content, err := r.loadInstructionsFile(project, task)
 
@@ -2874,43 +3572,56 @@ func (r *Runner) buildQAPrompt(project, path string, task *global.Task) (string,
 	}
 
 	// 2. Append inline instructions text if specified
-	if task.QA.InstructionsText != "" {
-		sb.WriteString(task.QA.InstructionsText)
+	if qa.InstructionsText != "" {
+		sb.WriteString(global.ExpandTaskEnv(qa.InstructionsText, task.Env))
 		sb.WriteString("\n\n")
 	}
 
 	// 3. Append QA-specific prompt with separator
-	if task.QA.Prompt != "" {
+	if qa.Prompt != "" {
 		sb.WriteString("=== QA TASK PROMPT ===\n\n")
-		sb.WriteString(task.QA.Prompt)
+		sb.WriteString(global.ExpandTaskEnv(qa.Prompt, task.Env))
 		sb.WriteString("\n\n")
 	}
 
 	// 3.5. Include expected response schema with clear instructions
 	if taskSet, err := r.tasks.GetTaskSet(project, path); err == nil && taskSet.QAResponseTemplate != "" {
 		schema := r.loadSchemaContent(project, taskSet.QAResponseTemplate)
+		native := r.nativeSchema(project, path, task.QA.LLMModelID, "qa") != ""
 		if schema != "" {
-			sb.WriteString("=== REQUIRED RESPONSE FORMAT ===\n\n")
-			sb.WriteString("IMPORTANT: You MUST respond with a valid JSON object that matches the schema below.\n")
-			sb.WriteString("Your response will be validated against this schema. If validation fails, you will be asked to retry.\n\n")
-			sb.WriteString("CRITICAL: Your JSON response MUST include a 'verdict' field with one of these exact values:\n")
-			sb.WriteString("  - \"pass\" - The work meets all requirements\n")
-			sb.WriteString("  - \"fail\" - The work has critical issues that cannot be resolved\n")
-			sb.WriteString("  - \"escalate\" - The work needs revision and should be sent back to the worker\n\n")
-			sb.WriteString("Expected JSON Schema:\n```json\n")
-			sb.WriteString(schema)
-			sb.WriteString("\n```\n\n")
+			if native {
+				writeNativeSchemaNote(sb)
+			} else {
+				sb.WriteString("=== REQUIRED RESPONSE FORMAT ===\n\n")
+				sb.WriteString("IMPORTANT: You MUST respond with a valid JSON object that matches the schema below.\n")
+				sb.WriteString("Your response will be validated against this schema. If validation fails, you will be asked to retry.\n\n")
+			}
+			// A task set with a custom verdict field defines its verdict in its own schema
+			if !taskSet.QAFields.CustomVerdict() {
+				sb.WriteString("CRITICAL: Your JSON response MUST include a 'verdict' field with one of these exact values:\n")
+				sb.WriteString("  - \"pass\" - The work meets all requirements\n")
+				sb.WriteString("  - \"fail\" - The work has critical issues that cannot be resolved\n")
+				sb.WriteString("  - \"escalate\" - The work needs revision and should be sent back to the worker\n\n")
+			}
+			if !native {
+				sb.WriteString("Expected JSON Schema:\n```json\n")
+				sb.WriteString(schema)
+				sb.WriteString("\n```\n\n")
+			}
 		}
 	}
 
 	// 3.6. If there was a previous schema error, include it for retry
+	sb = prompt.section("previous attempt errors", promptRetry)
 	if task.QA.Error != "" && task.QA.Invocations > 0 {
 		sb.WriteString("=== PREVIOUS ATTEMPT FAILED - PLEASE FIX ===\n\n")
 		sb.WriteString("Your previous response did not match the required schema. Please review the errors below and provide a corrected response.\n\n")
 		sb.WriteString("Common mistakes to avoid:\n")
-		sb.WriteString("  - Using 'passed: true/false' instead of 'verdict: \"pass\"/\"fail\"'\n")
-		sb.WriteString("  - Using 'qa_verdict' instead of 'verdict'\n")
-		sb.WriteString("  - Missing the 'verdict' field entirely\n")
+		if taskSet, err := r.tasks.GetTaskSet(project, path); err != nil || !taskSet.QAFields.CustomVerdict() {
+			sb.WriteString("  - Using 'passed: true/false' instead of 'verdict: \"pass\"/\"fail\"'\n")
+			sb.WriteString("  - Using 'qa_verdict' instead of 'verdict'\n")
+			sb.WriteString("  - Missing the 'verdict' field entirely\n")
+		}
 		sb.WriteString("  - Using boolean values where strings are expected\n\n")
 		sb.WriteString("Validation errors from your previous response:\n")
 		sb.WriteString(task.QA.Error)
@@ -2918,12 +3629,12 @@ func (r *Runner) buildQAPrompt(project, path string, task *global.Task) (string,
 	}
 
 	// 4. Append work result for QA to review (load full result from results file)
+	sb = prompt.section("work result", promptRequired)
 	sb.WriteString("=== WORK RESULT TO REVIEW ===\n\n")
 
 	// Load full result from results file
 	var fullResult string
-	resultsDir := r.tasks.GetResultsDir(project)
-	resultPath := filepath.Join(resultsDir, task.UUID+".json")
+	resultPath := r.tasks.ResultFile(project, path, task, global.ResultFileSuffix)
 	if data, err := os.ReadFile(resultPath); err == nil {
 		var taskResult global.TaskResult
 		if err := json.Unmarshal(data, &taskResult); err == nil {
@@ -2938,55 +3649,98 @@ func (r *Runner) buildQAPrompt(project, path string, task *global.Task) (string,
 
 	sb.WriteString(fullResult)
 
-	return sb.String(), nil
+	return r.assemblePrompt(project, task, &prompt, task.QA.LLMModelID, task.QA.Invocations)
 }
 
 // reviseWork re-executes the work with QA feedback
 func (r *Runner) reviseWork(project, path string, task *global.Task, budget *runBudget, limits global.Limits) error {
-	r.logger.Infof("Task %d: Revising work with QA feedback", task.ID)
+	log := r.taskLogger(project, path, task)
+	log.Infof("Task %d: Revising work with QA feedback", task.ID)
 	r.logToProject(project, fmt.Sprintf("Task %d: Revising work with QA feedback", task.ID))
 
+	// Determine LLM (host-dispatch: the host selects it). The prompt depends on
+	// whether it enforces the response schema natively.
+	llmID, ok := r.dispatchLLMID(task.Work.LLMModelID)
+	if !ok {
+		return fmt.Errorf("no LLMs are enabled")
+	}
+	// Store resolved canonical LLM ID for result file
+	task.Work.LLMModelID = llmID
+
+	// Load the reviewed attempt: its QA response, and the exchange a revision can continue
+	resultPath := r.tasks.ResultFile(project, path, task, global.ResultFileSuffix)
+	var previous *global.TaskResult
+	qaResponse := "(Failed to load QA result)"
+	if data, err := os.ReadFile(resultPath); err == nil {
+		qaResponse = "(QA response not found in results file)"
+		var taskResult global.TaskResult
+		if err := json.Unmarshal(data, &taskResult); err == nil {
+			previous = &taskResult
+			if taskResult.QA != nil {
+				qaResponse = taskResult.QA.Response
+			}
+		}
+	}
+	feedback := r.qaFeedback(project, path, qaResponse)
+
+	// When the LLM can hold the conversation, send only the QA feedback as a new
+	// turn instead of repeating the instructions
+	conversation, sessionID, continued := r.revisionConversation(llmID, previous)
+
+	// The task set's revision prompt template, if any, replaces the standard QA feedback
+	revision, custom := r.renderRevisionPrompt(project, path, task, previous, qaResponse, feedback, continued)
+
+	if continued {
+		how := "messages"
+		if sessionID != "" {
+			how = "session " + sessionID
+		}
+		r.logToProject(project, fmt.Sprintf("Task %d: Revision continues the previous conversation (%d earlier turns, via %s)", task.ID, len(conversation), how))
+		feedbackPrompt := revisionFeedbackPrompt(task, qaResponse, feedback)
+		if custom {
+			feedbackPrompt = &promptAssembler{}
+			feedbackPrompt.section("QA feedback", promptRequired).WriteString(revision)
+		}
+		return r.dispatchRevision(project, path, task, budget, llmID, resultPath, feedbackPrompt, conversation, sessionID)
+	}
+
 	// Build revised prompt with QA feedback appended
-	var sb strings.Builder
+	var prompt promptAssembler
 
 	// 0. Always inject project name (mandatory for cross-project isolation)
+	sb := prompt.section("project name", promptRequired)
 	sb.WriteString("=== PROJECT CONTEXT ===\n\n")
 	sb.WriteString(fmt.Sprintf("Project: %s\n", project))
 	sb.WriteString("IMPORTANT: Use this project name for ALL file operations (project_file_list, project_file_get, project_file_search).\n\n")
 
-	// Append optional user-defined context if available
+	// Append optional user-defined context if available (shortened first to fit the context window)
 	if proj, err := r.projects.Get(project); err == nil && proj.Context != "" {
+		sb = prompt.section("project context", promptOptional)
 		sb.WriteString(proj.Context)
 		sb.WriteString("\n\n")
 	}
+	sb = prompt.section("instructions", promptRequired)
 
-	// 1. Load instructions from file if specified
-	if task.Work.InstructionsFile != "" {
-		content, err := r.loadInstructionsFile(project, task)
-		if err != nil {
-			return fmt.Errorf("failed to load instructions file: %w", err)
-		}
-		sb.WriteString(content)
-		sb.WriteString("\n\n")
+	// 1-3. Instructions file, inline instructions and task prompt
+	instructions, err := r.taskInstructions(project, task)
+	if err != nil {
+		return err
 	}
+	sb.WriteString(instructions)
 
-	// 2. Append inline instructions text if specified
-	if task.Work.InstructionsText != "" {
-		sb.WriteString(task.Work.InstructionsText)
-		sb.WriteString("\n\n")
-	}
+	// 3.4. Inline the responses of the tasks it depends on, if requested
+	r.writeDependencyResults(project, task, sb)
 
-	// 3. Append task-specific prompt with separator
-	if task.Work.Prompt != "" {
-		sb.WriteString("=== TASK PROMPT ===\n\n")
-		sb.WriteString(task.Work.Prompt)
-		sb.WriteString("\n\n")
-	}
+	// 3.5. Inline the task's evidence files (shortened to fit the context window)
+	r.writeEvidence(project, task, &prompt)
+	sb = prompt.section("response format", promptRequired)
 
 	// 4. Include expected response schema with clear instructions if configured
 	if taskSet, err := r.tasks.GetTaskSet(project, path); err == nil && taskSet.WorkerResponseTemplate != "" {
 		schema := r.loadSchemaContent(project, taskSet.WorkerResponseTemplate)
-		if schema != "" {
+		if schema != "" && r.nativeSchema(project, path, task.Work.LLMModelID, "worker") != "" {
+			writeNativeSchemaNote(sb)
+		} else if schema != "" {
 			sb.WriteString("=== REQUIRED RESPONSE FORMAT ===\n\n")
 			sb.WriteString("IMPORTANT: You MUST respond with a valid JSON object that matches the schema below.\n")
 			sb.WriteString("Your response will be validated against this schema. If validation fails, you will be asked to retry.\n\n")
@@ -2996,37 +3750,50 @@ func (r *Runner) reviseWork(project, path string, task *global.Task, budget *run
 		}
 	}
 
+	// 4.5. Require the configured output language
+	writeLanguageInstructions(sb, r.outputLanguage(project, path))
+
+	// 4.6. Offer the task set's worker tools
+	writeWorkerToolsInstructions(sb, r.workerTools(project, path))
+
 	// 5. Append QA feedback
 	// Include the full QA result so the worker can see all feedback details
-	sb.WriteString("=== QA FEEDBACK ===\n\n")
-	sb.WriteString(fmt.Sprintf("The previous attempt was reviewed by QA and received verdict: %s\n\n", task.QA.Verdict))
-	sb.WriteString("Full QA response:\n")
-
-	// Load QA result from results file
-	resultsDir := r.tasks.GetResultsDir(project)
-	resultPath := filepath.Join(resultsDir, task.UUID+".json")
-	if data, err := os.ReadFile(resultPath); err == nil {
-		var taskResult global.TaskResult
-		if err := json.Unmarshal(data, &taskResult); err == nil && taskResult.QA != nil {
-			sb.WriteString(taskResult.QA.Response)
-		} else {
-			sb.WriteString("(QA response not found in results file)")
-		}
+	sb = prompt.section("QA feedback", promptRetry)
+	if custom {
+		sb.WriteString(revision)
 	} else {
-		sb.WriteString("(Failed to load QA result)")
+		writeQAFeedback(sb, task, qaResponse, feedback)
 	}
 
-	fullPrompt := sb.String()
-	promptSize := len(fullPrompt)
-	r.logger.Infof("Task %d: Revised prompt built (%d bytes)", task.ID, promptSize)
+	return r.dispatchRevision(project, path, task, budget, llmID, resultPath, &prompt, nil, "")
+}
 
-	// Determine LLM (host-dispatch: the host selects it).
-	llmID, ok := r.dispatchLLMID(task.Work.LLMModelID)
-	if !ok {
-		return fmt.Errorf("no LLMs are enabled")
+// qaFeedback returns the feedback field of a QA response, as named by the task
+// set's QA field map, or "" when the task set names none or it is missing
+func (r *Runner) qaFeedback(project, path, qaResponse string) string {
+	taskSet, err := r.tasks.GetTaskSet(project, path)
+	if err != nil || taskSet.QAFields == nil || taskSet.QAFields.Feedback == "" {
+		return ""
 	}
-	// Store resolved canonical LLM ID for result file
-	task.Work.LLMModelID = llmID
+	var doc map[string]any
+	if err := json.Unmarshal([]byte(templates.ExtractJSON(qaResponse)), &doc); err != nil {
+		return ""
+	}
+	return global.QAFieldText(doc, taskSet.QAFields.Feedback)
+}
+
+// dispatchRevision sends a revision prompt to the worker LLM and saves the
+// revised result. A non-empty conversation holds the earlier turns the prompt
+// continues; they are sent as messages unless the provider holds them in the
+// session identified by sessionID.
+func (r *Runner) dispatchRevision(project, path string, task *global.Task, budget *runBudget, llmID, resultPath string, prompt *promptAssembler, conversation []global.ConversationMessage, sessionID string) error {
+	log := r.taskLogger(project, path, task)
+	fullPrompt, err := r.assemblePrompt(project, task, prompt, llmID, task.Work.Invocations+1)
+	if err != nil {
+		return fmt.Errorf("failed to build revised prompt: %w", err)
+	}
+	promptSize := len(fullPrompt)
+	log.Infof("Task %d: Revised prompt built (%d bytes)", task.ID, promptSize)
 
 	// Get exec info for detailed logging
 	revExecInfo := r.llm.GetExecInfo(llmID)
@@ -3037,7 +3804,7 @@ func (r *Runner) reviseWork(project, path string, task *global.Task, budget *run
 		revMode = revExecInfo.Mode
 		revPromptInput = revExecInfo.PromptInput
 	}
-	r.logger.Infof("Task %d: Calling revision LLM: %s, mode: %s, prompt: %s, size: %d bytes", task.ID, revDisplayName, revMode, revPromptInput, promptSize)
+	log.Infof("Task %d: Calling revision LLM: %s, mode: %s, prompt: %s, size: %d bytes", task.ID, revDisplayName, revMode, revPromptInput, promptSize)
 	r.logToProject(project, fmt.Sprintf("Task %d: Calling revision LLM: %s, mode: %s, prompt: %s, size: %d bytes", task.ID, revDisplayName, revMode, revPromptInput, promptSize))
 
 	// Update work metadata (keep status as 'waiting' until fully complete)
@@ -3053,7 +3820,7 @@ func (r *Runner) reviseWork(project, path string, task *global.Task, budget *run
 		},
 	}
 	if _, err := r.tasks.UpdateTask(project, task.UUID, workUpdates); err != nil {
-		r.logger.Warnf("Task %d: Failed to save work metadata: %v", task.ID, err)
+		log.Warnf("Task %d: Failed to save work metadata: %v", task.ID, err)
 	}
 
 	// Check budget before LLM call
@@ -3063,16 +3830,22 @@ func (r *Runner) reviseWork(project, path string, task *global.Task, budget *run
 
 	// Call LLM
 	dispatchReq := &llm.DispatchRequest{
-		LLMID:  llmID,
-		Prompt: fullPrompt,
+		LLMID:     llmID,
+		Prompt:    fullPrompt,
+		Options:   r.dispatchOptions(project, task, llmID, task.Work.Invocations, r.nativeSchema(project, path, llmID, "worker")),
+		SessionID: sessionID,
+	}
+	if sessionID == "" {
+		dispatchReq.Messages = conversation
 	}
 
-	r.logLLMDispatch(task.ID, project, path, llmID, len(fullPrompt))
+	r.logLLMDispatch(log, task.ID, project, path, llmID, len(fullPrompt))
+	r.emitEvent(project, path, task, &global.TaskEvent{Event: global.EventLLMDispatched, Phase: "worker", LLMModelID: llmID, Detail: "revision"})
 	revisionLLMStartTime := time.Now()
-	dispatchResult, err := r.llm.Dispatch(dispatchReq)
+	dispatchResult, err := r.dispatchWorker(project, path, task, budget, "revision", dispatchReq)
 	if err != nil {
 		r.recordHistory(project, task.UUID, "system", "error", fmt.Sprintf("Revision LLM call failed: %v", err), llmID, task.Work.Invocations)
-		r.logLLMFinish(task.ID, llmID, nil, err.Error())
+		r.logLLMFinish(log, task.ID, llmID, nil, err.Error())
 		// Update work with error
 		workUpdates = map[string]interface{}{
 			"work": map[string]interface{}{
@@ -3081,7 +3854,7 @@ func (r *Runner) reviseWork(project, path string, task *global.Task, budget *run
 			},
 		}
 		if _, updateErr := r.tasks.UpdateTask(project, task.UUID, workUpdates); updateErr != nil {
-			r.logger.Errorf("Task %d: Failed to save work error: %v", task.ID, updateErr)
+			log.Errorf("Task %d: Failed to save work error: %v", task.ID, updateErr)
 		}
 		return fmt.Errorf("LLM call failed: %w", err)
 	}
@@ -3094,11 +3867,12 @@ func (r *Runner) reviseWork(project, path string, task *global.Task, budget *run
 
 	responseSize := len(response)
 	revisionLLMElapsed := time.Since(revisionLLMStartTime).Seconds()
-	r.logLLMFinish(task.ID, llmID, dispatchResult, "")
+	r.logLLMFinish(log, task.ID, llmID, dispatchResult, "")
+	r.recordSpend(project, task.ID, budget, llmID, dispatchResult)
 	r.logToProject(project, fmt.Sprintf("Task %d: Work revision LLM exited with code %d and returned %d bytes in %.1fs", task.ID, dispatchResult.ExitCode, responseSize, revisionLLMElapsed))
 
 	// Record revision response in history with full DispatchResult (raw response before JSON extraction)
-	r.recordHistoryResponse(task.UUID, "worker", dispatchResult, llmID, task.Work.Invocations)
+	r.recordHistoryResponse(task.UUID, "worker", dispatchReq.Options, dispatchResult, llmID, task.Work.Invocations)
 
 	// Extract JSON only when a worker response schema is configured (avoids corrupting plain-text responses)
 	if taskSet, err := r.tasks.GetTaskSet(project, path); err == nil && taskSet.WorkerResponseTemplate != "" {
@@ -3106,44 +3880,47 @@ func (r *Runner) reviseWork(project, path string, task *global.Task, budget *run
 	}
 
 	// Save revised work result
-	resultsDir = r.tasks.GetResultsDir(project)
 	taskResult := global.TaskResult{
-		TaskID:      task.ID,
-		TaskUUID:    task.UUID,
-		TaskTitle:   task.Title,
-		TaskType:    task.Type,
-		CreatedAt:   task.CreatedAt,
-		CompletedAt: time.Now(),
+		TaskID:         task.ID,
+		TaskUUID:       task.UUID,
+		TaskExternalID: task.ExternalID,
+		TaskTitle:      task.Title,
+		TaskType:       task.Type,
+		CreatedAt:      task.CreatedAt,
+		CompletedAt:    time.Now(),
 		Worker: global.WorkerResult{
 			InstructionsFile:       task.Work.InstructionsFile,
 			InstructionsFileSource: task.Work.InstructionsFileSource,
 			InstructionsText:       task.Work.InstructionsText,
 			TaskPrompt:             task.Work.Prompt,
+			PlaybookVersion:        r.playbookVersion(task.Work.InstructionsFile, task.Work.InstructionsFileSource),
 			FullPrompt:             fullPrompt,
 			Response:               response,
 			LLMModelID:             task.Work.LLMModelID,
 			Invocations:            task.Work.Invocations,
 			Status:                 global.ExecutionStatusDone,
+			Conversation:           conversation,
+			PII:                    r.detectPII(project, task, "Revised worker", response),
 		},
 		History: r.getTaskHistory(task.UUID),
+		Summary: r.resultSummary(project, path, task, response),
 	}
 
 	// Save individual result file
-	if err := os.MkdirAll(resultsDir, 0755); err != nil {
-		r.logger.Warnf("Task %d: Failed to create results directory: %v", task.ID, err)
+	if err := os.MkdirAll(filepath.Dir(resultPath), 0755); err != nil {
+		log.Warnf("Task %d: Failed to create results directory: %v", task.ID, err)
 	} else {
-		resultFilename := task.UUID + ".json"
-		resultPath := filepath.Join(resultsDir, resultFilename)
+		resultFilename := r.resultFileName(project, resultPath)
 		resultData, err := json.MarshalIndent(taskResult, "", "  ")
 		if err == nil {
-			if writeErr := os.WriteFile(resultPath, resultData, 0644); writeErr != nil {
-				r.logger.Warnf("Task %d: Failed to save result file: %v", task.ID, writeErr)
+			if writeErr := r.writeResultFile(resultPath, resultData); writeErr != nil {
+				log.Warnf("Task %d: Failed to save result file: %v", task.ID, writeErr)
 			} else {
-				r.logger.Infof("Task %d: Revised results written to %s (%d bytes)", task.ID, resultFilename, len(resultData))
+				log.Infof("Task %d: Revised results written to %s (%d bytes)", task.ID, resultFilename, len(resultData))
 				r.logToProject(project, fmt.Sprintf("Task %d: Revised results written to %s", task.ID, resultFilename))
 			}
 		} else {
-			r.logger.Warnf("Task %d: Failed to marshal result: %v", task.ID, err)
+			log.Warnf("Task %d: Failed to marshal result: %v", task.ID, err)
 		}
 	}
 
@@ -3165,7 +3942,7 @@ func (r *Runner) reviseWork(project, path string, task *global.Task, budget *run
 	// Update local task reference
 	task.Work = updatedTask.Work
 
-	r.logger.Infof("Task %d: Work revision completed successfully", task.ID)
+	log.Infof("Task %d: Work revision completed successfully", task.ID)
 	r.logToProject(project, fmt.Sprintf("Task %d: Work revision completed", task.ID))
 
 	return nil
@@ -3174,22 +3951,32 @@ func (r *Runner) reviseWork(project, path string, task *global.Task, budget *run
 // GenerateReport (generateAndSaveReport) generates reports after task execution completes.
 // Supports multiple reports via JSON manifest files. If a taskset's WorkerReportTemplate
 // points to a .json file, it's parsed as a manifest containing multiple {suffix, file} entries.
-// Each suffix produces a separate report file (e.g., Report.md, Internal.md, Summary.md).
+// Each suffix produces a separate report file (e.g., Report.md, Internal.md, Summary.md),
+// generated in manifest order, and an index listing the files is written alongside them.
+// A manifest entry's formats, and any formats passed in, are also rendered beside each
+// report (e.g., Report.html, Report.pdf).
+// Generation is idempotent within a report session: each part of a report is keyed
+// (overview, task set, task), so a re-run leaves unchanged parts alone and replaces
+// those whose content changed rather than appending them again. With regenerate,
+// each report is rebuilt from scratch, dropping anything appended by hand.
 // GenerateReport generates reports for a project's task results.
 // This is the public API for report generation, callable from handlers.
 // Returns the list of generated report filenames.
-func (r *Runner) GenerateReport(project, pathFilter string) ([]string, error) {
-	return r.generateAndSaveReport(project, pathFilter)
+func (r *Runner) GenerateReport(project, pathFilter string, formats []string, regenerate bool) ([]string, error) {
+	if err := global.ValidateReportFormats(formats); err != nil {
+		return nil, err
+	}
+	return r.generateAndSaveReport(project, pathFilter, formats, regenerate)
 }
 
-func (r *Runner) generateAndSaveReport(project, pathFilter string) ([]string, error) {
+func (r *Runner) generateAndSaveReport(project, pathFilter string, formats []string, regenerate bool) ([]string, error) {
 	r.logger.Infof("Starting report generation for project %s", project)
 	r.logToProject(project, "Starting report generation")
 
-	// Check if projects service is available
-	if r.projects == nil {
-		r.logger.Warnf("Projects service not available, skipping report generation")
-		return nil, fmt.Errorf("projects service not available")
+	// Reports are saved as project files
+	if err := r.RequireSubsystem(global.SubsystemProjects, "report generation"); err != nil {
+		r.logger.Warnf("Skipping report generation: %v", err)
+		return nil, err
 	}
 
 	// Get all task sets (optionally filtered by path)
@@ -3215,59 +4002,145 @@ func (r *Runner) generateAndSaveReport(project, pathFilter string) ([]string, er
 	report := r.reporter.BuildReport(project, taskSetList.TaskSets, filter, resultsDir)
 
 	// Collect all unique report suffixes and their template configs
-	// Map: suffix -> template file path (from first taskset that defines it)
-	reportConfigs := make(map[string]string)
+	// Map: suffix -> template config (from first taskset that defines it)
+	reportConfigs := make(map[string]global.ReportTemplateConfig)
 
 	for _, ts := range report.TaskSets {
 		configs := r.reporter.LoadTemplateConfigs(ts.WorkerReportTemplate)
 		for _, cfg := range configs {
 			if _, exists := reportConfigs[cfg.Suffix]; !exists {
-				reportConfigs[cfg.Suffix] = cfg.File
+				reportConfigs[cfg.Suffix] = cfg
 			}
 		}
 	}
 
 	// If no configs found, use default "Report" with no template
 	if len(reportConfigs) == 0 {
-		reportConfigs["Report"] = ""
+		reportConfigs["Report"] = global.ReportTemplateConfig{Suffix: "Report"}
 	}
 
+	// Generate reports in manifest order; the main report comes first among equals
+	ordered := make([]global.ReportTemplateConfig, 0, len(reportConfigs))
+	for _, cfg := range reportConfigs {
+		ordered = append(ordered, cfg)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		if ordered[i].Order != ordered[j].Order {
+			return ordered[i].Order < ordered[j].Order
+		}
+		if (ordered[i].Suffix == "Report") != (ordered[j].Suffix == "Report") {
+			return ordered[i].Suffix == "Report"
+		}
+		return ordered[i].Suffix < ordered[j].Suffix
+	})
+
 	// Generate content for each report suffix
 	var generatedReports []string
+	var indexEntries []global.ReportIndexEntry
+	hasMetadata := false
 	prefix, _ := r.projects.GetReportPrefix(project)
 
-	for suffix, templateFile := range reportConfigs {
+	for _, cfg := range ordered {
+		var piiReview []string
+		suffix := cfg.Suffix
+		if suffix == global.ReportIndexSuffix {
+			r.logger.Warnf("Report suffix %s is reserved for the report index, skipping", suffix)
+			continue
+		}
+
+		// The overview: summary, trends and aggregate, one block per path filter
 		var content strings.Builder
+		if cfg.IncludeSummary {
+			content.WriteString(reporting.GenerateSummaryMarkdown(report.Summary))
+		}
+		if cfg.IncludeTrends {
+			if trends, err := r.projects.GetTrends(project); err != nil {
+				r.logger.Warnf("Failed to read trends for report %s: %v", suffix, err)
+			} else {
+				content.WriteString(reporting.GenerateTrendsMarkdown(trends, r.config.Clock()))
+			}
+		}
 
+		// The task sets this report covers, as its variant sees them, with the
+		// template each is rendered with
+		var sections []reporting.TaskSetReport
+		var templateFiles []string
 		for _, ts := range report.TaskSets {
-			// Find the template file for this suffix from this taskset
-			tsTemplateFile := templateFile // default from first taskset
+			// Find the template entry for this suffix from this taskset
+			variant := cfg // default from first taskset
 			tsConfigs := r.reporter.LoadTemplateConfigs(ts.WorkerReportTemplate)
-			for _, cfg := range tsConfigs {
-				if cfg.Suffix == suffix {
-					tsTemplateFile = cfg.File
+			for _, tsCfg := range tsConfigs {
+				if tsCfg.Suffix == suffix {
+					variant = tsCfg
 					break
 				}
 			}
 
+			// Variants can leave out whole task sets
+			if !reporting.IncludesSection(variant, ts.Path) {
+				continue
+			}
+
+			section := ts
+			section.Tasks = make([]reporting.TaskReport, len(ts.Tasks))
+			for i, task := range ts.Tasks {
+				section.Tasks[i] = reporting.ApplyVariant(task, variant)
+			}
+			sections = append(sections, section)
+			templateFiles = append(templateFiles, variant.File)
+		}
+
+		// The aggregate template summarizes every task ahead of the detail
+		if cfg.Aggregate != "" {
+			aggregate := r.reporter.RenderAggregate(&reporting.ProjectReport{
+				Project:     project,
+				GeneratedAt: report.GeneratedAt,
+				TaskSets:    sections,
+			}, cfg.Aggregate, reporting.VariantName(cfg))
+			if trimmed := strings.TrimSpace(aggregate); trimmed != "" {
+				content.WriteString(trimmed)
+				content.WriteString("\n\n")
+			}
+		}
+
+		var blocks []global.ReportBlock
+		if content.Len() > 0 {
+			key := global.ReportBlockOverview
+			if pathFilter != "" {
+				key += ":" + pathFilter
+			}
+			blocks = append(blocks, global.ReportBlock{Key: key, Content: content.String()})
+		}
+
+		for i, ts := range sections {
+			tsTemplateFile := templateFiles[i]
+
 			// Write task set header (## level since main report has # header)
-			content.WriteString(fmt.Sprintf("## %s\n\n", ts.Title))
+			blocks = append(blocks, global.ReportBlock{
+				Key:     global.ReportBlockTaskSet + ts.Path,
+				Content: fmt.Sprintf("## %s\n", ts.Title),
+			})
 
 			// Write each task - template handles the full output including header
 			for _, task := range ts.Tasks {
+				var taskContent string
 				if task.WorkResult != "" {
 					// Use template if configured, otherwise raw result
 					renderedResult := r.reporter.RenderWithTemplate(task, tsTemplateFile)
 					trimmedResult := strings.TrimSpace(renderedResult)
 					// Only add content and separator if template produced output
 					if trimmedResult != "" {
-						content.WriteString(trimmedResult)
-						content.WriteString("\n\n---\n\n")
+						taskContent = trimmedResult + "\n\n---\n"
+						if len(task.PIITypes) > 0 {
+							piiReview = append(piiReview, fmt.Sprintf("%s: Task %d %s (%s)", ts.Title, task.ID, task.Title, strings.Join(task.PIITypes, ", ")))
+						}
 					}
 				} else {
 					// No result yet - just show basic task info
-					content.WriteString(fmt.Sprintf("### %s\n\n", task.Title))
-					content.WriteString(fmt.Sprintf("**Task**: %d (%s)\n\n---\n\n", task.ID, task.WorkStatus))
+					taskContent = fmt.Sprintf("### %s\n\n**Task**: %d (%s)\n\n---\n", task.Title, task.ID, task.WorkStatus)
+				}
+				if taskContent != "" {
+					blocks = append(blocks, global.ReportBlock{Key: global.ReportBlockTask + task.UUID, Content: taskContent})
 				}
 			}
 		}
@@ -3280,17 +4153,49 @@ func (r *Runner) generateAndSaveReport(project, pathFilter string) ([]string, er
 			reportName = suffix
 		}
 
-		// Append to report using reports domain
-		if err := r.projects.AppendReport(project, content.String(), reportName); err != nil {
-			r.logger.Errorf("Failed to append to report %s: %v", suffix, err)
+		// Merge into the report using reports domain
+		merged, err := r.projects.WriteGeneratedReport(project, reportName, blocks, &cfg, regenerate)
+		if err != nil {
+			r.logger.Errorf("Failed to write report %s: %v", suffix, err)
 			r.logToProject(project, fmt.Sprintf("Failed to save auto-report %s: %v", suffix, err))
 			continue
 		}
 
+		// The first append starts a report session when none was active
+		if prefix == "" {
+			prefix, _ = r.projects.GetReportPrefix(project)
+		}
 		filename := prefix + suffix + ".md"
-		// Note: projects.AppendReport already logs the write
-		r.logToProject(project, fmt.Sprintf("Wrote to report: %s", filename))
+		// Note: projects.WriteGeneratedReport already logs the write
+		r.logToProject(project, fmt.Sprintf("Wrote to report: %s (%d added, %d replaced, %d unchanged)",
+			filename, merged.Added, merged.Replaced, merged.Unchanged))
 		generatedReports = append(generatedReports, filename)
+		generatedReports = append(generatedReports, r.renderReport(project, filename, cfg, formats)...)
+
+		indexEntries = append(indexEntries, global.ReportIndexEntry{
+			File:        filename,
+			Suffix:      suffix,
+			Title:       cfg.Title,
+			Description: cfg.Description,
+			Audience:    cfg.Audience,
+			PIIReview:   piiReview,
+		})
+		if cfg.Title != "" || cfg.Description != "" || cfg.Audience != "" || len(piiReview) > 0 {
+			hasMetadata = true
+		}
+		if len(piiReview) > 0 {
+			r.logToProject(project, fmt.Sprintf("Report %s: %d section(s) contain possible PII and need manual redaction review", filename, len(piiReview)))
+		}
+	}
+
+	// Write the per-run index when there is more than one report or any report is described
+	if len(indexEntries) > 1 || hasMetadata {
+		if indexFile, err := r.projects.WriteReportIndex(project, indexEntries); err != nil {
+			r.logger.Warnf("Failed to write report index: %v", err)
+		} else {
+			r.logToProject(project, fmt.Sprintf("Wrote report index: %s", indexFile))
+			generatedReports = append(generatedReports, indexFile)
+		}
 	}
 
 	// Sync the logger to ensure all log entries are flushed before we return
@@ -3305,6 +4210,140 @@ func (r *Runner) generateAndSaveReport(project, pathFilter string) ([]string, er
 	return generatedReports, nil
 }
 
+// renderReport writes the HTML and PDF renderings of a generated report that its
+// manifest entry or the caller asks for, and returns their filenames. A rendering
+// that fails is logged and skipped, leaving the Markdown report in place.
+func (r *Runner) renderReport(project, filename string, cfg global.ReportTemplateConfig, extra []string) []string {
+	var formats []string
+	for _, format := range append(append([]string{}, cfg.Formats...), extra...) {
+		if format != global.ReportFormatHTML && format != global.ReportFormatPDF {
+			continue // Reported when the manifest was loaded
+		}
+		if !slices.Contains(formats, format) {
+			formats = append(formats, format)
+		}
+	}
+	if len(formats) == 0 {
+		return nil
+	}
+
+	report, err := r.projects.ReadReport(project, filename, 0, 0)
+	if err != nil {
+		r.logger.Warnf("Failed to read report %s for rendering: %v", filename, err)
+		return nil
+	}
+
+	theme := ""
+	if cfg.Theme != "" {
+		if theme, err = r.reporter.LoadTheme(cfg.Theme); err != nil {
+			r.logger.Warnf("Report %s: %v; using the default theme", filename, err)
+		}
+	}
+
+	var written []string
+	base := strings.TrimSuffix(filename, ".md")
+	for _, format := range formats {
+		data, err := r.reporter.Render(report.Content, format, theme)
+		if err == nil {
+			err = r.projects.WriteReportFile(project, base+"."+format, data)
+		}
+		if err != nil {
+			r.logger.Errorf("Failed to render report %s as %s: %v", filename, format, err)
+			r.logToProject(project, fmt.Sprintf("Failed to render report %s as %s: %v", filename, format, err))
+			continue
+		}
+		r.logToProject(project, fmt.Sprintf("Wrote report: %s", base+"."+format))
+		written = append(written, base+"."+format)
+	}
+	return written
+}
+
+// DebugReport renders a single task with a report template and returns the parsed
+// result fields, the merged template context, and the rendered output. If
+// templatePath is empty the task set's worker (or QA) report template is used; for
+// multi-report manifests, suffix selects the template (default "Report").
+func (r *Runner) DebugReport(project, taskUUID, templatePath, suffix string, qa bool) (*reporting.RenderDebug, error) {
+	task, path, err := r.tasks.GetTask(project, taskUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	taskSet, err := r.tasks.GetTaskSet(project, path)
+	if err != nil {
+		return nil, err
+	}
+
+	if templatePath == "" {
+		templatePath = taskSet.WorkerReportTemplate
+		if qa {
+			templatePath = taskSet.QAReportTemplate
+		}
+	}
+
+	// Resolve multi-report manifests to a single template and its variant settings
+	var variant *global.ReportTemplateConfig
+	if strings.HasSuffix(templatePath, ".json") {
+		if suffix == "" {
+			suffix = "Report"
+		}
+		configs := r.reporter.LoadTemplateConfigs(templatePath)
+		resolved := ""
+		var available []string
+		for _, cfg := range configs {
+			available = append(available, cfg.Suffix)
+			if cfg.Suffix == suffix {
+				resolved = cfg.File
+				variant = &cfg
+			}
+		}
+		if resolved == "" {
+			return nil, fmt.Errorf("report suffix %q not found in manifest %s (available: %s)", suffix, templatePath, strings.Join(available, ", "))
+		}
+		templatePath = resolved
+	}
+
+	// Build the task report exactly as report generation does
+	report := r.reporter.BuildReport(project, []*global.TaskSet{taskSet}, nil, r.tasks.GetResultsDir(project))
+	for _, ts := range report.TaskSets {
+		for _, taskReport := range ts.Tasks {
+			if taskReport.UUID == task.UUID {
+				if variant != nil && !qa {
+					taskReport = reporting.ApplyVariant(taskReport, *variant)
+				}
+				return r.reporter.DebugRender(taskReport, templatePath, qa), nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("task not found in report: %s", taskUUID)
+}
+
+// ReportTemplateContents returns the content of every template referenced by the
+// project's task sets, keyed by template path. Report manifests are expanded so the
+// per-suffix templates they reference are included. Inline schemas are skipped
+// because they are already stored in the task set itself.
+func (r *Runner) ReportTemplateContents(project string) (map[string]string, error) {
+	refs, err := r.referencedTemplates(project)
+	if err != nil {
+		return nil, err
+	}
+
+	contents := make(map[string]string)
+	for _, ref := range refs {
+		if ref.inline() {
+			continue
+		}
+		if _, done := contents[ref.path]; done {
+			continue
+		}
+		if content := r.loadSchemaContent(project, ref.path); content != "" {
+			contents[ref.path] = content
+		}
+	}
+
+	return contents, nil
+}
+
 // Callback event types. The "completed" event is fired when every task in the
 // taskset reached the done state; "failed" is fired when any task ended in a
 // non-done terminal state.
@@ -3463,7 +4502,7 @@ func (r *Runner) RunDispatch(req *DispatchRequest, notify CompletionSink) (*Disp
 	}
 
 	// Create taskset with SkipValidation=true
-	_, err := r.tasks.CreateTaskSet(req.Project, path, title, "", nil, false, global.Limits{}, true, req.CallbackURL)
+	_, err := r.tasks.CreateTaskSet(req.Project, path, title, "", nil, false, global.Limits{}, true, req.CallbackURL, "", nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create dispatch taskset: %w", err)
 	}
@@ -3478,7 +4517,7 @@ func (r *Runner) RunDispatch(req *DispatchRequest, notify CompletionSink) (*Disp
 		Status:                 global.ExecutionStatusWaiting,
 	}
 
-	task, err := r.tasks.CreateTask(req.Project, path, title, "", work, nil)
+	task, err := r.tasks.CreateTask(req.Project, path, title, "", "", work, nil)
 	if err != nil {
 		// Clean up taskset on task creation failure
 		_ = r.tasks.DeleteTaskSet(req.Project, path)
@@ -3495,7 +4534,7 @@ func (r *Runner) RunDispatch(req *DispatchRequest, notify CompletionSink) (*Disp
 		Message:              "Task dispatched and running asynchronously",
 	}
 
-	// Execute asynchronously - does NOT use runningProjects lock so dispatches
+	// Execute asynchronously - does NOT take a run lock so dispatches
 	// run concurrently with regular runs and other dispatches
 	r.activeRuns.Add(1)
 	go r.runDispatchExecution(req, task, path, r.tasks.GetTask, notify)
@@ -3524,7 +4563,7 @@ func (r *Runner) runDispatchExecution(req *DispatchRequest, task *global.Task, p
 	taskInfo, taskSetPath, err := initialLoadTask(req.Project, task.UUID)
 	if err != nil {
 		r.logger.Errorf("Dispatch: failed to load task %s: %v", task.UUID, err)
-		r.failTaskPreExecution(req.Project, task, "task_load_failed", err.Error(), nil)
+		r.failTaskPreExecution(req.Project, path, task, "task_load_failed", err.Error(), nil)
 		r.dispatchCallback(req.Project, path, notify)
 		return
 	}
@@ -3532,6 +4571,10 @@ func (r *Runner) runDispatchExecution(req *DispatchRequest, task *global.Task, p
 	// Use default limits for dispatch tasks
 	limits := r.config.Runner().Limits.WithDefaults()
 	budget := r.newRunBudget([]*global.Task{taskInfo}, limits, 0.10)
+	budget.maxCostUSD = limits.MaxCostUSD
+	budget.runID = uuid.New().String()
+	budget.journal = r.startJournal(req.Project, budget.runID, global.JournalKindDispatch, &global.RunRequest{Project: req.Project, Path: path})
+	defer budget.journal.finish()
 	localResult := &global.RunResult{}
 
 	r.executeTask(context.Background(), req.Project, taskSetPath, taskInfo, localResult, budget, limits)
@@ -3545,7 +4588,7 @@ func (r *Runner) runDispatchExecution(req *DispatchRequest, task *global.Task, p
 			if errorMsg == "" {
 				errorMsg = fmt.Sprintf("dispatch ended in non-terminal state %q", reloaded.Work.Status)
 			}
-			r.failTaskPreExecution(req.Project, reloaded, "dispatch_incomplete", errorMsg, nil)
+			r.failTaskPreExecution(req.Project, path, reloaded, "dispatch_incomplete", errorMsg, nil)
 		}
 	}
 
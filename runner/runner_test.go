@@ -7,8 +7,14 @@ package runner
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -23,7 +29,14 @@ import (
 )
 
 // setupTestRunner creates a test runner with minimal dependencies
-func setupTestRunner(t *testing.T) (*testRunner, string) {
+func setupTestRunner(t testing.TB) (*testRunner, string) {
+	t.Helper()
+	return setupTestRunnerWithConfig(t, "")
+}
+
+// setupTestRunnerWithConfig creates a test runner; extraConfig is inserted as
+// additional top-level JSON fields (e.g. `"results_layout": "partitioned",`)
+func setupTestRunnerWithConfig(t testing.TB, extraConfig string) (*testRunner, string) {
 	t.Helper()
 
 	// Create temp directory
@@ -55,6 +68,7 @@ func setupTestRunner(t *testing.T) (*testRunner, string) {
 		"projects_dir": "projects",
 		"playbooks_dir": "playbooks",
 		"default_llm": "test-llm",
+		` + extraConfig + `
 		"llms": [
 			{
 				"id": "test-llm",
@@ -106,7 +120,7 @@ func setupTestRunner(t *testing.T) (*testRunner, string) {
 		reference.WithExternalDirs(externalDirs),
 		reference.WithLogger(logger),
 	)
-	playbooksService := playbooks.NewService(cfg.PlaybooksDir(), logger)
+	playbooksService := playbooks.NewService(cfg.PlaybooksDir(), cfg.ExportsDir(), cfg.PlaybookSnapshots(), logger)
 	projectsService := projects.NewService(cfg, logger)
 	tasksService := tasks.NewService(cfg, projectsService, logger)
 	llmService := llm.NewService(cfg, logger, nil)
@@ -168,13 +182,13 @@ func TestGetTaskStatus(t *testing.T) {
 	projectName := "test-project"
 
 	// Create a project
-	_, err := runner.projects.Create(projectName, "Test Project", "Test project for status testing", "", "", "none")
+	_, err := runner.projects.Create(projectName, "Test Project", "Test project for status testing", "", "", "none", "")
 	if err != nil {
 		t.Fatalf("Failed to create project: %v", err)
 	}
 
 	// Create a task set
-	_, err = runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "Test task set", nil, false, global.Limits{}, false, "")
+	_, err = runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "Test task set", nil, false, global.Limits{}, false, "", "", nil)
 	if err != nil {
 		t.Fatalf("Failed to create task set: %v", err)
 	}
@@ -197,7 +211,7 @@ func TestGetTaskStatus(t *testing.T) {
 			Prompt:     "test prompt",
 			LLMModelID: "test-llm",
 		}
-		task, err := runner.tasks.CreateTask(projectName, "main", taskDef.title, "test", work, nil)
+		task, err := runner.tasks.CreateTask(projectName, "main", taskDef.title, "test", "", work, nil)
 		if err != nil {
 			t.Fatalf("Failed to create task: %v", err)
 		}
@@ -251,13 +265,13 @@ func TestGetTaskStatusWithTypeFilter(t *testing.T) {
 	projectName := "test-project"
 
 	// Create a project
-	_, err := runner.projects.Create(projectName, "Test Project", "Test project for type filtering", "", "", "none")
+	_, err := runner.projects.Create(projectName, "Test Project", "Test project for type filtering", "", "", "none", "")
 	if err != nil {
 		t.Fatalf("Failed to create project: %v", err)
 	}
 
 	// Create a task set
-	_, err = runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "Test task set", nil, false, global.Limits{}, false, "")
+	_, err = runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "Test task set", nil, false, global.Limits{}, false, "", "", nil)
 	if err != nil {
 		t.Fatalf("Failed to create task set: %v", err)
 	}
@@ -280,7 +294,7 @@ func TestGetTaskStatusWithTypeFilter(t *testing.T) {
 			Prompt:     "test prompt",
 			LLMModelID: "test-llm",
 		}
-		task, err := runner.tasks.CreateTask(projectName, "main", taskDef.title, taskDef.taskType, work, nil)
+		task, err := runner.tasks.CreateTask(projectName, "main", taskDef.title, taskDef.taskType, "", work, nil)
 		if err != nil {
 			t.Fatalf("Failed to create task: %v", err)
 		}
@@ -325,7 +339,7 @@ func TestRunReturnsImmediately(t *testing.T) {
 	projectName := "test-project"
 
 	// Create a project
-	_, err := runner.projects.Create(projectName, "Test Project", "Test project for async run", "", "", "none")
+	_, err := runner.projects.Create(projectName, "Test Project", "Test project for async run", "", "", "none", "")
 	if err != nil {
 		t.Fatalf("Failed to create project: %v", err)
 	}
@@ -334,7 +348,7 @@ func TestRunReturnsImmediately(t *testing.T) {
 	templates := createTestTemplates(t, tmpDir)
 
 	// Create a task set with templates
-	_, err = runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "Test task set", templates, false, global.Limits{}, false, "")
+	_, err = runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "Test task set", templates, false, global.Limits{}, false, "", "", nil)
 	if err != nil {
 		t.Fatalf("Failed to create task set: %v", err)
 	}
@@ -346,7 +360,7 @@ func TestRunReturnsImmediately(t *testing.T) {
 		Prompt:     "test prompt",
 		LLMModelID: "test-llm",
 	}
-	_, err = runner.tasks.CreateTask(projectName, "main", "Long Task", "test", work, nil)
+	_, err = runner.tasks.CreateTask(projectName, "main", "Long Task", "test", "", work, nil)
 	if err != nil {
 		t.Fatalf("Failed to create task: %v", err)
 	}
@@ -385,7 +399,7 @@ func TestRunConcurrencyPrevention(t *testing.T) {
 	projectName := "test-project"
 
 	// Create a project
-	_, err := runner.projects.Create(projectName, "Test Project", "Test project for concurrency", "", "", "none")
+	_, err := runner.projects.Create(projectName, "Test Project", "Test project for concurrency", "", "", "none", "")
 	if err != nil {
 		t.Fatalf("Failed to create project: %v", err)
 	}
@@ -394,7 +408,7 @@ func TestRunConcurrencyPrevention(t *testing.T) {
 	templates := createTestTemplates(t, tmpDir)
 
 	// Create a task set with templates
-	_, err = runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "Test task set", templates, false, global.Limits{}, false, "")
+	_, err = runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "Test task set", templates, false, global.Limits{}, false, "", "", nil)
 	if err != nil {
 		t.Fatalf("Failed to create task set: %v", err)
 	}
@@ -404,7 +418,7 @@ func TestRunConcurrencyPrevention(t *testing.T) {
 		Prompt:     "test prompt",
 		LLMModelID: "test-llm",
 	}
-	_, err = runner.tasks.CreateTask(projectName, "main", "Task", "test", work, nil)
+	_, err = runner.tasks.CreateTask(projectName, "main", "Task", "test", "", work, nil)
 	if err != nil {
 		t.Fatalf("Failed to create task: %v", err)
 	}
@@ -439,6 +453,49 @@ func TestRunConcurrencyPrevention(t *testing.T) {
 	time.Sleep(100 * time.Millisecond)
 }
 
+func TestRunLocks(t *testing.T) {
+	var locks runLocks
+
+	// Per-task-set runs of different task sets proceed together
+	if err := locks.acquire("p", []string{"extract"}, true); err != nil {
+		t.Fatalf("acquire extract: %v", err)
+	}
+	if err := locks.acquire("p", []string{"analysis", "summary"}, true); err != nil {
+		t.Fatalf("acquire analysis: %v", err)
+	}
+	if err := locks.acquire("p", []string{"summary"}, true); err == nil || !strings.Contains(err.Error(), "summary") {
+		t.Errorf("expected a conflict on the summary task set, got %v", err)
+	}
+	// A project-wide run waits for all of them
+	if err := locks.acquire("p", nil, false); err == nil {
+		t.Error("expected a project-wide run to be refused while task sets are running")
+	}
+	if !locks.running("p") || locks.running("other") {
+		t.Errorf("running = %t/%t, want true/false", locks.running("p"), locks.running("other"))
+	}
+
+	locks.release("p", []string{"extract"}, true)
+	locks.release("p", []string{"analysis", "summary"}, true)
+	if locks.running("p") {
+		t.Error("project still running after its task sets were released")
+	}
+
+	// A project-wide run holds every task set
+	if err := locks.acquire("p", []string{"extract"}, false); err != nil {
+		t.Fatalf("acquire project: %v", err)
+	}
+	if err := locks.acquire("p", []string{"analysis"}, true); err == nil {
+		t.Error("expected a task set run to be refused while the project is locked")
+	}
+	if got := locks.list(); len(got) != 1 || got[0] != "p" {
+		t.Errorf("list = %v", got)
+	}
+	locks.release("p", []string{"extract"}, false)
+	if len(locks.list()) != 0 {
+		t.Errorf("list after release = %v", locks.list())
+	}
+}
+
 func TestGetTaskStatusShowsRunInProgress(t *testing.T) {
 	runner, tmpDir := setupTestRunner(t)
 	defer os.RemoveAll(tmpDir)
@@ -446,7 +503,7 @@ func TestGetTaskStatusShowsRunInProgress(t *testing.T) {
 	projectName := "test-project"
 
 	// Create a project
-	_, err := runner.projects.Create(projectName, "Test Project", "Test project for run tracking", "", "", "none")
+	_, err := runner.projects.Create(projectName, "Test Project", "Test project for run tracking", "", "", "none", "")
 	if err != nil {
 		t.Fatalf("Failed to create project: %v", err)
 	}
@@ -455,7 +512,7 @@ func TestGetTaskStatusShowsRunInProgress(t *testing.T) {
 	templates := createTestTemplates(t, tmpDir)
 
 	// Create a task set with templates
-	_, err = runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "Test task set", templates, false, global.Limits{}, false, "")
+	_, err = runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "Test task set", templates, false, global.Limits{}, false, "", "", nil)
 	if err != nil {
 		t.Fatalf("Failed to create task set: %v", err)
 	}
@@ -465,7 +522,7 @@ func TestGetTaskStatusShowsRunInProgress(t *testing.T) {
 		Prompt:     "test prompt",
 		LLMModelID: "test-llm",
 	}
-	_, err = runner.tasks.CreateTask(projectName, "main", "Task", "test", work, nil)
+	_, err = runner.tasks.CreateTask(projectName, "main", "Task", "test", "", work, nil)
 	if err != nil {
 		t.Fatalf("Failed to create task: %v", err)
 	}
@@ -510,6 +567,55 @@ func TestGetTaskStatusShowsRunInProgress(t *testing.T) {
 	}
 }
 
+func TestGetTaskStatusShowsRecovery(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "recovery-status"
+	if _, err := runner.projects.Create(projectName, "Recovery", "recovery status", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	if _, err := runner.tasks.CreateTaskSet(projectName, "controls/network", "Network", "", nil, false, global.Limits{}, false, "", "", nil); err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+
+	// A run waiting out a rate limit, on its second probe interval
+	llmConfig := runner.llm.GetLLM("test-llm")
+	llmConfig.RecoveryConfig = &config.LLMRecoveryConfig{TestScheduleSeconds: []int{30, 300}, AbortAfterSeconds: 3600}
+	recovery := newRecoveryState()
+	recovery.enterRecovery("test-llm", llmConfig)
+	recovery.advanceSchedule()
+	recovery.scheduleProbe(recovery.getWaitDuration())
+	runner.recoveries.Store(projectName+"/controls/network", recovery)
+
+	status, err := runner.GetTaskStatus(projectName, "controls", "")
+	if err != nil {
+		t.Fatalf("GetTaskStatus failed: %v", err)
+	}
+	if len(status.Recovery) != 1 {
+		t.Fatalf("Recovery = %+v, want one run", status.Recovery)
+	}
+	rec := status.Recovery[0]
+	if rec.Path != "controls/network" || rec.LLMID != "test-llm" || rec.ScheduleIndex != 1 || rec.NextProbeAt == nil {
+		t.Errorf("Recovery = %+v", rec)
+	}
+	if wait := rec.NextProbeAt.Sub(rec.Since); wait < 299*time.Second || wait > 301*time.Second {
+		t.Errorf("next probe %v after the wait started, want 300s", wait)
+	}
+	if rec.AbortAt.Sub(rec.Since) != time.Hour {
+		t.Errorf("abort deadline %v after the wait started, want 1h", rec.AbortAt.Sub(rec.Since))
+	}
+
+	// Other task sets and runs that have recovered are not reported
+	if status, _ := runner.GetTaskStatus(projectName, "reports", ""); len(status.Recovery) != 0 {
+		t.Errorf("Recovery reported for another path: %+v", status.Recovery)
+	}
+	recovery.exitRecovery()
+	if status, _ := runner.GetTaskStatus(projectName, "", ""); len(status.Recovery) != 0 {
+		t.Errorf("Recovery reported after it ended: %+v", status.Recovery)
+	}
+}
+
 func TestCreateTaskRequiresPromptField(t *testing.T) {
 	runner, tmpDir := setupTestRunner(t)
 	defer os.RemoveAll(tmpDir)
@@ -517,13 +623,13 @@ func TestCreateTaskRequiresPromptField(t *testing.T) {
 	projectName := "test-project"
 
 	// Create a project
-	_, err := runner.projects.Create(projectName, "Test Project", "Test project for prompt validation", "", "", "none")
+	_, err := runner.projects.Create(projectName, "Test Project", "Test project for prompt validation", "", "", "none", "")
 	if err != nil {
 		t.Fatalf("Failed to create project: %v", err)
 	}
 
 	// Create a task set
-	_, err = runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "Test task set", nil, false, global.Limits{}, false, "")
+	_, err = runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "Test task set", nil, false, global.Limits{}, false, "", "", nil)
 	if err != nil {
 		t.Fatalf("Failed to create task set: %v", err)
 	}
@@ -532,7 +638,7 @@ func TestCreateTaskRequiresPromptField(t *testing.T) {
 	workEmpty := &global.WorkExecution{
 		LLMModelID: "test-llm",
 	}
-	_, err = runner.tasks.CreateTask(projectName, "main", "Task Without Prompt", "test", workEmpty, nil)
+	_, err = runner.tasks.CreateTask(projectName, "main", "Task Without Prompt", "test", "", workEmpty, nil)
 	if err == nil {
 		t.Error("Expected error when creating task without prompt fields, got nil")
 	} else if err.Error() != "at least one prompt field is required: instructions_file, instructions_text, or prompt" {
@@ -545,7 +651,7 @@ func TestCreateTaskRequiresPromptField(t *testing.T) {
 		InstructionsFileSource: "project",
 		LLMModelID:             "test-llm",
 	}
-	_, err = runner.tasks.CreateTask(projectName, "main", "Task With File", "test", workFile, nil)
+	_, err = runner.tasks.CreateTask(projectName, "main", "Task With File", "test", "", workFile, nil)
 	if err != nil {
 		t.Errorf("Expected task with instructions_file to succeed, got error: %v", err)
 	}
@@ -555,7 +661,7 @@ func TestCreateTaskRequiresPromptField(t *testing.T) {
 		InstructionsText: "inline instructions",
 		LLMModelID:       "test-llm",
 	}
-	_, err = runner.tasks.CreateTask(projectName, "main", "Task With Text", "test", workText, nil)
+	_, err = runner.tasks.CreateTask(projectName, "main", "Task With Text", "test", "", workText, nil)
 	if err != nil {
 		t.Errorf("Expected task with instructions_text to succeed, got error: %v", err)
 	}
@@ -565,7 +671,7 @@ func TestCreateTaskRequiresPromptField(t *testing.T) {
 		Prompt:     "task prompt",
 		LLMModelID: "test-llm",
 	}
-	_, err = runner.tasks.CreateTask(projectName, "main", "Task With Prompt", "test", workPrompt, nil)
+	_, err = runner.tasks.CreateTask(projectName, "main", "Task With Prompt", "test", "", workPrompt, nil)
 	if err != nil {
 		t.Errorf("Expected task with prompt to succeed, got error: %v", err)
 	}
@@ -599,7 +705,7 @@ func TestRunDispatch_NoPrompt(t *testing.T) {
 
 	projectName := "test-project"
 
-	_, err := runner.projects.Create(projectName, "Test Project", "Test project for dispatch", "", "", "none")
+	_, err := runner.projects.Create(projectName, "Test Project", "Test project for dispatch", "", "", "none", "")
 	if err != nil {
 		t.Fatalf("Failed to create project: %v", err)
 	}
@@ -627,7 +733,7 @@ func TestCreateTaskSetWithSkipValidation(t *testing.T) {
 
 	projectName := "test-project"
 
-	_, err := runner.projects.Create(projectName, "Test Project", "Test project for skip validation", "", "", "none")
+	_, err := runner.projects.Create(projectName, "Test Project", "Test project for skip validation", "", "", "none", "")
 	if err != nil {
 		t.Fatalf("Failed to create project: %v", err)
 	}
@@ -637,7 +743,7 @@ func TestCreateTaskSetWithSkipValidation(t *testing.T) {
 
 	taskSet, err := runner.tasks.CreateTaskSet(
 		projectName, "skip-val-set", "Skip Validation TaskSet", "test",
-		nil, false, global.Limits{}, skipValidation, callbackURL,
+		nil, false, global.Limits{}, skipValidation, callbackURL, "", nil,
 	)
 	if err != nil {
 		t.Fatalf("Failed to create task set with skip_validation: %v", err)
@@ -657,7 +763,7 @@ func TestCreateTaskSetWithCallback(t *testing.T) {
 
 	projectName := "test-project"
 
-	_, err := runner.projects.Create(projectName, "Test Project", "Test project for callback persistence", "", "", "none")
+	_, err := runner.projects.Create(projectName, "Test Project", "Test project for callback persistence", "", "", "none", "")
 	if err != nil {
 		t.Fatalf("Failed to create project: %v", err)
 	}
@@ -666,7 +772,7 @@ func TestCreateTaskSetWithCallback(t *testing.T) {
 
 	_, err = runner.tasks.CreateTaskSet(
 		projectName, "cb-persist-set", "Callback Persist TaskSet", "test",
-		nil, false, global.Limits{}, true, callbackURL,
+		nil, false, global.Limits{}, true, callbackURL, "", nil,
 	)
 	if err != nil {
 		t.Fatalf("Failed to create task set: %v", err)
@@ -692,7 +798,7 @@ func TestUpdateTaskSetSkipValidation(t *testing.T) {
 
 	projectName := "test-project"
 
-	_, err := runner.projects.Create(projectName, "Test Project", "Test project for update skip validation", "", "", "none")
+	_, err := runner.projects.Create(projectName, "Test Project", "Test project for update skip validation", "", "", "none", "")
 	if err != nil {
 		t.Fatalf("Failed to create project: %v", err)
 	}
@@ -700,7 +806,7 @@ func TestUpdateTaskSetSkipValidation(t *testing.T) {
 	// Create task set without skip_validation
 	_, err = runner.tasks.CreateTaskSet(
 		projectName, "update-skip-set", "Update Skip TaskSet", "test",
-		nil, false, global.Limits{}, false, "",
+		nil, false, global.Limits{}, false, "", "", nil,
 	)
 	if err != nil {
 		t.Fatalf("Failed to create task set: %v", err)
@@ -710,7 +816,7 @@ func TestUpdateTaskSetSkipValidation(t *testing.T) {
 	skipValidation := true
 	updated, err := runner.tasks.UpdateTaskSet(
 		projectName, "update-skip-set",
-		nil, nil, nil, nil, nil, &skipValidation, nil,
+		nil, nil, nil, nil, nil, &skipValidation, nil, nil, nil,
 	)
 	if err != nil {
 		t.Fatalf("Failed to update task set: %v", err)
@@ -720,3 +826,3234 @@ func TestUpdateTaskSetSkipValidation(t *testing.T) {
 		t.Errorf("After update: SkipValidation = false, want true")
 	}
 }
+
+func TestOutputLanguageEnforcement(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "test-project"
+
+	if _, err := runner.projects.Create(projectName, "Test Project", "output language", "", "", "none", "fr"); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	if _, err := runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "", nil, false, global.Limits{MaxWorker: 2}, false, "", "", nil); err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+	task, err := runner.tasks.CreateTask(projectName, "main", "Task 1", "", "", &global.WorkExecution{Prompt: "Describe the finding"}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	// Project setting is injected into the prompt
+	prompt, err := runner.buildPrompt(projectName, "main", task)
+	if err != nil {
+		t.Fatalf("buildPrompt failed: %v", err)
+	}
+	if !strings.Contains(prompt, "=== RESPONSE LANGUAGE ===") || !strings.Contains(prompt, "French (fr)") {
+		t.Errorf("prompt missing French language instructions:\n%s", prompt)
+	}
+
+	// Task set setting overrides the project
+	german := "de"
+	if _, err := runner.tasks.UpdateTaskSet(projectName, "main", nil, nil, nil, nil, nil, nil, nil, &german, nil); err != nil {
+		t.Fatalf("Failed to update task set: %v", err)
+	}
+	prompt, err = runner.buildPrompt(projectName, "main", task)
+	if err != nil {
+		t.Fatalf("buildPrompt failed: %v", err)
+	}
+	if !strings.Contains(prompt, "German (de)") {
+		t.Errorf("prompt missing German override:\n%s", prompt)
+	}
+
+	// A response in the wrong language fails with a specific error and stays retryable
+	task.Work.Invocations = 1
+	response := "The firewall configuration is missing an egress rule and the logging policy has not been reviewed for this quarter."
+	result := &global.RunResult{}
+	limits := global.Limits{MaxWorker: 2}.WithDefaults()
+	runner.finishTask(projectName, "main", task, response, "", "prompt", "", result, limits, true, "")
+
+	updated, _, err := runner.tasks.GetTask(projectName, task.UUID)
+	if err != nil {
+		t.Fatalf("Failed to get task: %v", err)
+	}
+	if !strings.HasPrefix(updated.Work.Error, languageMismatchPrefix) {
+		t.Errorf("Work.Error = %q, want language mismatch", updated.Work.Error)
+	}
+	if updated.Work.Status != global.ExecutionStatusWaiting {
+		t.Errorf("Work.Status = %q, want %q", updated.Work.Status, global.ExecutionStatusWaiting)
+	}
+	if result.TasksFailed != 1 {
+		t.Errorf("TasksFailed = %d, want 1", result.TasksFailed)
+	}
+}
+
+func TestLLMAllowlist(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "test-project"
+	if _, err := runner.projects.Create(projectName, "Test Project", "LLM allowlist", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	if _, err := runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "", nil, false, global.Limits{}, false, "", "", nil); err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+	task, err := runner.tasks.CreateTask(projectName, "main", "Task 1", "", "", &global.WorkExecution{Prompt: "Work"}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	paths := map[string]string{task.UUID: "main"}
+
+	// Without allowed_llms any LLM may be used
+	if err := runner.CheckLLMAllowed(projectName, "test-llm"); err != nil {
+		t.Errorf("CheckLLMAllowed() = %v, want nil", err)
+	}
+
+	if _, err := runner.projects.SetAllowedLLMs(projectName, []string{"on-prem-llm"}); err != nil {
+		t.Fatalf("SetAllowedLLMs() error = %v", err)
+	}
+	if err := runner.CheckLLMAllowed(projectName, "test-llm"); err == nil || !strings.Contains(err.Error(), "not allowed") {
+		t.Errorf("CheckLLMAllowed() = %v, want not allowed", err)
+	}
+	// The task uses the default LLM, which the project may not use
+	if problems := runner.preRunChecks(projectName, []*global.Task{task}, paths); len(problems) != 1 || !strings.Contains(problems[0], "test-llm") {
+		t.Errorf("preRunChecks() = %v, want one problem for test-llm", problems)
+	}
+	if _, err := runner.dispatchTracked(projectName, "main", task, "worker", &llm.DispatchRequest{LLMID: "test-llm", Prompt: "Work"}); err == nil {
+		t.Errorf("dispatchTracked() dispatched to an LLM the project may not use")
+	}
+
+	if _, err := runner.projects.SetAllowedLLMs(projectName, []string{"on-prem-llm", "test-llm"}); err != nil {
+		t.Fatalf("SetAllowedLLMs() error = %v", err)
+	}
+	if problems := runner.preRunChecks(projectName, []*global.Task{task}, paths); len(problems) > 0 {
+		t.Errorf("preRunChecks() = %v, want no problems", problems)
+	}
+}
+
+func TestPromptRedaction(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "test-project"
+	if _, err := runner.projects.Create(projectName, "Test Project", "Prompt redaction", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	redaction := &global.PromptRedaction{Enabled: true, Rules: []global.PromptRedactionRule{{Name: "client", Terms: []string{"Acme Corp"}}}}
+	if _, err := runner.projects.SetPromptRedaction(projectName, redaction); err != nil {
+		t.Fatalf("SetPromptRedaction() error = %v", err)
+	}
+
+	task := &global.Task{ID: 1, UUID: "redaction-uuid"}
+	prompt := "Assess Acme Corp access reviews with jane.doe@example.com"
+	req := &llm.DispatchRequest{LLMID: "test-llm", Prompt: prompt}
+	result, err := runner.dispatchTracked(projectName, "main", task, "worker", req)
+	if err != nil {
+		t.Fatalf("dispatchTracked() error = %v", err)
+	}
+
+	// The LLM sees placeholders; the response (the echoed prompt) gets the values back
+	if req.Prompt != "Assess [CLIENT_1] access reviews with [EMAIL_1]" {
+		t.Errorf("dispatched prompt = %q", req.Prompt)
+	}
+	if !strings.HasSuffix(strings.TrimSpace(result.Stdout), prompt) {
+		t.Errorf("restored response = %q, want %q", result.Stdout, prompt)
+	}
+
+	history := runner.getTaskHistory(task.UUID)
+	if len(history) != 1 || history[0].Type != global.HistoryTypeRedaction || !strings.Contains(history[0].Content, "1 CLIENT, 1 EMAIL") {
+		t.Errorf("history = %+v, want one redaction report", history)
+	}
+}
+
+func TestTaskEnv(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "test-project"
+	if _, err := runner.projects.Create(projectName, "Test Project", "Task env", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	if _, err := runner.projects.PutFile(projectName, "instructions.md", "Assess control ${CONTROL_ID}; keep ${UNDEFINED} as is", ""); err != nil {
+		t.Fatalf("Failed to create instructions file: %v", err)
+	}
+	if _, err := runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "", nil, false, global.Limits{}, true, "", "", nil); err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+	task, err := runner.tasks.CreateTask(projectName, "main", "Task 1", "", "", &global.WorkExecution{InstructionsFile: "instructions.md", Prompt: "Check ${HOST}"}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	if _, err := runner.tasks.UpdateTask(projectName, task.UUID, map[string]interface{}{"env": map[string]string{"1HOST": "x"}}); err == nil {
+		t.Error("UpdateTask accepted an invalid env name")
+	}
+	task, err = runner.tasks.UpdateTask(projectName, task.UUID, map[string]interface{}{"env": map[string]string{"HOST": "web01", "CONTROL_ID": "AC-2"}})
+	if err != nil {
+		t.Fatalf("Failed to set env: %v", err)
+	}
+
+	// The env is substituted into the instructions and the task prompt
+	prompt, err := runner.buildPrompt(projectName, "main", task)
+	if err != nil {
+		t.Fatalf("buildPrompt() error = %v", err)
+	}
+	for _, want := range []string{"Assess control AC-2; keep ${UNDEFINED} as is", "Check web01"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt does not contain %q:\n%s", want, prompt)
+		}
+	}
+
+	// and exported to command LLMs, whose own env takes precedence
+	llmConfig := runner.llm.GetLLM("test-llm")
+	llmConfig.Command = "/bin/sh"
+	llmConfig.Args = []string{"-c", "echo $HOST $CONTROL_ID"}
+	llmConfig.Env = map[string]string{"CONTROL_ID": "from-llm"}
+	result, err := runner.dispatchTracked(projectName, "main", task, "worker", &llm.DispatchRequest{LLMID: "test-llm", Prompt: "hello"})
+	if err != nil {
+		t.Fatalf("dispatchTracked() error = %v", err)
+	}
+	if got := strings.TrimSpace(result.Stdout); got != "web01 from-llm" {
+		t.Errorf("LLM environment = %q, want \"web01 from-llm\"", got)
+	}
+
+	// An empty env clears it
+	task, err = runner.tasks.UpdateTask(projectName, task.UUID, map[string]interface{}{"env": map[string]string{}})
+	if err != nil || task.Env != nil {
+		t.Errorf("clearing env: task.Env = %v, err = %v", task.Env, err)
+	}
+}
+
+func TestTaskLimits(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "test-project"
+	if _, err := runner.projects.Create(projectName, "Test Project", "Task limits", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	if _, err := runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "", nil, false, global.Limits{MaxWorker: 2, MaxQA: 2}, true, "", "", nil); err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+	plain, err := runner.tasks.CreateTask(projectName, "main", "Plain", "", "", &global.WorkExecution{Prompt: "Work"}, &global.QAExecution{Enabled: true})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	heavy, err := runner.tasks.CreateTask(projectName, "main", "Heavy", "", "", &global.WorkExecution{Prompt: "Work"}, &global.QAExecution{Enabled: true})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	if _, err := runner.tasks.UpdateTask(projectName, heavy.UUID, map[string]interface{}{"limits": &global.TaskLimits{Timeout: 10}}); err == nil {
+		t.Error("UpdateTask accepted a timeout below the minimum")
+	}
+	heavy, err = runner.tasks.UpdateTask(projectName, heavy.UUID, map[string]interface{}{"limits": &global.TaskLimits{MaxQA: 4, Timeout: 3600}})
+	if err != nil {
+		t.Fatalf("Failed to set limits: %v", err)
+	}
+
+	// The task's limits override the task set's, field by field
+	limits := global.Limits{MaxWorker: 2, MaxQA: 2, MaxRetries: 3}
+	if got := limits.ForTask(heavy); got.MaxWorker != 2 || got.MaxQA != 4 || got.MaxRetries != 3 {
+		t.Errorf("ForTask(heavy) = %+v", got)
+	}
+	if got := limits.ForTask(plain); got != limits {
+		t.Errorf("ForTask(plain) = %+v, want %+v", got, limits)
+	}
+
+	// The run budget counts each task's own limits: (2+2) + (2+4), plus 10%
+	budget := runner.newRunBudget([]*global.Task{plain, heavy}, limits, 0.10)
+	if budget.maxCalls != 11 {
+		t.Errorf("budget maxCalls = %d, want 11", budget.maxCalls)
+	}
+
+	// The timeout replaces the LLM's for the task's calls
+	req := &llm.DispatchRequest{LLMID: "test-llm", Prompt: "hello"}
+	if _, err := runner.dispatchTracked(projectName, "main", heavy, "worker", req); err != nil {
+		t.Fatalf("dispatchTracked() error = %v", err)
+	}
+	if req.Timeout != 3600 {
+		t.Errorf("dispatch timeout = %d, want 3600", req.Timeout)
+	}
+
+	// Empty limits remove the override
+	heavy, err = runner.tasks.UpdateTask(projectName, heavy.UUID, map[string]interface{}{"limits": &global.TaskLimits{}})
+	if err != nil || heavy.Limits != nil {
+		t.Errorf("clearing limits: task.Limits = %+v, err = %v", heavy.Limits, err)
+	}
+}
+
+func TestTaskPriority(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "test-project"
+	if _, err := runner.projects.Create(projectName, "Test Project", "Task priority", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	if _, err := runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "", nil, false, global.Limits{}, true, "", "", nil); err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+	var created []*global.Task
+	for _, title := range []string{"Low", "High", "Retried", "Default"} {
+		task, err := runner.tasks.CreateTask(projectName, "main", title, "", "", &global.WorkExecution{Prompt: "Work"}, nil)
+		if err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+		created = append(created, task)
+	}
+	for uuid, priority := range map[string]int{created[1].UUID: 10, created[2].UUID: 5, created[3].UUID: 5} {
+		if _, err := runner.tasks.UpdateTask(projectName, uuid, map[string]interface{}{"priority": priority}); err != nil {
+			t.Fatalf("Failed to set priority: %v", err)
+		}
+	}
+	if _, err := runner.tasks.UpdateTask(projectName, created[0].UUID, map[string]interface{}{"priority": -1}); err == nil {
+		t.Error("UpdateTask accepted a negative priority")
+	}
+	if _, err := runner.tasks.UpdateTask(projectName, created[2].UUID, map[string]interface{}{"work": map[string]interface{}{"invocations": 2}}); err != nil {
+		t.Fatalf("Failed to set invocations: %v", err)
+	}
+
+	titles := func(tasks []*global.Task) string {
+		var names []string
+		for _, task := range tasks {
+			names = append(names, task.Title)
+		}
+		return strings.Join(names, ",")
+	}
+
+	// Higher priorities run first; equal priorities keep the task ID order
+	if got := titles(runner.getTasksNeedingRetry(projectName, "")); got != "High,Retried,Default,Low" {
+		t.Errorf("run order = %s, want High,Retried,Default,Low", got)
+	}
+
+	// The attempts tie-break runs tasks that have not been tried first
+	tasks := runner.getTasksNeedingRetry(projectName, "")
+	sortByPriority(tasks, global.PriorityTieBreakAttempts)
+	if got := titles(tasks); got != "High,Default,Retried,Low" {
+		t.Errorf("run order with attempts tie-break = %s, want High,Default,Retried,Low", got)
+	}
+
+	status, err := runner.GetTaskStatus(projectName, "", "")
+	if err != nil {
+		t.Fatalf("GetTaskStatus() error = %v", err)
+	}
+	if len(status.Tasks) != 4 || status.Tasks[1].Priority != 10 {
+		t.Errorf("task status = %+v, want priority 10 for task 2", status.Tasks)
+	}
+}
+
+func TestDiffAttempts(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "test-project"
+	if _, err := runner.projects.Create(projectName, "Test Project", "Attempt diff", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	if _, err := runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "", nil, false, global.Limits{}, true, "", "", nil); err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+	task, err := runner.tasks.CreateTask(projectName, "main", "Task", "", "", &global.WorkExecution{Prompt: "Work"}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	exitCode := 0
+	response := func(role string, invocation int, stdout string) global.Message {
+		return global.Message{Role: role, Invocation: invocation, Type: "response", ExitCode: &exitCode, Stdout: stdout}
+	}
+	runner.taskHistory.Store(task.UUID, []global.Message{
+		{Role: "worker", Invocation: 1, Type: "prompt", Prompt: "Work"},
+		response("worker", 1, `{"status":"open","findings":[{"severity":"high"}]}`),
+		response("qa", 1, "Needs work.\nCheck the severity."),
+		response("worker", 2, "```json\n{\"status\":\"open\",\"findings\":[{\"severity\":\"low\"},{\"severity\":\"high\"}],\"notes\":\"x\"}\n```"),
+		response("qa", 2, "Needs work.\nCheck the notes."),
+		response("worker", 3, `{"status":"closed"}`),
+	})
+
+	// By default the last two attempts are compared
+	diff, err := runner.DiffAttempts(projectName, task.UUID, "", 0, 0)
+	if err != nil {
+		t.Fatalf("DiffAttempts() error = %v", err)
+	}
+	if diff.From.Invocation != 2 || diff.To.Invocation != 3 || diff.Format != "json" {
+		t.Errorf("diff from %d to %d as %s, want 2 to 3 as json", diff.From.Invocation, diff.To.Invocation, diff.Format)
+	}
+
+	// Fields are compared by path, through code fences
+	diff, err = runner.DiffAttempts(projectName, task.UUID, "worker", 1, 2)
+	if err != nil {
+		t.Fatalf("DiffAttempts() error = %v", err)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0].Field != "findings[0].severity" || diff.Changed[0].Before != "high" || diff.Changed[0].After != "low" {
+		t.Errorf("Changed = %+v", diff.Changed)
+	}
+	if len(diff.Added) != 2 || diff.Added[0].Field != "findings[1]" || diff.Added[1].Field != "notes" {
+		t.Errorf("Added = %+v", diff.Added)
+	}
+	if len(diff.Removed) != 0 || diff.Identical {
+		t.Errorf("Removed = %+v, Identical = %v", diff.Removed, diff.Identical)
+	}
+
+	// Other responses are compared line by line
+	diff, err = runner.DiffAttempts(projectName, task.UUID, "qa", 0, 0)
+	if err != nil {
+		t.Fatalf("DiffAttempts() error = %v", err)
+	}
+	if diff.Format != "text" || len(diff.TextDiff) != 2 || diff.TextDiff[0] != "- Check the severity." || diff.TextDiff[1] != "+ Check the notes." {
+		t.Errorf("TextDiff = %q (format %s)", diff.TextDiff, diff.Format)
+	}
+
+	if _, err := runner.DiffAttempts(projectName, task.UUID, "worker", 1, 5); err == nil {
+		t.Error("DiffAttempts() accepted a missing attempt")
+	}
+	if _, err := runner.DiffAttempts(projectName, task.UUID, "supervisor", 0, 0); err == nil {
+		t.Error("DiffAttempts() accepted an invalid role")
+	}
+}
+
+func TestQADefaultsInheritance(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "test-project"
+
+	if _, err := runner.projects.Create(projectName, "Test Project", "QA defaults", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	if _, err := runner.projects.PutFile(projectName, "qa/review.md", "Shared QA instructions", ""); err != nil {
+		t.Fatalf("Failed to create instructions file: %v", err)
+	}
+	defaults := &global.QADefaults{InstructionsFile: "qa/review.md", Prompt: "Default QA prompt"}
+	if _, err := runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "", nil, false, global.Limits{}, false, "", "", defaults); err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+
+	inherits, err := runner.tasks.CreateTask(projectName, "main", "Task 1", "", "", &global.WorkExecution{Prompt: "Work"}, &global.QAExecution{Enabled: true})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	overrides, err := runner.tasks.CreateTask(projectName, "main", "Task 2", "", "", &global.WorkExecution{Prompt: "Work"}, &global.QAExecution{Enabled: true, Prompt: "Task QA prompt"})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	qa := runner.effectiveQA(projectName, "main", inherits)
+	if qa.InstructionsFile != "qa/review.md" || qa.Prompt != "Default QA prompt" {
+		t.Errorf("effectiveQA = %+v, want task set defaults", qa)
+	}
+	qa = runner.effectiveQA(projectName, "main", overrides)
+	if qa.InstructionsFile != "qa/review.md" || qa.Prompt != "Task QA prompt" {
+		t.Errorf("effectiveQA = %+v, want the task's own prompt and the default file", qa)
+	}
+
+	// Inherited instruction files are checked before a run
+	paths := map[string]string{inherits.UUID: "main"}
+	if problems := runner.preRunChecks(projectName, []*global.Task{inherits}, paths); len(problems) > 0 {
+		t.Errorf("preRunChecks() = %v, want no problems", problems)
+	}
+	missing := &global.QADefaults{InstructionsFile: "qa/missing.md"}
+	if _, err := runner.tasks.UpdateTaskSet(projectName, "main", nil, nil, nil, nil, nil, nil, nil, nil, missing); err != nil {
+		t.Fatalf("Failed to update task set: %v", err)
+	}
+	if problems := runner.preRunChecks(projectName, []*global.Task{inherits}, paths); len(problems) != 1 {
+		t.Errorf("preRunChecks() = %v, want one problem for the missing default file", problems)
+	}
+
+	// Removing the defaults leaves tasks with only their own QA configuration
+	if _, err := runner.tasks.UpdateTaskSet(projectName, "main", nil, nil, nil, nil, nil, nil, nil, nil, &global.QADefaults{}); err != nil {
+		t.Fatalf("Failed to update task set: %v", err)
+	}
+	if qa := runner.effectiveQA(projectName, "main", inherits); qa.InstructionsFile != "" || qa.Prompt != "" {
+		t.Errorf("effectiveQA = %+v after removing defaults", qa)
+	}
+}
+
+func TestQASkipRules(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "test-project"
+
+	if _, err := runner.projects.Create(projectName, "Test Project", "QA skip rules", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	templates := createTestTemplates(t, tmpDir)
+	if _, err := runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "", templates, false, global.Limits{}, false, "", "", nil); err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+	rules := []global.QASkipRule{{Name: "not-applicable", Conditions: []global.FieldCondition{
+		{Field: "result", Op: global.ConditionOpEquals, Value: "not_applicable"},
+	}}}
+	if err := runner.tasks.SetTaskSetQASkipRules(projectName, "main", rules); err != nil {
+		t.Fatalf("Failed to set skip rules: %v", err)
+	}
+
+	// writeResult creates a task with QA enabled and a completed worker response
+	writeResult := func(title, response string) *global.Task {
+		task, err := runner.tasks.CreateTask(projectName, "main", title, "", "", &global.WorkExecution{Prompt: "Assess"}, &global.QAExecution{Enabled: true})
+		if err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+		data, _ := json.Marshal(global.TaskResult{TaskUUID: task.UUID, Worker: global.WorkerResult{Response: response, Status: global.ExecutionStatusDone}})
+		resultPath := runner.tasks.ResultFile(projectName, "main", task, global.ResultFileSuffix)
+		if err := os.MkdirAll(filepath.Dir(resultPath), 0755); err != nil {
+			t.Fatalf("Failed to create results directory: %v", err)
+		}
+		if err := os.WriteFile(resultPath, data, 0644); err != nil {
+			t.Fatalf("Failed to write result: %v", err)
+		}
+		return task
+	}
+
+	skipped := writeResult("N/A control", `{"result":"not_applicable"}`)
+	if !runner.skipQA(projectName, "main", skipped) {
+		t.Fatal("skipQA() = false for a matching response")
+	}
+	updated, _, err := runner.tasks.GetTask(projectName, skipped.UUID)
+	if err != nil {
+		t.Fatalf("Failed to get task: %v", err)
+	}
+	if !updated.QA.Skipped || updated.QA.SkipRule != "not-applicable" || updated.QA.Status != global.ExecutionStatusDone || updated.Work.Status != global.ExecutionStatusDone {
+		t.Errorf("Task after skip: work=%+v qa=%+v", updated.Work, updated.QA)
+	}
+	data, err := os.ReadFile(runner.tasks.ResultFile(projectName, "main", updated, global.ResultFileSuffix))
+	if err != nil {
+		t.Fatalf("Failed to read result: %v", err)
+	}
+	var result global.TaskResult
+	if err := json.Unmarshal(data, &result); err != nil || result.QA == nil || !result.QA.Skipped || result.QA.SkipRule != "not-applicable" {
+		t.Errorf("Result QA = %+v, want skipped by not-applicable", result.QA)
+	}
+
+	reviewed := writeResult("Applicable control", `{"result":"compliant"}`)
+	if runner.skipQA(projectName, "main", reviewed) {
+		t.Error("skipQA() = true for a response that matches no rule")
+	}
+
+	// Without schema validation the rules do not apply
+	skipValidation := true
+	if _, err := runner.tasks.UpdateTaskSet(projectName, "main", nil, nil, nil, nil, nil, &skipValidation, nil, nil, nil); err != nil {
+		t.Fatalf("Failed to update task set: %v", err)
+	}
+	unvalidated := writeResult("Unvalidated", `{"result":"not_applicable"}`)
+	if runner.skipQA(projectName, "main", unvalidated) {
+		t.Error("skipQA() = true for a task set that skips validation")
+	}
+}
+
+func TestResultSummary(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "test-project"
+
+	if _, err := runner.projects.Create(projectName, "Test Project", "Result summaries", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	if _, err := runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "", nil, false, global.Limits{}, false, "", "", nil); err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+	task, err := runner.tasks.CreateTask(projectName, "main", "Control", "", "", &global.WorkExecution{Prompt: "Assess"}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	long := strings.Repeat("word ", 100)
+	if got := runner.resultSummary(projectName, "main", task, long); len([]rune(got)) != global.DefaultResultSummaryChars || !strings.HasSuffix(got, "…") {
+		t.Errorf("Default summary = %q, want %d characters ending in an ellipsis", got, global.DefaultResultSummaryChars)
+	}
+
+	settings := &global.ResultSummary{Field: "assessment.notes", MaxChars: 20}
+	if err := runner.tasks.SetTaskSetResultSummary(projectName, "main", settings); err != nil {
+		t.Fatalf("Failed to set result summary: %v", err)
+	}
+	if got := runner.resultSummary(projectName, "main", task, `{"assessment":{"notes":"Fully  implemented"}}`); got != "Fully implemented" {
+		t.Errorf("Field summary = %q, want %q", got, "Fully implemented")
+	}
+}
+
+func TestBuildDashboard(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "test-project"
+
+	if _, err := runner.projects.Create(projectName, "Test Project", "dashboard", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	if _, err := runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "", nil, false, global.Limits{}, false, "", "", nil); err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+	done, err := runner.tasks.CreateTask(projectName, "main", "Done task", "", "", &global.WorkExecution{Prompt: "p1"}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	if _, err := runner.tasks.CreateTask(projectName, "main", "Waiting task", "", "", &global.WorkExecution{Prompt: "p2"}, nil); err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	if _, err := runner.tasks.UpdateTask(projectName, done.UUID, map[string]interface{}{
+		"work": map[string]interface{}{"status": global.ExecutionStatusDone},
+	}); err != nil {
+		t.Fatalf("Failed to update task: %v", err)
+	}
+
+	exitCode := 0
+	result := global.TaskResult{
+		TaskUUID: done.UUID,
+		Worker:   global.WorkerResult{Response: `{"severity": "High", "finding": "x"}`},
+		History: []global.Message{
+			{Role: "worker", Prompt: "p1"},
+			{Role: "worker", ExitCode: &exitCode, InputTokens: 100, OutputTokens: 20, CostUSD: 0.5},
+		},
+	}
+	data, _ := json.Marshal(result)
+	resultsDir := runner.tasks.GetResultsDir(projectName)
+	if err := os.MkdirAll(resultsDir, 0755); err != nil {
+		t.Fatalf("Failed to create results dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(resultsDir, done.UUID+".json"), data, 0644); err != nil {
+		t.Fatalf("Failed to write result: %v", err)
+	}
+
+	// Last run is carried over from the saved dashboard
+	runner.writeDashboard(projectName, &global.DashboardRun{Path: "main", TasksExecuted: 1})
+
+	dashboard, err := runner.BuildDashboard(projectName)
+	if err != nil {
+		t.Fatalf("BuildDashboard failed: %v", err)
+	}
+	if dashboard.TotalTasks != 2 || dashboard.ByStatus[global.ExecutionStatusDone] != 1 || dashboard.ByStatus[global.ExecutionStatusWaiting] != 1 {
+		t.Errorf("unexpected status counts: total=%d by_status=%v", dashboard.TotalTasks, dashboard.ByStatus)
+	}
+	if dashboard.BySeverity["high"] != 1 {
+		t.Errorf("BySeverity = %v, want high=1", dashboard.BySeverity)
+	}
+	if dashboard.Usage.LLMCalls != 1 || dashboard.Usage.InputTokens != 100 || dashboard.Usage.CostUSD != 0.5 {
+		t.Errorf("unexpected usage: %+v", dashboard.Usage)
+	}
+	if len(dashboard.TaskSets) != 1 || dashboard.TaskSets[0].TotalTasks != 2 {
+		t.Errorf("unexpected task sets: %+v", dashboard.TaskSets)
+	}
+	if dashboard.LastRun == nil || dashboard.LastRun.TasksExecuted != 1 {
+		t.Errorf("LastRun not carried over: %+v", dashboard.LastRun)
+	}
+}
+
+func TestPartitionedResultsLayout(t *testing.T) {
+	runner, tmpDir := setupTestRunnerWithConfig(t, `"results_layout": "partitioned",`)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "test-project"
+	path := "analysis/security"
+
+	if _, err := runner.projects.Create(projectName, "Test Project", "layout", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	if _, err := runner.tasks.CreateTaskSet(projectName, path, "Security", "", nil, false, global.Limits{}, false, "", "", nil); err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+	task, err := runner.tasks.CreateTask(projectName, path, "Task 1", "", "", &global.WorkExecution{Prompt: "p"}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	legacy, err := runner.tasks.CreateTask(projectName, path, "Task 2", "", "", &global.WorkExecution{Prompt: "p"}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	// New results are written under results/<path>/<yyyymm>/
+	runner.writeFailedTaskResult(projectName, path, task, "prompt", "response", "failed", "test")
+	resultsDir := runner.tasks.GetResultsDir(projectName)
+	want := filepath.Join(resultsDir, "analysis", "security", task.CreatedAt.Format("200601"), task.UUID+".json")
+	if !global.FileExists(want) {
+		t.Fatalf("result not written to partitioned path %s", want)
+	}
+	if got, err := runner.tasks.FindResultFile(projectName, task.UUID, global.ResultFileSuffix); err != nil || got != want {
+		t.Errorf("FindResultFile = %q, %v; want %q", got, err, want)
+	}
+
+	// Files already in the flat layout are still found
+	flat := filepath.Join(resultsDir, legacy.UUID+".json")
+	if err := os.WriteFile(flat, []byte(`{}`), 0644); err != nil {
+		t.Fatalf("Failed to write flat result: %v", err)
+	}
+	if got := runner.tasks.ResultFile(projectName, path, legacy, global.ResultFileSuffix); got != flat {
+		t.Errorf("ResultFile = %q, want flat %q", got, flat)
+	}
+
+	// Reports resolve the partitioned location
+	taskSet, err := runner.tasks.GetTaskSet(projectName, path)
+	if err != nil {
+		t.Fatalf("Failed to get task set: %v", err)
+	}
+	if _, err := runner.tasks.UpdateTask(projectName, task.UUID, map[string]interface{}{
+		"work": map[string]interface{}{"status": global.ExecutionStatusFailed},
+	}); err != nil {
+		t.Fatalf("Failed to update task: %v", err)
+	}
+	taskSet, _ = runner.tasks.GetTaskSet(projectName, path)
+	report := runner.reporter.BuildReport(projectName, []*global.TaskSet{taskSet}, nil, resultsDir)
+	if len(report.TaskSets) != 1 || report.TaskSets[0].Tasks[0].WorkResult != "response" {
+		t.Errorf("report did not load partitioned result: %+v", report.TaskSets)
+	}
+}
+
+func TestCleanupOrphanedResults(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "test-project"
+	path := "analysis"
+
+	if _, err := runner.projects.Create(projectName, "Test Project", "cleanup", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	if _, err := runner.tasks.CreateTaskSet(projectName, path, "Analysis", "", nil, false, global.Limits{}, false, "", "", nil); err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+	succeeded, err := runner.tasks.CreateTask(projectName, path, "Succeeded", "", "", &global.WorkExecution{Prompt: "p"}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	pending, err := runner.tasks.CreateTask(projectName, path, "Pending", "", "", &global.WorkExecution{Prompt: "p"}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	if _, err := runner.tasks.UpdateTask(projectName, succeeded.UUID, map[string]interface{}{
+		"work": map[string]interface{}{"status": global.ExecutionStatusDone},
+	}); err != nil {
+		t.Fatalf("Failed to update task: %v", err)
+	}
+
+	resultsDir := runner.tasks.GetResultsDir(projectName)
+	if err := os.MkdirAll(resultsDir, 0755); err != nil {
+		t.Fatalf("Failed to create results dir: %v", err)
+	}
+	old := time.Now().Add(-48 * time.Hour)
+	write := func(name string, aged bool) {
+		file := filepath.Join(resultsDir, name)
+		if err := os.WriteFile(file, []byte(`{}`), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+		if aged {
+			if err := os.Chtimes(file, old, old); err != nil {
+				t.Fatalf("Failed to age %s: %v", name, err)
+			}
+		}
+	}
+	write(succeeded.UUID+".json", true)
+	write(succeeded.UUID+global.ErrorFileSuffix, true) // task has since succeeded
+	write(pending.UUID+global.ErrorFileSuffix, true)   // still relevant
+	write("deleted-task"+global.ErrorFileSuffix, true) // task no longer exists
+	write("recent-task"+global.ErrorFileSuffix, false) // too new to collect
+	write(pending.UUID+".json.tmp", true)              // interrupted write
+
+	// Dry run reports without changing anything
+	summary, err := runner.tasks.CleanupResults(projectName, global.CleanupActionArchive, 24*time.Hour, true)
+	if err != nil {
+		t.Fatalf("CleanupResults dry run failed: %v", err)
+	}
+	if summary.Collected != 3 {
+		t.Fatalf("dry run collected %d, want 3: %+v", summary.Collected, summary.Files)
+	}
+	if summary.ByReason[global.CleanupReasonSucceeded] != 1 || summary.ByReason[global.CleanupReasonDeleted] != 1 || summary.ByReason[global.CleanupReasonPartial] != 1 {
+		t.Errorf("unexpected reasons: %v", summary.ByReason)
+	}
+	if !global.FileExists(filepath.Join(resultsDir, "deleted-task"+global.ErrorFileSuffix)) {
+		t.Error("dry run removed a file")
+	}
+
+	// Archive moves orphans under results/_orphaned/<timestamp>/
+	summary, err = runner.tasks.CleanupResults(projectName, global.CleanupActionArchive, 24*time.Hour, false)
+	if err != nil {
+		t.Fatalf("CleanupResults failed: %v", err)
+	}
+	if summary.Collected != 3 || summary.ArchiveDir == "" {
+		t.Fatalf("archive summary = %+v", summary)
+	}
+	if !global.FileExists(filepath.Join(resultsDir, filepath.FromSlash(summary.ArchiveDir), "deleted-task"+global.ErrorFileSuffix)) {
+		t.Error("orphan not moved to archive directory")
+	}
+	for _, kept := range []string{succeeded.UUID + ".json", pending.UUID + global.ErrorFileSuffix, "recent-task" + global.ErrorFileSuffix} {
+		if !global.FileExists(filepath.Join(resultsDir, kept)) {
+			t.Errorf("%s should have been kept", kept)
+		}
+	}
+	for _, gone := range []string{succeeded.UUID + global.ErrorFileSuffix, pending.UUID + ".json.tmp"} {
+		if global.FileExists(filepath.Join(resultsDir, gone)) {
+			t.Errorf("%s should have been collected", gone)
+		}
+	}
+
+	// The archive is not rescanned and nothing is left to collect
+	if summaries := runner.RunMaintenance(); len(summaries) != 0 {
+		t.Errorf("RunMaintenance collected again: %+v", summaries[0])
+	}
+}
+
+func TestPruneResults(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "test-project"
+	path := "analysis"
+
+	if _, err := runner.projects.Create(projectName, "Test Project", "prune", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	if _, err := runner.tasks.CreateTaskSet(projectName, path, "Analysis", "", nil, false, global.Limits{}, false, "", "", nil); err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+	oldTask, err := runner.tasks.CreateTask(projectName, path, "Old", "", "", &global.WorkExecution{Prompt: "p"}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	newTask, err := runner.tasks.CreateTask(projectName, path, "New", "", "", &global.WorkExecution{Prompt: "p"}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	resultsDir := runner.tasks.GetResultsDir(projectName)
+	if err := os.MkdirAll(resultsDir, 0755); err != nil {
+		t.Fatalf("Failed to create results dir: %v", err)
+	}
+	write := func(name string, task *global.Task, age time.Duration) {
+		result := global.TaskResult{
+			TaskID:    task.ID,
+			TaskUUID:  task.UUID,
+			TaskTitle: task.Title,
+			Worker:    global.WorkerResult{FullPrompt: strings.Repeat("prompt ", 200), Response: "response", Status: global.ExecutionStatusDone},
+			QA:        &global.QAResult{FullPrompt: strings.Repeat("qa ", 200), Verdict: global.QAVerdictPass},
+			History:   []global.Message{{Role: "worker", Prompt: strings.Repeat("history ", 200), Stdout: "response", CostUSD: 0.25}},
+		}
+		data, _ := json.Marshal(result)
+		file := filepath.Join(resultsDir, name)
+		if err := os.WriteFile(file, data, 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+		modTime := time.Now().Add(-age)
+		if err := os.Chtimes(file, modTime, modTime); err != nil {
+			t.Fatalf("Failed to age %s: %v", name, err)
+		}
+	}
+	write(oldTask.UUID+".json", oldTask, 100*24*time.Hour)
+	write(oldTask.UUID+global.ErrorFileSuffix, oldTask, 101*24*time.Hour)
+	write(newTask.UUID+".json", newTask, time.Hour)
+	write(newTask.UUID+global.ErrorFileSuffix, newTask, 2*time.Hour)
+
+	if _, err := runner.tasks.PruneResults(projectName, global.ResultsRetention{}, false); err == nil {
+		t.Error("PruneResults should require a limit")
+	}
+
+	// Dry run reports without changing anything
+	policy := global.ResultsRetention{MaxAgeDays: 90, MaxPerTask: 1}
+	summary, err := runner.tasks.PruneResults(projectName, policy, true)
+	if err != nil {
+		t.Fatalf("PruneResults dry run failed: %v", err)
+	}
+	if summary.Pruned != 3 || summary.ByReason[global.RetentionReasonMaxAge] != 2 || summary.ByReason[global.RetentionReasonPerTask] != 1 {
+		t.Fatalf("dry run summary = %+v", summary)
+	}
+	if !global.FileExists(filepath.Join(resultsDir, oldTask.UUID+global.ErrorFileSuffix)) {
+		t.Error("dry run removed a file")
+	}
+
+	// Compact keeps the old result without its prompts and deletes the error files
+	summary, err = runner.tasks.PruneResults(projectName, policy, false)
+	if err != nil {
+		t.Fatalf("PruneResults failed: %v", err)
+	}
+	if summary.Pruned != 3 || summary.BytesFreed <= 0 {
+		t.Fatalf("prune summary = %+v", summary)
+	}
+	for _, gone := range []string{oldTask.UUID + global.ErrorFileSuffix, newTask.UUID + global.ErrorFileSuffix} {
+		if global.FileExists(filepath.Join(resultsDir, gone)) {
+			t.Errorf("%s should have been deleted", gone)
+		}
+	}
+	data, err := os.ReadFile(filepath.Join(resultsDir, oldTask.UUID+".json"))
+	if err != nil {
+		t.Fatalf("compacted result missing: %v", err)
+	}
+	var compacted global.TaskResult
+	if err := json.Unmarshal(data, &compacted); err != nil {
+		t.Fatalf("compacted result unreadable: %v", err)
+	}
+	if compacted.CompactedAt == nil || compacted.Worker.FullPrompt != "" || compacted.QA.FullPrompt != "" || compacted.Worker.Response != "response" {
+		t.Errorf("compacted result = %+v", compacted)
+	}
+	if len(compacted.History) != 1 || compacted.History[0].Prompt != "" || compacted.History[0].Stdout != "" || compacted.History[0].CostUSD != 0.25 {
+		t.Errorf("compacted history = %+v", compacted.History)
+	}
+
+	// The index summarizes each pruned file
+	data, err = os.ReadFile(filepath.Join(resultsDir, global.ResultsIndexFile))
+	if err != nil {
+		t.Fatalf("results index missing: %v", err)
+	}
+	var index global.ResultsIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		t.Fatalf("results index unreadable: %v", err)
+	}
+	if len(index.Entries) != 3 {
+		t.Fatalf("index has %d entries, want 3", len(index.Entries))
+	}
+	for _, entry := range index.Entries {
+		if entry.TaskUUID == oldTask.UUID && entry.Action == global.RetentionActionCompact && entry.Verdict != global.QAVerdictPass {
+			t.Errorf("index entry = %+v", entry)
+		}
+	}
+
+	// Compacted results are not pruned again; delete removes them
+	if summary, _ := runner.tasks.PruneResults(projectName, policy, false); summary.Pruned != 0 {
+		t.Errorf("compacted results pruned again: %+v", summary.Files)
+	}
+	policy.Action = global.RetentionActionDelete
+	if _, err := runner.tasks.PruneResults(projectName, policy, false); err != nil {
+		t.Fatalf("PruneResults delete failed: %v", err)
+	}
+	if global.FileExists(filepath.Join(resultsDir, oldTask.UUID+".json")) {
+		t.Error("old result should have been deleted")
+	}
+	if !global.FileExists(filepath.Join(resultsDir, newTask.UUID+".json")) {
+		t.Error("new result should have been kept")
+	}
+}
+
+func TestTaskExternalID(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "test-project"
+	if _, err := runner.projects.Create(projectName, "Test Project", "external ids", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	for _, path := range []string{"main", "other"} {
+		if _, err := runner.tasks.CreateTaskSet(projectName, path, path, "", nil, false, global.Limits{}, false, "", "", nil); err != nil {
+			t.Fatalf("Failed to create task set: %v", err)
+		}
+	}
+
+	work := func() *global.WorkExecution { return &global.WorkExecution{Prompt: "p"} }
+	task, err := runner.tasks.CreateTask(projectName, "main", "Task 1", "", "REQ-001", work(), nil)
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	// External IDs are unique per project, across task sets
+	if _, err := runner.tasks.CreateTask(projectName, "other", "Dup", "", "REQ-001", work(), nil); err == nil {
+		t.Error("expected duplicate external_id to be rejected")
+	}
+	if _, err := runner.tasks.CreateTask(projectName, "other", "UUID", "", task.UUID, work(), nil); err == nil {
+		t.Error("expected UUID-shaped external_id to be rejected")
+	}
+
+	// The external ID is accepted wherever a UUID is
+	got, path, err := runner.tasks.GetTask(projectName, "REQ-001")
+	if err != nil || got.UUID != task.UUID || path != "main" {
+		t.Fatalf("GetTask by external_id = %v, %q, %v", got, path, err)
+	}
+	updated, err := runner.tasks.UpdateTask(projectName, "REQ-001", map[string]interface{}{"title": "Renamed"})
+	if err != nil || updated.Title != "Renamed" || updated.ExternalID != "REQ-001" {
+		t.Fatalf("UpdateTask by external_id = %v, %v", updated, err)
+	}
+
+	// Result files carry the external ID for exports
+	runner.writeFailedTaskResult(projectName, "main", updated, "prompt", "response", "failed", "test")
+	data, err := os.ReadFile(runner.tasks.ResultFile(projectName, "main", updated, global.ResultFileSuffix))
+	if err != nil {
+		t.Fatalf("Failed to read result: %v", err)
+	}
+	var result global.TaskResult
+	if err := json.Unmarshal(data, &result); err != nil || result.TaskExternalID != "REQ-001" {
+		t.Errorf("result task_external_id = %q, %v", result.TaskExternalID, err)
+	}
+
+	if err := runner.tasks.DeleteTask(projectName, "REQ-001"); err != nil {
+		t.Fatalf("DeleteTask by external_id failed: %v", err)
+	}
+	if _, _, err := runner.tasks.GetTask(projectName, task.UUID); err == nil {
+		t.Error("task still exists after delete")
+	}
+}
+
+func TestBulkUpdateStatus(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "test-project"
+	if _, err := runner.projects.Create(projectName, "Test Project", "bulk status", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	for _, path := range []string{"analysis", "review"} {
+		if _, err := runner.tasks.CreateTaskSet(projectName, path, path, "", nil, false, global.Limits{}, false, "", "", nil); err != nil {
+			t.Fatalf("Failed to create task set: %v", err)
+		}
+	}
+	var analysis []*global.Task
+	for i := 0; i < 3; i++ {
+		task, err := runner.tasks.CreateTask(projectName, "analysis", fmt.Sprintf("Task %d", i), "", "", &global.WorkExecution{Prompt: "p"}, nil)
+		if err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+		analysis = append(analysis, task)
+	}
+	other, err := runner.tasks.CreateTask(projectName, "review", "Other", "", "", &global.WorkExecution{Prompt: "p"}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	if _, err := runner.tasks.UpdateTask(projectName, analysis[2].UUID, map[string]interface{}{
+		"work": map[string]interface{}{"status": global.ExecutionStatusProcessing},
+	}); err != nil {
+		t.Fatalf("Failed to update task: %v", err)
+	}
+
+	// Only a task with a result file may be set to done
+	resultFile := runner.tasks.ResultFile(projectName, "analysis", analysis[0], global.ResultFileSuffix)
+	if err := os.MkdirAll(filepath.Dir(resultFile), 0755); err != nil {
+		t.Fatalf("Failed to create results dir: %v", err)
+	}
+	if err := os.WriteFile(resultFile, []byte(`{}`), 0644); err != nil {
+		t.Fatalf("Failed to write result file: %v", err)
+	}
+
+	if _, err := runner.tasks.BulkUpdateStatus(projectName, "analysis", "", "", global.ExecutionStatusProcessing, "", false); err == nil {
+		t.Error("expected error setting processing")
+	}
+
+	// Dry run reports without changing anything
+	summary, err := runner.tasks.BulkUpdateStatus(projectName, "analysis", "", "", global.ExecutionStatusDone, "", true)
+	if err != nil {
+		t.Fatalf("BulkUpdateStatus dry run failed: %v", err)
+	}
+	if summary.Matched != 3 || len(summary.Updated) != 1 || len(summary.Skipped) != 2 {
+		t.Fatalf("unexpected dry run summary: %+v", summary)
+	}
+	if task, _, _ := runner.tasks.GetTask(projectName, analysis[0].UUID); task.Work.Status != global.ExecutionStatusWaiting {
+		t.Errorf("dry run changed status to %s", task.Work.Status)
+	}
+
+	summary, err = runner.tasks.BulkUpdateStatus(projectName, "analysis", "", "", global.ExecutionStatusDone, "", false)
+	if err != nil {
+		t.Fatalf("BulkUpdateStatus failed: %v", err)
+	}
+	if len(summary.Updated) != 1 || summary.Updated[0].UUID != analysis[0].UUID {
+		t.Fatalf("unexpected updates: %+v", summary.Updated)
+	}
+	reasons := map[string]string{}
+	for _, skipped := range summary.Skipped {
+		reasons[skipped.UUID] = skipped.Reason
+	}
+	if reasons[analysis[1].UUID] != "no result file" || reasons[analysis[2].UUID] != "task is processing" {
+		t.Errorf("unexpected skip reasons: %v", reasons)
+	}
+	if task, _, _ := runner.tasks.GetTask(projectName, analysis[0].UUID); task.Work.Status != global.ExecutionStatusDone {
+		t.Errorf("status = %s, want done", task.Work.Status)
+	}
+	if task, _, _ := runner.tasks.GetTask(projectName, other.UUID); task.Work.Status != global.ExecutionStatusWaiting {
+		t.Errorf("task outside the path filter changed to %s", task.Work.Status)
+	}
+
+	// Tasks stuck in processing are recovered only when selected explicitly
+	summary, err = runner.tasks.BulkUpdateStatus(projectName, "", "", global.ExecutionStatusProcessing, global.ExecutionStatusWaiting, "", false)
+	if err != nil {
+		t.Fatalf("BulkUpdateStatus failed: %v", err)
+	}
+	if len(summary.Updated) != 1 || summary.Updated[0].UUID != analysis[2].UUID {
+		t.Errorf("unexpected recovery updates: %+v", summary.Updated)
+	}
+}
+
+func TestUpdateTasksBulk(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "test-project"
+	if _, err := runner.projects.Create(projectName, "Test Project", "bulk update", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	for _, path := range []string{"analysis/controls", "analysis/policies", "review"} {
+		if _, err := runner.tasks.CreateTaskSet(projectName, path, path, "", nil, false, global.Limits{}, false, "", "", nil); err != nil {
+			t.Fatalf("Failed to create task set: %v", err)
+		}
+	}
+	var created []*global.Task
+	for _, path := range []string{"analysis/controls", "analysis/policies", "analysis/policies", "review"} {
+		task, err := runner.tasks.CreateTask(projectName, path, "Task", "", "", &global.WorkExecution{Prompt: "p", LLMModelID: "old"}, nil)
+		if err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+		created = append(created, task)
+	}
+	if _, err := runner.tasks.UpdateTask(projectName, created[1].UUID, map[string]interface{}{
+		"work":   map[string]interface{}{"status": global.ExecutionStatusDone},
+		"qa":     map[string]interface{}{"verdict": global.QAVerdictEscalate},
+		"limits": &global.TaskLimits{MaxWorker: 2, MaxQA: 3},
+	}); err != nil {
+		t.Fatalf("Failed to update task: %v", err)
+	}
+
+	model := map[string]interface{}{"work": map[string]interface{}{"llm_model_id": "new"}}
+	if _, err := runner.tasks.UpdateTasks(projectName, tasks.BulkTaskFilter{Path: "analysis/*"}, map[string]interface{}{"title": "x"}, nil, false); err == nil {
+		t.Error("expected error updating titles in bulk")
+	}
+	if _, err := runner.tasks.UpdateTasks(projectName, tasks.BulkTaskFilter{Path: "analysis/*"}, map[string]interface{}{}, nil, false); err == nil {
+		t.Error("expected error without updates")
+	}
+
+	// Dry run reports the waiting tasks under analysis without changing them
+	summary, err := runner.tasks.UpdateTasks(projectName, tasks.BulkTaskFilter{Path: "analysis/*", Status: global.ExecutionStatusWaiting}, model, nil, true)
+	if err != nil {
+		t.Fatalf("UpdateTasks dry run failed: %v", err)
+	}
+	if summary.Matched != 2 || len(summary.Updated) != 2 || len(summary.Fields) != 1 || summary.Fields[0] != "llm_model_id" {
+		t.Fatalf("unexpected dry run summary: %+v", summary)
+	}
+	if task, _, _ := runner.tasks.GetTask(projectName, created[0].UUID); task.Work.LLMModelID != "old" {
+		t.Errorf("dry run changed the model to %s", task.Work.LLMModelID)
+	}
+
+	if _, err := runner.tasks.UpdateTasks(projectName, tasks.BulkTaskFilter{Path: "analysis/*", Status: global.ExecutionStatusWaiting}, model, nil, false); err != nil {
+		t.Fatalf("UpdateTasks failed: %v", err)
+	}
+	for i, want := range []string{"new", "old", "new", "old"} {
+		if task, _, _ := runner.tasks.GetTask(projectName, created[i].UUID); task.Work.LLMModelID != want {
+			t.Errorf("task %d model = %s, want %s", i, task.Work.LLMModelID, want)
+		}
+	}
+
+	// Running the same update again changes nothing
+	summary, err = runner.tasks.UpdateTasks(projectName, tasks.BulkTaskFilter{Path: "analysis/*", Status: global.ExecutionStatusWaiting}, model, nil, false)
+	if err != nil || len(summary.Updated) != 0 || len(summary.Skipped) != 2 {
+		t.Errorf("repeated update = %+v, %v", summary, err)
+	}
+
+	// Escalated tasks are marked failed; limits not given are kept
+	summary, err = runner.tasks.UpdateTasks(projectName, tasks.BulkTaskFilter{QAVerdict: global.QAVerdictEscalate},
+		map[string]interface{}{"work": map[string]interface{}{"status": global.ExecutionStatusFailed}},
+		&global.TaskLimits{MaxWorker: -1, MaxQA: 0, MaxRetries: 4, Timeout: -1}, false)
+	if err != nil {
+		t.Fatalf("UpdateTasks failed: %v", err)
+	}
+	if len(summary.Updated) != 1 || summary.Updated[0].UUID != created[1].UUID {
+		t.Fatalf("unexpected summary: %+v", summary)
+	}
+	task, _, _ := runner.tasks.GetTask(projectName, created[1].UUID)
+	if task.Work.Status != global.ExecutionStatusFailed || task.Limits == nil || task.Limits.MaxWorker != 2 || task.Limits.MaxQA != 0 || task.Limits.MaxRetries != 4 {
+		t.Errorf("escalated task = %+v, limits %+v", task.Work, task.Limits)
+	}
+}
+
+func TestCreateTasksBulk(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "test-project"
+	if _, err := runner.projects.Create(projectName, "Test Project", "bulk create", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	if _, err := runner.tasks.CreateTaskSet(projectName, "controls", "Controls", "", nil, false, global.Limits{}, false, "", "", nil); err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+	existing, err := runner.tasks.CreateTask(projectName, "controls", "Scope", "", "SCOPE", &global.WorkExecution{Prompt: "p"}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	// One invalid definition rejects the whole batch
+	defs := []global.TaskDefinition{
+		{Title: "Control 1", ExternalID: "C-1", Prompt: "p", DependsOn: []string{"SCOPE"}},
+		{Title: "Control 2", ExternalID: "C-1", Prompt: "p"},
+		{Title: "Control 3", Prompt: "p", DependsOn: []string{"missing"}},
+		{Title: "Control 4"},
+	}
+	result, err := runner.tasks.CreateTasks(projectName, "controls", defs, false)
+	if err != nil {
+		t.Fatalf("CreateTasks() error = %v", err)
+	}
+	if result.Created != 0 || len(result.Errors) != 3 || result.Errors[0].Index != 1 || result.Errors[1].Index != 2 || result.Errors[2].Index != 3 {
+		t.Fatalf("CreateTasks() = %+v", result)
+	}
+	if ts, _ := runner.tasks.GetTaskSet(projectName, "controls"); len(ts.Tasks) != 1 {
+		t.Fatalf("rejected batch created %d task(s)", len(ts.Tasks)-1)
+	}
+
+	// Dependencies on other definitions of the batch cannot form a cycle
+	cycle := []global.TaskDefinition{
+		{Title: "A", ExternalID: "A", Prompt: "p", DependsOn: []string{"B"}},
+		{Title: "B", ExternalID: "B", Prompt: "p", DependsOn: []string{"A"}},
+	}
+	if result, err = runner.tasks.CreateTasks(projectName, "controls", cycle, false); err != nil || len(result.Errors) == 0 {
+		t.Fatalf("CreateTasks() accepted a dependency cycle: %+v, %v", result, err)
+	}
+
+	defs = []global.TaskDefinition{
+		{Title: "Control 1", ExternalID: "C-1", Prompt: "p", DependsOn: []string{"SCOPE", "C-2"}, Priority: 5},
+		{Title: "Control 2", ExternalID: "C-2", Prompt: "p", QAEnabled: true, QAPrompt: "check", Env: map[string]string{"HOST": "web01"}},
+	}
+	result, err = runner.tasks.CreateTasks(projectName, "controls", defs, true)
+	if err != nil || len(result.Errors) > 0 || result.Created != 0 || len(result.Tasks) != 2 {
+		t.Fatalf("CreateTasks() dry run = %+v, %v", result, err)
+	}
+	result, err = runner.tasks.CreateTasks(projectName, "controls", defs, false)
+	if err != nil || len(result.Errors) > 0 || result.Created != 2 {
+		t.Fatalf("CreateTasks() = %+v, %v", result, err)
+	}
+	if result.Tasks[0].ID != existing.ID+1 || result.Tasks[1].ID != existing.ID+2 {
+		t.Errorf("created task IDs = %d, %d", result.Tasks[0].ID, result.Tasks[1].ID)
+	}
+	task, _, err := runner.tasks.GetTask(projectName, "C-2")
+	if err != nil {
+		t.Fatalf("GetTask() error = %v", err)
+	}
+	if !task.QA.Enabled || task.QA.Status != global.ExecutionStatusWaiting || task.Env["HOST"] != "web01" || task.Work.Status != global.ExecutionStatusWaiting {
+		t.Errorf("created task = %+v", task)
+	}
+	if task, _, _ := runner.tasks.GetTask(projectName, "C-1"); task.Priority != 5 || len(task.DependsOn) != 2 {
+		t.Errorf("created task priority %d, depends_on %v", task.Priority, task.DependsOn)
+	}
+}
+
+func TestOnHoldTasksAreNotRun(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "test-project"
+	if _, err := runner.projects.Create(projectName, "Test Project", "on hold", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	templates := createTestTemplates(t, tmpDir)
+	if _, err := runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "", templates, false, global.Limits{}, false, "", "", nil); err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+	work := &global.WorkExecution{Prompt: "test prompt", LLMModelID: "test-llm"}
+	held, err := runner.tasks.CreateTask(projectName, "main", "Held", "test", "", work, nil)
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	if _, err := runner.tasks.CreateTask(projectName, "main", "Runnable", "test", "", work, nil); err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	if _, err := runner.tasks.UpdateTask(projectName, held.UUID, map[string]interface{}{
+		"work": map[string]interface{}{"hold_reason": "awaiting evidence"},
+	}); err == nil {
+		t.Error("expected error setting hold_reason on a task that is not on hold")
+	}
+	task, err := runner.tasks.UpdateTask(projectName, held.UUID, map[string]interface{}{
+		"work": map[string]interface{}{"status": global.ExecutionStatusOnHold, "hold_reason": "awaiting evidence"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to hold task: %v", err)
+	}
+	if task.Work.HoldReason != "awaiting evidence" {
+		t.Errorf("HoldReason = %q", task.Work.HoldReason)
+	}
+
+	result, err := runner.Run(context.Background(), &global.RunRequest{Project: projectName}, nil)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	runner.Wait()
+	if result.TasksFound != 1 {
+		t.Errorf("TasksFound = %d, want 1", result.TasksFound)
+	}
+
+	status, err := runner.GetTaskStatus(projectName, "", "")
+	if err != nil {
+		t.Fatalf("GetTaskStatus failed: %v", err)
+	}
+	if status.OnHold != 1 {
+		t.Errorf("OnHold = %d, want 1", status.OnHold)
+	}
+
+	// Releasing the task clears the reason
+	task, err = runner.tasks.UpdateTask(projectName, held.UUID, map[string]interface{}{
+		"work": map[string]interface{}{"status": global.ExecutionStatusWaiting},
+	})
+	if err != nil {
+		t.Fatalf("Failed to release task: %v", err)
+	}
+	if task.Work.Status != global.ExecutionStatusWaiting || task.Work.HoldReason != "" {
+		t.Errorf("released task: status=%s hold_reason=%q", task.Work.Status, task.Work.HoldReason)
+	}
+}
+
+func TestEvidenceRequests(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "test-project"
+	if _, err := runner.projects.Create(projectName, "Test Project", "evidence", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	for _, path := range []string{"controls", "followup"} {
+		if _, err := runner.tasks.CreateTaskSet(projectName, path, path, "", nil, false, global.Limits{}, false, "", "", nil); err != nil {
+			t.Fatalf("Failed to create task set: %v", err)
+		}
+	}
+
+	writeResult := func(title, worker, qa string) {
+		task, err := runner.tasks.CreateTask(projectName, "controls", title, "", "", &global.WorkExecution{Prompt: "p"}, nil)
+		if err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+		result := global.TaskResult{TaskUUID: task.UUID, Worker: global.WorkerResult{Response: worker}}
+		if qa != "" {
+			result.QA = &global.QAResult{Response: qa}
+		}
+		data, _ := json.Marshal(result)
+		file := runner.tasks.ResultFile(projectName, "controls", task, global.ResultFileSuffix)
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatalf("Failed to create results dir: %v", err)
+		}
+		if err := os.WriteFile(file, data, 0644); err != nil {
+			t.Fatalf("Failed to write result: %v", err)
+		}
+	}
+	writeResult("Access control", `{"missing_evidence": ["Access review log", "Password policy"]}`, `{"missing_evidence": "MFA configuration export"}`)
+	writeResult("Change management", `{"missing_evidence": [{"description": "access  review LOG"}]}`, "")
+	writeResult("Backups", `{"status": "compliant"}`, "")
+
+	list, err := runner.CollectEvidenceRequests(projectName, "controls")
+	if err != nil {
+		t.Fatalf("CollectEvidenceRequests failed: %v", err)
+	}
+	if list.TasksScanned != 3 || list.Total != 3 {
+		t.Fatalf("scanned=%d total=%d, want 3 and 3: %+v", list.TasksScanned, list.Total, list.Requests)
+	}
+	if list.Requests[0].Description != "Access review log" || len(list.Requests[0].Sources) != 2 {
+		t.Errorf("duplicate requests not merged: %+v", list.Requests[0])
+	}
+	if list.Requests[2].Sources[0].From != "qa" {
+		t.Errorf("From = %s, want qa", list.Requests[2].Sources[0].From)
+	}
+	if md := FormatEvidenceRequests(list, nil); !strings.Contains(md, "1. Access review log") || !strings.Contains(md, "Related to: Access control; Change management") {
+		t.Errorf("unexpected markdown:\n%s", md)
+	}
+
+	if _, err := runner.CreateEvidenceFollowUps(projectName, "missing", "", "", list); err == nil {
+		t.Error("expected error for a follow-up task set that does not exist")
+	}
+	created, err := runner.CreateEvidenceFollowUps(projectName, "followup", "", "", list)
+	if err != nil {
+		t.Fatalf("CreateEvidenceFollowUps failed: %v", err)
+	}
+	if created != 3 {
+		t.Errorf("created %d follow-up tasks, want 3", created)
+	}
+	ts, err := runner.tasks.GetTaskSet(projectName, "followup")
+	if err != nil {
+		t.Fatalf("GetTaskSet failed: %v", err)
+	}
+	for _, task := range ts.Tasks {
+		if task.Work.Status != global.ExecutionStatusOnHold || task.Work.HoldReason != global.EvidenceHoldReason {
+			t.Errorf("follow-up task %q: status=%s hold_reason=%q", task.Title, task.Work.Status, task.Work.HoldReason)
+		}
+	}
+
+	// Regenerating does not duplicate follow-up tasks
+	if created, err = runner.CreateEvidenceFollowUps(projectName, "followup", "", "", list); err != nil || created != 0 {
+		t.Errorf("second pass created %d tasks (err=%v), want 0", created, err)
+	}
+}
+
+func TestDiffProjects(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	writeResult := func(project, title, externalID, response string) {
+		task, err := runner.tasks.CreateTask(project, "controls", title, "", externalID, &global.WorkExecution{Prompt: "p"}, nil)
+		if err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+		data, _ := json.Marshal(global.TaskResult{
+			TaskUUID:       task.UUID,
+			TaskExternalID: externalID,
+			TaskTitle:      title,
+			Worker:         global.WorkerResult{Response: response, Status: global.ExecutionStatusDone},
+		})
+		file := runner.tasks.ResultFile(project, "controls", task, global.ResultFileSuffix)
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatalf("Failed to create results dir: %v", err)
+		}
+		if err := os.WriteFile(file, data, 0644); err != nil {
+			t.Fatalf("Failed to write result: %v", err)
+		}
+	}
+	for _, project := range []string{"audit-2025", "audit-2026"} {
+		if _, err := runner.projects.Create(project, project, "diff", "", "", "none", ""); err != nil {
+			t.Fatalf("Failed to create project: %v", err)
+		}
+		if _, err := runner.tasks.CreateTaskSet(project, "controls", "Controls", "", nil, false, global.Limits{}, false, "", "", nil); err != nil {
+			t.Fatalf("Failed to create task set: %v", err)
+		}
+	}
+	writeResult("audit-2025", "Access control", "AC-1", `{"severity": "high", "status": "fail"}`)
+	writeResult("audit-2025", "Backups", "", `{"item_id": "BK-1", "severity": "low", "status": "fail"}`)
+	writeResult("audit-2025", "Logging", "", `{"severity": "medium", "status": "fail"}`)
+	writeResult("audit-2026", "Access control (re-test)", "AC-1", `{"severity": "low", "status": "fail"}`)
+	writeResult("audit-2026", "Backups", "", `{"item_id": "BK-1", "severity": "low", "status": "fail"}`)
+	writeResult("audit-2026", "Encryption", "", `{"item_id": "EN-1", "severity": "high", "status": "fail"}`)
+
+	if _, err := runner.DiffProjects("audit-2026", DiffOptions{}); err == nil {
+		t.Error("expected error without a baseline")
+	}
+
+	diff, err := runner.DiffProjects("audit-2026", DiffOptions{BaselineProject: "audit-2025"})
+	if err != nil {
+		t.Fatalf("DiffProjects failed: %v", err)
+	}
+	if diff.Summary.New != 1 || diff.New[0].Key != "EN-1" {
+		t.Errorf("unexpected new findings: %+v", diff.New)
+	}
+	if diff.Summary.Resolved != 1 || diff.Resolved[0].Key != "Logging" {
+		t.Errorf("unexpected resolved findings: %+v", diff.Resolved)
+	}
+	if diff.Summary.Changed != 1 || diff.Changed[0].Key != "AC-1" || len(diff.Changed[0].Changes) != 1 {
+		t.Fatalf("unexpected changed findings: %+v", diff.Changed)
+	}
+	if change := diff.Changed[0].Changes[0]; change.Field != "severity" || change.Before != "high" || change.After != "low" {
+		t.Errorf("unexpected change: %+v", change)
+	}
+	if diff.Summary.Unchanged != 1 {
+		t.Errorf("Unchanged = %d, want 1", diff.Summary.Unchanged)
+	}
+	if md := FormatProjectDiff(diff, nil); !strings.Contains(md, "- **AC-1**: Access control (re-test) (severity: high → low)") {
+		t.Errorf("unexpected delta report:\n%s", md)
+	}
+
+	// Archived results (either layout) can serve as the baseline
+	archiveDir := filepath.Join(tmpDir, "archive-results")
+	if err := os.MkdirAll(filepath.Join(archiveDir, "controls", "202501"), 0755); err != nil {
+		t.Fatalf("Failed to create archive dir: %v", err)
+	}
+	data, _ := json.Marshal(global.TaskResult{TaskUUID: "u1", TaskTitle: "Old", Worker: global.WorkerResult{Response: `{"item_id": "OLD-1"}`}})
+	_ = os.WriteFile(filepath.Join(archiveDir, "controls", "202501", "u1.json"), data, 0644)
+	_ = os.WriteFile(filepath.Join(archiveDir, "u1-error.json"), []byte(`{}`), 0644)
+	results, err := archiveResults(archiveDir)
+	if err != nil || len(results) != 1 || results[0].TaskUUID != "u1" {
+		t.Errorf("archiveResults = %+v, %v", results, err)
+	}
+}
+
+func TestDiffTaskSets(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	project := "audit-quarterly"
+	if _, err := runner.projects.Create(project, project, "diff", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	for _, path := range []string{"q2", "q3", "q3/extra"} {
+		if _, err := runner.tasks.CreateTaskSet(project, path, path, "", nil, false, global.Limits{}, false, "", "", nil); err != nil {
+			t.Fatalf("Failed to create task set: %v", err)
+		}
+	}
+	writeResult := func(path, title, taskType, externalID, response string) {
+		task, err := runner.tasks.CreateTask(project, path, title, taskType, externalID, &global.WorkExecution{Prompt: "p"}, nil)
+		if err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+		data, _ := json.Marshal(global.TaskResult{
+			TaskUUID:       task.UUID,
+			TaskExternalID: externalID,
+			TaskTitle:      title,
+			TaskType:       taskType,
+			Worker:         global.WorkerResult{Response: response, Status: global.ExecutionStatusDone},
+		})
+		file := runner.tasks.ResultFile(project, path, task, global.ResultFileSuffix)
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatalf("Failed to create results dir: %v", err)
+		}
+		if err := os.WriteFile(file, data, 0644); err != nil {
+			t.Fatalf("Failed to write result: %v", err)
+		}
+	}
+	writeResult("q2", "Access control", "control", "ac-1", `{"severity": "high", "status": "fail"}`)
+	writeResult("q2", "Access control", "policy", "ac-pol", `{"severity": "low", "status": "pass"}`)
+	writeResult("q2", "Backups", "control", "bk-1", `{"severity": "medium", "status": "fail"}`)
+	writeResult("q3", "Access control", "control", "ac-1-q3", `{"severity": "low", "status": "fail"}`)
+	writeResult("q3", "Access control", "policy", "ac-pol-q3", `{"severity": "low", "status": "pass"}`)
+	writeResult("q3/extra", "Encryption", "control", "en-1-q3", `{"severity": "high", "status": "fail"}`)
+
+	if _, err := runner.DiffProjects(project, DiffOptions{Path: "q3", BaselinePath: "q3"}); err == nil {
+		t.Error("expected error comparing a task set with itself")
+	}
+	if _, err := runner.DiffProjects(project, DiffOptions{Path: "q3", BaselinePath: "q2", MatchBy: "uuid"}); err == nil {
+		t.Error("expected error for an invalid match_by")
+	}
+
+	// By key, the suffixed external IDs of the re-run do not align
+	diff, err := runner.DiffProjects(project, DiffOptions{Path: "q3", BaselinePath: "q2"})
+	if err != nil {
+		t.Fatalf("DiffProjects failed: %v", err)
+	}
+	if diff.Summary.Baseline != 3 || diff.Summary.Current != 3 || diff.Summary.New != 3 || diff.Summary.Resolved != 3 {
+		t.Errorf("unexpected summary matching by key: %+v", diff.Summary)
+	}
+
+	// By title and type they do; the task set under q3 is included
+	diff, err = runner.DiffProjects(project, DiffOptions{Path: "q3", BaselinePath: "q2", MatchBy: global.DiffMatchTitle})
+	if err != nil {
+		t.Fatalf("DiffProjects failed: %v", err)
+	}
+	if diff.MatchBy != global.DiffMatchTitle || diff.Summary.Unchanged != 1 {
+		t.Errorf("unexpected summary matching by title: %+v", diff.Summary)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0].Key != "Access control (control)" || diff.Changed[0].Changes[0].After != "low" {
+		t.Errorf("unexpected changed findings: %+v", diff.Changed)
+	}
+	if len(diff.New) != 1 || diff.New[0].Key != "Encryption (control)" {
+		t.Errorf("unexpected new findings: %+v", diff.New)
+	}
+	if len(diff.Resolved) != 1 || diff.Resolved[0].Key != "Backups (control)" {
+		t.Errorf("unexpected resolved findings: %+v", diff.Resolved)
+	}
+	md := FormatProjectDiff(diff, nil)
+	if !strings.Contains(md, "**Baseline Task Sets**: q2") || !strings.Contains(md, "- **Backups (control)**: Backups\n") {
+		t.Errorf("unexpected delta report:\n%s", md)
+	}
+}
+
+func TestRecordTrend(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	project := "trends-test"
+	if _, err := runner.projects.Create(project, "Trends", "trends", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	if _, err := runner.tasks.CreateTaskSet(project, "controls", "Controls", "", nil, false, global.Limits{}, false, "", "", nil); err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+	task, err := runner.tasks.CreateTask(project, "controls", "Access control", "", "", &global.WorkExecution{Prompt: "p"}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	exitCode := 0
+	data, _ := json.Marshal(global.TaskResult{
+		TaskUUID: task.UUID,
+		Worker:   global.WorkerResult{Response: `{"severity": "High"}`, Status: global.ExecutionStatusDone},
+		History:  []global.Message{{Role: "worker", ExitCode: &exitCode, CostUSD: 0.25}},
+	})
+	file := runner.tasks.ResultFile(project, "controls", task, global.ResultFileSuffix)
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		t.Fatalf("Failed to create results dir: %v", err)
+	}
+	if err := os.WriteFile(file, data, 0644); err != nil {
+		t.Fatalf("Failed to write result: %v", err)
+	}
+
+	runner.recordTrend(project, &global.DashboardRun{CompletedAt: time.Now(), TasksExecuted: 1, TasksSucceeded: 1})
+	runner.recordTrend(project, &global.DashboardRun{CompletedAt: time.Now()})
+
+	points, err := runner.projects.GetTrends(project)
+	if err != nil {
+		t.Fatalf("GetTrends failed: %v", err)
+	}
+	if len(points) != 2 {
+		t.Fatalf("expected 2 trend points, got %d", len(points))
+	}
+	if points[0].TasksExecuted != 1 || points[0].BySeverity["high"] != 1 || points[0].RunCostUSD != 0.25 {
+		t.Errorf("unexpected first point: %+v", points[0])
+	}
+	if points[1].CostUSD != 0.25 || points[1].RunCostUSD != 0 {
+		t.Errorf("second run should add no cost: %+v", points[1])
+	}
+
+	point := trendPoint(&global.ProjectDashboard{ByQAVerdict: map[string]int{"pass": 3, "fail": 1}, Usage: global.DashboardUsage{CostUSD: 1}},
+		&global.DashboardRun{}, []global.TrendPoint{{CostUSD: 2}})
+	if point.QAReviewed != 4 || point.QAPassRate != 0.75 || point.RunCostUSD != 0 {
+		t.Errorf("unexpected trend point: %+v", point)
+	}
+}
+
+func TestTaskDependencies(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "test-project"
+	if _, err := runner.projects.Create(projectName, "Test Project", "dependencies", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	for _, path := range []string{"report", "scan"} {
+		if _, err := runner.tasks.CreateTaskSet(projectName, path, path, "", nil, true, global.Limits{}, false, "", "", nil); err != nil {
+			t.Fatalf("Failed to create task set: %v", err)
+		}
+	}
+	work := func() *global.WorkExecution { return &global.WorkExecution{Prompt: "p"} }
+	summary, _ := runner.tasks.CreateTask(projectName, "report", "Summary", "", "summary", work(), nil)
+	analyze, _ := runner.tasks.CreateTask(projectName, "scan", "Analyze", "", "analyze", work(), nil)
+	collect, err := runner.tasks.CreateTask(projectName, "scan", "Collect", "", "collect", work(), nil)
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	setDeps := func(task *global.Task, deps ...string) error {
+		_, err := runner.tasks.UpdateTask(projectName, task.UUID, map[string]interface{}{"depends_on": deps})
+		return err
+	}
+	if err := setDeps(summary, "analyze"); err != nil {
+		t.Fatalf("Failed to set dependencies: %v", err)
+	}
+	if err := setDeps(analyze, collect.UUID); err != nil {
+		t.Fatalf("Failed to set dependencies: %v", err)
+	}
+	if err := setDeps(collect, "summary"); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("expected cycle error, got %v", err)
+	}
+	if err := setDeps(collect, "collect"); err == nil {
+		t.Error("expected error for a self-dependency")
+	}
+	if err := runner.tasks.ValidateDependencies(projectName, "", []string{"missing"}); err == nil {
+		t.Error("expected error for an unknown dependency")
+	}
+
+	graph, err := runner.tasks.DependencyGraph(projectName)
+	if err != nil {
+		t.Fatalf("DependencyGraph failed: %v", err)
+	}
+	ordered := graph.Order([]*global.Task{summary, analyze, collect})
+	if ordered[0].UUID != collect.UUID || ordered[1].UUID != analyze.UUID || ordered[2].UUID != summary.UUID {
+		t.Errorf("unexpected order: %s, %s, %s", ordered[0].Title, ordered[1].Title, ordered[2].Title)
+	}
+
+	status, err := runner.GetTaskStatus(projectName, "", "")
+	if err != nil {
+		t.Fatalf("GetTaskStatus failed: %v", err)
+	}
+	if status.Pending != 3 || status.Blocked != 2 {
+		t.Errorf("Pending = %d, Blocked = %d, want 3 and 2", status.Pending, status.Blocked)
+	}
+
+	all := []*global.Task{summary, analyze, collect}
+	if ready := runner.readyTasks(projectName, all, nil); len(ready) != 1 || ready[0].UUID != collect.UUID {
+		t.Errorf("expected only Collect to be ready, got %d task(s)", len(ready))
+	}
+	if _, err := runner.tasks.UpdateTask(projectName, collect.UUID, map[string]interface{}{
+		"work": map[string]interface{}{"status": global.ExecutionStatusDone},
+	}); err != nil {
+		t.Fatalf("Failed to update task: %v", err)
+	}
+	if ready := runner.readyTasks(projectName, all, nil); len(ready) != 1 || ready[0].UUID != analyze.UUID {
+		t.Errorf("expected only Analyze to be ready, got %d task(s)", len(ready))
+	}
+	current, _, _ := runner.tasks.GetTask(projectName, summary.UUID)
+	if unmet := runner.unmetDependencies(projectName, "report", current); len(unmet) != 1 || unmet[0] != "analyze" {
+		t.Errorf("unmetDependencies = %v", unmet)
+	}
+
+	// Clearing the dependencies unblocks the task
+	if err := setDeps(summary); err != nil {
+		t.Fatalf("Failed to clear dependencies: %v", err)
+	}
+	if task, _, _ := runner.tasks.GetTask(projectName, summary.UUID); len(task.DependsOn) != 0 {
+		t.Errorf("DependsOn = %v, want none", task.DependsOn)
+	}
+}
+
+func TestCreateTaskFromTemplate(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "test-project"
+	if _, err := runner.projects.Create(projectName, "Test Project", "task templates", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	template := `{
+		"description": "Access review of one system",
+		"variables": [{"name": "SYSTEM", "required": true}],
+		"path": "access",
+		"title": "Access review: ${SYSTEM}",
+		"prompt": "Review access to ${SYSTEM}",
+		"qa": {"enabled": true, "prompt": "Check the review"},
+		"templates": {"worker_response_template": "audit/schemas/finding.json"},
+		"priority": 5
+	}`
+	dir := filepath.Join(tmpDir, "playbooks", "audit", "task-templates")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("Failed to create playbook dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "access.json"), []byte(template), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	tmpl, err := runner.LoadTaskTemplate("audit", "task-templates/access.json")
+	if err != nil {
+		t.Fatalf("LoadTaskTemplate() error = %v", err)
+	}
+	tmpl, err = tmpl.Instantiate(map[string]string{"SYSTEM": "Payroll"})
+	if err != nil {
+		t.Fatalf("Instantiate() error = %v", err)
+	}
+	task, err := runner.CreateTaskFromTemplate(projectName, tmpl, "", "", "AR-1")
+	if err != nil {
+		t.Fatalf("CreateTaskFromTemplate() error = %v", err)
+	}
+	if task.Title != "Access review: Payroll" || task.Work.Prompt != "Review access to Payroll" || !task.QA.Enabled || task.Priority != 5 || task.ExternalID != "AR-1" {
+		t.Errorf("created task = %+v", task)
+	}
+
+	// The task set is created with the template's schemas
+	ts, err := runner.tasks.GetTaskSet(projectName, "access")
+	if err != nil {
+		t.Fatalf("GetTaskSet() error = %v", err)
+	}
+	if ts.WorkerResponseTemplate != "audit/schemas/finding.json" {
+		t.Errorf("task set worker_response_template = %q", ts.WorkerResponseTemplate)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "broken.json"), []byte(`{"title": "x", "prompt": "y", "unknown": true}`), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+	if _, err := runner.LoadTaskTemplate("audit", "task-templates/broken.json"); err == nil {
+		t.Error("LoadTaskTemplate() accepted an unknown field")
+	}
+}
+
+func TestApplyPipeline(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "test-project"
+	if _, err := runner.projects.Create(projectName, "Test Project", "pipelines", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	writePipeline := func(scopePrompt string) {
+		pipeline := `{
+			"name": "audit",
+			"task_sets": [
+				{"path": "intake", "title": "Intake", "llm_model_id": "test-llm",
+				 "tasks": [{"title": "Scope", "external_id": "scope", "prompt": "` + scopePrompt + `"}]},
+				{"path": "assessment", "title": "Assessment", "depends_on": ["intake"],
+				 "qa": {"enabled": true, "skip_rules": [{"name": "na", "conditions": [{"field": "result", "op": "equals", "value": "n/a"}]}]},
+				 "tasks": [
+					{"title": "Access control", "prompt": "Assess access control", "depends_on": ["scope"]},
+					{"title": "Logging", "prompt": "Assess logging on ${SYSTEM}", "qa": false, "env": {"SYSTEM": "syslog"}}
+				 ]}
+			]
+		}`
+		dir := filepath.Join(tmpDir, "playbooks", "audit")
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create playbook dir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, global.DefaultPipelineFile), []byte(pipeline), 0644); err != nil {
+			t.Fatalf("Failed to write pipeline: %v", err)
+		}
+	}
+
+	writePipeline("Describe the scope")
+
+	// A dry run reports what would be created without creating it
+	result, err := runner.ApplyPipeline(projectName, "audit", "", true, false)
+	if err != nil {
+		t.Fatalf("ApplyPipeline() dry run error = %v", err)
+	}
+	if !result.DryRun || result.Created != 5 {
+		t.Errorf("Dry run: dry_run=%v created=%d, want true/5", result.DryRun, result.Created)
+	}
+	if _, err := runner.tasks.GetTaskSet(projectName, "intake"); err == nil {
+		t.Errorf("Dry run created task set intake")
+	}
+
+	result, err = runner.ApplyPipeline(projectName, "audit", "", false, false)
+	if err != nil {
+		t.Fatalf("ApplyPipeline() error = %v", err)
+	}
+	if result.Created != 5 || result.Updated != 0 || result.Unchanged != 0 {
+		t.Errorf("First apply: created=%d updated=%d unchanged=%d, want 5/0/0", result.Created, result.Updated, result.Unchanged)
+	}
+
+	assessment, err := runner.tasks.GetTaskSet(projectName, "assessment")
+	if err != nil {
+		t.Fatalf("Failed to get task set: %v", err)
+	}
+	if len(assessment.DependsOn) != 1 || len(assessment.QASkipRules) != 1 || len(assessment.Tasks) != 2 {
+		t.Fatalf("Assessment task set = %+v", assessment)
+	}
+	access, logTask := assessment.Tasks[0], assessment.Tasks[1]
+	if !access.QA.Enabled || logTask.QA.Enabled || len(access.DependsOn) != 1 || logTask.Env["SYSTEM"] != "syslog" {
+		t.Errorf("Tasks: access qa=%v deps=%v, logging qa=%v env=%v", access.QA.Enabled, access.DependsOn, logTask.QA.Enabled, logTask.Env)
+	}
+	if unmet := runner.unmetDependencies(projectName, "assessment", &logTask); len(unmet) != 1 || unmet[0] != "task set intake" {
+		t.Errorf("unmetDependencies = %v, want [task set intake]", unmet)
+	}
+
+	// Applying again changes nothing
+	result, err = runner.ApplyPipeline(projectName, "audit", "", false, false)
+	if err != nil {
+		t.Fatalf("ApplyPipeline() error = %v", err)
+	}
+	if result.Created != 0 || result.Updated != 0 || result.Unchanged != 5 {
+		t.Errorf("Second apply: created=%d updated=%d unchanged=%d, want 0/0/5", result.Created, result.Updated, result.Unchanged)
+	}
+
+	// A changed prompt updates the task without resetting its status
+	if _, err := runner.tasks.UpdateTask(projectName, "scope", map[string]interface{}{
+		"work": map[string]interface{}{"status": global.ExecutionStatusDone},
+	}); err != nil {
+		t.Fatalf("Failed to update task: %v", err)
+	}
+	writePipeline("Describe the scope and systems")
+	result, err = runner.ApplyPipeline(projectName, "audit", "", true, false)
+	if err != nil {
+		t.Fatalf("ApplyPipeline() dry run error = %v", err)
+	}
+	if result.Updated != 1 || result.Actions[1].Action != global.PipelineActionUpdated || len(result.Actions[1].Changes) != 1 {
+		t.Fatalf("Dry run: updated=%d actions=%+v", result.Updated, result.Actions)
+	}
+	if change := result.Actions[1].Changes[0]; change.Field != "prompt" || change.Before != "Describe the scope" || change.After != "Describe the scope and systems" {
+		t.Errorf("Dry run change = %+v", change)
+	}
+	scope, _, err := runner.tasks.GetTask(projectName, "scope")
+	if err != nil {
+		t.Fatalf("Failed to get task: %v", err)
+	}
+	if scope.Work.Prompt != "Describe the scope" {
+		t.Errorf("Dry run changed the prompt to %q", scope.Work.Prompt)
+	}
+
+	result, err = runner.ApplyPipeline(projectName, "audit", "", false, false)
+	if err != nil {
+		t.Fatalf("ApplyPipeline() error = %v", err)
+	}
+	if result.Updated != 1 || result.Actions[1].Action != global.PipelineActionUpdated {
+		t.Errorf("Third apply: updated=%d actions=%+v", result.Updated, result.Actions)
+	}
+	scope, _, err = runner.tasks.GetTask(projectName, "scope")
+	if err != nil {
+		t.Fatalf("Failed to get task: %v", err)
+	}
+	if scope.Work.Prompt != "Describe the scope and systems" || scope.Work.Status != global.ExecutionStatusDone {
+		t.Errorf("Scope task: prompt=%q status=%s", scope.Work.Prompt, scope.Work.Status)
+	}
+	if unmet := runner.unmetDependencies(projectName, "assessment", &logTask); len(unmet) != 0 {
+		t.Errorf("unmetDependencies = %v once intake is done, want none", unmet)
+	}
+
+	// Tasks the pipeline does not declare are kept unless pruning
+	if _, err := runner.tasks.CreateTask(projectName, "intake", "Extra", "", "", &global.WorkExecution{Prompt: "Extra"}, nil); err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	result, err = runner.ApplyPipeline(projectName, "audit", "", false, false)
+	if err != nil {
+		t.Fatalf("ApplyPipeline() error = %v", err)
+	}
+	if result.Deleted != 0 || result.Unchanged != 5 {
+		t.Errorf("Apply without prune: deleted=%d unchanged=%d, want 0/5", result.Deleted, result.Unchanged)
+	}
+	result, err = runner.ApplyPipeline(projectName, "audit", "", true, true)
+	if err != nil {
+		t.Fatalf("ApplyPipeline() dry run error = %v", err)
+	}
+	if result.Deleted != 1 || result.Actions[len(result.Actions)-1].Target != "intake/Extra" {
+		t.Errorf("Dry run prune: deleted=%d actions=%+v", result.Deleted, result.Actions)
+	}
+	if intake, _ := runner.tasks.GetTaskSet(projectName, "intake"); intake == nil || len(intake.Tasks) != 2 {
+		t.Errorf("Dry run pruned the extra task")
+	}
+	result, err = runner.ApplyPipeline(projectName, "audit", "", false, true)
+	if err != nil {
+		t.Fatalf("ApplyPipeline() prune error = %v", err)
+	}
+	if intake, _ := runner.tasks.GetTaskSet(projectName, "intake"); result.Deleted != 1 || intake == nil || len(intake.Tasks) != 1 {
+		t.Errorf("Prune: deleted=%d, intake=%+v", result.Deleted, intake)
+	}
+}
+
+func TestRedaction(t *testing.T) {
+	runner, tmpDir := setupTestRunnerWithConfig(t, `"redaction": {"patterns": ["topsecret-[0-9]+"]},`)
+	defer os.RemoveAll(tmpDir)
+
+	project := "redaction-test"
+	if _, err := runner.projects.Create(project, "Redaction", "redaction", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	// Result files, including history, are masked before they are written
+	data, _ := json.MarshalIndent(global.TaskResult{
+		Worker:  global.WorkerResult{Response: "found topsecret-123 in config"},
+		History: []global.Message{{Role: "worker", Stderr: "auth: Bearer abcdefghijklmnopqrstuvwxyz"}},
+	}, "", "  ")
+	file := filepath.Join(tmpDir, "result.json")
+	if err := runner.writeResultFile(file, data); err != nil {
+		t.Fatalf("writeResultFile failed: %v", err)
+	}
+	written, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("Failed to read result: %v", err)
+	}
+	var result global.TaskResult
+	if err := json.Unmarshal(written, &result); err != nil {
+		t.Fatalf("Redacted result is not valid JSON: %v", err)
+	}
+	if result.Worker.Response != "found [REDACTED] in config" {
+		t.Errorf("response not redacted: %q", result.Worker.Response)
+	}
+	if result.History[0].Stderr != "auth: [REDACTED]" {
+		t.Errorf("history stderr not redacted: %q", result.History[0].Stderr)
+	}
+
+	// So is the project log
+	runner.logToProject(project, "Task 1: stderr mentions topsecret-456")
+	logData, err := os.ReadFile(filepath.Join(tmpDir, "projects", project, global.ProjectLogName))
+	if err != nil {
+		t.Fatalf("Failed to read project log: %v", err)
+	}
+	if strings.Contains(string(logData), "topsecret-456") || !strings.Contains(string(logData), "[REDACTED]") {
+		t.Errorf("project log not redacted: %s", logData)
+	}
+}
+
+func TestRunCostLimit(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	// The task set limit takes precedence over the config
+	if got := runner.runCostLimit([]*global.TaskSet{{Limits: global.Limits{MaxCostUSD: 2.5}}}); got != 2.5 {
+		t.Errorf("runCostLimit = %v, want 2.5", got)
+	}
+	if got := runner.runCostLimit(nil); got != 0 {
+		t.Errorf("runCostLimit without a limit = %v, want 0", got)
+	}
+
+	// Without pricing, the provider-reported cost is used
+	if got := runner.estimateCost("test-llm", &llm.DispatchResult{CostUSD: 0.4, InputTokens: 1000}); got != 0.4 {
+		t.Errorf("estimateCost = %v, want 0.4", got)
+	}
+	pricing := &config.LLMPricing{InputPerMTok: 3, OutputPerMTok: 15}
+	if got := pricing.Cost(1_000_000, 100_000, 0, 0); got != 4.5 {
+		t.Errorf("pricing.Cost = %v, want 4.5", got)
+	}
+
+	// Reaching the limit stops further calls, once
+	budget := runner.newRunBudget([]*global.Task{{}}, global.Limits{}, 0.10)
+	budget.maxCostUSD = 1
+	if budget.addCost(0.6) {
+		t.Error("limit reported before it was reached")
+	}
+	if !budget.addCost(0.6) {
+		t.Error("limit not reported when reached")
+	}
+	if budget.addCost(0.1) {
+		t.Error("limit reported twice")
+	}
+	if budget.checkAndIncrement() {
+		t.Error("calls still allowed after the cost limit was reached")
+	}
+	if !budget.costExceeded || budget.spent() < 1.29 || budget.spent() > 1.31 {
+		t.Errorf("unexpected budget state: exceeded=%v spent=%v", budget.costExceeded, budget.spent())
+	}
+}
+
+func TestQAHaltSeverity(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "severity-test"
+	if _, err := runner.projects.Create(projectName, "Severity", "severity", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	if _, err := runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "", nil, false, global.Limits{}, false, "", "", nil); err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+	if err := runner.tasks.SetTaskSetQAHaltSeverity(projectName, "main", "severe"); err == nil {
+		t.Error("invalid qa_halt_severity accepted")
+	}
+	if err := runner.tasks.SetTaskSetQAHaltSeverity(projectName, "main", "High"); err != nil {
+		t.Fatalf("SetTaskSetQAHaltSeverity failed: %v", err)
+	}
+
+	budget := runner.newRunBudget([]*global.Task{{}}, global.Limits{}, 0.10)
+	task := &global.Task{ID: 1}
+
+	// Severities below the threshold do not halt the run
+	task.QA.Severity = global.QASeverityMedium
+	runner.checkQAHaltSeverity(projectName, "main", task, budget)
+	if budget.severityHalt || !budget.checkAndIncrement() {
+		t.Fatal("run halted below the severity threshold")
+	}
+
+	// A more severe finding halts it
+	task.QA.Severity = global.QASeverityCritical
+	runner.checkQAHaltSeverity(projectName, "main", task, budget)
+	if !budget.severityHalt || budget.checkAndIncrement() {
+		t.Error("run not halted at the severity threshold")
+	}
+}
+
+func TestApprovalGate(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "approval-test"
+	if _, err := runner.projects.Create(projectName, "Approval", "approval", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	if _, err := runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "", nil, false, global.Limits{}, false, "", "", nil); err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+	if err := runner.tasks.SetTaskSetApproval(projectName, "main", &global.ApprovalGate{Severity: "severe"}); err == nil {
+		t.Error("invalid approval severity accepted")
+	}
+	if err := runner.tasks.SetTaskSetApproval(projectName, "main", &global.ApprovalGate{Escalate: true, Severity: "High"}); err != nil {
+		t.Fatalf("SetTaskSetApproval failed: %v", err)
+	}
+
+	var tasks []*global.Task
+	for _, qa := range []global.QAExecution{
+		{Verdict: global.QAVerdictPass, Severity: global.QASeverityLow},
+		{Verdict: global.QAVerdictPass, Severity: global.QASeverityCritical},
+		{Verdict: global.QAVerdictEscalate},
+	} {
+		task, err := runner.tasks.CreateTask(projectName, "main", "Task "+qa.Verdict+qa.Severity, "test", "", &global.WorkExecution{Prompt: "test prompt"}, nil)
+		if err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+		if _, err := runner.tasks.UpdateTask(projectName, task.UUID, map[string]interface{}{
+			"work": map[string]interface{}{"status": global.ExecutionStatusDone},
+		}); err != nil {
+			t.Fatalf("Failed to update task: %v", err)
+		}
+		task.Work.Status = global.ExecutionStatusDone
+		task.QA = qa
+		runner.holdForApproval(projectName, "main", task)
+		tasks = append(tasks, task)
+	}
+
+	// Only the high-severity and escalated results are held
+	for i, want := range []string{global.ExecutionStatusDone, global.ExecutionStatusAwaitingApproval, global.ExecutionStatusAwaitingApproval} {
+		stored, _, err := runner.tasks.GetTask(projectName, tasks[i].UUID)
+		if err != nil {
+			t.Fatalf("GetTask failed: %v", err)
+		}
+		if stored.Work.Status != want {
+			t.Errorf("task %d status = %s, want %s", i, stored.Work.Status, want)
+		}
+	}
+	status, err := runner.GetTaskStatus(projectName, "", "")
+	if err != nil {
+		t.Fatalf("GetTaskStatus failed: %v", err)
+	}
+	if status.AwaitingApproval != 2 || status.Done != 1 {
+		t.Errorf("task status = %d awaiting approval, %d done; want 2 and 1", status.AwaitingApproval, status.Done)
+	}
+
+	// Only held tasks can be decided, and only once
+	if _, _, err := runner.tasks.DecideApproval(projectName, tasks[0].UUID, true, ""); err == nil {
+		t.Error("approved a task that was not awaiting approval")
+	}
+	approved, _, err := runner.tasks.DecideApproval(projectName, tasks[1].UUID, true, "")
+	if err != nil || approved.Work.Status != global.ExecutionStatusDone {
+		t.Fatalf("DecideApproval(approve) = %+v, %v", approved, err)
+	}
+	if _, _, err := runner.tasks.DecideApproval(projectName, tasks[1].UUID, false, "too late"); err == nil {
+		t.Error("decided a task twice")
+	}
+	rejected, _, err := runner.tasks.DecideApproval(projectName, tasks[2].UUID, false, "unsupported")
+	if err != nil {
+		t.Fatalf("DecideApproval(reject) failed: %v", err)
+	}
+	if rejected.Work.Status != global.ExecutionStatusFailed || rejected.Work.ErrorCode != global.ErrorCodeApprovalRejected || !strings.Contains(rejected.Work.Error, "unsupported") {
+		t.Errorf("rejected task = %+v", rejected.Work)
+	}
+}
+
+func TestRetryGeneration(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	task := &global.Task{ID: 1, UUID: "gen-task"}
+	if opts := runner.dispatchOptions("test-project", task, "test-llm", 2, ""); opts != nil {
+		t.Errorf("dispatchOptions without generation config = %+v, want nil", opts)
+	}
+
+	temperature, lower := 0.7, 0.2
+	llmConfig := runner.llm.GetLLM("test-llm")
+	llmConfig.Args = []string{"--temperature={{TEMPERATURE}}", "--max-tokens={{MAX_TOKENS}}", "{{PROMPT}}"}
+	llmConfig.Generation = &global.GenerationParams{Temperature: &temperature}
+	llmConfig.RetryGeneration = []global.GenerationParams{{Temperature: &lower}}
+
+	first := runner.dispatchOptions("test-project", task, "test-llm", 1, "")
+	retry := runner.dispatchOptions("test-project", task, "test-llm", 2, "")
+	if first == nil || *first.Temperature != 0.7 || retry == nil || *retry.Temperature != 0.2 {
+		t.Fatalf("dispatchOptions = %+v, %+v", first, retry)
+	}
+
+	// Command LLMs receive the parameters in their args; unset ones are omitted
+	result, err := runner.llm.Dispatch(&llm.DispatchRequest{LLMID: "test-llm", Prompt: "hello", Options: retry})
+	if err != nil {
+		t.Fatalf("Dispatch failed: %v", err)
+	}
+	if !strings.HasPrefix(result.Stdout, "--temperature=0.2 ") || strings.Contains(result.Stdout, "max-tokens") {
+		t.Errorf("LLM args = %q", result.Stdout)
+	}
+
+	// Each attempt records the parameters it used
+	runner.recordHistoryResponse(task.UUID, "worker", first, result, "test-llm", 1)
+	runner.recordHistoryResponse(task.UUID, "worker", retry, result, "test-llm", 2)
+	history := runner.getTaskHistory(task.UUID)
+	if len(history) != 2 || history[0].Generation == nil || *history[0].Generation.Temperature != 0.7 || *history[1].Generation.Temperature != 0.2 {
+		t.Errorf("history generation = %+v", history)
+	}
+}
+
+func TestStructuredOutput(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "structured-test"
+	if _, err := runner.projects.Create(projectName, "Structured", "structured output", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	templates := createTestTemplates(t, tmpDir)
+	if _, err := runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "Test task set", templates, false, global.Limits{}, false, "", "", nil); err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+	task, err := runner.tasks.CreateTask(projectName, "main", "Task 1", "", "", &global.WorkExecution{Prompt: "Describe the finding", LLMModelID: "test-llm"}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	// Without structured output the schema is embedded in the prompt
+	prompt, err := runner.buildPrompt(projectName, "main", task)
+	if err != nil {
+		t.Fatalf("buildPrompt failed: %v", err)
+	}
+	if !strings.Contains(prompt, "Expected JSON Schema") {
+		t.Errorf("prompt missing the embedded schema:\n%s", prompt)
+	}
+	if opts := runner.dispatchOptions(projectName, task, "test-llm", 1, runner.nativeSchema(projectName, "main", "test-llm", "worker")); opts != nil {
+		t.Errorf("dispatchOptions without structured output = %+v, want nil", opts)
+	}
+
+	// With structured output the schema is passed natively instead
+	llmConfig := runner.llm.GetLLM("test-llm")
+	llmConfig.StructuredOutput = true
+	llmConfig.Args = []string{"--schema={{SCHEMA}}", "{{PROMPT}}"}
+	prompt, err = runner.buildPrompt(projectName, "main", task)
+	if err != nil {
+		t.Fatalf("buildPrompt failed: %v", err)
+	}
+	if strings.Contains(prompt, "Expected JSON Schema") || !strings.Contains(prompt, "enforced by the LLM provider") {
+		t.Errorf("prompt still embeds the schema:\n%s", prompt)
+	}
+	schema := runner.nativeSchema(projectName, "main", "test-llm", "worker")
+	opts := runner.dispatchOptions(projectName, task, "test-llm", 1, schema)
+	if schema == "" || opts == nil || opts.ResponseSchema != schema {
+		t.Fatalf("dispatchOptions = %+v, want the worker schema", opts)
+	}
+	if got := runner.nativeSchema(projectName, "main", "test-llm", "qa"); got != "" {
+		t.Errorf("nativeSchema for qa without a QA schema = %q, want empty", got)
+	}
+
+	result, err := runner.llm.Dispatch(&llm.DispatchRequest{LLMID: "test-llm", Prompt: "hello", Options: opts})
+	if err != nil {
+		t.Fatalf("Dispatch failed: %v", err)
+	}
+	if !strings.HasPrefix(result.Stdout, "--schema="+schema) {
+		t.Errorf("LLM args = %q", result.Stdout)
+	}
+	runner.recordHistoryResponse(task.UUID, "worker", opts, result, "test-llm", 1)
+	if history := runner.getTaskHistory(task.UUID); len(history) != 1 || !history[0].StructuredOutput {
+		t.Errorf("history = %+v, want structured output recorded", history)
+	}
+}
+
+func TestTemplateRegistry(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "registry-test"
+	if _, err := runner.projects.Create(projectName, "Registry", "template registry", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	templates := createTestTemplates(t, tmpDir)
+	templates.QAResponseTemplate = `{"type": "object", "required": ["verdict"]}`
+	templates.QAReportTemplate = "missing-qa.md"
+	if _, err := runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "Test task set", templates, false, global.Limits{}, false, "", "", nil); err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+
+	registry, err := runner.TemplateRegistry(projectName)
+	if err != nil {
+		t.Fatalf("TemplateRegistry failed: %v", err)
+	}
+	entries := make(map[string]global.TemplateRegistryEntry)
+	for _, entry := range registry.Entries {
+		entries[entry.Path] = entry
+	}
+	schema := entries["test/templates/worker-response.json"]
+	if schema.Source != global.RegistrySourcePlaybook || schema.Kind != global.RegistryKindWorkerSchema || len(schema.Checksum) != 64 || schema.ModifiedAt == nil {
+		t.Errorf("worker schema entry = %+v", schema)
+	}
+	if inline := entries["inline:main:"+global.RegistryKindQASchema]; inline.Source != global.RegistrySourceInline || inline.Checksum == "" {
+		t.Errorf("inline schema entry = %+v", inline)
+	}
+	if registry.Missing != 1 || entries["missing-qa.md"].Source != global.RegistrySourceMissing {
+		t.Errorf("missing = %d, entries = %+v", registry.Missing, registry.Entries)
+	}
+	if registry.LastRunAt != nil || registry.Changed != 0 {
+		t.Errorf("changes reported without a previous run: %+v", registry)
+	}
+
+	// A run records the checksums; later edits are reported as changes
+	run := &global.DashboardRun{CompletedAt: time.Now(), Templates: runner.runTemplates(projectName)}
+	runner.recordTrend(projectName, run)
+	schemaPath := filepath.Join(tmpDir, "playbooks", "test", "templates", "worker-response.json")
+	if err := os.WriteFile(schemaPath, []byte(`{"type": "object"}`), 0644); err != nil {
+		t.Fatalf("Failed to edit schema: %v", err)
+	}
+	registry, err = runner.TemplateRegistry(projectName)
+	if err != nil {
+		t.Fatalf("TemplateRegistry failed: %v", err)
+	}
+	if registry.LastRunAt == nil || registry.Changed != 1 {
+		t.Fatalf("after edit: %+v", registry)
+	}
+	for _, entry := range registry.Entries {
+		if entry.Changed && (entry.Path != "test/templates/worker-response.json" || entry.PreviousChecksum != schema.Checksum) {
+			t.Errorf("unexpected change: %+v", entry)
+		}
+	}
+}
+
+func TestTaskEvents(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "events-test"
+	if _, err := runner.projects.Create(projectName, "Events", "progress events", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	templates := createTestTemplates(t, tmpDir)
+	if _, err := runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "Test task set", templates, false, global.Limits{}, false, "", "", nil); err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+	work := &global.WorkExecution{Prompt: "test prompt", LLMModelID: "test-llm"}
+	task, err := runner.tasks.CreateTask(projectName, "main", "Event Task", "test", "", work, nil)
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	if _, err := runner.Run(context.Background(), &global.RunRequest{Project: projectName}, nil); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	runner.Wait()
+
+	events, err := runner.projects.GetEvents(projectName)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	var kinds []string
+	for _, e := range events {
+		if e.TaskUUID != task.UUID || e.Path != "main" {
+			t.Errorf("event for unexpected task: %+v", e)
+		}
+		kinds = append(kinds, e.Event)
+	}
+	if len(kinds) < 3 || kinds[0] != global.EventTaskStarted || kinds[1] != global.EventLLMDispatched || kinds[len(kinds)-1] != global.EventTaskFinished {
+		t.Fatalf("unexpected event sequence: %v", kinds)
+	}
+	if last := events[len(events)-1]; last.Detail == "" {
+		t.Error("task_finished event has no final status")
+	}
+}
+
+func TestResumeInterruptedRuns(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "journal-test"
+	if _, err := runner.projects.Create(projectName, "Journal", "run journal", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	templates := createTestTemplates(t, tmpDir)
+	if _, err := runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "Test task set", templates, false, global.Limits{}, false, "", "", nil); err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+	work := &global.WorkExecution{Prompt: "test prompt", LLMModelID: "test-llm"}
+	task, err := runner.tasks.CreateTask(projectName, "main", "Journal Task", "test", "", work, nil)
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	// Simulate a crash: a journal left behind by a run that was never finished
+	journal := runner.startJournal(projectName, "interrupted-run", global.JournalKindRun, &global.RunRequest{Project: projectName, Path: "main"})
+	journal.taskStarted("main", task)
+	runner.activeJournals.Delete(projectName + "/" + journal.runID)
+
+	runs, err := runner.InterruptedRuns(projectName)
+	if err != nil {
+		t.Fatalf("InterruptedRuns failed: %v", err)
+	}
+	if len(runs) != 1 || len(runs[0].InFlight) != 1 || runs[0].InFlight[0].TaskUUID != task.UUID || runs[0].Path != "main" {
+		t.Fatalf("unexpected interrupted runs: %+v", runs)
+	}
+
+	dry, err := runner.ResumeInterruptedRuns(projectName, true)
+	if err != nil || dry.Resumed != nil || dry.Runs[0].Action != global.ResumeActionPending {
+		t.Fatalf("dry run changed state: %+v, %v", dry, err)
+	}
+
+	result, err := runner.ResumeInterruptedRuns(projectName, false)
+	if err != nil {
+		t.Fatalf("ResumeInterruptedRuns failed: %v", err)
+	}
+	runner.Wait()
+	if result.Resumed == nil || result.Runs[0].Action != global.ResumeActionResumed {
+		t.Fatalf("run not resumed: %+v", result)
+	}
+
+	// The resumed run completed the task and removed every journal
+	journals, err := runner.projects.GetJournals(projectName)
+	if err != nil || len(journals) != 0 {
+		t.Errorf("journals left after resume: %v, %v", journals, err)
+	}
+	if got, _, err := runner.tasks.GetTask(projectName, task.UUID); err != nil || got.Work.Status != global.ExecutionStatusDone {
+		t.Errorf("task not completed by the resumed run: %+v, %v", got, err)
+	}
+}
+
+func TestRecoverInterruptedRunsResetsStaleTasks(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	templates := createTestTemplates(t, tmpDir)
+	stuck := make(map[string]*global.Task)
+	for _, projectName := range []string{"stale-test", "live-test"} {
+		if _, err := runner.projects.Create(projectName, "Stale", "stale tasks", "", "", "none", ""); err != nil {
+			t.Fatalf("Failed to create project: %v", err)
+		}
+		if _, err := runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "Test task set", templates, false, global.Limits{}, false, "", "", nil); err != nil {
+			t.Fatalf("Failed to create task set: %v", err)
+		}
+		task, err := runner.tasks.CreateTask(projectName, "main", "Stuck Task", "test", "", &global.WorkExecution{Prompt: "test prompt", LLMModelID: "test-llm"}, nil)
+		if err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+		updates := map[string]interface{}{
+			"work": map[string]interface{}{"status": global.ExecutionStatusProcessing},
+			"qa":   map[string]interface{}{"status": global.ExecutionStatusProcessing},
+		}
+		if _, err := runner.tasks.UpdateTask(projectName, task.UUID, updates); err != nil {
+			t.Fatalf("Failed to mark task processing: %v", err)
+		}
+		stuck[projectName] = task
+	}
+
+	// A run of another live process (our parent) holds live-test
+	start := &global.RunJournalEntry{Entry: global.JournalRunStarted, Kind: global.JournalKindRun, PID: os.Getppid(), Host: hostname()}
+	if err := runner.projects.AppendJournal("live-test", "other-process", start); err != nil {
+		t.Fatalf("AppendJournal failed: %v", err)
+	}
+
+	runner.RecoverInterruptedRuns()
+
+	recovery := runner.StartupRecovery()
+	if recovery == nil {
+		t.Fatal("StartupRecovery is nil after RecoverInterruptedRuns")
+	}
+	if len(recovery.StaleTasks) != 2 || recovery.StaleTasks[0].Project != "stale-test" || recovery.InterruptedRuns != 0 {
+		t.Errorf("unexpected recovery: %+v", recovery)
+	}
+	if len(recovery.LiveElsewhere) != 1 || recovery.LiveElsewhere[0] != "live-test" {
+		t.Errorf("LiveElsewhere = %v, want [live-test]", recovery.LiveElsewhere)
+	}
+
+	got, _, err := runner.tasks.GetTask("stale-test", stuck["stale-test"].UUID)
+	if err != nil || got.Work.Status != global.ExecutionStatusWaiting || got.QA.Status != global.ExecutionStatusWaiting {
+		t.Errorf("stale task not returned to waiting: %+v, %v", got, err)
+	}
+	got, _, err = runner.tasks.GetTask("live-test", stuck["live-test"].UUID)
+	if err != nil || got.Work.Status != global.ExecutionStatusProcessing {
+		t.Errorf("task of a live run was reset: %+v, %v", got, err)
+	}
+	if runs, _ := runner.InterruptedRuns("live-test"); len(runs) != 0 {
+		t.Errorf("run of a live process reported as interrupted: %+v", runs)
+	}
+}
+
+func TestPreRunChecks(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "preflight-test"
+	if _, err := runner.projects.Create(projectName, "Preflight", "pre-run checks", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	templates := createTestTemplates(t, tmpDir)
+	if _, err := runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "Test task set", templates, false, global.Limits{}, false, "", "", nil); err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+	work := &global.WorkExecution{Prompt: "test prompt", LLMModelID: "test-llm", InstructionsFile: "missing/instructions.md"}
+	task, err := runner.tasks.CreateTask(projectName, "main", "Preflight Task", "test", "", work, nil)
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	// The run is refused before anything is dispatched
+	_, err = runner.Run(context.Background(), &global.RunRequest{Project: projectName}, nil)
+	if err == nil || !strings.Contains(err.Error(), "pre-run checks failed") || !strings.Contains(err.Error(), "missing/instructions.md") {
+		t.Fatalf("expected pre-run check failure, got %v", err)
+	}
+	if runner.IsProjectRunning(projectName) {
+		t.Error("project left marked as running")
+	}
+	if got, _, err := runner.tasks.GetTask(projectName, task.UUID); err != nil || got.Work.Status != global.ExecutionStatusWaiting {
+		t.Errorf("task changed by a refused run: %+v, %v", got, err)
+	}
+}
+
+func TestRunWebhooks(t *testing.T) {
+	type delivery struct {
+		event     string
+		signature string
+		body      []byte
+	}
+	deliveries := make(chan delivery, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		deliveries <- delivery{req.Header.Get(global.WebhookEventHeader), req.Header.Get(global.WebhookSignatureHeader), body}
+	}))
+	defer server.Close()
+
+	runner, tmpDir := setupTestRunnerWithConfig(t, `"webhooks": [
+		{"url": "`+server.URL+`", "secret": "s3cret", "events": ["run_completed"]},
+		{"url": "`+server.URL+`/escalations", "events": ["task_escalated"]}
+	],`)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "webhook-test"
+	if _, err := runner.projects.Create(projectName, "Webhooks", "webhook test", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	templates := createTestTemplates(t, tmpDir)
+	if _, err := runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "Test task set", templates, false, global.Limits{}, false, "", "", nil); err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+	work := &global.WorkExecution{Prompt: "test prompt", LLMModelID: "test-llm"}
+	if _, err := runner.tasks.CreateTask(projectName, "main", "Webhook Task", "test", "", work, nil); err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	if _, err := runner.Run(context.Background(), &global.RunRequest{Project: projectName}, nil); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	runner.Wait()
+
+	// Only the run_completed subscriber is called; nothing escalated
+	if len(deliveries) != 1 {
+		t.Fatalf("expected 1 webhook delivery, got %d", len(deliveries))
+	}
+	got := <-deliveries
+	if got.event != global.WebhookEventRunCompleted {
+		t.Errorf("event = %q", got.event)
+	}
+	if got.signature != "sha256="+webhookSignature("s3cret", got.body) {
+		t.Errorf("signature %q does not match body", got.signature)
+	}
+	var payload global.WebhookPayload
+	if err := json.Unmarshal(got.body, &payload); err != nil {
+		t.Fatalf("payload is not JSON: %v", err)
+	}
+	if payload.Project != projectName || payload.Run == nil || payload.Run.TasksExecuted != 1 {
+		t.Errorf("unexpected payload: %+v", payload)
+	}
+	if len(payload.Reports) == 0 || !strings.HasPrefix(payload.Reports[0], global.ReportsDir+"/") {
+		t.Errorf("report links missing: %v", payload.Reports)
+	}
+}
+
+func TestPromptContextWindow(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "context-window-test"
+	projectContext := strings.Repeat("Background on the engagement. ", 400) // ~12000 characters
+	if _, err := runner.projects.Create(projectName, "Context Window", "context window fitting", projectContext, "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	if _, err := runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "Test task set", nil, false, global.Limits{}, false, "", "", nil); err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+	task, err := runner.tasks.CreateTask(projectName, "main", "Task 1", "", "", &global.WorkExecution{Prompt: "Describe the finding", LLMModelID: "test-llm"}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	// Without a context window the prompt is unchanged
+	full, err := runner.buildPrompt(projectName, "main", task)
+	if err != nil {
+		t.Fatalf("buildPrompt failed: %v", err)
+	}
+	if !strings.Contains(full, projectContext) {
+		t.Fatal("prompt missing the project context")
+	}
+
+	// The project context is shortened first; the task prompt is kept
+	llmConfig := runner.llm.GetLLM("test-llm")
+	llmConfig.ContextWindow = 1500
+	prompt, err := runner.buildPrompt(projectName, "main", task)
+	if err != nil {
+		t.Fatalf("buildPrompt failed: %v", err)
+	}
+	if estimateTokens(prompt) > 1500 || !strings.Contains(prompt, "truncated") || !strings.Contains(prompt, "Describe the finding") {
+		t.Errorf("prompt not fitted (%d tokens):\n%s", estimateTokens(prompt), prompt)
+	}
+
+	// A task prompt that cannot fit fails with a clear error
+	task.Work.Prompt = strings.Repeat("Analyze this control in detail. ", 400)
+	_, err = runner.buildPrompt(projectName, "main", task)
+	tooLarge, ok := err.(*promptTooLargeError)
+	if !ok {
+		t.Fatalf("buildPrompt error = %v, want promptTooLargeError", err)
+	}
+	if tooLarge.largest != "instructions" || !strings.Contains(err.Error(), "context window of LLM test-llm") {
+		t.Errorf("error = %v", err)
+	}
+}
+
+func TestEvidenceFiles(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "evidence-files-test"
+	if _, err := runner.projects.Create(projectName, "Evidence", "evidence files", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	largeLog := strings.Repeat("2025-01-15 deny tcp 10.0.0.1:443\n", 1000) // ~33000 bytes, three chunks
+	for path, content := range map[string]string{
+		"evidence/web01/firewall.txt": "deny all inbound",
+		"evidence/web01/users.csv":    "alice,admin",
+		"logs/web01.log":              largeLog,
+		"notes.md":                    "not evidence",
+	} {
+		if _, err := runner.projects.PutFile(projectName, path, content, ""); err != nil {
+			t.Fatalf("PutFile failed: %v", err)
+		}
+	}
+	if _, err := runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "", nil, false, global.Limits{}, false, "", "", nil); err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+	work := &global.WorkExecution{Prompt: "Assess the firewall", LLMModelID: "test-llm", EvidenceFiles: []string{"evidence/${HOST}/**", "logs/*.log", "missing/*.txt"}}
+	task, err := runner.tasks.CreateTask(projectName, "main", "Firewall", "", "", work, nil)
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	task.Env = map[string]string{"HOST": "web01"}
+
+	prompt, err := runner.buildPrompt(projectName, "main", task)
+	if err != nil {
+		t.Fatalf("buildPrompt failed: %v", err)
+	}
+	for _, want := range []string{"=== EVIDENCE ===", "--- evidence/web01/firewall.txt ---\ndeny all inbound", "--- evidence/web01/users.csv ---\nalice,admin", "--- logs/web01.log (part 3 of 3) ---"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+	if strings.Contains(prompt, "not evidence") {
+		t.Error("prompt includes a file evidence_files does not match")
+	}
+	if strings.Index(prompt, "=== TASK PROMPT ===") > strings.Index(prompt, "=== EVIDENCE ===") {
+		t.Error("evidence precedes the task prompt")
+	}
+	if log, _ := os.ReadFile(filepath.Join(tmpDir, "projects", projectName, global.ProjectLogName)); !strings.Contains(string(log), `"missing/*.txt" matches no project file`) {
+		t.Errorf("project log does not note the unmatched entry: %s", log)
+	}
+
+	// Evidence is shortened from the last chunk to fit the context window; the task prompt is kept
+	runner.llm.GetLLM("test-llm").ContextWindow = 6000
+	prompt, err = runner.buildPrompt(projectName, "main", task)
+	if err != nil {
+		t.Fatalf("buildPrompt failed: %v", err)
+	}
+	if estimateTokens(prompt) > 6000 || !strings.Contains(prompt, "Assess the firewall") || !strings.Contains(prompt, "deny all inbound") || strings.Contains(prompt, "(part 3 of 3)") {
+		t.Errorf("evidence not fitted (%d tokens)", estimateTokens(prompt))
+	}
+
+	if chunks := chunkEvidence("line one\nline two\n", 12); len(chunks) != 2 || chunks[0] != "line one\n" || chunks[1] != "line two\n" {
+		t.Errorf("chunkEvidence() = %q", chunks)
+	}
+}
+
+func TestRevisionConversation(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	previous := &global.TaskResult{
+		Worker: global.WorkerResult{FullPrompt: "Assess the firewall", Response: "All good", LLMModelID: "test-llm"},
+		History: []global.Message{
+			{Role: "worker", SessionID: "session-1"},
+			{Role: "qa", SessionID: "qa-session"},
+		},
+	}
+
+	// Without a way to hold the conversation, the revision is a complete prompt
+	if _, _, ok := runner.revisionConversation("test-llm", previous); ok {
+		t.Error("revision continued a conversation without resume_args or a host dispatcher")
+	}
+
+	// An LLM with resume_args continues the worker's session
+	llmConfig := runner.llm.GetLLM("test-llm")
+	llmConfig.ResumeArgs = []string{"resume", "{{SESSION_ID}}", "{{PROMPT}}"}
+	conversation, sessionID, ok := runner.revisionConversation("test-llm", previous)
+	if !ok || sessionID != "session-1" || len(conversation) != 2 || conversation[1].Role != global.MessageRoleAssistant || conversation[1].Content != "All good" {
+		t.Fatalf("revisionConversation = %+v, %q, %v", conversation, sessionID, ok)
+	}
+	if _, _, ok := runner.revisionConversation("other-llm", previous); ok {
+		t.Error("revision continued the conversation of a different LLM")
+	}
+
+	// Only the new turn is sent when resuming; otherwise earlier turns become a transcript
+	result, err := runner.llm.Dispatch(&llm.DispatchRequest{LLMID: "test-llm", Prompt: "feedback", SessionID: sessionID, Messages: conversation})
+	if err != nil {
+		t.Fatalf("Dispatch failed: %v", err)
+	}
+	if result.Stdout != "resume session-1 feedback" {
+		t.Errorf("resumed LLM args = %q", result.Stdout)
+	}
+	result, err = runner.llm.Dispatch(&llm.DispatchRequest{LLMID: "test-llm", Prompt: "feedback", Messages: conversation})
+	if err != nil {
+		t.Fatalf("Dispatch failed: %v", err)
+	}
+	if !strings.Contains(result.Stdout, "--- ASSISTANT ---\nAll good") || !strings.HasSuffix(result.Stdout, "=== TASK ===\nfeedback") {
+		t.Errorf("transcript prompt = %q", result.Stdout)
+	}
+	if _, err := runner.llm.Dispatch(&llm.DispatchRequest{LLMID: "test-llm", Prompt: "x", Messages: []global.ConversationMessage{{Role: "tool"}}}); err == nil {
+		t.Error("expected error for an invalid message role")
+	}
+
+	// A second revision carries the whole conversation forward
+	previous.Worker.Conversation = conversation
+	previous.Worker.FullPrompt, previous.Worker.Response = "feedback", "Fixed"
+	if conversation, _, _ := runner.revisionConversation("test-llm", previous); len(conversation) != 4 || conversation[3].Content != "Fixed" {
+		t.Errorf("second revision conversation = %+v", conversation)
+	}
+
+	prompt, _, err := revisionFeedbackPrompt(&global.Task{QA: global.QAExecution{Verdict: "fail"}}, "Missing evidence", "").build("test-llm", 0)
+	if err != nil || !strings.Contains(prompt, "verdict: fail") || !strings.Contains(prompt, "Missing evidence") || strings.Contains(prompt, "PROJECT CONTEXT") {
+		t.Errorf("revision feedback prompt = %q, %v", prompt, err)
+	}
+}
+
+func TestRevisionPromptTemplate(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "revision-template-test"
+	if _, err := runner.projects.Create(projectName, "Revision", "revision template", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	if _, err := runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "", nil, false, global.Limits{}, false, "", "", nil); err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+	task, err := runner.tasks.CreateTask(projectName, "main", "Firewall", "test", "", &global.WorkExecution{Prompt: "Assess the firewall"}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	task.QA = global.QAExecution{Verdict: global.QAVerdictFail, Severity: global.QASeverityHigh}
+	previous := &global.TaskResult{Worker: global.WorkerResult{Response: "All good"}}
+	qaResponse := `{"verdict": "fail", "gaps": ["no evidence"]}`
+
+	// Without a template, the standard QA feedback is used
+	if _, ok := runner.renderRevisionPrompt(projectName, "main", task, previous, qaResponse, "", false); ok {
+		t.Error("revision prompt rendered without a template")
+	}
+
+	tmpl := "{{.QAVerdict}}/{{.QASeverity}}: {{index .QAFindings.gaps 0}}\nPrior: {{.PriorResponse}}\nOriginal: {{.OriginalPrompt}}"
+	if _, err := runner.projects.PutFile(projectName, "revision.tmpl", tmpl, ""); err != nil {
+		t.Fatalf("PutFile failed: %v", err)
+	}
+	if err := runner.tasks.SetTaskSetRevisionPromptTemplate(projectName, "main", "revision.tmpl"); err != nil {
+		t.Fatalf("SetTaskSetRevisionPromptTemplate failed: %v", err)
+	}
+	text, ok := runner.renderRevisionPrompt(projectName, "main", task, previous, qaResponse, "", false)
+	if !ok || !strings.Contains(text, "fail/high: no evidence") || !strings.Contains(text, "Prior: All good") || !strings.Contains(text, "=== TASK PROMPT ===\n\nAssess the firewall") {
+		t.Errorf("renderRevisionPrompt = %q, %v", text, ok)
+	}
+
+	// A template that fails to render falls back to the standard QA feedback
+	if _, err := runner.projects.PutFile(projectName, "revision.tmpl", "{{.Missing}}", ""); err != nil {
+		t.Fatalf("PutFile failed: %v", err)
+	}
+	if _, ok := runner.renderRevisionPrompt(projectName, "main", task, previous, qaResponse, "", false); ok {
+		t.Error("revision prompt rendered from a broken template")
+	}
+}
+
+func TestWarmUpLLMs(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	project := "warmup-project"
+	if _, err := runner.projects.Create(project, "Warm-up", "Warm standby test", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	readLog := func() string {
+		data, _ := os.ReadFile(filepath.Join(tmpDir, "projects", project, global.ProjectLogName))
+		return string(data)
+	}
+
+	// Without warm standby, no priming call is made
+	runner.warmUpLLMs(project, []string{"test-llm"}, nil)
+	if strings.Contains(readLog(), "Warm-up") {
+		t.Errorf("warm-up ran for an LLM without warmup enabled: %s", readLog())
+	}
+
+	llmConfig := runner.llm.GetLLM("test-llm")
+	llmConfig.Warmup = &config.LLMWarmup{Enabled: true, KeepAlive: "30m"}
+
+	// An LLM loaded by its pre-flight test call is not primed again
+	runner.warmUpLLMs(project, []string{"test-llm"}, map[string]bool{"test-llm": true})
+	if strings.Contains(readLog(), "Warm-up") {
+		t.Errorf("warm-up ran for an LLM primed by the pre-flight check: %s", readLog())
+	}
+
+	runner.warmUpLLMs(project, []string{"test-llm"}, map[string]bool{})
+	if !strings.Contains(readLog(), "Warm-up: test-llm loaded") {
+		t.Errorf("project log does not note the warm-up: %s", readLog())
+	}
+
+	// The keep-alive reaches the LLM through {{KEEP_ALIVE}}
+	llmConfig.Args = []string{"--keepalive={{KEEP_ALIVE}}", "{{PROMPT}}"}
+	result, err := runner.llm.Dispatch(&llm.DispatchRequest{LLMID: "test-llm", Prompt: "hello"})
+	if err != nil {
+		t.Fatalf("Dispatch failed: %v", err)
+	}
+	if !strings.HasPrefix(result.Stdout, "--keepalive=30m") {
+		t.Errorf("stdout = %q, want the keep-alive argument", result.Stdout)
+	}
+}
+
+func TestInflightTasks(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	llmConfig := runner.llm.GetLLM("test-llm")
+	llmConfig.Command = "/bin/sh"
+	llmConfig.Args = []string{"-c", "sleep 1; echo {{PROMPT}}"}
+
+	task := &global.Task{ID: 7, UUID: "inflight-uuid"}
+	done := make(chan error, 1)
+	go func() {
+		_, err := runner.dispatchTracked("inflight-project", "main", task, "qa", &llm.DispatchRequest{LLMID: "test-llm", Prompt: "hello"})
+		done <- err
+	}()
+
+	var inflight []global.InflightTask
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if inflight = runner.InflightTasks(); len(inflight) == 1 && inflight[0].PID > 0 {
+			break
+		}
+	}
+	if len(inflight) != 1 {
+		t.Fatalf("InflightTasks = %+v, want one call", inflight)
+	}
+	got := inflight[0]
+	if got.TaskUUID != "inflight-uuid" || got.TaskID != 7 || got.Project != "inflight-project" || got.Phase != "qa" || got.LLMID != "test-llm" || got.PromptBytes != 5 || got.PID <= 0 {
+		t.Errorf("in-flight call = %+v", got)
+	}
+
+	if err := <-done; err != nil {
+		t.Fatalf("dispatch failed: %v", err)
+	}
+	if inflight := runner.InflightTasks(); len(inflight) != 0 {
+		t.Errorf("InflightTasks after the call returned = %+v", inflight)
+	}
+}
+
+func TestSubsystemCapabilities(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	active := func() map[string]bool {
+		m := make(map[string]bool)
+		for _, c := range runner.Capabilities() {
+			m[c.Name] = c.Active
+		}
+		return m
+	}
+	if caps := active(); !caps[global.SubsystemPlaybooks] || !caps[global.SubsystemReference] || !caps[global.SubsystemProjects] {
+		t.Errorf("capabilities of a fully configured runner = %v", caps)
+	}
+
+	// A runner embedded without playbooks or reference reports them inactive
+	runner.playbooks = nil
+	runner.reference = nil
+	if caps := active(); caps[global.SubsystemPlaybooks] || caps[global.SubsystemReference] || !caps[global.SubsystemProjects] {
+		t.Errorf("capabilities without playbooks and reference = %v", caps)
+	}
+
+	// and features that need them fail with a structured error rather than a panic
+	task := &global.Task{Work: global.WorkExecution{InstructionsFile: "pb/instructions.md", InstructionsFileSource: "playbook"}}
+	_, err := runner.loadInstructionsFile("project", task)
+	if unavailable, ok := global.AsSubsystemUnavailable(err); !ok || unavailable.Subsystem != global.SubsystemPlaybooks || unavailable.Remedy == "" {
+		t.Errorf("loadInstructionsFile error = %v, want playbooks unavailable", err)
+	}
+	task.Work.InstructionsFileSource = "reference"
+	_, err = runner.loadInstructionsFile("project", task)
+	if unavailable, ok := global.AsSubsystemUnavailable(err); !ok || unavailable.Subsystem != global.SubsystemReference {
+		t.Errorf("loadInstructionsFile error = %v, want reference unavailable", err)
+	}
+	_, err = runner.LoadPipeline("pb", "pipeline.json")
+	if unavailable, ok := global.AsSubsystemUnavailable(err); !ok || unavailable.Subsystem != global.SubsystemPlaybooks {
+		t.Errorf("LoadPipeline error = %v, want playbooks unavailable", err)
+	}
+}
+
+func TestCopyTaskSet(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	for _, name := range []string{"q2-audit", "q3-audit"} {
+		if _, err := runner.projects.Create(name, name, "task set copy", "", "", "none", ""); err != nil {
+			t.Fatalf("Failed to create project: %v", err)
+		}
+	}
+	if _, err := runner.tasks.CreateTaskSet("q2-audit", "controls", "Controls", "", nil, false, global.Limits{}, false, "", "", nil); err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+	first, err := runner.tasks.CreateTask("q2-audit", "controls", "Access", "", "ac-1", &global.WorkExecution{Prompt: "p"}, &global.QAExecution{Enabled: true, Prompt: "qa"})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	second, err := runner.tasks.CreateTask("q2-audit", "controls", "Logging", "", "", &global.WorkExecution{Prompt: "p"}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	if _, err := runner.tasks.UpdateTask("q2-audit", second.UUID, map[string]interface{}{
+		"depends_on": []string{"ac-1"},
+		"work":       map[string]interface{}{"status": global.ExecutionStatusDone},
+	}); err != nil {
+		t.Fatalf("Failed to update task: %v", err)
+	}
+	if _, err := runner.tasks.UpdateTask("q2-audit", first.UUID, map[string]interface{}{
+		"work": map[string]interface{}{"status": global.ExecutionStatusFailed},
+		"qa":   map[string]interface{}{"status": global.ExecutionStatusDone, "verdict": global.QAVerdictFail},
+	}); err != nil {
+		t.Fatalf("Failed to update task: %v", err)
+	}
+
+	if _, err := runner.tasks.CopyTaskSet("q2-audit", "controls", "q2-audit", "controls", "", ""); err == nil {
+		t.Error("expected error copying a task set onto itself")
+	}
+	if _, err := runner.tasks.CopyTaskSet("q2-audit", "controls", "q2-audit", "controls-rerun", "", ""); err == nil {
+		t.Error("expected error for external IDs already used in the project")
+	}
+	if _, err := runner.tasks.CopyTaskSet("q2-audit", "controls", "missing", "controls", "", ""); err == nil {
+		t.Error("expected error for a missing target project")
+	}
+
+	// Within the project, with a suffix keeping external IDs unique
+	result, err := runner.tasks.CopyTaskSet("q2-audit", "controls", "q2-audit", "controls-rerun", "Controls (rerun)", "-rerun")
+	if err != nil {
+		t.Fatalf("CopyTaskSet failed: %v", err)
+	}
+	if len(result.Tasks) != 2 || result.Tasks[0].ExternalID != "ac-1-rerun" || result.Tasks[0].SourceUUID != first.UUID || result.Tasks[0].UUID == first.UUID {
+		t.Fatalf("unexpected copy result: %+v", result)
+	}
+	copied, err := runner.tasks.GetTaskSet("q2-audit", "controls-rerun")
+	if err != nil {
+		t.Fatalf("Failed to get copy: %v", err)
+	}
+	if copied.Title != "Controls (rerun)" {
+		t.Errorf("copy title = %s", copied.Title)
+	}
+	access, logging := copied.Tasks[0], copied.Tasks[1]
+	if access.Work.Status != global.ExecutionStatusWaiting || access.QA.Status != global.ExecutionStatusWaiting || access.QA.Verdict != "" || !access.QA.Enabled || access.QA.Prompt != "qa" {
+		t.Errorf("copied task not reset: work %+v, qa %+v", access.Work, access.QA)
+	}
+	if logging.Work.Status != global.ExecutionStatusWaiting || logging.QA.Status != "" {
+		t.Errorf("copied task not reset: work %+v, qa %+v", logging.Work, logging.QA)
+	}
+	if len(logging.DependsOn) != 1 || logging.DependsOn[0] != "ac-1-rerun" {
+		t.Errorf("dependency not remapped: %v", logging.DependsOn)
+	}
+	if source, _, _ := runner.tasks.GetTask("q2-audit", first.UUID); source.Work.Status != global.ExecutionStatusFailed {
+		t.Errorf("copy changed the source task status to %s", source.Work.Status)
+	}
+	if _, err := runner.tasks.CopyTaskSet("q2-audit", "controls", "q2-audit", "controls-rerun", "", "-again"); err == nil {
+		t.Error("expected error copying onto an existing task set")
+	}
+
+	// Into another project, keeping the path and external IDs
+	if _, err := runner.tasks.CopyTaskSet("q2-audit", "controls", "q3-audit", "", "", ""); err == nil {
+		t.Error("expected error for an empty target path")
+	}
+	result, err = runner.tasks.CopyTaskSet("q2-audit", "controls", "q3-audit", "controls", "", "")
+	if err != nil {
+		t.Fatalf("CopyTaskSet to another project failed: %v", err)
+	}
+	if result.Project != "q3-audit" || result.Tasks[0].ExternalID != "ac-1" {
+		t.Errorf("unexpected copy result: %+v", result)
+	}
+	if task, _, err := runner.tasks.GetTask("q3-audit", result.Tasks[1].UUID); err != nil || len(task.DependsOn) != 1 || task.DependsOn[0] != "ac-1" {
+		t.Errorf("dependency in other project = %v (err %v)", task, err)
+	}
+}
+
+func TestGenerateReportFormats(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	// A playbook manifest asking for HTML with its own theme
+	playbookDir := filepath.Join(tmpDir, "playbooks", "audit")
+	files := map[string]string{
+		"reports.json": `[{"suffix": "Report", "file": "audit/report.md", "formats": ["html"], "theme": "client.css"}]`,
+		"report.md":    "### {{._task_title}}\n",
+		"client.css":   "h1 { color: teal; }",
+	}
+	if err := os.MkdirAll(playbookDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(playbookDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := runner.projects.Create("formats", "Formats Audit", "report formats", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	templates := &global.DefaultTemplates{WorkerReportTemplate: "audit/reports.json"}
+	if _, err := runner.tasks.CreateTaskSet("formats", "controls", "Controls", "", templates, false, global.Limits{}, false, "", "", nil); err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+	if _, err := runner.tasks.CreateTask("formats", "controls", "Access", "", "", &global.WorkExecution{Prompt: "p"}, nil); err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	if _, err := runner.GenerateReport("formats", "", []string{"docx"}, false); err == nil {
+		t.Error("expected error for an unsupported format")
+	}
+
+	reports, err := runner.GenerateReport("formats", "", []string{global.ReportFormatPDF, global.ReportFormatHTML}, false)
+	if err != nil {
+		t.Fatalf("GenerateReport failed: %v", err)
+	}
+	prefix, _ := runner.projects.GetReportPrefix("formats")
+	want := []string{prefix + "Report.md", prefix + "Report.html", prefix + "Report.pdf"}
+	if strings.Join(reports, ",") != strings.Join(want, ",") {
+		t.Fatalf("reports = %v, want %v", reports, want)
+	}
+
+	reportsDir := filepath.Join(tmpDir, "projects", "formats", global.ReportsDir)
+	html, err := os.ReadFile(filepath.Join(reportsDir, prefix+"Report.html"))
+	if err != nil {
+		t.Fatalf("Failed to read HTML report: %v", err)
+	}
+	for _, s := range []string{"<h1>Formats Audit</h1>", "<h2>Controls</h2>", "color: teal"} {
+		if !strings.Contains(string(html), s) {
+			t.Errorf("HTML report missing %q:\n%s", s, html)
+		}
+	}
+	pdf, err := os.ReadFile(filepath.Join(reportsDir, prefix+"Report.pdf"))
+	if err != nil || !strings.HasPrefix(string(pdf), "%PDF-") {
+		t.Errorf("PDF report not written: %v", err)
+	}
+
+	items, err := runner.projects.ListReports("formats")
+	if err != nil || len(items) != 3 {
+		t.Errorf("ListReports() = %d reports, %v; want the report and its renderings", len(items), err)
+	}
+	if _, err := runner.projects.ReadReport("formats", prefix+"Report.pdf", 0, 0); err == nil {
+		t.Error("expected error reading a PDF report as text")
+	}
+}
+
+func TestGenerateReportAggregate(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	// The client report leaves out the internal task set, also from its summary
+	playbookDir := filepath.Join(tmpDir, "playbooks", "audit")
+	files := map[string]string{
+		"reports.json": `[{"suffix": "Report", "file": "audit/report.md", "aggregate": "summary.md", "exclude_sections": ["internal"]}]`,
+		"report.md":    "### {{._task_title}}\n",
+		"summary.md":   "## Executive Summary\n\n{{.summary.total_tasks}} controls tested, {{len .failed}} failed: {{range .failed}}{{._task_title}}{{end}}\n",
+	}
+	if err := os.MkdirAll(playbookDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(playbookDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := runner.projects.Create("rollup", "Rollup Audit", "aggregate", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	templates := &global.DefaultTemplates{WorkerReportTemplate: "audit/reports.json"}
+	for _, path := range []string{"controls", "internal"} {
+		if _, err := runner.tasks.CreateTaskSet("rollup", path, path, "", templates, false, global.Limits{}, false, "", "", nil); err != nil {
+			t.Fatalf("Failed to create task set: %v", err)
+		}
+	}
+	for _, task := range []struct{ path, title, status string }{
+		{"controls", "Access", global.ExecutionStatusDone},
+		{"controls", "Backups", global.ExecutionStatusFailed},
+		{"internal", "Staffing", global.ExecutionStatusFailed},
+	} {
+		created, err := runner.tasks.CreateTask("rollup", task.path, task.title, "", "", &global.WorkExecution{Prompt: "p"}, nil)
+		if err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+		if _, err := runner.tasks.UpdateTask("rollup", created.UUID, map[string]interface{}{"work": map[string]interface{}{"status": task.status}}); err != nil {
+			t.Fatalf("Failed to update task: %v", err)
+		}
+	}
+
+	reports, err := runner.GenerateReport("rollup", "", nil, false)
+	if err != nil || len(reports) != 1 {
+		t.Fatalf("GenerateReport() = %v, %v", reports, err)
+	}
+	item, err := runner.projects.ReadReport("rollup", reports[0], 0, 0)
+	if err != nil {
+		t.Fatalf("ReadReport failed: %v", err)
+	}
+	summary := strings.Index(item.Content, "2 controls tested, 1 failed: Backups")
+	detail := strings.Index(item.Content, "## controls")
+	if summary < 0 || detail < summary {
+		t.Errorf("aggregate summary missing or after the task detail:\n%s", item.Content)
+	}
+	if strings.Contains(item.Content, "Staffing") {
+		t.Errorf("excluded task set in report:\n%s", item.Content)
+	}
+}
+
+func TestGenerateReportIdempotent(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	if _, err := runner.projects.Create("rerun", "Rerun Audit", "idempotent reports", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	if _, err := runner.tasks.CreateTaskSet("rerun", "controls", "Controls", "", nil, false, global.Limits{}, false, "", "", nil); err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+	complete := func(title, response string) *global.Task {
+		task, err := runner.tasks.CreateTask("rerun", "controls", title, "", "", &global.WorkExecution{Prompt: "p"}, nil)
+		if err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+		writeResult(t, runner, "rerun", "controls", task, response)
+		if _, err := runner.tasks.UpdateTask("rerun", task.UUID, map[string]interface{}{"work": map[string]interface{}{"status": global.ExecutionStatusDone}}); err != nil {
+			t.Fatalf("Failed to update task: %v", err)
+		}
+		return task
+	}
+	access := complete("Access", "Access result one")
+	complete("Backups", "Backups result")
+
+	generate := func(regenerate bool) string {
+		t.Helper()
+		reports, err := runner.GenerateReport("rerun", "", nil, regenerate)
+		if err != nil || len(reports) != 1 {
+			t.Fatalf("GenerateReport() = %v, %v", reports, err)
+		}
+		item, err := runner.projects.ReadReport("rerun", reports[0], 0, 0)
+		if err != nil {
+			t.Fatalf("ReadReport failed: %v", err)
+		}
+		return item.Content
+	}
+	count := func(content string, want map[string]int) {
+		t.Helper()
+		for text, n := range want {
+			if got := strings.Count(content, text); got != n {
+				t.Errorf("%q occurs %d times, want %d:\n%s", text, got, n, content)
+			}
+		}
+	}
+
+	// A re-run leaves the report as it is, with text appended by hand in place
+	first := generate(false)
+	if err := runner.projects.AppendReport("rerun", "Reviewer note\n", "", nil); err != nil {
+		t.Fatalf("AppendReport failed: %v", err)
+	}
+	count(generate(false), map[string]int{"# Rerun Audit": 1, "## Controls": 1, "Access result one": 1, "Backups result": 1, "Reviewer note": 1})
+	if content := generate(false); !strings.HasPrefix(content, first) {
+		t.Errorf("re-run changed the generated part of the report:\n%s", content)
+	}
+
+	// A changed result replaces its section; a new task follows the last of its set
+	writeResult(t, runner, "rerun", "controls", access, "Access result two")
+	complete("Logging", "Logging result")
+	content := generate(false)
+	count(content, map[string]int{"Access result one": 0, "Access result two": 1, "Logging result": 1, "Reviewer note": 1})
+	if backups, logging, note := strings.Index(content, "Backups result"), strings.Index(content, "Logging result"), strings.Index(content, "Reviewer note"); backups > logging || logging > note {
+		t.Errorf("new task not inserted after its task set:\n%s", content)
+	}
+
+	// Regenerating rebuilds the report from scratch
+	count(generate(true), map[string]int{"# Rerun Audit": 1, "## Controls": 1, "Access result two": 1, "Logging result": 1, "Reviewer note": 0})
+}
+
+// writeResult writes a completed worker result for a task
+func writeResult(t *testing.T, runner *testRunner, project, path string, task *global.Task, response string) {
+	t.Helper()
+	data, _ := json.Marshal(global.TaskResult{
+		TaskUUID:  task.UUID,
+		TaskTitle: task.Title,
+		Worker:    global.WorkerResult{Response: response, Status: global.ExecutionStatusDone},
+	})
+	file := runner.tasks.ResultFile(project, path, task, global.ResultFileSuffix)
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		t.Fatalf("Failed to create results dir: %v", err)
+	}
+	if err := os.WriteFile(file, data, 0644); err != nil {
+		t.Fatalf("Failed to write result: %v", err)
+	}
+}
+
+func TestWorkerTools(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "worker-tools-test"
+	if _, err := runner.projects.Create(projectName, "Worker tools", "worker tools", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	if _, err := runner.projects.PutFile(projectName, "evidence/firewall.txt", "deny all inbound", ""); err != nil {
+		t.Fatalf("PutFile failed: %v", err)
+	}
+	if _, err := runner.tasks.CreateTaskSet(projectName, "main", "Main Tasks", "", nil, false, global.Limits{}, false, "", "", nil); err != nil {
+		t.Fatalf("Failed to create task set: %v", err)
+	}
+	task, err := runner.tasks.CreateTask(projectName, "main", "Firewall", "test", "", &global.WorkExecution{Prompt: "Assess the firewall"}, nil)
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	// Only a response holding nothing but a tool call is one
+	if call, ok := parseWorkerToolCall("```json\n{\"tool_call\": {\"name\": \"project_file_get\", \"arguments\": {\"path\": \"a.txt\"}}}\n```"); !ok || call.Name != global.ToolProjectFileGet || call.Arguments["path"] != "a.txt" {
+		t.Errorf("parseWorkerToolCall() = %+v, %v", call, ok)
+	}
+	for _, response := range []string{`{"finding": "ok"}`, `{"tool_call": {"name": "project_file_get"}, "finding": "ok"}`, `{"tool_call": {}}`, "no tools needed"} {
+		if _, ok := parseWorkerToolCall(response); ok {
+			t.Errorf("parseWorkerToolCall(%q) found a tool call", response)
+		}
+	}
+
+	tools := &global.WorkerTools{Tools: []string{global.ToolProjectFileGet, global.ToolProjectFileSearch}}
+	if out := runner.runWorkerTool(projectName, tools, &workerToolCall{Name: global.ToolProjectFileGet, Arguments: map[string]any{"path": "evidence/firewall.txt"}}); !strings.Contains(out, "deny all inbound") {
+		t.Errorf("project_file_get returned %q", out)
+	}
+	if out := runner.runWorkerTool(projectName, tools, &workerToolCall{Name: global.ToolProjectFileSearch, Arguments: map[string]any{"query": "inbound"}}); !strings.Contains(out, "evidence/firewall.txt") {
+		t.Errorf("project_file_search returned %q", out)
+	}
+	if out := runner.runWorkerTool(projectName, tools, &workerToolCall{Name: global.ToolListGet, Arguments: map[string]any{"list": "x"}}); !strings.HasPrefix(out, "Error:") {
+		t.Errorf("a tool the task set does not offer ran: %q", out)
+	}
+
+	// Without worker_tools a tool call response is the worker's answer
+	callPrompt := `{"tool_call": {"name": "project_file_get", "arguments": {"path": "evidence/firewall.txt"}}}`
+	if _, err := runner.dispatchWorker(projectName, "main", task, nil, "worker", &llm.DispatchRequest{LLMID: "test-llm", Prompt: callPrompt}); err != nil {
+		t.Fatalf("dispatchWorker() error = %v", err)
+	}
+	if history, ok := runner.taskHistory.Load(task.UUID); ok && len(history.([]global.Message)) > 0 {
+		t.Errorf("tool calls recorded without worker_tools: %+v", history)
+	}
+
+	// The echo LLM repeats the tool call of the first turn every time, so the
+	// loop runs to the call limit, tells the worker so, and stops
+	if err := runner.tasks.SetTaskSetWorkerTools(projectName, "main", &global.WorkerTools{MaxCalls: 2}); err != nil {
+		t.Fatalf("SetTaskSetWorkerTools failed: %v", err)
+	}
+	result, err := runner.dispatchWorker(projectName, "main", task, nil, "worker", &llm.DispatchRequest{LLMID: "test-llm", Prompt: callPrompt})
+	if err != nil || result == nil {
+		t.Fatalf("dispatchWorker() = %v, %v", result, err)
+	}
+	history, _ := runner.taskHistory.Load(task.UUID)
+	var toolMessages []global.Message
+	for _, msg := range history.([]global.Message) {
+		if msg.Role == "tool" {
+			toolMessages = append(toolMessages, msg)
+		}
+	}
+	if len(toolMessages) != 3 {
+		t.Fatalf("recorded %d tool calls, want 3: %+v", len(toolMessages), toolMessages)
+	}
+	if toolMessages[0].Tool != global.ToolProjectFileGet || toolMessages[0].ToolArgs["path"] != "evidence/firewall.txt" || !strings.Contains(toolMessages[0].Content, "deny all inbound") {
+		t.Errorf("first tool call recorded as %+v", toolMessages[0])
+	}
+	if !strings.Contains(toolMessages[2].Content, "limit reached") {
+		t.Errorf("last tool call result = %q, want the limit notice", toolMessages[2].Content)
+	}
+
+	var sb strings.Builder
+	writeWorkerToolsInstructions(&sb, tools)
+	if text := sb.String(); !strings.Contains(text, "=== PROJECT TOOLS ===") || !strings.Contains(text, "project_file_search") || strings.Contains(text, "list_get") {
+		t.Errorf("worker tools instructions = %q", text)
+	}
+}
+
+func TestMapReduce(t *testing.T) {
+	runner, tmpDir := setupTestRunner(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectName := "mapreduce-test"
+	if _, err := runner.projects.Create(projectName, "Map-Reduce", "map-reduce", "", "", "none", ""); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	document := strings.Repeat("The supplier shall deliver monthly reports.\n", 700) // ~30800 bytes, three chunks
+	if _, err := runner.projects.PutFile(projectName, "contracts/msa.txt", document, ""); err != nil {
+		t.Fatalf("PutFile failed: %v", err)
+	}
+
+	def := &global.MapReduceDefinition{File: "contracts/msa.txt", ChunkSize: 12000, Title: "MSA", MapPrompt: "List the obligations", ReducePrompt: "Merge the lists", LLMModelID: "test-llm"}
+	if _, err := runner.CreateMapReduce(projectName, "review", &global.MapReduceDefinition{File: "contracts/msa.txt", ChunkSize: 100, Title: "MSA", MapPrompt: "m", ReducePrompt: "r"}); err == nil {
+		t.Error("CreateMapReduce accepted a chunk_size below the minimum")
+	}
+	result, err := runner.CreateMapReduce(projectName, "review", def)
+	if err != nil {
+		t.Fatalf("CreateMapReduce failed: %v", err)
+	}
+	if len(result.Chunks) != 3 || len(result.MapTasks) != 3 || result.MapTasks[1].Title != "MSA (map 2 of 3)" {
+		t.Fatalf("result = %+v", result)
+	}
+	if chunk, err := runner.projects.GetFile(projectName, result.Chunks[2], 0, 0); err != nil || !strings.HasSuffix(chunk.Content, "reports.\n") {
+		t.Errorf("chunk file not saved: %v", err)
+	}
+	reduce, _, err := runner.tasks.GetTask(projectName, result.ReduceTask.UUID)
+	if err != nil || !reduce.Work.DependencyResults || len(reduce.DependsOn) != 3 || reduce.DependsOn[0] != result.MapTasks[0].UUID {
+		t.Fatalf("reduce task = %+v, %v", reduce, err)
+	}
+
+	// A map task's prompt carries its chunk as evidence
+	mapTask, _, _ := runner.tasks.GetTask(projectName, result.MapTasks[0].UUID)
+	prompt, err := runner.buildPrompt(projectName, "review", mapTask)
+	if err != nil {
+		t.Fatalf("buildPrompt failed: %v", err)
+	}
+	if !strings.Contains(prompt, "part 1 of 3 of contracts/msa.txt") || !strings.Contains(prompt, "--- "+result.Chunks[0]+" ---") {
+		t.Errorf("map prompt missing its chunk")
+	}
+
+	// The reduce prompt carries the map responses in order
+	for i, created := range result.MapTasks[:2] {
+		task, _, _ := runner.tasks.GetTask(projectName, created.UUID)
+		data, _ := json.Marshal(global.TaskResult{TaskUUID: task.UUID, Worker: global.WorkerResult{Response: fmt.Sprintf("obligations of part %d", i+1), Status: global.ExecutionStatusDone}})
+		resultPath := runner.tasks.ResultFile(projectName, "review", task, global.ResultFileSuffix)
+		if err := os.MkdirAll(filepath.Dir(resultPath), 0755); err != nil {
+			t.Fatalf("Failed to create results directory: %v", err)
+		}
+		if err := os.WriteFile(resultPath, data, 0644); err != nil {
+			t.Fatalf("Failed to write result: %v", err)
+		}
+	}
+	prompt, err = runner.buildPrompt(projectName, "review", reduce)
+	if err != nil {
+		t.Fatalf("buildPrompt failed: %v", err)
+	}
+	first, second := strings.Index(prompt, "obligations of part 1"), strings.Index(prompt, "obligations of part 2")
+	if !strings.Contains(prompt, "=== DEPENDENCY RESULTS ===") || first < 0 || second < first || !strings.Contains(prompt, "[no response recorded]") {
+		t.Errorf("reduce prompt = %q", prompt)
+	}
+}
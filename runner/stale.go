@@ -0,0 +1,98 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// hostname returns the name of this host, or "" if it cannot be determined
+func hostname() string {
+	name, _ := os.Hostname()
+	return name
+}
+
+// processAlive reports whether a process with the given ID exists on this host
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// liveElsewhere reports whether the run that started a journal belongs to
+// another process on this host that is still alive. Runs of this process are
+// tracked in activeJournals instead, so a journal with our own PID was left by
+// an earlier process that had the same ID (e.g. PID 1 in a container). Journals
+// written on another host, or without a PID, are treated as dead.
+func liveElsewhere(start global.RunJournalEntry) bool {
+	return start.PID != 0 && start.PID != os.Getpid() && start.Host == hostname() && processAlive(start.PID)
+}
+
+// projectRunningElsewhere reports whether another live process has a run in
+// progress for the project, going by the journals of its runs
+func (r *Runner) projectRunningElsewhere(project string) bool {
+	journals, err := r.projects.GetJournals(project)
+	if err != nil {
+		return false
+	}
+	for _, entries := range journals {
+		if len(entries) > 0 && entries[0].Entry == global.JournalRunStarted && liveElsewhere(entries[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+// resetStaleTasks returns the tasks of a project left in processing (work or
+// QA) to waiting, noting each in the project log. It must only be called when
+// no live run can be processing them.
+func (r *Runner) resetStaleTasks(project string) []global.StaleTask {
+	stale := []global.StaleTask{}
+	taskSetList, err := r.tasks.ListTaskSets(project, "")
+	if err != nil {
+		r.logger.Warnf("Run recovery: project %s: %v", project, err)
+		return stale
+	}
+	for _, ts := range taskSetList.TaskSets {
+		for _, task := range ts.Tasks {
+			for _, phase := range []string{"work", "qa"} {
+				status := task.Work.Status
+				if phase == "qa" {
+					status = task.QA.Status
+				}
+				if status != global.ExecutionStatusProcessing {
+					continue
+				}
+				updates := map[string]interface{}{
+					phase: map[string]interface{}{"status": global.ExecutionStatusWaiting},
+				}
+				if _, err := r.tasks.UpdateTask(project, task.UUID, updates); err != nil {
+					r.logger.Warnf("Run recovery: task %d in project %s: failed to reset stale %s status: %v", task.ID, project, phase, err)
+					continue
+				}
+				stale = append(stale, global.StaleTask{Project: project, Path: ts.Path, TaskUUID: task.UUID, TaskID: task.ID, Phase: phase})
+				r.logger.Warnf("Run recovery: task %d in project %s was stuck in %s processing with no live run, returned to waiting", task.ID, project, phase)
+				r.logToProject(project, fmt.Sprintf("Task %d: Found in %s processing after a restart with no live run, returned to waiting", task.ID, phase))
+			}
+		}
+	}
+	return stale
+}
+
+// StartupRecovery returns what the startup reconciliation of runs found and
+// recovered, or nil if it has not run
+func (r *Runner) StartupRecovery() *global.StartupRecovery {
+	r.startupMu.Lock()
+	defer r.startupMu.Unlock()
+	return r.startupRecovery
+}
@@ -0,0 +1,61 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PivotLLM/Maestro/global"
+	"github.com/PivotLLM/Maestro/llm"
+)
+
+// resultSummary makes the short summary stored in a task's result, so that
+// orchestrators can triage results without reading full responses. It follows
+// the task set's result_summary settings; an LLM summary that fails falls back
+// to truncating the text.
+func (r *Runner) resultSummary(project, path string, task *global.Task, response string) string {
+	settings := global.ResultSummary{}
+	if ts, err := r.tasks.GetTaskSet(project, path); err == nil && ts.ResultSummary != nil {
+		settings = *ts.ResultSummary
+	}
+	maxChars := settings.MaxChars
+	if maxChars <= 0 {
+		maxChars = global.DefaultResultSummaryChars
+	}
+
+	text := global.SummaryText(response, settings.Field)
+	if settings.LLMID == "" || len([]rune(text)) <= maxChars {
+		return global.TruncateSummary(text, maxChars)
+	}
+
+	llmID, ok := r.dispatchLLMID(settings.LLMID)
+	if !ok {
+		return global.TruncateSummary(text, maxChars)
+	}
+	log := r.taskLogger(project, path, task)
+	req := &llm.DispatchRequest{LLMID: llmID, Prompt: fmt.Sprintf(global.ResultSummaryPrompt, maxChars) + text}
+	result, err := r.dispatchTracked(project, path, task, "summary", req)
+	if err != nil || result == nil || !result.Success {
+		errMsg := "LLM reported an error"
+		if err != nil {
+			errMsg = err.Error()
+		}
+		log.Warnf("Task %d: Summary by %s failed, truncating instead: %s", task.ID, llmID, errMsg)
+		return global.TruncateSummary(text, maxChars)
+	}
+
+	summary := result.Text
+	if summary == "" {
+		summary = result.Stdout
+	}
+	summary = strings.Join(strings.Fields(summary), " ")
+	if summary == "" {
+		return global.TruncateSummary(text, maxChars)
+	}
+	log.Debugf("Task %d: Summarized by %s (%d characters)", task.ID, llmID, len([]rune(summary)))
+	return global.TruncateSummary(summary, maxChars)
+}
@@ -0,0 +1,103 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// LoadTaskTemplate reads and validates a task template file from a playbook
+func (r *Runner) LoadTaskTemplate(playbook, file string) (*global.TaskTemplate, error) {
+	if err := r.RequireSubsystem(global.SubsystemPlaybooks, "loading task template "+playbook+"/"+file); err != nil {
+		return nil, err
+	}
+	item, err := r.playbooks.GetFile(playbook, file, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read task template %s/%s: %w", playbook, file, err)
+	}
+	decoder := json.NewDecoder(strings.NewReader(item.Content))
+	decoder.DisallowUnknownFields()
+	var tmpl global.TaskTemplate
+	if err := decoder.Decode(&tmpl); err != nil {
+		return nil, fmt.Errorf("invalid task template %s/%s: %w", playbook, file, err)
+	}
+	if err := global.ValidateTaskTemplate(&tmpl); err != nil {
+		return nil, fmt.Errorf("invalid task template %s/%s: %w", playbook, file, err)
+	}
+	return &tmpl, nil
+}
+
+// CreateTaskFromTemplate creates a task in a project from an instantiated task
+// template, in the template's task set unless path is given and with its title
+// unless title is given. A task set that does not exist is created with the
+// template's schemas and report templates.
+func (r *Runner) CreateTaskFromTemplate(project string, tmpl *global.TaskTemplate, path, title, externalID string) (*global.Task, error) {
+	if path == "" {
+		path = tmpl.Path
+	}
+	if path == "" {
+		return nil, fmt.Errorf("path is required (the template does not name a task set)")
+	}
+	if title == "" {
+		title = tmpl.Title
+	}
+	if !r.tasks.ProjectExists(project) {
+		return nil, fmt.Errorf("project not found: %s", project)
+	}
+
+	if _, err := r.tasks.GetTaskSet(project, path); err != nil {
+		if _, err := r.tasks.CreateTaskSet(project, path, path, "", tmpl.Templates, false, global.Limits{}, false, "", "", nil); err != nil {
+			return nil, fmt.Errorf("failed to create task set: %w", err)
+		}
+		r.logger.Infof("Created task set '%s' for task template", path)
+	}
+
+	work := &global.WorkExecution{
+		InstructionsFile:       tmpl.InstructionsFile,
+		InstructionsFileSource: tmpl.InstructionsFileSource,
+		InstructionsText:       tmpl.InstructionsText,
+		Prompt:                 tmpl.Prompt,
+		LLMModelID:             tmpl.LLMModelID,
+		Status:                 global.ExecutionStatusWaiting,
+	}
+	var qa *global.QAExecution
+	if tmpl.QA != nil && tmpl.QA.Enabled {
+		qa = &global.QAExecution{
+			Enabled:                true,
+			InstructionsFile:       tmpl.QA.InstructionsFile,
+			InstructionsFileSource: tmpl.QA.InstructionsFileSource,
+			InstructionsText:       tmpl.QA.InstructionsText,
+			Prompt:                 tmpl.QA.Prompt,
+			LLMModelID:             tmpl.QA.LLMModelID,
+		}
+	}
+
+	task, err := r.tasks.CreateTask(project, path, title, tmpl.Type, externalID, work, qa)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := map[string]interface{}{}
+	if len(tmpl.Env) > 0 {
+		settings["env"] = tmpl.Env
+	}
+	if !tmpl.Limits.IsEmpty() {
+		settings["limits"] = tmpl.Limits
+	}
+	if tmpl.Priority != 0 {
+		settings["priority"] = tmpl.Priority
+	}
+	if len(settings) > 0 {
+		if task, err = r.tasks.UpdateTask(project, task.UUID, settings); err != nil {
+			return nil, fmt.Errorf("task created but its settings were not applied: %w", err)
+		}
+	}
+	return task, nil
+}
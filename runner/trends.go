@@ -0,0 +1,67 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// recordTrend appends the project metrics at the end of a run to trends.jsonl,
+// so progress across re-tests can be followed with project_trends
+func (r *Runner) recordTrend(project string, run *global.DashboardRun) {
+	if r.projects == nil {
+		return
+	}
+
+	dashboard, err := r.BuildDashboard(project)
+	if err != nil {
+		r.logger.Warnf("Failed to compute trend metrics for project %s: %v", project, err)
+		return
+	}
+	previous, err := r.projects.GetTrends(project)
+	if err != nil {
+		r.logger.Warnf("Failed to read trends for project %s: %v", project, err)
+	}
+
+	point := trendPoint(dashboard, run, previous)
+	if err := r.projects.AppendTrend(project, point); err != nil {
+		r.logger.Warnf("Failed to record trends for project %s: %v", project, err)
+	}
+}
+
+// trendPoint derives a run's trend point from the project dashboard. The run cost
+// is the growth of the recorded cost since the previous point, floored at zero
+// because results may have been reset or pruned in between.
+func trendPoint(dashboard *global.ProjectDashboard, run *global.DashboardRun, previous []global.TrendPoint) *global.TrendPoint {
+	point := &global.TrendPoint{
+		RunAt:          run.CompletedAt,
+		Path:           run.Path,
+		TasksExecuted:  run.TasksExecuted,
+		TasksSucceeded: run.TasksSucceeded,
+		TasksFailed:    run.TasksFailed,
+		TotalTasks:     dashboard.TotalTasks,
+		ByStatus:       dashboard.ByStatus,
+		BySeverity:     dashboard.BySeverity,
+		CostUSD:        dashboard.Usage.CostUSD,
+		Templates:      run.Templates,
+	}
+
+	for _, count := range dashboard.ByQAVerdict {
+		point.QAReviewed += count
+	}
+	if point.QAReviewed > 0 {
+		point.QAPassRate = float64(dashboard.ByQAVerdict[global.QAVerdictPass]) / float64(point.QAReviewed)
+	}
+
+	point.RunCostUSD = point.CostUSD
+	if len(previous) > 0 {
+		point.RunCostUSD = point.CostUSD - previous[len(previous)-1].CostUSD
+	}
+	if point.RunCostUSD < 0 {
+		point.RunCostUSD = 0
+	}
+	return point
+}
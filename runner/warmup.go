@@ -0,0 +1,51 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+	"github.com/PivotLLM/Maestro/llm"
+)
+
+// warmUpLLMs sends a priming call to each LLM of a run that has warm standby
+// enabled, so a local model is loaded before the first task rather than during
+// it. LLMs primed by the pre-flight test call are skipped, as that call already
+// loaded the model. A failed warm-up is only logged: the tasks themselves will
+// load the model, more slowly.
+func (r *Runner) warmUpLLMs(project string, llmIDs []string, primed map[string]bool) {
+	for _, llmID := range llmIDs {
+		llmConfig := r.llm.GetLLM(llmID)
+		if llmConfig == nil || llmConfig.Warmup == nil || !llmConfig.Warmup.Enabled || primed[llmID] {
+			continue
+		}
+
+		prompt := llmConfig.Warmup.Prompt
+		if prompt == "" && llmConfig.RecoveryConfig != nil {
+			prompt = llmConfig.RecoveryConfig.TestPrompt
+		}
+		if prompt == "" {
+			prompt = global.DefaultLLMTestPrompt
+		}
+
+		start := time.Now()
+		result, err := r.llm.Dispatch(&llm.DispatchRequest{LLMID: llmID, Prompt: prompt})
+		elapsed := time.Since(start).Round(time.Millisecond)
+		switch {
+		case err != nil:
+			r.logger.Warnf("Warm-up of %s failed: %v", llmID, err)
+			r.logToProject(project, fmt.Sprintf("Warm-up of %s failed: %v", llmID, err))
+		case result.ExitCode != 0:
+			r.logger.Warnf("Warm-up of %s exited with code %d", llmID, result.ExitCode)
+			r.logToProject(project, fmt.Sprintf("Warm-up of %s exited with code %d", llmID, result.ExitCode))
+		default:
+			r.logger.Infof("Warm-up: %s loaded in %s", llmID, elapsed)
+			r.logToProject(project, fmt.Sprintf("Warm-up: %s loaded in %s", llmID, elapsed))
+		}
+	}
+}
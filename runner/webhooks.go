@@ -0,0 +1,108 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// notifyWebhooks posts an event to every webhook subscribed to it. Deliveries
+// run in the background; failures are logged and not retried.
+func (r *Runner) notifyWebhooks(payload *global.WebhookPayload) {
+	webhooks := r.config.Webhooks()
+	if len(webhooks) == 0 {
+		return
+	}
+
+	payload.Timestamp = time.Now()
+	body, err := json.Marshal(payload)
+	if err != nil {
+		r.logger.Errorf("Webhook: failed to marshal %s payload for project %s: %v", payload.Event, payload.Project, err)
+		return
+	}
+
+	for _, webhook := range webhooks {
+		if !webhook.Wants(payload.Event) {
+			continue
+		}
+		r.webhookSends.Add(1)
+		go func(webhook global.Webhook) {
+			defer r.webhookSends.Done()
+			if err := sendWebhook(webhook, payload.Event, body); err != nil {
+				r.logger.Warnf("Webhook: %s for project %s to %s failed: %v", payload.Event, payload.Project, webhook.URL, err)
+				return
+			}
+			r.logger.Debugf("Webhook: delivered %s for project %s to %s", payload.Event, payload.Project, webhook.URL)
+		}(webhook)
+	}
+}
+
+// sendWebhook posts one payload, signing it when the webhook has a secret
+func sendWebhook(webhook global.Webhook, event string, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(global.WebhookEventHeader, event)
+	if webhook.Secret != "" {
+		req.Header.Set(global.WebhookSignatureHeader, "sha256="+webhookSignature(webhook.Secret, body))
+	}
+
+	client := &http.Client{Timeout: time.Duration(webhook.TimeoutSeconds) * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// webhookSignature returns the hex HMAC-SHA256 of body keyed with secret
+func webhookSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// notifyRunWebhooks sends the end-of-run events: budget_exceeded when the run
+// was halted by its call budget or cost limit, then run_completed
+func (r *Runner) notifyRunWebhooks(project, runPath string, result *global.RunResult, budget *runBudget, reports []string) {
+	links := make([]string, 0, len(reports))
+	for _, report := range reports {
+		links = append(links, path.Join(global.ReportsDir, report))
+	}
+
+	if budget.exceeded {
+		message := fmt.Sprintf("LLM call budget exceeded (%d/%d calls); remaining tasks were skipped", budget.used(), budget.maxCalls)
+		if budget.costExceeded {
+			message = fmt.Sprintf("cost limit reached: spent $%.4f of $%.4f; remaining tasks were skipped", budget.spent(), budget.maxCostUSD)
+		}
+		r.notifyWebhooks(&global.WebhookPayload{Event: global.WebhookEventBudgetExceeded, Project: project, Path: runPath, Message: message, Run: result})
+	}
+
+	r.notifyWebhooks(&global.WebhookPayload{
+		Event:   global.WebhookEventRunCompleted,
+		Project: project,
+		Path:    runPath,
+		Message: fmt.Sprintf("executed=%d, succeeded=%d, failed=%d, skipped=%d", result.TasksExecuted, result.TasksSucceeded, result.TasksFailed, result.TasksSkipped),
+		Run:     result,
+		Reports: links,
+	})
+}
@@ -0,0 +1,234 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+	"github.com/PivotLLM/Maestro/lists"
+	"github.com/PivotLLM/Maestro/llm"
+	"github.com/PivotLLM/Maestro/projects"
+	"github.com/PivotLLM/Maestro/templates"
+)
+
+// workerToolCall is a tool call requested by a worker response of the form
+// {"tool_call": {"name": "...", "arguments": {...}}}
+type workerToolCall struct {
+	Name      string         `json:"name"`
+	Arguments map[string]any `json:"arguments"`
+}
+
+// workerTools returns the worker tool settings of a task set, or nil when its
+// workers cannot call tools
+func (r *Runner) workerTools(project, path string) *global.WorkerTools {
+	taskSet, err := r.tasks.GetTaskSet(project, path)
+	if err != nil {
+		return nil
+	}
+	return taskSet.WorkerTools
+}
+
+// writeWorkerToolsInstructions tells the worker which tools it may call and how
+func writeWorkerToolsInstructions(sb *strings.Builder, tools *global.WorkerTools) {
+	if tools == nil {
+		return
+	}
+	sb.WriteString("=== PROJECT TOOLS ===\n\n")
+	sb.WriteString(fmt.Sprintf("You may call up to %d read-only tools to look up project files and lists before answering. ", tools.Limit()))
+	sb.WriteString("To call a tool, respond with ONLY a JSON object of this form and nothing else:\n")
+	sb.WriteString("{\"tool_call\": {\"name\": \"<tool>\", \"arguments\": {...}}}\n")
+	sb.WriteString("The tool result is sent back to you as the next message. Call one tool at a time. When you have what you need, respond with your final answer instead of a tool call.\n\n")
+	sb.WriteString("Available tools:\n")
+	for _, tool := range tools.Allowed() {
+		switch tool {
+		case global.ToolProjectFileList:
+			sb.WriteString("  - project_file_list: list project files. Arguments: prefix (optional path prefix)\n")
+		case global.ToolProjectFileGet:
+			sb.WriteString("  - project_file_get: read a project file. Arguments: path, byte_offset and max_bytes (optional, for large files)\n")
+		case global.ToolProjectFileSearch:
+			sb.WriteString("  - project_file_search: search project files. Arguments: query, limit and offset (optional)\n")
+		case global.ToolListGet:
+			sb.WriteString("  - list_get: read a project list with its items. Arguments: list\n")
+		}
+	}
+	sb.WriteString("\n")
+}
+
+// parseWorkerToolCall returns the tool call of a worker response that consists
+// of nothing but a tool call
+func parseWorkerToolCall(response string) (*workerToolCall, bool) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(templates.ExtractJSON(response)), &doc); err != nil || len(doc) != 1 {
+		return nil, false
+	}
+	raw, ok := doc["tool_call"]
+	if !ok {
+		return nil, false
+	}
+	var call workerToolCall
+	if err := json.Unmarshal(raw, &call); err != nil || call.Name == "" {
+		return nil, false
+	}
+	return &call, true
+}
+
+// dispatchWorker dispatches a worker prompt and runs the tool-use loop of task
+// sets with worker_tools: while the response is a tool call, the tool is run
+// against the task's project and its result sent back as the next turn of the
+// conversation. Each intermediate response and tool call is recorded in the
+// task history; the final response is returned for the caller to record.
+func (r *Runner) dispatchWorker(project, path string, task *global.Task, budget *runBudget, phase string, req *llm.DispatchRequest) (*llm.DispatchResult, error) {
+	result, err := r.dispatchTracked(project, path, task, phase, req)
+	tools := r.workerTools(project, path)
+	if tools == nil {
+		return result, err
+	}
+	log := r.taskLogger(project, path, task)
+
+	conversation := append([]global.ConversationMessage{}, req.Messages...)
+	prompt := req.Prompt
+	calls, limitSent := 0, false
+	for err == nil && result.ExitCode == 0 && !result.ProviderReportedError() {
+		response := result.Text
+		if response == "" && !result.ResponseParsed {
+			response = result.Stdout
+		}
+		call, ok := parseWorkerToolCall(response)
+		if !ok || limitSent {
+			break
+		}
+
+		// Record the tool call response as an intermediate response of the attempt
+		r.logLLMFinish(log, task.ID, req.LLMID, result, "")
+		r.recordSpend(project, task.ID, budget, req.LLMID, result)
+		r.recordHistoryResponse(task.UUID, "worker", req.Options, result, req.LLMID, task.Work.Invocations)
+
+		var output string
+		if calls >= tools.Limit() {
+			output = fmt.Sprintf("Tool call limit reached (%d calls). No more tool calls are allowed: respond now with your final answer.", tools.Limit())
+			limitSent = true
+		} else {
+			calls++
+			output = r.runWorkerTool(project, tools, call)
+		}
+		r.recordToolCall(task.UUID, call, output, req.LLMID, task.Work.Invocations)
+		r.logToProject(project, fmt.Sprintf("Task %d: Worker tool call %d/%d: %s (%d bytes returned)", task.ID, calls, tools.Limit(), call.Name, len(output)))
+
+		// Tool turns are capped by max_calls rather than the run's call budget,
+		// but stop once the run is halted (e.g. by its cost limit)
+		if budget != nil && budget.exceeded {
+			log.Warnf("Task %d: Run halted during worker tool calls", task.ID)
+			return result, fmt.Errorf("run halted during worker tool calls")
+		}
+
+		// Continue the conversation with the tool result, in the provider
+		// session when the LLM can resume it
+		conversation = append(conversation,
+			global.ConversationMessage{Role: global.MessageRoleUser, Content: prompt},
+			global.ConversationMessage{Role: global.MessageRoleAssistant, Content: response},
+		)
+		next := *req
+		next.Prompt, next.Messages, next.SessionID = output, conversation, ""
+		if llmConfig := r.llm.GetLLM(req.LLMID); result.SessionID != "" && llmConfig != nil && len(llmConfig.ResumeArgs) > 0 {
+			next.Messages, next.SessionID = nil, result.SessionID
+		}
+		prompt = output
+
+		r.logLLMDispatch(log, task.ID, project, path, req.LLMID, len(output))
+		r.emitEvent(project, path, task, &global.TaskEvent{Event: global.EventLLMDispatched, Phase: "worker", LLMModelID: req.LLMID, Detail: "tool_result"})
+		result, err = r.dispatchTracked(project, path, task, phase, &next)
+	}
+	return result, err
+}
+
+// runWorkerTool runs a worker's tool call against its task's project and
+// returns the result to send back: JSON, or an error message the worker can act on
+func (r *Runner) runWorkerTool(project string, tools *global.WorkerTools, call *workerToolCall) string {
+	if !slices.Contains(tools.Allowed(), call.Name) {
+		return fmt.Sprintf("Error: %q is not an available tool (available: %s)", call.Name, strings.Join(tools.Allowed(), ", "))
+	}
+
+	var value any
+	var err error
+	switch call.Name {
+	case global.ToolProjectFileList:
+		value, err = r.projects.ListFiles(project, toolArgString(call.Arguments, "prefix"))
+	case global.ToolProjectFileGet:
+		filePath := toolArgString(call.Arguments, "path")
+		if filePath == "" {
+			return "Error: path is required"
+		}
+		value, err = r.projects.GetFile(project, filePath, int64(toolArgInt(call.Arguments, "byte_offset")), int64(toolArgInt(call.Arguments, "max_bytes")))
+	case global.ToolProjectFileSearch:
+		query := toolArgString(call.Arguments, "query")
+		if query == "" {
+			return "Error: query is required"
+		}
+		var files []projects.FileItem
+		var total int
+		files, total, err = r.projects.SearchFiles(project, query, toolArgInt(call.Arguments, "limit"), toolArgInt(call.Arguments, "offset"))
+		value = map[string]any{"files": files, "total": total}
+	case global.ToolListGet:
+		name := toolArgString(call.Arguments, "list")
+		if name == "" {
+			return "Error: list is required"
+		}
+		value, err = r.lists.Get(lists.SourceProject, project, "", name)
+	}
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+	if len(data) > global.MaxWorkerToolOutputBytes {
+		return string(data[:global.MaxWorkerToolOutputBytes]) + fmt.Sprintf("\n[truncated at %d bytes; request less, e.g. with byte_offset and max_bytes]", global.MaxWorkerToolOutputBytes)
+	}
+	return string(data)
+}
+
+// recordToolCall records a worker tool call and the result sent back in task history
+func (r *Runner) recordToolCall(taskUUID string, call *workerToolCall, output, llmID string, invocation int) {
+	msg := global.Message{
+		Timestamp:  time.Now(),
+		Role:       "tool",
+		Invocation: invocation,
+		LLMModelID: llmID,
+		Tool:       call.Name,
+		ToolArgs:   call.Arguments,
+		Content:    output,
+	}
+	existing, _ := r.taskHistory.LoadOrStore(taskUUID, []global.Message{})
+	history := existing.([]global.Message)
+	history = append(history, msg)
+	r.taskHistory.Store(taskUUID, history)
+}
+
+// toolArgString returns a string argument of a tool call
+func toolArgString(args map[string]any, name string) string {
+	s, _ := args[name].(string)
+	return s
+}
+
+// toolArgInt returns a numeric argument of a tool call, 0 when missing
+func toolArgInt(args map[string]any, name string) int {
+	switch v := args[name].(type) {
+	case float64:
+		return int(v)
+	case string:
+		var n int
+		fmt.Sscanf(v, "%d", &n)
+		return n
+	}
+	return 0
+}
@@ -63,7 +63,7 @@ func New(cfg *config.Config, logger *logging.Logger) (*Server, error) {
 		reference.WithExternalDirs(externalDirs),
 		reference.WithLogger(logger),
 	)
-	playbooksService := playbooks.NewService(cfg.PlaybooksDir(), logger)
+	playbooksService := playbooks.NewService(cfg.PlaybooksDir(), cfg.ExportsDir(), cfg.PlaybookSnapshots(), logger)
 	projectsService := projects.NewService(cfg, logger)
 	tasksService := tasks.NewService(cfg, projectsService, logger)
 	listsService := lists.NewService(
@@ -149,6 +149,7 @@ func (s *Server) registerTools() error {
 		Host: maestro.HostDeps{
 			Logger: s.logger,
 			Runner: s.runner,
+			LLM:    s.llm,
 		},
 	}
 	tools := provider.RegisterTools(deps)
@@ -235,6 +236,20 @@ func (s *Server) registerTools() error {
 
 // Run starts the MCP server with graceful shutdown
 func (s *Server) Run() error {
+	// Optional LLM validation on startup. Strict mode waits for it and refuses to
+	// start when the default LLM fails; otherwise it runs alongside the server.
+	if s.config.ValidateLLMsOnStartup() {
+		if s.config.ValidateLLMsStrict() {
+			s.logger.Info("Validating enabled LLMs before starting (strict)")
+			if err := s.llm.ValidateLLMs().DefaultLLMError(s.config.DefaultLLM()); err != nil {
+				return fmt.Errorf("LLM validation failed: %w", err)
+			}
+		} else {
+			s.logger.Info("Validating enabled LLMs in the background")
+			go s.llm.ValidateLLMs()
+		}
+	}
+
 	// Set up signal handling for graceful shutdown
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
@@ -249,6 +264,17 @@ func (s *Server) Run() error {
 
 	s.logger.Infof("MCP server started successfully")
 
+	// Report or resume runs interrupted by a crash or restart
+	s.runner.RecoverInterruptedRuns()
+
+	// Background cleanup of orphaned result files (no-op unless configured)
+	stopMaintenance := s.runner.StartMaintenance()
+	defer stopMaintenance()
+
+	// Background availability probes of enabled LLMs (no-op unless configured)
+	stopProber := s.llm.StartProber()
+	defer stopProber()
+
 	// Wait for shutdown signal, stdin close, or error
 	select {
 	case <-sigChan:
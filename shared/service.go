@@ -0,0 +1,380 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+// Package shared provides the shared evidence library: documents reused across
+// projects (standards texts, prior-year reports) that are imported once and
+// read by any project. Projects cannot write to the library; it is managed
+// through its own tools.
+package shared
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+	"github.com/PivotLLM/Maestro/logging"
+)
+
+// Service provides shared evidence library operations.
+type Service struct {
+	baseDir string
+	policy  global.ImportPolicy
+	logger  *logging.Logger
+	mu      sync.Mutex // serializes imports and deletions
+}
+
+// FileItem represents a file in the shared evidence library.
+type FileItem struct {
+	Path       string    `json:"path"`
+	SizeBytes  int64     `json:"size_bytes"`
+	ModifiedAt time.Time `json:"modified_at"`
+	Summary    string    `json:"summary,omitempty"`
+	Content    string    `json:"content,omitempty"`
+	// Byte range fields (only set when offset/max_bytes used)
+	Offset     int64 `json:"offset,omitempty"`
+	TotalBytes int64 `json:"total_bytes,omitempty"`
+}
+
+// ImportResult contains information about an import operation.
+type ImportResult struct {
+	Source        string `json:"source"`
+	Recursive     bool   `json:"recursive"`
+	FilesImported int    `json:"files_imported"`
+	LinksSkipped  int    `json:"links_skipped,omitempty"` // Symlinks are never imported into the library
+	ImportedTo    string `json:"imported_to"`
+
+	Rejected []global.ImportRejection `json:"rejected,omitempty"` // Files left out under the import policy
+}
+
+// NewService creates a new shared evidence library service whose imports
+// follow policy.
+func NewService(baseDir string, policy global.ImportPolicy, logger *logging.Logger) *Service {
+	return &Service{
+		baseDir: baseDir,
+		policy:  policy,
+		logger:  logger,
+	}
+}
+
+// Dir returns the library directory.
+func (s *Service) Dir() string {
+	return s.baseDir
+}
+
+// AbsPath validates a library path, preventing traversal, and returns its
+// location on disk.
+func (s *Service) AbsPath(path string) (string, error) {
+	if path == "" {
+		return "", fmt.Errorf("path cannot be empty")
+	}
+	absPath, err := global.ValidatePathWithinDir(s.baseDir, path)
+	if err != nil {
+		return "", err
+	}
+	if root, _ := filepath.Abs(s.baseDir); absPath == root {
+		return "", fmt.Errorf("path refers to the shared library root: %s", path)
+	}
+	return absPath, nil
+}
+
+// walk calls fn for every library file (metadata files excluded) with its
+// library path.
+func (s *Service) walk(fn func(relPath, absPath string, info fs.FileInfo)) error {
+	return filepath.WalkDir(s.baseDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // Skip entries we can't read
+		}
+		if !d.Type().IsRegular() || strings.HasSuffix(path, global.MetaSuffix) {
+			return nil
+		}
+		relPath, err := filepath.Rel(s.baseDir, path)
+		if err != nil {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		fn(filepath.ToSlash(relPath), path, info)
+		return nil
+	})
+}
+
+// newItem builds the listing entry of a library file
+func newItem(relPath, absPath string, info fs.FileInfo) FileItem {
+	item := FileItem{
+		Path:       relPath,
+		SizeBytes:  info.Size(),
+		ModifiedAt: info.ModTime(),
+	}
+	if meta, err := global.LoadFileMetadata(absPath); err == nil && meta != nil {
+		item.Summary = meta.Summary
+	}
+	return item
+}
+
+// List lists library files, optionally filtered by prefix.
+func (s *Service) List(prefix string) ([]FileItem, error) {
+	items := []FileItem{}
+	err := s.walk(func(relPath, absPath string, info fs.FileInfo) {
+		if prefix != "" && !strings.HasPrefix(relPath, prefix) {
+			return
+		}
+		items = append(items, newItem(relPath, absPath, info))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shared files: %w", err)
+	}
+
+	s.logger.Debugf("Listed %d shared files", len(items))
+	return items, nil
+}
+
+// Get retrieves a library file with optional byte range.
+// If offset is 0 and maxBytes is 0, returns the entire file.
+// If maxBytes > 0, returns at most maxBytes starting from offset.
+func (s *Service) Get(path string, offset, maxBytes int64) (*FileItem, error) {
+	absPath, err := s.AbsPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(absPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("shared file not found: %s", path)
+		}
+		return nil, fmt.Errorf("failed to stat shared file: %w", err)
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("path is a directory, not a file: %s", path)
+	}
+
+	if err := global.IsValidUTF8File(absPath); err != nil {
+		return nil, fmt.Errorf("binary_or_invalid_utf8: %w", err)
+	}
+
+	content, err := os.ReadFile(absPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read shared file: %w", err)
+	}
+
+	item := newItem(filepath.ToSlash(filepath.Clean(path)), absPath, info)
+	item.TotalBytes = int64(len(content))
+	if maxBytes > 0 {
+		if offset < 0 {
+			offset = 0
+		}
+		if offset < int64(len(content)) {
+			end := offset + maxBytes
+			if end > int64(len(content)) {
+				end = int64(len(content))
+			}
+			item.Content = string(content[offset:end])
+		}
+		item.Offset = offset
+	} else {
+		item.Content = string(content)
+	}
+	item.SizeBytes = int64(len(item.Content))
+
+	s.logger.Debugf("Retrieved shared file: %s (offset=%d, bytes=%d, total=%d)", path, item.Offset, len(item.Content), item.TotalBytes)
+	return &item, nil
+}
+
+// Search searches library files for a query in their path or content.
+func (s *Service) Search(query string, limit, offset int) ([]FileItem, int, error) {
+	if query == "" {
+		return nil, 0, fmt.Errorf("search query cannot be empty")
+	}
+	if limit <= 0 {
+		limit = global.DefaultLimit
+	}
+
+	var allMatches []FileItem
+	lowerQuery := strings.ToLower(query)
+	err := s.walk(func(relPath, absPath string, info fs.FileInfo) {
+		if !strings.Contains(strings.ToLower(relPath), lowerQuery) {
+			if global.IsValidUTF8File(absPath) != nil {
+				return
+			}
+			content, err := os.ReadFile(absPath)
+			if err != nil || !strings.Contains(strings.ToLower(string(content)), lowerQuery) {
+				return
+			}
+		}
+		allMatches = append(allMatches, newItem(relPath, absPath, info))
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search shared files: %w", err)
+	}
+
+	total := len(allMatches)
+	if offset >= total {
+		return []FileItem{}, total, nil
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+
+	s.logger.Debugf("Shared search '%s' found %d total matches, returning %d", query, total, end-offset)
+	return allMatches[offset:end], total, nil
+}
+
+// Import copies an external file or directory into the library. The source
+// can be anywhere on the filesystem; it lands under dest (default: its own
+// name). Directories require recursive and keep their structure. Symlinks are
+// skipped so the library never points outside itself. Files the import policy
+// rejects are left out of directories and listed in the result; a rejected
+// single file, or files larger in total than the policy allows, fail the import.
+func (s *Service) Import(source, dest string, recursive bool) (*ImportResult, error) {
+	sourceInfo, err := os.Lstat(source)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("source path not found: %s", source)
+		}
+		return nil, fmt.Errorf("failed to access source: %w", err)
+	}
+	if sourceInfo.Mode()&os.ModeSymlink != 0 {
+		return nil, fmt.Errorf("source is a symlink: %s", source)
+	}
+	if sourceInfo.IsDir() && !recursive {
+		return nil, fmt.Errorf("source is a directory but recursive is false")
+	}
+
+	if dest == "" {
+		dest = filepath.Base(source)
+	}
+	target, err := s.AbsPath(dest)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{
+		Source:     source,
+		Recursive:  recursive,
+		ImportedTo: filepath.ToSlash(filepath.Clean(dest)),
+	}
+
+	var files []string
+	if !sourceInfo.IsDir() {
+		if err := s.policy.CheckFile(filepath.Base(source), sourceInfo.Size(), global.FileHead(source)); err != nil {
+			return nil, err
+		}
+		files = []string{source}
+	} else {
+		var total int64
+		err := filepath.WalkDir(source, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil // Skip entries we can't read
+			}
+			if d.Type()&os.ModeSymlink != 0 {
+				result.LinksSkipped++
+				return nil
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			relPath, err := filepath.Rel(source, path)
+			if err != nil {
+				return nil
+			}
+			if err := s.policy.CheckFile(filepath.ToSlash(relPath), info.Size(), global.FileHead(path)); err != nil {
+				result.Rejected = append(result.Rejected, global.ImportRejection{Path: filepath.ToSlash(relPath), Reason: err.Error()})
+				return nil
+			}
+			files = append(files, path)
+			total += info.Size()
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to walk source directory: %w", err)
+		}
+		if err := s.policy.CheckTotal(len(files), total); err != nil {
+			return nil, err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !sourceInfo.IsDir() {
+		if err := copyFile(source, target); err != nil {
+			return nil, fmt.Errorf("failed to copy file: %w", err)
+		}
+		result.FilesImported = 1
+	} else {
+		for _, path := range files {
+			relPath, err := filepath.Rel(source, path)
+			if err != nil {
+				continue
+			}
+			if err := copyFile(path, filepath.Join(target, relPath)); err != nil {
+				s.logger.Warnf("Failed to import %s into the shared library: %v", path, err)
+				continue
+			}
+			result.FilesImported++
+		}
+	}
+
+	if len(result.Rejected) > 0 {
+		s.logger.Warnf("Import into the shared library left out %d file(s) under the import policy", len(result.Rejected))
+	}
+	s.logger.Infof("Imported %d files into the shared library at '%s' (%d symlinks skipped)", result.FilesImported, result.ImportedTo, result.LinksSkipped)
+	return result, nil
+}
+
+// Delete deletes a file or directory from the library.
+func (s *Service) Delete(path string) error {
+	absPath, err := s.AbsPath(path)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := os.Stat(absPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("shared file not found: %s", path)
+		}
+		return fmt.Errorf("failed to stat shared file: %w", err)
+	}
+	if info.IsDir() {
+		err = os.RemoveAll(absPath)
+	} else {
+		err = os.Remove(absPath)
+		_ = global.DeleteFileMetadata(absPath)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete shared file: %w", err)
+	}
+
+	s.logger.Infof("Deleted from the shared library: %s", path)
+	return nil
+}
+
+// copyFile copies a regular file, replacing any existing file at dst
+func copyFile(src, dst string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	if err := global.EnsureDir(filepath.Dir(dst)); err != nil {
+		return err
+	}
+	return global.AtomicWrite(dst, data)
+}
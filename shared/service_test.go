@@ -0,0 +1,142 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package shared
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/PivotLLM/Maestro/global"
+	"github.com/PivotLLM/Maestro/logging"
+)
+
+func createTestLogger(t *testing.T) *logging.Logger {
+	t.Helper()
+	logger, err := logging.New(filepath.Join(t.TempDir(), "test.log"))
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	t.Cleanup(func() { _ = logger.Close() })
+	return logger
+}
+
+func TestSharedLibrary(t *testing.T) {
+	svc := NewService(t.TempDir(), global.ImportPolicy{}.WithDefaults(), createTestLogger(t))
+
+	// Import a directory of standards texts, skipping symlinks
+	src := filepath.Join(t.TempDir(), "standards")
+	if err := os.MkdirAll(filepath.Join(src, "iso"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "iso", "27001.md"), []byte("Annex A controls: access control policy."), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "nist.md"), []byte("Identify, Protect, Detect."), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("/etc/passwd", filepath.Join(src, "escape.md")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := svc.Import(src, "", false); err == nil {
+		t.Error("expected error importing a directory without recursive")
+	}
+	result, err := svc.Import(src, "", true)
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if result.FilesImported != 2 || result.LinksSkipped != 1 || result.ImportedTo != "standards" {
+		t.Errorf("import result = %+v", result)
+	}
+
+	// A single file can be imported under a chosen path
+	report := filepath.Join(t.TempDir(), "report.md")
+	if err := os.WriteFile(report, []byte("Prior-year findings: access control gaps."), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Import(report, "prior/2025-report.md", false); err != nil {
+		t.Fatalf("Import file failed: %v", err)
+	}
+
+	items, err := svc.List("")
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(items) != 3 {
+		t.Errorf("List returned %d items, want 3: %+v", len(items), items)
+	}
+	if items, _ := svc.List("standards/"); len(items) != 2 {
+		t.Errorf("List with prefix returned %d items, want 2", len(items))
+	}
+
+	item, err := svc.Get("standards/iso/27001.md", 8, 8)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if item.Content != "controls" || item.TotalBytes != 40 {
+		t.Errorf("Get range = %q (total %d)", item.Content, item.TotalBytes)
+	}
+	if _, err := svc.Get("../outside.md", 0, 0); err == nil {
+		t.Error("expected error for path traversal")
+	}
+
+	matches, total, err := svc.Search("ACCESS CONTROL", 10, 0)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if total != 2 || len(matches) != 2 {
+		t.Errorf("Search found %d (%d returned), want 2", total, len(matches))
+	}
+
+	if err := svc.Delete("standards"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := svc.Delete("."); err == nil || !strings.Contains(err.Error(), "root") {
+		t.Errorf("expected error deleting the library root, got %v", err)
+	}
+	if items, _ := svc.List(""); len(items) != 1 || items[0].Path != "prior/2025-report.md" {
+		t.Errorf("List after delete = %+v", items)
+	}
+}
+
+func TestSharedImportPolicy(t *testing.T) {
+	svc := NewService(t.TempDir(), global.ImportPolicy{MaxFileSizeMB: -1, MaxTotalSizeMB: 1, BlockedExtensions: []string{".vmdk"}}, createTestLogger(t))
+
+	src := filepath.Join(t.TempDir(), "evidence")
+	if err := os.MkdirAll(src, 0755); err != nil {
+		t.Fatal(err)
+	}
+	large := make([]byte, 600*1024)
+	for _, name := range []string{"a.log", "b.log"} {
+		if err := os.WriteFile(filepath.Join(src, name), large, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(src, "server.vmdk"), []byte("disk"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Over the total limit, nothing is imported
+	if _, err := svc.Import(src, "", true); err == nil || !strings.Contains(err.Error(), "import limit of 1 MB per call") {
+		t.Fatalf("expected total size error, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(svc.Dir(), "evidence")); !os.IsNotExist(err) {
+		t.Errorf("files were written by a rejected import (stat error %v)", err)
+	}
+
+	if err := os.Remove(filepath.Join(src, "b.log")); err != nil {
+		t.Fatal(err)
+	}
+	result, err := svc.Import(src, "", true)
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if result.FilesImported != 1 || len(result.Rejected) != 1 || result.Rejected[0].Path != "server.vmdk" {
+		t.Errorf("import result = %+v", result)
+	}
+}
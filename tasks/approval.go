@@ -0,0 +1,95 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package tasks
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// SetTaskSetApproval sets which QA results of a task set need human approval;
+// nil removes the gate
+func (s *Service) SetTaskSetApproval(project, path string, gate *global.ApprovalGate) error {
+	if err := global.ValidateApprovalGate(gate); err != nil {
+		return err
+	}
+	if gate != nil {
+		gate.Severity = global.NormalizeQASeverity(gate.Severity)
+		if !gate.Escalate && gate.Severity == "" {
+			gate = nil
+		}
+	}
+	return s.withLock(project, path, func() error {
+		ts, err := s.loadTaskSet(project, path)
+		if err != nil {
+			return err
+		}
+		ts.Approval = gate
+		ts.UpdatedAt = time.Now()
+		return s.saveTaskSet(project, path, ts)
+	})
+}
+
+// DecideApproval records a human decision on a task awaiting approval: an
+// approved task is done, a rejected one failed. The status is checked and
+// changed under the task set lock so a task is decided only once. Returns the
+// updated task and the path of its task set.
+func (s *Service) DecideApproval(project, taskRef string, approve bool, note string) (*global.Task, string, error) {
+	if !s.projects.ProjectExists(project) {
+		return nil, "", fmt.Errorf("project not found: %s", project)
+	}
+	found, path, err := s.locateTask(project, taskRef)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var decided *global.Task
+	err = s.withLock(project, path, func() error {
+		taskSet, err := s.loadTaskSet(project, path)
+		if err != nil {
+			return err
+		}
+		idx, task := findTaskByUUID(taskSet.Tasks, found.UUID)
+		if task == nil {
+			return fmt.Errorf("task not found: %s", taskRef)
+		}
+		if task.Work.Status != global.ExecutionStatusAwaitingApproval {
+			return fmt.Errorf("task %d is not awaiting approval (status: %s)", task.ID, task.Work.Status)
+		}
+
+		if approve {
+			task.Work.Status = global.ExecutionStatusDone
+		} else {
+			task.Work.Status = global.ExecutionStatusFailed
+			task.Work.ErrorCode = global.ErrorCodeApprovalRejected
+			task.Work.Error = "rejected by approver"
+			if note != "" {
+				task.Work.Error += ": " + note
+			}
+		}
+
+		task.UpdatedAt = time.Now()
+		taskSet.Tasks[idx] = *task
+		taskSet.UpdatedAt = time.Now()
+		if err := s.saveTaskSet(project, path, taskSet); err != nil {
+			return err
+		}
+		decided = task
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	decision := global.ApprovalDecisionApproved
+	if !approve {
+		decision = global.ApprovalDecisionRejected
+	}
+	s.logger.Infof("Task %s: project=%s uuid=%s", decision, project, decided.UUID)
+	return decided, path, nil
+}
@@ -0,0 +1,566 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package tasks
+
+import (
+	"fmt"
+	pathpkg "path"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+	"github.com/google/uuid"
+)
+
+// bulkStatusTargets are the work statuses a bulk transition may set.
+// "processing" is owned by the runner and cannot be set by hand.
+var bulkStatusTargets = []string{
+	global.ExecutionStatusWaiting,
+	global.ExecutionStatusRetry,
+	global.ExecutionStatusFailed,
+	global.ExecutionStatusError,
+	global.ExecutionStatusDone,
+	global.ExecutionStatusOnHold,
+}
+
+// BulkUpdateStatus sets the work status of every task matching the filters
+// (task set path prefix, exact task type, current work status; empty matches all).
+// Matching tasks are skipped rather than changed when the transition is unsafe:
+// tasks being processed (unless fromStatus is "processing", to recover tasks left
+// behind by an interrupted run), tasks already in toStatus, and tasks that would
+// become "done" without a result file. With dryRun the summary lists what would
+// change without saving anything. holdReason is recorded on tasks put on hold.
+func (s *Service) BulkUpdateStatus(project, pathPrefix, taskType, fromStatus, toStatus, holdReason string, dryRun bool) (*global.BulkStatusSummary, error) {
+	if !isBulkStatusTarget(toStatus) {
+		return nil, fmt.Errorf("invalid status '%s': must be one of %v", toStatus, bulkStatusTargets)
+	}
+	if holdReason != "" && toStatus != global.ExecutionStatusOnHold {
+		return nil, fmt.Errorf("hold_reason can only be set with status %s", global.ExecutionStatusOnHold)
+	}
+
+	taskSetList, err := s.ListTaskSets(project, pathPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &global.BulkStatusSummary{
+		Project:  project,
+		ToStatus: toStatus,
+		DryRun:   dryRun,
+		Updated:  []global.BulkStatusChange{},
+	}
+
+	for _, listed := range taskSetList.TaskSets {
+		path := listed.Path
+		err := s.withLock(project, path, func() error {
+			taskSet, err := s.loadTaskSet(project, path)
+			if err != nil {
+				return err
+			}
+
+			now := time.Now()
+			changed := false
+			for i := range taskSet.Tasks {
+				task := &taskSet.Tasks[i]
+				if taskType != "" && task.Type != taskType {
+					continue
+				}
+				if fromStatus != "" && task.Work.Status != fromStatus {
+					continue
+				}
+				summary.Matched++
+
+				change := global.BulkStatusChange{
+					UUID:       task.UUID,
+					ExternalID: task.ExternalID,
+					Path:       path,
+					ID:         task.ID,
+					FromStatus: task.Work.Status,
+				}
+				if change.Reason = s.bulkSkipReason(project, path, task, fromStatus, toStatus); change.Reason != "" {
+					summary.Skipped = append(summary.Skipped, change)
+					continue
+				}
+				summary.Updated = append(summary.Updated, change)
+				if dryRun {
+					continue
+				}
+
+				task.Work.Status = toStatus
+				task.Work.HoldReason = holdReason
+				task.UpdatedAt = now
+				changed = true
+			}
+
+			if !changed {
+				return nil
+			}
+			taskSet.UpdatedAt = now
+			return s.saveTaskSet(project, path, taskSet)
+		})
+		if err != nil {
+			return summary, fmt.Errorf("task set %s: %w", path, err)
+		}
+	}
+
+	if len(summary.Updated) > 0 && !dryRun {
+		msg := fmt.Sprintf("Bulk status update: %d task(s) set to %s", len(summary.Updated), toStatus)
+		if pathPrefix != "" {
+			msg += fmt.Sprintf(" (path=%s)", pathPrefix)
+		}
+		if err := s.AppendLog(project, msg); err != nil {
+			s.logger.Warnf("Failed to log bulk status update for %s: %v", project, err)
+		}
+		s.logger.Infof("Project %s: %s", project, msg)
+	}
+
+	return summary, nil
+}
+
+// BulkTaskFilter selects the tasks of a bulk update. Empty fields match all.
+// Path is a task set path prefix, or a pattern when it contains '*' (as in
+// path.Match, so "analysis/*" matches the task sets directly under analysis).
+type BulkTaskFilter struct {
+	Path      string
+	Type      string
+	Status    string // Current work status
+	QAVerdict string // QA verdict, e.g. "escalate"
+}
+
+// UpdateTasks applies the same updates to every task matching the filter. The
+// updates take the keys of UpdateTask except title, depends_on and env, which
+// differ from task to task. limits is merged into each task's own limits:
+// negative fields keep the task's value and 0 removes an override. Tasks being
+// processed are skipped, as are tasks that would become "done" without a result
+// file and tasks an update cannot apply to. With dryRun the summary lists what
+// would change without saving anything.
+func (s *Service) UpdateTasks(project string, filter BulkTaskFilter, updates map[string]interface{}, limits *global.TaskLimits, dryRun bool) (*global.BulkUpdateSummary, error) {
+	for _, key := range []string{"title", "depends_on", "env"} {
+		if _, ok := updates[key]; ok {
+			return nil, fmt.Errorf("%s cannot be updated in bulk", key)
+		}
+	}
+	toStatus := ""
+	if work, ok := updates["work"].(map[string]interface{}); ok {
+		toStatus, _ = work["status"].(string)
+	}
+	if toStatus != "" && !isBulkStatusTarget(toStatus) {
+		return nil, fmt.Errorf("invalid status '%s': must be one of %v", toStatus, bulkStatusTargets)
+	}
+	if priority, ok := updates["priority"].(int); ok {
+		if err := global.ValidateTaskPriority(priority); err != nil {
+			return nil, err
+		}
+	}
+	fields := bulkUpdateFields(updates, limits)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no fields to update")
+	}
+
+	prefix := filter.Path
+	if strings.Contains(prefix, "*") {
+		if _, err := pathpkg.Match(prefix, ""); err != nil {
+			return nil, fmt.Errorf("invalid path pattern: %w", err)
+		}
+		prefix = ""
+	}
+	taskSetList, err := s.ListTaskSets(project, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &global.BulkUpdateSummary{
+		Project: project,
+		Fields:  fields,
+		DryRun:  dryRun,
+		Updated: []global.BulkStatusChange{},
+	}
+
+	for _, listed := range taskSetList.TaskSets {
+		path := listed.Path
+		if prefix != filter.Path {
+			if matched, _ := pathpkg.Match(filter.Path, path); !matched {
+				continue
+			}
+		}
+		err := s.withLock(project, path, func() error {
+			taskSet, err := s.loadTaskSet(project, path)
+			if err != nil {
+				return err
+			}
+
+			now := time.Now()
+			changed := false
+			for i := range taskSet.Tasks {
+				task := &taskSet.Tasks[i]
+				if (filter.Type != "" && task.Type != filter.Type) ||
+					(filter.Status != "" && task.Work.Status != filter.Status) ||
+					(filter.QAVerdict != "" && task.QA.Verdict != filter.QAVerdict) {
+					continue
+				}
+				summary.Matched++
+
+				change := global.BulkStatusChange{
+					UUID:       task.UUID,
+					ExternalID: task.ExternalID,
+					Path:       path,
+					ID:         task.ID,
+					FromStatus: task.Work.Status,
+				}
+				updated, reason := s.bulkUpdateTask(project, path, task, updates, limits, toStatus)
+				if change.Reason = reason; reason != "" {
+					summary.Skipped = append(summary.Skipped, change)
+					continue
+				}
+				summary.Updated = append(summary.Updated, change)
+				if dryRun {
+					continue
+				}
+
+				updated.UpdatedAt = now
+				*task = *updated
+				changed = true
+			}
+
+			if !changed {
+				return nil
+			}
+			taskSet.UpdatedAt = now
+			return s.saveTaskSet(project, path, taskSet)
+		})
+		if err != nil {
+			return summary, fmt.Errorf("task set %s: %w", path, err)
+		}
+	}
+
+	if len(summary.Updated) > 0 && !dryRun {
+		msg := fmt.Sprintf("Bulk task update: %d task(s) updated (%s)", len(summary.Updated), strings.Join(fields, ", "))
+		if filter.Path != "" {
+			msg += fmt.Sprintf(" (path=%s)", filter.Path)
+		}
+		if err := s.AppendLog(project, msg); err != nil {
+			s.logger.Warnf("Failed to log bulk task update for %s: %v", project, err)
+		}
+		s.logger.Infof("Project %s: %s", project, msg)
+	}
+
+	return summary, nil
+}
+
+// bulkUpdateTask returns a copy of a task with the updates of a bulk update
+// applied, or why the task must be skipped
+func (s *Service) bulkUpdateTask(project, path string, task *global.Task, updates map[string]interface{}, limits *global.TaskLimits, toStatus string) (*global.Task, string) {
+	switch {
+	case task.Work.Status == global.ExecutionStatusProcessing:
+		return nil, "task is processing"
+	case toStatus == global.ExecutionStatusDone && task.Work.Status != toStatus && !global.FileExists(s.ResultFile(project, path, task, global.ResultFileSuffix)):
+		return nil, "no result file"
+	}
+
+	taskUpdates := updates
+	if limits != nil {
+		merged := &global.TaskLimits{}
+		if task.Limits != nil {
+			*merged = *task.Limits
+		}
+		for _, l := range []struct {
+			value  int
+			target *int
+		}{
+			{limits.MaxWorker, &merged.MaxWorker},
+			{limits.MaxQA, &merged.MaxQA},
+			{limits.MaxRetries, &merged.MaxRetries},
+			{limits.Timeout, &merged.Timeout},
+		} {
+			if l.value >= 0 {
+				*l.target = l.value
+			}
+		}
+		if err := merged.Validate(); err != nil {
+			return nil, err.Error()
+		}
+		taskUpdates = make(map[string]interface{}, len(updates)+1)
+		for key, value := range updates {
+			taskUpdates[key] = value
+		}
+		taskUpdates["limits"] = merged
+	}
+
+	updated := *task
+	if err := applyTaskUpdates(&updated, taskUpdates); err != nil {
+		return nil, err.Error()
+	}
+	if reflect.DeepEqual(&updated, task) {
+		return nil, "already up to date"
+	}
+	return &updated, ""
+}
+
+// bulkUpdateFields lists the task fields a bulk update sets, as task_update
+// parameter names
+func bulkUpdateFields(updates map[string]interface{}, limits *global.TaskLimits) []string {
+	var fields []string
+	for _, key := range []string{"type", "priority"} {
+		if _, ok := updates[key]; ok {
+			fields = append(fields, key)
+		}
+	}
+	if limits != nil {
+		for _, l := range []struct {
+			name  string
+			value int
+		}{
+			{"max_worker", limits.MaxWorker},
+			{"max_qa", limits.MaxQA},
+			{"max_retries", limits.MaxRetries},
+			{"timeout", limits.Timeout},
+		} {
+			if l.value >= 0 {
+				fields = append(fields, l.name)
+			}
+		}
+	}
+	for _, section := range []struct {
+		key, prefix string
+	}{
+		{"work", ""},
+		{"qa", "qa_"},
+	} {
+		sectionUpdates, _ := updates[section.key].(map[string]interface{})
+		keys := make([]string, 0, len(sectionUpdates))
+		for key := range sectionUpdates {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			switch {
+			case section.key == "work" && key == "status":
+				fields = append(fields, "work_status")
+			case section.key == "work" && key == "hold_reason":
+				fields = append(fields, key)
+			default:
+				fields = append(fields, section.prefix+key)
+			}
+		}
+	}
+	return fields
+}
+
+// bulkSkipReason returns why a matching task must not be transitioned, or "" if it can be
+func (s *Service) bulkSkipReason(project, path string, task *global.Task, fromStatus, toStatus string) string {
+	switch {
+	case task.Work.Status == toStatus:
+		return fmt.Sprintf("already %s", toStatus)
+	case task.Work.Status == global.ExecutionStatusProcessing && fromStatus != global.ExecutionStatusProcessing:
+		return "task is processing"
+	case toStatus == global.ExecutionStatusDone && !global.FileExists(s.ResultFile(project, path, task, global.ResultFileSuffix)):
+		return "no result file"
+	}
+	return ""
+}
+
+// isBulkStatusTarget reports whether status may be set by a bulk transition
+func isBulkStatusTarget(status string) bool {
+	for _, target := range bulkStatusTargets {
+		if status == target {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateTasks creates tasks in a task set from their definitions, all or none.
+// Every definition is checked first; if any is invalid, nothing is created and
+// the result lists the error of each rejected definition. depends_on may name
+// existing tasks of the project or other definitions of the batch by external
+// ID. The tasks are added to the task set in one write. With dryRun the
+// definitions are only checked.
+func (s *Service) CreateTasks(project, path string, defs []global.TaskDefinition, dryRun bool) (*global.BulkCreateResult, error) {
+	if err := validatePath(path); err != nil {
+		return nil, fmt.Errorf("invalid path: %w", err)
+	}
+	if !s.projects.ProjectExists(project) {
+		return nil, fmt.Errorf("project not found: %s", project)
+	}
+	if len(defs) == 0 {
+		return nil, fmt.Errorf("no task definitions given")
+	}
+	if len(defs) > global.MaxBulkTasks {
+		return nil, fmt.Errorf("too many task definitions: %d (maximum %d per call)", len(defs), global.MaxBulkTasks)
+	}
+
+	result := &global.BulkCreateResult{
+		Project: project,
+		Path:    path,
+		DryRun:  dryRun,
+		Tasks:   []global.BulkCreatedTask{},
+	}
+	rejected := make([]bool, len(defs))
+	reject := func(i int, err error) {
+		if !rejected[i] {
+			rejected[i] = true
+			result.Errors = append(result.Errors, global.BulkTaskError{Index: i, Title: defs[i].Title, Error: err.Error()})
+		}
+	}
+
+	err := s.withLock(project, path, func() error {
+		taskSet, err := s.loadTaskSet(project, path)
+		if err != nil {
+			return err
+		}
+		taskSetList, err := s.ListTaskSets(project, "")
+		if err != nil {
+			return err
+		}
+		existing := NewDependencyGraph(taskSetList.TaskSets)
+
+		now := time.Now()
+		nextID := getNextTaskID(taskSet.Tasks)
+		created := make([]global.Task, len(defs))
+		batchIDs := make(map[string]int)
+		for i, def := range defs {
+			if err := checkTaskDefinition(&def); err != nil {
+				reject(i, err)
+			}
+			if def.ExternalID != "" {
+				if err := validateExternalID(def.ExternalID); err != nil {
+					reject(i, err)
+				} else if _, ok := existing.refs[def.ExternalID]; ok {
+					reject(i, fmt.Errorf("external_id '%s' is already used by a task in %s", def.ExternalID, existing.paths[existing.refs[def.ExternalID]]))
+				} else if other, ok := batchIDs[def.ExternalID]; ok {
+					reject(i, fmt.Errorf("external_id '%s' is also used by task %d of the batch", def.ExternalID, other))
+				} else {
+					batchIDs[def.ExternalID] = i
+				}
+			}
+
+			task := global.Task{
+				ID:         nextID + i,
+				UUID:       uuid.New().String(),
+				ExternalID: def.ExternalID,
+				Title:      def.Title,
+				Type:       def.Type,
+				DependsOn:  def.DependsOn,
+				Env:        def.Env,
+				Priority:   def.Priority,
+				CreatedAt:  now,
+				UpdatedAt:  now,
+				Work: global.WorkExecution{
+					InstructionsFile:       def.InstructionsFile,
+					InstructionsFileSource: def.InstructionsFileSource,
+					InstructionsText:       def.InstructionsText,
+					Prompt:                 def.Prompt,
+					EvidenceFiles:          def.EvidenceFiles,
+					LLMModelID:             def.LLMModelID,
+					Status:                 global.ExecutionStatusWaiting,
+				},
+				QA: global.QAExecution{Enabled: false},
+			}
+			if !def.Limits.IsEmpty() {
+				task.Limits = def.Limits
+			}
+			if def.QAEnabled {
+				task.QA = global.QAExecution{
+					Enabled:                true,
+					InstructionsFile:       def.QAInstructionsFile,
+					InstructionsFileSource: def.QAInstructionsFileSource,
+					InstructionsText:       def.QAInstructionsText,
+					Prompt:                 def.QAPrompt,
+					LLMModelID:             def.QALLMModelID,
+					Status:                 global.ExecutionStatusWaiting,
+				}
+			}
+			created[i] = task
+		}
+
+		for i, def := range defs {
+			var unknown []string
+			for _, ref := range def.DependsOn {
+				_, inProject := existing.refs[ref]
+				_, inBatch := batchIDs[ref]
+				if !inProject && !inBatch {
+					unknown = append(unknown, ref)
+				}
+			}
+			if len(unknown) > 0 {
+				reject(i, fmt.Errorf("depends_on references unknown task(s): %s", strings.Join(unknown, ", ")))
+			}
+		}
+
+		if len(result.Errors) == 0 {
+			// The new tasks can only form a cycle among themselves or through
+			// existing tasks that depend on one of their external IDs
+			taskSets := make([]*global.TaskSet, 0, len(taskSetList.TaskSets)+1)
+			for _, ts := range taskSetList.TaskSets {
+				if ts.Path != path {
+					taskSets = append(taskSets, ts)
+				}
+			}
+			withNew := *taskSet
+			withNew.Tasks = append(append([]global.Task{}, taskSet.Tasks...), created...)
+			g := NewDependencyGraph(append(taskSets, &withNew))
+			for i := range created {
+				if err := g.FindCycle([]*global.Task{&created[i]}); err != nil {
+					reject(i, err)
+				}
+			}
+		}
+
+		if len(result.Errors) > 0 {
+			sort.Slice(result.Errors, func(a, b int) bool { return result.Errors[a].Index < result.Errors[b].Index })
+			return nil
+		}
+		for i, task := range created {
+			result.Tasks = append(result.Tasks, global.BulkCreatedTask{Index: i, ID: task.ID, UUID: task.UUID, ExternalID: task.ExternalID, Title: task.Title})
+		}
+		if dryRun {
+			return nil
+		}
+
+		taskSet.Tasks = append(taskSet.Tasks, created...)
+		taskSet.UpdatedAt = now
+		if err := s.saveTaskSet(project, path, taskSet); err != nil {
+			return err
+		}
+		result.Created = len(created)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Created > 0 {
+		msg := fmt.Sprintf("Bulk task creation: %d task(s) created in %s", result.Created, path)
+		if err := s.AppendLog(project, msg); err != nil {
+			s.logger.Warnf("Failed to log bulk task creation for %s: %v", project, err)
+		}
+		s.logger.Infof("Project %s: %s", project, msg)
+	}
+	return result, nil
+}
+
+// checkTaskDefinition checks the fields of a task definition that do not
+// depend on the rest of the project
+func checkTaskDefinition(def *global.TaskDefinition) error {
+	if def.Title == "" {
+		return fmt.Errorf("title cannot be empty")
+	}
+	if def.Prompt == "" && def.InstructionsFile == "" && def.InstructionsText == "" {
+		return fmt.Errorf("at least one prompt field is required: instructions_file, instructions_text, or prompt")
+	}
+	if err := global.ValidateTaskEnv(def.Env); err != nil {
+		return err
+	}
+	if err := global.ValidateEvidenceFiles(def.EvidenceFiles); err != nil {
+		return err
+	}
+	if err := def.Limits.Validate(); err != nil {
+		return err
+	}
+	return global.ValidateTaskPriority(def.Priority)
+}
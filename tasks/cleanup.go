@@ -0,0 +1,158 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package tasks
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// CleanupResults collects orphaned files from a project's results directory:
+// error files (<uuid>-error.json) whose task has since succeeded or been deleted,
+// and partial files (*.tmp) left behind by interrupted writes. Collected files are
+// deleted, or with action "archive" moved to results/_orphaned/<timestamp>/.
+// Files modified within minAge are never collected so in-flight writes are safe.
+// With dryRun the summary lists what would be collected without changing anything.
+func (s *Service) CleanupResults(project, action string, minAge time.Duration, dryRun bool) (*global.ResultsCleanupSummary, error) {
+	if action == "" {
+		action = global.CleanupActionDelete
+	}
+	if action != global.CleanupActionDelete && action != global.CleanupActionArchive {
+		return nil, fmt.Errorf("invalid action '%s': must be '%s' or '%s'", action, global.CleanupActionDelete, global.CleanupActionArchive)
+	}
+
+	taskSetList, err := s.ListTaskSets(project, "")
+	if err != nil {
+		return nil, err
+	}
+	statuses := make(map[string]string)
+	for _, ts := range taskSetList.TaskSets {
+		for _, task := range ts.Tasks {
+			statuses[task.UUID] = task.Work.Status
+		}
+	}
+
+	summary := &global.ResultsCleanupSummary{
+		Project:     project,
+		Action:      action,
+		DryRun:      dryRun,
+		MinAgeHours: int(minAge / time.Hour),
+		ByReason:    make(map[string]int),
+	}
+
+	resultsDir := s.GetResultsDir(project)
+	if _, err := os.Stat(resultsDir); os.IsNotExist(err) {
+		return summary, nil
+	}
+
+	cutoff := time.Now().Add(-minAge)
+	archiveRel := filepath.Join(global.OrphanedResultsDir, time.Now().Format("20060102-150405"))
+	archiveDir := filepath.Join(resultsDir, archiveRel)
+
+	err = filepath.WalkDir(resultsDir, func(filePath string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if d.IsDir() {
+			if d.Name() == global.OrphanedResultsDir && filepath.Dir(filePath) == resultsDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		summary.Scanned++
+
+		reason := orphanReason(d.Name(), statuses)
+		if reason == "" {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || info.ModTime().After(cutoff) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(resultsDir, filePath)
+		if err != nil {
+			return err
+		}
+		summary.Collected++
+		summary.Bytes += info.Size()
+		summary.ByReason[reason]++
+		summary.Files = append(summary.Files, global.ResultsCleanupFile{
+			Path:   filepath.ToSlash(rel),
+			Reason: reason,
+			Size:   info.Size(),
+		})
+		if dryRun {
+			return nil
+		}
+
+		if action == global.CleanupActionArchive {
+			dest := filepath.Join(archiveDir, rel)
+			if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+				return fmt.Errorf("failed to create archive directory: %w", err)
+			}
+			if err := os.Rename(filePath, dest); err != nil {
+				return fmt.Errorf("failed to archive %s: %w", rel, err)
+			}
+			return nil
+		}
+		if err := os.Remove(filePath); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", rel, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return summary, fmt.Errorf("results cleanup failed: %w", err)
+	}
+
+	if action == global.CleanupActionArchive && summary.Collected > 0 {
+		summary.ArchiveDir = filepath.ToSlash(archiveRel)
+	}
+
+	if summary.Collected > 0 && !dryRun {
+		msg := fmt.Sprintf("Results cleanup (%s): collected %d orphaned file(s), %d bytes", action, summary.Collected, summary.Bytes)
+		if summary.ArchiveDir != "" {
+			msg += fmt.Sprintf(" into results/%s", summary.ArchiveDir)
+		}
+		if err := s.AppendLog(project, msg); err != nil {
+			s.logger.Warnf("Failed to log results cleanup for %s: %v", project, err)
+		}
+		s.logger.Infof("Project %s: %s", project, msg)
+	}
+
+	return summary, nil
+}
+
+// orphanReason returns why a results file is orphaned, or "" if it should be kept.
+// Error files are orphaned once their task is done or no longer exists; partial
+// files are orphaned unless their task is still being processed.
+func orphanReason(name string, statuses map[string]string) string {
+	if strings.HasSuffix(name, global.PartialFileSuffix) {
+		uuid := strings.TrimSuffix(strings.TrimSuffix(name, global.PartialFileSuffix), global.ResultFileSuffix)
+		uuid = strings.TrimSuffix(uuid, strings.TrimSuffix(global.ErrorFileSuffix, global.ResultFileSuffix))
+		if statuses[uuid] == global.ExecutionStatusProcessing {
+			return ""
+		}
+		return global.CleanupReasonPartial
+	}
+
+	if strings.HasSuffix(name, global.ErrorFileSuffix) {
+		status, exists := statuses[strings.TrimSuffix(name, global.ErrorFileSuffix)]
+		if !exists {
+			return global.CleanupReasonDeleted
+		}
+		if status == global.ExecutionStatusDone {
+			return global.CleanupReasonSucceeded
+		}
+	}
+	return ""
+}
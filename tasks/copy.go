@@ -0,0 +1,194 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package tasks
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+	"github.com/google/uuid"
+)
+
+// CopyTaskSet copies a task set, with its settings, templates and tasks, to
+// dstPath in dstProject, which may be the source project. The tasks keep their
+// IDs and instructions but get new UUIDs, and their execution state is reset:
+// work is waiting and QA, where enabled, is waiting; results are not copied.
+// externalIDSuffix is appended to the tasks' external IDs, which must be unique
+// in the destination project. Dependencies on tasks of the set are remapped to
+// their copies; dependencies on other tasks must resolve in the destination
+// project. Task set dependencies the destination project does not have are
+// dropped with a warning.
+func (s *Service) CopyTaskSet(srcProject, srcPath, dstProject, dstPath, title, externalIDSuffix string) (*global.TaskSetCopyResult, error) {
+	if err := validatePath(dstPath); err != nil {
+		return nil, fmt.Errorf("invalid path: %w", err)
+	}
+	if srcProject == dstProject && srcPath == dstPath {
+		return nil, fmt.Errorf("a task set cannot be copied onto itself")
+	}
+	if !s.projects.ProjectExists(dstProject) {
+		return nil, fmt.Errorf("project not found: %s", dstProject)
+	}
+	src, err := s.GetTaskSet(srcProject, srcPath)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	copied := *src
+	copied.Path = dstPath
+	if title != "" {
+		copied.Title = title
+	}
+	copied.CallbackedAt = nil
+	copied.CreatedAt = now
+	copied.UpdatedAt = now
+	copied.QASkipRules = slices.Clone(src.QASkipRules)
+	copied.Sampling = slices.Clone(src.Sampling)
+	if src.QADefaults != nil {
+		defaults := *src.QADefaults
+		copied.QADefaults = &defaults
+	}
+	if src.ResultSummary != nil {
+		summary := *src.ResultSummary
+		copied.ResultSummary = &summary
+	}
+
+	// New references of the tasks, by UUID and external ID
+	refs := make(map[string]string)
+	copied.Tasks = make([]global.Task, len(src.Tasks))
+	for i, task := range src.Tasks {
+		t := task
+		t.UUID = uuid.New().String()
+		refs[task.UUID] = t.UUID
+		if task.ExternalID != "" {
+			t.ExternalID = task.ExternalID + externalIDSuffix
+			if err := validateExternalID(t.ExternalID); err != nil {
+				return nil, err
+			}
+			refs[task.ExternalID] = t.ExternalID
+		}
+		t.Env = maps.Clone(task.Env)
+		if task.Limits != nil {
+			limits := *task.Limits
+			t.Limits = &limits
+		}
+		t.CreatedAt = now
+		t.UpdatedAt = now
+		t.Work = global.WorkExecution{
+			InstructionsFile:       task.Work.InstructionsFile,
+			InstructionsFileSource: task.Work.InstructionsFileSource,
+			InstructionsText:       task.Work.InstructionsText,
+			Prompt:                 task.Work.Prompt,
+			LLMModelID:             task.Work.LLMModelID,
+			Status:                 global.ExecutionStatusWaiting,
+		}
+		t.QA = global.QAExecution{
+			Enabled:                task.QA.Enabled,
+			InstructionsFile:       task.QA.InstructionsFile,
+			InstructionsFileSource: task.QA.InstructionsFileSource,
+			InstructionsText:       task.QA.InstructionsText,
+			Prompt:                 task.QA.Prompt,
+			LLMModelID:             task.QA.LLMModelID,
+		}
+		if t.QA.Enabled {
+			t.QA.Status = global.ExecutionStatusWaiting
+		}
+		copied.Tasks[i] = t
+	}
+	for i := range copied.Tasks {
+		t := &copied.Tasks[i]
+		t.DependsOn = nil
+		for _, ref := range src.Tasks[i].DependsOn {
+			if mapped, ok := refs[ref]; ok {
+				ref = mapped
+			}
+			t.DependsOn = append(t.DependsOn, ref)
+		}
+	}
+
+	result := &global.TaskSetCopyResult{
+		SourceProject: srcProject,
+		SourcePath:    srcPath,
+		Project:       dstProject,
+		Path:          dstPath,
+		Title:         copied.Title,
+		Tasks:         make([]global.CopiedTask, len(copied.Tasks)),
+	}
+	for i, t := range copied.Tasks {
+		result.Tasks[i] = global.CopiedTask{ID: t.ID, UUID: t.UUID, ExternalID: t.ExternalID, SourceUUID: src.Tasks[i].UUID}
+	}
+
+	err = s.withLock(dstProject, dstPath, func() error {
+		if _, err := os.Stat(s.getTaskSetFilePath(dstProject, dstPath)); err == nil {
+			return fmt.Errorf("task set already exists: %s", dstPath)
+		}
+		taskSetList, err := s.ListTaskSets(dstProject, "")
+		if err != nil {
+			return err
+		}
+		existing := NewDependencyGraph(taskSetList.TaskSets)
+
+		var dependsOn []string
+		for _, path := range copied.DependsOn {
+			if slices.ContainsFunc(taskSetList.TaskSets, func(ts *global.TaskSet) bool { return ts.Path == path }) {
+				dependsOn = append(dependsOn, path)
+				continue
+			}
+			result.Warnings = append(result.Warnings, fmt.Sprintf("dependency on task set %s dropped: it does not exist in project %s", path, dstProject))
+		}
+		copied.DependsOn = dependsOn
+
+		var unknown []string
+		for _, t := range copied.Tasks {
+			if t.ExternalID != "" {
+				if uuid, ok := existing.refs[t.ExternalID]; ok {
+					return fmt.Errorf("external_id '%s' is already used by a task in %s; give an external_id_suffix", t.ExternalID, existing.paths[uuid])
+				}
+			}
+			for _, ref := range t.DependsOn {
+				if _, ok := existing.refs[ref]; !ok && !isCopiedRef(copied.Tasks, ref) && !slices.Contains(unknown, ref) {
+					unknown = append(unknown, ref)
+				}
+			}
+		}
+		if len(unknown) > 0 {
+			return fmt.Errorf("tasks depend on task(s) outside the task set that are not in project %s: %s", dstProject, strings.Join(unknown, ", "))
+		}
+
+		g := NewDependencyGraph(append(taskSetList.TaskSets, &copied))
+		for i := range copied.Tasks {
+			if err := g.FindCycle([]*global.Task{&copied.Tasks[i]}); err != nil {
+				return err
+			}
+		}
+		return s.saveTaskSet(dstProject, dstPath, &copied)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	msg := fmt.Sprintf("Copied task set %s/%s to %s (%d task(s))", srcProject, srcPath, dstPath, len(copied.Tasks))
+	if err := s.AppendLog(dstProject, msg); err != nil {
+		s.logger.Warnf("Failed to log task set copy for %s: %v", dstProject, err)
+	}
+	s.logger.Infof("Project %s: %s", dstProject, msg)
+	return result, nil
+}
+
+// isCopiedRef reports whether ref is the UUID or external ID of a copied task
+func isCopiedRef(tasks []global.Task, ref string) bool {
+	for _, t := range tasks {
+		if t.UUID == ref || (t.ExternalID != "" && t.ExternalID == ref) {
+			return true
+		}
+	}
+	return false
+}
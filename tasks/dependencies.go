@@ -0,0 +1,260 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package tasks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// DependencyGraph resolves the depends_on references of the tasks in a project.
+// A reference is a task UUID or external ID; tasks may depend on tasks in any
+// task set of the same project. A task set may also depend on other task sets,
+// which makes each of its tasks depend on every task of those sets.
+type DependencyGraph struct {
+	tasks    map[string]*global.Task // UUID -> task
+	refs     map[string]string       // UUID or external ID -> UUID
+	paths    map[string]string       // UUID -> task set path
+	setDeps  map[string][]string     // task set path -> task set paths it depends on
+	setTasks map[string][]string     // task set path -> UUIDs of its tasks
+}
+
+// NewDependencyGraph builds the dependency graph of the given task sets
+func NewDependencyGraph(taskSets []*global.TaskSet) *DependencyGraph {
+	g := &DependencyGraph{
+		tasks:    make(map[string]*global.Task),
+		refs:     make(map[string]string),
+		paths:    make(map[string]string),
+		setDeps:  make(map[string][]string),
+		setTasks: make(map[string][]string),
+	}
+	for _, taskSet := range taskSets {
+		if len(taskSet.DependsOn) > 0 {
+			g.setDeps[taskSet.Path] = taskSet.DependsOn
+		}
+		for i := range taskSet.Tasks {
+			task := &taskSet.Tasks[i]
+			g.tasks[task.UUID] = task
+			g.paths[task.UUID] = taskSet.Path
+			g.setTasks[taskSet.Path] = append(g.setTasks[taskSet.Path], task.UUID)
+			if task.ExternalID != "" {
+				g.refs[task.ExternalID] = task.UUID
+			}
+		}
+	}
+	// UUIDs take precedence over external IDs, as in locateTask
+	for id := range g.tasks {
+		g.refs[id] = id
+	}
+	return g
+}
+
+// DependencyGraph builds the dependency graph of all tasks in a project
+func (s *Service) DependencyGraph(project string) (*DependencyGraph, error) {
+	taskSetList, err := s.ListTaskSets(project, "")
+	if err != nil {
+		return nil, err
+	}
+	return NewDependencyGraph(taskSetList.TaskSets), nil
+}
+
+// Task returns the current state of a task by UUID, or nil if it is not in the graph
+func (g *DependencyGraph) Task(taskUUID string) *global.Task {
+	return g.tasks[taskUUID]
+}
+
+// Unmet returns the dependencies of a task that are not done, including
+// references to tasks that no longer exist. A task set dependency with tasks
+// not done yet is reported as "task set <path>". A task with unmet
+// dependencies is blocked.
+func (g *DependencyGraph) Unmet(task *global.Task) []string {
+	var unmet []string
+	for _, ref := range task.DependsOn {
+		id, ok := g.refs[ref]
+		if !ok || g.tasks[id].Work.Status != global.ExecutionStatusDone {
+			unmet = append(unmet, ref)
+		}
+	}
+	for _, path := range g.setDeps[g.paths[task.UUID]] {
+		for _, id := range g.setTasks[path] {
+			if g.tasks[id].Work.Status != global.ExecutionStatusDone {
+				unmet = append(unmet, "task set "+path)
+				break
+			}
+		}
+	}
+	return unmet
+}
+
+// dependencies returns the references a task depends on: its own depends_on
+// and the UUIDs of the tasks of the task sets its task set depends on
+func (g *DependencyGraph) dependencies(task *global.Task) []string {
+	deps := task.DependsOn
+	for _, path := range g.setDeps[g.paths[task.UUID]] {
+		deps = append(deps[:len(deps):len(deps)], g.setTasks[path]...)
+	}
+	return deps
+}
+
+// FindCycle returns an error describing the first dependency cycle reachable
+// from the given tasks, or nil if there is none
+func (g *DependencyGraph) FindCycle(tasks []*global.Task) error {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int)
+	var stack []string
+
+	var visit func(id string) error
+	visit = func(id string) error {
+		switch state[id] {
+		case visited:
+			return nil
+		case visiting:
+			start := 0
+			for i, s := range stack {
+				if s == id {
+					start = i
+					break
+				}
+			}
+			cycle := make([]string, 0, len(stack)-start+1)
+			for _, s := range append(stack[start:], id) {
+				cycle = append(cycle, g.label(s))
+			}
+			return fmt.Errorf("dependency cycle: %s", strings.Join(cycle, " -> "))
+		}
+
+		state[id] = visiting
+		stack = append(stack, id)
+		for _, ref := range g.dependencies(g.tasks[id]) {
+			if dep, ok := g.refs[ref]; ok {
+				if err := visit(dep); err != nil {
+					return err
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[id] = visited
+		return nil
+	}
+
+	for _, task := range tasks {
+		if _, ok := g.tasks[task.UUID]; !ok {
+			continue
+		}
+		if err := visit(task.UUID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Order returns the tasks sorted so that each task comes after the tasks it
+// depends on, as recorded in the graph. Otherwise the original order is kept;
+// dependencies outside the list do not affect the order.
+func (g *DependencyGraph) Order(tasks []*global.Task) []*global.Task {
+	index := make(map[string]int, len(tasks))
+	for i, task := range tasks {
+		index[task.UUID] = i
+	}
+
+	pending := make([]int, len(tasks)) // unordered in-list dependencies per task
+	dependents := make(map[int][]int)  // task index -> indexes of tasks depending on it
+	for i, task := range tasks {
+		dependsOn := task.DependsOn
+		if current := g.tasks[task.UUID]; current != nil {
+			dependsOn = g.dependencies(current)
+		}
+		seen := make(map[int]bool)
+		for _, ref := range dependsOn {
+			dep, ok := index[g.refs[ref]]
+			if !ok || dep == i || seen[dep] {
+				continue
+			}
+			seen[dep] = true
+			pending[i]++
+			dependents[dep] = append(dependents[dep], i)
+		}
+	}
+
+	var ready []int
+	for i := range tasks {
+		if pending[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	ordered := make([]*global.Task, 0, len(tasks))
+	placed := make([]bool, len(tasks))
+	for len(ready) > 0 {
+		i := ready[0]
+		ready = ready[1:]
+		ordered = append(ordered, tasks[i])
+		placed[i] = true
+		for _, next := range dependents[i] {
+			if pending[next]--; pending[next] == 0 {
+				pos := sort.SearchInts(ready, next)
+				ready = append(ready, 0)
+				copy(ready[pos+1:], ready[pos:])
+				ready[pos] = next
+			}
+		}
+	}
+
+	// Tasks in a cycle cannot be ordered; keep them at the end in their original order
+	for i, task := range tasks {
+		if !placed[i] {
+			ordered = append(ordered, task)
+		}
+	}
+	return ordered
+}
+
+// label identifies a task in messages by external ID, falling back to UUID
+func (g *DependencyGraph) label(taskUUID string) string {
+	if task := g.tasks[taskUUID]; task != nil && task.ExternalID != "" {
+		return task.ExternalID
+	}
+	return taskUUID
+}
+
+// ValidateDependencies checks that every reference in dependsOn names an existing
+// task of the project and that setting them on the task taskRef (a UUID or
+// external ID; "" for a task not yet created) would not create a cycle.
+func (s *Service) ValidateDependencies(project, taskRef string, dependsOn []string) error {
+	g, err := s.DependencyGraph(project)
+	if err != nil {
+		return err
+	}
+
+	var unknown []string
+	for _, ref := range dependsOn {
+		if _, ok := g.refs[ref]; !ok {
+			unknown = append(unknown, ref)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("depends_on references unknown task(s): %s", strings.Join(unknown, ", "))
+	}
+
+	if taskRef == "" {
+		// Nothing can depend on a task that does not exist yet, so it cannot close a cycle
+		return nil
+	}
+	id, ok := g.refs[taskRef]
+	if !ok {
+		return fmt.Errorf("task not found: %s", taskRef)
+	}
+	task := *g.tasks[id]
+	task.DependsOn = dependsOn
+	g.tasks[id] = &task
+	return g.FindCycle([]*global.Task{&task})
+}
@@ -0,0 +1,303 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// retainedFile is a file in a project's results directory considered for pruning
+type retainedFile struct {
+	path    string
+	rel     string
+	uuid    string
+	size    int64
+	modTime time.Time
+	result  bool // a task result (<uuid>.json), as opposed to error details or an archived orphan
+}
+
+// PruneResults applies a retention policy to a project's results directory.
+// Files older than policy.MaxAgeDays, beyond the newest policy.MaxPerTask files
+// of a task, and then the oldest files while the directory is larger than
+// policy.MaxTotalMB are pruned. With action "compact" result files keep their
+// responses, verdicts and usage but lose the full prompts and the raw LLM output
+// in their history; error files and archived orphans have nothing worth
+// compacting and are deleted.
+// With action "delete" every pruned file is deleted. Each pruned file is
+// summarized in results/_index.json. Files of tasks being processed are never
+// pruned. With dryRun the summary lists what would be pruned without changing
+// anything.
+func (s *Service) PruneResults(project string, policy global.ResultsRetention, dryRun bool) (*global.ResultsPruneSummary, error) {
+	action := policy.Action
+	if action == "" {
+		action = global.RetentionActionCompact
+	}
+	if action != global.RetentionActionCompact && action != global.RetentionActionDelete {
+		return nil, fmt.Errorf("invalid action '%s': must be '%s' or '%s'", action, global.RetentionActionCompact, global.RetentionActionDelete)
+	}
+	if policy.MaxAgeDays < 0 || policy.MaxPerTask < 0 || policy.MaxTotalMB < 0 {
+		return nil, fmt.Errorf("retention limits must not be negative")
+	}
+	if !policy.Enabled() {
+		return nil, fmt.Errorf("no retention limit set: use max_age_days, max_per_task or max_total_mb")
+	}
+
+	taskSetList, err := s.ListTaskSets(project, "")
+	if err != nil {
+		return nil, err
+	}
+	tasks := make(map[string]*global.Task)
+	taskSets := make(map[string]string)
+	for _, ts := range taskSetList.TaskSets {
+		for i := range ts.Tasks {
+			tasks[ts.Tasks[i].UUID] = &ts.Tasks[i]
+			taskSets[ts.Tasks[i].UUID] = ts.Path
+		}
+	}
+
+	summary := &global.ResultsPruneSummary{
+		Project:  project,
+		Action:   action,
+		DryRun:   dryRun,
+		ByReason: make(map[string]int),
+	}
+
+	resultsDir := s.GetResultsDir(project)
+	if _, err := os.Stat(resultsDir); os.IsNotExist(err) {
+		return summary, nil
+	}
+
+	// Collect the files that may be pruned, oldest first
+	var files []*retainedFile
+	err = filepath.WalkDir(resultsDir, func(filePath string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if d.IsDir() || !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		summary.Scanned++
+		summary.TotalBytes += info.Size()
+
+		name := d.Name()
+		if filePath == filepath.Join(resultsDir, global.ResultsIndexFile) || !strings.HasSuffix(name, global.ResultFileSuffix) {
+			return nil
+		}
+		uuid := strings.TrimSuffix(name, global.ErrorFileSuffix)
+		isError := uuid != name
+		uuid = strings.TrimSuffix(uuid, global.ResultFileSuffix)
+		if task, ok := tasks[uuid]; ok && task.Work.Status == global.ExecutionStatusProcessing {
+			return nil
+		}
+
+		rel, err := filepath.Rel(resultsDir, filePath)
+		if err != nil {
+			return err
+		}
+		archived := strings.HasPrefix(rel, global.OrphanedResultsDir+string(filepath.Separator))
+		files = append(files, &retainedFile{
+			path:    filePath,
+			rel:     filepath.ToSlash(rel),
+			uuid:    uuid,
+			size:    info.Size(),
+			modTime: info.ModTime(),
+			result:  !isError && !archived,
+		})
+		return nil
+	})
+	if err != nil {
+		return summary, fmt.Errorf("results prune failed: %w", err)
+	}
+	sort.SliceStable(files, func(i, j int) bool { return files[i].modTime.Before(files[j].modTime) })
+
+	// Decide why each file is pruned; the size limit applies to what remains
+	reasons := make(map[*retainedFile]string)
+	if policy.MaxAgeDays > 0 {
+		cutoff := time.Now().AddDate(0, 0, -policy.MaxAgeDays)
+		for _, f := range files {
+			if f.modTime.Before(cutoff) {
+				reasons[f] = global.RetentionReasonMaxAge
+			}
+		}
+	}
+	if policy.MaxPerTask > 0 {
+		kept := make(map[string]int)
+		for i := len(files) - 1; i >= 0; i-- {
+			f := files[i]
+			kept[f.uuid]++
+			if kept[f.uuid] > policy.MaxPerTask && reasons[f] == "" {
+				reasons[f] = global.RetentionReasonPerTask
+			}
+		}
+	}
+
+	now := time.Now()
+	var entries []global.ResultsIndexEntry
+	prune := func(f *retainedFile, reason string) error {
+		entry := global.ResultsIndexEntry{
+			Path:     f.rel,
+			TaskUUID: f.uuid,
+			TaskSet:  taskSets[f.uuid],
+			Size:     f.size,
+			Action:   global.RetentionActionDelete,
+			Reason:   reason,
+			PrunedAt: now,
+		}
+		if task, ok := tasks[f.uuid]; ok {
+			entry.TaskID = task.ID
+			entry.TaskTitle = task.Title
+		}
+
+		var compacted []byte
+		if f.result {
+			data, err := os.ReadFile(f.path)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", f.rel, err)
+			}
+			var result global.TaskResult
+			if err := json.Unmarshal(data, &result); err == nil {
+				entry.TaskID = result.TaskID
+				entry.TaskTitle = result.TaskTitle
+				entry.Status = result.Worker.Status
+				if result.QA != nil {
+					entry.Verdict = result.QA.Verdict
+				}
+				if !result.CompletedAt.IsZero() {
+					completedAt := result.CompletedAt
+					entry.CompletedAt = &completedAt
+				}
+				if action == global.RetentionActionCompact {
+					if result.CompactedAt != nil {
+						return nil
+					}
+					compacted, err = compactResult(&result, now)
+					if err != nil {
+						return fmt.Errorf("failed to compact %s: %w", f.rel, err)
+					}
+					entry.Action = global.RetentionActionCompact
+				}
+			} else if action == global.RetentionActionCompact {
+				// Not a result Maestro wrote, so there is nothing safe to compact
+				return nil
+			}
+		}
+
+		freed := f.size
+		if compacted != nil {
+			freed = f.size - int64(len(compacted))
+		}
+		if !dryRun {
+			if compacted != nil {
+				if err := global.AtomicWrite(f.path, compacted); err != nil {
+					return fmt.Errorf("failed to compact %s: %w", f.rel, err)
+				}
+				// Keep the file's age so later passes still apply max_age_days
+				_ = os.Chtimes(f.path, f.modTime, f.modTime)
+			} else if err := os.Remove(f.path); err != nil {
+				return fmt.Errorf("failed to delete %s: %w", f.rel, err)
+			}
+		}
+
+		reasons[f] = reason
+		summary.Pruned++
+		summary.BytesFreed += freed
+		summary.TotalBytes -= freed
+		summary.ByReason[reason]++
+		entries = append(entries, entry)
+		return nil
+	}
+
+	var pruneErr error
+	for _, f := range files {
+		if reason := reasons[f]; reason != "" {
+			delete(reasons, f)
+			if pruneErr = prune(f, reason); pruneErr != nil {
+				break
+			}
+		}
+	}
+	if pruneErr == nil && policy.MaxTotalMB > 0 {
+		limit := int64(policy.MaxTotalMB) * 1024 * 1024
+		for _, f := range files {
+			if summary.TotalBytes <= limit {
+				break
+			}
+			if reasons[f] != "" {
+				continue // already pruned
+			}
+			if pruneErr = prune(f, global.RetentionReasonMaxTotal); pruneErr != nil {
+				break
+			}
+		}
+	}
+	summary.Files = entries
+
+	if len(entries) > 0 && !dryRun {
+		if err := s.appendResultsIndex(project, entries); err != nil {
+			s.logger.Warnf("Failed to update results index for %s: %v", project, err)
+		}
+		msg := fmt.Sprintf("Results retention (%s): pruned %d file(s), freed %d bytes", action, summary.Pruned, summary.BytesFreed)
+		if err := s.AppendLog(project, msg); err != nil {
+			s.logger.Warnf("Failed to log results retention for %s: %v", project, err)
+		}
+		s.logger.Infof("Project %s: %s", project, msg)
+	}
+	if pruneErr != nil {
+		return summary, fmt.Errorf("results prune failed: %w", pruneErr)
+	}
+
+	return summary, nil
+}
+
+// compactResult removes the full prompts and the raw LLM output in the message
+// history from a task result, keeping the responses and verdicts that reports
+// are built from and the usage accounting of each message
+func compactResult(result *global.TaskResult, now time.Time) ([]byte, error) {
+	result.Worker.FullPrompt = ""
+	if result.QA != nil {
+		result.QA.FullPrompt = ""
+	}
+	for i := range result.History {
+		msg := &result.History[i]
+		msg.Prompt = ""
+		msg.Stdout = ""
+		msg.Stderr = ""
+		msg.Text = ""
+		msg.Content = ""
+	}
+	result.CompactedAt = &now
+	return json.MarshalIndent(result, "", "  ")
+}
+
+// appendResultsIndex adds entries to the project's results/_index.json
+func (s *Service) appendResultsIndex(project string, entries []global.ResultsIndexEntry) error {
+	indexPath := filepath.Join(s.GetResultsDir(project), global.ResultsIndexFile)
+	index := global.ResultsIndex{Project: project}
+	if data, err := os.ReadFile(indexPath); err == nil {
+		if err := json.Unmarshal(data, &index); err != nil {
+			return fmt.Errorf("invalid %s: %w", global.ResultsIndexFile, err)
+		}
+	}
+	index.Entries = append(index.Entries, entries...)
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return err
+	}
+	return global.AtomicWrite(indexPath, data)
+}
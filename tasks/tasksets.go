@@ -29,6 +29,7 @@ type Service struct {
 	config   *config.Config
 	projects *projects.Service
 	logger   *logging.Logger
+	cache    global.FileCache[*global.TaskSet] // Parsed task set files, reused while unchanged
 }
 
 // TaskSetListResult represents the response for task set list operations
@@ -39,9 +40,10 @@ type TaskSetListResult struct {
 
 // TaskListResult represents the response for task list operations
 type TaskListResult struct {
-	Tasks []*global.Task `json:"tasks"`
-	Total int            `json:"total"`
-	Path  string         `json:"path"`
+	Tasks      []*global.Task `json:"tasks"`
+	Total      int            `json:"total"`
+	Path       string         `json:"path"`
+	NextCursor string         `json:"next_cursor,omitempty"` // Pass as cursor to get the next page
 }
 
 // pathSegmentRegex validates individual path segments
@@ -107,9 +109,16 @@ func (s *Service) withLock(project, path string, fn func() error) error {
 	return fn()
 }
 
-// loadTaskSet loads a task set from disk
+// loadTaskSet loads a task set from disk, or takes it from the cache while the file is unchanged
 func (s *Service) loadTaskSet(project, path string) (*global.TaskSet, error) {
 	filePath := s.getTaskSetFilePath(project, path)
+	info, statErr := os.Stat(filePath)
+	if statErr == nil {
+		if taskSet, ok := s.cache.Get(filePath, info); ok {
+			return taskSet, nil
+		}
+	}
+
 	data, err := os.ReadFile(filePath)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -127,12 +136,16 @@ func (s *Service) loadTaskSet(project, path string) (*global.TaskSet, error) {
 		taskSet.Tasks = []global.Task{}
 	}
 
+	if statErr == nil {
+		s.cache.Put(filePath, info, &taskSet)
+	}
 	return &taskSet, nil
 }
 
 // saveTaskSet saves a task set to disk with atomic writes
 func (s *Service) saveTaskSet(project, path string, taskSet *global.TaskSet) error {
 	filePath := s.getTaskSetFilePath(project, path)
+	defer s.cache.Invalidate(filePath)
 
 	// Ensure directory exists
 	dir := filepath.Dir(filePath)
@@ -164,8 +177,9 @@ func (s *Service) saveTaskSet(project, path string, taskSet *global.TaskSet) err
 	return nil
 }
 
-// CreateTaskSet creates a new task set at the given path
-func (s *Service) CreateTaskSet(project, path, title, description string, templates *global.DefaultTemplates, parallel bool, limits global.Limits, skipValidation bool, callbackURL string) (*global.TaskSet, error) {
+// CreateTaskSet creates a new task set at the given path. qaDefaults, if set,
+// provides QA instructions to the tasks of the set that have QA enabled.
+func (s *Service) CreateTaskSet(project, path, title, description string, templates *global.DefaultTemplates, parallel bool, limits global.Limits, skipValidation bool, callbackURL, outputLanguage string, qaDefaults *global.QADefaults) (*global.TaskSet, error) {
 	// Validate inputs
 	if err := validatePath(path); err != nil {
 		return nil, fmt.Errorf("invalid path: %w", err)
@@ -200,11 +214,17 @@ func (s *Service) CreateTaskSet(project, path, title, description string, templa
 			Limits:         limits,
 			SkipValidation: skipValidation,
 			CallbackURL:    callbackURL,
+			OutputLanguage: outputLanguage,
+			QADefaults:     qaDefaults,
 			CreatedAt:      now,
 			UpdatedAt:      now,
 			Tasks:          []global.Task{},
 		}
 
+		if qaDefaults.IsEmpty() {
+			taskSet.QADefaults = nil
+		}
+
 		// Apply templates if provided
 		if templates != nil {
 			taskSet.WorkerResponseTemplate = templates.WorkerResponseTemplate
@@ -317,8 +337,9 @@ func (s *Service) ListTaskSets(project, pathPrefix string) (*TaskSetListResult,
 	}, nil
 }
 
-// UpdateTaskSet updates task set metadata
-func (s *Service) UpdateTaskSet(project, path string, title, description *string, templates *global.DefaultTemplates, parallel *bool, limits *global.Limits, skipValidation *bool, callbackURL *string) (*global.TaskSet, error) {
+// UpdateTaskSet updates task set metadata. A non-nil qaDefaults replaces the
+// QA defaults; an empty one removes them.
+func (s *Service) UpdateTaskSet(project, path string, title, description *string, templates *global.DefaultTemplates, parallel *bool, limits *global.Limits, skipValidation *bool, callbackURL, outputLanguage *string, qaDefaults *global.QADefaults) (*global.TaskSet, error) {
 	if err := validatePath(path); err != nil {
 		return nil, fmt.Errorf("invalid path: %w", err)
 	}
@@ -370,6 +391,17 @@ func (s *Service) UpdateTaskSet(project, path string, title, description *string
 			taskSet.CallbackURL = *callbackURL
 		}
 
+		if outputLanguage != nil {
+			taskSet.OutputLanguage = *outputLanguage
+		}
+
+		if qaDefaults != nil {
+			taskSet.QADefaults = qaDefaults
+			if qaDefaults.IsEmpty() {
+				taskSet.QADefaults = nil
+			}
+		}
+
 		taskSet.UpdatedAt = time.Now()
 		return s.saveTaskSet(project, path, taskSet)
 	})
@@ -417,8 +449,9 @@ func (s *Service) DeleteTaskSet(project, path string) error {
 	return nil
 }
 
-// CreateTask creates a new task in a task set
-func (s *Service) CreateTask(project, path, title, taskType string, work *global.WorkExecution, qa *global.QAExecution) (*global.Task, error) {
+// CreateTask creates a new task in a task set. externalID is an optional
+// caller-assigned identifier that must be unique within the project.
+func (s *Service) CreateTask(project, path, title, taskType, externalID string, work *global.WorkExecution, qa *global.QAExecution) (*global.Task, error) {
 	if err := validatePath(path); err != nil {
 		return nil, fmt.Errorf("invalid path: %w", err)
 	}
@@ -439,6 +472,18 @@ func (s *Service) CreateTask(project, path, title, taskType string, work *global
 	if work.Prompt == "" && work.InstructionsFile == "" && work.InstructionsText == "" {
 		return nil, fmt.Errorf("at least one prompt field is required: instructions_file, instructions_text, or prompt")
 	}
+	if err := global.ValidateEvidenceFiles(work.EvidenceFiles); err != nil {
+		return nil, err
+	}
+
+	if externalID != "" {
+		if err := validateExternalID(externalID); err != nil {
+			return nil, err
+		}
+		if _, path, err := s.locateTask(project, externalID); err == nil {
+			return nil, fmt.Errorf("external_id '%s' is already used by a task in %s", externalID, path)
+		}
+	}
 
 	var task *global.Task
 	err := s.withLock(project, path, func() error {
@@ -465,14 +510,15 @@ func (s *Service) CreateTask(project, path, title, taskType string, work *global
 		}
 
 		task = &global.Task{
-			ID:        taskID,
-			UUID:      uuid.New().String(),
-			Title:     title,
-			Type:      taskType,
-			CreatedAt: now,
-			UpdatedAt: now,
-			Work:      *work,
-			QA:        qaExec,
+			ID:         taskID,
+			UUID:       uuid.New().String(),
+			ExternalID: externalID,
+			Title:      title,
+			Type:       taskType,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+			Work:       *work,
+			QA:         qaExec,
 		}
 
 		taskSet.Tasks = append(taskSet.Tasks, *task)
@@ -493,27 +539,35 @@ func (s *Service) CreateTask(project, path, title, taskType string, work *global
 	return task, nil
 }
 
-// GetTask retrieves a task by UUID (searches all task sets)
+// GetTask retrieves a task by UUID or external ID (searches all task sets)
 func (s *Service) GetTask(project, taskUUID string) (*global.Task, string, error) {
 	if !s.projects.ProjectExists(project) {
 		return nil, "", fmt.Errorf("project not found: %s", project)
 	}
+	return s.locateTask(project, taskUUID)
+}
 
-	// List all task sets
+// locateTask finds a task by UUID, falling back to its external ID, and returns
+// it with the path of its task set. UUIDs take precedence so a task can always
+// be addressed by its UUID.
+func (s *Service) locateTask(project, ref string) (*global.Task, string, error) {
 	result, err := s.ListTaskSets(project, "")
 	if err != nil {
 		return nil, "", err
 	}
 
-	// Search each task set for the UUID
 	for _, taskSet := range result.TaskSets {
-		_, task := findTaskByUUID(taskSet.Tasks, taskUUID)
-		if task != nil {
+		if _, task := findTaskByUUID(taskSet.Tasks, ref); task != nil {
+			return task, taskSet.Path, nil
+		}
+	}
+	for _, taskSet := range result.TaskSets {
+		if _, task := findTaskByExternalID(taskSet.Tasks, ref); task != nil {
 			return task, taskSet.Path, nil
 		}
 	}
 
-	return nil, "", fmt.Errorf("task not found: %s", taskUUID)
+	return nil, "", fmt.Errorf("task not found: %s", ref)
 }
 
 // GetTaskByID retrieves a task by ID within a specific task set
@@ -549,8 +603,10 @@ func (s *Service) GetTaskByID(project, path string, taskID int) (*global.Task, e
 	return task, nil
 }
 
-// ListTasks lists tasks with optional filters
-func (s *Service) ListTasks(project, path, statusFilter, typeFilter string, limit, offset int) (*TaskListResult, error) {
+// ListTasks lists tasks with optional filters. A non-empty cursor (from a previous
+// result's next_cursor) takes precedence over offset and pages by task ID, so tasks
+// added between calls are neither skipped nor repeated.
+func (s *Service) ListTasks(project, path, statusFilter, typeFilter string, limit, offset int, cursor string) (*TaskListResult, error) {
 	if err := validatePath(path); err != nil {
 		return nil, fmt.Errorf("invalid path: %w", err)
 	}
@@ -588,48 +644,67 @@ func (s *Service) ListTasks(project, path, statusFilter, typeFilter string, limi
 		return nil, err
 	}
 
-	// Apply pagination
+	// Apply pagination (tasks are stored in ascending ID order)
 	total := len(tasks)
-	if offset >= total {
-		tasks = []*global.Task{}
-	} else {
-		end := offset + limit
-		if limit <= 0 || end > total {
-			end = total
-		}
-		tasks = tasks[offset:end]
+	tasks, _, nextCursor, err := global.Paginate(tasks, func(t *global.Task) string {
+		return global.IntCursorKey(t.ID)
+	}, cursor, offset, limit)
+	if err != nil {
+		return nil, err
 	}
 
 	return &TaskListResult{
-		Tasks: tasks,
-		Total: total,
-		Path:  path,
+		Tasks:      tasks,
+		Total:      total,
+		Path:       path,
+		NextCursor: nextCursor,
 	}, nil
 }
 
-// UpdateTask updates a task by UUID
+// UpdateTask updates a task by UUID or external ID
 func (s *Service) UpdateTask(project, taskUUID string, updates map[string]interface{}) (*global.Task, error) {
 	if !s.projects.ProjectExists(project) {
 		return nil, fmt.Errorf("project not found: %s", project)
 	}
 
 	// Find the task set containing this task
-	result, err := s.ListTaskSets(project, "")
+	found, targetPath, err := s.locateTask(project, taskUUID)
 	if err != nil {
 		return nil, err
 	}
+	taskUUID = found.UUID
 
-	var targetPath string
-	for _, taskSet := range result.TaskSets {
-		_, task := findTaskByUUID(taskSet.Tasks, taskUUID)
-		if task != nil {
-			targetPath = taskSet.Path
-			break
+	// Dependencies are checked against the whole project before taking the task set lock
+	dependsOn, setDependsOn := updates["depends_on"].([]string)
+	if setDependsOn {
+		if err := s.ValidateDependencies(project, taskUUID, dependsOn); err != nil {
+			return nil, err
 		}
 	}
-
-	if targetPath == "" {
-		return nil, fmt.Errorf("task not found: %s", taskUUID)
+	env, setEnv := updates["env"].(map[string]string)
+	if setEnv {
+		if err := global.ValidateTaskEnv(env); err != nil {
+			return nil, err
+		}
+	}
+	limits, setLimits := updates["limits"].(*global.TaskLimits)
+	if setLimits {
+		if err := limits.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	priority, setPriority := updates["priority"].(int)
+	if setPriority {
+		if err := global.ValidateTaskPriority(priority); err != nil {
+			return nil, err
+		}
+	}
+	if work, ok := updates["work"].(map[string]interface{}); ok {
+		if evidenceFiles, ok := work["evidence_files"].([]string); ok {
+			if err := global.ValidateEvidenceFiles(evidenceFiles); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	// Update the task
@@ -645,85 +720,8 @@ func (s *Service) UpdateTask(project, taskUUID string, updates map[string]interf
 			return fmt.Errorf("task not found: %s", taskUUID)
 		}
 
-		// Apply updates
-		if title, ok := updates["title"].(string); ok {
-			if title == "" {
-				return fmt.Errorf("title cannot be empty")
-			}
-			task.Title = title
-		}
-
-		if taskType, ok := updates["type"].(string); ok {
-			task.Type = taskType
-		}
-
-		// Update work fields if provided
-		if workUpdates, ok := updates["work"].(map[string]interface{}); ok {
-			if status, ok := workUpdates["status"].(string); ok {
-				task.Work.Status = status
-			}
-			// Note: result is stored in results/<uuid>.json, not in tasks.json
-			if errMsg, ok := workUpdates["error"].(string); ok {
-				task.Work.Error = errMsg
-			}
-			if errCode, ok := workUpdates["error_code"].(string); ok {
-				task.Work.ErrorCode = errCode
-			}
-			if invocations, ok := workUpdates["invocations"].(int); ok {
-				task.Work.Invocations = invocations
-			}
-			if lastAttemptAt, ok := workUpdates["last_attempt_at"].(*time.Time); ok {
-				task.Work.LastAttemptAt = lastAttemptAt
-			}
-			// Instruction and prompt fields
-			if instructionsFile, ok := workUpdates["instructions_file"].(string); ok {
-				task.Work.InstructionsFile = instructionsFile
-			}
-			if instructionsFileSource, ok := workUpdates["instructions_file_source"].(string); ok {
-				task.Work.InstructionsFileSource = instructionsFileSource
-			}
-			if instructionsText, ok := workUpdates["instructions_text"].(string); ok {
-				task.Work.InstructionsText = instructionsText
-			}
-			if prompt, ok := workUpdates["prompt"].(string); ok {
-				task.Work.Prompt = prompt
-			}
-			if llmModelID, ok := workUpdates["llm_model_id"].(string); ok {
-				task.Work.LLMModelID = llmModelID
-			}
-		}
-
-		// Update QA fields if provided
-		if qaUpdates, ok := updates["qa"].(map[string]interface{}); ok {
-			if status, ok := qaUpdates["status"].(string); ok {
-				task.QA.Status = status
-			}
-			// Note: result is stored in results/<uuid>.json, not in tasks.json
-			if verdict, ok := qaUpdates["verdict"].(string); ok {
-				task.QA.Verdict = verdict
-			}
-			if errorMsg, ok := qaUpdates["error"].(string); ok {
-				task.QA.Error = errorMsg
-			}
-			if invocations, ok := qaUpdates["invocations"].(int); ok {
-				task.QA.Invocations = invocations
-			}
-			// Instruction and prompt fields
-			if instructionsFile, ok := qaUpdates["instructions_file"].(string); ok {
-				task.QA.InstructionsFile = instructionsFile
-			}
-			if instructionsFileSource, ok := qaUpdates["instructions_file_source"].(string); ok {
-				task.QA.InstructionsFileSource = instructionsFileSource
-			}
-			if instructionsText, ok := qaUpdates["instructions_text"].(string); ok {
-				task.QA.InstructionsText = instructionsText
-			}
-			if prompt, ok := qaUpdates["prompt"].(string); ok {
-				task.QA.Prompt = prompt
-			}
-			if llmModelID, ok := qaUpdates["llm_model_id"].(string); ok {
-				task.QA.LLMModelID = llmModelID
-			}
+		if err := applyTaskUpdates(task, updates); err != nil {
+			return err
 		}
 
 		task.UpdatedAt = time.Now()
@@ -746,30 +744,158 @@ func (s *Service) UpdateTask(project, taskUUID string, updates map[string]interf
 	return updatedTask, nil
 }
 
-// DeleteTask deletes a task by UUID
+// applyTaskUpdates applies the updates of UpdateTask to a task. Dependencies,
+// env, limits, priority and evidence files must have been validated.
+func applyTaskUpdates(task *global.Task, updates map[string]interface{}) error {
+	if title, ok := updates["title"].(string); ok {
+		if title == "" {
+			return fmt.Errorf("title cannot be empty")
+		}
+		task.Title = title
+	}
+
+	if taskType, ok := updates["type"].(string); ok {
+		task.Type = taskType
+	}
+
+	if priority, ok := updates["priority"].(int); ok {
+		task.Priority = priority
+	}
+
+	if dependsOn, ok := updates["depends_on"].([]string); ok {
+		task.DependsOn = nil
+		if len(dependsOn) > 0 {
+			task.DependsOn = dependsOn
+		}
+	}
+
+	if env, ok := updates["env"].(map[string]string); ok {
+		task.Env = nil
+		if len(env) > 0 {
+			task.Env = env
+		}
+	}
+
+	if limits, ok := updates["limits"].(*global.TaskLimits); ok {
+		task.Limits = nil
+		if !limits.IsEmpty() {
+			task.Limits = limits
+		}
+	}
+
+	// Update work fields if provided
+	if workUpdates, ok := updates["work"].(map[string]interface{}); ok {
+		if status, ok := workUpdates["status"].(string); ok {
+			task.Work.Status = status
+		}
+		if holdReason, ok := workUpdates["hold_reason"].(string); ok {
+			if task.Work.Status != global.ExecutionStatusOnHold {
+				return fmt.Errorf("hold_reason can only be set on a task with status %s", global.ExecutionStatusOnHold)
+			}
+			task.Work.HoldReason = holdReason
+		}
+		if task.Work.Status != global.ExecutionStatusOnHold {
+			task.Work.HoldReason = ""
+		}
+		// Note: result is stored in results/<uuid>.json, not in tasks.json
+		if errMsg, ok := workUpdates["error"].(string); ok {
+			task.Work.Error = errMsg
+		}
+		if errCode, ok := workUpdates["error_code"].(string); ok {
+			task.Work.ErrorCode = errCode
+		}
+		if invocations, ok := workUpdates["invocations"].(int); ok {
+			task.Work.Invocations = invocations
+		}
+		if lastAttemptAt, ok := workUpdates["last_attempt_at"].(*time.Time); ok {
+			task.Work.LastAttemptAt = lastAttemptAt
+		}
+		// Instruction and prompt fields
+		if instructionsFile, ok := workUpdates["instructions_file"].(string); ok {
+			task.Work.InstructionsFile = instructionsFile
+		}
+		if instructionsFileSource, ok := workUpdates["instructions_file_source"].(string); ok {
+			task.Work.InstructionsFileSource = instructionsFileSource
+		}
+		if instructionsText, ok := workUpdates["instructions_text"].(string); ok {
+			task.Work.InstructionsText = instructionsText
+		}
+		if prompt, ok := workUpdates["prompt"].(string); ok {
+			task.Work.Prompt = prompt
+		}
+		if evidenceFiles, ok := workUpdates["evidence_files"].([]string); ok {
+			task.Work.EvidenceFiles = nil
+			if len(evidenceFiles) > 0 {
+				task.Work.EvidenceFiles = evidenceFiles
+			}
+		}
+		if llmModelID, ok := workUpdates["llm_model_id"].(string); ok {
+			task.Work.LLMModelID = llmModelID
+		}
+	}
+
+	// Update QA fields if provided
+	if qaUpdates, ok := updates["qa"].(map[string]interface{}); ok {
+		if status, ok := qaUpdates["status"].(string); ok {
+			task.QA.Status = status
+		}
+		// Note: result is stored in results/<uuid>.json, not in tasks.json
+		if verdict, ok := qaUpdates["verdict"].(string); ok {
+			task.QA.Verdict = verdict
+		}
+		if severity, ok := qaUpdates["severity"].(string); ok {
+			task.QA.Severity = severity
+		}
+		if errorMsg, ok := qaUpdates["error"].(string); ok {
+			task.QA.Error = errorMsg
+		}
+		if invocations, ok := qaUpdates["invocations"].(int); ok {
+			task.QA.Invocations = invocations
+		}
+		// Instruction and prompt fields
+		if instructionsFile, ok := qaUpdates["instructions_file"].(string); ok {
+			task.QA.InstructionsFile = instructionsFile
+		}
+		if instructionsFileSource, ok := qaUpdates["instructions_file_source"].(string); ok {
+			task.QA.InstructionsFileSource = instructionsFileSource
+		}
+		if instructionsText, ok := qaUpdates["instructions_text"].(string); ok {
+			task.QA.InstructionsText = instructionsText
+		}
+		if prompt, ok := qaUpdates["prompt"].(string); ok {
+			task.QA.Prompt = prompt
+		}
+		if llmModelID, ok := qaUpdates["llm_model_id"].(string); ok {
+			task.QA.LLMModelID = llmModelID
+		}
+		if enabled, ok := qaUpdates["enabled"].(bool); ok {
+			task.QA.Enabled = enabled
+			if enabled && task.QA.Status == "" {
+				task.QA.Status = global.ExecutionStatusWaiting
+			}
+		}
+		if skipped, ok := qaUpdates["skipped"].(bool); ok {
+			task.QA.Skipped = skipped
+		}
+		if skipRule, ok := qaUpdates["skip_rule"].(string); ok {
+			task.QA.SkipRule = skipRule
+		}
+	}
+	return nil
+}
+
+// DeleteTask deletes a task by UUID or external ID
 func (s *Service) DeleteTask(project, taskUUID string) error {
 	if !s.projects.ProjectExists(project) {
 		return fmt.Errorf("project not found: %s", project)
 	}
 
 	// Find the task set containing this task
-	result, err := s.ListTaskSets(project, "")
+	found, targetPath, err := s.locateTask(project, taskUUID)
 	if err != nil {
 		return err
 	}
-
-	var targetPath string
-	for _, taskSet := range result.TaskSets {
-		_, task := findTaskByUUID(taskSet.Tasks, taskUUID)
-		if task != nil {
-			targetPath = taskSet.Path
-			break
-		}
-	}
-
-	if targetPath == "" {
-		return fmt.Errorf("task not found: %s", taskUUID)
-	}
+	taskUUID = found.UUID
 
 	// Delete the task
 	err = s.withLock(project, targetPath, func() error {
@@ -831,6 +957,33 @@ func findTaskByUUID(tasks []global.Task, taskUUID string) (int, *global.Task) {
 	return -1, nil
 }
 
+// findTaskByExternalID finds a task by external ID in a slice
+func findTaskByExternalID(tasks []global.Task, externalID string) (int, *global.Task) {
+	if externalID == "" {
+		return -1, nil
+	}
+	for i := range tasks {
+		if tasks[i].ExternalID == externalID {
+			return i, &tasks[i]
+		}
+	}
+	return -1, nil
+}
+
+// validateExternalID checks that an external ID is usable as a task reference
+func validateExternalID(externalID string) error {
+	if len(externalID) > global.MaxExternalIDLength {
+		return fmt.Errorf("external_id exceeds %d characters", global.MaxExternalIDLength)
+	}
+	if strings.TrimSpace(externalID) != externalID || strings.ContainsAny(externalID, "\n\r\t") {
+		return fmt.Errorf("external_id cannot contain leading/trailing whitespace or control characters")
+	}
+	if _, err := uuid.Parse(externalID); err == nil {
+		return fmt.Errorf("external_id cannot be a UUID")
+	}
+	return nil
+}
+
 // Exposed methods for runner and other packages
 
 // GetResultsDir returns the results directory for a project
@@ -838,6 +991,39 @@ func (s *Service) GetResultsDir(project string) string {
 	return s.projects.GetResultsDir(project)
 }
 
+// ResultFile returns the path of a task's result file (suffix global.ResultFileSuffix)
+// or validation error details (suffix global.ErrorFileSuffix). With the partitioned
+// layout, files live under results/<taskset path>/<yyyymm>/, using the month the task
+// was created so the location never changes. A file that already exists in the other
+// layout is returned instead, so the setting can change on a live project.
+func (s *Service) ResultFile(project, path string, task *global.Task, suffix string) string {
+	resultsDir := s.GetResultsDir(project)
+	flat := filepath.Join(resultsDir, task.UUID+suffix)
+	if s.config == nil || s.config.ResultsLayout() != global.ResultsLayoutPartitioned {
+		return flat
+	}
+
+	created := task.CreatedAt
+	if created.IsZero() {
+		created = time.Now()
+	}
+	partitioned := filepath.Join(resultsDir, filepath.FromSlash(path), created.Format("200601"), task.UUID+suffix)
+
+	if !global.FileExists(partitioned) && global.FileExists(flat) {
+		return flat
+	}
+	return partitioned
+}
+
+// FindResultFile returns the result file path for a task UUID, looking up its task set
+func (s *Service) FindResultFile(project, taskUUID, suffix string) (string, error) {
+	task, path, err := s.GetTask(project, taskUUID)
+	if err != nil {
+		return "", err
+	}
+	return s.ResultFile(project, path, task, suffix), nil
+}
+
 // RemoveTaskSetLock deletes the on-disk lock file for a task set.
 // The flock library does not auto-clean its lock files; callers that own
 // the full lifecycle of a task set (e.g. dispatch) use this to avoid leaks.
@@ -856,6 +1042,139 @@ func (s *Service) MarkCallbackDelivered(project, path string) error {
 	})
 }
 
+// AddTaskSetSampling records a list sample that tasks in the set were created from
+func (s *Service) AddTaskSetSampling(project, path string, sampling global.ListSampling) error {
+	return s.withLock(project, path, func() error {
+		ts, err := s.loadTaskSet(project, path)
+		if err != nil {
+			return err
+		}
+		ts.Sampling = append(ts.Sampling, sampling)
+		return s.saveTaskSet(project, path, ts)
+	})
+}
+
+// SetTaskSetQASkipRules replaces the QA skip rules of a task set; nil removes them
+func (s *Service) SetTaskSetQASkipRules(project, path string, rules []global.QASkipRule) error {
+	if err := global.ValidateQASkipRules(rules); err != nil {
+		return err
+	}
+	return s.withLock(project, path, func() error {
+		ts, err := s.loadTaskSet(project, path)
+		if err != nil {
+			return err
+		}
+		ts.QASkipRules = rules
+		ts.UpdatedAt = time.Now()
+		return s.saveTaskSet(project, path, ts)
+	})
+}
+
+// SetTaskSetQAHaltSeverity sets the QA severity at or above which a run of the
+// task set halts; "" removes the policy
+func (s *Service) SetTaskSetQAHaltSeverity(project, path, severity string) error {
+	if err := global.ValidateQASeverity(severity); err != nil {
+		return err
+	}
+	return s.withLock(project, path, func() error {
+		ts, err := s.loadTaskSet(project, path)
+		if err != nil {
+			return err
+		}
+		ts.QAHaltSeverity = global.NormalizeQASeverity(severity)
+		ts.UpdatedAt = time.Now()
+		return s.saveTaskSet(project, path, ts)
+	})
+}
+
+// SetTaskSetQAFields sets where the verdict, feedback and severity are found
+// in the task set's QA responses; nil restores the standard fields
+func (s *Service) SetTaskSetQAFields(project, path string, fields *global.QAFieldMap) error {
+	if err := global.ValidateQAFieldMap(fields); err != nil {
+		return err
+	}
+	return s.withLock(project, path, func() error {
+		ts, err := s.loadTaskSet(project, path)
+		if err != nil {
+			return err
+		}
+		ts.QAFields = fields
+		ts.UpdatedAt = time.Now()
+		return s.saveTaskSet(project, path, ts)
+	})
+}
+
+// SetTaskSetRevisionPromptTemplate sets the template that replaces the QA
+// feedback of the task set's revision prompts; "" restores the standard feedback
+func (s *Service) SetTaskSetRevisionPromptTemplate(project, path, templatePath string) error {
+	return s.withLock(project, path, func() error {
+		ts, err := s.loadTaskSet(project, path)
+		if err != nil {
+			return err
+		}
+		ts.RevisionPromptTemplate = templatePath
+		ts.UpdatedAt = time.Now()
+		return s.saveTaskSet(project, path, ts)
+	})
+}
+
+// SetTaskSetWorkerTools sets the read-only tools the task set's workers may
+// call during a task; nil turns worker tool calls off
+func (s *Service) SetTaskSetWorkerTools(project, path string, tools *global.WorkerTools) error {
+	if err := global.ValidateWorkerTools(tools); err != nil {
+		return err
+	}
+	return s.withLock(project, path, func() error {
+		ts, err := s.loadTaskSet(project, path)
+		if err != nil {
+			return err
+		}
+		ts.WorkerTools = tools
+		ts.UpdatedAt = time.Now()
+		return s.saveTaskSet(project, path, ts)
+	})
+}
+
+// SetTaskSetResultSummary replaces the result summary settings of a task set;
+// nil restores the default (the start of the worker response)
+func (s *Service) SetTaskSetResultSummary(project, path string, summary *global.ResultSummary) error {
+	if err := global.ValidateResultSummary(summary); err != nil {
+		return err
+	}
+	return s.withLock(project, path, func() error {
+		ts, err := s.loadTaskSet(project, path)
+		if err != nil {
+			return err
+		}
+		ts.ResultSummary = summary
+		ts.UpdatedAt = time.Now()
+		return s.saveTaskSet(project, path, ts)
+	})
+}
+
+// SetTaskSetDependsOn replaces the task sets whose tasks must all be done
+// before the tasks of the set at path run; nil removes the dependency. Each path
+// must name another task set of the project.
+func (s *Service) SetTaskSetDependsOn(project, path string, dependsOn []string) error {
+	for _, dep := range dependsOn {
+		if dep == path {
+			return fmt.Errorf("task set %s cannot depend on itself", path)
+		}
+		if _, err := s.GetTaskSet(project, dep); err != nil {
+			return fmt.Errorf("depends_on task set %s: %w", dep, err)
+		}
+	}
+	return s.withLock(project, path, func() error {
+		ts, err := s.loadTaskSet(project, path)
+		if err != nil {
+			return err
+		}
+		ts.DependsOn = dependsOn
+		ts.UpdatedAt = time.Now()
+		return s.saveTaskSet(project, path, ts)
+	})
+}
+
 func (s *Service) RemoveTaskSetLock(project, path string) error {
 	lockPath := s.getLockPath(project, path)
 	if err := os.Remove(lockPath); err != nil && !os.IsNotExist(err) {
@@ -957,6 +1276,7 @@ func (s *Service) ResetTaskSet(project, path, mode string, deleteResults bool) (
 			task.Work.Invocations = 0
 			task.Work.Error = ""
 			task.Work.LastAttemptAt = nil
+			task.Work.HoldReason = ""
 
 			// Reset QA phase if enabled
 			if task.QA.Enabled {
@@ -964,18 +1284,21 @@ func (s *Service) ResetTaskSet(project, path, mode string, deleteResults bool) (
 				task.QA.Invocations = 0
 				task.QA.Error = ""
 				task.QA.Verdict = ""
+				task.QA.Severity = ""
+				task.QA.Skipped = false
+				task.QA.SkipRule = ""
 			}
 
 			task.UpdatedAt = now
 
 			// Delete results file if requested
 			if deleteResults && resultsDir != "" {
-				resultFile := filepath.Join(resultsDir, task.UUID+".json")
+				resultFile := s.ResultFile(project, path, task, global.ResultFileSuffix)
 				if err := os.Remove(resultFile); err != nil && !os.IsNotExist(err) {
 					s.logger.Warnf("Failed to delete result file %s: %v", resultFile, err)
 				}
 				// Also delete any error files
-				errorFile := filepath.Join(resultsDir, task.UUID+"-error.json")
+				errorFile := s.ResultFile(project, path, task, global.ErrorFileSuffix)
 				if err := os.Remove(errorFile); err != nil && !os.IsNotExist(err) {
 					s.logger.Warnf("Failed to delete error file %s: %v", errorFile, err)
 				}
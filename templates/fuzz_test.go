@@ -0,0 +1,157 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package templates
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PivotLLM/Maestro/global"
+)
+
+// Fuzz tests of the path an LLM response takes: JSON extraction, schema
+// validation and QA verdict parsing. The seeds under testdata/fuzz are shaped
+// after the responses recorded in error files; to seed from real ones, point
+// MAESTRO_FUZZ_ERROR_FILES at a results directory:
+//
+//	MAESTRO_FUZZ_ERROR_FILES=~/.maestro/projects/x/results go test ./templates -fuzz FuzzExtractJSON
+
+// errorFileResponses returns the LLM responses recorded in the error files under
+// the directory named by MAESTRO_FUZZ_ERROR_FILES, if set
+func errorFileResponses(tb testing.TB) []string {
+	dir := os.Getenv(global.FuzzErrorFilesEnvVar)
+	if dir == "" {
+		return nil
+	}
+	var responses []string
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, global.ErrorFileSuffix) {
+			return err
+		}
+		var details struct {
+			LLMResponse string `json:"llm_response"`
+		}
+		if data, err := os.ReadFile(path); err == nil && json.Unmarshal(data, &details) == nil && details.LLMResponse != "" {
+			responses = append(responses, details.LLMResponse)
+		}
+		return nil
+	})
+	if err != nil {
+		tb.Fatalf("Failed to read error files: %v", err)
+	}
+	return responses
+}
+
+func FuzzExtractJSON(f *testing.F) {
+	for _, seed := range []string{
+		`{"result": "ok"}`,
+		"```json\n{\"verdict\": \"pass\"}\n```",
+		`{"text": "Here it is: {\"result\": \"ok\"} done"}`,
+		`[1, 2] then {"a": 1}`,
+		`Prose with a } stray brace {"a": "}"} and {"b": 2}`,
+	} {
+		f.Add(seed)
+	}
+	for _, response := range errorFileResponses(f) {
+		f.Add(response)
+	}
+
+	f.Fuzz(func(t *testing.T, response string) {
+		out := ExtractJSON(response)
+
+		// Anything that is not the response itself, or its unwrapped text, is JSON
+		trimmed := strings.TrimSpace(response)
+		if out != trimmed && out != unwrapTextWrapper(trimmed) && !json.Valid([]byte(out)) {
+			t.Fatalf("ExtractJSON(%q) = %q, neither the response nor JSON", response, out)
+		}
+
+		// Extraction is stable: extracted JSON extracts to itself
+		if json.Valid([]byte(out)) && unwrapTextWrapper(out) == out {
+			if again := ExtractJSON(out); again != out {
+				t.Fatalf("ExtractJSON(%q) = %q, not stable", out, again)
+			}
+		}
+	})
+}
+
+// FuzzExtractJSONEmbedded checks that a JSON object surrounded by prose, as LLMs
+// often answer, is extracted intact
+func FuzzExtractJSONEmbedded(f *testing.F) {
+	f.Add("Here is my assessment:", "The control is effective } {", "Let me know if you need more.")
+	f.Add("", "```json\nnested fence\n```", "")
+	f.Add("Result", `{"text": "x"}`, "]")
+
+	f.Fuzz(func(t *testing.T, prefix, finding, suffix string) {
+		// Prose without JSON or fences of its own
+		clean := func(s string) string {
+			return strings.Map(func(r rune) rune {
+				if strings.ContainsRune("{}[]`", r) {
+					return -1
+				}
+				return r
+			}, s)
+		}
+		object, err := json.Marshal(map[string]interface{}{"finding": finding, "severity": "high"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var want map[string]interface{}
+		if err := json.Unmarshal(object, &want); err != nil {
+			t.Fatal(err)
+		}
+		response := clean(prefix) + "\n" + string(object) + "\n" + clean(suffix)
+
+		var got map[string]interface{}
+		if err := json.Unmarshal([]byte(ExtractJSON(response)), &got); err != nil || !reflect.DeepEqual(got, want) {
+			t.Fatalf("ExtractJSON(%q) = %q, want %s", response, ExtractJSON(response), object)
+		}
+	})
+}
+
+// FuzzParseQAResponse runs responses through extraction, schema validation and
+// verdict parsing, as the runner does with QA output
+func FuzzParseQAResponse(f *testing.F) {
+	for _, seed := range []string{
+		`{"verdict": "pass", "feedback": "ok"}`,
+		`{"verdict": "ESCALATE"}`,
+		`{"verdict": "maybe"}`,
+		`{"verdict": 1}`,
+		`{"verdict": "fail", "verdict": "pass"}`,
+		`{"verdict": "fail", "severity": "CRITICAL"}`,
+		"```json\n{\"verdict\": \"fail\", \"issues\": [\"x\"]}\n```",
+	} {
+		f.Add(seed)
+	}
+	for _, response := range errorFileResponses(f) {
+		f.Add(response)
+	}
+
+	v := New(nil)
+	schema := DefaultQASchema()
+	verdicts := map[string]bool{global.QAVerdictPass: true, global.QAVerdictFail: true, global.QAVerdictEscalate: true}
+
+	f.Fuzz(func(t *testing.T, response string) {
+		data := []byte(ExtractJSON(response))
+
+		parsed, err := v.ParseQAResponse(data)
+		if err == nil && !verdicts[parsed.Verdict] {
+			t.Fatalf("ParseQAResponse(%q) verdict = %q", data, parsed.Verdict)
+		}
+		if err == nil && parsed.Severity != "" && global.NormalizeQASeverity(parsed.Severity) != parsed.Severity {
+			t.Fatalf("ParseQAResponse(%q) severity = %q", data, parsed.Severity)
+		}
+
+		// A response the QA schema accepts always has a usable verdict
+		result, verr := v.ValidateJSON(data, schema)
+		if verr == nil && result.Valid && err != nil {
+			t.Fatalf("ParseQAResponse(%q) = %v, but the response matches the QA schema", data, err)
+		}
+	})
+}
@@ -0,0 +1,279 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package templates
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// languageMinLetters is the minimum number of letters required before a
+// language verdict is issued. Shorter responses are treated as inconclusive.
+const languageMinLetters = 40
+
+// languageMinStopwords is the minimum number of stopword hits required to
+// identify a Latin-script language. Fewer hits are treated as inconclusive.
+const languageMinStopwords = 3
+
+// language describes a supported output language
+type language struct {
+	code   string
+	name   string
+	script string
+}
+
+// supportedLanguages maps ISO 639-1 codes to language descriptions
+var supportedLanguages = map[string]language{
+	"en": {code: "en", name: "English", script: "latin"},
+	"fr": {code: "fr", name: "French", script: "latin"},
+	"de": {code: "de", name: "German", script: "latin"},
+	"es": {code: "es", name: "Spanish", script: "latin"},
+	"it": {code: "it", name: "Italian", script: "latin"},
+	"pt": {code: "pt", name: "Portuguese", script: "latin"},
+	"nl": {code: "nl", name: "Dutch", script: "latin"},
+	"ru": {code: "ru", name: "Russian", script: "cyrillic"},
+	"el": {code: "el", name: "Greek", script: "greek"},
+	"ar": {code: "ar", name: "Arabic", script: "arabic"},
+	"he": {code: "he", name: "Hebrew", script: "hebrew"},
+	"zh": {code: "zh", name: "Chinese", script: "han"},
+	"ja": {code: "ja", name: "Japanese", script: "kana"},
+	"ko": {code: "ko", name: "Korean", script: "hangul"},
+}
+
+// scriptLanguages maps a non-Latin script to the language reported when it dominates
+var scriptLanguages = map[string]string{
+	"cyrillic": "ru",
+	"greek":    "el",
+	"arabic":   "ar",
+	"hebrew":   "he",
+	"han":      "zh",
+	"kana":     "ja",
+	"hangul":   "ko",
+}
+
+// stopwords holds high-frequency function words used to tell Latin-script languages apart.
+// Some words are shared between languages; the relative score decides.
+var stopwords = map[string][]string{
+	"en": {"the", "and", "is", "are", "was", "were", "this", "that", "with", "for", "not", "have", "has", "which", "should", "be", "it", "of", "to", "on"},
+	"fr": {"le", "les", "des", "est", "sont", "et", "une", "pour", "dans", "pas", "avec", "qui", "cette", "sur", "du", "au", "aux", "être", "été", "doit"},
+	"de": {"der", "die", "das", "und", "ist", "sind", "nicht", "mit", "ein", "eine", "für", "auf", "dem", "den", "wird", "werden", "auch", "sich", "wurde", "muss"},
+	"es": {"el", "los", "las", "del", "es", "son", "y", "una", "para", "con", "por", "que", "está", "están", "debe", "sin", "pero", "fue", "sus", "como"},
+	"it": {"il", "gli", "della", "delle", "è", "sono", "e", "una", "per", "con", "che", "non", "nel", "alla", "dei", "deve", "questo", "questa", "anche", "stato"},
+	"pt": {"os", "as", "da", "das", "do", "dos", "é", "são", "uma", "para", "com", "não", "que", "em", "na", "no", "deve", "foi", "está", "também"},
+	"nl": {"het", "een", "van", "en", "is", "zijn", "niet", "met", "voor", "op", "dat", "wordt", "worden", "ook", "moet", "deze", "bij", "naar", "door", "heeft"},
+}
+
+// LanguageCheck is the result of checking a response against an expected language
+type LanguageCheck struct {
+	Expected   string // Expected language code
+	Detected   string // Detected language code (empty when inconclusive)
+	Match      bool   // True if the response matches or the check was inconclusive
+	Conclusive bool   // False when the text was too short or ambiguous to judge
+}
+
+// NormalizeLanguage converts a language code or English language name
+// (e.g. "fr", "French") to its ISO 639-1 code. An empty value is returned unchanged.
+func NormalizeLanguage(value string) (string, error) {
+	v := strings.ToLower(strings.TrimSpace(value))
+	if v == "" {
+		return "", nil
+	}
+	// Accept regional variants such as "en-US" or "pt_BR"
+	if i := strings.IndexAny(v, "-_"); i > 0 {
+		v = v[:i]
+	}
+	if _, ok := supportedLanguages[v]; ok {
+		return v, nil
+	}
+	for code, lang := range supportedLanguages {
+		if strings.ToLower(lang.name) == v {
+			return code, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported output language: %s (supported: %s)", value, strings.Join(SupportedLanguageCodes(), ", "))
+}
+
+// SupportedLanguageCodes returns the sorted list of supported language codes
+func SupportedLanguageCodes() []string {
+	codes := make([]string, 0, len(supportedLanguages))
+	for code := range supportedLanguages {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// LanguageName returns the English name for a language code, or the code itself if unknown
+func LanguageName(code string) string {
+	if lang, ok := supportedLanguages[code]; ok {
+		return lang.name
+	}
+	return code
+}
+
+// CheckLanguage determines whether a response is predominantly written in the
+// expected language. JSON responses are judged on their string values only so
+// that schema field names do not skew the result. Short or ambiguous text is
+// reported as inconclusive and treated as a match.
+func CheckLanguage(response, expected string) *LanguageCheck {
+	check := &LanguageCheck{Expected: expected, Match: true}
+
+	lang, ok := supportedLanguages[expected]
+	if !ok {
+		return check
+	}
+
+	text := languageText(response)
+	scripts, total := countScripts(text)
+	if total < languageMinLetters {
+		return check
+	}
+
+	// Find the dominant script
+	dominant := ""
+	for script, count := range scripts {
+		if dominant == "" || count > scripts[dominant] || (count == scripts[dominant] && script < dominant) {
+			dominant = script
+		}
+	}
+
+	// Japanese mixes kana and kanji, so any meaningful kana presence marks it as Japanese
+	if dominant == "han" && scripts["kana"]*5 >= scripts["han"] {
+		dominant = "kana"
+	}
+
+	if dominant != "latin" {
+		check.Conclusive = true
+		check.Detected = scriptLanguages[dominant]
+		check.Match = dominant == lang.script
+		return check
+	}
+
+	if lang.script != "latin" {
+		check.Conclusive = true
+		check.Detected = bestLanguage(stopwordScores(text))
+		check.Match = false
+		return check
+	}
+
+	scores := stopwordScores(text)
+	best := bestLanguage(scores)
+	if best == "" || scores[best] < languageMinStopwords {
+		return check
+	}
+
+	check.Conclusive = true
+	check.Detected = best
+	// Predominantly the wrong language: another language clearly outscores the expected one
+	check.Match = best == expected || scores[expected]*2 >= scores[best]
+	if check.Match {
+		check.Detected = expected
+	}
+	return check
+}
+
+// languageText returns the natural-language portion of a response.
+// For JSON documents the string values are concatenated; other text is returned as-is.
+func languageText(response string) string {
+	trimmed := strings.TrimSpace(response)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return response
+	}
+
+	var data interface{}
+	if err := json.Unmarshal([]byte(trimmed), &data); err != nil {
+		return response
+	}
+
+	var sb strings.Builder
+	collectStrings(data, &sb)
+	return sb.String()
+}
+
+// collectStrings appends all string values in a decoded JSON value to sb
+func collectStrings(value interface{}, sb *strings.Builder) {
+	switch v := value.(type) {
+	case string:
+		sb.WriteString(v)
+		sb.WriteString("\n")
+	case []interface{}:
+		for _, item := range v {
+			collectStrings(item, sb)
+		}
+	case map[string]interface{}:
+		for _, item := range v {
+			collectStrings(item, sb)
+		}
+	}
+}
+
+// countScripts counts letters per writing system and returns the counts and total
+func countScripts(text string) (map[string]int, int) {
+	counts := make(map[string]int)
+	total := 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		total++
+		switch {
+		case unicode.Is(unicode.Latin, r):
+			counts["latin"]++
+		case unicode.Is(unicode.Cyrillic, r):
+			counts["cyrillic"]++
+		case unicode.Is(unicode.Greek, r):
+			counts["greek"]++
+		case unicode.Is(unicode.Arabic, r):
+			counts["arabic"]++
+		case unicode.Is(unicode.Hebrew, r):
+			counts["hebrew"]++
+		case unicode.Is(unicode.Hiragana, r), unicode.Is(unicode.Katakana, r):
+			counts["kana"]++
+		case unicode.Is(unicode.Han, r):
+			counts["han"]++
+		case unicode.Is(unicode.Hangul, r):
+			counts["hangul"]++
+		default:
+			counts["other"]++
+		}
+	}
+	return counts, total
+}
+
+// stopwordScores counts stopword occurrences per Latin-script language
+func stopwordScores(text string) map[string]int {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+
+	scores := make(map[string]int)
+	for code, list := range stopwords {
+		set := make(map[string]bool, len(list))
+		for _, w := range list {
+			set[w] = true
+		}
+		for _, w := range words {
+			if set[w] {
+				scores[code]++
+			}
+		}
+	}
+	return scores
+}
+
+// bestLanguage returns the highest-scoring language, or "" if nothing scored
+func bestLanguage(scores map[string]int) string {
+	best := ""
+	for code, score := range scores {
+		if score > 0 && (best == "" || score > scores[best] || (score == scores[best] && code < best)) {
+			best = code
+		}
+	}
+	return best
+}
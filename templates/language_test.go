@@ -0,0 +1,90 @@
+/******************************************************************************
+ * Copyright (c) 2025-2026 Tenebris Technologies Inc.                         *
+ * Please see the LICENSE file for details                                    *
+ ******************************************************************************/
+
+package templates
+
+import "testing"
+
+func TestNormalizeLanguage(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "", want: ""},
+		{input: "fr", want: "fr"},
+		{input: "French", want: "fr"},
+		{input: " EN-us ", want: "en"},
+		{input: "pt_BR", want: "pt"},
+		{input: "klingon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeLanguage(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NormalizeLanguage(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("NormalizeLanguage(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestCheckLanguage(t *testing.T) {
+	english := "The firewall configuration is missing an egress rule and the logging policy has not been reviewed for this quarter."
+	french := "La configuration du pare-feu est incomplète et les journaux ne sont pas conservés pour une durée suffisante dans cette organisation."
+	german := "Die Konfiguration der Firewall ist unvollständig und die Protokolle werden nicht für den erforderlichen Zeitraum aufbewahrt."
+	russian := "Конфигурация межсетевого экрана неполная, и журналы не хранятся в течение необходимого периода времени."
+
+	tests := []struct {
+		name       string
+		response   string
+		expected   string
+		match      bool
+		conclusive bool
+		detected   string
+	}{
+		{name: "english as expected", response: english, expected: "en", match: true, conclusive: true, detected: "en"},
+		{name: "french as expected", response: french, expected: "fr", match: true, conclusive: true, detected: "fr"},
+		{name: "english when french expected", response: english, expected: "fr", match: false, conclusive: true, detected: "en"},
+		{name: "german when english expected", response: german, expected: "en", match: false, conclusive: true, detected: "de"},
+		{name: "cyrillic when english expected", response: russian, expected: "en", match: false, conclusive: true, detected: "ru"},
+		{name: "latin when russian expected", response: english, expected: "ru", match: false, conclusive: true, detected: "en"},
+		{name: "short text is inconclusive", response: "OK", expected: "fr", match: true},
+		{name: "unknown expected language", response: english, expected: "xx", match: true},
+		{
+			name:       "json keys ignored",
+			response:   `{"finding": "` + french + `", "severity": "high", "recommendation": "Activer la journalisation pour tous les serveurs et les postes."}`,
+			expected:   "fr",
+			match:      true,
+			conclusive: true,
+			detected:   "fr",
+		},
+		{
+			name:       "json values in wrong language",
+			response:   `{"finding": "` + english + `"}`,
+			expected:   "fr",
+			match:      false,
+			conclusive: true,
+			detected:   "en",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := CheckLanguage(tt.response, tt.expected)
+			if check.Match != tt.match {
+				t.Errorf("Match = %v, want %v (detected %q)", check.Match, tt.match, check.Detected)
+			}
+			if check.Conclusive != tt.conclusive {
+				t.Errorf("Conclusive = %v, want %v", check.Conclusive, tt.conclusive)
+			}
+			if check.Detected != tt.detected {
+				t.Errorf("Detected = %q, want %q", check.Detected, tt.detected)
+			}
+		})
+	}
+}
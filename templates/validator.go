@@ -15,6 +15,7 @@ import (
 	"strings"
 	"text/template"
 
+	"github.com/PivotLLM/Maestro/global"
 	"github.com/PivotLLM/Maestro/logging"
 	"github.com/xeipuuv/gojsonschema"
 )
@@ -253,6 +254,15 @@ func (v *Validator) PopulateTemplate(templateContent string, data interface{}) (
 	return buf.String(), nil
 }
 
+// ValidateTemplate checks that a Go template parses with the template functions
+// PopulateTemplate provides
+func ValidateTemplate(templateContent string) error {
+	if _, err := template.New("template").Funcs(templateFuncs()).Parse(templateContent); err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+	return nil
+}
+
 // PopulateTemplateFile populates a Go template file with data
 func (v *Validator) PopulateTemplateFile(templatePath string, data interface{}) (string, error) {
 	content, err := os.ReadFile(templatePath)
@@ -434,18 +444,9 @@ func extractJSONObject(response string) string {
 		return candidate
 	}
 
-	// Fallback: iterate through } characters to find the first valid JSON
+	// Fallback: decode the first JSON value from the first {
 	// This handles cases like extra } after the JSON or multiple JSON objects
-	for i := firstBrace; i < len(response); i++ {
-		if response[i] == '}' {
-			candidate := response[firstBrace : i+1]
-			if json.Unmarshal([]byte(candidate), &js) == nil {
-				return candidate
-			}
-		}
-	}
-
-	return ""
+	return firstJSONValue(response[firstBrace:])
 }
 
 // extractJSONArray finds the first valid JSON array in the response
@@ -467,57 +468,80 @@ func extractJSONArray(response string) string {
 		return candidate
 	}
 
-	// Fallback: iterate through ] characters to find the first valid JSON
-	for i := firstBracket; i < len(response); i++ {
-		if response[i] == ']' {
-			candidate := response[firstBracket : i+1]
-			if json.Unmarshal([]byte(candidate), &js) == nil {
-				return candidate
-			}
-		}
-	}
+	// Fallback: decode the first JSON value from the first [
+	return firstJSONValue(response[firstBracket:])
+}
 
-	return ""
+// firstJSONValue returns the JSON value at the start of s, ignoring whatever
+// follows it, or "" if s does not start with valid JSON. A single pass, unlike
+// trying each closing brace in turn, which is quadratic on deeply nested input.
+func firstJSONValue(s string) string {
+	var js json.RawMessage
+	if err := json.NewDecoder(strings.NewReader(s)).Decode(&js); err != nil {
+		return ""
+	}
+	return string(js)
 }
 
 // QAResponse represents the parsed QA response with the standardized verdict
 type QAResponse struct {
-	Verdict string `json:"verdict"` // Standardized: "pass", "fail", "escalate"
-}
-
-// qaVerdictOnly is used internally to extract only the verdict field
-type qaVerdictOnly struct {
-	Verdict string `json:"verdict"`
+	Verdict  string `json:"verdict"`            // Standardized: "pass", "fail", "escalate"
+	Severity string `json:"severity,omitempty"` // Optional: "critical", "high", "medium", "low"
+	Feedback string `json:"feedback,omitempty"` // Set when a QA field map names the feedback field
 }
 
 // ParseQAResponse parses a QA response and extracts the standardized verdict field.
 // The verdict must be one of: "pass", "fail", "escalate" (case-insensitive).
 // All QA schemas must include this field for workflow control.
+// The optional severity is normalized to one of "critical", "high", "medium" or
+// "low"; other values are ignored so playbook-specific severities do not fail QA.
 // Other fields in the QA response are playbook-specific and used only for reporting.
 func (v *Validator) ParseQAResponse(data []byte) (*QAResponse, error) {
-	var parsed qaVerdictOnly
-	if err := json.Unmarshal(data, &parsed); err != nil {
+	return v.ParseQAResponseWith(data, nil)
+}
+
+// ParseQAResponseWith is ParseQAResponse for a QA schema that keeps the verdict,
+// severity or feedback elsewhere, as described by a task set's QA field map.
+// The verdict is mapped to a standardized one before it is checked. A nil map
+// parses the standard fields.
+func (v *Validator) ParseQAResponseWith(data []byte, fields *global.QAFieldMap) (*QAResponse, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
 		return nil, fmt.Errorf("failed to parse QA response: %w", err)
 	}
 
-	if parsed.Verdict == "" {
-		return nil, fmt.Errorf("QA response missing required 'verdict' field")
+	path := fields.VerdictPath()
+	value, found := global.LookupQAField(doc, path)
+	raw, isString := value.(string)
+	if found && value != nil && !isString {
+		return nil, fmt.Errorf("QA response '%s' field must be a string", path)
+	}
+	if raw == "" {
+		return nil, fmt.Errorf("QA response missing required '%s' field", path)
 	}
 
-	// Normalize to lowercase for comparison
-	verdict := strings.ToLower(parsed.Verdict)
+	// Map and normalize to lowercase for comparison
+	verdict := fields.MapVerdict(raw)
 
 	// Validate verdict value
 	switch verdict {
 	case "pass", "fail", "escalate":
 		// Valid
 	default:
-		return nil, fmt.Errorf("invalid verdict: %q (must be 'pass', 'fail', or 'escalate')", parsed.Verdict)
+		return nil, fmt.Errorf("invalid verdict: %q (must be 'pass', 'fail', or 'escalate')", raw)
 	}
 
-	return &QAResponse{
-		Verdict: verdict,
-	}, nil
+	value, _ = global.LookupQAField(doc, fields.SeverityPath())
+	severity, _ := value.(string)
+
+	result := &QAResponse{
+		Verdict:  verdict,
+		Severity: global.NormalizeQASeverity(severity),
+	}
+	if fields != nil && fields.Feedback != "" {
+		result.Feedback = global.QAFieldText(doc, fields.Feedback)
+	}
+	return result, nil
 }
 
 // DefaultQASchema returns the default JSON schema for QA responses.
@@ -533,6 +557,11 @@ func DefaultQASchema() string {
       "enum": ["pass", "fail", "escalate"],
       "description": "QA verdict: pass = work acceptable, fail = send back to worker, escalate = cannot be resolved by QA"
     },
+    "severity": {
+      "type": "string",
+      "enum": ["critical", "high", "medium", "low"],
+      "description": "Optional: severity of the most serious issue found"
+    },
     "feedback": {"type": "string"},
     "issues": {
       "type": "array",
@@ -554,6 +583,48 @@ func DefaultWorkerSchema() string {
 }`
 }
 
+// ValidateQASchemaWith is ValidateQASchema for a task set with a QA field map.
+// When the map moves the verdict or renames its values, the schema must define
+// a property at the verdict path, and any enum on it may only hold values that
+// map to pass, fail or escalate.
+func ValidateQASchemaWith(schemaContent string, fields *global.QAFieldMap) error {
+	if !fields.CustomVerdict() {
+		return ValidateQASchema(schemaContent)
+	}
+	if schemaContent == "" {
+		return nil // No schema to validate
+	}
+
+	var schema map[string]interface{}
+	if err := json.Unmarshal([]byte(schemaContent), &schema); err != nil {
+		return fmt.Errorf("invalid JSON schema: %w", err)
+	}
+
+	// Follow the verdict path through the schema's properties
+	path := fields.VerdictPath()
+	prop := schema
+	for _, key := range strings.Split(path, ".") {
+		properties, _ := prop["properties"].(map[string]interface{})
+		next, ok := properties[key].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("QA schema has no property at the qa_fields verdict path '%s'", path)
+		}
+		prop = next
+	}
+
+	enumValues, _ := prop["enum"].([]interface{})
+	for _, v := range enumValues {
+		value, _ := v.(string)
+		switch fields.MapVerdict(value) {
+		case "pass", "fail", "escalate":
+		default:
+			return fmt.Errorf("QA schema verdict value %v does not map to 'pass', 'fail' or 'escalate' (add it to qa_fields.verdicts)", v)
+		}
+	}
+
+	return nil
+}
+
 // ValidateQASchema validates that a QA response schema includes the required verdict field.
 // Returns an error if the schema is missing the verdict field or has invalid enum values.
 func ValidateQASchema(schemaContent string) error {
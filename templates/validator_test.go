@@ -8,6 +8,8 @@ package templates
 import (
 	"strings"
 	"testing"
+
+	"github.com/PivotLLM/Maestro/global"
 )
 
 func TestValidateJSON(t *testing.T) {
@@ -197,14 +199,26 @@ func TestPopulateTemplate(t *testing.T) {
 	}
 }
 
+func TestValidateTemplate(t *testing.T) {
+	if err := ValidateTemplate("{{.QAVerdict}}: {{truncate .QAFeedback 80}}"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for _, tmpl := range []string{"{{.QAVerdict", "{{unknownFunc .X}}", "{{end}}"} {
+		if err := ValidateTemplate(tmpl); err == nil {
+			t.Errorf("ValidateTemplate(%q): expected error", tmpl)
+		}
+	}
+}
+
 func TestParseQAResponse(t *testing.T) {
 	v := New(nil)
 
 	tests := []struct {
-		name        string
-		data        string
-		wantVerdict string
-		wantErr     bool
+		name         string
+		data         string
+		wantVerdict  string
+		wantSeverity string
+		wantErr      bool
 	}{
 		{
 			name:        "verdict pass lowercase",
@@ -246,6 +260,22 @@ func TestParseQAResponse(t *testing.T) {
 			data:        `{"verdict": "pass", "document_verification": [], "issues": [], "comments": "OK"}`,
 			wantVerdict: "pass",
 		},
+		{
+			name:         "severity normalized",
+			data:         `{"verdict": "fail", "severity": " Critical "}`,
+			wantVerdict:  "fail",
+			wantSeverity: "critical",
+		},
+		{
+			name:        "unknown severity ignored",
+			data:        `{"verdict": "fail", "severity": "blocker"}`,
+			wantVerdict: "fail",
+		},
+		{
+			name:        "non-string severity ignored",
+			data:        `{"verdict": "pass", "severity": 3}`,
+			wantVerdict: "pass",
+		},
 		{
 			name:    "missing verdict field",
 			data:    `{"comments": "No verdict here"}`,
@@ -285,6 +315,9 @@ func TestParseQAResponse(t *testing.T) {
 			if response.Verdict != tt.wantVerdict {
 				t.Errorf("verdict = %q, want %q", response.Verdict, tt.wantVerdict)
 			}
+			if response.Severity != tt.wantSeverity {
+				t.Errorf("severity = %q, want %q", response.Severity, tt.wantSeverity)
+			}
 		})
 	}
 }
@@ -459,6 +492,114 @@ func TestValidateQASchema(t *testing.T) {
 	}
 }
 
+func TestParseQAResponseWith(t *testing.T) {
+	v := New(nil)
+	fields := &global.QAFieldMap{
+		Verdict:  "$.assessment.outcome",
+		Feedback: "assessment.notes",
+		Severity: "risk",
+		Verdicts: map[string]string{"Approved": "pass", "rework": "escalate"},
+	}
+
+	tests := []struct {
+		name         string
+		data         string
+		wantVerdict  string
+		wantSeverity string
+		wantFeedback string
+		wantErr      bool
+	}{
+		{
+			name:         "mapped verdict",
+			data:         `{"assessment": {"outcome": "approved", "notes": "All controls evidenced"}, "risk": "Low"}`,
+			wantVerdict:  "pass",
+			wantSeverity: "low",
+			wantFeedback: "All controls evidenced",
+		},
+		{
+			name:         "standard verdict at custom path",
+			data:         `{"assessment": {"outcome": "Fail", "notes": ["gap 1", "gap 2"]}}`,
+			wantVerdict:  "fail",
+			wantFeedback: `["gap 1","gap 2"]`,
+		},
+		{
+			name:        "mapped escalate",
+			data:        `{"assessment": {"outcome": "REWORK"}}`,
+			wantVerdict: "escalate",
+		},
+		{
+			name:    "standard field not used",
+			data:    `{"verdict": "pass"}`,
+			wantErr: true,
+		},
+		{
+			name:    "unmapped value",
+			data:    `{"assessment": {"outcome": "maybe"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "non-string verdict",
+			data:    `{"assessment": {"outcome": true}}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := v.ParseQAResponseWith([]byte(tt.data), fields)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if response.Verdict != tt.wantVerdict {
+				t.Errorf("verdict = %q, want %q", response.Verdict, tt.wantVerdict)
+			}
+			if response.Severity != tt.wantSeverity {
+				t.Errorf("severity = %q, want %q", response.Severity, tt.wantSeverity)
+			}
+			if response.Feedback != tt.wantFeedback {
+				t.Errorf("feedback = %q, want %q", response.Feedback, tt.wantFeedback)
+			}
+		})
+	}
+}
+
+func TestValidateQASchemaWith(t *testing.T) {
+	schema := `{
+		"type": "object",
+		"properties": {
+			"assessment": {
+				"type": "object",
+				"properties": {"outcome": {"type": "string", "enum": ["approved", "rejected", "pass"]}}
+			}
+		}
+	}`
+
+	mapped := &global.QAFieldMap{Verdict: "assessment.outcome", Verdicts: map[string]string{"approved": "pass", "rejected": "fail"}}
+	if err := ValidateQASchemaWith(schema, mapped); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	unmapped := &global.QAFieldMap{Verdict: "assessment.outcome", Verdicts: map[string]string{"approved": "pass"}}
+	if err := ValidateQASchemaWith(schema, unmapped); err == nil || !strings.Contains(err.Error(), "rejected") {
+		t.Errorf("expected error naming the unmapped value, got %v", err)
+	}
+
+	if err := ValidateQASchemaWith(schema, &global.QAFieldMap{Verdict: "assessment.result"}); err == nil {
+		t.Error("expected error for a verdict path missing from the schema")
+	}
+
+	// Without a custom verdict the standard 'verdict' field is required
+	if err := ValidateQASchemaWith(schema, &global.QAFieldMap{Feedback: "assessment.notes"}); err == nil {
+		t.Error("expected error for a schema without a verdict field")
+	}
+}
+
 func TestExtractJSON(t *testing.T) {
 	tests := []struct {
 		name     string